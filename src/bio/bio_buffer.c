@@ -757,6 +757,25 @@ skip_media_error:
 		ABT_eventual_set(biod->bd_dma_done, NULL, 0);
 }
 
+/* Account a completed DMA transfer in the per-xstream blob I/O stats */
+static void
+io_stats_update(struct bio_xs_context *xs_ctxt, bool update, uint64_t bytes,
+		uint64_t start)
+{
+	struct bio_io_stats	*stats = &xs_ctxt->bxc_io_stats;
+	uint64_t		 lat_us;
+	int			 dir, bkt = 0;
+
+	dir = update ? BIO_IO_STAT_WRITE : BIO_IO_STAT_READ;
+	lat_us = (daos_get_ntime() - start) / NSEC_PER_USEC;
+	while (bkt < BIO_LAT_BKT_CNT - 1 && lat_us >= (1ULL << bkt))
+		bkt++;
+
+	stats->bis_ops[dir]++;
+	stats->bis_bytes[dir] += bytes;
+	stats->bis_lat_bkts[dir][bkt]++;
+}
+
 static void
 dma_rw(struct bio_desc *biod, bool prep)
 {
@@ -766,6 +785,7 @@ dma_rw(struct bio_desc *biod, bool prep)
 	struct bio_rsrvd_region	*rg;
 	struct bio_xs_context	*xs_ctxt;
 	uint64_t		 pg_idx, pg_cnt, pg_end;
+	uint64_t		 io_bytes = 0, io_start;
 	void			*payload, *pg_rmw = NULL;
 	bool			 rmw_read = (prep && biod->bd_update);
 	unsigned int		 pg_off;
@@ -793,6 +813,7 @@ dma_rw(struct bio_desc *biod, bool prep)
 
 	D_ASSERT(channel != NULL);
 	biod->bd_ctxt->bic_inflight_dmas++;
+	io_start = daos_get_ntime();
 
 	D_DEBUG(DB_IO, "DMA start, blob:%p, update:%d, rmw:%d\n",
 		blob, biod->bd_update, rmw_read);
@@ -813,6 +834,11 @@ dma_rw(struct bio_desc *biod, bool prep)
 
 			biod->bd_inflights++;
 			xs_ctxt->bxc_blob_rw++;
+			if (xs_ctxt->bxc_blob_rw >
+			    xs_ctxt->bxc_io_stats.bis_max_blob_rw)
+				xs_ctxt->bxc_io_stats.bis_max_blob_rw =
+					xs_ctxt->bxc_blob_rw;
+			io_bytes += pg_cnt << BIO_DMA_PAGE_SHIFT;
 			/* NVMe poll needs be scheduled */
 			if (bio_need_nvme_poll(xs_ctxt))
 				bio_yield();
@@ -878,6 +904,8 @@ dma_rw(struct bio_desc *biod, bool prep)
 	}
 
 	biod->bd_ctxt->bic_inflight_dmas--;
+	if (io_bytes != 0)
+		io_stats_update(xs_ctxt, biod->bd_update, io_bytes, io_start);
 	D_DEBUG(DB_IO, "DMA done, blob:%p, update:%d, rmw:%d\n",
 		blob, biod->bd_update, rmw_read);
 }
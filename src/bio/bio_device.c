@@ -354,6 +354,15 @@ replace_dev(struct bio_xs_context *xs_ctxt, struct smd_dev_info *old_info,
 	D_ASSERT(owner_thread(bbs) != NULL);
 	spdk_thread_send_msg(owner_thread(bbs), setup_bio_bdev, old_dev);
 
+	/*
+	 * Set the LED of the VMD device to OFF state, the slot may still be
+	 * indicating the fault of the device that was replaced.
+	 */
+	if (bio_set_led_state(xs_ctxt, old_dev->bb_uuid, "off",
+			      false/*reset*/) != 0)
+		D_ERROR("Error managing LED on device:"DF_UUID"\n",
+			DP_UUID(old_dev->bb_uuid));
+
 out:
 	free_blob_list(xs_ctxt, &blob_list, new_dev);
 pool_list_out:
@@ -17,6 +17,7 @@
 #define BIO_DMA_PAGE_SHIFT	12	/* 4K */
 #define BIO_DMA_PAGE_SZ		(1UL << BIO_DMA_PAGE_SHIFT)
 #define BIO_XS_CNT_MAX		48	/* Max VOS xstreams per blobstore */
+#define BIO_LAT_BKT_CNT		24	/* Latency buckets, up to ~16 seconds */
 /*
  * Period to query raw device health stats, auto detect faulty and transition
  * device state. 60 seconds by default. Once FAULTY state has occurred, reduce
@@ -273,10 +274,30 @@ struct bio_blobstore {
 				 bb_unloading:1;
 };
 
+/*
+ * Per-xstream blob I/O statistics, only updated by the xstream issuing the
+ * I/O. Arrays are indexed by BIO_IO_STAT_READ/BIO_IO_STAT_WRITE.
+ */
+enum {
+	BIO_IO_STAT_READ	= 0,
+	BIO_IO_STAT_WRITE,
+	BIO_IO_STAT_MAX,
+};
+
+struct bio_io_stats {
+	uint64_t		 bis_ops[BIO_IO_STAT_MAX];
+	uint64_t		 bis_bytes[BIO_IO_STAT_MAX];
+	/* Power of two latency histogram, bucket N counts I/Os < 2^N us */
+	uint64_t		 bis_lat_bkts[BIO_IO_STAT_MAX][BIO_LAT_BKT_CNT];
+	/* Peak inflight blob read/write */
+	unsigned int		 bis_max_blob_rw;
+};
+
 /* Per-xstream NVMe context */
 struct bio_xs_context {
 	int			 bxc_tgt_id;
 	unsigned int		 bxc_blob_rw;	/* inflight blob read/write */
+	struct bio_io_stats	 bxc_io_stats;
 	struct spdk_thread	*bxc_thread;
 	struct bio_blobstore	*bxc_blobstore;
 	struct spdk_io_channel	*bxc_io_channel;
@@ -54,6 +54,75 @@ collect_bs_usage(struct spdk_blob_store *bs, struct nvme_stats *stats)
 	stats->avail_bytes = spdk_bs_free_cluster_count(bs) * cl_sz;
 }
 
+/* Upper bound in microseconds of the latency bucket holding the percentile */
+static uint64_t
+lat_percentile(uint64_t *bkts, uint64_t total, unsigned int pct)
+{
+	uint64_t	target, seen = 0;
+	int		i;
+
+	if (total == 0)
+		return 0;
+
+	target = (total * pct + 99) / 100;
+	for (i = 0; i < BIO_LAT_BKT_CNT; i++) {
+		seen += bkts[i];
+		if (seen >= target)
+			break;
+	}
+
+	return 1ULL << min(i, BIO_LAT_BKT_CNT - 1);
+}
+
+/*
+ * Aggregate the blob I/O stats of all xstreams using the blobstore. The
+ * counters are updated locklessly by each xstream so the result is only a
+ * snapshot, which is good enough for diagnostics.
+ */
+static void
+collect_io_stats(struct bio_blobstore *bbs, struct nvme_stats *stats)
+{
+	struct bio_io_stats	*xs_stats;
+	uint64_t		 lat[BIO_IO_STAT_MAX][BIO_LAT_BKT_CNT] = { 0 };
+	uint64_t		 ops[BIO_IO_STAT_MAX] = { 0 };
+	uint64_t		 bytes[BIO_IO_STAT_MAX] = { 0 };
+	int			 i, j, dir;
+
+	D_ASSERT(stats != NULL);
+	stats->io_inflight = 0;
+	stats->io_queue_depth = 0;
+
+	ABT_mutex_lock(bbs->bb_mutex);
+	for (i = 0; i < BIO_XS_CNT_MAX; i++) {
+		if (bbs->bb_xs_ctxts[i] == NULL)
+			continue;
+
+		xs_stats = &bbs->bb_xs_ctxts[i]->bxc_io_stats;
+		stats->io_inflight += bbs->bb_xs_ctxts[i]->bxc_blob_rw;
+		stats->io_queue_depth += xs_stats->bis_max_blob_rw;
+		for (dir = 0; dir < BIO_IO_STAT_MAX; dir++) {
+			ops[dir] += xs_stats->bis_ops[dir];
+			bytes[dir] += xs_stats->bis_bytes[dir];
+			for (j = 0; j < BIO_LAT_BKT_CNT; j++)
+				lat[dir][j] += xs_stats->bis_lat_bkts[dir][j];
+		}
+	}
+	ABT_mutex_unlock(bbs->bb_mutex);
+
+	stats->read_ops = ops[BIO_IO_STAT_READ];
+	stats->write_ops = ops[BIO_IO_STAT_WRITE];
+	stats->read_bytes = bytes[BIO_IO_STAT_READ];
+	stats->write_bytes = bytes[BIO_IO_STAT_WRITE];
+	stats->read_lat_p50 = lat_percentile(lat[BIO_IO_STAT_READ],
+					     stats->read_ops, 50);
+	stats->read_lat_p99 = lat_percentile(lat[BIO_IO_STAT_READ],
+					     stats->read_ops, 99);
+	stats->write_lat_p50 = lat_percentile(lat[BIO_IO_STAT_WRITE],
+					      stats->write_ops, 50);
+	stats->write_lat_p99 = lat_percentile(lat[BIO_IO_STAT_WRITE],
+					      stats->write_ops, 99);
+}
+
 /* Copy out the nvme_stats in the device owner xstream context */
 static void
 bio_get_dev_state_internal(void *msg_arg)
@@ -64,6 +133,7 @@ bio_get_dev_state_internal(void *msg_arg)
 
 	dsm->devstate = dsm->xs->bxc_blobstore->bb_dev_health.bdh_health_state;
 	collect_bs_usage(dsm->xs->bxc_blobstore->bb_bs, &dsm->devstate);
+	collect_io_stats(dsm->xs->bxc_blobstore, &dsm->devstate);
 	ABT_eventual_set(dsm->eventual, NULL, 0);
 }
 
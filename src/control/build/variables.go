@@ -12,6 +12,8 @@ var (
 	ConfigDir string = "./"
 	// DaosVersion should be set via linker flag using the value of DAOS_VERSION.
 	DaosVersion string = "unset"
+	// Revision should be set via linker flag using the git hash of the source tree.
+	Revision string = "unset"
 	// ControlPlaneName defines a consistent name for the control plane server.
 	ControlPlaneName = "DAOS Control Server"
 	// DataPlaneName defines a consistent name for the engine.
@@ -20,6 +22,8 @@ var (
 	ManagementServiceName = "DAOS Management Service"
 	// AgentName defines a consistent name for the compute node agent.
 	AgentName = "DAOS Agent"
+	// GatewayName defines a consistent name for the REST gateway.
+	GatewayName = "DAOS REST Gateway"
 
 	// DefaultControlPort defines the default control plane listener port.
 	DefaultControlPort = 10001
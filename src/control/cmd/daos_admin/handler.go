@@ -143,6 +143,31 @@ func (h *scmPrepHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.Res
 	return pbin.NewResponseWithPayload(pRes)
 }
 
+// scmVerifyHandler implements the ScmVerifyRegions method.
+type scmVerifyHandler struct {
+	scmHandler
+}
+
+func (h *scmVerifyHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.Response {
+	if req == nil {
+		return getNilRequestResp()
+	}
+
+	var vReq scm.VerifyRequest
+	if err := json.Unmarshal(req.Payload, &vReq); err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	h.setupProvider(log)
+
+	vRes, err := h.scmProvider.VerifyRegions(vReq)
+	if err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	return pbin.NewResponseWithPayload(vRes)
+}
+
 // bdevHandler provides the ability to set up the bdev.Provider for bdev methods.
 type bdevHandler struct {
 	bdevProvider *bdev.Provider
@@ -228,3 +253,28 @@ func (h *bdevFormatHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.
 
 	return pbin.NewResponseWithPayload(fRes)
 }
+
+// bdevVerifyHandler implements the BdevVerify method.
+type bdevVerifyHandler struct {
+	bdevHandler
+}
+
+func (h *bdevVerifyHandler) Handle(log logging.Logger, req *pbin.Request) *pbin.Response {
+	if req == nil {
+		return getNilRequestResp()
+	}
+
+	var vReq bdev.VerifyRequest
+	if err := json.Unmarshal(req.Payload, &vReq); err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	h.setupProvider(log)
+
+	vRes, err := h.bdevProvider.Verify(vReq)
+	if err != nil {
+		return pbin.NewResponseWithError(err)
+	}
+
+	return pbin.NewResponseWithPayload(vRes)
+}
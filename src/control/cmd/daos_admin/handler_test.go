@@ -289,6 +289,78 @@ func TestDaosAdmin_ScmPrepHandler(t *testing.T) {
 	}
 }
 
+func TestDaosAdmin_ScmVerifyHandler(t *testing.T) {
+	scmVerifyReqPayload, err := json.Marshal(scm.VerifyRequest{
+		ForwardableRequest: pbin.ForwardableRequest{Forwarded: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		req        *pbin.Request
+		smbc       *scm.MockBackendConfig
+		smsc       *scm.MockSysConfig
+		expPayload *scm.VerifyResponse
+		expErr     *fault.Fault
+	}{
+		"nil request": {
+			expErr: pbin.PrivilegedHelperRequestFailed("nil request"),
+		},
+		"ScmVerifyRegions nil payload": {
+			req: &pbin.Request{
+				Method: "ScmVerifyRegions",
+			},
+			expErr: nilPayloadErr,
+		},
+		"ScmVerifyRegions success": {
+			req: &pbin.Request{
+				Method:  "ScmVerifyRegions",
+				Payload: scmVerifyReqPayload,
+			},
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: storage.ScmModules{storage.MockScmModule()},
+			},
+			expPayload: &scm.VerifyResponse{
+				Regions: storage.ScmRegions{
+					{SocketID: storage.MockScmModule().SocketID, PersistentMemoryType: "AppDirect"},
+				},
+			},
+		},
+		"ScmVerifyRegions mismatch": {
+			req: &pbin.Request{
+				Method:  "ScmVerifyRegions",
+				Payload: scmVerifyReqPayload,
+			},
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes:       storage.ScmModules{storage.MockScmModule()},
+				GetPmemRegionsRes: storage.ScmRegions{},
+			},
+			expErr: scm.FaultRegionsGoalMismatch([]string{
+				fmt.Sprintf("socket %d has no region", storage.MockScmModule().SocketID),
+			}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			sp := scm.NewMockProvider(log, tc.smbc, tc.smsc)
+			handler := &scmVerifyHandler{scmHandler: scmHandler{scmProvider: sp}}
+
+			resp := handler.Handle(log, tc.req)
+
+			if diff := cmp.Diff(tc.expErr, resp.Error); diff != "" {
+				t.Errorf("got wrong fault (-want, +got)\n%s\n", diff)
+			}
+			if tc.expPayload == nil {
+				tc.expPayload = &scm.VerifyResponse{}
+			}
+			expectPayload(t, resp, &scm.VerifyResponse{}, tc.expPayload)
+		})
+	}
+}
+
 func TestDaosAdmin_ScmScanHandler(t *testing.T) {
 	scmScanReqPayload, err := json.Marshal(scm.ScanRequest{
 		ForwardableRequest: pbin.ForwardableRequest{Forwarded: true},
@@ -550,3 +622,74 @@ func TestDaosAdmin_BdevFormatHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDaosAdmin_BdevVerifyHandler(t *testing.T) {
+	bdevVerifyReqPayload, err := json.Marshal(bdev.VerifyRequest{
+		ForwardableRequest: pbin.ForwardableRequest{Forwarded: true},
+		PciAddr:            "foo",
+		NsID:               1,
+		NumLBAs:            8,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		req        *pbin.Request
+		bmbc       *bdev.MockBackendConfig
+		expPayload *bdev.VerifyResponse
+		expErr     *fault.Fault
+	}{
+		"nil request": {
+			expErr: pbin.PrivilegedHelperRequestFailed("nil request"),
+		},
+		"BdevVerify nil payload": {
+			req: &pbin.Request{
+				Method: "BdevVerify",
+			},
+			expErr: nilPayloadErr,
+		},
+		"BdevVerify success": {
+			req: &pbin.Request{
+				Method:  "BdevVerify",
+				Payload: bdevVerifyReqPayload,
+			},
+			bmbc: &bdev.MockBackendConfig{
+				VerifyRes: &bdev.VerifyResponse{
+					BadLBAs: []uint64{3},
+				},
+			},
+			expPayload: &bdev.VerifyResponse{
+				BadLBAs: []uint64{3},
+			},
+		},
+		"BdevVerify failure": {
+			req: &pbin.Request{
+				Method:  "BdevVerify",
+				Payload: bdevVerifyReqPayload,
+			},
+			bmbc: &bdev.MockBackendConfig{
+				VerifyErr: bdev.FaultUnknown,
+			},
+			expErr: bdev.FaultUnknown,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			bp := bdev.NewMockProvider(log, tc.bmbc)
+			handler := &bdevVerifyHandler{bdevHandler: bdevHandler{bdevProvider: bp}}
+
+			resp := handler.Handle(log, tc.req)
+
+			if diff := cmp.Diff(tc.expErr, resp.Error); diff != "" {
+				t.Errorf("got wrong fault (-want, +got)\n%s\n", diff)
+			}
+			if tc.expPayload == nil {
+				tc.expPayload = &bdev.VerifyResponse{}
+			}
+			expectPayload(t, resp, &bdev.VerifyResponse{}, tc.expPayload)
+		})
+	}
+}
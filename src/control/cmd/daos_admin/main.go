@@ -36,8 +36,10 @@ func addMethodHandlers(app *pbin.App) {
 	app.AddHandler("ScmCheckFormat", &scmFormatCheckHandler{})
 	app.AddHandler("ScmScan", &scmScanHandler{})
 	app.AddHandler("ScmPrepare", &scmPrepHandler{})
+	app.AddHandler("ScmVerifyRegions", &scmVerifyHandler{})
 
 	app.AddHandler("BdevPrepare", &bdevPrepHandler{})
 	app.AddHandler("BdevScan", &bdevScanHandler{})
 	app.AddHandler("BdevFormat", &bdevFormatHandler{})
+	app.AddHandler("BdevVerify", &bdevVerifyHandler{})
 }
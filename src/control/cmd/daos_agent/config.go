@@ -9,6 +9,7 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -20,6 +21,8 @@ const (
 	defaultConfigFile = "daos_agent.yml"
 	defaultRuntimeDir = "/var/run/daos_agent"
 	defaultLogFile    = "/tmp/daos_agent.log"
+
+	defaultFabricCheckInterval = 5 * time.Second
 )
 
 // Config defines the agent configuration.
@@ -30,6 +33,9 @@ type Config struct {
 	RuntimeDir      string                    `yaml:"runtime_dir"`
 	LogFile         string                    `yaml:"log_file"`
 	TransportConfig *security.TransportConfig `yaml:"transport_config"`
+	// interval between checks of the fabric interfaces offered to
+	// clients, zero disables the checks
+	FabricCheckInterval time.Duration `yaml:"fabric_check_interval"`
 }
 
 func LoadConfig(cfgPath string) (*Config, error) {
@@ -54,5 +60,7 @@ func DefaultConfig() *Config {
 		RuntimeDir:      defaultRuntimeDir,
 		LogFile:         defaultLogFile,
 		TransportConfig: security.DefaultAgentTransportConfig(),
+
+		FabricCheckInterval: defaultFabricCheckInterval,
 	}
 }
@@ -0,0 +1,103 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const defaultSysRoot = "/sys"
+
+// ifaceIsUp reports whether the operational state of the named network
+// interface allows it to be used. An interface that has disappeared is
+// considered down.
+func ifaceIsUp(sysRoot, name string) bool {
+	data, err := ioutil.ReadFile(filepath.Join(sysRoot, "class", "net", name, "operstate"))
+	if err != nil {
+		return false
+	}
+
+	switch strings.TrimSpace(string(data)) {
+	case "down", "lowerlayerdown", "notpresent":
+		return false
+	default:
+		return true
+	}
+}
+
+// ifaceMonitor periodically checks the state of the fabric interfaces
+// offered to clients. When an interface goes down, new clients are steered to
+// a backup interface and a RAS event is published.
+type ifaceMonitor struct {
+	log       logging.Logger
+	sysRoot   string
+	hostname  string
+	aiCache   *attachInfoCache
+	publisher events.Publisher
+}
+
+func newIfaceMonitor(log logging.Logger, aiCache *attachInfoCache, publisher events.Publisher) *ifaceMonitor {
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Errorf("unable to determine hostname: %s", err)
+	}
+
+	return &ifaceMonitor{
+		log:       log,
+		sysRoot:   defaultSysRoot,
+		hostname:  hostname,
+		aiCache:   aiCache,
+		publisher: publisher,
+	}
+}
+
+// check updates the cache with the current state of each interface and
+// publishes an event for each interface whose state has changed.
+func (im *ifaceMonitor) check() {
+	for _, iface := range im.aiCache.interfaces() {
+		up := ifaceIsUp(im.sysRoot, iface)
+
+		changed, backup, err := im.aiCache.setIfaceState(iface, up)
+		if err != nil {
+			im.log.Errorf("failed to update responses for interface %s: %s", iface, err)
+		}
+		if !changed {
+			continue
+		}
+
+		var event *events.RASEvent
+		if up {
+			event = events.NewAgentIfaceUpEvent(im.hostname, iface)
+		} else {
+			event = events.NewAgentIfaceDownEvent(im.hostname, iface, backup)
+		}
+		im.publisher.Publish(event.WithForwardable(true))
+	}
+}
+
+// startMonitoring checks the interfaces at the given interval until the
+// context is cancelled.
+func (im *ifaceMonitor) startMonitoring(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+				im.check()
+			}
+		}
+	}()
+}
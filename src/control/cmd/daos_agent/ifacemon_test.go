@@ -0,0 +1,102 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+type testPublisher struct {
+	published []*events.RASEvent
+}
+
+func (tp *testPublisher) Publish(event *events.RASEvent) {
+	tp.published = append(tp.published, event)
+}
+
+func setOperState(t *testing.T, sysRoot, iface, state string) {
+	t.Helper()
+
+	dir := filepath.Join(sysRoot, "class", "net", iface)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "operstate"), []byte(state+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAgent_ifaceIsUp(t *testing.T) {
+	sysRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	for state, expUp := range map[string]bool{
+		"up":             true,
+		"unknown":        true,
+		"dormant":        true,
+		"down":           false,
+		"lowerlayerdown": false,
+		"notpresent":     false,
+	} {
+		t.Run(state, func(t *testing.T) {
+			setOperState(t, sysRoot, "eth0", state)
+			common.AssertEqual(t, expUp, ifaceIsUp(sysRoot, "eth0"), "unexpected state")
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		common.AssertFalse(t, ifaceIsUp(sysRoot, "eth1"), "missing interface should be down")
+	})
+}
+
+func TestAgent_ifaceMonitor_check(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	sysRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	aiCache := newTestFailoverCache(t, log)
+	pub := &testPublisher{}
+	im := newIfaceMonitor(log, aiCache, pub)
+	im.sysRoot = sysRoot
+	im.hostname = "host1"
+
+	for _, iface := range []string{"ib0", "ib1", "ib2"} {
+		setOperState(t, sysRoot, iface, "up")
+	}
+	im.check()
+	common.AssertEqual(t, 0, len(pub.published), "no events expected while interfaces are up")
+
+	setOperState(t, sysRoot, "ib0", "down")
+	im.check()
+	im.check()
+	if len(pub.published) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(pub.published))
+	}
+	evt := pub.published[0]
+	common.AssertEqual(t, events.RASAgentIfaceDown, evt.ID, "unexpected event ID")
+	common.AssertEqual(t, "ib0", evt.HWID, "unexpected event HWID")
+	common.AssertEqual(t, "host1", evt.Hostname, "unexpected event hostname")
+	common.AssertTrue(t, evt.ShouldForward(), "event should be forwarded")
+	common.AssertEqual(t, events.NewAgentIfaceDownEvent("host1", "ib0", "ib1").Msg, evt.Msg,
+		"unexpected event message")
+
+	setOperState(t, sysRoot, "ib0", "up")
+	im.check()
+	if len(pub.published) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(pub.published))
+	}
+	common.AssertEqual(t, events.RASAgentIfaceUp, pub.published[1].ID, "unexpected event ID")
+}
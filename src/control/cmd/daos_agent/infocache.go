@@ -8,8 +8,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -26,59 +29,180 @@ const (
 	verbsProvider        = "ofi+verbs"
 	defaultNetworkDevice = "lo"
 	defaultDomain        = "lo"
+	// maxFabricSelections is the number of recent fabric selections
+	// recorded for reporting by the status subcommands
+	maxFabricSelections = 32
 )
 
+// attachDevice describes a local network device that clients may use.
+type attachDevice struct {
+	Interface string
+	Domain    string
+	NUMANode  int
+	CPUList   string
+}
+
 type attachInfoCache struct {
 	log logging.Logger
 	// is caching enabled?
 	enabled atm.Bool
 	// is the cache initialized?
 	initialized atm.Bool
+	// response from the MS without device-specific settings
+	baseResp *mgmtpb.GetAttachInfoResp
+	// maps NUMA affinity to the devices with that affinity
+	numaDevices map[int][]*attachDevice
+	// interfaces detected to be down, not offered to clients while
+	// another interface is usable
+	ifaceDown map[string]bool
 	// maps NUMA affinity and device index to a response
 	numaDeviceMarshResp map[int]map[int][]byte
+	// maps NUMA affinity and device index to a response including
+	// recommended client bindings
+	numaDeviceHintsResp map[int]map[int][]byte
 	// maps NUMA affinity to a device index
 	currentNumaDevIdx map[int]int
 	mutex             sync.Mutex
 	// specifies what NUMA node to use when there are no devices
 	// associated with the client NUMA node
 	defaultNumaNode int
+	// time at which the cache was last initialized
+	updatedAt time.Time
+	// number of clients assigned to each interface
+	ifaceAssigned map[string]uint64
+	// most recent fabric selections, oldest first
+	selections []*fabricSelection
+}
+
+// fabricSelection records the device selected for a client and why.
+type fabricSelection struct {
+	Time       time.Time `json:"time"`
+	ClientNUMA int       `json:"client_numa"`
+	Interface  string    `json:"interface"`
+	Domain     string    `json:"domain"`
+	NUMANode   int       `json:"numa_node"`
+	Reason     string    `json:"reason"`
 }
 
 // loadBalance is a simple round-robin load balancing scheme
 // to assign network interface adapters to clients
 // on the same NUMA node that have multiple adapters
 // to choose from.  Returns the index of the device to use.
-func (aic *attachInfoCache) loadBalance(numaNode int) int {
+// If skipDown is set, devices whose interface is down are not
+// assigned.
+func (aic *attachInfoCache) loadBalance(numaNode int, skipDown bool) int {
 	aic.mutex.Lock()
-	deviceIndex := invalidIndex
-	numDevs := len(aic.numaDeviceMarshResp[numaNode])
-	if numDevs > 0 {
-		deviceIndex = aic.currentNumaDevIdx[numaNode]
-		aic.currentNumaDevIdx[numaNode] = (deviceIndex + 1) % numDevs
+	defer aic.mutex.Unlock()
+
+	devs := aic.numaDevices[numaNode]
+	for range devs {
+		deviceIndex := aic.currentNumaDevIdx[numaNode]
+		aic.currentNumaDevIdx[numaNode] = (deviceIndex + 1) % len(devs)
+		if !skipDown || !aic.ifaceDown[devs[deviceIndex].Interface] {
+			return deviceIndex
+		}
 	}
-	aic.mutex.Unlock()
-	return deviceIndex
+	return invalidIndex
 }
 
-func (aic *attachInfoCache) getResponse(numaNode int) ([]byte, error) {
-	deviceIndex := aic.loadBalance(numaNode)
-	// If there is no response available for the client's actual NUMA node,
-	// use the default NUMA node
-	if deviceIndex == invalidIndex {
-		deviceIndex = aic.loadBalance(aic.defaultNumaNode)
-		if deviceIndex == invalidIndex {
-			return nil, errors.Errorf("No default response found for the default NUMA node %d", aic.defaultNumaNode)
+// failoverNumaNodes returns the NUMA nodes other than the one given that
+// have devices, the default NUMA node first and the others in order.
+func (aic *attachInfoCache) failoverNumaNodes(numaNode int) []int {
+	aic.mutex.Lock()
+	defer aic.mutex.Unlock()
+
+	var nodes []int
+	for numa := range aic.numaDevices {
+		if numa != numaNode && numa != aic.defaultNumaNode {
+			nodes = append(nodes, numa)
+		}
+	}
+	sort.Ints(nodes)
+	if numaNode != aic.defaultNumaNode {
+		nodes = append([]int{aic.defaultNumaNode}, nodes...)
+	}
+	return nodes
+}
+
+// selectDevice returns the NUMA node and index of the device to be used by a
+// client on the given NUMA node, along with the reason for the selection. A
+// device local to the client is preferred, then one on the default NUMA node,
+// then one on any other NUMA node. Devices whose interface is down are only
+// selected if no other device is usable.
+func (aic *attachInfoCache) selectDevice(numaNode int) (int, int, string) {
+	for _, skipDown := range []bool{true, false} {
+		suffix := ""
+		if !skipDown {
+			suffix = ", all interfaces are down"
+		}
+
+		if deviceIndex := aic.loadBalance(numaNode, skipDown); deviceIndex != invalidIndex {
+			return numaNode, deviceIndex, "local to client NUMA node" + suffix
+		}
+		for _, numa := range aic.failoverNumaNodes(numaNode) {
+			if deviceIndex := aic.loadBalance(numa, skipDown); deviceIndex != invalidIndex {
+				reason := fmt.Sprintf("no usable device on client NUMA node %d, failed over to NUMA node %d",
+					numaNode, numa)
+				if numa == aic.defaultNumaNode {
+					reason += " (default)"
+				}
+				return numa, deviceIndex, reason + suffix
+			}
+		}
+		if skipDown {
+			aic.log.Debugf("All network devices are down, selecting a device for NUMA %d regardless", numaNode)
 		}
-		aic.log.Infof("No network devices bound to client NUMA node %d.  Using response from NUMA %d", numaNode, aic.defaultNumaNode)
-		numaNode = aic.defaultNumaNode
+	}
+
+	return numaNode, invalidIndex, ""
+}
+
+// recordSelection records the device selected for a client. The caller must
+// hold the cache mutex.
+func (aic *attachInfoCache) recordSelection(clientNuma, numaNode int, dev *attachDevice, reason string) {
+	if aic.ifaceAssigned == nil {
+		aic.ifaceAssigned = make(map[string]uint64)
+	}
+	aic.ifaceAssigned[dev.Interface]++
+
+	if len(aic.selections) == maxFabricSelections {
+		aic.selections = aic.selections[1:]
+	}
+	aic.selections = append(aic.selections, &fabricSelection{
+		Time:       time.Now(),
+		ClientNUMA: clientNuma,
+		Interface:  dev.Interface,
+		Domain:     dev.Domain,
+		NUMANode:   numaNode,
+		Reason:     reason,
+	})
+}
+
+// getResponse returns the next marshalled response for the client NUMA node.
+// If withHints is set, the response includes the NUMA node and CPUs local to
+// the selected network device as recommended client bindings.
+func (aic *attachInfoCache) getResponse(numaNode int, withHints bool) ([]byte, error) {
+	clientNuma := numaNode
+	selectedNuma, deviceIndex, reason := aic.selectDevice(numaNode)
+	if deviceIndex == invalidIndex {
+		return nil, errors.Errorf("No default response found for the default NUMA node %d", aic.defaultNumaNode)
+	}
+	if selectedNuma != numaNode {
+		aic.log.Infof("No usable network devices bound to client NUMA node %d.  Using response from NUMA %d", numaNode, selectedNuma)
+		numaNode = selectedNuma
 	}
 
 	aic.mutex.Lock()
 	defer aic.mutex.Unlock()
-	numaDeviceMarshResp, ok := aic.numaDeviceMarshResp[numaNode][deviceIndex]
+	responses := aic.numaDeviceMarshResp
+	if withHints {
+		responses = aic.numaDeviceHintsResp
+	}
+	numaDeviceMarshResp, ok := responses[numaNode][deviceIndex]
 	if !ok {
 		return nil, errors.Errorf("GetAttachInfo entry for numaNode %d device index %d did not exist", numaNode, deviceIndex)
 	}
+	aic.recordSelection(clientNuma, numaNode, aic.numaDevices[numaNode][deviceIndex], reason)
 
 	aic.log.Debugf("Retrieved response for NUMA %d with device index %d\n", numaNode, deviceIndex)
 	return numaDeviceMarshResp, nil
@@ -95,13 +219,19 @@ func (aic *attachInfoCache) initResponseCache(ctx context.Context, resp *mgmtpb.
 	defer aic.mutex.Unlock()
 
 	// Make a new map each time the cache is initialized
-	aic.numaDeviceMarshResp = make(map[int]map[int][]byte)
+	aic.baseResp = proto.Clone(resp).(*mgmtpb.GetAttachInfoResp)
+	aic.numaDevices = make(map[int][]*attachDevice)
+	numaCPUList := make(map[int]string)
 
 	// Make a new map just once.
 	// Preserve any previous device index map in order to maintain ability to load balance
 	if len(aic.currentNumaDevIdx) == 0 {
 		aic.currentNumaDevIdx = make(map[int]int)
 	}
+	// Preserve the state of interfaces detected by the interface monitor
+	if aic.ifaceDown == nil {
+		aic.ifaceDown = make(map[string]bool)
+	}
 
 	var haveDefaultNuma bool
 
@@ -116,30 +246,35 @@ func (aic *attachInfoCache) initResponseCache(ctx context.Context, resp *mgmtpb.
 			continue
 		}
 
-		resp.Interface = fs.DeviceName
 		// by default, the domain is the deviceName
-		resp.Domain = fs.DeviceName
+		dev := &attachDevice{
+			Interface: fs.DeviceName,
+			Domain:    fs.DeviceName,
+			NUMANode:  int(fs.NUMANode),
+		}
 		if strings.HasPrefix(resp.Provider, verbsProvider) {
-			deviceAlias, err := netdetect.GetDeviceAlias(ctx, resp.Interface)
+			deviceAlias, err := netdetect.GetDeviceAlias(ctx, dev.Interface)
 			if err != nil {
-				aic.log.Debugf("non-fatal error: %v. unable to determine OFI_DOMAIN for %s", err, resp.Interface)
+				aic.log.Debugf("non-fatal error: %v. unable to determine OFI_DOMAIN for %s", err, dev.Interface)
 			} else {
-				resp.Domain = deviceAlias
-				aic.log.Debugf("OFI_DOMAIN has been detected as: %s", resp.Domain)
+				dev.Domain = deviceAlias
+				aic.log.Debugf("OFI_DOMAIN has been detected as: %s", dev.Domain)
 			}
 		}
 
-		numa := int(fs.NUMANode)
-
-		numaDeviceMarshResp, err := proto.Marshal(resp)
-		if err != nil {
-			return drpc.MarshalingFailure()
+		numa := dev.NUMANode
+		cpuList, ok := numaCPUList[numa]
+		if !ok {
+			var err error
+			cpuList, err = netdetect.GetNUMANodeCPUList(ctx, numa)
+			if err != nil {
+				aic.log.Debugf("non-fatal error: %v. unable to determine CPUs local to NUMA %d", err, numa)
+			}
+			numaCPUList[numa] = cpuList
 		}
+		dev.CPUList = cpuList
 
-		if _, ok := aic.numaDeviceMarshResp[numa]; !ok {
-			aic.numaDeviceMarshResp[numa] = make(map[int][]byte)
-		}
-		aic.numaDeviceMarshResp[numa][len(aic.numaDeviceMarshResp[numa])] = numaDeviceMarshResp
+		aic.numaDevices[numa] = append(aic.numaDevices[numa], dev)
 
 		// Any client bound to a NUMA node that has no network devices associated with it will
 		// get a response from this defaultNumaNode.
@@ -151,22 +286,24 @@ func (aic *attachInfoCache) initResponseCache(ctx context.Context, resp *mgmtpb.
 			aic.log.Debugf("The default NUMA node is: %d", aic.defaultNumaNode)
 		}
 
-		aic.log.Debugf("Added device %s, domain %s for NUMA %d, device number %d\n", resp.Interface, resp.Domain, numa, len(aic.numaDeviceMarshResp[numa])-1)
+		aic.log.Debugf("Added device %s, domain %s for NUMA %d, device number %d\n", dev.Interface, dev.Domain, numa, len(aic.numaDevices[numa])-1)
 	}
 
 	// If there were no network devices found, then add a default response to the default NUMA node entry
-	if _, ok := aic.numaDeviceMarshResp[aic.defaultNumaNode]; !ok {
+	if _, ok := aic.numaDevices[aic.defaultNumaNode]; !ok {
 		aic.log.Info("No network devices detected in fabric scan; default AttachInfo response may be incorrect\n")
-		aic.numaDeviceMarshResp[aic.defaultNumaNode] = make(map[int][]byte)
-		resp.Interface = defaultNetworkDevice
-		resp.Domain = defaultDomain
-		numaDeviceMarshResp, err := proto.Marshal(resp)
-		if err != nil {
-			return drpc.MarshalingFailure()
+		// No bindings can be recommended for the default device.
+		aic.numaDevices[aic.defaultNumaNode] = []*attachDevice{
+			{Interface: defaultNetworkDevice, Domain: defaultDomain},
 		}
-		aic.numaDeviceMarshResp[aic.defaultNumaNode][0] = numaDeviceMarshResp
 	}
 
+	if err := aic.buildResponses(); err != nil {
+		return err
+	}
+
+	aic.updatedAt = time.Now()
+
 	// If caching is enabled, the cache is now 'initialized'
 	if aic.enabled.IsTrue() {
 		aic.initialized.SetTrue()
@@ -174,3 +311,200 @@ func (aic *attachInfoCache) initResponseCache(ctx context.Context, resp *mgmtpb.
 
 	return nil
 }
+
+// altInterfaces returns the interfaces that a client assigned the given
+// device may use instead, in order of preference. Other devices on the same
+// NUMA node are preferred to those on other NUMA nodes, and interfaces that
+// are down are excluded. The caller must hold the cache mutex.
+func (aic *attachInfoCache) altInterfaces(dev *attachDevice) []*mgmtpb.GetAttachInfoResp_FabricInterface {
+	numaNodes := []int{dev.NUMANode}
+	var others []int
+	for numa := range aic.numaDevices {
+		if numa != dev.NUMANode {
+			others = append(others, numa)
+		}
+	}
+	sort.Ints(others)
+	numaNodes = append(numaNodes, others...)
+
+	var alts []*mgmtpb.GetAttachInfoResp_FabricInterface
+	for _, numa := range numaNodes {
+		devs := aic.numaDevices[numa]
+		// start after the device itself so that clients assigned
+		// different devices fail over to different alternatives
+		start := 0
+		for i, d := range devs {
+			if d == dev {
+				start = i + 1
+			}
+		}
+		for i := range devs {
+			alt := devs[(start+i)%len(devs)]
+			if alt == dev || alt.Interface == defaultNetworkDevice || aic.ifaceDown[alt.Interface] {
+				continue
+			}
+			alts = append(alts, &mgmtpb.GetAttachInfoResp_FabricInterface{
+				Interface: alt.Interface,
+				Domain:    alt.Domain,
+				NumaNode:  uint32(alt.NUMANode),
+			})
+		}
+	}
+
+	return alts
+}
+
+// buildResponses marshals the response for each device, with and without
+// recommended client bindings. The caller must hold the cache mutex.
+func (aic *attachInfoCache) buildResponses() error {
+	aic.numaDeviceMarshResp = make(map[int]map[int][]byte)
+	aic.numaDeviceHintsResp = make(map[int]map[int][]byte)
+
+	for numa, devs := range aic.numaDevices {
+		aic.numaDeviceMarshResp[numa] = make(map[int][]byte)
+		aic.numaDeviceHintsResp[numa] = make(map[int][]byte)
+
+		for devIdx, dev := range devs {
+			resp := proto.Clone(aic.baseResp).(*mgmtpb.GetAttachInfoResp)
+			resp.Interface = dev.Interface
+			resp.Domain = dev.Domain
+			resp.AltInterfaces = aic.altInterfaces(dev)
+
+			numaDeviceMarshResp, err := proto.Marshal(resp)
+			if err != nil {
+				return drpc.MarshalingFailure()
+			}
+			aic.numaDeviceMarshResp[numa][devIdx] = numaDeviceMarshResp
+
+			resp.NumaNode = uint32(dev.NUMANode)
+			resp.CpuList = dev.CPUList
+			numaDeviceHintsResp, err := proto.Marshal(resp)
+			if err != nil {
+				return drpc.MarshalingFailure()
+			}
+			aic.numaDeviceHintsResp[numa][devIdx] = numaDeviceHintsResp
+		}
+	}
+
+	return nil
+}
+
+// interfaces returns the names of the interfaces of the cached devices.
+func (aic *attachInfoCache) interfaces() []string {
+	aic.mutex.Lock()
+	defer aic.mutex.Unlock()
+
+	var ifaces []string
+	for _, devs := range aic.numaDevices {
+		for _, dev := range devs {
+			if dev.Interface != defaultNetworkDevice {
+				ifaces = append(ifaces, dev.Interface)
+			}
+		}
+	}
+	sort.Strings(ifaces)
+
+	return ifaces
+}
+
+// setIfaceState records whether an interface is up and regenerates the cached
+// responses if it has changed, so that new clients are steered away from an
+// interface that is down. Returns true if the state changed, along with the
+// preferred alternative to the interface, if any.
+func (aic *attachInfoCache) setIfaceState(iface string, up bool) (bool, string, error) {
+	aic.mutex.Lock()
+	defer aic.mutex.Unlock()
+
+	if aic.ifaceDown == nil {
+		aic.ifaceDown = make(map[string]bool)
+	}
+	if aic.ifaceDown[iface] == !up {
+		return false, "", nil
+	}
+	aic.ifaceDown[iface] = !up
+
+	var backup string
+	for _, devs := range aic.numaDevices {
+		for _, dev := range devs {
+			if dev.Interface != iface {
+				continue
+			}
+			if alts := aic.altInterfaces(dev); len(alts) > 0 {
+				backup = alts[0].Interface
+			}
+		}
+	}
+
+	if aic.baseResp == nil {
+		return true, backup, nil
+	}
+	return true, backup, aic.buildResponses()
+}
+
+// attachInfoStatus describes the state of the attach info cache.
+type attachInfoStatus struct {
+	Enabled         bool            `json:"enabled"`
+	Cached          bool            `json:"cached"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Provider        string          `json:"provider"`
+	NetDevClass     uint32          `json:"net_dev_class"`
+	NumRanks        int             `json:"num_ranks"`
+	MSRanks         []uint32        `json:"ms_ranks"`
+	CrtTimeout      uint32          `json:"crt_timeout"`
+	DefaultNumaNode int             `json:"default_numa_node"`
+	Devices         []*deviceStatus `json:"devices"`
+}
+
+// deviceStatus describes a network device offered to clients.
+type deviceStatus struct {
+	Interface string `json:"interface"`
+	Domain    string `json:"domain"`
+	NUMANode  int    `json:"numa_node"`
+	CPUList   string `json:"cpu_list"`
+	Up        bool   `json:"up"`
+	Assigned  uint64 `json:"assigned"` // clients assigned to the device
+}
+
+// status returns the state of the cache and the most recent fabric
+// selections.
+func (aic *attachInfoCache) status() (*attachInfoStatus, []*fabricSelection) {
+	aic.mutex.Lock()
+	defer aic.mutex.Unlock()
+
+	status := &attachInfoStatus{
+		Enabled:         aic.enabled.IsTrue(),
+		Cached:          aic.isCached(),
+		UpdatedAt:       aic.updatedAt,
+		DefaultNumaNode: aic.defaultNumaNode,
+	}
+	if aic.baseResp != nil {
+		status.Provider = aic.baseResp.Provider
+		status.NetDevClass = aic.baseResp.NetDevClass
+		status.NumRanks = len(aic.baseResp.RankUris)
+		status.MSRanks = aic.baseResp.MsRanks
+		status.CrtTimeout = aic.baseResp.CrtTimeout
+	}
+
+	var numaNodes []int
+	for numa := range aic.numaDevices {
+		numaNodes = append(numaNodes, numa)
+	}
+	sort.Ints(numaNodes)
+	for _, numa := range numaNodes {
+		for _, dev := range aic.numaDevices[numa] {
+			status.Devices = append(status.Devices, &deviceStatus{
+				Interface: dev.Interface,
+				Domain:    dev.Domain,
+				NUMANode:  numa,
+				CPUList:   dev.CPUList,
+				Up:        !aic.ifaceDown[dev.Interface],
+				Assigned:  aic.ifaceAssigned[dev.Interface],
+			})
+		}
+	}
+
+	selections := make([]*fabricSelection, len(aic.selections))
+	copy(selections, aic.selections)
+
+	return status, selections
+}
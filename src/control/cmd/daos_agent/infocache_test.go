@@ -73,7 +73,7 @@ func TestInfoCacheInitNoScanResults(t *testing.T) {
 			var res []byte
 			var err error
 			if aiCache.isCached() {
-				res, err = aiCache.getResponse(tc.numaNode)
+				res, err = aiCache.getResponse(tc.numaNode, false)
 				common.AssertEqual(t, err, nil, "getResponse error")
 			}
 			resp := &mgmtpb.GetAttachInfoResp{}
@@ -133,6 +133,63 @@ func TestInfoCacheInit(t *testing.T) {
 	}
 }
 
+func TestInfoCacheBindingHints(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+	enabled := atm.NewBool(true)
+	scanResults := []*netdetect.FabricScan{
+		{Provider: "ofi+sockets", DeviceName: "eth0_node0", NUMANode: 0},
+		{Provider: "ofi+sockets", DeviceName: "eth0_node1", NUMANode: 1},
+	}
+
+	aiCache := attachInfoCache{log: log, enabled: enabled}
+
+	netCtx, cleanupFn := initCache(t, scanResults, &aiCache)
+	defer cleanupFn(netCtx)
+
+	for name, tc := range map[string]struct {
+		numaNode     int
+		withHints    bool
+		expInterface string
+	}{
+		"numa 0 without hints": {
+			numaNode:     0,
+			expInterface: "eth0_node0",
+		},
+		"numa 0 with hints": {
+			numaNode:     0,
+			withHints:    true,
+			expInterface: "eth0_node0",
+		},
+		"numa 1 with hints": {
+			numaNode:     1,
+			withHints:    true,
+			expInterface: "eth0_node1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := aiCache.getResponse(tc.numaNode, tc.withHints)
+			common.AssertEqual(t, err, nil, "getResponse error")
+
+			resp := &mgmtpb.GetAttachInfoResp{}
+			if err = proto.Unmarshal(res, resp); err != nil {
+				t.Fatalf("Unexpected error on proto.Unmarshal: %v", err)
+			}
+			common.AssertEqual(t, resp.GetInterface(), tc.expInterface, "unexpected interface")
+
+			var expNumaNode uint32
+			var expCPUList string
+			if tc.withHints {
+				expNumaNode = uint32(tc.numaNode)
+				// CPU list is only available if the host has the NUMA node
+				expCPUList, _ = netdetect.GetNUMANodeCPUList(netCtx, tc.numaNode)
+			}
+			common.AssertEqual(t, resp.GetNumaNode(), expNumaNode, "unexpected NUMA node hint")
+			common.AssertEqual(t, resp.GetCpuList(), expCPUList, "unexpected CPU list hint")
+		})
+	}
+}
+
 func TestInfoCacheInitWithDeviceFiltering(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
@@ -241,7 +298,7 @@ func TestInfoCacheGetResponse(t *testing.T) {
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
-			res, err := aiCache.getResponse(tc.numaNode)
+			res, err := aiCache.getResponse(tc.numaNode, false)
 			common.AssertEqual(t, err, nil, "getResponse error")
 
 			resp := &mgmtpb.GetAttachInfoResp{}
@@ -290,7 +347,7 @@ func TestInfoCacheDefaultNumaNode(t *testing.T) {
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
-			res, err := aiCache.getResponse(tc.numaNode)
+			res, err := aiCache.getResponse(tc.numaNode, false)
 			common.AssertEqual(t, err, nil, "getResponse error")
 
 			resp := &mgmtpb.GetAttachInfoResp{}
@@ -379,7 +436,7 @@ func TestInfoCacheLoadBalancer(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			for i := 0; i < tc.numDevices+2; i++ {
 				var err error
-				results[i], err = aiCache.getResponse(tc.numaNode)
+				results[i], err = aiCache.getResponse(tc.numaNode, false)
 				common.AssertEqual(t, err, nil, "getResponse error")
 				response[i] = &mgmtpb.GetAttachInfoResp{}
 				if err = proto.Unmarshal(results[i], response[i]); err != nil {
@@ -409,7 +466,7 @@ func TestInfoCacheLoadBalancer(t *testing.T) {
 // given numa node.
 func getResponse(t *testing.T, aiCache *attachInfoCache, numaNode int, wg *sync.WaitGroup) {
 	defer wg.Done()
-	res, err := aiCache.getResponse(numaNode)
+	res, err := aiCache.getResponse(numaNode, false)
 	common.AssertEqual(t, err, nil, "TestInfoCacheConcurrentAccess getResponse error")
 
 	resp := &mgmtpb.GetAttachInfoResp{}
@@ -459,3 +516,162 @@ func TestInfoCacheConcurrentAccess(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// newTestFailoverCache returns a cache populated without a fabric scan, with
+// two devices on NUMA 0 and one on NUMA 1.
+func newTestFailoverCache(t *testing.T, log logging.Logger) *attachInfoCache {
+	aiCache := &attachInfoCache{
+		log:               log,
+		enabled:           atm.NewBool(true),
+		baseResp:          &mgmtpb.GetAttachInfoResp{Provider: "ofi+sockets"},
+		currentNumaDevIdx: make(map[int]int),
+		ifaceDown:         make(map[string]bool),
+		numaDevices: map[int][]*attachDevice{
+			0: {
+				{Interface: "ib0", Domain: "mlx5_0", NUMANode: 0},
+				{Interface: "ib1", Domain: "mlx5_1", NUMANode: 0},
+			},
+			1: {
+				{Interface: "ib2", Domain: "mlx5_2", NUMANode: 1},
+			},
+		},
+	}
+	if err := aiCache.buildResponses(); err != nil {
+		t.Fatal(err)
+	}
+	aiCache.initialized.SetTrue()
+
+	return aiCache
+}
+
+func TestInfoCacheFailover(t *testing.T) {
+	for name, tc := range map[string]struct {
+		down         []string
+		numaNode     int
+		expInterface string
+		expAlts      []string
+		expBackup    string
+		expReason    string
+	}{
+		"all up": {
+			numaNode:     1,
+			expInterface: "ib2",
+			expAlts:      []string{"ib0", "ib1"},
+			expReason:    "local to client NUMA node",
+		},
+		"local device down": {
+			down:         []string{"ib2"},
+			numaNode:     1,
+			expInterface: "ib0",
+			expAlts:      []string{"ib1"},
+			expBackup:    "ib0",
+			expReason:    "no usable device on client NUMA node 1, failed over to NUMA node 0 (default)",
+		},
+		"same numa device preferred": {
+			down:         []string{"ib0"},
+			numaNode:     0,
+			expInterface: "ib1",
+			expAlts:      []string{"ib2"},
+			expBackup:    "ib1",
+			expReason:    "local to client NUMA node",
+		},
+		"all down": {
+			down:         []string{"ib0", "ib1", "ib2"},
+			numaNode:     1,
+			expInterface: "ib2",
+			expReason:    "local to client NUMA node, all interfaces are down",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			aiCache := newTestFailoverCache(t, log)
+
+			var backup string
+			for _, iface := range tc.down {
+				changed, b, err := aiCache.setIfaceState(iface, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				common.AssertTrue(t, changed, "expected interface state to change")
+				backup = b
+			}
+			common.AssertEqual(t, tc.expBackup, backup, "unexpected backup interface")
+
+			res, err := aiCache.getResponse(tc.numaNode, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := &mgmtpb.GetAttachInfoResp{}
+			if err := proto.Unmarshal(res, resp); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expInterface, resp.GetInterface(), "unexpected interface")
+
+			var gotAlts []string
+			for _, alt := range resp.GetAltInterfaces() {
+				gotAlts = append(gotAlts, alt.GetInterface())
+			}
+			common.AssertEqual(t, tc.expAlts, gotAlts, "unexpected alternative interfaces")
+
+			_, selections := aiCache.status()
+			if len(selections) != 1 {
+				t.Fatalf("expected 1 fabric selection, got %d", len(selections))
+			}
+			common.AssertEqual(t, tc.expInterface, selections[0].Interface, "unexpected selected interface")
+			common.AssertEqual(t, tc.numaNode, selections[0].ClientNUMA, "unexpected client NUMA")
+			common.AssertEqual(t, tc.expReason, selections[0].Reason, "unexpected selection reason")
+
+			// bringing the interfaces back up restores the original selection
+			for _, iface := range tc.down {
+				if _, _, err := aiCache.setIfaceState(iface, true); err != nil {
+					t.Fatal(err)
+				}
+			}
+			changed, _, err := aiCache.setIfaceState("ib0", true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertFalse(t, changed, "expected no change for interface already up")
+		})
+	}
+}
+
+func TestInfoCacheStatus(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	aiCache := newTestFailoverCache(t, log)
+	aiCache.baseResp.MsRanks = []uint32{0, 2}
+	aiCache.baseResp.RankUris = []*mgmtpb.GetAttachInfoResp_RankUri{
+		{Rank: 0}, {Rank: 1}, {Rank: 2},
+	}
+	if _, _, err := aiCache.setIfaceState("ib1", false); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < maxFabricSelections+2; i++ {
+		if _, err := aiCache.getResponse(i%2, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	status, selections := aiCache.status()
+	common.AssertTrue(t, status.Cached, "expected cache to be initialized")
+	common.AssertEqual(t, "ofi+sockets", status.Provider, "unexpected provider")
+	common.AssertEqual(t, 3, status.NumRanks, "unexpected rank count")
+	common.AssertEqual(t, []uint32{0, 2}, status.MSRanks, "unexpected MS ranks")
+
+	var gotDevs []string
+	for _, dev := range status.Devices {
+		gotDevs = append(gotDevs, fmt.Sprintf("%s:%d:%t:%d", dev.Interface, dev.NUMANode, dev.Up, dev.Assigned))
+	}
+	expDevs := []string{"ib0:0:true:17", "ib1:0:false:0", "ib2:1:true:17"}
+	common.AssertEqual(t, expDevs, gotDevs, "unexpected devices")
+
+	common.AssertEqual(t, maxFabricSelections, len(selections), "unexpected number of selections")
+	last := selections[len(selections)-1]
+	common.AssertEqual(t, "ib2", last.Interface, "unexpected last selection")
+	common.AssertEqual(t, 1, last.ClientNUMA, "unexpected last selection client NUMA")
+}
@@ -36,6 +36,8 @@ type cliOptions struct {
 	Version    versionCmd        `command:"version" description:"Print daos_agent version"`
 	DumpInfo   dumpAttachInfoCmd `command:"dump-attachinfo" description:"Dump system attachinfo"`
 	NetScan    netScanCmd        `command:"net-scan" description:"Perform local network fabric scan"`
+	Status     statusCmd         `command:"status" description:"Show the state of the running daos_agent"`
+	Query      queryCmd          `command:"query" description:"Show the cached attach info and fabric selections of the running daos_agent"`
 }
 
 type (
@@ -194,6 +196,13 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 			cfg.RuntimeDir = opts.RuntimeDir
 		}
 
+		switch cmd.(type) {
+		case *statusCmd, *queryCmd:
+			// these commands only query the running agent
+			cmd.(configSetter).setConfig(cfg)
+			return cmd.Execute(args)
+		}
+
 		if opts.LogFile != "" {
 			log.Debugf("Overriding LogFile path from config file with %s", opts.LogFile)
 			cfg.LogFile = opts.LogFile
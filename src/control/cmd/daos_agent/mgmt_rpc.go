@@ -15,6 +15,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/drpc"
@@ -35,6 +36,7 @@ type mgmtModule struct {
 	netCtx     context.Context
 	mutex      sync.Mutex
 	monitor    *procMon
+	status     *statusReporter
 }
 
 func (mod *mgmtModule) HandleCall(session *drpc.Session, method drpc.Method, req []byte) ([]byte, error) {
@@ -69,6 +71,10 @@ func (mod *mgmtModule) HandleCall(session *drpc.Session, method drpc.Method, req
 		// call the disconnect handler and return success.
 		mod.handleNotifyExit(ctx, cred.Pid)
 		return nil, nil
+	case drpc.MethodAgentStatus:
+		ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+		defer cancel()
+		return mod.handleAgentStatus(ctx, cred.Uid)
 	default:
 		return nil, drpc.UnknownMethodFailure()
 	}
@@ -129,7 +135,7 @@ func (mod *mgmtModule) handleGetAttachInfo(ctx context.Context, reqb []byte, pid
 		if !mod.numaAware {
 			numaNode = mod.aiCache.defaultNumaNode
 		}
-		return mod.aiCache.getResponse(numaNode)
+		return mod.aiCache.getResponse(numaNode, pbReq.BindingHints)
 	}
 
 	// Ask the MS for _all_ info, regardless of pbReq.AllRanks, so that the
@@ -137,6 +143,7 @@ func (mod *mgmtModule) handleGetAttachInfo(ctx context.Context, reqb []byte, pid
 	req := new(control.GetAttachInfoReq)
 	req.SetSystem(pbReq.GetSys())
 	req.AllRanks = true
+	req.AgentVersion = build.DaosVersion
 	resp, err := control.GetAttachInfo(ctx, mod.ctlInvoker, req)
 	if err != nil {
 		return nil, errors.Wrapf(err, "GetAttachInfo %+v", pbReq)
@@ -168,7 +175,7 @@ func (mod *mgmtModule) handleGetAttachInfo(ctx context.Context, reqb []byte, pid
 		numaNode = mod.aiCache.defaultNumaNode
 	}
 
-	cacheResp, err := mod.aiCache.getResponse(numaNode)
+	cacheResp, err := mod.aiCache.getResponse(numaNode, pbReq.BindingHints)
 	if err != nil {
 		return nil, err
 	}
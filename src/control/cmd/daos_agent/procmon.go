@@ -113,10 +113,18 @@ type procMon struct {
 	procs      map[int32]*procInfo
 	request    chan *procMonRequest
 	response   chan *procMonResponse
+	statusReq  chan chan *clientStatus
 	ctlInvoker control.Invoker
 	systemName string
 }
 
+// clientStatus describes the client processes monitored by the agent.
+type clientStatus struct {
+	Processes   int `json:"processes"`    // processes with open pool handles
+	Pools       int `json:"pools"`        // pools connected to by those processes
+	PoolHandles int `json:"pool_handles"` // pool handles held by those processes
+}
+
 // NewProcMon creates a new process monitor struct setting initializing the
 // internal process map and the request channel.
 func NewProcMon(logger logging.Logger, ctlInvoker control.Invoker, systemName string) *procMon {
@@ -125,6 +133,7 @@ func NewProcMon(logger logging.Logger, ctlInvoker control.Invoker, systemName st
 		procs:      make(map[int32]*procInfo),
 		request:    make(chan *procMonRequest),
 		response:   make(chan *procMonResponse),
+		statusReq:  make(chan chan *clientStatus),
 		ctlInvoker: ctlInvoker,
 		systemName: systemName,
 	}
@@ -158,6 +167,38 @@ func (p *procMon) NotifyExit(ctx context.Context, Pid int32) {
 	p.submitRequest(ctx, req)
 }
 
+// status returns the number of client processes being monitored and the pool
+// handles they hold.
+func (p *procMon) status(ctx context.Context) (*clientStatus, error) {
+	reply := make(chan *clientStatus, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case p.statusReq <- reply:
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case cs := <-reply:
+		return cs, nil
+	}
+}
+
+func (p *procMon) handleStatus() *clientStatus {
+	cs := &clientStatus{Processes: len(p.procs)}
+	pools := make(map[string]struct{})
+	for _, info := range p.procs {
+		for poolUUID, handles := range info.handles {
+			pools[poolUUID] = struct{}{}
+			cs.PoolHandles += len(handles)
+		}
+	}
+	cs.Pools = len(pools)
+
+	return cs
+}
+
 func (p *procMon) submitRequest(ctx context.Context, request *procMonRequest) {
 	select {
 	case <-ctx.Done():
@@ -276,6 +317,8 @@ func (p *procMon) handleRequests(ctx context.Context) {
 			default:
 				p.log.Debugf("Received request with invalid action type %s", request.action)
 			}
+		case reply := <-p.statusReq:
+			reply <- p.handleStatus()
 		case resp := <-p.response:
 			p.log.Debugf("Received response from Process %d, terminated with %s", resp.pid, resp.err)
 			info, found := p.procs[resp.pid]
@@ -8,6 +8,7 @@ package main
 
 import (
 	"net"
+	"sync/atomic"
 
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -20,6 +21,15 @@ type SecurityModule struct {
 	log    logging.Logger
 	ext    auth.UserExt
 	config *security.TransportConfig
+	// credential request counters, accessed atomically
+	credRequests uint64
+	credFailures uint64
+}
+
+// credentialStatus describes the credential requests handled by the agent.
+type credentialStatus struct {
+	Requests uint64 `json:"requests"`
+	Failures uint64 `json:"failures"`
 }
 
 //NewSecurityModule creates a new module with the given initialized TransportConfig
@@ -38,7 +48,20 @@ func (m *SecurityModule) HandleCall(session *drpc.Session, method drpc.Method, b
 		return nil, drpc.UnknownMethodFailure()
 	}
 
-	return m.getCredential(session)
+	atomic.AddUint64(&m.credRequests, 1)
+	resp, err := m.getCredential(session)
+	if err != nil {
+		atomic.AddUint64(&m.credFailures, 1)
+	}
+	return resp, err
+}
+
+// status returns the number of credential requests handled and failed.
+func (m *SecurityModule) status() *credentialStatus {
+	return &credentialStatus{
+		Requests: atomic.LoadUint64(&m.credRequests),
+		Failures: atomic.LoadUint64(&m.credFailures),
+	}
 }
 
 // getCredentials generates a signed user credential based on the data attached to
@@ -73,6 +96,7 @@ func (m *SecurityModule) getCredential(session *drpc.Session) ([]byte, error) {
 }
 
 func (m *SecurityModule) credRespWithStatus(status drpc.DaosStatus) ([]byte, error) {
+	atomic.AddUint64(&m.credFailures, 1)
 	resp := &auth.GetCredResp{Status: int32(status)}
 	return drpc.Marshal(resp)
 }
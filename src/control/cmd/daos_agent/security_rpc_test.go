@@ -111,6 +111,7 @@ func TestAgentSecurityModule_RequestCreds_OK(t *testing.T) {
 	}
 
 	expectCredResp(t, respBytes, 0, true)
+	common.AssertEqual(t, &credentialStatus{Requests: 1}, mod.status(), "unexpected credential status")
 }
 
 func TestAgentSecurityModule_RequestCreds_NotUnixConn(t *testing.T) {
@@ -163,6 +164,7 @@ func TestAgentSecurityModule_RequestCreds_BadConfig(t *testing.T) {
 	}
 
 	expectCredResp(t, respBytes, int32(drpc.DaosInvalidInput), false)
+	common.AssertEqual(t, &credentialStatus{Requests: 1, Failures: 1}, mod.status(), "unexpected credential status")
 }
 
 func TestAgentSecurityModule_RequestCreds_BadUid(t *testing.T) {
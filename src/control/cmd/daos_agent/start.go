@@ -15,7 +15,9 @@ import (
 	"time"
 
 	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 )
 
@@ -65,15 +67,34 @@ func (cmd *startCmd) Execute(_ []string) error {
 	procmon := NewProcMon(cmd.log, cmd.ctlInvoker, cmd.cfg.SystemName)
 	procmon.startMonitoring(ctx)
 
-	drpcServer.RegisterRPCModule(NewSecurityModule(cmd.log, cmd.cfg.TransportConfig))
+	// Log agent RAS events locally and forward them to the MS.
+	pubSub := events.NewPubSub(ctx, cmd.log)
+	defer pubSub.Close()
+	pubSub.Subscribe(events.RASTypeAny, control.NewEventLogger(cmd.log, events.EventFormatRAS))
+	pubSub.Subscribe(events.RASTypeAny, control.NewEventForwarder(cmd.ctlInvoker, cmd.cfg.AccessPoints))
+
+	aiCache := &attachInfoCache{log: cmd.log, enabled: enabled}
+	if cmd.cfg.FabricCheckInterval > 0 {
+		newIfaceMonitor(cmd.log, aiCache, pubSub).startMonitoring(ctx, cmd.cfg.FabricCheckInterval)
+	}
+
+	secModule := NewSecurityModule(cmd.log, cmd.cfg.TransportConfig)
+	drpcServer.RegisterRPCModule(secModule)
 	drpcServer.RegisterRPCModule(&mgmtModule{
 		log:        cmd.log,
 		sys:        cmd.cfg.SystemName,
 		ctlInvoker: cmd.ctlInvoker,
-		aiCache:    &attachInfoCache{log: cmd.log, enabled: enabled},
+		aiCache:    aiCache,
 		numaAware:  numaAware,
 		netCtx:     netCtx,
 		monitor:    procmon,
+		status: &statusReporter{
+			cfg:       cmd.cfg,
+			startedAt: startedAt,
+			aiCache:   aiCache,
+			monitor:   procmon,
+			security:  secModule,
+		},
 	})
 
 	err = drpcServer.Start()
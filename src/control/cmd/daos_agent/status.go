@@ -0,0 +1,274 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+const statusTimeout = 5 * time.Second
+
+// agentStatus describes the state of a running agent, as reported to the
+// status and query subcommands.
+type agentStatus struct {
+	Version          string             `json:"version"`
+	Pid              int                `json:"pid"`
+	StartedAt        time.Time          `json:"started_at"`
+	SystemName       string             `json:"system_name"`
+	AccessPoints     []string           `json:"access_points"`
+	Clients          *clientStatus      `json:"clients"`
+	Credentials      *credentialStatus  `json:"credentials"`
+	AttachInfo       *attachInfoStatus  `json:"attach_info"`
+	FabricSelections []*fabricSelection `json:"fabric_selections"`
+}
+
+// statusReporter collects the state of the running agent.
+type statusReporter struct {
+	cfg       *Config
+	startedAt time.Time
+	aiCache   *attachInfoCache
+	monitor   *procMon
+	security  *SecurityModule
+}
+
+func (sr *statusReporter) getStatus(ctx context.Context) (*agentStatus, error) {
+	clients, err := sr.monitor.status(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying client processes")
+	}
+
+	aiStatus, selections := sr.aiCache.status()
+
+	return &agentStatus{
+		Version:          build.DaosVersion,
+		Pid:              os.Getpid(),
+		StartedAt:        sr.startedAt,
+		SystemName:       sr.cfg.SystemName,
+		AccessPoints:     sr.cfg.AccessPoints,
+		Clients:          clients,
+		Credentials:      sr.security.status(),
+		AttachInfo:       aiStatus,
+		FabricSelections: selections,
+	}, nil
+}
+
+// handleAgentStatus returns the JSON-encoded state of the agent. The state is
+// only reported to root and to the user running the agent.
+func (mod *mgmtModule) handleAgentStatus(ctx context.Context, uid uint32) ([]byte, error) {
+	if uid != 0 && int(uid) != os.Getuid() {
+		return nil, drpc.NewFailureWithMessage("permission denied")
+	}
+
+	if mod.status == nil {
+		return nil, drpc.NewFailureWithMessage("agent status not available")
+	}
+
+	status, err := mod.status.getStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(status)
+}
+
+// getAgentStatus queries the running agent listening on the socket in the
+// given runtime directory for its state.
+func getAgentStatus(runtimeDir string) (*agentStatus, error) {
+	sockPath := filepath.Join(runtimeDir, agentSockName)
+	client := drpc.NewClientConnection(sockPath)
+	if err := client.Connect(); err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to agent at %s", sockPath)
+	}
+	defer client.Close()
+
+	resp, err := client.SendMsg(&drpc.Call{
+		Module: drpc.ModuleMgmt.ID(),
+		Method: drpc.MethodAgentStatus.ID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != drpc.Status_SUCCESS {
+		return nil, errors.Errorf("agent status request failed: %s", resp.Status)
+	}
+
+	status := new(agentStatus)
+	if err := json.Unmarshal(resp.Body, status); err != nil {
+		return nil, errors.Wrap(err, "unable to decode agent status")
+	}
+	return status, nil
+}
+
+type statusCmd struct {
+	configCmd
+	jsonOutputCmd
+}
+
+// Execute prints a summary of the state of the running agent.
+func (cmd *statusCmd) Execute(_ []string) error {
+	status, err := getAgentStatus(cmd.cfg.RuntimeDir)
+	if err != nil {
+		return err
+	}
+
+	if cmd.shouldEmitJSON {
+		return cmd.outputJSON(os.Stdout, status)
+	}
+
+	return printAgentStatus(os.Stdout, status)
+}
+
+type queryCmd struct {
+	configCmd
+	jsonOutputCmd
+}
+
+// Execute prints the cached attach info of the running agent and the fabric
+// interfaces recently selected for clients.
+func (cmd *queryCmd) Execute(_ []string) error {
+	status, err := getAgentStatus(cmd.cfg.RuntimeDir)
+	if err != nil {
+		return err
+	}
+
+	if cmd.shouldEmitJSON {
+		return cmd.outputJSON(os.Stdout, struct {
+			AttachInfo       *attachInfoStatus  `json:"attach_info"`
+			FabricSelections []*fabricSelection `json:"fabric_selections"`
+		}{status.AttachInfo, status.FabricSelections})
+	}
+
+	return printAgentQuery(os.Stdout, status)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func printAgentStatus(out io.Writer, status *agentStatus) error {
+	ai := status.AttachInfo
+	cache := "disabled"
+	switch {
+	case ai.Cached:
+		cache = fmt.Sprintf("cached at %s", formatTime(ai.UpdatedAt))
+	case ai.Enabled:
+		cache = "not yet cached"
+	}
+
+	var ifaces []string
+	for _, dev := range ai.Devices {
+		ifaces = append(ifaces, dev.Interface)
+	}
+
+	rows := []txtfmt.TableRow{
+		{"Version": status.Version},
+		{"PID": fmt.Sprintf("%d", status.Pid)},
+		{"Started": formatTime(status.StartedAt)},
+		{"System": status.SystemName},
+		{"Access Points": strings.Join(status.AccessPoints, ",")},
+		{"Client Processes": fmt.Sprintf("%d", status.Clients.Processes)},
+		{"Pool Handles": fmt.Sprintf("%d (%d pools)", status.Clients.PoolHandles, status.Clients.Pools)},
+		{"Credential Requests": fmt.Sprintf("%d (%d failed)", status.Credentials.Requests, status.Credentials.Failures)},
+		{"Attach Info": cache},
+		{"Fabric Interfaces": strings.Join(ifaces, ",")},
+	}
+
+	_, err := fmt.Fprint(out, txtfmt.FormatEntity("Agent Status", rows))
+	return err
+}
+
+func printAgentQuery(out io.Writer, status *agentStatus) error {
+	ai := status.AttachInfo
+	if !ai.Cached && ai.UpdatedAt.IsZero() {
+		_, err := fmt.Fprintln(out, "No attach info has been retrieved from the management service")
+		return err
+	}
+
+	msRanks := make([]string, 0, len(ai.MSRanks))
+	for _, r := range ai.MSRanks {
+		msRanks = append(msRanks, fmt.Sprintf("%d", r))
+	}
+	rows := []txtfmt.TableRow{
+		{"Updated": formatTime(ai.UpdatedAt)},
+		{"Provider": ai.Provider},
+		{"Device Class": netdetect.DevClassName(ai.NetDevClass)},
+		{"Ranks": fmt.Sprintf("%d", ai.NumRanks)},
+		{"MS Ranks": strings.Join(msRanks, ",")},
+		{"CaRT Timeout": fmt.Sprintf("%d", ai.CrtTimeout)},
+		{"Default NUMA": fmt.Sprintf("%d", ai.DefaultNumaNode)},
+	}
+	ew := txtfmt.NewErrWriter(out)
+	fmt.Fprintln(ew, txtfmt.FormatEntity("Attach Info", rows))
+
+	ifaceTitle := "Interface"
+	domainTitle := "Domain"
+	numaTitle := "NUMA"
+	cpuTitle := "CPUs"
+	stateTitle := "State"
+	assignedTitle := "Assigned"
+	devTable := txtfmt.NewTableFormatter(ifaceTitle, domainTitle, numaTitle, cpuTitle, stateTitle, assignedTitle)
+	devTable.InitWriter(ew)
+	var devRows []txtfmt.TableRow
+	for _, dev := range ai.Devices {
+		state := "up"
+		if !dev.Up {
+			state = "down"
+		}
+		devRows = append(devRows, txtfmt.TableRow{
+			ifaceTitle:    dev.Interface,
+			domainTitle:   dev.Domain,
+			numaTitle:     fmt.Sprintf("%d", dev.NUMANode),
+			cpuTitle:      dev.CPUList,
+			stateTitle:    state,
+			assignedTitle: fmt.Sprintf("%d", dev.Assigned),
+		})
+	}
+	devTable.Format(devRows)
+
+	if len(status.FabricSelections) == 0 {
+		fmt.Fprintln(ew, "\nNo fabric interfaces have been selected for clients")
+		return ew.Err
+	}
+
+	fmt.Fprintln(ew, "\nRecent Fabric Selections")
+	fmt.Fprintln(ew, "------------------------")
+	timeTitle := "Time"
+	clientTitle := "Client NUMA"
+	reasonTitle := "Reason"
+	selTable := txtfmt.NewTableFormatter(timeTitle, clientTitle, ifaceTitle, numaTitle, reasonTitle)
+	selTable.InitWriter(ew)
+	var selRows []txtfmt.TableRow
+	for _, sel := range status.FabricSelections {
+		selRows = append(selRows, txtfmt.TableRow{
+			timeTitle:   formatTime(sel.Time),
+			clientTitle: fmt.Sprintf("%d", sel.ClientNUMA),
+			ifaceTitle:  sel.Interface,
+			numaTitle:   fmt.Sprintf("%d", sel.NUMANode),
+			reasonTitle: sel.Reason,
+		})
+	}
+	selTable.Format(selRows)
+
+	return ew.Err
+}
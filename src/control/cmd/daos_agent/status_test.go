@@ -0,0 +1,130 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestAgent_procMon_status(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	procmon := NewProcMon(log, nil, build.DefaultSystemName)
+	procmon.startMonitoring(ctx)
+
+	pid := int32(os.Getpid())
+	for _, req := range []*mgmtpb.PoolMonitorReq{
+		{PoolUUID: "pool-1", PoolHandleUUID: "handle-1"},
+		{PoolUUID: "pool-1", PoolHandleUUID: "handle-2"},
+		{PoolUUID: "pool-2", PoolHandleUUID: "handle-3"},
+	} {
+		procmon.AddPoolHandle(ctx, pid, req)
+	}
+
+	cs, err := procmon.status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, &clientStatus{Processes: 1, Pools: 2, PoolHandles: 3}, cs,
+		"unexpected client status")
+}
+
+func TestAgent_getAgentStatus(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := DefaultConfig()
+	cfg.RuntimeDir = tmpDir
+	startedAt := time.Now().Round(time.Second)
+
+	procmon := NewProcMon(log, nil, cfg.SystemName)
+	procmon.startMonitoring(ctx)
+	aiCache := newTestFailoverCache(t, log)
+	if _, err := aiCache.getResponse(1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := drpc.NewDomainSocketServer(ctx, log, filepath.Join(tmpDir, agentSockName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.RegisterRPCModule(&mgmtModule{
+		log:     log,
+		sys:     cfg.SystemName,
+		aiCache: aiCache,
+		monitor: procmon,
+		status: &statusReporter{
+			cfg:       cfg,
+			startedAt: startedAt,
+			aiCache:   aiCache,
+			monitor:   procmon,
+			security:  NewSecurityModule(log, nil),
+		},
+	})
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Shutdown()
+
+	status, err := getAgentStatus(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	common.AssertEqual(t, os.Getpid(), status.Pid, "unexpected pid")
+	common.AssertTrue(t, startedAt.Equal(status.StartedAt), "unexpected start time")
+	common.AssertEqual(t, cfg.SystemName, status.SystemName, "unexpected system name")
+	common.AssertEqual(t, &clientStatus{}, status.Clients, "unexpected client status")
+	common.AssertEqual(t, &credentialStatus{}, status.Credentials, "unexpected credential status")
+	common.AssertTrue(t, status.AttachInfo.Cached, "expected attach info to be cached")
+	common.AssertEqual(t, 1, len(status.FabricSelections), "unexpected fabric selections")
+	common.AssertEqual(t, "ib2", status.FabricSelections[0].Interface, "unexpected selected interface")
+
+	var b strings.Builder
+	if err := printAgentStatus(&b, status); err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{
+		"Client Processes    : 0",
+		"Credential Requests : 0 (0 failed)",
+		"Fabric Interfaces   : ib0,ib1,ib2",
+	} {
+		common.AssertTrue(t, strings.Contains(b.String(), exp),
+			"expected "+exp+" in status output:\n"+b.String())
+	}
+}
+
+func TestAgent_getAgentStatus_NotRunning(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	_, err := getAgentStatus(tmpDir)
+	common.CmpErr(t, errors.New("unable to connect to agent"), err)
+}
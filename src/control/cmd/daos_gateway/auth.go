@@ -0,0 +1,89 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	// maxTokenFilePerms is the most permissive mode allowed for the token
+	// file, which must not be accessible to other users.
+	maxTokenFilePerms = 0600
+)
+
+// tokenSet holds the API tokens accepted by the gateway.
+type tokenSet [][]byte
+
+// loadTokens reads the API tokens from the given file, one per line. Blank
+// lines and lines starting with # are ignored.
+func loadTokens(path string) (tokenSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening token file")
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&^maxTokenFilePerms != 0 {
+		return nil, errors.Errorf("token file %s has permissions %#o, must not exceed %#o",
+			path, fi.Mode().Perm(), maxTokenFilePerms)
+	}
+
+	var tokens tokenSet
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		token := strings.TrimSpace(scanner.Text())
+		if token == "" || strings.HasPrefix(token, "#") {
+			continue
+		}
+		tokens = append(tokens, []byte(token))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading token file %s", path)
+	}
+	if len(tokens) == 0 {
+		return nil, errors.Errorf("no tokens in token file %s", path)
+	}
+
+	return tokens, nil
+}
+
+// valid returns true if the token matches one of the set. Every token is
+// compared in constant time so that the comparison does not leak the
+// position of the first mismatch.
+func (ts tokenSet) valid(token string) bool {
+	found := 0
+	for _, t := range ts {
+		found |= subtle.ConstantTimeCompare(t, []byte(token))
+	}
+	return found == 1
+}
+
+// requireToken rejects requests that do not carry a valid bearer token in
+// the Authorization header.
+func (ts tokenSet) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, bearerPrefix) || !ts.valid(strings.TrimPrefix(auth, bearerPrefix)) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="daos"`)
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,116 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestGateway_loadTokens(t *testing.T) {
+	for name, tc := range map[string]struct {
+		content   string
+		perms     os.FileMode
+		noFile    bool
+		expTokens tokenSet
+		expErr    error
+	}{
+		"missing file": {
+			noFile: true,
+			expErr: errors.New("opening token file"),
+		},
+		"world readable": {
+			content: "secret\n",
+			perms:   0644,
+			expErr:  errors.New("must not exceed 0600"),
+		},
+		"no tokens": {
+			content: "# comment only\n\n",
+			perms:   0600,
+			expErr:  errors.New("no tokens"),
+		},
+		"comments and blanks skipped": {
+			content:   "# admin\nsecret1\n\n  secret2  \n",
+			perms:     0400,
+			expTokens: tokenSet{[]byte("secret1"), []byte("secret2")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			path := filepath.Join(tmpDir, "tokens")
+			if !tc.noFile {
+				if err := ioutil.WriteFile(path, []byte(tc.content), tc.perms); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotTokens, gotErr := loadTokens(path)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			common.AssertEqual(t, tc.expTokens, gotTokens, "unexpected tokens")
+		})
+	}
+}
+
+func TestGateway_requireToken(t *testing.T) {
+	tokens := tokenSet{[]byte("secret1"), []byte("secret2")}
+	handler := tokens.requireToken(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	for name, tc := range map[string]struct {
+		auth    string
+		expCode int
+	}{
+		"no header": {
+			expCode: http.StatusUnauthorized,
+		},
+		"wrong scheme": {
+			auth:    "Basic secret1",
+			expCode: http.StatusUnauthorized,
+		},
+		"wrong token": {
+			auth:    "Bearer secret",
+			expCode: http.StatusUnauthorized,
+		},
+		"first token": {
+			auth:    "Bearer secret1",
+			expCode: http.StatusTeapot,
+		},
+		"second token": {
+			auth:    "Bearer secret2",
+			expCode: http.StatusTeapot,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/system", nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			common.AssertEqual(t, tc.expCode, rec.Code, "unexpected status code")
+			if tc.expCode == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("expected WWW-Authenticate header")
+			}
+		})
+	}
+}
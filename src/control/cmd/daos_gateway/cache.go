@@ -0,0 +1,83 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// fetchFn fetches a response to be cached.
+	fetchFn func(context.Context) (interface{}, error)
+
+	cacheEntry struct {
+		sync.Mutex
+		fetched time.Time
+		resp    interface{}
+	}
+
+	// respCache caches successful responses for a fixed period, so that
+	// frequently polled endpoints don't result in a control API call for
+	// every request.
+	respCache struct {
+		sync.Mutex
+		ttl     time.Duration
+		now     func() time.Time
+		entries map[string]*cacheEntry
+	}
+)
+
+func newRespCache(ttl time.Duration) *respCache {
+	return &respCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (rc *respCache) entry(key string) *cacheEntry {
+	rc.Lock()
+	defer rc.Unlock()
+
+	ce, found := rc.entries[key]
+	if !found {
+		ce = new(cacheEntry)
+		rc.entries[key] = ce
+	}
+	return ce
+}
+
+// get returns the cached response for the key if it is younger than the
+// cache TTL, otherwise the response is fetched and cached if successful. Only
+// one fetch per key is in progress at a time, concurrent requests wait for
+// its result. The time at which the response was fetched is also returned.
+func (rc *respCache) get(ctx context.Context, key string, fetch fetchFn) (interface{}, time.Time, error) {
+	if rc == nil || rc.ttl <= 0 {
+		resp, err := fetch(ctx)
+		return resp, time.Now(), err
+	}
+
+	ce := rc.entry(key)
+	ce.Lock()
+	defer ce.Unlock()
+
+	now := rc.now()
+	if ce.resp != nil && now.Sub(ce.fetched) < rc.ttl {
+		return ce.resp, ce.fetched, nil
+	}
+
+	resp, err := fetch(ctx)
+	if err != nil {
+		return nil, now, err
+	}
+	ce.resp = resp
+	ce.fetched = now
+
+	return resp, now, nil
+}
@@ -0,0 +1,77 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestGateway_respCache(t *testing.T) {
+	start := time.Now()
+	for name, tc := range map[string]struct {
+		ttl        time.Duration
+		fetchErrs  []error
+		elapsed    []time.Duration
+		expFetches int
+		expErr     error
+	}{
+		"disabled": {
+			elapsed:    []time.Duration{0, 0, 0},
+			expFetches: 3,
+		},
+		"cached": {
+			ttl:        5 * time.Second,
+			elapsed:    []time.Duration{0, time.Second, 4 * time.Second},
+			expFetches: 1,
+		},
+		"expired": {
+			ttl:        5 * time.Second,
+			elapsed:    []time.Duration{0, time.Second, 5 * time.Second, 6 * time.Second},
+			expFetches: 2,
+		},
+		"errors not cached": {
+			ttl:        5 * time.Second,
+			fetchErrs:  []error{errors.New("fetch failed"), nil},
+			elapsed:    []time.Duration{0, time.Second, 2 * time.Second},
+			expFetches: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rc := newRespCache(tc.ttl)
+
+			var fetches int
+			fetch := func(_ context.Context) (interface{}, error) {
+				fetches++
+				if len(tc.fetchErrs) >= fetches && tc.fetchErrs[fetches-1] != nil {
+					return nil, tc.fetchErrs[fetches-1]
+				}
+				return fetches, nil
+			}
+
+			for i, elapsed := range tc.elapsed {
+				rc.now = func() time.Time { return start.Add(elapsed) }
+
+				resp, _, err := rc.get(context.Background(), "key", fetch)
+				if i < len(tc.fetchErrs) && tc.fetchErrs[i] != nil {
+					common.CmpErr(t, tc.fetchErrs[i], err)
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				common.AssertEqual(t, fetches, resp, "unexpected cached response")
+			}
+			common.AssertEqual(t, tc.expFetches, fetches, "unexpected number of fetches")
+		})
+	}
+}
@@ -0,0 +1,268 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	dashboardPath = apiPrefix + "/dashboard"
+
+	defaultEventLimit = 20
+	maxEventLimit     = 100
+
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+)
+
+type (
+	// dashboardHealth summarizes the state of the system members and the
+	// Management Service replicas.
+	dashboardHealth struct {
+		Status   string                     `json:"status"`
+		Leader   string                     `json:"leader"`
+		Replicas []*control.MSReplicaStatus `json:"replicas"`
+		Members  int                        `json:"members"`
+		States   map[string]int             `json:"member_states"`
+		Errors   []string                   `json:"errors,omitempty"`
+	}
+
+	// dashboardUsage is the space used in a storage tier.
+	dashboardUsage struct {
+		Total uint64 `json:"total"`
+		Free  uint64 `json:"free"`
+	}
+
+	// dashboardPool summarizes the capacity and state of a pool.
+	dashboardPool struct {
+		UUID            string          `json:"uuid"`
+		SvcReplicas     []uint32        `json:"svc_reps"`
+		TotalTargets    uint32          `json:"total_targets"`
+		DisabledTargets uint32          `json:"disabled_targets"`
+		Rebuilding      bool            `json:"rebuilding"`
+		Scm             *dashboardUsage `json:"scm,omitempty"`
+		Nvme            *dashboardUsage `json:"nvme,omitempty"`
+		Error           string          `json:"error,omitempty"`
+	}
+
+	// dashboardPools lists the pools together with the total capacity of
+	// all pools.
+	dashboardPools struct {
+		Pools []*dashboardPool `json:"pools"`
+		Scm   dashboardUsage   `json:"scm"`
+		Nvme  dashboardUsage   `json:"nvme"`
+	}
+
+	// dashboardDevice summarizes the state of an NVMe SSD.
+	dashboardDevice struct {
+		Hosts      string      `json:"hosts"`
+		Rank       system.Rank `json:"rank"`
+		UUID       string      `json:"uuid"`
+		TrAddr     string      `json:"tr_addr"`
+		State      string      `json:"state"`
+		TotalBytes uint64      `json:"total_bytes"`
+		AvailBytes uint64      `json:"avail_bytes"`
+	}
+
+	// dashboardDevices lists the devices of all hosts together with the
+	// number of devices in each state.
+	dashboardDevices struct {
+		Devices []*dashboardDevice `json:"devices"`
+		States  map[string]int     `json:"device_states"`
+		Errors  []string           `json:"errors,omitempty"`
+	}
+
+	// dashboardEvents lists the most recent administrative operations.
+	dashboardEvents struct {
+		Events []*control.OperationRecord `json:"events"`
+	}
+)
+
+// serveCached writes the cached response for the key, fetching it if not
+// cached. The Age header is set to the number of seconds since the response
+// was fetched.
+func (gw *gateway) serveCached(w http.ResponseWriter, r *http.Request, key string, fetch fetchFn) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	resp, fetched, err := gw.cache.get(r.Context(), key, fetch)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	w.Header().Set("Age", strconv.Itoa(int(time.Since(fetched).Seconds())))
+	writeResponse(w, http.StatusOK, resp, nil)
+}
+
+func (gw *gateway) dashboardHealth(w http.ResponseWriter, r *http.Request) {
+	gw.serveCached(w, r, "health", gw.fetchHealth)
+}
+
+func (gw *gateway) fetchHealth(ctx context.Context) (interface{}, error) {
+	sqResp, err := control.SystemQuery(ctx, gw.invoker, new(control.SystemQueryReq))
+	if err != nil {
+		return nil, err
+	}
+
+	health := &dashboardHealth{
+		Status:  healthOK,
+		Members: len(sqResp.Members),
+		States:  make(map[string]int),
+	}
+	for _, m := range sqResp.Members {
+		health.States[m.State().String()]++
+		if m.State() != system.MemberStateJoined {
+			health.Status = healthDegraded
+		}
+	}
+
+	msResp, err := control.MSHealthQuery(ctx, gw.invoker, new(control.MSHealthQueryReq))
+	if err != nil {
+		return nil, err
+	}
+	health.Leader = msResp.Leader
+	health.Replicas = msResp.Replicas
+	for _, hes := range msResp.HostErrors {
+		health.Errors = append(health.Errors,
+			fmt.Sprintf("%s: %s", hes.HostSet, hes.HostError))
+	}
+	if len(health.Errors) > 0 {
+		sort.Strings(health.Errors)
+		health.Status = healthDegraded
+	}
+
+	return health, nil
+}
+
+func (gw *gateway) dashboardPools(w http.ResponseWriter, r *http.Request) {
+	gw.serveCached(w, r, "pools", gw.fetchPools)
+}
+
+func (gw *gateway) fetchPools(ctx context.Context) (interface{}, error) {
+	lpResp, err := control.ListPools(ctx, gw.invoker, new(control.ListPoolsReq))
+	if err != nil {
+		return nil, err
+	}
+
+	pools := &dashboardPools{Pools: make([]*dashboardPool, 0, len(lpResp.Pools))}
+	for _, p := range lpResp.Pools {
+		dp := &dashboardPool{
+			UUID:        p.UUID,
+			SvcReplicas: p.SvcReplicas,
+		}
+		pools.Pools = append(pools.Pools, dp)
+
+		pqResp, err := control.PoolQuery(ctx, gw.invoker, &control.PoolQueryReq{UUID: p.UUID})
+		if err != nil {
+			if control.IsConnectionError(err) {
+				return nil, err
+			}
+			dp.Error = err.Error()
+			continue
+		}
+
+		dp.TotalTargets = pqResp.TotalTargets
+		dp.DisabledTargets = pqResp.DisabledTargets
+		if pqResp.Rebuild != nil {
+			dp.Rebuilding = pqResp.Rebuild.State == control.PoolRebuildStateBusy
+		}
+		if pqResp.Scm != nil {
+			dp.Scm = &dashboardUsage{Total: pqResp.Scm.Total, Free: pqResp.Scm.Free}
+			pools.Scm.Total += pqResp.Scm.Total
+			pools.Scm.Free += pqResp.Scm.Free
+		}
+		if pqResp.Nvme != nil {
+			dp.Nvme = &dashboardUsage{Total: pqResp.Nvme.Total, Free: pqResp.Nvme.Free}
+			pools.Nvme.Total += pqResp.Nvme.Total
+			pools.Nvme.Free += pqResp.Nvme.Free
+		}
+	}
+
+	return pools, nil
+}
+
+func (gw *gateway) dashboardDevices(w http.ResponseWriter, r *http.Request) {
+	gw.serveCached(w, r, "devices", gw.fetchDevices)
+}
+
+func (gw *gateway) fetchDevices(ctx context.Context) (interface{}, error) {
+	resp, err := control.SmdQuery(ctx, gw.invoker, &control.SmdQueryReq{OmitPools: true})
+	if err != nil {
+		return nil, err
+	}
+
+	devices := &dashboardDevices{
+		Devices: []*dashboardDevice{},
+		States:  make(map[string]int),
+	}
+	for _, hss := range resp.HostStorage {
+		if hss.HostStorage.SmdInfo == nil {
+			continue
+		}
+		for _, dev := range hss.HostStorage.SmdInfo.Devices {
+			devices.Devices = append(devices.Devices, &dashboardDevice{
+				Hosts:      hss.HostSet.String(),
+				Rank:       dev.Rank,
+				UUID:       dev.UUID,
+				TrAddr:     dev.TrAddr,
+				State:      dev.State,
+				TotalBytes: dev.TotalBytes,
+				AvailBytes: dev.AvailBytes,
+			})
+			devices.States[dev.State]++
+		}
+	}
+	sort.Slice(devices.Devices, func(i, j int) bool {
+		di, dj := devices.Devices[i], devices.Devices[j]
+		if di.Rank != dj.Rank {
+			return di.Rank < dj.Rank
+		}
+		return di.UUID < dj.UUID
+	})
+	for _, hes := range resp.HostErrors {
+		devices.Errors = append(devices.Errors,
+			fmt.Sprintf("%s: %s", hes.HostSet, hes.HostError))
+	}
+	sort.Strings(devices.Errors)
+
+	return devices, nil
+}
+
+func (gw *gateway) dashboardEvents(w http.ResponseWriter, r *http.Request) {
+	limit := defaultEventLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > maxEventLimit {
+			writeError(w, http.StatusBadRequest,
+				errors.Errorf("invalid limit %q: must be between 1 and %d", l, maxEventLimit))
+			return
+		}
+		limit = n
+	}
+
+	gw.serveCached(w, r, fmt.Sprintf("events:%d", limit), func(ctx context.Context) (interface{}, error) {
+		resp, err := control.SystemHistory(ctx, gw.invoker, &control.SystemHistoryReq{
+			Limit: limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &dashboardEvents{Events: resp.Operations}, nil
+	})
+}
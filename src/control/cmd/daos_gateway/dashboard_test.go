@@ -0,0 +1,129 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestGateway_fetchPools(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	mi := control.NewMockInvoker(log, &control.MockInvokerConfig{
+		UnaryResponseSet: []*control.UnaryResponse{
+			control.MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.ListPoolsResp{
+				Pools: []*mgmtpb.ListPoolsResp_Pool{
+					{Uuid: common.MockUUID(1), SvcReps: []uint32{0}},
+					{Uuid: common.MockUUID(2), SvcReps: []uint32{1}},
+				},
+			}),
+			control.MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.PoolQueryResp{
+				Uuid:            common.MockUUID(1),
+				TotalTargets:    16,
+				DisabledTargets: 2,
+				Rebuild: &mgmtpb.PoolRebuildStatus{
+					State: mgmtpb.PoolRebuildStatus_BUSY,
+				},
+				Scm:  &mgmtpb.StorageUsageStats{Total: 100, Free: 40},
+				Nvme: &mgmtpb.StorageUsageStats{Total: 1000, Free: 600},
+			}),
+			control.MockMSResponse("10.0.0.1:10001", drpc.DaosNonexistant, nil),
+		},
+	})
+	gw := &gateway{log: log, invoker: mi}
+
+	gotResp, err := gw.fetchPools(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expResp := &dashboardPools{
+		Pools: []*dashboardPool{
+			{
+				UUID:            common.MockUUID(1),
+				SvcReplicas:     []uint32{0},
+				TotalTargets:    16,
+				DisabledTargets: 2,
+				Rebuilding:      true,
+				Scm:             &dashboardUsage{Total: 100, Free: 40},
+				Nvme:            &dashboardUsage{Total: 1000, Free: 600},
+			},
+			{
+				UUID:        common.MockUUID(2),
+				SvcReplicas: []uint32{1},
+				Error:       drpc.DaosNonexistant.Error(),
+			},
+		},
+		Scm:  dashboardUsage{Total: 100, Free: 40},
+		Nvme: dashboardUsage{Total: 1000, Free: 600},
+	}
+	if diff := cmp.Diff(expResp, gotResp); diff != "" {
+		t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestGateway_dashboardEvents(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path     string
+		requests int
+		expCode  int
+		expAge   bool
+	}{
+		"limit too large": {
+			path:     "/v1/dashboard/events?limit=1000",
+			requests: 1,
+			expCode:  http.StatusBadRequest,
+		},
+		"cached": {
+			path:     "/v1/dashboard/events?limit=5",
+			requests: 3,
+			expCode:  http.StatusOK,
+			expAge:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			// Only the first request is answered, subsequent requests
+			// must be served from the cache.
+			mi := control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponseSet: []*control.UnaryResponse{
+					control.MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemHistoryResp{}),
+					control.MockMSResponse("10.0.0.1:10001", drpc.DaosMiscError, nil),
+				},
+			})
+			gw := &gateway{log: log, invoker: mi, cache: newRespCache(time.Minute)}
+			handler := gw.handler(tokenSet{[]byte("secret")})
+
+			for i := 0; i < tc.requests; i++ {
+				req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+				req.Header.Set("Authorization", bearerPrefix+"secret")
+				rec := httptest.NewRecorder()
+
+				handler.ServeHTTP(rec, req)
+
+				common.AssertEqual(t, tc.expCode, rec.Code, "unexpected status code")
+				common.AssertEqual(t, tc.expAge, rec.Header().Get("Age") != "",
+					"unexpected Age header")
+			}
+		})
+	}
+}
@@ -0,0 +1,542 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	apiPrefix   = "/v1"
+	systemPath  = apiPrefix + "/system"
+	poolsPath   = apiPrefix + "/pools"
+	versionPath = apiPrefix + "/version"
+
+	restoreSuffix = "/restore"
+
+	// defaultScmRatio is the percentage of the total pool size allocated
+	// to SCM if not specified, as with dmg pool create.
+	defaultScmRatio = 6
+	maxBodySize     = 1 << 20
+)
+
+type (
+	// gatewayResp is the envelope of every gateway response, matching the
+	// JSON output of dmg commands.
+	gatewayResp struct {
+		Response interface{} `json:"response"`
+		Error    *string     `json:"error"`
+		Status   int         `json:"status"`
+	}
+
+	// rankListBody selects the system members a request applies to.
+	rankListBody struct {
+		Ranks string `json:"ranks"`
+		Hosts string `json:"rank_hosts"`
+	}
+
+	// systemStopBody contains the parameters of a system stop request.
+	systemStopBody struct {
+		rankListBody
+		Force bool `json:"force"`
+	}
+
+	// poolCreateBody contains the parameters of a pool create request,
+	// sizes are given in human-readable form as with dmg pool create.
+	poolCreateBody struct {
+		Label      string  `json:"label"`
+		User       string  `json:"user"`
+		Group      string  `json:"group"`
+		Size       string  `json:"size"`
+		ScmRatio   float64 `json:"scm_ratio"`
+		NumRanks   uint32  `json:"nranks"`
+		MinDomains uint32  `json:"min_domains"`
+		NumSvcReps uint32  `json:"nsvc"`
+		ScmSize    string  `json:"scm_size"`
+		NVMeSize   string  `json:"nvme_size"`
+		Ranks      string  `json:"ranks"`
+	}
+)
+
+// gateway translates HTTP+JSON requests into control API calls.
+type gateway struct {
+	log     logging.Logger
+	invoker control.UnaryInvoker
+	version string
+	cache   *respCache
+}
+
+// writeResponse writes the response or error in the gateway envelope with the
+// given HTTP status code. The envelope status is the DAOS status of the error.
+func writeResponse(w http.ResponseWriter, code int, resp interface{}, cmdErr error) {
+	gr := gatewayResp{Response: resp}
+	if cmdErr != nil {
+		errStr := cmdErr.Error()
+		gr.Error = &errStr
+		gr.Status = int(drpc.DaosMiscError)
+		if s, ok := errors.Cause(cmdErr).(drpc.DaosStatus); ok {
+			gr.Status = int(s)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(gr)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeResponse(w, code, nil, err)
+}
+
+// controlErrorCode returns the HTTP status code for an error returned by the
+// control API.
+func controlErrorCode(err error) int {
+	switch {
+	case control.IsConnectionError(err):
+		return http.StatusBadGateway
+	case system.IsUnavailable(err):
+		return http.StatusServiceUnavailable
+	}
+
+	switch errors.Cause(err) {
+	case drpc.DaosNonexistant:
+		return http.StatusNotFound
+	case drpc.DaosInvalidInput:
+		return http.StatusBadRequest
+	case drpc.DaosNoPermission:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeResult writes the result of a control API call, which may return a
+// response describing partial failures together with an error.
+func writeResult(w http.ResponseWriter, resp interface{}, err error) {
+	code := http.StatusOK
+	if err != nil {
+		code = controlErrorCode(err)
+	}
+	writeResponse(w, code, resp, err)
+}
+
+// decodeBody decodes the optional JSON request body, rejecting unknown fields.
+func decodeBody(r *http.Request, body interface{}) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(body); err != nil && err != io.EOF {
+		return errors.Wrap(err, "invalid request body")
+	}
+	return nil
+}
+
+// allowMethods returns false and writes an error response if the request
+// method is not one of those allowed.
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, m := range methods {
+		if r.Method == m {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+	return false
+}
+
+func (rl *rankListBody) sets() (*hostlist.HostSet, *system.RankSet, error) {
+	hostSet, err := hostlist.CreateSet(rl.Hosts)
+	if err != nil {
+		return nil, nil, err
+	}
+	rankSet, err := system.CreateRankSet(rl.Ranks)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hostSet.Count() > 0 && rankSet.Count() > 0 {
+		return nil, nil, errors.New("ranks and rank_hosts cannot be set together")
+	}
+
+	return hostSet, rankSet, nil
+}
+
+// handler returns the handler for all gateway endpoints, each of which
+// requires a valid API token.
+func (gw *gateway) handler(tokens tokenSet) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(versionPath, gw.versionInfo)
+	mux.HandleFunc(systemPath, gw.systemQuery)
+	mux.HandleFunc(systemPath+"/leader", gw.leaderQuery)
+	mux.HandleFunc(systemPath+"/start", gw.systemStart)
+	mux.HandleFunc(systemPath+"/stop", gw.systemStop)
+	mux.HandleFunc(systemPath+"/history", gw.systemHistory)
+	mux.HandleFunc(poolsPath, gw.pools)
+	mux.HandleFunc(poolsPath+"/", gw.pool)
+	mux.HandleFunc(dashboardPath+"/health", gw.dashboardHealth)
+	mux.HandleFunc(dashboardPath+"/pools", gw.dashboardPools)
+	mux.HandleFunc(dashboardPath+"/devices", gw.dashboardDevices)
+	mux.HandleFunc(dashboardPath+"/events", gw.dashboardEvents)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, errors.Errorf("unknown endpoint %s", r.URL.Path))
+	})
+
+	return tokens.requireToken(gw.logRequests(mux))
+}
+
+// logRequests logs each request together with the resulting status code.
+func (gw *gateway) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(sw, r)
+		gw.log.Debugf("%s %s %s: %d (%s)", r.RemoteAddr, r.Method, r.URL, sw.code,
+			time.Since(start))
+	})
+}
+
+// statusWriter records the status code written in a response.
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (sw *statusWriter) WriteHeader(code int) {
+	sw.code = code
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+func (gw *gateway) versionInfo(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+	writeResponse(w, http.StatusOK, map[string]string{"version": gw.version}, nil)
+}
+
+func (gw *gateway) systemQuery(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	rl := rankListBody{
+		Ranks: r.URL.Query().Get("ranks"),
+		Hosts: r.URL.Query().Get("rank_hosts"),
+	}
+	hostSet, rankSet, err := rl.sets()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := new(control.SystemQueryReq)
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemQuery(r.Context(), gw.invoker, req)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeResult(w, resp, resp.Errors())
+}
+
+func (gw *gateway) leaderQuery(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	resp, err := control.LeaderQuery(r.Context(), gw.invoker, new(control.LeaderQueryReq))
+	writeResult(w, resp, err)
+}
+
+func (gw *gateway) systemStart(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodPost) {
+		return
+	}
+
+	var body rankListBody
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	hostSet, rankSet, err := body.sets()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := new(control.SystemStartReq)
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemStart(r.Context(), gw.invoker, req)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeResult(w, resp, resp.Errors())
+}
+
+func (gw *gateway) systemStop(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodPost) {
+		return
+	}
+
+	var body systemStopBody
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	hostSet, rankSet, err := body.sets()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	req := &control.SystemStopReq{Force: body.Force}
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemStop(r.Context(), gw.invoker, req)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeResult(w, resp, resp.Errors())
+}
+
+func (gw *gateway) systemHistory(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet) {
+		return
+	}
+
+	q := r.URL.Query()
+	req := &control.SystemHistoryReq{
+		Operation: q.Get("operation"),
+		Initiator: q.Get("initiator"),
+	}
+	if since := q.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid since"))
+			return
+		}
+		req.Since = t
+	}
+	if failed := q.Get("failed"); failed != "" {
+		b, err := strconv.ParseBool(failed)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid failed"))
+			return
+		}
+		req.FailedOnly = b
+	}
+	if limit := q.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid limit"))
+			return
+		}
+		req.Limit = n
+	}
+
+	resp, err := control.SystemHistory(r.Context(), gw.invoker, req)
+	writeResult(w, resp, err)
+}
+
+func (gw *gateway) pools(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		resp, err := control.ListPools(r.Context(), gw.invoker, new(control.ListPoolsReq))
+		writeResult(w, resp, err)
+		return
+	}
+
+	var body poolCreateBody
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req, err := body.request()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	resp, err := control.PoolCreate(r.Context(), gw.invoker, req)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+	writeResponse(w, http.StatusCreated, resp, nil)
+}
+
+// request converts the body into a pool create request, validating the
+// parameters as dmg pool create does.
+func (body *poolCreateBody) request() (*control.PoolCreateReq, error) {
+	switch {
+	case body.Size != "" && (body.ScmSize != "" || body.NVMeSize != ""):
+		return nil, errors.New("size cannot be set with scm_size or nvme_size")
+	case body.Size == "" && body.ScmSize == "":
+		return nil, errors.New("either size or scm_size must be set")
+	}
+
+	req := &control.PoolCreateReq{
+		User:       body.User,
+		UserGroup:  body.Group,
+		Label:      body.Label,
+		NumSvcReps: body.NumSvcReps,
+		MinDomains: body.MinDomains,
+	}
+
+	var err error
+	req.Ranks, err = system.ParseRanks(body.Ranks)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing rank list")
+	}
+
+	if body.Size != "" {
+		req.TotalBytes, err = humanize.ParseBytes(body.Size)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse pool size")
+		}
+		if body.NumRanks > 0 && body.Ranks != "" {
+			return nil, errors.New("nranks cannot be set with ranks")
+		}
+		req.NumRanks = body.NumRanks
+
+		ratio := body.ScmRatio
+		if ratio == 0 {
+			ratio = defaultScmRatio
+		}
+		if ratio < 1 || ratio > 100 {
+			return nil, errors.New("SCM:NVMe ratio must be a value between 1-100")
+		}
+		req.ScmRatio = ratio / 100
+
+		return req, nil
+	}
+
+	if body.NumRanks > 0 {
+		return nil, errors.New("nranks cannot be set with scm_size")
+	}
+	req.ScmBytes, err = humanize.ParseBytes(body.ScmSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse pool SCM size")
+	}
+	if body.NVMeSize != "" {
+		req.NvmeBytes, err = humanize.ParseBytes(body.NVMeSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse pool NVMe size")
+		}
+	}
+
+	return req, nil
+}
+
+// resolvePoolID resolves a pool label into a UUID.
+func (gw *gateway) resolvePoolID(r *http.Request, id string) (string, error) {
+	if _, err := uuid.Parse(id); err == nil {
+		return id, nil
+	}
+
+	resp, err := control.PoolResolveID(r.Context(), gw.invoker, &control.PoolResolveIDReq{
+		HumanID: id,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve pool ID into UUID")
+	}
+
+	return resp.UUID, nil
+}
+
+func (gw *gateway) pool(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, poolsPath+"/")
+	if strings.HasSuffix(id, restoreSuffix) {
+		gw.poolRestore(w, r, strings.TrimSuffix(id, restoreSuffix))
+		return
+	}
+
+	if !allowMethods(w, r, http.MethodGet, http.MethodDelete) {
+		return
+	}
+	if id == "" || strings.Contains(id, "/") {
+		writeError(w, http.StatusNotFound, errors.Errorf("unknown endpoint %s", r.URL.Path))
+		return
+	}
+	poolUUID, err := gw.resolvePoolID(r, id)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		resp, err := control.PoolQuery(r.Context(), gw.invoker, &control.PoolQueryReq{
+			UUID: poolUUID,
+		})
+		writeResult(w, resp, err)
+		return
+	}
+
+	var force bool
+	if f := r.URL.Query().Get("force"); f != "" {
+		if force, err = strconv.ParseBool(f); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid force"))
+			return
+		}
+	}
+	var delay time.Duration
+	if d := r.URL.Query().Get("delay"); d != "" {
+		if delay, err = time.ParseDuration(d); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid delay"))
+			return
+		}
+	}
+	err = control.PoolDestroy(r.Context(), gw.invoker, &control.PoolDestroyReq{
+		UUID:  poolUUID,
+		Force: force,
+		Delay: delay,
+	})
+	writeResult(w, nil, err)
+}
+
+func (gw *gateway) poolRestore(w http.ResponseWriter, r *http.Request, id string) {
+	if !allowMethods(w, r, http.MethodPost) {
+		return
+	}
+	if id == "" || strings.Contains(id, "/") {
+		writeError(w, http.StatusNotFound, errors.Errorf("unknown endpoint %s", r.URL.Path))
+		return
+	}
+
+	poolUUID, err := gw.resolvePoolID(r, id)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	err = control.PoolRestore(r.Context(), gw.invoker, &control.PoolRestoreReq{UUID: poolUUID})
+	writeResult(w, nil, err)
+}
@@ -0,0 +1,210 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestGateway_Handler(t *testing.T) {
+	const token = "secret"
+
+	for name, tc := range map[string]struct {
+		method    string
+		path      string
+		body      string
+		msResp    proto.Message
+		msErr     error
+		expCode   int
+		expStatus drpc.DaosStatus
+		expErrStr string
+	}{
+		"unknown endpoint": {
+			method:    http.MethodGet,
+			path:      "/v1/containers",
+			expCode:   http.StatusNotFound,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "unknown endpoint",
+		},
+		"method not allowed": {
+			method:    http.MethodPut,
+			path:      "/v1/system",
+			expCode:   http.StatusMethodNotAllowed,
+			expStatus: drpc.DaosMiscError,
+		},
+		"system query": {
+			method: http.MethodGet,
+			path:   "/v1/system?ranks=0-1",
+			msResp: &mgmtpb.SystemQueryResp{
+				Members: []*mgmtpb.SystemMember{
+					{
+						Rank:  0,
+						Uuid:  common.MockUUID(0),
+						State: system.MemberStateJoined.String(),
+						Addr:  "10.0.0.1:10001",
+					},
+				},
+			},
+			expCode: http.StatusOK,
+		},
+		"system query ranks and hosts": {
+			method:    http.MethodGet,
+			path:      "/v1/system?ranks=0-1&rank_hosts=foo1",
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "cannot be set together",
+		},
+		"system query ms failure": {
+			method:    http.MethodGet,
+			path:      "/v1/system",
+			msErr:     drpc.DaosNoPermission,
+			expCode:   http.StatusForbidden,
+			expStatus: drpc.DaosNoPermission,
+		},
+		"system stop unknown field": {
+			method:    http.MethodPost,
+			path:      "/v1/system/stop",
+			body:      `{"forced": true}`,
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "invalid request body",
+		},
+		"system stop": {
+			method:  http.MethodPost,
+			path:    "/v1/system/stop",
+			body:    `{"ranks": "0", "force": true}`,
+			msResp:  &mgmtpb.SystemStopResp{},
+			expCode: http.StatusOK,
+		},
+		"system history bad since": {
+			method:    http.MethodGet,
+			path:      "/v1/system/history?since=yesterday",
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "invalid since",
+		},
+		"list pools": {
+			method:  http.MethodGet,
+			path:    "/v1/pools",
+			msResp:  &mgmtpb.ListPoolsResp{},
+			expCode: http.StatusOK,
+		},
+		"pool create missing size": {
+			method:    http.MethodPost,
+			path:      "/v1/pools",
+			body:      `{"label": "foo"}`,
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "either size or scm_size",
+		},
+		"pool create size with scm_size": {
+			method:    http.MethodPost,
+			path:      "/v1/pools",
+			body:      `{"size": "1TB", "scm_size": "1GB"}`,
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "cannot be set with",
+		},
+		"pool create": {
+			method: http.MethodPost,
+			path:   "/v1/pools",
+			body:   `{"label": "foo", "size": "1TB"}`,
+			msResp: &mgmtpb.PoolCreateResp{
+				SvcReps:  []uint32{0},
+				TgtRanks: []uint32{0, 1},
+			},
+			expCode: http.StatusCreated,
+		},
+		"pool query nonexistent": {
+			method:    http.MethodGet,
+			path:      "/v1/pools/" + common.MockUUID(),
+			msErr:     drpc.DaosNonexistant,
+			expCode:   http.StatusNotFound,
+			expStatus: drpc.DaosNonexistant,
+		},
+		"pool destroy bad force": {
+			method:    http.MethodDelete,
+			path:      "/v1/pools/" + common.MockUUID() + "?force=maybe",
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "invalid force",
+		},
+		"pool destroy bad delay": {
+			method:    http.MethodDelete,
+			path:      "/v1/pools/" + common.MockUUID() + "?delay=1day",
+			expCode:   http.StatusBadRequest,
+			expStatus: drpc.DaosMiscError,
+			expErrStr: "invalid delay",
+		},
+		"pool restore wrong method": {
+			method:    http.MethodGet,
+			path:      "/v1/pools/" + common.MockUUID() + "/restore",
+			expCode:   http.StatusMethodNotAllowed,
+			expStatus: drpc.DaosMiscError,
+		},
+		"pool restore": {
+			method:  http.MethodPost,
+			path:    "/v1/pools/" + common.MockUUID() + "/restore",
+			msResp:  &mgmtpb.PoolRestoreResp{},
+			expCode: http.StatusOK,
+		},
+		"pool destroy": {
+			method:  http.MethodDelete,
+			path:    "/v1/pools/" + common.MockUUID() + "?force=true",
+			msResp:  &mgmtpb.PoolDestroyResp{},
+			expCode: http.StatusOK,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponse: control.MockMSResponse("10.0.0.1:10001", tc.msErr, tc.msResp),
+			})
+			gw := &gateway{log: log, invoker: mi}
+			handler := gw.handler(tokenSet{[]byte(token)})
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", bearerPrefix+token)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			common.AssertEqual(t, tc.expCode, rec.Code, "unexpected status code")
+			common.AssertEqual(t, "application/json", rec.Header().Get("Content-Type"),
+				"unexpected content type")
+
+			var gotResp gatewayResp
+			if err := json.Unmarshal(rec.Body.Bytes(), &gotResp); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, int(tc.expStatus), gotResp.Status, "unexpected DAOS status")
+			if tc.expErrStr == "" {
+				return
+			}
+			if gotResp.Error == nil {
+				t.Fatalf("expected error containing %q", tc.expErrStr)
+			}
+			common.CmpErr(t, errors.New(tc.expErrStr), errors.New(*gotResp.Error))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"syscall"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	defaultPort     = 8080
+	shutdownTimeout = 10 * time.Second
+)
+
+type cliOptions struct {
+	AllowProxy bool          `long:"allow-proxy" description:"Allow proxy configuration via environment"`
+	Debug      bool          `short:"d" long:"debug" description:"Enable debug output"`
+	JSONLogs   bool          `short:"J" long:"json-logging" description:"Enable JSON-formatted log output"`
+	ConfigPath string        `short:"o" long:"config-path" description:"Control (dmg) config file path"`
+	Insecure   bool          `short:"i" long:"insecure" description:"Connect to the servers without certificates"`
+	Listen     string        `short:"a" long:"address" description:"Address to listen on"`
+	Port       int           `short:"p" long:"port" description:"Port to listen on"`
+	TokenFile  string        `short:"t" long:"token-file" description:"File containing the accepted API tokens, one per line"`
+	TLSCert    string        `long:"tls-cert" description:"Certificate to serve HTTPS with"`
+	TLSKey     string        `long:"tls-key" description:"Private key to serve HTTPS with"`
+	CacheTTL   time.Duration `long:"dashboard-cache-ttl" default:"5s" description:"Period for which dashboard responses are cached, 0 to disable"`
+	Start      startCmd      `command:"start" description:"Start daos_gateway daemon (default behavior)"`
+	Version    versionCmd    `command:"version" description:"Print daos_gateway version"`
+}
+
+func versionString() string {
+	return fmt.Sprintf("%s v%s", build.GatewayName, build.DaosVersion)
+}
+
+type versionCmd struct{}
+
+func (cmd *versionCmd) Execute(_ []string) error {
+	_, err := fmt.Println(versionString())
+	return err
+}
+
+type startCmd struct {
+	log     logging.Logger
+	opts    *cliOptions
+	invoker control.Invoker
+}
+
+// Execute serves the gateway API until the process is signaled to stop.
+func (cmd *startCmd) Execute(_ []string) error {
+	opts := cmd.opts
+	if opts.TokenFile == "" {
+		return errors.New("--token-file must be set")
+	}
+	if (opts.TLSCert == "") != (opts.TLSKey == "") {
+		return errors.New("--tls-cert and --tls-key must be set together")
+	}
+
+	tokens, err := loadTokens(opts.TokenFile)
+	if err != nil {
+		return err
+	}
+
+	port := opts.Port
+	if port == 0 {
+		port = defaultPort
+	}
+
+	gw := &gateway{
+		log:     cmd.log,
+		invoker: cmd.invoker,
+		version: build.DaosVersion,
+		cache:   newRespCache(opts.CacheTTL),
+	}
+	srv := &http.Server{
+		Addr:    net.JoinHostPort(opts.Listen, strconv.Itoa(port)),
+		Handler: gw.handler(tokens),
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		cmd.log.Debugf("caught signal: %s", sig)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			cmd.log.Errorf("gateway shutdown: %s", err)
+		}
+	}()
+
+	cmd.log.Infof("%s (pid %d) listening on %s", versionString(), os.Getpid(), srv.Addr)
+	if opts.TLSCert != "" {
+		err = srv.ListenAndServeTLS(opts.TLSCert, opts.TLSKey)
+	} else {
+		cmd.log.Info("TLS is disabled, API tokens are sent in clear text")
+		err = srv.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
+		return err
+	}
+
+	cmd.log.Infof("%s shutting down", build.GatewayName)
+	return nil
+}
+
+func exitWithError(log logging.Logger, err error) {
+	log.Errorf("%s: %v", path.Base(os.Args[0]), err)
+	os.Exit(1)
+}
+
+func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *logging.LeveledLogger) error {
+	p := flags.NewParser(opts, flags.Default)
+	p.Options ^= flags.PrintErrors // Don't allow the library to print errors
+	p.SubcommandsOptional = true
+
+	p.CommandHandler = func(cmd flags.Commander, args []string) error {
+		if len(args) > 0 {
+			exitWithError(log, errors.Errorf("unknown command %q", args[0]))
+		}
+
+		if cmd == nil {
+			cmd = &opts.Start
+		}
+
+		if opts.Debug {
+			log.WithLogLevel(logging.LogLevelDebug)
+			log.Debug("debug output enabled")
+		}
+
+		if opts.JSONLogs {
+			log.WithJSONOutput()
+		}
+
+		if _, ok := cmd.(*versionCmd); ok {
+			return cmd.Execute(args)
+		}
+
+		if !opts.AllowProxy {
+			common.ScrubProxyVariables()
+		}
+
+		ctlCfg, err := control.LoadConfig(opts.ConfigPath)
+		if err != nil {
+			if opts.ConfigPath != "" {
+				return errors.WithMessage(err, "failed to load control configuration")
+			}
+			ctlCfg = control.DefaultConfig()
+		}
+		if ctlCfg.Path != "" {
+			log.Debugf("control config loaded from %s", ctlCfg.Path)
+		}
+
+		if opts.Insecure {
+			ctlCfg.TransportConfig.AllowInsecure = true
+		}
+		if err := ctlCfg.TransportConfig.PreLoadCertData(); err != nil {
+			return errors.Wrap(err, "Unable to load Certificate Data")
+		}
+		invoker.SetConfig(ctlCfg)
+
+		if start, ok := cmd.(*startCmd); ok {
+			start.log = log
+			start.opts = opts
+			start.invoker = invoker
+		}
+
+		return cmd.Execute(args)
+	}
+
+	_, err := p.ParseArgs(args)
+	return err
+}
+
+func main() {
+	var opts cliOptions
+	log := logging.NewCommandLineLogger()
+
+	ctlInvoker := control.NewClient(
+		control.WithClientLogger(log),
+		control.WithConnectionReuse(),
+	)
+
+	if err := parseOpts(os.Args[1:], &opts, ctlInvoker, log); err != nil {
+		if fe, ok := errors.Cause(err).(*flags.Error); ok && fe.Type == flags.ErrHelp {
+			log.Info(fe.Error())
+			os.Exit(0)
+		}
+		exitWithError(log, err)
+	}
+}
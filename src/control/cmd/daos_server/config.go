@@ -7,9 +7,24 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/server"
 	"github.com/daos-stack/daos/src/control/server/config"
 )
 
+// configDataEnvVar may hold the complete server configuration as a YAML
+// document, allowing the server to be configured without a config file
+// (e.g. from a Kubernetes ConfigMap).
+const configDataEnvVar = "DAOS_SERVER_CONFIG_DATA"
+
 type cfgLoader interface {
 	loadConfig(cfgPath string) error
 	configPath() string
@@ -20,13 +35,17 @@ type cliOverrider interface {
 }
 
 type cfgCmd struct {
-	config *config.Server
+	config  *config.Server
+	fromEnv bool
 }
 
 func (c *cfgCmd) configPath() string {
 	if c.config == nil {
 		return ""
 	}
+	if c.fromEnv {
+		return "$" + configDataEnvVar
+	}
 	return c.config.Path
 }
 
@@ -39,9 +58,155 @@ func (c *cfgCmd) loadConfig(cfgPath string) error {
 	}
 
 	c.config = config.DefaultServer()
+	if data, set := os.LookupEnv(configDataEnvVar); set && cfgPath == "" {
+		c.fromEnv = true
+		return c.config.Parse([]byte(data))
+	}
+
 	if err := c.config.SetPath(cfgPath); err != nil {
 		return err
 	}
 
 	return c.config.Load()
 }
+
+type configCmd struct {
+	Dump configDumpCmd `command:"dump" description:"Dump the loaded server configuration and engine core isolation report as JSON"`
+	Diff configDiffCmd `command:"diff" description:"Compare two server configuration files and report changes requiring storage reformat or restart"`
+}
+
+type coreIsolationGetter func(*config.Server) (*server.CoreIsolationReport, error)
+
+type configDumpCmd struct {
+	logCmd
+	cfgCmd
+	noHelperCmd
+	getCoreIsolation coreIsolationGetter
+	out              io.Writer
+}
+
+// yamlToJSON converts a value decoded from YAML into one that can be encoded
+// as JSON, which requires maps to be keyed by strings.
+func yamlToJSON(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[fmt.Sprint(key)] = yamlToJSON(val)
+		}
+		return out
+	case []interface{}:
+		for i, val := range v {
+			v[i] = yamlToJSON(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (cmd *configDumpCmd) Execute(_ []string) error {
+	if cmd.getCoreIsolation == nil {
+		cmd.getCoreIsolation = server.GetCoreIsolationReport
+	}
+	if cmd.out == nil {
+		cmd.out = os.Stdout
+	}
+
+	data, err := yaml.Marshal(cmd.config)
+	if err != nil {
+		return err
+	}
+	var cfg interface{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	cir, err := cmd.getCoreIsolation(cmd.config)
+	if err != nil {
+		return errors.Wrap(err, "core isolation report")
+	}
+
+	out, err := json.MarshalIndent(struct {
+		Config        interface{}                 `json:"config"`
+		CoreIsolation *server.CoreIsolationReport `json:"core_isolation"`
+	}{
+		Config:        yamlToJSON(cfg),
+		CoreIsolation: cir,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(cmd.out, string(out))
+	return err
+}
+
+type configDiffCmd struct {
+	logCmd
+	noHelperCmd
+	Args struct {
+		Old string `positional-arg-name:"old.yml" required:"1"`
+		New string `positional-arg-name:"new.yml" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func loadConfigFile(path string) (*config.Server, error) {
+	cfg := config.DefaultServer()
+	if err := cfg.SetPath(path); err != nil {
+		return nil, err
+	}
+	if err := cfg.Load(); err != nil {
+		return nil, errors.Wrapf(err, "loading %s", path)
+	}
+	return cfg, nil
+}
+
+func (cmd *configDiffCmd) Execute(_ []string) error {
+	oldCfg, err := loadConfigFile(cmd.Args.Old)
+	if err != nil {
+		return err
+	}
+	newCfg, err := loadConfigFile(cmd.Args.New)
+	if err != nil {
+		return err
+	}
+
+	cd, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if len(cd.Changes) == 0 {
+		fmt.Fprintln(&bld, "No changes")
+		cmd.log.Info(bld.String())
+		return nil
+	}
+
+	section := -2
+	for _, cc := range cd.Changes {
+		if cc.Engine != section {
+			section = cc.Engine
+			if section < 0 {
+				fmt.Fprintln(&bld, "server:")
+			} else {
+				fmt.Fprintf(&bld, "engine %d:\n", section)
+			}
+		}
+		fmt.Fprintf(&bld, "  %s\n", cc)
+	}
+
+	nrEngines := len(newCfg.Engines)
+	if len(oldCfg.Engines) > nrEngines {
+		nrEngines = len(oldCfg.Engines)
+	}
+	if engines := cd.ReformatEngines(nrEngines); len(engines) > 0 {
+		fmt.Fprintf(&bld, "Storage reformat required for engines %v\n", engines)
+	} else {
+		fmt.Fprintln(&bld, "Restart required, no storage reformat needed")
+	}
+	cmd.log.Info(bld.String())
+
+	return nil
+}
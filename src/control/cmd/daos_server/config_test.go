@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestDaosServer_ConfigDump(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cir    *server.CoreIsolationReport
+		cirErr error
+		expErr error
+	}{
+		"report failed": {
+			cirErr: errors.New("no /proc"),
+			expErr: errors.New("no /proc"),
+		},
+		"success": {
+			cir: &server.CoreIsolationReport{
+				IsolCPUs: []int{1, 2},
+				Engines: []*server.EngineCoreIsolation{
+					{ServiceCore: 0, EngineCores: []int{0, 1, 2}, NotIsolated: []int{0}},
+				},
+				Warnings: []string{"engine 0: cores [0] not in kernel isolcpus list"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var out bytes.Buffer
+			cmd := &configDumpCmd{
+				logCmd: logCmd{log: log},
+				cfgCmd: cfgCmd{
+					config: config.DefaultServer().
+						WithSystemName("daos_test").
+						WithEngines(engine.NewConfig().WithTargetCount(2)),
+				},
+				getCoreIsolation: func(*config.Server) (*server.CoreIsolationReport, error) {
+					return tc.cir, tc.cirErr
+				},
+				out: &out,
+			}
+
+			gotErr := cmd.Execute(nil)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var dump struct {
+				Config        map[string]interface{}      `json:"config"`
+				CoreIsolation *server.CoreIsolationReport `json:"core_isolation"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &dump); err != nil {
+				t.Fatalf("invalid JSON output: %s\n%s", err, out.String())
+			}
+
+			common.AssertEqual(t, "daos_test", dump.Config["name"], "unexpected system name")
+			if diff := cmp.Diff(tc.cir, dump.CoreIsolation); diff != "" {
+				t.Fatalf("unexpected core isolation report (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -30,7 +30,7 @@ const (
 type mainOpts struct {
 	AllowProxy bool `long:"allow-proxy" description:"Allow proxy configuration via environment"`
 	// Minimal set of top-level options
-	ConfigPath string `short:"o" long:"config" description:"Server config file path"`
+	ConfigPath string `short:"o" long:"config" env:"DAOS_SERVER_CONFIG" description:"Server config file path"`
 	// TODO(DAOS-3129): This should be -d, but it conflicts with the start
 	// subcommand's -d flag when we default to running it.
 	Debug   bool `short:"b" long:"debug" description:"Enable debug output"`
@@ -39,9 +39,15 @@ type mainOpts struct {
 
 	// Define subcommands
 	Storage storageCmd `command:"storage" description:"Perform tasks related to locally-attached storage"`
+	Scm     scmCmd     `command:"scm" description:"Perform tasks related to locally-attached SCM modules"`
 	Start   startCmd   `command:"start" description:"Start daos_server"`
 	Network networkCmd `command:"network" description:"Perform network device scan based on fabric provider"`
+	Config  configCmd  `command:"config" description:"Perform tasks related to the server configuration"`
 	Version versionCmd `command:"version" description:"Print daos_server version"`
+
+	// checkHelper verifies that the privileged helper is available, it is
+	// skipped for commands running in containerized mode.
+	checkHelper func() error
 }
 
 type versionCmd struct{}
@@ -52,6 +58,33 @@ func (cmd *versionCmd) Execute(_ []string) error {
 	return nil
 }
 
+type containerizedCmd interface {
+	isContainerized() bool
+}
+
+// unprivilegedCmd is implemented by commands that never need the privileged
+// helper, e.g. because they only inspect configuration files.
+type unprivilegedCmd interface {
+	isUnprivileged() bool
+}
+
+type noHelperCmd struct{}
+
+func (noHelperCmd) isUnprivileged() bool {
+	return true
+}
+
+// needsHelper returns true if the privileged helper is required to run cmd.
+func needsHelper(cmd flags.Commander) bool {
+	if cc, ok := cmd.(containerizedCmd); ok && cc.isContainerized() {
+		return false
+	}
+	if uc, ok := cmd.(unprivilegedCmd); ok && uc.isUnprivileged() {
+		return false
+	}
+	return true
+}
+
 type cmdLogger interface {
 	setLog(*logging.LeveledLogger)
 }
@@ -103,7 +136,7 @@ func parseOpts(args []string, opts *mainOpts, log *logging.LeveledLogger) error
 			logCmd.setLog(log)
 		}
 
-		if opts.ConfigPath == "" {
+		if _, set := os.LookupEnv(configDataEnvVar); opts.ConfigPath == "" && !set {
 			defaultConfigPath := path.Join(build.ConfigDir, defaultConfigFile)
 			if _, err := os.Stat(defaultConfigPath); err == nil {
 				opts.ConfigPath = defaultConfigPath
@@ -114,7 +147,9 @@ func parseOpts(args []string, opts *mainOpts, log *logging.LeveledLogger) error
 			if err := cfgCmd.loadConfig(opts.ConfigPath); err != nil {
 				return errors.Wrapf(err, "failed to load config from %s", cfgCmd.configPath())
 			}
-			log.Infof("DAOS Server config loaded from %s", cfgCmd.configPath())
+			if cfgCmd.configPath() != "" {
+				log.Infof("DAOS Server config loaded from %s", cfgCmd.configPath())
+			}
 
 			if ovrCmd, ok := cfgCmd.(cliOverrider); ok {
 				if err := ovrCmd.setCLIOverrides(); err != nil {
@@ -123,6 +158,12 @@ func parseOpts(args []string, opts *mainOpts, log *logging.LeveledLogger) error
 			}
 		}
 
+		if opts.checkHelper != nil && needsHelper(cmd) {
+			if err := opts.checkHelper(); err != nil {
+				return err
+			}
+		}
+
 		if err := cmd.Execute(cmdArgs); err != nil {
 			return err
 		}
@@ -141,11 +182,12 @@ func parseOpts(args []string, opts *mainOpts, log *logging.LeveledLogger) error
 
 func main() {
 	log := logging.NewCommandLineLogger()
-	var opts mainOpts
-
-	// Check this right away to avoid lots of annoying failures later.
-	if err := pbin.CheckHelper(log, pbin.DaosAdminName); err != nil {
-		exitWithError(log, err)
+	opts := mainOpts{
+		// Check this before running any command to avoid lots of
+		// annoying failures later.
+		checkHelper: func() error {
+			return pbin.CheckHelper(log, pbin.DaosAdminName)
+		},
 	}
 
 	if err := parseOpts(os.Args[1:], &opts, log); err != nil {
@@ -60,9 +60,12 @@ func (cmd *networkScanCmd) Execute(args []string) error {
 	hf := &control.HostFabric{}
 	for _, fi := range results {
 		hf.AddInterface(&control.HostFabricInterface{
-			Provider: fi.Provider,
-			Device:   fi.DeviceName,
-			NumaNode: uint32(fi.NUMANode),
+			Provider:  fi.Provider,
+			Device:    fi.DeviceName,
+			NumaNode:  uint32(fi.NUMANode),
+			PhysFn:    fi.PhysFn,
+			LinkState: fi.LinkState,
+			LinkSpeed: fi.LinkSpeed,
 		})
 	}
 
@@ -0,0 +1,48 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+type scmCmd struct {
+	Verify scmVerifyCmd `command:"verify" description:"Verify that SCM regions match the goal requested by storage prepare, run after the reboot that applies the goal."`
+}
+
+type scmVerifyCmd struct {
+	scp *scm.Provider
+	logCmd
+}
+
+func (cmd *scmVerifyCmd) Execute(args []string) error {
+	if cmd.scp == nil {
+		cmd.scp = scm.DefaultProvider(cmd.log)
+	}
+
+	cmd.log.Info("Verifying locally-attached SCM regions...")
+
+	resp, err := cmd.scp.VerifyRegions(scm.VerifyRequest{})
+	if err != nil {
+		return err
+	}
+	if len(resp.Regions) == 0 {
+		// no modules detected, nothing to verify
+		return nil
+	}
+
+	for _, region := range resp.Regions {
+		cmd.log.Infof("socket %d: %s region %s, capacity %s (%s free)", region.SocketID,
+			region.PersistentMemoryType, region.ID, humanize.IBytes(region.Capacity),
+			humanize.IBytes(region.FreeCapacity))
+	}
+	cmd.log.Info("SCM regions match the requested AppDirect interleaved goal")
+
+	return nil
+}
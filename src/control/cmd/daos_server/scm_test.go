@@ -0,0 +1,81 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+func TestDaosServer_ScmVerify(t *testing.T) {
+	twoSockets := storage.ScmModules{
+		{SocketID: 0, UID: "mod0"},
+		{SocketID: 1, UID: "mod1"},
+	}
+
+	for name, tc := range map[string]struct {
+		smbc      *scm.MockBackendConfig
+		expLogMsg string
+		expErr    error
+	}{
+		"no modules": {
+			expLogMsg: "no modules detected",
+		},
+		"regions match goal": {
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: twoSockets,
+			},
+			expLogMsg: "match the requested AppDirect interleaved goal",
+		},
+		"mismatched sockets": {
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: twoSockets,
+				GetPmemRegionsRes: storage.ScmRegions{
+					{SocketID: 0, PersistentMemoryType: "AppDirectNotInterleaved"},
+				},
+			},
+			expErr: scm.FaultRegionsGoalMismatch([]string{
+				"socket 0 has AppDirectNotInterleaved region, expected AppDirect",
+				"socket 1 has no region",
+			}),
+		},
+		"get regions fails": {
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes:       twoSockets,
+				GetPmemRegionsErr: errors.New("ipmctl failed"),
+			},
+			expErr: errors.New("ipmctl failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cmd := &scmVerifyCmd{
+				logCmd: logCmd{log: log},
+				scp:    scm.NewMockProvider(log, tc.smbc, nil),
+			}
+
+			gotErr := cmd.Execute(nil)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if !strings.Contains(buf.String(), tc.expLogMsg) {
+				t.Fatalf("expected to see %q in log, got %q", tc.expLogMsg, buf.String())
+			}
+		})
+	}
+}
@@ -33,6 +33,12 @@ type startCmd struct {
 	SocketDir           string  `short:"d" long:"socket_dir" description:"Location for all daos_server & daos_engine sockets"`
 	Insecure            bool    `short:"i" long:"insecure" description:"allow for insecure connections"`
 	RecreateSuperblocks bool    `long:"recreate-superblocks" description:"recreate missing superblocks rather than failing"`
+	Containerized       bool    `long:"containerized" env:"DAOS_SERVER_CONTAINERIZED" description:"run without the privileged helper, NVMe devices must be pre-bound and hugepages mounted"`
+	NoFix               bool    `long:"no-fix" description:"report ownership, permission and SELinux label problems with server paths without repairing them"`
+}
+
+func (cmd *startCmd) isContainerized() bool {
+	return cmd.Containerized || (cmd.config != nil && cmd.config.Containerized)
 }
 
 func (cmd *startCmd) setCLIOverrides() error {
@@ -56,6 +62,12 @@ func (cmd *startCmd) setCLIOverrides() error {
 		cmd.config.WithModules(*cmd.Modules)
 	}
 	cmd.config.RecreateSuperblocks = cmd.RecreateSuperblocks
+	if cmd.Containerized {
+		cmd.config.WithContainerized(true)
+	}
+	if cmd.NoFix {
+		cmd.config.WithNoPathFixup(true)
+	}
 
 	host, err := os.Hostname()
 	if err != nil {
@@ -102,12 +114,6 @@ func (cmd *startCmd) configureLogging() error {
 		return err
 	}
 
-	for i, srv := range cmd.config.Engines {
-		if srv.LogFile == "" {
-			cmd.log.Errorf("no daos log file specified for server %d", i)
-		}
-	}
-
 	// Set log file for default logger if specified in config.
 	if cmd.config.ControlLogFile != "" {
 		f, err := common.AppendFile(cmd.config.ControlLogFile)
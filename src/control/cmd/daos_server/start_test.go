@@ -237,6 +237,18 @@ func TestStartOptions(t *testing.T) {
 				return cfg.WithTransportConfig(insecureTransport)
 			},
 		},
+		"Containerized": {
+			argList: []string{"--containerized"},
+			expCfgFn: func(cfg *config.Server) *config.Server {
+				return cfg.WithContainerized(true)
+			},
+		},
+		"No path fixup": {
+			argList: []string{"--no-fix"},
+			expCfgFn: func(cfg *config.Server) *config.Server {
+				return cfg.WithNoPathFixup(true)
+			},
+		},
 	} {
 		t.Run(desc, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -407,3 +419,74 @@ func TestStartLoggingConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestStartContainerized(t *testing.T) {
+	minimalYaml := "name: foo\nport: 10042\nprovider: ofi+sockets\n"
+
+	for name, tc := range map[string]struct {
+		env              map[string]string
+		argList          []string
+		expContainerized bool
+		expHelperChecked bool
+		expSystemName    string
+	}{
+		"not containerized": {
+			env:              map[string]string{configDataEnvVar: minimalYaml},
+			expHelperChecked: true,
+			expSystemName:    "foo",
+		},
+		"containerized flag": {
+			env:              map[string]string{configDataEnvVar: minimalYaml},
+			argList:          []string{"--containerized"},
+			expContainerized: true,
+			expSystemName:    "foo",
+		},
+		"containerized env": {
+			env: map[string]string{
+				configDataEnvVar:            minimalYaml,
+				"DAOS_SERVER_CONTAINERIZED": "true",
+			},
+			expContainerized: true,
+			expSystemName:    "foo",
+		},
+		"containerized config": {
+			env: map[string]string{
+				configDataEnvVar: minimalYaml + "containerized: true\n",
+			},
+			expContainerized: true,
+			expSystemName:    "foo",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			for k, v := range tc.env {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			var gotConfig *config.Server
+			var helperChecked bool
+			opts := mainOpts{
+				checkHelper: func() error {
+					helperChecked = true
+					return nil
+				},
+			}
+			opts.Start.start = func(log *logging.LeveledLogger, cfg *config.Server) error {
+				gotConfig = cfg
+				return nil
+			}
+
+			if err := parseOpts(append([]string{"start"}, tc.argList...), &opts, log); err != nil {
+				t.Fatal(err)
+			}
+
+			common.AssertEqual(t, tc.expHelperChecked, helperChecked, "helper check")
+			common.AssertEqual(t, tc.expContainerized, gotConfig.Containerized, "containerized")
+			common.AssertEqual(t, tc.expSystemName, gotConfig.SystemName, "system name")
+			common.AssertEqual(t, "$"+configDataEnvVar, opts.Start.configPath(), "config path")
+		})
+	}
+}
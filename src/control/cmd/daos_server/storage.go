@@ -8,6 +8,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"os/user"
 	"strings"
 
@@ -29,8 +30,24 @@ type storageCmd struct {
 
 type storagePrepareCmd struct {
 	scs *server.StorageControlService
+	cfgCmd
 	logCmd
 	commands.StoragePrepareCmd
+	CompactHugePages bool `long:"compact-hugepages" description:"Drop caches and compact memory before allocating hugepages, use when free memory is too fragmented for hugepage allocation."`
+	SetupPermissions bool `long:"setup-permissions" description:"Grant the target user the vfio group membership, hugetlbfs access and memlock limit required to run SPDK as a non-root user."`
+}
+
+// loadConfig loads the server config so that the state files set in it are
+// used. Storage may be prepared before the server has been configured, so the
+// defaults are used if no config file is found.
+func (cmd *storagePrepareCmd) loadConfig(cfgPath string) error {
+	err := cmd.cfgCmd.loadConfig(cfgPath)
+	if cfgPath == "" && os.IsNotExist(errors.Cause(err)) {
+		cmd.config = config.DefaultServer()
+		cmd.config.Path = ""
+		return nil
+	}
+	return err
 }
 
 func (cmd *storagePrepareCmd) Execute(args []string) error {
@@ -38,14 +55,26 @@ func (cmd *storagePrepareCmd) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
+	if cmd.CompactHugePages && (!prepNvme || cmd.Reset) {
+		return errors.New("--compact-hugepages requires an NVMe prepare and may not be used with --scm-only or --reset")
+	}
+	if cmd.SetupPermissions && (!prepNvme || cmd.Reset) {
+		return errors.New("--setup-permissions requires an NVMe prepare and may not be used with --scm-only or --reset")
+	}
 
 	// This is a little ugly, but allows for easier unit testing.
 	// FIXME: With the benefit of hindsight, it seems apparent
 	// that we should have made these Execute() methods thin
 	// wrappers around more easily-testable functions.
 	if cmd.scs == nil {
+		cfg := cmd.config
+		if cfg == nil {
+			cfg = config.DefaultServer()
+		}
 		cmd.scs = server.NewStorageControlService(cmd.log, bdev.DefaultProvider(cmd.log),
-			scm.DefaultProvider(cmd.log), config.DefaultServer().Engines)
+			scm.DefaultProvider(cmd.log), cfg.Engines).
+			WithPrepareStateFile(cfg.PrepareStateFile).
+			WithDeviceLedgerFile(cfg.DeviceLedgerFile)
 	}
 
 	op := "Preparing"
@@ -67,13 +96,23 @@ func (cmd *storagePrepareCmd) Execute(args []string) error {
 		}
 
 		// Prepare NVMe access through SPDK
-		if _, err := cmd.scs.NvmePrepare(bdev.PrepareRequest{
-			HugePageCount: cmd.NrHugepages,
-			TargetUser:    cmd.TargetUser,
-			PCIAllowlist:  cmd.PCIAllowList,
-			ResetOnly:     cmd.Reset,
-		}); err != nil {
+		resp, err := cmd.scs.NvmePrepare(bdev.PrepareRequest{
+			HugePageCount:    cmd.NrHugepages,
+			CompactHugePages: cmd.CompactHugePages,
+			TargetUser:       cmd.TargetUser,
+			PCIAllowlist:     cmd.PCIAllowList,
+			ResetOnly:        cmd.Reset,
+			SetupPermissions: cmd.SetupPermissions,
+		})
+		if err != nil {
 			scanErrors = append(scanErrors, err)
+		} else if cmd.SetupPermissions {
+			if len(resp.PermissionChanges) == 0 {
+				cmd.log.Infof("permissions for user %s already set up", cmd.TargetUser)
+			}
+			for _, change := range resp.PermissionChanges {
+				cmd.log.Info(change)
+			}
 		}
 	}
 
@@ -130,7 +169,7 @@ func (cmd *storageScanCmd) Execute(args []string) error {
 	var bld strings.Builder
 	scanErrors := make([]error, 0, 2)
 
-	nvmeResp, err := svc.NvmeScan(bdev.ScanRequest{})
+	nvmeResp, err := svc.NvmeScan(bdev.ScanRequest{KernelBound: true})
 	if err != nil {
 		scanErrors = append(scanErrors, err)
 	} else {
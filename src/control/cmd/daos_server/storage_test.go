@@ -6,6 +6,9 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -16,6 +19,7 @@ import (
 	commands "github.com/daos-stack/daos/src/control/common/storage"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server"
+	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/server/storage/bdev"
 	"github.com/daos-stack/daos/src/control/server/storage/scm"
@@ -34,6 +38,8 @@ func TestDaosServer_StoragePrepare(t *testing.T) {
 		scmOnly   bool
 		reset     bool
 		force     bool
+		compact   bool
+		setupPerm bool
 		bmbc      *bdev.MockBackendConfig
 		smbc      *scm.MockBackendConfig
 		expLogMsg string
@@ -51,6 +57,45 @@ func TestDaosServer_StoragePrepare(t *testing.T) {
 			scmOnly:  true,
 			expErr:   errors.New("should not be set"),
 		},
+		"nvme-only compact hugepages; success": {
+			nvmeOnly: true,
+			compact:  true,
+		},
+		"scm-only compact hugepages should fail": {
+			scmOnly: true,
+			compact: true,
+			expErr:  errors.New("requires an NVMe prepare"),
+		},
+		"reset compact hugepages should fail": {
+			reset:   true,
+			compact: true,
+			expErr:  errors.New("requires an NVMe prepare"),
+		},
+		"nvme-only setup permissions; changes reported": {
+			nvmeOnly:  true,
+			setupPerm: true,
+			bmbc: &bdev.MockBackendConfig{
+				PrepareResp: &bdev.PrepareResponse{
+					PermissionChanges: []string{"created group vfio"},
+				},
+			},
+			expLogMsg: "created group vfio",
+		},
+		"nvme-only setup permissions; no changes": {
+			nvmeOnly:  true,
+			setupPerm: true,
+			expLogMsg: "already set up",
+		},
+		"scm-only setup permissions should fail": {
+			scmOnly:   true,
+			setupPerm: true,
+			expErr:    errors.New("requires an NVMe prepare"),
+		},
+		"reset setup permissions should fail": {
+			reset:     true,
+			setupPerm: true,
+			expErr:    errors.New("requires an NVMe prepare"),
+		},
 		"prepared scm; success": {
 			smbc: &scm.MockBackendConfig{
 				DiscoverRes:         storage.ScmModules{storage.MockScmModule()},
@@ -125,6 +170,8 @@ func TestDaosServer_StoragePrepare(t *testing.T) {
 					Reset:    tc.reset,
 					Force:    tc.force,
 				},
+				CompactHugePages: tc.compact,
+				SetupPermissions: tc.setupPerm,
 				logCmd: logCmd{
 					log: log,
 				},
@@ -146,3 +193,46 @@ func TestDaosServer_StoragePrepare(t *testing.T) {
 		})
 	}
 }
+
+func TestDaosServer_StoragePrepare_loadConfig(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	cfgPath := filepath.Join(testDir, "daos_server.yml")
+	ledgerPath := filepath.Join(testDir, "device_ledger.yaml")
+	if err := ioutil.WriteFile(cfgPath, []byte("device_ledger_file: "+ledgerPath+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		cfgPath   string
+		expLedger string
+		expErr    error
+	}{
+		"no config file; defaults used": {
+			expLedger: config.DefaultServer().DeviceLedgerFile,
+		},
+		"config file": {
+			cfgPath:   cfgPath,
+			expLedger: ledgerPath,
+		},
+		"missing config file": {
+			cfgPath: filepath.Join(testDir, "missing.yml"),
+			expErr:  errors.New("no such file"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			os.Unsetenv(configDataEnvVar)
+
+			cmd := new(storagePrepareCmd)
+			gotErr := cmd.loadConfig(tc.cfgPath)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expLedger, cmd.config.DeviceLedgerFile,
+				"unexpected device ledger file")
+		})
+	}
+}
@@ -8,6 +8,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -16,6 +19,12 @@ import (
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const (
+	savedNetworkScanFile = "network.json"
+	savedStorageScanFile = "storage.json"
 )
 
 // configCmd is the struct representing the top-level config subcommand.
@@ -33,6 +42,34 @@ type configGenCmd struct {
 	NrEngines    int    `short:"e" long:"num-engines" description:"Set the number of DAOS Engine sections to be populated in the config file output. If unset then the value will be set to the number of NUMA nodes on storage hosts in the DAOS system."`
 	MinNrSSDs    int    `default:"1" short:"s" long:"min-ssds" description:"Minimum number of NVMe SSDs required per DAOS Engine (SSDs must reside on the host that is managing the engine). Set to 0 to generate a config with no NVMe."`
 	NetClass     string `default:"best-available" short:"c" long:"net-class" description:"Network class preferred" choice:"best-available" choice:"ethernet" choice:"infiniband"`
+	TgtsPerSSD   int    `short:"t" long:"targets-per-ssd" description:"Number of targets to assign per NVMe SSD. If unset then the target count will be the largest multiple of the number of SSDs that fits in the available cores."`
+	ReserveCores int    `short:"r" long:"reserve-cores" description:"Number of cores per NUMA node to exclude from target and helper calculations"`
+	TierSSDs     bool   `long:"tier-ssds" description:"Group the NVMe SSDs of each DAOS Engine into storage tiers by capacity, smallest first"`
+	Verbose      bool   `short:"v" long:"verbose" description:"Include the target and helper calculations as comments in the generated config"`
+	FromScan     string `long:"from-scan" description:"Generate config from scan results saved in this directory instead of scanning hosts, network.json and storage.json should contain the output of dmg -j network scan and dmg -j storage scan"`
+	Existing     string `long:"existing-config" description:"Path to the server config file deployed on hosts already running engines, the generated config for new hosts will match its engine count and preserve its fabric ports and device assignments where possible"`
+}
+
+// readSavedScan unpacks the response in a file containing the JSON output of
+// a dmg scan command.
+func readSavedScan(path string, resp interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "reading saved scan results")
+	}
+
+	var saved struct {
+		Response json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return errors.Wrapf(err, "parsing saved scan results in %s", path)
+	}
+	if len(saved.Response) == 0 || string(saved.Response) == "null" {
+		return errors.Errorf("no scan results in %s", path)
+	}
+
+	return errors.Wrapf(json.Unmarshal(saved.Response, resp),
+		"parsing saved scan results in %s", path)
 }
 
 // Execute is run when configGenCmd activates.
@@ -46,11 +83,14 @@ func (cmd *configGenCmd) Execute(_ []string) error {
 	cmd.log.Debugf("configGenCmd input control config: %+v", cmd.config)
 
 	req := control.ConfigGenerateReq{
-		NrEngines: cmd.NrEngines,
-		MinNrSSDs: cmd.MinNrSSDs,
-		HostList:  cmd.config.HostList,
-		Client:    cmd.ctlInvoker,
-		Log:       cmd.log,
+		NrEngines:     cmd.NrEngines,
+		MinNrSSDs:     cmd.MinNrSSDs,
+		TargetsPerSSD: cmd.TgtsPerSSD,
+		ReserveCores:  cmd.ReserveCores,
+		TierSSDs:      cmd.TierSSDs,
+		HostList:      cmd.config.HostList,
+		Client:        cmd.ctlInvoker,
+		Log:           cmd.log,
 	}
 	switch cmd.NetClass {
 	case "ethernet":
@@ -63,6 +103,28 @@ func (cmd *configGenCmd) Execute(_ []string) error {
 	if cmd.AccessPoints != "" {
 		req.AccessPoints = strings.Split(cmd.AccessPoints, ",")
 	}
+	if cmd.FromScan != "" {
+		req.SavedNetworkScan = new(control.NetworkScanResp)
+		if err := readSavedScan(filepath.Join(cmd.FromScan, savedNetworkScanFile),
+			req.SavedNetworkScan); err != nil {
+			return err
+		}
+		req.SavedStorageScan = new(control.StorageScanResp)
+		if err := readSavedScan(filepath.Join(cmd.FromScan, savedStorageScanFile),
+			req.SavedStorageScan); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Existing != "" {
+		req.ExistingConfig = config.DefaultServer()
+		if err := req.ExistingConfig.SetPath(cmd.Existing); err != nil {
+			return errors.Wrap(err, "existing config")
+		}
+		if err := req.ExistingConfig.Load(); err != nil {
+			return errors.Wrapf(err, "loading existing config %s", cmd.Existing)
+		}
+	}
 
 	// TODO: decide whether we want meaningful JSON output
 	if cmd.jsonOutputEnabled() {
@@ -96,7 +158,19 @@ func (cmd *configGenCmd) Execute(_ []string) error {
 		return err
 	}
 
+	var bld strings.Builder
+	if cmd.Verbose {
+		for _, line := range resp.Report {
+			bld.WriteString("# " + line + "\n")
+		}
+	}
+	// estimated performance is always included to flag unbalanced configs
+	for _, line := range resp.Envelope.Report() {
+		bld.WriteString("# " + line + "\n")
+	}
+	bld.Write(bytes)
+
 	// output recommended server config yaml file
-	cmd.log.Info(string(bytes))
+	cmd.log.Info(bld.String())
 	return nil
 }
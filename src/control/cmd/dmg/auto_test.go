@@ -7,15 +7,44 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
 
 func TestDmg_ConfigCommands(t *testing.T) {
+	scanDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	writeScan := func(dir, name, content string) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	emptyScanDir := filepath.Join(scanDir, "empty")
+	writeScan(emptyScanDir, savedNetworkScanFile,
+		`{"response": {"host_errors": {}, "HostFabrics": {}}, "error": null, "status": 0}`)
+	writeScan(emptyScanDir, savedStorageScanFile,
+		`{"response": {"host_errors": {}, "HostStorage": {}}, "error": null, "status": 0}`)
+	failedScanDir := filepath.Join(scanDir, "failed")
+	writeScan(failedScanDir, savedNetworkScanFile,
+		`{"response": null, "error": "no hosts", "status": -1025}`)
+	existingCfgDir := filepath.Join(scanDir, "existing")
+	writeScan(existingCfgDir, "daos_server.yml",
+		"name: daos_server\nengines:\n- targets: 8\n  fabric_iface_port: 20000\n")
+	writeScan(existingCfgDir, "bad.yml", "engines: [")
+
 	runCmdTests(t, []cmdTest{
 		{
 			"Generate with no access point",
@@ -81,6 +110,71 @@ func TestDmg_ConfigCommands(t *testing.T) {
 			}, " "),
 			errors.New("no host responses"),
 		},
+		{
+			"Generate with target calculation parameters",
+			"config generate -a foo --targets-per-ssd 4 --reserve-cores 2 --verbose",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate with short option target calculation parameters",
+			"config generate -a foo -t 4 -r 2 -v",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate with tiered ssds",
+			"config generate -a foo --tier-ssds",
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate from saved scan results",
+			fmt.Sprintf("config generate -a foo --from-scan %s", emptyScanDir),
+			"",
+			errors.New("no host responses"),
+		},
+		{
+			"Generate from missing saved scan results",
+			fmt.Sprintf("config generate -a foo --from-scan %s", filepath.Join(scanDir, "missing")),
+			"",
+			errors.New("reading saved scan results"),
+		},
+		{
+			"Generate from failed saved scan",
+			fmt.Sprintf("config generate -a foo --from-scan %s", failedScanDir),
+			"",
+			errors.New("no scan results"),
+		},
+		{
+			"Generate reconciled with existing config",
+			fmt.Sprintf("config generate --existing-config %s",
+				filepath.Join(existingCfgDir, "daos_server.yml")),
+			strings.Join([]string{
+				printRequest(t, &control.NetworkScanReq{}),
+			}, " "),
+			errors.New("no host responses"),
+		},
+		{
+			"Generate reconciled with missing existing config",
+			fmt.Sprintf("config generate -a foo --existing-config %s",
+				filepath.Join(existingCfgDir, "missing.yml")),
+			"",
+			errors.New("no such file"),
+		},
+		{
+			"Generate reconciled with invalid existing config",
+			fmt.Sprintf("config generate -a foo --existing-config %s",
+				filepath.Join(existingCfgDir, "bad.yml")),
+			"",
+			errors.New("loading existing config"),
+		},
 		{
 			"Generate with unsupported network device class",
 			"config generate -a foo --net-class loopback",
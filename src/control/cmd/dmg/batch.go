@@ -0,0 +1,367 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const batchBackground = "&"
+
+type (
+	// batchEntry is a command listed in a batch file.
+	batchEntry struct {
+		line       int
+		text       string
+		background bool
+		args       []string
+		name       string
+		cmd        flags.Commander
+		cmdArgs    []string
+		out        strings.Builder
+		jsonOut    bytes.Buffer
+		wroteJSON  atm.Bool
+		ran        bool
+		err        error
+	}
+
+	// batchResult describes the outcome of a command run in a batch.
+	batchResult struct {
+		Line     int             `json:"line"`
+		Command  string          `json:"command"`
+		Response json.RawMessage `json:"response"`
+		Error    *string         `json:"error"`
+		Status   int             `json:"status"`
+	}
+
+	// batchResp contains the results of the commands run in a batch, in the
+	// order in which they are listed.
+	batchResp struct {
+		Results []*batchResult `json:"results"`
+	}
+)
+
+// splitCmdLine splits a line into arguments on unquoted whitespace. Single
+// and double quotes group characters into an argument and a backslash outside
+// of single quotes escapes the following character.
+func splitCmdLine(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	var quote rune
+	inArg, escaped := false, false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			arg.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	switch {
+	case quote != 0:
+		return nil, errors.Errorf("unterminated %c quote", quote)
+	case escaped:
+		return nil, errors.New("trailing backslash")
+	case inArg:
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+// parseBatch reads the commands listed in a batch file, one per line. Blank
+// lines and lines starting with # are ignored. A command ending in & is run
+// in the background.
+func parseBatch(r io.Reader) ([]*batchEntry, error) {
+	var entries []*batchEntry
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		entry := &batchEntry{line: lineNum, text: text}
+		if strings.HasSuffix(text, batchBackground) && !strings.HasSuffix(text, `\`+batchBackground) {
+			entry.background = true
+			entry.text = strings.TrimSpace(strings.TrimSuffix(text, batchBackground))
+		}
+		args, err := splitCmdLine(entry.text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		if len(args) == 0 {
+			return nil, errors.Errorf("line %d: missing command", lineNum)
+		}
+		entry.args = args
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// batchGroups splits the entries into groups to be run in turn. Consecutive
+// background entries form a group whose commands are run concurrently, any
+// other entry forms a group of its own.
+func batchGroups(entries []*batchEntry) [][]*batchEntry {
+	var groups [][]*batchEntry
+	var bg []*batchEntry
+
+	for _, entry := range entries {
+		if entry.background {
+			bg = append(bg, entry)
+			continue
+		}
+		if len(bg) > 0 {
+			groups = append(groups, bg)
+			bg = nil
+		}
+		groups = append(groups, []*batchEntry{entry})
+	}
+	if len(bg) > 0 {
+		groups = append(groups, bg)
+	}
+
+	return groups
+}
+
+// batchCmd runs the dmg commands listed in a file within a single dmg
+// process, so that connections to the servers are established once and
+// reused by all of the commands.
+type batchCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	File     string `short:"f" long:"file" required:"1" description:"File listing dmg commands, one per line; commands ending in & are run concurrently"`
+	Parallel int    `short:"p" long:"parallel" default:"8" description:"Maximum number of commands to run concurrently"`
+}
+
+// setupEntry parses the command of a batch entry and supplies it with a
+// logger capturing its output, the shared control client and a copy of the
+// control configuration. The command policy is checked for every command
+// before any is run so that required confirmations are not interleaved.
+func (cmd *batchCmd) setupEntry(entry *batchEntry) error {
+	var opts cliOptions
+	p := flags.NewParser(&opts, flags.Default)
+	p.Options ^= flags.PrintErrors // Don't allow the library to print errors
+	p.CommandHandler = func(c flags.Commander, args []string) error {
+		entry.cmd = c
+		entry.cmdArgs = args
+		return nil
+	}
+	if _, err := p.ParseArgs(entry.args); err != nil {
+		return err
+	}
+	if entry.cmd == nil {
+		return errors.New("missing command")
+	}
+
+	entry.name = activeCmdName(p)
+	switch {
+	case entry.name == "batch", entry.name == "version":
+		return errors.Errorf("dmg %s cannot be run in a batch", entry.name)
+	case opts.AllowProxy, opts.Insecure, opts.Debug, opts.JSON, opts.JSONLogs, opts.NoColor, opts.ConfigPath != "":
+		return errors.New("only the --host-list option may be set on commands in a batch")
+	case opts.HostList == stdinArg:
+		return errors.New("commands in a batch may not read from stdin")
+	}
+	if pr, ok := entry.cmd.(paramsReader); ok && pr.paramsPath() == stdinArg {
+		return errors.New("commands in a batch may not read from stdin")
+	}
+	if err := readParams(activeLeaf(p), entry.cmd); err != nil {
+		return err
+	}
+
+	// Debug and error messages are logged as they occur, informational
+	// output is captured so that it is not interleaved with the output
+	// of concurrent commands.
+	log := new(logging.LeveledLogger).
+		WithLogLevel(logging.LogLevelDebug).
+		WithDebugLogger(cmd.log).
+		WithErrorLogger(cmd.log).
+		WithInfoLogger(logging.NewCommandLineInfoLogger(&entry.out))
+	if logCmd, ok := entry.cmd.(cmdLogger); ok {
+		logCmd.setLog(log)
+	}
+	if jsonCmd, ok := entry.cmd.(jsonOutputter); ok {
+		jsonCmd.enableJsonOutput(cmd.jsonOutputEnabled(), &entry.jsonOut, &entry.wroteJSON)
+	}
+
+	ctlCfg := *cmd.config
+	if err := setupCmd(entry.cmd, cmd.ctlInvoker, &ctlCfg, opts.HostList); err != nil {
+		return err
+	}
+
+	return checkCmdPolicy(cmd.log, &ctlCfg, entry.name, entry.cmd, cmd.jsonOutputEnabled())
+}
+
+// runGroup runs the commands of the group concurrently, up to the parallel
+// limit. Commands not yet started when a command fails are not run.
+func (cmd *batchCmd) runGroup(group []*batchEntry) {
+	var failed atm.Bool
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, cmd.Parallel)
+
+	for _, entry := range group {
+		limit <- struct{}{}
+		if failed.IsTrue() {
+			break
+		}
+
+		entry.ran = true
+		wg.Add(1)
+		go func(entry *batchEntry) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+
+			cmd.log.Debugf("running line %d: dmg %s", entry.line, entry.text)
+			entry.err = entry.cmd.Execute(entry.cmdArgs)
+			if entry.err != nil && !control.IsSimulatedRequest(entry.err) {
+				failed.SetTrue()
+			}
+		}(entry)
+	}
+	wg.Wait()
+}
+
+// result returns the outcome of a command that was run in a batch with JSON
+// output enabled.
+func (entry *batchEntry) result() (*batchResult, error) {
+	if entry.wroteJSON.IsFalse() {
+		_ = outputJSON(&entry.jsonOut, nil, entry.err)
+	}
+
+	var out struct {
+		Response json.RawMessage `json:"response"`
+		Error    *string         `json:"error"`
+		Status   int             `json:"status"`
+	}
+	if err := json.Unmarshal(entry.jsonOut.Bytes(), &out); err != nil {
+		return nil, errors.Wrapf(err, "line %d: invalid JSON output", entry.line)
+	}
+
+	return &batchResult{
+		Line:     entry.line,
+		Command:  entry.text,
+		Response: out.Response,
+		Error:    out.Error,
+		Status:   out.Status,
+	}, nil
+}
+
+// Execute is run when batchCmd activates.
+func (cmd *batchCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "batch failed")
+	}()
+
+	if cmd.Parallel < 1 {
+		return errors.New("parallel must be at least 1")
+	}
+
+	f, err := os.Open(cmd.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entries, err := parseBatch(f)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", cmd.File)
+	}
+	for _, entry := range entries {
+		if err := cmd.setupEntry(entry); err != nil {
+			return errors.Wrapf(err, "line %d", entry.line)
+		}
+	}
+
+	resp := new(batchResp)
+	var failed *batchEntry
+	for _, group := range batchGroups(entries) {
+		cmd.runGroup(group)
+
+		for _, entry := range group {
+			if !entry.ran {
+				continue
+			}
+			if failed == nil && entry.err != nil && !control.IsSimulatedRequest(entry.err) {
+				failed = entry
+			}
+
+			if cmd.jsonOutputEnabled() {
+				res, err := entry.result()
+				if err != nil {
+					return err
+				}
+				resp.Results = append(resp.Results, res)
+				continue
+			}
+
+			cmd.log.Info("dmg " + entry.text)
+			if out := strings.TrimSuffix(entry.out.String(), "\n"); out != "" {
+				cmd.log.Info(out)
+			}
+			if control.IsSimulatedRequest(entry.err) {
+				cmd.log.Info(entry.err.Error())
+			}
+		}
+
+		if failed != nil {
+			break
+		}
+	}
+
+	if failed != nil {
+		err = errors.Wrapf(failed.err, "line %d: dmg %s", failed.line, failed.text)
+	}
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	return err
+}
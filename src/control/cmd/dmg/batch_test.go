@@ -0,0 +1,259 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestDmg_splitCmdLine(t *testing.T) {
+	for name, tc := range map[string]struct {
+		line    string
+		expArgs []string
+		expErr  error
+	}{
+		"empty": {},
+		"whitespace": {
+			line:    "  pool   create\t-s 1TB ",
+			expArgs: []string{"pool", "create", "-s", "1TB"},
+		},
+		"quoted": {
+			line:    `pool set-prop --pool "my pool" -n 'label' -v it\'s`,
+			expArgs: []string{"pool", "set-prop", "--pool", "my pool", "-n", "label", "-v", "it's"},
+		},
+		"escapes in quotes": {
+			line:    `a "b \"c\"" 'd \e'`,
+			expArgs: []string{"a", `b "c"`, `d \e`},
+		},
+		"empty quoted argument": {
+			line:    `a ""`,
+			expArgs: []string{"a", ""},
+		},
+		"unterminated quote": {
+			line:   `a "b`,
+			expErr: errors.New(`unterminated " quote`),
+		},
+		"trailing backslash": {
+			line:   `a b\`,
+			expErr: errors.New("trailing backslash"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotArgs, gotErr := splitCmdLine(tc.line)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expArgs, gotArgs); diff != "" {
+				t.Fatalf("unexpected args (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDmg_parseBatch(t *testing.T) {
+	for name, tc := range map[string]struct {
+		content   string
+		expGroups [][]string
+		expErr    error
+	}{
+		"comments and blank lines": {
+			content: "# provision\n\nsystem query\n  # done\n",
+			expGroups: [][]string{
+				{"system query"},
+			},
+		},
+		"background commands": {
+			content: "system query\npool create -s 1T p1 &\npool create -s 1T p2&\n" +
+				"system leader-query\npool list &\n",
+			expGroups: [][]string{
+				{"system query"},
+				{"pool create -s 1T p1", "pool create -s 1T p2"},
+				{"system leader-query"},
+				{"pool list"},
+			},
+		},
+		"missing command": {
+			content: "system query\n&\n",
+			expErr:  errors.New("line 2: missing command"),
+		},
+		"bad quoting": {
+			content: "pool create -s 1T 'p1\n",
+			expErr:  errors.New("line 1: unterminated"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			entries, gotErr := parseBatch(strings.NewReader(tc.content))
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotGroups [][]string
+			for _, group := range batchGroups(entries) {
+				var texts []string
+				for _, entry := range group {
+					texts = append(texts, entry.text)
+				}
+				gotGroups = append(gotGroups, texts)
+			}
+			if diff := cmp.Diff(tc.expGroups, gotGroups); diff != "" {
+				t.Fatalf("unexpected groups (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDmg_BatchCommands(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	seqFile := common.CreateTestFile(t, testDir,
+		"# query the system\nsystem query\n\nsystem history --limit 5\n")
+	failFile := common.CreateTestFile(t, testDir,
+		"system query\nsystem history --limit -1\nsystem leader-query\n")
+	nestedFile := common.CreateTestFile(t, testDir, "system query\nbatch -f foo\n")
+	globalOptFile := common.CreateTestFile(t, testDir, "--insecure system query\n")
+	unknownFile := common.CreateTestFile(t, testDir, "system query\nsystem quack\n")
+	stdinFile := common.CreateTestFile(t, testDir, "system query\n-l - system query\n")
+	paramsFile := common.CreateTestFile(t, testDir, `{"properties": "reclaim:lazy"}`)
+	paramsBatchFile := common.CreateTestFile(t, testDir,
+		"system set-pool-defaults --params "+paramsFile+"\n")
+
+	runCmdTests(t, []cmdTest{
+		{
+			"Batch of sequential commands",
+			"batch -f " + seqFile,
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{FailOnUnavailable: false}),
+				printRequest(t, &control.SystemHistoryReq{Limit: 5}),
+			}, " "),
+			nil,
+		},
+		{
+			"Batch stops on error",
+			"batch -f " + failFile,
+			"",
+			errors.New("line 2: dmg system history --limit -1: .*must not be negative"),
+		},
+		{
+			"Batch with nested batch",
+			"batch -f " + nestedFile,
+			"",
+			errors.New("line 2: dmg batch cannot be run in a batch"),
+		},
+		{
+			"Batch with global option on command",
+			"batch -f " + globalOptFile,
+			"",
+			errors.New("only the --host-list option"),
+		},
+		{
+			"Batch with host list from stdin",
+			"batch -f " + stdinFile,
+			"",
+			errors.New("line 2: commands in a batch may not read from stdin"),
+		},
+		{
+			"Batch with params file",
+			"batch -f " + paramsBatchFile,
+			printRequest(t, &control.SystemPoolDefaultsReq{
+				Set: []*control.PoolPropDefault{
+					{Name: "reclaim", Value: "lazy"},
+				},
+			}),
+			nil,
+		},
+		{
+			"Batch with unknown command",
+			"batch -f " + unknownFile,
+			"",
+			errors.New("line 2: Unknown command"),
+		},
+		{
+			"Batch with missing file",
+			"batch -f " + testDir + "/missing",
+			"",
+			errors.New("no such file"),
+		},
+		{
+			"Batch with bad parallel limit",
+			"batch -f " + seqFile + " --parallel 0",
+			"",
+			errors.New("parallel must be at least 1"),
+		},
+	})
+}
+
+// lockedInvoker serializes requests to the wrapped invoker so that mock
+// invokers can be used by commands running concurrently.
+type lockedInvoker struct {
+	sync.Mutex
+	*bridgeConnInvoker
+}
+
+func (li *lockedInvoker) InvokeUnaryRPC(ctx context.Context, req control.UnaryRequest) (*control.UnaryResponse, error) {
+	li.Lock()
+	defer li.Unlock()
+	return li.bridgeConnInvoker.InvokeUnaryRPC(ctx, req)
+}
+
+func TestDmg_BatchConcurrent(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+	batchFile := common.CreateTestFile(t, testDir,
+		"system history -o a &\nsystem history -o b &\nsystem history -o c &\n"+
+			"system history -o d\nsystem history -o e &\n")
+
+	conn := newTestConn(t)
+	invoker := &lockedInvoker{
+		bridgeConnInvoker: &bridgeConnInvoker{
+			MockInvoker: *control.DefaultMockInvoker(log),
+			t:           t,
+			conn:        conn,
+		},
+	}
+	if err := runCmd(t, "batch --parallel 2 -f "+batchFile, log, invoker); err != nil {
+		t.Fatal(err)
+	}
+
+	var expCalls []string
+	for _, op := range []string{"a", "b", "c"} {
+		expCalls = append(expCalls, printRequest(t, &control.SystemHistoryReq{Operation: op}))
+	}
+	gotCalls := append([]string{}, conn.called[:3]...)
+	sort.Strings(gotCalls)
+	expCalls = append(expCalls,
+		printRequest(t, &control.SystemHistoryReq{Operation: "d"}),
+		printRequest(t, &control.SystemHistoryReq{Operation: "e"}))
+	gotCalls = append(gotCalls, conn.called[3:]...)
+	if diff := cmp.Diff(expCalls, gotCalls); diff != "" {
+		t.Fatalf("unexpected calls (-want, +got):\n%s\n", diff)
+	}
+
+	// output of each command is emitted in the order listed
+	var cmdLines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if i := strings.Index(line, "INFO "); i >= 0 && strings.Contains(line, "dmg system history -o ") {
+			cmdLines = append(cmdLines, line[len(line)-1:])
+		}
+	}
+	if diff := cmp.Diff([]string{"a", "b", "c", "d", "e"}, cmdLines); diff != "" {
+		t.Fatalf("unexpected output order (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,217 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// checkCmd is the struct representing the top-level check subcommand.
+type checkCmd struct {
+	Start  checkStartCmd  `command:"start" description:"Start a check of the consistency of system metadata"`
+	Query  checkQueryCmd  `command:"query" description:"Show the status and findings of the most recent check"`
+	Repair checkRepairCmd `command:"repair" description:"Repair an inconsistency found by the most recent check"`
+}
+
+// checkStartCmd is the struct representing the command to start a check of
+// the consistency of the pool entries held by the MS against the pool and
+// container services.
+type checkStartCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	Pools string `long:"pools" description:"Comma-separated list of pool labels or UUIDs to check (default: all pools)"`
+}
+
+// Execute is run when checkStartCmd activates.
+func (cmd *checkStartCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "check start failed")
+	}()
+
+	req := new(control.CheckStartReq)
+	if cmd.Pools != "" {
+		req.Pools = strings.Split(cmd.Pools, ",")
+	}
+
+	resp, err := control.CheckStart(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	cmd.log.Infof("Check %d started, run 'dmg check query' to monitor its progress\n", resp.RunID)
+
+	return nil
+}
+
+// checkQueryCmd is the struct representing the command to query the status
+// and findings of the most recent check.
+type checkQueryCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+}
+
+// Execute is run when checkQueryCmd activates.
+func (cmd *checkQueryCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "check query failed")
+	}()
+
+	resp, err := control.CheckQuery(context.Background(), cmd.ctlInvoker, new(control.CheckQueryReq))
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintCheckQueryResponse(&out, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// checkRepairCmd is the struct representing the command to take a repair
+// action for a finding of the most recent check, or to step through each
+// pending finding interactively.
+type checkRepairCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	Action      string `long:"action" short:"a" description:"Repair action to take (default: the suggested action)"`
+	Interactive bool   `long:"interactive" short:"i" description:"Prompt for the repair action to take for each pending finding"`
+	Args        struct {
+		Finding uint64 `positional-arg-name:"finding" description:"ID of the finding to repair"`
+	} `positional-args:"yes"`
+
+	input io.Reader
+}
+
+// Execute is run when checkRepairCmd activates.
+func (cmd *checkRepairCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "check repair failed")
+	}()
+
+	ctx := context.Background()
+	if cmd.Interactive {
+		if cmd.Args.Finding != 0 || cmd.Action != "" {
+			return errors.New("finding and --action may not be used with --interactive")
+		}
+		if cmd.jsonOutputEnabled() {
+			return errors.New("--interactive may not be used with JSON output")
+		}
+		if cmd.input == nil {
+			cmd.input = os.Stdin
+		}
+		return repairInteractive(ctx, cmd.log, cmd.ctlInvoker, cmd.input)
+	}
+
+	if cmd.Args.Finding == 0 {
+		return errors.New("a finding ID must be supplied unless --interactive is used")
+	}
+
+	resp, err := control.CheckRepair(ctx, cmd.ctlInvoker, &control.CheckRepairReq{
+		Finding: cmd.Args.Finding,
+		Action:  cmd.Action,
+	})
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintCheckFinding(&out, resp.Finding); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// repairInteractive prompts for the action to take for each pending finding
+// of the most recent check. An empty response takes the suggested action,
+// "skip" leaves the finding pending and "quit" stops prompting.
+func repairInteractive(ctx context.Context, log logging.Logger, rpcClient control.UnaryInvoker, input io.Reader) error {
+	qResp, err := control.CheckQuery(ctx, rpcClient, new(control.CheckQueryReq))
+	if err != nil {
+		return err
+	}
+	if qResp.Status == control.CheckStatusRunning {
+		return errors.Errorf("check %d is still running", qResp.RunID)
+	}
+
+	pending := qResp.Pending()
+	if len(pending) == 0 {
+		log.Info("No pending findings to repair\n")
+		return nil
+	}
+
+	scanner := bufio.NewScanner(input)
+	for _, f := range pending {
+		var out strings.Builder
+		if err := pretty.PrintCheckFinding(&out, f); err != nil {
+			return err
+		}
+		log.Info(out.String())
+
+		for {
+			log.Infof("Action to take [%s] (or skip, quit): ", f.Suggested)
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return err
+				}
+				return nil
+			}
+
+			action := strings.TrimSpace(scanner.Text())
+			switch action {
+			case "quit":
+				return nil
+			case "skip":
+			default:
+				rResp, err := control.CheckRepair(ctx, rpcClient, &control.CheckRepairReq{
+					Finding: f.ID,
+					Action:  action,
+				})
+				if err != nil {
+					log.Errorf("repair of finding %d failed: %s", f.ID, err)
+					continue
+				}
+				log.Infof("Finding %d %s: %s\n", f.ID, rResp.Finding.Status, rResp.Finding.Result)
+			}
+			break
+		}
+	}
+
+	return nil
+}
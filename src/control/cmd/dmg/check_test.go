@@ -0,0 +1,212 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestDmg_CheckCommands(t *testing.T) {
+	runCmdTests(t, []cmdTest{
+		{
+			"check start all pools",
+			"check start",
+			printRequest(t, &control.CheckStartReq{}),
+			nil,
+		},
+		{
+			"check start selected pools",
+			"check start --pools tank," + common.MockUUID(1),
+			printRequest(t, &control.CheckStartReq{
+				Pools: []string{"tank", common.MockUUID(1)},
+			}),
+			nil,
+		},
+		{
+			"check query",
+			"check query",
+			printRequest(t, &control.CheckQueryReq{}),
+			nil,
+		},
+		{
+			"check repair suggested action",
+			"check repair 2",
+			printRequest(t, &control.CheckRepairReq{Finding: 2}),
+			nil,
+		},
+		{
+			"check repair with action",
+			"check repair --action ignore 2",
+			printRequest(t, &control.CheckRepairReq{Finding: 2, Action: "ignore"}),
+			nil,
+		},
+		{
+			"check repair without finding",
+			"check repair",
+			"",
+			errors.New("finding ID must be supplied"),
+		},
+		{
+			"check repair interactive with finding",
+			"check repair --interactive 2",
+			"",
+			errors.New("may not be used with --interactive"),
+		},
+		{
+			"check repair interactive with JSON",
+			"-j check repair --interactive",
+			"",
+			errors.New("may not be used with JSON output"),
+		},
+	})
+}
+
+func TestDmg_repairInteractive(t *testing.T) {
+	pendingFinding := func(id uint64) *mgmtpb.CheckFinding {
+		return &mgmtpb.CheckFinding{
+			Id:          id,
+			Class:       "pool-svc-missing",
+			PoolUuid:    common.MockUUID(int32(id)),
+			Description: "pool entry in the MS has no corresponding pool service",
+			Actions:     []string{"remove-pool-entry", "ignore"},
+			Suggested:   "remove-pool-entry",
+			Status:      "pending",
+		}
+	}
+	repaired := func(id uint64, status, action string) *control.UnaryResponse {
+		return control.MockMSResponse("host1", nil, &mgmtpb.CheckRepairResp{
+			Finding: &mgmtpb.CheckFinding{Id: id, Status: status, Action: action},
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		query      *mgmtpb.CheckQueryResp
+		repairs    []*control.UnaryResponse
+		input      string
+		expRepairs []*control.CheckRepairReq
+		expOut     []string
+		expErr     error
+	}{
+		"check running": {
+			query:  &mgmtpb.CheckQueryResp{RunId: 1, Status: control.CheckStatusRunning},
+			expErr: errors.New("still running"),
+		},
+		"nothing pending": {
+			query:  &mgmtpb.CheckQueryResp{RunId: 1, Status: "completed"},
+			expOut: []string{"No pending findings"},
+		},
+		"suggested and explicit actions": {
+			query: &mgmtpb.CheckQueryResp{
+				RunId:    1,
+				Status:   "completed",
+				Findings: []*mgmtpb.CheckFinding{pendingFinding(1), pendingFinding(2)},
+			},
+			repairs: []*control.UnaryResponse{
+				repaired(1, "repaired", "remove-pool-entry"),
+				repaired(2, "ignored", "ignore"),
+			},
+			input: "\nignore\n",
+			expRepairs: []*control.CheckRepairReq{
+				{Finding: 1},
+				{Finding: 2, Action: "ignore"},
+			},
+			expOut: []string{"Finding 1 repaired", "Finding 2 ignored"},
+		},
+		"skip and quit": {
+			query: &mgmtpb.CheckQueryResp{
+				RunId:    1,
+				Status:   "completed",
+				Findings: []*mgmtpb.CheckFinding{pendingFinding(1), pendingFinding(2)},
+			},
+			input: "skip\nquit\n",
+		},
+		"failed repair prompts again": {
+			query: &mgmtpb.CheckQueryResp{
+				RunId:    1,
+				Status:   "completed",
+				Findings: []*mgmtpb.CheckFinding{pendingFinding(1)},
+			},
+			repairs: []*control.UnaryResponse{
+				control.MockMSResponse("host1", errors.New("invalid action"), nil),
+				repaired(1, "ignored", "ignore"),
+			},
+			input: "bogus\nignore\n",
+			expRepairs: []*control.CheckRepairReq{
+				{Finding: 1, Action: "bogus"},
+				{Finding: 1, Action: "ignore"},
+			},
+			expOut: []string{"repair of finding 1 failed", "Finding 1 ignored"},
+		},
+		"end of input": {
+			query: &mgmtpb.CheckQueryResp{
+				RunId:    1,
+				Status:   "completed",
+				Findings: []*mgmtpb.CheckFinding{pendingFinding(1)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponseSet: append([]*control.UnaryResponse{
+					control.MockMSResponse("host1", nil, tc.query),
+				}, tc.repairs...),
+			})
+			rec := &recordingInvoker{MockInvoker: mi}
+
+			gotErr := repairInteractive(context.TODO(), log, rec, strings.NewReader(tc.input))
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotRepairs []*control.CheckRepairReq
+			for _, req := range rec.reqs {
+				if rr, ok := req.(*control.CheckRepairReq); ok {
+					gotRepairs = append(gotRepairs, &control.CheckRepairReq{
+						Finding: rr.Finding,
+						Action:  rr.Action,
+					})
+				}
+			}
+			common.AssertEqual(t, len(tc.expRepairs), len(gotRepairs), "unexpected number of repairs")
+			for i, exp := range tc.expRepairs {
+				common.AssertEqual(t, exp.Finding, gotRepairs[i].Finding, "unexpected finding")
+				common.AssertEqual(t, exp.Action, gotRepairs[i].Action, "unexpected action")
+			}
+
+			for _, exp := range tc.expOut {
+				if !strings.Contains(buf.String(), exp) {
+					t.Fatalf("expected to see %q in output, got %q", exp, buf.String())
+				}
+			}
+		})
+	}
+}
+
+// recordingInvoker wraps a MockInvoker in order to record the requests that
+// are invoked.
+type recordingInvoker struct {
+	*control.MockInvoker
+	reqs []control.UnaryRequest
+}
+
+func (ri *recordingInvoker) InvokeUnaryRPC(ctx context.Context, req control.UnaryRequest) (*control.UnaryResponse, error) {
+	ri.reqs = append(ri.reqs, req)
+	return ri.MockInvoker.InvokeUnaryRPC(ctx, req)
+}
@@ -14,9 +14,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -108,6 +110,8 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		})
 	case *control.PoolDestroyReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolDestroyResp{})
+	case *control.PoolRestoreReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.PoolRestoreResp{})
 	case *control.PoolEvictReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolEvictResp{})
 	case *control.PoolSetPropReq:
@@ -121,12 +125,42 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemEraseResp{})
 	case *control.SystemStartReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.SystemStartResp{})
+	case *control.SystemMaintenanceReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemMaintenanceResp{})
+	case *control.SystemLockReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemLockResp{})
+	case *control.SystemHistoryReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemHistoryResp{})
+	case *control.SystemPoolDefaultsReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemPoolDefaultsResp{})
+	case *control.CheckStartReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.CheckStartResp{})
+	case *control.CheckQueryReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.CheckQueryResp{})
+	case *control.CheckRepairReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.CheckRepairResp{Finding: &mgmtpb.CheckFinding{}})
+	case *control.SystemExcludeReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemExcludeResp{})
+	case *control.SystemSetFaultDomainReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemSetFaultDomainResp{})
+	case *control.SystemActivateSpareReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemActivateSpareResp{})
 	case *control.SystemQueryReq:
 		if req.FailOnUnavailable {
 			resp = control.MockMSResponse("", system.ErrRaftUnavail, nil)
 			break
 		}
-		resp = control.MockMSResponse("", nil, &mgmtpb.SystemQueryResp{})
+		resp = control.MockMSResponse("", nil, &mgmtpb.SystemQueryResp{
+			Members: []*mgmtpb.SystemMember{
+				{Addr: "127.0.0.1:10001", State: "joined"},
+			},
+		})
+	case *control.VersionQueryReq:
+		resp = &control.UnaryResponse{
+			Responses: []*control.HostResponse{
+				{Addr: "127.0.0.1:10001", Message: &ctlpb.VersionQueryResp{}},
+			},
+		}
 	case *control.LeaderQueryReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.LeaderQueryResp{})
 	case *control.ListPoolsReq:
@@ -139,6 +173,30 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		})
 	case *control.PoolQueryReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolQueryResp{})
+	case *control.PoolQueryTargetsReq:
+		resp = &control.UnaryResponse{
+			Responses: []*control.HostResponse{
+				{
+					Addr: "127.0.0.1:10001",
+					Message: &ctlpb.PoolQueryTargetsResp{
+						Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+							{
+								Rank: 0,
+								Targets: []*ctlpb.PoolTargetInfo{
+									{
+										State:     control.PoolTargetStateUpIn,
+										ScmTotal:  humanize.TByte,
+										ScmFree:   humanize.TByte,
+										NvmeTotal: humanize.TByte,
+										NvmeFree:  humanize.TByte,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
 	case *control.PoolGetACLReq, *control.PoolOverwriteACLReq,
 		*control.PoolUpdateACLReq, *control.PoolDeleteACLReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.ACLResp{})
@@ -150,6 +208,16 @@ func (bci *bridgeConnInvoker) InvokeUnaryRPC(ctx context.Context, uReq control.U
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolExtendResp{})
 	case *control.PoolReintegrateReq:
 		resp = control.MockMSResponse("", nil, &mgmtpb.PoolReintegrateResp{})
+	case *control.GetAttachInfoReq:
+		resp = control.MockMSResponse("", nil, &mgmtpb.GetAttachInfoResp{
+			RankUris: []*mgmtpb.GetAttachInfoResp_RankUri{
+				{Rank: 0, Uri: "ofi+sockets://127.0.0.1:31416"},
+			},
+		})
+	case *control.NetworkSelfTestReq:
+		resp = control.MockMSResponse("", nil, &ctlpb.NetworkSelfTestResp{})
+	case *control.StorageSelfTestReq:
+		resp = control.MockMSResponse("", nil, &ctlpb.StorageSelfTestResp{})
 	}
 
 	return resp, nil
@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+// EngineCmd is the struct representing the top-level engine subcommand.
+type EngineCmd struct {
+	Stats engineStatsCmd `command:"stats" alias:"s" description:"Display runtime scheduling statistics for each I/O Engine xstream"`
+}
+
+// engineStatsCmd is the struct representing the command to retrieve
+// per-xstream scheduling statistics from running I/O Engines.
+type engineStatsCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+	rankCmd
+}
+
+// Execute is run when engineStatsCmd activates.
+func (cmd *engineStatsCmd) Execute(_ []string) error {
+	ctx := context.Background()
+	req := &control.EngineStatsReq{
+		Rank: cmd.GetRank(),
+	}
+	req.SetHostList(cmd.hostlist)
+
+	cmd.log.Debugf("engine stats req: %+v", req)
+
+	resp, err := control.GetEngineStats(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+
+	if err := pretty.PrintEngineStats(resp.HostStats, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
@@ -0,0 +1,40 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestEngineCommands(t *testing.T) {
+	runCmdTests(t, []cmdTest{
+		{
+			"Engine stats all ranks",
+			"engine stats",
+			strings.Join([]string{
+				printRequest(t, &control.EngineStatsReq{
+					Rank: system.NilRank,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Engine stats single rank",
+			"engine stats --rank 1",
+			strings.Join([]string{
+				printRequest(t, &control.EngineStatsReq{
+					Rank: 1,
+				}),
+			}, " "),
+			nil,
+		},
+	})
+}
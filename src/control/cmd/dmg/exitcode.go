@@ -0,0 +1,158 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/lib/atm"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// Exit codes returned by dmg so that automation can distinguish failures
+// that may be retried from those that will not succeed without intervention.
+const (
+	exitSuccess = iota
+	// the operation was performed but failed
+	exitOperationFault
+	// invalid arguments or configuration, no request was sent
+	exitValidationError
+	// no server or management service could be contacted
+	exitConnectionFailure
+	// the request succeeded on some hosts but failed on the others
+	exitPartialHostFailure
+	// the operation did not complete within the allotted time
+	exitTimeout
+)
+
+// timeoutError indicates that a command gave up waiting for a condition to
+// be met.
+type timeoutError struct {
+	error
+}
+
+func (te *timeoutError) Unwrap() error {
+	return te.error
+}
+
+// errTimedOut marks the given error as resulting from a timeout.
+func errTimedOut(err error) error {
+	return &timeoutError{err}
+}
+
+func isTimeout(err error) bool {
+	var te *timeoutError
+	if errors.As(err, &te) {
+		return true
+	}
+
+	cause := errors.Cause(err)
+	return cause == context.DeadlineExceeded || status.Code(cause) == codes.DeadlineExceeded
+}
+
+// exitCode returns the exit code for the error returned by a command. If no
+// request was sent before the error occurred, the command is considered to
+// have been rejected as invalid. Host errors are only reported as a partial
+// failure if a host that the request was sent to succeeded.
+func exitCode(err error, it *invokeTracker) int {
+	if err == nil {
+		return exitSuccess
+	}
+
+	var fe *flags.Error
+	if errors.As(err, &fe) {
+		return exitValidationError
+	}
+
+	if isTimeout(err) {
+		return exitTimeout
+	}
+
+	if control.IsConnectionError(err) || control.IsMSConnectionFailure(err) ||
+		system.IsUnavailable(err) {
+		return exitConnectionFailure
+	}
+
+	var hee *control.HostErrorsError
+	if errors.As(err, &hee) {
+		if hee.AllConnectionErrors() {
+			return exitConnectionFailure
+		}
+		if it.anyHostSucceeded(hee.Hosts()) {
+			return exitPartialHostFailure
+		}
+		return exitOperationFault
+	}
+
+	if it.invoked.IsFalse() {
+		return exitValidationError
+	}
+
+	return exitOperationFault
+}
+
+// invokeTracker wraps a control.Invoker in order to record whether any
+// request was sent by a command and the hosts that responded to each.
+type invokeTracker struct {
+	control.Invoker
+	invoked atm.Bool
+
+	sync.Mutex
+	respHosts []*hostlist.HostSet
+}
+
+func (it *invokeTracker) InvokeUnaryRPC(ctx context.Context, req control.UnaryRequest) (*control.UnaryResponse, error) {
+	it.invoked.SetTrue()
+	ur, err := it.Invoker.InvokeUnaryRPC(ctx, req)
+	if ur != nil {
+		it.addResponseHosts(ur.Responses)
+	}
+	return ur, err
+}
+
+func (it *invokeTracker) addResponseHosts(resps []*control.HostResponse) {
+	hosts := new(hostlist.HostSet)
+	for _, hr := range resps {
+		if _, err := hosts.Insert(hr.Addr); err != nil {
+			continue
+		}
+	}
+
+	it.Lock()
+	defer it.Unlock()
+	it.respHosts = append(it.respHosts, hosts)
+}
+
+// anyHostSucceeded returns true if any of the hosts that responded to the
+// failed request did not have an error. The failed request is taken to be
+// the last one to which every host with an error responded.
+func (it *invokeTracker) anyHostSucceeded(failed *hostlist.HostSet) bool {
+	it.Lock()
+	defer it.Unlock()
+
+	for i := len(it.respHosts) - 1; i >= 0; i-- {
+		if within, err := it.respHosts[i].Within(failed.String()); err != nil || !within {
+			continue
+		}
+		return it.respHosts[i].Count() > failed.Count()
+	}
+
+	return false
+}
+
+func (it *invokeTracker) InvokeUnaryRPCAsync(ctx context.Context, req control.UnaryRequest) (control.HostResponseChan, error) {
+	it.invoked.SetTrue()
+	return it.Invoker.InvokeUnaryRPCAsync(ctx, req)
+}
@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestDmg_exitCode(t *testing.T) {
+	hostErrs := func(hostErrors ...*control.MockHostError) error {
+		resp := control.MockHostErrorsResp(t, hostErrors...)
+		return resp.Errors()
+	}
+
+	for name, tc := range map[string]struct {
+		err       error
+		invoked   bool
+		respHosts []string
+		expCode   int
+	}{
+		"success": {
+			invoked: true,
+			expCode: exitSuccess,
+		},
+		"flag parsing error": {
+			err:     &flags.Error{Type: flags.ErrUnknownFlag},
+			expCode: exitValidationError,
+		},
+		"rejected before request sent": {
+			err:     errors.New("--foo may not be combined with --bar"),
+			expCode: exitValidationError,
+		},
+		"operation fault": {
+			err:     drpc.DaosNoSpace,
+			invoked: true,
+			expCode: exitOperationFault,
+		},
+		"connection refused": {
+			err:     control.FaultConnectionRefused("host1"),
+			invoked: true,
+			expCode: exitConnectionFailure,
+		},
+		"ms unavailable": {
+			err:     errors.Wrap(system.ErrRaftUnavail, "query"),
+			invoked: true,
+			expCode: exitConnectionFailure,
+		},
+		"partial host failure": {
+			err: hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+			),
+			invoked:   true,
+			respHosts: []string{"host[1-2]"},
+			expCode:   exitPartialHostFailure,
+		},
+		"wrapped partial host failure": {
+			err: errors.Wrap(hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+			), "scan"),
+			invoked:   true,
+			respHosts: []string{"host[1-2]"},
+			expCode:   exitPartialHostFailure,
+		},
+		"all hosts failed": {
+			err: hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+				&control.MockHostError{Hosts: "host2", Error: "oops"},
+			),
+			invoked:   true,
+			respHosts: []string{"host[1-2]"},
+			expCode:   exitOperationFault,
+		},
+		"all hosts failed after request to other hosts": {
+			err: hostErrs(
+				&control.MockHostError{Hosts: "host[1-2]", Error: "whoops"},
+			),
+			invoked:   true,
+			respHosts: []string{"host[1-3]", "host[1-2]", "host3"},
+			expCode:   exitOperationFault,
+		},
+		"host failure of earlier request": {
+			err: hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+			),
+			invoked:   true,
+			respHosts: []string{"host[1-2]", "host3"},
+			expCode:   exitPartialHostFailure,
+		},
+		"host failure without responses": {
+			err: hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+			),
+			invoked: true,
+			expCode: exitOperationFault,
+		},
+		"context deadline": {
+			err:     errors.Wrap(context.DeadlineExceeded, "pool create"),
+			invoked: true,
+			expCode: exitTimeout,
+		},
+		"grpc deadline": {
+			err:     status.Error(codes.DeadlineExceeded, "deadline"),
+			invoked: true,
+			expCode: exitTimeout,
+		},
+		"timed out waiting": {
+			err: errTimedOut(errors.Wrap(hostErrs(
+				&control.MockHostError{Hosts: "host1", Error: "whoops"},
+			), "timed out after 1m0s")),
+			invoked: true,
+			expCode: exitTimeout,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			it := new(invokeTracker)
+			if tc.invoked {
+				it.invoked.SetTrue()
+			}
+			for _, hosts := range tc.respHosts {
+				it.respHosts = append(it.respHosts, hostlist.MustCreateSet(hosts))
+			}
+
+			common.AssertEqual(t, tc.expCode, exitCode(tc.err, it), "")
+		})
+	}
+}
+
+func TestDmg_exitCode_AllConnectionErrors(t *testing.T) {
+	resp := &control.HostErrorsResp{HostErrors: make(control.HostErrorsMap)}
+	for _, host := range []string{"host1", "host2"} {
+		if err := resp.HostErrors.Add(host, control.FaultConnectionRefused(host)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	it := new(invokeTracker)
+	it.invoked.SetTrue()
+	common.AssertEqual(t, exitConnectionFailure, exitCode(resp.Errors(), it), "")
+}
+
+func TestDmg_invokeTracker(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	it := &invokeTracker{
+		Invoker: control.DefaultMockInvoker(log),
+	}
+
+	var opts cliOptions
+	if err := parseOpts([]string{"-i", "pool", "create", "--size", "1Q"}, &opts, it, log); err == nil {
+		t.Fatal("expected error")
+	}
+	if it.invoked.IsTrue() {
+		t.Fatal("request sent for invalid command")
+	}
+
+	// only whether a request was sent matters here, not the response
+	_ = parseOpts([]string{"-i", "system", "query"}, &opts, it, log)
+	if it.invoked.IsFalse() {
+		t.Fatal("request not recorded")
+	}
+}
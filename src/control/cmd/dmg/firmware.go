@@ -11,6 +11,9 @@ import (
 	"io"
 	"strings"
 
+	"github.com/dustin/go-humanize/english"
+	"github.com/pkg/errors"
+
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/lib/control"
 )
@@ -36,6 +39,7 @@ type firmwareQueryCmd struct {
 	Devices     string `short:"d" long:"devices" description:"Comma-separated list of device identifiers to query"`
 	ModelID     string `short:"m" long:"model" description:"Model ID to filter results by"`
 	FirmwareRev string `short:"f" long:"fwrev" description:"Firmware revision to filter results by"`
+	Catalog     string `short:"c" long:"catalog" description:"Path to a firmware catalog, report devices not running an approved firmware revision"`
 	Verbose     bool   `short:"v" long:"verbose" description:"Display verbose output"`
 }
 
@@ -43,6 +47,14 @@ type firmwareQueryCmd struct {
 func (cmd *firmwareQueryCmd) Execute(args []string) error {
 	ctx := context.Background()
 
+	var catalog *control.FirmwareCatalog
+	if cmd.Catalog != "" {
+		var err error
+		if catalog, err = control.LoadFirmwareCatalog(cmd.Catalog); err != nil {
+			return err
+		}
+	}
+
 	req := &control.FirmwareQueryReq{
 		SCM:         cmd.isSCMRequested(),
 		NVMe:        cmd.isNVMeRequested(),
@@ -57,6 +69,10 @@ func (cmd *firmwareQueryCmd) Execute(args []string) error {
 	req.SetHostList(cmd.hostlist)
 	resp, err := control.FirmwareQuery(ctx, cmd.ctlInvoker, req)
 
+	if catalog != nil {
+		return cmd.checkCompliance(catalog, resp, err)
+	}
+
 	if cmd.jsonOutputEnabled() {
 		return cmd.outputJSON(resp, err)
 	}
@@ -86,6 +102,52 @@ func (cmd *firmwareQueryCmd) Execute(args []string) error {
 	return resp.Errors()
 }
 
+// firmwareComplianceResp is the JSON output of a firmware query made against
+// a firmware catalog.
+type firmwareComplianceResp struct {
+	control.HostErrorsResp
+	NonCompliant []*control.NonCompliantDevice `json:"non_compliant"`
+}
+
+// checkCompliance reports the queried devices not compliant with the firmware
+// catalog and returns an error if any were found.
+func (cmd *firmwareQueryCmd) checkCompliance(catalog *control.FirmwareCatalog, resp *control.FirmwareQueryResp, err error) error {
+	if err != nil {
+		if cmd.jsonOutputEnabled() {
+			return cmd.outputJSON(nil, err)
+		}
+		return err
+	}
+
+	nonCompliant := catalog.CheckCompliance(resp)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(&firmwareComplianceResp{
+			HostErrorsResp: resp.HostErrorsResp,
+			NonCompliant:   nonCompliant,
+		}, nil)
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintFirmwareCompliance(nonCompliant, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	if err := resp.Errors(); err != nil {
+		return err
+	}
+	if len(nonCompliant) > 0 {
+		return errors.Errorf("%d %s not compliant with firmware catalog", len(nonCompliant),
+			english.PluralWord(len(nonCompliant), "device", ""))
+	}
+
+	return nil
+}
+
 func (cmd *firmwareQueryCmd) isSCMRequested() bool {
 	return cmd.DeviceType == "scm" || cmd.DeviceType == "all"
 }
@@ -116,11 +178,13 @@ type firmwareUpdateCmd struct {
 	ctlInvokerCmd
 	hostListCmd
 	jsonOutputCmd
-	DeviceType  string `short:"t" long:"type" choice:"nvme" choice:"scm" required:"1" description:"Type of storage devices to update"`
-	FilePath    string `short:"p" long:"path" required:"1" description:"Path to the firmware file accessible from all nodes"`
+	DeviceType  string `short:"t" long:"type" choice:"nvme" choice:"scm" description:"Type of storage devices to update"`
+	FilePath    string `short:"p" long:"path" description:"Path to the firmware file accessible from all nodes"`
 	Devices     string `short:"d" long:"devices" description:"Comma-separated list of device identifiers to update"`
 	ModelID     string `short:"m" long:"model" description:"Limit update to a model ID"`
 	FirmwareRev string `short:"f" long:"fwrev" description:"Limit update to a current firmware revision"`
+	Activate    bool   `short:"a" long:"activate" description:"Activate staged SCM firmware without a power cycle where supported by the platform"`
+	FromCatalog string `short:"c" long:"from-catalog" description:"Path to a firmware catalog, update devices not running an approved firmware revision with the firmware file of their model"`
 	Verbose     bool   `short:"v" long:"verbose" description:"Display verbose output"`
 }
 
@@ -128,10 +192,25 @@ type firmwareUpdateCmd struct {
 func (cmd *firmwareUpdateCmd) Execute(args []string) error {
 	ctx := context.Background()
 
+	if cmd.FromCatalog != "" {
+		if cmd.DeviceType != "" || cmd.FilePath != "" || cmd.Devices != "" ||
+			cmd.ModelID != "" || cmd.FirmwareRev != "" {
+			return errors.New("--from-catalog cannot be used with device type, path or filter options")
+		}
+		return cmd.updateFromCatalog(ctx)
+	}
+	if cmd.DeviceType == "" {
+		return errors.New("the required flag `-t, --type' was not specified")
+	}
+	if cmd.FilePath == "" {
+		return errors.New("the required flag `-p, --path' was not specified")
+	}
+
 	req := &control.FirmwareUpdateReq{
 		FirmwarePath: cmd.FilePath,
 		ModelID:      cmd.ModelID,
 		FirmwareRev:  cmd.FirmwareRev,
+		Activate:     cmd.Activate,
 	}
 
 	if cmd.isSCMUpdate() {
@@ -169,6 +248,51 @@ func (cmd *firmwareUpdateCmd) Execute(args []string) error {
 	return resp.Errors()
 }
 
+// updateFromCatalog updates the firmware of all devices not compliant with
+// the firmware catalog.
+func (cmd *firmwareUpdateCmd) updateFromCatalog(ctx context.Context) error {
+	catalog, err := control.LoadFirmwareCatalog(cmd.FromCatalog)
+	if err != nil {
+		return err
+	}
+
+	req := &control.FirmwareCatalogUpdateReq{
+		Catalog:  catalog,
+		Activate: cmd.Activate,
+	}
+	req.SetHostList(cmd.hostlist)
+	resp, err := control.FirmwareCatalogUpdate(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintFirmwareCompliance(resp.NonCompliant, &bld); err != nil {
+		return err
+	}
+	if len(resp.HostSCMResult) > 0 {
+		if err := cmd.printSCMUpdateResult(&resp.FirmwareUpdateResp, &bld); err != nil {
+			return err
+		}
+	}
+	if len(resp.HostNVMeResult) > 0 {
+		if err := cmd.printNVMeUpdateResult(&resp.FirmwareUpdateResp, &bld); err != nil {
+			return err
+		}
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
+
 func (cmd *firmwareUpdateCmd) isSCMUpdate() bool {
 	return cmd.DeviceType == "scm"
 }
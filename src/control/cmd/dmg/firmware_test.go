@@ -112,6 +112,12 @@ func TestFirmwareCommands(t *testing.T) {
 			"",
 			errors.New("Invalid value `none' for option `-t, --type'. Allowed values are: nvme, scm or all"),
 		},
+		{
+			"Query with missing catalog",
+			"firmware query --catalog=/does_not/exist.yml",
+			"",
+			errors.New("failed to read firmware catalog"),
+		},
 		{
 			"Update with no path",
 			"firmware update --type=scm",
@@ -124,6 +130,18 @@ func TestFirmwareCommands(t *testing.T) {
 			"",
 			errors.New("the required flag `-t, --type' was not specified"),
 		},
+		{
+			"Update from catalog with path",
+			"firmware update --from-catalog=/dont/care.yml --path=/dont/care",
+			"",
+			errors.New("--from-catalog cannot be used with"),
+		},
+		{
+			"Update from missing catalog",
+			"firmware update --from-catalog=/does_not/exist.yml",
+			"",
+			errors.New("failed to read firmware catalog"),
+		},
 		{
 			"Update with invalid type",
 			"firmware update --type=all --path=/does_not/matter",
@@ -187,6 +205,24 @@ func TestFirmwareCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Update with activate",
+			"firmware update --type=scm --path=/dont/care --activate",
+			strings.Join([]string{
+				printRequest(t, &control.FirmwareUpdateReq{
+					FirmwarePath: "/dont/care",
+					Type:         control.DeviceTypeSCM,
+					Activate:     true,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Update NVMe with activate",
+			"firmware update --type=nvme --path=/dont/care --activate",
+			"",
+			errors.New("only supported for SCM devices"),
+		},
 		{
 			"Update with device list",
 			"firmware update --type=scm --path=/dont/care --devices=D1,D2,D3",
@@ -0,0 +1,447 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+type (
+	// jsonSchema is a JSON Schema document or subschema.
+	jsonSchema map[string]interface{}
+
+	// jsonOutput describes the value emitted in the "response" field of a
+	// command's JSON output. A nil response indicates that the command
+	// only reports an error status. Commands with options that change the
+	// type of the response list the alternatives by option.
+	jsonOutput struct {
+		response interface{}
+		variants map[string]interface{}
+	}
+)
+
+// jsonOutputs maps each dmg command supporting JSON output to a description
+// of that output. New commands supporting JSON output must be added here.
+var jsonOutputs = map[string]jsonOutput{
+	"storage prepare":             {response: (*control.StoragePrepareResp)(nil)},
+	"storage scan":                {response: (*control.StorageScanResp)(nil)},
+	"storage format":              {response: (*control.StorageFormatResp)(nil)},
+	"storage query target-health": {response: (*control.SmdQueryResp)(nil)},
+	"storage query device-health": {response: (*control.SmdQueryResp)(nil)},
+	"storage query device-stats":  {response: (*control.SmdQueryResp)(nil)},
+	"storage query list-pools":    {response: (*control.SmdQueryResp)(nil)},
+	"storage query list-devices":  {response: (*control.SmdQueryResp)(nil)},
+	"storage query usage":         {response: (*control.StorageScanResp)(nil)},
+	"storage query ownership":     {response: (*control.StorageOwnershipResp)(nil)},
+	"storage query verify":        {response: (*control.StorageVerifyResp)(nil)},
+	"storage set nvme-faulty":     {response: (*control.SmdQueryResp)(nil)},
+	"storage replace nvme":        {response: (*control.SmdQueryResp)(nil)},
+	"storage identify vmd":        {response: (*control.SmdQueryResp)(nil)},
+	"storage self-test":           {response: (*control.StorageSelfTestResp)(nil)},
+	"config generate":             {response: (*control.ConfigGenerateResp)(nil)},
+	"system leader-query":         {response: (*control.LeaderQueryResp)(nil)},
+	"system query": {
+		response: (*control.SystemQueryResp)(nil),
+		variants: map[string]interface{}{
+			"--versions": (*control.VersionQueryResp)(nil),
+			"--skew":     (*control.VersionSkewReport)(nil),
+			"--ms":       (*control.MSHealthQueryResp)(nil),
+		},
+	},
+	"system wait":               {response: (*control.SystemWaitResp)(nil)},
+	"system extend":             {response: (*control.SystemExtendResp)(nil)},
+	"system stop":               {response: (*control.SystemStopResp)(nil)},
+	"system start":              {response: (*control.SystemStartResp)(nil)},
+	"system erase":              {response: (*control.SystemEraseResp)(nil)},
+	"system list-pools":         {response: (*control.ListPoolsResp)(nil)},
+	"system maintenance start":  {response: (*control.SystemMaintenanceResp)(nil)},
+	"system maintenance end":    {response: (*control.SystemMaintenanceResp)(nil)},
+	"system maintenance list":   {response: (*control.SystemMaintenanceResp)(nil)},
+	"system exclude":            {response: (*control.SystemExcludeResp)(nil)},
+	"system clear-exclude":      {response: (*control.SystemExcludeResp)(nil)},
+	"system set-fault-domain":   {response: (*control.SystemSetFaultDomainResp)(nil)},
+	"system clear-fault-domain": {response: (*control.SystemSetFaultDomainResp)(nil)},
+	"system activate-spare":     {response: (*control.SystemActivateSpareResp)(nil)},
+	"system lock acquire":       {response: (*control.SystemLockResp)(nil)},
+	"system lock release":       {response: (*control.SystemLockResp)(nil)},
+	"system lock list":          {response: (*control.SystemLockResp)(nil)},
+	"system history":            {response: (*control.SystemHistoryResp)(nil)},
+	"system set-pool-defaults":  {response: (*control.SystemPoolDefaultsResp)(nil)},
+	"system get-pool-defaults":  {response: (*control.SystemPoolDefaultsResp)(nil)},
+	"check start":               {response: (*control.CheckStartResp)(nil)},
+	"check query":               {response: (*control.CheckQueryResp)(nil)},
+	"check repair":              {response: (*control.CheckRepairResp)(nil)},
+	"network scan":              {response: (*control.NetworkScanResp)(nil)},
+	"network self-test":         {response: (*control.NetworkSelfTestResp)(nil)},
+	"engine stats":              {response: (*control.EngineStatsResp)(nil)},
+	"pool create":               {response: (*control.PoolCreateResp)(nil)},
+	"pool destroy":              {},
+	"pool evict":                {},
+	"pool restore":              {},
+	"pool list":                 {response: (*control.ListPoolsResp)(nil)},
+	"pool extend":               {},
+	"pool exclude":              {},
+	"pool drain":                {},
+	"pool reintegrate":          {},
+	"pool query":                {response: (*control.PoolQueryResp)(nil)},
+	"pool check-fit":            {response: (*control.PoolCheckFitResp)(nil)},
+	"pool get-acl":              {response: (*control.AccessControlList)(nil)},
+	"pool overwrite-acl":        {response: (*control.AccessControlList)(nil)},
+	"pool update-acl":           {response: (*control.AccessControlList)(nil)},
+	"pool delete-acl":           {response: (*control.AccessControlList)(nil)},
+	"pool set-prop":             {response: (*control.PoolSetPropResp)(nil)},
+	"cont set-owner":            {},
+	"telemetry metrics list":    {response: (*control.MetricsListResp)(nil)},
+	"telemetry metrics query":   {response: (*control.MetricsQueryResp)(nil)},
+	"telemetry snapshot":        {response: (*telemSnapshotResp)(nil)},
+	"batch":                     {response: (*batchResp)(nil)},
+	"firmware query": {
+		response: (*control.FirmwareQueryResp)(nil),
+		variants: map[string]interface{}{
+			"--catalog": (*firmwareComplianceResp)(nil),
+		},
+	},
+	"firmware update": {
+		response: (*control.FirmwareUpdateResp)(nil),
+		variants: map[string]interface{}{
+			"--from-catalog": (*control.FirmwareCatalogUpdateResp)(nil),
+		},
+	},
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// jsonSchemaOverrides describes the output of types with custom JSON
+	// marshalling whose schema cannot be inferred from a zero value.
+	jsonSchemaOverrides = map[reflect.Type]jsonSchema{
+		reflect.TypeOf(time.Time{}): {
+			"type":   "string",
+			"format": "date-time",
+		},
+		reflect.TypeOf(control.AccessControlList{}): {
+			"type":  []string{"array", "null"},
+			"items": jsonSchema{"type": "string"},
+		},
+		reflect.TypeOf(control.HostErrorsMap{}): {
+			"type":                 []string{"object", "null"},
+			"additionalProperties": jsonSchema{"type": "string"},
+		},
+	}
+)
+
+// nullable returns the schema modified to also accept null.
+func nullable(s jsonSchema) jsonSchema {
+	if t, ok := s["type"].(string); ok && t != "null" {
+		s["type"] = []string{t, "null"}
+	}
+	return s
+}
+
+// sampleSchema infers a schema from a decoded JSON value.
+func sampleSchema(v interface{}) jsonSchema {
+	switch val := v.(type) {
+	case bool:
+		return jsonSchema{"type": "boolean"}
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return jsonSchema{"type": "integer"}
+		}
+		return jsonSchema{"type": "number"}
+	case string:
+		return jsonSchema{"type": "string"}
+	case []interface{}:
+		s := jsonSchema{"type": "array"}
+		if len(val) > 0 {
+			s["items"] = sampleSchema(val[0])
+		}
+		return s
+	case map[string]interface{}:
+		// keys present in the sample are not omitted when empty
+		props := jsonSchema{}
+		var required []string
+		for k, pv := range val {
+			props[k] = sampleSchema(pv)
+			required = append(required, k)
+		}
+		s := jsonSchema{"type": "object", "properties": props}
+		if len(required) > 0 {
+			sort.Strings(required)
+			s["required"] = required
+		}
+		return s
+	default:
+		return jsonSchema{} // null, any value is accepted
+	}
+}
+
+// marshalerSchema infers the schema of a type with custom JSON marshalling
+// from the output produced for its zero value.
+func marshalerSchema(t reflect.Type) (s jsonSchema) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = jsonSchema{}
+		}
+	}()
+
+	data, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return jsonSchema{}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var sample interface{}
+	if err := dec.Decode(&sample); err != nil {
+		return jsonSchema{}
+	}
+
+	return sampleSchema(sample)
+}
+
+// addStructProperties adds the properties marshalled for the fields of the
+// given struct type, following the rules used by encoding/json. Fields of
+// embedded structs are only added if not shadowed by a shallower field.
+func addStructProperties(t reflect.Type, props jsonSchema, required map[string]bool, seen map[reflect.Type]bool) {
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		name := opts[0]
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		props[name] = typeSchema(f.Type, seen)
+		required[name] = true
+		for _, opt := range opts[1:] {
+			if opt == "omitempty" {
+				required[name] = false
+			}
+		}
+	}
+
+	for _, et := range embedded {
+		eProps := jsonSchema{}
+		eRequired := make(map[string]bool)
+		addStructProperties(et, eProps, eRequired, seen)
+		for name, ps := range eProps {
+			if _, shadowed := props[name]; shadowed {
+				continue
+			}
+			props[name] = ps
+			required[name] = eRequired[name]
+		}
+	}
+}
+
+func structSchema(t reflect.Type, seen map[reflect.Type]bool) jsonSchema {
+	if seen[t] {
+		// recursive type, don't describe it again
+		return jsonSchema{"type": "object"}
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	props := jsonSchema{}
+	required := make(map[string]bool)
+	addStructProperties(t, props, required, seen)
+
+	s := jsonSchema{"type": "object", "properties": props}
+	var reqNames []string
+	for name, req := range required {
+		if req {
+			reqNames = append(reqNames, name)
+		}
+	}
+	if len(reqNames) > 0 {
+		sort.Strings(reqNames)
+		s["required"] = reqNames
+	}
+
+	return s
+}
+
+// typeSchema generates the schema of the JSON produced by encoding/json for
+// values of the given type.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) jsonSchema {
+	if override, found := jsonSchemaOverrides[t]; found {
+		s := jsonSchema{}
+		for k, v := range override {
+			s[k] = v
+		}
+		return s
+	}
+
+	if t.Kind() == reflect.Ptr {
+		return nullable(typeSchema(t.Elem(), seen))
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return marshalerSchema(t)
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return jsonSchema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return jsonSchema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return jsonSchema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return jsonSchema{"type": []string{"string", "null"}, "contentEncoding": "base64"}
+		}
+		return nullable(jsonSchema{"type": "array", "items": typeSchema(t.Elem(), seen)})
+	case reflect.Array:
+		return jsonSchema{
+			"type":     "array",
+			"items":    typeSchema(t.Elem(), seen),
+			"minItems": t.Len(),
+			"maxItems": t.Len(),
+		}
+	case reflect.Map:
+		return nullable(jsonSchema{
+			"type":                 "object",
+			"additionalProperties": typeSchema(t.Elem(), seen),
+		})
+	case reflect.Struct:
+		return structSchema(t, seen)
+	default:
+		return jsonSchema{} // interfaces may hold any value
+	}
+}
+
+// responseSchema generates the schema of the "response" field of a command's
+// JSON output.
+func (jo jsonOutput) responseSchema() jsonSchema {
+	respSchema := func(resp interface{}) jsonSchema {
+		if resp == nil {
+			return jsonSchema{"type": "null"}
+		}
+		return typeSchema(reflect.TypeOf(resp), make(map[reflect.Type]bool))
+	}
+
+	if len(jo.variants) == 0 {
+		return respSchema(jo.response)
+	}
+
+	alternatives := []jsonSchema{respSchema(jo.response)}
+	var opts []string
+	for opt := range jo.variants {
+		opts = append(opts, opt)
+	}
+	sort.Strings(opts)
+	for _, opt := range opts {
+		s := respSchema(jo.variants[opt])
+		s["description"] = "output when " + opt + " is specified"
+		alternatives = append(alternatives, s)
+	}
+
+	return jsonSchema{"anyOf": alternatives}
+}
+
+// commandJSONSchema returns the schema of the JSON output of the named dmg
+// command.
+func commandJSONSchema(cmd string) (jsonSchema, error) {
+	jo, found := jsonOutputs[cmd]
+	if !found {
+		return nil, errors.Errorf("no JSON output schema for command %q", cmd)
+	}
+
+	return jsonSchema{
+		"$schema": jsonSchemaDraft,
+		"title":   "dmg " + cmd,
+		"type":    "object",
+		"properties": jsonSchema{
+			"response": jo.responseSchema(),
+			"error":    jsonSchema{"type": []string{"string", "null"}},
+			"status":   jsonSchema{"type": "integer"},
+		},
+		"required":             []string{"response", "error", "status"},
+		"additionalProperties": false,
+	}, nil
+}
+
+// jsonSchemaCmd is the struct representing the command to print the JSON
+// Schema of the JSON output of dmg commands.
+type jsonSchemaCmd struct {
+	logCmd
+	Args struct {
+		Command []string `positional-arg-name:"command"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when jsonSchemaCmd activates.
+//
+// If no command is given, the schemas of all commands are printed as a single
+// JSON object keyed by command.
+func (cmd *jsonSchemaCmd) Execute(_ []string) error {
+	var out interface{}
+	if len(cmd.Args.Command) > 0 {
+		schema, err := commandJSONSchema(strings.Join(cmd.Args.Command, " "))
+		if err != nil {
+			return err
+		}
+		out = schema
+	} else {
+		all := make(map[string]jsonSchema)
+		for name := range jsonOutputs {
+			schema, err := commandJSONSchema(name)
+			if err != nil {
+				return err
+			}
+			all[name] = schema
+		}
+		out = all
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.log.Info(string(data))
+
+	return nil
+}
@@ -0,0 +1,321 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// noJSONOutput lists the commands that do not support JSON output.
+var noJSONOutput = map[string]bool{
+	"version":          true,
+	"telemetry config": true,
+	"telemetry run":    true,
+	"json-schema":      true,
+}
+
+func jsonTypeMatches(types []string, v interface{}) bool {
+	for _, t := range types {
+		switch val := v.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case json.Number:
+			if t == "number" {
+				return true
+			}
+			if _, err := val.Int64(); err == nil && t == "integer" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkJSONSchema validates a decoded JSON value against the subset of JSON
+// Schema produced by typeSchema.
+func checkJSONSchema(schema jsonSchema, v interface{}, path string) error {
+	if alts, ok := schema["anyOf"].([]jsonSchema); ok {
+		for _, alt := range alts {
+			if checkJSONSchema(alt, v, path) == nil {
+				return nil
+			}
+		}
+		return errors.Errorf("%s: no alternative schema matched", path)
+	}
+
+	switch t := schema["type"].(type) {
+	case string:
+		if !jsonTypeMatches([]string{t}, v) {
+			return errors.Errorf("%s: %v is not of type %s", path, v, t)
+		}
+	case []string:
+		if !jsonTypeMatches(t, v) {
+			return errors.Errorf("%s: %v is not of type %v", path, v, t)
+		}
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]string); ok {
+			for _, name := range required {
+				if _, found := val[name]; !found {
+					return errors.Errorf("%s: missing required property %q", path, name)
+				}
+			}
+		}
+		props, _ := schema["properties"].(jsonSchema)
+		for name, pv := range val {
+			if ps, found := props[name]; found {
+				if err := checkJSONSchema(ps.(jsonSchema), pv, path+"."+name); err != nil {
+					return err
+				}
+				continue
+			}
+			switch ap := schema["additionalProperties"].(type) {
+			case bool:
+				if !ap {
+					return errors.Errorf("%s: unexpected property %q", path, name)
+				}
+			case jsonSchema:
+				if err := checkJSONSchema(ap, pv, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(jsonSchema); ok {
+			for _, iv := range val {
+				if err := checkJSONSchema(items, iv, path+"[]"); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkJSONOutput validates the JSON output of a dmg command against the
+// schema exported for it.
+func checkJSONOutput(t *testing.T, cmd string, output []byte) {
+	t.Helper()
+
+	schema, err := commandJSONSchema(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(output))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkJSONSchema(schema, v, "$"); err != nil {
+		t.Fatalf("output does not match schema: %s\n%s", err, output)
+	}
+}
+
+func TestDmg_jsonOutputs(t *testing.T) {
+	cmds := make(map[string]bool)
+	visit := func(cmd []string) {
+		cmds[strings.Join(cmd, " ")] = true
+	}
+	walkStruct(reflect.ValueOf(cliOptions{}), nil, visit)
+	walkStruct(reflect.ValueOf(firmwareOption{}), nil, visit)
+
+	for cmd := range cmds {
+		if noJSONOutput[cmd] {
+			continue
+		}
+		if _, found := jsonOutputs[cmd]; !found {
+			t.Errorf("no JSON output registered for command %q", cmd)
+		}
+	}
+	for cmd := range jsonOutputs {
+		if !cmds[cmd] {
+			t.Errorf("JSON output registered for unknown command %q", cmd)
+		}
+		if _, err := commandJSONSchema(cmd); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+type (
+	testSchemaInner struct {
+		Count uint32 `json:"count"`
+	}
+
+	testSchemaEmbedded struct {
+		Shadowed string
+		Promoted bool `json:"promoted"`
+	}
+
+	testSchemaMarshaler struct {
+		hidden string
+	}
+
+	testSchemaStruct struct {
+		testSchemaEmbedded
+		Shadowed string              `json:"Shadowed,omitempty"`
+		Name     string              `json:"name"`
+		Skipped  string              `json:"-"`
+		Score    float64             `json:"score"`
+		Inner    *testSchemaInner    `json:"inner,omitempty"`
+		List     []testSchemaInner   `json:"list"`
+		ByName   map[string]int      `json:"by_name"`
+		Data     []byte              `json:"data"`
+		When     time.Time           `json:"when"`
+		Custom   testSchemaMarshaler `json:"custom"`
+		Any      interface{}         `json:"any"`
+		private  string
+	}
+)
+
+func (tsm testSchemaMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`{"hidden":"","count":0}`), nil
+}
+
+func TestDmg_typeSchema(t *testing.T) {
+	expSchema := jsonSchema{
+		"type": []string{"object", "null"},
+		"properties": jsonSchema{
+			"Shadowed": jsonSchema{"type": "string"},
+			"promoted": jsonSchema{"type": "boolean"},
+			"name":     jsonSchema{"type": "string"},
+			"score":    jsonSchema{"type": "number"},
+			"inner": jsonSchema{
+				"type": []string{"object", "null"},
+				"properties": jsonSchema{
+					"count": jsonSchema{"type": "integer", "minimum": 0},
+				},
+				"required": []string{"count"},
+			},
+			"list": jsonSchema{
+				"type": []string{"array", "null"},
+				"items": jsonSchema{
+					"type": "object",
+					"properties": jsonSchema{
+						"count": jsonSchema{"type": "integer", "minimum": 0},
+					},
+					"required": []string{"count"},
+				},
+			},
+			"by_name": jsonSchema{
+				"type":                 []string{"object", "null"},
+				"additionalProperties": jsonSchema{"type": "integer"},
+			},
+			"data": jsonSchema{"type": []string{"string", "null"}, "contentEncoding": "base64"},
+			"when": jsonSchema{"type": "string", "format": "date-time"},
+			"custom": jsonSchema{
+				"type": "object",
+				"properties": jsonSchema{
+					"hidden": jsonSchema{"type": "string"},
+					"count":  jsonSchema{"type": "integer"},
+				},
+				"required": []string{"count", "hidden"},
+			},
+			"any": jsonSchema{},
+		},
+		"required": []string{"any", "by_name", "custom", "data", "list", "name", "promoted",
+			"score", "when"},
+	}
+
+	gotSchema := typeSchema(reflect.TypeOf(&testSchemaStruct{}), make(map[reflect.Type]bool))
+	if diff := cmp.Diff(expSchema, gotSchema); diff != "" {
+		t.Fatalf("unexpected schema (-want, +got):\n%s\n", diff)
+	}
+
+	data, err := json.Marshal(&testSchemaStruct{Name: "test", List: []testSchemaInner{{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkJSONSchema(gotSchema, v, "$"); err != nil {
+		t.Fatalf("marshalled value does not match schema: %s\n%s", err, data)
+	}
+}
+
+func TestDmg_JsonSchemaCmd(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args     []string
+		expTitle string
+		expErr   error
+	}{
+		"unknown command": {
+			args:   []string{"system", "quack"},
+			expErr: errors.New(`no JSON output schema for command "system quack"`),
+		},
+		"no JSON output": {
+			args:   []string{"version"},
+			expErr: errors.New("no JSON output schema"),
+		},
+		"single command": {
+			args:     []string{"system", "lock", "list"},
+			expTitle: "dmg system lock list",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+			var out bytes.Buffer
+			log.WithInfoLogger(logging.NewCommandLineInfoLogger(&out))
+
+			cmd := new(jsonSchemaCmd)
+			cmd.setLog(log)
+			cmd.Args.Command = tc.args
+
+			gotErr := cmd.Execute(nil)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var schema map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+				t.Fatalf("invalid JSON schema: %s\n%s", err, out.String())
+			}
+			common.AssertEqual(t, tc.expTitle, schema["title"], "unexpected schema title")
+		})
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
+
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -61,12 +63,14 @@ func TestDmg_JsonOutput(t *testing.T) {
 	defer cleanup()
 	aclContent := "A::OWNER@:rw\nA::user1@:rw\nA:g:group1@:r\n"
 	aclPath := common.CreateTestFile(t, testDir, aclContent)
+	batchPath := common.CreateTestFile(t, testDir, "system query\npool list &\nsystem leader-query &\n")
 
 	for _, args := range cmdArgs {
 		t.Run(strings.Join(args, " "), func(t *testing.T) {
 			testArgs := append([]string{"-i", "--json"}, args...)
+			var expErr error
 			switch strings.Join(args, " ") {
-			case "version", "telemetry config", "telemetry run":
+			case "version", "telemetry config", "telemetry run", "json-schema":
 				return
 			case "storage prepare":
 				testArgs = append(testArgs, "--force")
@@ -82,21 +86,50 @@ func TestDmg_JsonOutput(t *testing.T) {
 				testArgs = append(testArgs, []string{"--uuid", common.MockUUID()}...)
 			case "pool create":
 				testArgs = append(testArgs, []string{"-s", "1TB"}...)
-			case "pool destroy", "pool evict", "pool query", "pool get-acl":
+			case "pool destroy", "pool evict", "pool restore", "pool query", "pool get-acl":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID()}...)
 			case "pool overwrite-acl", "pool update-acl":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "-a", aclPath}...)
 			case "pool delete-acl":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "-p", "foo@"}...)
+			case "pool check-fit":
+				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "-z", "1GB"}...)
 			case "pool set-prop":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "-n", "foo", "-v", "bar"}...)
 			case "pool extend":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "--ranks", "0", "-s", "1TB"}...)
 			case "pool exclude", "pool drain", "pool reintegrate":
 				testArgs = append(testArgs, []string{"--pool", common.MockUUID(), "--rank", "0"}...)
+			case "system exclude", "system clear-exclude", "system clear-fault-domain":
+				testArgs = append(testArgs, []string{"--ranks", "0"}...)
+			case "system set-fault-domain":
+				testArgs = append(testArgs, []string{"--ranks", "0", "/rack0/host1"}...)
+			case "system erase":
+				testArgs = append(testArgs, []string{"--confirm", "daos_server"}...)
+			case "system activate-spare":
+				testArgs = append(testArgs, []string{"--rank", "0"}...)
+			case "system wait":
+				testArgs = append(testArgs, []string{"--for", "rebuild-idle"}...)
+			case "system extend":
+				// the new hosts never join the mock system, the
+				// status is still expected to be output as JSON
+				testArgs = append(testArgs, []string{"--hosts", "foo-1", "--interval", "1ms", "--timeout", "10ms"}...)
+				expErr = errors.New("timed out")
+			case "system maintenance start":
+				testArgs = append(testArgs, []string{"--hosts", "foo-1"}...)
+			case "system lock acquire", "system lock release":
+				testArgs = append(testArgs, "foo")
+			case "system set-pool-defaults":
+				testArgs = append(testArgs, []string{"--properties", "reclaim:lazy"}...)
+			case "check repair":
+				testArgs = append(testArgs, "1")
+			case "network self-test":
+				testArgs = append(testArgs, []string{"--src-ranks", "0", "--dst-ranks", "1"}...)
 			case "cont set-owner":
 				testArgs = append(testArgs, []string{"--user", "foo", "--pool", common.MockUUID(), "--cont", common.MockUUID()}...)
-			case "telemetry metrics list", "telemetry metrics query":
+			case "batch":
+				testArgs = append(testArgs, []string{"-f", batchPath, "--parallel", "1"}...)
+			case "telemetry metrics list", "telemetry metrics query", "telemetry snapshot":
 				return // These commands query via http directly
 			}
 
@@ -126,7 +159,9 @@ func TestDmg_JsonOutput(t *testing.T) {
 			}
 
 			err := parseOpts(testArgs, &cliOptions{}, bridge, log)
-			if err != nil {
+			if expErr != nil {
+				common.CmpErr(t, expErr, err)
+			} else if err != nil {
 				t.Errorf("%s: %s", strings.Join(testArgs, " "), err)
 			}
 			w.Close()
@@ -135,6 +170,7 @@ func TestDmg_JsonOutput(t *testing.T) {
 			if !json.Valid(result.Bytes()) {
 				t.Fatalf("invalid JSON in response: %s", result.String())
 			}
+			checkJSONOutput(t, strings.Join(args, " "), result.Bytes())
 		})
 	}
 }
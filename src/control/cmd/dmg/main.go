@@ -18,9 +18,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/drpc"
-	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/lib/atm"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -55,6 +55,10 @@ type (
 		writer         io.Writer
 		shouldEmitJSON bool
 	}
+
+	outputFormatCmd struct {
+		Format string `long:"format" default:"table" choice:"table" choice:"csv" description:"Format of tabular output"`
+	}
 )
 
 func (cmd *ctlInvokerCmd) setInvoker(c control.Invoker) {
@@ -122,6 +126,10 @@ func (cmd *jsonOutputCmd) errorJSON(err error) error {
 
 var _ jsonOutputter = (*jsonOutputCmd)(nil)
 
+func (cmd *outputFormatCmd) csvOutputEnabled() bool {
+	return cmd.Format == "csv"
+}
+
 type cmdLogger interface {
 	setLog(*logging.LeveledLogger)
 }
@@ -150,22 +158,42 @@ func (c *cfgCmd) setConfig(cfg *control.Config) {
 }
 
 type cliOptions struct {
-	AllowProxy     bool       `long:"allow-proxy" description:"Allow proxy configuration via environment"`
-	HostList       string     `short:"l" long:"host-list" description:"comma separated list of addresses <ipv4addr/hostname>"`
-	Insecure       bool       `short:"i" long:"insecure" description:"have dmg attempt to connect without certificates"`
-	Debug          bool       `short:"d" long:"debug" description:"enable debug output"`
-	JSON           bool       `short:"j" long:"json" description:"Enable JSON output"`
-	JSONLogs       bool       `short:"J" long:"json-logging" description:"Enable JSON-formatted log output"`
-	ConfigPath     string     `short:"o" long:"config-path" description:"Client config file path"`
-	Storage        storageCmd `command:"storage" alias:"st" description:"Perform tasks related to storage attached to remote servers"`
-	Config         configCmd  `command:"config" alias:"co" description:"Perform tasks related to configuration of hardware remote servers"`
-	System         SystemCmd  `command:"system" alias:"sy" description:"Perform distributed tasks related to DAOS system"`
-	Network        NetCmd     `command:"network" alias:"n" description:"Perform tasks related to network devices attached to remote servers"`
-	Pool           PoolCmd    `command:"pool" alias:"p" description:"Perform tasks related to DAOS pools"`
-	Cont           ContCmd    `command:"cont" alias:"c" description:"Perform tasks related to DAOS containers"`
-	Version        versionCmd `command:"version" description:"Print dmg version"`
-	Telemetry      telemCmd   `command:"telemetry" description:"Perform telemetry operations"`
-	firmwareOption            // build with tag "firmware" to enable
+	AllowProxy     bool          `long:"allow-proxy" description:"Allow proxy configuration via environment"`
+	HostList       string        `short:"l" long:"host-list" description:"comma separated list of addresses <ipv4addr/hostname>, or - to read them from stdin"`
+	Insecure       bool          `short:"i" long:"insecure" description:"have dmg attempt to connect without certificates"`
+	Debug          bool          `short:"d" long:"debug" description:"enable debug output"`
+	JSON           bool          `short:"j" long:"json" description:"Enable JSON output"`
+	JSONLogs       bool          `short:"J" long:"json-logging" description:"Enable JSON-formatted log output"`
+	NoColor        bool          `long:"no-color" description:"Disable colored terminal output, severity is indicated by icons instead (also set by the NO_COLOR environment variable)"`
+	ConfigPath     string        `short:"o" long:"config-path" description:"Client config file path"`
+	Simulate       string        `long:"simulate" description:"Serve commands from the synthetic cluster described in the given topology file instead of contacting servers"`
+	Storage        storageCmd    `command:"storage" alias:"st" description:"Perform tasks related to storage attached to remote servers"`
+	Config         configCmd     `command:"config" alias:"co" description:"Perform tasks related to configuration of hardware remote servers"`
+	System         SystemCmd     `command:"system" alias:"sy" description:"Perform distributed tasks related to DAOS system"`
+	Network        NetCmd        `command:"network" alias:"n" description:"Perform tasks related to network devices attached to remote servers"`
+	Engine         EngineCmd     `command:"engine" alias:"e" description:"Perform tasks related to DAOS I/O Engines on remote servers"`
+	Pool           PoolCmd       `command:"pool" alias:"p" description:"Perform tasks related to DAOS pools"`
+	Cont           ContCmd       `command:"cont" alias:"c" description:"Perform tasks related to DAOS containers"`
+	Check          checkCmd      `command:"check" description:"Check and repair the consistency of DAOS system metadata"`
+	Version        versionCmd    `command:"version" description:"Print dmg version"`
+	Telemetry      telemCmd      `command:"telemetry" description:"Perform telemetry operations"`
+	JSONSchema     jsonSchemaCmd `command:"json-schema" description:"Print the JSON Schema of the JSON output of dmg commands"`
+	Batch          batchCmd      `command:"batch" description:"Run the dmg commands listed in a file"`
+	firmwareOption               // build with tag "firmware" to enable
+
+	// progress renders the progress of requests sent to multiple hosts.
+	progress *progressRenderer
+	// messages replaces the default user-facing messages, if loaded.
+	messages *msgCatalog
+}
+
+// noColorEnv disables colored output when set to any value, see
+// https://no-color.org.
+const noColorEnv = "NO_COLOR"
+
+// colorDisabled returns true if the user has disabled colored output.
+func colorDisabled(opts *cliOptions) bool {
+	return opts.NoColor || os.Getenv(noColorEnv) != ""
 }
 
 type versionCmd struct{}
@@ -176,13 +204,11 @@ func (cmd *versionCmd) Execute(_ []string) error {
 	return nil
 }
 
-func exitWithError(log logging.Logger, err error) {
-	cmdName := path.Base(os.Args[0])
-	log.Errorf("%s: %v", cmdName, err)
-	if fault.HasResolution(err) {
-		log.Errorf("%s: %s", cmdName, fault.ShowResolutionFor(err))
+func exitWithError(log logging.Logger, msgs *msgCatalog, style pretty.OutputStyle, err error, code int) {
+	for _, msg := range msgs.errorMessages(path.Base(os.Args[0]), err) {
+		log.Error(style.Render(pretty.SeverityError, msg))
 	}
-	os.Exit(1)
+	os.Exit(code)
 }
 
 func writeManPage(wr io.Writer) {
@@ -199,6 +225,40 @@ and access control settings, along with system wide operations.`
 	p.WriteManPage(wr)
 }
 
+// useSimulator replaces the client used to invoke requests with the
+// simulator, so that no requests are sent to servers.
+func useSimulator(invoker control.Invoker, sim *control.Simulator) control.Invoker {
+	if it, ok := invoker.(*invokeTracker); ok {
+		it.Invoker = sim
+		return it
+	}
+	return sim
+}
+
+// setupCmd supplies the command with the control client and configuration
+// it requires, applying the host list to both if set.
+func setupCmd(cmd flags.Commander, invoker control.Invoker, ctlCfg *control.Config, hostList string) error {
+	if ctlCmd, ok := cmd.(ctlInvoker); ok {
+		ctlCmd.setInvoker(invoker)
+		if hostList != "" {
+			if hlCmd, ok := cmd.(hostListSetter); ok {
+				hl := strings.Split(hostList, ",")
+				hlCmd.setHostList(hl)
+				ctlCfg.HostList = hl
+			} else {
+				return errors.Errorf("this command does not accept a hostlist parameter (set it in %s or %s)",
+					control.UserConfigPath(), control.SystemConfigPath())
+			}
+		}
+	}
+
+	if cfgCmd, ok := cmd.(cmdConfigSetter); ok {
+		cfgCmd.setConfig(ctlCfg)
+	}
+
+	return nil
+}
+
 func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *logging.LeveledLogger) error {
 	var wroteJSON atm.Bool
 	p := flags.NewParser(opts, flags.Default)
@@ -233,6 +293,19 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 			logCmd.setLog(log)
 		}
 
+		if opts.progress != nil {
+			// Progress is drawn on stdout, so it is not rendered when
+			// the output is JSON or when batch commands may be
+			// running concurrently.
+			opts.progress.setTTY(!opts.JSON && activeCmdName(p) != "batch" &&
+				isTerminal(os.Stdout))
+		}
+
+		// The severity of values is only rendered in human-readable
+		// output written to a terminal.
+		pretty.SetOutputStyle(pretty.SelectOutputStyle(!opts.JSON && isTerminal(os.Stdout),
+			colorDisabled(opts)))
+
 		ctlCfg, err := control.LoadConfig(opts.ConfigPath)
 		if err != nil {
 			if opts.ConfigPath != "" {
@@ -244,30 +317,44 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 			log.Debugf("control config loaded from %s", ctlCfg.Path)
 		}
 
-		if opts.Insecure {
+		if opts.messages, err = loadCfgMsgCatalog(ctlCfg); err != nil {
+			return err
+		}
+
+		if opts.Insecure || opts.Simulate != "" {
 			ctlCfg.TransportConfig.AllowInsecure = true
 		}
 		if err := ctlCfg.TransportConfig.PreLoadCertData(); err != nil {
 			return errors.Wrap(err, "Unable to load Certificate Data")
 		}
 
-		invoker.SetConfig(ctlCfg)
-		if ctlCmd, ok := cmd.(ctlInvoker); ok {
-			ctlCmd.setInvoker(invoker)
-			if opts.HostList != "" {
-				if hlCmd, ok := cmd.(hostListSetter); ok {
-					hl := strings.Split(opts.HostList, ",")
-					hlCmd.setHostList(hl)
-					ctlCfg.HostList = hl
-				} else {
-					return errors.Errorf("this command does not accept a hostlist parameter (set it in %s or %s)",
-						control.UserConfigPath(), control.SystemConfigPath())
-				}
+		if opts.Simulate != "" {
+			topo, err := control.LoadSimTopology(opts.Simulate)
+			if err != nil {
+				return err
 			}
+			log.Debugf("simulating the system described in %s", opts.Simulate)
+			invoker = useSimulator(invoker, control.NewSimulator(log, topo))
 		}
 
-		if cfgCmd, ok := cmd.(cmdConfigSetter); ok {
-			cfgCmd.setConfig(ctlCfg)
+		hostList := opts.HostList
+		if hostList == stdinArg {
+			if hostList, err = readHostList(stdinInput); err != nil {
+				return err
+			}
+		}
+
+		invoker.SetConfig(ctlCfg)
+		if err := setupCmd(cmd, invoker, ctlCfg, hostList); err != nil {
+			return err
+		}
+
+		if err := readParams(activeLeaf(p), cmd); err != nil {
+			return err
+		}
+
+		if err := checkCmdPolicy(log, ctlCfg, activeCmdName(p), cmd, opts.JSON); err != nil {
+			return err
 		}
 
 		if err := cmd.Execute(args); err != nil {
@@ -285,18 +372,30 @@ func parseOpts(args []string, opts *cliOptions, invoker control.Invoker, log *lo
 }
 
 func main() {
-	var opts cliOptions
 	log := logging.NewCommandLineLogger()
+	opts := cliOptions{
+		progress: newProgressRenderer(log, os.Stdout),
+	}
 
-	ctlInvoker := control.NewClient(
-		control.WithClientLogger(log),
-	)
+	ctlInvoker := &invokeTracker{
+		Invoker: control.NewClient(
+			control.WithClientLogger(log),
+			control.WithConnectionReuse(),
+			control.WithProgressReporter(opts.progress),
+		),
+	}
 
-	if err := parseOpts(os.Args[1:], &opts, ctlInvoker, log); err != nil {
+	err := parseOpts(os.Args[1:], &opts, ctlInvoker, log)
+	if control.IsSimulatedRequest(err) {
+		log.Info(err.Error())
+		os.Exit(exitSuccess)
+	}
+	if err != nil {
 		if fe, ok := errors.Cause(err).(*flags.Error); ok && fe.Type == flags.ErrHelp {
 			log.Info(fe.Error())
 			os.Exit(0)
 		}
-		exitWithError(log, err)
+		errStyle := pretty.SelectOutputStyle(!opts.JSON && isTerminal(os.Stderr), colorDisabled(&opts))
+		exitWithError(log, opts.messages, errStyle, err, exitCode(err, ctlInvoker))
 	}
 }
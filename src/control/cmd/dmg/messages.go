@@ -0,0 +1,189 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+const (
+	// msgError is the ID of the message displaying the error that dmg
+	// exited with.
+	msgError = "error"
+	// msgResolution is the ID of the message displaying the resolution of
+	// the fault that dmg exited with.
+	msgResolution = "resolution"
+)
+
+type (
+	// msgCatalog holds templates replacing the user-facing messages printed
+	// by dmg, so that sites can localize them or add site-specific details
+	// such as links to a ticket system. Templates use text/template syntax
+	// and are executed with a msgData value.
+	msgCatalog struct {
+		// values made available to all templates as .Site
+		Site map[string]string `yaml:"site"`
+		// templates keyed by message ID
+		Messages map[string]string `yaml:"messages"`
+		// templates keyed by fault code, replacing the resolution of
+		// the fault
+		Resolutions map[int]string `yaml:"resolutions"`
+		path        string
+		messages    map[string]*template.Template
+		resolutions map[int]*template.Template
+	}
+
+	// msgData is the data that message templates are executed with.
+	msgData struct {
+		Site        map[string]string
+		Command     string
+		Error       string
+		Domain      string
+		Code        int
+		Description string
+		Reason      string
+		Resolution  string
+	}
+)
+
+func parseMsgTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Parse(text)
+}
+
+// loadMsgCatalog reads the message catalog file at the given path and parses
+// the templates in it.
+func loadMsgCatalog(catalogPath string) (*msgCatalog, error) {
+	data, err := ioutil.ReadFile(catalogPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read message catalog")
+	}
+
+	mc := &msgCatalog{
+		path:        catalogPath,
+		messages:    make(map[string]*template.Template),
+		resolutions: make(map[int]*template.Template),
+	}
+	if err := yaml.UnmarshalStrict(data, mc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse message catalog %s", catalogPath)
+	}
+
+	for id, text := range mc.Messages {
+		if id != msgError && id != msgResolution {
+			return nil, errors.Errorf("message catalog %s: unknown message %q",
+				catalogPath, id)
+		}
+		tmpl, err := parseMsgTemplate(id, text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "message catalog %s: message %q",
+				catalogPath, id)
+		}
+		mc.messages[id] = tmpl
+	}
+	for code, text := range mc.Resolutions {
+		tmpl, err := parseMsgTemplate("resolution", text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "message catalog %s: resolution for code %d",
+				catalogPath, code)
+		}
+		mc.resolutions[code] = tmpl
+	}
+
+	return mc, nil
+}
+
+func (mc *msgCatalog) execute(tmpl *template.Template, data *msgData) (string, error) {
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", errors.Wrapf(err, "message catalog %s", mc.path)
+	}
+	return out.String(), nil
+}
+
+// errorMessages returns the messages to be displayed for the error that dmg
+// exits with, any message that cannot be rendered from the catalog is
+// displayed in its default format instead.
+func (mc *msgCatalog) errorMessages(cmdName string, err error) []string {
+	data := &msgData{
+		Command: cmdName,
+		Error:   err.Error(),
+	}
+
+	f, _ := errors.Cause(err).(*fault.Fault)
+	if f != nil {
+		data.Domain = f.Domain
+		data.Code = int(f.Code)
+		data.Description = f.Description
+		data.Reason = f.Reason
+		data.Resolution = f.Resolution
+	}
+
+	var msgs []string
+	if mc == nil {
+		msgs = append(msgs, cmdName+": "+data.Error)
+		if fault.HasResolution(err) {
+			msgs = append(msgs, cmdName+": "+fault.ShowResolutionFor(err))
+		}
+		return msgs
+	}
+	data.Site = mc.Site
+
+	if tmpl, found := mc.messages[msgError]; found {
+		msg, err := mc.execute(tmpl, data)
+		if err == nil {
+			msgs = append(msgs, msg)
+		} else {
+			msgs = append(msgs, cmdName+": "+data.Error, cmdName+": "+err.Error())
+		}
+	} else {
+		msgs = append(msgs, cmdName+": "+data.Error)
+	}
+
+	if f == nil {
+		return msgs
+	}
+	if tmpl, found := mc.resolutions[data.Code]; found {
+		resolution, err := mc.execute(tmpl, data)
+		if err == nil {
+			data.Resolution = resolution
+		} else {
+			msgs = append(msgs, cmdName+": "+err.Error())
+		}
+	}
+	if data.Resolution == fault.ResolutionEmpty {
+		return msgs
+	}
+
+	if tmpl, found := mc.messages[msgResolution]; found {
+		msg, err := mc.execute(tmpl, data)
+		if err == nil {
+			return append(msgs, msg)
+		}
+		msgs = append(msgs, cmdName+": "+err.Error())
+	}
+	withResolution := *f
+	withResolution.Resolution = data.Resolution
+
+	return append(msgs, cmdName+": "+fault.ShowResolutionFor(&withResolution))
+}
+
+// loadCfgMsgCatalog loads the message catalog referenced by the control
+// configuration, if any.
+func loadCfgMsgCatalog(cfg *control.Config) (*msgCatalog, error) {
+	if cfg.MessageCatalog == "" {
+		return nil, nil
+	}
+
+	return loadMsgCatalog(cfg.MessageCatalog)
+}
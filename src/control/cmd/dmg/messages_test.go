@@ -0,0 +1,183 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/fault"
+)
+
+func TestDmg_loadMsgCatalog(t *testing.T) {
+	for name, tc := range map[string]struct {
+		catalog string
+		expErr  error
+	}{
+		"empty": {},
+		"valid": {
+			catalog: strings.Join([]string{
+				"site: {tickets: 'https://tickets.example.com'}",
+				"messages:",
+				"  error: 'Fehler: {{.Error}}'",
+				"  resolution: 'Lösung: {{.Resolution}}'",
+				"resolutions:",
+				"  201: 'open a ticket at {{.Site.tickets}}'",
+			}, "\n"),
+		},
+		"unknown key": {
+			catalog: "templates: {error: '{{.Error}}'}",
+			expErr:  errors.New("failed to parse"),
+		},
+		"unknown message": {
+			catalog: "messages: {warning: '{{.Error}}'}",
+			expErr:  errors.New("unknown message \"warning\""),
+		},
+		"bad message template": {
+			catalog: "messages: {error: '{{.Error'}",
+			expErr:  errors.New("message \"error\""),
+		},
+		"bad resolution template": {
+			catalog: "resolutions: {201: '{{if}}'}",
+			expErr:  errors.New("resolution for code 201"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			catalogPath := filepath.Join(dir, "messages.yml")
+			writeTestFile(t, catalogPath, tc.catalog)
+
+			_, err := loadMsgCatalog(catalogPath)
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDmg_msgCatalog_errorMessages(t *testing.T) {
+	testFault := &fault.Fault{
+		Domain:      "storage",
+		Code:        201,
+		Description: "scm not found",
+		Resolution:  "check the config",
+	}
+	noResFault := &fault.Fault{
+		Domain:      "storage",
+		Code:        202,
+		Description: "nvme not found",
+	}
+
+	for name, tc := range map[string]struct {
+		catalog   string
+		err       error
+		expMsgs   []string
+		expPrefix bool
+	}{
+		"no catalog; error": {
+			err:     errors.New("failed"),
+			expMsgs: []string{"dmg: failed"},
+		},
+		"no catalog; fault": {
+			err: testFault,
+			expMsgs: []string{
+				"dmg: " + testFault.Error(),
+				"dmg: " + fault.ShowResolutionFor(testFault),
+			},
+		},
+		"empty catalog; fault": {
+			catalog: "site: {}",
+			err:     testFault,
+			expMsgs: []string{
+				"dmg: " + testFault.Error(),
+				"dmg: " + fault.ShowResolutionFor(testFault),
+			},
+		},
+		"templated error": {
+			catalog: "messages: {error: '{{.Command}} a échoué : {{.Error}}'}",
+			err:     errors.New("failed"),
+			expMsgs: []string{"dmg a échoué : failed"},
+		},
+		"templated fault": {
+			catalog: strings.Join([]string{
+				"site: {tickets: 'https://tickets.example.com'}",
+				"messages:",
+				"  error: '{{.Command}}: {{.Domain}} {{.Code}}: {{.Description}}'",
+				"  resolution: '{{.Command}}: {{.Resolution}}, see {{.Site.tickets}}/{{.Code}}'",
+			}, "\n"),
+			err: errors.Wrap(testFault, "format failed"),
+			expMsgs: []string{
+				"dmg: storage 201: scm not found",
+				"dmg: check the config, see https://tickets.example.com/201",
+			},
+		},
+		"resolution added for fault code": {
+			catalog: strings.Join([]string{
+				"site: {tickets: 'https://tickets.example.com'}",
+				"resolutions:",
+				"  202: 'open a ticket at {{.Site.tickets}}'",
+			}, "\n"),
+			err: noResFault,
+			expMsgs: []string{
+				"dmg: " + noResFault.Error(),
+				"dmg: " + fault.ShowResolutionFor(&fault.Fault{
+					Domain:     "storage",
+					Code:       202,
+					Resolution: "open a ticket at https://tickets.example.com",
+				}),
+			},
+		},
+		"missing site value": {
+			catalog: "messages: {error: '{{.Error}} {{.Site.tickets}}'}",
+			err:     errors.New("failed"),
+			expMsgs: []string{"failed "},
+		},
+		"template execution failure": {
+			catalog: "messages: {error: '{{.Error.Unknown}}'}",
+			err:     errors.New("failed"),
+			expMsgs: []string{
+				"dmg: failed",
+				"dmg: message catalog",
+			},
+			expPrefix: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mc *msgCatalog
+			if tc.catalog != "" {
+				dir, cleanup := common.CreateTestDir(t)
+				defer cleanup()
+
+				catalogPath := filepath.Join(dir, "messages.yml")
+				writeTestFile(t, catalogPath, tc.catalog)
+
+				var err error
+				if mc, err = loadMsgCatalog(catalogPath); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotMsgs := mc.errorMessages("dmg", tc.err)
+			if tc.expPrefix {
+				// execution errors include details from text/template
+				for i := range gotMsgs {
+					if i < len(tc.expMsgs) && strings.HasPrefix(gotMsgs[i], tc.expMsgs[i]) {
+						gotMsgs[i] = tc.expMsgs[i]
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.expMsgs, gotMsgs); diff != "" {
+				t.Fatalf("unexpected messages (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -10,13 +10,19 @@ import (
 	"context"
 	"strings"
 
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 // NetCmd is the struct representing the top-level network subcommand.
 type NetCmd struct {
-	Scan networkScanCmd `command:"scan" description:"Scan for network interface devices on remote servers"`
+	Scan     networkScanCmd     `command:"scan" description:"Scan for network interface devices on remote servers"`
+	SelfTest networkSelfTestCmd `command:"self-test" description:"Measure fabric latency and bandwidth between I/O Engine ranks"`
 }
 
 // networkScanCmd is the struct representing the command to scan the machine for network interface devices
@@ -62,3 +68,96 @@ func (cmd *networkScanCmd) Execute(_ []string) error {
 
 	return resp.Errors()
 }
+
+// networkSelfTestCmd is the struct representing the command to measure fabric
+// performance between I/O Engine ranks. The test is driven from a single
+// host, by default the first host in the configured hostlist.
+type networkSelfTestCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+	SrcRanks    string `long:"src-ranks" required:"1" description:"Ranks sending test messages, e.g. 0-1"`
+	DstRanks    string `long:"dst-ranks" required:"1" description:"Ranks receiving test messages, e.g. 2-5"`
+	Sizes       string `long:"sizes" default:"4KiB,1MiB" description:"Comma-separated list of message sizes to test"`
+	Repetitions uint32 `long:"repetitions" default:"10000" description:"Number of messages to send per size"`
+	MaxInflight uint32 `long:"max-inflight" default:"16" description:"Maximum concurrent messages per source rank"`
+}
+
+// testHost returns the host from which the test should be run.
+func (cmd *networkSelfTestCmd) testHost() ([]string, error) {
+	hosts := cmd.hostlist
+	if len(hosts) == 0 && cmd.config != nil {
+		hosts = cmd.config.HostList
+	}
+	if len(hosts) == 0 {
+		return nil, errors.New("no host specified to run network self-test from")
+	}
+
+	hs, err := hostlist.CreateSet(strings.Join(hosts, ","))
+	if err != nil {
+		return nil, err
+	}
+	if len(cmd.hostlist) > 0 && hs.Count() > 1 {
+		return nil, errors.New("network self-test must be run from a single host")
+	}
+	return hs.Slice()[:1], nil
+}
+
+// Execute is run when networkSelfTestCmd activates.
+func (cmd *networkSelfTestCmd) Execute(_ []string) error {
+	srcRanks, err := system.CreateRankSet(cmd.SrcRanks)
+	if err != nil {
+		return errors.Wrap(err, "--src-ranks")
+	}
+	dstRanks, err := system.CreateRankSet(cmd.DstRanks)
+	if err != nil {
+		return errors.Wrap(err, "--dst-ranks")
+	}
+
+	req := &control.NetworkSelfTestReq{
+		Repetitions: cmd.Repetitions,
+		MaxInflight: cmd.MaxInflight,
+	}
+	req.SrcRanks.ReplaceSet(srcRanks)
+	req.DstRanks.ReplaceSet(dstRanks)
+	for _, size := range strings.Split(cmd.Sizes, ",") {
+		bytes, err := humanize.ParseBytes(strings.TrimSpace(size))
+		if err != nil {
+			return errors.Wrapf(err, "invalid message size %q", size)
+		}
+		req.MsgSizes = append(req.MsgSizes, uint32(bytes))
+	}
+
+	host, err := cmd.testHost()
+	if err != nil {
+		return err
+	}
+	req.SetHostList(host)
+
+	cmd.log.Debugf("network self-test req: %+v", req)
+
+	ctx := context.Background()
+	resp, err := control.NetworkSelfTest(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+
+	if err := pretty.PrintNetworkSelfTestResults(resp.Results, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
@@ -10,7 +10,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 func TestNetworkCommands(t *testing.T) {
@@ -43,5 +47,60 @@ func TestNetworkCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Perform network self-test from default host",
+			"network self-test --src-ranks 0 --dst-ranks 1-2",
+			strings.Join([]string{
+				printRequest(t, mockAttachInfoReq()),
+				printRequest(t, mockSelfTestReq("0", "1-2", []uint32{4096, 1048576},
+					10000, 16, "localhost:10001")),
+			}, " "),
+			nil,
+		},
+		{
+			"Perform network self-test from specific host",
+			"network self-test -l foo-1 --src-ranks 0-1 --dst-ranks 2 --sizes 8KiB --repetitions 100 --max-inflight 4",
+			strings.Join([]string{
+				printRequest(t, mockAttachInfoReq()),
+				printRequest(t, mockSelfTestReq("0-1", "2", []uint32{8192}, 100, 4, "foo-1")),
+			}, " "),
+			nil,
+		},
+		{
+			"Perform network self-test from multiple hosts",
+			"network self-test -l foo-[1-2] --src-ranks 0 --dst-ranks 1",
+			"",
+			errors.New("single host"),
+		},
+		{
+			"Perform network self-test with bad size",
+			"network self-test --src-ranks 0 --dst-ranks 1 --sizes 4KiB,huge",
+			"",
+			errors.New("invalid message size"),
+		},
+		{
+			"Perform network self-test with bad ranks",
+			"network self-test --src-ranks 0 --dst-ranks foo",
+			"",
+			errors.New("--dst-ranks"),
+		},
 	})
 }
+
+func mockAttachInfoReq() *control.GetAttachInfoReq {
+	req := &control.GetAttachInfoReq{AllRanks: true}
+	req.SetSystem(build.DefaultSystemName)
+	return req
+}
+
+func mockSelfTestReq(src, dst string, sizes []uint32, reps, inflight uint32, host string) *control.NetworkSelfTestReq {
+	req := &control.NetworkSelfTestReq{
+		MsgSizes:    sizes,
+		Repetitions: reps,
+		MaxInflight: inflight,
+	}
+	req.SrcRanks.ReplaceSet(system.MustCreateRankSet(src))
+	req.DstRanks.ReplaceSet(system.MustCreateRankSet(dst))
+	req.SetHostList([]string{host})
+	return req
+}
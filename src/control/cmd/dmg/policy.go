@@ -0,0 +1,206 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// confirmInput is read for typed confirmations of destructive commands.
+var confirmInput io.Reader = os.Stdin
+
+// promptConfirm asks the user to type the expected value and returns an error
+// if a different value is read from confirmInput.
+func promptConfirm(log logging.Logger, expect string) error {
+	log.Infof("Type %q to continue: ", expect)
+	response, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return errors.Wrap(err, "failed to read confirmation")
+	}
+	if strings.TrimSpace(response) != expect {
+		return errors.New("confirmation not given")
+	}
+
+	return nil
+}
+
+type (
+	// cmdPolicy describes restrictions on the commands that dmg will run,
+	// commands are identified by their name without the "dmg" prefix and an
+	// entry also applies to all subcommands of the named command.
+	cmdPolicy struct {
+		// commands requiring the target to be typed before running
+		Confirm []string `yaml:"confirm"`
+		// commands that may not be run at all
+		Forbid []string `yaml:"forbid"`
+		// pool labels or UUIDs, shell patterns are accepted, and host
+		// sets which commands in either list above may not target
+		ProtectedPools []string `yaml:"protected_pools"`
+		ProtectedHosts []string `yaml:"protected_hosts"`
+		path           string
+	}
+
+	// poolTargeter is implemented by commands operating on a single pool.
+	poolTargeter interface {
+		targetPool() string
+	}
+)
+
+func (cmd *poolCmd) targetPool() string {
+	return cmd.ID
+}
+
+// loadCmdPolicy reads and validates the command policy file at the given path.
+func loadCmdPolicy(policyPath string) (*cmdPolicy, error) {
+	data, err := ioutil.ReadFile(policyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read command policy")
+	}
+
+	cp := &cmdPolicy{path: policyPath}
+	if err := yaml.UnmarshalStrict(data, cp); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse command policy %s", policyPath)
+	}
+
+	for _, pattern := range cp.ProtectedPools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "command policy %s: protected pool %q",
+				policyPath, pattern)
+		}
+	}
+	for _, hosts := range cp.ProtectedHosts {
+		if _, err := hostlist.CreateSet(hosts); err != nil {
+			return nil, errors.Wrapf(err, "command policy %s: protected hosts %q",
+				policyPath, hosts)
+		}
+	}
+
+	return cp, nil
+}
+
+func matchCmd(entries []string, name string) bool {
+	for _, entry := range entries {
+		entry = strings.Join(strings.Fields(entry), " ")
+		if name == entry || strings.HasPrefix(name, entry+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func (cp *cmdPolicy) protectedPool(poolID string) bool {
+	for _, pattern := range cp.ProtectedPools {
+		if matched, _ := path.Match(pattern, poolID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (cp *cmdPolicy) protectedHosts(hosts []string) (string, error) {
+	if len(hosts) == 0 || len(cp.ProtectedHosts) == 0 {
+		return "", nil
+	}
+
+	names := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		names = append(names, strings.Split(host, ":")[0])
+	}
+	for _, protected := range cp.ProtectedHosts {
+		hs, err := hostlist.CreateSet(protected)
+		if err != nil {
+			return "", err
+		}
+		matched, err := hs.Intersects(strings.Join(names, ","))
+		if err != nil {
+			return "", err
+		}
+		if matched.Count() > 0 {
+			return matched.String(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// check returns an error if the policy doesn't allow the named command to be
+// run. Commands requiring confirmation prompt for the pool ID or, for commands
+// that don't target a pool, the command name to be typed.
+func (cp *cmdPolicy) check(log logging.Logger, name string, cmd flags.Commander, hosts []string, jsonOutput bool) error {
+	if matchCmd(cp.Forbid, name) {
+		return errors.Errorf("dmg %s is forbidden by command policy %s", name, cp.path)
+	}
+	if !matchCmd(cp.Confirm, name) {
+		return nil
+	}
+
+	expect := name
+	if pt, ok := cmd.(poolTargeter); ok && pt.targetPool() != "" {
+		expect = pt.targetPool()
+		if cp.protectedPool(expect) {
+			return errors.Errorf("dmg %s on protected pool %s is forbidden by command policy %s",
+				name, expect, cp.path)
+		}
+	}
+	if _, ok := cmd.(hostListSetter); ok {
+		protected, err := cp.protectedHosts(hosts)
+		if err != nil {
+			return err
+		}
+		if protected != "" {
+			return errors.Errorf("dmg %s on protected hosts %s is forbidden by command policy %s",
+				name, protected, cp.path)
+		}
+	}
+
+	if jsonOutput {
+		return errors.Errorf("dmg %s requires confirmation by command policy %s, "+
+			"which is not possible with --json", name, cp.path)
+	}
+
+	log.Infof("dmg %s requires confirmation by command policy %s.\n", name, cp.path)
+	return promptConfirm(log, expect)
+}
+
+// activeCmdName returns the space-separated name of the command selected on
+// the command line, e.g. "pool destroy".
+func activeCmdName(p *flags.Parser) string {
+	var names []string
+	for c := p.Active; c != nil; c = c.Active {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// checkCmdPolicy enforces the command policy referenced by the control
+// configuration, if any, before the command is run.
+func checkCmdPolicy(log logging.Logger, cfg *control.Config, name string, cmd flags.Commander, jsonOutput bool) error {
+	if cfg.CommandPolicy == "" {
+		return nil
+	}
+
+	cp, err := loadCmdPolicy(cfg.CommandPolicy)
+	if err != nil {
+		return err
+	}
+	log.Debugf("command policy loaded from %s", cp.path)
+
+	return cp.check(log, name, cmd, cfg.HostList, jsonOutput)
+}
@@ -0,0 +1,176 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDmg_loadCmdPolicy(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy string
+		expErr error
+	}{
+		"empty": {},
+		"valid": {
+			policy: strings.Join([]string{
+				"confirm: ['pool destroy', 'system stop']",
+				"forbid: ['system erase']",
+				"protected_pools: ['prod-*']",
+				"protected_hosts: ['prod-[1-4]']",
+			}, "\n"),
+		},
+		"unknown key": {
+			policy: "deny: ['system erase']",
+			expErr: errors.New("failed to parse"),
+		},
+		"bad pool pattern": {
+			policy: "protected_pools: ['prod-[']",
+			expErr: errors.New("protected pool"),
+		},
+		"bad host set": {
+			policy: "protected_hosts: ['prod-[4-1]']",
+			expErr: errors.New("protected hosts"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			policyPath := filepath.Join(dir, "policy.yml")
+			writeTestFile(t, policyPath, tc.policy)
+
+			_, err := loadCmdPolicy(policyPath)
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestDmg_cmdPolicy(t *testing.T) {
+	policy := strings.Join([]string{
+		"confirm: ['pool destroy', 'system stop', 'storage format']",
+		"forbid: ['system erase', 'pool  evict']",
+		"protected_pools: ['prod-*']",
+		"protected_hosts: ['prod-[1-4]']",
+	}, "\n")
+
+	for name, tc := range map[string]struct {
+		cmd      string
+		input    string
+		noPolicy bool
+		expCalls bool
+		expErr   error
+	}{
+		"not in policy": {
+			cmd:      "system query",
+			expCalls: true,
+		},
+		"no policy configured": {
+			noPolicy: true,
+			cmd:      "system erase --confirm daos_server",
+			expCalls: true,
+		},
+		"forbidden": {
+			cmd:    "system erase",
+			expErr: errors.New("dmg system erase is forbidden"),
+		},
+		"forbidden with extra whitespace in policy": {
+			cmd:    "pool evict --pool test",
+			expErr: errors.New("dmg pool evict is forbidden"),
+		},
+		"pool confirmed": {
+			cmd:      "pool destroy --pool test",
+			input:    "test\n",
+			expCalls: true,
+		},
+		"pool not confirmed": {
+			cmd:    "pool destroy --pool test",
+			input:  "pool destroy\n",
+			expErr: errors.New("confirmation not given"),
+		},
+		"no confirmation input": {
+			cmd:    "pool destroy --pool test",
+			expErr: errors.New("confirmation not given"),
+		},
+		"protected pool": {
+			cmd:    "pool destroy --pool prod-1",
+			input:  "prod-1\n",
+			expErr: errors.New("protected pool prod-1 is forbidden"),
+		},
+		"command confirmed": {
+			cmd:      "system stop",
+			input:    "system stop\n",
+			expCalls: true,
+		},
+		"confirmation with json output": {
+			cmd:    "-j system stop",
+			input:  "system stop\n",
+			expErr: errors.New("not possible with --json"),
+		},
+		"protected host": {
+			cmd:    "-l prod-2:10001 storage format",
+			input:  "storage format\n",
+			expErr: errors.New("protected hosts prod-2 is forbidden"),
+		},
+		"unprotected host": {
+			cmd:      "-l dev-2 storage format",
+			input:    "storage format\n",
+			expCalls: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			dir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			policyPath := filepath.Join(dir, "policy.yml")
+			writeTestFile(t, policyPath, policy)
+			cfgPath := filepath.Join(dir, "daos_control.yml")
+			cfg := ""
+			if !tc.noPolicy {
+				cfg = fmt.Sprintf("command_policy: %s\n", policyPath)
+			}
+			writeTestFile(t, cfgPath, cfg)
+
+			confirmInput = strings.NewReader(tc.input)
+			defer func() {
+				confirmInput = os.Stdin
+			}()
+
+			conn := newTestConn(t)
+			bridge := &bridgeConnInvoker{
+				MockInvoker: *control.DefaultMockInvoker(log),
+				t:           t,
+				conn:        conn,
+			}
+			err := runCmd(t, "-o "+cfgPath+" "+tc.cmd, log, bridge)
+			common.CmpErr(t, tc.expErr, err)
+			common.AssertEqual(t, tc.expCalls, len(conn.called) > 0, "unexpected RPC calls")
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ import (
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -28,13 +30,15 @@ import (
 type PoolCmd struct {
 	Create       PoolCreateCmd       `command:"create" alias:"c" description:"Create a DAOS pool"`
 	Destroy      PoolDestroyCmd      `command:"destroy" alias:"d" description:"Destroy a DAOS pool"`
-	Evict        PoolEvictCmd        `command:"evict" alias:"ev" description:"Evict all pool connections to a DAOS pool"`
+	Restore      PoolRestoreCmd      `command:"restore" description:"Restore a DAOS pool that is pending a delayed destroy"`
+	Evict        PoolEvictCmd        `command:"evict" alias:"ev" description:"Evict connections to a DAOS pool"`
 	List         PoolListCmd         `command:"list" alias:"l" description:"List DAOS pools"`
 	Extend       PoolExtendCmd       `command:"extend" alias:"ext" description:"Extend a DAOS pool to include new ranks."`
 	Exclude      PoolExcludeCmd      `command:"exclude" alias:"e" description:"Exclude targets from a rank"`
 	Drain        PoolDrainCmd        `command:"drain" alias:"d" description:"Drain targets from a rank"`
 	Reintegrate  PoolReintegrateCmd  `command:"reintegrate" alias:"r" description:"Reintegrate targets for a rank"`
 	Query        PoolQueryCmd        `command:"query" alias:"q" description:"Query a DAOS pool"`
+	CheckFit     PoolCheckFitCmd     `command:"check-fit" alias:"cf" description:"Check whether an additional allocation would fit in a DAOS pool"`
 	GetACL       PoolGetACLCmd       `command:"get-acl" alias:"ga" description:"Get a DAOS pool's Access Control List"`
 	OverwriteACL PoolOverwriteACLCmd `command:"overwrite-acl" alias:"oa" description:"Overwrite a DAOS pool's Access Control List"`
 	UpdateACL    PoolUpdateACLCmd    `command:"update-acl" alias:"ua" description:"Update entries in a DAOS pool's Access Control List"`
@@ -48,6 +52,7 @@ type PoolCreateCmd struct {
 	cfgCmd
 	ctlInvokerCmd
 	jsonOutputCmd
+	paramsCmd
 	GroupName  string  `short:"g" long:"group" description:"DAOS pool to be owned by given group, format name@domain"`
 	UserName   string  `short:"u" long:"user" description:"DAOS pool to be owned by given user, format name@domain"`
 	PoolLabel  string  `short:"p" long:"label" description:"Unique label for pool"`
@@ -55,10 +60,35 @@ type PoolCreateCmd struct {
 	Size       string  `short:"z" long:"size" description:"Total size of DAOS pool (auto)"`
 	ScmRatio   float64 `short:"t" long:"scm-ratio" default:"6" description:"Percentage of SCM:NVMe for pool storage (auto)"`
 	NumRanks   uint32  `short:"k" long:"nranks" description:"Number of ranks to use (auto)"`
+	MinDomains uint32  `long:"min-domains" description:"Minimum number of fault domains across which pool ranks must be spread"`
+	RankStrat  string  `long:"rank-strategy" choice:"spread" choice:"pack" choice:"balanced" description:"Strategy for selecting pool ranks by free capacity and health when --ranks is not supplied (default spread)"`
 	NumSvcReps uint32  `short:"v" long:"nsvc" description:"Number of pool service replicas"`
 	ScmSize    string  `short:"s" long:"scm-size" description:"Per-server SCM allocation for DAOS pool (manual)"`
 	NVMeSize   string  `short:"n" long:"nvme-size" description:"Per-server NVMe allocation for DAOS pool (manual)"`
 	RankList   string  `short:"r" long:"ranks" description:"Storage server unique identifiers (ranks) for DAOS pool"`
+	Properties string  `short:"P" long:"properties" description:"Pool properties to be set, overriding system defaults (e.g. reclaim:lazy,self_heal:exclude)"`
+	Verbose    bool    `long:"verbose" description:"Show the time taken by each phase of the pool create"`
+}
+
+// parsePoolProperties parses a comma-separated list of name:value pool
+// properties.
+func parsePoolProperties(in string) ([]*control.PoolProperty, error) {
+	var props []*control.PoolProperty
+	for _, field := range strings.Split(in, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" || strings.TrimSpace(kv[1]) == "" {
+			return nil, errors.Errorf("invalid pool property %q (expected name:value)", field)
+		}
+		props = append(props, &control.PoolProperty{
+			Name:  strings.TrimSpace(kv[0]),
+			Value: strings.TrimSpace(kv[1]),
+		})
+	}
+
+	return props, nil
 }
 
 // Execute is run when PoolCreateCmd subcommand is activated
@@ -72,10 +102,12 @@ func (cmd *PoolCreateCmd) Execute(args []string) error {
 
 	var err error
 	req := &control.PoolCreateReq{
-		User:       cmd.UserName,
-		UserGroup:  cmd.GroupName,
-		Label:      cmd.PoolLabel,
-		NumSvcReps: cmd.NumSvcReps,
+		User:         cmd.UserName,
+		UserGroup:    cmd.GroupName,
+		Label:        cmd.PoolLabel,
+		NumSvcReps:   cmd.NumSvcReps,
+		MinDomains:   cmd.MinDomains,
+		RankStrategy: cmd.RankStrat,
 	}
 
 	if cmd.ACLFile != "" {
@@ -89,6 +121,14 @@ func (cmd *PoolCreateCmd) Execute(args []string) error {
 	if err != nil {
 		return errors.Wrap(err, "parsing rank list")
 	}
+	if cmd.RankStrat != "" && cmd.RankList != "" {
+		return errIncompatFlags("rank-strategy", "ranks")
+	}
+
+	req.Properties, err = parsePoolProperties(cmd.Properties)
+	if err != nil {
+		return err
+	}
 
 	if cmd.Size != "" {
 		// auto-selection of storage values
@@ -150,7 +190,7 @@ func (cmd *PoolCreateCmd) Execute(args []string) error {
 	}
 
 	var bld strings.Builder
-	if err := pretty.PrintPoolCreateResponse(resp, &bld); err != nil {
+	if err := pretty.PrintPoolCreateResponse(resp, &bld, pretty.PrintWithVerboseOutput(cmd.Verbose)); err != nil {
 		return err
 	}
 	cmd.log.Info(bld.String())
@@ -164,6 +204,7 @@ type PoolListCmd struct {
 	cfgCmd
 	ctlInvokerCmd
 	jsonOutputCmd
+	outputFormatCmd
 }
 
 // Execute is run when PoolListCmd activates
@@ -188,7 +229,11 @@ func (cmd *PoolListCmd) Execute(_ []string) (errOut error) {
 	}
 
 	var out strings.Builder
-	if err := pretty.PrintListPoolsResponse(&out, resp); err != nil {
+	if cmd.csvOutputEnabled() {
+		if err := pretty.PrintListPoolsCSV(&out, resp); err != nil {
+			return err
+		}
+	} else if err := pretty.PrintListPoolsResponse(&out, resp); err != nil {
 		return err
 	}
 	cmd.log.Info(out.String())
@@ -234,7 +279,8 @@ func (cmd *poolCmd) resolveID() error {
 type PoolDestroyCmd struct {
 	poolCmd
 	// TODO: implement --sys & --svc options (currently unsupported server side)
-	Force bool `short:"f" long:"force" description:"Force removal of DAOS pool"`
+	Force bool          `short:"f" long:"force" description:"Force removal of DAOS pool"`
+	Delay time.Duration `long:"delay" description:"Disable the pool and only destroy it after this period (e.g. 24h), allowing it to be restored"`
 }
 
 // Execute is run when PoolDestroyCmd subcommand is activated
@@ -245,11 +291,13 @@ func (cmd *PoolDestroyCmd) Execute(args []string) error {
 		return err
 	}
 
-	req := &control.PoolDestroyReq{UUID: cmd.UUID, Force: cmd.Force}
+	req := &control.PoolDestroyReq{UUID: cmd.UUID, Force: cmd.Force, Delay: cmd.Delay}
 
 	err := control.PoolDestroy(context.Background(), cmd.ctlInvoker, req)
 	if err != nil {
 		msg = errors.WithMessage(err, "failed").Error()
+	} else if cmd.Delay > 0 {
+		msg = fmt.Sprintf("succeeded, pool disabled and will be destroyed in %s", cmd.Delay)
 	}
 
 	cmd.log.Infof("Pool-destroy command %s\n", msg)
@@ -257,24 +305,77 @@ func (cmd *PoolDestroyCmd) Execute(args []string) error {
 	return err
 }
 
+// PoolRestoreCmd is the struct representing the command to restore a DAOS
+// pool that is pending a delayed destroy.
+type PoolRestoreCmd struct {
+	poolCmd
+}
+
+// Execute is run when PoolRestoreCmd subcommand is activated
+func (cmd *PoolRestoreCmd) Execute(args []string) error {
+	msg := "succeeded"
+
+	if err := cmd.resolveID(); err != nil {
+		return err
+	}
+
+	req := &control.PoolRestoreReq{UUID: cmd.UUID}
+
+	err := control.PoolRestore(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		msg = errors.WithMessage(err, "failed").Error()
+	}
+
+	cmd.log.Infof("Pool-restore command %s\n", msg)
+
+	return err
+}
+
 // PoolEvictCmd is the struct representing the command to evict a DAOS pool.
 type PoolEvictCmd struct {
 	poolCmd
+	Machines string `short:"m" long:"machines" description:"Only evict the connections from these machines (e.g. node[1-4])"`
+	Handles  string `long:"handles" description:"Comma-separated list of the pool handles to evict"`
 }
 
 // Execute is run when PoolEvictCmd subcommand is activated
 func (cmd *PoolEvictCmd) Execute(args []string) error {
 	msg := "succeeded"
 
+	if cmd.Machines != "" && cmd.Handles != "" {
+		return errIncompatFlags("machines", "handles")
+	}
 	if err := cmd.resolveID(); err != nil {
 		return err
 	}
 
-	req := &control.PoolEvictReq{UUID: cmd.UUID}
+	reqs := []*control.PoolEvictReq{{UUID: cmd.UUID}}
+	if cmd.Handles != "" {
+		reqs[0].Handles = strings.Split(cmd.Handles, ",")
+	}
+	if cmd.Machines != "" {
+		machines, err := hostlist.CreateSet(cmd.Machines)
+		if err != nil {
+			return err
+		}
+		reqs = nil
+		for _, machine := range machines.Slice() {
+			reqs = append(reqs, &control.PoolEvictReq{
+				UUID:    cmd.UUID,
+				Machine: machine,
+			})
+		}
+	}
 
-	err := control.PoolEvict(context.Background(), cmd.ctlInvoker, req)
-	if err != nil {
-		msg = errors.WithMessage(err, "failed").Error()
+	var err error
+	for _, req := range reqs {
+		if err = control.PoolEvict(context.Background(), cmd.ctlInvoker, req); err != nil {
+			if req.Machine != "" {
+				err = errors.Wrapf(err, "machine %s", req.Machine)
+			}
+			msg = errors.WithMessage(err, "failed").Error()
+			break
+		}
 	}
 
 	cmd.log.Infof("Pool-evict command %s\n", msg)
@@ -438,6 +539,8 @@ func (cmd *PoolReintegrateCmd) Execute(args []string) error {
 // PoolQueryCmd is the struct representing the command to query a DAOS pool.
 type PoolQueryCmd struct {
 	poolCmd
+	Targets     bool `short:"t" long:"targets" description:"Include per-target space usage and health"`
+	ShowDevices bool `short:"D" long:"show-devices" description:"Include the NVMe SSD and SCM namespace backing each target (implies --targets)"`
 }
 
 // Execute is run when PoolQueryCmd subcommand is activated
@@ -446,13 +549,29 @@ func (cmd *PoolQueryCmd) Execute(args []string) error {
 		return err
 	}
 
+	ctx := context.Background()
 	req := &control.PoolQueryReq{
 		UUID: cmd.UUID,
 	}
 
-	resp, err := control.PoolQuery(context.Background(), cmd.ctlInvoker, req)
+	resp, err := control.PoolQuery(ctx, cmd.ctlInvoker, req)
+
+	var tgtResp *control.PoolQueryTargetsResp
+	if err == nil && (cmd.Targets || cmd.ShowDevices) {
+		tgtResp, err = control.PoolQueryTargets(ctx, cmd.ctlInvoker, &control.PoolQueryTargetsReq{
+			UUID:        cmd.UUID,
+			Rank:        system.NilRank,
+			ShowDevices: cmd.ShowDevices,
+		})
+		if err == nil {
+			resp.Targets = tgtResp.Targets
+		}
+	}
 
 	if cmd.jsonOutputEnabled() {
+		if err == nil && tgtResp != nil {
+			err = tgtResp.Errors()
+		}
 		return cmd.outputJSON(resp, err)
 	}
 
@@ -461,10 +580,83 @@ func (cmd *PoolQueryCmd) Execute(args []string) error {
 	}
 
 	var bld strings.Builder
+	if tgtResp != nil {
+		if err := pretty.PrintResponseErrors(tgtResp, &bld); err != nil {
+			return err
+		}
+	}
 	if err := pretty.PrintPoolQueryResponse(resp, &bld); err != nil {
 		return err
 	}
 	cmd.log.Info(bld.String())
+
+	if tgtResp != nil {
+		return tgtResp.Errors()
+	}
+	return nil
+}
+
+// PoolCheckFitCmd is the struct representing the command to check whether an
+// additional allocation would fit in a DAOS pool without allocating it.
+type PoolCheckFitCmd struct {
+	poolCmd
+	Size       string  `short:"z" long:"size" required:"1" description:"Size of the user data to be allocated"`
+	Redundancy string  `short:"r" long:"redundancy" default:"none" description:"Redundancy scheme of the allocation (e.g. EC_4P2, RP_3 or none)"`
+	ScmRatio   float64 `short:"t" long:"scm-ratio" default:"6" description:"Percentage of SCM:NVMe expected for the allocation"`
+}
+
+// Execute is run when PoolCheckFitCmd subcommand is activated
+func (cmd *PoolCheckFitCmd) Execute(args []string) error {
+	if err := cmd.resolveID(); err != nil {
+		return err
+	}
+
+	req := &control.PoolCheckFitReq{
+		UUID: cmd.UUID,
+	}
+
+	var err error
+	req.Size, err = humanize.ParseBytes(cmd.Size)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse allocation size")
+	}
+	req.Redundancy, err = control.ParseRedundancyScheme(cmd.Redundancy)
+	if err != nil {
+		return err
+	}
+	if cmd.ScmRatio < 0 || cmd.ScmRatio > 100 {
+		return errors.New("SCM:NVMe ratio must be a value between 0-100")
+	}
+	req.ScmRatio = cmd.ScmRatio / 100
+
+	resp, err := control.PoolCheckFit(context.Background(), cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		if err == nil {
+			err = resp.Errors()
+		}
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "pool check-fit failed")
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintPoolCheckFitResponse(resp, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	if err := resp.Errors(); err != nil {
+		return err
+	}
+	if !resp.Fits {
+		return errors.New("allocation does not fit in pool")
+	}
 	return nil
 }
 
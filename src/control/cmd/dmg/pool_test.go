@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -144,6 +145,88 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Create pool with minimum fault domains",
+			fmt.Sprintf("pool create --size %s --nranks 8 --min-domains 4", testScmSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes: uint64(testScmSize),
+					ScmRatio:   0.06,
+					NumRanks:   8,
+					MinDomains: 4,
+					User:       eUsr.Username + "@",
+					UserGroup:  eGrp.Name + "@",
+					Ranks:      []system.Rank{},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool with rank strategy",
+			fmt.Sprintf("pool create --size %s --nranks 4 --rank-strategy pack", testScmSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes:   uint64(testScmSize),
+					ScmRatio:     0.06,
+					NumRanks:     4,
+					RankStrategy: "pack",
+					User:         eUsr.Username + "@",
+					UserGroup:    eGrp.Name + "@",
+					Ranks:        []system.Rank{},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool with verbose output",
+			fmt.Sprintf("pool create --size %s --verbose", testScmSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes: uint64(testScmSize),
+					ScmRatio:   0.06,
+					User:       eUsr.Username + "@",
+					UserGroup:  eGrp.Name + "@",
+					Ranks:      []system.Rank{},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool with rank strategy and ranks",
+			fmt.Sprintf("pool create --size %s --ranks 1,2 --rank-strategy pack", testScmSizeStr),
+			"",
+			errors.New("may not be mixed"),
+		},
+		{
+			"Create pool with unknown rank strategy",
+			fmt.Sprintf("pool create --size %s --rank-strategy random", testScmSizeStr),
+			"",
+			errors.New("Invalid value `random'"),
+		},
+		{
+			"Create pool with properties",
+			fmt.Sprintf("pool create --size %s --properties reclaim:lazy,self_heal:exclude", testScmSizeStr),
+			strings.Join([]string{
+				printRequest(t, &control.PoolCreateReq{
+					TotalBytes: uint64(testScmSize),
+					ScmRatio:   0.06,
+					User:       eUsr.Username + "@",
+					UserGroup:  eGrp.Name + "@",
+					Ranks:      []system.Rank{},
+					Properties: []*control.PoolProperty{
+						{Name: "reclaim", Value: "lazy"},
+						{Name: "self_heal", Value: "exclude"},
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Create pool with malformed properties",
+			fmt.Sprintf("pool create --size %s --properties reclaim", testScmSizeStr),
+			"",
+			errors.New("expected name:value"),
+		},
 		{
 			"Create pool with all arguments",
 			fmt.Sprintf("pool create --scm-size %s --nsvc 3 --user foo --group bar --nvme-size %s --acl-file %s",
@@ -382,6 +465,27 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Destroy pool with delay",
+			"pool destroy --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --delay 24h",
+			strings.Join([]string{
+				printRequest(t, &control.PoolDestroyReq{
+					UUID:  "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+					Delay: 24 * time.Hour,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Restore pool",
+			"pool restore --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+			strings.Join([]string{
+				printRequest(t, &control.PoolRestoreReq{
+					UUID: "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+				}),
+			}, " "),
+			nil,
+		},
 		{
 			"Evict pool",
 			"pool evict --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
@@ -392,6 +496,47 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Evict pool handles",
+			"pool evict --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --handles 00000001-0001-0001-0001-000000000001,00000002-0002-0002-0002-000000000002",
+			strings.Join([]string{
+				printRequest(t, &control.PoolEvictReq{
+					UUID: "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+					Handles: []string{
+						"00000001-0001-0001-0001-000000000001",
+						"00000002-0002-0002-0002-000000000002",
+					},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Evict pool machines",
+			"pool evict --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --machines node[1-2]",
+			strings.Join([]string{
+				printRequest(t, &control.PoolEvictReq{
+					UUID:    "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+					Machine: "node1",
+				}),
+				printRequest(t, &control.PoolEvictReq{
+					UUID:    "031bcaf8-f0f5-42ef-b3c5-ee048676dceb",
+					Machine: "node2",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Evict pool with machines and handles",
+			"pool evict --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --machines node1 --handles 00000001-0001-0001-0001-000000000001",
+			"",
+			errIncompatFlags("machines", "handles"),
+		},
+		{
+			"Evict pool with bad machines",
+			"pool evict --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --machines node[1-",
+			"",
+			errors.New("invalid range"),
+		},
 		{
 			"List pools",
 			"pool list",
@@ -400,6 +545,14 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"List pools CSV",
+			"pool list --format csv",
+			strings.Join([]string{
+				printRequest(t, &control.ListPoolsReq{}),
+			}, " "),
+			nil,
+		},
 		{
 			"Set string pool property",
 			"pool set-prop --pool 031bcaf8-f0f5-42ef-b3c5-ee048676dceb --name reclaim --value lazy",
@@ -596,6 +749,35 @@ func TestPoolCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Query pool with UUID and targets",
+			"pool query --pool 12345678-1234-1234-1234-1234567890ab --targets",
+			strings.Join([]string{
+				printRequest(t, &control.PoolQueryReq{
+					UUID: "12345678-1234-1234-1234-1234567890ab",
+				}),
+				printRequest(t, &control.PoolQueryTargetsReq{
+					UUID: "12345678-1234-1234-1234-1234567890ab",
+					Rank: system.NilRank,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Query pool with UUID and target devices",
+			"pool query --pool 12345678-1234-1234-1234-1234567890ab --show-devices",
+			strings.Join([]string{
+				printRequest(t, &control.PoolQueryReq{
+					UUID: "12345678-1234-1234-1234-1234567890ab",
+				}),
+				printRequest(t, &control.PoolQueryTargetsReq{
+					UUID:        "12345678-1234-1234-1234-1234567890ab",
+					Rank:        system.NilRank,
+					ShowDevices: true,
+				}),
+			}, " "),
+			nil,
+		},
 		{
 			"Query pool with Label",
 			"pool query --pool test-label",
@@ -615,6 +797,35 @@ func TestPoolCommands(t *testing.T) {
 			"",
 			fmt.Errorf("pool ID"),
 		},
+		{
+			"Check allocation fit",
+			"pool check-fit --pool 12345678-1234-1234-1234-1234567890ab --size 1GB --redundancy RP_1",
+			strings.Join([]string{
+				printRequest(t, &control.PoolQueryTargetsReq{
+					UUID: "12345678-1234-1234-1234-1234567890ab",
+					Rank: system.NilRank,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Check allocation fit with too few ranks",
+			"pool check-fit --pool 12345678-1234-1234-1234-1234567890ab --size 1GB --redundancy EC_4P2",
+			"",
+			fmt.Errorf("does not fit"),
+		},
+		{
+			"Check allocation fit with invalid redundancy",
+			"pool check-fit --pool 12345678-1234-1234-1234-1234567890ab --size 1GB --redundancy mirror",
+			"",
+			fmt.Errorf("invalid redundancy scheme"),
+		},
+		{
+			"Check allocation fit with invalid size",
+			"pool check-fit --pool 12345678-1234-1234-1234-1234567890ab --size foo",
+			"",
+			fmt.Errorf("failed to parse allocation size"),
+		},
 		{
 			"Nonexistent subcommand",
 			"pool quack",
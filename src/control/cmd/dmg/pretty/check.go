@@ -0,0 +1,109 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// checkFindingPool returns the label of the pool affected by a finding if it
+// has one, otherwise its UUID.
+func checkFindingPool(f *control.CheckFinding) string {
+	if f.PoolLabel != "" {
+		return f.PoolLabel
+	}
+	return f.PoolUUID
+}
+
+// PrintCheckQueryResponse generates a human-readable representation of the
+// supplied CheckQueryResp struct and writes it to the supplied io.Writer.
+func PrintCheckQueryResponse(out io.Writer, resp *control.CheckQueryResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if resp.RunID == 0 {
+		fmt.Fprintln(out, "No system check has been run")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Check %d %s: %d/%d pools checked, started %s", resp.RunID, resp.Status,
+		resp.PoolsChecked, resp.PoolsTotal, resp.Started.Format(time.RFC3339))
+	if !resp.Finished.IsZero() {
+		fmt.Fprintf(out, ", finished %s", resp.Finished.Format(time.RFC3339))
+	}
+	fmt.Fprintln(out)
+	if resp.Error != "" {
+		fmt.Fprintf(out, "Error: %s\n", resp.Error)
+	}
+
+	if len(resp.Findings) == 0 {
+		fmt.Fprintln(out, "No inconsistencies found")
+		return nil
+	}
+	fmt.Fprintln(out)
+
+	idTitle := "ID"
+	poolTitle := "Pool"
+	classTitle := "Class"
+	descTitle := "Description"
+	statusTitle := "Status"
+	actionTitle := "Action"
+
+	formatter := txtfmt.NewTableFormatter(idTitle, poolTitle, classTitle, descTitle,
+		statusTitle, actionTitle)
+	var table []txtfmt.TableRow
+
+	for _, f := range resp.Findings {
+		action := f.Action
+		if f.Status == control.FindingStatusPending {
+			action = fmt.Sprintf("%s (suggested)", f.Suggested)
+		}
+		table = append(table, txtfmt.TableRow{
+			idTitle:     fmt.Sprintf("%d", f.ID),
+			poolTitle:   checkFindingPool(f),
+			classTitle:  f.Class,
+			descTitle:   f.Description,
+			statusTitle: f.Status,
+			actionTitle: action,
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintCheckFinding generates a human-readable representation of a single
+// check finding, including the available repair actions and the outcome of
+// any repair, and writes it to the supplied io.Writer.
+func PrintCheckFinding(out io.Writer, f *control.CheckFinding) error {
+	if f == nil {
+		return errors.Errorf("nil %T", f)
+	}
+
+	fmt.Fprintf(out, "Finding %d: %s\n", f.ID, f.Description)
+	fmt.Fprintf(out, "  Pool:    %s\n", checkFindingPool(f))
+	fmt.Fprintf(out, "  Class:   %s\n", f.Class)
+	fmt.Fprintf(out, "  Status:  %s\n", f.Status)
+	if f.Status == control.FindingStatusPending {
+		fmt.Fprintf(out, "  Actions: %s (suggested: %s)\n", strings.Join(f.Actions, ", "), f.Suggested)
+		return nil
+	}
+	fmt.Fprintf(out, "  Action:  %s\n", f.Action)
+	fmt.Fprintf(out, "  Result:  %s\n", f.Result)
+
+	return nil
+}
@@ -0,0 +1,169 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func mockCheckFindings() []*control.CheckFinding {
+	return []*control.CheckFinding{
+		{
+			ID:          1,
+			Class:       "pool-svc-missing",
+			PoolUUID:    common.MockUUID(1),
+			PoolLabel:   "tank",
+			Description: "pool entry in the MS has no corresponding pool service",
+			Actions:     []string{"remove-pool-entry", "ignore"},
+			Suggested:   "remove-pool-entry",
+			Status:      "pending",
+		},
+		{
+			ID:          2,
+			Class:       "pool-stale-state",
+			PoolUUID:    common.MockUUID(2),
+			Description: "pool entry in the MS has been left in the Creating state",
+			Actions:     []string{"destroy-pool", "ignore"},
+			Suggested:   "destroy-pool",
+			Status:      "repaired",
+			Action:      "destroy-pool",
+			Result:      "pool destroyed and entry removed from the MS",
+		},
+	}
+}
+
+func TestPretty_PrintCheckQueryResponse(t *testing.T) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.CheckQueryResp
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.CheckQueryResp"),
+		},
+		"no check run": {
+			resp: &control.CheckQueryResp{},
+			expPrintStr: `
+No system check has been run
+`,
+		},
+		"running": {
+			resp: &control.CheckQueryResp{
+				RunID:        2,
+				Status:       "running",
+				PoolsChecked: 1,
+				PoolsTotal:   4,
+				Started:      started,
+			},
+			expPrintStr: `
+Check 2 running: 1/4 pools checked, started 2021-06-01T12:00:00Z
+No inconsistencies found
+`,
+		},
+		"failed": {
+			resp: &control.CheckQueryResp{
+				RunID:        2,
+				Status:       "failed",
+				PoolsChecked: 1,
+				PoolsTotal:   4,
+				Started:      started,
+				Finished:     started.Add(time.Minute),
+				Error:        "not the MS leader",
+			},
+			expPrintStr: `
+Check 2 failed: 1/4 pools checked, started 2021-06-01T12:00:00Z, finished 2021-06-01T12:01:00Z
+Error: not the MS leader
+No inconsistencies found
+`,
+		},
+		"findings": {
+			resp: &control.CheckQueryResp{
+				RunID:        3,
+				Status:       "completed",
+				PoolsChecked: 2,
+				PoolsTotal:   2,
+				Started:      started,
+				Finished:     started.Add(time.Minute),
+				Findings:     mockCheckFindings(),
+			},
+			expPrintStr: `
+Check 3 completed: 2/2 pools checked, started 2021-06-01T12:00:00Z, finished 2021-06-01T12:01:00Z
+
+ID Pool                                 Class            Description                                              Status   Action                        
+-- ----                                 -----            -----------                                              ------   ------                        
+1  tank                                 pool-svc-missing pool entry in the MS has no corresponding pool service   pending  remove-pool-entry (suggested) 
+2  00000002-0002-0002-0002-000000000002 pool-stale-state pool entry in the MS has been left in the Creating state repaired destroy-pool                  
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := PrintCheckQueryResponse(&out, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintCheckFinding(t *testing.T) {
+	findings := mockCheckFindings()
+
+	for name, tc := range map[string]struct {
+		finding     *control.CheckFinding
+		expPrintStr string
+	}{
+		"pending": {
+			finding: findings[0],
+			expPrintStr: `
+Finding 1: pool entry in the MS has no corresponding pool service
+  Pool:    tank
+  Class:   pool-svc-missing
+  Status:  pending
+  Actions: remove-pool-entry, ignore (suggested: remove-pool-entry)
+`,
+		},
+		"repaired": {
+			finding: findings[1],
+			expPrintStr: `
+Finding 2: pool entry in the MS has been left in the Creating state
+  Pool:    00000002-0002-0002-0002-000000000002
+  Class:   pool-stale-state
+  Status:  repaired
+  Action:  destroy-pool
+  Result:  pool destroyed and entry removed from the MS
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			if err := PrintCheckFinding(&out, tc.finding); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// xstreamType returns a description of the role of the given xstream.
+func xstreamType(xs *control.XstreamStats) string {
+	switch {
+	case xs.TgtID < 0:
+		return "system"
+	case xs.Main:
+		return "main"
+	default:
+		return "helper"
+	}
+}
+
+// PrintEngineStats generates a human-readable representation of the supplied
+// per-host engine xstream statistics and writes it to the supplied io.Writer.
+func PrintEngineStats(hostStats map[string][]*control.EngineStats, out io.Writer) error {
+	if len(hostStats) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	rankTitle := "Rank"
+	xsTitle := "XS"
+	typeTitle := "Type"
+	tgtTitle := "Target"
+	queuedTitle := "ULTs Queued"
+	cycleTitle := "Cycle Time"
+	idleTitle := "Idle"
+
+	tablePrint := txtfmt.NewTableFormatter(hostTitle, rankTitle, xsTitle, typeTitle,
+		tgtTitle, queuedTitle, cycleTitle, idleTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	hosts := make([]string, 0, len(hostStats))
+	for host := range hostStats {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		for _, es := range hostStats[host] {
+			for _, xs := range es.Xstreams {
+				tgt := "-"
+				if xs.TgtID >= 0 {
+					tgt = fmt.Sprintf("%d", xs.TgtID)
+				}
+				table = append(table, txtfmt.TableRow{
+					hostTitle:   host,
+					rankTitle:   es.Rank.String(),
+					xsTitle:     fmt.Sprintf("%d", xs.XsID),
+					typeTitle:   xstreamType(xs),
+					tgtTitle:    tgt,
+					queuedTitle: fmt.Sprintf("%d", xs.UltsQueued),
+					cycleTitle:  xs.CycleTime().String(),
+					idleTitle:   fmt.Sprintf("%.1f%%", xs.IdlePercent()),
+				})
+			}
+		}
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
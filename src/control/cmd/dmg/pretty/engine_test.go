@@ -0,0 +1,71 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+)
+
+func TestPretty_PrintEngineStats(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostStats   map[string][]*control.EngineStats
+		expPrintStr string
+	}{
+		"empty": {
+			hostStats:   map[string][]*control.EngineStats{},
+			expPrintStr: "",
+		},
+		"two hosts": {
+			hostStats: map[string][]*control.EngineStats{
+				"host2": {
+					{
+						Rank: 1,
+						Xstreams: []*control.XstreamStats{
+							{XsID: 2, TgtID: 0, Main: true, UltsQueued: 12,
+								TotalTime: 1000, RelaxTime: 0, Cycles: 100},
+						},
+					},
+				},
+				"host1": {
+					{
+						Rank: 0,
+						Xstreams: []*control.XstreamStats{
+							{XsID: 0, TgtID: -1, TotalTime: 1000, RelaxTime: 990, Cycles: 1000},
+							{XsID: 2, TgtID: 0, Main: true, UltsQueued: 1,
+								TotalTime: 1000, RelaxTime: 750, Cycles: 4000},
+							{XsID: 3, TgtID: 0, UltsQueued: 40, TotalTime: 1000},
+						},
+					},
+				},
+			},
+			expPrintStr: `
+Host  Rank XS Type   Target ULTs Queued Cycle Time Idle  
+----  ---- -- ----   ------ ----------- ---------- ----  
+host1 0    0  system -      0           1ms        99.0% 
+host1 0    2  main   0      1           250µs      75.0% 
+host1 0    3  helper 0      40          0s         0.0%  
+host2 1    2  main   0      12          10ms       0.0%  
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintEngineStats(tc.hostStats, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
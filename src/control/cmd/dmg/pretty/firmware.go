@@ -23,10 +23,11 @@ import (
 )
 
 const (
-	scmUpdateSuccess = "Success - The new firmware was staged. A power cycle is required to apply."
-	scmNotFound      = "No SCM devices detected"
-	scmDevTitle      = "Device:PhyID:Socket:Ctrl:Chan:Pos"
-	scmSectionHeader = "SCM Device Firmware"
+	scmUpdateSuccess   = "Success - The new firmware was staged. A power cycle is required to apply."
+	scmUpdateActivated = "Success - The new firmware was activated."
+	scmNotFound        = "No SCM devices detected"
+	scmDevTitle        = "Device:PhyID:Socket:Ctrl:Chan:Pos"
+	scmSectionHeader   = "SCM Device Firmware"
 
 	nvmeUpdateSuccess = "Success - The NVMe device controller firmware was updated."
 	nvmeNotFound      = "No NVMe device controllers detected"
@@ -34,6 +35,15 @@ const (
 	nvmeSectionHeader = "NVMe Device Firmware"
 )
 
+// getSCMUpdateSuccess returns the success message for the SCM update result,
+// indicating whether a power cycle is required to apply the new firmware.
+func getSCMUpdateSuccess(res *control.SCMUpdateResult) string {
+	if res.Activated {
+		return scmUpdateActivated
+	}
+	return scmUpdateSuccess
+}
+
 func printScmModule(module *storage.ScmModule, out io.Writer, opts ...PrintConfigOption) error {
 	_, err := fmt.Fprintf(out, "%s\n", module.String())
 	return err
@@ -326,6 +336,11 @@ func PrintSCMFirmwareUpdateMap(fwMap control.HostSCMUpdateMap, out io.Writer,
 
 	return printCondensedResults(successes, out, opts,
 		func(result string, set *hostDeviceSet, _ []PrintConfigOption, w io.Writer) {
+			if result == scmUpdateActivated {
+				fmt.Fprintf(w, "Firmware activated on %s.\n",
+					english.Plural(len(set.Devices), "device", "devices"))
+				return
+			}
 			fmt.Fprintf(w, "Firmware staged on %s. A power cycle is required to apply the update.\n",
 				english.Plural(len(set.Devices), "device", "devices"))
 		})
@@ -347,7 +362,7 @@ func condenseSCMUpdateMap(fwMap control.HostSCMUpdateMap) (hostDeviceResultMap,
 		for _, devRes := range results {
 			devID := getShortSCMString(devRes.Module)
 			if devRes.Error == nil {
-				err := successes.AddHostDevice(scmUpdateSuccess, host, devID)
+				err := successes.AddHostDevice(getSCMUpdateSuccess(devRes), host, devID)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -391,7 +406,7 @@ func PrintSCMFirmwareUpdateMapVerbose(fwMap control.HostSCMUpdateMap, out io.Wri
 				continue
 			}
 
-			fmt.Fprintf(iw2, "%s\n", scmUpdateSuccess)
+			fmt.Fprintf(iw2, "%s\n", getSCMUpdateSuccess(res))
 		}
 	}
 
@@ -569,3 +584,43 @@ func PrintNVMeFirmwareUpdateMapVerbose(fwMap control.HostNVMeUpdateMap, out io.W
 	}
 	return w.Err
 }
+
+// PrintFirmwareCompliance displays the devices found not to be compliant with
+// a firmware catalog.
+func PrintFirmwareCompliance(nonCompliant []*control.NonCompliantDevice, out io.Writer, opts ...PrintConfigOption) error {
+	w := txtfmt.NewErrWriter(out)
+
+	if len(nonCompliant) == 0 {
+		fmt.Fprintln(out, "All devices compliant with firmware catalog")
+		return w.Err
+	}
+
+	hostTitle := "Host"
+	typeTitle := "Type"
+	devTitle := "Device"
+	modelTitle := "Model"
+	fwTitle := "FW Revision"
+	approvedTitle := "Approved Revisions"
+
+	fmt.Fprintf(out, "%d non-compliant %s:\n", len(nonCompliant),
+		english.PluralWord(len(nonCompliant), "device", ""))
+	formatter := txtfmt.NewTableFormatter(
+		hostTitle, typeTitle, devTitle, modelTitle, fwTitle, approvedTitle,
+	)
+	formatter.InitWriter(txtfmt.NewIndentWriter(out))
+	var table []txtfmt.TableRow
+
+	for _, ncd := range nonCompliant {
+		row := txtfmt.TableRow{hostTitle: getPrintHosts(ncd.Host, opts...)}
+		row[typeTitle] = ncd.Type.String()
+		row[devTitle] = ncd.DeviceID
+		row[modelTitle] = ncd.ModelID
+		row[fwTitle] = ncd.FirmwareRev
+		row[approvedTitle] = strings.Join(ncd.Approved, ",")
+
+		table = append(table, row)
+	}
+
+	formatter.Format(table)
+	return w.Err
+}
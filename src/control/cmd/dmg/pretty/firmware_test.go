@@ -604,6 +604,7 @@ host2
 							ChannelID:       2,
 							ChannelPosition: 1,
 						},
+						Activated: true,
 					},
 				},
 			},
@@ -616,7 +617,7 @@ host1
   UID:Device2 PhysicalID:2 Capacity:66 KiB Location:(socket:6 memctrlr:7 chan:8 pos:9)
     Error: test error
   UID:Device3 PhysicalID:3 Capacity:66 KiB Location:(socket:1 memctrlr:2 chan:2 pos:1)
-    Success - The new firmware was staged. A power cycle is required to apply.
+    Success - The new firmware was activated.
 `,
 		},
 		"multiple hosts": {
@@ -809,6 +810,60 @@ Errors:
 host[1-2]
 ---------
   Firmware staged on 2 devices. A power cycle is required to apply the update.
+`,
+		},
+		"activated and staged": {
+			fwMap: control.HostSCMUpdateMap{
+				"host1": []*control.SCMUpdateResult{
+					{
+						Module: storage.ScmModule{
+							UID:             "Device1",
+							PhysicalID:      1,
+							Capacity:        (1 << 31),
+							SocketID:        1,
+							ControllerID:    2,
+							ChannelID:       3,
+							ChannelPosition: 5,
+						},
+						Activated: true,
+					},
+				},
+				"host2": []*control.SCMUpdateResult{
+					{
+						Module: storage.ScmModule{
+							UID:             "Device2",
+							PhysicalID:      2,
+							Capacity:        (1 << 30),
+							SocketID:        6,
+							ControllerID:    7,
+							ChannelID:       8,
+							ChannelPosition: 9,
+						},
+						Activated: true,
+					},
+					{
+						Module: storage.ScmModule{
+							UID:             "Device3",
+							PhysicalID:      3,
+							Capacity:        (1 << 32),
+							SocketID:        1,
+							ControllerID:    2,
+							ChannelID:       2,
+							ChannelPosition: 1,
+						},
+						StagedVersion: "FWRev9",
+					},
+				},
+			},
+			expPrintStr: `
+---------
+host[1-2]
+---------
+  Firmware activated on 2 devices.
+-----
+host2
+-----
+  Firmware staged on 1 device. A power cycle is required to apply the update.
 `,
 		},
 		"no errors": {
@@ -1449,3 +1504,54 @@ host2
 		})
 	}
 }
+
+func TestPretty_PrintFirmwareCompliance(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nonCompliant []*control.NonCompliantDevice
+		expPrintStr  string
+	}{
+		"all compliant": {
+			expPrintStr: `
+All devices compliant with firmware catalog
+`,
+		},
+		"non-compliant devices": {
+			nonCompliant: []*control.NonCompliantDevice{
+				{
+					Host:        "host1:10001",
+					Type:        control.DeviceTypeSCM,
+					DeviceID:    "uid1",
+					ModelID:     "PartNumber1",
+					FirmwareRev: "Rev0",
+					Approved:    []string{"Rev1"},
+				},
+				{
+					Host:        "host2:10001",
+					Type:        control.DeviceTypeNVMe,
+					DeviceID:    "0000:80:00.1",
+					ModelID:     "model-1",
+					FirmwareRev: "fwRev-2",
+					Approved:    []string{"fwRev-0", "fwRev-1"},
+				},
+			},
+			expPrintStr: `
+2 non-compliant devices:
+  Host  Type Device       Model       FW Revision Approved Revisions 
+  ----  ---- ------       -----       ----------- ------------------ 
+  host1 SCM  uid1         PartNumber1 Rev0        Rev1               
+  host2 NVMe 0000:80:00.1 model-1     fwRev-2     fwRev-0,fwRev-1    
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintFirmwareCompliance(tc.nonCompliant, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
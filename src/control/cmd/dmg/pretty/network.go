@@ -9,9 +9,13 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/lib/txtfmt"
 )
 
@@ -22,7 +26,21 @@ func (h hfiMap) addInterface(fi *control.HostFabricInterface) {
 	if _, ok := h[nn]; !ok {
 		h[nn] = make(map[string][]string)
 	}
-	h[nn][fi.Provider] = append(h[nn][fi.Provider], fi.Device)
+	dev := fi.Device
+	if fi.Alias != "" {
+		dev = fmt.Sprintf("%s (%s)", fi.Device, fi.Alias)
+	}
+	var notes []string
+	if fi.PhysFn != "" {
+		notes = append(notes, "VF of "+fi.PhysFn)
+	}
+	if fi.LinkState == netdetect.LinkStateDown {
+		notes = append(notes, "link down")
+	}
+	if len(notes) > 0 {
+		dev = fmt.Sprintf("%s [%s]", dev, strings.Join(notes, ", "))
+	}
+	h[nn][fi.Provider] = append(h[nn][fi.Provider], dev)
 }
 
 // PrintHostFabricMap generates a human-readable representation of the supplied
@@ -70,7 +88,88 @@ func PrintHostFabricMap(hfm control.HostFabricMap, out io.Writer, opts ...PrintC
 			fmt.Fprint(iwTable, formatter.Format(table))
 			fmt.Fprintln(ew)
 		}
+
+		printHostLabels(hfs.HostLabels, iw)
 	}
 
 	return ew.Err
 }
+
+// printHostLabels writes a table of any site-specific labels reported by each
+// host to the supplied io.Writer.
+func printHostLabels(hostLabels map[string]map[string]string, out io.Writer) {
+	if len(hostLabels) == 0 {
+		return
+	}
+
+	hostTitle := "Host"
+	labelsTitle := "Labels"
+
+	hosts := make([]string, 0, len(hostLabels))
+	for host := range hostLabels {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	formatter := txtfmt.NewTableFormatter(hostTitle, labelsTitle)
+	var table []txtfmt.TableRow
+	for _, host := range hosts {
+		labels := hostLabels[host]
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		pairs := make([]string, 0, len(names))
+		for _, name := range names {
+			pairs = append(pairs, name+"="+labels[name])
+		}
+		table = append(table, txtfmt.TableRow{
+			hostTitle:   host,
+			labelsTitle: strings.Join(pairs, ", "),
+		})
+	}
+
+	fmt.Fprint(out, formatter.Format(table))
+	fmt.Fprintln(out)
+}
+
+// PrintNetworkSelfTestResults generates a human-readable representation of
+// the supplied network self-test results and writes it to the supplied
+// io.Writer.
+func PrintNetworkSelfTestResults(results []*control.NetworkSelfTestResult, out io.Writer) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	srcTitle := "Source"
+	sizeTitle := "Size"
+	bwTitle := "Bandwidth (MB/s)"
+	rateTitle := "RPCs/s"
+	minTitle := "Min (us)"
+	medTitle := "Median (us)"
+	maxTitle := "Max (us)"
+	failTitle := "Failures"
+
+	tablePrint := txtfmt.NewTableFormatter(srcTitle, sizeTitle, bwTitle, rateTitle,
+		minTitle, medTitle, maxTitle, failTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	for _, r := range results {
+		table = append(table, txtfmt.TableRow{
+			srcTitle:  r.SrcRank.String(),
+			sizeTitle: humanize.IBytes(uint64(r.MsgSize)),
+			bwTitle:   fmt.Sprintf("%.2f", r.Bandwidth),
+			rateTitle: fmt.Sprintf("%.0f", r.Throughput),
+			minTitle:  fmt.Sprintf("%d", r.LatMin),
+			medTitle:  fmt.Sprintf("%d", r.LatMedian),
+			maxTitle:  fmt.Sprintf("%d", r.LatMax),
+			failTitle: fmt.Sprintf("%d", r.Failures),
+		})
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
@@ -0,0 +1,172 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+)
+
+func TestPretty_PrintHostFabricMap(t *testing.T) {
+	mockHostFabricMap := func(hosts string, labels map[string]map[string]string, extra ...*control.HostFabricInterface) control.HostFabricMap {
+		hf := &control.HostFabric{
+			Interfaces: append([]*control.HostFabricInterface{
+				{Provider: "ofi+psm2", Device: "ib0", Alias: "hsn0"},
+				{Provider: "ofi+psm2", Device: "ib1"},
+			}, extra...),
+			Providers: []string{"ofi+psm2"},
+		}
+		hk, err := hf.HashKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hs, err := hostlist.CreateSet(hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return control.HostFabricMap{
+			hk: &control.HostFabricSet{
+				HostFabric: hf,
+				HostSet:    hs,
+				HostLabels: labels,
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		hfm         control.HostFabricMap
+		expPrintStr string
+	}{
+		"empty": {
+			hfm:         control.HostFabricMap{},
+			expPrintStr: "",
+		},
+		"aliases; no labels": {
+			hfm: mockHostFabricMap("host[1-2]", nil),
+			expPrintStr: `
+---------
+host[1-2]
+---------
+
+    -------------
+    NUMA Socket 0
+    -------------
+
+        Provider Interfaces      
+        -------- ----------      
+        ofi+psm2 ib0 (hsn0), ib1 
+
+`,
+		},
+		"virtual functions": {
+			hfm: mockHostFabricMap("host[1-2]", nil,
+				&control.HostFabricInterface{
+					Provider: "ofi+psm2", Device: "ib0v1", PhysFn: "ib0", LinkState: "up",
+				},
+				&control.HostFabricInterface{
+					Provider: "ofi+psm2", Device: "ib0v2", PhysFn: "ib0", LinkState: "down",
+				},
+			),
+			expPrintStr: `
+---------
+host[1-2]
+---------
+
+    -------------
+    NUMA Socket 0
+    -------------
+
+        Provider Interfaces                                                       
+        -------- ----------                                                       
+        ofi+psm2 ib0 (hsn0), ib1, ib0v1 [VF of ib0], ib0v2 [VF of ib0, link down] 
+
+`,
+		},
+		"aliases and labels": {
+			hfm: mockHostFabricMap("host[1-2]", map[string]map[string]string{
+				"host2": {"rack": "r2", "asset_tag": "A2"},
+				"host1": {"rack": "r1", "asset_tag": "A1"},
+			}),
+			expPrintStr: `
+---------
+host[1-2]
+---------
+
+    -------------
+    NUMA Socket 0
+    -------------
+
+        Provider Interfaces      
+        -------- ----------      
+        ofi+psm2 ib0 (hsn0), ib1 
+
+    Host  Labels                
+    ----  ------                
+    host1 asset_tag=A1, rack=r1 
+    host2 asset_tag=A2, rack=r2 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintHostFabricMap(tc.hfm, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintNetworkSelfTestResults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		results     []*control.NetworkSelfTestResult
+		expPrintStr string
+	}{
+		"empty": {
+			expPrintStr: "",
+		},
+		"two sizes": {
+			results: []*control.NetworkSelfTestResult{
+				{
+					SrcRank: 0, MsgSize: 4096, Bandwidth: 812.34, Throughput: 103980,
+					LatMin: 42, LatMedian: 150, LatMax: 977, LatAvg: 153,
+				},
+				{
+					SrcRank: 0, MsgSize: 1048576, Bandwidth: 11263.5, Throughput: 5631,
+					LatMin: 1200, LatMedian: 2800, LatMax: 5100, LatAvg: 2810, Failures: 2,
+				},
+			},
+			expPrintStr: `
+Source Size    Bandwidth (MB/s) RPCs/s Min (us) Median (us) Max (us) Failures 
+------ ----    ---------------- ------ -------- ----------- -------- -------- 
+0      4.0 KiB 812.34           103980 42       150         977      0        
+0      1.0 MiB 11263.50         5631   1200     2800        5100     2        
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintNetworkSelfTestResults(tc.results, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
@@ -52,6 +53,222 @@ func PrintPoolQueryResponse(pqr *control.PoolQueryResp, out io.Writer, opts ...P
 			fmt.Fprintf(w, "Rebuild failed, rc=%d, status=%d\n", pqr.Status, pqr.Rebuild.Status)
 		}
 	}
+	if len(pqr.Targets) > 0 {
+		if err := PrintPoolTargetHeatmap(pqr.Targets, w); err != nil {
+			return err
+		}
+		if pqr.Targets[0].Device != nil {
+			fmt.Fprintln(w)
+			if err := PrintPoolTargetDevices(pqr.Targets, w); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Err
+}
+
+// PrintPoolTargetDevices generates a table of the storage devices backing
+// each of the supplied pool targets and writes it to the supplied io.Writer.
+func PrintPoolTargetDevices(tgts []*control.PoolTargetInfo, out io.Writer) error {
+	rankTitle := "Rank"
+	tgtTitle := "Target"
+	stateTitle := "State"
+	nvmeTitle := "NVMe PCI Address"
+	serialTitle := "NVMe Serial"
+	scmTitle := "SCM Namespace"
+
+	tablePrint := txtfmt.NewTableFormatter(rankTitle, tgtTitle, stateTitle,
+		nvmeTitle, serialTitle, scmTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	for _, tgt := range tgts {
+		row := txtfmt.TableRow{
+			rankTitle:   tgt.Rank.String(),
+			tgtTitle:    fmt.Sprintf("%d", tgt.TgtIdx),
+			stateTitle:  RenderState(tgt.State),
+			nvmeTitle:   "-",
+			serialTitle: "-",
+			scmTitle:    "-",
+		}
+		if dev := tgt.Device; dev != nil {
+			row[nvmeTitle] = orDash(dev.NvmePciAddr)
+			row[serialTitle] = orDash(dev.NvmeSerial)
+			scm := dev.ScmMount
+			if dev.ScmBlockdev != "" {
+				scm = fmt.Sprintf("%s (%s)", dev.ScmBlockdev, dev.ScmMount)
+			}
+			row[scmTitle] = orDash(scm)
+		}
+		table = append(table, row)
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
+
+// heatmapCell returns a single character representing the health and space
+// usage of a pool target.
+func heatmapCell(tgt *control.PoolTargetInfo) byte {
+	switch {
+	case tgt.IsDown():
+		return 'X'
+	case tgt.State == control.PoolTargetStateDrain:
+		return 'D'
+	}
+
+	decile := int(tgt.UsedPercent() / 10)
+	if decile > 9 {
+		decile = 9
+	}
+	return byte('0' + decile)
+}
+
+// PrintPoolTargetHeatmap generates a compact per-rank representation of the
+// health and space usage of the supplied pool targets and writes it to the
+// supplied io.Writer. Targets are expected to be ordered by rank and index.
+func PrintPoolTargetHeatmap(tgts []*control.PoolTargetInfo, out io.Writer) error {
+	w := txtfmt.NewErrWriter(out)
+
+	rankWidth := 0
+	for _, tgt := range tgts {
+		if l := len(tgt.Rank.String()); l > rankWidth {
+			rankWidth = l
+		}
+	}
+
+	fmt.Fprintln(w, "Target usage (0-9 = 0-100% used, D = draining, X = down):")
+
+	var down, up int
+	var minPct, maxPct, sumPct float64
+	for i, tgt := range tgts {
+		if i == 0 || tgt.Rank != tgts[i-1].Rank {
+			if i != 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "- Rank %*s: ", rankWidth, tgt.Rank.String())
+		}
+		fmt.Fprintf(w, "%c", heatmapCell(tgt))
+
+		if tgt.IsDown() {
+			down++
+			continue
+		}
+		pct := tgt.UsedPercent()
+		if up == 0 || pct < minPct {
+			minPct = pct
+		}
+		if pct > maxPct {
+			maxPct = pct
+		}
+		sumPct += pct
+		up++
+	}
+	if len(tgts) > 0 {
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Targets: %d total, %d down", len(tgts), down)
+	if up > 0 {
+		fmt.Fprintf(w, ", used min:%.0f%%, max:%.0f%%, mean:%.0f%%",
+			minPct, maxPct, sumPct/float64(up))
+	}
+	fmt.Fprintln(w)
+
+	return w.Err
+}
+
+// PrintPoolCheckFitResponse generates a human-readable representation of the
+// supplied PoolCheckFitResp and writes it to the supplied io.Writer.
+func PrintPoolCheckFitResponse(resp *control.PoolCheckFitResp, out io.Writer) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+	w := txtfmt.NewErrWriter(out)
+
+	fitStr := func(fits bool) string {
+		if fits {
+			return "yes"
+		}
+		return "no"
+	}
+
+	verdict := "fits"
+	if !resp.Fits {
+		verdict = "does not fit"
+	}
+	fmt.Fprintf(w, "Pool %s: %s %s allocation %s\n", resp.UUID,
+		humanize.Bytes(resp.Size), resp.Redundancy, verdict)
+	for _, problem := range resp.Problems {
+		fmt.Fprintf(w, "- %s\n", problem)
+	}
+	fmt.Fprintln(w)
+
+	tierTitle := "Tier"
+	reqTitle := "Required"
+	perTgtTitle := "Per Target"
+	freeTitle := "Free"
+	fitsTitle := "Fits"
+
+	tierTable := []txtfmt.TableRow{}
+	for _, tier := range []struct {
+		name string
+		fit  *control.PoolTierFit
+	}{
+		{"SCM", resp.Scm},
+		{"NVMe", resp.Nvme},
+	} {
+		if tier.fit == nil {
+			continue
+		}
+		tierTable = append(tierTable, txtfmt.TableRow{
+			tierTitle:   tier.name,
+			reqTitle:    humanize.Bytes(tier.fit.Required),
+			perTgtTitle: humanize.Bytes(tier.fit.PerTarget),
+			freeTitle:   humanize.Bytes(tier.fit.Free),
+			fitsTitle:   fitStr(tier.fit.Fits),
+		})
+	}
+	tierPrint := txtfmt.NewTableFormatter(tierTitle, reqTitle, perTgtTitle, freeTitle, fitsTitle)
+	tierPrint.InitWriter(w)
+	tierPrint.Format(tierTable)
+
+	if len(resp.Ranks) == 0 {
+		return w.Err
+	}
+	fmt.Fprintln(w)
+
+	rankTitle := "Rank"
+	tgtsTitle := "Targets"
+	scmReqTitle := "SCM Required"
+	scmFreeTitle := "SCM Free"
+	nvmeReqTitle := "NVMe Required"
+	nvmeFreeTitle := "NVMe Free"
+
+	rankTable := []txtfmt.TableRow{}
+	for _, rf := range resp.Ranks {
+		rankTable = append(rankTable, txtfmt.TableRow{
+			rankTitle:     rf.Rank.String(),
+			tgtsTitle:     fmt.Sprintf("%d", rf.Targets),
+			scmReqTitle:   humanize.Bytes(rf.ScmNeed),
+			scmFreeTitle:  humanize.Bytes(rf.ScmFree),
+			nvmeReqTitle:  humanize.Bytes(rf.NvmeNeed),
+			nvmeFreeTitle: humanize.Bytes(rf.NvmeFree),
+			fitsTitle:     fitStr(rf.Fits),
+		})
+	}
+	rankPrint := txtfmt.NewTableFormatter(rankTitle, tgtsTitle, scmReqTitle, scmFreeTitle,
+		nvmeReqTitle, nvmeFreeTitle, fitsTitle)
+	rankPrint.InitWriter(w)
+	rankPrint.Format(rankTable)
 
 	return w.Err
 }
@@ -74,14 +291,56 @@ func PrintPoolCreateResponse(pcr *control.PoolCreateResp, out io.Writer, opts ..
 
 	numRanks := uint64(len(pcr.TgtRanks))
 	title := fmt.Sprintf("Pool created with %0.2f%%%% SCM/NVMe ratio", ratio*100)
-	_, err := fmt.Fprintln(out, txtfmt.FormatEntity(title, []txtfmt.TableRow{
+	rows := []txtfmt.TableRow{
 		{"UUID": pcr.UUID},
 		{"Service Ranks": formatRanks(pcr.SvcReps)},
 		{"Storage Ranks": formatRanks(pcr.TgtRanks)},
 		{"Total Size": humanize.Bytes((pcr.ScmBytes + pcr.NvmeBytes) * numRanks)},
 		{"SCM": fmt.Sprintf("%s (%s / rank)", humanize.Bytes(pcr.ScmBytes*numRanks), humanize.Bytes(pcr.ScmBytes))},
 		{"NVMe": fmt.Sprintf("%s (%s / rank)", humanize.Bytes(pcr.NvmeBytes*numRanks), humanize.Bytes(pcr.NvmeBytes))},
-	}))
+	}
+	if len(pcr.FaultDomains) > 0 {
+		domains := make([]string, len(pcr.FaultDomains))
+		for i, fd := range pcr.FaultDomains {
+			domains[i] = fmt.Sprintf("%s %s", fd.Domain, formatRanks(fd.Ranks))
+		}
+		rows = append(rows, txtfmt.TableRow{"Fault Domains": strings.Join(domains, ", ")})
+	}
+	if len(pcr.Properties) > 0 {
+		props := make([]string, len(pcr.Properties))
+		for i, prop := range pcr.Properties {
+			props[i] = prop.Name + ":" + prop.Value
+		}
+		rows = append(rows, txtfmt.TableRow{"Properties": strings.Join(props, ",")})
+	}
+	if len(pcr.RankChoices) > 0 {
+		rows = append(rows, txtfmt.TableRow{"Rank Strategy": pcr.RankStrategy})
+	}
+
+	ew := txtfmt.NewErrWriter(out)
+	fmt.Fprintln(ew, txtfmt.FormatEntity(title, rows))
+	if getPrintConfig(opts...).Verbose && len(pcr.Timings) > 0 {
+		printPhaseTimings(pcr.Timings, ew)
+		fmt.Fprintln(ew)
+	}
+	if len(pcr.RankChoices) == 0 {
+		return ew.Err
+	}
+
+	rankTitle := "Rank"
+	domainTitle := "Fault Domain"
+	reasonTitle := "Selected Because"
+	choiceTable := []txtfmt.TableRow{}
+	for _, rc := range pcr.RankChoices {
+		choiceTable = append(choiceTable, txtfmt.TableRow{
+			rankTitle:   fmt.Sprintf("%d", rc.Rank),
+			domainTitle: rc.Domain,
+			reasonTitle: rc.Reason,
+		})
+	}
+	choicePrint := txtfmt.NewTableFormatter(rankTitle, domainTitle, reasonTitle)
+	choicePrint.InitWriter(ew)
+	choicePrint.Format(choiceTable)
 
-	return err
+	return ew.Err
 }
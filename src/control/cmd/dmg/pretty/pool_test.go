@@ -17,6 +17,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 func TestPretty_PrintPoolQueryResp(t *testing.T) {
@@ -121,6 +122,182 @@ Rebuild failed, rc=0, status=2
 	}
 }
 
+func TestPretty_PrintPoolTargetHeatmap(t *testing.T) {
+	mockTarget := func(rank system.Rank, idx uint32, state string, nvmeFree uint64) *control.PoolTargetInfo {
+		return &control.PoolTargetInfo{
+			Rank:      rank,
+			TgtIdx:    idx,
+			State:     state,
+			ScmTotal:  100,
+			ScmFree:   100,
+			NvmeTotal: 100,
+			NvmeFree:  nvmeFree,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		tgts        []*control.PoolTargetInfo
+		expPrintStr string
+	}{
+		"no targets": {
+			expPrintStr: `
+Target usage (0-9 = 0-100% used, D = draining, X = down):
+Targets: 0 total, 0 down
+`,
+		},
+		"mixed health and usage": {
+			tgts: []*control.PoolTargetInfo{
+				mockTarget(0, 0, "UPIN", 100),
+				mockTarget(0, 1, "UPIN", 55),
+				mockTarget(0, 2, "UPIN", 0),
+				mockTarget(10, 0, "DRAIN", 50),
+				mockTarget(10, 1, "DOWNOUT", 100),
+				mockTarget(10, 2, "DOWN", 100),
+			},
+			expPrintStr: `
+Target usage (0-9 = 0-100% used, D = draining, X = down):
+- Rank  0: 049
+- Rank 10: DXX
+Targets: 6 total, 2 down, used min:0%, max:100%, mean:49%
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintPoolTargetHeatmap(tc.tgts, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintPoolTargetDevices(t *testing.T) {
+	tgts := []*control.PoolTargetInfo{
+		{
+			Rank:   0,
+			TgtIdx: 0,
+			State:  "UPIN",
+			Device: &control.PoolTargetDevice{
+				NvmeUUID:    common.MockUUID(1),
+				NvmePciAddr: "0000:80:00.0",
+				NvmeSerial:  "serial0",
+				ScmBlockdev: "pmem0",
+				ScmMount:    "/mnt/daos0",
+			},
+		},
+		{
+			Rank:   0,
+			TgtIdx: 1,
+			State:  "DOWN",
+			Device: &control.PoolTargetDevice{
+				ScmMount: "/mnt/daos0",
+			},
+		},
+		{
+			Rank:   1,
+			TgtIdx: 0,
+			State:  "UPIN",
+		},
+	}
+
+	expPrintStr := `
+Rank Target State NVMe PCI Address NVMe Serial SCM Namespace      
+---- ------ ----- ---------------- ----------- -------------      
+0    0      UPIN  0000:80:00.0     serial0     pmem0 (/mnt/daos0) 
+0    1      DOWN  -                -           /mnt/daos0         
+1    0      UPIN  -                -           -                  
+`
+
+	var bld strings.Builder
+	if err := PrintPoolTargetDevices(tgts, &bld); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(strings.TrimLeft(expPrintStr, "\n"), bld.String()); diff != "" {
+		t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestPretty_PrintPoolCheckFitResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.PoolCheckFitResp
+		expPrintStr string
+	}{
+		"nil response": {
+			expPrintStr: "",
+		},
+		"no targets": {
+			resp: &control.PoolCheckFitResp{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: &control.RedundancyScheme{Name: "none", DataCells: 1},
+				Scm:        &control.PoolTierFit{},
+				Nvme:       &control.PoolTierFit{},
+				Problems:   []string{"pool has no available targets"},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool %s: 8.0 kB none allocation does not fit
+- pool has no available targets
+
+Tier Required Per Target Free Fits 
+---- -------- ---------- ---- ---- 
+SCM  0 B      0 B        0 B  no   
+NVMe 0 B      0 B        0 B  no   
+`, common.MockUUID()),
+		},
+		"target too full": {
+			resp: &control.PoolCheckFitResp{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: &control.RedundancyScheme{Name: "EC_2P1", DataCells: 2, ParityCells: 1},
+				Scm:        &control.PoolTierFit{Required: 720, PerTarget: 180, Free: 2000, Fits: true},
+				Nvme:       &control.PoolTierFit{Required: 12000, PerTarget: 3000, Free: 14999, TargetsTooFull: 1},
+				Ranks: []*control.PoolRankFit{
+					{Rank: 0, Targets: 2, ScmNeed: 360, ScmFree: 1000, NvmeNeed: 6000, NvmeFree: 9000, Fits: true},
+					{Rank: 1, Targets: 1, ScmNeed: 180, ScmFree: 500, NvmeNeed: 3000, NvmeFree: 2999},
+				},
+				Problems: []string{"NVMe: 1 targets have less than the 3.0 kB required per target"},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool %s: 8.0 kB EC_2P1 allocation does not fit
+- NVMe: 1 targets have less than the 3.0 kB required per target
+
+Tier Required Per Target Free   Fits 
+---- -------- ---------- ----   ---- 
+SCM  720 B    180 B      2.0 kB yes  
+NVMe 12 kB    3.0 kB     15 kB  no   
+
+Rank Targets SCM Required SCM Free NVMe Required NVMe Free Fits 
+---- ------- ------------ -------- ------------- --------- ---- 
+0    2       360 B        1.0 kB   6.0 kB        9.0 kB    yes  
+1    1       180 B        500 B    3.0 kB        3.0 kB    no   
+`, common.MockUUID()),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			err := PrintPoolCheckFitResponse(tc.resp, &bld)
+			if tc.resp == nil {
+				if err == nil {
+					t.Fatal("expected error for nil response")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func mockRanks(ranks ...uint32) []uint32 {
 	return ranks
 }
@@ -128,6 +305,7 @@ func mockRanks(ranks ...uint32) []uint32 {
 func TestPretty_PrintPoolCreateResp(t *testing.T) {
 	for name, tc := range map[string]struct {
 		pcr         *control.PoolCreateResp
+		verbose     bool
 		expPrintStr string
 		expErr      error
 	}{
@@ -156,6 +334,139 @@ Pool created with 6.00%%%% SCM/NVMe ratio
   SCM           : 2.4 GB (600 MB / rank)              
   NVMe          : 40 GB (10 GB / rank)                
 
+`, common.MockUUID()),
+		},
+		"fault domains": {
+			pcr: &control.PoolCreateResp{
+				UUID:      common.MockUUID(),
+				SvcReps:   mockRanks(0, 1, 2),
+				TgtRanks:  mockRanks(0, 1, 2, 3),
+				ScmBytes:  600 * humanize.MByte,
+				NvmeBytes: 10 * humanize.GByte,
+				FaultDomains: []*control.PoolFaultDomain{
+					{Domain: "/rack0", Ranks: mockRanks(0, 2)},
+					{Domain: "/rack1", Ranks: mockRanks(1, 3)},
+				},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool created with 6.00%%%% SCM/NVMe ratio
+---------------------------------------
+  UUID          : %s
+  Service Ranks : [0-2]                               
+  Storage Ranks : [0-3]                               
+  Total Size    : 42 GB                               
+  SCM           : 2.4 GB (600 MB / rank)              
+  NVMe          : 40 GB (10 GB / rank)                
+  Fault Domains : /rack0 [0,2], /rack1 [1,3]          
+
+`, common.MockUUID()),
+		},
+		"properties": {
+			pcr: &control.PoolCreateResp{
+				UUID:      common.MockUUID(),
+				SvcReps:   mockRanks(0, 1, 2),
+				TgtRanks:  mockRanks(0, 1, 2, 3),
+				ScmBytes:  600 * humanize.MByte,
+				NvmeBytes: 10 * humanize.GByte,
+				Properties: []*control.PoolProperty{
+					{Name: "reclaim", Value: "lazy"},
+					{Name: "self_heal", Value: "exclude"},
+				},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool created with 6.00%%%% SCM/NVMe ratio
+---------------------------------------
+  UUID          : %s
+  Service Ranks : [0-2]                               
+  Storage Ranks : [0-3]                               
+  Total Size    : 42 GB                               
+  SCM           : 2.4 GB (600 MB / rank)              
+  NVMe          : 40 GB (10 GB / rank)                
+  Properties    : reclaim:lazy,self_heal:exclude      
+
+`, common.MockUUID()),
+		},
+		"rank choices": {
+			pcr: &control.PoolCreateResp{
+				UUID:         common.MockUUID(),
+				SvcReps:      mockRanks(0),
+				TgtRanks:     mockRanks(0, 2),
+				ScmBytes:     600 * humanize.MByte,
+				NvmeBytes:    10 * humanize.GByte,
+				RankStrategy: "spread",
+				RankChoices: []*control.PoolRankChoice{
+					{Rank: 0, Domain: "/rack0", Reason: "spread across fault domains, 4.0 TB free"},
+					{Rank: 2, Domain: "/rack1", Reason: "spread across fault domains, 2.0 TB free"},
+				},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool created with 6.00%%%% SCM/NVMe ratio
+---------------------------------------
+  UUID          : %s
+  Service Ranks : 0                                   
+  Storage Ranks : [0,2]                               
+  Total Size    : 21 GB                               
+  SCM           : 1.2 GB (600 MB / rank)              
+  NVMe          : 20 GB (10 GB / rank)                
+  Rank Strategy : spread                              
+
+Rank Fault Domain Selected Because                         
+---- ------------ ----------------                         
+0    /rack0       spread across fault domains, 4.0 TB free 
+2    /rack1       spread across fault domains, 2.0 TB free 
+`, common.MockUUID()),
+		},
+		"timings": {
+			pcr: &control.PoolCreateResp{
+				UUID:      common.MockUUID(),
+				SvcReps:   mockRanks(0, 1, 2),
+				TgtRanks:  mockRanks(0, 1, 2, 3),
+				ScmBytes:  600 * humanize.MByte,
+				NvmeBytes: 10 * humanize.GByte,
+				Timings: []*control.PhaseTiming{
+					{Phase: "engine pool create", DurationUs: 3456789},
+					{Phase: "set properties", DurationUs: 120000},
+				},
+			},
+			verbose: true,
+			expPrintStr: fmt.Sprintf(`
+Pool created with 6.00%%%% SCM/NVMe ratio
+---------------------------------------
+  UUID          : %s
+  Service Ranks : [0-2]                               
+  Storage Ranks : [0-3]                               
+  Total Size    : 42 GB                               
+  SCM           : 2.4 GB (600 MB / rank)              
+  NVMe          : 40 GB (10 GB / rank)                
+
+Phase              Duration 
+-----              -------- 
+engine pool create 3.457s   
+set properties     120ms    
+
+`, common.MockUUID()),
+		},
+		"timings not verbose": {
+			pcr: &control.PoolCreateResp{
+				UUID:      common.MockUUID(),
+				SvcReps:   mockRanks(0, 1, 2),
+				TgtRanks:  mockRanks(0, 1, 2, 3),
+				ScmBytes:  600 * humanize.MByte,
+				NvmeBytes: 10 * humanize.GByte,
+				Timings: []*control.PhaseTiming{
+					{Phase: "engine pool create", DurationUs: 3456789},
+				},
+			},
+			expPrintStr: fmt.Sprintf(`
+Pool created with 6.00%%%% SCM/NVMe ratio
+---------------------------------------
+  UUID          : %s
+  Service Ranks : [0-2]                               
+  Storage Ranks : [0-3]                               
+  Total Size    : 42 GB                               
+  SCM           : 2.4 GB (600 MB / rank)              
+  NVMe          : 40 GB (10 GB / rank)                
+
 `, common.MockUUID()),
 		},
 		"no nvme": {
@@ -180,7 +491,7 @@ Pool created with 100.00%%%% SCM/NVMe ratio
 	} {
 		t.Run(name, func(t *testing.T) {
 			var bld strings.Builder
-			gotErr := PrintPoolCreateResponse(tc.pcr, &bld)
+			gotErr := PrintPoolCreateResponse(tc.pcr, &bld, PrintWithVerboseOutput(tc.verbose))
 			common.CmpErr(t, tc.expErr, gotErr)
 			if tc.expErr != nil {
 				return
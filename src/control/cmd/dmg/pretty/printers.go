@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -47,6 +48,18 @@ type (
 	hostErrorsGetter interface {
 		GetHostErrors() control.HostErrorsMap
 	}
+
+	// hostWarningsGetter define an interface for responses which return
+	// a HostWarningsMap.
+	hostWarningsGetter interface {
+		GetHostWarnings() control.HostWarningsMap
+	}
+
+	// hostTimingsGetter define an interface for responses which return
+	// a HostTimingsMap.
+	hostTimingsGetter interface {
+		GetHostTimings() control.HostTimingsMap
+	}
 )
 
 // PrintWithVerboseOutput toggles verbose output from the formatter.
@@ -122,6 +135,7 @@ func PrintHostErrorsMap(hem control.HostErrorsMap, out io.Writer, opts ...PrintC
 		if f, ok := hostErr.(*fault.Fault); ok {
 			row[errTitle] = f.Description
 		}
+		row[errTitle] = Render(SeverityError, row[errTitle])
 
 		table = append(table, row)
 	}
@@ -145,3 +159,91 @@ func PrintResponseErrors(resp hostErrorsGetter, out io.Writer, opts ...PrintConf
 
 	return nil
 }
+
+// PrintHostWarningsMap generates a human-readable representation of the
+// supplied HostWarningsMap and writes it to the supplied io.Writer.
+func PrintHostWarningsMap(hwm control.HostWarningsMap, out io.Writer, opts ...PrintConfigOption) error {
+	if len(hwm) == 0 {
+		return nil
+	}
+
+	setTitle := "Hosts"
+	warnTitle := "Warning"
+
+	tablePrint := txtfmt.NewTableFormatter(setTitle, warnTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	for _, warning := range hwm.Keys() {
+		table = append(table, txtfmt.TableRow{
+			setTitle:  getPrintHosts(hwm[warning].RangedString(), opts...),
+			warnTitle: Render(SeverityWarning, warning),
+		})
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
+
+// PrintResponseWarnings writes the advisory messages reported by hosts in
+// the supplied response, if any, to the supplied io.Writer.
+func PrintResponseWarnings(resp hostWarningsGetter, out io.Writer, opts ...PrintConfigOption) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.GetHostWarnings()) > 0 {
+		fmt.Fprintln(out, "Warnings:")
+		if err := PrintHostWarningsMap(resp.GetHostWarnings(), txtfmt.NewIndentWriter(out), opts...); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+
+	return nil
+}
+
+// formatPhaseDuration rounds the duration of a phase for display.
+func formatPhaseDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// printPhaseTimings writes a table of the time taken by each phase of an
+// operation to the supplied io.Writer.
+func printPhaseTimings(timings []*control.PhaseTiming, out io.Writer) {
+	phaseTitle := "Phase"
+	durTitle := "Duration"
+
+	tablePrint := txtfmt.NewTableFormatter(phaseTitle, durTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	for _, pt := range timings {
+		table = append(table, txtfmt.TableRow{
+			phaseTitle: pt.Phase,
+			durTitle:   formatPhaseDuration(pt.Duration()),
+		})
+	}
+
+	tablePrint.Format(table)
+}
+
+// PrintResponseTimings writes the time taken by each phase of the operation
+// on each host in the supplied response, if any, to the supplied io.Writer.
+func PrintResponseTimings(resp hostTimingsGetter, out io.Writer, opts ...PrintConfigOption) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	htm := resp.GetHostTimings()
+	for _, addr := range htm.Keys() {
+		fmt.Fprintf(out, "Timings for %s:\n", getPrintHosts(addr, opts...))
+		printPhaseTimings(htm[addr], txtfmt.NewIndentWriter(out))
+		fmt.Fprintln(out)
+	}
+
+	return nil
+}
@@ -107,3 +107,98 @@ host1:1,host2:2 whoops
 		})
 	}
 }
+
+func TestControl_PrintResponseWarnings(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostWarnings map[string][]string
+		expPrintStr  string
+	}{
+		"no warnings": {
+			hostWarnings: map[string][]string{"host1": nil},
+		},
+		"two hosts two warnings": {
+			hostWarnings: map[string][]string{
+				"host1": {"firmware outdated", "too few hugepages"},
+				"host2": {"too few hugepages"},
+			},
+			expPrintStr: `
+Warnings:
+  Hosts     Warning           
+  -----     -------           
+  host1     firmware outdated 
+  host[1-2] too few hugepages 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &control.HostWarningsResp{
+				HostWarnings: make(control.HostWarningsMap),
+			}
+			for host, warnings := range tc.hostWarnings {
+				for _, warning := range warnings {
+					if err := resp.HostWarnings.Add(host, warning); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var bld strings.Builder
+			if err := PrintResponseWarnings(resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_PrintResponseTimings(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostTimings control.HostTimingsMap
+		expPrintStr string
+	}{
+		"no timings": {},
+		"two hosts": {
+			hostTimings: control.HostTimingsMap{
+				"host2:10001": {
+					{Phase: "scm format", DurationUs: 2500123},
+				},
+				"host1:10001": {
+					{Phase: "scm format", DurationUs: 2100456},
+					{Phase: "instance 0 spdk init", DurationUs: 850},
+					{Phase: "instance 0 nvme format", DurationUs: 61000000},
+				},
+			},
+			expPrintStr: `
+Timings for host1:
+  Phase                  Duration 
+  -----                  -------- 
+  scm format             2.1s     
+  instance 0 spdk init   850µs    
+  instance 0 nvme format 1m1s     
+
+Timings for host2:
+  Phase      Duration 
+  -----      -------- 
+  scm format 2.5s     
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &control.HostTimingsResp{HostTimings: tc.hostTimings}
+
+			var bld strings.Builder
+			if err := PrintResponseTimings(resp, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import "strings"
+
+// Severity indicates how significant a value in the output is to the user.
+type Severity int
+
+// Severities in increasing order of significance.
+const (
+	SeverityNone Severity = iota
+	SeverityOK
+	SeverityWarning
+	SeverityError
+)
+
+// OutputStyle determines how the severity of values is rendered.
+type OutputStyle int
+
+const (
+	// OutputStyleNone renders values unchanged, for output that is not
+	// written to a terminal.
+	OutputStyleNone OutputStyle = iota
+	// OutputStyleColor renders values in a color indicating severity.
+	OutputStyleColor
+	// OutputStylePlain prefixes values with an icon indicating severity,
+	// for terminals where color has been disabled.
+	OutputStylePlain
+)
+
+const ansiReset = "\x1b[0m"
+
+var (
+	severityColors = map[Severity]string{
+		SeverityOK:      "\x1b[32m",   // green
+		SeverityWarning: "\x1b[33m",   // yellow
+		SeverityError:   "\x1b[1;31m", // bold red
+	}
+	severityIcons = map[Severity]string{
+		SeverityOK:      "✓", // check mark
+		SeverityWarning: "⚠", // warning sign
+		SeverityError:   "✗", // ballot x
+	}
+
+	// stateSeverities maps the lower-case states of ranks, pool targets
+	// and SSDs to their severity.
+	stateSeverities = map[string]Severity{
+		"ready":        SeverityOK,
+		"joined":       SeverityOK,
+		"normal":       SeverityOK,
+		"up":           SeverityOK,
+		"upin":         SeverityOK,
+		"ok":           SeverityOK,
+		"awaitformat":  SeverityWarning,
+		"starting":     SeverityWarning,
+		"stopping":     SeverityWarning,
+		"stopped":      SeverityWarning,
+		"unknown":      SeverityWarning,
+		"unknown rank": SeverityWarning,
+		"new":          SeverityWarning,
+		"drain":        SeverityWarning,
+		"degraded":     SeverityWarning,
+		"excluded":     SeverityError,
+		"errored":      SeverityError,
+		"unresponsive": SeverityError,
+		"evicted":      SeverityError,
+		"faulty":       SeverityError,
+		"down":         SeverityError,
+		"downout":      SeverityError,
+	}
+
+	// outputStyle is the style used when rendering values, it is set once
+	// when dmg starts.
+	outputStyle = OutputStyleNone
+)
+
+// SelectOutputStyle returns the style for output that may be written to a
+// terminal, color is only used if it has not been disabled by the user.
+func SelectOutputStyle(tty, noColor bool) OutputStyle {
+	switch {
+	case !tty:
+		return OutputStyleNone
+	case noColor:
+		return OutputStylePlain
+	default:
+		return OutputStyleColor
+	}
+}
+
+// SetOutputStyle sets the style used to render the severity of values in
+// subsequent output.
+func SetOutputStyle(style OutputStyle) {
+	outputStyle = style
+}
+
+// Render returns the value decorated to indicate the given severity.
+func (style OutputStyle) Render(sev Severity, value string) string {
+	if value == "" || sev == SeverityNone {
+		return value
+	}
+
+	switch style {
+	case OutputStyleColor:
+		return severityColors[sev] + value + ansiReset
+	case OutputStylePlain:
+		return severityIcons[sev] + " " + value
+	default:
+		return value
+	}
+}
+
+// Render returns the value decorated to indicate the given severity in the
+// current output style.
+func Render(sev Severity, value string) string {
+	return outputStyle.Render(sev, value)
+}
+
+// StateSeverity returns the severity of a rank, pool target or SSD state.
+func StateSeverity(state string) Severity {
+	return stateSeverities[strings.ToLower(state)]
+}
+
+// RenderState returns the state decorated to indicate its severity in the
+// current output style.
+func RenderState(state string) string {
+	return Render(StateSeverity(state), state)
+}
+
+// renderResult returns the result of an operation decorated to indicate
+// success or failure in the current output style.
+func renderResult(result string) string {
+	sev := StateSeverity(result)
+	if sev == SeverityNone {
+		sev = SeverityError
+	}
+	return Render(sev, result)
+}
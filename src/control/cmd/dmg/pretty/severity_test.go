@@ -0,0 +1,79 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"testing"
+)
+
+func TestPretty_SelectOutputStyle(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tty      bool
+		noColor  bool
+		expStyle OutputStyle
+	}{
+		"not a terminal":           {expStyle: OutputStyleNone},
+		"not a terminal; no color": {noColor: true, expStyle: OutputStyleNone},
+		"terminal":                 {tty: true, expStyle: OutputStyleColor},
+		"terminal; no color":       {tty: true, noColor: true, expStyle: OutputStylePlain},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := SelectOutputStyle(tc.tty, tc.noColor); got != tc.expStyle {
+				t.Fatalf("expected style %d, got %d", tc.expStyle, got)
+			}
+		})
+	}
+}
+
+func TestPretty_OutputStyle_Render(t *testing.T) {
+	for name, tc := range map[string]struct {
+		style  OutputStyle
+		sev    Severity
+		value  string
+		expOut string
+	}{
+		"none":               {style: OutputStyleNone, sev: SeverityError, value: "Excluded", expOut: "Excluded"},
+		"color; ok":          {style: OutputStyleColor, sev: SeverityOK, value: "Joined", expOut: "\x1b[32mJoined\x1b[0m"},
+		"color; warning":     {style: OutputStyleColor, sev: SeverityWarning, value: "Stopped", expOut: "\x1b[33mStopped\x1b[0m"},
+		"color; error":       {style: OutputStyleColor, sev: SeverityError, value: "Excluded", expOut: "\x1b[1;31mExcluded\x1b[0m"},
+		"color; no severity": {style: OutputStyleColor, value: "foo", expOut: "foo"},
+		"color; empty value": {style: OutputStyleColor, sev: SeverityError, expOut: ""},
+		"plain; ok":          {style: OutputStylePlain, sev: SeverityOK, value: "Joined", expOut: "✓ Joined"},
+		"plain; warning":     {style: OutputStylePlain, sev: SeverityWarning, value: "Stopped", expOut: "⚠ Stopped"},
+		"plain; error":       {style: OutputStylePlain, sev: SeverityError, value: "Excluded", expOut: "✗ Excluded"},
+		"plain; no severity": {style: OutputStylePlain, value: "foo", expOut: "foo"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.style.Render(tc.sev, tc.value); got != tc.expOut {
+				t.Fatalf("expected %q, got %q", tc.expOut, got)
+			}
+		})
+	}
+}
+
+func TestPretty_StateSeverity(t *testing.T) {
+	for state, expSev := range map[string]Severity{
+		"Ready":        SeverityOK,
+		"Joined":       SeverityOK,
+		"NORMAL":       SeverityOK,
+		"up":           SeverityOK,
+		"Stopped":      SeverityWarning,
+		"Unknown Rank": SeverityWarning,
+		"drain":        SeverityWarning,
+		"Excluded":     SeverityError,
+		"Errored":      SeverityError,
+		"FAULTY":       SeverityError,
+		"downout":      SeverityError,
+		"something":    SeverityNone,
+	} {
+		t.Run(state, func(t *testing.T) {
+			if got := StateSeverity(state); got != expSev {
+				t.Fatalf("expected severity %d, got %d", expSev, got)
+			}
+		})
+	}
+}
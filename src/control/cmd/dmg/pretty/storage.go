@@ -9,7 +9,9 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 
@@ -40,6 +42,13 @@ func printHostStorageMapVerbose(hsm control.HostStorageMap, out io.Writer, opts
 			return err
 		}
 		fmt.Fprintln(out)
+		if len(hss.HostStorage.DeviceOwners) > 0 {
+			printDeviceOwners(hss.HostStorage.DeviceOwners, out)
+			fmt.Fprintln(out)
+		}
+		if hss.HostStorage.NvmeCapabilities != nil {
+			fmt.Fprintf(out, "NVMe Capabilities: %s\n\n", hss.HostStorage.NvmeCapabilities)
+		}
 	}
 
 	return nil
@@ -82,6 +91,50 @@ func PrintHostStorageMap(hsm control.HostStorageMap, out io.Writer, opts ...Prin
 	return nil
 }
 
+// PrintHostStorageMapCSV generates a comma-separated values representation of
+// the supplied HostStorageMap with one record per host and writes it to the
+// supplied io.Writer.
+func PrintHostStorageMapCSV(hsm control.HostStorageMap, out io.Writer, opts ...PrintConfigOption) error {
+	hostTitle := "Host"
+	scmModTitle := "SCM Modules"
+	scmModBytesTitle := "SCM Module Bytes"
+	scmNsTitle := "SCM Namespaces"
+	scmNsBytesTitle := "SCM Namespace Bytes"
+	nvmeTitle := "NVMe Controllers"
+	nvmeBytesTitle := "NVMe Bytes"
+
+	formatter := txtfmt.NewCSVFormatter(hostTitle, scmModTitle, scmModBytesTitle,
+		scmNsTitle, scmNsBytesTitle, nvmeTitle, nvmeBytesTitle)
+	table := []txtfmt.TableRow{}
+
+	for _, key := range hsm.Keys() {
+		hss := hsm[key]
+		hs := hss.HostStorage
+		for _, host := range hss.HostSet.Slice() {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:        getPrintHosts(host, opts...),
+				scmModTitle:      fmt.Sprintf("%d", len(hs.ScmModules)),
+				scmModBytesTitle: fmt.Sprintf("%d", hs.ScmModules.Capacity()),
+				scmNsTitle:       fmt.Sprintf("%d", len(hs.ScmNamespaces)),
+				scmNsBytesTitle:  fmt.Sprintf("%d", hs.ScmNamespaces.Capacity()),
+				nvmeTitle:        fmt.Sprintf("%d", len(hs.NvmeDevices)),
+				nvmeBytesTitle:   fmt.Sprintf("%d", hs.NvmeDevices.Capacity()),
+			})
+		}
+	}
+	sort.Slice(table, func(i, j int) bool {
+		return table[i][hostTitle] < table[j][hostTitle]
+	})
+
+	csv, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, csv)
+
+	return err
+}
+
 // PrintHostStorageUsageMap generates a human-readable representation of the supplied
 // HostStorageMap struct and writes utilization info to the supplied io.Writer.
 func PrintHostStorageUsageMap(hsm control.HostStorageMap, out io.Writer) error {
@@ -149,6 +202,66 @@ func PrintScmPrepareMap(hsm control.HostStorageMap, out io.Writer, opts ...Print
 	return nil
 }
 
+// PrintNvmePrepareStatusMap generates a human-readable representation of the
+// NVMe driver binding state in the supplied HostStorageMap which is populated
+// in response to a StoragePrepare status request.
+func PrintNvmePrepareStatusMap(hsm control.HostStorageMap, out io.Writer, opts ...PrintConfigOption) error {
+	for _, key := range hsm.Keys() {
+		hss := hsm[key]
+		nps := hss.HostStorage.NvmePrepareStatus
+		if nps == nil {
+			continue
+		}
+
+		hosts := getPrintHosts(hss.HostSet.RangedString(), opts...)
+		lineBreak := strings.Repeat("-", len(hosts))
+		fmt.Fprintf(out, "%s\n%s\n%s\n", lineBreak, hosts, lineBreak)
+
+		iw := txtfmt.NewIndentWriter(out)
+		fmt.Fprintf(iw, "Bind State: %s\n", nps.BindState)
+		fmt.Fprintf(iw, "Reset Required: %t\n", nps.ResetRequired())
+		if nps.LastOp != "" {
+			fmt.Fprintf(iw, "Last Operation: %s at %s\n", nps.LastOp,
+				getTimestampString(nps.LastOpTime))
+			if nps.LastOpError != "" {
+				fmt.Fprintf(iw, "Last Operation Error: %s\n", nps.LastOpError)
+			}
+		}
+		fmt.Fprintln(out)
+
+		if len(nps.Bindings) == 0 {
+			fmt.Fprintln(iw, "No NVMe devices found")
+			fmt.Fprintln(out)
+			continue
+		}
+
+		pciTitle := "PCI Address"
+		driverTitle := "Driver"
+		stateTitle := "State"
+
+		tablePrint := txtfmt.NewTableFormatter(pciTitle, driverTitle, stateTitle)
+		tablePrint.InitWriter(iw)
+		table := []txtfmt.TableRow{}
+
+		for _, nb := range nps.Bindings {
+			driver := nb.Driver
+			if driver == "" {
+				driver = "none"
+			}
+			table = append(table, txtfmt.TableRow{
+				pciTitle:    nb.PciAddr,
+				driverTitle: driver,
+				stateTitle:  string(nb.State),
+			})
+		}
+
+		tablePrint.Format(table)
+		fmt.Fprintln(out)
+	}
+
+	return nil
+}
+
 func printStorageFormatMapVerbose(hsm control.HostStorageMap, out io.Writer, opts ...PrintConfigOption) error {
 	for _, key := range hsm.Keys() {
 		hss := hsm[key]
@@ -211,7 +324,7 @@ func printSmdDevice(dev *storage.SmdDevice, iw io.Writer, opts ...PrintConfigOpt
 
 	iw1 := txtfmt.NewIndentWriter(iw)
 	if _, err := fmt.Fprintf(iw1, "Targets:%+v Rank:%d State:%s\n",
-		dev.TargetIDs, dev.Rank, dev.State); err != nil {
+		dev.TargetIDs, dev.Rank, RenderState(dev.State)); err != nil {
 
 		return err
 	}
@@ -292,3 +405,235 @@ func PrintSmdInfoMap(req *control.SmdQueryReq, hsm control.HostStorageMap, out i
 
 	return w.Err
 }
+
+// PrintSmdDeviceStats generates a human-readable table of the I/O statistics
+// of the devices in the supplied HostStorageMap and writes it to the supplied
+// io.Writer. Latencies are reported as the 50th and 99th percentiles.
+func PrintSmdDeviceStats(hsm control.HostStorageMap, out io.Writer, opts ...PrintConfigOption) error {
+	hostTitle := "Host"
+	rankTitle := "Rank"
+	uuidTitle := "UUID"
+	inflightTitle := "In-flight"
+	depthTitle := "Peak Depth"
+	readsTitle := "Reads"
+	writesTitle := "Writes"
+	readLatTitle := "Read p50/p99"
+	writeLatTitle := "Write p50/p99"
+
+	tablePrint := txtfmt.NewTableFormatter(hostTitle, rankTitle, uuidTitle,
+		inflightTitle, depthTitle, readsTitle, writesTitle, readLatTitle, writeLatTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	latency := func(p50, p99 uint64) string {
+		return fmt.Sprintf("%s/%s", time.Duration(p50)*time.Microsecond,
+			time.Duration(p99)*time.Microsecond)
+	}
+
+	for _, key := range hsm.Keys() {
+		hss := hsm[key]
+		if hss.HostStorage.SmdInfo == nil {
+			continue
+		}
+		hosts := getPrintHosts(hss.HostSet.RangedString(), opts...)
+
+		for _, dev := range hss.HostStorage.SmdInfo.Devices {
+			row := txtfmt.TableRow{
+				hostTitle: hosts,
+				rankTitle: dev.Rank.String(),
+				uuidTitle: dev.UUID,
+			}
+			if dev.Health == nil {
+				row[inflightTitle] = "N/A"
+				table = append(table, row)
+				continue
+			}
+
+			h := dev.Health
+			row[inflightTitle] = fmt.Sprintf("%d", h.IOInflight)
+			row[depthTitle] = fmt.Sprintf("%d", h.IOQueueDepth)
+			row[readsTitle] = fmt.Sprintf("%d (%s)", h.ReadOps, humanize.IBytes(h.ReadBytes))
+			row[writesTitle] = fmt.Sprintf("%d (%s)", h.WriteOps, humanize.IBytes(h.WriteBytes))
+			row[readLatTitle] = latency(h.ReadLatP50, h.ReadLatP99)
+			row[writeLatTitle] = latency(h.WriteLatP50, h.WriteLatP99)
+			table = append(table, row)
+		}
+	}
+
+	if len(table) == 0 {
+		fmt.Fprintln(out, "No devices found")
+		return nil
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
+
+// printDeviceOwners writes a table of the engine instances recorded in a
+// host's device ledger as owning its storage devices.
+func printDeviceOwners(owners []*control.DeviceOwner, out io.Writer) {
+	deviceTitle := "Device"
+	classTitle := "Class"
+	engineTitle := "Owning Engine"
+
+	tablePrint := txtfmt.NewTableFormatter(deviceTitle, classTitle, engineTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	for _, owner := range owners {
+		table = append(table, txtfmt.TableRow{
+			deviceTitle: owner.Device,
+			classTitle:  owner.Class,
+			engineTitle: fmt.Sprintf("%d", owner.InstanceIdx),
+		})
+	}
+
+	tablePrint.Format(table)
+}
+
+// PrintStorageOwnership generates a human-readable representation of the
+// supplied per-host device ownership ledgers and writes it to the supplied
+// io.Writer.
+func PrintStorageOwnership(hostOwners map[string][]*control.DeviceOwner, out io.Writer) error {
+	if len(hostOwners) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	deviceTitle := "Device"
+	classTitle := "Class"
+	engineTitle := "Engine"
+
+	tablePrint := txtfmt.NewTableFormatter(hostTitle, deviceTitle, classTitle, engineTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	hosts := make([]string, 0, len(hostOwners))
+	for host := range hostOwners {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		for _, owner := range hostOwners[host] {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   host,
+				deviceTitle: owner.Device,
+				classTitle:  owner.Class,
+				engineTitle: fmt.Sprintf("%d", owner.InstanceIdx),
+			})
+		}
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
+
+// PrintStorageVerify generates a human-readable representation of the
+// supplied per-host NVMe read verification progress and writes it to the
+// supplied io.Writer. Any errors encountered are listed after the table.
+func PrintStorageVerify(hostVerify map[string]*control.HostStorageVerify, out io.Writer) error {
+	if len(hostVerify) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	deviceTitle := "Device"
+	nsTitle := "Namespace"
+	engineTitle := "Engine"
+	stateTitle := "State"
+	progressTitle := "Progress"
+	badTitle := "Bad LBAs"
+
+	tablePrint := txtfmt.NewTableFormatter(hostTitle, deviceTitle, nsTitle, engineTitle,
+		stateTitle, progressTitle, badTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	hosts := make([]string, 0, len(hostVerify))
+	for host := range hostVerify {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var failures []string
+	for _, host := range hosts {
+		for _, result := range hostVerify[host].Results {
+			progress := "-"
+			if result.TotalBytes > 0 {
+				progress = fmt.Sprintf("%.1f%%",
+					float64(result.VerifiedBytes)/float64(result.TotalBytes)*100)
+			}
+			table = append(table, txtfmt.TableRow{
+				hostTitle:     host,
+				deviceTitle:   result.PciAddr,
+				nsTitle:       fmt.Sprintf("%d", result.NsID),
+				engineTitle:   fmt.Sprintf("%d", result.InstanceIdx),
+				stateTitle:    result.State,
+				progressTitle: progress,
+				badTitle:      fmt.Sprintf("%d", result.NumBadLBAs),
+			})
+			if result.Error != "" {
+				failures = append(failures, fmt.Sprintf("%s %s: %s", host,
+					result.PciAddr, result.Error))
+			}
+		}
+	}
+
+	if len(table) == 0 {
+		fmt.Fprintln(out, "No NVMe read verification results")
+		return nil
+	}
+
+	tablePrint.Format(table)
+	for _, failure := range failures {
+		fmt.Fprintln(out, failure)
+	}
+
+	return nil
+}
+
+// PrintStorageSelfTestResults generates a human-readable representation of
+// the supplied per-host storage self-test results and writes it to the
+// supplied io.Writer.
+func PrintStorageSelfTestResults(hostResults map[string][]*control.StorageSelfTestResult, out io.Writer) error {
+	if len(hostResults) == 0 {
+		return nil
+	}
+
+	hostTitle := "Host"
+	deviceTitle := "Device"
+	iopsTitle := "IOPS"
+	bwTitle := "Bandwidth (MiB/s)"
+	avgTitle := "Avg (us)"
+	minTitle := "Min (us)"
+	maxTitle := "Max (us)"
+
+	tablePrint := txtfmt.NewTableFormatter(hostTitle, deviceTitle, iopsTitle, bwTitle,
+		avgTitle, minTitle, maxTitle)
+	tablePrint.InitWriter(out)
+	table := []txtfmt.TableRow{}
+
+	hosts := make([]string, 0, len(hostResults))
+	for host := range hostResults {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		for _, r := range hostResults[host] {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   host,
+				deviceTitle: r.PciAddr,
+				iopsTitle:   fmt.Sprintf("%.0f", r.Iops),
+				bwTitle:     fmt.Sprintf("%.2f", r.Bandwidth),
+				avgTitle:    fmt.Sprintf("%.2f", r.LatAvg),
+				minTitle:    fmt.Sprintf("%.2f", r.LatMin),
+				maxTitle:    fmt.Sprintf("%.2f", r.LatMax),
+			})
+		}
+	}
+
+	tablePrint.Format(table)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
@@ -156,10 +157,37 @@ func PrintNvmeControllers(controllers storage.NvmeControllers, out io.Writer, op
 	fwTitle := "FW Revision"
 	socketTitle := "Socket ID"
 	capacityTitle := "Capacity"
+	linkTitle := "PCIe Link"
+	zonedTitle := "Zoned"
+	bindTitle := "Binding"
 
-	formatter := txtfmt.NewTableFormatter(
-		pciTitle, modelTitle, fwTitle, socketTitle, capacityTitle,
-	)
+	titles := []string{pciTitle, modelTitle, fwTitle, socketTitle, capacityTitle}
+	// only display link status if reported for any of the controllers
+	for _, ctrlr := range controllers {
+		if ctrlr.PciLink != nil {
+			titles = append(titles, linkTitle)
+			break
+		}
+	}
+	// only display zoned status if any of the controllers has a ZNS namespace
+	for _, ctrlr := range controllers {
+		if ctrlr.Zoned() {
+			titles = append(titles, zonedTitle)
+			break
+		}
+	}
+	// only display binding if any of the controllers is bound to the kernel
+	var kernelBound int
+	for _, ctrlr := range controllers {
+		if ctrlr.KernelBound() {
+			kernelBound++
+		}
+	}
+	if kernelBound > 0 {
+		titles = append(titles, bindTitle)
+	}
+
+	formatter := txtfmt.NewTableFormatter(titles...)
 	formatter.InitWriter(out)
 	var table []txtfmt.TableRow
 
@@ -171,11 +199,22 @@ func PrintNvmeControllers(controllers storage.NvmeControllers, out io.Writer, op
 		row[fwTitle] = ctrlr.FwRev
 		row[socketTitle] = fmt.Sprint(ctrlr.SocketID)
 		row[capacityTitle] = humanize.Bytes(ctrlr.Capacity())
+		row[linkTitle] = ctrlr.PciLink.String()
+		row[zonedTitle] = fmt.Sprint(ctrlr.Zoned())
+		row[bindTitle] = string(storage.NvmeBindStateSpdk)
+		if ctrlr.KernelBound() {
+			row[bindTitle] = fmt.Sprintf("%s (%s)", ctrlr.BindState, ctrlr.Driver)
+		}
 
 		table = append(table, row)
 	}
 
 	formatter.Format(table)
+	if kernelBound > 0 {
+		fmt.Fprintf(w, "\n%s bound to the kernel NVMe driver, run storage prepare "+
+			"to make %s available to DAOS\n", english.Plural(kernelBound, "SSD is", "SSDs are"),
+			english.PluralWord(kernelBound, "it", "them"))
+	}
 	return w.Err
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/storage"
@@ -422,7 +423,29 @@ func TestControl_PrintStorageScanResponseVerbose(t *testing.T) {
 		bothFailed = control.MockServerScanResp(t, "bothFailed")
 		nvmeBasicA = control.MockServerScanResp(t, "nvmeBasicA")
 		nvmeBasicB = control.MockServerScanResp(t, "nvmeBasicB")
+		pciLink    = control.MockServerScanResp(t, "standard")
+		withCapsA  = control.MockServerScanResp(t, "withCapsA")
+		withCapsB  = control.MockServerScanResp(t, "withCapsB")
+		zoned      = control.MockServerScanResp(t, "standard")
+		kernelBnd  = control.MockServerScanResp(t, "standard")
+		withOwners = control.MockServerScanResp(t, "withOwners")
 	)
+	pciLink.Nvme.Ctrlrs[0].PciLink = &ctlpb.NvmeController_PciLink{
+		Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4,
+	}
+	zoned.Nvme.Ctrlrs[0].Namespaces = []*ctlpb.NvmeController_Namespace{
+		{Id: 1, Size: 2000000000000, Zoned: true},
+	}
+	kernelBnd.Nvme.Ctrlrs = append(kernelBnd.Nvme.Ctrlrs, &ctlpb.NvmeController{
+		Model:      "model-2",
+		Serial:     "serial-2",
+		PciAddr:    "0000:81:00.0",
+		FwRev:      "fwRev-2",
+		SocketId:   1,
+		Namespaces: []*ctlpb.NvmeController_Namespace{{Id: 1, Size: 1600000000000}},
+		Driver:     storage.NvmeKernelDriver,
+		BindState:  string(storage.NvmeBindStateKernel),
+	})
 
 	for name, tc := range map[string]struct {
 		mic         *control.MockInvokerConfig
@@ -587,6 +610,158 @@ NVMe PCI     Model   FW Revision Socket ID Capacity
 --------     -----   ----------- --------- -------- 
 0000:80:00.1 model-1 fwRev-1     1         2.0 TB   
 
+`,
+		},
+		"single host with degraded pci link": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponse: &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    "host1",
+							Message: pciLink,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+-----
+host1
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity PCIe Link                             
+--------     -----   ----------- --------- -------- ---------                             
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   x1 8GT/s (degraded, x4 8GT/s capable) 
+
+`,
+		},
+		"single host with zoned ssd": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponse: &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    "host1",
+							Message: zoned,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+-----
+host1
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity Zoned 
+--------     -----   ----------- --------- -------- ----- 
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   true  
+
+`,
+		},
+		"single host with kernel-bound ssd": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponse: &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    "host1",
+							Message: kernelBnd,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+-----
+host1
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity Binding             
+--------     -----   ----------- --------- -------- -------             
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   spdk-bound          
+0000:81:00.0 model-2 fwRev-2     1         1.6 TB   kernel-bound (nvme) 
+
+1 SSD is bound to the kernel NVMe driver, run storage prepare to make it available to DAOS
+
+`,
+		},
+		"single host with device owners": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponse: &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    "host1",
+							Message: withOwners,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+-----
+host1
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity 
+--------     -----   ----------- --------- -------- 
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   
+
+Device       Class Owning Engine 
+------       ----- ------------- 
+/dev/pmem0   dcpm  0             
+0000:81:00.0 nvme  0             
+
+`,
+		},
+		"hosts with different nvme capabilities": {
+			mic: &control.MockInvokerConfig{
+				UnaryResponse: &control.UnaryResponse{
+					Responses: []*control.HostResponse{
+						{
+							Addr:    "host1",
+							Message: withCapsA,
+						},
+						{
+							Addr:    "host2",
+							Message: withCapsB,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+-----
+host1
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity 
+--------     -----   ----------- --------- -------- 
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   
+
+NVMe Capabilities: SPDK v21.07, DPDK 21.05.0, features: vmd, zns, cmb
+
+-----
+host2
+-----
+SCM Module ID Socket ID Memory Ctrlr ID Channel ID Channel Slot Capacity 
+------------- --------- --------------- ---------- ------------ -------- 
+1             1         1               1          1            954 MiB  
+
+NVMe PCI     Model   FW Revision Socket ID Capacity 
+--------     -----   ----------- --------- -------- 
+0000:80:00.1 model-1 fwRev-1     1         2.0 TB   
+
+NVMe Capabilities: SPDK v20.01, DPDK 19.11.0, features: vmd (disabled)
+
 `,
 		},
 		"single host with namespace": {
@@ -1497,3 +1672,383 @@ host1
 		})
 	}
 }
+
+func TestPretty_PrintSmdDeviceStats(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hsm         control.HostStorageMap
+		expPrintStr string
+	}{
+		"no devices": {
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{
+					"host1",
+					&control.HostStorage{
+						SmdInfo: &control.SmdInfo{},
+					},
+				},
+			),
+			expPrintStr: `
+No devices found
+`,
+		},
+		"stats and missing health": {
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{
+					"host1",
+					&control.HostStorage{
+						SmdInfo: &control.SmdInfo{
+							Devices: []*storage.SmdDevice{
+								{
+									UUID: common.MockUUID(0),
+									Rank: 0,
+									Health: &storage.NvmeHealth{
+										IOInflight:   4,
+										IOQueueDepth: 32,
+										ReadOps:      100,
+										WriteOps:     20,
+										ReadBytes:    1 << 20,
+										WriteBytes:   2 << 10,
+										ReadLatP50:   64,
+										ReadLatP99:   1024,
+										WriteLatP50:  128,
+										WriteLatP99:  2048,
+									},
+								},
+								{
+									UUID: common.MockUUID(1),
+									Rank: 1,
+								},
+							},
+						},
+					},
+				},
+			),
+			expPrintStr: `
+Host  Rank UUID                                 In-flight Peak Depth Reads         Writes       Read p50/p99 Write p50/p99 
+----  ---- ----                                 --------- ---------- -----         ------       ------------ ------------- 
+host1 0    00000000-0000-0000-0000-000000000000 4         32         100 (1.0 MiB) 20 (2.0 KiB) 64µs/1.024ms 128µs/2.048ms 
+host1 1    00000001-0001-0001-0001-000000000001 N/A       None       None          None         None         None          
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSmdDeviceStats(tc.hsm, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected print output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintStorageOwnership(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostOwners  map[string][]*control.DeviceOwner
+		expPrintStr string
+	}{
+		"empty": {
+			expPrintStr: "",
+		},
+		"two hosts": {
+			hostOwners: map[string][]*control.DeviceOwner{
+				"host2": {
+					{Device: "0000:81:00.0", Class: "nvme", InstanceIdx: 1},
+				},
+				"host1": {
+					{Device: "/dev/pmem0", Class: "dcpm", InstanceIdx: 0},
+					{Device: "0000:81:00.0", Class: "nvme", InstanceIdx: 0},
+				},
+			},
+			expPrintStr: `
+Host  Device       Class Engine 
+----  ------       ----- ------ 
+host1 /dev/pmem0   dcpm  0      
+host1 0000:81:00.0 nvme  0      
+host2 0000:81:00.0 nvme  1      
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintStorageOwnership(tc.hostOwners, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintStorageVerify(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostVerify  map[string]*control.HostStorageVerify
+		expPrintStr string
+	}{
+		"empty": {
+			expPrintStr: "",
+		},
+		"no results": {
+			hostVerify: map[string]*control.HostStorageVerify{
+				"host1": {},
+			},
+			expPrintStr: `
+No NVMe read verification results
+`,
+		},
+		"two hosts": {
+			hostVerify: map[string]*control.HostStorageVerify{
+				"host2": {
+					Rate: 100,
+					Results: []*control.NvmeVerifyResult{
+						{
+							PciAddr:     "0000:81:00.0",
+							NsID:        1,
+							InstanceIdx: 1,
+							State:       "failed",
+							Error:       "spdk verify failed",
+						},
+					},
+				},
+				"host1": {
+					Rate: 100,
+					Results: []*control.NvmeVerifyResult{
+						{
+							PciAddr:       "0000:81:00.0",
+							NsID:          1,
+							State:         "done",
+							TotalBytes:    4096,
+							VerifiedBytes: 4096,
+							NumBadLBAs:    2,
+						},
+						{
+							PciAddr:       "0000:82:00.0",
+							NsID:          1,
+							State:         "running",
+							TotalBytes:    4096,
+							VerifiedBytes: 1024,
+						},
+					},
+				},
+			},
+			expPrintStr: `
+Host  Device       Namespace Engine State   Progress Bad LBAs 
+----  ------       --------- ------ -----   -------- -------- 
+host1 0000:81:00.0 1         0      done    100.0%   2        
+host1 0000:82:00.0 1         0      running 25.0%    0        
+host2 0000:81:00.0 1         1      failed  -        0        
+host2 0000:81:00.0: spdk verify failed
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintStorageVerify(tc.hostVerify, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintHostStorageMapCSV(t *testing.T) {
+	withNvme := &control.HostStorage{
+		ScmModules: storage.ScmModules{
+			{Capacity: 1000}, {Capacity: 1000},
+		},
+		ScmNamespaces: storage.ScmNamespaces{
+			{Size: 1500},
+		},
+		NvmeDevices: storage.NvmeControllers{
+			{Namespaces: []*storage.NvmeNamespace{{Size: 3000}}},
+		},
+	}
+	noNvme := &control.HostStorage{
+		ScmModules: storage.ScmModules{
+			{Capacity: 1000},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		hsm         control.HostStorageMap
+		expPrintStr string
+	}{
+		"empty": {
+			hsm: control.HostStorageMap{},
+			expPrintStr: `
+Host,SCM Modules,SCM Module Bytes,SCM Namespaces,SCM Namespace Bytes,NVMe Controllers,NVMe Bytes
+`,
+		},
+		"one record per host": {
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{"host3:10001", withNvme},
+				&mockHostStorage{"host1:10001", withNvme},
+				&mockHostStorage{"host2:10001", noNvme},
+			),
+			expPrintStr: `
+Host,SCM Modules,SCM Module Bytes,SCM Namespaces,SCM Namespace Bytes,NVMe Controllers,NVMe Bytes
+host1,2,2000,1,1500,1,3000
+host2,1,1000,0,0,0,0
+host3,2,2000,1,1500,1,3000
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintHostStorageMapCSV(tc.hsm, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintStorageSelfTestResults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostResults map[string][]*control.StorageSelfTestResult
+		expPrintStr string
+	}{
+		"empty": {
+			hostResults: map[string][]*control.StorageSelfTestResult{},
+			expPrintStr: "",
+		},
+		"two hosts": {
+			hostResults: map[string][]*control.StorageSelfTestResult{
+				"host2": {
+					{PciAddr: "0000:81:00.0", Iops: 24980.6, Bandwidth: 3122.58,
+						LatAvg: 1280.1, LatMin: 200.5, LatMax: 9012.25},
+				},
+				"host1": {
+					{PciAddr: "0000:81:00.0", Iops: 25361.2, Bandwidth: 3170.15,
+						LatAvg: 1261.52, LatMin: 210.11, LatMax: 8421.93},
+					{PciAddr: "0000:82:00.0", Iops: 25102.4, Bandwidth: 3137.8,
+						LatAvg: 1274.4, LatMin: 205.9, LatMax: 8811.01},
+				},
+			},
+			expPrintStr: `
+Host  Device       IOPS  Bandwidth (MiB/s) Avg (us) Min (us) Max (us) 
+----  ------       ----  ----------------- -------- -------- -------- 
+host1 0000:81:00.0 25361 3170.15           1261.52  210.11   8421.93  
+host1 0000:82:00.0 25102 3137.80           1274.40  205.90   8811.01  
+host2 0000:81:00.0 24981 3122.58           1280.10  200.50   9012.25  
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintStorageSelfTestResults(tc.hostResults, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintNvmePrepareStatusMap(t *testing.T) {
+	partial := &control.HostStorage{
+		NvmePrepareStatus: &storage.NvmePrepareStatus{
+			BindState: storage.NvmeBindStatePartial,
+			Bindings: storage.NvmeBindings{
+				{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+				{PciAddr: "0000:82:00.0", State: storage.NvmeBindStateUnknown},
+			},
+			LastOp:      "reset",
+			LastOpTime:  1634300000,
+			LastOpError: "spdk reset failed",
+		},
+	}
+	kernel := &control.HostStorage{
+		NvmePrepareStatus: &storage.NvmePrepareStatus{
+			BindState: storage.NvmeBindStateKernel,
+			Bindings: storage.NvmeBindings{
+				{PciAddr: "0000:81:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+			},
+		},
+	}
+	noDevs := &control.HostStorage{
+		NvmePrepareStatus: &storage.NvmePrepareStatus{
+			BindState: storage.NvmeBindStateUnknown,
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		hsm         control.HostStorageMap
+		expPrintStr string
+	}{
+		"empty": {
+			hsm:         control.HostStorageMap{},
+			expPrintStr: "",
+		},
+		"no status": {
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{"host1", &control.HostStorage{}},
+			),
+			expPrintStr: "",
+		},
+		"multiple hosts": {
+			hsm: mockHostStorageMap(t,
+				&mockHostStorage{"host1", partial},
+				&mockHostStorage{"host2", kernel},
+				&mockHostStorage{"host3", kernel},
+				&mockHostStorage{"host4", noDevs},
+			),
+			expPrintStr: fmt.Sprintf(`
+-----
+host1
+-----
+  Bind State: partially-bound
+  Reset Required: true
+  Last Operation: reset at %s
+  Last Operation Error: spdk reset failed
+
+  PCI Address  Driver   State      
+  -----------  ------   -----      
+  0000:81:00.0 vfio-pci spdk-bound 
+  0000:82:00.0 none     unknown    
+
+-----
+host4
+-----
+  Bind State: unknown
+  Reset Required: false
+
+  No NVMe devices found
+
+---------
+host[2-3]
+---------
+  Bind State: kernel-bound
+  Reset Required: false
+
+  PCI Address  Driver State        
+  -----------  ------ -----        
+  0000:81:00.0 nvme   kernel-bound 
+
+`, getTimestampString(1634300000)),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintNvmePrepareStatusMap(tc.hsm, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -9,11 +9,16 @@ package pretty
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/dustin/go-humanize/english"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/lib/txtfmt"
@@ -39,7 +44,14 @@ func tabulateRankGroups(out io.Writer, groups system.RankGroups, titles ...strin
 			return errors.New("unexpected summary format")
 		}
 		for i, title := range columnTitles {
-			row[title] = summary[i]
+			switch title {
+			case "State":
+				row[title] = RenderState(summary[i])
+			case "Result":
+				row[title] = renderResult(summary[i])
+			default:
+				row[title] = summary[i]
+			}
 		}
 
 		table = append(table, row)
@@ -66,6 +78,34 @@ func printAbsentRanks(out io.Writer, absentRanks *system.RankSet) {
 	}
 }
 
+// skewedHosts returns the control addresses of the members whose clock skew
+// exceeds the threshold, each address is only returned once.
+func skewedHosts(members system.Members) []string {
+	seen := make(map[string]struct{})
+	var hosts []string
+	for _, m := range members {
+		if m.ClockSkew == nil || !m.ClockSkew.Exceeded {
+			continue
+		}
+		addr := m.Addr.String()
+		if _, found := seen[addr]; found {
+			continue
+		}
+		seen[addr] = struct{}{}
+		hosts = append(hosts, addr)
+	}
+
+	return hosts
+}
+
+func printSkewedHosts(out io.Writer, members system.Members) {
+	if hosts := skewedHosts(members); len(hosts) > 0 {
+		fmt.Fprintf(out, "Clock skew exceeds threshold on %s: %s\n",
+			english.Plural(len(hosts), "host", "hosts"),
+			strings.Join(hosts, ","))
+	}
+}
+
 func printSystemQuery(out io.Writer, members system.Members, absentRanks *system.RankSet) error {
 	groups := make(system.RankGroups)
 	if err := groups.FromMembers(members); err != nil {
@@ -99,13 +139,100 @@ func printSystemQueryVerbose(out io.Writer, members system.Members) {
 		row[uuidTitle] = m.UUID.String()
 		row[addrTitle] = m.Addr.String()
 		row[faultDomainTitle] = m.FaultDomain.String()
-		row[stateTitle] = m.State().String()
+		row[stateTitle] = RenderState(m.State().String())
 		row[reasonTitle] = m.Info
 
 		table = append(table, row)
 	}
 
 	fmt.Fprintln(out, formatter.Format(table))
+
+	printStartupTimelines(out, members)
+	printClockSkews(out, members)
+}
+
+// printClockSkews prints the clock offset of each member's host as measured
+// by the MS replica that handled the query.
+func printClockSkews(out io.Writer, members system.Members) {
+	addrTitle := "Control Address"
+	offsetTitle := "Offset"
+	rttTitle := "Round Trip"
+	checkedTitle := "Checked"
+	statusTitle := "Status"
+
+	formatter := txtfmt.NewTableFormatter(addrTitle, offsetTitle, rttTitle, checkedTitle, statusTitle)
+	var table []txtfmt.TableRow
+
+	seen := make(map[string]struct{})
+	for _, m := range members {
+		cs := m.ClockSkew
+		if cs == nil {
+			continue
+		}
+		addr := m.Addr.String()
+		if _, found := seen[addr]; found {
+			continue
+		}
+		seen[addr] = struct{}{}
+
+		status := "OK"
+		if cs.Exceeded {
+			status = "Skewed"
+		}
+		table = append(table, txtfmt.TableRow{
+			addrTitle:    addr,
+			offsetTitle:  cs.Offset.Round(time.Millisecond).String(),
+			rttTitle:     cs.RoundTrip.Round(time.Millisecond).String(),
+			checkedTitle: common.FormatTimeNoMicro(cs.Checked),
+			statusTitle:  status,
+		})
+	}
+	if len(table) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "Clock Skew:")
+	fmt.Fprintln(out, formatter.Format(table))
+}
+
+// printStartupTimelines prints the time the members last reached storage
+// ready followed by the time taken to reach each subsequent startup phase.
+func printStartupTimelines(out io.Writer, members system.Members) {
+	rankTitle := "Rank"
+	storageTitle := "Storage Ready"
+	fabricTitle := "Fabric Up"
+	joinedTitle := "Joined"
+	poolsTitle := "Pools Restored"
+
+	formatter := txtfmt.NewTableFormatter(rankTitle, storageTitle, fabricTitle, joinedTitle, poolsTitle)
+	var table []txtfmt.TableRow
+
+	for _, m := range members {
+		st := m.Startup
+		if st == nil {
+			continue
+		}
+		elapsed := func(phase time.Time) string {
+			if phase.IsZero() {
+				return "N/A"
+			}
+			return "+" + phase.Sub(st.StorageReady).Round(time.Millisecond).String()
+		}
+
+		table = append(table, txtfmt.TableRow{
+			rankTitle:    fmt.Sprintf("%d", m.Rank),
+			storageTitle: common.FormatTimeNoMicro(st.StorageReady),
+			fabricTitle:  elapsed(st.FabricUp),
+			joinedTitle:  elapsed(st.Joined),
+			poolsTitle:   elapsed(st.PoolsRestored),
+		})
+	}
+	if len(table) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "Startup Timeline:")
+	fmt.Fprintln(out, formatter.Format(table))
 }
 
 // PrintSystemQueryResponse generates a human-readable representation of the supplied
@@ -125,16 +252,124 @@ func PrintSystemQueryResponse(out, outErr io.Writer, resp *control.SystemQueryRe
 			return err
 		}
 		printAbsentHosts(outErr, &resp.AbsentHosts)
+		printSkewedHosts(outErr, resp.Members)
 
 		return nil
 	}
 
 	printAbsentHosts(outErr, &resp.AbsentHosts)
 	printAbsentRanks(outErr, &resp.AbsentRanks)
+	printSkewedHosts(outErr, resp.Members)
 
 	return nil
 }
 
+// PrintSystemQueryCSV generates a comma-separated values representation of
+// the members in the supplied SystemQueryResp struct with one record per rank
+// and writes it to the supplied io.Writer.
+func PrintSystemQueryCSV(out io.Writer, resp *control.SystemQueryResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	rankTitle := "Rank"
+	uuidTitle := "UUID"
+	addrTitle := "Control Address"
+	faultDomainTitle := "Fault Domain"
+	stateTitle := "State"
+	reasonTitle := "Reason"
+
+	formatter := txtfmt.NewCSVFormatter(rankTitle, uuidTitle, addrTitle, faultDomainTitle,
+		stateTitle, reasonTitle)
+	table := []txtfmt.TableRow{}
+
+	for _, m := range resp.Members {
+		table = append(table, txtfmt.TableRow{
+			rankTitle:        fmt.Sprintf("%d", m.Rank),
+			uuidTitle:        m.UUID.String(),
+			addrTitle:        m.Addr.String(),
+			faultDomainTitle: m.FaultDomain.String(),
+			stateTitle:       m.State().String(),
+			reasonTitle:      m.Info,
+		})
+	}
+
+	csv, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, csv)
+
+	return err
+}
+
+// shortConfigHash abbreviates a config hash for display.
+func shortConfigHash(hash string) string {
+	const shortLen = 12
+	if len(hash) > shortLen {
+		return hash[:shortLen]
+	}
+	return hash
+}
+
+// PrintConfigDriftReport generates a human-readable representation of the
+// supplied ConfigDriftReport and writes it to the supplied io.Writer. Each
+// config hash is listed with the hosts reporting it, followed by the hosts
+// whose config deviates from the expected config.
+func PrintConfigDriftReport(cdr *control.ConfigDriftReport, out io.Writer, opts ...PrintConfigOption) error {
+	if cdr == nil {
+		return errors.Errorf("nil %T", cdr)
+	}
+
+	ew := txtfmt.NewErrWriter(out)
+
+	if len(cdr.Configs) > 0 {
+		hashTitle := "Config Hash"
+		hostsTitle := "Hosts"
+		statusTitle := "Status"
+
+		formatter := txtfmt.NewTableFormatter(hashTitle, hostsTitle, statusTitle)
+		var table []txtfmt.TableRow
+
+		for _, chh := range cdr.Configs {
+			status := "OK"
+			if chh.Drifted {
+				status = "Drifted"
+			}
+			table = append(table, txtfmt.TableRow{
+				hashTitle:   shortConfigHash(chh.Hash),
+				hostsTitle:  getPrintHosts(chh.Hosts.RangedString(), opts...),
+				statusTitle: status,
+			})
+		}
+
+		fmt.Fprintln(ew, formatter.Format(table))
+	}
+
+	switch {
+	case cdr.Reference:
+		fmt.Fprintf(ew, "Compared with reference config %s\n", shortConfigHash(cdr.Expected))
+	case len(cdr.Configs) > 1 && cdr.Expected == "":
+		fmt.Fprintln(ew, "No config is held by a majority of hosts")
+	}
+
+	if n := cdr.DriftedHosts.Count(); n > 0 {
+		fmt.Fprintf(ew, "Config drift detected on %s: %s\n",
+			english.Plural(n, "host", "hosts"),
+			getPrintHosts(cdr.DriftedHosts.RangedString(), opts...))
+	} else if len(cdr.Configs) > 0 {
+		fmt.Fprintln(ew, "No config drift detected")
+	}
+
+	if n := cdr.Unknown.Count(); n > 0 {
+		fmt.Fprintf(ew, "Config hash not yet reported by %s: %s\n",
+			english.Plural(n, "host", "hosts"),
+			getPrintHosts(cdr.Unknown.RangedString(), opts...))
+	}
+
+	return ew.Err
+}
+
 func printSystemResultTable(out io.Writer, results system.MemberResults, absentRanks *system.RankSet) error {
 	groups := make(system.RankGroups)
 	if err := groups.FromMemberResults(results, rowFieldSep); err != nil {
@@ -181,6 +416,411 @@ func PrintSystemStopResponse(out, outErr io.Writer, resp *control.SystemStopResp
 	return printSystemResults(out, outErr, resp.Results, &resp.AbsentHosts, &resp.AbsentRanks)
 }
 
+// PrintSystemExcludeResponse generates a human-readable representation of the
+// supplied SystemExcludeResp struct and writes it to the supplied io.Writer.
+func PrintSystemExcludeResponse(out, outErr io.Writer, resp *control.SystemExcludeResp) error {
+	return printSystemResults(out, outErr, resp.Results, &resp.AbsentHosts, &resp.AbsentRanks)
+}
+
+// PrintSystemSetFaultDomainResponse generates a human-readable representation
+// of the supplied SystemSetFaultDomainResp struct and writes it to the supplied
+// io.Writer.
+func PrintSystemSetFaultDomainResponse(out, outErr io.Writer, resp *control.SystemSetFaultDomainResp) error {
+	return printSystemResults(out, outErr, resp.Results, &resp.AbsentHosts, &resp.AbsentRanks)
+}
+
+// PrintSystemEraseResponse generates a human-readable per-host report of the
+// ranks erased in the supplied SystemEraseResp struct and writes it to the
+// supplied io.Writer.
+func PrintSystemEraseResponse(out io.Writer, resp *control.SystemEraseResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Results) == 0 {
+		fmt.Fprintln(out, "No ranks erased")
+		return nil
+	}
+
+	// host address to result to ranks
+	hostResults := make(map[string]map[string]*system.RankSet)
+	var addrs []string
+	for _, r := range resp.Results {
+		result := "erased"
+		if r.Errored {
+			result = r.Msg
+		}
+		if _, found := hostResults[r.Addr]; !found {
+			hostResults[r.Addr] = make(map[string]*system.RankSet)
+			addrs = append(addrs, r.Addr)
+		}
+		if _, found := hostResults[r.Addr][result]; !found {
+			hostResults[r.Addr][result] = &system.RankSet{}
+		}
+		hostResults[r.Addr][result].Add(r.Rank)
+	}
+
+	hostTitle := "Host"
+	ranksTitle := "Ranks"
+	resultTitle := "Result"
+	formatter := txtfmt.NewTableFormatter(hostTitle, ranksTitle, resultTitle)
+	var table []txtfmt.TableRow
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		results := make([]string, 0, len(hostResults[addr]))
+		for result := range hostResults[addr] {
+			results = append(results, result)
+		}
+		sort.Strings(results)
+
+		for _, result := range results {
+			table = append(table, txtfmt.TableRow{
+				hostTitle:   addr,
+				ranksTitle:  hostResults[addr][result].RangedString(),
+				resultTitle: result,
+			})
+		}
+	}
+	fmt.Fprintln(out, formatter.Format(table))
+
+	if resp.Results.Errors() != nil {
+		fmt.Fprintln(out, "Management service state retained as some ranks were not erased")
+		return nil
+	}
+	fmt.Fprintln(out, "Management service state erased, storage may now be reformatted")
+
+	return nil
+}
+
+// PrintSystemMaintenanceResponse generates a human-readable representation of
+// the maintenance windows in the supplied SystemMaintenanceResp struct and
+// writes it to the supplied io.Writer.
+func PrintSystemMaintenanceResponse(out, outErr io.Writer, resp *control.SystemMaintenanceResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	printAbsentHosts(outErr, &resp.AbsentHosts)
+
+	if len(resp.Windows) == 0 {
+		fmt.Fprintln(out, "No active maintenance windows")
+		return nil
+	}
+
+	idTitle := "Window"
+	hostsTitle := "Hosts"
+	ranksTitle := "Ranks"
+	startTitle := "Start"
+	endTitle := "End"
+	reasonTitle := "Reason"
+
+	formatter := txtfmt.NewTableFormatter(idTitle, hostsTitle, ranksTitle, startTitle,
+		endTitle, reasonTitle)
+	var table []txtfmt.TableRow
+
+	for _, mw := range resp.Windows {
+		table = append(table, txtfmt.TableRow{
+			idTitle:     mw.ID,
+			hostsTitle:  mw.Hosts,
+			ranksTitle:  system.RankSetFromRanks(mw.Ranks).RangedString(),
+			startTitle:  mw.Start.Format(time.RFC3339),
+			endTitle:    mw.End.Format(time.RFC3339),
+			reasonTitle: mw.Reason,
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintSystemLockResponse generates a human-readable representation of the
+// administrative locks in the supplied SystemLockResp struct and writes it to
+// the supplied io.Writer.
+func PrintSystemLockResponse(out io.Writer, resp *control.SystemLockResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Locks) == 0 {
+		fmt.Fprintln(out, "No locks held")
+		return nil
+	}
+
+	nameTitle := "Lock"
+	ownerTitle := "Owner"
+	acquiredTitle := "Acquired"
+	expiresTitle := "Expires"
+	reasonTitle := "Reason"
+
+	formatter := txtfmt.NewTableFormatter(nameTitle, ownerTitle, acquiredTitle,
+		expiresTitle, reasonTitle)
+	var table []txtfmt.TableRow
+
+	for _, al := range resp.Locks {
+		table = append(table, txtfmt.TableRow{
+			nameTitle:     al.Name,
+			ownerTitle:    al.Owner,
+			acquiredTitle: al.Acquired.Format(time.RFC3339),
+			expiresTitle:  al.Expires.Format(time.RFC3339),
+			reasonTitle:   al.Reason,
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintSystemHistoryResponse generates a human-readable representation of the
+// operation records in the supplied SystemHistoryResp struct and writes it to
+// the supplied io.Writer. Client addresses and operation parameters are only
+// included if verbose is set.
+func PrintSystemHistoryResponse(out io.Writer, resp *control.SystemHistoryResp, verbose bool) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Operations) == 0 {
+		fmt.Fprintln(out, "No operations recorded")
+		return nil
+	}
+
+	idTitle := "ID"
+	timeTitle := "Time"
+	opTitle := "Operation"
+	initiatorTitle := "Initiator"
+	addrTitle := "Address"
+	hostTitle := "Host"
+	resultTitle := "Result"
+	paramsTitle := "Parameters"
+
+	titles := []string{idTitle, timeTitle, opTitle, initiatorTitle}
+	if verbose {
+		titles = append(titles, addrTitle)
+	}
+	titles = append(titles, hostTitle, resultTitle)
+	if verbose {
+		titles = append(titles, paramsTitle)
+	}
+
+	formatter := txtfmt.NewTableFormatter(titles...)
+	var table []txtfmt.TableRow
+
+	for _, rec := range resp.Operations {
+		row := txtfmt.TableRow{
+			idTitle:        fmt.Sprintf("%d", rec.ID),
+			timeTitle:      rec.Time.Format(time.RFC3339),
+			opTitle:        rec.Operation,
+			initiatorTitle: rec.Initiator,
+			hostTitle:      rec.Host,
+			resultTitle:    rec.Result,
+		}
+		if verbose {
+			row[addrTitle] = rec.Address
+			row[paramsTitle] = rec.Parameters
+		}
+		table = append(table, row)
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintSystemPoolDefaultsResponse generates a human-readable representation
+// of the pool property defaults in the supplied SystemPoolDefaultsResp struct
+// and writes it to the supplied io.Writer.
+func PrintSystemPoolDefaultsResponse(out io.Writer, resp *control.SystemPoolDefaultsResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if len(resp.Defaults) == 0 {
+		fmt.Fprintln(out, "No pool property defaults set")
+		return nil
+	}
+
+	nameTitle := "Property"
+	valueTitle := "Default"
+	enforcedTitle := "Enforced"
+
+	formatter := txtfmt.NewTableFormatter(nameTitle, valueTitle, enforcedTitle)
+	var table []txtfmt.TableRow
+
+	for _, pd := range resp.Defaults {
+		table = append(table, txtfmt.TableRow{
+			nameTitle:     pd.Name,
+			valueTitle:    pd.Value,
+			enforcedTitle: strconv.FormatBool(pd.Enforced),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintSystemActivateSpareResponse generates a human-readable representation
+// of the spare engine activation in the supplied SystemActivateSpareResp
+// struct and writes it to the supplied io.Writer.
+func PrintSystemActivateSpareResponse(out io.Writer, rank system.Rank, resp *control.SystemActivateSpareResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	fmt.Fprintf(out, "Rank %d on %s replaced by spare engine %d (replacing engine %d), state: %s\n",
+		rank, resp.Host, resp.SpareIdx, resp.FailedIdx, resp.State)
+	if system.Rank(resp.SpareRank) != system.NilRank {
+		fmt.Fprintf(out, "Spare engine joined the system as rank %d\n", resp.SpareRank)
+	}
+	fmt.Fprintf(out, "Rank %d excluded from the system and its pools for rebuild\n", rank)
+	if len(resp.ReleasedDevices) > 0 {
+		fmt.Fprintf(out, "Devices released from engine %d: %s\n", resp.FailedIdx,
+			strings.Join(resp.ReleasedDevices, ", "))
+	}
+
+	return nil
+}
+
+// PrintMSHealthQueryResponse generates a human-readable representation of the
+// supplied MSHealthQueryResp struct and writes it to the supplied io.Writer.
+func PrintMSHealthQueryResponse(out io.Writer, resp *control.MSHealthQueryResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	leader := resp.Leader
+	if leader == "" {
+		leader = "unknown"
+	}
+	fmt.Fprintf(out, "MS Leader: %s\n", leader)
+
+	if len(resp.Replicas) == 0 {
+		return nil
+	}
+
+	replicaTitle := "Replica"
+	stateTitle := "State"
+	termTitle := "Term"
+	appliedTitle := "Applied Index"
+	lagTitle := "Lag"
+	snapTitle := "Last Snapshot"
+	sizeTitle := "DB Size"
+
+	formatter := txtfmt.NewTableFormatter(replicaTitle, stateTitle, termTitle,
+		appliedTitle, lagTitle, snapTitle, sizeTitle)
+	var table []txtfmt.TableRow
+
+	for _, rs := range resp.Replicas {
+		snapTime := rs.LastSnapshotTime
+		if snapTime == "" {
+			snapTime = "None"
+		}
+		table = append(table, txtfmt.TableRow{
+			replicaTitle: rs.Addr,
+			stateTitle:   rs.State,
+			termTitle:    fmt.Sprintf("%d", rs.Term),
+			appliedTitle: fmt.Sprintf("%d", rs.AppliedIndex),
+			lagTitle:     fmt.Sprintf("%d", rs.Lag),
+			snapTitle:    snapTime,
+			sizeTitle:    humanize.IBytes(rs.DBSize),
+		})
+	}
+
+	fmt.Fprintln(out, formatter.Format(table))
+
+	return nil
+}
+
+// PrintSystemWaitResponse generates a human-readable representation of the
+// supplied SystemWaitResp struct and writes it to the supplied io.Writer.
+func PrintSystemWaitResponse(out io.Writer, resp *control.SystemWaitResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	result := "met"
+	if !resp.Met {
+		result = "not met"
+	}
+	fmt.Fprintf(out, "Condition %s %s after %.1fs (%s)\n", resp.Condition, result,
+		resp.ElapsedSecs, english.Plural(resp.Checks, "check", "checks"))
+	if resp.Met {
+		return nil
+	}
+
+	iw := txtfmt.NewIndentWriter(out)
+	if resp.PendingRanks != "" {
+		fmt.Fprintf(iw, "Pending ranks: %s\n", resp.PendingRanks)
+	}
+	if len(resp.PendingPools) > 0 {
+		fmt.Fprintf(iw, "Pending pools: %s\n", strings.Join(resp.PendingPools, ", "))
+	}
+	if resp.LastError != "" {
+		fmt.Fprintf(iw, "Last error: %s\n", resp.LastError)
+	}
+
+	return nil
+}
+
+// PrintSystemExtendResponse generates a human-readable representation of the
+// supplied SystemExtendResp struct and writes it to the supplied io.Writer.
+func PrintSystemExtendResponse(out io.Writer, resp *control.SystemExtendResp) error {
+	if resp == nil {
+		return errors.Errorf("nil %T", resp)
+	}
+
+	if !resp.Joined {
+		fmt.Fprintf(out, "New hosts %s have not joined the system\n", resp.NewHosts)
+		iw := txtfmt.NewIndentWriter(out)
+		if resp.PendingRanks != "" {
+			fmt.Fprintf(iw, "Pending ranks: %s\n", resp.PendingRanks)
+		}
+		if resp.LastError != "" {
+			fmt.Fprintf(iw, "Last error: %s\n", resp.LastError)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "New hosts %s joined the system as ranks %s\n", resp.NewHosts, resp.NewRanks)
+	if len(resp.Pools) == 0 {
+		return nil
+	}
+
+	poolTitle := "Pool"
+	resultTitle := "Result"
+	formatter := txtfmt.NewTableFormatter(poolTitle, resultTitle)
+	var table []txtfmt.TableRow
+	for _, pr := range resp.Pools {
+		result := "extended"
+		if pr.Error != "" {
+			result = pr.Error
+		}
+		table = append(table, txtfmt.TableRow{
+			poolTitle:   pr.ID,
+			resultTitle: result,
+		})
+	}
+	fmt.Fprintln(out)
+	fmt.Fprint(out, formatter.Format(table))
+
+	return nil
+}
+
+// poolStatus describes a pool that is disabled pending destroy or protected
+// from being destroyed, returning an empty string for other pools.
+func poolStatus(pool *common.PoolDiscovery) string {
+	switch {
+	case pool.DestroyAfter != "":
+		return fmt.Sprintf("disabled, destroy after %s", pool.DestroyAfter)
+	case pool.Protected:
+		return "protected"
+	default:
+		return ""
+	}
+}
+
 // PrintListPoolsResponse generates a human-readable representation of the
 // supplied ListPoolsResp struct and writes it to the supplied io.Writer.
 func PrintListPoolsResponse(out io.Writer, resp *control.ListPoolsResp) error {
@@ -191,8 +831,17 @@ func PrintListPoolsResponse(out io.Writer, resp *control.ListPoolsResp) error {
 
 	uuidTitle := "Pool UUID"
 	svcRepTitle := "Svc Replicas"
+	statusTitle := "Status"
+
+	titles := []string{uuidTitle, svcRepTitle}
+	for _, pool := range resp.Pools {
+		if poolStatus(pool) != "" {
+			titles = append(titles, statusTitle)
+			break
+		}
+	}
 
-	formatter := txtfmt.NewTableFormatter(uuidTitle, svcRepTitle)
+	formatter := txtfmt.NewTableFormatter(titles...)
 	var table []txtfmt.TableRow
 
 	for _, pool := range resp.Pools {
@@ -201,6 +850,7 @@ func PrintListPoolsResponse(out io.Writer, resp *control.ListPoolsResp) error {
 		if len(pool.SvcReplicas) != 0 {
 			row[svcRepTitle] = formatRanks(pool.SvcReplicas)
 		}
+		row[statusTitle] = poolStatus(pool)
 
 		table = append(table, row)
 	}
@@ -208,3 +858,30 @@ func PrintListPoolsResponse(out io.Writer, resp *control.ListPoolsResp) error {
 
 	return nil
 }
+
+// PrintListPoolsCSV generates a comma-separated values representation of the
+// supplied ListPoolsResp struct with one record per pool and writes it to the
+// supplied io.Writer.
+func PrintListPoolsCSV(out io.Writer, resp *control.ListPoolsResp) error {
+	uuidTitle := "Pool UUID"
+	svcRepTitle := "Svc Replicas"
+
+	formatter := txtfmt.NewCSVFormatter(uuidTitle, svcRepTitle)
+	table := []txtfmt.TableRow{}
+
+	for _, pool := range resp.Pools {
+		row := txtfmt.TableRow{uuidTitle: pool.UUID}
+		if len(pool.SvcReplicas) != 0 {
+			row[svcRepTitle] = formatRanks(pool.SvcReplicas)
+		}
+		table = append(table, row)
+	}
+
+	csv, err := formatter.Format(table)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, csv)
+
+	return err
+}
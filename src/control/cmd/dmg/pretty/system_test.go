@@ -10,6 +10,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -43,9 +44,33 @@ func TestPretty_tabulateRankGroups(t *testing.T) {
 	for name, tc := range map[string]struct {
 		groups      RankGroups
 		cTitles     []string
+		style       OutputStyle
 		expPrintStr string
 		expErrMsg   string
 	}{
+		"colored results": {
+			groups:  mockRankGroups(t),
+			cTitles: mockColumnTitles,
+			style:   OutputStyleColor,
+			expPrintStr: "" +
+				"Ranks       Action Result \n" +
+				"-----       ------ ------ \n" +
+				"[10,20-299] bar    \x1b[1;31mBAD\x1b[0m    \n" +
+				"[0-9,11-19] foo    \x1b[32mOK\x1b[0m     \n" +
+				"\n",
+		},
+		"plain results": {
+			groups:  mockRankGroups(t),
+			cTitles: mockColumnTitles,
+			style:   OutputStylePlain,
+			expPrintStr: `
+Ranks       Action Result 
+-----       ------ ------ 
+[10,20-299] bar    ✗ BAD  
+[0-9,11-19] foo    ✓ OK   
+
+`,
+		},
 		"formatted results": {
 			groups:  mockRankGroups(t),
 			cTitles: mockColumnTitles,
@@ -69,6 +94,9 @@ Ranks       Action Result
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
+			SetOutputStyle(tc.style)
+			defer SetOutputStyle(OutputStyleNone)
+
 			var bld strings.Builder
 
 			gotErr := tabulateRankGroups(&bld, tc.groups, tc.cTitles...)
@@ -82,6 +110,28 @@ Ranks       Action Result
 }
 
 func TestPretty_PrintSystemQueryResp(t *testing.T) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	startedMember := func(rank Rank, fabricUp, joined, poolsRestored time.Duration) *Member {
+		m := MockMember(t, uint32(rank), MemberStateJoined)
+		m.Startup = &StartupTimeline{
+			StorageReady:  started,
+			FabricUp:      started.Add(fabricUp),
+			Joined:        started.Add(joined),
+			PoolsRestored: started.Add(poolsRestored),
+		}
+		return m
+	}
+	skewedMember := func(rank Rank, offset time.Duration, exceeded bool) *Member {
+		m := MockMember(t, uint32(rank), MemberStateJoined)
+		m.ClockSkew = &ClockSkew{
+			Offset:    offset,
+			RoundTrip: 2 * time.Millisecond,
+			Checked:   started,
+			Exceeded:  exceeded,
+		}
+		return m
+	}
+
 	for name, tc := range map[string]struct {
 		resp        *control.SystemQueryResp
 		absentHosts string
@@ -116,6 +166,46 @@ Rank State
 ---- -----  
 0    Joined 
 
+`,
+		},
+		"response with skewed host": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					skewedMember(0, 10*time.Millisecond, false),
+					skewedMember(1, -3*time.Second, true),
+				},
+			},
+			expPrintStr: `
+Rank  State  
+----  -----  
+[0-1] Joined 
+
+Clock skew exceeds threshold on 1 host: 127.0.0.1:10001
+`,
+		},
+		"response verbose with clock skews": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					skewedMember(0, 10*time.Millisecond, false),
+					skewedMember(1, -3*time.Second, true),
+					MockMember(t, 2, MemberStateStopped),
+				},
+			},
+			verbose: true,
+			expPrintStr: `
+Rank UUID                                 Control Address Fault Domain State   Reason 
+---- ----                                 --------------- ------------ -----   ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined         
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined         
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped        
+
+Clock Skew:
+Control Address Offset Round Trip Checked              Status 
+--------------- ------ ---------- -------              ------ 
+127.0.0.0:10001 10ms   2ms        2021-06-01T12:00:00Z OK     
+127.0.0.1:10001 -3s    2ms        2021-06-01T12:00:00Z Skewed 
+
+Clock skew exceeds threshold on 1 host: 127.0.0.1:10001
 `,
 		},
 		"single response with missing hosts and ranks": {
@@ -283,6 +373,30 @@ Rank UUID                                 Control Address Fault Domain State   R
 5    00000005-0005-0005-0005-000000000005 127.0.0.5:10001 /            Joined         
 6    00000006-0006-0006-0006-000000000006 127.0.0.6:10001 /            Joined         
 
+`,
+		},
+		"response verbose with startup timelines": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					startedMember(0, 2*time.Second, 2500*time.Millisecond, 4*time.Second),
+					startedMember(1, 31*time.Second, 32*time.Second, time.Minute),
+					MockMember(t, 2, MemberStateStopped),
+				},
+			},
+			verbose: true,
+			expPrintStr: `
+Rank UUID                                 Control Address Fault Domain State   Reason 
+---- ----                                 --------------- ------------ -----   ------ 
+0    00000000-0000-0000-0000-000000000000 127.0.0.0:10001 /            Joined         
+1    00000001-0001-0001-0001-000000000001 127.0.0.1:10001 /            Joined         
+2    00000002-0002-0002-0002-000000000002 127.0.0.2:10001 /            Stopped        
+
+Startup Timeline:
+Rank Storage Ready        Fabric Up Joined Pools Restored 
+---- -------------        --------- ------ -------------- 
+0    2021-06-01T12:00:00Z +2s       +2.5s  +4s            
+1    2021-06-01T12:00:00Z +31s      +32s   +1m0s          
+
 `,
 		},
 		"response verbose with missing hosts and ranks": {
@@ -522,3 +636,757 @@ Unknown 3 hosts: foo[7-9]
 		})
 	}
 }
+
+func TestPretty_PrintSystemQueryCSV(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemQueryResp
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemQueryResp"),
+		},
+		"empty response": {
+			resp: &control.SystemQueryResp{},
+			expPrintStr: `
+Rank,UUID,Control Address,Fault Domain,State,Reason
+`,
+		},
+		"multiple members": {
+			resp: &control.SystemQueryResp{
+				Members: Members{
+					MockMember(t, 0, MemberStateJoined),
+					MockMember(t, 1, MemberStateErrored, "exited, bad things"),
+				},
+			},
+			expPrintStr: `
+Rank,UUID,Control Address,Fault Domain,State,Reason
+0,00000000-0000-0000-0000-000000000000,127.0.0.0:10001,/,Joined,
+1,00000001-0001-0001-0001-000000000001,127.0.0.1:10001,/,Errored,"exited, bad things"
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			gotErr := PrintSystemQueryCSV(&bld, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintConfigDriftReport(t *testing.T) {
+	hashA := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	hashB := "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	for name, tc := range map[string]struct {
+		report      *control.ConfigDriftReport
+		expPrintStr string
+	}{
+		"no hashes reported": {
+			report: &control.ConfigDriftReport{
+				DriftedHosts: hostlist.MustCreateSet(""),
+				Unknown:      hostlist.MustCreateSet("host[1-2]"),
+			},
+			expPrintStr: `
+Config hash not yet reported by 2 hosts: host[1-2]
+`,
+		},
+		"no drift": {
+			report: &control.ConfigDriftReport{
+				Expected: hashA,
+				Configs: []*control.ConfigHashHosts{
+					{Hash: hashA, Hosts: hostlist.MustCreateSet("host[1-3]")},
+				},
+				DriftedHosts: hostlist.MustCreateSet(""),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+			expPrintStr: `
+Config Hash  Hosts     Status 
+-----------  -----     ------ 
+aaaaaaaaaaaa host[1-3] OK     
+
+No config drift detected
+`,
+		},
+		"drift from majority": {
+			report: &control.ConfigDriftReport{
+				Expected: hashA,
+				Configs: []*control.ConfigHashHosts{
+					{Hash: hashA, Hosts: hostlist.MustCreateSet("host[1-3]")},
+					{Hash: hashB, Hosts: hostlist.MustCreateSet("host4"), Drifted: true},
+				},
+				DriftedHosts: hostlist.MustCreateSet("host4"),
+				Unknown:      hostlist.MustCreateSet("host5"),
+			},
+			expPrintStr: `
+Config Hash  Hosts     Status  
+-----------  -----     ------  
+aaaaaaaaaaaa host[1-3] OK      
+bbbbbbbbbbbb host4     Drifted 
+
+Config drift detected on 1 host: host4
+Config hash not yet reported by 1 host: host5
+`,
+		},
+		"no majority": {
+			report: &control.ConfigDriftReport{
+				Configs: []*control.ConfigHashHosts{
+					{Hash: hashA, Hosts: hostlist.MustCreateSet("host1"), Drifted: true},
+					{Hash: hashB, Hosts: hostlist.MustCreateSet("host2"), Drifted: true},
+				},
+				DriftedHosts: hostlist.MustCreateSet("host[1-2]"),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+			expPrintStr: `
+Config Hash  Hosts Status  
+-----------  ----- ------  
+aaaaaaaaaaaa host1 Drifted 
+bbbbbbbbbbbb host2 Drifted 
+
+No config is held by a majority of hosts
+Config drift detected on 2 hosts: host[1-2]
+`,
+		},
+		"drift from reference": {
+			report: &control.ConfigDriftReport{
+				Expected:  hashB,
+				Reference: true,
+				Configs: []*control.ConfigHashHosts{
+					{Hash: hashA, Hosts: hostlist.MustCreateSet("host[1-2]"), Drifted: true},
+				},
+				DriftedHosts: hostlist.MustCreateSet("host[1-2]"),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+			expPrintStr: `
+Config Hash  Hosts     Status  
+-----------  -----     ------  
+aaaaaaaaaaaa host[1-2] Drifted 
+
+Compared with reference config bbbbbbbbbbbb
+Config drift detected on 2 hosts: host[1-2]
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintConfigDriftReport(tc.report, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintListPoolsCSV(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.ListPoolsResp
+		expPrintStr string
+	}{
+		"no pools": {
+			resp: &control.ListPoolsResp{},
+			expPrintStr: `
+Pool UUID,Svc Replicas
+`,
+		},
+		"two pools": {
+			resp: &control.ListPoolsResp{
+				Pools: []*common.PoolDiscovery{
+					{UUID: common.MockUUID(1), SvcReplicas: []uint32{0, 1, 2}},
+					{UUID: common.MockUUID(2)},
+				},
+			},
+			expPrintStr: `
+Pool UUID,Svc Replicas
+00000001-0001-0001-0001-000000000001,[0-2]
+00000002-0002-0002-0002-000000000002,
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintListPoolsCSV(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemMaintenanceResp(t *testing.T) {
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	absentHosts := new(control.SystemMaintenanceResp)
+	absentHosts.AbsentHosts.ReplaceSet(hostlist.MustCreateSet("foo-[1-2]"))
+
+	for name, tc := range map[string]struct {
+		resp           *control.SystemMaintenanceResp
+		expPrintStr    string
+		expErrPrintStr string
+		expErr         error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemMaintenanceResp"),
+		},
+		"no windows": {
+			resp: &control.SystemMaintenanceResp{},
+			expPrintStr: `
+No active maintenance windows
+`,
+		},
+		"absent hosts": {
+			resp: absentHosts,
+			expPrintStr: `
+No active maintenance windows
+`,
+			expErrPrintStr: `
+Unknown 2 hosts: foo-[1-2]
+`,
+		},
+		"windows": {
+			resp: &control.SystemMaintenanceResp{
+				Windows: []*control.MaintenanceWindow{
+					{
+						ID:     common.MockUUID(1),
+						Hosts:  "foo-1",
+						Ranks:  []Rank{0, 1},
+						Reason: "firmware update",
+						Start:  start,
+						End:    start.Add(time.Hour),
+					},
+					{
+						ID:    common.MockUUID(2),
+						Hosts: "foo-[2-3]",
+						Ranks: []Rank{2, 3, 5},
+						Start: start,
+						End:   start.Add(30 * time.Minute),
+					},
+				},
+			},
+			expPrintStr: `
+Window                               Hosts     Ranks   Start                End                  Reason          
+------                               -----     -----   -----                ---                  ------          
+00000001-0001-0001-0001-000000000001 foo-1     [0-1]   2021-06-01T12:00:00Z 2021-06-01T13:00:00Z firmware update 
+00000002-0002-0002-0002-000000000002 foo-[2-3] [2-3,5] 2021-06-01T12:00:00Z 2021-06-01T12:30:00Z                 
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out, outErr strings.Builder
+			gotErr := PrintSystemMaintenanceResponse(&out, &outErr, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(strings.TrimLeft(tc.expErrPrintStr, "\n"), outErr.String()); diff != "" {
+				t.Fatalf("unexpected stderr format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemLockResp(t *testing.T) {
+	acquired := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemLockResp
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemLockResp"),
+		},
+		"no locks": {
+			resp: &control.SystemLockResp{},
+			expPrintStr: `
+No locks held
+`,
+		},
+		"locks": {
+			resp: &control.SystemLockResp{
+				Locks: []*control.AdminLock{
+					{
+						Name:     "storage-format:foo-1:10001",
+						Owner:    "root@foo-0[1234]",
+						Reason:   "storage format",
+						Acquired: acquired,
+						Expires:  acquired.Add(30 * time.Minute),
+					},
+					{
+						Name:     "upgrade",
+						Owner:    "script1",
+						Acquired: acquired,
+						Expires:  acquired.Add(time.Hour),
+					},
+				},
+			},
+			expPrintStr: `
+Lock                       Owner            Acquired             Expires              Reason         
+----                       -----            --------             -------              ------         
+storage-format:foo-1:10001 root@foo-0[1234] 2021-06-01T12:00:00Z 2021-06-01T12:30:00Z storage format 
+upgrade                    script1          2021-06-01T12:00:00Z 2021-06-01T13:00:00Z                
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := PrintSystemLockResponse(&out, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemPoolDefaultsResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemPoolDefaultsResp
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemPoolDefaultsResp"),
+		},
+		"no defaults": {
+			resp: &control.SystemPoolDefaultsResp{},
+			expPrintStr: `
+No pool property defaults set
+`,
+		},
+		"defaults": {
+			resp: &control.SystemPoolDefaultsResp{
+				Defaults: []*control.PoolPropDefault{
+					{Name: "reclaim", Value: "lazy"},
+					{Name: "self_heal", Value: "exclude", Enforced: true},
+				},
+			},
+			expPrintStr: `
+Property  Default Enforced 
+--------  ------- -------- 
+reclaim   lazy    false    
+self_heal exclude true     
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := PrintSystemPoolDefaultsResponse(&out, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemActivateSpareResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemActivateSpareResp
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemActivateSpareResp"),
+		},
+		"no devices released": {
+			resp: &control.SystemActivateSpareResp{
+				Host:      "host1:10001",
+				SpareIdx:  1,
+				State:     "Ready",
+				SpareRank: 5,
+			},
+			expPrintStr: `
+Rank 2 on host1:10001 replaced by spare engine 1 (replacing engine 0), state: Ready
+Spare engine joined the system as rank 5
+Rank 2 excluded from the system and its pools for rebuild
+`,
+		},
+		"devices released": {
+			resp: &control.SystemActivateSpareResp{
+				Host:            "host1:10001",
+				SpareIdx:        1,
+				ReleasedDevices: []string{"0000:81:00.0", "0000:82:00.0"},
+				State:           "Ready",
+				SpareRank:       5,
+			},
+			expPrintStr: `
+Rank 2 on host1:10001 replaced by spare engine 1 (replacing engine 0), state: Ready
+Spare engine joined the system as rank 5
+Rank 2 excluded from the system and its pools for rebuild
+Devices released from engine 0: 0000:81:00.0, 0000:82:00.0
+`,
+		},
+		"spare not joined": {
+			resp: &control.SystemActivateSpareResp{
+				Host:      "host1:10001",
+				SpareIdx:  1,
+				State:     "Stopped",
+				SpareRank: uint32(NilRank),
+			},
+			expPrintStr: `
+Rank 2 on host1:10001 replaced by spare engine 1 (replacing engine 0), state: Stopped
+Rank 2 excluded from the system and its pools for rebuild
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := PrintSystemActivateSpareResponse(&out, 2, tc.resp)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemHistoryResp(t *testing.T) {
+	opTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	resp := &control.SystemHistoryResp{
+		Operations: []*control.OperationRecord{
+			{
+				ID:         1,
+				Time:       opTime,
+				Operation:  "storage-format",
+				Initiator:  "admin",
+				Address:    "10.0.0.9:41234",
+				Host:       "foo-1",
+				Parameters: "reformat:true",
+				Result:     "ok on 3 devices",
+			},
+			{
+				ID:         2,
+				Time:       opTime.Add(time.Hour),
+				Operation:  "pool-create",
+				Initiator:  "admin",
+				Address:    "10.0.0.9:41240",
+				Host:       "foo-0",
+				Parameters: `label:"tank"`,
+				Result:     "failed: DER_NOSPACE",
+				Failed:     true,
+			},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemHistoryResp
+		verbose     bool
+		expPrintStr string
+		expErr      error
+	}{
+		"nil response": {
+			expErr: errors.New("nil *control.SystemHistoryResp"),
+		},
+		"no operations": {
+			resp: &control.SystemHistoryResp{},
+			expPrintStr: `
+No operations recorded
+`,
+		},
+		"operations": {
+			resp: resp,
+			expPrintStr: `
+ID Time                 Operation      Initiator Host  Result              
+-- ----                 ---------      --------- ----  ------              
+1  2021-06-01T12:00:00Z storage-format admin     foo-1 ok on 3 devices     
+2  2021-06-01T13:00:00Z pool-create    admin     foo-0 failed: DER_NOSPACE 
+
+`,
+		},
+		"operations verbose": {
+			resp:    resp,
+			verbose: true,
+			expPrintStr: `
+ID Time                 Operation      Initiator Address        Host  Result              Parameters    
+-- ----                 ---------      --------- -------        ----  ------              ----------    
+1  2021-06-01T12:00:00Z storage-format admin     10.0.0.9:41234 foo-1 ok on 3 devices     reformat:true 
+2  2021-06-01T13:00:00Z pool-create    admin     10.0.0.9:41240 foo-0 failed: DER_NOSPACE label:"tank"  
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out strings.Builder
+			gotErr := PrintSystemHistoryResponse(&out, tc.resp, tc.verbose)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), out.String()); diff != "" {
+				t.Fatalf("unexpected stdout format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemWaitResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemWaitResp
+		expPrintStr string
+	}{
+		"condition met": {
+			resp: &control.SystemWaitResp{
+				Condition:   control.WaitRanksJoined,
+				Met:         true,
+				Checks:      3,
+				ElapsedSecs: 4.25,
+			},
+			expPrintStr: `
+Condition joined met after 4.2s (3 checks)
+`,
+		},
+		"pending ranks": {
+			resp: &control.SystemWaitResp{
+				Condition:    control.WaitRanksStopped,
+				Checks:       1,
+				ElapsedSecs:  60,
+				PendingRanks: "[1-3]",
+				LastError:    "non-existent hosts foo",
+			},
+			expPrintStr: `
+Condition stopped not met after 60.0s (1 check)
+  Pending ranks: [1-3]
+  Last error: non-existent hosts foo
+`,
+		},
+		"pending pools": {
+			resp: &control.SystemWaitResp{
+				Condition:    control.WaitRebuildIdle,
+				Checks:       10,
+				ElapsedSecs:  20.5,
+				PendingPools: []string{common.MockUUID(1), common.MockUUID(2)},
+			},
+			expPrintStr: `
+Condition rebuild-idle not met after 20.5s (10 checks)
+  Pending pools: 00000001-0001-0001-0001-000000000001, 00000002-0002-0002-0002-000000000002
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemWaitResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemExtendResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.SystemExtendResp
+		expPrintStr string
+	}{
+		"not joined": {
+			resp: &control.SystemExtendResp{
+				NewHosts:     "node[5-6]",
+				PendingRanks: "[4-5]",
+				LastError:    "non-existent hosts node6",
+			},
+			expPrintStr: `
+New hosts node[5-6] have not joined the system
+  Pending ranks: [4-5]
+  Last error: non-existent hosts node6
+`,
+		},
+		"joined without pools": {
+			resp: &control.SystemExtendResp{
+				NewHosts: "node[5-6]",
+				Joined:   true,
+				NewRanks: "[4-5]",
+			},
+			expPrintStr: `
+New hosts node[5-6] joined the system as ranks [4-5]
+`,
+		},
+		"joined with pools": {
+			resp: &control.SystemExtendResp{
+				NewHosts: "node[5-6]",
+				Joined:   true,
+				NewRanks: "[4-5]",
+				Pools: []*control.SystemExtendPoolResult{
+					{ID: "tank", UUID: common.MockUUID(1)},
+					{ID: "pond", UUID: common.MockUUID(2), Error: "pool extend failed"},
+				},
+			},
+			expPrintStr: `
+New hosts node[5-6] joined the system as ranks [4-5]
+
+Pool Result             
+---- ------             
+tank extended           
+pond pool extend failed 
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemExtendResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintSystemEraseResp(t *testing.T) {
+	mockResult := func(rank uint32, addr string, err error) *MemberResult {
+		mr := NewMemberResult(Rank(rank), err, MemberStateAwaitFormat, "reset format")
+		mr.Addr = addr
+		return mr
+	}
+
+	for name, tc := range map[string]struct {
+		resp        *control.SystemEraseResp
+		expPrintStr string
+	}{
+		"no results": {
+			resp: &control.SystemEraseResp{},
+			expPrintStr: `
+No ranks erased
+`,
+		},
+		"all erased": {
+			resp: &control.SystemEraseResp{
+				Results: MemberResults{
+					mockResult(2, "10.0.0.2:10001", nil),
+					mockResult(0, "10.0.0.1:10001", nil),
+					mockResult(1, "10.0.0.1:10001", nil),
+					mockResult(3, "10.0.0.2:10001", nil),
+				},
+			},
+			expPrintStr: `
+Host           Ranks Result 
+----           ----- ------ 
+10.0.0.1:10001 [0-1] erased 
+10.0.0.2:10001 [2-3] erased 
+
+Management service state erased, storage may now be reformatted
+`,
+		},
+		"some failed": {
+			resp: &control.SystemEraseResp{
+				Results: MemberResults{
+					mockResult(0, "10.0.0.1:10001", nil),
+					mockResult(1, "10.0.0.1:10001", errors.New("trim NVMe: format failed")),
+					mockResult(2, "10.0.0.2:10001", nil),
+					mockResult(3, "10.0.0.2:10001", nil),
+				},
+			},
+			expPrintStr: `
+Host           Ranks Result                   
+----           ----- ------                   
+10.0.0.1:10001 0     erased                   
+10.0.0.1:10001 1     trim NVMe: format failed 
+10.0.0.2:10001 [2-3] erased                   
+
+Management service state retained as some ranks were not erased
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintSystemEraseResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintMSHealthQueryResp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resp        *control.MSHealthQueryResp
+		expPrintStr string
+	}{
+		"no leader": {
+			resp: &control.MSHealthQueryResp{},
+			expPrintStr: `
+MS Leader: unknown
+`,
+		},
+		"replicas": {
+			resp: &control.MSHealthQueryResp{
+				Leader: "10.0.0.1:10001",
+				Replicas: []*control.MSReplicaStatus{
+					{
+						Addr:             "10.0.0.1:10001",
+						State:            "Leader",
+						Term:             4,
+						AppliedIndex:     100,
+						LastSnapshotTime: "2021-06-01T12:00:00Z",
+						DBSize:           65536,
+					},
+					{
+						Addr:         "10.0.0.2:10001",
+						State:        "Follower",
+						Term:         4,
+						AppliedIndex: 90,
+						DBSize:       32768,
+						Lag:          10,
+					},
+				},
+			},
+			expPrintStr: `
+MS Leader: 10.0.0.1:10001
+Replica        State    Term Applied Index Lag Last Snapshot        DB Size 
+-------        -----    ---- ------------- --- -------------        ------- 
+10.0.0.1:10001 Leader   4    100           0   2021-06-01T12:00:00Z 64 KiB  
+10.0.0.2:10001 Follower 4    90            10  None                 32 KiB  
+
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintMSHealthQueryResponse(&bld, tc.resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/txtfmt"
+)
+
+// PrintHostVersionMap generates a human-readable representation of the supplied
+// HostVersionMap and writes it to the supplied io.Writer. Hosts reporting
+// identical component versions are grouped together and any components whose
+// versions differ between hosts are highlighted.
+func PrintHostVersionMap(hvm control.HostVersionMap, out io.Writer, opts ...PrintConfigOption) error {
+	if len(hvm) == 0 {
+		return nil
+	}
+
+	ew := txtfmt.NewErrWriter(out)
+
+	componentTitle := "Component"
+	versionTitle := "Version"
+	revisionTitle := "Revision"
+
+	for _, key := range hvm.Keys() {
+		hvs := hvm[key]
+		hosts := getPrintHosts(hvs.HostSet.RangedString(), opts...)
+		lineBreak := strings.Repeat("-", len(hosts))
+		fmt.Fprintf(ew, "%s\n%s\n%s\n", lineBreak, hosts, lineBreak)
+		fmt.Fprintln(ew)
+
+		formatter := txtfmt.NewTableFormatter(componentTitle, versionTitle, revisionTitle)
+		var table []txtfmt.TableRow
+
+		for _, cv := range hvs.HostVersions.Components {
+			row := txtfmt.TableRow{
+				componentTitle: cv.Name,
+				versionTitle:   cv.Version,
+				revisionTitle:  cv.Revision,
+			}
+			if cv.Error != "" {
+				row[versionTitle] = fmt.Sprintf("unknown (%s)", cv.Error)
+			}
+			if row[revisionTitle] == "" {
+				row[revisionTitle] = "N/A"
+			}
+			table = append(table, row)
+		}
+
+		iw := txtfmt.NewIndentWriter(ew, txtfmt.WithPadCount(4))
+		fmt.Fprint(iw, formatter.Format(table))
+		fmt.Fprintln(ew)
+	}
+
+	if skewed := hvm.Skewed(); len(skewed) > 0 {
+		fmt.Fprintf(ew, "Version mismatch between hosts detected for: %s\n",
+			strings.Join(skewed, ", "))
+	}
+
+	return ew.Err
+}
+
+// PrintVersionSkewReport generates a human-readable representation of the
+// supplied VersionSkewReport and writes it to the supplied io.Writer. Each
+// version of a component is listed with the hosts running it, followed by the
+// components whose versions differ and whether the DAOS releases found are
+// outside of the supported window.
+func PrintVersionSkewReport(vsr *control.VersionSkewReport, out io.Writer, opts ...PrintConfigOption) error {
+	if vsr == nil {
+		return errors.Errorf("nil %T", vsr)
+	}
+	if len(vsr.Components) == 0 {
+		return nil
+	}
+
+	ew := txtfmt.NewErrWriter(out)
+
+	componentTitle := "Component"
+	versionTitle := "Version"
+	revisionTitle := "Revision"
+	hostsTitle := "Hosts"
+
+	formatter := txtfmt.NewTableFormatter(componentTitle, versionTitle, revisionTitle, hostsTitle)
+	var table []txtfmt.TableRow
+
+	var skewed []string
+	for _, cs := range vsr.Components {
+		if cs.Skewed() {
+			skewed = append(skewed, cs.Name)
+		}
+		for _, vh := range cs.Versions {
+			row := txtfmt.TableRow{
+				componentTitle: cs.Name,
+				versionTitle:   vh.Version,
+				revisionTitle:  vh.Revision,
+				hostsTitle:     getPrintHosts(vh.Hosts.RangedString(), opts...),
+			}
+			if row[revisionTitle] == "" {
+				row[revisionTitle] = "N/A"
+			}
+			table = append(table, row)
+		}
+	}
+
+	fmt.Fprint(ew, formatter.Format(table))
+
+	if len(skewed) > 0 {
+		fmt.Fprintf(ew, "\nVersion mismatch detected for: %s\n", strings.Join(skewed, ", "))
+	}
+	if vsr.OutsideWindow() {
+		fmt.Fprintf(ew, "DAOS releases %s are outside of the supported window\n",
+			strings.Join(vsr.Releases, ", "))
+	}
+
+	return ew.Err
+}
@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+)
+
+func TestPretty_PrintHostVersionMap(t *testing.T) {
+	mockVersions := func(engineVer string) *control.HostVersions {
+		return &control.HostVersions{
+			Components: []*control.ComponentVersion{
+				{Name: "daos_server", Version: "2.0.0", Revision: "abc123"},
+				{Name: "daos_engine", Version: engineVer, Revision: "abc123"},
+				{Name: "spdk", Version: "v21.07"},
+				{Name: "ndctl", Error: "not found"},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		hosts       map[string]*control.HostVersions
+		expPrintStr string
+	}{
+		"empty": {
+			expPrintStr: "",
+		},
+		"two hosts; same versions": {
+			hosts: map[string]*control.HostVersions{
+				"host1": mockVersions("2.0.0"),
+				"host2": mockVersions("2.0.0"),
+			},
+			expPrintStr: `
+---------
+host[1-2]
+---------
+
+    Component   Version             Revision 
+    ---------   -------             -------- 
+    daos_server 2.0.0               abc123   
+    daos_engine 2.0.0               abc123   
+    spdk        v21.07              N/A      
+    ndctl       unknown (not found) N/A      
+
+`,
+		},
+		"two hosts; engine version mismatch": {
+			hosts: map[string]*control.HostVersions{
+				"host1": mockVersions("2.0.0"),
+				"host2": mockVersions("1.2.0"),
+			},
+			expPrintStr: `
+-----
+host1
+-----
+
+    Component   Version             Revision 
+    ---------   -------             -------- 
+    daos_server 2.0.0               abc123   
+    daos_engine 2.0.0               abc123   
+    spdk        v21.07              N/A      
+    ndctl       unknown (not found) N/A      
+
+-----
+host2
+-----
+
+    Component   Version             Revision 
+    ---------   -------             -------- 
+    daos_server 2.0.0               abc123   
+    daos_engine 1.2.0               abc123   
+    spdk        v21.07              N/A      
+    ndctl       unknown (not found) N/A      
+
+Version mismatch between hosts detected for: daos_engine
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hvm := make(control.HostVersionMap)
+			for host, hv := range tc.hosts {
+				if err := hvm.Add(host, hv); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var bld strings.Builder
+			if err := PrintHostVersionMap(hvm, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPretty_PrintVersionSkewReport(t *testing.T) {
+	vh := func(ver, rev, hosts string) *control.VersionHosts {
+		return &control.VersionHosts{
+			Version:  ver,
+			Revision: rev,
+			Hosts:    hostlist.MustCreateSet(hosts),
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		report      *control.VersionSkewReport
+		expPrintStr string
+	}{
+		"empty": {
+			report:      &control.VersionSkewReport{},
+			expPrintStr: "",
+		},
+		"no skew": {
+			report: &control.VersionSkewReport{
+				Components: []*control.ComponentSkew{
+					{Name: "daos_server", Versions: []*control.VersionHosts{vh("2.0.0", "abc123", "host[1-2]")}},
+					{Name: "daos_engine", Versions: []*control.VersionHosts{vh("2.0.0", "abc123", "host[1-2]")}},
+					{Name: "daos_agent", Versions: []*control.VersionHosts{vh("2.0.0", "", "client1")}},
+				},
+				Releases: []string{"2.0"},
+			},
+			expPrintStr: `
+Component   Version Revision Hosts     
+---------   ------- -------- -----     
+daos_server 2.0.0   abc123   host[1-2] 
+daos_engine 2.0.0   abc123   host[1-2] 
+daos_agent  2.0.0   N/A      client1   
+`,
+		},
+		"skew outside window": {
+			report: &control.VersionSkewReport{
+				Components: []*control.ComponentSkew{
+					{Name: "daos_server", Versions: []*control.VersionHosts{vh("2.0.0", "abc123", "host[1-2]")}},
+					{Name: "daos_engine", Versions: []*control.VersionHosts{vh("2.0.0", "abc123", "host[1-2]")}},
+					{Name: "daos_agent", Versions: []*control.VersionHosts{
+						vh("2.0.0", "", "client1"),
+						vh("2.2.0", "", "client2"),
+					}},
+				},
+				Releases: []string{"2.0", "2.2"},
+			},
+			expPrintStr: `
+Component   Version Revision Hosts     
+---------   ------- -------- -----     
+daos_server 2.0.0   abc123   host[1-2] 
+daos_engine 2.0.0   abc123   host[1-2] 
+daos_agent  2.0.0   N/A      client1   
+daos_agent  2.2.0   N/A      client2   
+
+Version mismatch detected for: daos_agent
+DAOS releases 2.0, 2.2 are outside of the supported window
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var bld strings.Builder
+			if err := PrintVersionSkewReport(tc.report, &bld); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expPrintStr, "\n"), bld.String()); diff != "" {
+				t.Fatalf("unexpected format string (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
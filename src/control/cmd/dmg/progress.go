@@ -0,0 +1,152 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	progressBarWidth = 20
+	// clearLine returns the cursor to the start of the line and erases it.
+	clearLine = "\r\033[K"
+)
+
+// isTerminal returns true if the file refers to a terminal.
+func isTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), unix.TCGETS)
+	return err == nil
+}
+
+// progressRenderer implements control.ProgressReporter. When rendering to a
+// terminal is enabled, requests sent to multiple hosts display a progress bar
+// showing how many hosts have responded and an estimate of the time remaining,
+// which is erased once all responses have been received. Otherwise progress
+// is written to the debug log.
+type progressRenderer struct {
+	sync.Mutex
+	log     logging.Logger
+	out     io.Writer
+	tty     bool
+	now     func() time.Time
+	phase   string
+	total   int
+	done    int
+	failed  int
+	started time.Time
+	drawn   bool
+}
+
+var _ control.ProgressReporter = (*progressRenderer)(nil)
+
+func newProgressRenderer(log logging.Logger, out io.Writer) *progressRenderer {
+	return &progressRenderer{
+		log: log,
+		out: out,
+		now: time.Now,
+	}
+}
+
+// setTTY enables or disables rendering of progress to the terminal.
+func (pr *progressRenderer) setTTY(tty bool) {
+	pr.Lock()
+	defer pr.Unlock()
+
+	pr.tty = tty
+}
+
+func (pr *progressRenderer) rendering() bool {
+	return pr.tty && pr.total > 1
+}
+
+// Start implements control.ProgressReporter.
+func (pr *progressRenderer) Start(phase string, hosts int) {
+	pr.Lock()
+	defer pr.Unlock()
+
+	pr.phase = phase
+	pr.total = hosts
+	pr.done = 0
+	pr.failed = 0
+	pr.started = pr.now()
+
+	if !pr.rendering() {
+		pr.log.Debugf("%s: request sent to %d hosts", phase, hosts)
+		return
+	}
+	pr.draw()
+}
+
+// HostDone implements control.ProgressReporter.
+func (pr *progressRenderer) HostDone(addr string, err error) {
+	pr.Lock()
+	defer pr.Unlock()
+
+	pr.done++
+	if err != nil {
+		pr.failed++
+	}
+
+	if !pr.rendering() {
+		pr.log.Debugf("%s: %d/%d hosts responded (%s)", pr.phase, pr.done, pr.total, addr)
+		return
+	}
+	pr.draw()
+}
+
+// Finish implements control.ProgressReporter.
+func (pr *progressRenderer) Finish() {
+	pr.Lock()
+	defer pr.Unlock()
+
+	if pr.drawn {
+		fmt.Fprint(pr.out, clearLine)
+		pr.drawn = false
+	}
+}
+
+// eta returns an estimate of the time remaining until all hosts have
+// responded, based on the average time taken by the hosts so far.
+func (pr *progressRenderer) eta() string {
+	if pr.done == 0 {
+		return "--"
+	}
+	if pr.done >= pr.total {
+		return "0s"
+	}
+
+	elapsed := pr.now().Sub(pr.started)
+	remaining := elapsed / time.Duration(pr.done) * time.Duration(pr.total-pr.done)
+	return remaining.Round(time.Second).String()
+}
+
+func (pr *progressRenderer) draw() {
+	filled := progressBarWidth
+	if pr.done < pr.total {
+		filled = pr.done * progressBarWidth / pr.total
+	}
+
+	var failed string
+	if pr.failed > 0 {
+		failed = fmt.Sprintf(", %d failed", pr.failed)
+	}
+
+	fmt.Fprintf(pr.out, "%s%s [%s%s] %d/%d hosts%s, ETA %s", clearLine, pr.phase,
+		strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled),
+		pr.done, pr.total, failed, pr.eta())
+	pr.drawn = true
+}
@@ -0,0 +1,84 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestDmg_progressRenderer(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tty       bool
+		hosts     int
+		expOut    []string
+		expLogMsg string
+	}{
+		"tty multiple hosts": {
+			tty:   true,
+			hosts: 4,
+			expOut: []string{
+				clearLine + "storage scan [                    ] 0/4 hosts, ETA --",
+				clearLine + "storage scan [=====               ] 1/4 hosts, ETA 6s",
+				clearLine + "storage scan [==========          ] 2/4 hosts, 1 failed, ETA 2s",
+				clearLine,
+			},
+		},
+		"tty single host": {
+			tty:       true,
+			hosts:     1,
+			expLogMsg: "storage scan: 1/1 hosts responded (host2)",
+		},
+		"not a tty": {
+			hosts:     4,
+			expLogMsg: "storage scan: 2/4 hosts responded (host2)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var out strings.Builder
+			now := time.Now()
+			pr := newProgressRenderer(log, &out)
+			pr.now = func() time.Time { return now }
+			pr.setTTY(tc.tty)
+
+			var expOut strings.Builder
+			pr.Start("storage scan", tc.hosts)
+			if len(tc.expOut) > 0 {
+				expOut.WriteString(tc.expOut[0])
+			}
+			now = now.Add(2 * time.Second)
+			if tc.hosts > 1 {
+				pr.HostDone("host1", nil)
+				if len(tc.expOut) > 1 {
+					expOut.WriteString(tc.expOut[1])
+				}
+			}
+			pr.HostDone("host2", errors.New("failed"))
+			if len(tc.expOut) > 2 {
+				expOut.WriteString(tc.expOut[2])
+			}
+			pr.Finish()
+			if len(tc.expOut) > 3 {
+				expOut.WriteString(tc.expOut[3])
+			}
+
+			common.AssertEqual(t, expOut.String(), out.String(), "unexpected output")
+			if tc.expLogMsg != "" && !strings.Contains(buf.String(), tc.expLogMsg) {
+				t.Fatalf("expected to see %q in log, got %q", tc.expLogMsg, buf.String())
+			}
+		})
+	}
+}
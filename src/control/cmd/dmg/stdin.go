@@ -0,0 +1,235 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+// stdinArg is supplied in place of a value to read it from stdin.
+const stdinArg = "-"
+
+// stdinInput is read for values supplied as stdinArg.
+var stdinInput io.Reader = os.Stdin
+
+type (
+	// paramsReader is implemented by commands whose options may be read
+	// from a JSON object.
+	paramsReader interface {
+		paramsPath() string
+	}
+
+	// paramsCmd is embedded in commands whose options may be read from a
+	// JSON object keyed by long option name, e.g. {"size": "10TB"}.
+	paramsCmd struct {
+		Params string `long:"params" description:"Read options from a JSON object keyed by long option name in the given file, or from stdin if -"`
+	}
+)
+
+func (cmd *paramsCmd) paramsPath() string {
+	return cmd.Params
+}
+
+// readHostList reads hosts listed one or more per line, separated by
+// commas or whitespace. Blank lines and lines starting with # are ignored.
+func readHostList(r io.Reader) (string, error) {
+	var hosts []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hosts = append(hosts, strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})...)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "reading host list")
+	}
+	if len(hosts) == 0 {
+		return "", errors.New("no hosts in host list read from stdin")
+	}
+
+	return strings.Join(hosts, ","), nil
+}
+
+// activeLeaf returns the subcommand that is run by the parser.
+func activeLeaf(p *flags.Parser) *flags.Command {
+	cmd := p.Active
+	for cmd != nil && cmd.Active != nil {
+		cmd = cmd.Active
+	}
+	return cmd
+}
+
+// findOptionField returns the field of the command struct tagged with the
+// given long option name.
+func findOptionField(v reflect.Value, longName string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		if sf.Tag.Get("long") == longName {
+			return v.Field(i), true
+		}
+		if sf.Anonymous || sf.Tag.Get("group") != "" {
+			if fv, found := findOptionField(v.Field(i), longName); found {
+				return fv, true
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// paramString returns a JSON parameter value in the form it would be given
+// on the command line. Arrays are joined into a comma-separated list.
+func paramString(raw json.RawMessage) (string, error) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, nil
+	}
+
+	var list []interface{}
+	if err := json.Unmarshal(raw, &list); err == nil {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			itemRaw, err := json.Marshal(item)
+			if err != nil {
+				return "", err
+			}
+			itemStr, err := paramString(itemRaw)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, itemStr)
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return "", errors.New("objects are not supported")
+	}
+
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// setParam sets the value of an option field from a JSON parameter value.
+func setParam(opt *flags.Option, field reflect.Value, raw json.RawMessage) error {
+	if u, ok := field.Addr().Interface().(flags.Unmarshaler); ok || field.Kind() == reflect.String {
+		str, err := paramString(raw)
+		if err != nil {
+			return err
+		}
+		if len(opt.Choices) > 0 && !containsString(opt.Choices, str) {
+			return errors.Errorf("invalid value %q (valid values: %s)", str,
+				strings.Join(opt.Choices, ", "))
+		}
+		if ok {
+			return u.UnmarshalFlag(str)
+		}
+		field.SetString(str)
+		return nil
+	}
+
+	// numbers and booleans may also be quoted
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		raw = json.RawMessage(str)
+	}
+	return json.Unmarshal(raw, field.Addr().Interface())
+}
+
+func containsString(list []string, str string) bool {
+	for _, item := range list {
+		if item == str {
+			return true
+		}
+	}
+	return false
+}
+
+// applyParams sets the options of the command from a JSON object keyed by
+// long option name. Options supplied on the command line take precedence.
+func applyParams(fc *flags.Command, cmd flags.Commander, r io.Reader) error {
+	var params map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&params); err != nil {
+		return errors.Wrap(err, "parsing params as a JSON object")
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		opt := fc.FindOptionByLongName(name)
+		if opt == nil || name == "params" {
+			return errors.Errorf("unknown param %q for command %q", name, fc.Name)
+		}
+		if opt.IsSet() && !opt.IsSetDefault() {
+			continue
+		}
+
+		field, found := findOptionField(reflect.ValueOf(cmd), name)
+		if !found {
+			return errors.Errorf("unknown param %q for command %q", name, fc.Name)
+		}
+		if err := setParam(opt, field, params[name]); err != nil {
+			return errors.Wrapf(err, "param %q", name)
+		}
+	}
+
+	return nil
+}
+
+// readParams applies the params of a command which implements
+// paramsReader, reading them from stdin if the path is stdinArg.
+func readParams(fc *flags.Command, cmd flags.Commander) error {
+	pr, ok := cmd.(paramsReader)
+	if !ok || pr.paramsPath() == "" {
+		return nil
+	}
+
+	if pr.paramsPath() == stdinArg {
+		return applyParams(fc, cmd, stdinInput)
+	}
+
+	f, err := os.Open(pr.paramsPath())
+	if err != nil {
+		return errors.Wrap(err, "reading params")
+	}
+	defer f.Close()
+
+	return applyParams(fc, cmd, f)
+}
@@ -0,0 +1,178 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestDmg_readHostList(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input    string
+		expHosts string
+		expErr   error
+	}{
+		"empty": {
+			expErr: errors.New("no hosts"),
+		},
+		"comments only": {
+			input:  "# inventory\n\n",
+			expErr: errors.New("no hosts"),
+		},
+		"one per line": {
+			input:    "host1\nhost2:10001\n",
+			expHosts: "host1,host2:10001",
+		},
+		"mixed separators": {
+			input:    "# rack 1\n host1, host2\thost3\n\nhost-[4-5]\n",
+			expHosts: "host1,host2,host3,host-[4-5]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotHosts, gotErr := readHostList(strings.NewReader(tc.input))
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expHosts, gotHosts, "host list")
+		})
+	}
+}
+
+func TestDmg_StdinInput(t *testing.T) {
+	eUsr, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	eGrp, err := user.LookupGroupId(eUsr.Gid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+	paramsPath := filepath.Join(dir, "params.json")
+	writeTestFile(t, paramsPath, `{"size": "10GB", "label": "from-file"}`)
+
+	scanReq := &control.StorageScanReq{NvmeBasic: true}
+	scanReq.SetHostList([]string{"host1", "host2", "host3"})
+
+	createReq := func(label string, props ...*control.PoolProperty) *control.PoolCreateReq {
+		return &control.PoolCreateReq{
+			TotalBytes: 10000000000,
+			ScmRatio:   0.06,
+			User:       eUsr.Username + "@",
+			UserGroup:  eGrp.Name + "@",
+			Label:      label,
+			NumSvcReps: 3,
+			Ranks:      []system.Rank{},
+			Properties: props,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cmd      string
+		input    string
+		expCalls string
+		expErr   error
+	}{
+		"host list from stdin": {
+			cmd:      "-l - storage scan",
+			input:    "host1\nhost2,host3\n",
+			expCalls: printRequest(t, scanReq),
+		},
+		"empty host list from stdin": {
+			cmd:    "-l - storage scan",
+			expErr: errors.New("no hosts"),
+		},
+		"pool create params from stdin": {
+			cmd:   "pool create --params -",
+			input: `{"size": "10GB", "label": "foo", "nsvc": 3, "properties": ["reclaim:lazy", "self_heal:exclude"]}`,
+			expCalls: printRequest(t, createReq("foo",
+				&control.PoolProperty{Name: "reclaim", Value: "lazy"},
+				&control.PoolProperty{Name: "self_heal", Value: "exclude"})),
+		},
+		"command line overrides params": {
+			cmd:      "pool create --label bar --params -",
+			input:    `{"size": "10GB", "label": "foo", "nsvc": "3"}`,
+			expCalls: printRequest(t, createReq("bar")),
+		},
+		"params from file": {
+			cmd:      fmt.Sprintf("pool create --nsvc 3 --params %s", paramsPath),
+			expCalls: printRequest(t, createReq("from-file")),
+		},
+		"params file missing": {
+			cmd:    fmt.Sprintf("pool create --params %s", filepath.Join(dir, "missing.json")),
+			expErr: errors.New("reading params"),
+		},
+		"unknown param": {
+			cmd:    "pool create --params -",
+			input:  `{"size": "10GB", "colour": "blue"}`,
+			expErr: errors.New(`unknown param "colour"`),
+		},
+		"params not an object": {
+			cmd:    "pool create --params -",
+			input:  `["size", "10GB"]`,
+			expErr: errors.New("JSON object"),
+		},
+		"bad param value": {
+			cmd:    "pool create --params -",
+			input:  `{"size": "10GB", "nsvc": "three"}`,
+			expErr: errors.New(`param "nsvc"`),
+		},
+		"set-pool-defaults params from stdin": {
+			cmd:   "system set-pool-defaults --params -",
+			input: `{"properties": "reclaim:lazy", "enforce": true}`,
+			expCalls: printRequest(t, &control.SystemPoolDefaultsReq{
+				Set: []*control.PoolPropDefault{
+					{Name: "reclaim", Value: "lazy", Enforced: true},
+				},
+			}),
+		},
+		"params not supported": {
+			cmd:    "pool list --params -",
+			expErr: errors.New("unknown flag `params'"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			stdinInput = strings.NewReader(tc.input)
+			defer func() {
+				stdinInput = os.Stdin
+			}()
+
+			conn := newTestConn(t)
+			bridge := &bridgeConnInvoker{
+				MockInvoker: *control.DefaultMockInvoker(log),
+				t:           t,
+				conn:        conn,
+			}
+			gotErr := runCmd(t, tc.cmd, log, bridge)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expCalls, strings.Join(conn.called, " "), "calls")
+		})
+	}
+}
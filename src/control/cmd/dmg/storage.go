@@ -10,6 +10,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
@@ -27,6 +28,7 @@ type storageCmd struct {
 	Set      setFaultyCmd       `command:"set" alias:"s" description:"Manually set the device state."`
 	Replace  storageReplaceCmd  `command:"replace" alias:"r" description:"Replace a storage device that has been hot-removed with a new device."`
 	Identify storageIdentifyCmd `command:"identify" alias:"i" description:"Blink the status LED on a given VMD device for visual SSD identification."`
+	SelfTest storageSelfTestCmd `command:"self-test" description:"Measure the performance of NVMe SSDs attached to remote servers."`
 }
 
 // storagePrepareCmd is the struct representing the prep storage subcommand.
@@ -36,10 +38,16 @@ type storagePrepareCmd struct {
 	hostListCmd
 	jsonOutputCmd
 	types.StoragePrepareCmd
+	Status  bool `long:"status" description:"Display the current NVMe driver binding state on each host, nothing is prepared or reset"`
+	Verbose bool `short:"v" long:"verbose" description:"Show the time taken by each phase of the prepare on each host"`
 }
 
 // Execute is run when storagePrepareCmd activates
 func (cmd *storagePrepareCmd) Execute(args []string) error {
+	if cmd.Status {
+		return cmd.status()
+	}
+
 	prepNvme, prepScm, err := cmd.Validate()
 	if err != nil {
 		return err
@@ -84,6 +92,24 @@ func (cmd *storagePrepareCmd) Execute(args []string) error {
 		cmd.log.Error(outErr.String())
 	}
 
+	var outWarn strings.Builder
+	if err := pretty.PrintResponseWarnings(resp, &outWarn); err != nil {
+		return err
+	}
+	if outWarn.Len() > 0 {
+		cmd.log.Info(outWarn.String())
+	}
+
+	if cmd.Verbose {
+		var outTimes strings.Builder
+		if err := pretty.PrintResponseTimings(resp, &outTimes); err != nil {
+			return err
+		}
+		if outTimes.Len() > 0 {
+			cmd.log.Info(outTimes.String())
+		}
+	}
+
 	if prepScm {
 		var out strings.Builder
 		if err := pretty.PrintScmPrepareMap(resp.HostStorage, &out); err != nil {
@@ -95,12 +121,51 @@ func (cmd *storagePrepareCmd) Execute(args []string) error {
 	return resp.Errors()
 }
 
+// status displays the NVMe driver binding state and the last prepare or reset
+// operation recorded on each host.
+func (cmd *storagePrepareCmd) status() error {
+	if cmd.ScmOnly || cmd.Reset || cmd.Force || cmd.PCIAllowList != "" ||
+		cmd.NrHugepages != 0 || cmd.TargetUser != "" {
+		return errors.New("--status cannot be used with other prepare options")
+	}
+
+	req := &control.StoragePrepareReq{
+		NVMe: &control.NvmePrepareReq{Status: true},
+	}
+	req.SetHostList(cmd.hostlist)
+	resp, err := control.StoragePrepare(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var outErr strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &outErr); err != nil {
+		return err
+	}
+	if outErr.Len() > 0 {
+		cmd.log.Error(outErr.String())
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintNvmePrepareStatusMap(resp.HostStorage, &out); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return resp.Errors()
+}
+
 // storageScanCmd is the struct representing the scan storage subcommand.
 type storageScanCmd struct {
 	logCmd
 	ctlInvokerCmd
 	hostListCmd
 	jsonOutputCmd
+	outputFormatCmd
 	Verbose    bool `short:"v" long:"verbose" description:"List SCM & NVMe device details"`
 	NvmeHealth bool `short:"n" long:"nvme-health" description:"Display NVMe device health statistics"`
 	NvmeMeta   bool `short:"m" long:"nvme-meta" description:"Display server meta data held on NVMe storage"`
@@ -116,6 +181,9 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 	if cmd.Verbose && (cmd.NvmeHealth || cmd.NvmeMeta) {
 		return errors.New("cannot use --verbose with --nvme-health or --nvme-meta")
 	}
+	if cmd.csvOutputEnabled() && (cmd.Verbose || cmd.NvmeHealth || cmd.NvmeMeta) {
+		return errors.New("cannot use --format csv with --verbose, --nvme-health or --nvme-meta")
+	}
 
 	req := &control.StorageScanReq{
 		NvmeHealth: cmd.NvmeHealth,
@@ -151,6 +219,10 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 		if err := pretty.PrintNvmeMetaMap(resp.HostStorage, &out); err != nil {
 			return err
 		}
+	case cmd.csvOutputEnabled():
+		if err := pretty.PrintHostStorageMapCSV(resp.HostStorage, &out); err != nil {
+			return err
+		}
 	default:
 		verbose := pretty.PrintWithVerboseOutput(cmd.Verbose)
 		if err := pretty.PrintHostStorageMap(resp.HostStorage, &out, verbose); err != nil {
@@ -165,12 +237,18 @@ func (cmd *storageScanCmd) Execute(_ []string) error {
 // storageFormatCmd is the struct representing the format storage subcommand.
 type storageFormatCmd struct {
 	logCmd
+	cfgCmd
 	ctlInvokerCmd
 	hostListCmd
 	jsonOutputCmd
-	Verbose  bool `short:"v" long:"verbose" description:"Show results of each SCM & NVMe device format operation"`
+	Verbose  bool `short:"v" long:"verbose" description:"Show results of each SCM & NVMe device format operation and the time taken by each phase"`
 	Reformat bool `long:"reformat" description:"Alias for --force, will be removed in a future release"`
 	Force    bool `long:"force" description:"Force storage format on a host, stopping any running engines (CAUTION: destructive operation)"`
+	Restart  bool `long:"restart" description:"Format all devices again rather than resuming a previous format that did not complete"`
+
+	MaxParallel      uint  `long:"max-parallel" description:"Maximum number of hosts to format at once, all hosts are formatted at once if unset"`
+	MaxFailures      *uint `long:"max-failures" description:"Skip the remaining hosts once more than this number of hosts have failed to format"`
+	AbortMultiDomain bool  `long:"abort-multi-domain" description:"Skip the remaining hosts once hosts in more than one fault domain have failed to format"`
 }
 
 // Execute is run when storageFormatCmd activates.
@@ -179,8 +257,20 @@ type storageFormatCmd struct {
 func (cmd *storageFormatCmd) Execute(args []string) (err error) {
 	ctx := context.Background()
 
-	req := &control.StorageFormatReq{Reformat: cmd.Force}
-	req.SetHostList(cmd.hostlist)
+	req := &control.StorageFormatReq{
+		Reformat:         cmd.Force,
+		Restart:          cmd.Restart,
+		MaxParallel:      cmd.MaxParallel,
+		MaxFailures:      cmd.MaxFailures,
+		AbortMultiDomain: cmd.AbortMultiDomain,
+	}
+	// Resolve the hostlist from the config when not set so that the
+	// hosts being formatted are locked.
+	hosts := cmd.hostlist
+	if len(hosts) == 0 && cmd.config != nil {
+		hosts = cmd.config.HostList
+	}
+	req.SetHostList(hosts)
 
 	// TODO (DAOS-7080): Deprecate this parameter in favor of wiping SCM
 	// during the erase operation. For the moment, though, the reworked
@@ -211,6 +301,24 @@ func (cmd *storageFormatCmd) printFormatResp(resp *control.StorageFormatResp) er
 		cmd.log.Error(outErr.String())
 	}
 
+	var outWarn strings.Builder
+	if err := pretty.PrintResponseWarnings(resp, &outWarn); err != nil {
+		return err
+	}
+	if outWarn.Len() > 0 {
+		cmd.log.Info(outWarn.String())
+	}
+
+	if cmd.Verbose {
+		var outTimes strings.Builder
+		if err := pretty.PrintResponseTimings(resp, &outTimes); err != nil {
+			return err
+		}
+		if outTimes.Len() > 0 {
+			cmd.log.Info(outTimes.String())
+		}
+	}
+
 	var out strings.Builder
 	verbose := pretty.PrintWithVerboseOutput(cmd.Verbose)
 	if err := pretty.PrintStorageFormatMap(resp.HostStorage, &out, verbose); err != nil {
@@ -307,3 +415,64 @@ func (cmd *vmdIdentifyCmd) Execute(_ []string) error {
 	}
 	return cmd.makeRequest(context.Background(), req)
 }
+
+// storageSelfTestCmd is the struct representing the command to measure the
+// performance of NVMe SSDs attached to remote servers. I/O Engines must be
+// stopped on the servers.
+type storageSelfTestCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+	PciAddrs   string `long:"pci-addrs" description:"Comma-separated list of NVMe SSD PCI addresses to test (default is all configured)"`
+	Workload   string `short:"w" long:"workload" default:"read" choice:"read" choice:"write" choice:"randread" choice:"randwrite" description:"I/O pattern to test"`
+	IoSize     string `long:"io-size" default:"128KiB" description:"Size of each I/O"`
+	QueueDepth uint32 `long:"queue-depth" default:"32" description:"Number of outstanding I/Os per SSD"`
+	Duration   uint32 `long:"duration" default:"10" description:"Seconds to run the test on each SSD"`
+	Force      bool   `long:"force" description:"Allow write workloads, which destroy any data on the tested SSDs"`
+}
+
+// Execute is run when storageSelfTestCmd activates.
+func (cmd *storageSelfTestCmd) Execute(_ []string) error {
+	ioSize, err := humanize.ParseBytes(cmd.IoSize)
+	if err != nil {
+		return errors.Wrap(err, "--io-size")
+	}
+
+	req := &control.StorageSelfTestReq{
+		Workload:   cmd.Workload,
+		IoSize:     uint32(ioSize),
+		QueueDepth: cmd.QueueDepth,
+		Duration:   cmd.Duration,
+		Force:      cmd.Force,
+	}
+	if cmd.PciAddrs != "" {
+		req.PciAddrs = strings.Split(cmd.PciAddrs, ",")
+	}
+	req.SetHostList(cmd.hostlist)
+
+	cmd.log.Debugf("storage self-test req: %+v", req)
+
+	ctx := context.Background()
+	resp, err := control.StorageSelfTest(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+
+	if err := pretty.PrintStorageSelfTestResults(resp.HostResults, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
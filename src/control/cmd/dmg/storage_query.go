@@ -8,11 +8,15 @@ package main
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
+
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -32,11 +36,18 @@ type smdQueryCmd struct {
 	ctlInvokerCmd
 	hostListCmd
 	jsonOutputCmd
+
+	// sortKey, if set, is the field used to sort the devices in the
+	// response for each host.
+	sortKey string
 }
 
 func (cmd *smdQueryCmd) makeRequest(ctx context.Context, req *control.SmdQueryReq, opts ...pretty.PrintConfigOption) error {
 	req.SetHostList(cmd.hostlist)
 	resp, err := control.SmdQuery(ctx, cmd.ctlInvoker, req)
+	if err == nil && cmd.sortKey != "" {
+		sortSmdDevices(resp, cmd.sortKey)
+	}
 
 	if cmd.jsonOutputEnabled() {
 		return cmd.outputJSON(resp, err)
@@ -62,9 +73,12 @@ func (cmd *smdQueryCmd) makeRequest(ctx context.Context, req *control.SmdQueryRe
 type storageQueryCmd struct {
 	TargetHealth tgtHealthQueryCmd   `command:"target-health" alias:"t" description:"Query the target health"`
 	DeviceHealth devHealthQueryCmd   `command:"device-health" alias:"d" description:"Query the device health"`
+	DeviceStats  devStatsQueryCmd    `command:"device-stats" alias:"s" description:"Show NVMe I/O statistics per device"`
 	ListPools    listPoolsQueryCmd   `command:"list-pools" alias:"p" description:"List pools on the server"`
 	ListDevices  listDevicesQueryCmd `command:"list-devices" alias:"d" description:"List storage devices on the server"`
 	Usage        usageQueryCmd       `command:"usage" alias:"u" description:"Show SCM & NVMe storage space utilization per storage server"`
+	Ownership    ownershipQueryCmd   `command:"ownership" alias:"o" description:"Show the engine instance owning each storage device per storage server"`
+	Verify       verifyQueryCmd      `command:"verify" alias:"v" description:"Show the progress of NVMe read verification after format per storage server"`
 }
 
 type devHealthQueryCmd struct {
@@ -83,6 +97,42 @@ func (cmd *devHealthQueryCmd) Execute(_ []string) error {
 	return cmd.makeRequest(ctx, req)
 }
 
+type devStatsQueryCmd struct {
+	smdQueryCmd
+	rankCmd
+	UUID string `short:"u" long:"uuid" description:"Device UUID (all devices if blank)"`
+}
+
+func (cmd *devStatsQueryCmd) Execute(_ []string) error {
+	req := &control.SmdQueryReq{
+		OmitPools:        true,
+		IncludeBioHealth: true,
+		Rank:             cmd.GetRank(),
+		UUID:             cmd.UUID,
+	}
+	req.SetHostList(cmd.hostlist)
+	resp, err := control.SmdQuery(context.Background(), cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintSmdDeviceStats(resp.HostStorage, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
+
 type tgtHealthQueryCmd struct {
 	smdQueryCmd
 	Rank  uint32 `short:"r" long:"rank" required:"1" description:"Server rank hosting target"`
@@ -100,20 +150,106 @@ func (cmd *tgtHealthQueryCmd) Execute(_ []string) error {
 	return cmd.makeRequest(ctx, req)
 }
 
+const (
+	devSortRank        = "rank"
+	devSortUUID        = "uuid"
+	devSortState       = "state"
+	devSortTrAddr      = "tr-addr"
+	devSortTemp        = "temp"
+	devSortMediaErrors = "media-errors"
+
+	// kelvinOffset converts whole degrees Celsius to Kelvin.
+	kelvinOffset = 273
+)
+
+// sortSmdDevices sorts the devices listed for each host in the response by the
+// given key. Health fields are sorted in descending order and devices with
+// equal keys are ordered by rank and UUID.
+func sortSmdDevices(resp *control.SmdQueryResp, key string) {
+	health := func(dev *storage.SmdDevice) storage.NvmeHealth {
+		if dev.Health == nil {
+			return storage.NvmeHealth{}
+		}
+		return *dev.Health
+	}
+
+	for _, hss := range resp.HostStorage {
+		if hss.HostStorage == nil || hss.HostStorage.SmdInfo == nil {
+			continue
+		}
+
+		devs := hss.HostStorage.SmdInfo.Devices
+		sort.SliceStable(devs, func(i, j int) bool {
+			di, dj := devs[i], devs[j]
+			switch key {
+			case devSortUUID:
+				return di.UUID < dj.UUID
+			case devSortState:
+				if di.State != dj.State {
+					return di.State < dj.State
+				}
+			case devSortTrAddr:
+				if di.TrAddr != dj.TrAddr {
+					return di.TrAddr < dj.TrAddr
+				}
+			case devSortTemp:
+				if ti, tj := health(di).Temperature, health(dj).Temperature; ti != tj {
+					return ti > tj
+				}
+			case devSortMediaErrors:
+				if mi, mj := health(di).MediaErrors, health(dj).MediaErrors; mi != mj {
+					return mi > mj
+				}
+			}
+			if di.Rank != dj.Rank {
+				return di.Rank < dj.Rank
+			}
+			return di.UUID < dj.UUID
+		})
+	}
+}
+
 type listDevicesQueryCmd struct {
 	smdQueryCmd
 	rankCmd
-	Health bool   `short:"b" long:"health" description:"Include device health in results"`
-	UUID   string `short:"u" long:"uuid" description:"Device UUID (all devices if blank)"`
+	Health       bool   `short:"b" long:"health" description:"Include device health in results"`
+	UUID         string `short:"u" long:"uuid" description:"Device UUID (all devices if blank)"`
+	State        string `long:"state" description:"Only list devices in this state (e.g. NORMAL or FAULTY)"`
+	FaultyOnly   bool   `long:"faulty-only" description:"Only list devices in the FAULTY state"`
+	Host         string `long:"host" description:"Only list devices on servers with a hostname in this hostlist"`
+	Model        string `long:"model" description:"Only list devices with a model containing this string"`
+	MinTemp      uint32 `long:"min-temp" description:"Only list devices at or above this temperature in degrees Celsius"`
+	MinMediaErrs uint64 `long:"min-media-errors" description:"Only list devices with at least this many media errors"`
+	MinIoErrs    uint32 `long:"min-io-errors" description:"Only list devices with at least this many read, write, unmap and checksum errors combined"`
+	Sort         string `long:"sort" choice:"rank" choice:"uuid" choice:"state" choice:"tr-addr" choice:"temp" choice:"media-errors" description:"Sort the devices listed for each server by this field (temp and media-errors require --health and are sorted in descending order)"`
 }
 
 func (cmd *listDevicesQueryCmd) Execute(_ []string) error {
+	if cmd.FaultyOnly {
+		if cmd.State != "" && !strings.EqualFold(cmd.State, "FAULTY") {
+			return errors.New("--faulty-only may not be combined with --state")
+		}
+		cmd.State = "FAULTY"
+	}
+	if (cmd.Sort == devSortTemp || cmd.Sort == devSortMediaErrors) && !cmd.Health {
+		return errors.Errorf("sorting by %s requires --health", cmd.Sort)
+	}
+	cmd.sortKey = cmd.Sort
+
 	ctx := context.Background()
 	req := &control.SmdQueryReq{
 		OmitPools:        true,
 		IncludeBioHealth: cmd.Health,
 		Rank:             cmd.GetRank(),
 		UUID:             cmd.UUID,
+		StateFilter:      cmd.State,
+		HostFilter:       cmd.Host,
+		ModelFilter:      cmd.Model,
+		MinMediaErrs:     cmd.MinMediaErrs,
+		MinIoErrs:        cmd.MinIoErrs,
+	}
+	if cmd.MinTemp > 0 {
+		req.MinTemp = cmd.MinTemp + kelvinOffset
 	}
 	return cmd.makeRequest(ctx, req)
 }
@@ -173,3 +309,79 @@ func (cmd *usageQueryCmd) Execute(_ []string) error {
 
 	return resp.Errors()
 }
+
+// ownershipQueryCmd is the struct representing the storage ownership query
+// subcommand.
+type ownershipQueryCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+}
+
+// Execute is run when ownershipQueryCmd activates.
+//
+// Queries the device ownership ledger on hosts.
+func (cmd *ownershipQueryCmd) Execute(_ []string) error {
+	ctx := context.Background()
+	req := &control.StorageOwnershipReq{}
+	req.SetHostList(cmd.hostlist)
+	resp, err := control.StorageOwnershipQuery(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintStorageOwnership(resp.HostOwners, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
+
+// verifyQueryCmd is the struct representing the storage verify query
+// subcommand.
+type verifyQueryCmd struct {
+	logCmd
+	ctlInvokerCmd
+	hostListCmd
+	jsonOutputCmd
+}
+
+// Execute is run when verifyQueryCmd activates.
+//
+// Queries the progress of NVMe read verification on hosts.
+func (cmd *verifyQueryCmd) Execute(_ []string) error {
+	ctx := context.Background()
+	req := &control.StorageVerifyReq{}
+	req.SetHostList(cmd.hostlist)
+	resp, err := control.StorageVerifyQuery(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintStorageVerify(resp.HostVerify, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
@@ -10,9 +10,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -52,6 +55,27 @@ func TestStorageQueryCommands(t *testing.T) {
 			printRequest(t, &control.SmdQueryReq{}),
 			errors.New("required flag"),
 		},
+		{
+			"per-server metadata device stats query",
+			"storage query device-stats",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:             system.NilRank,
+				OmitPools:        true,
+				IncludeBioHealth: true,
+			}),
+			nil,
+		},
+		{
+			"per-server metadata device stats query (by rank and uuid)",
+			"storage query device-stats --rank 1 --uuid 842c739b-86b5-462f-a7ba-b4a91b674f3d",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:             system.Rank(1),
+				OmitPools:        true,
+				IncludeBioHealth: true,
+				UUID:             "842c739b-86b5-462f-a7ba-b4a91b674f3d",
+			}),
+			nil,
+		},
 		{
 			"per-server metadata query pools",
 			"storage query list-pools",
@@ -118,12 +142,72 @@ func TestStorageQueryCommands(t *testing.T) {
 			}),
 			nil,
 		},
+		{
+			"per-server metadata query devices (with filters)",
+			"storage query list-devices --state NORMAL --host host-[1-3] --model P4510 " +
+				"--min-temp 60 --min-media-errors 1 --min-io-errors 2",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:         system.NilRank,
+				OmitPools:    true,
+				StateFilter:  "NORMAL",
+				HostFilter:   "host-[1-3]",
+				ModelFilter:  "P4510",
+				MinTemp:      333,
+				MinMediaErrs: 1,
+				MinIoErrs:    2,
+			}),
+			nil,
+		},
+		{
+			"per-server metadata query devices (faulty only)",
+			"storage query list-devices --faulty-only --sort state",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:        system.NilRank,
+				OmitPools:   true,
+				StateFilter: "FAULTY",
+			}),
+			nil,
+		},
+		{
+			"per-server metadata query devices (faulty only with other state)",
+			"storage query list-devices --faulty-only --state NORMAL",
+			"",
+			errors.New("may not be combined"),
+		},
+		{
+			"per-server metadata query devices (sort by health without health)",
+			"storage query list-devices --sort temp",
+			"",
+			errors.New("requires --health"),
+		},
+		{
+			"per-server metadata query devices (sort by health)",
+			"storage query list-devices --health --sort media-errors",
+			printRequest(t, &control.SmdQueryReq{
+				Rank:             system.NilRank,
+				OmitPools:        true,
+				IncludeBioHealth: true,
+			}),
+			nil,
+		},
 		{
 			"per-server storage space utilization query",
 			"storage query usage",
 			printRequest(t, &control.StorageScanReq{Usage: true}),
 			nil,
 		},
+		{
+			"per-server storage device ownership query",
+			"storage query ownership",
+			printRequest(t, &control.StorageOwnershipReq{}),
+			nil,
+		},
+		{
+			"per-server NVMe read verification query",
+			"storage query verify",
+			printRequest(t, &control.StorageVerifyReq{}),
+			nil,
+		},
 		{
 			"Nonexistent subcommand",
 			"storage query quack",
@@ -132,3 +216,71 @@ func TestStorageQueryCommands(t *testing.T) {
 		},
 	})
 }
+
+func TestStorageQuery_sortSmdDevices(t *testing.T) {
+	mockDev := func(rank uint32, uuidIdx int32, state string, temp uint32, mediaErrs uint64) *storage.SmdDevice {
+		return &storage.SmdDevice{
+			UUID:   common.MockUUID(uuidIdx),
+			Rank:   system.Rank(rank),
+			State:  state,
+			TrAddr: common.MockPCIAddr(uuidIdx),
+			Health: &storage.NvmeHealth{
+				Temperature: temp,
+				MediaErrors: mediaErrs,
+			},
+		}
+	}
+	devA := mockDev(1, 3, "NORMAL", 300, 0)
+	devB := mockDev(0, 2, "FAULTY", 320, 5)
+	devC := mockDev(0, 1, "NORMAL", 340, 1)
+
+	for name, tc := range map[string]struct {
+		key     string
+		expDevs []*storage.SmdDevice
+	}{
+		"rank": {
+			key:     devSortRank,
+			expDevs: []*storage.SmdDevice{devC, devB, devA},
+		},
+		"uuid": {
+			key:     devSortUUID,
+			expDevs: []*storage.SmdDevice{devC, devB, devA},
+		},
+		"state": {
+			key:     devSortState,
+			expDevs: []*storage.SmdDevice{devB, devC, devA},
+		},
+		"tr-addr": {
+			key:     devSortTrAddr,
+			expDevs: []*storage.SmdDevice{devC, devB, devA},
+		},
+		"temp": {
+			key:     devSortTemp,
+			expDevs: []*storage.SmdDevice{devC, devB, devA},
+		},
+		"media-errors": {
+			key:     devSortMediaErrors,
+			expDevs: []*storage.SmdDevice{devB, devC, devA},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			hsm := make(control.HostStorageMap)
+			if err := hsm.Add("host1", &control.HostStorage{
+				SmdInfo: &control.SmdInfo{
+					Devices: []*storage.SmdDevice{devA, devB, devC},
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			resp := &control.SmdQueryResp{HostStorage: hsm}
+
+			sortSmdDevices(resp, tc.key)
+
+			for _, hss := range resp.HostStorage {
+				if diff := cmp.Diff(tc.expDevs, hss.HostStorage.SmdInfo.Devices); diff != "" {
+					t.Fatalf("unexpected device order (-want, +got):\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
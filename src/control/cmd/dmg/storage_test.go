@@ -7,8 +7,11 @@
 package main
 
 import (
+	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -18,25 +21,62 @@ import (
 func TestStorageCommands(t *testing.T) {
 	storageFormatReq := &control.StorageFormatReq{Reformat: true}
 	storageFormatReq.SetHostList([]string{})
-	systemQueryReq := &control.SystemQueryReq{FailOnUnavailable: true}
+	// format requests are sent to, and lock, the configured hosts when no
+	// hostlist is given
+	localServer := control.DefaultConfig().HostList[0]
+	formatReqs := func(req *control.StorageFormatReq) string {
+		sysReq := &control.SystemQueryReq{FailOnUnavailable: true}
+		sysReq.AddHost(localServer)
+		req.SetHostList([]string{localServer})
+		lockNames := []string{"storage-format:" + localServer}
+		owner := fmt.Sprintf("%s[%d]", control.DefaultLockOwner(), os.Getpid())
+
+		return strings.Join([]string{
+			printRequest(t, sysReq),
+			printRequest(t, &control.SystemLockReq{
+				Action:            control.LockAcquire,
+				Names:             lockNames,
+				Owner:             owner,
+				TTL:               30 * time.Minute,
+				Reason:            "storage format",
+				FailOnUnavailable: true,
+			}),
+			printRequest(t, req),
+			printRequest(t, &control.SystemLockReq{
+				Action: control.LockRelease,
+				Names:  lockNames,
+				Owner:  owner,
+			}),
+		}, " ")
+	}
 
 	runCmdTests(t, []cmdTest{
 		{
 			"Format",
 			"storage format",
-			strings.Join([]string{
-				printRequest(t, systemQueryReq),
-				printRequest(t, &control.StorageFormatReq{}),
-			}, " "),
+			formatReqs(&control.StorageFormatReq{}),
 			nil,
 		},
 		{
 			"Format with reformat",
 			"storage format --reformat",
-			strings.Join([]string{
-				printRequest(t, systemQueryReq),
-				printRequest(t, &control.StorageFormatReq{Reformat: true}),
-			}, " "),
+			formatReqs(&control.StorageFormatReq{Reformat: true}),
+			nil,
+		},
+		{
+			"Format with restart",
+			"storage format --restart",
+			formatReqs(&control.StorageFormatReq{Restart: true}),
+			nil,
+		},
+		{
+			"Format with parallelism and failure limits",
+			"storage format --max-parallel 4 --max-failures 0 --abort-multi-domain",
+			formatReqs(&control.StorageFormatReq{
+				MaxParallel:      4,
+				MaxFailures:      new(uint),
+				AbortMultiDomain: true,
+			}),
 			nil,
 		},
 		{
@@ -55,6 +95,26 @@ func TestStorageCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Scan summary CSV",
+			"storage scan --format csv",
+			strings.Join([]string{
+				printRequest(t, &control.StorageScanReq{NvmeBasic: true}),
+			}, " "),
+			nil,
+		},
+		{
+			"Scan verbose CSV",
+			"storage scan --verbose --format csv",
+			"",
+			errors.New("cannot use --format csv"),
+		},
+		{
+			"Scan invalid format",
+			"storage scan --format xml",
+			"",
+			errors.New("Invalid value"),
+		},
 		{
 			"Scan NVMe health short",
 			"storage scan -n",
@@ -129,6 +189,16 @@ func TestStorageCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Prepare with nvme-only and verbose",
+			"storage prepare --force --nvme-only --verbose",
+			strings.Join([]string{
+				printRequest(t, &control.StoragePrepareReq{
+					NVMe: &control.NvmePrepareReq{},
+				}),
+			}, " "),
+			nil,
+		},
 		{
 			"Prepare with non-existent option",
 			"storage prepare --force --nvme",
@@ -157,6 +227,22 @@ func TestStorageCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"Prepare status",
+			"storage prepare --status",
+			strings.Join([]string{
+				printRequest(t, &control.StoragePrepareReq{
+					NVMe: &control.NvmePrepareReq{Status: true},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"Prepare status with reset",
+			"storage prepare --status --reset",
+			"",
+			errors.New("--status cannot be used with other prepare options"),
+		},
 		{
 			"Set FAULTY device status (force)",
 			"storage set nvme-faulty --uuid 842c739b-86b5-462f-a7ba-b4a91b674f3d -f",
@@ -225,5 +311,41 @@ func TestStorageCommands(t *testing.T) {
 			}),
 			nil,
 		},
+		{
+			"Self-test all configured devices",
+			"storage self-test",
+			printRequest(t, &control.StorageSelfTestReq{
+				Workload:   "read",
+				IoSize:     131072,
+				QueueDepth: 32,
+				Duration:   10,
+			}),
+			nil,
+		},
+		{
+			"Self-test specific devices",
+			"storage self-test --pci-addrs 0000:81:00.0,0000:82:00.0 -w randwrite --io-size 4KiB --queue-depth 128 --duration 30 --force",
+			printRequest(t, &control.StorageSelfTestReq{
+				PciAddrs:   []string{"0000:81:00.0", "0000:82:00.0"},
+				Workload:   "randwrite",
+				IoSize:     4096,
+				QueueDepth: 128,
+				Duration:   30,
+				Force:      true,
+			}),
+			nil,
+		},
+		{
+			"Self-test with bad workload",
+			"storage self-test -w trim",
+			"",
+			errors.New("Invalid value `trim'"),
+		},
+		{
+			"Self-test with bad io size",
+			"storage self-test --io-size huge",
+			"",
+			errors.New("--io-size"),
+		},
 	})
 }
@@ -8,24 +8,42 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
 // SystemCmd is the struct representing the top-level system subcommand.
 type SystemCmd struct {
-	LeaderQuery leaderQueryCmd `command:"leader-query" alias:"l" description:"Query for current Management Service leader"`
-	Query       systemQueryCmd `command:"query" alias:"q" description:"Query DAOS system status"`
-	Stop        systemStopCmd  `command:"stop" alias:"s" description:"Perform controlled shutdown of DAOS system"`
-	Start       systemStartCmd `command:"start" alias:"r" description:"Perform start of stopped DAOS system"`
-	Erase       systemEraseCmd `command:"erase" alias:"e" description:"Erase system metadata prior to reformat"`
-	ListPools   PoolListCmd    `command:"list-pools" alias:"p" description:"List all pools in the DAOS system"`
+	LeaderQuery      leaderQueryCmd            `command:"leader-query" alias:"l" description:"Query for current Management Service leader"`
+	Query            systemQueryCmd            `command:"query" alias:"q" description:"Query DAOS system status"`
+	Wait             systemWaitCmd             `command:"wait" alias:"w" description:"Wait for the DAOS system to reach a condition"`
+	Extend           systemExtendCmd           `command:"extend" description:"Add new hosts to the DAOS system and optionally extend pools onto them"`
+	Stop             systemStopCmd             `command:"stop" alias:"s" description:"Perform controlled shutdown of DAOS system"`
+	Start            systemStartCmd            `command:"start" alias:"r" description:"Perform start of stopped DAOS system"`
+	Erase            systemEraseCmd            `command:"erase" alias:"e" description:"Erase system metadata prior to reformat"`
+	ListPools        PoolListCmd               `command:"list-pools" alias:"p" description:"List all pools in the DAOS system"`
+	Maintenance      systemMaintenanceCmd      `command:"maintenance" alias:"m" description:"Manage system maintenance windows"`
+	Exclude          systemExcludeCmd          `command:"exclude" alias:"x" description:"Administratively exclude ranks from the DAOS system"`
+	ClearExclude     systemClearExcludeCmd     `command:"clear-exclude" description:"Clear the administrative exclusion of ranks"`
+	SetFaultDomain   systemSetFaultDomainCmd   `command:"set-fault-domain" description:"Assign a fault domain to ranks, overriding the one reported by their servers"`
+	ClearFaultDomain systemClearFaultDomainCmd `command:"clear-fault-domain" description:"Clear the fault domain assignment of ranks"`
+	ActivateSpare    systemActivateSpareCmd    `command:"activate-spare" description:"Replace a failed rank with a spare engine on the same server"`
+	Lock             systemLockCmd             `command:"lock" alias:"k" description:"Manage administrative locks held in the Management Service"`
+	History          systemHistoryCmd          `command:"history" description:"Show the history of administrative operations"`
+	SetPoolDefaults  systemSetPoolDefaultsCmd  `command:"set-pool-defaults" description:"Set or unset system-level defaults for pool properties"`
+	GetPoolDefaults  systemGetPoolDefaultsCmd  `command:"get-pool-defaults" description:"Show system-level defaults for pool properties"`
 }
 
 type leaderQueryCmd struct {
@@ -95,8 +113,354 @@ type systemQueryCmd struct {
 	cfgCmd
 	ctlInvokerCmd
 	jsonOutputCmd
+	outputFormatCmd
 	rankListCmd
-	Verbose bool `long:"verbose" short:"v" description:"Display more member details"`
+	Verbose  bool          `long:"verbose" short:"v" description:"Display more member details"`
+	Versions bool          `long:"versions" description:"Display versions of DAOS and dependent software components on member hosts"`
+	Skew     bool          `long:"skew" description:"Highlight version differences between components on member hosts and known agents"`
+	FailSkew bool          `long:"fail-on-skew" description:"With --skew, return an error if DAOS versions span more than one major.minor release"`
+	Watch    bool          `long:"watch" short:"w" description:"Refresh member states at an interval, highlighting changes"`
+	Interval time.Duration `long:"interval" default:"2s" description:"Refresh interval in watch mode"`
+	Until    string        `long:"until" choice:"awaitformat" choice:"starting" choice:"ready" choice:"joined" choice:"stopping" choice:"stopped" choice:"evicted" choice:"excluded" choice:"errored" choice:"unresponsive" description:"Exit watch mode once all queried members reach the given state"`
+	Timeout  time.Duration `long:"timeout" description:"Exit watch mode with an error if the --until state is not reached in time"`
+	MS       bool          `long:"ms" description:"Display Management Service leader and replica health"`
+	Drift    bool          `long:"config-drift" description:"List hosts whose server config differs from that of most hosts, or from the --config-ref file"`
+	DriftRef string        `long:"config-ref" description:"With --config-drift, server config file to compare the configs of member hosts with"`
+}
+
+// queryMS retrieves and displays the health of the Management Service
+// replicas.
+func (cmd *systemQueryCmd) queryMS(ctx context.Context) error {
+	resp, err := control.MSHealthQuery(ctx, cmd.ctlInvoker, new(control.MSHealthQueryReq))
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintMSHealthQueryResponse(&bld, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
+
+// queryVersions retrieves and displays software component versions from the
+// hosts of the members returned in the given system query response.
+func (cmd *systemQueryCmd) queryVersions(ctx context.Context, sqr *control.SystemQueryResp) error {
+	hosts := make([]string, 0, len(sqr.Members))
+	for _, m := range sqr.Members {
+		hosts = append(hosts, m.Addr.String())
+	}
+	if len(hosts) == 0 {
+		return errors.New("no system members found to query versions from")
+	}
+
+	req := new(control.VersionQueryReq)
+	req.SetHostList(common.DedupeStringSlice(hosts))
+
+	resp, err := control.VersionQuery(ctx, cmd.ctlInvoker, req)
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(resp, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintHostVersionMap(resp.HostVersions, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return resp.Errors()
+}
+
+// querySkew retrieves software component versions from the hosts of the
+// members and the agents returned in the given system query response and
+// displays any differences between them.
+func (cmd *systemQueryCmd) querySkew(ctx context.Context, sqr *control.SystemQueryResp) error {
+	hosts := make([]string, 0, len(sqr.Members))
+	for _, m := range sqr.Members {
+		hosts = append(hosts, m.Addr.String())
+	}
+	if len(hosts) == 0 {
+		return errors.New("no system members found to query versions from")
+	}
+
+	req := new(control.VersionQueryReq)
+	req.SetHostList(common.DedupeStringSlice(hosts))
+
+	resp, err := control.VersionQuery(ctx, cmd.ctlInvoker, req)
+	if err != nil {
+		if cmd.jsonOutputEnabled() {
+			return cmd.outputJSON(nil, err)
+		}
+		return err
+	}
+
+	report, err := control.NewVersionSkewReport(resp.HostVersions, sqr.Agents)
+	if err != nil {
+		return err
+	}
+	report.HostErrorsResp = resp.HostErrorsResp
+
+	var skewErr error
+	if cmd.FailSkew && report.OutsideWindow() {
+		skewErr = errors.Errorf("DAOS versions span releases %s",
+			strings.Join(report.Releases, ", "))
+	}
+
+	if cmd.jsonOutputEnabled() {
+		if skewErr == nil {
+			skewErr = report.Errors()
+		}
+		return cmd.outputJSON(report, skewErr)
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintResponseErrors(report, &bld); err != nil {
+		return err
+	}
+	if err := pretty.PrintVersionSkewReport(report, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	if skewErr != nil {
+		return skewErr
+	}
+	return report.Errors()
+}
+
+// reportConfigDrift displays the hosts of the members returned in the given
+// system query response whose config deviates from the reference config file,
+// if supplied, or otherwise from the config held by most hosts.
+func (cmd *systemQueryCmd) reportConfigDrift(sqr *control.SystemQueryResp) error {
+	var refHash string
+	if cmd.DriftRef != "" {
+		var err error
+		refHash, err = config.HashFile(cmd.DriftRef)
+		if err != nil {
+			return errors.Wrapf(err, "unable to hash reference config %s", cmd.DriftRef)
+		}
+	}
+
+	report, err := control.NewConfigDriftReport(sqr.Members, refHash)
+	if err != nil {
+		return err
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(report, sqr.Errors())
+	}
+
+	var bld strings.Builder
+	if err := pretty.PrintConfigDriftReport(report, &bld); err != nil {
+		return err
+	}
+	cmd.log.Info(bld.String())
+
+	return sqr.Errors()
+}
+
+// memberStateChanges returns a description of each member whose state differs
+// between the previous and current system query responses.
+func memberStateChanges(prev, cur system.Members) []string {
+	prevStates := make(map[system.Rank]system.MemberState)
+	for _, m := range prev {
+		prevStates[m.Rank] = m.State()
+	}
+
+	var changes []string
+	for _, m := range cur {
+		prevState, found := prevStates[m.Rank]
+		switch {
+		case !found:
+			changes = append(changes, fmt.Sprintf("rank %d: %s (new)", m.Rank, m.State()))
+		case prevState != m.State():
+			changes = append(changes, fmt.Sprintf("rank %d: %s -> %s", m.Rank,
+				prevState, m.State()))
+		}
+	}
+
+	return changes
+}
+
+// membersNotInState returns the ranks of members not in the given state.
+func membersNotInState(members system.Members, state string) *system.RankSet {
+	notInState := system.MustCreateRankSet("")
+	for _, m := range members {
+		if !strings.EqualFold(m.State().String(), state) {
+			notInState.Add(m.Rank)
+		}
+	}
+
+	return notInState
+}
+
+func (cmd *systemQueryCmd) printQueryResponse(resp *control.SystemQueryResp) error {
+	var out, outErr strings.Builder
+	if err := pretty.PrintSystemQueryResponse(&out, &outErr, resp,
+		pretty.PrintWithVerboseOutput(cmd.Verbose)); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+	if outErr.String() != "" {
+		cmd.log.Error(outErr.String())
+	}
+
+	return nil
+}
+
+// watch repeatedly queries the system and displays member states along with
+// any state changes since the previous refresh. If a target state has been
+// requested, watch returns once all queried members have reached it or with
+// an error if the timeout expires first.
+//
+// Query failures are reported but do not end the watch as the management
+// service may be unavailable for periods during system bring-up.
+func (cmd *systemQueryCmd) watch(ctx context.Context, req *control.SystemQueryReq) error {
+	if cmd.Interval <= 0 {
+		return errors.New("--interval must be greater than zero")
+	}
+
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	var prev system.Members
+	var lastErr error
+	for {
+		cmd.log.Infof("Every %s: dmg system query\t%s\n", cmd.Interval,
+			time.Now().Format(time.RFC1123))
+
+		resp, err := control.SystemQuery(ctx, cmd.ctlInvoker, req)
+		if err == nil {
+			err = resp.Errors()
+		}
+		lastErr = err
+		if err != nil {
+			cmd.log.Errorf("query failed: %s\n", err)
+		}
+
+		if resp != nil && len(resp.Members) > 0 {
+			if err := cmd.printQueryResponse(resp); err != nil {
+				return err
+			}
+			if prev != nil {
+				if changes := memberStateChanges(prev, resp.Members); len(changes) > 0 {
+					cmd.log.Infof("Changes since last refresh:\n  %s\n",
+						strings.Join(changes, "\n  "))
+				}
+			}
+			prev = resp.Members
+
+			if cmd.Until != "" && lastErr == nil &&
+				membersNotInState(resp.Members, cmd.Until).Count() == 0 {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if cmd.Timeout == 0 {
+				return ctx.Err()
+			}
+			notInState := membersNotInState(prev, cmd.Until)
+			if notInState.Count() == 0 {
+				if lastErr != nil {
+					return errTimedOut(errors.Wrapf(lastErr, "timed out after %s",
+						cmd.Timeout))
+				}
+				return errTimedOut(errors.Errorf("timed out after %s with no members found",
+					cmd.Timeout))
+			}
+			return errTimedOut(errors.Errorf("timed out after %s waiting for %s %s to be %s",
+				cmd.Timeout, english.PluralWord(notInState.Count(), "rank", "ranks"),
+				notInState, cmd.Until))
+		case <-time.After(cmd.Interval):
+		}
+	}
+}
+
+func (cmd *systemQueryCmd) checkWatchOpts() error {
+	if !cmd.Watch {
+		if cmd.Until != "" || cmd.Timeout != 0 {
+			return errors.New("--until and --timeout require --watch")
+		}
+		return nil
+	}
+
+	switch {
+	case cmd.Versions:
+		return errors.New("cannot use --watch with --versions")
+	case cmd.Skew:
+		return errors.New("cannot use --watch with --skew")
+	case cmd.Drift:
+		return errors.New("cannot use --watch with --config-drift")
+	case cmd.jsonOutputEnabled():
+		return errors.New("cannot use --watch with --json")
+	case cmd.csvOutputEnabled():
+		return errors.New("cannot use --watch with --format csv")
+	case cmd.Timeout != 0 && cmd.Until == "":
+		return errors.New("--timeout requires --until")
+	}
+
+	return nil
+}
+
+func (cmd *systemQueryCmd) checkSkewOpts() error {
+	if !cmd.Skew {
+		if cmd.FailSkew {
+			return errors.New("--fail-on-skew requires --skew")
+		}
+		return nil
+	}
+
+	switch {
+	case cmd.Versions:
+		return errors.New("cannot use --skew with --versions")
+	case cmd.csvOutputEnabled():
+		return errors.New("cannot use --skew with --format csv")
+	}
+
+	return nil
+}
+
+func (cmd *systemQueryCmd) checkDriftOpts() error {
+	if !cmd.Drift {
+		if cmd.DriftRef != "" {
+			return errors.New("--config-ref requires --config-drift")
+		}
+		return nil
+	}
+
+	switch {
+	case cmd.Versions:
+		return errors.New("cannot use --config-drift with --versions")
+	case cmd.Skew:
+		return errors.New("cannot use --config-drift with --skew")
+	case cmd.csvOutputEnabled():
+		return errors.New("cannot use --config-drift with --format csv")
+	}
+
+	return nil
 }
 
 // Execute is run when systemQueryCmd activates.
@@ -105,47 +469,253 @@ func (cmd *systemQueryCmd) Execute(_ []string) (errOut error) {
 		errOut = errors.Wrap(errOut, "system query failed")
 	}()
 
+	if cmd.Versions && cmd.csvOutputEnabled() {
+		return errors.New("cannot use --versions with --format csv")
+	}
+	if err := cmd.checkSkewOpts(); err != nil {
+		return err
+	}
+	if err := cmd.checkDriftOpts(); err != nil {
+		return err
+	}
+	if err := cmd.checkWatchOpts(); err != nil {
+		return err
+	}
+
 	hostSet, rankSet, err := cmd.validateHostsRanks()
 	if err != nil {
 		return err
 	}
+
+	ctx := context.Background()
+	if cmd.MS {
+		switch {
+		case cmd.Versions, cmd.Skew, cmd.Drift, cmd.Watch, cmd.csvOutputEnabled():
+			return errors.New("--ms cannot be used with --versions, --skew, --config-drift, --watch or --format csv")
+		case hostSet.Count() > 0 || rankSet.Count() > 0:
+			return errors.New("--ms cannot be used with --ranks or --rank-hosts")
+		}
+		return cmd.queryMS(ctx)
+	}
+
 	req := new(control.SystemQueryReq)
 	req.Hosts.ReplaceSet(hostSet)
 	req.Ranks.ReplaceSet(rankSet)
+	req.Agents = cmd.Skew
+
+	if cmd.Watch {
+		return cmd.watch(ctx, req)
+	}
 
-	resp, err := control.SystemQuery(context.Background(), cmd.ctlInvoker, req)
+	resp, err := control.SystemQuery(ctx, cmd.ctlInvoker, req)
 	if err != nil {
 		return err // control api returned an error, disregard response
 	}
 
+	if cmd.Versions {
+		return cmd.queryVersions(ctx, resp)
+	}
+	if cmd.Skew {
+		return cmd.querySkew(ctx, resp)
+	}
+	if cmd.Drift {
+		return cmd.reportConfigDrift(resp)
+	}
+
 	if cmd.jsonOutputEnabled() {
 		return cmd.outputJSON(resp, resp.Errors())
 	}
 
-	var out, outErr strings.Builder
-	if err := pretty.PrintSystemQueryResponse(&out, &outErr, resp,
-		pretty.PrintWithVerboseOutput(cmd.Verbose)); err != nil {
+	if cmd.csvOutputEnabled() {
+		var out strings.Builder
+		if err := pretty.PrintSystemQueryCSV(&out, resp); err != nil {
+			return err
+		}
+		cmd.log.Info(out.String())
+
+		return resp.Errors()
+	}
+
+	if err := cmd.printQueryResponse(resp); err != nil {
+		return err
+	}
+
+	return resp.Errors()
+}
+
+// systemWaitCmd is the struct representing the command to wait for the system
+// to reach a condition.
+type systemWaitCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	rankListCmd
+	Condition string        `long:"for" short:"f" required:"1" choice:"joined" choice:"stopped" choice:"rebuild-idle" description:"Condition to wait for: all ranks joined, all ranks stopped or no pool rebuilding"`
+	Interval  time.Duration `long:"interval" default:"2s" description:"Time between checks of the condition"`
+	Timeout   time.Duration `long:"timeout" description:"Fail if the condition is not met within this time, by default wait indefinitely"`
+}
+
+// Execute is run when systemWaitCmd activates.
+func (cmd *systemWaitCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system wait failed")
+	}()
+
+	hostSet, rankSet, err := cmd.validateHostsRanks()
+	if err != nil {
+		return err
+	}
+	req := &control.SystemWaitReq{
+		Condition: control.SystemWaitCondition(cmd.Condition),
+		Interval:  cmd.Interval,
+		Timeout:   cmd.Timeout,
+	}
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemWait(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	var waitErr error
+	if !resp.Met {
+		waitErr = errTimedOut(errors.Errorf("timed out after %s waiting for %s",
+			cmd.Timeout, resp.Condition))
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, waitErr)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemWaitResponse(&out, resp); err != nil {
 		return err
 	}
+	if waitErr != nil {
+		cmd.log.Error(out.String())
+		return waitErr
+	}
 	cmd.log.Info(out.String())
-	if outErr.String() != "" {
-		cmd.log.Error(outErr.String())
+
+	return nil
+}
+
+type systemExtendCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	Hosts    string        `long:"hosts" required:"1" description:"Hostlist of the new hosts being added to the system"`
+	Pools    string        `long:"pools" description:"Comma-separated list of pool labels or UUIDs to extend onto the ranks of the new hosts"`
+	Interval time.Duration `long:"interval" default:"2s" description:"Time between checks for the new hosts joining"`
+	Timeout  time.Duration `long:"timeout" description:"Fail if the new hosts have not joined within this time, by default wait indefinitely"`
+}
+
+// Execute is run when systemExtendCmd activates.
+//
+// Validate that the new hosts are compatible with the existing system, wait
+// for their engines to join and then extend the selected pools onto the new
+// ranks.
+func (cmd *systemExtendCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system extend failed")
+	}()
+
+	newHosts, err := hostlist.CreateSet(cmd.Hosts)
+	if err != nil {
+		return err
+	}
+	req := &control.SystemExtendReq{
+		NewHosts: newHosts,
+		Interval: cmd.Interval,
+		Timeout:  cmd.Timeout,
+	}
+	if cmd.Pools != "" {
+		req.Pools = strings.Split(cmd.Pools, ",")
 	}
 
-	return resp.Errors()
+	resp, err := control.SystemExtend(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	extendErr := resp.Errors()
+	if !resp.Joined {
+		extendErr = errTimedOut(errors.Errorf("timed out after %s waiting for new hosts to join",
+			cmd.Timeout))
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, extendErr)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemExtendResponse(&out, resp); err != nil {
+		return err
+	}
+	if extendErr != nil {
+		cmd.log.Error(out.String())
+		return extendErr
+	}
+	cmd.log.Info(out.String())
+
+	return nil
 }
 
 type systemEraseCmd struct {
 	logCmd
+	cfgCmd
 	ctlInvokerCmd
+	jsonOutputCmd
+	NvmeTrim bool   `long:"nvme-trim" description:"Also deallocate (TRIM) the NVMe SSDs of erased ranks"`
+	Confirm  string `long:"confirm" description:"Confirm the erase with the system name rather than being prompted for it"`
+}
+
+// confirmErase requires the name of the system to be erased to be given,
+// either with --confirm or when prompted.
+func (cmd *systemEraseCmd) confirmErase() error {
+	sysName := cmd.config.SystemName
+	if cmd.Confirm != "" {
+		if cmd.Confirm != sysName {
+			return errors.Errorf("--confirm %q does not match system name %q", cmd.Confirm, sysName)
+		}
+		return nil
+	}
+	if cmd.jsonOutputEnabled() {
+		return errors.New("--confirm is required with --json")
+	}
+
+	cmd.log.Infof("WARNING: This command will erase the metadata of all ranks in system %q!\n", sysName)
+	return promptConfirm(cmd.log, sysName)
 }
 
-func (cmd *systemEraseCmd) Execute(_ []string) error {
-	resp, err := control.SystemErase(context.Background(), cmd.ctlInvoker, new(control.SystemEraseReq))
+func (cmd *systemEraseCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system erase failed")
+	}()
+
+	if err := cmd.confirmErase(); err != nil {
+		return err
+	}
+
+	req := &control.SystemEraseReq{NvmeTrim: cmd.NvmeTrim}
+	resp, err := control.SystemErase(context.Background(), cmd.ctlInvoker, req)
 	if err != nil {
 		return err
 	}
 
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemEraseResponse(&out, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
 	return resp.Errors()
 }
 
@@ -239,3 +809,560 @@ func (cmd *systemStartCmd) Execute(_ []string) (errOut error) {
 
 	return resp.Errors()
 }
+
+// systemExcludeCmd is the struct representing the command to exclude ranks
+// from the system.
+type systemExcludeCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	rankListCmd
+}
+
+func (cmd *systemExcludeCmd) execute(clear bool) error {
+	hostSet, rankSet, err := cmd.validateHostsRanks()
+	if err != nil {
+		return err
+	}
+	if hostSet.Count() == 0 && rankSet.Count() == 0 {
+		return errors.New("--ranks or --rank-hosts option must be set")
+	}
+	req := &control.SystemExcludeReq{Clear: clear}
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemExclude(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSystemExcludeResponse(&out, &outErr, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+	if outErr.String() != "" {
+		cmd.log.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
+
+// Execute is run when systemExcludeCmd activates.
+func (cmd *systemExcludeCmd) Execute(_ []string) error {
+	return errors.Wrap(cmd.execute(false), "system exclude failed")
+}
+
+// systemClearExcludeCmd is the struct representing the command to clear the
+// exclusion of ranks so that they may rejoin the system.
+type systemClearExcludeCmd struct {
+	systemExcludeCmd
+}
+
+// Execute is run when systemClearExcludeCmd activates.
+func (cmd *systemClearExcludeCmd) Execute(_ []string) error {
+	return errors.Wrap(cmd.execute(true), "system clear-exclude failed")
+}
+
+// systemFaultDomainCmd contains the common parameters of the commands that
+// manage fault domain assignments.
+type systemFaultDomainCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	rankListCmd
+}
+
+func (cmd *systemFaultDomainCmd) execute(req *control.SystemSetFaultDomainReq) error {
+	hostSet, rankSet, err := cmd.validateHostsRanks()
+	if err != nil {
+		return err
+	}
+	if hostSet.Count() == 0 && rankSet.Count() == 0 {
+		return errors.New("--ranks or --rank-hosts option must be set")
+	}
+	req.Hosts.ReplaceSet(hostSet)
+	req.Ranks.ReplaceSet(rankSet)
+
+	resp, err := control.SystemSetFaultDomain(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSystemSetFaultDomainResponse(&out, &outErr, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+	if outErr.String() != "" {
+		cmd.log.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
+
+// systemSetFaultDomainCmd is the struct representing the command to assign a
+// fault domain to ranks.
+type systemSetFaultDomainCmd struct {
+	systemFaultDomainCmd
+	Args struct {
+		Domain string `positional-arg-name:"domain" description:"Fault domain path (e.g. /rack0/host1)"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// Execute is run when systemSetFaultDomainCmd activates.
+func (cmd *systemSetFaultDomainCmd) Execute(_ []string) error {
+	req := &control.SystemSetFaultDomainReq{Domain: cmd.Args.Domain}
+	return errors.Wrap(cmd.execute(req), "system set-fault-domain failed")
+}
+
+// systemClearFaultDomainCmd is the struct representing the command to clear
+// the fault domain assignment of ranks so that the fault domain reported by
+// their servers is used when they next join.
+type systemClearFaultDomainCmd struct {
+	systemFaultDomainCmd
+}
+
+// Execute is run when systemClearFaultDomainCmd activates.
+func (cmd *systemClearFaultDomainCmd) Execute(_ []string) error {
+	req := &control.SystemSetFaultDomainReq{Clear: true}
+	return errors.Wrap(cmd.execute(req), "system clear-fault-domain failed")
+}
+
+// systemActivateSpareCmd is the struct representing the command to replace a
+// stopped or failed rank with a spare engine on the same server.
+type systemActivateSpareCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	Rank uint32 `long:"rank" required:"1" description:"Rank to be replaced by a spare engine"`
+}
+
+// Execute is run when systemActivateSpareCmd activates.
+func (cmd *systemActivateSpareCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system activate-spare failed")
+	}()
+
+	req := &control.SystemActivateSpareReq{Rank: system.Rank(cmd.Rank)}
+	resp, err := control.SystemActivateSpare(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemActivateSpareResponse(&out, req.Rank, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// systemMaintenanceCmd is the struct representing the system maintenance
+// subcommands.
+type systemMaintenanceCmd struct {
+	Start systemMaintenanceStartCmd `command:"start" alias:"s" description:"Start a maintenance window on a set of hosts"`
+	End   systemMaintenanceEndCmd   `command:"end" alias:"e" description:"End active maintenance windows"`
+	List  systemMaintenanceListCmd  `command:"list" alias:"l" description:"List active maintenance windows"`
+}
+
+// maintenanceBaseCmd contains the options common to all system maintenance
+// subcommands.
+type maintenanceBaseCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+}
+
+func (cmd *maintenanceBaseCmd) invoke(req *control.SystemMaintenanceReq, hosts string) error {
+	hostSet, err := hostlist.CreateSet(hosts)
+	if err != nil {
+		return err
+	}
+	req.Hosts.ReplaceSet(hostSet)
+
+	resp, err := control.SystemMaintenance(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, resp.Errors())
+	}
+
+	var out, outErr strings.Builder
+	if err := pretty.PrintSystemMaintenanceResponse(&out, &outErr, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+	if outErr.String() != "" {
+		cmd.log.Error(outErr.String())
+	}
+
+	return resp.Errors()
+}
+
+// systemMaintenanceStartCmd is the struct representing the command to start
+// a maintenance window.
+type systemMaintenanceStartCmd struct {
+	maintenanceBaseCmd
+	Hosts    string        `long:"hosts" required:"1" description:"Hostlist representing hosts to be placed under maintenance"`
+	Duration time.Duration `long:"duration" short:"d" default:"1h" description:"Length of the maintenance window (e.g. 30m, 2h)"`
+	Reason   string        `long:"reason" description:"Reason for the maintenance, recorded with the window"`
+}
+
+// Execute is run when systemMaintenanceStartCmd activates.
+func (cmd *systemMaintenanceStartCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system maintenance start failed")
+	}()
+
+	if cmd.Duration < time.Second {
+		return errors.New("maintenance duration must be at least 1s")
+	}
+
+	return cmd.invoke(&control.SystemMaintenanceReq{
+		Action:   control.MaintenanceStart,
+		Duration: cmd.Duration,
+		Reason:   cmd.Reason,
+	}, cmd.Hosts)
+}
+
+// systemMaintenanceEndCmd is the struct representing the command to end
+// active maintenance windows.
+type systemMaintenanceEndCmd struct {
+	maintenanceBaseCmd
+	Hosts string `long:"hosts" description:"Hostlist representing hosts whose maintenance windows are to be ended (default all)"`
+}
+
+// Execute is run when systemMaintenanceEndCmd activates.
+func (cmd *systemMaintenanceEndCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system maintenance end failed")
+	}()
+
+	return cmd.invoke(&control.SystemMaintenanceReq{
+		Action: control.MaintenanceEnd,
+	}, cmd.Hosts)
+}
+
+// systemMaintenanceListCmd is the struct representing the command to list
+// active maintenance windows.
+type systemMaintenanceListCmd struct {
+	maintenanceBaseCmd
+	Hosts string `long:"hosts" description:"Hostlist representing hosts to filter windows by"`
+}
+
+// Execute is run when systemMaintenanceListCmd activates.
+func (cmd *systemMaintenanceListCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system maintenance list failed")
+	}()
+
+	return cmd.invoke(&control.SystemMaintenanceReq{
+		Action: control.MaintenanceList,
+	}, cmd.Hosts)
+}
+
+// systemLockCmd is the struct representing the system lock subcommands.
+type systemLockCmd struct {
+	Acquire systemLockAcquireCmd `command:"acquire" alias:"a" description:"Acquire named administrative locks"`
+	Release systemLockReleaseCmd `command:"release" alias:"r" description:"Release named administrative locks"`
+	List    systemLockListCmd    `command:"list" alias:"l" description:"List held administrative locks"`
+}
+
+// lockBaseCmd contains the options common to all system lock subcommands.
+type lockBaseCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+}
+
+func (cmd *lockBaseCmd) invoke(req *control.SystemLockReq) error {
+	resp, err := control.SystemLock(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemLockResponse(&out, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// lockNamesArgs contains the names of the locks operated on.
+type lockNamesArgs struct {
+	Names []string `positional-arg-name:"name" required:"1"`
+}
+
+// systemLockAcquireCmd is the struct representing the command to acquire
+// administrative locks.
+type systemLockAcquireCmd struct {
+	lockBaseCmd
+	Owner  string        `long:"owner" description:"Identity of the lock holder (default user@host)"`
+	TTL    time.Duration `long:"ttl" default:"1h" description:"Time after which the locks expire if not released (e.g. 30m, 2h)"`
+	Reason string        `long:"reason" description:"Reason for holding the locks, recorded with them"`
+	Args   lockNamesArgs `positional-args:"yes" required:"yes"`
+}
+
+// Execute is run when systemLockAcquireCmd activates.
+func (cmd *systemLockAcquireCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system lock acquire failed")
+	}()
+
+	if cmd.TTL < time.Second {
+		return errors.New("lock ttl must be at least 1s")
+	}
+
+	return cmd.invoke(&control.SystemLockReq{
+		Action: control.LockAcquire,
+		Names:  cmd.Args.Names,
+		Owner:  cmd.Owner,
+		TTL:    cmd.TTL,
+		Reason: cmd.Reason,
+	})
+}
+
+// systemLockReleaseCmd is the struct representing the command to release
+// administrative locks.
+type systemLockReleaseCmd struct {
+	lockBaseCmd
+	Owner string        `long:"owner" description:"Identity of the lock holder (default user@host)"`
+	Force bool          `long:"force" short:"f" description:"Release the locks even if held by another owner"`
+	Args  lockNamesArgs `positional-args:"yes" required:"yes"`
+}
+
+// Execute is run when systemLockReleaseCmd activates.
+func (cmd *systemLockReleaseCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system lock release failed")
+	}()
+
+	return cmd.invoke(&control.SystemLockReq{
+		Action: control.LockRelease,
+		Names:  cmd.Args.Names,
+		Owner:  cmd.Owner,
+		Force:  cmd.Force,
+	})
+}
+
+// systemLockListCmd is the struct representing the command to list held
+// administrative locks.
+type systemLockListCmd struct {
+	lockBaseCmd
+	Args struct {
+		Names []string `positional-arg-name:"name"`
+	} `positional-args:"yes"`
+}
+
+// Execute is run when systemLockListCmd activates.
+func (cmd *systemLockListCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system lock list failed")
+	}()
+
+	return cmd.invoke(&control.SystemLockReq{
+		Action: control.LockList,
+		Names:  cmd.Args.Names,
+	})
+}
+
+// systemHistoryCmd is the struct representing the command to query the
+// history of administrative operations.
+type systemHistoryCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+	Operation string `long:"operation" short:"o" description:"Only show operations of this type (e.g. pool-create, storage-format)"`
+	Initiator string `long:"initiator" short:"i" description:"Only show operations requested by this initiator"`
+	Since     string `long:"since" short:"s" description:"Only show operations after this time, given as a duration before now (e.g. 24h) or an RFC3339 time"`
+	Failed    bool   `long:"failed" short:"f" description:"Only show operations that failed"`
+	Limit     int    `long:"limit" short:"n" description:"Only show this many of the most recent operations"`
+	Verbose   bool   `long:"verbose" short:"v" description:"Show client addresses and operation parameters"`
+	Export    string `long:"export" short:"e" choice:"cef" choice:"rfc5424" description:"Print one CEF or RFC5424 syslog record per operation for ingestion by a SIEM"`
+}
+
+// parseSince converts a duration before now or an RFC3339 time string into
+// a time.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.New("since duration must not be negative")
+		}
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid since %q: expected a duration or RFC3339 time", since)
+	}
+	return t, nil
+}
+
+// Execute is run when systemHistoryCmd activates.
+func (cmd *systemHistoryCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system history failed")
+	}()
+
+	if cmd.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+
+	req := &control.SystemHistoryReq{
+		Operation:  cmd.Operation,
+		Initiator:  cmd.Initiator,
+		FailedOnly: cmd.Failed,
+		Limit:      cmd.Limit,
+	}
+	if cmd.Since != "" {
+		since, err := parseSince(cmd.Since, time.Now())
+		if err != nil {
+			return err
+		}
+		req.Since = since
+	}
+
+	resp, err := control.SystemHistory(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if cmd.Export != "" {
+		for _, rec := range resp.Operations {
+			if cmd.Export == string(events.EventFormatCEF) {
+				fmt.Fprintln(&out, rec.CEF())
+				continue
+			}
+			fmt.Fprintln(&out, rec.RFC5424())
+		}
+		cmd.log.Info(out.String())
+
+		return nil
+	}
+
+	if err := pretty.PrintSystemHistoryResponse(&out, resp, cmd.Verbose); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// poolDefaultsBaseCmd contains the options common to the pool defaults
+// subcommands.
+type poolDefaultsBaseCmd struct {
+	logCmd
+	cfgCmd
+	ctlInvokerCmd
+	jsonOutputCmd
+}
+
+func (cmd *poolDefaultsBaseCmd) invoke(req *control.SystemPoolDefaultsReq) error {
+	resp, err := control.SystemPoolDefaults(context.Background(), cmd.ctlInvoker, req)
+	if err != nil {
+		return err // control api returned an error, disregard response
+	}
+
+	if cmd.jsonOutputEnabled() {
+		return cmd.outputJSON(resp, nil)
+	}
+
+	var out strings.Builder
+	if err := pretty.PrintSystemPoolDefaultsResponse(&out, resp); err != nil {
+		return err
+	}
+	cmd.log.Info(out.String())
+
+	return nil
+}
+
+// systemSetPoolDefaultsCmd is the struct representing the command to set or
+// unset system-level pool property defaults.
+type systemSetPoolDefaultsCmd struct {
+	poolDefaultsBaseCmd
+	paramsCmd
+	Properties string `short:"P" long:"properties" description:"Pool property defaults to set (e.g. reclaim:lazy,self_heal:exclude)"`
+	Enforce    bool   `long:"enforce" description:"Prevent the defaults being overridden when a pool is created"`
+	Unset      string `long:"unset" description:"Comma-separated names of pool property defaults to remove"`
+}
+
+// Execute is run when systemSetPoolDefaultsCmd activates.
+func (cmd *systemSetPoolDefaultsCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system set-pool-defaults failed")
+	}()
+
+	props, err := parsePoolProperties(cmd.Properties)
+	if err != nil {
+		return err
+	}
+	var unset []string
+	if cmd.Unset != "" {
+		unset = strings.Split(cmd.Unset, ",")
+	}
+	if len(props) == 0 && len(unset) == 0 {
+		return errors.New("either --properties or --unset must be supplied")
+	}
+	if cmd.Enforce && len(props) == 0 {
+		return errors.New("--enforce requires --properties")
+	}
+
+	req := &control.SystemPoolDefaultsReq{Unset: unset}
+	for _, prop := range props {
+		req.Set = append(req.Set, &control.PoolPropDefault{
+			Name:     prop.Name,
+			Value:    prop.Value,
+			Enforced: cmd.Enforce,
+		})
+	}
+
+	return cmd.invoke(req)
+}
+
+// systemGetPoolDefaultsCmd is the struct representing the command to show the
+// system-level pool property defaults.
+type systemGetPoolDefaultsCmd struct {
+	poolDefaultsBaseCmd
+}
+
+// Execute is run when systemGetPoolDefaultsCmd activates.
+func (cmd *systemGetPoolDefaultsCmd) Execute(_ []string) (errOut error) {
+	defer func() {
+		errOut = errors.Wrap(errOut, "system get-pool-defaults failed")
+	}()
+
+	return cmd.invoke(new(control.SystemPoolDefaultsReq))
+}
@@ -8,9 +8,12 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -31,11 +34,25 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system query CSV",
+			"system query --format csv",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system query CSV with versions",
+			"system query --format csv --versions",
+			"",
+			errors.New("cannot use --versions with --format csv"),
+		},
 		{
 			"system query with single rank",
 			"system query --ranks 0",
 			strings.Join([]string{
-				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"0","Hosts":"","FailOnUnavailable":false}`,
+				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"0","Hosts":"","FailOnUnavailable":false,"Agents":false}`,
 			}, " "),
 			nil,
 		},
@@ -43,7 +60,7 @@ func TestDmg_SystemCommands(t *testing.T) {
 			"system query with multiple ranks",
 			"system query --ranks 0,2,4-8",
 			strings.Join([]string{
-				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"[0,2,4-8]","Hosts":"","FailOnUnavailable":false}`,
+				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"[0,2,4-8]","Hosts":"","FailOnUnavailable":false,"Agents":false}`,
 			}, " "),
 			nil,
 		},
@@ -57,7 +74,7 @@ func TestDmg_SystemCommands(t *testing.T) {
 			"system query with single host",
 			"system query --rank-hosts foo-0",
 			strings.Join([]string{
-				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"foo-0","FailOnUnavailable":false}`,
+				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"foo-0","FailOnUnavailable":false,"Agents":false}`,
 			}, " "),
 			nil,
 		},
@@ -65,7 +82,7 @@ func TestDmg_SystemCommands(t *testing.T) {
 			"system query with multiple hosts",
 			"system query --rank-hosts bar9,foo-[0-100]",
 			strings.Join([]string{
-				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"bar9,foo-[0-100]","FailOnUnavailable":false}`,
+				`*control.SystemQueryReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"bar9,foo-[0-100]","FailOnUnavailable":false,"Agents":false}`,
 			}, " "),
 			nil,
 		},
@@ -89,6 +106,188 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system query versions",
+			"system query --versions",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+				`*control.VersionQueryReq-{"Sys":"","HostList":["127.0.0.1:10001"]}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system query skew",
+			"system query --skew --fail-on-skew",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{Agents: true}),
+				`*control.VersionQueryReq-{"Sys":"","HostList":["127.0.0.1:10001"]}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system query fail on skew without skew",
+			"system query --fail-on-skew",
+			"",
+			errors.New("--fail-on-skew requires --skew"),
+		},
+		{
+			"system query skew with versions",
+			"system query --skew --versions",
+			"",
+			errors.New("cannot use --skew with --versions"),
+		},
+		{
+			"system query watch with skew",
+			"system query --watch --skew",
+			"",
+			errors.New("cannot use --watch with --skew"),
+		},
+		{
+			"system query config drift",
+			"system query --config-drift",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system query config drift with missing reference",
+			"system query --config-drift --config-ref /nonexistent/daos_server.yml",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			errors.New("unable to hash reference config"),
+		},
+		{
+			"system query config reference without config drift",
+			"system query --config-ref daos_server.yml",
+			"",
+			errors.New("--config-ref requires --config-drift"),
+		},
+		{
+			"system query config drift with skew",
+			"system query --config-drift --skew",
+			"",
+			errors.New("cannot use --config-drift with --skew"),
+		},
+		{
+			"system query watch with config drift",
+			"system query --watch --config-drift",
+			"",
+			errors.New("cannot use --watch with --config-drift"),
+		},
+		{
+			"system query watch until joined",
+			"system query --watch --until joined --timeout 1m",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system query watch with bad until state",
+			"system query --watch --until running",
+			"",
+			errors.New("Invalid value `running'"),
+		},
+		{
+			"system query until without watch",
+			"system query --until joined",
+			"",
+			errors.New("--until and --timeout require --watch"),
+		},
+		{
+			"system query watch timeout without until",
+			"system query --watch --timeout 1m",
+			"",
+			errors.New("--timeout requires --until"),
+		},
+		{
+			"system query watch with versions",
+			"system query --watch --versions",
+			"",
+			errors.New("cannot use --watch with --versions"),
+		},
+		{
+			"system query watch CSV",
+			"system query --watch --format csv",
+			"",
+			errors.New("cannot use --watch with --format csv"),
+		},
+		{
+			"system query watch with zero interval",
+			"system query --watch --interval 0s",
+			"",
+			errors.New("--interval must be greater than zero"),
+		},
+		{
+			"system query ms",
+			"system query --ms",
+			"",
+			errors.New("no MS replicas found"),
+		},
+		{
+			"system query ms with versions",
+			"system query --ms --versions",
+			"",
+			errors.New("--ms cannot be used with --versions, --skew, --config-drift, --watch or --format csv"),
+		},
+		{
+			"system query ms with ranks",
+			"system query --ms --ranks 0",
+			"",
+			errors.New("--ms cannot be used with --ranks or --rank-hosts"),
+		},
+		{
+			"system wait for joined with MS unavailable",
+			"system wait --for joined --ranks 0 --interval 1ms --timeout 10ms",
+			"",
+			errors.New("timed out after 10ms waiting for joined"),
+		},
+		{
+			"system wait for rebuild idle",
+			"system wait --for rebuild-idle --timeout 1m",
+			strings.Join([]string{
+				printRequest(t, &control.ListPoolsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system wait without condition",
+			"system wait",
+			"",
+			errors.New("the required flag `-f, --for' was not specified"),
+		},
+		{
+			"system wait with bad condition",
+			"system wait --for running",
+			"",
+			errors.New("Invalid value `running'"),
+		},
+		{
+			"system wait for rebuild idle with ranks",
+			"system wait --for rebuild-idle --ranks 0",
+			"",
+			errors.New("ranks or hosts cannot be selected when waiting for rebuild-idle"),
+		},
+		{
+			"system wait with both hosts and ranks specified",
+			"system wait --for stopped --rank-hosts foo-0 --ranks 0",
+			"",
+			errors.New("--ranks and --rank-hosts options cannot be set together"),
+		},
+		{
+			"system extend without hosts",
+			"system extend",
+			"",
+			errors.New("the required flag `--hosts' was not specified"),
+		},
+		{
+			"system extend with bad hostlist",
+			"system extend --hosts node[5-",
+			"",
+			errors.New("invalid range"),
+		},
 		{
 			"system stop with no arguments",
 			"system stop",
@@ -141,6 +340,128 @@ func TestDmg_SystemCommands(t *testing.T) {
 			"",
 			errors.New("--ranks and --rank-hosts options cannot be set together"),
 		},
+		{
+			"system exclude with no arguments",
+			"system exclude",
+			"",
+			errors.New("--ranks or --rank-hosts option must be set"),
+		},
+		{
+			"system exclude with multiple ranks",
+			"system exclude --ranks 0,1,4",
+			strings.Join([]string{
+				`*control.SystemExcludeReq-{"Sys":"","HostList":null,"Ranks":"[0-1,4]","Hosts":"","Clear":false}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system exclude with multiple hosts",
+			"system exclude --rank-hosts bar9,foo-[0-100]",
+			strings.Join([]string{
+				`*control.SystemExcludeReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"bar9,foo-[0-100]","Clear":false}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system exclude with both hosts and ranks specified",
+			"system exclude --rank-hosts bar9 --ranks 0",
+			"",
+			errors.New("--ranks and --rank-hosts options cannot be set together"),
+		},
+		{
+			"system clear-exclude with single rank",
+			"system clear-exclude --ranks 2",
+			strings.Join([]string{
+				`*control.SystemExcludeReq-{"Sys":"","HostList":null,"Ranks":"2","Hosts":"","Clear":true}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system clear-exclude with no arguments",
+			"system clear-exclude",
+			"",
+			errors.New("--ranks or --rank-hosts option must be set"),
+		},
+		{
+			"system set-fault-domain with no domain",
+			"system set-fault-domain --ranks 0",
+			"",
+			errors.New("the required argument `domain` was not provided"),
+		},
+		{
+			"system set-fault-domain with no ranks",
+			"system set-fault-domain /rack0/host1",
+			"",
+			errors.New("--ranks or --rank-hosts option must be set"),
+		},
+		{
+			"system set-fault-domain with multiple ranks",
+			"system set-fault-domain --ranks 0,1,4 /rack0/host1",
+			strings.Join([]string{
+				`*control.SystemSetFaultDomainReq-{"Sys":"","HostList":null,"Ranks":"[0-1,4]","Hosts":"","Domain":"/rack0/host1","Clear":false}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system set-fault-domain with multiple hosts",
+			"system set-fault-domain --rank-hosts bar9,foo-[0-100] /rack1/host2",
+			strings.Join([]string{
+				`*control.SystemSetFaultDomainReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"bar9,foo-[0-100]","Domain":"/rack1/host2","Clear":false}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system clear-fault-domain with single rank",
+			"system clear-fault-domain --ranks 2",
+			strings.Join([]string{
+				`*control.SystemSetFaultDomainReq-{"Sys":"","HostList":null,"Ranks":"2","Hosts":"","Domain":"","Clear":true}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system activate-spare with no rank",
+			"system activate-spare",
+			"",
+			errors.New("the required flag `--rank' was not specified"),
+		},
+		{
+			"system activate-spare",
+			"system activate-spare --rank 3",
+			strings.Join([]string{
+				printRequest(t, &control.SystemActivateSpareReq{Rank: 3}),
+			}, " "),
+			nil,
+		},
+		{
+			"system erase confirmed",
+			"system erase --confirm daos_server",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{FailOnUnavailable: true}),
+				printRequest(t, &control.SystemEraseReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system erase with nvme trim",
+			"system erase --confirm daos_server --nvme-trim",
+			strings.Join([]string{
+				printRequest(t, &control.SystemQueryReq{FailOnUnavailable: true}),
+				printRequest(t, &control.SystemEraseReq{NvmeTrim: true}),
+			}, " "),
+			nil,
+		},
+		{
+			"system erase with wrong system name",
+			"system erase --confirm other",
+			"",
+			errors.New(`--confirm "other" does not match system name "daos_server"`),
+		},
+		{
+			"system erase with json output and no confirm",
+			"-j system erase",
+			"",
+			errors.New("--confirm is required with --json"),
+		},
 		{
 			"system start with no arguments",
 			"system start",
@@ -201,6 +522,223 @@ func TestDmg_SystemCommands(t *testing.T) {
 			}, " "),
 			nil,
 		},
+		{
+			"system maintenance start with defaults",
+			"system maintenance start --hosts foo-[1-2]",
+			strings.Join([]string{
+				`*control.SystemMaintenanceReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"foo-[1-2]","Action":1,"Duration":3600000000000,"Reason":""}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system maintenance start with duration and reason",
+			"system maintenance start --hosts foo-1 --duration 30m --reason firmware",
+			strings.Join([]string{
+				`*control.SystemMaintenanceReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"foo-1","Action":1,"Duration":1800000000000,"Reason":"firmware"}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system maintenance start without hosts",
+			"system maintenance start",
+			"",
+			errors.New("the required flag `--hosts' was not specified"),
+		},
+		{
+			"system maintenance start with short duration",
+			"system maintenance start --hosts foo-1 --duration 10ms",
+			"",
+			errors.New("at least 1s"),
+		},
+		{
+			"system maintenance start with bad hostlist",
+			"system maintenance start --hosts foo-[1",
+			"",
+			errors.New("invalid range"),
+		},
+		{
+			"system maintenance end",
+			"system maintenance end --hosts foo-1",
+			strings.Join([]string{
+				`*control.SystemMaintenanceReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"foo-1","Action":2,"Duration":0,"Reason":""}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system maintenance list",
+			"system maintenance list",
+			strings.Join([]string{
+				`*control.SystemMaintenanceReq-{"Sys":"","HostList":null,"Ranks":"","Hosts":"","Action":0,"Duration":0,"Reason":""}`,
+			}, " "),
+			nil,
+		},
+		{
+			"system lock acquire with defaults",
+			"system lock acquire foo",
+			strings.Join([]string{
+				printRequest(t, &control.SystemLockReq{
+					Action: control.LockAcquire,
+					Names:  []string{"foo"},
+					TTL:    time.Hour,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system lock acquire with options",
+			"system lock acquire foo bar --owner script1 --ttl 30m --reason upgrade",
+			strings.Join([]string{
+				printRequest(t, &control.SystemLockReq{
+					Action: control.LockAcquire,
+					Names:  []string{"foo", "bar"},
+					Owner:  "script1",
+					TTL:    30 * time.Minute,
+					Reason: "upgrade",
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system lock acquire without names",
+			"system lock acquire",
+			"",
+			errors.New("the required argument `name .*` was not provided"),
+		},
+		{
+			"system lock acquire with short ttl",
+			"system lock acquire foo --ttl 10ms",
+			"",
+			errors.New("at least 1s"),
+		},
+		{
+			"system lock release",
+			"system lock release foo --owner script1 --force",
+			strings.Join([]string{
+				printRequest(t, &control.SystemLockReq{
+					Action: control.LockRelease,
+					Names:  []string{"foo"},
+					Owner:  "script1",
+					Force:  true,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system lock release without names",
+			"system lock release",
+			"",
+			errors.New("the required argument `name .*` was not provided"),
+		},
+		{
+			"system lock list",
+			"system lock list",
+			strings.Join([]string{
+				printRequest(t, &control.SystemLockReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system lock list by name",
+			"system lock list foo",
+			strings.Join([]string{
+				printRequest(t, &control.SystemLockReq{
+					Names: []string{"foo"},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system set-pool-defaults",
+			"system set-pool-defaults --properties self_heal:exclude,reclaim:lazy --enforce --unset space_rb",
+			strings.Join([]string{
+				printRequest(t, &control.SystemPoolDefaultsReq{
+					Set: []*control.PoolPropDefault{
+						{Name: "self_heal", Value: "exclude", Enforced: true},
+						{Name: "reclaim", Value: "lazy", Enforced: true},
+					},
+					Unset: []string{"space_rb"},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system set-pool-defaults unset only",
+			"system set-pool-defaults --unset reclaim,self_heal",
+			strings.Join([]string{
+				printRequest(t, &control.SystemPoolDefaultsReq{
+					Unset: []string{"reclaim", "self_heal"},
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system set-pool-defaults without properties",
+			"system set-pool-defaults",
+			"",
+			errors.New("either --properties or --unset"),
+		},
+		{
+			"system set-pool-defaults enforce without properties",
+			"system set-pool-defaults --enforce --unset reclaim",
+			"",
+			errors.New("--enforce requires --properties"),
+		},
+		{
+			"system get-pool-defaults",
+			"system get-pool-defaults",
+			strings.Join([]string{
+				printRequest(t, &control.SystemPoolDefaultsReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system history",
+			"system history",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system history with filters",
+			"system history --operation pool-create --initiator admin --since 2021-06-01T12:00:00Z --failed --limit 10",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{
+					Operation:  "pool-create",
+					Initiator:  "admin",
+					Since:      time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+					FailedOnly: true,
+					Limit:      10,
+				}),
+			}, " "),
+			nil,
+		},
+		{
+			"system history exported as cef",
+			"system history --export cef",
+			strings.Join([]string{
+				printRequest(t, &control.SystemHistoryReq{}),
+			}, " "),
+			nil,
+		},
+		{
+			"system history with unknown export format",
+			"system history --export leef",
+			"",
+			errors.New("Invalid value `leef'"),
+		},
+		{
+			"system history with bad since",
+			"system history --since yesterday",
+			"",
+			errors.New("expected a duration or RFC3339 time"),
+		},
+		{
+			"system history with negative limit",
+			"system history --limit -1",
+			"",
+			errors.New("must not be negative"),
+		},
 		{
 			"Non-existent subcommand",
 			"system quack",
@@ -216,6 +754,46 @@ func TestDmg_SystemCommands(t *testing.T) {
 	})
 }
 
+func TestDmg_systemEraseCmd_Confirm(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input    string
+		expErr   error
+		expCalls bool
+	}{
+		"no input": {
+			expErr: errors.New("confirmation not given"),
+		},
+		"wrong system name": {
+			input:  "other\n",
+			expErr: errors.New("confirmation not given"),
+		},
+		"confirmed": {
+			input:    "daos_server\n",
+			expCalls: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			confirmInput = strings.NewReader(tc.input)
+			defer func() {
+				confirmInput = os.Stdin
+			}()
+
+			conn := newTestConn(t)
+			bridge := &bridgeConnInvoker{
+				MockInvoker: *control.DefaultMockInvoker(log),
+				t:           t,
+				conn:        conn,
+			}
+			err := runCmd(t, "system erase", log, bridge)
+			common.CmpErr(t, tc.expErr, err)
+			common.AssertEqual(t, tc.expCalls, len(conn.called) > 0, "unexpected RPC calls")
+		})
+	}
+}
+
 func TestDmg_LeaderQueryCmd_Errors(t *testing.T) {
 	for name, tc := range map[string]struct {
 		ctlCfg *control.Config
@@ -326,6 +904,139 @@ func TestDmg_systemQueryCmd_Errors(t *testing.T) {
 	}
 }
 
+func TestDmg_memberStateChanges(t *testing.T) {
+	mockMember := func(rank system.Rank, state system.MemberState) *system.Member {
+		return system.MockMember(t, uint32(rank), state)
+	}
+
+	for name, tc := range map[string]struct {
+		prev       system.Members
+		cur        system.Members
+		expChanges []string
+	}{
+		"no changes": {
+			prev: system.Members{mockMember(0, system.MemberStateJoined)},
+			cur:  system.Members{mockMember(0, system.MemberStateJoined)},
+		},
+		"state changes": {
+			prev: system.Members{
+				mockMember(0, system.MemberStateStopped),
+				mockMember(1, system.MemberStateReady),
+				mockMember(2, system.MemberStateJoined),
+			},
+			cur: system.Members{
+				mockMember(0, system.MemberStateStarting),
+				mockMember(1, system.MemberStateJoined),
+				mockMember(2, system.MemberStateJoined),
+			},
+			expChanges: []string{
+				"rank 0: Stopped -> Starting",
+				"rank 1: Ready -> Joined",
+			},
+		},
+		"new member": {
+			prev: system.Members{mockMember(0, system.MemberStateJoined)},
+			cur: system.Members{
+				mockMember(0, system.MemberStateJoined),
+				mockMember(1, system.MemberStateReady),
+			},
+			expChanges: []string{"rank 1: Ready (new)"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotChanges := memberStateChanges(tc.prev, tc.cur)
+			if diff := cmp.Diff(tc.expChanges, gotChanges); diff != "" {
+				t.Fatalf("unexpected changes (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDmg_systemQueryCmd_Watch(t *testing.T) {
+	mockResp := func(states ...system.MemberState) *control.UnaryResponse {
+		resp := new(mgmtpb.SystemQueryResp)
+		for i, state := range states {
+			resp.Members = append(resp.Members, &mgmtpb.SystemMember{
+				Rank:  uint32(i),
+				Uuid:  common.MockUUID(int32(i)),
+				State: state.String(),
+				Addr:  "10.0.0.1:10001",
+			})
+		}
+		return control.MockMSResponse("10.0.0.1:10001", nil, resp)
+	}
+
+	for name, tc := range map[string]struct {
+		respSet    []*control.UnaryResponse
+		resp       *control.UnaryResponse
+		until      string
+		timeout    time.Duration
+		expErr     error
+		expChanges []string
+	}{
+		"ranks reach state": {
+			respSet: []*control.UnaryResponse{
+				mockResp(system.MemberStateStopped, system.MemberStateStopped),
+				mockResp(system.MemberStateReady, system.MemberStateStarting),
+			},
+			resp:  mockResp(system.MemberStateJoined, system.MemberStateJoined),
+			until: "joined",
+			expChanges: []string{
+				"rank 0: Stopped -> Ready",
+				"rank 1: Stopped -> Starting",
+				"rank 0: Ready -> Joined",
+				"rank 1: Starting -> Joined",
+			},
+		},
+		"transient query failure": {
+			respSet: []*control.UnaryResponse{
+				control.MockMSResponse("10.0.0.1:10001", errors.New("not leader"), nil),
+			},
+			resp:  mockResp(system.MemberStateJoined),
+			until: "joined",
+		},
+		"timeout waiting for state": {
+			resp:    mockResp(system.MemberStateJoined, system.MemberStateStopped),
+			until:   "joined",
+			timeout: 20 * time.Millisecond,
+			expErr:  errors.New("timed out after 20ms waiting for rank 1 to be joined"),
+		},
+		"timeout with failing queries": {
+			resp:    control.MockMSResponse("10.0.0.1:10001", errors.New("not leader"), nil),
+			until:   "joined",
+			timeout: 20 * time.Millisecond,
+			expErr:  errors.New("timed out after 20ms"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponseSet: tc.respSet,
+				UnaryResponse:    tc.resp,
+			})
+
+			queryCmd := new(systemQueryCmd)
+			queryCmd.setInvoker(mi)
+			queryCmd.setLog(log)
+			queryCmd.Watch = true
+			queryCmd.Interval = time.Millisecond
+			queryCmd.Until = tc.until
+			queryCmd.Timeout = tc.timeout
+
+			gotErr := queryCmd.Execute(nil)
+			common.CmpErr(t, tc.expErr, gotErr)
+
+			for _, change := range tc.expChanges {
+				if !strings.Contains(buf.String(), change) {
+					t.Fatalf("expected %q in output", change)
+				}
+			}
+		})
+	}
+}
+
 func TestDmg_systemStartCmd_Errors(t *testing.T) {
 	for name, tc := range map[string]struct {
 		resp   *mgmtpb.SystemStartResp
@@ -583,3 +1294,42 @@ func TestDmg_systemStopCmd_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestDmg_parseSince(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		since    string
+		expSince time.Time
+		expErr   error
+	}{
+		"duration": {
+			since:    "90m",
+			expSince: now.Add(-90 * time.Minute),
+		},
+		"negative duration": {
+			since:  "-1h",
+			expErr: errors.New("must not be negative"),
+		},
+		"rfc3339": {
+			since:    "2021-05-31T08:30:00Z",
+			expSince: time.Date(2021, 5, 31, 8, 30, 0, 0, time.UTC),
+		},
+		"invalid": {
+			since:  "last week",
+			expErr: errors.New(`invalid since "last week"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotSince, gotErr := parseSince(tc.since, now)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if !gotSince.Equal(tc.expSince) {
+				t.Fatalf("expected %s, got %s", tc.expSince, gotSince)
+			}
+		})
+	}
+}
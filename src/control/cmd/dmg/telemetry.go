@@ -30,12 +30,14 @@ import (
 	"github.com/daos-stack/daos/src/control/cmd/dmg/pretty"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
 )
 
 type telemCmd struct {
-	Configure telemConfigCmd `command:"config" description:"Configure telemetry"`
-	Run       telemRunCmd    `command:"run" description:"Launch telemetry system"`
-	Metrics   metricsCmd     `command:"metrics" description:"Interact with metrics"`
+	Configure telemConfigCmd   `command:"config" description:"Configure telemetry"`
+	Run       telemRunCmd      `command:"run" description:"Launch telemetry system"`
+	Metrics   metricsCmd       `command:"metrics" description:"Interact with metrics"`
+	Snapshot  telemSnapshotCmd `command:"snapshot" description:"Capture a snapshot of all metrics from DAOS storage nodes"`
 }
 
 type telemConfigCmd struct {
@@ -420,3 +422,160 @@ func (cmd *metricsQueryCmd) Execute(args []string) error {
 	cmd.log.Info(b.String())
 	return nil
 }
+
+const snapshotManifest = "manifest.json"
+
+type (
+	// snapshotFile describes the file holding the metrics captured from
+	// a host.
+	snapshotFile struct {
+		Host    string `json:"host"`
+		File    string `json:"file"` // relative to the snapshot directory
+		Metrics int    `json:"metrics"`
+	}
+
+	// telemSnapshotResp describes the files written by a snapshot.
+	telemSnapshotResp struct {
+		Timestamp  time.Time         `json:"timestamp"`
+		Files      []*snapshotFile   `json:"files"`
+		HostErrors map[string]string `json:"host_errors"`
+	}
+)
+
+// telemSnapshotCmd captures a point-in-time dump of all metrics from the
+// requested DAOS servers into compressed files, for clusters without
+// connectivity to external monitoring.
+type telemSnapshotCmd struct {
+	logCmd
+	cfgCmd
+	jsonOutputCmd
+	Hosts  string `short:"s" long:"hosts" description:"Hostlist of DAOS servers to capture metrics from (default: hostlist from the dmg config)"`
+	Port   uint32 `short:"p" long:"port" default:"9191" description:"Telemetry port on the hosts"`
+	Output string `short:"o" long:"output" required:"1" description:"Directory to write the snapshot to"`
+}
+
+func (cmd *telemSnapshotCmd) getHosts() ([]string, error) {
+	if cmd.Hosts != "" {
+		hs, err := hostlist.CreateSet(cmd.Hosts)
+		if err != nil {
+			return nil, err
+		}
+		return hs.Slice(), nil
+	}
+
+	if cmd.config == nil || len(cmd.config.HostList) == 0 {
+		return nil, errors.New("no hosts specified")
+	}
+
+	hosts := make([]string, 0, len(cmd.config.HostList))
+	for _, h := range cmd.config.HostList {
+		host, _, err := common.SplitPort(h, 0)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// writeSnapshotFile writes the host snapshot to a gzip-compressed
+// OpenMetrics file in the output directory.
+func (cmd *telemSnapshotCmd) writeSnapshotFile(hms *control.HostMetricsSnapshot) (*snapshotFile, error) {
+	name := fmt.Sprintf("%s-%s.om.gz", hms.Host, hms.Timestamp.UTC().Format("20060102T150405Z"))
+	path := filepath.Join(cmd.Output, name)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s", path)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	gzw.Name = strings.TrimSuffix(name, ".gz")
+	gzw.ModTime = hms.Timestamp
+	if err := hms.WriteOpenMetrics(gzw); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", path)
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", path)
+	}
+
+	return &snapshotFile{
+		Host:    hms.Host,
+		File:    name,
+		Metrics: hms.MetricCount(),
+	}, f.Close()
+}
+
+// Execute runs the command to capture a snapshot of the metrics on the DAOS
+// storage nodes.
+func (cmd *telemSnapshotCmd) Execute(_ []string) error {
+	hosts, err := cmd.getHosts()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cmd.Output, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create %s", cmd.Output)
+	}
+
+	req := &control.MetricsSnapshotReq{
+		Hosts: hosts,
+		Port:  cmd.Port,
+	}
+
+	if !cmd.shouldEmitJSON {
+		cmd.log.Infof("capturing metrics from %d host(s)...", len(hosts))
+	}
+
+	snapResp, err := control.MetricsSnapshot(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	resp := &telemSnapshotResp{
+		Timestamp:  time.Now(),
+		HostErrors: make(map[string]string),
+	}
+	for host, hostErr := range snapResp.HostErrors {
+		resp.HostErrors[host] = hostErr.Error()
+	}
+	for _, hms := range snapResp.Snapshots {
+		file, err := cmd.writeSnapshotFile(hms)
+		if err != nil {
+			return err
+		}
+		resp.Files = append(resp.Files, file)
+	}
+
+	// the manifest allows support tooling to identify the contents of
+	// the snapshot without decompressing each file
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifest := filepath.Join(cmd.Output, snapshotManifest)
+	if err := ioutil.WriteFile(manifest, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", manifest)
+	}
+
+	if len(resp.Files) == 0 {
+		err = errors.New("no metrics could be captured")
+	}
+
+	if cmd.shouldEmitJSON {
+		return cmd.outputJSON(resp, err)
+	}
+
+	for _, file := range resp.Files {
+		cmd.log.Infof("%s: %d metrics written to %s", file.Host, file.Metrics,
+			filepath.Join(cmd.Output, file.File))
+	}
+	for _, host := range hosts {
+		if hostErr, found := resp.HostErrors[host]; found {
+			cmd.log.Errorf("%s: %s", host, hostErr)
+		}
+	}
+
+	return err
+}
@@ -34,102 +34,190 @@ var file_ctl_ctl_proto_rawDesc = []byte{
 	0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x63, 0x74, 0x6c, 0x2f,
 	0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0d,
 	0x63, 0x74, 0x6c, 0x2f, 0x73, 0x6d, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x63,
-	0x74, 0x6c, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xb7,
-	0x05, 0x0a, 0x06, 0x43, 0x74, 0x6c, 0x53, 0x76, 0x63, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x74,
-	0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
-	0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a,
-	0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x13, 0x2e,
-	0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52,
-	0x65, 0x71, 0x1a, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x53, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x74,
-	0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52,
-	0x65, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
-	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x13, 0x2e, 0x63, 0x74,
-	0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71,
-	0x1a, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x63,
-	0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x46, 0x69, 0x72, 0x6d,
-	0x77, 0x61, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
-	0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
-	0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x46, 0x69,
-	0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x63,
-	0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77,
-	0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
-	0x31, 0x0a, 0x08, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x10, 0x2e, 0x63, 0x74,
-	0x6c, 0x2e, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e,
-	0x63, 0x74, 0x6c, 0x2e, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x22, 0x00, 0x12, 0x34, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x70, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f,
-	0x77, 0x6e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61,
-	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e,
-	0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x53, 0x74, 0x6f, 0x70,
-	0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b,
-	0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x61,
-	0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52,
-	0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x65, 0x74, 0x46, 0x6f, 0x72,
-	0x6d, 0x61, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52,
+	0x74, 0x6c, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11,
+	0x63, 0x74, 0x6c, 0x2f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x10, 0x63, 0x74, 0x6c, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x65, 0x6c, 0x66, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x63, 0x74, 0x6c, 0x2f, 0x68, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xec, 0x0a, 0x0a,
+	0x06, 0x43, 0x74, 0x6c, 0x53, 0x76, 0x63, 0x12, 0x43, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x13, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71,
+	0x1a, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63,
+	0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x15, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x12, 0x18, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e,
+	0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x12, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x4e, 0x0a, 0x15, 0x42, 0x64, 0x65, 0x76, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x18, 0x2e, 0x63, 0x74, 0x6c,
+	0x2e, 0x42, 0x64, 0x65, 0x76, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x42, 0x64, 0x65, 0x76, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x63, 0x61, 0x6e,
+	0x12, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x63,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a,
+	0x0d, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x15,
+	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d,
+	0x77, 0x61, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x43, 0x0a, 0x0e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x1a, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x6d, 0x64, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x70, 0x53,
+	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63,
+	0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2c, 0x0a,
+	0x09, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c,
+	0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x50,
+	0x69, 0x6e, 0x67, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52,
 	0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61,
-	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0a, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61,
-	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e,
-	0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63,
-	0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x10, 0x52, 0x65, 0x73,
+	0x65, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e,
+	0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63,
+	0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x2d,
+	0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x0d, 0x2e, 0x63,
+	0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x40, 0x0a,
+	0x0d, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x12, 0x15,
+	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3a,
+	0x0a, 0x0b, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x2e,
+	0x63, 0x74, 0x6c, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x1a, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x50, 0x6f,
+	0x6f, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x18,
+	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50,
+	0x6f, 0x6f, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x18, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53,
+	0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x46, 0x0a,
+	0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74,
+	0x12, 0x17, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65,
+	0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x12, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x39, 0x5a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73,
+	0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_ctl_ctl_proto_goTypes = []interface{}{
-	(*StoragePrepareReq)(nil),  // 0: ctl.StoragePrepareReq
-	(*StorageScanReq)(nil),     // 1: ctl.StorageScanReq
-	(*StorageFormatReq)(nil),   // 2: ctl.StorageFormatReq
-	(*NetworkScanReq)(nil),     // 3: ctl.NetworkScanReq
-	(*FirmwareQueryReq)(nil),   // 4: ctl.FirmwareQueryReq
-	(*FirmwareUpdateReq)(nil),  // 5: ctl.FirmwareUpdateReq
-	(*SmdQueryReq)(nil),        // 6: ctl.SmdQueryReq
-	(*RanksReq)(nil),           // 7: ctl.RanksReq
-	(*StoragePrepareResp)(nil), // 8: ctl.StoragePrepareResp
-	(*StorageScanResp)(nil),    // 9: ctl.StorageScanResp
-	(*StorageFormatResp)(nil),  // 10: ctl.StorageFormatResp
-	(*NetworkScanResp)(nil),    // 11: ctl.NetworkScanResp
-	(*FirmwareQueryResp)(nil),  // 12: ctl.FirmwareQueryResp
-	(*FirmwareUpdateResp)(nil), // 13: ctl.FirmwareUpdateResp
-	(*SmdQueryResp)(nil),       // 14: ctl.SmdQueryResp
-	(*RanksResp)(nil),          // 15: ctl.RanksResp
+	(*StoragePrepareReq)(nil),    // 0: ctl.StoragePrepareReq
+	(*StorageScanReq)(nil),       // 1: ctl.StorageScanReq
+	(*StorageFormatReq)(nil),     // 2: ctl.StorageFormatReq
+	(*StorageOwnershipReq)(nil),  // 3: ctl.StorageOwnershipReq
+	(*StorageVerifyReq)(nil),     // 4: ctl.StorageVerifyReq
+	(*BdevCapabilitiesReq)(nil),  // 5: ctl.BdevCapabilitiesReq
+	(*NetworkScanReq)(nil),       // 6: ctl.NetworkScanReq
+	(*FirmwareQueryReq)(nil),     // 7: ctl.FirmwareQueryReq
+	(*FirmwareUpdateReq)(nil),    // 8: ctl.FirmwareUpdateReq
+	(*SmdQueryReq)(nil),          // 9: ctl.SmdQueryReq
+	(*RanksReq)(nil),             // 10: ctl.RanksReq
+	(*ActivateSpareReq)(nil),     // 11: ctl.ActivateSpareReq
+	(*VersionQueryReq)(nil),      // 12: ctl.VersionQueryReq
+	(*EngineStatsReq)(nil),       // 13: ctl.EngineStatsReq
+	(*PoolQueryTargetsReq)(nil),  // 14: ctl.PoolQueryTargetsReq
+	(*NetworkSelfTestReq)(nil),   // 15: ctl.NetworkSelfTestReq
+	(*StorageSelfTestReq)(nil),   // 16: ctl.StorageSelfTestReq
+	(*HeartbeatReq)(nil),         // 17: ctl.HeartbeatReq
+	(*StoragePrepareResp)(nil),   // 18: ctl.StoragePrepareResp
+	(*StorageScanResp)(nil),      // 19: ctl.StorageScanResp
+	(*StorageFormatResp)(nil),    // 20: ctl.StorageFormatResp
+	(*StorageOwnershipResp)(nil), // 21: ctl.StorageOwnershipResp
+	(*StorageVerifyResp)(nil),    // 22: ctl.StorageVerifyResp
+	(*BdevCapabilitiesResp)(nil), // 23: ctl.BdevCapabilitiesResp
+	(*NetworkScanResp)(nil),      // 24: ctl.NetworkScanResp
+	(*FirmwareQueryResp)(nil),    // 25: ctl.FirmwareQueryResp
+	(*FirmwareUpdateResp)(nil),   // 26: ctl.FirmwareUpdateResp
+	(*SmdQueryResp)(nil),         // 27: ctl.SmdQueryResp
+	(*RanksResp)(nil),            // 28: ctl.RanksResp
+	(*ActivateSpareResp)(nil),    // 29: ctl.ActivateSpareResp
+	(*VersionQueryResp)(nil),     // 30: ctl.VersionQueryResp
+	(*EngineStatsResp)(nil),      // 31: ctl.EngineStatsResp
+	(*PoolQueryTargetsResp)(nil), // 32: ctl.PoolQueryTargetsResp
+	(*NetworkSelfTestResp)(nil),  // 33: ctl.NetworkSelfTestResp
+	(*StorageSelfTestResp)(nil),  // 34: ctl.StorageSelfTestResp
+	(*HeartbeatResp)(nil),        // 35: ctl.HeartbeatResp
 }
 var file_ctl_ctl_proto_depIdxs = []int32{
 	0,  // 0: ctl.CtlSvc.StoragePrepare:input_type -> ctl.StoragePrepareReq
 	1,  // 1: ctl.CtlSvc.StorageScan:input_type -> ctl.StorageScanReq
 	2,  // 2: ctl.CtlSvc.StorageFormat:input_type -> ctl.StorageFormatReq
-	3,  // 3: ctl.CtlSvc.NetworkScan:input_type -> ctl.NetworkScanReq
-	4,  // 4: ctl.CtlSvc.FirmwareQuery:input_type -> ctl.FirmwareQueryReq
-	5,  // 5: ctl.CtlSvc.FirmwareUpdate:input_type -> ctl.FirmwareUpdateReq
-	6,  // 6: ctl.CtlSvc.SmdQuery:input_type -> ctl.SmdQueryReq
-	7,  // 7: ctl.CtlSvc.PrepShutdownRanks:input_type -> ctl.RanksReq
-	7,  // 8: ctl.CtlSvc.StopRanks:input_type -> ctl.RanksReq
-	7,  // 9: ctl.CtlSvc.PingRanks:input_type -> ctl.RanksReq
-	7,  // 10: ctl.CtlSvc.ResetFormatRanks:input_type -> ctl.RanksReq
-	7,  // 11: ctl.CtlSvc.StartRanks:input_type -> ctl.RanksReq
-	8,  // 12: ctl.CtlSvc.StoragePrepare:output_type -> ctl.StoragePrepareResp
-	9,  // 13: ctl.CtlSvc.StorageScan:output_type -> ctl.StorageScanResp
-	10, // 14: ctl.CtlSvc.StorageFormat:output_type -> ctl.StorageFormatResp
-	11, // 15: ctl.CtlSvc.NetworkScan:output_type -> ctl.NetworkScanResp
-	12, // 16: ctl.CtlSvc.FirmwareQuery:output_type -> ctl.FirmwareQueryResp
-	13, // 17: ctl.CtlSvc.FirmwareUpdate:output_type -> ctl.FirmwareUpdateResp
-	14, // 18: ctl.CtlSvc.SmdQuery:output_type -> ctl.SmdQueryResp
-	15, // 19: ctl.CtlSvc.PrepShutdownRanks:output_type -> ctl.RanksResp
-	15, // 20: ctl.CtlSvc.StopRanks:output_type -> ctl.RanksResp
-	15, // 21: ctl.CtlSvc.PingRanks:output_type -> ctl.RanksResp
-	15, // 22: ctl.CtlSvc.ResetFormatRanks:output_type -> ctl.RanksResp
-	15, // 23: ctl.CtlSvc.StartRanks:output_type -> ctl.RanksResp
-	12, // [12:24] is the sub-list for method output_type
-	0,  // [0:12] is the sub-list for method input_type
+	3,  // 3: ctl.CtlSvc.StorageOwnershipQuery:input_type -> ctl.StorageOwnershipReq
+	4,  // 4: ctl.CtlSvc.StorageVerifyQuery:input_type -> ctl.StorageVerifyReq
+	5,  // 5: ctl.CtlSvc.BdevCapabilitiesQuery:input_type -> ctl.BdevCapabilitiesReq
+	6,  // 6: ctl.CtlSvc.NetworkScan:input_type -> ctl.NetworkScanReq
+	7,  // 7: ctl.CtlSvc.FirmwareQuery:input_type -> ctl.FirmwareQueryReq
+	8,  // 8: ctl.CtlSvc.FirmwareUpdate:input_type -> ctl.FirmwareUpdateReq
+	9,  // 9: ctl.CtlSvc.SmdQuery:input_type -> ctl.SmdQueryReq
+	10, // 10: ctl.CtlSvc.PrepShutdownRanks:input_type -> ctl.RanksReq
+	10, // 11: ctl.CtlSvc.StopRanks:input_type -> ctl.RanksReq
+	10, // 12: ctl.CtlSvc.PingRanks:input_type -> ctl.RanksReq
+	10, // 13: ctl.CtlSvc.ResetFormatRanks:input_type -> ctl.RanksReq
+	10, // 14: ctl.CtlSvc.StartRanks:input_type -> ctl.RanksReq
+	11, // 15: ctl.CtlSvc.ActivateSpare:input_type -> ctl.ActivateSpareReq
+	12, // 16: ctl.CtlSvc.VersionQuery:input_type -> ctl.VersionQueryReq
+	13, // 17: ctl.CtlSvc.EngineStats:input_type -> ctl.EngineStatsReq
+	14, // 18: ctl.CtlSvc.PoolQueryTargets:input_type -> ctl.PoolQueryTargetsReq
+	15, // 19: ctl.CtlSvc.NetworkSelfTest:input_type -> ctl.NetworkSelfTestReq
+	16, // 20: ctl.CtlSvc.StorageSelfTest:input_type -> ctl.StorageSelfTestReq
+	17, // 21: ctl.CtlSvc.Heartbeat:input_type -> ctl.HeartbeatReq
+	18, // 22: ctl.CtlSvc.StoragePrepare:output_type -> ctl.StoragePrepareResp
+	19, // 23: ctl.CtlSvc.StorageScan:output_type -> ctl.StorageScanResp
+	20, // 24: ctl.CtlSvc.StorageFormat:output_type -> ctl.StorageFormatResp
+	21, // 25: ctl.CtlSvc.StorageOwnershipQuery:output_type -> ctl.StorageOwnershipResp
+	22, // 26: ctl.CtlSvc.StorageVerifyQuery:output_type -> ctl.StorageVerifyResp
+	23, // 27: ctl.CtlSvc.BdevCapabilitiesQuery:output_type -> ctl.BdevCapabilitiesResp
+	24, // 28: ctl.CtlSvc.NetworkScan:output_type -> ctl.NetworkScanResp
+	25, // 29: ctl.CtlSvc.FirmwareQuery:output_type -> ctl.FirmwareQueryResp
+	26, // 30: ctl.CtlSvc.FirmwareUpdate:output_type -> ctl.FirmwareUpdateResp
+	27, // 31: ctl.CtlSvc.SmdQuery:output_type -> ctl.SmdQueryResp
+	28, // 32: ctl.CtlSvc.PrepShutdownRanks:output_type -> ctl.RanksResp
+	28, // 33: ctl.CtlSvc.StopRanks:output_type -> ctl.RanksResp
+	28, // 34: ctl.CtlSvc.PingRanks:output_type -> ctl.RanksResp
+	28, // 35: ctl.CtlSvc.ResetFormatRanks:output_type -> ctl.RanksResp
+	28, // 36: ctl.CtlSvc.StartRanks:output_type -> ctl.RanksResp
+	29, // 37: ctl.CtlSvc.ActivateSpare:output_type -> ctl.ActivateSpareResp
+	30, // 38: ctl.CtlSvc.VersionQuery:output_type -> ctl.VersionQueryResp
+	31, // 39: ctl.CtlSvc.EngineStats:output_type -> ctl.EngineStatsResp
+	32, // 40: ctl.CtlSvc.PoolQueryTargets:output_type -> ctl.PoolQueryTargetsResp
+	33, // 41: ctl.CtlSvc.NetworkSelfTest:output_type -> ctl.NetworkSelfTestResp
+	34, // 42: ctl.CtlSvc.StorageSelfTest:output_type -> ctl.StorageSelfTestResp
+	35, // 43: ctl.CtlSvc.Heartbeat:output_type -> ctl.HeartbeatResp
+	22, // [22:44] is the sub-list for method output_type
+	0,  // [0:22] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -145,6 +233,10 @@ func file_ctl_ctl_proto_init() {
 	file_ctl_firmware_proto_init()
 	file_ctl_smd_proto_init()
 	file_ctl_ranks_proto_init()
+	file_ctl_version_proto_init()
+	file_ctl_engine_proto_init()
+	file_ctl_selftest_proto_init()
+	file_ctl_heartbeat_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
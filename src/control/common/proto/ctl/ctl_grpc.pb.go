@@ -24,6 +24,12 @@ type CtlSvcClient interface {
 	StorageScan(ctx context.Context, in *StorageScanReq, opts ...grpc.CallOption) (*StorageScanResp, error)
 	// Format nonvolatile storage devices for use with DAOS
 	StorageFormat(ctx context.Context, in *StorageFormatReq, opts ...grpc.CallOption) (*StorageFormatResp, error)
+	// Retrieve the engine instances owning storage devices on server
+	StorageOwnershipQuery(ctx context.Context, in *StorageOwnershipReq, opts ...grpc.CallOption) (*StorageOwnershipResp, error)
+	// Retrieve the progress of NVMe read verification after format
+	StorageVerifyQuery(ctx context.Context, in *StorageVerifyReq, opts ...grpc.CallOption) (*StorageVerifyResp, error)
+	// Retrieve the SPDK/DPDK versions and supported NVMe features on server
+	BdevCapabilitiesQuery(ctx context.Context, in *BdevCapabilitiesReq, opts ...grpc.CallOption) (*BdevCapabilitiesResp, error)
 	// Perform a fabric scan to determine the available provider, device, NUMA node combinations
 	NetworkScan(ctx context.Context, in *NetworkScanReq, opts ...grpc.CallOption) (*NetworkScanResp, error)
 	// Retrieve firmware details from storage devices on server
@@ -42,6 +48,20 @@ type CtlSvcClient interface {
 	ResetFormatRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
 	// Start DAOS I/O Engines on a host. (gRPC fanout)
 	StartRanks(ctx context.Context, in *RanksReq, opts ...grpc.CallOption) (*RanksResp, error)
+	// Activate a spare DAOS I/O Engine on a host to replace a failed rank
+	ActivateSpare(ctx context.Context, in *ActivateSpareReq, opts ...grpc.CallOption) (*ActivateSpareResp, error)
+	// Retrieve versions of DAOS and dependent software components on server
+	VersionQuery(ctx context.Context, in *VersionQueryReq, opts ...grpc.CallOption) (*VersionQueryResp, error)
+	// Retrieve runtime scheduling statistics of DAOS I/O Engine xstreams
+	EngineStats(ctx context.Context, in *EngineStatsReq, opts ...grpc.CallOption) (*EngineStatsResp, error)
+	// Retrieve state and space usage of pool targets local to DAOS I/O Engines
+	PoolQueryTargets(ctx context.Context, in *PoolQueryTargetsReq, opts ...grpc.CallOption) (*PoolQueryTargetsResp, error)
+	// Run a fabric point-to-point test between I/O Engines
+	NetworkSelfTest(ctx context.Context, in *NetworkSelfTestReq, opts ...grpc.CallOption) (*NetworkSelfTestResp, error)
+	// Run a bandwidth test of local NVMe SSDs
+	StorageSelfTest(ctx context.Context, in *StorageSelfTestReq, opts ...grpc.CallOption) (*StorageSelfTestResp, error)
+	// Retrieve the wall clock time of a server to measure clock skew
+	Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatResp, error)
 }
 
 type ctlSvcClient struct {
@@ -79,6 +99,33 @@ func (c *ctlSvcClient) StorageFormat(ctx context.Context, in *StorageFormatReq,
 	return out, nil
 }
 
+func (c *ctlSvcClient) StorageOwnershipQuery(ctx context.Context, in *StorageOwnershipReq, opts ...grpc.CallOption) (*StorageOwnershipResp, error) {
+	out := new(StorageOwnershipResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/StorageOwnershipQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) StorageVerifyQuery(ctx context.Context, in *StorageVerifyReq, opts ...grpc.CallOption) (*StorageVerifyResp, error) {
+	out := new(StorageVerifyResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/StorageVerifyQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) BdevCapabilitiesQuery(ctx context.Context, in *BdevCapabilitiesReq, opts ...grpc.CallOption) (*BdevCapabilitiesResp, error) {
+	out := new(BdevCapabilitiesResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/BdevCapabilitiesQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ctlSvcClient) NetworkScan(ctx context.Context, in *NetworkScanReq, opts ...grpc.CallOption) (*NetworkScanResp, error) {
 	out := new(NetworkScanResp)
 	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/NetworkScan", in, out, opts...)
@@ -160,6 +207,69 @@ func (c *ctlSvcClient) StartRanks(ctx context.Context, in *RanksReq, opts ...grp
 	return out, nil
 }
 
+func (c *ctlSvcClient) ActivateSpare(ctx context.Context, in *ActivateSpareReq, opts ...grpc.CallOption) (*ActivateSpareResp, error) {
+	out := new(ActivateSpareResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/ActivateSpare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) VersionQuery(ctx context.Context, in *VersionQueryReq, opts ...grpc.CallOption) (*VersionQueryResp, error) {
+	out := new(VersionQueryResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/VersionQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) EngineStats(ctx context.Context, in *EngineStatsReq, opts ...grpc.CallOption) (*EngineStatsResp, error) {
+	out := new(EngineStatsResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/EngineStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) PoolQueryTargets(ctx context.Context, in *PoolQueryTargetsReq, opts ...grpc.CallOption) (*PoolQueryTargetsResp, error) {
+	out := new(PoolQueryTargetsResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/PoolQueryTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) NetworkSelfTest(ctx context.Context, in *NetworkSelfTestReq, opts ...grpc.CallOption) (*NetworkSelfTestResp, error) {
+	out := new(NetworkSelfTestResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/NetworkSelfTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) StorageSelfTest(ctx context.Context, in *StorageSelfTestReq, opts ...grpc.CallOption) (*StorageSelfTestResp, error) {
+	out := new(StorageSelfTestResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/StorageSelfTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlSvcClient) Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatResp, error) {
+	out := new(HeartbeatResp)
+	err := c.cc.Invoke(ctx, "/ctl.CtlSvc/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CtlSvcServer is the server API for CtlSvc service.
 // All implementations must embed UnimplementedCtlSvcServer
 // for forward compatibility
@@ -170,6 +280,12 @@ type CtlSvcServer interface {
 	StorageScan(context.Context, *StorageScanReq) (*StorageScanResp, error)
 	// Format nonvolatile storage devices for use with DAOS
 	StorageFormat(context.Context, *StorageFormatReq) (*StorageFormatResp, error)
+	// Retrieve the engine instances owning storage devices on server
+	StorageOwnershipQuery(context.Context, *StorageOwnershipReq) (*StorageOwnershipResp, error)
+	// Retrieve the progress of NVMe read verification after format
+	StorageVerifyQuery(context.Context, *StorageVerifyReq) (*StorageVerifyResp, error)
+	// Retrieve the SPDK/DPDK versions and supported NVMe features on server
+	BdevCapabilitiesQuery(context.Context, *BdevCapabilitiesReq) (*BdevCapabilitiesResp, error)
 	// Perform a fabric scan to determine the available provider, device, NUMA node combinations
 	NetworkScan(context.Context, *NetworkScanReq) (*NetworkScanResp, error)
 	// Retrieve firmware details from storage devices on server
@@ -188,6 +304,20 @@ type CtlSvcServer interface {
 	ResetFormatRanks(context.Context, *RanksReq) (*RanksResp, error)
 	// Start DAOS I/O Engines on a host. (gRPC fanout)
 	StartRanks(context.Context, *RanksReq) (*RanksResp, error)
+	// Activate a spare DAOS I/O Engine on a host to replace a failed rank
+	ActivateSpare(context.Context, *ActivateSpareReq) (*ActivateSpareResp, error)
+	// Retrieve versions of DAOS and dependent software components on server
+	VersionQuery(context.Context, *VersionQueryReq) (*VersionQueryResp, error)
+	// Retrieve runtime scheduling statistics of DAOS I/O Engine xstreams
+	EngineStats(context.Context, *EngineStatsReq) (*EngineStatsResp, error)
+	// Retrieve state and space usage of pool targets local to DAOS I/O Engines
+	PoolQueryTargets(context.Context, *PoolQueryTargetsReq) (*PoolQueryTargetsResp, error)
+	// Run a fabric point-to-point test between I/O Engines
+	NetworkSelfTest(context.Context, *NetworkSelfTestReq) (*NetworkSelfTestResp, error)
+	// Run a bandwidth test of local NVMe SSDs
+	StorageSelfTest(context.Context, *StorageSelfTestReq) (*StorageSelfTestResp, error)
+	// Retrieve the wall clock time of a server to measure clock skew
+	Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatResp, error)
 	mustEmbedUnimplementedCtlSvcServer()
 }
 
@@ -204,6 +334,15 @@ func (UnimplementedCtlSvcServer) StorageScan(context.Context, *StorageScanReq) (
 func (UnimplementedCtlSvcServer) StorageFormat(context.Context, *StorageFormatReq) (*StorageFormatResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StorageFormat not implemented")
 }
+func (UnimplementedCtlSvcServer) StorageOwnershipQuery(context.Context, *StorageOwnershipReq) (*StorageOwnershipResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorageOwnershipQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) StorageVerifyQuery(context.Context, *StorageVerifyReq) (*StorageVerifyResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorageVerifyQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) BdevCapabilitiesQuery(context.Context, *BdevCapabilitiesReq) (*BdevCapabilitiesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BdevCapabilitiesQuery not implemented")
+}
 func (UnimplementedCtlSvcServer) NetworkScan(context.Context, *NetworkScanReq) (*NetworkScanResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NetworkScan not implemented")
 }
@@ -231,6 +370,27 @@ func (UnimplementedCtlSvcServer) ResetFormatRanks(context.Context, *RanksReq) (*
 func (UnimplementedCtlSvcServer) StartRanks(context.Context, *RanksReq) (*RanksResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartRanks not implemented")
 }
+func (UnimplementedCtlSvcServer) ActivateSpare(context.Context, *ActivateSpareReq) (*ActivateSpareResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateSpare not implemented")
+}
+func (UnimplementedCtlSvcServer) VersionQuery(context.Context, *VersionQueryReq) (*VersionQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VersionQuery not implemented")
+}
+func (UnimplementedCtlSvcServer) EngineStats(context.Context, *EngineStatsReq) (*EngineStatsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EngineStats not implemented")
+}
+func (UnimplementedCtlSvcServer) PoolQueryTargets(context.Context, *PoolQueryTargetsReq) (*PoolQueryTargetsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PoolQueryTargets not implemented")
+}
+func (UnimplementedCtlSvcServer) NetworkSelfTest(context.Context, *NetworkSelfTestReq) (*NetworkSelfTestResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NetworkSelfTest not implemented")
+}
+func (UnimplementedCtlSvcServer) StorageSelfTest(context.Context, *StorageSelfTestReq) (*StorageSelfTestResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorageSelfTest not implemented")
+}
+func (UnimplementedCtlSvcServer) Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
 func (UnimplementedCtlSvcServer) mustEmbedUnimplementedCtlSvcServer() {}
 
 // UnsafeCtlSvcServer may be embedded to opt out of forward compatibility for this service.
@@ -298,6 +458,60 @@ func _CtlSvc_StorageFormat_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CtlSvc_StorageOwnershipQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageOwnershipReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).StorageOwnershipQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/StorageOwnershipQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).StorageOwnershipQuery(ctx, req.(*StorageOwnershipReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_StorageVerifyQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageVerifyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).StorageVerifyQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/StorageVerifyQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).StorageVerifyQuery(ctx, req.(*StorageVerifyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_BdevCapabilitiesQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BdevCapabilitiesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).BdevCapabilitiesQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/BdevCapabilitiesQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).BdevCapabilitiesQuery(ctx, req.(*BdevCapabilitiesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CtlSvc_NetworkScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NetworkScanReq)
 	if err := dec(in); err != nil {
@@ -460,6 +674,132 @@ func _CtlSvc_StartRanks_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CtlSvc_ActivateSpare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateSpareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).ActivateSpare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/ActivateSpare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).ActivateSpare(ctx, req.(*ActivateSpareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_VersionQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).VersionQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/VersionQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).VersionQuery(ctx, req.(*VersionQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_EngineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EngineStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).EngineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/EngineStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).EngineStats(ctx, req.(*EngineStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_PoolQueryTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolQueryTargetsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).PoolQueryTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/PoolQueryTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).PoolQueryTargets(ctx, req.(*PoolQueryTargetsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_NetworkSelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkSelfTestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).NetworkSelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/NetworkSelfTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).NetworkSelfTest(ctx, req.(*NetworkSelfTestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_StorageSelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageSelfTestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).StorageSelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/StorageSelfTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).StorageSelfTest(ctx, req.(*StorageSelfTestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CtlSvc_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlSvcServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.CtlSvc/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlSvcServer).Heartbeat(ctx, req.(*HeartbeatReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CtlSvc_ServiceDesc is the grpc.ServiceDesc for CtlSvc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -479,6 +819,18 @@ var CtlSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StorageFormat",
 			Handler:    _CtlSvc_StorageFormat_Handler,
 		},
+		{
+			MethodName: "StorageOwnershipQuery",
+			Handler:    _CtlSvc_StorageOwnershipQuery_Handler,
+		},
+		{
+			MethodName: "StorageVerifyQuery",
+			Handler:    _CtlSvc_StorageVerifyQuery_Handler,
+		},
+		{
+			MethodName: "BdevCapabilitiesQuery",
+			Handler:    _CtlSvc_BdevCapabilitiesQuery_Handler,
+		},
 		{
 			MethodName: "NetworkScan",
 			Handler:    _CtlSvc_NetworkScan_Handler,
@@ -515,6 +867,34 @@ var CtlSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartRanks",
 			Handler:    _CtlSvc_StartRanks_Handler,
 		},
+		{
+			MethodName: "ActivateSpare",
+			Handler:    _CtlSvc_ActivateSpare_Handler,
+		},
+		{
+			MethodName: "VersionQuery",
+			Handler:    _CtlSvc_VersionQuery_Handler,
+		},
+		{
+			MethodName: "EngineStats",
+			Handler:    _CtlSvc_EngineStats_Handler,
+		},
+		{
+			MethodName: "PoolQueryTargets",
+			Handler:    _CtlSvc_PoolQueryTargets_Handler,
+		},
+		{
+			MethodName: "NetworkSelfTest",
+			Handler:    _CtlSvc_NetworkSelfTest_Handler,
+		},
+		{
+			MethodName: "StorageSelfTest",
+			Handler:    _CtlSvc_StorageSelfTest_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _CtlSvc_Heartbeat_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ctl/ctl.proto",
@@ -0,0 +1,1158 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.12.4
+// source: ctl/engine.proto
+
+package ctl
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type XstreamStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *XstreamStatsReq) Reset() {
+	*x = XstreamStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *XstreamStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XstreamStatsReq) ProtoMessage() {}
+
+func (x *XstreamStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XstreamStatsReq.ProtoReflect.Descriptor instead.
+func (*XstreamStatsReq) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{0}
+}
+
+// XstreamStats mirrors dss_xstream_stats structure.
+type XstreamStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	XsId       int32  `protobuf:"varint,1,opt,name=xs_id,json=xsId,proto3" json:"xs_id,omitempty"`                   // xstream ID
+	TgtId      int32  `protobuf:"varint,2,opt,name=tgt_id,json=tgtId,proto3" json:"tgt_id,omitempty"`                // VOS target ID, -1 for system xstreams
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                // xstream name
+	Main       bool   `protobuf:"varint,4,opt,name=main,proto3" json:"main,omitempty"`                               // true for main (target) xstreams
+	UltsQueued uint64 `protobuf:"varint,5,opt,name=ults_queued,json=ultsQueued,proto3" json:"ults_queued,omitempty"` // ULTs currently queued
+	TotalTime  uint64 `protobuf:"varint,6,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`    // total scheduler CPU time (ms)
+	RelaxTime  uint64 `protobuf:"varint,7,opt,name=relax_time,json=relaxTime,proto3" json:"relax_time,omitempty"`    // idle scheduler CPU time (ms)
+	Cycles     uint64 `protobuf:"varint,8,opt,name=cycles,proto3" json:"cycles,omitempty"`                           // number of scheduling cycles
+}
+
+func (x *XstreamStats) Reset() {
+	*x = XstreamStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *XstreamStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XstreamStats) ProtoMessage() {}
+
+func (x *XstreamStats) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XstreamStats.ProtoReflect.Descriptor instead.
+func (*XstreamStats) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *XstreamStats) GetXsId() int32 {
+	if x != nil {
+		return x.XsId
+	}
+	return 0
+}
+
+func (x *XstreamStats) GetTgtId() int32 {
+	if x != nil {
+		return x.TgtId
+	}
+	return 0
+}
+
+func (x *XstreamStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *XstreamStats) GetMain() bool {
+	if x != nil {
+		return x.Main
+	}
+	return false
+}
+
+func (x *XstreamStats) GetUltsQueued() uint64 {
+	if x != nil {
+		return x.UltsQueued
+	}
+	return 0
+}
+
+func (x *XstreamStats) GetTotalTime() uint64 {
+	if x != nil {
+		return x.TotalTime
+	}
+	return 0
+}
+
+func (x *XstreamStats) GetRelaxTime() uint64 {
+	if x != nil {
+		return x.RelaxTime
+	}
+	return 0
+}
+
+func (x *XstreamStats) GetCycles() uint64 {
+	if x != nil {
+		return x.Cycles
+	}
+	return 0
+}
+
+type XstreamStatsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   int32           `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`    // DAOS error code
+	Xstreams []*XstreamStats `protobuf:"bytes,2,rep,name=xstreams,proto3" json:"xstreams,omitempty"` // per-xstream statistics
+}
+
+func (x *XstreamStatsResp) Reset() {
+	*x = XstreamStatsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *XstreamStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XstreamStatsResp) ProtoMessage() {}
+
+func (x *XstreamStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XstreamStatsResp.ProtoReflect.Descriptor instead.
+func (*XstreamStatsResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *XstreamStatsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *XstreamStatsResp) GetXstreams() []*XstreamStats {
+	if x != nil {
+		return x.Xstreams
+	}
+	return nil
+}
+
+type EngineStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank uint32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // response should only include information about this rank
+}
+
+func (x *EngineStatsReq) Reset() {
+	*x = EngineStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineStatsReq) ProtoMessage() {}
+
+func (x *EngineStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineStatsReq.ProtoReflect.Descriptor instead.
+func (*EngineStatsReq) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EngineStatsReq) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type EngineStatsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranks []*EngineStatsResp_RankResp `protobuf:"bytes,1,rep,name=ranks,proto3" json:"ranks,omitempty"` // List of per-rank responses
+}
+
+func (x *EngineStatsResp) Reset() {
+	*x = EngineStatsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineStatsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineStatsResp) ProtoMessage() {}
+
+func (x *EngineStatsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineStatsResp.ProtoReflect.Descriptor instead.
+func (*EngineStatsResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EngineStatsResp) GetRanks() []*EngineStatsResp_RankResp {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+// PoolTargetsReq requests the state and space usage of the pool targets local
+// to an I/O Engine.
+type PoolTargetsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // pool UUID
+}
+
+func (x *PoolTargetsReq) Reset() {
+	*x = PoolTargetsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolTargetsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolTargetsReq) ProtoMessage() {}
+
+func (x *PoolTargetsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolTargetsReq.ProtoReflect.Descriptor instead.
+func (*PoolTargetsReq) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PoolTargetsReq) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// PoolTargetInfo represents the state and space usage of a single pool target.
+type PoolTargetInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TgtIdx    uint32 `protobuf:"varint,1,opt,name=tgt_idx,json=tgtIdx,proto3" json:"tgt_idx,omitempty"`          // target index on the engine
+	State     string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`                           // pool map target state
+	ScmTotal  uint64 `protobuf:"varint,3,opt,name=scm_total,json=scmTotal,proto3" json:"scm_total,omitempty"`    // total SCM space in bytes
+	ScmFree   uint64 `protobuf:"varint,4,opt,name=scm_free,json=scmFree,proto3" json:"scm_free,omitempty"`       // free SCM space in bytes
+	NvmeTotal uint64 `protobuf:"varint,5,opt,name=nvme_total,json=nvmeTotal,proto3" json:"nvme_total,omitempty"` // total NVMe space in bytes
+	NvmeFree  uint64 `protobuf:"varint,6,opt,name=nvme_free,json=nvmeFree,proto3" json:"nvme_free,omitempty"`    // free NVMe space in bytes
+}
+
+func (x *PoolTargetInfo) Reset() {
+	*x = PoolTargetInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolTargetInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolTargetInfo) ProtoMessage() {}
+
+func (x *PoolTargetInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolTargetInfo.ProtoReflect.Descriptor instead.
+func (*PoolTargetInfo) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PoolTargetInfo) GetTgtIdx() uint32 {
+	if x != nil {
+		return x.TgtIdx
+	}
+	return 0
+}
+
+func (x *PoolTargetInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PoolTargetInfo) GetScmTotal() uint64 {
+	if x != nil {
+		return x.ScmTotal
+	}
+	return 0
+}
+
+func (x *PoolTargetInfo) GetScmFree() uint64 {
+	if x != nil {
+		return x.ScmFree
+	}
+	return 0
+}
+
+func (x *PoolTargetInfo) GetNvmeTotal() uint64 {
+	if x != nil {
+		return x.NvmeTotal
+	}
+	return 0
+}
+
+func (x *PoolTargetInfo) GetNvmeFree() uint64 {
+	if x != nil {
+		return x.NvmeFree
+	}
+	return 0
+}
+
+type PoolTargetsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  int32             `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`  // DAOS error code
+	Targets []*PoolTargetInfo `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"` // per-target information
+}
+
+func (x *PoolTargetsResp) Reset() {
+	*x = PoolTargetsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolTargetsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolTargetsResp) ProtoMessage() {}
+
+func (x *PoolTargetsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolTargetsResp.ProtoReflect.Descriptor instead.
+func (*PoolTargetsResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PoolTargetsResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *PoolTargetsResp) GetTargets() []*PoolTargetInfo {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type PoolQueryTargetsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid        string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                                   // pool UUID
+	Rank        uint32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`                                  // response should only include information about this rank
+	ShowDevices bool   `protobuf:"varint,3,opt,name=show_devices,json=showDevices,proto3" json:"show_devices,omitempty"` // resolve the storage devices backing each target
+}
+
+func (x *PoolQueryTargetsReq) Reset() {
+	*x = PoolQueryTargetsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolQueryTargetsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolQueryTargetsReq) ProtoMessage() {}
+
+func (x *PoolQueryTargetsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolQueryTargetsReq.ProtoReflect.Descriptor instead.
+func (*PoolQueryTargetsReq) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PoolQueryTargetsReq) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *PoolQueryTargetsReq) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PoolQueryTargetsReq) GetShowDevices() bool {
+	if x != nil {
+		return x.ShowDevices
+	}
+	return false
+}
+
+// PoolTargetDevice describes the storage devices backing a pool target.
+type PoolTargetDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TgtIdx       uint32 `protobuf:"varint,1,opt,name=tgt_idx,json=tgtIdx,proto3" json:"tgt_idx,omitempty"`                  // target index on the engine
+	NvmeUuid     string `protobuf:"bytes,2,opt,name=nvme_uuid,json=nvmeUuid,proto3" json:"nvme_uuid,omitempty"`             // UUID of the SMD device (blobstore)
+	NvmePciAddr  string `protobuf:"bytes,3,opt,name=nvme_pci_addr,json=nvmePciAddr,proto3" json:"nvme_pci_addr,omitempty"`  // PCI address of the NVMe SSD
+	NvmeSerial   string `protobuf:"bytes,4,opt,name=nvme_serial,json=nvmeSerial,proto3" json:"nvme_serial,omitempty"`       // serial number of the NVMe SSD
+	ScmBlockdev  string `protobuf:"bytes,5,opt,name=scm_blockdev,json=scmBlockdev,proto3" json:"scm_blockdev,omitempty"`    // block device of the SCM namespace
+	ScmNamespace string `protobuf:"bytes,6,opt,name=scm_namespace,json=scmNamespace,proto3" json:"scm_namespace,omitempty"` // UUID of the SCM namespace
+	ScmMount     string `protobuf:"bytes,7,opt,name=scm_mount,json=scmMount,proto3" json:"scm_mount,omitempty"`             // SCM mount point
+}
+
+func (x *PoolTargetDevice) Reset() {
+	*x = PoolTargetDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolTargetDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolTargetDevice) ProtoMessage() {}
+
+func (x *PoolTargetDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolTargetDevice.ProtoReflect.Descriptor instead.
+func (*PoolTargetDevice) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PoolTargetDevice) GetTgtIdx() uint32 {
+	if x != nil {
+		return x.TgtIdx
+	}
+	return 0
+}
+
+func (x *PoolTargetDevice) GetNvmeUuid() string {
+	if x != nil {
+		return x.NvmeUuid
+	}
+	return ""
+}
+
+func (x *PoolTargetDevice) GetNvmePciAddr() string {
+	if x != nil {
+		return x.NvmePciAddr
+	}
+	return ""
+}
+
+func (x *PoolTargetDevice) GetNvmeSerial() string {
+	if x != nil {
+		return x.NvmeSerial
+	}
+	return ""
+}
+
+func (x *PoolTargetDevice) GetScmBlockdev() string {
+	if x != nil {
+		return x.ScmBlockdev
+	}
+	return ""
+}
+
+func (x *PoolTargetDevice) GetScmNamespace() string {
+	if x != nil {
+		return x.ScmNamespace
+	}
+	return ""
+}
+
+func (x *PoolTargetDevice) GetScmMount() string {
+	if x != nil {
+		return x.ScmMount
+	}
+	return ""
+}
+
+type PoolQueryTargetsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranks []*PoolQueryTargetsResp_RankResp `protobuf:"bytes,1,rep,name=ranks,proto3" json:"ranks,omitempty"` // List of per-rank responses
+}
+
+func (x *PoolQueryTargetsResp) Reset() {
+	*x = PoolQueryTargetsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolQueryTargetsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolQueryTargetsResp) ProtoMessage() {}
+
+func (x *PoolQueryTargetsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolQueryTargetsResp.ProtoReflect.Descriptor instead.
+func (*PoolQueryTargetsResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PoolQueryTargetsResp) GetRanks() []*PoolQueryTargetsResp_RankResp {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+type EngineStatsResp_RankResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank     uint32          `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`        // rank to which this response corresponds
+	Xstreams []*XstreamStats `protobuf:"bytes,2,rep,name=xstreams,proto3" json:"xstreams,omitempty"` // per-xstream statistics
+}
+
+func (x *EngineStatsResp_RankResp) Reset() {
+	*x = EngineStatsResp_RankResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EngineStatsResp_RankResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngineStatsResp_RankResp) ProtoMessage() {}
+
+func (x *EngineStatsResp_RankResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngineStatsResp_RankResp.ProtoReflect.Descriptor instead.
+func (*EngineStatsResp_RankResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *EngineStatsResp_RankResp) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *EngineStatsResp_RankResp) GetXstreams() []*XstreamStats {
+	if x != nil {
+		return x.Xstreams
+	}
+	return nil
+}
+
+type PoolQueryTargetsResp_RankResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank    uint32              `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`      // rank to which this response corresponds
+	Targets []*PoolTargetInfo   `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"` // per-target information
+	Devices []*PoolTargetDevice `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"` // per-target devices, if requested
+}
+
+func (x *PoolQueryTargetsResp_RankResp) Reset() {
+	*x = PoolQueryTargetsResp_RankResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_engine_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolQueryTargetsResp_RankResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolQueryTargetsResp_RankResp) ProtoMessage() {}
+
+func (x *PoolQueryTargetsResp_RankResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_engine_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolQueryTargetsResp_RankResp.ProtoReflect.Descriptor instead.
+func (*PoolQueryTargetsResp_RankResp) Descriptor() ([]byte, []int) {
+	return file_ctl_engine_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *PoolQueryTargetsResp_RankResp) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PoolQueryTargetsResp_RankResp) GetTargets() []*PoolTargetInfo {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *PoolQueryTargetsResp_RankResp) GetDevices() []*PoolTargetDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+var File_ctl_engine_proto protoreflect.FileDescriptor
+
+var file_ctl_engine_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x63, 0x74, 0x6c, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0x11, 0x0a, 0x0f, 0x58, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x22, 0xd9, 0x01, 0x0a, 0x0c, 0x58,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x78,
+	0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x78, 0x73, 0x49, 0x64,
+	0x12, 0x15, 0x0a, 0x06, 0x74, 0x67, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x74, 0x67, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x75, 0x6c, 0x74, 0x73, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x75, 0x6c, 0x74, 0x73, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6c, 0x61, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x22, 0x59, 0x0a, 0x10, 0x58, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2d, 0x0a, 0x08, 0x78, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x58, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x78, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x73, 0x22, 0x24, 0x0a, 0x0e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x22, 0x95, 0x01, 0x0a, 0x0f, 0x45, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x33, 0x0a, 0x05, 0x72,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x74, 0x6c,
+	0x2e, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73,
+	0x1a, 0x4d, 0x0a, 0x08, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b,
+	0x12, 0x2d, 0x0a, 0x08, 0x78, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x58, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x78, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22,
+	0x24, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0xb3, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x67, 0x74, 0x5f,
+	0x69, 0x64, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x67, 0x74, 0x49, 0x64,
+	0x78, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x6d, 0x5f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x63, 0x6d, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x63, 0x6d, 0x5f, 0x66, 0x72, 0x65, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x63, 0x6d, 0x46, 0x72, 0x65, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1b,
+	0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x6e, 0x76, 0x6d, 0x65, 0x46, 0x72, 0x65, 0x65, 0x22, 0x58, 0x0a, 0x0f, 0x50,
+	0x6f, 0x6f, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x13, 0x50, 0x6f, 0x6f, 0x6c, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x68, 0x6f, 0x77,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0xf2, 0x01, 0x0a, 0x10, 0x50, 0x6f, 0x6f, 0x6c,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x74, 0x67, 0x74, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74,
+	0x67, 0x74, 0x49, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x76, 0x6d, 0x65, 0x55, 0x75,
+	0x69, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x70, 0x63, 0x69, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x76, 0x6d, 0x65, 0x50,
+	0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x76, 0x6d,
+	0x65, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6d, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x64, 0x65, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
+	0x63, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x64, 0x65, 0x76, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63,
+	0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x63, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x63, 0x6d, 0x5f, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x6d, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd0, 0x01, 0x0a,
+	0x14, 0x50, 0x6f, 0x6f, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x38, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e,
+	0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x1a,
+	0x7e, 0x0a, 0x08, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12,
+	0x2d, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x2f,
+	0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x42,
+	0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61,
+	0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_ctl_engine_proto_rawDescOnce sync.Once
+	file_ctl_engine_proto_rawDescData = file_ctl_engine_proto_rawDesc
+)
+
+func file_ctl_engine_proto_rawDescGZIP() []byte {
+	file_ctl_engine_proto_rawDescOnce.Do(func() {
+		file_ctl_engine_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_engine_proto_rawDescData)
+	})
+	return file_ctl_engine_proto_rawDescData
+}
+
+var file_ctl_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_ctl_engine_proto_goTypes = []interface{}{
+	(*XstreamStatsReq)(nil),               // 0: ctl.XstreamStatsReq
+	(*XstreamStats)(nil),                  // 1: ctl.XstreamStats
+	(*XstreamStatsResp)(nil),              // 2: ctl.XstreamStatsResp
+	(*EngineStatsReq)(nil),                // 3: ctl.EngineStatsReq
+	(*EngineStatsResp)(nil),               // 4: ctl.EngineStatsResp
+	(*PoolTargetsReq)(nil),                // 5: ctl.PoolTargetsReq
+	(*PoolTargetInfo)(nil),                // 6: ctl.PoolTargetInfo
+	(*PoolTargetsResp)(nil),               // 7: ctl.PoolTargetsResp
+	(*PoolQueryTargetsReq)(nil),           // 8: ctl.PoolQueryTargetsReq
+	(*PoolTargetDevice)(nil),              // 9: ctl.PoolTargetDevice
+	(*PoolQueryTargetsResp)(nil),          // 10: ctl.PoolQueryTargetsResp
+	(*EngineStatsResp_RankResp)(nil),      // 11: ctl.EngineStatsResp.RankResp
+	(*PoolQueryTargetsResp_RankResp)(nil), // 12: ctl.PoolQueryTargetsResp.RankResp
+}
+var file_ctl_engine_proto_depIdxs = []int32{
+	1,  // 0: ctl.XstreamStatsResp.xstreams:type_name -> ctl.XstreamStats
+	11, // 1: ctl.EngineStatsResp.ranks:type_name -> ctl.EngineStatsResp.RankResp
+	6,  // 2: ctl.PoolTargetsResp.targets:type_name -> ctl.PoolTargetInfo
+	12, // 3: ctl.PoolQueryTargetsResp.ranks:type_name -> ctl.PoolQueryTargetsResp.RankResp
+	1,  // 4: ctl.EngineStatsResp.RankResp.xstreams:type_name -> ctl.XstreamStats
+	6,  // 5: ctl.PoolQueryTargetsResp.RankResp.targets:type_name -> ctl.PoolTargetInfo
+	9,  // 6: ctl.PoolQueryTargetsResp.RankResp.devices:type_name -> ctl.PoolTargetDevice
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_ctl_engine_proto_init() }
+func file_ctl_engine_proto_init() {
+	if File_ctl_engine_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_engine_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XstreamStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XstreamStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*XstreamStatsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineStatsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolTargetsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolTargetInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolTargetsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolQueryTargetsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolTargetDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolQueryTargetsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EngineStatsResp_RankResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_engine_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolQueryTargetsResp_RankResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ctl_engine_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ctl_engine_proto_goTypes,
+		DependencyIndexes: file_ctl_engine_proto_depIdxs,
+		MessageInfos:      file_ctl_engine_proto_msgTypes,
+	}.Build()
+	File_ctl_engine_proto = out.File
+	file_ctl_engine_proto_rawDesc = nil
+	file_ctl_engine_proto_goTypes = nil
+	file_ctl_engine_proto_depIdxs = nil
+}
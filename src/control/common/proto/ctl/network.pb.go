@@ -89,6 +89,7 @@ type NetworkScanResp struct {
 	Interfaces   []*FabricInterface `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
 	Numacount    int32              `protobuf:"varint,2,opt,name=numacount,proto3" json:"numacount,omitempty"`
 	Corespernuma int32              `protobuf:"varint,3,opt,name=corespernuma,proto3" json:"corespernuma,omitempty"` // physical cores per numa node
+	Labels       []*HostLabel       `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty"`              // site-specific labels from discovery plugin
 }
 
 func (x *NetworkScanResp) Reset() {
@@ -144,6 +145,68 @@ func (x *NetworkScanResp) GetCorespernuma() int32 {
 	return 0
 }
 
+func (x *NetworkScanResp) GetLabels() []*HostLabel {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type HostLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *HostLabel) Reset() {
+	*x = HostLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_network_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostLabel) ProtoMessage() {}
+
+func (x *HostLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_network_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostLabel.ProtoReflect.Descriptor instead.
+func (*HostLabel) Descriptor() ([]byte, []int) {
+	return file_ctl_network_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HostLabel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HostLabel) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 type FabricInterface struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -154,12 +217,16 @@ type FabricInterface struct {
 	Numanode    uint32 `protobuf:"varint,3,opt,name=numanode,proto3" json:"numanode,omitempty"`
 	Priority    uint32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
 	Netdevclass uint32 `protobuf:"varint,5,opt,name=netdevclass,proto3" json:"netdevclass,omitempty"`
+	Alias       string `protobuf:"bytes,6,opt,name=alias,proto3" json:"alias,omitempty"`          // site-specific name from discovery plugin
+	Physfn      string `protobuf:"bytes,7,opt,name=physfn,proto3" json:"physfn,omitempty"`        // physical function if device is an SR-IOV virtual function
+	Linkstate   string `protobuf:"bytes,8,opt,name=linkstate,proto3" json:"linkstate,omitempty"`  // operational state of the interface link
+	Linkspeed   uint32 `protobuf:"varint,9,opt,name=linkspeed,proto3" json:"linkspeed,omitempty"` // speed of the interface link in Mb/s, zero if unknown
 }
 
 func (x *FabricInterface) Reset() {
 	*x = FabricInterface{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_network_proto_msgTypes[2]
+		mi := &file_ctl_network_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -172,7 +239,7 @@ func (x *FabricInterface) String() string {
 func (*FabricInterface) ProtoMessage() {}
 
 func (x *FabricInterface) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_network_proto_msgTypes[2]
+	mi := &file_ctl_network_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,7 +252,7 @@ func (x *FabricInterface) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FabricInterface.ProtoReflect.Descriptor instead.
 func (*FabricInterface) Descriptor() ([]byte, []int) {
-	return file_ctl_network_proto_rawDescGZIP(), []int{2}
+	return file_ctl_network_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *FabricInterface) GetProvider() string {
@@ -223,6 +290,34 @@ func (x *FabricInterface) GetNetdevclass() uint32 {
 	return 0
 }
 
+func (x *FabricInterface) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *FabricInterface) GetPhysfn() string {
+	if x != nil {
+		return x.Physfn
+	}
+	return ""
+}
+
+func (x *FabricInterface) GetLinkstate() string {
+	if x != nil {
+		return x.Linkstate
+	}
+	return ""
+}
+
+func (x *FabricInterface) GetLinkspeed() uint32 {
+	if x != nil {
+		return x.Linkspeed
+	}
+	return 0
+}
+
 var File_ctl_network_proto protoreflect.FileDescriptor
 
 var file_ctl_network_proto_rawDesc = []byte{
@@ -233,7 +328,7 @@ var file_ctl_network_proto_rawDesc = []byte{
 	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
 	0x65, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x0f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x61, 0x63, 0x65, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x0f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
 	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65,
 	0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63,
 	0x74, 0x6c, 0x2e, 0x46, 0x61, 0x62, 0x72, 0x69, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
@@ -242,21 +337,33 @@ var file_ctl_network_proto_rawDesc = []byte{
 	0x05, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x61, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c,
 	0x63, 0x6f, 0x72, 0x65, 0x73, 0x70, 0x65, 0x72, 0x6e, 0x75, 0x6d, 0x61, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x05, 0x52, 0x0c, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x70, 0x65, 0x72, 0x6e, 0x75, 0x6d, 0x61,
-	0x22, 0x9f, 0x01, 0x0a, 0x0f, 0x46, 0x61, 0x62, 0x72, 0x69, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x61,
-	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x61,
-	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
-	0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x63, 0x6c, 0x61,
-	0x73, 0x73, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73,
-	0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x26, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x35, 0x0a, 0x09, 0x48, 0x6f, 0x73, 0x74,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x89, 0x02, 0x0a, 0x0f, 0x46, 0x61, 0x62, 0x72, 0x69, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x61, 0x6e,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x61, 0x6e,
+	0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x79, 0x73, 0x66,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x68, 0x79, 0x73, 0x66, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x70, 0x65, 0x65, 0x64, 0x42, 0x39, 0x5a, 0x37, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73,
+	0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -271,19 +378,21 @@ func file_ctl_network_proto_rawDescGZIP() []byte {
 	return file_ctl_network_proto_rawDescData
 }
 
-var file_ctl_network_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ctl_network_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ctl_network_proto_goTypes = []interface{}{
 	(*NetworkScanReq)(nil),  // 0: ctl.NetworkScanReq
 	(*NetworkScanResp)(nil), // 1: ctl.NetworkScanResp
-	(*FabricInterface)(nil), // 2: ctl.FabricInterface
+	(*HostLabel)(nil),       // 2: ctl.HostLabel
+	(*FabricInterface)(nil), // 3: ctl.FabricInterface
 }
 var file_ctl_network_proto_depIdxs = []int32{
-	2, // 0: ctl.NetworkScanResp.interfaces:type_name -> ctl.FabricInterface
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: ctl.NetworkScanResp.interfaces:type_name -> ctl.FabricInterface
+	2, // 1: ctl.NetworkScanResp.labels:type_name -> ctl.HostLabel
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_ctl_network_proto_init() }
@@ -317,6 +426,18 @@ func file_ctl_network_proto_init() {
 			}
 		}
 		file_ctl_network_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_network_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FabricInterface); i {
 			case 0:
 				return &v.state
@@ -335,7 +456,7 @@ func file_ctl_network_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ctl_network_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
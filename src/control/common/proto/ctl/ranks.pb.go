@@ -34,8 +34,9 @@ type RanksReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Force bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"` // force operation
-	Ranks string `protobuf:"bytes,4,opt,name=ranks,proto3" json:"ranks,omitempty"`  // rankset to operate over
+	Force    bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`                       // force operation
+	Ranks    string `protobuf:"bytes,4,opt,name=ranks,proto3" json:"ranks,omitempty"`                        // rankset to operate over
+	NvmeTrim bool   `protobuf:"varint,5,opt,name=nvme_trim,json=nvmeTrim,proto3" json:"nvme_trim,omitempty"` // deallocate NVMe SSDs on reset format
 }
 
 func (x *RanksReq) Reset() {
@@ -84,6 +85,13 @@ func (x *RanksReq) GetRanks() string {
 	return ""
 }
 
+func (x *RanksReq) GetNvmeTrim() bool {
+	if x != nil {
+		return x.NvmeTrim
+	}
+	return false
+}
+
 // Generic response containing DER result from multiple ranks.
 // Used in gRPC fanout to operate on hosts with multiple ranks.
 type RanksResp struct {
@@ -133,23 +141,168 @@ func (x *RanksResp) GetResults() []*shared.RankResult {
 	return nil
 }
 
+// ActivateSpareReq requests that a spare engine on the host take over a failed
+// rank held by another engine on the same host.
+type ActivateSpareReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank uint32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // failed rank to be replaced
+}
+
+func (x *ActivateSpareReq) Reset() {
+	*x = ActivateSpareReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_ranks_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateSpareReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateSpareReq) ProtoMessage() {}
+
+func (x *ActivateSpareReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_ranks_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateSpareReq.ProtoReflect.Descriptor instead.
+func (*ActivateSpareReq) Descriptor() ([]byte, []int) {
+	return file_ctl_ranks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ActivateSpareReq) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// ActivateSpareResp returns the outcome of activating a spare engine.
+type ActivateSpareResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpareIdx        uint32   `protobuf:"varint,1,opt,name=spare_idx,json=spareIdx,proto3" json:"spare_idx,omitempty"`                     // index of the activated spare engine
+	FailedIdx       uint32   `protobuf:"varint,2,opt,name=failed_idx,json=failedIdx,proto3" json:"failed_idx,omitempty"`                  // index of the engine that held the rank
+	ReleasedDevices []string `protobuf:"bytes,3,rep,name=released_devices,json=releasedDevices,proto3" json:"released_devices,omitempty"` // devices of the failed engine released from the ledger
+	State           string   `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`                                            // state of the spare engine after activation
+	SpareRank       uint32   `protobuf:"varint,5,opt,name=spare_rank,json=spareRank,proto3" json:"spare_rank,omitempty"`                  // rank assigned to the spare engine on joining the system
+}
+
+func (x *ActivateSpareResp) Reset() {
+	*x = ActivateSpareResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_ranks_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateSpareResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateSpareResp) ProtoMessage() {}
+
+func (x *ActivateSpareResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_ranks_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateSpareResp.ProtoReflect.Descriptor instead.
+func (*ActivateSpareResp) Descriptor() ([]byte, []int) {
+	return file_ctl_ranks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ActivateSpareResp) GetSpareIdx() uint32 {
+	if x != nil {
+		return x.SpareIdx
+	}
+	return 0
+}
+
+func (x *ActivateSpareResp) GetFailedIdx() uint32 {
+	if x != nil {
+		return x.FailedIdx
+	}
+	return 0
+}
+
+func (x *ActivateSpareResp) GetReleasedDevices() []string {
+	if x != nil {
+		return x.ReleasedDevices
+	}
+	return nil
+}
+
+func (x *ActivateSpareResp) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ActivateSpareResp) GetSpareRank() uint32 {
+	if x != nil {
+		return x.SpareRank
+	}
+	return 0
+}
+
 var File_ctl_ranks_proto protoreflect.FileDescriptor
 
 var file_ctl_ranks_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x63, 0x74, 0x6c, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x1a, 0x12, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2f, 0x72,
-	0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x08, 0x52, 0x61,
+	0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x53, 0x0a, 0x08, 0x52, 0x61,
 	0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18,
 	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e,
-	0x6b, 0x73, 0x22, 0x39, 0x0a, 0x09, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x42, 0x39, 0x5a,
-	0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73,
-	0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x76, 0x6d, 0x65, 0x54, 0x72, 0x69, 0x6d, 0x22,
+	0x39, 0x0a, 0x09, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61,
+	0x6e, 0x6b, 0x22, 0xaf, 0x01, 0x0a, 0x11, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53,
+	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x61, 0x72,
+	0x65, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x70, 0x61,
+	0x72, 0x65, 0x49, 0x64, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x69, 0x64, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x49, 0x64, 0x78, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64,
+	0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x72,
+	0x61, 0x6e, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x61, 0x6e, 0x6b, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61,
+	0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -164,14 +317,16 @@ func file_ctl_ranks_proto_rawDescGZIP() []byte {
 	return file_ctl_ranks_proto_rawDescData
 }
 
-var file_ctl_ranks_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ctl_ranks_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ctl_ranks_proto_goTypes = []interface{}{
 	(*RanksReq)(nil),          // 0: ctl.RanksReq
 	(*RanksResp)(nil),         // 1: ctl.RanksResp
-	(*shared.RankResult)(nil), // 2: shared.RankResult
+	(*ActivateSpareReq)(nil),  // 2: ctl.ActivateSpareReq
+	(*ActivateSpareResp)(nil), // 3: ctl.ActivateSpareResp
+	(*shared.RankResult)(nil), // 4: shared.RankResult
 }
 var file_ctl_ranks_proto_depIdxs = []int32{
-	2, // 0: ctl.RanksResp.results:type_name -> shared.RankResult
+	4, // 0: ctl.RanksResp.results:type_name -> shared.RankResult
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -209,6 +364,30 @@ func file_ctl_ranks_proto_init() {
 				return nil
 			}
 		}
+		file_ctl_ranks_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivateSpareReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_ranks_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActivateSpareResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -216,7 +395,7 @@ func file_ctl_ranks_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ctl_ranks_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,796 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.12.4
+// source: ctl/selftest.proto
+
+package ctl
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RankUri associates a system rank with its fabric URI.
+type RankUri struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank uint32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Uri  string `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+}
+
+func (x *RankUri) Reset() {
+	*x = RankUri{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RankUri) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankUri) ProtoMessage() {}
+
+func (x *RankUri) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankUri.ProtoReflect.Descriptor instead.
+func (*RankUri) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RankUri) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *RankUri) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// NetworkSelfTestReq requests a fabric point-to-point test between the
+// I/O Engines of the given ranks, driven from the receiving host.
+type NetworkSelfTestReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys         string     `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`                                     // DAOS system name
+	Provider    string     `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`                           // fabric provider in use by the system
+	RankUris    []*RankUri `protobuf:"bytes,3,rep,name=rank_uris,json=rankUris,proto3" json:"rank_uris,omitempty"`           // fabric URIs of system ranks
+	SrcRanks    string     `protobuf:"bytes,4,opt,name=src_ranks,json=srcRanks,proto3" json:"src_ranks,omitempty"`           // rankset sending test messages
+	DstRanks    string     `protobuf:"bytes,5,opt,name=dst_ranks,json=dstRanks,proto3" json:"dst_ranks,omitempty"`           // rankset receiving test messages
+	MsgSizes    []uint32   `protobuf:"varint,6,rep,packed,name=msg_sizes,json=msgSizes,proto3" json:"msg_sizes,omitempty"`   // message sizes in bytes
+	Repetitions uint32     `protobuf:"varint,7,opt,name=repetitions,proto3" json:"repetitions,omitempty"`                    // messages per size
+	MaxInflight uint32     `protobuf:"varint,8,opt,name=max_inflight,json=maxInflight,proto3" json:"max_inflight,omitempty"` // max concurrent messages per source
+}
+
+func (x *NetworkSelfTestReq) Reset() {
+	*x = NetworkSelfTestReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkSelfTestReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSelfTestReq) ProtoMessage() {}
+
+func (x *NetworkSelfTestReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSelfTestReq.ProtoReflect.Descriptor instead.
+func (*NetworkSelfTestReq) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NetworkSelfTestReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *NetworkSelfTestReq) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *NetworkSelfTestReq) GetRankUris() []*RankUri {
+	if x != nil {
+		return x.RankUris
+	}
+	return nil
+}
+
+func (x *NetworkSelfTestReq) GetSrcRanks() string {
+	if x != nil {
+		return x.SrcRanks
+	}
+	return ""
+}
+
+func (x *NetworkSelfTestReq) GetDstRanks() string {
+	if x != nil {
+		return x.DstRanks
+	}
+	return ""
+}
+
+func (x *NetworkSelfTestReq) GetMsgSizes() []uint32 {
+	if x != nil {
+		return x.MsgSizes
+	}
+	return nil
+}
+
+func (x *NetworkSelfTestReq) GetRepetitions() uint32 {
+	if x != nil {
+		return x.Repetitions
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestReq) GetMaxInflight() uint32 {
+	if x != nil {
+		return x.MaxInflight
+	}
+	return 0
+}
+
+// NetworkSelfTestResult contains the measurements for one source rank
+// and message size.
+type NetworkSelfTestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SrcRank    uint32  `protobuf:"varint,1,opt,name=src_rank,json=srcRank,proto3" json:"src_rank,omitempty"`
+	MsgSize    uint32  `protobuf:"varint,2,opt,name=msg_size,json=msgSize,proto3" json:"msg_size,omitempty"`       // bytes
+	Bandwidth  float64 `protobuf:"fixed64,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`                 // MB/s
+	Throughput float64 `protobuf:"fixed64,4,opt,name=throughput,proto3" json:"throughput,omitempty"`               // RPCs/s
+	LatMin     uint64  `protobuf:"varint,5,opt,name=lat_min,json=latMin,proto3" json:"lat_min,omitempty"`          // us
+	LatMedian  uint64  `protobuf:"varint,6,opt,name=lat_median,json=latMedian,proto3" json:"lat_median,omitempty"` // us
+	LatMax     uint64  `protobuf:"varint,7,opt,name=lat_max,json=latMax,proto3" json:"lat_max,omitempty"`          // us
+	LatAvg     uint64  `protobuf:"varint,8,opt,name=lat_avg,json=latAvg,proto3" json:"lat_avg,omitempty"`          // us
+	Failures   uint32  `protobuf:"varint,9,opt,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *NetworkSelfTestResult) Reset() {
+	*x = NetworkSelfTestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkSelfTestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSelfTestResult) ProtoMessage() {}
+
+func (x *NetworkSelfTestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSelfTestResult.ProtoReflect.Descriptor instead.
+func (*NetworkSelfTestResult) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NetworkSelfTestResult) GetSrcRank() uint32 {
+	if x != nil {
+		return x.SrcRank
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetMsgSize() uint32 {
+	if x != nil {
+		return x.MsgSize
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetBandwidth() float64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetThroughput() float64 {
+	if x != nil {
+		return x.Throughput
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetLatMin() uint64 {
+	if x != nil {
+		return x.LatMin
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetLatMedian() uint64 {
+	if x != nil {
+		return x.LatMedian
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetLatMax() uint64 {
+	if x != nil {
+		return x.LatMax
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetLatAvg() uint64 {
+	if x != nil {
+		return x.LatAvg
+	}
+	return 0
+}
+
+func (x *NetworkSelfTestResult) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+type NetworkSelfTestResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*NetworkSelfTestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *NetworkSelfTestResp) Reset() {
+	*x = NetworkSelfTestResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkSelfTestResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkSelfTestResp) ProtoMessage() {}
+
+func (x *NetworkSelfTestResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkSelfTestResp.ProtoReflect.Descriptor instead.
+func (*NetworkSelfTestResp) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NetworkSelfTestResp) GetResults() []*NetworkSelfTestResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// StorageSelfTestReq requests a bandwidth test of local NVMe SSDs.
+type StorageSelfTestReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PciAddrs   []string `protobuf:"bytes,1,rep,name=pci_addrs,json=pciAddrs,proto3" json:"pci_addrs,omitempty"` // devices to test, all configured if empty
+	Workload   string   `protobuf:"bytes,2,opt,name=workload,proto3" json:"workload,omitempty"`                 // read, write, randread or randwrite
+	IoSize     uint32   `protobuf:"varint,3,opt,name=io_size,json=ioSize,proto3" json:"io_size,omitempty"`      // bytes
+	QueueDepth uint32   `protobuf:"varint,4,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	Duration   uint32   `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"` // seconds
+	Force      bool     `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`       // allow write workloads, which destroy data on the SSDs
+}
+
+func (x *StorageSelfTestReq) Reset() {
+	*x = StorageSelfTestReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageSelfTestReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageSelfTestReq) ProtoMessage() {}
+
+func (x *StorageSelfTestReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageSelfTestReq.ProtoReflect.Descriptor instead.
+func (*StorageSelfTestReq) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StorageSelfTestReq) GetPciAddrs() []string {
+	if x != nil {
+		return x.PciAddrs
+	}
+	return nil
+}
+
+func (x *StorageSelfTestReq) GetWorkload() string {
+	if x != nil {
+		return x.Workload
+	}
+	return ""
+}
+
+func (x *StorageSelfTestReq) GetIoSize() uint32 {
+	if x != nil {
+		return x.IoSize
+	}
+	return 0
+}
+
+func (x *StorageSelfTestReq) GetQueueDepth() uint32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *StorageSelfTestReq) GetDuration() uint32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *StorageSelfTestReq) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// StorageSelfTestResult contains the measurements for one NVMe SSD.
+type StorageSelfTestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PciAddr   string  `protobuf:"bytes,1,opt,name=pci_addr,json=pciAddr,proto3" json:"pci_addr,omitempty"`
+	Iops      float64 `protobuf:"fixed64,2,opt,name=iops,proto3" json:"iops,omitempty"`
+	Bandwidth float64 `protobuf:"fixed64,3,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`         // MiB/s
+	LatAvg    float64 `protobuf:"fixed64,4,opt,name=lat_avg,json=latAvg,proto3" json:"lat_avg,omitempty"` // us
+	LatMin    float64 `protobuf:"fixed64,5,opt,name=lat_min,json=latMin,proto3" json:"lat_min,omitempty"` // us
+	LatMax    float64 `protobuf:"fixed64,6,opt,name=lat_max,json=latMax,proto3" json:"lat_max,omitempty"` // us
+}
+
+func (x *StorageSelfTestResult) Reset() {
+	*x = StorageSelfTestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageSelfTestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageSelfTestResult) ProtoMessage() {}
+
+func (x *StorageSelfTestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageSelfTestResult.ProtoReflect.Descriptor instead.
+func (*StorageSelfTestResult) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StorageSelfTestResult) GetPciAddr() string {
+	if x != nil {
+		return x.PciAddr
+	}
+	return ""
+}
+
+func (x *StorageSelfTestResult) GetIops() float64 {
+	if x != nil {
+		return x.Iops
+	}
+	return 0
+}
+
+func (x *StorageSelfTestResult) GetBandwidth() float64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *StorageSelfTestResult) GetLatAvg() float64 {
+	if x != nil {
+		return x.LatAvg
+	}
+	return 0
+}
+
+func (x *StorageSelfTestResult) GetLatMin() float64 {
+	if x != nil {
+		return x.LatMin
+	}
+	return 0
+}
+
+func (x *StorageSelfTestResult) GetLatMax() float64 {
+	if x != nil {
+		return x.LatMax
+	}
+	return 0
+}
+
+type StorageSelfTestResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*StorageSelfTestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *StorageSelfTestResp) Reset() {
+	*x = StorageSelfTestResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_selftest_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageSelfTestResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageSelfTestResp) ProtoMessage() {}
+
+func (x *StorageSelfTestResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_selftest_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageSelfTestResp.ProtoReflect.Descriptor instead.
+func (*StorageSelfTestResp) Descriptor() ([]byte, []int) {
+	return file_ctl_selftest_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StorageSelfTestResp) GetResults() []*StorageSelfTestResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_ctl_selftest_proto protoreflect.FileDescriptor
+
+var file_ctl_selftest_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x65, 0x6c, 0x66, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x22, 0x2f, 0x0a, 0x07, 0x52, 0x61, 0x6e,
+	0x6b, 0x55, 0x72, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0x89, 0x02, 0x0a, 0x12, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x79, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x29, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x75, 0x72, 0x69, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x55, 0x72, 0x69,
+	0x52, 0x08, 0x72, 0x61, 0x6e, 0x6b, 0x55, 0x72, 0x69, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x72,
+	0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x72, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x73, 0x74, 0x5f, 0x72,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x73, 0x74, 0x52,
+	0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x73, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x73, 0x67, 0x53, 0x69, 0x7a, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x66, 0x6c, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6e,
+	0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0x91, 0x02, 0x0a, 0x15, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x72, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x73, 0x72, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x73, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d,
+	0x73, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77,
+	0x69, 0x64, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x70,
+	0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67,
+	0x68, 0x70, 0x75, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x61, 0x74, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x61, 0x74, 0x5f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x12, 0x17, 0x0a, 0x07,
+	0x6c, 0x61, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c,
+	0x61, 0x74, 0x4d, 0x61, 0x78, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x5f, 0x61, 0x76, 0x67,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x61, 0x74, 0x41, 0x76, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x13, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6f, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x69, 0x6f, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74,
+	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x22, 0xaf, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53,
+	0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6f, 0x70, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x69, 0x6f, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61,
+	0x74, 0x5f, 0x61, 0x76, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x61, 0x74,
+	0x41, 0x76, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x61, 0x74, 0x4d, 0x69, 0x6e, 0x12, 0x17, 0x0a, 0x07,
+	0x6c, 0x61, 0x74, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c,
+	0x61, 0x74, 0x4d, 0x61, 0x78, 0x22, 0x4b, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x53, 0x65, 0x6c, 0x66, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x34, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x63, 0x74, 0x6c, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6c, 0x66, 0x54,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73,
+	0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ctl_selftest_proto_rawDescOnce sync.Once
+	file_ctl_selftest_proto_rawDescData = file_ctl_selftest_proto_rawDesc
+)
+
+func file_ctl_selftest_proto_rawDescGZIP() []byte {
+	file_ctl_selftest_proto_rawDescOnce.Do(func() {
+		file_ctl_selftest_proto_rawDescData = protoimpl.X.CompressGZIP(file_ctl_selftest_proto_rawDescData)
+	})
+	return file_ctl_selftest_proto_rawDescData
+}
+
+var file_ctl_selftest_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_ctl_selftest_proto_goTypes = []interface{}{
+	(*RankUri)(nil),               // 0: ctl.RankUri
+	(*NetworkSelfTestReq)(nil),    // 1: ctl.NetworkSelfTestReq
+	(*NetworkSelfTestResult)(nil), // 2: ctl.NetworkSelfTestResult
+	(*NetworkSelfTestResp)(nil),   // 3: ctl.NetworkSelfTestResp
+	(*StorageSelfTestReq)(nil),    // 4: ctl.StorageSelfTestReq
+	(*StorageSelfTestResult)(nil), // 5: ctl.StorageSelfTestResult
+	(*StorageSelfTestResp)(nil),   // 6: ctl.StorageSelfTestResp
+}
+var file_ctl_selftest_proto_depIdxs = []int32{
+	0, // 0: ctl.NetworkSelfTestReq.rank_uris:type_name -> ctl.RankUri
+	2, // 1: ctl.NetworkSelfTestResp.results:type_name -> ctl.NetworkSelfTestResult
+	5, // 2: ctl.StorageSelfTestResp.results:type_name -> ctl.StorageSelfTestResult
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_ctl_selftest_proto_init() }
+func file_ctl_selftest_proto_init() {
+	if File_ctl_selftest_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ctl_selftest_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RankUri); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkSelfTestReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkSelfTestResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetworkSelfTestResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageSelfTestReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageSelfTestResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_selftest_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageSelfTestResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ctl_selftest_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ctl_selftest_proto_goTypes,
+		DependencyIndexes: file_ctl_selftest_proto_depIdxs,
+		MessageInfos:      file_ctl_selftest_proto_msgTypes,
+	}.Build()
+	File_ctl_selftest_proto = out.File
+	file_ctl_selftest_proto_rawDesc = nil
+	file_ctl_selftest_proto_goTypes = nil
+	file_ctl_selftest_proto_depIdxs = nil
+}
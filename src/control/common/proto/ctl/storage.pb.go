@@ -86,8 +86,10 @@ type StoragePrepareResp struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nvme *PrepareNvmeResp `protobuf:"bytes,1,opt,name=nvme,proto3" json:"nvme,omitempty"`
-	Scm  *PrepareScmResp  `protobuf:"bytes,2,opt,name=scm,proto3" json:"scm,omitempty"`
+	Nvme     *PrepareNvmeResp `protobuf:"bytes,1,opt,name=nvme,proto3" json:"nvme,omitempty"`
+	Scm      *PrepareScmResp  `protobuf:"bytes,2,opt,name=scm,proto3" json:"scm,omitempty"`
+	Warnings []string         `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"` // Advisories that did not prevent success
+	Timings  []*PhaseTiming   `protobuf:"bytes,4,rep,name=timings,proto3" json:"timings,omitempty"`   // Duration of each phase of the prepare
 }
 
 func (x *StoragePrepareResp) Reset() {
@@ -136,6 +138,20 @@ func (x *StoragePrepareResp) GetScm() *PrepareScmResp {
 	return nil
 }
 
+func (x *StoragePrepareResp) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *StoragePrepareResp) GetTimings() []*PhaseTiming {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
 type StorageScanReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -196,8 +212,9 @@ type StorageScanResp struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nvme *ScanNvmeResp `protobuf:"bytes,1,opt,name=nvme,proto3" json:"nvme,omitempty"`
-	Scm  *ScanScmResp  `protobuf:"bytes,2,opt,name=scm,proto3" json:"scm,omitempty"`
+	Nvme   *ScanNvmeResp  `protobuf:"bytes,1,opt,name=nvme,proto3" json:"nvme,omitempty"`
+	Scm    *ScanScmResp   `protobuf:"bytes,2,opt,name=scm,proto3" json:"scm,omitempty"`
+	Owners []*DeviceOwner `protobuf:"bytes,3,rep,name=owners,proto3" json:"owners,omitempty"` // Devices recorded in the ledger
 }
 
 func (x *StorageScanResp) Reset() {
@@ -246,6 +263,13 @@ func (x *StorageScanResp) GetScm() *ScanScmResp {
 	return nil
 }
 
+func (x *StorageScanResp) GetOwners() []*DeviceOwner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
 type StorageFormatReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -254,6 +278,7 @@ type StorageFormatReq struct {
 	Nvme     *FormatNvmeReq `protobuf:"bytes,1,opt,name=nvme,proto3" json:"nvme,omitempty"`
 	Scm      *FormatScmReq  `protobuf:"bytes,2,opt,name=scm,proto3" json:"scm,omitempty"`
 	Reformat bool           `protobuf:"varint,3,opt,name=reformat,proto3" json:"reformat,omitempty"`
+	Restart  bool           `protobuf:"varint,4,opt,name=restart,proto3" json:"restart,omitempty"` // discard state of a previous partial format
 }
 
 func (x *StorageFormatReq) Reset() {
@@ -309,13 +334,23 @@ func (x *StorageFormatReq) GetReformat() bool {
 	return false
 }
 
+func (x *StorageFormatReq) GetRestart() bool {
+	if x != nil {
+		return x.Restart
+	}
+	return false
+}
+
 type StorageFormatResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Crets []*NvmeControllerResult `protobuf:"bytes,1,rep,name=crets,proto3" json:"crets,omitempty"` // One per controller format attempt
-	Mrets []*ScmMountResult       `protobuf:"bytes,2,rep,name=mrets,proto3" json:"mrets,omitempty"` // One per scm format and mount attempt
+	Crets       []*NvmeControllerResult `protobuf:"bytes,1,rep,name=crets,proto3" json:"crets,omitempty"`                                // One per controller format attempt
+	Mrets       []*ScmMountResult       `protobuf:"bytes,2,rep,name=mrets,proto3" json:"mrets,omitempty"`                                // One per scm format and mount attempt
+	Warnings    []string                `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`                          // Advisories that did not prevent success
+	FaultDomain string                  `protobuf:"bytes,4,opt,name=fault_domain,json=faultDomain,proto3" json:"fault_domain,omitempty"` // Fault domain of the formatted host
+	Timings     []*PhaseTiming          `protobuf:"bytes,5,rep,name=timings,proto3" json:"timings,omitempty"`                            // Duration of each phase of the format
 }
 
 func (x *StorageFormatResp) Reset() {
@@ -364,6 +399,536 @@ func (x *StorageFormatResp) GetMrets() []*ScmMountResult {
 	return nil
 }
 
+func (x *StorageFormatResp) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *StorageFormatResp) GetFaultDomain() string {
+	if x != nil {
+		return x.FaultDomain
+	}
+	return ""
+}
+
+func (x *StorageFormatResp) GetTimings() []*PhaseTiming {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+type StorageOwnershipReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StorageOwnershipReq) Reset() {
+	*x = StorageOwnershipReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageOwnershipReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageOwnershipReq) ProtoMessage() {}
+
+func (x *StorageOwnershipReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageOwnershipReq.ProtoReflect.Descriptor instead.
+func (*StorageOwnershipReq) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{6}
+}
+
+type DeviceOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Device      string `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`            // Device identifier (PCI address, pmem namespace, etc.)
+	Class       string `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`              // Storage class of device
+	Instanceidx uint32 `protobuf:"varint,3,opt,name=instanceidx,proto3" json:"instanceidx,omitempty"` // Index of engine instance owning device
+}
+
+func (x *DeviceOwner) Reset() {
+	*x = DeviceOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceOwner) ProtoMessage() {}
+
+func (x *DeviceOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceOwner.ProtoReflect.Descriptor instead.
+func (*DeviceOwner) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeviceOwner) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DeviceOwner) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DeviceOwner) GetInstanceidx() uint32 {
+	if x != nil {
+		return x.Instanceidx
+	}
+	return 0
+}
+
+type StorageOwnershipResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owners []*DeviceOwner `protobuf:"bytes,1,rep,name=owners,proto3" json:"owners,omitempty"` // Devices recorded in the ledger
+}
+
+func (x *StorageOwnershipResp) Reset() {
+	*x = StorageOwnershipResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageOwnershipResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageOwnershipResp) ProtoMessage() {}
+
+func (x *StorageOwnershipResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageOwnershipResp.ProtoReflect.Descriptor instead.
+func (*StorageOwnershipResp) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageOwnershipResp) GetOwners() []*DeviceOwner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+type BdevCapabilitiesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BdevCapabilitiesReq) Reset() {
+	*x = BdevCapabilitiesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BdevCapabilitiesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BdevCapabilitiesReq) ProtoMessage() {}
+
+func (x *BdevCapabilitiesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BdevCapabilitiesReq.ProtoReflect.Descriptor instead.
+func (*BdevCapabilitiesReq) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{9}
+}
+
+type BdevCapabilitiesResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capabilities *BdevCapabilities `protobuf:"bytes,1,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (x *BdevCapabilitiesResp) Reset() {
+	*x = BdevCapabilitiesResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BdevCapabilitiesResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BdevCapabilitiesResp) ProtoMessage() {}
+
+func (x *BdevCapabilitiesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BdevCapabilitiesResp.ProtoReflect.Descriptor instead.
+func (*BdevCapabilitiesResp) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BdevCapabilitiesResp) GetCapabilities() *BdevCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type StorageVerifyReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StorageVerifyReq) Reset() {
+	*x = StorageVerifyReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageVerifyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageVerifyReq) ProtoMessage() {}
+
+func (x *StorageVerifyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageVerifyReq.ProtoReflect.Descriptor instead.
+func (*StorageVerifyReq) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{11}
+}
+
+type NvmeVerifyResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PciAddr       string   `protobuf:"bytes,1,opt,name=pci_addr,json=pciAddr,proto3" json:"pci_addr,omitempty"`                    // PCI address of NVMe controller
+	NsId          uint32   `protobuf:"varint,2,opt,name=ns_id,json=nsId,proto3" json:"ns_id,omitempty"`                            // Identifier of namespace verified
+	Instanceidx   uint32   `protobuf:"varint,3,opt,name=instanceidx,proto3" json:"instanceidx,omitempty"`                          // Index of engine instance using device
+	State         string   `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`                                       // Verification state (pending, running, done or failed)
+	TotalBytes    uint64   `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`          // Size of namespace to be read
+	VerifiedBytes uint64   `protobuf:"varint,6,opt,name=verified_bytes,json=verifiedBytes,proto3" json:"verified_bytes,omitempty"` // Bytes read so far
+	NumBadLbas    uint64   `protobuf:"varint,7,opt,name=num_bad_lbas,json=numBadLbas,proto3" json:"num_bad_lbas,omitempty"`        // Number of unreadable LBAs found so far
+	BadLbas       []uint64 `protobuf:"varint,8,rep,packed,name=bad_lbas,json=badLbas,proto3" json:"bad_lbas,omitempty"`            // First unreadable LBAs found, list is truncated
+	Error         string   `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`                                       // Error that stopped verification
+	Started       int64    `protobuf:"varint,10,opt,name=started,proto3" json:"started,omitempty"`                                 // Unix time verification started
+	Finished      int64    `protobuf:"varint,11,opt,name=finished,proto3" json:"finished,omitempty"`                               // Unix time verification finished
+}
+
+func (x *NvmeVerifyResult) Reset() {
+	*x = NvmeVerifyResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NvmeVerifyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NvmeVerifyResult) ProtoMessage() {}
+
+func (x *NvmeVerifyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NvmeVerifyResult.ProtoReflect.Descriptor instead.
+func (*NvmeVerifyResult) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NvmeVerifyResult) GetPciAddr() string {
+	if x != nil {
+		return x.PciAddr
+	}
+	return ""
+}
+
+func (x *NvmeVerifyResult) GetNsId() uint32 {
+	if x != nil {
+		return x.NsId
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetInstanceidx() uint32 {
+	if x != nil {
+		return x.Instanceidx
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *NvmeVerifyResult) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetVerifiedBytes() uint64 {
+	if x != nil {
+		return x.VerifiedBytes
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetNumBadLbas() uint64 {
+	if x != nil {
+		return x.NumBadLbas
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetBadLbas() []uint64 {
+	if x != nil {
+		return x.BadLbas
+	}
+	return nil
+}
+
+func (x *NvmeVerifyResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NvmeVerifyResult) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *NvmeVerifyResult) GetFinished() int64 {
+	if x != nil {
+		return x.Finished
+	}
+	return 0
+}
+
+type StorageVerifyResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rate    uint64              `protobuf:"varint,1,opt,name=rate,proto3" json:"rate,omitempty"`      // Verification bandwidth limit in MiB/s, zero if disabled
+	Results []*NvmeVerifyResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"` // One per namespace verified after the last format
+}
+
+func (x *StorageVerifyResp) Reset() {
+	*x = StorageVerifyResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageVerifyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageVerifyResp) ProtoMessage() {}
+
+func (x *StorageVerifyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageVerifyResp.ProtoReflect.Descriptor instead.
+func (*StorageVerifyResp) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StorageVerifyResp) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *StorageVerifyResp) GetResults() []*NvmeVerifyResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// PhaseTiming is the time taken by a phase of a storage operation.
+type PhaseTiming struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase      string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`                              // Description of the phase
+	DurationUs uint64 `protobuf:"varint,2,opt,name=duration_us,json=durationUs,proto3" json:"duration_us,omitempty"` // Phase duration in microseconds
+}
+
+func (x *PhaseTiming) Reset() {
+	*x = PhaseTiming{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhaseTiming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseTiming) ProtoMessage() {}
+
+func (x *PhaseTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseTiming.ProtoReflect.Descriptor instead.
+func (*PhaseTiming) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PhaseTiming) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PhaseTiming) GetDurationUs() uint64 {
+	if x != nil {
+		return x.DurationUs
+	}
+	return 0
+}
+
 var File_ctl_storage_proto protoreflect.FileDescriptor
 
 var file_ctl_storage_proto_rawDesc = []byte{
@@ -377,44 +942,110 @@ var file_ctl_storage_proto_rawDesc = []byte{
 	0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x52,
 	0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x03, 0x73, 0x63, 0x6d, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
-	0x53, 0x63, 0x6d, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x22, 0x65, 0x0a, 0x12, 0x53,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x12, 0x28, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x03, 0x73,
-	0x63, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50,
-	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x52, 0x03, 0x73,
-	0x63, 0x6d, 0x22, 0x59, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61,
-	0x6e, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d,
-	0x65, 0x52, 0x65, 0x71, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x73, 0x63,
-	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63,
-	0x61, 0x6e, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x22, 0x5c, 0x0a,
-	0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x25, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x03, 0x73, 0x63, 0x6d, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53,
-	0x63, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x22, 0x7b, 0x0a, 0x10, 0x53,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x71, 0x12,
-	0x26, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x63, 0x74, 0x6c, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65,
-	0x71, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x03, 0x73, 0x63, 0x6d, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61,
-	0x74, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x12, 0x1a, 0x0a, 0x08,
-	0x72, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
-	0x72, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x6f, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x72,
+	0x53, 0x63, 0x6d, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x22, 0xad, 0x01, 0x0a, 0x12,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x28, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x03,
+	0x73, 0x63, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x52, 0x03,
+	0x73, 0x63, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x2a, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x59, 0x0a, 0x0e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a,
+	0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74,
+	0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x52, 0x04, 0x6e,
+	0x76, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x73, 0x63, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x63, 0x6d, 0x52, 0x65,
+	0x71, 0x52, 0x03, 0x73, 0x63, 0x6d, 0x22, 0x86, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x25, 0x0a, 0x04, 0x6e, 0x76,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53,
+	0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x52, 0x04, 0x6e, 0x76, 0x6d,
+	0x65, 0x12, 0x22, 0x0a, 0x03, 0x73, 0x63, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x52, 0x03, 0x73, 0x63, 0x6d, 0x12, 0x28, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22,
+	0x95, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x65, 0x71, 0x12, 0x26, 0x0a, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x4e,
+	0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x52, 0x04, 0x6e, 0x76, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x03,
+	0x73, 0x63, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x53, 0x63, 0x6d, 0x52, 0x65, 0x71, 0x52, 0x03, 0x73, 0x63,
+	0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x72,
 	0x61, 0x67, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2f, 0x0a,
 	0x05, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63,
 	0x74, 0x6c, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
 	0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x29,
 	0x0a, 0x05, 0x6d, 0x72, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
 	0x63, 0x74, 0x6c, 0x2e, 0x53, 0x63, 0x6d, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x05, 0x6d, 0x72, 0x65, 0x74, 0x73, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61,
-	0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x74, 0x52, 0x05, 0x6d, 0x72, 0x65, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x22, 0x5d, 0x0a, 0x0b, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x69, 0x64, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x69, 0x64, 0x78, 0x22, 0x40, 0x0a, 0x14, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x28, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x15, 0x0a, 0x13,
+	0x42, 0x64, 0x65, 0x76, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x22, 0x51, 0x0a, 0x14, 0x42, 0x64, 0x65, 0x76, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x39, 0x0a, 0x0c, 0x63,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x42, 0x64, 0x65, 0x76, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x22, 0xcb, 0x02, 0x0a, 0x10, 0x4e,
+	0x76, 0x6d, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x13, 0x0a, 0x05, 0x6e, 0x73,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6e, 0x73, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x69, 0x64, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x69, 0x64,
+	0x78, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x20, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x61, 0x64, 0x5f, 0x6c, 0x62, 0x61, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x42, 0x61, 0x64, 0x4c, 0x62, 0x61,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x64, 0x5f, 0x6c, 0x62, 0x61, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x07, 0x62, 0x61, 0x64, 0x4c, 0x62, 0x61, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0x58, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0x44, 0x0a, 0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63,
+	0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -429,7 +1060,7 @@ func file_ctl_storage_proto_rawDescGZIP() []byte {
 	return file_ctl_storage_proto_rawDescData
 }
 
-var file_ctl_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_ctl_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_ctl_storage_proto_goTypes = []interface{}{
 	(*StoragePrepareReq)(nil),    // 0: ctl.StoragePrepareReq
 	(*StoragePrepareResp)(nil),   // 1: ctl.StoragePrepareResp
@@ -437,37 +1068,53 @@ var file_ctl_storage_proto_goTypes = []interface{}{
 	(*StorageScanResp)(nil),      // 3: ctl.StorageScanResp
 	(*StorageFormatReq)(nil),     // 4: ctl.StorageFormatReq
 	(*StorageFormatResp)(nil),    // 5: ctl.StorageFormatResp
-	(*PrepareNvmeReq)(nil),       // 6: ctl.PrepareNvmeReq
-	(*PrepareScmReq)(nil),        // 7: ctl.PrepareScmReq
-	(*PrepareNvmeResp)(nil),      // 8: ctl.PrepareNvmeResp
-	(*PrepareScmResp)(nil),       // 9: ctl.PrepareScmResp
-	(*ScanNvmeReq)(nil),          // 10: ctl.ScanNvmeReq
-	(*ScanScmReq)(nil),           // 11: ctl.ScanScmReq
-	(*ScanNvmeResp)(nil),         // 12: ctl.ScanNvmeResp
-	(*ScanScmResp)(nil),          // 13: ctl.ScanScmResp
-	(*FormatNvmeReq)(nil),        // 14: ctl.FormatNvmeReq
-	(*FormatScmReq)(nil),         // 15: ctl.FormatScmReq
-	(*NvmeControllerResult)(nil), // 16: ctl.NvmeControllerResult
-	(*ScmMountResult)(nil),       // 17: ctl.ScmMountResult
+	(*StorageOwnershipReq)(nil),  // 6: ctl.StorageOwnershipReq
+	(*DeviceOwner)(nil),          // 7: ctl.DeviceOwner
+	(*StorageOwnershipResp)(nil), // 8: ctl.StorageOwnershipResp
+	(*BdevCapabilitiesReq)(nil),  // 9: ctl.BdevCapabilitiesReq
+	(*BdevCapabilitiesResp)(nil), // 10: ctl.BdevCapabilitiesResp
+	(*StorageVerifyReq)(nil),     // 11: ctl.StorageVerifyReq
+	(*NvmeVerifyResult)(nil),     // 12: ctl.NvmeVerifyResult
+	(*StorageVerifyResp)(nil),    // 13: ctl.StorageVerifyResp
+	(*PhaseTiming)(nil),          // 14: ctl.PhaseTiming
+	(*PrepareNvmeReq)(nil),       // 15: ctl.PrepareNvmeReq
+	(*PrepareScmReq)(nil),        // 16: ctl.PrepareScmReq
+	(*PrepareNvmeResp)(nil),      // 17: ctl.PrepareNvmeResp
+	(*PrepareScmResp)(nil),       // 18: ctl.PrepareScmResp
+	(*ScanNvmeReq)(nil),          // 19: ctl.ScanNvmeReq
+	(*ScanScmReq)(nil),           // 20: ctl.ScanScmReq
+	(*ScanNvmeResp)(nil),         // 21: ctl.ScanNvmeResp
+	(*ScanScmResp)(nil),          // 22: ctl.ScanScmResp
+	(*FormatNvmeReq)(nil),        // 23: ctl.FormatNvmeReq
+	(*FormatScmReq)(nil),         // 24: ctl.FormatScmReq
+	(*NvmeControllerResult)(nil), // 25: ctl.NvmeControllerResult
+	(*ScmMountResult)(nil),       // 26: ctl.ScmMountResult
+	(*BdevCapabilities)(nil),     // 27: ctl.BdevCapabilities
 }
 var file_ctl_storage_proto_depIdxs = []int32{
-	6,  // 0: ctl.StoragePrepareReq.nvme:type_name -> ctl.PrepareNvmeReq
-	7,  // 1: ctl.StoragePrepareReq.scm:type_name -> ctl.PrepareScmReq
-	8,  // 2: ctl.StoragePrepareResp.nvme:type_name -> ctl.PrepareNvmeResp
-	9,  // 3: ctl.StoragePrepareResp.scm:type_name -> ctl.PrepareScmResp
-	10, // 4: ctl.StorageScanReq.nvme:type_name -> ctl.ScanNvmeReq
-	11, // 5: ctl.StorageScanReq.scm:type_name -> ctl.ScanScmReq
-	12, // 6: ctl.StorageScanResp.nvme:type_name -> ctl.ScanNvmeResp
-	13, // 7: ctl.StorageScanResp.scm:type_name -> ctl.ScanScmResp
-	14, // 8: ctl.StorageFormatReq.nvme:type_name -> ctl.FormatNvmeReq
-	15, // 9: ctl.StorageFormatReq.scm:type_name -> ctl.FormatScmReq
-	16, // 10: ctl.StorageFormatResp.crets:type_name -> ctl.NvmeControllerResult
-	17, // 11: ctl.StorageFormatResp.mrets:type_name -> ctl.ScmMountResult
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	15, // 0: ctl.StoragePrepareReq.nvme:type_name -> ctl.PrepareNvmeReq
+	16, // 1: ctl.StoragePrepareReq.scm:type_name -> ctl.PrepareScmReq
+	17, // 2: ctl.StoragePrepareResp.nvme:type_name -> ctl.PrepareNvmeResp
+	18, // 3: ctl.StoragePrepareResp.scm:type_name -> ctl.PrepareScmResp
+	14, // 4: ctl.StoragePrepareResp.timings:type_name -> ctl.PhaseTiming
+	19, // 5: ctl.StorageScanReq.nvme:type_name -> ctl.ScanNvmeReq
+	20, // 6: ctl.StorageScanReq.scm:type_name -> ctl.ScanScmReq
+	21, // 7: ctl.StorageScanResp.nvme:type_name -> ctl.ScanNvmeResp
+	22, // 8: ctl.StorageScanResp.scm:type_name -> ctl.ScanScmResp
+	7,  // 9: ctl.StorageScanResp.owners:type_name -> ctl.DeviceOwner
+	23, // 10: ctl.StorageFormatReq.nvme:type_name -> ctl.FormatNvmeReq
+	24, // 11: ctl.StorageFormatReq.scm:type_name -> ctl.FormatScmReq
+	25, // 12: ctl.StorageFormatResp.crets:type_name -> ctl.NvmeControllerResult
+	26, // 13: ctl.StorageFormatResp.mrets:type_name -> ctl.ScmMountResult
+	14, // 14: ctl.StorageFormatResp.timings:type_name -> ctl.PhaseTiming
+	7,  // 15: ctl.StorageOwnershipResp.owners:type_name -> ctl.DeviceOwner
+	27, // 16: ctl.BdevCapabilitiesResp.capabilities:type_name -> ctl.BdevCapabilities
+	12, // 17: ctl.StorageVerifyResp.results:type_name -> ctl.NvmeVerifyResult
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_ctl_storage_proto_init() }
@@ -550,6 +1197,114 @@ func file_ctl_storage_proto_init() {
 				return nil
 			}
 		}
+		file_ctl_storage_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageOwnershipReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageOwnershipResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BdevCapabilitiesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BdevCapabilitiesResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageVerifyReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NvmeVerifyResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageVerifyResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhaseTiming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -557,7 +1312,7 @@ func file_ctl_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ctl_storage_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -13,11 +13,10 @@
 package ctl
 
 import (
-	reflect "reflect"
-	sync "sync"
-
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
 const (
@@ -41,6 +40,9 @@ type NvmeController struct {
 	HealthStats *NvmeController_Health      `protobuf:"bytes,6,opt,name=health_stats,json=healthStats,proto3" json:"health_stats,omitempty"` // controller's health stats
 	Namespaces  []*NvmeController_Namespace `protobuf:"bytes,7,rep,name=namespaces,proto3" json:"namespaces,omitempty"`                      // controller's namespaces
 	SmdDevices  []*NvmeController_SmdDevice `protobuf:"bytes,8,rep,name=smd_devices,json=smdDevices,proto3" json:"smd_devices,omitempty"`    // controller's blobstores
+	PciLink     *NvmeController_PciLink     `protobuf:"bytes,9,opt,name=pci_link,json=pciLink,proto3" json:"pci_link,omitempty"`             // controller's PCIe link status
+	Driver      string                      `protobuf:"bytes,10,opt,name=driver,proto3" json:"driver,omitempty"`                             // kernel driver, set if not accessible by SPDK
+	BindState   string                      `protobuf:"bytes,11,opt,name=bind_state,json=bindState,proto3" json:"bind_state,omitempty"`      // kernel-bound if set, see driver
 }
 
 func (x *NvmeController) Reset() {
@@ -131,6 +133,27 @@ func (x *NvmeController) GetSmdDevices() []*NvmeController_SmdDevice {
 	return nil
 }
 
+func (x *NvmeController) GetPciLink() *NvmeController_PciLink {
+	if x != nil {
+		return x.PciLink
+	}
+	return nil
+}
+
+func (x *NvmeController) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *NvmeController) GetBindState() string {
+	if x != nil {
+		return x.BindState
+	}
+	return ""
+}
+
 // NvmeControllerResult represents state of operation performed on controller.
 type NvmeControllerResult struct {
 	state         protoimpl.MessageState
@@ -196,6 +219,7 @@ type PrepareNvmeReq struct {
 	NrHugePages  int32  `protobuf:"varint,2,opt,name=nr_huge_pages,json=nrHugePages,proto3" json:"nr_huge_pages,omitempty"`   // Number of hugepages to allocate (in MB)
 	TargetUser   string `protobuf:"bytes,3,opt,name=target_user,json=targetUser,proto3" json:"target_user,omitempty"`         // User to access NVMe devices
 	Reset_       bool   `protobuf:"varint,4,opt,name=reset,proto3" json:"reset,omitempty"`                                    // Reset SPDK returning devices to kernel
+	Status       bool   `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`                                  // Report device bindings, no changes made
 }
 
 func (x *PrepareNvmeReq) Reset() {
@@ -258,12 +282,24 @@ func (x *PrepareNvmeReq) GetReset_() bool {
 	return false
 }
 
+func (x *PrepareNvmeReq) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
 type PrepareNvmeResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	State *ResponseState `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	State       *ResponseState             `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	BindState   string                     `protobuf:"bytes,2,opt,name=bind_state,json=bindState,proto3" json:"bind_state,omitempty"`         // Combined binding state of host SSDs
+	Bindings    []*PrepareNvmeResp_Binding `protobuf:"bytes,3,rep,name=bindings,proto3" json:"bindings,omitempty"`                            // Binding of each SSD on host
+	LastOp      string                     `protobuf:"bytes,4,opt,name=last_op,json=lastOp,proto3" json:"last_op,omitempty"`                  // Last recorded prepare or reset
+	LastOpTime  uint64                     `protobuf:"varint,5,opt,name=last_op_time,json=lastOpTime,proto3" json:"last_op_time,omitempty"`   // Time of last op (Unix seconds)
+	LastOpError string                     `protobuf:"bytes,6,opt,name=last_op_error,json=lastOpError,proto3" json:"last_op_error,omitempty"` // Error returned by last op, if any
 }
 
 func (x *PrepareNvmeResp) Reset() {
@@ -305,6 +341,41 @@ func (x *PrepareNvmeResp) GetState() *ResponseState {
 	return nil
 }
 
+func (x *PrepareNvmeResp) GetBindState() string {
+	if x != nil {
+		return x.BindState
+	}
+	return ""
+}
+
+func (x *PrepareNvmeResp) GetBindings() []*PrepareNvmeResp_Binding {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+func (x *PrepareNvmeResp) GetLastOp() string {
+	if x != nil {
+		return x.LastOp
+	}
+	return ""
+}
+
+func (x *PrepareNvmeResp) GetLastOpTime() uint64 {
+	if x != nil {
+		return x.LastOpTime
+	}
+	return 0
+}
+
+func (x *PrepareNvmeResp) GetLastOpError() string {
+	if x != nil {
+		return x.LastOpError
+	}
+	return ""
+}
+
 type ScanNvmeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -368,19 +439,109 @@ func (x *ScanNvmeReq) GetBasic() bool {
 	return false
 }
 
+// BdevCapabilities describes the SPDK and DPDK libraries used to access NVMe
+// SSDs and the optional features they support.
+type BdevCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpdkVersion string `protobuf:"bytes,1,opt,name=spdk_version,json=spdkVersion,proto3" json:"spdk_version,omitempty"` // linked SPDK version
+	DpdkVersion string `protobuf:"bytes,2,opt,name=dpdk_version,json=dpdkVersion,proto3" json:"dpdk_version,omitempty"` // linked DPDK version
+	Vmd         bool   `protobuf:"varint,3,opt,name=vmd,proto3" json:"vmd,omitempty"`                                   // VMD supported by SPDK
+	VmdEnabled  bool   `protobuf:"varint,4,opt,name=vmd_enabled,json=vmdEnabled,proto3" json:"vmd_enabled,omitempty"`   // VMD enabled in server config
+	Zns         bool   `protobuf:"varint,5,opt,name=zns,proto3" json:"zns,omitempty"`                                   // zoned namespaces supported by SPDK
+	Cmb         bool   `protobuf:"varint,6,opt,name=cmb,proto3" json:"cmb,omitempty"`                                   // controller memory buffer supported by SPDK
+}
+
+func (x *BdevCapabilities) Reset() {
+	*x = BdevCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_nvme_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BdevCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BdevCapabilities) ProtoMessage() {}
+
+func (x *BdevCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_nvme_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BdevCapabilities.ProtoReflect.Descriptor instead.
+func (*BdevCapabilities) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BdevCapabilities) GetSpdkVersion() string {
+	if x != nil {
+		return x.SpdkVersion
+	}
+	return ""
+}
+
+func (x *BdevCapabilities) GetDpdkVersion() string {
+	if x != nil {
+		return x.DpdkVersion
+	}
+	return ""
+}
+
+func (x *BdevCapabilities) GetVmd() bool {
+	if x != nil {
+		return x.Vmd
+	}
+	return false
+}
+
+func (x *BdevCapabilities) GetVmdEnabled() bool {
+	if x != nil {
+		return x.VmdEnabled
+	}
+	return false
+}
+
+func (x *BdevCapabilities) GetZns() bool {
+	if x != nil {
+		return x.Zns
+	}
+	return false
+}
+
+func (x *BdevCapabilities) GetCmb() bool {
+	if x != nil {
+		return x.Cmb
+	}
+	return false
+}
+
 type ScanNvmeResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ctrlrs []*NvmeController `protobuf:"bytes,1,rep,name=ctrlrs,proto3" json:"ctrlrs,omitempty"`
-	State  *ResponseState    `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Ctrlrs       []*NvmeController `protobuf:"bytes,1,rep,name=ctrlrs,proto3" json:"ctrlrs,omitempty"`
+	State        *ResponseState    `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Capabilities *BdevCapabilities `protobuf:"bytes,3,opt,name=capabilities,proto3" json:"capabilities,omitempty"` // omitted when Basic is requested
 }
 
 func (x *ScanNvmeResp) Reset() {
 	*x = ScanNvmeResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_storage_nvme_proto_msgTypes[5]
+		mi := &file_ctl_storage_nvme_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -393,7 +554,7 @@ func (x *ScanNvmeResp) String() string {
 func (*ScanNvmeResp) ProtoMessage() {}
 
 func (x *ScanNvmeResp) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_storage_nvme_proto_msgTypes[5]
+	mi := &file_ctl_storage_nvme_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +567,7 @@ func (x *ScanNvmeResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScanNvmeResp.ProtoReflect.Descriptor instead.
 func (*ScanNvmeResp) Descriptor() ([]byte, []int) {
-	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{5}
+	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ScanNvmeResp) GetCtrlrs() []*NvmeController {
@@ -423,6 +584,13 @@ func (x *ScanNvmeResp) GetState() *ResponseState {
 	return nil
 }
 
+func (x *ScanNvmeResp) GetCapabilities() *BdevCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 type FormatNvmeReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -432,7 +600,7 @@ type FormatNvmeReq struct {
 func (x *FormatNvmeReq) Reset() {
 	*x = FormatNvmeReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_storage_nvme_proto_msgTypes[6]
+		mi := &file_ctl_storage_nvme_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -445,7 +613,7 @@ func (x *FormatNvmeReq) String() string {
 func (*FormatNvmeReq) ProtoMessage() {}
 
 func (x *FormatNvmeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_storage_nvme_proto_msgTypes[6]
+	mi := &file_ctl_storage_nvme_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,43 +626,39 @@ func (x *FormatNvmeReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FormatNvmeReq.ProtoReflect.Descriptor instead.
 func (*FormatNvmeReq) Descriptor() ([]byte, []int) {
-	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{6}
+	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{7}
 }
 
-// Health mirrors bio_dev_state structure.
 type NvmeController_Health struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// Device health details
-	WarnTempTime    uint32 `protobuf:"varint,3,opt,name=warn_temp_time,json=warnTempTime,proto3" json:"warn_temp_time,omitempty"`
-	CritTempTime    uint32 `protobuf:"varint,4,opt,name=crit_temp_time,json=critTempTime,proto3" json:"crit_temp_time,omitempty"`
-	CtrlBusyTime    uint64 `protobuf:"varint,5,opt,name=ctrl_busy_time,json=ctrlBusyTime,proto3" json:"ctrl_busy_time,omitempty"`
-	PowerCycles     uint64 `protobuf:"varint,6,opt,name=power_cycles,json=powerCycles,proto3" json:"power_cycles,omitempty"`
-	PowerOnHours    uint64 `protobuf:"varint,7,opt,name=power_on_hours,json=powerOnHours,proto3" json:"power_on_hours,omitempty"`
-	UnsafeShutdowns uint64 `protobuf:"varint,8,opt,name=unsafe_shutdowns,json=unsafeShutdowns,proto3" json:"unsafe_shutdowns,omitempty"`
-	MediaErrs       uint64 `protobuf:"varint,9,opt,name=media_errs,json=mediaErrs,proto3" json:"media_errs,omitempty"`
-	ErrLogEntries   uint64 `protobuf:"varint,10,opt,name=err_log_entries,json=errLogEntries,proto3" json:"err_log_entries,omitempty"`
-	// I/O error counters
-	BioReadErrs  uint32 `protobuf:"varint,11,opt,name=bio_read_errs,json=bioReadErrs,proto3" json:"bio_read_errs,omitempty"`
-	BioWriteErrs uint32 `protobuf:"varint,12,opt,name=bio_write_errs,json=bioWriteErrs,proto3" json:"bio_write_errs,omitempty"`
-	BioUnmapErrs uint32 `protobuf:"varint,13,opt,name=bio_unmap_errs,json=bioUnmapErrs,proto3" json:"bio_unmap_errs,omitempty"`
-	ChecksumErrs uint32 `protobuf:"varint,14,opt,name=checksum_errs,json=checksumErrs,proto3" json:"checksum_errs,omitempty"`
-	Temperature  uint32 `protobuf:"varint,15,opt,name=temperature,proto3" json:"temperature,omitempty"` // in Kelvin
-	// Critical warnings
-	TempWarn           bool `protobuf:"varint,16,opt,name=temp_warn,json=tempWarn,proto3" json:"temp_warn,omitempty"`
-	AvailSpareWarn     bool `protobuf:"varint,17,opt,name=avail_spare_warn,json=availSpareWarn,proto3" json:"avail_spare_warn,omitempty"`
-	DevReliabilityWarn bool `protobuf:"varint,18,opt,name=dev_reliability_warn,json=devReliabilityWarn,proto3" json:"dev_reliability_warn,omitempty"`
-	ReadOnlyWarn       bool `protobuf:"varint,19,opt,name=read_only_warn,json=readOnlyWarn,proto3" json:"read_only_warn,omitempty"`
-	VolatileMemWarn    bool `protobuf:"varint,20,opt,name=volatile_mem_warn,json=volatileMemWarn,proto3" json:"volatile_mem_warn,omitempty"` // volatile memory backup
+	Timestamp          uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	WarnTempTime       uint32 `protobuf:"varint,3,opt,name=warn_temp_time,json=warnTempTime,proto3" json:"warn_temp_time,omitempty"`
+	CritTempTime       uint32 `protobuf:"varint,4,opt,name=crit_temp_time,json=critTempTime,proto3" json:"crit_temp_time,omitempty"`
+	CtrlBusyTime       uint64 `protobuf:"varint,5,opt,name=ctrl_busy_time,json=ctrlBusyTime,proto3" json:"ctrl_busy_time,omitempty"`
+	PowerCycles        uint64 `protobuf:"varint,6,opt,name=power_cycles,json=powerCycles,proto3" json:"power_cycles,omitempty"`
+	PowerOnHours       uint64 `protobuf:"varint,7,opt,name=power_on_hours,json=powerOnHours,proto3" json:"power_on_hours,omitempty"`
+	UnsafeShutdowns    uint64 `protobuf:"varint,8,opt,name=unsafe_shutdowns,json=unsafeShutdowns,proto3" json:"unsafe_shutdowns,omitempty"`
+	MediaErrs          uint64 `protobuf:"varint,9,opt,name=media_errs,json=mediaErrs,proto3" json:"media_errs,omitempty"`
+	ErrLogEntries      uint64 `protobuf:"varint,10,opt,name=err_log_entries,json=errLogEntries,proto3" json:"err_log_entries,omitempty"`
+	BioReadErrs        uint32 `protobuf:"varint,11,opt,name=bio_read_errs,json=bioReadErrs,proto3" json:"bio_read_errs,omitempty"`
+	BioWriteErrs       uint32 `protobuf:"varint,12,opt,name=bio_write_errs,json=bioWriteErrs,proto3" json:"bio_write_errs,omitempty"`
+	BioUnmapErrs       uint32 `protobuf:"varint,13,opt,name=bio_unmap_errs,json=bioUnmapErrs,proto3" json:"bio_unmap_errs,omitempty"`
+	ChecksumErrs       uint32 `protobuf:"varint,14,opt,name=checksum_errs,json=checksumErrs,proto3" json:"checksum_errs,omitempty"`
+	Temperature        uint32 `protobuf:"varint,15,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	TempWarn           bool   `protobuf:"varint,16,opt,name=temp_warn,json=tempWarn,proto3" json:"temp_warn,omitempty"`
+	AvailSpareWarn     bool   `protobuf:"varint,17,opt,name=avail_spare_warn,json=availSpareWarn,proto3" json:"avail_spare_warn,omitempty"`
+	DevReliabilityWarn bool   `protobuf:"varint,18,opt,name=dev_reliability_warn,json=devReliabilityWarn,proto3" json:"dev_reliability_warn,omitempty"`
+	ReadOnlyWarn       bool   `protobuf:"varint,19,opt,name=read_only_warn,json=readOnlyWarn,proto3" json:"read_only_warn,omitempty"`
+	VolatileMemWarn    bool   `protobuf:"varint,20,opt,name=volatile_mem_warn,json=volatileMemWarn,proto3" json:"volatile_mem_warn,omitempty"`
 }
 
 func (x *NvmeController_Health) Reset() {
 	*x = NvmeController_Health{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_storage_nvme_proto_msgTypes[7]
+		mi := &file_ctl_storage_nvme_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -507,7 +671,7 @@ func (x *NvmeController_Health) String() string {
 func (*NvmeController_Health) ProtoMessage() {}
 
 func (x *NvmeController_Health) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_storage_nvme_proto_msgTypes[7]
+	mi := &file_ctl_storage_nvme_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,21 +820,21 @@ func (x *NvmeController_Health) GetVolatileMemWarn() bool {
 	return false
 }
 
-// Namespace represents a namespace created on an NvmeController.
 type NvmeController_Namespace struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                          // namespace id
-	Size         uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                                      // device capacity in bytes
-	CtrlrPciAddr string `protobuf:"bytes,3,opt,name=ctrlr_pci_addr,json=ctrlrPciAddr,proto3" json:"ctrlr_pci_addr,omitempty"` // parent controller PCI address
+	Id           uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size         uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	CtrlrPciAddr string `protobuf:"bytes,3,opt,name=ctrlr_pci_addr,json=ctrlrPciAddr,proto3" json:"ctrlr_pci_addr,omitempty"`
+	Zoned        bool   `protobuf:"varint,4,opt,name=zoned,proto3" json:"zoned,omitempty"`
 }
 
 func (x *NvmeController_Namespace) Reset() {
 	*x = NvmeController_Namespace{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_storage_nvme_proto_msgTypes[8]
+		mi := &file_ctl_storage_nvme_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -683,7 +847,7 @@ func (x *NvmeController_Namespace) String() string {
 func (*NvmeController_Namespace) ProtoMessage() {}
 
 func (x *NvmeController_Namespace) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_storage_nvme_proto_msgTypes[8]
+	mi := &file_ctl_storage_nvme_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,26 +884,31 @@ func (x *NvmeController_Namespace) GetCtrlrPciAddr() string {
 	return ""
 }
 
-// SmdDevice represents a blobstore created on a NvmeController_Namespace.
-// TODO: this should be embedded in Namespace above
+func (x *NvmeController_Namespace) GetZoned() bool {
+	if x != nil {
+		return x.Zoned
+	}
+	return false
+}
+
 type NvmeController_SmdDevice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid       string  `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                                // UUID of blobstore
-	TgtIds     []int32 `protobuf:"varint,2,rep,packed,name=tgt_ids,json=tgtIds,proto3" json:"tgt_ids,omitempty"`      // VOS target IDs
-	State      string  `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`                              // NORMAL or FAULTY
-	Rank       uint32  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`                               // DAOS I/O Engine using controller
-	TotalBytes uint64  `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"` // blobstore clusters total bytes
-	AvailBytes uint64  `protobuf:"varint,6,opt,name=avail_bytes,json=availBytes,proto3" json:"avail_bytes,omitempty"` // blobstore clusters available bytes
-	TrAddr     string  `protobuf:"bytes,7,opt,name=tr_addr,json=trAddr,proto3" json:"tr_addr,omitempty"`              // transport address of the blobstore
+	Uuid       string  `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	TgtIds     []int32 `protobuf:"varint,2,rep,packed,name=tgt_ids,json=tgtIds,proto3" json:"tgt_ids,omitempty"`
+	State      string  `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Rank       uint32  `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	TotalBytes uint64  `protobuf:"varint,5,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	AvailBytes uint64  `protobuf:"varint,6,opt,name=avail_bytes,json=availBytes,proto3" json:"avail_bytes,omitempty"`
+	TrAddr     string  `protobuf:"bytes,7,opt,name=tr_addr,json=trAddr,proto3" json:"tr_addr,omitempty"`
 }
 
 func (x *NvmeController_SmdDevice) Reset() {
 	*x = NvmeController_SmdDevice{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ctl_storage_nvme_proto_msgTypes[9]
+		mi := &file_ctl_storage_nvme_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -752,7 +921,7 @@ func (x *NvmeController_SmdDevice) String() string {
 func (*NvmeController_SmdDevice) ProtoMessage() {}
 
 func (x *NvmeController_SmdDevice) ProtoReflect() protoreflect.Message {
-	mi := &file_ctl_storage_nvme_proto_msgTypes[9]
+	mi := &file_ctl_storage_nvme_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,13 +986,147 @@ func (x *NvmeController_SmdDevice) GetTrAddr() string {
 	return ""
 }
 
+type NvmeController_PciLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Speed    float64 `protobuf:"fixed64,1,opt,name=speed,proto3" json:"speed,omitempty"`
+	Width    uint32  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	MaxSpeed float64 `protobuf:"fixed64,3,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	MaxWidth uint32  `protobuf:"varint,4,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`
+}
+
+func (x *NvmeController_PciLink) Reset() {
+	*x = NvmeController_PciLink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_nvme_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NvmeController_PciLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NvmeController_PciLink) ProtoMessage() {}
+
+func (x *NvmeController_PciLink) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_nvme_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NvmeController_PciLink.ProtoReflect.Descriptor instead.
+func (*NvmeController_PciLink) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *NvmeController_PciLink) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *NvmeController_PciLink) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *NvmeController_PciLink) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *NvmeController_PciLink) GetMaxWidth() uint32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+type PrepareNvmeResp_Binding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PciAddr string `protobuf:"bytes,1,opt,name=pci_addr,json=pciAddr,proto3" json:"pci_addr,omitempty"`
+	Driver  string `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
+	State   string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *PrepareNvmeResp_Binding) Reset() {
+	*x = PrepareNvmeResp_Binding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ctl_storage_nvme_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareNvmeResp_Binding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareNvmeResp_Binding) ProtoMessage() {}
+
+func (x *PrepareNvmeResp_Binding) ProtoReflect() protoreflect.Message {
+	mi := &file_ctl_storage_nvme_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareNvmeResp_Binding.ProtoReflect.Descriptor instead.
+func (*PrepareNvmeResp_Binding) Descriptor() ([]byte, []int) {
+	return file_ctl_storage_nvme_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *PrepareNvmeResp_Binding) GetPciAddr() string {
+	if x != nil {
+		return x.PciAddr
+	}
+	return ""
+}
+
+func (x *PrepareNvmeResp_Binding) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *PrepareNvmeResp_Binding) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 var File_ctl_storage_nvme_proto protoreflect.FileDescriptor
 
 var file_ctl_storage_nvme_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x63, 0x74, 0x6c, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x76,
 	0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x63, 0x74, 0x6c, 0x1a, 0x10, 0x63,
 	0x74, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xba, 0x0a, 0x0a, 0x0e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0xb0, 0x0c, 0x0a, 0x0e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
 	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69,
 	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
@@ -843,106 +1146,154 @@ var file_ctl_storage_nvme_proto_rawDesc = []byte{
 	0x0b, 0x73, 0x6d, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e,
 	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x53, 0x6d, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x52, 0x0a, 0x73, 0x6d, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x1a, 0xd5, 0x05,
-	0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x61, 0x72, 0x6e, 0x5f, 0x74,
-	0x65, 0x6d, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
-	0x77, 0x61, 0x72, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e,
-	0x63, 0x72, 0x69, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x72, 0x69, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x74, 0x72, 0x6c, 0x5f, 0x62, 0x75, 0x73, 0x79, 0x5f,
-	0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x74, 0x72, 0x6c,
-	0x42, 0x75, 0x73, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65,
-	0x72, 0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
-	0x70, 0x6f, 0x77, 0x65, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x70,
-	0x6f, 0x77, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x48, 0x6f, 0x75, 0x72,
-	0x73, 0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x73, 0x61, 0x66, 0x65, 0x5f, 0x73, 0x68, 0x75, 0x74,
-	0x64, 0x6f, 0x77, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x75, 0x6e, 0x73,
-	0x61, 0x66, 0x65, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x45, 0x72, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x65,
-	0x72, 0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72,
-	0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x69, 0x6f, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f,
-	0x65, 0x72, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x69, 0x6f, 0x52,
-	0x65, 0x61, 0x64, 0x45, 0x72, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x69, 0x6f, 0x5f, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0c, 0x62, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x73, 0x12, 0x24, 0x0a,
-	0x0e, 0x62, 0x69, 0x6f, 0x5f, 0x75, 0x6e, 0x6d, 0x61, 0x70, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18,
-	0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x62, 0x69, 0x6f, 0x55, 0x6e, 0x6d, 0x61, 0x70, 0x45,
-	0x72, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x5f,
-	0x65, 0x72, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63,
-	0x6b, 0x73, 0x75, 0x6d, 0x45, 0x72, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74,
-	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65,
-	0x6d, 0x70, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74,
-	0x65, 0x6d, 0x70, 0x57, 0x61, 0x72, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c,
-	0x5f, 0x73, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x53, 0x70, 0x61, 0x72, 0x65, 0x57, 0x61, 0x72,
-	0x6e, 0x12, 0x30, 0x0a, 0x14, 0x64, 0x65, 0x76, 0x5f, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69,
-	0x6c, 0x69, 0x74, 0x79, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x12, 0x64, 0x65, 0x76, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x57,
-	0x61, 0x72, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79,
-	0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x61,
-	0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x57, 0x61, 0x72, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x76, 0x6f, 0x6c,
-	0x61, 0x74, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x14,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x76, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x65, 0x4d, 0x65,
-	0x6d, 0x57, 0x61, 0x72, 0x6e, 0x1a, 0x55, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x74, 0x72, 0x6c, 0x72, 0x5f,
-	0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x63, 0x74, 0x72, 0x6c, 0x72, 0x50, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x1a, 0xbd, 0x01, 0x0a,
-	0x09, 0x53, 0x6d, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x74, 0x67, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52,
-	0x06, 0x74, 0x67, 0x74, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e,
-	0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74,
-	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x42, 0x79,
-	0x74, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x72, 0x41, 0x64, 0x64, 0x72, 0x22, 0x5b, 0x0a, 0x14,
-	0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12,
-	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x65, 0x52, 0x0a, 0x73, 0x6d, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x08, 0x70, 0x63, 0x69, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x50, 0x63, 0x69, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x07, 0x70, 0x63,
+	0x69, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x1d, 0x0a,
+	0x0a, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x62, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x1a, 0xd5, 0x05, 0x0a,
+	0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x61, 0x72, 0x6e, 0x5f, 0x74, 0x65,
+	0x6d, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x77,
+	0x61, 0x72, 0x6e, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63,
+	0x72, 0x69, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x72, 0x69, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x74, 0x72, 0x6c, 0x5f, 0x62, 0x75, 0x73, 0x79, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x74, 0x72, 0x6c, 0x42,
+	0x75, 0x73, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x5f, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x4f, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x73, 0x61, 0x66, 0x65, 0x5f, 0x73, 0x68, 0x75, 0x74, 0x64,
+	0x6f, 0x77, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x75, 0x6e, 0x73, 0x61,
+	0x66, 0x65, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x45, 0x72, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x72,
+	0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x4c, 0x6f, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x69, 0x6f, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x65,
+	0x72, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x69, 0x6f, 0x52, 0x65,
+	0x61, 0x64, 0x45, 0x72, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x69, 0x6f, 0x5f, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x62, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x45, 0x72, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x62, 0x69, 0x6f, 0x5f, 0x75, 0x6e, 0x6d, 0x61, 0x70, 0x5f, 0x65, 0x72, 0x72, 0x73, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x62, 0x69, 0x6f, 0x55, 0x6e, 0x6d, 0x61, 0x70, 0x45, 0x72,
+	0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x5f, 0x65,
+	0x72, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x45, 0x72, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x65,
+	0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6d,
+	0x70, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74, 0x65,
+	0x6d, 0x70, 0x57, 0x61, 0x72, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x5f,
+	0x73, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x53, 0x70, 0x61, 0x72, 0x65, 0x57, 0x61, 0x72, 0x6e,
+	0x12, 0x30, 0x0a, 0x14, 0x64, 0x65, 0x76, 0x5f, 0x72, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x64, 0x65, 0x76, 0x52, 0x65, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x57, 0x61,
+	0x72, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f,
+	0x77, 0x61, 0x72, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x64,
+	0x4f, 0x6e, 0x6c, 0x79, 0x57, 0x61, 0x72, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x76, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x76, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x6d,
+	0x57, 0x61, 0x72, 0x6e, 0x1a, 0x6b, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x63, 0x74, 0x72, 0x6c, 0x72, 0x5f, 0x70,
+	0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x74, 0x72, 0x6c, 0x72, 0x50, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x7a,
+	0x6f, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x7a, 0x6f, 0x6e, 0x65,
+	0x64, 0x1a, 0xbd, 0x01, 0x0a, 0x09, 0x53, 0x6d, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x67, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x06, 0x74, 0x67, 0x74, 0x49, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x72, 0x41, 0x64, 0x64,
+	0x72, 0x1a, 0x6f, 0x0a, 0x07, 0x50, 0x63, 0x69, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78,
+	0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x57, 0x69, 0x64,
+	0x74, 0x68, 0x22, 0x5b, 0x0a, 0x14, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x63,
+	0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x63,
+	0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22,
+	0xa9, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x12, 0x24, 0x0a, 0x0e, 0x70, 0x63, 0x69, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
+	0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x63, 0x69, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x72, 0x5f, 0x68,
+	0x75, 0x67, 0x65, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x6e, 0x72, 0x48, 0x75, 0x67, 0x65, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xc7, 0x02, 0x0a, 0x0f,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x0e, 0x50, 0x72,
-	0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x0e,
-	0x70, 0x63, 0x69, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x63, 0x69, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x4c, 0x69,
-	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x6e, 0x72, 0x5f, 0x68, 0x75, 0x67, 0x65, 0x5f, 0x70, 0x61,
-	0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6e, 0x72, 0x48, 0x75, 0x67,
-	0x65, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x5f, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x73, 0x65, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x22, 0x3b, 0x0a,
-	0x0f, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x4f, 0x0a, 0x0b, 0x53, 0x63,
-	0x61, 0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x61,
-	0x6c, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74,
-	0x68, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x65, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x61, 0x73, 0x69, 0x63, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x42, 0x61, 0x73, 0x69, 0x63, 0x22, 0x65, 0x0a, 0x0c, 0x53,
-	0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x63,
-	0x74, 0x72, 0x6c, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74,
-	0x6c, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
-	0x52, 0x06, 0x63, 0x74, 0x72, 0x6c, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x4e, 0x76, 0x6d, 0x65,
-	0x52, 0x65, 0x71, 0x42, 0x39, 0x5a, 0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f,
-	0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x69, 0x6e,
+	0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62,
+	0x69, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x62, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x74, 0x6c,
+	0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x2e, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x73, 0x74, 0x4f, 0x70, 0x12, 0x20, 0x0a, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x4f, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6f, 0x70, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4f, 0x70, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x1a, 0x52, 0x0a, 0x07, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08,
+	0x70, 0x63, 0x69, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x63, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x4f, 0x0a, 0x0b, 0x53, 0x63, 0x61, 0x6e, 0x4e, 0x76, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x4d, 0x65, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x4d, 0x65, 0x74, 0x61,
+	0x12, 0x14, 0x0a, 0x05, 0x42, 0x61, 0x73, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x42, 0x61, 0x73, 0x69, 0x63, 0x22, 0xaf, 0x01, 0x0a, 0x10, 0x42, 0x64, 0x65, 0x76, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x70, 0x64, 0x6b, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x70, 0x64, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x0c, 0x64, 0x70, 0x64, 0x6b, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x70, 0x64, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x6d, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x76, 0x6d, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x6d, 0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x76, 0x6d, 0x64, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x7a, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x7a, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x62, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x03, 0x63, 0x6d, 0x62, 0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x61,
+	0x6e, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x74, 0x72,
+	0x6c, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x74, 0x6c, 0x2e,
+	0x4e, 0x76, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x52, 0x06,
+	0x63, 0x74, 0x72, 0x6c, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x39, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x74, 0x6c, 0x2e, 0x42, 0x64, 0x65,
+	0x76, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x4e, 0x76, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x42, 0x39, 0x5a, 0x37,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d,
+	0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x74, 0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -957,33 +1308,39 @@ func file_ctl_storage_nvme_proto_rawDescGZIP() []byte {
 	return file_ctl_storage_nvme_proto_rawDescData
 }
 
-var file_ctl_storage_nvme_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_ctl_storage_nvme_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_ctl_storage_nvme_proto_goTypes = []interface{}{
 	(*NvmeController)(nil),           // 0: ctl.NvmeController
 	(*NvmeControllerResult)(nil),     // 1: ctl.NvmeControllerResult
 	(*PrepareNvmeReq)(nil),           // 2: ctl.PrepareNvmeReq
 	(*PrepareNvmeResp)(nil),          // 3: ctl.PrepareNvmeResp
 	(*ScanNvmeReq)(nil),              // 4: ctl.ScanNvmeReq
-	(*ScanNvmeResp)(nil),             // 5: ctl.ScanNvmeResp
-	(*FormatNvmeReq)(nil),            // 6: ctl.FormatNvmeReq
-	(*NvmeController_Health)(nil),    // 7: ctl.NvmeController.Health
-	(*NvmeController_Namespace)(nil), // 8: ctl.NvmeController.Namespace
-	(*NvmeController_SmdDevice)(nil), // 9: ctl.NvmeController.SmdDevice
-	(*ResponseState)(nil),            // 10: ctl.ResponseState
+	(*BdevCapabilities)(nil),         // 5: ctl.BdevCapabilities
+	(*ScanNvmeResp)(nil),             // 6: ctl.ScanNvmeResp
+	(*FormatNvmeReq)(nil),            // 7: ctl.FormatNvmeReq
+	(*NvmeController_Health)(nil),    // 8: ctl.NvmeController.Health
+	(*NvmeController_Namespace)(nil), // 9: ctl.NvmeController.Namespace
+	(*NvmeController_SmdDevice)(nil), // 10: ctl.NvmeController.SmdDevice
+	(*NvmeController_PciLink)(nil),   // 11: ctl.NvmeController.PciLink
+	(*PrepareNvmeResp_Binding)(nil),  // 12: ctl.PrepareNvmeResp.Binding
+	(*ResponseState)(nil),            // 13: ctl.ResponseState
 }
 var file_ctl_storage_nvme_proto_depIdxs = []int32{
-	7,  // 0: ctl.NvmeController.health_stats:type_name -> ctl.NvmeController.Health
-	8,  // 1: ctl.NvmeController.namespaces:type_name -> ctl.NvmeController.Namespace
-	9,  // 2: ctl.NvmeController.smd_devices:type_name -> ctl.NvmeController.SmdDevice
-	10, // 3: ctl.NvmeControllerResult.state:type_name -> ctl.ResponseState
-	10, // 4: ctl.PrepareNvmeResp.state:type_name -> ctl.ResponseState
-	0,  // 5: ctl.ScanNvmeResp.ctrlrs:type_name -> ctl.NvmeController
-	10, // 6: ctl.ScanNvmeResp.state:type_name -> ctl.ResponseState
-	7,  // [7:7] is the sub-list for method output_type
-	7,  // [7:7] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	8,  // 0: ctl.NvmeController.health_stats:type_name -> ctl.NvmeController.Health
+	9,  // 1: ctl.NvmeController.namespaces:type_name -> ctl.NvmeController.Namespace
+	10, // 2: ctl.NvmeController.smd_devices:type_name -> ctl.NvmeController.SmdDevice
+	11, // 3: ctl.NvmeController.pci_link:type_name -> ctl.NvmeController.PciLink
+	13, // 4: ctl.NvmeControllerResult.state:type_name -> ctl.ResponseState
+	13, // 5: ctl.PrepareNvmeResp.state:type_name -> ctl.ResponseState
+	12, // 6: ctl.PrepareNvmeResp.bindings:type_name -> ctl.PrepareNvmeResp.Binding
+	0,  // 7: ctl.ScanNvmeResp.ctrlrs:type_name -> ctl.NvmeController
+	13, // 8: ctl.ScanNvmeResp.state:type_name -> ctl.ResponseState
+	5,  // 9: ctl.ScanNvmeResp.capabilities:type_name -> ctl.BdevCapabilities
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_ctl_storage_nvme_proto_init() }
@@ -1054,7 +1411,7 @@ func file_ctl_storage_nvme_proto_init() {
 			}
 		}
 		file_ctl_storage_nvme_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScanNvmeResp); i {
+			switch v := v.(*BdevCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1066,7 +1423,7 @@ func file_ctl_storage_nvme_proto_init() {
 			}
 		}
 		file_ctl_storage_nvme_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FormatNvmeReq); i {
+			switch v := v.(*ScanNvmeResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1078,7 +1435,7 @@ func file_ctl_storage_nvme_proto_init() {
 			}
 		}
 		file_ctl_storage_nvme_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NvmeController_Health); i {
+			switch v := v.(*FormatNvmeReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1090,7 +1447,7 @@ func file_ctl_storage_nvme_proto_init() {
 			}
 		}
 		file_ctl_storage_nvme_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NvmeController_Namespace); i {
+			switch v := v.(*NvmeController_Health); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1102,6 +1459,18 @@ func file_ctl_storage_nvme_proto_init() {
 			}
 		}
 		file_ctl_storage_nvme_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NvmeController_Namespace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_nvme_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NvmeController_SmdDevice); i {
 			case 0:
 				return &v.state
@@ -1113,6 +1482,30 @@ func file_ctl_storage_nvme_proto_init() {
 				return nil
 			}
 		}
+		file_ctl_storage_nvme_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NvmeController_PciLink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ctl_storage_nvme_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareNvmeResp_Binding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1120,7 +1513,7 @@ func file_ctl_storage_nvme_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ctl_storage_nvme_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
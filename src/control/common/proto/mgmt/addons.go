@@ -85,3 +85,8 @@ func (r *ModifyACLReq) SetSvcRanks(rl []uint32) {
 func (r *DeleteACLReq) SetSvcRanks(rl []uint32) {
 	r.SvcRanks = rl
 }
+
+// SetSvcRanks sets the request's Pool Service Ranks.
+func (r *ListContReq) SetSvcRanks(rl []uint32) {
+	r.SvcRanks = rl
+}
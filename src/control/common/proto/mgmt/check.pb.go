@@ -0,0 +1,721 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.12.4
+// source: mgmt/check.proto
+
+package mgmt
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CheckStartReq supplies the pools to be checked.
+type CheckStartReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys   string   `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`     // DAOS system name
+	Pools []string `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"` // UUIDs or labels of pools to check, all if empty
+}
+
+func (x *CheckStartReq) Reset() {
+	*x = CheckStartReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStartReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStartReq) ProtoMessage() {}
+
+func (x *CheckStartReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStartReq.ProtoReflect.Descriptor instead.
+func (*CheckStartReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckStartReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *CheckStartReq) GetPools() []string {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+// CheckStartResp returns the identifier of the check run that was started.
+type CheckStartResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RunId uint64 `protobuf:"varint,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"` // identifier of the check run
+}
+
+func (x *CheckStartResp) Reset() {
+	*x = CheckStartResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStartResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStartResp) ProtoMessage() {}
+
+func (x *CheckStartResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStartResp.ProtoReflect.Descriptor instead.
+func (*CheckStartResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckStartResp) GetRunId() uint64 {
+	if x != nil {
+		return x.RunId
+	}
+	return 0
+}
+
+// CheckFinding describes an inconsistency found by a check run.
+type CheckFinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                               // identifier of the finding within the check run
+	Class       string   `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`                          // class of inconsistency
+	PoolUuid    string   `protobuf:"bytes,3,opt,name=pool_uuid,json=poolUuid,proto3" json:"pool_uuid,omitempty"`    // uuid of the affected pool
+	PoolLabel   string   `protobuf:"bytes,4,opt,name=pool_label,json=poolLabel,proto3" json:"pool_label,omitempty"` // label of the affected pool
+	Description string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`              // description of the inconsistency
+	Actions     []string `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`                      // repair actions that may be taken
+	Suggested   string   `protobuf:"bytes,7,opt,name=suggested,proto3" json:"suggested,omitempty"`                  // suggested repair action
+	Status      string   `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`                        // pending, repaired, ignored or failed
+	Action      string   `protobuf:"bytes,9,opt,name=action,proto3" json:"action,omitempty"`                        // repair action taken
+	Result      string   `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`                       // outcome of the repair action
+}
+
+func (x *CheckFinding) Reset() {
+	*x = CheckFinding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFinding) ProtoMessage() {}
+
+func (x *CheckFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFinding.ProtoReflect.Descriptor instead.
+func (*CheckFinding) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckFinding) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CheckFinding) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetPoolUuid() string {
+	if x != nil {
+		return x.PoolUuid
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetPoolLabel() string {
+	if x != nil {
+		return x.PoolLabel
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *CheckFinding) GetSuggested() string {
+	if x != nil {
+		return x.Suggested
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *CheckFinding) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+// CheckQueryReq requests the status of the most recent check run.
+type CheckQueryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system name
+}
+
+func (x *CheckQueryReq) Reset() {
+	*x = CheckQueryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckQueryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckQueryReq) ProtoMessage() {}
+
+func (x *CheckQueryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckQueryReq.ProtoReflect.Descriptor instead.
+func (*CheckQueryReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckQueryReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// CheckQueryResp returns the status and findings of the most recent check run.
+type CheckQueryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RunId        uint64          `protobuf:"varint,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`                      // identifier of the check run, zero if none has run
+	Status       string          `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                  // running, completed or failed
+	PoolsChecked uint32          `protobuf:"varint,3,opt,name=pools_checked,json=poolsChecked,proto3" json:"pools_checked,omitempty"` // number of pools checked so far
+	PoolsTotal   uint32          `protobuf:"varint,4,opt,name=pools_total,json=poolsTotal,proto3" json:"pools_total,omitempty"`       // number of pools to be checked
+	Started      string          `protobuf:"bytes,5,opt,name=started,proto3" json:"started,omitempty"`                                // RFC3339 time the check run started
+	Finished     string          `protobuf:"bytes,6,opt,name=finished,proto3" json:"finished,omitempty"`                              // RFC3339 time the check run finished
+	Findings     []*CheckFinding `protobuf:"bytes,7,rep,name=findings,proto3" json:"findings,omitempty"`
+	Error        string          `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"` // reason the check run failed
+}
+
+func (x *CheckQueryResp) Reset() {
+	*x = CheckQueryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckQueryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckQueryResp) ProtoMessage() {}
+
+func (x *CheckQueryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckQueryResp.ProtoReflect.Descriptor instead.
+func (*CheckQueryResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckQueryResp) GetRunId() uint64 {
+	if x != nil {
+		return x.RunId
+	}
+	return 0
+}
+
+func (x *CheckQueryResp) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CheckQueryResp) GetPoolsChecked() uint32 {
+	if x != nil {
+		return x.PoolsChecked
+	}
+	return 0
+}
+
+func (x *CheckQueryResp) GetPoolsTotal() uint32 {
+	if x != nil {
+		return x.PoolsTotal
+	}
+	return 0
+}
+
+func (x *CheckQueryResp) GetStarted() string {
+	if x != nil {
+		return x.Started
+	}
+	return ""
+}
+
+func (x *CheckQueryResp) GetFinished() string {
+	if x != nil {
+		return x.Finished
+	}
+	return ""
+}
+
+func (x *CheckQueryResp) GetFindings() []*CheckFinding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *CheckQueryResp) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// CheckRepairReq supplies the repair action to take for a finding.
+type CheckRepairReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys     string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`          // DAOS system name
+	Finding uint64 `protobuf:"varint,2,opt,name=finding,proto3" json:"finding,omitempty"` // identifier of the finding to repair
+	Action  string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`    // repair action to take
+}
+
+func (x *CheckRepairReq) Reset() {
+	*x = CheckRepairReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckRepairReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRepairReq) ProtoMessage() {}
+
+func (x *CheckRepairReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRepairReq.ProtoReflect.Descriptor instead.
+func (*CheckRepairReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckRepairReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *CheckRepairReq) GetFinding() uint64 {
+	if x != nil {
+		return x.Finding
+	}
+	return 0
+}
+
+func (x *CheckRepairReq) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+// CheckRepairResp returns the finding updated with the outcome of the repair.
+type CheckRepairResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Finding *CheckFinding `protobuf:"bytes,1,opt,name=finding,proto3" json:"finding,omitempty"`
+}
+
+func (x *CheckRepairResp) Reset() {
+	*x = CheckRepairResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_check_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckRepairResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRepairResp) ProtoMessage() {}
+
+func (x *CheckRepairResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_check_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRepairResp.ProtoReflect.Descriptor instead.
+func (*CheckRepairResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_check_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckRepairResp) GetFinding() *CheckFinding {
+	if x != nil {
+		return x.Finding
+	}
+	return nil
+}
+
+var File_mgmt_check_proto protoreflect.FileDescriptor
+
+var file_mgmt_check_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x6d, 0x67, 0x6d, 0x74, 0x22, 0x37, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c,
+	0x73, 0x22, 0x27, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x49, 0x64, 0x22, 0x92, 0x02, 0x0a, 0x0c, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6c, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x6f, 0x6c, 0x55, 0x75, 0x69, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
+	0x21, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
+	0x79, 0x73, 0x22, 0x81, 0x02, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x5f, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x6f, 0x6f,
+	0x6c, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x6f,
+	0x6c, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x12, 0x2e, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x46,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x54, 0x0a, 0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x66, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x0f,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x2c, 0x0a, 0x07, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x46, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x3a, 0x5a,
+	0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73,
+	0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_mgmt_check_proto_rawDescOnce sync.Once
+	file_mgmt_check_proto_rawDescData = file_mgmt_check_proto_rawDesc
+)
+
+func file_mgmt_check_proto_rawDescGZIP() []byte {
+	file_mgmt_check_proto_rawDescOnce.Do(func() {
+		file_mgmt_check_proto_rawDescData = protoimpl.X.CompressGZIP(file_mgmt_check_proto_rawDescData)
+	})
+	return file_mgmt_check_proto_rawDescData
+}
+
+var file_mgmt_check_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_mgmt_check_proto_goTypes = []interface{}{
+	(*CheckStartReq)(nil),   // 0: mgmt.CheckStartReq
+	(*CheckStartResp)(nil),  // 1: mgmt.CheckStartResp
+	(*CheckFinding)(nil),    // 2: mgmt.CheckFinding
+	(*CheckQueryReq)(nil),   // 3: mgmt.CheckQueryReq
+	(*CheckQueryResp)(nil),  // 4: mgmt.CheckQueryResp
+	(*CheckRepairReq)(nil),  // 5: mgmt.CheckRepairReq
+	(*CheckRepairResp)(nil), // 6: mgmt.CheckRepairResp
+}
+var file_mgmt_check_proto_depIdxs = []int32{
+	2, // 0: mgmt.CheckQueryResp.findings:type_name -> mgmt.CheckFinding
+	2, // 1: mgmt.CheckRepairResp.finding:type_name -> mgmt.CheckFinding
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_mgmt_check_proto_init() }
+func file_mgmt_check_proto_init() {
+	if File_mgmt_check_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_mgmt_check_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckStartReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckStartResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckFinding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckQueryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckQueryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckRepairReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_check_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckRepairResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mgmt_check_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_mgmt_check_proto_goTypes,
+		DependencyIndexes: file_mgmt_check_proto_depIdxs,
+		MessageInfos:      file_mgmt_check_proto_msgTypes,
+	}.Build()
+	File_mgmt_check_proto = out.File
+	file_mgmt_check_proto_rawDesc = nil
+	file_mgmt_check_proto_goTypes = nil
+	file_mgmt_check_proto_depIdxs = nil
+}
@@ -37,29 +37,34 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x67, 0x6d, 0x74, 0x2f, 0x73, 0x76, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x6d,
 	0x67, 0x6d, 0x74, 0x2f, 0x61, 0x63, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x6d,
 	0x67, 0x6d, 0x74, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x32, 0xdd, 0x0b, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12, 0x27, 0x0a, 0x04,
-	0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x0d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f, 0x69, 0x6e,
-	0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x18,
-	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x4c, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
-	0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a,
-	0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x50, 0x6f, 0x6f, 0x6c,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
-	0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67,
-	0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c,
-	0x76, 0x65, 0x49, 0x44, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c,
-	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49,
-	0x44, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x50, 0x6f, 0x6f, 0x6c, 0x44,
-	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
-	0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d,
-	0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52,
+	0x1a, 0x10, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x32, 0x8d, 0x13, 0x0a, 0x07, 0x4d, 0x67, 0x6d, 0x74, 0x53, 0x76, 0x63, 0x12, 0x27,
+	0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x0d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x18, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b,
+	0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x2e, 0x6d, 0x67,
+	0x6d, 0x74, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x1a, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x50, 0x6f,
+	0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e,
+	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x49, 0x44, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x49, 0x44, 0x52, 0x65, 0x71, 0x1a, 0x17,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x50, 0x6f, 0x6f,
+	0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e,
+	0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x15,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x50, 0x6f, 0x6f, 0x6c, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52,
 	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x09, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76, 0x69,
 	0x63, 0x74, 0x12, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76,
 	0x69, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
@@ -131,60 +136,141 @@ var file_mgmt_mgmt_proto_rawDesc = []byte{
 	0x65, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45,
 	0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53,
 	0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
-	0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64,
-	0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73,
-	0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x4e, 0x0a, 0x11, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x1b, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00,
+	0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x12, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1b, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x12, 0x57, 0x0a, 0x14, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x13, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61,
+	0x72, 0x65, 0x12, 0x1c, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71,
+	0x1a, 0x1d, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22,
+	0x00, 0x12, 0x42, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x13, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x6d, 0x67, 0x6d,
+	0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x12, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x12, 0x1b, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50,
+	0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1c,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x6f, 0x6f, 0x6c,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39,
+	0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x13, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6d,
+	0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70,
+	0x61, 0x69, 0x72, 0x12, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73,
+	0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_mgmt_mgmt_proto_goTypes = []interface{}{
-	(*JoinReq)(nil),                 // 0: mgmt.JoinReq
-	(*shared.ClusterEventReq)(nil),  // 1: shared.ClusterEventReq
-	(*LeaderQueryReq)(nil),          // 2: mgmt.LeaderQueryReq
-	(*PoolCreateReq)(nil),           // 3: mgmt.PoolCreateReq
-	(*PoolResolveIDReq)(nil),        // 4: mgmt.PoolResolveIDReq
-	(*PoolDestroyReq)(nil),          // 5: mgmt.PoolDestroyReq
-	(*PoolEvictReq)(nil),            // 6: mgmt.PoolEvictReq
-	(*PoolExcludeReq)(nil),          // 7: mgmt.PoolExcludeReq
-	(*PoolDrainReq)(nil),            // 8: mgmt.PoolDrainReq
-	(*PoolExtendReq)(nil),           // 9: mgmt.PoolExtendReq
-	(*PoolReintegrateReq)(nil),      // 10: mgmt.PoolReintegrateReq
-	(*PoolQueryReq)(nil),            // 11: mgmt.PoolQueryReq
-	(*PoolSetPropReq)(nil),          // 12: mgmt.PoolSetPropReq
-	(*GetACLReq)(nil),               // 13: mgmt.GetACLReq
-	(*ModifyACLReq)(nil),            // 14: mgmt.ModifyACLReq
-	(*DeleteACLReq)(nil),            // 15: mgmt.DeleteACLReq
-	(*GetAttachInfoReq)(nil),        // 16: mgmt.GetAttachInfoReq
-	(*ListPoolsReq)(nil),            // 17: mgmt.ListPoolsReq
-	(*ListContReq)(nil),             // 18: mgmt.ListContReq
-	(*ContSetOwnerReq)(nil),         // 19: mgmt.ContSetOwnerReq
-	(*SystemQueryReq)(nil),          // 20: mgmt.SystemQueryReq
-	(*SystemStopReq)(nil),           // 21: mgmt.SystemStopReq
-	(*SystemStartReq)(nil),          // 22: mgmt.SystemStartReq
-	(*SystemEraseReq)(nil),          // 23: mgmt.SystemEraseReq
-	(*JoinResp)(nil),                // 24: mgmt.JoinResp
-	(*shared.ClusterEventResp)(nil), // 25: shared.ClusterEventResp
-	(*LeaderQueryResp)(nil),         // 26: mgmt.LeaderQueryResp
-	(*PoolCreateResp)(nil),          // 27: mgmt.PoolCreateResp
-	(*PoolResolveIDResp)(nil),       // 28: mgmt.PoolResolveIDResp
-	(*PoolDestroyResp)(nil),         // 29: mgmt.PoolDestroyResp
-	(*PoolEvictResp)(nil),           // 30: mgmt.PoolEvictResp
-	(*PoolExcludeResp)(nil),         // 31: mgmt.PoolExcludeResp
-	(*PoolDrainResp)(nil),           // 32: mgmt.PoolDrainResp
-	(*PoolExtendResp)(nil),          // 33: mgmt.PoolExtendResp
-	(*PoolReintegrateResp)(nil),     // 34: mgmt.PoolReintegrateResp
-	(*PoolQueryResp)(nil),           // 35: mgmt.PoolQueryResp
-	(*PoolSetPropResp)(nil),         // 36: mgmt.PoolSetPropResp
-	(*ACLResp)(nil),                 // 37: mgmt.ACLResp
-	(*GetAttachInfoResp)(nil),       // 38: mgmt.GetAttachInfoResp
-	(*ListPoolsResp)(nil),           // 39: mgmt.ListPoolsResp
-	(*ListContResp)(nil),            // 40: mgmt.ListContResp
-	(*ContSetOwnerResp)(nil),        // 41: mgmt.ContSetOwnerResp
-	(*SystemQueryResp)(nil),         // 42: mgmt.SystemQueryResp
-	(*SystemStopResp)(nil),          // 43: mgmt.SystemStopResp
-	(*SystemStartResp)(nil),         // 44: mgmt.SystemStartResp
-	(*SystemEraseResp)(nil),         // 45: mgmt.SystemEraseResp
+	(*JoinReq)(nil),                  // 0: mgmt.JoinReq
+	(*shared.ClusterEventReq)(nil),   // 1: shared.ClusterEventReq
+	(*LeaderQueryReq)(nil),           // 2: mgmt.LeaderQueryReq
+	(*PoolCreateReq)(nil),            // 3: mgmt.PoolCreateReq
+	(*PoolResolveIDReq)(nil),         // 4: mgmt.PoolResolveIDReq
+	(*PoolDestroyReq)(nil),           // 5: mgmt.PoolDestroyReq
+	(*PoolRestoreReq)(nil),           // 6: mgmt.PoolRestoreReq
+	(*PoolEvictReq)(nil),             // 7: mgmt.PoolEvictReq
+	(*PoolExcludeReq)(nil),           // 8: mgmt.PoolExcludeReq
+	(*PoolDrainReq)(nil),             // 9: mgmt.PoolDrainReq
+	(*PoolExtendReq)(nil),            // 10: mgmt.PoolExtendReq
+	(*PoolReintegrateReq)(nil),       // 11: mgmt.PoolReintegrateReq
+	(*PoolQueryReq)(nil),             // 12: mgmt.PoolQueryReq
+	(*PoolSetPropReq)(nil),           // 13: mgmt.PoolSetPropReq
+	(*GetACLReq)(nil),                // 14: mgmt.GetACLReq
+	(*ModifyACLReq)(nil),             // 15: mgmt.ModifyACLReq
+	(*DeleteACLReq)(nil),             // 16: mgmt.DeleteACLReq
+	(*GetAttachInfoReq)(nil),         // 17: mgmt.GetAttachInfoReq
+	(*ListPoolsReq)(nil),             // 18: mgmt.ListPoolsReq
+	(*ListContReq)(nil),              // 19: mgmt.ListContReq
+	(*ContSetOwnerReq)(nil),          // 20: mgmt.ContSetOwnerReq
+	(*SystemQueryReq)(nil),           // 21: mgmt.SystemQueryReq
+	(*SystemStopReq)(nil),            // 22: mgmt.SystemStopReq
+	(*SystemStartReq)(nil),           // 23: mgmt.SystemStartReq
+	(*SystemEraseReq)(nil),           // 24: mgmt.SystemEraseReq
+	(*SystemMaintenanceReq)(nil),     // 25: mgmt.SystemMaintenanceReq
+	(*SystemExcludeReq)(nil),         // 26: mgmt.SystemExcludeReq
+	(*SystemReplicaQueryReq)(nil),    // 27: mgmt.SystemReplicaQueryReq
+	(*SystemLockReq)(nil),            // 28: mgmt.SystemLockReq
+	(*SystemSetFaultDomainReq)(nil),  // 29: mgmt.SystemSetFaultDomainReq
+	(*SystemActivateSpareReq)(nil),   // 30: mgmt.SystemActivateSpareReq
+	(*SystemHistoryReq)(nil),         // 31: mgmt.SystemHistoryReq
+	(*SystemHistoryRecordReq)(nil),   // 32: mgmt.SystemHistoryRecordReq
+	(*SystemPoolDefaultsReq)(nil),    // 33: mgmt.SystemPoolDefaultsReq
+	(*CheckStartReq)(nil),            // 34: mgmt.CheckStartReq
+	(*CheckQueryReq)(nil),            // 35: mgmt.CheckQueryReq
+	(*CheckRepairReq)(nil),           // 36: mgmt.CheckRepairReq
+	(*JoinResp)(nil),                 // 37: mgmt.JoinResp
+	(*shared.ClusterEventResp)(nil),  // 38: shared.ClusterEventResp
+	(*LeaderQueryResp)(nil),          // 39: mgmt.LeaderQueryResp
+	(*PoolCreateResp)(nil),           // 40: mgmt.PoolCreateResp
+	(*PoolResolveIDResp)(nil),        // 41: mgmt.PoolResolveIDResp
+	(*PoolDestroyResp)(nil),          // 42: mgmt.PoolDestroyResp
+	(*PoolRestoreResp)(nil),          // 43: mgmt.PoolRestoreResp
+	(*PoolEvictResp)(nil),            // 44: mgmt.PoolEvictResp
+	(*PoolExcludeResp)(nil),          // 45: mgmt.PoolExcludeResp
+	(*PoolDrainResp)(nil),            // 46: mgmt.PoolDrainResp
+	(*PoolExtendResp)(nil),           // 47: mgmt.PoolExtendResp
+	(*PoolReintegrateResp)(nil),      // 48: mgmt.PoolReintegrateResp
+	(*PoolQueryResp)(nil),            // 49: mgmt.PoolQueryResp
+	(*PoolSetPropResp)(nil),          // 50: mgmt.PoolSetPropResp
+	(*ACLResp)(nil),                  // 51: mgmt.ACLResp
+	(*GetAttachInfoResp)(nil),        // 52: mgmt.GetAttachInfoResp
+	(*ListPoolsResp)(nil),            // 53: mgmt.ListPoolsResp
+	(*ListContResp)(nil),             // 54: mgmt.ListContResp
+	(*ContSetOwnerResp)(nil),         // 55: mgmt.ContSetOwnerResp
+	(*SystemQueryResp)(nil),          // 56: mgmt.SystemQueryResp
+	(*SystemStopResp)(nil),           // 57: mgmt.SystemStopResp
+	(*SystemStartResp)(nil),          // 58: mgmt.SystemStartResp
+	(*SystemEraseResp)(nil),          // 59: mgmt.SystemEraseResp
+	(*SystemMaintenanceResp)(nil),    // 60: mgmt.SystemMaintenanceResp
+	(*SystemExcludeResp)(nil),        // 61: mgmt.SystemExcludeResp
+	(*SystemReplicaQueryResp)(nil),   // 62: mgmt.SystemReplicaQueryResp
+	(*SystemLockResp)(nil),           // 63: mgmt.SystemLockResp
+	(*SystemSetFaultDomainResp)(nil), // 64: mgmt.SystemSetFaultDomainResp
+	(*SystemActivateSpareResp)(nil),  // 65: mgmt.SystemActivateSpareResp
+	(*SystemHistoryResp)(nil),        // 66: mgmt.SystemHistoryResp
+	(*SystemHistoryRecordResp)(nil),  // 67: mgmt.SystemHistoryRecordResp
+	(*SystemPoolDefaultsResp)(nil),   // 68: mgmt.SystemPoolDefaultsResp
+	(*CheckStartResp)(nil),           // 69: mgmt.CheckStartResp
+	(*CheckQueryResp)(nil),           // 70: mgmt.CheckQueryResp
+	(*CheckRepairResp)(nil),          // 71: mgmt.CheckRepairResp
 }
 var file_mgmt_mgmt_proto_depIdxs = []int32{
 	0,  // 0: mgmt.MgmtSvc.Join:input_type -> mgmt.JoinReq
@@ -193,52 +279,78 @@ var file_mgmt_mgmt_proto_depIdxs = []int32{
 	3,  // 3: mgmt.MgmtSvc.PoolCreate:input_type -> mgmt.PoolCreateReq
 	4,  // 4: mgmt.MgmtSvc.PoolResolveID:input_type -> mgmt.PoolResolveIDReq
 	5,  // 5: mgmt.MgmtSvc.PoolDestroy:input_type -> mgmt.PoolDestroyReq
-	6,  // 6: mgmt.MgmtSvc.PoolEvict:input_type -> mgmt.PoolEvictReq
-	7,  // 7: mgmt.MgmtSvc.PoolExclude:input_type -> mgmt.PoolExcludeReq
-	8,  // 8: mgmt.MgmtSvc.PoolDrain:input_type -> mgmt.PoolDrainReq
-	9,  // 9: mgmt.MgmtSvc.PoolExtend:input_type -> mgmt.PoolExtendReq
-	10, // 10: mgmt.MgmtSvc.PoolReintegrate:input_type -> mgmt.PoolReintegrateReq
-	11, // 11: mgmt.MgmtSvc.PoolQuery:input_type -> mgmt.PoolQueryReq
-	12, // 12: mgmt.MgmtSvc.PoolSetProp:input_type -> mgmt.PoolSetPropReq
-	13, // 13: mgmt.MgmtSvc.PoolGetACL:input_type -> mgmt.GetACLReq
-	14, // 14: mgmt.MgmtSvc.PoolOverwriteACL:input_type -> mgmt.ModifyACLReq
-	14, // 15: mgmt.MgmtSvc.PoolUpdateACL:input_type -> mgmt.ModifyACLReq
-	15, // 16: mgmt.MgmtSvc.PoolDeleteACL:input_type -> mgmt.DeleteACLReq
-	16, // 17: mgmt.MgmtSvc.GetAttachInfo:input_type -> mgmt.GetAttachInfoReq
-	17, // 18: mgmt.MgmtSvc.ListPools:input_type -> mgmt.ListPoolsReq
-	18, // 19: mgmt.MgmtSvc.ListContainers:input_type -> mgmt.ListContReq
-	19, // 20: mgmt.MgmtSvc.ContSetOwner:input_type -> mgmt.ContSetOwnerReq
-	20, // 21: mgmt.MgmtSvc.SystemQuery:input_type -> mgmt.SystemQueryReq
-	21, // 22: mgmt.MgmtSvc.SystemStop:input_type -> mgmt.SystemStopReq
-	22, // 23: mgmt.MgmtSvc.SystemStart:input_type -> mgmt.SystemStartReq
-	23, // 24: mgmt.MgmtSvc.SystemErase:input_type -> mgmt.SystemEraseReq
-	24, // 25: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
-	25, // 26: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
-	26, // 27: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
-	27, // 28: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
-	28, // 29: mgmt.MgmtSvc.PoolResolveID:output_type -> mgmt.PoolResolveIDResp
-	29, // 30: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
-	30, // 31: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
-	31, // 32: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
-	32, // 33: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
-	33, // 34: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
-	34, // 35: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintegrateResp
-	35, // 36: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
-	36, // 37: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
-	37, // 38: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
-	37, // 39: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
-	37, // 40: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
-	37, // 41: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
-	38, // 42: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
-	39, // 43: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
-	40, // 44: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
-	41, // 45: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.ContSetOwnerResp
-	42, // 46: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
-	43, // 47: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
-	44, // 48: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
-	45, // 49: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
-	25, // [25:50] is the sub-list for method output_type
-	0,  // [0:25] is the sub-list for method input_type
+	6,  // 6: mgmt.MgmtSvc.PoolRestore:input_type -> mgmt.PoolRestoreReq
+	7,  // 7: mgmt.MgmtSvc.PoolEvict:input_type -> mgmt.PoolEvictReq
+	8,  // 8: mgmt.MgmtSvc.PoolExclude:input_type -> mgmt.PoolExcludeReq
+	9,  // 9: mgmt.MgmtSvc.PoolDrain:input_type -> mgmt.PoolDrainReq
+	10, // 10: mgmt.MgmtSvc.PoolExtend:input_type -> mgmt.PoolExtendReq
+	11, // 11: mgmt.MgmtSvc.PoolReintegrate:input_type -> mgmt.PoolReintegrateReq
+	12, // 12: mgmt.MgmtSvc.PoolQuery:input_type -> mgmt.PoolQueryReq
+	13, // 13: mgmt.MgmtSvc.PoolSetProp:input_type -> mgmt.PoolSetPropReq
+	14, // 14: mgmt.MgmtSvc.PoolGetACL:input_type -> mgmt.GetACLReq
+	15, // 15: mgmt.MgmtSvc.PoolOverwriteACL:input_type -> mgmt.ModifyACLReq
+	15, // 16: mgmt.MgmtSvc.PoolUpdateACL:input_type -> mgmt.ModifyACLReq
+	16, // 17: mgmt.MgmtSvc.PoolDeleteACL:input_type -> mgmt.DeleteACLReq
+	17, // 18: mgmt.MgmtSvc.GetAttachInfo:input_type -> mgmt.GetAttachInfoReq
+	18, // 19: mgmt.MgmtSvc.ListPools:input_type -> mgmt.ListPoolsReq
+	19, // 20: mgmt.MgmtSvc.ListContainers:input_type -> mgmt.ListContReq
+	20, // 21: mgmt.MgmtSvc.ContSetOwner:input_type -> mgmt.ContSetOwnerReq
+	21, // 22: mgmt.MgmtSvc.SystemQuery:input_type -> mgmt.SystemQueryReq
+	22, // 23: mgmt.MgmtSvc.SystemStop:input_type -> mgmt.SystemStopReq
+	23, // 24: mgmt.MgmtSvc.SystemStart:input_type -> mgmt.SystemStartReq
+	24, // 25: mgmt.MgmtSvc.SystemErase:input_type -> mgmt.SystemEraseReq
+	25, // 26: mgmt.MgmtSvc.SystemMaintenance:input_type -> mgmt.SystemMaintenanceReq
+	26, // 27: mgmt.MgmtSvc.SystemExclude:input_type -> mgmt.SystemExcludeReq
+	27, // 28: mgmt.MgmtSvc.SystemReplicaQuery:input_type -> mgmt.SystemReplicaQueryReq
+	28, // 29: mgmt.MgmtSvc.SystemLock:input_type -> mgmt.SystemLockReq
+	29, // 30: mgmt.MgmtSvc.SystemSetFaultDomain:input_type -> mgmt.SystemSetFaultDomainReq
+	30, // 31: mgmt.MgmtSvc.SystemActivateSpare:input_type -> mgmt.SystemActivateSpareReq
+	31, // 32: mgmt.MgmtSvc.SystemHistory:input_type -> mgmt.SystemHistoryReq
+	32, // 33: mgmt.MgmtSvc.SystemHistoryRecord:input_type -> mgmt.SystemHistoryRecordReq
+	33, // 34: mgmt.MgmtSvc.SystemPoolDefaults:input_type -> mgmt.SystemPoolDefaultsReq
+	34, // 35: mgmt.MgmtSvc.CheckStart:input_type -> mgmt.CheckStartReq
+	35, // 36: mgmt.MgmtSvc.CheckQuery:input_type -> mgmt.CheckQueryReq
+	36, // 37: mgmt.MgmtSvc.CheckRepair:input_type -> mgmt.CheckRepairReq
+	37, // 38: mgmt.MgmtSvc.Join:output_type -> mgmt.JoinResp
+	38, // 39: mgmt.MgmtSvc.ClusterEvent:output_type -> shared.ClusterEventResp
+	39, // 40: mgmt.MgmtSvc.LeaderQuery:output_type -> mgmt.LeaderQueryResp
+	40, // 41: mgmt.MgmtSvc.PoolCreate:output_type -> mgmt.PoolCreateResp
+	41, // 42: mgmt.MgmtSvc.PoolResolveID:output_type -> mgmt.PoolResolveIDResp
+	42, // 43: mgmt.MgmtSvc.PoolDestroy:output_type -> mgmt.PoolDestroyResp
+	43, // 44: mgmt.MgmtSvc.PoolRestore:output_type -> mgmt.PoolRestoreResp
+	44, // 45: mgmt.MgmtSvc.PoolEvict:output_type -> mgmt.PoolEvictResp
+	45, // 46: mgmt.MgmtSvc.PoolExclude:output_type -> mgmt.PoolExcludeResp
+	46, // 47: mgmt.MgmtSvc.PoolDrain:output_type -> mgmt.PoolDrainResp
+	47, // 48: mgmt.MgmtSvc.PoolExtend:output_type -> mgmt.PoolExtendResp
+	48, // 49: mgmt.MgmtSvc.PoolReintegrate:output_type -> mgmt.PoolReintegrateResp
+	49, // 50: mgmt.MgmtSvc.PoolQuery:output_type -> mgmt.PoolQueryResp
+	50, // 51: mgmt.MgmtSvc.PoolSetProp:output_type -> mgmt.PoolSetPropResp
+	51, // 52: mgmt.MgmtSvc.PoolGetACL:output_type -> mgmt.ACLResp
+	51, // 53: mgmt.MgmtSvc.PoolOverwriteACL:output_type -> mgmt.ACLResp
+	51, // 54: mgmt.MgmtSvc.PoolUpdateACL:output_type -> mgmt.ACLResp
+	51, // 55: mgmt.MgmtSvc.PoolDeleteACL:output_type -> mgmt.ACLResp
+	52, // 56: mgmt.MgmtSvc.GetAttachInfo:output_type -> mgmt.GetAttachInfoResp
+	53, // 57: mgmt.MgmtSvc.ListPools:output_type -> mgmt.ListPoolsResp
+	54, // 58: mgmt.MgmtSvc.ListContainers:output_type -> mgmt.ListContResp
+	55, // 59: mgmt.MgmtSvc.ContSetOwner:output_type -> mgmt.ContSetOwnerResp
+	56, // 60: mgmt.MgmtSvc.SystemQuery:output_type -> mgmt.SystemQueryResp
+	57, // 61: mgmt.MgmtSvc.SystemStop:output_type -> mgmt.SystemStopResp
+	58, // 62: mgmt.MgmtSvc.SystemStart:output_type -> mgmt.SystemStartResp
+	59, // 63: mgmt.MgmtSvc.SystemErase:output_type -> mgmt.SystemEraseResp
+	60, // 64: mgmt.MgmtSvc.SystemMaintenance:output_type -> mgmt.SystemMaintenanceResp
+	61, // 65: mgmt.MgmtSvc.SystemExclude:output_type -> mgmt.SystemExcludeResp
+	62, // 66: mgmt.MgmtSvc.SystemReplicaQuery:output_type -> mgmt.SystemReplicaQueryResp
+	63, // 67: mgmt.MgmtSvc.SystemLock:output_type -> mgmt.SystemLockResp
+	64, // 68: mgmt.MgmtSvc.SystemSetFaultDomain:output_type -> mgmt.SystemSetFaultDomainResp
+	65, // 69: mgmt.MgmtSvc.SystemActivateSpare:output_type -> mgmt.SystemActivateSpareResp
+	66, // 70: mgmt.MgmtSvc.SystemHistory:output_type -> mgmt.SystemHistoryResp
+	67, // 71: mgmt.MgmtSvc.SystemHistoryRecord:output_type -> mgmt.SystemHistoryRecordResp
+	68, // 72: mgmt.MgmtSvc.SystemPoolDefaults:output_type -> mgmt.SystemPoolDefaultsResp
+	69, // 73: mgmt.MgmtSvc.CheckStart:output_type -> mgmt.CheckStartResp
+	70, // 74: mgmt.MgmtSvc.CheckQuery:output_type -> mgmt.CheckQueryResp
+	71, // 75: mgmt.MgmtSvc.CheckRepair:output_type -> mgmt.CheckRepairResp
+	38, // [38:76] is the sub-list for method output_type
+	0,  // [0:38] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -254,6 +366,7 @@ func file_mgmt_mgmt_proto_init() {
 	file_mgmt_svc_proto_init()
 	file_mgmt_acl_proto_init()
 	file_mgmt_system_proto_init()
+	file_mgmt_check_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
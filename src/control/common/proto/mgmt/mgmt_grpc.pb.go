@@ -32,6 +32,8 @@ type MgmtSvcClient interface {
 	PoolResolveID(ctx context.Context, in *PoolResolveIDReq, opts ...grpc.CallOption) (*PoolResolveIDResp, error)
 	// Destroy a DAOS pool allocated across a number of ranks.
 	PoolDestroy(ctx context.Context, in *PoolDestroyReq, opts ...grpc.CallOption) (*PoolDestroyResp, error)
+	// Restore a DAOS pool that is pending a delayed destroy
+	PoolRestore(ctx context.Context, in *PoolRestoreReq, opts ...grpc.CallOption) (*PoolRestoreResp, error)
 	// Evict a DAOS pool's connections.
 	PoolEvict(ctx context.Context, in *PoolEvictReq, opts ...grpc.CallOption) (*PoolEvictResp, error)
 	// Exclude a pool target.
@@ -70,6 +72,30 @@ type MgmtSvcClient interface {
 	SystemStart(ctx context.Context, in *SystemStartReq, opts ...grpc.CallOption) (*SystemStartResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(ctx context.Context, in *SystemEraseReq, opts ...grpc.CallOption) (*SystemEraseResp, error)
+	// Manage DAOS system maintenance windows
+	SystemMaintenance(ctx context.Context, in *SystemMaintenanceReq, opts ...grpc.CallOption) (*SystemMaintenanceResp, error)
+	// Exclude DAOS system members or clear their exclusion
+	SystemExclude(ctx context.Context, in *SystemExcludeReq, opts ...grpc.CallOption) (*SystemExcludeResp, error)
+	// Query the raft status of a Management Service replica
+	SystemReplicaQuery(ctx context.Context, in *SystemReplicaQueryReq, opts ...grpc.CallOption) (*SystemReplicaQueryResp, error)
+	// Manage DAOS system administrative locks
+	SystemLock(ctx context.Context, in *SystemLockReq, opts ...grpc.CallOption) (*SystemLockResp, error)
+	// Assign fault domains to DAOS system members or clear the assignment
+	SystemSetFaultDomain(ctx context.Context, in *SystemSetFaultDomainReq, opts ...grpc.CallOption) (*SystemSetFaultDomainResp, error)
+	// Activate a spare engine to replace a failed DAOS system member
+	SystemActivateSpare(ctx context.Context, in *SystemActivateSpareReq, opts ...grpc.CallOption) (*SystemActivateSpareResp, error)
+	// Query the history of administrative operations
+	SystemHistory(ctx context.Context, in *SystemHistoryReq, opts ...grpc.CallOption) (*SystemHistoryResp, error)
+	// Record an administrative operation performed on a server
+	SystemHistoryRecord(ctx context.Context, in *SystemHistoryRecordReq, opts ...grpc.CallOption) (*SystemHistoryRecordResp, error)
+	// Set or query the system-level pool property defaults
+	SystemPoolDefaults(ctx context.Context, in *SystemPoolDefaultsReq, opts ...grpc.CallOption) (*SystemPoolDefaultsResp, error)
+	// Start a check of the consistency of system metadata
+	CheckStart(ctx context.Context, in *CheckStartReq, opts ...grpc.CallOption) (*CheckStartResp, error)
+	// Query the status and findings of the most recent check
+	CheckQuery(ctx context.Context, in *CheckQueryReq, opts ...grpc.CallOption) (*CheckQueryResp, error)
+	// Repair an inconsistency found by a check
+	CheckRepair(ctx context.Context, in *CheckRepairReq, opts ...grpc.CallOption) (*CheckRepairResp, error)
 }
 
 type mgmtSvcClient struct {
@@ -134,6 +160,15 @@ func (c *mgmtSvcClient) PoolDestroy(ctx context.Context, in *PoolDestroyReq, opt
 	return out, nil
 }
 
+func (c *mgmtSvcClient) PoolRestore(ctx context.Context, in *PoolRestoreReq, opts ...grpc.CallOption) (*PoolRestoreResp, error) {
+	out := new(PoolRestoreResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/PoolRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mgmtSvcClient) PoolEvict(ctx context.Context, in *PoolEvictReq, opts ...grpc.CallOption) (*PoolEvictResp, error) {
 	out := new(PoolEvictResp)
 	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/PoolEvict", in, out, opts...)
@@ -305,6 +340,114 @@ func (c *mgmtSvcClient) SystemErase(ctx context.Context, in *SystemEraseReq, opt
 	return out, nil
 }
 
+func (c *mgmtSvcClient) SystemMaintenance(ctx context.Context, in *SystemMaintenanceReq, opts ...grpc.CallOption) (*SystemMaintenanceResp, error) {
+	out := new(SystemMaintenanceResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemExclude(ctx context.Context, in *SystemExcludeReq, opts ...grpc.CallOption) (*SystemExcludeResp, error) {
+	out := new(SystemExcludeResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemExclude", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemReplicaQuery(ctx context.Context, in *SystemReplicaQueryReq, opts ...grpc.CallOption) (*SystemReplicaQueryResp, error) {
+	out := new(SystemReplicaQueryResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemReplicaQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemLock(ctx context.Context, in *SystemLockReq, opts ...grpc.CallOption) (*SystemLockResp, error) {
+	out := new(SystemLockResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemSetFaultDomain(ctx context.Context, in *SystemSetFaultDomainReq, opts ...grpc.CallOption) (*SystemSetFaultDomainResp, error) {
+	out := new(SystemSetFaultDomainResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemSetFaultDomain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemActivateSpare(ctx context.Context, in *SystemActivateSpareReq, opts ...grpc.CallOption) (*SystemActivateSpareResp, error) {
+	out := new(SystemActivateSpareResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemActivateSpare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemHistory(ctx context.Context, in *SystemHistoryReq, opts ...grpc.CallOption) (*SystemHistoryResp, error) {
+	out := new(SystemHistoryResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemHistoryRecord(ctx context.Context, in *SystemHistoryRecordReq, opts ...grpc.CallOption) (*SystemHistoryRecordResp, error) {
+	out := new(SystemHistoryRecordResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemHistoryRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) SystemPoolDefaults(ctx context.Context, in *SystemPoolDefaultsReq, opts ...grpc.CallOption) (*SystemPoolDefaultsResp, error) {
+	out := new(SystemPoolDefaultsResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/SystemPoolDefaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) CheckStart(ctx context.Context, in *CheckStartReq, opts ...grpc.CallOption) (*CheckStartResp, error) {
+	out := new(CheckStartResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/CheckStart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) CheckQuery(ctx context.Context, in *CheckQueryReq, opts ...grpc.CallOption) (*CheckQueryResp, error) {
+	out := new(CheckQueryResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/CheckQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mgmtSvcClient) CheckRepair(ctx context.Context, in *CheckRepairReq, opts ...grpc.CallOption) (*CheckRepairResp, error) {
+	out := new(CheckRepairResp)
+	err := c.cc.Invoke(ctx, "/mgmt.MgmtSvc/CheckRepair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MgmtSvcServer is the server API for MgmtSvc service.
 // All implementations must embed UnimplementedMgmtSvcServer
 // for forward compatibility
@@ -322,6 +465,8 @@ type MgmtSvcServer interface {
 	PoolResolveID(context.Context, *PoolResolveIDReq) (*PoolResolveIDResp, error)
 	// Destroy a DAOS pool allocated across a number of ranks.
 	PoolDestroy(context.Context, *PoolDestroyReq) (*PoolDestroyResp, error)
+	// Restore a DAOS pool that is pending a delayed destroy
+	PoolRestore(context.Context, *PoolRestoreReq) (*PoolRestoreResp, error)
 	// Evict a DAOS pool's connections.
 	PoolEvict(context.Context, *PoolEvictReq) (*PoolEvictResp, error)
 	// Exclude a pool target.
@@ -360,6 +505,30 @@ type MgmtSvcServer interface {
 	SystemStart(context.Context, *SystemStartReq) (*SystemStartResp, error)
 	// Erase DAOS system database prior to reformat
 	SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error)
+	// Manage DAOS system maintenance windows
+	SystemMaintenance(context.Context, *SystemMaintenanceReq) (*SystemMaintenanceResp, error)
+	// Exclude DAOS system members or clear their exclusion
+	SystemExclude(context.Context, *SystemExcludeReq) (*SystemExcludeResp, error)
+	// Query the raft status of a Management Service replica
+	SystemReplicaQuery(context.Context, *SystemReplicaQueryReq) (*SystemReplicaQueryResp, error)
+	// Manage DAOS system administrative locks
+	SystemLock(context.Context, *SystemLockReq) (*SystemLockResp, error)
+	// Assign fault domains to DAOS system members or clear the assignment
+	SystemSetFaultDomain(context.Context, *SystemSetFaultDomainReq) (*SystemSetFaultDomainResp, error)
+	// Activate a spare engine to replace a failed DAOS system member
+	SystemActivateSpare(context.Context, *SystemActivateSpareReq) (*SystemActivateSpareResp, error)
+	// Query the history of administrative operations
+	SystemHistory(context.Context, *SystemHistoryReq) (*SystemHistoryResp, error)
+	// Record an administrative operation performed on a server
+	SystemHistoryRecord(context.Context, *SystemHistoryRecordReq) (*SystemHistoryRecordResp, error)
+	// Set or query the system-level pool property defaults
+	SystemPoolDefaults(context.Context, *SystemPoolDefaultsReq) (*SystemPoolDefaultsResp, error)
+	// Start a check of the consistency of system metadata
+	CheckStart(context.Context, *CheckStartReq) (*CheckStartResp, error)
+	// Query the status and findings of the most recent check
+	CheckQuery(context.Context, *CheckQueryReq) (*CheckQueryResp, error)
+	// Repair an inconsistency found by a check
+	CheckRepair(context.Context, *CheckRepairReq) (*CheckRepairResp, error)
 	mustEmbedUnimplementedMgmtSvcServer()
 }
 
@@ -385,6 +554,9 @@ func (UnimplementedMgmtSvcServer) PoolResolveID(context.Context, *PoolResolveIDR
 func (UnimplementedMgmtSvcServer) PoolDestroy(context.Context, *PoolDestroyReq) (*PoolDestroyResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PoolDestroy not implemented")
 }
+func (UnimplementedMgmtSvcServer) PoolRestore(context.Context, *PoolRestoreReq) (*PoolRestoreResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PoolRestore not implemented")
+}
 func (UnimplementedMgmtSvcServer) PoolEvict(context.Context, *PoolEvictReq) (*PoolEvictResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PoolEvict not implemented")
 }
@@ -442,6 +614,42 @@ func (UnimplementedMgmtSvcServer) SystemStart(context.Context, *SystemStartReq)
 func (UnimplementedMgmtSvcServer) SystemErase(context.Context, *SystemEraseReq) (*SystemEraseResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SystemErase not implemented")
 }
+func (UnimplementedMgmtSvcServer) SystemMaintenance(context.Context, *SystemMaintenanceReq) (*SystemMaintenanceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemMaintenance not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemExclude(context.Context, *SystemExcludeReq) (*SystemExcludeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemExclude not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemReplicaQuery(context.Context, *SystemReplicaQueryReq) (*SystemReplicaQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemReplicaQuery not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemLock(context.Context, *SystemLockReq) (*SystemLockResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemLock not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemSetFaultDomain(context.Context, *SystemSetFaultDomainReq) (*SystemSetFaultDomainResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemSetFaultDomain not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemActivateSpare(context.Context, *SystemActivateSpareReq) (*SystemActivateSpareResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemActivateSpare not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemHistory(context.Context, *SystemHistoryReq) (*SystemHistoryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemHistory not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemHistoryRecord(context.Context, *SystemHistoryRecordReq) (*SystemHistoryRecordResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemHistoryRecord not implemented")
+}
+func (UnimplementedMgmtSvcServer) SystemPoolDefaults(context.Context, *SystemPoolDefaultsReq) (*SystemPoolDefaultsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemPoolDefaults not implemented")
+}
+func (UnimplementedMgmtSvcServer) CheckStart(context.Context, *CheckStartReq) (*CheckStartResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckStart not implemented")
+}
+func (UnimplementedMgmtSvcServer) CheckQuery(context.Context, *CheckQueryReq) (*CheckQueryResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckQuery not implemented")
+}
+func (UnimplementedMgmtSvcServer) CheckRepair(context.Context, *CheckRepairReq) (*CheckRepairResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRepair not implemented")
+}
 func (UnimplementedMgmtSvcServer) mustEmbedUnimplementedMgmtSvcServer() {}
 
 // UnsafeMgmtSvcServer may be embedded to opt out of forward compatibility for this service.
@@ -563,6 +771,24 @@ func _MgmtSvc_PoolDestroy_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_PoolRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoolRestoreReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).PoolRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/PoolRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).PoolRestore(ctx, req.(*PoolRestoreReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MgmtSvc_PoolEvict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PoolEvictReq)
 	if err := dec(in); err != nil {
@@ -905,6 +1131,222 @@ func _MgmtSvc_SystemErase_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MgmtSvc_SystemMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemMaintenanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemMaintenance(ctx, req.(*SystemMaintenanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemExclude_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemExcludeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemExclude(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemExclude",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemExclude(ctx, req.(*SystemExcludeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemReplicaQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemReplicaQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemReplicaQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemReplicaQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemReplicaQuery(ctx, req.(*SystemReplicaQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemLockReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemLock(ctx, req.(*SystemLockReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemSetFaultDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemSetFaultDomainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemSetFaultDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemSetFaultDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemSetFaultDomain(ctx, req.(*SystemSetFaultDomainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemActivateSpare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemActivateSpareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemActivateSpare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemActivateSpare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemActivateSpare(ctx, req.(*SystemActivateSpareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemHistory(ctx, req.(*SystemHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemHistoryRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemHistoryRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemHistoryRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemHistoryRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemHistoryRecord(ctx, req.(*SystemHistoryRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_SystemPoolDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemPoolDefaultsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).SystemPoolDefaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/SystemPoolDefaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).SystemPoolDefaults(ctx, req.(*SystemPoolDefaultsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_CheckStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckStartReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).CheckStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/CheckStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).CheckStart(ctx, req.(*CheckStartReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_CheckQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckQueryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).CheckQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/CheckQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).CheckQuery(ctx, req.(*CheckQueryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MgmtSvc_CheckRepair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRepairReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MgmtSvcServer).CheckRepair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mgmt.MgmtSvc/CheckRepair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MgmtSvcServer).CheckRepair(ctx, req.(*CheckRepairReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MgmtSvc_ServiceDesc is the grpc.ServiceDesc for MgmtSvc service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -936,6 +1378,10 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PoolDestroy",
 			Handler:    _MgmtSvc_PoolDestroy_Handler,
 		},
+		{
+			MethodName: "PoolRestore",
+			Handler:    _MgmtSvc_PoolRestore_Handler,
+		},
 		{
 			MethodName: "PoolEvict",
 			Handler:    _MgmtSvc_PoolEvict_Handler,
@@ -1012,6 +1458,54 @@ var MgmtSvc_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SystemErase",
 			Handler:    _MgmtSvc_SystemErase_Handler,
 		},
+		{
+			MethodName: "SystemMaintenance",
+			Handler:    _MgmtSvc_SystemMaintenance_Handler,
+		},
+		{
+			MethodName: "SystemExclude",
+			Handler:    _MgmtSvc_SystemExclude_Handler,
+		},
+		{
+			MethodName: "SystemReplicaQuery",
+			Handler:    _MgmtSvc_SystemReplicaQuery_Handler,
+		},
+		{
+			MethodName: "SystemLock",
+			Handler:    _MgmtSvc_SystemLock_Handler,
+		},
+		{
+			MethodName: "SystemSetFaultDomain",
+			Handler:    _MgmtSvc_SystemSetFaultDomain_Handler,
+		},
+		{
+			MethodName: "SystemActivateSpare",
+			Handler:    _MgmtSvc_SystemActivateSpare_Handler,
+		},
+		{
+			MethodName: "SystemHistory",
+			Handler:    _MgmtSvc_SystemHistory_Handler,
+		},
+		{
+			MethodName: "SystemHistoryRecord",
+			Handler:    _MgmtSvc_SystemHistoryRecord_Handler,
+		},
+		{
+			MethodName: "SystemPoolDefaults",
+			Handler:    _MgmtSvc_SystemPoolDefaults_Handler,
+		},
+		{
+			MethodName: "CheckStart",
+			Handler:    _MgmtSvc_CheckStart_Handler,
+		},
+		{
+			MethodName: "CheckQuery",
+			Handler:    _MgmtSvc_CheckQuery_Handler,
+		},
+		{
+			MethodName: "CheckRepair",
+			Handler:    _MgmtSvc_CheckRepair_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mgmt/mgmt.proto",
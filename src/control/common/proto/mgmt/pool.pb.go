@@ -72,7 +72,7 @@ func (x PoolRebuildStatus_State) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PoolRebuildStatus_State.Descriptor instead.
 func (PoolRebuildStatus_State) EnumDescriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{22, 0}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{26, 0}
 }
 
 // PoolCreateReq supplies new pool parameters.
@@ -91,14 +91,17 @@ type PoolCreateReq struct {
 	// representing members of the tree in a breadth-first traversal order.
 	// Each domain above rank consists of: (level, id, num children)
 	// Each rank consists of: (rank number)
-	FaultDomains []uint32 `protobuf:"varint,7,rep,packed,name=faultDomains,proto3" json:"faultDomains,omitempty"` // Fault domain tree, minimal format
-	Numsvcreps   uint32   `protobuf:"varint,8,opt,name=numsvcreps,proto3" json:"numsvcreps,omitempty"`            // desired number of pool service replicas
-	Totalbytes   uint64   `protobuf:"varint,9,opt,name=totalbytes,proto3" json:"totalbytes,omitempty"`            // Total pool size in bytes (auto config)
-	Scmratio     float64  `protobuf:"fixed64,10,opt,name=scmratio,proto3" json:"scmratio,omitempty"`              // Ratio of SCM:NVMe expressed as % (auto config)
-	Numranks     uint32   `protobuf:"varint,11,opt,name=numranks,proto3" json:"numranks,omitempty"`               // Number of target ranks to use (auto config)
-	Ranks        []uint32 `protobuf:"varint,12,rep,packed,name=ranks,proto3" json:"ranks,omitempty"`              // target ranks (manual config)
-	Scmbytes     uint64   `protobuf:"varint,13,opt,name=scmbytes,proto3" json:"scmbytes,omitempty"`               // SCM size in bytes (manual config)
-	Nvmebytes    uint64   `protobuf:"varint,14,opt,name=nvmebytes,proto3" json:"nvmebytes,omitempty"`             // NVMe size in bytes (manual config)
+	FaultDomains []uint32        `protobuf:"varint,7,rep,packed,name=faultDomains,proto3" json:"faultDomains,omitempty"` // Fault domain tree, minimal format
+	Numsvcreps   uint32          `protobuf:"varint,8,opt,name=numsvcreps,proto3" json:"numsvcreps,omitempty"`            // desired number of pool service replicas
+	Totalbytes   uint64          `protobuf:"varint,9,opt,name=totalbytes,proto3" json:"totalbytes,omitempty"`            // Total pool size in bytes (auto config)
+	Scmratio     float64         `protobuf:"fixed64,10,opt,name=scmratio,proto3" json:"scmratio,omitempty"`              // Ratio of SCM:NVMe expressed as % (auto config)
+	Numranks     uint32          `protobuf:"varint,11,opt,name=numranks,proto3" json:"numranks,omitempty"`               // Number of target ranks to use (auto config)
+	Ranks        []uint32        `protobuf:"varint,12,rep,packed,name=ranks,proto3" json:"ranks,omitempty"`              // target ranks (manual config)
+	Scmbytes     uint64          `protobuf:"varint,13,opt,name=scmbytes,proto3" json:"scmbytes,omitempty"`               // SCM size in bytes (manual config)
+	Nvmebytes    uint64          `protobuf:"varint,14,opt,name=nvmebytes,proto3" json:"nvmebytes,omitempty"`             // NVMe size in bytes (manual config)
+	Mindomains   uint32          `protobuf:"varint,15,opt,name=mindomains,proto3" json:"mindomains,omitempty"`           // minimum number of fault domains spanned by target ranks
+	Properties   []*PoolProperty `protobuf:"bytes,16,rep,name=properties,proto3" json:"properties,omitempty"`            // properties to set on the new pool
+	Rankstrategy string          `protobuf:"bytes,17,opt,name=rankstrategy,proto3" json:"rankstrategy,omitempty"`        // strategy for selecting target ranks (auto config)
 }
 
 func (x *PoolCreateReq) Reset() {
@@ -231,23 +234,161 @@ func (x *PoolCreateReq) GetNvmebytes() uint64 {
 	return 0
 }
 
+func (x *PoolCreateReq) GetMindomains() uint32 {
+	if x != nil {
+		return x.Mindomains
+	}
+	return 0
+}
+
+func (x *PoolCreateReq) GetProperties() []*PoolProperty {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *PoolCreateReq) GetRankstrategy() string {
+	if x != nil {
+		return x.Rankstrategy
+	}
+	return ""
+}
+
+// PoolProperty is a pool property name and value in string form.
+type PoolProperty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // property name, e.g. "reclaim"
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"` // property value, e.g. "lazy"
+}
+
+func (x *PoolProperty) Reset() {
+	*x = PoolProperty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolProperty) ProtoMessage() {}
+
+func (x *PoolProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolProperty.ProtoReflect.Descriptor instead.
+func (*PoolProperty) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PoolProperty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PoolProperty) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// PoolFaultDomain describes the pool target ranks placed in a fault domain.
+type PoolFaultDomain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain string   `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`       // fault domain
+	Ranks  []uint32 `protobuf:"varint,2,rep,packed,name=ranks,proto3" json:"ranks,omitempty"` // pool target ranks in the fault domain
+}
+
+func (x *PoolFaultDomain) Reset() {
+	*x = PoolFaultDomain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolFaultDomain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolFaultDomain) ProtoMessage() {}
+
+func (x *PoolFaultDomain) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolFaultDomain.ProtoReflect.Descriptor instead.
+func (*PoolFaultDomain) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PoolFaultDomain) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *PoolFaultDomain) GetRanks() []uint32 {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
 // PoolCreateResp returns created pool uuid and ranks.
 type PoolCreateResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status    int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                            // DAOS error code
-	SvcReps   []uint32 `protobuf:"varint,2,rep,packed,name=svc_reps,json=svcReps,proto3" json:"svc_reps,omitempty"`    // pool service replica ranks
-	TgtRanks  []uint32 `protobuf:"varint,3,rep,packed,name=tgt_ranks,json=tgtRanks,proto3" json:"tgt_ranks,omitempty"` // pool target ranks
-	ScmBytes  uint64   `protobuf:"varint,4,opt,name=scm_bytes,json=scmBytes,proto3" json:"scm_bytes,omitempty"`        // total SCM allocated to pool
-	NvmeBytes uint64   `protobuf:"varint,5,opt,name=nvme_bytes,json=nvmeBytes,proto3" json:"nvme_bytes,omitempty"`     // total NVMe allocated to pool
+	Status       int32               `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                                // DAOS error code
+	SvcReps      []uint32            `protobuf:"varint,2,rep,packed,name=svc_reps,json=svcReps,proto3" json:"svc_reps,omitempty"`        // pool service replica ranks
+	TgtRanks     []uint32            `protobuf:"varint,3,rep,packed,name=tgt_ranks,json=tgtRanks,proto3" json:"tgt_ranks,omitempty"`     // pool target ranks
+	ScmBytes     uint64              `protobuf:"varint,4,opt,name=scm_bytes,json=scmBytes,proto3" json:"scm_bytes,omitempty"`            // total SCM allocated to pool
+	NvmeBytes    uint64              `protobuf:"varint,5,opt,name=nvme_bytes,json=nvmeBytes,proto3" json:"nvme_bytes,omitempty"`         // total NVMe allocated to pool
+	FaultDomains []*PoolFaultDomain  `protobuf:"bytes,6,rep,name=fault_domains,json=faultDomains,proto3" json:"fault_domains,omitempty"` // placement of target ranks
+	Properties   []*PoolProperty     `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty"`                         // properties set, including system defaults
+	RankStrategy string              `protobuf:"bytes,8,opt,name=rank_strategy,json=rankStrategy,proto3" json:"rank_strategy,omitempty"` // strategy used to select target ranks
+	RankChoices  []*PoolRankChoice   `protobuf:"bytes,9,rep,name=rank_choices,json=rankChoices,proto3" json:"rank_choices,omitempty"`    // rationale for each selected target rank
+	Timings      []*PoolCreateTiming `protobuf:"bytes,10,rep,name=timings,proto3" json:"timings,omitempty"`                              // duration of each phase of the create
 }
 
 func (x *PoolCreateResp) Reset() {
 	*x = PoolCreateResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[1]
+		mi := &file_mgmt_pool_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -260,7 +401,7 @@ func (x *PoolCreateResp) String() string {
 func (*PoolCreateResp) ProtoMessage() {}
 
 func (x *PoolCreateResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[1]
+	mi := &file_mgmt_pool_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +414,7 @@ func (x *PoolCreateResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolCreateResp.ProtoReflect.Descriptor instead.
 func (*PoolCreateResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{1}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PoolCreateResp) GetStatus() int32 {
@@ -311,6 +452,41 @@ func (x *PoolCreateResp) GetNvmeBytes() uint64 {
 	return 0
 }
 
+func (x *PoolCreateResp) GetFaultDomains() []*PoolFaultDomain {
+	if x != nil {
+		return x.FaultDomains
+	}
+	return nil
+}
+
+func (x *PoolCreateResp) GetProperties() []*PoolProperty {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *PoolCreateResp) GetRankStrategy() string {
+	if x != nil {
+		return x.RankStrategy
+	}
+	return ""
+}
+
+func (x *PoolCreateResp) GetRankChoices() []*PoolRankChoice {
+	if x != nil {
+		return x.RankChoices
+	}
+	return nil
+}
+
+func (x *PoolCreateResp) GetTimings() []*PoolCreateTiming {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
 // PoolDestroyReq supplies pool identifier and force flag.
 type PoolDestroyReq struct {
 	state         protoimpl.MessageState
@@ -321,12 +497,13 @@ type PoolDestroyReq struct {
 	Uuid     string   `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`                                 // uuid of pool to destroy
 	Force    bool     `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`                              // destroy regardless of active connections
 	SvcRanks []uint32 `protobuf:"varint,4,rep,packed,name=svc_ranks,json=svcRanks,proto3" json:"svc_ranks,omitempty"` // List of pool service ranks
+	Delay    uint64   `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`                              // seconds to retain the disabled pool, 0 to destroy immediately
 }
 
 func (x *PoolDestroyReq) Reset() {
 	*x = PoolDestroyReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[2]
+		mi := &file_mgmt_pool_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +516,7 @@ func (x *PoolDestroyReq) String() string {
 func (*PoolDestroyReq) ProtoMessage() {}
 
 func (x *PoolDestroyReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[2]
+	mi := &file_mgmt_pool_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +529,7 @@ func (x *PoolDestroyReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolDestroyReq.ProtoReflect.Descriptor instead.
 func (*PoolDestroyReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{2}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PoolDestroyReq) GetSys() string {
@@ -383,6 +560,13 @@ func (x *PoolDestroyReq) GetSvcRanks() []uint32 {
 	return nil
 }
 
+func (x *PoolDestroyReq) GetDelay() uint64 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
 // PoolDestroyResp returns resultant state of destroy operation.
 type PoolDestroyResp struct {
 	state         protoimpl.MessageState
@@ -395,7 +579,7 @@ type PoolDestroyResp struct {
 func (x *PoolDestroyResp) Reset() {
 	*x = PoolDestroyResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[3]
+		mi := &file_mgmt_pool_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +592,7 @@ func (x *PoolDestroyResp) String() string {
 func (*PoolDestroyResp) ProtoMessage() {}
 
 func (x *PoolDestroyResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[3]
+	mi := &file_mgmt_pool_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +605,7 @@ func (x *PoolDestroyResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolDestroyResp.ProtoReflect.Descriptor instead.
 func (*PoolDestroyResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{3}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PoolDestroyResp) GetStatus() int32 {
@@ -431,6 +615,110 @@ func (x *PoolDestroyResp) GetStatus() int32 {
 	return 0
 }
 
+// PoolRestoreReq supplies the pool to be restored from a delayed destroy.
+type PoolRestoreReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys  string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`   // DAOS system identifier
+	Uuid string `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"` // uuid of pool to restore
+}
+
+func (x *PoolRestoreReq) Reset() {
+	*x = PoolRestoreReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolRestoreReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolRestoreReq) ProtoMessage() {}
+
+func (x *PoolRestoreReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolRestoreReq.ProtoReflect.Descriptor instead.
+func (*PoolRestoreReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PoolRestoreReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *PoolRestoreReq) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+// PoolRestoreResp returns resultant state of restore operation.
+type PoolRestoreResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // DAOS error code
+}
+
+func (x *PoolRestoreResp) Reset() {
+	*x = PoolRestoreResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolRestoreResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolRestoreResp) ProtoMessage() {}
+
+func (x *PoolRestoreResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolRestoreResp.ProtoReflect.Descriptor instead.
+func (*PoolRestoreResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PoolRestoreResp) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
 // PoolEvictReq supplies pool identifier.
 type PoolEvictReq struct {
 	state         protoimpl.MessageState
@@ -441,12 +729,13 @@ type PoolEvictReq struct {
 	Uuid     string   `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`                                 // uuid of pool to evict
 	SvcRanks []uint32 `protobuf:"varint,3,rep,packed,name=svc_ranks,json=svcRanks,proto3" json:"svc_ranks,omitempty"` // List of pool service ranks
 	Handles  []string `protobuf:"bytes,4,rep,name=handles,proto3" json:"handles,omitempty"`                           // Optional list of handles to evict
+	Machine  string   `protobuf:"bytes,5,opt,name=machine,proto3" json:"machine,omitempty"`                           // Optional machine name, to only evict its handles
 }
 
 func (x *PoolEvictReq) Reset() {
 	*x = PoolEvictReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[4]
+		mi := &file_mgmt_pool_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -459,7 +748,7 @@ func (x *PoolEvictReq) String() string {
 func (*PoolEvictReq) ProtoMessage() {}
 
 func (x *PoolEvictReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[4]
+	mi := &file_mgmt_pool_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,7 +761,7 @@ func (x *PoolEvictReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolEvictReq.ProtoReflect.Descriptor instead.
 func (*PoolEvictReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{4}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PoolEvictReq) GetSys() string {
@@ -503,6 +792,13 @@ func (x *PoolEvictReq) GetHandles() []string {
 	return nil
 }
 
+func (x *PoolEvictReq) GetMachine() string {
+	if x != nil {
+		return x.Machine
+	}
+	return ""
+}
+
 // PoolEvictResp returns resultant state of evict operation.
 type PoolEvictResp struct {
 	state         protoimpl.MessageState
@@ -515,7 +811,7 @@ type PoolEvictResp struct {
 func (x *PoolEvictResp) Reset() {
 	*x = PoolEvictResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[5]
+		mi := &file_mgmt_pool_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -528,7 +824,7 @@ func (x *PoolEvictResp) String() string {
 func (*PoolEvictResp) ProtoMessage() {}
 
 func (x *PoolEvictResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[5]
+	mi := &file_mgmt_pool_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +837,7 @@ func (x *PoolEvictResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolEvictResp.ProtoReflect.Descriptor instead.
 func (*PoolEvictResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{5}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PoolEvictResp) GetStatus() int32 {
@@ -567,7 +863,7 @@ type PoolExcludeReq struct {
 func (x *PoolExcludeReq) Reset() {
 	*x = PoolExcludeReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[6]
+		mi := &file_mgmt_pool_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -580,7 +876,7 @@ func (x *PoolExcludeReq) String() string {
 func (*PoolExcludeReq) ProtoMessage() {}
 
 func (x *PoolExcludeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[6]
+	mi := &file_mgmt_pool_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +889,7 @@ func (x *PoolExcludeReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolExcludeReq.ProtoReflect.Descriptor instead.
 func (*PoolExcludeReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{6}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *PoolExcludeReq) GetSys() string {
@@ -643,7 +939,7 @@ type PoolExcludeResp struct {
 func (x *PoolExcludeResp) Reset() {
 	*x = PoolExcludeResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[7]
+		mi := &file_mgmt_pool_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -656,7 +952,7 @@ func (x *PoolExcludeResp) String() string {
 func (*PoolExcludeResp) ProtoMessage() {}
 
 func (x *PoolExcludeResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[7]
+	mi := &file_mgmt_pool_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,7 +965,7 @@ func (x *PoolExcludeResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolExcludeResp.ProtoReflect.Descriptor instead.
 func (*PoolExcludeResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{7}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PoolExcludeResp) GetStatus() int32 {
@@ -695,7 +991,7 @@ type PoolDrainReq struct {
 func (x *PoolDrainReq) Reset() {
 	*x = PoolDrainReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[8]
+		mi := &file_mgmt_pool_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -708,7 +1004,7 @@ func (x *PoolDrainReq) String() string {
 func (*PoolDrainReq) ProtoMessage() {}
 
 func (x *PoolDrainReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[8]
+	mi := &file_mgmt_pool_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +1017,7 @@ func (x *PoolDrainReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolDrainReq.ProtoReflect.Descriptor instead.
 func (*PoolDrainReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{8}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PoolDrainReq) GetSys() string {
@@ -771,7 +1067,7 @@ type PoolDrainResp struct {
 func (x *PoolDrainResp) Reset() {
 	*x = PoolDrainResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[9]
+		mi := &file_mgmt_pool_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -784,7 +1080,7 @@ func (x *PoolDrainResp) String() string {
 func (*PoolDrainResp) ProtoMessage() {}
 
 func (x *PoolDrainResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[9]
+	mi := &file_mgmt_pool_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +1093,7 @@ func (x *PoolDrainResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolDrainResp.ProtoReflect.Descriptor instead.
 func (*PoolDrainResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{9}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PoolDrainResp) GetStatus() int32 {
@@ -825,7 +1121,7 @@ type PoolExtendReq struct {
 func (x *PoolExtendReq) Reset() {
 	*x = PoolExtendReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[10]
+		mi := &file_mgmt_pool_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +1134,7 @@ func (x *PoolExtendReq) String() string {
 func (*PoolExtendReq) ProtoMessage() {}
 
 func (x *PoolExtendReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[10]
+	mi := &file_mgmt_pool_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1147,7 @@ func (x *PoolExtendReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolExtendReq.ProtoReflect.Descriptor instead.
 func (*PoolExtendReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{10}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PoolExtendReq) GetSys() string {
@@ -915,7 +1211,7 @@ type PoolExtendResp struct {
 func (x *PoolExtendResp) Reset() {
 	*x = PoolExtendResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[11]
+		mi := &file_mgmt_pool_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -928,7 +1224,7 @@ func (x *PoolExtendResp) String() string {
 func (*PoolExtendResp) ProtoMessage() {}
 
 func (x *PoolExtendResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[11]
+	mi := &file_mgmt_pool_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1237,7 @@ func (x *PoolExtendResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolExtendResp.ProtoReflect.Descriptor instead.
 func (*PoolExtendResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{11}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PoolExtendResp) GetStatus() int32 {
@@ -967,7 +1263,7 @@ type PoolReintegrateReq struct {
 func (x *PoolReintegrateReq) Reset() {
 	*x = PoolReintegrateReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[12]
+		mi := &file_mgmt_pool_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -980,7 +1276,7 @@ func (x *PoolReintegrateReq) String() string {
 func (*PoolReintegrateReq) ProtoMessage() {}
 
 func (x *PoolReintegrateReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[12]
+	mi := &file_mgmt_pool_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -993,7 +1289,7 @@ func (x *PoolReintegrateReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolReintegrateReq.ProtoReflect.Descriptor instead.
 func (*PoolReintegrateReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{12}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PoolReintegrateReq) GetSys() string {
@@ -1043,7 +1339,7 @@ type PoolReintegrateResp struct {
 func (x *PoolReintegrateResp) Reset() {
 	*x = PoolReintegrateResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[13]
+		mi := &file_mgmt_pool_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1352,7 @@ func (x *PoolReintegrateResp) String() string {
 func (*PoolReintegrateResp) ProtoMessage() {}
 
 func (x *PoolReintegrateResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[13]
+	mi := &file_mgmt_pool_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1365,7 @@ func (x *PoolReintegrateResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolReintegrateResp.ProtoReflect.Descriptor instead.
 func (*PoolReintegrateResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{13}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PoolReintegrateResp) GetStatus() int32 {
@@ -1085,13 +1381,15 @@ type ListPoolsReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system identifier
+	Sys    string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`       // DAOS system identifier
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // return pools after this pool UUID
+	Limit  uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`  // maximum number of pools to return, 0 for all
 }
 
 func (x *ListPoolsReq) Reset() {
 	*x = ListPoolsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[14]
+		mi := &file_mgmt_pool_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1104,7 +1402,7 @@ func (x *ListPoolsReq) String() string {
 func (*ListPoolsReq) ProtoMessage() {}
 
 func (x *ListPoolsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[14]
+	mi := &file_mgmt_pool_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,7 +1415,7 @@ func (x *ListPoolsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPoolsReq.ProtoReflect.Descriptor instead.
 func (*ListPoolsReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{14}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListPoolsReq) GetSys() string {
@@ -1127,20 +1425,35 @@ func (x *ListPoolsReq) GetSys() string {
 	return ""
 }
 
+func (x *ListPoolsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListPoolsReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 // ListPoolsResp returns the list of pools in the system.
 type ListPoolsResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status int32                 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"` // DAOS error code
-	Pools  []*ListPoolsResp_Pool `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`    // pools list
+	Status     int32                 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`                          // DAOS error code
+	Pools      []*ListPoolsResp_Pool `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`                             // pools list
+	NextCursor string                `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // cursor for next page, empty if no more pools
 }
 
 func (x *ListPoolsResp) Reset() {
 	*x = ListPoolsResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[15]
+		mi := &file_mgmt_pool_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1153,7 +1466,7 @@ func (x *ListPoolsResp) String() string {
 func (*ListPoolsResp) ProtoMessage() {}
 
 func (x *ListPoolsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[15]
+	mi := &file_mgmt_pool_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1166,7 +1479,7 @@ func (x *ListPoolsResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPoolsResp.ProtoReflect.Descriptor instead.
 func (*ListPoolsResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{15}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ListPoolsResp) GetStatus() int32 {
@@ -1183,6 +1496,13 @@ func (x *ListPoolsResp) GetPools() []*ListPoolsResp_Pool {
 	return nil
 }
 
+func (x *ListPoolsResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 // PoolResolveIDReq contains the parameters to resolve a user-friendly pool ID
 // to a UUID for use in API requests.
 type PoolResolveIDReq struct {
@@ -1197,7 +1517,7 @@ type PoolResolveIDReq struct {
 func (x *PoolResolveIDReq) Reset() {
 	*x = PoolResolveIDReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[16]
+		mi := &file_mgmt_pool_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1210,7 +1530,7 @@ func (x *PoolResolveIDReq) String() string {
 func (*PoolResolveIDReq) ProtoMessage() {}
 
 func (x *PoolResolveIDReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[16]
+	mi := &file_mgmt_pool_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1223,7 +1543,7 @@ func (x *PoolResolveIDReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolResolveIDReq.ProtoReflect.Descriptor instead.
 func (*PoolResolveIDReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{16}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *PoolResolveIDReq) GetSys() string {
@@ -1252,7 +1572,7 @@ type PoolResolveIDResp struct {
 func (x *PoolResolveIDResp) Reset() {
 	*x = PoolResolveIDResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[17]
+		mi := &file_mgmt_pool_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1265,7 +1585,7 @@ func (x *PoolResolveIDResp) String() string {
 func (*PoolResolveIDResp) ProtoMessage() {}
 
 func (x *PoolResolveIDResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[17]
+	mi := &file_mgmt_pool_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1278,7 +1598,7 @@ func (x *PoolResolveIDResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolResolveIDResp.ProtoReflect.Descriptor instead.
 func (*PoolResolveIDResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{17}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *PoolResolveIDResp) GetUuid() string {
@@ -1304,7 +1624,7 @@ type ListContReq struct {
 func (x *ListContReq) Reset() {
 	*x = ListContReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[18]
+		mi := &file_mgmt_pool_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1317,7 +1637,7 @@ func (x *ListContReq) String() string {
 func (*ListContReq) ProtoMessage() {}
 
 func (x *ListContReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[18]
+	mi := &file_mgmt_pool_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1330,7 +1650,7 @@ func (x *ListContReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListContReq.ProtoReflect.Descriptor instead.
 func (*ListContReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{18}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ListContReq) GetSys() string {
@@ -1366,7 +1686,7 @@ type ListContResp struct {
 func (x *ListContResp) Reset() {
 	*x = ListContResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[19]
+		mi := &file_mgmt_pool_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1379,7 +1699,7 @@ func (x *ListContResp) String() string {
 func (*ListContResp) ProtoMessage() {}
 
 func (x *ListContResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[19]
+	mi := &file_mgmt_pool_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1392,7 +1712,7 @@ func (x *ListContResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListContResp.ProtoReflect.Descriptor instead.
 func (*ListContResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{19}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListContResp) GetStatus() int32 {
@@ -1423,7 +1743,7 @@ type PoolQueryReq struct {
 func (x *PoolQueryReq) Reset() {
 	*x = PoolQueryReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[20]
+		mi := &file_mgmt_pool_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1436,7 +1756,7 @@ func (x *PoolQueryReq) String() string {
 func (*PoolQueryReq) ProtoMessage() {}
 
 func (x *PoolQueryReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[20]
+	mi := &file_mgmt_pool_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1449,7 +1769,7 @@ func (x *PoolQueryReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolQueryReq.ProtoReflect.Descriptor instead.
 func (*PoolQueryReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{20}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *PoolQueryReq) GetSys() string {
@@ -1489,7 +1809,7 @@ type StorageUsageStats struct {
 func (x *StorageUsageStats) Reset() {
 	*x = StorageUsageStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[21]
+		mi := &file_mgmt_pool_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1502,7 +1822,7 @@ func (x *StorageUsageStats) String() string {
 func (*StorageUsageStats) ProtoMessage() {}
 
 func (x *StorageUsageStats) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[21]
+	mi := &file_mgmt_pool_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1515,7 +1835,7 @@ func (x *StorageUsageStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageUsageStats.ProtoReflect.Descriptor instead.
 func (*StorageUsageStats) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{21}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *StorageUsageStats) GetTotal() uint64 {
@@ -1568,7 +1888,7 @@ type PoolRebuildStatus struct {
 func (x *PoolRebuildStatus) Reset() {
 	*x = PoolRebuildStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[22]
+		mi := &file_mgmt_pool_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1581,7 +1901,7 @@ func (x *PoolRebuildStatus) String() string {
 func (*PoolRebuildStatus) ProtoMessage() {}
 
 func (x *PoolRebuildStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[22]
+	mi := &file_mgmt_pool_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1594,7 +1914,7 @@ func (x *PoolRebuildStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolRebuildStatus.ProtoReflect.Descriptor instead.
 func (*PoolRebuildStatus) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{22}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PoolRebuildStatus) GetStatus() int32 {
@@ -1647,7 +1967,7 @@ type PoolQueryResp struct {
 func (x *PoolQueryResp) Reset() {
 	*x = PoolQueryResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[23]
+		mi := &file_mgmt_pool_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1660,7 +1980,7 @@ func (x *PoolQueryResp) String() string {
 func (*PoolQueryResp) ProtoMessage() {}
 
 func (x *PoolQueryResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[23]
+	mi := &file_mgmt_pool_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +1993,7 @@ func (x *PoolQueryResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolQueryResp.ProtoReflect.Descriptor instead.
 func (*PoolQueryResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{23}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *PoolQueryResp) GetStatus() int32 {
@@ -1775,7 +2095,7 @@ type PoolSetPropReq struct {
 func (x *PoolSetPropReq) Reset() {
 	*x = PoolSetPropReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[24]
+		mi := &file_mgmt_pool_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1788,7 +2108,7 @@ func (x *PoolSetPropReq) String() string {
 func (*PoolSetPropReq) ProtoMessage() {}
 
 func (x *PoolSetPropReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[24]
+	mi := &file_mgmt_pool_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1801,7 +2121,7 @@ func (x *PoolSetPropReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolSetPropReq.ProtoReflect.Descriptor instead.
 func (*PoolSetPropReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{24}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *PoolSetPropReq) GetSys() string {
@@ -1919,7 +2239,7 @@ type PoolSetPropResp struct {
 func (x *PoolSetPropResp) Reset() {
 	*x = PoolSetPropResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[25]
+		mi := &file_mgmt_pool_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1932,7 +2252,7 @@ func (x *PoolSetPropResp) String() string {
 func (*PoolSetPropResp) ProtoMessage() {}
 
 func (x *PoolSetPropResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[25]
+	mi := &file_mgmt_pool_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1945,7 +2265,7 @@ func (x *PoolSetPropResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PoolSetPropResp.ProtoReflect.Descriptor instead.
 func (*PoolSetPropResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{25}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *PoolSetPropResp) GetStatus() int32 {
@@ -2029,19 +2349,158 @@ func (*PoolSetPropResp_Strval) isPoolSetPropResp_Value() {}
 
 func (*PoolSetPropResp_Numval) isPoolSetPropResp_Value() {}
 
+// PoolRankChoice describes why a target rank was selected for a new pool.
+type PoolRankChoice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank      uint32 `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`                            // selected rank
+	Domain    string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`                         // fault domain of the rank
+	FreeBytes uint64 `protobuf:"varint,3,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"` // free NVMe capacity reported by the rank
+	Failures  uint32 `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`                    // recent failures of the rank
+	Reason    string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                         // reason for the selection
+}
+
+func (x *PoolRankChoice) Reset() {
+	*x = PoolRankChoice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolRankChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolRankChoice) ProtoMessage() {}
+
+func (x *PoolRankChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolRankChoice.ProtoReflect.Descriptor instead.
+func (*PoolRankChoice) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PoolRankChoice) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PoolRankChoice) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *PoolRankChoice) GetFreeBytes() uint64 {
+	if x != nil {
+		return x.FreeBytes
+	}
+	return 0
+}
+
+func (x *PoolRankChoice) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *PoolRankChoice) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// PoolCreateTiming is the time taken by a phase of pool creation.
+type PoolCreateTiming struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase      string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`                              // description of the phase
+	DurationUs uint64 `protobuf:"varint,2,opt,name=duration_us,json=durationUs,proto3" json:"duration_us,omitempty"` // phase duration in microseconds
+}
+
+func (x *PoolCreateTiming) Reset() {
+	*x = PoolCreateTiming{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_pool_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolCreateTiming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolCreateTiming) ProtoMessage() {}
+
+func (x *PoolCreateTiming) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_pool_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolCreateTiming.ProtoReflect.Descriptor instead.
+func (*PoolCreateTiming) Descriptor() ([]byte, []int) {
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PoolCreateTiming) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PoolCreateTiming) GetDurationUs() uint64 {
+	if x != nil {
+		return x.DurationUs
+	}
+	return 0
+}
+
 type ListPoolsResp_Pool struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid    string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`                              // uuid of pool
-	SvcReps []uint32 `protobuf:"varint,2,rep,packed,name=svc_reps,json=svcReps,proto3" json:"svc_reps,omitempty"` // pool service replica ranks
+	Uuid         string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	SvcReps      []uint32 `protobuf:"varint,2,rep,packed,name=svc_reps,json=svcReps,proto3" json:"svc_reps,omitempty"`
+	State        string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Protected    bool     `protobuf:"varint,4,opt,name=protected,proto3" json:"protected,omitempty"`
+	DestroyAfter string   `protobuf:"bytes,5,opt,name=destroy_after,json=destroyAfter,proto3" json:"destroy_after,omitempty"`
 }
 
 func (x *ListPoolsResp_Pool) Reset() {
 	*x = ListPoolsResp_Pool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[26]
+		mi := &file_mgmt_pool_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2054,7 +2513,7 @@ func (x *ListPoolsResp_Pool) String() string {
 func (*ListPoolsResp_Pool) ProtoMessage() {}
 
 func (x *ListPoolsResp_Pool) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[26]
+	mi := &file_mgmt_pool_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2067,7 +2526,7 @@ func (x *ListPoolsResp_Pool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPoolsResp_Pool.ProtoReflect.Descriptor instead.
 func (*ListPoolsResp_Pool) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{15, 0}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{19, 0}
 }
 
 func (x *ListPoolsResp_Pool) GetUuid() string {
@@ -2084,18 +2543,39 @@ func (x *ListPoolsResp_Pool) GetSvcReps() []uint32 {
 	return nil
 }
 
+func (x *ListPoolsResp_Pool) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ListPoolsResp_Pool) GetProtected() bool {
+	if x != nil {
+		return x.Protected
+	}
+	return false
+}
+
+func (x *ListPoolsResp_Pool) GetDestroyAfter() string {
+	if x != nil {
+		return x.DestroyAfter
+	}
+	return ""
+}
+
 type ListContResp_Cont struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // uuid of container
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 }
 
 func (x *ListContResp_Cont) Reset() {
 	*x = ListContResp_Cont{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_pool_proto_msgTypes[27]
+		mi := &file_mgmt_pool_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2108,7 +2588,7 @@ func (x *ListContResp_Cont) String() string {
 func (*ListContResp_Cont) ProtoMessage() {}
 
 func (x *ListContResp_Cont) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_pool_proto_msgTypes[27]
+	mi := &file_mgmt_pool_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2121,7 +2601,7 @@ func (x *ListContResp_Cont) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListContResp_Cont.ProtoReflect.Descriptor instead.
 func (*ListContResp_Cont) Descriptor() ([]byte, []int) {
-	return file_mgmt_pool_proto_rawDescGZIP(), []int{19, 0}
+	return file_mgmt_pool_proto_rawDescGZIP(), []int{23, 0}
 }
 
 func (x *ListContResp_Cont) GetUuid() string {
@@ -2135,7 +2615,7 @@ var File_mgmt_pool_proto protoreflect.FileDescriptor
 
 var file_mgmt_pool_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x04, 0x6d, 0x67, 0x6d, 0x74, 0x22, 0xfb, 0x02, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c,
+	0x6f, 0x12, 0x04, 0x6d, 0x67, 0x6d, 0x74, 0x22, 0xf3, 0x03, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c,
 	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a,
 	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61,
@@ -2159,36 +2639,114 @@ var file_mgmt_pool_proto_rawDesc = []byte{
 	0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
 	0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x62,
 	0x79, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76, 0x6d, 0x65,
-	0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x19, 0x0a, 0x08, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0d, 0x52, 0x07, 0x73, 0x76, 0x63, 0x52, 0x65, 0x70, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74,
-	0x67, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08,
-	0x74, 0x67, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x6d, 0x5f,
-	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x63, 0x6d,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x62, 0x79,
-	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x42,
-	0x79, 0x74, 0x65, 0x73, 0x22, 0x69, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74,
-	0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
-	0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x22,
-	0x29, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x6b, 0x0a, 0x0c, 0x50, 0x6f,
-	0x6f, 0x6c, 0x45, 0x76, 0x69, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74,
+	0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x61, 0x6e,
+	0x6b, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22, 0x38, 0x0a,
+	0x0c, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3f, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x22, 0x9c, 0x03, 0x0a, 0x0e, 0x50, 0x6f, 0x6f,
+	0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x65, 0x70, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x76, 0x63, 0x52, 0x65, 0x70, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x67, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x08, 0x74, 0x67, 0x74, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x63, 0x6d, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x73, 0x63, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x76, 0x6d, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76,
+	0x6d, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x0c, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50,
+	0x6f, 0x6f, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x6b, 0x5f,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x61, 0x6e, 0x6b, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x37, 0x0a, 0x0c,
+	0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x61,
+	0x6e, 0x6b, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x0b, 0x72, 0x61, 0x6e, 0x6b, 0x43, 0x68,
+	0x6f, 0x69, 0x63, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x7f, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x44,
+	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e,
+	0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x29, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c,
+	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x36, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x0f, 0x50,
+	0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0c, 0x50, 0x6f, 0x6f, 0x6c, 0x45,
+	0x76, 0x69, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x22, 0x27,
+	0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x76, 0x69, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c,
+	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
 	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04,
 	0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x45,
-	0x76, 0x69, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x22, 0x85, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69, 0x64,
+	0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69,
+	0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x22,
+	0x29, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0c, 0x50,
+	0x6f, 0x6f, 0x6c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69,
+	0x64, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x69, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73,
+	0x22, 0x27, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x0d, 0x50, 0x6f,
+	0x6f, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52,
+	0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76, 0x6d, 0x65, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x0c, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x73, 0x22, 0x28, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x89, 0x01, 0x0a,
+	0x12, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e,
@@ -2196,59 +2754,32 @@ var file_mgmt_pool_proto_rawDesc = []byte{
 	0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69, 0x64, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d,
 	0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x73,
 	0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08,
-	0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x22, 0x29, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c,
-	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0c, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x72, 0x61, 0x69,
-	0x6e, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61,
-	0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1c,
-	0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69, 0x64, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0d, 0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x69, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09,
-	0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52,
-	0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x22, 0x27, 0x0a, 0x0d, 0x50, 0x6f, 0x6f,
-	0x6c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x6f, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e,
-	0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61,
-	0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73,
-	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1a, 0x0a,
-	0x08, 0x73, 0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x08, 0x73, 0x63, 0x6d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x76, 0x6d,
-	0x65, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x76,
-	0x6d, 0x65, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0c, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x28, 0x0a, 0x0e, 0x50,
-	0x6f, 0x6f, 0x6c, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x12, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65,
-	0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03,
-	0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x12,
-	0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x69, 0x64, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x69, 0x64, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x61, 0x6e, 0x6b,
-	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b,
-	0x73, 0x22, 0x2d, 0x0a, 0x13, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x69, 0x6e, 0x74, 0x65, 0x67,
-	0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x22, 0x20, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71,
-	0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
-	0x79, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x05,
-	0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x67,
-	0x6d, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x1a, 0x35, 0x0a, 0x04,
-	0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x76, 0x63, 0x5f,
-	0x72, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x76, 0x63, 0x52,
-	0x65, 0x70, 0x73, 0x22, 0x3e, 0x0a, 0x10, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x73, 0x76, 0x63, 0x52, 0x61, 0x6e, 0x6b, 0x73, 0x22, 0x2d, 0x0a, 0x13, 0x50, 0x6f, 0x6f, 0x6c,
+	0x52, 0x65, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4e, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x2e, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x1a, 0x8e, 0x01, 0x0a, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x76, 0x63, 0x5f, 0x72, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0d, 0x52, 0x07, 0x73, 0x76, 0x63, 0x52, 0x65, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x10, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c,
 	0x76, 0x65, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x75, 0x6d,
 	0x61, 0x6e, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x75, 0x6d, 0x61,
@@ -2340,11 +2871,25 @@ var file_mgmt_pool_proto_rawDesc = []byte{
 	0x09, 0x48, 0x01, 0x52, 0x06, 0x73, 0x74, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x06, 0x6e,
 	0x75, 0x6d, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x06, 0x6e,
 	0x75, 0x6d, 0x76, 0x61, 0x6c, 0x42, 0x0a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
-	0x79, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74,
-	0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e,
-	0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8f, 0x01, 0x0a, 0x0e, 0x50,
+	0x6f, 0x6f, 0x6c, 0x52, 0x61, 0x6e, 0x6b, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72, 0x61, 0x6e,
+	0x6b, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65,
+	0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66,
+	0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x10,
+	0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b,
+	0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d,
+	0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2360,50 +2905,61 @@ func file_mgmt_pool_proto_rawDescGZIP() []byte {
 }
 
 var file_mgmt_pool_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_mgmt_pool_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_mgmt_pool_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_mgmt_pool_proto_goTypes = []interface{}{
 	(PoolRebuildStatus_State)(0), // 0: mgmt.PoolRebuildStatus.State
 	(*PoolCreateReq)(nil),        // 1: mgmt.PoolCreateReq
-	(*PoolCreateResp)(nil),       // 2: mgmt.PoolCreateResp
-	(*PoolDestroyReq)(nil),       // 3: mgmt.PoolDestroyReq
-	(*PoolDestroyResp)(nil),      // 4: mgmt.PoolDestroyResp
-	(*PoolEvictReq)(nil),         // 5: mgmt.PoolEvictReq
-	(*PoolEvictResp)(nil),        // 6: mgmt.PoolEvictResp
-	(*PoolExcludeReq)(nil),       // 7: mgmt.PoolExcludeReq
-	(*PoolExcludeResp)(nil),      // 8: mgmt.PoolExcludeResp
-	(*PoolDrainReq)(nil),         // 9: mgmt.PoolDrainReq
-	(*PoolDrainResp)(nil),        // 10: mgmt.PoolDrainResp
-	(*PoolExtendReq)(nil),        // 11: mgmt.PoolExtendReq
-	(*PoolExtendResp)(nil),       // 12: mgmt.PoolExtendResp
-	(*PoolReintegrateReq)(nil),   // 13: mgmt.PoolReintegrateReq
-	(*PoolReintegrateResp)(nil),  // 14: mgmt.PoolReintegrateResp
-	(*ListPoolsReq)(nil),         // 15: mgmt.ListPoolsReq
-	(*ListPoolsResp)(nil),        // 16: mgmt.ListPoolsResp
-	(*PoolResolveIDReq)(nil),     // 17: mgmt.PoolResolveIDReq
-	(*PoolResolveIDResp)(nil),    // 18: mgmt.PoolResolveIDResp
-	(*ListContReq)(nil),          // 19: mgmt.ListContReq
-	(*ListContResp)(nil),         // 20: mgmt.ListContResp
-	(*PoolQueryReq)(nil),         // 21: mgmt.PoolQueryReq
-	(*StorageUsageStats)(nil),    // 22: mgmt.StorageUsageStats
-	(*PoolRebuildStatus)(nil),    // 23: mgmt.PoolRebuildStatus
-	(*PoolQueryResp)(nil),        // 24: mgmt.PoolQueryResp
-	(*PoolSetPropReq)(nil),       // 25: mgmt.PoolSetPropReq
-	(*PoolSetPropResp)(nil),      // 26: mgmt.PoolSetPropResp
-	(*ListPoolsResp_Pool)(nil),   // 27: mgmt.ListPoolsResp.Pool
-	(*ListContResp_Cont)(nil),    // 28: mgmt.ListContResp.Cont
+	(*PoolProperty)(nil),         // 2: mgmt.PoolProperty
+	(*PoolFaultDomain)(nil),      // 3: mgmt.PoolFaultDomain
+	(*PoolCreateResp)(nil),       // 4: mgmt.PoolCreateResp
+	(*PoolDestroyReq)(nil),       // 5: mgmt.PoolDestroyReq
+	(*PoolDestroyResp)(nil),      // 6: mgmt.PoolDestroyResp
+	(*PoolRestoreReq)(nil),       // 7: mgmt.PoolRestoreReq
+	(*PoolRestoreResp)(nil),      // 8: mgmt.PoolRestoreResp
+	(*PoolEvictReq)(nil),         // 9: mgmt.PoolEvictReq
+	(*PoolEvictResp)(nil),        // 10: mgmt.PoolEvictResp
+	(*PoolExcludeReq)(nil),       // 11: mgmt.PoolExcludeReq
+	(*PoolExcludeResp)(nil),      // 12: mgmt.PoolExcludeResp
+	(*PoolDrainReq)(nil),         // 13: mgmt.PoolDrainReq
+	(*PoolDrainResp)(nil),        // 14: mgmt.PoolDrainResp
+	(*PoolExtendReq)(nil),        // 15: mgmt.PoolExtendReq
+	(*PoolExtendResp)(nil),       // 16: mgmt.PoolExtendResp
+	(*PoolReintegrateReq)(nil),   // 17: mgmt.PoolReintegrateReq
+	(*PoolReintegrateResp)(nil),  // 18: mgmt.PoolReintegrateResp
+	(*ListPoolsReq)(nil),         // 19: mgmt.ListPoolsReq
+	(*ListPoolsResp)(nil),        // 20: mgmt.ListPoolsResp
+	(*PoolResolveIDReq)(nil),     // 21: mgmt.PoolResolveIDReq
+	(*PoolResolveIDResp)(nil),    // 22: mgmt.PoolResolveIDResp
+	(*ListContReq)(nil),          // 23: mgmt.ListContReq
+	(*ListContResp)(nil),         // 24: mgmt.ListContResp
+	(*PoolQueryReq)(nil),         // 25: mgmt.PoolQueryReq
+	(*StorageUsageStats)(nil),    // 26: mgmt.StorageUsageStats
+	(*PoolRebuildStatus)(nil),    // 27: mgmt.PoolRebuildStatus
+	(*PoolQueryResp)(nil),        // 28: mgmt.PoolQueryResp
+	(*PoolSetPropReq)(nil),       // 29: mgmt.PoolSetPropReq
+	(*PoolSetPropResp)(nil),      // 30: mgmt.PoolSetPropResp
+	(*PoolRankChoice)(nil),       // 31: mgmt.PoolRankChoice
+	(*PoolCreateTiming)(nil),     // 32: mgmt.PoolCreateTiming
+	(*ListPoolsResp_Pool)(nil),   // 33: mgmt.ListPoolsResp.Pool
+	(*ListContResp_Cont)(nil),    // 34: mgmt.ListContResp.Cont
 }
 var file_mgmt_pool_proto_depIdxs = []int32{
-	27, // 0: mgmt.ListPoolsResp.pools:type_name -> mgmt.ListPoolsResp.Pool
-	28, // 1: mgmt.ListContResp.containers:type_name -> mgmt.ListContResp.Cont
-	0,  // 2: mgmt.PoolRebuildStatus.state:type_name -> mgmt.PoolRebuildStatus.State
-	23, // 3: mgmt.PoolQueryResp.rebuild:type_name -> mgmt.PoolRebuildStatus
-	22, // 4: mgmt.PoolQueryResp.scm:type_name -> mgmt.StorageUsageStats
-	22, // 5: mgmt.PoolQueryResp.nvme:type_name -> mgmt.StorageUsageStats
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	2,  // 0: mgmt.PoolCreateReq.properties:type_name -> mgmt.PoolProperty
+	3,  // 1: mgmt.PoolCreateResp.fault_domains:type_name -> mgmt.PoolFaultDomain
+	2,  // 2: mgmt.PoolCreateResp.properties:type_name -> mgmt.PoolProperty
+	31, // 3: mgmt.PoolCreateResp.rank_choices:type_name -> mgmt.PoolRankChoice
+	32, // 4: mgmt.PoolCreateResp.timings:type_name -> mgmt.PoolCreateTiming
+	33, // 5: mgmt.ListPoolsResp.pools:type_name -> mgmt.ListPoolsResp.Pool
+	34, // 6: mgmt.ListContResp.containers:type_name -> mgmt.ListContResp.Cont
+	0,  // 7: mgmt.PoolRebuildStatus.state:type_name -> mgmt.PoolRebuildStatus.State
+	27, // 8: mgmt.PoolQueryResp.rebuild:type_name -> mgmt.PoolRebuildStatus
+	26, // 9: mgmt.PoolQueryResp.scm:type_name -> mgmt.StorageUsageStats
+	26, // 10: mgmt.PoolQueryResp.nvme:type_name -> mgmt.StorageUsageStats
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_mgmt_pool_proto_init() }
@@ -2425,7 +2981,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolCreateResp); i {
+			switch v := v.(*PoolProperty); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2437,7 +2993,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolDestroyReq); i {
+			switch v := v.(*PoolFaultDomain); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2449,7 +3005,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolDestroyResp); i {
+			switch v := v.(*PoolCreateResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2461,7 +3017,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolEvictReq); i {
+			switch v := v.(*PoolDestroyReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2473,7 +3029,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolEvictResp); i {
+			switch v := v.(*PoolDestroyResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2485,7 +3041,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolExcludeReq); i {
+			switch v := v.(*PoolRestoreReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2497,7 +3053,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolExcludeResp); i {
+			switch v := v.(*PoolRestoreResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2509,7 +3065,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolDrainReq); i {
+			switch v := v.(*PoolEvictReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2521,7 +3077,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolDrainResp); i {
+			switch v := v.(*PoolEvictResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2533,7 +3089,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolExtendReq); i {
+			switch v := v.(*PoolExcludeReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2545,7 +3101,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolExtendResp); i {
+			switch v := v.(*PoolExcludeResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2557,7 +3113,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolReintegrateReq); i {
+			switch v := v.(*PoolDrainReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2569,7 +3125,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolReintegrateResp); i {
+			switch v := v.(*PoolDrainResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2581,7 +3137,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPoolsReq); i {
+			switch v := v.(*PoolExtendReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2593,7 +3149,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPoolsResp); i {
+			switch v := v.(*PoolExtendResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2605,7 +3161,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolResolveIDReq); i {
+			switch v := v.(*PoolReintegrateReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2617,7 +3173,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolResolveIDResp); i {
+			switch v := v.(*PoolReintegrateResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2629,7 +3185,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListContReq); i {
+			switch v := v.(*ListPoolsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2641,7 +3197,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListContResp); i {
+			switch v := v.(*ListPoolsResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2653,7 +3209,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolQueryReq); i {
+			switch v := v.(*PoolResolveIDReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2665,7 +3221,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageUsageStats); i {
+			switch v := v.(*PoolResolveIDResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2677,7 +3233,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolRebuildStatus); i {
+			switch v := v.(*ListContReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2689,7 +3245,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolQueryResp); i {
+			switch v := v.(*ListContResp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2701,7 +3257,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolSetPropReq); i {
+			switch v := v.(*PoolQueryReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2713,7 +3269,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PoolSetPropResp); i {
+			switch v := v.(*StorageUsageStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2725,7 +3281,7 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPoolsResp_Pool); i {
+			switch v := v.(*PoolRebuildStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2737,6 +3293,78 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 		file_mgmt_pool_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolQueryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolSetPropReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolSetPropResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolRankChoice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolCreateTiming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPoolsResp_Pool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_pool_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListContResp_Cont); i {
 			case 0:
 				return &v.state
@@ -2749,13 +3377,13 @@ func file_mgmt_pool_proto_init() {
 			}
 		}
 	}
-	file_mgmt_pool_proto_msgTypes[24].OneofWrappers = []interface{}{
+	file_mgmt_pool_proto_msgTypes[28].OneofWrappers = []interface{}{
 		(*PoolSetPropReq_Name)(nil),
 		(*PoolSetPropReq_Number)(nil),
 		(*PoolSetPropReq_Strval)(nil),
 		(*PoolSetPropReq_Numval)(nil),
 	}
-	file_mgmt_pool_proto_msgTypes[25].OneofWrappers = []interface{}{
+	file_mgmt_pool_proto_msgTypes[29].OneofWrappers = []interface{}{
 		(*PoolSetPropResp_Name)(nil),
 		(*PoolSetPropResp_Number)(nil),
 		(*PoolSetPropResp_Strval)(nil),
@@ -2767,7 +3395,7 @@ func file_mgmt_pool_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mgmt_pool_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   28,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -27,6 +27,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SystemMaintenanceReq_Action int32
+
+const (
+	SystemMaintenanceReq_LIST  SystemMaintenanceReq_Action = 0
+	SystemMaintenanceReq_START SystemMaintenanceReq_Action = 1
+	SystemMaintenanceReq_END   SystemMaintenanceReq_Action = 2
+)
+
+// Enum value maps for SystemMaintenanceReq_Action.
+var (
+	SystemMaintenanceReq_Action_name = map[int32]string{
+		0: "LIST",
+		1: "START",
+		2: "END",
+	}
+	SystemMaintenanceReq_Action_value = map[string]int32{
+		"LIST":  0,
+		"START": 1,
+		"END":   2,
+	}
+)
+
+func (x SystemMaintenanceReq_Action) Enum() *SystemMaintenanceReq_Action {
+	p := new(SystemMaintenanceReq_Action)
+	*p = x
+	return p
+}
+
+func (x SystemMaintenanceReq_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SystemMaintenanceReq_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_mgmt_system_proto_enumTypes[0].Descriptor()
+}
+
+func (SystemMaintenanceReq_Action) Type() protoreflect.EnumType {
+	return &file_mgmt_system_proto_enumTypes[0]
+}
+
+func (x SystemMaintenanceReq_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SystemMaintenanceReq_Action.Descriptor instead.
+func (SystemMaintenanceReq_Action) EnumDescriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type SystemLockReq_Action int32
+
+const (
+	SystemLockReq_LIST    SystemLockReq_Action = 0
+	SystemLockReq_ACQUIRE SystemLockReq_Action = 1
+	SystemLockReq_RELEASE SystemLockReq_Action = 2
+)
+
+// Enum value maps for SystemLockReq_Action.
+var (
+	SystemLockReq_Action_name = map[int32]string{
+		0: "LIST",
+		1: "ACQUIRE",
+		2: "RELEASE",
+	}
+	SystemLockReq_Action_value = map[string]int32{
+		"LIST":    0,
+		"ACQUIRE": 1,
+		"RELEASE": 2,
+	}
+)
+
+func (x SystemLockReq_Action) Enum() *SystemLockReq_Action {
+	p := new(SystemLockReq_Action)
+	*p = x
+	return p
+}
+
+func (x SystemLockReq_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SystemLockReq_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_mgmt_system_proto_enumTypes[1].Descriptor()
+}
+
+func (SystemLockReq_Action) Type() protoreflect.EnumType {
+	return &file_mgmt_system_proto_enumTypes[1]
+}
+
+func (x SystemLockReq_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SystemLockReq_Action.Descriptor instead.
+func (SystemLockReq_Action) EnumDescriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{20, 0}
+}
+
 // SystemMember refers to a data-plane instance that is a member of DAOS
 // system running on host with the control-plane listening at "Addr".
 type SystemMember struct {
@@ -41,8 +139,11 @@ type SystemMember struct {
 	FabricUri      string `protobuf:"bytes,5,opt,name=fabric_uri,json=fabricUri,proto3" json:"fabric_uri,omitempty"`
 	FabricContexts uint32 `protobuf:"varint,6,opt,name=fabric_contexts,json=fabricContexts,proto3" json:"fabric_contexts,omitempty"`
 	// ancillary info e.g. error msg or reason for state change
-	Info        string `protobuf:"bytes,7,opt,name=info,proto3" json:"info,omitempty"`
-	FaultDomain string `protobuf:"bytes,8,opt,name=fault_domain,json=faultDomain,proto3" json:"fault_domain,omitempty"`
+	Info        string           `protobuf:"bytes,7,opt,name=info,proto3" json:"info,omitempty"`
+	FaultDomain string           `protobuf:"bytes,8,opt,name=fault_domain,json=faultDomain,proto3" json:"fault_domain,omitempty"`
+	Startup     *StartupTimeline `protobuf:"bytes,9,opt,name=startup,proto3" json:"startup,omitempty"`                          // timeline of most recent engine startup
+	ClockSkew   *ClockSkew       `protobuf:"bytes,10,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`    // clock offset measured by the MS replica
+	ConfigHash  string           `protobuf:"bytes,11,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"` // hash of the server config reported with heartbeats
 }
 
 func (x *SystemMember) Reset() {
@@ -133,6 +234,171 @@ func (x *SystemMember) GetFaultDomain() string {
 	return ""
 }
 
+func (x *SystemMember) GetStartup() *StartupTimeline {
+	if x != nil {
+		return x.Startup
+	}
+	return nil
+}
+
+func (x *SystemMember) GetClockSkew() *ClockSkew {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return nil
+}
+
+func (x *SystemMember) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+// StartupTimeline records the time each phase of an engine startup was reached.
+type StartupTimeline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageReady  string `protobuf:"bytes,1,opt,name=storage_ready,json=storageReady,proto3" json:"storage_ready,omitempty"`
+	FabricUp      string `protobuf:"bytes,2,opt,name=fabric_up,json=fabricUp,proto3" json:"fabric_up,omitempty"`
+	Joined        string `protobuf:"bytes,3,opt,name=joined,proto3" json:"joined,omitempty"`
+	PoolsRestored string `protobuf:"bytes,4,opt,name=pools_restored,json=poolsRestored,proto3" json:"pools_restored,omitempty"`
+}
+
+func (x *StartupTimeline) Reset() {
+	*x = StartupTimeline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartupTimeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartupTimeline) ProtoMessage() {}
+
+func (x *StartupTimeline) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartupTimeline.ProtoReflect.Descriptor instead.
+func (*StartupTimeline) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StartupTimeline) GetStorageReady() string {
+	if x != nil {
+		return x.StorageReady
+	}
+	return ""
+}
+
+func (x *StartupTimeline) GetFabricUp() string {
+	if x != nil {
+		return x.FabricUp
+	}
+	return ""
+}
+
+func (x *StartupTimeline) GetJoined() string {
+	if x != nil {
+		return x.Joined
+	}
+	return ""
+}
+
+func (x *StartupTimeline) GetPoolsRestored() string {
+	if x != nil {
+		return x.PoolsRestored
+	}
+	return ""
+}
+
+// ClockSkew records the offset of a server clock from that of an MS replica.
+type ClockSkew struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset    int64  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`                        // offset in nanoseconds, positive if the server is ahead
+	RoundTrip int64  `protobuf:"varint,2,opt,name=round_trip,json=roundTrip,proto3" json:"round_trip,omitempty"` // round trip time of the measurement in nanoseconds
+	Checked   string `protobuf:"bytes,3,opt,name=checked,proto3" json:"checked,omitempty"`                       // time of the measurement
+	Exceeded  bool   `protobuf:"varint,4,opt,name=exceeded,proto3" json:"exceeded,omitempty"`                    // offset exceeds the configured threshold
+}
+
+func (x *ClockSkew) Reset() {
+	*x = ClockSkew{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClockSkew) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockSkew) ProtoMessage() {}
+
+func (x *ClockSkew) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockSkew.ProtoReflect.Descriptor instead.
+func (*ClockSkew) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClockSkew) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetRoundTrip() int64 {
+	if x != nil {
+		return x.RoundTrip
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetChecked() string {
+	if x != nil {
+		return x.Checked
+	}
+	return ""
+}
+
+func (x *ClockSkew) GetExceeded() bool {
+	if x != nil {
+		return x.Exceeded
+	}
+	return false
+}
+
 // SystemStopReq supplies system shutdown parameters.
 type SystemStopReq struct {
 	state         protoimpl.MessageState
@@ -150,7 +416,7 @@ type SystemStopReq struct {
 func (x *SystemStopReq) Reset() {
 	*x = SystemStopReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[1]
+		mi := &file_mgmt_system_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -163,7 +429,7 @@ func (x *SystemStopReq) String() string {
 func (*SystemStopReq) ProtoMessage() {}
 
 func (x *SystemStopReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[1]
+	mi := &file_mgmt_system_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -176,7 +442,7 @@ func (x *SystemStopReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStopReq.ProtoReflect.Descriptor instead.
 func (*SystemStopReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{1}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SystemStopReq) GetSys() string {
@@ -236,7 +502,7 @@ type SystemStopResp struct {
 func (x *SystemStopResp) Reset() {
 	*x = SystemStopResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[2]
+		mi := &file_mgmt_system_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -249,7 +515,7 @@ func (x *SystemStopResp) String() string {
 func (*SystemStopResp) ProtoMessage() {}
 
 func (x *SystemStopResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[2]
+	mi := &file_mgmt_system_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +528,7 @@ func (x *SystemStopResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStopResp.ProtoReflect.Descriptor instead.
 func (*SystemStopResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{2}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SystemStopResp) GetResults() []*shared.RankResult {
@@ -300,7 +566,7 @@ type SystemStartReq struct {
 func (x *SystemStartReq) Reset() {
 	*x = SystemStartReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[3]
+		mi := &file_mgmt_system_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -313,7 +579,7 @@ func (x *SystemStartReq) String() string {
 func (*SystemStartReq) ProtoMessage() {}
 
 func (x *SystemStartReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[3]
+	mi := &file_mgmt_system_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +592,7 @@ func (x *SystemStartReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStartReq.ProtoReflect.Descriptor instead.
 func (*SystemStartReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{3}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SystemStartReq) GetSys() string {
@@ -365,7 +631,7 @@ type SystemStartResp struct {
 func (x *SystemStartResp) Reset() {
 	*x = SystemStartResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[4]
+		mi := &file_mgmt_system_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +644,7 @@ func (x *SystemStartResp) String() string {
 func (*SystemStartResp) ProtoMessage() {}
 
 func (x *SystemStartResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[4]
+	mi := &file_mgmt_system_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +657,7 @@ func (x *SystemStartResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemStartResp.ProtoReflect.Descriptor instead.
 func (*SystemStartResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{4}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SystemStartResp) GetResults() []*shared.RankResult {
@@ -421,15 +687,16 @@ type SystemQueryReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sys   string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`     // DAOS system name
-	Ranks string `protobuf:"bytes,2,opt,name=ranks,proto3" json:"ranks,omitempty"` // rankset to query
-	Hosts string `protobuf:"bytes,3,opt,name=hosts,proto3" json:"hosts,omitempty"` // hostset to query
+	Sys    string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`        // DAOS system name
+	Ranks  string `protobuf:"bytes,2,opt,name=ranks,proto3" json:"ranks,omitempty"`    // rankset to query
+	Hosts  string `protobuf:"bytes,3,opt,name=hosts,proto3" json:"hosts,omitempty"`    // hostset to query
+	Agents bool   `protobuf:"varint,4,opt,name=agents,proto3" json:"agents,omitempty"` // include the agents known to the MS
 }
 
 func (x *SystemQueryReq) Reset() {
 	*x = SystemQueryReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[5]
+		mi := &file_mgmt_system_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -442,7 +709,7 @@ func (x *SystemQueryReq) String() string {
 func (*SystemQueryReq) ProtoMessage() {}
 
 func (x *SystemQueryReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[5]
+	mi := &file_mgmt_system_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +722,7 @@ func (x *SystemQueryReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemQueryReq.ProtoReflect.Descriptor instead.
 func (*SystemQueryReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{5}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SystemQueryReq) GetSys() string {
@@ -479,6 +746,77 @@ func (x *SystemQueryReq) GetHosts() string {
 	return ""
 }
 
+func (x *SystemQueryReq) GetAgents() bool {
+	if x != nil {
+		return x.Agents
+	}
+	return false
+}
+
+// AgentInfo describes a client agent known to the MS.
+type AgentInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host     string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                         // address of the agent host
+	Version  string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                   // DAOS version of the agent
+	LastSeen string `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // time the agent last requested attach info
+}
+
+func (x *AgentInfo) Reset() {
+	*x = AgentInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentInfo) ProtoMessage() {}
+
+func (x *AgentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentInfo.ProtoReflect.Descriptor instead.
+func (*AgentInfo) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AgentInfo) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
 // SystemQueryResp returns active system members.
 type SystemQueryResp struct {
 	state         protoimpl.MessageState
@@ -488,12 +826,13 @@ type SystemQueryResp struct {
 	Members     []*SystemMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
 	Absentranks string          `protobuf:"bytes,2,opt,name=absentranks,proto3" json:"absentranks,omitempty"` // rankset missing from membership
 	Absenthosts string          `protobuf:"bytes,3,opt,name=absenthosts,proto3" json:"absenthosts,omitempty"` // hostset missing from membership
+	Agents      []*AgentInfo    `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`           // agents known to the MS, if requested
 }
 
 func (x *SystemQueryResp) Reset() {
 	*x = SystemQueryResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[6]
+		mi := &file_mgmt_system_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -506,7 +845,7 @@ func (x *SystemQueryResp) String() string {
 func (*SystemQueryResp) ProtoMessage() {}
 
 func (x *SystemQueryResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[6]
+	mi := &file_mgmt_system_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +858,7 @@ func (x *SystemQueryResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemQueryResp.ProtoReflect.Descriptor instead.
 func (*SystemQueryResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{6}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SystemQueryResp) GetMembers() []*SystemMember {
@@ -543,19 +882,27 @@ func (x *SystemQueryResp) GetAbsenthosts() string {
 	return ""
 }
 
+func (x *SystemQueryResp) GetAgents() []*AgentInfo {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
 // SystemEraseReq supplies system erase parameters.
 type SystemEraseReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	Sys      string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`
+	NvmeTrim bool   `protobuf:"varint,2,opt,name=nvme_trim,json=nvmeTrim,proto3" json:"nvme_trim,omitempty"` // deallocate NVMe SSDs of erased ranks
 }
 
 func (x *SystemEraseReq) Reset() {
 	*x = SystemEraseReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[7]
+		mi := &file_mgmt_system_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -568,7 +915,7 @@ func (x *SystemEraseReq) String() string {
 func (*SystemEraseReq) ProtoMessage() {}
 
 func (x *SystemEraseReq) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[7]
+	mi := &file_mgmt_system_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +928,7 @@ func (x *SystemEraseReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemEraseReq.ProtoReflect.Descriptor instead.
 func (*SystemEraseReq) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{7}
+	return file_mgmt_system_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SystemEraseReq) GetSys() string {
@@ -591,31 +938,1328 @@ func (x *SystemEraseReq) GetSys() string {
 	return ""
 }
 
-type SystemEraseResp struct {
-	state         protoimpl.MessageState
+func (x *SystemEraseReq) GetNvmeTrim() bool {
+	if x != nil {
+		return x.NvmeTrim
+	}
+	return false
+}
+
+type SystemEraseResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*shared.RankResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SystemEraseResp) Reset() {
+	*x = SystemEraseResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemEraseResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEraseResp) ProtoMessage() {}
+
+func (x *SystemEraseResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEraseResp.ProtoReflect.Descriptor instead.
+func (*SystemEraseResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SystemEraseResp) GetResults() []*shared.RankResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// MaintenanceWindow describes a period during which a set of system
+// members is undergoing administrative maintenance.
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`               // window UUID
+	Hosts  string   `protobuf:"bytes,2,opt,name=hosts,proto3" json:"hosts,omitempty"`         // hostset under maintenance
+	Ranks  []uint32 `protobuf:"varint,3,rep,packed,name=ranks,proto3" json:"ranks,omitempty"` // ranks hosted on hostset
+	Reason string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`       // administrative reason for maintenance
+	Start  string   `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`         // RFC3339 start time
+	End    string   `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`             // RFC3339 end time
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MaintenanceWindow) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetHosts() string {
+	if x != nil {
+		return x.Hosts
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetRanks() []uint32 {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+// SystemMaintenanceReq supplies system maintenance window parameters.
+type SystemMaintenanceReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys      string                      `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system name
+	Action   SystemMaintenanceReq_Action `protobuf:"varint,2,opt,name=action,proto3,enum=mgmt.SystemMaintenanceReq_Action" json:"action,omitempty"`
+	Hosts    string                      `protobuf:"bytes,3,opt,name=hosts,proto3" json:"hosts,omitempty"`        // hostset to start or end maintenance on
+	Duration uint64                      `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"` // window duration in seconds (start only)
+	Reason   string                      `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`      // administrative reason (start only)
+}
+
+func (x *SystemMaintenanceReq) Reset() {
+	*x = SystemMaintenanceReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemMaintenanceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemMaintenanceReq) ProtoMessage() {}
+
+func (x *SystemMaintenanceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemMaintenanceReq.ProtoReflect.Descriptor instead.
+func (*SystemMaintenanceReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SystemMaintenanceReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemMaintenanceReq) GetAction() SystemMaintenanceReq_Action {
+	if x != nil {
+		return x.Action
+	}
+	return SystemMaintenanceReq_LIST
+}
+
+func (x *SystemMaintenanceReq) GetHosts() string {
+	if x != nil {
+		return x.Hosts
+	}
+	return ""
+}
+
+func (x *SystemMaintenanceReq) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *SystemMaintenanceReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// SystemMaintenanceResp returns the maintenance windows affected by the
+// request.
+type SystemMaintenanceResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Windows     []*MaintenanceWindow `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	Absenthosts string               `protobuf:"bytes,2,opt,name=absenthosts,proto3" json:"absenthosts,omitempty"` // hostset missing from membership
+}
+
+func (x *SystemMaintenanceResp) Reset() {
+	*x = SystemMaintenanceResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemMaintenanceResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemMaintenanceResp) ProtoMessage() {}
+
+func (x *SystemMaintenanceResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemMaintenanceResp.ProtoReflect.Descriptor instead.
+func (*SystemMaintenanceResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SystemMaintenanceResp) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *SystemMaintenanceResp) GetAbsenthosts() string {
+	if x != nil {
+		return x.Absenthosts
+	}
+	return ""
+}
+
+// SystemExcludeReq supplies parameters to administratively exclude system
+// members or to clear an existing exclusion.
+type SystemExcludeReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys   string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`      // DAOS system name
+	Ranks string `protobuf:"bytes,2,opt,name=ranks,proto3" json:"ranks,omitempty"`  // rankset to exclude
+	Hosts string `protobuf:"bytes,3,opt,name=hosts,proto3" json:"hosts,omitempty"`  // hostset to exclude
+	Clear bool   `protobuf:"varint,4,opt,name=clear,proto3" json:"clear,omitempty"` // clear exclusion rather than exclude
+}
+
+func (x *SystemExcludeReq) Reset() {
+	*x = SystemExcludeReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemExcludeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemExcludeReq) ProtoMessage() {}
+
+func (x *SystemExcludeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemExcludeReq.ProtoReflect.Descriptor instead.
+func (*SystemExcludeReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SystemExcludeReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemExcludeReq) GetRanks() string {
+	if x != nil {
+		return x.Ranks
+	}
+	return ""
+}
+
+func (x *SystemExcludeReq) GetHosts() string {
+	if x != nil {
+		return x.Hosts
+	}
+	return ""
+}
+
+func (x *SystemExcludeReq) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+// SystemExcludeResp returns results of attempts to exclude or clear the
+// exclusion of system members.
+type SystemExcludeResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results     []*shared.RankResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Absentranks string               `protobuf:"bytes,2,opt,name=absentranks,proto3" json:"absentranks,omitempty"` // rankset missing from membership
+	Absenthosts string               `protobuf:"bytes,3,opt,name=absenthosts,proto3" json:"absenthosts,omitempty"` // hostset missing from membership
+}
+
+func (x *SystemExcludeResp) Reset() {
+	*x = SystemExcludeResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemExcludeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemExcludeResp) ProtoMessage() {}
+
+func (x *SystemExcludeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemExcludeResp.ProtoReflect.Descriptor instead.
+func (*SystemExcludeResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SystemExcludeResp) GetResults() []*shared.RankResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SystemExcludeResp) GetAbsentranks() string {
+	if x != nil {
+		return x.Absentranks
+	}
+	return ""
+}
+
+func (x *SystemExcludeResp) GetAbsenthosts() string {
+	if x != nil {
+		return x.Absenthosts
+	}
+	return ""
+}
+
+// SystemReplicaQueryReq requests the raft status of a management service
+// replica.
+type SystemReplicaQueryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system name
+}
+
+func (x *SystemReplicaQueryReq) Reset() {
+	*x = SystemReplicaQueryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemReplicaQueryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemReplicaQueryReq) ProtoMessage() {}
+
+func (x *SystemReplicaQueryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemReplicaQueryReq.ProtoReflect.Descriptor instead.
+func (*SystemReplicaQueryReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SystemReplicaQueryReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+// SystemReplicaQueryResp returns the raft status of a management service
+// replica.
+type SystemReplicaQueryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State             string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`                                                     // raft state of the replica
+	Leader            string `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`                                                   // leader address known to the replica
+	Term              uint64 `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`                                                      // current raft term
+	LastIndex         uint64 `protobuf:"varint,4,opt,name=last_index,json=lastIndex,proto3" json:"last_index,omitempty"`                           // index of the last log entry
+	CommitIndex       uint64 `protobuf:"varint,5,opt,name=commit_index,json=commitIndex,proto3" json:"commit_index,omitempty"`                     // index of the last committed log entry
+	AppliedIndex      uint64 `protobuf:"varint,6,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`                  // index of the last log entry applied to the database
+	LastSnapshotIndex uint64 `protobuf:"varint,7,opt,name=last_snapshot_index,json=lastSnapshotIndex,proto3" json:"last_snapshot_index,omitempty"` // index of the last snapshot
+	LastSnapshotTime  string `protobuf:"bytes,8,opt,name=last_snapshot_time,json=lastSnapshotTime,proto3" json:"last_snapshot_time,omitempty"`     // RFC3339 time of the last snapshot
+	DbSize            uint64 `protobuf:"varint,9,opt,name=db_size,json=dbSize,proto3" json:"db_size,omitempty"`                                    // size of the database file in bytes
+}
+
+func (x *SystemReplicaQueryResp) Reset() {
+	*x = SystemReplicaQueryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemReplicaQueryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemReplicaQueryResp) ProtoMessage() {}
+
+func (x *SystemReplicaQueryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemReplicaQueryResp.ProtoReflect.Descriptor instead.
+func (*SystemReplicaQueryResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SystemReplicaQueryResp) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SystemReplicaQueryResp) GetLeader() string {
+	if x != nil {
+		return x.Leader
+	}
+	return ""
+}
+
+func (x *SystemReplicaQueryResp) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *SystemReplicaQueryResp) GetLastIndex() uint64 {
+	if x != nil {
+		return x.LastIndex
+	}
+	return 0
+}
+
+func (x *SystemReplicaQueryResp) GetCommitIndex() uint64 {
+	if x != nil {
+		return x.CommitIndex
+	}
+	return 0
+}
+
+func (x *SystemReplicaQueryResp) GetAppliedIndex() uint64 {
+	if x != nil {
+		return x.AppliedIndex
+	}
+	return 0
+}
+
+func (x *SystemReplicaQueryResp) GetLastSnapshotIndex() uint64 {
+	if x != nil {
+		return x.LastSnapshotIndex
+	}
+	return 0
+}
+
+func (x *SystemReplicaQueryResp) GetLastSnapshotTime() string {
+	if x != nil {
+		return x.LastSnapshotTime
+	}
+	return ""
+}
+
+func (x *SystemReplicaQueryResp) GetDbSize() uint64 {
+	if x != nil {
+		return x.DbSize
+	}
+	return 0
+}
+
+// SystemLock describes an administrative lock held in the management service.
+type SystemLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`         // lock name
+	Owner    string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`       // identity of the lock holder
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`     // administrative reason for holding the lock
+	Acquired string `protobuf:"bytes,4,opt,name=acquired,proto3" json:"acquired,omitempty"` // RFC3339 time the lock was acquired
+	Expires  string `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`   // RFC3339 time the lock expires
+}
+
+func (x *SystemLock) Reset() {
+	*x = SystemLock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemLock) ProtoMessage() {}
+
+func (x *SystemLock) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemLock.ProtoReflect.Descriptor instead.
+func (*SystemLock) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SystemLock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SystemLock) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SystemLock) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SystemLock) GetAcquired() string {
+	if x != nil {
+		return x.Acquired
+	}
+	return ""
+}
+
+func (x *SystemLock) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+// SystemLockReq supplies administrative lock parameters.
+type SystemLockReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys    string               `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system name
+	Action SystemLockReq_Action `protobuf:"varint,2,opt,name=action,proto3,enum=mgmt.SystemLockReq_Action" json:"action,omitempty"`
+	Names  []string             `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty"`   // names of locks to acquire, release or list
+	Owner  string               `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`   // identity of the lock holder (acquire and release)
+	Ttl    uint64               `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`      // seconds until the lock expires (acquire only)
+	Reason string               `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"` // administrative reason (acquire only)
+	Force  bool                 `protobuf:"varint,7,opt,name=force,proto3" json:"force,omitempty"`  // release locks held by other owners (release only)
+}
+
+func (x *SystemLockReq) Reset() {
+	*x = SystemLockReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemLockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemLockReq) ProtoMessage() {}
+
+func (x *SystemLockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemLockReq.ProtoReflect.Descriptor instead.
+func (*SystemLockReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SystemLockReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemLockReq) GetAction() SystemLockReq_Action {
+	if x != nil {
+		return x.Action
+	}
+	return SystemLockReq_LIST
+}
+
+func (x *SystemLockReq) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *SystemLockReq) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SystemLockReq) GetTtl() uint64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *SystemLockReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SystemLockReq) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// SystemLockResp returns the locks affected by the request.
+type SystemLockResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locks []*SystemLock `protobuf:"bytes,1,rep,name=locks,proto3" json:"locks,omitempty"`
+}
+
+func (x *SystemLockResp) Reset() {
+	*x = SystemLockResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemLockResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemLockResp) ProtoMessage() {}
+
+func (x *SystemLockResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemLockResp.ProtoReflect.Descriptor instead.
+func (*SystemLockResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SystemLockResp) GetLocks() []*SystemLock {
+	if x != nil {
+		return x.Locks
+	}
+	return nil
+}
+
+// SystemSetFaultDomainReq supplies the fault domain to assign to system members.
+type SystemSetFaultDomainReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys    string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`       // DAOS system name
+	Ranks  string `protobuf:"bytes,2,opt,name=ranks,proto3" json:"ranks,omitempty"`   // rankset to assign the fault domain to
+	Hosts  string `protobuf:"bytes,3,opt,name=hosts,proto3" json:"hosts,omitempty"`   // hostset to assign the fault domain to
+	Domain string `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"` // fault domain to assign
+	Clear  bool   `protobuf:"varint,5,opt,name=clear,proto3" json:"clear,omitempty"`  // clear assignment rather than assign
+}
+
+func (x *SystemSetFaultDomainReq) Reset() {
+	*x = SystemSetFaultDomainReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemSetFaultDomainReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemSetFaultDomainReq) ProtoMessage() {}
+
+func (x *SystemSetFaultDomainReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemSetFaultDomainReq.ProtoReflect.Descriptor instead.
+func (*SystemSetFaultDomainReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SystemSetFaultDomainReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemSetFaultDomainReq) GetRanks() string {
+	if x != nil {
+		return x.Ranks
+	}
+	return ""
+}
+
+func (x *SystemSetFaultDomainReq) GetHosts() string {
+	if x != nil {
+		return x.Hosts
+	}
+	return ""
+}
+
+func (x *SystemSetFaultDomainReq) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SystemSetFaultDomainReq) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+// SystemSetFaultDomainResp returns results of attempts to assign fault domains
+// to system members.
+type SystemSetFaultDomainResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results     []*shared.RankResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Absentranks string               `protobuf:"bytes,2,opt,name=absentranks,proto3" json:"absentranks,omitempty"` // rankset missing from membership
+	Absenthosts string               `protobuf:"bytes,3,opt,name=absenthosts,proto3" json:"absenthosts,omitempty"` // hostset missing from membership
+}
+
+func (x *SystemSetFaultDomainResp) Reset() {
+	*x = SystemSetFaultDomainResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemSetFaultDomainResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemSetFaultDomainResp) ProtoMessage() {}
+
+func (x *SystemSetFaultDomainResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemSetFaultDomainResp.ProtoReflect.Descriptor instead.
+func (*SystemSetFaultDomainResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SystemSetFaultDomainResp) GetResults() []*shared.RankResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SystemSetFaultDomainResp) GetAbsentranks() string {
+	if x != nil {
+		return x.Absentranks
+	}
+	return ""
+}
+
+func (x *SystemSetFaultDomainResp) GetAbsenthosts() string {
+	if x != nil {
+		return x.Absenthosts
+	}
+	return ""
+}
+
+// SystemOperation describes an administrative operation recorded in the
+// management service history.
+type SystemOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                // sequence number of the record
+	Time       string `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`             // RFC3339 time the operation completed
+	Operation  string `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`   // name of the operation
+	Initiator  string `protobuf:"bytes,4,opt,name=initiator,proto3" json:"initiator,omitempty"`   // identity of the client that requested the operation
+	Address    string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`       // address of the client that requested the operation
+	Host       string `protobuf:"bytes,6,opt,name=host,proto3" json:"host,omitempty"`             // host on which the operation was performed
+	Parameters string `protobuf:"bytes,7,opt,name=parameters,proto3" json:"parameters,omitempty"` // operation request parameters
+	Result     string `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`         // summary of the operation outcome
+	Failed     bool   `protobuf:"varint,9,opt,name=failed,proto3" json:"failed,omitempty"`        // true if the operation failed
+}
+
+func (x *SystemOperation) Reset() {
+	*x = SystemOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOperation) ProtoMessage() {}
+
+func (x *SystemOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOperation.ProtoReflect.Descriptor instead.
+func (*SystemOperation) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SystemOperation) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SystemOperation) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetParameters() string {
+	if x != nil {
+		return x.Parameters
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *SystemOperation) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+// SystemHistoryReq supplies filters for the operation history query.
+type SystemHistoryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys       string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`             // DAOS system name
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"` // only return records of this operation
+	Initiator string `protobuf:"bytes,3,opt,name=initiator,proto3" json:"initiator,omitempty"` // only return records from this initiator
+	Since     string `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`         // only return records after this RFC3339 time
+	Failed    bool   `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`      // only return records of failed operations
+	Limit     uint32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`        // maximum number of most recent records to return
+}
+
+func (x *SystemHistoryReq) Reset() {
+	*x = SystemHistoryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemHistoryReq) ProtoMessage() {}
+
+func (x *SystemHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemHistoryReq.ProtoReflect.Descriptor instead.
+func (*SystemHistoryReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SystemHistoryReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemHistoryReq) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *SystemHistoryReq) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *SystemHistoryReq) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *SystemHistoryReq) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *SystemHistoryReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SystemHistoryResp returns the matching operation records, oldest first.
+type SystemHistoryResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operations []*SystemOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *SystemHistoryResp) Reset() {
+	*x = SystemHistoryResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemHistoryResp) ProtoMessage() {}
+
+func (x *SystemHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemHistoryResp.ProtoReflect.Descriptor instead.
+func (*SystemHistoryResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SystemHistoryResp) GetOperations() []*SystemOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// SystemHistoryRecordReq supplies an operation performed on a server for
+// recording in the history.
+type SystemHistoryRecordReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys       string           `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"` // DAOS system name
+	Operation *SystemOperation `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+}
+
+func (x *SystemHistoryRecordReq) Reset() {
+	*x = SystemHistoryRecordReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemHistoryRecordReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemHistoryRecordReq) ProtoMessage() {}
+
+func (x *SystemHistoryRecordReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemHistoryRecordReq.ProtoReflect.Descriptor instead.
+func (*SystemHistoryRecordReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SystemHistoryRecordReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemHistoryRecordReq) GetOperation() *SystemOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+// SystemHistoryRecordResp is returned once the operation has been recorded.
+type SystemHistoryRecordResp struct {
+	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	Results []*shared.RankResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+func (x *SystemHistoryRecordResp) Reset() {
+	*x = SystemHistoryRecordResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *SystemEraseResp) Reset() {
-	*x = SystemEraseResp{}
+func (x *SystemHistoryRecordResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemHistoryRecordResp) ProtoMessage() {}
+
+func (x *SystemHistoryRecordResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemHistoryRecordResp.ProtoReflect.Descriptor instead.
+func (*SystemHistoryRecordResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{28}
+}
+
+// PoolPropDefault is a system-level default for a pool property.
+type PoolPropDefault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`          // property name
+	Value    string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`        // default value
+	Enforced bool   `protobuf:"varint,3,opt,name=enforced,proto3" json:"enforced,omitempty"` // value may not be overridden at pool create
+}
+
+func (x *PoolPropDefault) Reset() {
+	*x = PoolPropDefault{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mgmt_system_proto_msgTypes[8]
+		mi := &file_mgmt_system_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SystemEraseResp) String() string {
+func (x *PoolPropDefault) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemEraseResp) ProtoMessage() {}
+func (*PoolPropDefault) ProtoMessage() {}
 
-func (x *SystemEraseResp) ProtoReflect() protoreflect.Message {
-	mi := &file_mgmt_system_proto_msgTypes[8]
+func (x *PoolPropDefault) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,24 +2270,297 @@ func (x *SystemEraseResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemEraseResp.ProtoReflect.Descriptor instead.
-func (*SystemEraseResp) Descriptor() ([]byte, []int) {
-	return file_mgmt_system_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use PoolPropDefault.ProtoReflect.Descriptor instead.
+func (*PoolPropDefault) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *SystemEraseResp) GetResults() []*shared.RankResult {
+func (x *PoolPropDefault) GetName() string {
 	if x != nil {
-		return x.Results
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PoolPropDefault) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *PoolPropDefault) GetEnforced() bool {
+	if x != nil {
+		return x.Enforced
+	}
+	return false
+}
+
+// SystemPoolDefaultsReq supplies the pool property defaults to set or unset.
+// A request without either returns the current defaults.
+type SystemPoolDefaultsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys   string             `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`     // DAOS system name
+	Set   []*PoolPropDefault `protobuf:"bytes,2,rep,name=set,proto3" json:"set,omitempty"`     // defaults to set
+	Unset []string           `protobuf:"bytes,3,rep,name=unset,proto3" json:"unset,omitempty"` // names of defaults to remove
+}
+
+func (x *SystemPoolDefaultsReq) Reset() {
+	*x = SystemPoolDefaultsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemPoolDefaultsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemPoolDefaultsReq) ProtoMessage() {}
+
+func (x *SystemPoolDefaultsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemPoolDefaultsReq.ProtoReflect.Descriptor instead.
+func (*SystemPoolDefaultsReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SystemPoolDefaultsReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemPoolDefaultsReq) GetSet() []*PoolPropDefault {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *SystemPoolDefaultsReq) GetUnset() []string {
+	if x != nil {
+		return x.Unset
+	}
+	return nil
+}
+
+// SystemPoolDefaultsResp returns the pool property defaults in effect after
+// the request.
+type SystemPoolDefaultsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Defaults []*PoolPropDefault `protobuf:"bytes,1,rep,name=defaults,proto3" json:"defaults,omitempty"`
+}
+
+func (x *SystemPoolDefaultsResp) Reset() {
+	*x = SystemPoolDefaultsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemPoolDefaultsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemPoolDefaultsResp) ProtoMessage() {}
+
+func (x *SystemPoolDefaultsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemPoolDefaultsResp.ProtoReflect.Descriptor instead.
+func (*SystemPoolDefaultsResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SystemPoolDefaultsResp) GetDefaults() []*PoolPropDefault {
+	if x != nil {
+		return x.Defaults
+	}
+	return nil
+}
+
+// SystemActivateSpareReq supplies the failed rank to be replaced by a spare
+// engine on the same host.
+type SystemActivateSpareReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sys  string `protobuf:"bytes,1,opt,name=sys,proto3" json:"sys,omitempty"`    // DAOS system name
+	Rank uint32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"` // failed rank to be replaced
+}
+
+func (x *SystemActivateSpareReq) Reset() {
+	*x = SystemActivateSpareReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemActivateSpareReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemActivateSpareReq) ProtoMessage() {}
+
+func (x *SystemActivateSpareReq) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemActivateSpareReq.ProtoReflect.Descriptor instead.
+func (*SystemActivateSpareReq) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SystemActivateSpareReq) GetSys() string {
+	if x != nil {
+		return x.Sys
+	}
+	return ""
+}
+
+func (x *SystemActivateSpareReq) GetRank() uint32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// SystemActivateSpareResp returns the outcome of activating a spare engine.
+type SystemActivateSpareResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host            string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`                                              // control address of the host of the rank
+	SpareIdx        uint32   `protobuf:"varint,2,opt,name=spare_idx,json=spareIdx,proto3" json:"spare_idx,omitempty"`                     // index of the activated spare engine
+	FailedIdx       uint32   `protobuf:"varint,3,opt,name=failed_idx,json=failedIdx,proto3" json:"failed_idx,omitempty"`                  // index of the engine that held the rank
+	ReleasedDevices []string `protobuf:"bytes,4,rep,name=released_devices,json=releasedDevices,proto3" json:"released_devices,omitempty"` // devices of the failed engine released from the ledger
+	State           string   `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`                                            // state of the spare engine after activation
+	SpareRank       uint32   `protobuf:"varint,6,opt,name=spare_rank,json=spareRank,proto3" json:"spare_rank,omitempty"`                  // rank assigned to the spare engine on joining the system
+}
+
+func (x *SystemActivateSpareResp) Reset() {
+	*x = SystemActivateSpareResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mgmt_system_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemActivateSpareResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemActivateSpareResp) ProtoMessage() {}
+
+func (x *SystemActivateSpareResp) ProtoReflect() protoreflect.Message {
+	mi := &file_mgmt_system_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemActivateSpareResp.ProtoReflect.Descriptor instead.
+func (*SystemActivateSpareResp) Descriptor() ([]byte, []int) {
+	return file_mgmt_system_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SystemActivateSpareResp) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SystemActivateSpareResp) GetSpareIdx() uint32 {
+	if x != nil {
+		return x.SpareIdx
+	}
+	return 0
+}
+
+func (x *SystemActivateSpareResp) GetFailedIdx() uint32 {
+	if x != nil {
+		return x.FailedIdx
+	}
+	return 0
+}
+
+func (x *SystemActivateSpareResp) GetReleasedDevices() []string {
+	if x != nil {
+		return x.ReleasedDevices
 	}
 	return nil
 }
 
+func (x *SystemActivateSpareResp) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SystemActivateSpareResp) GetSpareRank() uint32 {
+	if x != nil {
+		return x.SpareRank
+	}
+	return 0
+}
+
 var File_mgmt_system_proto protoreflect.FileDescriptor
 
 var file_mgmt_system_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x6d, 0x67, 0x6d, 0x74, 0x2f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d, 0x67, 0x6d, 0x74, 0x1a, 0x12, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x01,
+	0x64, 0x2f, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x02,
 	0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12,
 	0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64,
 	0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
@@ -657,17 +2574,198 @@ var file_mgmt_system_proto_rawDesc = []byte{
 	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f,
 	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x21, 0x0a, 0x0c,
 	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22,
-	0x8b, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x28, 0x09, 0x52, 0x0b, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x2f, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x54,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70,
+	0x12, 0x2e, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x6b, 0x65, 0x77, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0x92, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x54, 0x69, 0x6d,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61,
+	0x62, 0x72, 0x69, 0x63, 0x5f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x61, 0x62, 0x72, 0x69, 0x63, 0x55, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x22, 0x78, 0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x6b, 0x65, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x72, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x69, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x22, 0x8b, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x70, 0x52,
+	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x72, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x70, 0x72, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x82,
+	0x01, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x4e, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f,
+	0x73, 0x74, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65,
+	0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e,
+	0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62,
+	0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x66, 0x0a, 0x0e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61,
+	0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x73, 0x22, 0x56, 0x0a, 0x09, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x22, 0xac, 0x01, 0x0a, 0x0f, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a,
+	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61,
+	0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x3f, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x72, 0x69, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6e, 0x76, 0x6d, 0x65, 0x54, 0x72, 0x69, 0x6d, 0x22, 0x3f, 0x0a, 0x0f, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x4d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0xd5, 0x01, 0x0a,
+	0x14, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x39, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x26, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x45,
+	0x4e, 0x44, 0x10, 0x02, 0x22, 0x6c, 0x0a, 0x15, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x61,
+	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x31, 0x0a,
+	0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73,
+	0x74, 0x73, 0x22, 0x66, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68,
+	0x6f, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x22, 0x85, 0x01, 0x0a, 0x11, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73,
+	0x74, 0x73, 0x22, 0x29, 0x0a, 0x15, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x22, 0xb8, 0x02,
+	0x0a, 0x16, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d,
+	0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11,
+	0x6c, 0x61, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x62, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x64, 0x62, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x22,
+	0xef, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
 	0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x73, 0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x72, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x70, 0x72, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x66,
-	0x6f, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x82, 0x01,
-	0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x73, 0x79, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x22, 0x2c, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a,
+	0x04, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x43, 0x51, 0x55, 0x49,
+	0x52, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x10,
+	0x02, 0x22, 0x38, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x17,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e,
+	0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x22, 0x8c, 0x01, 0x0a, 0x18, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x65,
+	0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70,
 	0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52,
 	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20,
@@ -675,44 +2773,83 @@ var file_mgmt_system_proto_rawDesc = []byte{
 	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73,
 	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18,
 	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73,
-	0x74, 0x73, 0x22, 0x4e, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73,
-	0x74, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x2e, 0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61,
-	0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e,
-	0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74,
-	0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x62, 0x73,
-	0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x4e, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x61, 0x6e,
-	0x6b, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07,
-	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x62,
-	0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b,
-	0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x61, 0x62, 0x73, 0x65, 0x6e, 0x74, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x22,
-	0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71,
-	0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
-	0x79, 0x73, 0x22, 0x3f, 0x0a, 0x0f, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x61, 0x73,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
-	0x52, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f,
-	0x73, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f,
-	0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x0f, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x22, 0xa4, 0x01, 0x0a, 0x10, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x4a, 0x0a, 0x11, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x35, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5f, 0x0a, 0x16, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x79, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x19, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x57, 0x0a, 0x0f, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x64, 0x22, 0x68, 0x0a, 0x15,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c,
+	0x50, 0x72, 0x6f, 0x70, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x03, 0x73, 0x65, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x75, 0x6e, 0x73, 0x65, 0x74, 0x22, 0x4b, 0x0a, 0x16, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x50, 0x6f, 0x6f, 0x6c, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x31, 0x0a, 0x08, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x67, 0x6d, 0x74, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x50, 0x72,
+	0x6f, 0x70, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x08, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x22, 0x3e, 0x0a, 0x16, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x79, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x72,
+	0x61, 0x6e, 0x6b, 0x22, 0xc9, 0x01, 0x0a, 0x17, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x70, 0x61, 0x72, 0x65, 0x49, 0x64, 0x78,
+	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x49, 0x64, 0x78, 0x12,
+	0x29, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x70, 0x61, 0x72, 0x65, 0x52, 0x61, 0x6e, 0x6b, 0x42,
+	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61,
+	0x6f, 0x73, 0x2d, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x2f, 0x64, 0x61, 0x6f, 0x73, 0x2f, 0x73, 0x72,
+	0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x67, 0x6d, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -727,29 +2864,70 @@ func file_mgmt_system_proto_rawDescGZIP() []byte {
 	return file_mgmt_system_proto_rawDescData
 }
 
-var file_mgmt_system_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_mgmt_system_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_mgmt_system_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_mgmt_system_proto_goTypes = []interface{}{
-	(*SystemMember)(nil),      // 0: mgmt.SystemMember
-	(*SystemStopReq)(nil),     // 1: mgmt.SystemStopReq
-	(*SystemStopResp)(nil),    // 2: mgmt.SystemStopResp
-	(*SystemStartReq)(nil),    // 3: mgmt.SystemStartReq
-	(*SystemStartResp)(nil),   // 4: mgmt.SystemStartResp
-	(*SystemQueryReq)(nil),    // 5: mgmt.SystemQueryReq
-	(*SystemQueryResp)(nil),   // 6: mgmt.SystemQueryResp
-	(*SystemEraseReq)(nil),    // 7: mgmt.SystemEraseReq
-	(*SystemEraseResp)(nil),   // 8: mgmt.SystemEraseResp
-	(*shared.RankResult)(nil), // 9: shared.RankResult
+	(SystemMaintenanceReq_Action)(0), // 0: mgmt.SystemMaintenanceReq.Action
+	(SystemLockReq_Action)(0),        // 1: mgmt.SystemLockReq.Action
+	(*SystemMember)(nil),             // 2: mgmt.SystemMember
+	(*StartupTimeline)(nil),          // 3: mgmt.StartupTimeline
+	(*ClockSkew)(nil),                // 4: mgmt.ClockSkew
+	(*SystemStopReq)(nil),            // 5: mgmt.SystemStopReq
+	(*SystemStopResp)(nil),           // 6: mgmt.SystemStopResp
+	(*SystemStartReq)(nil),           // 7: mgmt.SystemStartReq
+	(*SystemStartResp)(nil),          // 8: mgmt.SystemStartResp
+	(*SystemQueryReq)(nil),           // 9: mgmt.SystemQueryReq
+	(*AgentInfo)(nil),                // 10: mgmt.AgentInfo
+	(*SystemQueryResp)(nil),          // 11: mgmt.SystemQueryResp
+	(*SystemEraseReq)(nil),           // 12: mgmt.SystemEraseReq
+	(*SystemEraseResp)(nil),          // 13: mgmt.SystemEraseResp
+	(*MaintenanceWindow)(nil),        // 14: mgmt.MaintenanceWindow
+	(*SystemMaintenanceReq)(nil),     // 15: mgmt.SystemMaintenanceReq
+	(*SystemMaintenanceResp)(nil),    // 16: mgmt.SystemMaintenanceResp
+	(*SystemExcludeReq)(nil),         // 17: mgmt.SystemExcludeReq
+	(*SystemExcludeResp)(nil),        // 18: mgmt.SystemExcludeResp
+	(*SystemReplicaQueryReq)(nil),    // 19: mgmt.SystemReplicaQueryReq
+	(*SystemReplicaQueryResp)(nil),   // 20: mgmt.SystemReplicaQueryResp
+	(*SystemLock)(nil),               // 21: mgmt.SystemLock
+	(*SystemLockReq)(nil),            // 22: mgmt.SystemLockReq
+	(*SystemLockResp)(nil),           // 23: mgmt.SystemLockResp
+	(*SystemSetFaultDomainReq)(nil),  // 24: mgmt.SystemSetFaultDomainReq
+	(*SystemSetFaultDomainResp)(nil), // 25: mgmt.SystemSetFaultDomainResp
+	(*SystemOperation)(nil),          // 26: mgmt.SystemOperation
+	(*SystemHistoryReq)(nil),         // 27: mgmt.SystemHistoryReq
+	(*SystemHistoryResp)(nil),        // 28: mgmt.SystemHistoryResp
+	(*SystemHistoryRecordReq)(nil),   // 29: mgmt.SystemHistoryRecordReq
+	(*SystemHistoryRecordResp)(nil),  // 30: mgmt.SystemHistoryRecordResp
+	(*PoolPropDefault)(nil),          // 31: mgmt.PoolPropDefault
+	(*SystemPoolDefaultsReq)(nil),    // 32: mgmt.SystemPoolDefaultsReq
+	(*SystemPoolDefaultsResp)(nil),   // 33: mgmt.SystemPoolDefaultsResp
+	(*SystemActivateSpareReq)(nil),   // 34: mgmt.SystemActivateSpareReq
+	(*SystemActivateSpareResp)(nil),  // 35: mgmt.SystemActivateSpareResp
+	(*shared.RankResult)(nil),        // 36: shared.RankResult
 }
 var file_mgmt_system_proto_depIdxs = []int32{
-	9, // 0: mgmt.SystemStopResp.results:type_name -> shared.RankResult
-	9, // 1: mgmt.SystemStartResp.results:type_name -> shared.RankResult
-	0, // 2: mgmt.SystemQueryResp.members:type_name -> mgmt.SystemMember
-	9, // 3: mgmt.SystemEraseResp.results:type_name -> shared.RankResult
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3,  // 0: mgmt.SystemMember.startup:type_name -> mgmt.StartupTimeline
+	4,  // 1: mgmt.SystemMember.clock_skew:type_name -> mgmt.ClockSkew
+	36, // 2: mgmt.SystemStopResp.results:type_name -> shared.RankResult
+	36, // 3: mgmt.SystemStartResp.results:type_name -> shared.RankResult
+	2,  // 4: mgmt.SystemQueryResp.members:type_name -> mgmt.SystemMember
+	10, // 5: mgmt.SystemQueryResp.agents:type_name -> mgmt.AgentInfo
+	36, // 6: mgmt.SystemEraseResp.results:type_name -> shared.RankResult
+	0,  // 7: mgmt.SystemMaintenanceReq.action:type_name -> mgmt.SystemMaintenanceReq.Action
+	14, // 8: mgmt.SystemMaintenanceResp.windows:type_name -> mgmt.MaintenanceWindow
+	36, // 9: mgmt.SystemExcludeResp.results:type_name -> shared.RankResult
+	1,  // 10: mgmt.SystemLockReq.action:type_name -> mgmt.SystemLockReq.Action
+	21, // 11: mgmt.SystemLockResp.locks:type_name -> mgmt.SystemLock
+	36, // 12: mgmt.SystemSetFaultDomainResp.results:type_name -> shared.RankResult
+	26, // 13: mgmt.SystemHistoryResp.operations:type_name -> mgmt.SystemOperation
+	26, // 14: mgmt.SystemHistoryRecordReq.operation:type_name -> mgmt.SystemOperation
+	31, // 15: mgmt.SystemPoolDefaultsReq.set:type_name -> mgmt.PoolPropDefault
+	31, // 16: mgmt.SystemPoolDefaultsResp.defaults:type_name -> mgmt.PoolPropDefault
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_mgmt_system_proto_init() }
@@ -770,7 +2948,31 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartupTimeline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClockSkew); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemStopReq); i {
 			case 0:
 				return &v.state
@@ -782,7 +2984,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemStopResp); i {
 			case 0:
 				return &v.state
@@ -794,7 +2996,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemStartReq); i {
 			case 0:
 				return &v.state
@@ -806,7 +3008,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemStartResp); i {
 			case 0:
 				return &v.state
@@ -818,7 +3020,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemQueryReq); i {
 			case 0:
 				return &v.state
@@ -830,7 +3032,19 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemQueryResp); i {
 			case 0:
 				return &v.state
@@ -842,7 +3056,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemEraseReq); i {
 			case 0:
 				return &v.state
@@ -854,7 +3068,7 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
-		file_mgmt_system_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_mgmt_system_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemEraseResp); i {
 			case 0:
 				return &v.state
@@ -866,19 +3080,284 @@ func file_mgmt_system_proto_init() {
 				return nil
 			}
 		}
+		file_mgmt_system_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaintenanceWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemMaintenanceReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemMaintenanceResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemExcludeReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemExcludeResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemReplicaQueryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemReplicaQueryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemLock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemLockReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemLockResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemSetFaultDomainReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemSetFaultDomainResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemHistoryReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemHistoryResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemHistoryRecordReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemHistoryRecordResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolPropDefault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemPoolDefaultsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemPoolDefaultsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemActivateSpareReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mgmt_system_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SystemActivateSpareResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mgmt_system_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      2,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_mgmt_system_proto_goTypes,
 		DependencyIndexes: file_mgmt_system_proto_depIdxs,
+		EnumInfos:         file_mgmt_system_proto_enumTypes,
 		MessageInfos:      file_mgmt_system_proto_msgTypes,
 	}.Build()
 	File_mgmt_system_proto = out.File
@@ -60,8 +60,11 @@ func (acl *AccessControlList) String() string {
 
 // PoolDiscovery represents the basic discovery information for a pool.
 type PoolDiscovery struct {
-	UUID        string   `json:"uuid"`     // Unique identifier
-	SvcReplicas []uint32 `json:"svc_reps"` // Ranks of pool service replicas
+	UUID         string   `json:"uuid"`                    // Unique identifier
+	SvcReplicas  []uint32 `json:"svc_reps"`                // Ranks of pool service replicas
+	State        string   `json:"state"`                   // State of pool service
+	Protected    bool     `json:"protected"`               // Pool may not be destroyed
+	DestroyAfter string   `json:"destroy_after,omitempty"` // Time at which a disabled pool is destroyed
 }
 
 // InterfaceIsNil returns true if the interface itself or its underlying value
@@ -138,6 +138,9 @@ func (m MgmtMethod) String() string {
 		MethodPoolQuery:       "PoolQuery",
 		MethodPoolSetProp:     "PoolSetProp",
 		MethodListPools:       "ListPools",
+		MethodXstreamStats:    "XstreamStats",
+		MethodPoolTargets:     "PoolTargets",
+		MethodAgentStatus:     "AgentStatus",
 	}[m]; ok {
 		return s
 	}
@@ -227,6 +230,12 @@ const (
 	MethodNotifyExit MgmtMethod = C.DRPC_METHOD_MGMT_NOTIFY_EXIT
 	// MethodIdentifyStorage is a ModuleMgmt method
 	MethodIdentifyStorage MgmtMethod = C.DRPC_METHOD_MGMT_DEV_IDENTIFY
+	// MethodXstreamStats defines a method for retrieving per-xstream scheduling stats
+	MethodXstreamStats MgmtMethod = C.DRPC_METHOD_MGMT_XSTREAM_STATS
+	// MethodPoolTargets defines a method for retrieving local pool target info
+	MethodPoolTargets MgmtMethod = C.DRPC_METHOD_MGMT_POOL_TARGETS
+	// MethodAgentStatus defines a method for retrieving the state of the agent
+	MethodAgentStatus MgmtMethod = C.DRPC_METHOD_MGMT_AGENT_STATUS
 )
 
 type srvMethod int32
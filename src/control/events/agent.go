@@ -0,0 +1,46 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"fmt"
+	"math"
+)
+
+// NewAgentIfaceDownEvent creates an AgentIfaceDown event from given inputs.
+// The backup is the interface that new client connections are steered to, or
+// empty if there is none.
+func NewAgentIfaceDownEvent(hostname, iface, backup string) *RASEvent {
+	msg := fmt.Sprintf("fabric interface %s is down, no backup interface is available", iface)
+	if backup != "" {
+		msg = fmt.Sprintf("fabric interface %s is down, new client connections will use %s",
+			iface, backup)
+	}
+
+	return fill(&RASEvent{
+		Msg:      msg,
+		ID:       RASAgentIfaceDown,
+		Hostname: hostname,
+		Rank:     math.MaxUint32,
+		HWID:     iface,
+		Type:     RASTypeStateChange,
+		Severity: RASSeverityWarning,
+	})
+}
+
+// NewAgentIfaceUpEvent creates an AgentIfaceUp event from given inputs.
+func NewAgentIfaceUpEvent(hostname, iface string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:      fmt.Sprintf("fabric interface %s is up", iface),
+		ID:       RASAgentIfaceUp,
+		Hostname: hostname,
+		Rank:     math.MaxUint32,
+		HWID:     iface,
+		Type:     RASTypeStateChange,
+		Severity: RASSeverityNotice,
+	})
+}
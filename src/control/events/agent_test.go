@@ -0,0 +1,54 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvents_ConvertAgentIface(t *testing.T) {
+	for name, tc := range map[string]struct {
+		event  *RASEvent
+		expMsg string
+	}{
+		"down with backup": {
+			event:  NewAgentIfaceDownEvent(tHost, "ib0", "ib1"),
+			expMsg: "new client connections will use ib1",
+		},
+		"down without backup": {
+			event:  NewAgentIfaceDownEvent(tHost, "ib0", ""),
+			expMsg: "no backup interface",
+		},
+		"up": {
+			event:  NewAgentIfaceUpEvent(tHost, "ib0"),
+			expMsg: "ib0 is up",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if !strings.Contains(tc.event.Msg, tc.expMsg) {
+				t.Fatalf("expected %q in message %q", tc.expMsg, tc.event.Msg)
+			}
+
+			pbEvent, err := tc.event.ToProto()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			returnedEvent := new(RASEvent)
+			if err := returnedEvent.FromProto(pbEvent); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.event, returnedEvent, defEvtCmpOpts...); diff != "" {
+				t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -74,6 +74,21 @@ func NewEngineDiedEvent(hostname string, instanceIdx uint32, rank uint32, exitEr
 	})
 }
 
+// NewEngineStartedEvent creates an EngineStarted event from given inputs. The
+// startup timeline of the engine is supplied in an encoded form and stored as
+// opaque extended info.
+func NewEngineStartedEvent(hostname string, instanceIdx uint32, rank uint32, timeline string) *RASEvent {
+	return fill(&RASEvent{
+		Msg:          fmt.Sprintf("DAOS engine %d started as rank %d", instanceIdx, rank),
+		ID:           RASEngineStarted,
+		Hostname:     hostname,
+		Rank:         rank,
+		Type:         RASTypeStateChange,
+		Severity:     RASSeverityNotice,
+		ExtendedInfo: NewStrInfo(timeline),
+	})
+}
+
 // NewEngineFormatRequiredEvent creates a EngineFormatRequired event from given inputs.
 func NewEngineFormatRequiredEvent(hostname string, instanceIdx uint32, formatType string) *RASEvent {
 	return fill(&RASEvent{
@@ -87,3 +102,21 @@ func NewEngineFormatRequiredEvent(hostname string, instanceIdx uint32, formatTyp
 		},
 	})
 }
+
+// NewDeviceIOErrorEvent creates a DeviceIOError event from given inputs. The
+// UUID of the affected NVMe SSD is stored in the hardware ID field.
+func NewDeviceIOErrorEvent(hostname string, instanceIdx uint32, rank uint32, tgtID int32, devUUID, errType string) *RASEvent {
+	return fill(&RASEvent{
+		Msg: fmt.Sprintf("DAOS engine %d target %d detected %s error on device %s",
+			instanceIdx, tgtID, errType, devUUID),
+		ID:       RASDeviceIOError,
+		Hostname: hostname,
+		Rank:     rank,
+		HWID:     devUUID,
+		Type:     RASTypeStateChange,
+		Severity: RASSeverityError,
+		ExtendedInfo: &EngineStateInfo{
+			InstanceIdx: instanceIdx,
+		},
+	})
+}
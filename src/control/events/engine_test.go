@@ -20,6 +20,8 @@ const (
 	tRank        = 1
 	tPid         = 1234
 	tFmtType     = "Metadata"
+	tTgtID       = 3
+	tDevUUID     = "00000000-0000-0000-0000-000000000001"
 )
 
 var (
@@ -36,6 +38,11 @@ func mockEvtFmtReq(t *testing.T) *RASEvent {
 	return NewEngineFormatRequiredEvent(tHost, tInstanceIdx, tFmtType)
 }
 
+func mockEvtDevIOErr(t *testing.T) *RASEvent {
+	t.Helper()
+	return NewDeviceIOErrorEvent(tHost, tInstanceIdx, tRank, tTgtID, tDevUUID, "read")
+}
+
 func TestEvents_ConvertEngineDied(t *testing.T) {
 	event := mockEvtDied(t)
 
@@ -79,3 +86,39 @@ func TestEvents_ConvertEngineFormatRequired(t *testing.T) {
 		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
 	}
 }
+
+func TestEvents_ConvertDeviceIOError(t *testing.T) {
+	event := mockEvtDevIOErr(t)
+
+	pbEvent, err := event.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returnedEvent := new(RASEvent)
+	if err := returnedEvent.FromProto(pbEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(event, returnedEvent, defEvtCmpOpts...); diff != "" {
+		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestEvents_ConvertEngineStarted(t *testing.T) {
+	event := NewEngineStartedEvent(tHost, tInstanceIdx, tRank, `{"joined":"2021-01-01T00:00:00Z"}`)
+
+	pbEvent, err := event.ToProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returnedEvent := new(RASEvent)
+	if err := returnedEvent.FromProto(pbEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(event, returnedEvent, defEvtCmpOpts...); diff != "" {
+		t.Fatalf("unexpected event (-want, +got):\n%s\n", diff)
+	}
+}
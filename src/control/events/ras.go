@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
@@ -53,6 +54,13 @@ const (
 	RASSwimRankDead         RASID = C.RAS_SWIM_RANK_DEAD         // info
 	RASSystemStartFailed    RASID = C.RAS_SYSTEM_START_FAILED    // error
 	RASSystemStopFailed     RASID = C.RAS_SYSTEM_STOP_FAILED     // error
+	RASDeviceIOError        RASID = C.RAS_DEVICE_IO_ERROR        // error
+	RASDeviceSetFaulty      RASID = C.RAS_DEVICE_SET_FAULTY      // warning
+	RASRankExcluded         RASID = C.RAS_RANK_EXCLUDED          // warning
+	RASAutoActionSuppressed RASID = C.RAS_AUTO_ACTION_SUPPRESSED // warning
+	RASEngineStarted        RASID = C.RAS_ENGINE_STARTED         // notice
+	RASAgentIfaceDown       RASID = C.RAS_AGENT_IFACE_DOWN       // warning
+	RASAgentIfaceUp         RASID = C.RAS_AGENT_IFACE_UP         // notice
 )
 
 func (id RASID) String() string {
@@ -352,6 +360,137 @@ func (evt *RASEvent) PrintRAS() string {
 	return b.String()
 }
 
+// cefSeverity maps RAS severity to CEF severity.
+func (sev RASSeverityID) cefSeverity() int {
+	switch sev {
+	case RASSeverityError:
+		return 8
+	case RASSeverityWarning:
+		return 6
+	case RASSeverityNotice:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// eventTime returns the time the event was raised, or the zero time if the
+// timestamp cannot be parsed.
+func (evt *RASEvent) eventTime() time.Time {
+	t, err := common.ParseTime(evt.Timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// idString returns the string representation of a process or thread ID, or
+// an empty string if unset.
+func idString(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+// siemFields returns the optional event fields common to the CEF and RFC5424
+// representations, keyed by the names used in RFC5424 structured data.
+func (evt *RASEvent) siemFields() map[string]string {
+	fields := map[string]string{
+		"hwid":      evt.HWID,
+		"jobid":     evt.JobID,
+		"pool":      evt.PoolUUID,
+		"container": evt.ContUUID,
+		"objid":     evt.ObjID,
+		"ctlop":     evt.CtlOp,
+	}
+	if evt.Rank != C.CRT_NO_RANK {
+		fields["rank"] = fmt.Sprintf("%d", evt.Rank)
+	}
+	if ei := evt.GetStrInfo(); ei != nil {
+		fields["data"] = string(*ei)
+	}
+
+	return fields
+}
+
+// CEF generates a representation of the event in ArcSight Common Event
+// Format.
+func (evt *RASEvent) CEF() string {
+	fields := evt.siemFields()
+
+	cr := &CEFRecord{
+		Product:     SyslogAppName,
+		Version:     build.DaosVersion,
+		SignatureID: evt.ID.String(),
+		Name:        evt.Msg,
+		Severity:    evt.Severity.cefSeverity(),
+		Extension: []SIEMField{
+			{"rt", CEFTime(evt.eventTime())},
+			{"dvchost", evt.Hostname},
+			{"dvcpid", idString(evt.ProcID)},
+			{"cat", evt.Type.String()},
+			{"act", fields["ctlop"]},
+		},
+	}
+	// CEF only provides six custom string fields, labelled where used
+	for i, name := range []string{"rank", "pool", "container", "jobid", "hwid", "objid"} {
+		if fields[name] == "" {
+			continue
+		}
+		cr.Extension = append(cr.Extension,
+			SIEMField{fmt.Sprintf("cs%dLabel", i+1), name},
+			SIEMField{fmt.Sprintf("cs%d", i+1), fields[name]})
+	}
+	cr.Extension = append(cr.Extension, SIEMField{"msg", fields["data"]})
+	if cr.Name == "" {
+		cr.Name = evt.ID.String()
+	}
+
+	return cr.String()
+}
+
+// RFC5424 generates a representation of the event as a syslog message with
+// the event fields in structured data as per RFC5424.
+func (evt *RASEvent) RFC5424() string {
+	fields := evt.siemFields()
+
+	return (&SyslogMessage{
+		Priority:  evt.Severity.SyslogPriority(),
+		Timestamp: evt.eventTime(),
+		Hostname:  evt.Hostname,
+		ProcID:    idString(evt.ProcID),
+		MsgID:     evt.ID.String(),
+		SDName:    "ras",
+		SDParams: []SIEMField{
+			{"type", evt.Type.String()},
+			{"sev", evt.Severity.String()},
+			{"rank", fields["rank"]},
+			{"tid", idString(evt.ThreadID)},
+			{"hwid", fields["hwid"]},
+			{"jobid", fields["jobid"]},
+			{"pool", fields["pool"]},
+			{"container", fields["container"]},
+			{"objid", fields["objid"]},
+			{"ctlop", fields["ctlop"]},
+			{"data", fields["data"]},
+		},
+		Msg: evt.Msg,
+	}).String()
+}
+
+// Format generates a representation of the event in the given format.
+func (evt *RASEvent) Format(format EventFormat) string {
+	switch format {
+	case EventFormatCEF:
+		return evt.CEF()
+	case EventFormatRFC5424:
+		return evt.RFC5424()
+	default:
+		return evt.PrintRAS()
+	}
+}
+
 // HandleClusterEvent extracts event field from protobuf request message and
 // converts to native event type.
 // The Event is then published to make available to locally subscribed consumers
@@ -0,0 +1,196 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CEFVendor is the device vendor reported in CEF records.
+	CEFVendor = "DAOS"
+	// SyslogAppName is the application name reported in RFC5424 messages.
+	SyslogAppName = "daos_server"
+	// syslogEnterpriseID is the IANA private enterprise number used to
+	// qualify the structured data IDs in RFC5424 messages (Intel Corporation).
+	syslogEnterpriseID = 343
+	// syslogNilValue is used in RFC5424 messages for header fields with no
+	// value.
+	syslogNilValue = "-"
+	// rfc5424Time is the RFC5424 timestamp layout, which limits fractional
+	// seconds to microseconds and requires a colon in the zone offset.
+	rfc5424Time = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// EventFormat identifies the format in which records are written to an event
+// sink.
+type EventFormat string
+
+// EventFormat constant definitions.
+const (
+	// EventFormatRAS matches the format of events logged by the engine.
+	EventFormatRAS EventFormat = "ras"
+	// EventFormatCEF is the ArcSight Common Event Format.
+	EventFormatCEF EventFormat = "cef"
+	// EventFormatRFC5424 is syslog with structured data as per RFC5424.
+	EventFormatRFC5424 EventFormat = "rfc5424"
+)
+
+// ParseEventFormat returns the EventFormat matching the supplied string. An
+// empty string selects the default RAS format.
+func ParseEventFormat(in string) (EventFormat, error) {
+	switch f := EventFormat(strings.ToLower(in)); f {
+	case "":
+		return EventFormatRAS, nil
+	case EventFormatRAS, EventFormatCEF, EventFormatRFC5424:
+		return f, nil
+	default:
+		return "", errors.Errorf("unknown event format %q (expected %s, %s or %s)",
+			in, EventFormatRAS, EventFormatCEF, EventFormatRFC5424)
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting any format name
+// understood by ParseEventFormat.
+func (f *EventFormat) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var in string
+	if err := unmarshal(&in); err != nil {
+		return err
+	}
+
+	format, err := ParseEventFormat(in)
+	if err != nil {
+		return err
+	}
+	*f = format
+
+	return nil
+}
+
+// SIEMField is a named value included in a CEF extension or in RFC5424
+// structured data. Fields with empty values are omitted.
+type SIEMField struct {
+	Name  string
+	Value string
+}
+
+// CEFRecord describes a record rendered in ArcSight Common Event Format.
+type CEFRecord struct {
+	Product     string
+	Version     string
+	SignatureID string
+	Name        string
+	Severity    int // 0 (lowest) to 10 (highest)
+	Extension   []SIEMField
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtEscaper    = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func (cr *CEFRecord) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|", CEFVendor,
+		cefHeaderEscaper.Replace(cr.Product),
+		cefHeaderEscaper.Replace(cr.Version),
+		cefHeaderEscaper.Replace(cr.SignatureID),
+		cefHeaderEscaper.Replace(cr.Name),
+		cr.Severity)
+
+	var sep string
+	for _, f := range cr.Extension {
+		if f.Value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s=%s", sep, f.Name, cefExtEscaper.Replace(f.Value))
+		sep = " "
+	}
+
+	return b.String()
+}
+
+// CEFTime returns the representation of a time used in CEF extensions,
+// milliseconds since the epoch.
+func CEFTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d", t.UnixNano()/int64(time.Millisecond))
+}
+
+// SyslogMessage describes a record rendered as an RFC5424 syslog message
+// with a single structured data element.
+type SyslogMessage struct {
+	Priority  syslog.Priority
+	Timestamp time.Time
+	Hostname  string
+	ProcID    string
+	MsgID     string
+	SDName    string // qualified with the enterprise number to form the SD-ID
+	SDParams  []SIEMField
+	Msg       string
+}
+
+var sdParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// syslogHeaderField returns the value or the nil value if empty. Header
+// fields may only contain printable ASCII without spaces.
+func syslogHeaderField(val string, maxLen int) string {
+	val = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, val)
+	if val == "" {
+		return syslogNilValue
+	}
+	if len(val) > maxLen {
+		val = val[:maxLen]
+	}
+	return val
+}
+
+func (sm *SyslogMessage) String() string {
+	var b strings.Builder
+
+	ts := syslogNilValue
+	if !sm.Timestamp.IsZero() {
+		ts = sm.Timestamp.Format(rfc5424Time)
+	}
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s ", sm.Priority, ts,
+		syslogHeaderField(sm.Hostname, 255),
+		syslogHeaderField(SyslogAppName, 48),
+		syslogHeaderField(sm.ProcID, 128),
+		syslogHeaderField(sm.MsgID, 32))
+
+	if sm.SDName == "" {
+		b.WriteString(syslogNilValue)
+	} else {
+		fmt.Fprintf(&b, "[%s@%d", sm.SDName, syslogEnterpriseID)
+		for _, p := range sm.SDParams {
+			if p.Value == "" {
+				continue
+			}
+			fmt.Fprintf(&b, " %s=\"%s\"", p.Name, sdParamEscaper.Replace(p.Value))
+		}
+		b.WriteString("]")
+	}
+
+	if sm.Msg != "" {
+		fmt.Fprintf(&b, " %s", sm.Msg)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package events
+
+import (
+	"log/syslog"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestEvents_ParseEventFormat(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in        string
+		expFormat EventFormat
+		expErr    error
+	}{
+		"default": {
+			expFormat: EventFormatRAS,
+		},
+		"cef": {
+			in:        "CEF",
+			expFormat: EventFormatCEF,
+		},
+		"rfc5424": {
+			in:        "rfc5424",
+			expFormat: EventFormatRFC5424,
+		},
+		"unknown": {
+			in:     "leef",
+			expErr: errors.New(`unknown event format "leef"`),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotFormat, gotErr := ParseEventFormat(tc.in)
+			common.CmpErr(t, tc.expErr, gotErr)
+			common.AssertEqual(t, tc.expFormat, gotFormat, "unexpected format")
+		})
+	}
+}
+
+func TestEvents_CEFRecord_String(t *testing.T) {
+	cr := &CEFRecord{
+		Product:     "daos|server",
+		Version:     "2.0.0",
+		SignatureID: "pool-create",
+		Name:        `a\b`,
+		Severity:    5,
+		Extension: []SIEMField{
+			{"rt", CEFTime(time.Unix(1622548800, 5e8))},
+			{"suser", ""},
+			{"msg", "key=value\nnext line"},
+		},
+	}
+
+	exp := `CEF:0|DAOS|daos\|server|2.0.0|pool-create|a\\b|5|rt=1622548800500 msg=key\=value\nnext line`
+	if diff := cmp.Diff(exp, cr.String()); diff != "" {
+		t.Fatalf("unexpected CEF record (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestEvents_SyslogMessage_String(t *testing.T) {
+	ts := time.Date(2021, 6, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60))
+
+	for name, tc := range map[string]struct {
+		msg    *SyslogMessage
+		expStr string
+	}{
+		"nil values": {
+			msg:    &SyslogMessage{Priority: syslog.LOG_NOTICE | syslog.LOG_DAEMON},
+			expStr: "<29>1 - - daos_server - - -",
+		},
+		"structured data": {
+			msg: &SyslogMessage{
+				Priority:  syslog.LOG_ERR | syslog.LOG_DAEMON,
+				Timestamp: ts,
+				Hostname:  "foo 1",
+				ProcID:    "1234",
+				MsgID:     "engine_died",
+				SDName:    "ras",
+				SDParams: []SIEMField{
+					{"rank", "0"},
+					{"pool", ""},
+					{"data", `say "hi" [\]`},
+				},
+				Msg: "DAOS engine 0 exited",
+			},
+			expStr: `<27>1 2021-06-01T12:00:00.000000+02:00 foo_1 daos_server 1234 engine_died [ras@343 rank="0" data="say \"hi\" [\\\]"] DAOS engine 0 exited`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expStr, tc.msg.String()); diff != "" {
+				t.Fatalf("unexpected syslog message (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestEvents_RASEvent_Format(t *testing.T) {
+	evt := NewEngineDiedEvent("foo", 0, 0, common.NormalExit, 1234)
+	evt.Timestamp = "2021-06-01T12:00:00.000000Z"
+
+	for name, tc := range map[string]struct {
+		format EventFormat
+		expStr string
+	}{
+		"ras": {
+			format: EventFormatRAS,
+			expStr: evt.PrintRAS(),
+		},
+		"cef": {
+			format: EventFormatCEF,
+			expStr: "CEF:0|DAOS|daos_server|unset|engine_died|DAOS engine 0 exited unexpectedly: process exited with 0|8|" +
+				"rt=1622548800000 dvchost=foo dvcpid=1234 cat=STATE_CHANGE cs1Label=rank cs1=0",
+		},
+		"rfc5424": {
+			format: EventFormatRFC5424,
+			expStr: "<27>1 2021-06-01T12:00:00.000000Z foo daos_server 1234 engine_died " +
+				`[ras@343 type="STATE_CHANGE" sev="ERROR" rank="0"] ` +
+				"DAOS engine 0 exited unexpectedly: process exited with 0",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expStr, evt.Format(tc.format)); diff != "" {
+				t.Fatalf("unexpected event output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -73,6 +73,7 @@ const (
 	ScmDiscoveryFailed
 	ScmDuplicatesInDeviceList
 	ScmNoDevicesMatchFilter
+	ScmRegionsGoalMismatch
 )
 
 // Bdev fault codes
@@ -84,12 +85,18 @@ const (
 	BdevPCIAddressNotFound
 	BdevDuplicatesInDeviceList
 	BdevNoDevicesMatchFilter
+	BdevFormatDeviceInUse
+	BdevFormatZonedNamespace
+	BdevFormatLbaUnsupported
 )
 
 // DAOS system fault codes
 const (
 	SystemUnknown Code = iota + 400
 	SystemBadFaultDomainDepth
+	SystemJoinDuplicateUUID
+	SystemJoinDuplicateFabricURI
+	SystemJoinDuplicateHostname
 )
 
 // client fault codes
@@ -103,6 +110,7 @@ const (
 	ClientConnectionRefused
 	ClientConnectionClosed
 	ClientFormatRunningSystem
+	ClientFormatAborted
 )
 
 // server fault codes
@@ -123,6 +131,19 @@ const (
 	ServerInstancesNotStopped
 	ServerConfigInvalidNetDevClass
 	ServerVfioDisabled
+	ServerDeviceOwnedByOtherEngine
+	ServerVfioNoIommu
+	ServerPoolInsufficientFaultDomains
+	ServerPoolProtected
+	ServerPoolDisabled
+	ServerCheckRunning
+	ServerBdevZonedNamespace
+	ServerConflictingProcesses
+	ServerPoolPropertyEnforced
+	ServerNoSpareEngine
+	ServerSystemEraseRanksRunning
+	ServerValidationHookDenied
+	ServerValidationHookFailed
 )
 
 // server config fault codes
@@ -149,6 +170,18 @@ const (
 	ServerConfigBothFaultPathAndCb
 	ServerConfigFaultCallbackEmpty
 	ServerConfigFaultDomainTooManyLayers
+	ServerConfigDiscoveryPluginNotFound
+	ServerConfigDiscoveryPluginInsecure
+	ServerConfigDiscoveryPluginBadPerms
+	ServerConfigDiscoveryPluginFailed
+	ServerConfigBadNvmeDriver
+	ServerConfigVirtualFunctionLinkDown
+	ServerConfigNoLogFile
+	ServerConfigValidationHookNotFound
+	ServerConfigValidationHookInsecure
+	ServerConfigValidationHookBadPerms
+	ServerConfigBadControlIface
+	ServerConfigBadTelemetryIface
 )
 
 // SPDK library bindings codes
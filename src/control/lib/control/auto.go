@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -29,6 +30,7 @@ const (
 	defaultTargetCount    = 16
 	defaultEngineLogFile  = "/tmp/daos_engine"
 	defaultControlLogFile = "/tmp/daos_server.log"
+	maxSSDTiers           = 3
 	// NetDevAny matches any netdetect network device class
 	NetDevAny = math.MaxUint32
 
@@ -38,7 +40,12 @@ const (
 	errInsufNrPMemGroups = "insufficient number of pmem device numa groups %v, want %d got %d"
 	errInvalNrEngines    = "unexpected number of engines requested, want %d got %d"
 	errInsufNrSSDs       = "insufficient number of ssds for numa %d, want %d got %d"
+	errTooManySSDTiers   = "ssds on numa %d have %d distinct capacities, max %d tiers supported"
 	errInvalNrCores      = "invalid number of cores for numa %d"
+	errInsufNrCores      = "insufficient cores remaining after reserving %d of %d, need at least 2"
+	errInsufTgtCores     = "%d ssds x %d targets per ssd requires %d cores, got %d available"
+	errNoExistingEngines = "existing config contains no engine sections"
+	errExistingNrEngines = "requested number of engines differs from existing config, want %d got %d"
 )
 
 type (
@@ -46,19 +53,37 @@ type (
 	ConfigGenerateReq struct {
 		unaryRequest
 		msRequest
-		NrEngines    int
-		MinNrSSDs    int
-		NetClass     uint32
-		Client       UnaryInvoker
-		HostList     []string
-		AccessPoints []string
-		Log          logging.Logger
+		NrEngines     int
+		MinNrSSDs     int
+		TargetsPerSSD int
+		ReserveCores  int
+		TierSSDs      bool
+		NetClass      uint32
+		Client        UnaryInvoker
+		HostList      []string
+		AccessPoints  []string
+		Log           logging.Logger
+		// SavedNetworkScan and SavedStorageScan, if set, contain the
+		// results of earlier scans to be used in place of scanning the
+		// hosts in HostList.
+		SavedNetworkScan *NetworkScanResp
+		SavedStorageScan *StorageScanResp
+		// ExistingConfig, if set, is the config already deployed on hosts
+		// running engines. The generated config will match its engine
+		// count and preserve its fabric ports and device assignments
+		// where the hardware on the new hosts allows.
+		ExistingConfig *config.Server
 	}
 
 	// ConfigGenerateResp contains the request response.
 	ConfigGenerateResp struct {
 		HostErrorsResp
 		ConfigOut *config.Server
+		// Report describes how calculated config values were derived.
+		Report []string
+		// Envelope is the estimated peak performance of the engines
+		// and of each host with the generated config.
+		Envelope *PerfEnvelope
 	}
 )
 
@@ -69,10 +94,28 @@ type (
 func ConfigGenerate(ctx context.Context, req ConfigGenerateReq) (*ConfigGenerateResp, error) {
 	req.Log.Debugf("ConfigGenerate called with request %+v", req)
 
-	if len(req.HostList) == 0 {
+	offline := req.SavedNetworkScan != nil || req.SavedStorageScan != nil
+	if offline && (req.SavedNetworkScan == nil || req.SavedStorageScan == nil) {
+		return nil, errors.New("both network and storage scan results required")
+	}
+	if !offline && len(req.HostList) == 0 {
 		return nil, errors.New("no hosts specified")
 	}
 
+	if req.ExistingConfig != nil {
+		nrEngines := len(req.ExistingConfig.Engines)
+		if nrEngines == 0 {
+			return nil, errors.New(errNoExistingEngines)
+		}
+		if req.NrEngines != 0 && req.NrEngines != nrEngines {
+			return nil, errors.Errorf(errExistingNrEngines, nrEngines, req.NrEngines)
+		}
+		req.NrEngines = nrEngines
+		if len(req.AccessPoints) == 0 {
+			req.AccessPoints = req.ExistingConfig.AccessPoints
+		}
+	}
+
 	if len(req.AccessPoints) == 0 {
 		return nil, errors.New("no access points specified")
 	}
@@ -87,7 +130,8 @@ func ConfigGenerate(ctx context.Context, req ConfigGenerateReq) (*ConfigGenerate
 		return checkHostErrors(hostErrs), err
 	}
 
-	ccs, err := getCPUDetails(req.Log, sd.numaSSDs, nd.numaCoreCount)
+	ccs, err := getCPUDetails(req.Log, sd.numaSSDs, nd.numaCoreCount,
+		req.TargetsPerSSD, req.ReserveCores)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +141,19 @@ func ConfigGenerate(ctx context.Context, req ConfigGenerateReq) (*ConfigGenerate
 		return nil, err
 	}
 
-	return &ConfigGenerateResp{ConfigOut: cfg}, nil
+	report := append(nd.report(), ccs.report()...)
+	if req.ExistingConfig != nil {
+		report = append(report, reconcileConfig(cfg, req.ExistingConfig, sd)...)
+		if err := cfg.Validate(req.Log); err != nil {
+			return nil, errors.Wrap(err, "reconciled config")
+		}
+	}
+
+	return &ConfigGenerateResp{
+		ConfigOut: cfg,
+		Report:    report,
+		Envelope:  estimatePerfEnvelope(cfg, nd, sd),
+	}, nil
 }
 
 func checkHostErrors(hes *HostErrorsResp) *ConfigGenerateResp {
@@ -108,18 +164,24 @@ func checkHostErrors(hes *HostErrorsResp) *ConfigGenerateResp {
 	return &ConfigGenerateResp{HostErrorsResp: *hes}
 }
 
-// getNetworkSet retrieves the result of network scan over host list and
-// verifies that there is only a single network set in response which indicates
-// that network hardware setup is homogeneous across all hosts.
+// getNetworkSet retrieves the result of network scan over host list, or the
+// saved scan results if supplied in the request, and verifies that there is
+// only a single network set in response which indicates that network hardware
+// setup is homogeneous across all hosts.
 //
 // Return host errors, network scan results for the host set or error.
-func getNetworkSet(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (*HostFabricSet, *HostErrorsResp, error) {
-	scanReq := new(NetworkScanReq)
-	scanReq.SetHostList(hostList)
-
-	scanResp, err := NetworkScan(ctx, client, scanReq)
-	if err != nil {
-		return nil, nil, err
+func getNetworkSet(ctx context.Context, req ConfigGenerateReq) (*HostFabricSet, *HostErrorsResp, error) {
+	log := req.Log
+	scanResp := req.SavedNetworkScan
+	if scanResp == nil {
+		scanReq := new(NetworkScanReq)
+		scanReq.SetHostList(req.HostList)
+
+		var err error
+		scanResp, err = NetworkScan(ctx, req.Client, scanReq)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	if len(scanResp.GetHostErrors()) > 0 {
@@ -211,6 +273,11 @@ func parseInterfaces(log logging.Logger, reqClass uint32, engineCount int, inter
 			continue // iface class unsupported
 		}
 
+		if iface.PhysFn != "" && iface.LinkState == nd.LinkStateDown {
+			log.Debugf("virtual function %s link is down, skipping", iface.Device)
+			continue
+		}
+
 		// init network device slice for a new provider
 		if _, exists := buckets[iface.Provider]; !exists {
 			buckets[iface.Provider] = make(classInterfaces)
@@ -255,6 +322,63 @@ type networkDetails struct {
 	engineCount   int
 	numaIfaces    numaNetIfaceMap
 	numaCoreCount int
+	hostLabels    map[string]map[string]string
+}
+
+// report returns a description of any site-specific interface aliases and
+// host labels reported by the hardware discovery plugin, and of any selected
+// interfaces that are SR-IOV virtual functions.
+func (nd *networkDetails) report() []string {
+	var lines []string
+
+	numaIDs := make([]int, 0, len(nd.numaIfaces))
+	for numaID := range nd.numaIfaces {
+		numaIDs = append(numaIDs, numaID)
+	}
+	sort.Ints(numaIDs)
+	for _, numaID := range numaIDs {
+		iface := nd.numaIfaces[numaID]
+		var notes []string
+		if iface.Alias != "" {
+			notes = append(notes, "alias "+iface.Alias)
+		}
+		if iface.PhysFn != "" {
+			notes = append(notes, "virtual function of "+iface.PhysFn)
+		}
+		if len(notes) > 0 {
+			lines = append(lines, fmt.Sprintf("numa %d: interface %s (%s)",
+				numaID, iface.Device, strings.Join(notes, ", ")))
+		}
+	}
+
+	hosts := make([]string, 0, len(nd.hostLabels))
+	for host := range nd.hostLabels {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		lines = append(lines, fmt.Sprintf("%s: %s", host,
+			formatLabels(nd.hostLabels[host])))
+	}
+
+	return lines
+}
+
+// formatLabels returns a string representation of the supplied labels sorted
+// by label name.
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, name+"="+labels[name])
+	}
+
+	return strings.Join(pairs, ", ")
 }
 
 // getNetworkDetails retrieves recommended network interfaces.
@@ -262,7 +386,7 @@ type networkDetails struct {
 // Returns map of NUMA node ID to chosen fabric interfaces, number of engines to
 // provide mappings for, per-NUMA core count and any host errors.
 func getNetworkDetails(ctx context.Context, req ConfigGenerateReq) (*networkDetails, *HostErrorsResp, error) {
-	netSet, hostErrs, err := getNetworkSet(ctx, req.Log, req.HostList, req.Client)
+	netSet, hostErrs, err := getNetworkSet(ctx, req)
 	if err != nil {
 		return nil, hostErrs, err
 	}
@@ -270,6 +394,7 @@ func getNetworkDetails(ctx context.Context, req ConfigGenerateReq) (*networkDeta
 	nd := &networkDetails{
 		engineCount:   req.NrEngines,
 		numaCoreCount: int(netSet.HostFabric.CoresPerNuma),
+		hostLabels:    netSet.HostLabels,
 	}
 	// set number of engines if unset based on number of NUMA nodes on hosts
 	if nd.engineCount == 0 {
@@ -290,20 +415,67 @@ func getNetworkDetails(ctx context.Context, req ConfigGenerateReq) (*networkDeta
 	return nd, nil, nil
 }
 
-// getStorageSet retrieves the result of storage scan over host list and
-// verifies that there is only a single storage set in response which indicates
-// that storage hardware setup is homogeneous across all hosts.
+// basicStorageScanResp regroups the host storage sets in a saved storage scan
+// response after removing NVMe details that are not returned in a scan with
+// the NvmeBasic flag set, so that saved results are coalesced as they would be
+// in a live scan.
+func basicStorageScanResp(in *StorageScanResp) (*StorageScanResp, error) {
+	out := &StorageScanResp{
+		HostErrorsResp: in.HostErrorsResp,
+		HostStorage:    make(HostStorageMap),
+	}
+
+	for _, hss := range in.HostStorage {
+		if hss == nil || hss.HostStorage == nil || hss.HostSet == nil {
+			return nil, errors.New("invalid host storage set in saved scan")
+		}
+
+		hs := *hss.HostStorage
+		hs.SmdInfo = nil
+		hs.ScmMountPoints = nil
+		hs.NvmeDevices = make(storage.NvmeControllers, 0, len(hss.HostStorage.NvmeDevices))
+		for _, nc := range hss.HostStorage.NvmeDevices {
+			basic := *nc
+			basic.Serial = ""
+			basic.Model = ""
+			basic.FwRev = ""
+			basic.HealthStats = nil
+			basic.SmdDevices = nil
+			hs.NvmeDevices = append(hs.NvmeDevices, &basic)
+		}
+
+		for _, host := range strings.Split(hss.HostSet.DerangedString(), ",") {
+			if err := out.HostStorage.Add(host, &hs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// getStorageSet retrieves the result of storage scan over host list, or the
+// saved scan results if supplied in the request, and verifies that there is
+// only a single storage set in response which indicates that storage hardware
+// setup is homogeneous across all hosts.
 //
 // Filter NVMe storage scan so only NUMA affinity and PCI address is taking into
 // account by supplying NvmeBasic flag in scan request. This enables
 // configuration to work with different combinations of SSD models.
 //
 // Return host errors, storage scan results for the host set or error.
-func getStorageSet(ctx context.Context, log logging.Logger, hostList []string, client UnaryInvoker) (*HostErrorsResp, *HostStorageSet, error) {
-	scanReq := &StorageScanReq{NvmeBasic: true}
-	scanReq.SetHostList(hostList)
-
-	scanResp, err := StorageScan(ctx, client, scanReq)
+func getStorageSet(ctx context.Context, req ConfigGenerateReq) (*HostErrorsResp, *HostStorageSet, error) {
+	log := req.Log
+	var scanResp *StorageScanResp
+	var err error
+	if req.SavedStorageScan != nil {
+		scanResp, err = basicStorageScanResp(req.SavedStorageScan)
+	} else {
+		scanReq := &StorageScanReq{NvmeBasic: true}
+		scanReq.SetHostList(req.HostList)
+
+		scanResp, err = StorageScan(ctx, req.Client, scanReq)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -361,9 +533,14 @@ func mapPMems(nss storage.ScmNamespaces) numaPMemsMap {
 type numaSSDsMap map[int]sort.StringSlice
 
 // mapSSDs maps NUMA node ID to NVMe SSD PCI addresses, sort addresses.
+// Controllers bound to the kernel NVMe driver are not usable by DAOS without
+// being prepared and are skipped.
 func mapSSDs(ssds storage.NvmeControllers) numaSSDsMap {
 	nssds := make(numaSSDsMap)
 	for _, ssd := range ssds {
+		if ssd.KernelBound() {
+			continue
+		}
 		nn := int(ssd.SocketID)
 		nssds[nn] = append(nssds[nn], ssd.PciAddr)
 	}
@@ -374,9 +551,48 @@ func mapSSDs(ssds storage.NvmeControllers) numaSSDsMap {
 	return nssds
 }
 
+// numaSSDTiersMap is an alias for a map of NUMA node ID to NVMe SSD PCI
+// addresses grouped by SSD capacity in ascending order of capacity.
+type numaSSDTiersMap map[int][]sort.StringSlice
+
+// mapSSDTiers maps NUMA node ID to NVMe SSD PCI addresses grouped by capacity,
+// smallest first, sort addresses within each group. Kernel-bound controllers
+// are skipped.
+func mapSSDTiers(ssds storage.NvmeControllers) numaSSDTiersMap {
+	numaCapSSDs := make(map[int]map[uint64]sort.StringSlice)
+	for _, ssd := range ssds {
+		if ssd.KernelBound() {
+			continue
+		}
+		nn := int(ssd.SocketID)
+		if _, exists := numaCapSSDs[nn]; !exists {
+			numaCapSSDs[nn] = make(map[uint64]sort.StringSlice)
+		}
+		numaCapSSDs[nn][ssd.Capacity()] = append(numaCapSSDs[nn][ssd.Capacity()], ssd.PciAddr)
+	}
+
+	nssdTiers := make(numaSSDTiersMap)
+	for nn, capSSDs := range numaCapSSDs {
+		caps := make([]uint64, 0, len(capSSDs))
+		for c := range capSSDs {
+			caps = append(caps, c)
+		}
+		sort.Slice(caps, func(i, j int) bool { return caps[i] < caps[j] })
+
+		for _, c := range caps {
+			capSSDs[c].Sort()
+			nssdTiers[nn] = append(nssdTiers[nn], capSSDs[c])
+		}
+	}
+
+	return nssdTiers
+}
+
 type storageDetails struct {
-	numaPMems numaPMemsMap
-	numaSSDs  numaSSDsMap
+	numaPMems    numaPMemsMap
+	numaSSDs     numaSSDsMap
+	numaSSDTiers numaSSDTiersMap
+	ssdLinks     map[string]*storage.NvmePciLink
 }
 
 // validate checks sufficient PMem devices and SSD NUMA groups exist for the
@@ -423,7 +639,7 @@ func getStorageDetails(ctx context.Context, req ConfigGenerateReq, engineCount i
 		return nil, nil, errors.Errorf(errInvalNrEngines, 1, engineCount)
 	}
 
-	hostErrs, storageSet, err := getStorageSet(ctx, req.Log, req.HostList, req.Client)
+	hostErrs, storageSet, err := getStorageSet(ctx, req)
 	if err != nil {
 		return nil, hostErrs, err
 	}
@@ -431,11 +647,26 @@ func getStorageDetails(ctx context.Context, req ConfigGenerateReq, engineCount i
 	sd := &storageDetails{
 		numaPMems: mapPMems(storageSet.HostStorage.ScmNamespaces),
 		numaSSDs:  mapSSDs(storageSet.HostStorage.NvmeDevices),
+		ssdLinks:  make(map[string]*storage.NvmePciLink),
+	}
+	for _, ssd := range storageSet.HostStorage.NvmeDevices {
+		sd.ssdLinks[ssd.PciAddr] = ssd.PciLink
 	}
 	if err := sd.validate(req.Log, engineCount, req.MinNrSSDs); err != nil {
 		return nil, nil, err
 	}
 
+	if req.TierSSDs && req.MinNrSSDs != 0 {
+		sd.numaSSDTiers = mapSSDTiers(storageSet.HostStorage.NvmeDevices)
+		for nn := 0; nn < engineCount; nn++ {
+			req.Log.Debugf("ssd tiers bound to numa %d: %v", nn, sd.numaSSDTiers[nn])
+			if len(sd.numaSSDTiers[nn]) > maxSSDTiers {
+				return nil, nil, errors.Errorf(errTooManySSDTiers, nn,
+					len(sd.numaSSDTiers[nn]), maxSSDTiers)
+			}
+		}
+	}
+
 	return sd, nil, nil
 }
 
@@ -458,56 +689,97 @@ func calcHelpers(log logging.Logger, targets, cores int) int {
 type coreCounts struct {
 	nrTgts  int
 	nrHlprs int
+	calc    string
 }
 
 // numaCoreCountsMap is an alias for a map of NUMA node ID to calculate target
 // and helper core counts.
 type numaCoreCountsMap map[int]*coreCounts
 
+// report returns a description of the core count calculation for each NUMA
+// node, ordered by node ID.
+func (nccm numaCoreCountsMap) report() []string {
+	numaIDs := make([]int, 0, len(nccm))
+	for numaID := range nccm {
+		numaIDs = append(numaIDs, numaID)
+	}
+	sort.Ints(numaIDs)
+
+	lines := make([]string, 0, len(numaIDs))
+	for _, numaID := range numaIDs {
+		lines = append(lines, fmt.Sprintf("numa %d: %s", numaID, nccm[numaID].calc))
+	}
+
+	return lines
+}
+
 // checkCPUs validates and returns recommended values for I/O service and
 // offload thread counts.
 //
 // The target count should be a multiplier of the number of SSDs and typically
 // daos gets the best performance with 16x targets per I/O Engine so target
-// count will typically be between 12 and 20.
+// count will typically be between 12 and 20. If a number of targets per SSD
+// is specified, the target count is instead fixed at that multiple of the
+// number of SSDs.
 //
 // Validate number of targets + 1 cores are available per IO engine, not
-// usually a problem as sockets normally have at least 18 cores.
+// usually a problem as sockets normally have at least 18 cores. Reserved
+// cores are excluded from those available to the engine.
 //
 // Create helper threads for the remaining available cores, e.g. with 24 cores,
 // allocate 7 helper threads. Number of helper threads should never be more than
 // number of targets.
-func checkCPUs(log logging.Logger, numSSDs, numaCoreCount int) (*coreCounts, error) {
+func checkCPUs(log logging.Logger, numSSDs, numaCoreCount, tgtsPerSSD, reserveCores int) (*coreCounts, error) {
+	availCores := numaCoreCount - reserveCores
+	if availCores < 2 {
+		return nil, errors.Errorf(errInsufNrCores, reserveCores, numaCoreCount)
+	}
+	calc := fmt.Sprintf("%d cores - %d reserved = %d available", numaCoreCount,
+		reserveCores, availCores)
+
 	var numTargets int
-	if numSSDs == 0 {
+	switch {
+	case numSSDs == 0:
 		numTargets = defaultTargetCount
-		if numTargets >= numaCoreCount {
-			return &coreCounts{
-				nrTgts:  numaCoreCount - 1,
-				nrHlprs: 0,
-			}, nil
+		if numTargets >= availCores {
+			numTargets = availCores - 1
+		}
+		calc += fmt.Sprintf("; no ssds, min(%d default, %d available - 1) = %d targets",
+			defaultTargetCount, availCores, numTargets)
+	case tgtsPerSSD > 0:
+		numTargets = numSSDs * tgtsPerSSD
+		if numTargets >= availCores {
+			return nil, errors.Errorf(errInsufTgtCores, numSSDs, tgtsPerSSD,
+				numTargets+1, availCores)
+		}
+		calc += fmt.Sprintf("; %d ssds x %d targets per ssd = %d targets",
+			numSSDs, tgtsPerSSD, numTargets)
+	default:
+		if numSSDs >= availCores {
+			return nil, errors.Errorf("need more cores than ssds, got %d want %d",
+				availCores, numSSDs)
 		}
 
-		return &coreCounts{
-			nrTgts:  numTargets,
-			nrHlprs: calcHelpers(log, numTargets, numaCoreCount),
-		}, nil
+		for tgts := numSSDs; tgts < availCores; tgts += numSSDs {
+			numTargets = tgts
+		}
+		calc += fmt.Sprintf("; largest multiple of %d ssds below %d available = %d targets",
+			numSSDs, availCores, numTargets)
 	}
 
-	if numSSDs >= numaCoreCount {
-		return nil, errors.Errorf("need more cores than ssds, got %d want %d",
-			numaCoreCount, numSSDs)
-	}
+	log.Debugf("%d targets assigned with %d ssds", numTargets, numSSDs)
 
-	for tgts := numSSDs; tgts < numaCoreCount; tgts += numSSDs {
-		numTargets = tgts
+	numHelpers := calcHelpers(log, numTargets, availCores)
+	calc += fmt.Sprintf("; %d available - %d targets - 1 = %d helpers",
+		availCores, numTargets, availCores-numTargets-1)
+	if numHelpers != availCores-numTargets-1 {
+		calc += fmt.Sprintf(" (capped at %d)", numHelpers)
 	}
 
-	log.Debugf("%d targets assigned with %d ssds", numTargets, numSSDs)
-
 	return &coreCounts{
 		nrTgts:  numTargets,
-		nrHlprs: calcHelpers(log, numTargets, numaCoreCount),
+		nrHlprs: numHelpers,
+		calc:    calc,
 	}, nil
 }
 
@@ -515,14 +787,18 @@ func checkCPUs(log logging.Logger, numSSDs, numaCoreCount int) (*coreCounts, err
 // threads suitable for the server config file.
 //
 // Returns core counts struct or error.
-func getCPUDetails(log logging.Logger, numaSSDs numaSSDsMap, coresPerNuma int) (numaCoreCountsMap, error) {
+func getCPUDetails(log logging.Logger, numaSSDs numaSSDsMap, coresPerNuma, tgtsPerSSD, reserveCores int) (numaCoreCountsMap, error) {
 	if coresPerNuma < 1 {
 		return nil, errors.Errorf(errInvalNrCores, coresPerNuma)
 	}
+	if tgtsPerSSD < 0 || reserveCores < 0 {
+		return nil, errors.Errorf("invalid targets per ssd (%d) or reserved cores (%d)",
+			tgtsPerSSD, reserveCores)
+	}
 
 	numaCoreCounts := make(numaCoreCountsMap)
 	for numaID, ssds := range numaSSDs {
-		coreCounts, err := checkCPUs(log, len(ssds), coresPerNuma)
+		coreCounts, err := checkCPUs(log, len(ssds), coresPerNuma, tgtsPerSSD, reserveCores)
 		if err != nil {
 			return nil, err
 		}
@@ -532,6 +808,26 @@ func getCPUDetails(log logging.Logger, numaSSDs numaSSDsMap, coresPerNuma int) (
 	return numaCoreCounts, nil
 }
 
+// bdevTiers returns block device storage tiers for the given groups of SSDs,
+// or nil if there is at most one group.
+func bdevTiers(groups []sort.StringSlice) []*storage.TierConfig {
+	if len(groups) < 2 {
+		return nil
+	}
+
+	tiers := make([]*storage.TierConfig, 0, len(groups))
+	for _, ssds := range groups {
+		tiers = append(tiers, &storage.TierConfig{
+			Bdev: storage.BdevConfig{
+				Class:      storage.BdevClassNvme,
+				DeviceList: ssds,
+			},
+		})
+	}
+
+	return tiers
+}
+
 func defaultEngineCfg(idx int) *engine.Config {
 	return engine.NewConfig().
 		WithTargetCount(defaultTargetCount).
@@ -572,6 +868,13 @@ func genConfig(log logging.Logger, accessPoints []string, nd *networkDetails, sd
 			WithTargetCount(ccs[nn].nrTgts).
 			WithHelperStreamCount(ccs[nn].nrHlprs)
 
+		if tiers := bdevTiers(sd.numaSSDTiers[nn]); len(tiers) > 0 {
+			scmCfg := engineCfg.Storage.SCM()
+			engineCfg.WithStorage(append([]*storage.TierConfig{
+				{Scm: scmCfg},
+			}, tiers...)...)
+		}
+
 		pnn := uint(nn)
 		engineCfg.Fabric = engine.FabricConfig{
 			Provider:       nd.numaIfaces[nn].Provider,
@@ -591,3 +894,71 @@ func genConfig(log logging.Logger, accessPoints []string, nd *networkDetails, sd
 
 	return cfg, cfg.Validate(log)
 }
+
+// missingDevices returns the devices in the supplied list that are not found
+// in any of the NUMA groups.
+func missingDevices(devices []string, groups map[int]sort.StringSlice) []string {
+	found := make(map[string]bool)
+	for _, group := range groups {
+		for _, dev := range group {
+			found[dev] = true
+		}
+	}
+
+	var missing []string
+	for _, dev := range devices {
+		if !found[dev] {
+			missing = append(missing, dev)
+		}
+	}
+
+	return missing
+}
+
+// reconcileConfig updates a generated config so that it is consistent with
+// the config already deployed on hosts running engines, enabling new hosts to
+// be added to an existing system. The system name, control port and engine
+// fabric ports are always taken from the existing config. Engine storage and
+// target assignments are taken from the existing config if all of the
+// referenced devices are present on the new hosts.
+//
+// Returns report lines describing the decisions made.
+func reconcileConfig(cfg, existing *config.Server, sd *storageDetails) []string {
+	var report []string
+
+	cfg.WithSystemName(existing.SystemName)
+	cfg.ControlPort = existing.ControlPort
+
+	for idx, engineCfg := range cfg.Engines {
+		if idx >= len(existing.Engines) {
+			break
+		}
+		exCfg := existing.Engines[idx]
+
+		engineCfg.Fabric.InterfacePort = exCfg.Fabric.InterfacePort
+		if exCfg.Fabric.Interface != engineCfg.Fabric.Interface {
+			report = append(report, fmt.Sprintf(
+				"engine %d: fabric interface %s differs from existing %s",
+				idx, engineCfg.Fabric.Interface, exCfg.Fabric.Interface))
+		}
+
+		missing := missingDevices(exCfg.Storage.SCM().DeviceList, sd.numaPMems)
+		missing = append(missing,
+			missingDevices(exCfg.Storage.Tiers.NvmeDevices(), sd.numaSSDs)...)
+		if len(missing) != 0 {
+			report = append(report, fmt.Sprintf(
+				"engine %d: devices %s not found, storage assignment regenerated",
+				idx, strings.Join(missing, ",")))
+			continue
+		}
+
+		engineCfg.Storage = exCfg.Storage
+		engineCfg.TargetCount = exCfg.TargetCount
+		engineCfg.HelperStreamCount = exCfg.HelperStreamCount
+		report = append(report, fmt.Sprintf(
+			"engine %d: storage assignment and %d targets preserved",
+			idx, exCfg.TargetCount))
+	}
+
+	return report
+}
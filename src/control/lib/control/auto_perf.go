@@ -0,0 +1,206 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	// default PCIe link assumed for SSDs whose link was not reported
+	defaultSSDLinkSpeed = 8 // GT/s
+	defaultSSDLinkWidth = 4
+	// maxSSDIOPS caps the 4KiB random read IOPS estimated for a single SSD
+	// from its link bandwidth.
+	maxSSDIOPS = 1000000
+	// ioSize is the I/O size used to derive IOPS from bandwidth.
+	ioSize = 4096
+	// imbalanceFactor is the ratio between the estimated storage and network
+	// bandwidth of an engine above which the engine is reported unbalanced.
+	imbalanceFactor = 2
+)
+
+// EnginePerfEnvelope is the estimated peak performance of a generated engine
+// config, derived from the PCIe links of its SSDs and the speed of its
+// fabric interface.
+type EnginePerfEnvelope struct {
+	Index        int
+	NrSSDs       int
+	SSDBandwidth uint64 // bytes/s
+	SSDIOPS      uint64
+	Interface    string
+	NetBandwidth uint64 // bytes/s, zero if link speed unknown
+	Bandwidth    uint64 // bytes/s
+	IOPS         uint64
+}
+
+// PerfEnvelope is the estimated peak performance of the engines and the host
+// described by a generated config. Estimates are upper bounds intended to
+// highlight unbalanced configurations rather than to predict performance.
+type PerfEnvelope struct {
+	Engines       []*EnginePerfEnvelope
+	HostBandwidth uint64 // bytes/s
+	HostIOPS      uint64
+	Warnings      []string
+}
+
+// pciLinkBandwidth returns the usable bandwidth in bytes/s of a PCIe link
+// after line encoding overhead.
+func pciLinkBandwidth(link *storage.NvmePciLink) uint64 {
+	speed, width := float64(defaultSSDLinkSpeed), float64(defaultSSDLinkWidth)
+	if link != nil && link.Speed > 0 && link.Width > 0 {
+		speed, width = link.Speed, float64(link.Width)
+	}
+
+	// PCIe gen 1 and 2 use 8b/10b encoding, later generations 128b/130b
+	encoding := 128.0 / 130.0
+	if speed <= 5 {
+		encoding = 8.0 / 10.0
+	}
+
+	return uint64(speed * 1e9 * encoding * width / 8)
+}
+
+func estimateSSDIOPS(bandwidth uint64) uint64 {
+	iops := bandwidth / ioSize
+	if iops > maxSSDIOPS {
+		return maxSSDIOPS
+	}
+	return iops
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// estimatePerfEnvelope estimates the peak bandwidth and IOPS of each engine
+// in the generated config as the lesser of the aggregate capability of its
+// SSDs and the bandwidth of its fabric interface. Host totals count each
+// fabric interface once if it is shared by engines.
+func estimatePerfEnvelope(cfg *config.Server, nd *networkDetails, sd *storageDetails) *PerfEnvelope {
+	ifaceSpeeds := make(map[string]uint32)
+	for _, iface := range nd.numaIfaces {
+		ifaceSpeeds[iface.Device] = iface.LinkSpeed
+	}
+
+	pe := new(PerfEnvelope)
+	var ssdBandwidth, ssdIOPS uint64
+	netBandwidth := make(map[string]uint64)
+	netKnown := true
+	for idx, engineCfg := range cfg.Engines {
+		epe := &EnginePerfEnvelope{
+			Index:        idx,
+			Interface:    engineCfg.Fabric.Interface,
+			NetBandwidth: uint64(ifaceSpeeds[engineCfg.Fabric.Interface]) * 1e6 / 8,
+		}
+		for _, ssd := range engineCfg.Storage.Tiers.NvmeDevices() {
+			bw := pciLinkBandwidth(sd.ssdLinks[ssd])
+			epe.NrSSDs++
+			epe.SSDBandwidth += bw
+			epe.SSDIOPS += estimateSSDIOPS(bw)
+		}
+
+		epe.Bandwidth, epe.IOPS = epe.SSDBandwidth, epe.SSDIOPS
+		if epe.NetBandwidth != 0 {
+			epe.Bandwidth = minUint64(epe.Bandwidth, epe.NetBandwidth)
+			epe.IOPS = minUint64(epe.IOPS, epe.NetBandwidth/ioSize)
+			netBandwidth[epe.Interface] = epe.NetBandwidth
+		} else {
+			netKnown = false
+		}
+
+		ssdBandwidth += epe.SSDBandwidth
+		ssdIOPS += epe.SSDIOPS
+		pe.Engines = append(pe.Engines, epe)
+		pe.Warnings = append(pe.Warnings, epe.warnings()...)
+	}
+
+	pe.HostBandwidth, pe.HostIOPS = ssdBandwidth, ssdIOPS
+	if netKnown {
+		var total uint64
+		for _, bw := range netBandwidth {
+			total += bw
+		}
+		pe.HostBandwidth = minUint64(pe.HostBandwidth, total)
+		pe.HostIOPS = minUint64(pe.HostIOPS, total/ioSize)
+	}
+
+	return pe
+}
+
+func formatBandwidth(bw uint64) string {
+	return humanize.Bytes(bw) + "/s"
+}
+
+func formatIOPS(iops uint64) string {
+	switch {
+	case iops >= 1e6:
+		return fmt.Sprintf("%.1fM IOPS", float64(iops)/1e6)
+	case iops >= 1e3:
+		return fmt.Sprintf("%.1fK IOPS", float64(iops)/1e3)
+	}
+	return fmt.Sprintf("%d IOPS", iops)
+}
+
+func (epe *EnginePerfEnvelope) warnings() []string {
+	if epe.NrSSDs == 0 || epe.NetBandwidth == 0 {
+		return nil
+	}
+
+	switch {
+	case epe.SSDBandwidth > epe.NetBandwidth*imbalanceFactor:
+		return []string{fmt.Sprintf("engine %d: %d SSDs (%s) exceed the bandwidth of %s (%s) "+
+			"by %.1fx, the engine is network bound", epe.Index, epe.NrSSDs,
+			formatBandwidth(epe.SSDBandwidth), epe.Interface,
+			formatBandwidth(epe.NetBandwidth),
+			float64(epe.SSDBandwidth)/float64(epe.NetBandwidth))}
+	case epe.NetBandwidth > epe.SSDBandwidth*imbalanceFactor:
+		return []string{fmt.Sprintf("engine %d: bandwidth of %s (%s) exceeds %d SSDs (%s) "+
+			"by %.1fx, the engine is storage bound", epe.Index, epe.Interface,
+			formatBandwidth(epe.NetBandwidth), epe.NrSSDs,
+			formatBandwidth(epe.SSDBandwidth),
+			float64(epe.NetBandwidth)/float64(epe.SSDBandwidth))}
+	}
+
+	return nil
+}
+
+// Report returns a description of the estimated performance envelope of each
+// engine and of the host, followed by any imbalance warnings.
+func (pe *PerfEnvelope) Report() []string {
+	if pe == nil {
+		return nil
+	}
+
+	var lines []string
+	for _, epe := range pe.Engines {
+		net := "unknown speed"
+		if epe.NetBandwidth != 0 {
+			net = formatBandwidth(epe.NetBandwidth)
+		}
+		lines = append(lines, fmt.Sprintf("engine %d: %d SSDs %s %s, %s %s, "+
+			"estimated peak %s %s", epe.Index, epe.NrSSDs,
+			formatBandwidth(epe.SSDBandwidth), formatIOPS(epe.SSDIOPS),
+			epe.Interface, net, formatBandwidth(epe.Bandwidth), formatIOPS(epe.IOPS)))
+	}
+	lines = append(lines, fmt.Sprintf("host: estimated peak %s %s",
+		formatBandwidth(pe.HostBandwidth), formatIOPS(pe.HostIOPS)))
+
+	for _, w := range pe.Warnings {
+		lines = append(lines, "WARNING: "+w)
+	}
+
+	return lines
+}
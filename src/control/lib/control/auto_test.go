@@ -8,12 +8,15 @@ package control
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
@@ -62,6 +65,14 @@ var (
 	eth1 = &HostFabricInterface{
 		Provider: "ofi+sockets", Device: "eth1", NumaNode: 1, NetDevClass: 1, Priority: 3,
 	}
+	ib0v1 = &HostFabricInterface{
+		Provider: "ofi+psm2", Device: "ib0v1", NumaNode: 0, NetDevClass: 32, Priority: 0,
+		PhysFn: "ib0", LinkState: nd.LinkStateUp,
+	}
+	ib0v2 = &HostFabricInterface{
+		Provider: "ofi+psm2", Device: "ib0v2", NumaNode: 0, NetDevClass: 32, Priority: 0,
+		PhysFn: "ib0", LinkState: nd.LinkStateDown,
+	}
 )
 
 func cmpHostErrs(t *testing.T, expErrs []*MockHostError, gotErrs *HostErrorsResp) {
@@ -105,6 +116,18 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 	if err := convert.Types(eth1, eth1PB); err != nil {
 		t.Fatal(err)
 	}
+	ib0rPB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib0r, ib0rPB); err != nil {
+		t.Fatal(err)
+	}
+	ib0v1PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib0v1, ib0v1PB); err != nil {
+		t.Fatal(err)
+	}
+	ib0v2PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib0v2, ib0v2PB); err != nil {
+		t.Fatal(err)
+	}
 	fabIfs1 := &ctlpb.NetworkScanResp{Interfaces: []*ctlpb.FabricInterface{if1PB, if2PB}}
 	fabIfs1wNuma := &ctlpb.NetworkScanResp{
 		Interfaces: []*ctlpb.FabricInterface{if1PB, if2PB}, Numacount: 2,
@@ -119,6 +142,12 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 	fabIfs5 := &ctlpb.NetworkScanResp{
 		Interfaces: []*ctlpb.FabricInterface{eth0PB, eth1PB}, Numacount: 2, Corespernuma: 24,
 	}
+	fabIfs6 := &ctlpb.NetworkScanResp{
+		Interfaces: []*ctlpb.FabricInterface{ib0v1PB, ib0rPB}, Numacount: 2, Corespernuma: 24,
+	}
+	fabIfs7 := &ctlpb.NetworkScanResp{
+		Interfaces: []*ctlpb.FabricInterface{ib0v2PB, ib0rPB}, Numacount: 2, Corespernuma: 24,
+	}
 	hostRespRemoteFail := []*HostResponse{
 		{Addr: "host1", Message: fabIfs1},
 		{Addr: "host2", Error: errors.New("remote failed"), Message: fabIfs1}}
@@ -286,6 +315,18 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 			expIfs:          []*HostFabricInterface{ib0r, ib1r},
 			expCoresPerNuma: 24,
 		},
+		"single engine set with virtual function": {
+			engineCount:     1,
+			hostResponses:   dualHostRespSame(fabIfs6),
+			expIfs:          []*HostFabricInterface{ib0v1},
+			expCoresPerNuma: 24,
+		},
+		"single engine set with virtual function link down": {
+			engineCount:     1,
+			hostResponses:   dualHostRespSame(fabIfs7),
+			expIfs:          []*HostFabricInterface{ib0r},
+			expCoresPerNuma: 24,
+		},
 		"dual engine single ib dual eth": {
 			hostResponses:   dualHostRespSame(sinIbFabIfs),
 			expIfs:          []*HostFabricInterface{eth0, eth1},
@@ -336,6 +377,55 @@ func TestControl_AutoConfig_getNetworkDetails(t *testing.T) {
 	}
 }
 
+func TestControl_AutoConfig_networkDetails_report(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nd        *networkDetails
+		expReport []string
+	}{
+		"no aliases or labels": {
+			nd: &networkDetails{
+				numaIfaces: numaNetIfaceMap{0: ib0, 1: ib1},
+			},
+		},
+		"aliases and labels": {
+			nd: &networkDetails{
+				numaIfaces: numaNetIfaceMap{
+					0: &HostFabricInterface{Device: "ib0", Alias: "hsn0"},
+					1: &HostFabricInterface{Device: "ib1", Alias: "hsn1"},
+				},
+				hostLabels: map[string]map[string]string{
+					"host2": {"rack": "r2", "asset_tag": "A2"},
+					"host1": {"rack": "r1", "asset_tag": "A1"},
+				},
+			},
+			expReport: []string{
+				"numa 0: interface ib0 (alias hsn0)",
+				"numa 1: interface ib1 (alias hsn1)",
+				"host1: asset_tag=A1, rack=r1",
+				"host2: asset_tag=A2, rack=r2",
+			},
+		},
+		"virtual functions": {
+			nd: &networkDetails{
+				numaIfaces: numaNetIfaceMap{
+					0: &HostFabricInterface{Device: "ib0v1", Alias: "hsn0", PhysFn: "ib0"},
+					1: &HostFabricInterface{Device: "ib1v1", PhysFn: "ib1"},
+				},
+			},
+			expReport: []string{
+				"numa 0: interface ib0v1 (alias hsn0, virtual function of ib0)",
+				"numa 1: interface ib1v1 (virtual function of ib1)",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expReport, tc.nd.report()); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 	dualHostResp := func(r1, r2 string) []*HostResponse {
 		return []*HostResponse{
@@ -422,7 +512,7 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			hostResponses: hostRespWithScmNs,
 			expErr: errors.Errorf(errInsufNrPMemGroups,
 				numaPMemsMap{0: []string{
-					engineCfgWithSSDs(t, 0).Storage.SCM.DeviceList[0],
+					engineCfgWithSSDs(t, 0).Storage.SCM().DeviceList[0],
 				}}, 2, 1),
 		},
 		"dual engine dual pmems zero ssds": {
@@ -440,8 +530,8 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			hostResponses: hostRespWithScmNssNumaZero,
 			expErr: errors.Errorf(errInsufNrPMemGroups,
 				numaPMemsMap{0: []string{
-					engineCfgWithSSDs(t, 0).Storage.SCM.DeviceList[0],
-					engineCfgWithSSDs(t, 1).Storage.SCM.DeviceList[0],
+					engineCfgWithSSDs(t, 0).Storage.SCM().DeviceList[0],
+					engineCfgWithSSDs(t, 1).Storage.SCM().DeviceList[0],
 				}}, 2, 1),
 		},
 		"single min ssd single ctrlr on single numa node": {
@@ -453,12 +543,12 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			engineCount:   2,
 			hostResponses: hostRespWithSSDs,
 			expPMems: [][]string{
-				engineCfgWithSSDs(t, 0).Storage.SCM.DeviceList,
-				engineCfgWithSSDs(t, 1).Storage.SCM.DeviceList,
+				engineCfgWithSSDs(t, 0).Storage.SCM().DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.SCM().DeviceList,
 			},
 			expSSDs: [][]string{
-				engineCfgWithSSDs(t, 0).Storage.Bdev.DeviceList,
-				engineCfgWithSSDs(t, 1).Storage.Bdev.DeviceList,
+				engineCfgWithSSDs(t, 0).Storage.Tiers.BdevDevices(),
+				engineCfgWithSSDs(t, 1).Storage.Tiers.BdevDevices(),
 			},
 		},
 		"dual min ssd multiple ctrlrs on dual numa nodes": {
@@ -466,12 +556,12 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			minSSDs:       2,
 			hostResponses: hostRespWithSSDs,
 			expPMems: [][]string{
-				engineCfgWithSSDs(t, 0).Storage.SCM.DeviceList,
-				engineCfgWithSSDs(t, 1).Storage.SCM.DeviceList,
+				engineCfgWithSSDs(t, 0).Storage.SCM().DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.SCM().DeviceList,
 			},
 			expSSDs: [][]string{
-				engineCfgWithSSDs(t, 0).Storage.Bdev.DeviceList,
-				engineCfgWithSSDs(t, 1).Storage.Bdev.DeviceList,
+				engineCfgWithSSDs(t, 0).Storage.Tiers.BdevDevices(),
+				engineCfgWithSSDs(t, 1).Storage.Tiers.BdevDevices(),
 			},
 		},
 		"zero min ssd multiple ctrlrs on dual numa nodes": {
@@ -479,8 +569,8 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 			disableNVMe:   true,
 			hostResponses: hostRespWithSSDs,
 			expPMems: [][]string{
-				engineCfgWithSSDs(t, 0).Storage.SCM.DeviceList,
-				engineCfgWithSSDs(t, 1).Storage.SCM.DeviceList,
+				engineCfgWithSSDs(t, 0).Storage.SCM().DeviceList,
+				engineCfgWithSSDs(t, 1).Storage.SCM().DeviceList,
 			},
 			expSSDs: [][]string{{}, {}},
 		},
@@ -540,6 +630,84 @@ func TestControl_AutoConfig_getStorageDetails(t *testing.T) {
 	}
 }
 
+func TestControl_AutoConfig_mapSSDTiers(t *testing.T) {
+	ssd := func(idx, numa int32, tb uint64) *storage.NvmeController {
+		return &storage.NvmeController{
+			PciAddr:  common.MockPCIAddr(idx),
+			SocketID: numa,
+			Namespaces: []*storage.NvmeNamespace{
+				{Size: tb * uint64(humanize.TByte)},
+			},
+		}
+	}
+
+	kernelBound := func(c *storage.NvmeController) *storage.NvmeController {
+		c.BindState = storage.NvmeBindStateKernel
+		return c
+	}
+
+	for name, tc := range map[string]struct {
+		ssds     storage.NvmeControllers
+		expTiers numaSSDTiersMap
+	}{
+		"no ssds": {
+			expTiers: numaSSDTiersMap{},
+		},
+		"single capacity": {
+			ssds: storage.NvmeControllers{ssd(2, 0, 4), ssd(1, 0, 4)},
+			expTiers: numaSSDTiersMap{
+				0: {common.MockPCIAddrs(1, 2)},
+			},
+		},
+		"multiple capacities on dual numa nodes": {
+			ssds: storage.NvmeControllers{
+				ssd(1, 0, 8), ssd(2, 0, 1), ssd(3, 0, 16), ssd(4, 0, 8),
+				ssd(5, 1, 16), ssd(6, 1, 1),
+			},
+			expTiers: numaSSDTiersMap{
+				0: {
+					common.MockPCIAddrs(2),
+					common.MockPCIAddrs(1, 4),
+					common.MockPCIAddrs(3),
+				},
+				1: {
+					common.MockPCIAddrs(6),
+					common.MockPCIAddrs(5),
+				},
+			},
+		},
+		"kernel-bound ssds skipped": {
+			ssds: storage.NvmeControllers{
+				ssd(1, 0, 4), kernelBound(ssd(2, 0, 4)), kernelBound(ssd(3, 1, 8)),
+			},
+			expTiers: numaSSDTiersMap{
+				0: {common.MockPCIAddrs(1)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotTiers := mapSSDTiers(tc.ssds)
+
+			if diff := cmp.Diff(tc.expTiers, gotTiers); diff != "" {
+				t.Fatalf("unexpected ssd tiers (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_mapSSDs(t *testing.T) {
+	kernelBound := storage.MockNvmeController(6)
+	kernelBound.BindState = storage.NvmeBindStateKernel
+
+	gotSSDs := mapSSDs(storage.NvmeControllers{
+		storage.MockNvmeController(4), kernelBound, storage.MockNvmeController(2),
+	})
+	expSSDs := numaSSDsMap{0: {"0000:80:00.2", "0000:80:00.4"}}
+	if diff := cmp.Diff(expSSDs, gotSSDs); diff != "" {
+		t.Fatalf("unexpected ssds (-want, +got):\n%s\n", diff)
+	}
+}
+
 func TestControl_AutoConfig_getCPUDetails(t *testing.T) {
 	for name, tc := range map[string]struct {
 		numaCoreCount int   // physical cores per NUMA node
@@ -595,7 +763,121 @@ func TestControl_AutoConfig_getCPUDetails(t *testing.T) {
 				}
 			}
 
-			nccs, gotErr := getCPUDetails(log, numaSSDs, tc.numaCoreCount)
+			nccs, gotErr := getCPUDetails(log, numaSSDs, tc.numaCoreCount, 0, 0)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			for nn := range numaSSDs {
+				if diff := cmp.Diff(tc.expTgtCounts[nn], nccs[nn].nrTgts); diff != "" {
+					t.Fatalf("unexpected target counts (-want, +got):\n%s\n", diff)
+				}
+				if diff := cmp.Diff(tc.expHlprCounts[nn], nccs[nn].nrHlprs); diff != "" {
+					t.Fatalf("unexpected helper counts (-want, +got):\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_getCPUDetails_options(t *testing.T) {
+	for name, tc := range map[string]struct {
+		numaCoreCount int
+		ssdListSizes  []int
+		tgtsPerSSD    int
+		reserveCores  int
+		expTgtCounts  []int
+		expHlprCounts []int
+		expReport     []string
+		expErr        error
+	}{
+		"negative targets per ssd": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			tgtsPerSSD:    -1,
+			expErr:        errors.New("invalid targets per ssd"),
+		},
+		"all cores reserved": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			reserveCores:  23,
+			expErr:        errors.Errorf(errInsufNrCores, 23, 24),
+		},
+		"24 cores 4 ssds; no options": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			expTgtCounts:  []int{20},
+			expHlprCounts: []int{3},
+			expReport: []string{
+				"numa 0: 24 cores - 0 reserved = 24 available; " +
+					"largest multiple of 4 ssds below 24 available = 20 targets; " +
+					"24 available - 20 targets - 1 = 3 helpers",
+			},
+		},
+		"24 cores 4 ssds; 2 reserved": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			reserveCores:  2,
+			expTgtCounts:  []int{20},
+			expHlprCounts: []int{1},
+		},
+		"24 cores 4 ssds; 4 reserved": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			reserveCores:  4,
+			expTgtCounts:  []int{16},
+			expHlprCounts: []int{3},
+		},
+		"24 cores 4:2 ssds; 4 targets per ssd": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4, 2},
+			tgtsPerSSD:    4,
+			expTgtCounts:  []int{16, 8},
+			expHlprCounts: []int{7, 7},
+			expReport: []string{
+				"numa 0: 24 cores - 0 reserved = 24 available; " +
+					"4 ssds x 4 targets per ssd = 16 targets; " +
+					"24 available - 16 targets - 1 = 7 helpers",
+				"numa 1: 24 cores - 0 reserved = 24 available; " +
+					"2 ssds x 4 targets per ssd = 8 targets; " +
+					"24 available - 8 targets - 1 = 15 helpers (capped at 7)",
+			},
+		},
+		"24 cores 4 ssds; 6 targets per ssd": {
+			numaCoreCount: 24,
+			ssdListSizes:  []int{4},
+			tgtsPerSSD:    6,
+			expErr:        errors.Errorf(errInsufTgtCores, 4, 6, 25, 24),
+		},
+		"18 cores no ssds; 4 reserved": {
+			numaCoreCount: 18,
+			ssdListSizes:  []int{0},
+			tgtsPerSSD:    4,
+			reserveCores:  4,
+			expTgtCounts:  []int{13},
+			expHlprCounts: []int{0},
+			expReport: []string{
+				"numa 0: 18 cores - 4 reserved = 14 available; " +
+					"no ssds, min(16 default, 14 available - 1) = 13 targets; " +
+					"14 available - 13 targets - 1 = 0 helpers",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			numaSSDs := make(numaSSDsMap)
+			for nn, count := range tc.ssdListSizes {
+				numaSSDs[nn] = []string{}
+				for i := 0; i < count; i++ {
+					numaSSDs[nn] = append(numaSSDs[nn], common.MockPCIAddr(int32(i)))
+				}
+			}
+
+			nccs, gotErr := getCPUDetails(log, numaSSDs, tc.numaCoreCount,
+				tc.tgtsPerSSD, tc.reserveCores)
 			common.CmpErr(t, tc.expErr, gotErr)
 			if tc.expErr != nil {
 				return
@@ -609,6 +891,13 @@ func TestControl_AutoConfig_getCPUDetails(t *testing.T) {
 					t.Fatalf("unexpected helper counts (-want, +got):\n%s\n", diff)
 				}
 			}
+
+			if tc.expReport == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expReport, nccs.report()); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
 		})
 	}
 }
@@ -627,6 +916,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 		accessPoints   []string          // list of access point host/ip addresses
 		numaPMems      numaPMemsMap      // numa to pmem mappings
 		numaSSDs       numaSSDsMap       // numa to ssds mappings
+		numaSSDTiers   numaSSDTiersMap   // numa to ssd tiers mappings
 		numaIfaces     numaNetIfaceMap   // numa to network interface mappings
 		numaCoreCounts numaCoreCountsMap // numa to cpu mappings
 		expCfg         *config.Server    // expected config generated
@@ -643,7 +933,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10001").WithEngines(
 				defaultEngineCfg(0).
 					WithFabricInterface("ib0").
@@ -660,7 +950,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10002").WithEngines(
 				defaultEngineCfg(0).
 					WithFabricInterface("ib0").
@@ -677,7 +967,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expErr:         config.FaultConfigBadControlPort,
 		},
 		"access point ip with valid port": {
@@ -686,7 +976,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expCfg: baseConfig("ofi+psm2").WithAccessPoints("192.168.1.1:10002").WithEngines(
 				defaultEngineCfg(0).
 					WithFabricInterface("ib0").
@@ -703,7 +993,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expErr:         config.FaultConfigBadControlPort,
 		},
 		"single pmem single ssd": {
@@ -712,7 +1002,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 			numaPMems:      numaPMemsMap{0: []string{"/dev/pmem0"}},
 			numaIfaces:     numaNetIfaceMap{0: ib0},
 			numaSSDs:       numaSSDsMap{0: []string{common.MockPCIAddr(1)}},
-			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{16, 7}},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 16, nrHlprs: 7}},
 			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10002").WithEngines(
 				defaultEngineCfg(0).
 					WithFabricInterface("ib0").
@@ -733,7 +1023,7 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 				0: common.MockPCIAddrs(0, 1, 2, 3), 1: common.MockPCIAddrs(4, 5, 6),
 			},
 			numaCoreCounts: numaCoreCountsMap{
-				0: &coreCounts{16, 7}, 1: &coreCounts{15, 6},
+				0: &coreCounts{nrTgts: 16, nrHlprs: 7}, 1: &coreCounts{nrTgts: 15, nrHlprs: 6},
 			},
 			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10002").WithEngines(
 				defaultEngineCfg(0).
@@ -757,6 +1047,40 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 					WithTargetCount(15).
 					WithHelperStreamCount(6)),
 		},
+		"single pmem tiered ssds": {
+			engineCount:  1,
+			accessPoints: []string{"hostX:10002"},
+			numaPMems:    numaPMemsMap{0: []string{"/dev/pmem0"}},
+			numaIfaces:   numaNetIfaceMap{0: ib0},
+			numaSSDs:     numaSSDsMap{0: common.MockPCIAddrs(0, 1, 2)},
+			numaSSDTiers: numaSSDTiersMap{
+				0: {common.MockPCIAddrs(0), common.MockPCIAddrs(1, 2)},
+			},
+			numaCoreCounts: numaCoreCountsMap{0: &coreCounts{nrTgts: 15, nrHlprs: 7}},
+			expCfg: baseConfig("ofi+psm2").WithAccessPoints("hostX:10002").WithEngines(
+				defaultEngineCfg(0).
+					WithFabricInterface("ib0").
+					WithFabricInterfacePort(defaultFiPort).
+					WithFabricProvider("ofi+psm2").
+					WithPinnedNumaNode(&numa0).
+					WithStorage(
+						&storage.TierConfig{Scm: storage.ScmConfig{
+							Class:      storage.ScmClassDCPM,
+							MountPoint: "/mnt/daos0",
+							DeviceList: []string{"/dev/pmem0"},
+						}},
+						&storage.TierConfig{Bdev: storage.BdevConfig{
+							Class:      storage.BdevClassNvme,
+							DeviceList: common.MockPCIAddrs(0),
+						}},
+						&storage.TierConfig{Bdev: storage.BdevConfig{
+							Class:      storage.BdevClassNvme,
+							DeviceList: common.MockPCIAddrs(1, 2),
+						}},
+					).
+					WithTargetCount(15).
+					WithHelperStreamCount(7)),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -767,8 +1091,9 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 				numaIfaces:  tc.numaIfaces,
 			}
 			sd := &storageDetails{
-				numaPMems: tc.numaPMems,
-				numaSSDs:  tc.numaSSDs,
+				numaPMems:    tc.numaPMems,
+				numaSSDs:     tc.numaSSDs,
+				numaSSDTiers: tc.numaSSDTiers,
 			}
 
 			gotCfg, gotErr := genConfig(log, tc.accessPoints, nd, sd, tc.numaCoreCounts)
@@ -781,6 +1106,8 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 				cmpopts.IgnoreUnexported(security.CertificateConfig{},
 					config.Server{}),
 				cmpopts.IgnoreFields(config.Server{}, "GetDeviceClassFn"),
+				// populated from the server config on validation
+				cmpopts.IgnoreFields(engine.StorageConfig{}, "ControlMetadata"),
 			}
 			cmpOpts = append(cmpOpts, defResCmpOpts()...)
 
@@ -790,3 +1117,390 @@ func TestControl_AutoConfig_genConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_AutoConfig_ConfigGenerate_saved(t *testing.T) {
+	ib0PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib0, ib0PB); err != nil {
+		t.Fatal(err)
+	}
+	ib1PB := new(ctlpb.FabricInterface)
+	if err := convert.Types(ib1, ib1PB); err != nil {
+		t.Fatal(err)
+	}
+	netPB := &ctlpb.NetworkScanResp{
+		Interfaces: []*ctlpb.FabricInterface{ib0PB, ib1PB}, Numacount: 2, Corespernuma: 24,
+	}
+	storPB := MockServerScanResp(t, "nvmeA")
+	// differs only in details not considered when generating a config
+	storPBOtherModel := MockServerScanResp(t, "nvmeA")
+	storPBOtherModel.Nvme.Ctrlrs[0].Model = "other-model"
+	storPBOtherModel.Nvme.Ctrlrs[0].FwRev = "other-fwrev"
+	storPBOtherSSDs := MockServerScanResp(t, "nvmeB")
+
+	dualHostResp := func(m1, m2 proto.Message) *UnaryResponse {
+		return &UnaryResponse{
+			Responses: []*HostResponse{
+				{Addr: "host1", Message: m1},
+				{Addr: "host2", Message: m2},
+			},
+		}
+	}
+
+	// saveAndLoad simulates the results of a scan being saved to and then
+	// loaded from a file in JSON format.
+	saveAndLoad := func(t *testing.T, in, out interface{}) {
+		t.Helper()
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		netResp     *UnaryResponse
+		storResp    *UnaryResponse
+		noNetwork   bool
+		expErr      error
+		expHostErrs []*MockHostError
+	}{
+		"missing network scan": {
+			storResp:  dualHostResp(storPB, storPB),
+			noNetwork: true,
+			expErr:    errors.New("both network and storage"),
+		},
+		"homogeneous hardware": {
+			netResp:  dualHostResp(netPB, netPB),
+			storResp: dualHostResp(storPB, storPB),
+		},
+		"different ssd models": {
+			netResp:  dualHostResp(netPB, netPB),
+			storResp: dualHostResp(storPB, storPBOtherModel),
+		},
+		"heterogeneous storage": {
+			netResp:  dualHostResp(netPB, netPB),
+			storResp: dualHostResp(storPB, storPBOtherSSDs),
+			expErr:   errors.New("storage hardware not consistent"),
+		},
+		"network scan host errors": {
+			netResp: &UnaryResponse{
+				Responses: []*HostResponse{
+					{Addr: "host1", Message: netPB},
+					{Addr: "host2", Error: errors.New("remote failed")},
+				},
+			},
+			storResp:    dualHostResp(storPB, storPB),
+			expErr:      errors.New("1 host had errors"),
+			expHostErrs: []*MockHostError{{"host2", "remote failed"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ctx := context.TODO()
+			req := ConfigGenerateReq{
+				MinNrSSDs:    1,
+				NetClass:     NetDevAny,
+				AccessPoints: []string{"localhost"},
+				Log:          log,
+				// no RPCs should be issued when using saved results
+				Client: NewMockInvoker(log, &MockInvokerConfig{
+					UnaryError: errors.New("unexpected RPC"),
+				}),
+			}
+
+			req.SavedStorageScan = new(StorageScanResp)
+			storResp, err := StorageScan(ctx, NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.storResp,
+			}), &StorageScanReq{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			saveAndLoad(t, storResp, req.SavedStorageScan)
+
+			if !tc.noNetwork {
+				req.SavedNetworkScan = new(NetworkScanResp)
+				netResp, err := NetworkScan(ctx, NewMockInvoker(log, &MockInvokerConfig{
+					UnaryResponse: tc.netResp,
+				}), &NetworkScanReq{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				saveAndLoad(t, netResp, req.SavedNetworkScan)
+			}
+
+			gotResp, gotErr := ConfigGenerate(ctx, req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expHostErrs != nil {
+				cmpHostErrs(t, tc.expHostErrs, &gotResp.HostErrorsResp)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			// the generated config should match that from a live scan
+			// of hosts with identical hardware
+			liveReq := req
+			liveReq.SavedNetworkScan = nil
+			liveReq.SavedStorageScan = nil
+			liveReq.HostList = []string{"host1", "host2"}
+			liveReq.Client = NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					dualHostResp(netPB, netPB),
+					dualHostResp(storPB, storPB),
+				},
+			})
+			expResp, err := ConfigGenerate(ctx, liveReq)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreUnexported(security.CertificateConfig{}, config.Server{}),
+				cmpopts.IgnoreFields(config.Server{}, "GetDeviceClassFn"),
+			}
+			if diff := cmp.Diff(expResp.ConfigOut, gotResp.ConfigOut, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected config (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_reconcileConfig(t *testing.T) {
+	genCfg := func() *config.Server {
+		return config.DefaultServer().WithEngines(
+			defaultEngineCfg(0).
+				WithFabricInterface("ib0").
+				WithFabricInterfacePort(defaultFiPort).
+				WithScmDeviceList("/dev/pmem0").
+				WithScmMountPoint("/mnt/daos0").
+				WithBdevDeviceList(common.MockPCIAddr(1)).
+				WithTargetCount(16),
+			defaultEngineCfg(1).
+				WithFabricInterface("ib1").
+				WithFabricInterfacePort(defaultFiPort+defaultFiPortInterval).
+				WithScmDeviceList("/dev/pmem1").
+				WithScmMountPoint("/mnt/daos1").
+				WithBdevDeviceList(common.MockPCIAddr(2)).
+				WithTargetCount(16))
+	}
+	sd := &storageDetails{
+		numaPMems: numaPMemsMap{0: {"/dev/pmem0"}, 1: {"/dev/pmem1"}},
+		numaSSDs:  numaSSDsMap{0: {common.MockPCIAddr(1)}, 1: {common.MockPCIAddr(2)}},
+	}
+
+	for name, tc := range map[string]struct {
+		existing  *config.Server
+		expCfg    *config.Server
+		expReport []string
+	}{
+		"matching hardware": {
+			existing: config.DefaultServer().
+				WithSystemName("prod").
+				WithControlPort(10005).
+				WithEngines(
+					defaultEngineCfg(0).
+						WithFabricInterface("ib0").
+						WithFabricInterfacePort(20000).
+						WithScmDeviceList("/dev/pmem0").
+						WithScmMountPoint("/mnt/daos_a").
+						WithBdevDeviceList(common.MockPCIAddr(1)).
+						WithTargetCount(8).
+						WithHelperStreamCount(1),
+					defaultEngineCfg(1).
+						WithFabricInterface("ib1").
+						WithFabricInterfacePort(21000).
+						WithScmDeviceList("/dev/pmem1").
+						WithScmMountPoint("/mnt/daos_b").
+						WithBdevDeviceList(common.MockPCIAddr(2)).
+						WithTargetCount(8).
+						WithHelperStreamCount(1)),
+			expCfg: func() *config.Server {
+				cfg := genCfg().WithSystemName("prod").WithControlPort(10005)
+				cfg.Engines[0].WithFabricInterfacePort(20000).
+					WithScmMountPoint("/mnt/daos_a").
+					WithTargetCount(8).
+					WithHelperStreamCount(1)
+				cfg.Engines[1].WithFabricInterfacePort(21000).
+					WithScmMountPoint("/mnt/daos_b").
+					WithTargetCount(8).
+					WithHelperStreamCount(1)
+				return cfg
+			}(),
+			expReport: []string{
+				"engine 0: storage assignment and 8 targets preserved",
+				"engine 1: storage assignment and 8 targets preserved",
+			},
+		},
+		"devices and interface differ": {
+			existing: config.DefaultServer().
+				WithEngines(
+					defaultEngineCfg(0).
+						WithFabricInterface("eth0").
+						WithFabricInterfacePort(20000).
+						WithScmDeviceList("/dev/pmem0").
+						WithScmMountPoint("/mnt/daos0").
+						WithBdevDeviceList(common.MockPCIAddr(7)).
+						WithTargetCount(8),
+					defaultEngineCfg(1).
+						WithFabricInterface("ib1").
+						WithFabricInterfacePort(21000).
+						WithScmDeviceList("/dev/pmem3").
+						WithScmMountPoint("/mnt/daos1").
+						WithBdevDeviceList(common.MockPCIAddr(2)).
+						WithTargetCount(8)),
+			expCfg: func() *config.Server {
+				cfg := genCfg()
+				cfg.Engines[0].WithFabricInterfacePort(20000)
+				cfg.Engines[1].WithFabricInterfacePort(21000)
+				return cfg
+			}(),
+			expReport: []string{
+				"engine 0: fabric interface ib0 differs from existing eth0",
+				fmt.Sprintf("engine 0: devices %s not found, storage assignment regenerated",
+					common.MockPCIAddr(7)),
+				"engine 1: devices /dev/pmem3 not found, storage assignment regenerated",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotCfg := genCfg()
+			gotReport := reconcileConfig(gotCfg, tc.existing, sd)
+
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreUnexported(security.CertificateConfig{}, config.Server{}),
+				cmpopts.IgnoreFields(config.Server{}, "GetDeviceClassFn"),
+			}
+			if diff := cmp.Diff(tc.expCfg, gotCfg, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected config (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expReport, gotReport); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_AutoConfig_ConfigGenerate_existing(t *testing.T) {
+	for name, tc := range map[string]struct {
+		existing *config.Server
+		nrEngs   int
+		expErr   error
+	}{
+		"no engines in existing config": {
+			existing: config.DefaultServer(),
+			expErr:   errors.New(errNoExistingEngines),
+		},
+		"engine count mismatch": {
+			existing: config.DefaultServer().WithEngines(defaultEngineCfg(0)),
+			nrEngs:   2,
+			expErr:   errors.Errorf(errExistingNrEngines, 1, 2),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			_, gotErr := ConfigGenerate(context.TODO(), ConfigGenerateReq{
+				NrEngines:      tc.nrEngs,
+				HostList:       []string{"host1"},
+				AccessPoints:   []string{"localhost"},
+				ExistingConfig: tc.existing,
+				Log:            log,
+				Client: NewMockInvoker(log, &MockInvokerConfig{
+					UnaryError: errors.New("unexpected RPC"),
+				}),
+			})
+			common.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestControl_AutoConfig_estimatePerfEnvelope(t *testing.T) {
+	ssds := func(prefix string, n int) []string {
+		addrs := make([]string, n)
+		for i := range addrs {
+			addrs[i] = fmt.Sprintf("0000:%s:%02x.0", prefix, i)
+		}
+		return addrs
+	}
+	engineCfg := func(iface string, ssds []string) *engine.Config {
+		ec := engine.NewConfig().WithBdevClass(storage.BdevClassNvme.String()).
+			WithBdevDeviceList(ssds...)
+		ec.Fabric.Interface = iface
+		return ec
+	}
+	netDetails := func(speeds ...uint32) *networkDetails {
+		nd := &networkDetails{numaIfaces: make(numaNetIfaceMap)}
+		for nn, speed := range speeds {
+			nd.numaIfaces[nn] = &HostFabricInterface{
+				Device: fmt.Sprintf("eth%d", nn), LinkSpeed: speed,
+			}
+		}
+		return nd
+	}
+
+	for name, tc := range map[string]struct {
+		engineCfgs []*engine.Config
+		nd         *networkDetails
+		ssdLinks   map[string]*storage.NvmePciLink
+		expReport  []string
+	}{
+		"many ssds behind one slow link": {
+			engineCfgs: []*engine.Config{engineCfg("eth0", ssds("81", 14))},
+			nd:         netDetails(25000),
+			expReport: []string{
+				"engine 0: 14 SSDs 55 GB/s 13.5M IOPS, eth0 3.1 GB/s, estimated peak 3.1 GB/s 762.9K IOPS",
+				"host: estimated peak 3.1 GB/s 762.9K IOPS",
+				"WARNING: engine 0: 14 SSDs (55 GB/s) exceed the bandwidth of eth0 (3.1 GB/s) by 17.6x, the engine is network bound",
+			},
+		},
+		"balanced engines": {
+			engineCfgs: []*engine.Config{
+				engineCfg("eth0", ssds("81", 2)),
+				engineCfg("eth1", ssds("da", 2)),
+			},
+			nd: netDetails(100000, 100000),
+			expReport: []string{
+				"engine 0: 2 SSDs 7.9 GB/s 1.9M IOPS, eth0 12 GB/s, estimated peak 7.9 GB/s 1.9M IOPS",
+				"engine 1: 2 SSDs 7.9 GB/s 1.9M IOPS, eth1 12 GB/s, estimated peak 7.9 GB/s 1.9M IOPS",
+				"host: estimated peak 16 GB/s 3.8M IOPS",
+			},
+		},
+		"degraded ssd links; storage bound": {
+			engineCfgs: []*engine.Config{engineCfg("eth0", ssds("81", 2))},
+			nd:         netDetails(100000),
+			ssdLinks: map[string]*storage.NvmePciLink{
+				"0000:81:00.0": {Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4},
+				"0000:81:01.0": {Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4},
+			},
+			expReport: []string{
+				"engine 0: 2 SSDs 2.0 GB/s 480.8K IOPS, eth0 12 GB/s, estimated peak 2.0 GB/s 480.8K IOPS",
+				"host: estimated peak 2.0 GB/s 480.8K IOPS",
+				"WARNING: engine 0: bandwidth of eth0 (12 GB/s) exceeds 2 SSDs (2.0 GB/s) by 6.3x, the engine is storage bound",
+			},
+		},
+		"unknown link speed": {
+			engineCfgs: []*engine.Config{engineCfg("eth0", ssds("81", 14))},
+			nd:         netDetails(0),
+			expReport: []string{
+				"engine 0: 14 SSDs 55 GB/s 13.5M IOPS, eth0 unknown speed, estimated peak 55 GB/s 13.5M IOPS",
+				"host: estimated peak 55 GB/s 13.5M IOPS",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultServer().WithEngines(tc.engineCfgs...)
+			sd := &storageDetails{ssdLinks: tc.ssdLinks}
+
+			pe := estimatePerfEnvelope(cfg, tc.nd, sd)
+			if diff := cmp.Diff(tc.expReport, pe.Report()); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
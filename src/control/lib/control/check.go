@@ -0,0 +1,179 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+const (
+	// CheckStatusRunning indicates that a check is in progress.
+	CheckStatusRunning = "running"
+	// FindingStatusPending indicates that a finding has not been repaired
+	// or ignored.
+	FindingStatusPending = "pending"
+	// CheckActionIgnore is the repair action which leaves an inconsistency
+	// in place.
+	CheckActionIgnore = "ignore"
+)
+
+// CheckFinding describes an inconsistency between the system metadata held
+// by the MS, pool services and container services, along with the repair
+// actions that may be taken.
+type CheckFinding struct {
+	ID          uint64   `json:"id"`
+	Class       string   `json:"class"`
+	PoolUUID    string   `json:"pool_uuid"`
+	PoolLabel   string   `json:"pool_label"`
+	Description string   `json:"description"`
+	Actions     []string `json:"actions"`
+	Suggested   string   `json:"suggested"`
+	Status      string   `json:"status"`
+	Action      string   `json:"action"`
+	Result      string   `json:"result"`
+}
+
+// CheckStartReq contains the parameters for a system check request.
+type CheckStartReq struct {
+	unaryRequest
+	msRequest
+	Pools []string // UUIDs or labels of pools to check, all if empty
+}
+
+// CheckStartResp contains the identifier of the check run that was started.
+type CheckStartResp struct {
+	RunID uint64 `json:"run_id"`
+}
+
+// CheckStart starts a check of the consistency of the system metadata held
+// by the MS against the pool and container services. The check runs in the
+// background; its progress and findings are returned by CheckQuery.
+func CheckStart(ctx context.Context, rpcClient UnaryInvoker, req *CheckStartReq) (*CheckStartResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.CheckStartReq{
+		Sys:   req.getSystem(rpcClient),
+		Pools: req.Pools,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).CheckStart(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system check start request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(CheckStartResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// CheckQueryReq contains the parameters for a system check query request.
+type CheckQueryReq struct {
+	unaryRequest
+	msRequest
+}
+
+// CheckQueryResp contains the status and findings of the most recent
+// system check.
+type CheckQueryResp struct {
+	RunID        uint64          `json:"run_id"`
+	Status       string          `json:"status"`
+	PoolsChecked uint32          `json:"pools_checked"`
+	PoolsTotal   uint32          `json:"pools_total"`
+	Started      time.Time       `json:"started"`
+	Finished     time.Time       `json:"finished"`
+	Findings     []*CheckFinding `json:"findings"`
+	Error        string          `json:"error"`
+}
+
+// Pending returns the findings which have not been repaired or ignored.
+func (resp *CheckQueryResp) Pending() []*CheckFinding {
+	var pending []*CheckFinding
+	for _, f := range resp.Findings {
+		if f.Status == FindingStatusPending {
+			pending = append(pending, f)
+		}
+	}
+	return pending
+}
+
+// CheckQuery queries the status and findings of the most recent system check.
+func CheckQuery(ctx context.Context, rpcClient UnaryInvoker, req *CheckQueryReq) (*CheckQueryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.CheckQueryReq{
+		Sys: req.getSystem(rpcClient),
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).CheckQuery(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system check query request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(CheckQueryResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// CheckRepairReq contains the parameters for a system check repair request.
+type CheckRepairReq struct {
+	unaryRequest
+	msRequest
+	Finding uint64 // Identifier of the finding to repair
+	Action  string // Repair action to take, the suggested action if empty
+}
+
+// CheckRepairResp contains the finding updated with the repair outcome.
+type CheckRepairResp struct {
+	Finding *CheckFinding `json:"finding"`
+}
+
+// CheckRepair takes a repair action for a finding of the most recent system
+// check. The MS verifies that the inconsistency is still present before
+// repairing it.
+func CheckRepair(ctx context.Context, rpcClient UnaryInvoker, req *CheckRepairReq) (*CheckRepairResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.Finding == 0 {
+		return nil, errors.New("no finding specified")
+	}
+
+	pbReq := &mgmtpb.CheckRepairReq{
+		Sys:     req.getSystem(rpcClient),
+		Finding: req.Finding,
+		Action:  req.Action,
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).CheckRepair(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system check repair request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(CheckRepairResp)
+	return resp, convertMSResponse(ur, resp)
+}
@@ -0,0 +1,230 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_CheckStart(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *CheckStartReq
+		uResp   *UnaryResponse
+		expResp *CheckStartResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.CheckStartReq request"),
+		},
+		"remote failure": {
+			req:    new(CheckStartReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"success": {
+			req:     &CheckStartReq{Pools: []string{"tank"}},
+			uResp:   MockMSResponse("host1", nil, &mgmtpb.CheckStartResp{RunId: 3}),
+			expResp: &CheckStartResp{RunID: 3},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := CheckStart(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_CheckQuery(t *testing.T) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req        *CheckQueryReq
+		uResp      *UnaryResponse
+		expResp    *CheckQueryResp
+		expPending int
+		expErr     error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.CheckQueryReq request"),
+		},
+		"remote failure": {
+			req:    new(CheckQueryReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"no check run": {
+			req:     new(CheckQueryReq),
+			uResp:   MockMSResponse("host1", nil, &mgmtpb.CheckQueryResp{}),
+			expResp: &CheckQueryResp{},
+		},
+		"running": {
+			req: new(CheckQueryReq),
+			uResp: MockMSResponse("host1", nil, &mgmtpb.CheckQueryResp{
+				RunId:        1,
+				Status:       CheckStatusRunning,
+				PoolsChecked: 1,
+				PoolsTotal:   2,
+				Started:      started.Format(time.RFC3339),
+				Findings: []*mgmtpb.CheckFinding{
+					{
+						Id:          1,
+						Class:       "pool-svc-missing",
+						PoolUuid:    common.MockUUID(1),
+						PoolLabel:   "tank",
+						Description: "pool entry in the MS has no corresponding pool service",
+						Actions:     []string{"remove-pool-entry", CheckActionIgnore},
+						Suggested:   "remove-pool-entry",
+						Status:      FindingStatusPending,
+					},
+					{
+						Id:        2,
+						Class:     "cont-metadata",
+						PoolUuid:  common.MockUUID(2),
+						Actions:   []string{CheckActionIgnore},
+						Suggested: CheckActionIgnore,
+						Status:    "ignored",
+						Action:    CheckActionIgnore,
+						Result:    "finding ignored",
+					},
+				},
+			}),
+			expResp: &CheckQueryResp{
+				RunID:        1,
+				Status:       CheckStatusRunning,
+				PoolsChecked: 1,
+				PoolsTotal:   2,
+				Started:      started,
+				Findings: []*CheckFinding{
+					{
+						ID:          1,
+						Class:       "pool-svc-missing",
+						PoolUUID:    common.MockUUID(1),
+						PoolLabel:   "tank",
+						Description: "pool entry in the MS has no corresponding pool service",
+						Actions:     []string{"remove-pool-entry", CheckActionIgnore},
+						Suggested:   "remove-pool-entry",
+						Status:      FindingStatusPending,
+					},
+					{
+						ID:        2,
+						Class:     "cont-metadata",
+						PoolUUID:  common.MockUUID(2),
+						Actions:   []string{CheckActionIgnore},
+						Suggested: CheckActionIgnore,
+						Status:    "ignored",
+						Action:    CheckActionIgnore,
+						Result:    "finding ignored",
+					},
+				},
+			},
+			expPending: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := CheckQuery(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expPending, len(gotResp.Pending()), "unexpected pending findings")
+		})
+	}
+}
+
+func TestControl_CheckRepair(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *CheckRepairReq
+		uResp   *UnaryResponse
+		expResp *CheckRepairResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.CheckRepairReq request"),
+		},
+		"no finding": {
+			req:    new(CheckRepairReq),
+			expErr: errors.New("no finding"),
+		},
+		"remote failure": {
+			req:    &CheckRepairReq{Finding: 1},
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"success": {
+			req: &CheckRepairReq{Finding: 1, Action: CheckActionIgnore},
+			uResp: MockMSResponse("host1", nil, &mgmtpb.CheckRepairResp{
+				Finding: &mgmtpb.CheckFinding{
+					Id:     1,
+					Status: "ignored",
+					Action: CheckActionIgnore,
+					Result: "finding ignored",
+				},
+			}),
+			expResp: &CheckRepairResp{
+				Finding: &CheckFinding{
+					ID:     1,
+					Status: "ignored",
+					Action: CheckActionIgnore,
+					Result: "finding ignored",
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := CheckRepair(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
 )
 
 const (
@@ -28,6 +29,9 @@ type Config struct {
 	ControlPort     int                       `yaml:"port"`
 	HostList        []string                  `yaml:"hostlist"`
 	TransportConfig *security.TransportConfig `yaml:"transport_config"`
+	Grpc            *config.GrpcConfig        `yaml:"grpc,omitempty"`
+	CommandPolicy   string                    `yaml:"command_policy,omitempty"`
+	MessageCatalog  string                    `yaml:"message_catalog,omitempty"`
 	Path            string                    `yaml:"-"`
 }
 
@@ -83,6 +87,9 @@ func LoadConfig(cfgPath string) (*Config, error) {
 	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Grpc.Validate(); err != nil {
+		return nil, err
+	}
 	cfg.Path = cfgPath
 
 	return cfg, nil
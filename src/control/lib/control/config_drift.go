@@ -0,0 +1,103 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"sort"
+
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type (
+	// ConfigHashHosts lists the hosts whose config has the given hash.
+	ConfigHashHosts struct {
+		Hash    string            `json:"hash"`
+		Hosts   *hostlist.HostSet `json:"hosts"`
+		Drifted bool              `json:"drifted"`
+	}
+
+	// ConfigDriftReport describes the configs reported by the hosts of a
+	// system and whether they deviate from the expected config.
+	ConfigDriftReport struct {
+		// Expected is the hash of the reference config if supplied,
+		// otherwise that of the config held by most hosts. It is empty
+		// if no config is held by more hosts than any other.
+		Expected  string             `json:"expected"`
+		Reference bool               `json:"reference"`
+		Configs   []*ConfigHashHosts `json:"configs"`
+		// DriftedHosts lists the hosts whose config deviates from the
+		// expected config.
+		DriftedHosts *hostlist.HostSet `json:"drifted_hosts"`
+		// Unknown lists the hosts which have not reported a config hash.
+		Unknown *hostlist.HostSet `json:"unknown"`
+	}
+)
+
+// NewConfigDriftReport builds a report of the config hashes reported by the
+// hosts of the given members. Hosts are compared with the reference hash if
+// supplied, otherwise with the hash held by the majority of hosts.
+func NewConfigDriftReport(members system.Members, refHash string) (*ConfigDriftReport, error) {
+	cdr := &ConfigDriftReport{
+		Expected:     refHash,
+		Reference:    refHash != "",
+		DriftedHosts: hostlist.MustCreateSet(""),
+		Unknown:      hostlist.MustCreateSet(""),
+	}
+
+	seen := make(map[string]struct{})
+	found := make(map[string]*hostlist.HostSet)
+	for _, m := range members {
+		addr := m.Addr.String()
+		if _, exists := seen[addr]; exists {
+			continue
+		}
+		seen[addr] = struct{}{}
+
+		hosts := cdr.Unknown
+		if m.ConfigHash != "" {
+			if _, exists := found[m.ConfigHash]; !exists {
+				found[m.ConfigHash] = hostlist.MustCreateSet("")
+			}
+			hosts = found[m.ConfigHash]
+		}
+		if _, err := hosts.Insert(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	for hash, hosts := range found {
+		cdr.Configs = append(cdr.Configs, &ConfigHashHosts{
+			Hash:  hash,
+			Hosts: hosts,
+		})
+	}
+	sort.Slice(cdr.Configs, func(i, j int) bool {
+		ci, cj := cdr.Configs[i], cdr.Configs[j]
+		if ci.Hosts.Count() != cj.Hosts.Count() {
+			return ci.Hosts.Count() > cj.Hosts.Count()
+		}
+		return ci.Hash < cj.Hash
+	})
+
+	if !cdr.Reference && len(cdr.Configs) > 0 {
+		if len(cdr.Configs) == 1 || cdr.Configs[0].Hosts.Count() > cdr.Configs[1].Hosts.Count() {
+			cdr.Expected = cdr.Configs[0].Hash
+		}
+	}
+	for _, chh := range cdr.Configs {
+		chh.Drifted = chh.Hash != cdr.Expected
+		if !chh.Drifted {
+			continue
+		}
+		if err := cdr.DriftedHosts.MergeSet(chh.Hosts); err != nil {
+			return nil, err
+		}
+	}
+
+	return cdr, nil
+}
@@ -0,0 +1,110 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_NewConfigDriftReport(t *testing.T) {
+	member := func(idx uint32, hash string) *system.Member {
+		m := system.MockMember(t, idx, system.MemberStateJoined)
+		m.ConfigHash = hash
+		return m
+	}
+
+	for name, tc := range map[string]struct {
+		members   system.Members
+		refHash   string
+		expReport *ConfigDriftReport
+	}{
+		"no members": {
+			expReport: &ConfigDriftReport{
+				DriftedHosts: hostlist.MustCreateSet(""),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+		},
+		"no drift": {
+			members: system.Members{
+				member(1, "aaaa"), member(2, "aaaa"),
+			},
+			expReport: &ConfigDriftReport{
+				Expected: "aaaa",
+				Configs: []*ConfigHashHosts{
+					{Hash: "aaaa", Hosts: hostlist.MustCreateSet("127.0.0.[1-2]:10001")},
+				},
+				DriftedHosts: hostlist.MustCreateSet(""),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+		},
+		"drift from majority": {
+			members: system.Members{
+				member(1, "aaaa"), member(2, "bbbb"), member(3, "aaaa"),
+				member(4, ""),
+			},
+			expReport: &ConfigDriftReport{
+				Expected: "aaaa",
+				Configs: []*ConfigHashHosts{
+					{Hash: "aaaa", Hosts: hostlist.MustCreateSet("127.0.0.[1,3]:10001")},
+					{Hash: "bbbb", Hosts: hostlist.MustCreateSet("127.0.0.2:10001"), Drifted: true},
+				},
+				DriftedHosts: hostlist.MustCreateSet("127.0.0.2:10001"),
+				Unknown:      hostlist.MustCreateSet("127.0.0.4:10001"),
+			},
+		},
+		"no majority": {
+			members: system.Members{
+				member(1, "aaaa"), member(2, "bbbb"),
+			},
+			expReport: &ConfigDriftReport{
+				Configs: []*ConfigHashHosts{
+					{Hash: "aaaa", Hosts: hostlist.MustCreateSet("127.0.0.1:10001"), Drifted: true},
+					{Hash: "bbbb", Hosts: hostlist.MustCreateSet("127.0.0.2:10001"), Drifted: true},
+				},
+				DriftedHosts: hostlist.MustCreateSet("127.0.0.[1-2]:10001"),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+		},
+		"drift from reference": {
+			members: system.Members{
+				member(1, "aaaa"), member(2, "bbbb"), member(3, "aaaa"),
+			},
+			refHash: "bbbb",
+			expReport: &ConfigDriftReport{
+				Expected:  "bbbb",
+				Reference: true,
+				Configs: []*ConfigHashHosts{
+					{Hash: "aaaa", Hosts: hostlist.MustCreateSet("127.0.0.[1,3]:10001"), Drifted: true},
+					{Hash: "bbbb", Hosts: hostlist.MustCreateSet("127.0.0.2:10001")},
+				},
+				DriftedHosts: hostlist.MustCreateSet("127.0.0.[1,3]:10001"),
+				Unknown:      hostlist.MustCreateSet(""),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotReport, err := NewConfigDriftReport(tc.members, tc.refHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmpOpts := []cmp.Option{
+				cmp.Comparer(func(x, y *hostlist.HostSet) bool {
+					return x.String() == y.String()
+				}),
+			}
+			if diff := cmp.Diff(tc.expReport, gotReport, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -12,14 +12,17 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/security"
+	"github.com/daos-stack/daos/src/control/server/config"
 )
 
 var (
@@ -120,3 +123,49 @@ func TestControl_LoadSpecifiedConfig(t *testing.T) {
 		t.Fatalf("loaded cfg doesn't match (-want, +got):\n%s\n", diff)
 	}
 }
+
+func TestControl_LoadConfig_Grpc(t *testing.T) {
+	for name, tc := range map[string]struct {
+		yml     string
+		expGrpc *config.GrpcConfig
+		expErr  error
+	}{
+		"unset": {
+			yml: "name: daos_server\n",
+		},
+		"tuned": {
+			yml: "grpc:\n  compression: gzip\n  max_recv_msg_size: 67108864\n" +
+				"  keepalive_time: 30s\n  keepalive_timeout: 10s\n",
+			expGrpc: &config.GrpcConfig{
+				Compression:      config.GrpcCompressionGzip,
+				MaxRecvMsgSize:   64 << 20,
+				KeepaliveTime:    30 * time.Second,
+				KeepaliveTimeout: 10 * time.Second,
+			},
+		},
+		"bad compression": {
+			yml:    "grpc:\n  compression: lz4\n",
+			expErr: errors.New("unsupported compression"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			testPath := path.Join(tmpDir, "test.yml")
+			if err := ioutil.WriteFile(testPath, []byte(tc.yml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			gotCfg, gotErr := LoadConfig(testPath)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expGrpc, gotCfg.Grpc); diff != "" {
+				t.Fatalf("unexpected grpc config (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
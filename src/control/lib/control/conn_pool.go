@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// connPool caches client connections by host address so that subsequent
+// requests to a host reuse the established connection rather than repeating
+// the dial and TLS handshake.
+type connPool struct {
+	sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newConnPool() *connPool {
+	return &connPool{
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// get returns the cached connection to the host, or nil if none exists.
+func (p *connPool) get(addr string) *grpc.ClientConn {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.conns[addr]
+}
+
+// put caches the connection to the host and returns it. If a connection to
+// the host was cached concurrently, the supplied connection is closed and the
+// cached one is returned instead.
+func (p *connPool) put(addr string, conn *grpc.ClientConn) *grpc.ClientConn {
+	p.Lock()
+	defer p.Unlock()
+
+	if cached, found := p.conns[addr]; found {
+		conn.Close()
+		return cached
+	}
+	p.conns[addr] = conn
+
+	return conn
+}
+
+// evict removes the connection to the host from the pool and closes it.
+func (p *connPool) evict(addr string, conn *grpc.ClientConn) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.conns[addr] == conn {
+		delete(p.conns, addr)
+	}
+	conn.Close()
+}
+
+// closeAll closes and removes all cached connections.
+func (p *connPool) closeAll() {
+	p.Lock()
+	defer p.Unlock()
+
+	for addr, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, addr)
+	}
+}
+
+// isConnFailure returns true if the error indicates that the connection used
+// for the request is broken and should not be reused.
+func isConnFailure(err error) bool {
+	return IsConnectionError(err) || status.Code(errors.Cause(err)) == codes.Unavailable
+}
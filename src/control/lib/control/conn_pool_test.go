@@ -0,0 +1,110 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_isConnFailure(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		expRes bool
+	}{
+		"nil":                {},
+		"other error":        {err: errors.New("failed")},
+		"connection refused": {err: FaultConnectionRefused("host1:10001"), expRes: true},
+		"unavailable": {
+			err:    errors.Wrap(status.Error(codes.Unavailable, "down"), "wrapped"),
+			expRes: true,
+		},
+		"permission denied": {err: status.Error(codes.PermissionDenied, "denied")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expRes, isConnFailure(tc.err), "unexpected result")
+		})
+	}
+}
+
+func TestControl_Client_ConnectionReuse(t *testing.T) {
+	clientCfg := DefaultConfig()
+	clientCfg.TransportConfig.AllowInsecure = true
+
+	for name, tc := range map[string]struct {
+		reuse      bool
+		rpcErr     error
+		expReused  bool
+		expEvicted bool
+	}{
+		"reuse disabled": {},
+		"reused": {
+			reuse:     true,
+			expReused: true,
+		},
+		"reused after request error": {
+			reuse:     true,
+			rpcErr:    errors.New("request failed"),
+			expReused: true,
+		},
+		"redialled after connection failure": {
+			reuse:      true,
+			rpcErr:     FaultConnectionClosed("127.0.0.1:1"),
+			expEvicted: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			opts := []ClientOption{WithConfig(clientCfg), WithClientLogger(log)}
+			if tc.reuse {
+				opts = append(opts, WithConnectionReuse())
+			}
+			client := NewClient(opts...)
+			defer client.Close()
+
+			var conns []*grpc.ClientConn
+			req := &testRequest{
+				HostList: []string{"127.0.0.1:1"},
+				rpcFn: func(_ context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+					conns = append(conns, conn)
+					return defaultMessage, tc.rpcErr
+				},
+			}
+
+			for i := 0; i < 2; i++ {
+				respChan, err := client.InvokeUnaryRPCAsync(context.TODO(), req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for range respChan {
+				}
+			}
+
+			common.AssertEqual(t, tc.expReused, conns[0] == conns[1], "unexpected connection reuse")
+			if !tc.reuse {
+				return
+			}
+			common.AssertEqual(t, tc.expEvicted, client.conns.get("127.0.0.1:1") == nil,
+				"unexpected cached connection")
+
+			client.Close()
+			common.AssertTrue(t, client.conns.get("127.0.0.1:1") == nil,
+				"connection cached after close")
+		})
+	}
+}
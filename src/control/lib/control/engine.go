@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type (
+	// XstreamStats contains runtime scheduling statistics of an I/O Engine
+	// execution stream (xstream).
+	XstreamStats struct {
+		XsID       int32  `json:"xs_id"`
+		TgtID      int32  `json:"tgt_id"`
+		Name       string `json:"name"`
+		Main       bool   `json:"main"`
+		UltsQueued uint64 `json:"ults_queued"`
+		TotalTime  uint64 `json:"total_time"` // ms
+		RelaxTime  uint64 `json:"relax_time"` // ms
+		Cycles     uint64 `json:"cycles"`
+	}
+
+	// EngineStats contains the per-xstream statistics of an I/O Engine.
+	EngineStats struct {
+		Rank     system.Rank     `json:"rank"`
+		Xstreams []*XstreamStats `json:"xstreams"`
+	}
+
+	// EngineStatsReq contains the parameters for an engine stats request.
+	EngineStatsReq struct {
+		unaryRequest
+		Rank system.Rank
+	}
+
+	// EngineStatsResp contains the results of an engine stats request,
+	// keyed by host address.
+	EngineStatsResp struct {
+		HostErrorsResp
+		HostStats map[string][]*EngineStats `json:"host_stats"`
+	}
+)
+
+// IdlePercent returns the percentage of scheduler CPU time the xstream has
+// spent idle.
+func (xs *XstreamStats) IdlePercent() float64 {
+	if xs.TotalTime == 0 {
+		return 0
+	}
+	return float64(xs.RelaxTime) * 100 / float64(xs.TotalTime)
+}
+
+// CycleTime returns the average duration of a scheduling cycle on the xstream.
+func (xs *XstreamStats) CycleTime() time.Duration {
+	if xs.Cycles == 0 {
+		return 0
+	}
+	return time.Duration(xs.TotalTime) * time.Millisecond / time.Duration(xs.Cycles)
+}
+
+func (esr *EngineStatsResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.EngineStatsResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	var stats []*EngineStats
+	if err := convert.Types(pbResp.GetRanks(), &stats); err != nil {
+		return esr.addHostError(hr.Addr, err)
+	}
+
+	if esr.HostStats == nil {
+		esr.HostStats = make(map[string][]*EngineStats)
+	}
+	esr.HostStats[hr.Addr] = stats
+
+	return nil
+}
+
+// GetEngineStats concurrently retrieves runtime scheduling statistics for
+// each xstream of the I/O Engines running on all hosts supplied in the
+// request's hostlist, or all configured hosts if not explicitly specified.
+func GetEngineStats(ctx context.Context, rpcClient UnaryInvoker, req *EngineStatsReq) (*EngineStatsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).EngineStats(ctx, &ctlpb.EngineStatsReq{
+			Rank: req.Rank.Uint32(),
+		})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	esr := new(EngineStatsResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := esr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := esr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return esr, nil
+}
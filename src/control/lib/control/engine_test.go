@@ -0,0 +1,168 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_XstreamStats(t *testing.T) {
+	for name, tc := range map[string]struct {
+		xs           *XstreamStats
+		expIdle      float64
+		expCycleTime time.Duration
+	}{
+		"no samples": {
+			xs: &XstreamStats{},
+		},
+		"busy": {
+			xs: &XstreamStats{
+				TotalTime: 2000,
+				RelaxTime: 500,
+				Cycles:    4000,
+			},
+			expIdle:      25,
+			expCycleTime: 500 * time.Microsecond,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expIdle, tc.xs.IdlePercent(), "unexpected idle percent")
+			common.AssertEqual(t, tc.expCycleTime, tc.xs.CycleTime(), "unexpected cycle time")
+		})
+	}
+}
+
+func TestControl_GetEngineStats(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *EngineStatsReq
+		expResp *EngineStatsResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.EngineStatsReq request"),
+		},
+		"local failure": {
+			req: &EngineStatsReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &EngineStatsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &EngineStatsResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &EngineStatsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, nil),
+			},
+			expErr: errors.New("unpack"),
+		},
+		"success": {
+			req: &EngineStatsReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.EngineStatsResp{
+						Ranks: []*ctlpb.EngineStatsResp_RankResp{
+							{
+								Rank: 1,
+								Xstreams: []*ctlpb.XstreamStats{
+									{
+										XsId:      0,
+										TgtId:     -1,
+										Name:      "daos_sys_0",
+										TotalTime: 1000,
+										RelaxTime: 900,
+										Cycles:    10,
+									},
+									{
+										XsId:       2,
+										TgtId:      0,
+										Name:       "daos_io_0",
+										Main:       true,
+										UltsQueued: 3,
+										TotalTime:  1000,
+										RelaxTime:  100,
+										Cycles:     100,
+									},
+								},
+							},
+						},
+					},
+				),
+			},
+			expResp: &EngineStatsResp{
+				HostStats: map[string][]*EngineStats{
+					"host1": {
+						{
+							Rank: 1,
+							Xstreams: []*XstreamStats{
+								{
+									XsID:      0,
+									TgtID:     -1,
+									Name:      "daos_sys_0",
+									TotalTime: 1000,
+									RelaxTime: 900,
+									Cycles:    10,
+								},
+								{
+									XsID:       2,
+									TgtID:      0,
+									Name:       "daos_io_0",
+									Main:       true,
+									UltsQueued: 3,
+									TotalTime:  1000,
+									RelaxTime:  100,
+									Cycles:     100,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := GetEngineStats(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -8,8 +8,11 @@ package control
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
 	"log/syslog"
+	"net"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -109,9 +112,14 @@ func NewEventForwarder(rpcClient UnaryInvoker, accessPts []string) *EventForward
 // EventLogger implements the events.Handler interface and logs RAS event to
 // INFO using supplied logging.Logger. In addition syslog is written to at the
 // priority level derived from the event severity.
+//
+// Events are rendered in the configured format. RFC5424 messages carry their
+// own header and so are written directly to the local syslog socket.
 type EventLogger struct {
 	log        logging.Logger
+	format     events.EventFormat
 	sysloggers map[events.RASSeverityID]*log.Logger
+	rawSyslog  io.Writer
 }
 
 // OnEvent implements the events.Handler interface.
@@ -124,7 +132,16 @@ func (el *EventLogger) OnEvent(_ context.Context, evt *events.RASEvent) {
 		return // event has already been logged at source
 	}
 
-	out := evt.PrintRAS()
+	out := evt.Format(el.format)
+	if el.format == events.EventFormatRFC5424 {
+		if el.rawSyslog != nil {
+			if _, err := fmt.Fprint(el.rawSyslog, out); err == nil {
+				return
+			}
+		}
+		el.log.Info("&&& RAS " + out)
+		return
+	}
 	if sl := el.sysloggers[evt.Severity]; sl != nil {
 		sl.Print(out)
 		return
@@ -133,16 +150,46 @@ func (el *EventLogger) OnEvent(_ context.Context, evt *events.RASEvent) {
 	el.log.Info("&&& RAS " + out)
 }
 
-type newSysloggerFn func(syslog.Priority, int) (*log.Logger, error)
+type (
+	newSysloggerFn func(syslog.Priority, int) (*log.Logger, error)
+	dialSyslogFn   func() (io.Writer, error)
+)
+
+// dialSyslog connects to the local syslog socket, trying the same locations
+// as log/syslog.
+func dialSyslog() (io.Writer, error) {
+	var err error
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range []string{"/dev/log", "/var/run/syslog", "/var/run/log"} {
+			var conn net.Conn
+			if conn, err = net.Dial(network, path); err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, err
+}
 
-// newEventLogger returns an initialized EventLogger using the provided function
-// to populate syslog endpoints which map to event severity identifiers.
-func newEventLogger(logBasic logging.Logger, newSyslogger newSysloggerFn) *EventLogger {
+// newEventLogger returns an initialized EventLogger using the provided functions
+// to populate syslog endpoints which map to event severity identifiers, or to
+// connect to syslog directly if events are to be written in RFC5424 format.
+func newEventLogger(logBasic logging.Logger, format events.EventFormat, newSyslogger newSysloggerFn, dial dialSyslogFn) *EventLogger {
 	el := &EventLogger{
 		log:        logBasic,
+		format:     format,
 		sysloggers: make(map[events.RASSeverityID]*log.Logger),
 	}
 
+	if format == events.EventFormatRFC5424 {
+		w, err := dial()
+		if err != nil {
+			logBasic.Errorf("failed to connect to syslog: %s", err)
+		}
+		el.rawSyslog = w
+
+		return el
+	}
+
 	// syslog writer will prepend timestamp in message header so don't add
 	// duplicate timestamp in log entries
 	flags := log.LstdFlags &^ (log.Ldate | log.Ltime)
@@ -166,7 +213,7 @@ func newEventLogger(logBasic logging.Logger, newSyslogger newSysloggerFn) *Event
 }
 
 // NewEventLogger returns an initialized EventLogger capable of writing to the
-// supplied logger in addition to syslog.
-func NewEventLogger(log logging.Logger) *EventLogger {
-	return newEventLogger(log, syslog.NewLogger)
+// supplied logger in addition to syslog, rendering events in the given format.
+func NewEventLogger(log logging.Logger, format events.EventFormat) *EventLogger {
+	return newEventLogger(log, format, syslog.NewLogger, dialSyslog)
 }
@@ -9,6 +9,7 @@ package control
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"log/syslog"
 	"math"
@@ -208,7 +209,7 @@ prio27 id: [engine_died] ts: [%s] host: [foo] type: [STATE_CHANGE] sev: [ERROR]
 				tc.newSyslogger = mockNewSyslogger
 			}
 
-			el := newEventLogger(logBasic, tc.newSyslogger)
+			el := newEventLogger(logBasic, events.EventFormatRAS, tc.newSyslogger, nil)
 			el.OnEvent(context.TODO(), tc.event)
 
 			// check event logged to control plane
@@ -245,3 +246,58 @@ prio27 id: [engine_died] ts: [%s] host: [foo] type: [STATE_CHANGE] sev: [ERROR]
 		})
 	}
 }
+
+func TestControl_EventLogger_Format(t *testing.T) {
+	evt := mockEvtEngineDied(t).WithForwarded(false)
+
+	for name, tc := range map[string]struct {
+		format       events.EventFormat
+		dialErr      error
+		expSyslogOut string
+		expLogOut    string
+	}{
+		"cef": {
+			format:       events.EventFormatCEF,
+			expSyslogOut: "prio27 " + evt.Format(events.EventFormatCEF) + "\n",
+		},
+		"rfc5424": {
+			format:       events.EventFormatRFC5424,
+			expSyslogOut: evt.Format(events.EventFormatRFC5424),
+		},
+		"rfc5424 without syslog": {
+			format:    events.EventFormatRFC5424,
+			dialErr:   errors.New("no syslog"),
+			expLogOut: evt.Format(events.EventFormatRFC5424),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			logBasic, bufBasic := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, bufBasic)
+
+			syslogBuf := &strings.Builder{}
+			mockNewSyslogger := func(prio syslog.Priority, flags int) (*log.Logger, error) {
+				return log.New(syslogBuf, fmt.Sprintf("prio%d ", prio), flags), nil
+			}
+			mockDial := func() (io.Writer, error) {
+				if tc.dialErr != nil {
+					return nil, tc.dialErr
+				}
+				return syslogBuf, nil
+			}
+
+			el := newEventLogger(logBasic, tc.format, mockNewSyslogger, mockDial)
+			el.OnEvent(context.TODO(), evt)
+
+			if diff := cmp.Diff(tc.expSyslogOut, syslogBuf.String()); diff != "" {
+				t.Fatalf("unexpected syslog output (-want, +got):\n%s\n", diff)
+			}
+			if tc.expLogOut == "" {
+				common.AssertTrue(t, !strings.Contains(bufBasic.String(), "&&& RAS"),
+					"unexpected event in log output")
+				return
+			}
+			common.AssertTrue(t, strings.Contains(bufBasic.String(), tc.expLogOut),
+				"expected event in log output")
+		})
+	}
+}
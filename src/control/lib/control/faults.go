@@ -56,6 +56,12 @@ func IsConnectionError(err error) bool {
 	return false
 }
 
+// IsMSConnectionFailure returns true if the error indicates that the
+// management service could not be contacted.
+func IsMSConnectionFailure(err error) bool {
+	return errors.Cause(err) == errMSConnectionFailure
+}
+
 func FaultConnectionBadHost(srvAddr string) *fault.Fault {
 	return clientFault(
 		code.ClientConnectionBadHost,
@@ -88,6 +94,16 @@ func FaultConnectionClosed(srvAddr string) *fault.Fault {
 	)
 }
 
+// FaultFormatAborted creates a Fault for a host that was not formatted because
+// the format was aborted after failures on other hosts.
+func FaultFormatAborted(reason string) *fault.Fault {
+	return clientFault(
+		code.ClientFormatAborted,
+		fmt.Sprintf("storage format not attempted: %s", reason),
+		"investigate the format failures reported for other hosts, then retry the format on the remaining hosts",
+	)
+}
+
 func clientFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "client",
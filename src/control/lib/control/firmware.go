@@ -208,7 +208,7 @@ type (
 		Devices      []string // Specific devices to update
 		ModelID      string   // Update only devices of specific model
 		FirmwareRev  string   // Update only devices with a specific current firmware
-
+		Activate     bool     // Activate staged SCM firmware without a reboot
 	}
 
 	// HostSCMUpdateMap maps a host name to a slice of SCM update results.
@@ -217,8 +217,10 @@ type (
 	// SCMUpdateResult represents the results of a firmware update
 	// for a single SCM device.
 	SCMUpdateResult struct {
-		Module storage.ScmModule
-		Error  error
+		Module        storage.ScmModule
+		Error         error
+		StagedVersion string // Version pending activation, if any
+		Activated     bool   // New firmware active without a power cycle
 	}
 
 	// HostNVMeUpdateMap maps a host name to a slice of NVMe update results.
@@ -283,7 +285,10 @@ func (ur *FirmwareUpdateResp) addHostSCMResults(hostAddr string, pbResp *ctlpb.F
 		scmResults := make([]*SCMUpdateResult, 0, len(pbResp.ScmResults))
 
 		for _, pbRes := range pbResp.ScmResults {
-			devResult := &SCMUpdateResult{}
+			devResult := &SCMUpdateResult{
+				StagedVersion: pbRes.StagedVersion,
+				Activated:     pbRes.Activated,
+			}
 			if err := convert.Types(pbRes.Module, &devResult.Module); err != nil {
 				return errors.Wrapf(err, "unable to convert module")
 			}
@@ -356,6 +361,9 @@ func FirmwareUpdate(ctx context.Context, rpcClient UnaryInvoker, req *FirmwareUp
 	if err != nil {
 		return nil, err
 	}
+	if req.Activate && req.Type != DeviceTypeSCM {
+		return nil, errors.New("firmware activation is only supported for SCM devices")
+	}
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return ctlpb.NewCtlSvcClient(conn).FirmwareUpdate(ctx, &ctlpb.FirmwareUpdateReq{
 			FirmwarePath: req.FirmwarePath,
@@ -363,6 +371,7 @@ func FirmwareUpdate(ctx context.Context, rpcClient UnaryInvoker, req *FirmwareUp
 			DeviceIDs:    req.Devices,
 			ModelID:      req.ModelID,
 			FirmwareRev:  req.FirmwareRev,
+			Activate:     req.Activate,
 		})
 	})
 
@@ -0,0 +1,350 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	// FirmwareCatalogEntry lists the approved firmware revisions for a
+	// storage device model.
+	FirmwareCatalogEntry struct {
+		ModelID   string   `yaml:"model"`
+		Revisions []string `yaml:"revisions"`
+		// Path to the firmware file, accessible from all hosts, used to
+		// update non-compliant devices of the model.
+		FirmwarePath string `yaml:"firmware_path,omitempty"`
+	}
+
+	// FirmwareCatalog lists the approved firmware revisions of the SCM and
+	// NVMe device models in a DAOS system.
+	FirmwareCatalog struct {
+		SCM  []*FirmwareCatalogEntry `yaml:"scm"`
+		NVMe []*FirmwareCatalogEntry `yaml:"nvme"`
+	}
+
+	// NonCompliantDevice describes a storage device running a firmware
+	// revision not approved for its model by a firmware catalog.
+	NonCompliantDevice struct {
+		Host        string     `json:"host"`
+		Type        DeviceType `json:"type"`
+		DeviceID    string     `json:"device_id"`
+		ModelID     string     `json:"model"`
+		FirmwareRev string     `json:"fw_rev"`
+		Approved    []string   `json:"approved"`
+	}
+
+	// FirmwareCatalogUpdateReq is a request to update the firmware of all
+	// devices not compliant with the catalog.
+	FirmwareCatalogUpdateReq struct {
+		unaryRequest
+		Catalog  *FirmwareCatalog
+		Activate bool // Activate staged SCM firmware without a reboot
+	}
+
+	// FirmwareCatalogUpdateResp returns the results of the firmware update
+	// operations required to bring devices into compliance with a catalog.
+	FirmwareCatalogUpdateResp struct {
+		FirmwareUpdateResp
+		NonCompliant []*NonCompliantDevice `json:"non_compliant"`
+	}
+)
+
+func (t DeviceType) String() string {
+	switch t {
+	case DeviceTypeSCM:
+		return "SCM"
+	case DeviceTypeNVMe:
+		return "NVMe"
+	}
+	return "Unknown"
+}
+
+// MarshalJSON outputs the device type as a string.
+func (t DeviceType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// LoadFirmwareCatalog reads and validates the firmware catalog at the given
+// path.
+func LoadFirmwareCatalog(catPath string) (*FirmwareCatalog, error) {
+	data, err := ioutil.ReadFile(catPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read firmware catalog")
+	}
+
+	fc := new(FirmwareCatalog)
+	if err := yaml.UnmarshalStrict(data, fc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse firmware catalog %s", catPath)
+	}
+
+	if err := fc.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "firmware catalog %s", catPath)
+	}
+
+	return fc, nil
+}
+
+// Validate checks that each entry in the catalog names a model and at least
+// one approved revision, and that no model is listed twice.
+func (fc *FirmwareCatalog) Validate() error {
+	for _, devType := range []DeviceType{DeviceTypeSCM, DeviceTypeNVMe} {
+		seen := make(map[string]bool)
+		for _, entry := range fc.entries(devType) {
+			if entry.ModelID == "" {
+				return errors.Errorf("%s entry missing model", devType)
+			}
+			model := strings.ToLower(entry.ModelID)
+			if seen[model] {
+				return errors.Errorf("%s model %q listed more than once",
+					devType, entry.ModelID)
+			}
+			seen[model] = true
+
+			if len(entry.Revisions) == 0 {
+				return errors.Errorf("%s model %q has no approved revisions",
+					devType, entry.ModelID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fc *FirmwareCatalog) entries(devType DeviceType) []*FirmwareCatalogEntry {
+	if devType == DeviceTypeSCM {
+		return fc.SCM
+	}
+	return fc.NVMe
+}
+
+// lookup returns the catalog entry for the given model, model IDs are matched
+// without regard to case in the same way as firmware request filters.
+func (fc *FirmwareCatalog) lookup(devType DeviceType, modelID string) *FirmwareCatalogEntry {
+	for _, entry := range fc.entries(devType) {
+		if strings.EqualFold(entry.ModelID, modelID) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (fc *FirmwareCatalog) checkDevice(host string, devType DeviceType, devID, modelID, fwRev string) *NonCompliantDevice {
+	entry := fc.lookup(devType, modelID)
+	if entry == nil {
+		// devices of models not in the catalog are not checked
+		return nil
+	}
+	for _, rev := range entry.Revisions {
+		if strings.EqualFold(rev, fwRev) {
+			return nil
+		}
+	}
+
+	return &NonCompliantDevice{
+		Host:        host,
+		Type:        devType,
+		DeviceID:    devID,
+		ModelID:     modelID,
+		FirmwareRev: fwRev,
+		Approved:    entry.Revisions,
+	}
+}
+
+// CheckCompliance returns the devices in the firmware query response whose
+// firmware revision is not approved for their model by the catalog. Devices
+// of models not listed in the catalog are ignored.
+func (fc *FirmwareCatalog) CheckCompliance(resp *FirmwareQueryResp) []*NonCompliantDevice {
+	var nonCompliant []*NonCompliantDevice
+
+	for host, results := range resp.HostSCMFirmware {
+		for _, res := range results {
+			mod := res.Module
+			if ncd := fc.checkDevice(host, DeviceTypeSCM, mod.UID, mod.PartNumber,
+				mod.FirmwareRevision); ncd != nil {
+				nonCompliant = append(nonCompliant, ncd)
+			}
+		}
+	}
+	for host, results := range resp.HostNVMeFirmware {
+		for _, res := range results {
+			dev := res.Device
+			if ncd := fc.checkDevice(host, DeviceTypeNVMe, dev.PciAddr, dev.Model,
+				dev.FwRev); ncd != nil {
+				nonCompliant = append(nonCompliant, ncd)
+			}
+		}
+	}
+
+	sort.Slice(nonCompliant, func(i, j int) bool {
+		a, b := nonCompliant[i], nonCompliant[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.DeviceID < b.DeviceID
+	})
+
+	return nonCompliant
+}
+
+// UpdateRequests returns the firmware update requests that install the
+// catalog firmware file on the given non-compliant devices.
+//
+// A request is generated for each device model and set of device IDs, with
+// the request's hostlist containing all hosts sharing the same set of
+// non-compliant devices of the model.
+func (fc *FirmwareCatalog) UpdateRequests(nonCompliant []*NonCompliantDevice) ([]*FirmwareUpdateReq, error) {
+	type modelKey struct {
+		devType DeviceType
+		model   string
+	}
+	hostDevs := make(map[modelKey]map[string][]string)
+	var models []modelKey
+
+	for _, ncd := range nonCompliant {
+		entry := fc.lookup(ncd.Type, ncd.ModelID)
+		if entry == nil {
+			return nil, errors.Errorf("%s model %q not in firmware catalog",
+				ncd.Type, ncd.ModelID)
+		}
+		if entry.FirmwarePath == "" {
+			return nil, errors.Errorf("no firmware_path for %s model %q in firmware catalog",
+				ncd.Type, entry.ModelID)
+		}
+
+		key := modelKey{devType: ncd.Type, model: entry.ModelID}
+		if _, found := hostDevs[key]; !found {
+			hostDevs[key] = make(map[string][]string)
+			models = append(models, key)
+		}
+		hostDevs[key][ncd.Host] = append(hostDevs[key][ncd.Host], ncd.DeviceID)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].devType != models[j].devType {
+			return models[i].devType < models[j].devType
+		}
+		return models[i].model < models[j].model
+	})
+
+	var reqs []*FirmwareUpdateReq
+	for _, key := range models {
+		entry := fc.lookup(key.devType, key.model)
+
+		// group hosts by their set of non-compliant devices
+		devHosts := make(map[string][]string)
+		for host, devs := range hostDevs[key] {
+			sort.Strings(devs)
+			devList := strings.Join(devs, ",")
+			devHosts[devList] = append(devHosts[devList], host)
+		}
+		devLists := make([]string, 0, len(devHosts))
+		for devList := range devHosts {
+			devLists = append(devLists, devList)
+		}
+		sort.Strings(devLists)
+
+		for _, devList := range devLists {
+			hosts := devHosts[devList]
+			sort.Strings(hosts)
+
+			req := &FirmwareUpdateReq{
+				FirmwarePath: entry.FirmwarePath,
+				Type:         key.devType,
+				Devices:      strings.Split(devList, ","),
+				ModelID:      entry.ModelID,
+			}
+			req.SetHostList(hosts)
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs, nil
+}
+
+// merge adds the results and host errors of the given response.
+func (ur *FirmwareUpdateResp) merge(other *FirmwareUpdateResp) error {
+	for host, results := range other.HostSCMResult {
+		if ur.HostSCMResult == nil {
+			ur.HostSCMResult = make(HostSCMUpdateMap)
+		}
+		ur.HostSCMResult[host] = append(ur.HostSCMResult[host], results...)
+	}
+	for host, results := range other.HostNVMeResult {
+		if ur.HostNVMeResult == nil {
+			ur.HostNVMeResult = make(HostNVMeUpdateMap)
+		}
+		ur.HostNVMeResult[host] = append(ur.HostNVMeResult[host], results...)
+	}
+	for _, hes := range other.HostErrors {
+		for _, host := range hes.HostSet.Slice() {
+			if err := ur.addHostError(host, hes.HostError); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FirmwareCatalogUpdate queries the device firmware on all hosts supplied in
+// the request's hostlist, or all configured hosts if not explicitly
+// specified, and updates the firmware of devices not compliant with the
+// catalog. The function blocks until all updates have completed and returns
+// a single response structure containing the non-compliant devices found and
+// the results of all update operations.
+func FirmwareCatalogUpdate(ctx context.Context, rpcClient UnaryInvoker, req *FirmwareCatalogUpdateReq) (*FirmwareCatalogUpdateResp, error) {
+	if req.Catalog == nil {
+		return nil, errors.New("firmware catalog missing")
+	}
+
+	queryReq := &FirmwareQueryReq{
+		SCM:  len(req.Catalog.SCM) > 0,
+		NVMe: len(req.Catalog.NVMe) > 0,
+	}
+	queryReq.SetHostList(req.getHostList())
+	queryResp, err := FirmwareQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying firmware")
+	}
+
+	resp := &FirmwareCatalogUpdateResp{
+		NonCompliant: req.Catalog.CheckCompliance(queryResp),
+	}
+	if err := resp.merge(&FirmwareUpdateResp{HostErrorsResp: queryResp.HostErrorsResp}); err != nil {
+		return nil, err
+	}
+
+	updateReqs, err := req.Catalog.UpdateRequests(resp.NonCompliant)
+	if err != nil {
+		return nil, err
+	}
+	for _, updateReq := range updateReqs {
+		updateReq.Activate = req.Activate && updateReq.Type == DeviceTypeSCM
+		updateResp, err := FirmwareUpdate(ctx, rpcClient, updateReq)
+		if err != nil {
+			return nil, errors.Wrapf(err, "updating %s model %q firmware",
+				updateReq.Type, updateReq.ModelID)
+		}
+		if err := resp.merge(updateResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,497 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/proto"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestControl_LoadFirmwareCatalog(t *testing.T) {
+	for name, tc := range map[string]struct {
+		catalog []string
+		expCat  *FirmwareCatalog
+		expErr  error
+	}{
+		"valid catalog": {
+			catalog: []string{
+				"scm:",
+				"- model: NMA1XXD128GPS",
+				"  revisions: [01.02.00.5435]",
+				"nvme:",
+				"- model: INTEL SSDPE2KE016T8",
+				"  revisions: [VDV10170, VDV10184]",
+				"  firmware_path: /shared/fw/VDV10184.bin",
+			},
+			expCat: &FirmwareCatalog{
+				SCM: []*FirmwareCatalogEntry{
+					{
+						ModelID:   "NMA1XXD128GPS",
+						Revisions: []string{"01.02.00.5435"},
+					},
+				},
+				NVMe: []*FirmwareCatalogEntry{
+					{
+						ModelID:      "INTEL SSDPE2KE016T8",
+						Revisions:    []string{"VDV10170", "VDV10184"},
+						FirmwarePath: "/shared/fw/VDV10184.bin",
+					},
+				},
+			},
+		},
+		"unknown key": {
+			catalog: []string{
+				"nvme:",
+				"- model: foo",
+				"  revision: [1]",
+			},
+			expErr: errors.New("field revision not found"),
+		},
+		"missing model": {
+			catalog: []string{
+				"nvme:",
+				"- revisions: [1]",
+			},
+			expErr: errors.New("NVMe entry missing model"),
+		},
+		"duplicate model": {
+			catalog: []string{
+				"scm:",
+				"- model: foo",
+				"  revisions: [1]",
+				"- model: FOO",
+				"  revisions: [2]",
+			},
+			expErr: errors.New("SCM model \"FOO\" listed more than once"),
+		},
+		"no revisions": {
+			catalog: []string{
+				"nvme:",
+				"- model: foo",
+			},
+			expErr: errors.New("NVMe model \"foo\" has no approved revisions"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			catPath := filepath.Join(testDir, "catalog.yml")
+			data := []byte(strings.Join(tc.catalog, "\n"))
+			if err := ioutil.WriteFile(catPath, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			gotCat, gotErr := LoadFirmwareCatalog(catPath)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCat, gotCat); diff != "" {
+				t.Fatalf("unexpected catalog (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func mockFirmwareCatalog() *FirmwareCatalog {
+	return &FirmwareCatalog{
+		SCM: []*FirmwareCatalogEntry{
+			{
+				ModelID:      "PartNumber1",
+				Revisions:    []string{"Rev1"},
+				FirmwarePath: "/fw/scm.bin",
+			},
+		},
+		NVMe: []*FirmwareCatalogEntry{
+			{
+				ModelID:      "model-1",
+				Revisions:    []string{"fwRev-0", "fwRev-1"},
+				FirmwarePath: "/fw/model-1.bin",
+			},
+			{
+				ModelID:   "model-2",
+				Revisions: []string{"fwRev-0"},
+			},
+		},
+	}
+}
+
+func TestControl_FirmwareCatalog_CheckCompliance(t *testing.T) {
+	scmResult := func(uid, part, rev string) *SCMQueryResult {
+		return &SCMQueryResult{
+			Module: storage.ScmModule{
+				UID:              uid,
+				PartNumber:       part,
+				FirmwareRevision: rev,
+			},
+		}
+	}
+	nvmeResult := func(idx int32, rev string) *NVMeQueryResult {
+		ctrlr := storage.MockNvmeController(idx)
+		ctrlr.FwRev = rev
+		return &NVMeQueryResult{Device: *ctrlr}
+	}
+
+	for name, tc := range map[string]struct {
+		resp            *FirmwareQueryResp
+		expNonCompliant []*NonCompliantDevice
+	}{
+		"empty response": {
+			resp: &FirmwareQueryResp{},
+		},
+		"all compliant": {
+			resp: &FirmwareQueryResp{
+				HostSCMFirmware: HostSCMQueryMap{
+					"host1": {scmResult("uid1", "partnumber1", "REV1")},
+				},
+				HostNVMeFirmware: HostNVMeQueryMap{
+					"host1": {nvmeResult(1, "fwRev-1"), nvmeResult(2, "fwRev-0")},
+				},
+			},
+		},
+		"unlisted models ignored": {
+			resp: &FirmwareQueryResp{
+				HostSCMFirmware: HostSCMQueryMap{
+					"host1": {scmResult("uid1", "PartNumber2", "Rev0")},
+				},
+				HostNVMeFirmware: HostNVMeQueryMap{
+					"host1": {nvmeResult(3, "fwRev-0")},
+				},
+			},
+		},
+		"non-compliant devices": {
+			resp: &FirmwareQueryResp{
+				HostSCMFirmware: HostSCMQueryMap{
+					"host2": {
+						scmResult("uid2", "PartNumber1", "Rev0"),
+						scmResult("uid1", "PartNumber1", "Rev1"),
+					},
+				},
+				HostNVMeFirmware: HostNVMeQueryMap{
+					"host1": {nvmeResult(1, "fwRev-1"), nvmeResult(2, "fwRev-2")},
+					"host2": {nvmeResult(1, "fwRev-2")},
+				},
+			},
+			expNonCompliant: []*NonCompliantDevice{
+				{
+					Host:        "host1",
+					Type:        DeviceTypeNVMe,
+					DeviceID:    "0000:80:00.2",
+					ModelID:     "model-2",
+					FirmwareRev: "fwRev-2",
+					Approved:    []string{"fwRev-0"},
+				},
+				{
+					Host:        "host2",
+					Type:        DeviceTypeSCM,
+					DeviceID:    "uid2",
+					ModelID:     "PartNumber1",
+					FirmwareRev: "Rev0",
+					Approved:    []string{"Rev1"},
+				},
+				{
+					Host:        "host2",
+					Type:        DeviceTypeNVMe,
+					DeviceID:    "0000:80:00.1",
+					ModelID:     "model-1",
+					FirmwareRev: "fwRev-2",
+					Approved:    []string{"fwRev-0", "fwRev-1"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotNonCompliant := mockFirmwareCatalog().CheckCompliance(tc.resp)
+
+			if diff := cmp.Diff(tc.expNonCompliant, gotNonCompliant); diff != "" {
+				t.Fatalf("unexpected non-compliant devices (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_FirmwareCatalog_UpdateRequests(t *testing.T) {
+	type updateReq struct {
+		Hosts   []string
+		Type    DeviceType
+		Path    string
+		Model   string
+		Devices []string
+	}
+
+	nvmeDev := func(host, pciAddr, model string) *NonCompliantDevice {
+		return &NonCompliantDevice{
+			Host:     host,
+			Type:     DeviceTypeNVMe,
+			DeviceID: pciAddr,
+			ModelID:  model,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		nonCompliant []*NonCompliantDevice
+		expReqs      []updateReq
+		expErr       error
+	}{
+		"no devices": {},
+		"hosts grouped by device set": {
+			nonCompliant: []*NonCompliantDevice{
+				nvmeDev("host1", "0000:80:00.1", "model-1"),
+				nvmeDev("host2", "0000:80:00.1", "MODEL-1"),
+				nvmeDev("host3", "0000:81:00.1", "model-1"),
+				nvmeDev("host3", "0000:80:00.1", "model-1"),
+				{
+					Host:     "host1",
+					Type:     DeviceTypeSCM,
+					DeviceID: "uid1",
+					ModelID:  "PartNumber1",
+				},
+			},
+			expReqs: []updateReq{
+				{
+					Hosts:   []string{"host1"},
+					Type:    DeviceTypeSCM,
+					Path:    "/fw/scm.bin",
+					Model:   "PartNumber1",
+					Devices: []string{"uid1"},
+				},
+				{
+					Hosts:   []string{"host1", "host2"},
+					Type:    DeviceTypeNVMe,
+					Path:    "/fw/model-1.bin",
+					Model:   "model-1",
+					Devices: []string{"0000:80:00.1"},
+				},
+				{
+					Hosts:   []string{"host3"},
+					Type:    DeviceTypeNVMe,
+					Path:    "/fw/model-1.bin",
+					Model:   "model-1",
+					Devices: []string{"0000:80:00.1", "0000:81:00.1"},
+				},
+			},
+		},
+		"no firmware path": {
+			nonCompliant: []*NonCompliantDevice{
+				nvmeDev("host1", "0000:80:00.2", "model-2"),
+			},
+			expErr: errors.New("no firmware_path for NVMe model \"model-2\""),
+		},
+		"model not in catalog": {
+			nonCompliant: []*NonCompliantDevice{
+				nvmeDev("host1", "0000:80:00.3", "model-3"),
+			},
+			expErr: errors.New("NVMe model \"model-3\" not in firmware catalog"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reqs, gotErr := mockFirmwareCatalog().UpdateRequests(tc.nonCompliant)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if gotErr != nil {
+				return
+			}
+
+			var gotReqs []updateReq
+			for _, req := range reqs {
+				gotReqs = append(gotReqs, updateReq{
+					Hosts:   req.getHostList(),
+					Type:    req.Type,
+					Path:    req.FirmwarePath,
+					Model:   req.ModelID,
+					Devices: req.Devices,
+				})
+			}
+
+			if diff := cmp.Diff(tc.expReqs, gotReqs); diff != "" {
+				t.Fatalf("unexpected requests (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_FirmwareCatalogUpdate(t *testing.T) {
+	pbNvmeDevice := func(t *testing.T, idx int32, rev string) *ctlpb.NvmeController {
+		ctrlr := storage.MockNvmeController(idx)
+		ctrlr.FwRev = rev
+		pb := new(proto.NvmeController)
+		if err := pb.FromNative(ctrlr); err != nil {
+			t.Fatal(err)
+		}
+		return pb.AsProto()
+	}
+	queryResp := &UnaryResponse{
+		Responses: []*HostResponse{
+			{
+				Addr: "host1",
+				Message: &ctlpb.FirmwareQueryResp{
+					NvmeResults: []*ctlpb.NvmeFirmwareQueryResp{
+						{Device: pbNvmeDevice(t, 1, "fwRev-1")},
+					},
+				},
+			},
+			{
+				Addr: "host2",
+				Message: &ctlpb.FirmwareQueryResp{
+					NvmeResults: []*ctlpb.NvmeFirmwareQueryResp{
+						{Device: pbNvmeDevice(t, 1, "fwRev-2")},
+					},
+				},
+			},
+			{
+				Addr:  "host3",
+				Error: errors.New("query failed"),
+			},
+		},
+	}
+	nonCompliant := []*NonCompliantDevice{
+		{
+			Host:        "host2",
+			Type:        DeviceTypeNVMe,
+			DeviceID:    "0000:80:00.1",
+			ModelID:     "model-1",
+			FirmwareRev: "fwRev-2",
+			Approved:    []string{"fwRev-0", "fwRev-1"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		catalog *FirmwareCatalog
+		mic     *MockInvokerConfig
+		expResp *FirmwareCatalogUpdateResp
+		expErr  error
+	}{
+		"no catalog": {
+			expErr: errors.New("firmware catalog missing"),
+		},
+		"query failure": {
+			catalog: mockFirmwareCatalog(),
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"all compliant": {
+			catalog: &FirmwareCatalog{
+				NVMe: []*FirmwareCatalogEntry{
+					{
+						ModelID:   "model-1",
+						Revisions: []string{"fwRev-1", "fwRev-2"},
+					},
+				},
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: queryResp,
+			},
+			expResp: &FirmwareCatalogUpdateResp{
+				FirmwareUpdateResp: FirmwareUpdateResp{
+					HostErrorsResp: HostErrorsResp{
+						HostErrors: HostErrorsMap{
+							"query failed": &HostErrorSet{
+								HostSet:   createTestHostSet(t, "host3"),
+								HostError: errors.New("query failed"),
+							},
+						},
+					},
+				},
+			},
+		},
+		"non-compliant device updated": {
+			catalog: mockFirmwareCatalog(),
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					queryResp,
+					MockMSResponse("host2", nil, &ctlpb.FirmwareUpdateResp{
+						NvmeResults: []*ctlpb.NvmeFirmwareUpdateResp{
+							{PciAddr: "0000:80:00.1"},
+						},
+					}),
+				},
+			},
+			expResp: &FirmwareCatalogUpdateResp{
+				FirmwareUpdateResp: FirmwareUpdateResp{
+					HostErrorsResp: HostErrorsResp{
+						HostErrors: HostErrorsMap{
+							"query failed": &HostErrorSet{
+								HostSet:   createTestHostSet(t, "host3"),
+								HostError: errors.New("query failed"),
+							},
+						},
+					},
+					HostNVMeResult: HostNVMeUpdateMap{
+						"host2": {{DevicePCIAddr: "0000:80:00.1"}},
+					},
+				},
+				NonCompliant: nonCompliant,
+			},
+		},
+		"update failure": {
+			catalog: mockFirmwareCatalog(),
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					queryResp,
+					MockMSResponse("host2", errors.New("update failed"), nil),
+				},
+			},
+			expResp: &FirmwareCatalogUpdateResp{
+				FirmwareUpdateResp: FirmwareUpdateResp{
+					HostErrorsResp: HostErrorsResp{
+						HostErrors: HostErrorsMap{
+							"query failed": &HostErrorSet{
+								HostSet:   createTestHostSet(t, "host3"),
+								HostError: errors.New("query failed"),
+							},
+							"update failed": &HostErrorSet{
+								HostSet:   createTestHostSet(t, "host2"),
+								HostError: errors.New("update failed"),
+							},
+						},
+					},
+				},
+				NonCompliant: nonCompliant,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := FirmwareCatalogUpdate(ctx, mi, &FirmwareCatalogUpdateReq{
+				Catalog: tc.catalog,
+			})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, getCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -337,6 +337,14 @@ func TestControl_FirmwareUpdate(t *testing.T) {
 			},
 			expErr: errors.New("firmware file path missing"),
 		},
+		"activate NVMe": {
+			req: &FirmwareUpdateReq{
+				Type:         DeviceTypeNVMe,
+				FirmwarePath: "/my/path",
+				Activate:     true,
+			},
+			expErr: errors.New("only supported for SCM devices"),
+		},
 		"local failure": {
 			req: &FirmwareUpdateReq{
 				Type:         DeviceTypeSCM,
@@ -436,7 +444,8 @@ func getTestSCMUpdateResults(t *testing.T) ([]*ctlpb.ScmFirmwareUpdateResp, []*S
 				Channelid:       4,
 				Channelposition: 5,
 			},
-			Error: "",
+			Error:         "",
+			StagedVersion: "STAGED",
 		},
 		{
 			Module: &ctlpb.ScmModule{
@@ -454,7 +463,10 @@ func getTestSCMUpdateResults(t *testing.T) ([]*ctlpb.ScmFirmwareUpdateResp, []*S
 
 	expSCMResults := make([]*SCMUpdateResult, 0, len(pbSCMResults))
 	for _, pbRes := range pbSCMResults {
-		res := &SCMUpdateResult{}
+		res := &SCMUpdateResult{
+			StagedVersion: pbRes.StagedVersion,
+			Activated:     pbRes.Activated,
+		}
 		if err := convert.Types(pbRes.Module, &res.Module); err != nil {
 			t.Fatalf("couldn't set up expected results: %v", err)
 		}
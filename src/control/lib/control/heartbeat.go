@@ -0,0 +1,123 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+type (
+	// HeartbeatReq contains the parameters for a heartbeat request.
+	HeartbeatReq struct {
+		unaryRequest
+	}
+
+	// HostClock describes the clock of a host relative to the local clock.
+	HostClock struct {
+		// Offset is positive if the host clock is ahead of the local clock.
+		Offset time.Duration
+		// RoundTrip is the duration of the request, the offset is
+		// accurate to within half of it.
+		RoundTrip time.Duration
+	}
+
+	// HeartbeatResp contains the clock of each host that responded and
+	// the hash of its config file, if known.
+	HeartbeatResp struct {
+		HostErrorsResp
+		HostClocks       map[string]*HostClock
+		HostConfigHashes map[string]string
+	}
+
+	// rpcTiming records when a request was sent and its response received.
+	rpcTiming struct {
+		sent time.Time
+		recv time.Time
+	}
+)
+
+// hostClock estimates the offset of the remote clock assuming that the remote
+// time was sampled halfway through the request.
+func (rt rpcTiming) hostClock(remote time.Time) *HostClock {
+	rtt := rt.recv.Sub(rt.sent)
+	return &HostClock{
+		Offset:    remote.Sub(rt.sent.Add(rtt / 2)),
+		RoundTrip: rtt,
+	}
+}
+
+// Heartbeat concurrently retrieves the wall clock time of all hosts supplied
+// in the request's hostlist and compares it with the local clock. Each
+// request is timed individually so that slow hosts do not distort the offsets
+// calculated for the others.
+func Heartbeat(ctx context.Context, rpcClient UnaryInvoker, req *HeartbeatReq) (*HeartbeatResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	var timingsMu sync.Mutex
+	timings := make(map[string]rpcTiming)
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		rt := rpcTiming{sent: time.Now()}
+		resp, err := ctlpb.NewCtlSvcClient(conn).Heartbeat(ctx, &ctlpb.HeartbeatReq{})
+		rt.recv = time.Now()
+
+		timingsMu.Lock()
+		timings[conn.Target()] = rt
+		timingsMu.Unlock()
+
+		return resp, err
+	})
+
+	// Fall back to the timing of the whole invocation for hosts whose
+	// request was not timed individually.
+	invokeTiming := rpcTiming{sent: time.Now()}
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	invokeTiming.recv = time.Now()
+
+	hbr := &HeartbeatResp{
+		HostClocks:       make(map[string]*HostClock),
+		HostConfigHashes: make(map[string]string),
+	}
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := hbr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hostResp.Message.(*ctlpb.HeartbeatResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+		}
+
+		timingsMu.Lock()
+		rt, found := timings[hostResp.Addr]
+		timingsMu.Unlock()
+		if !found {
+			rt = invokeTiming
+		}
+		hbr.HostClocks[hostResp.Addr] = rt.hostClock(time.Unix(0, pbResp.Time))
+		if pbResp.ConfigHash != "" {
+			hbr.HostConfigHashes[hostResp.Addr] = pbResp.ConfigHash
+		}
+	}
+
+	return hbr, nil
+}
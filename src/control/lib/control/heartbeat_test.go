@@ -0,0 +1,155 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_rpcTiming_hostClock(t *testing.T) {
+	sent := time.Unix(1000, 0)
+
+	for name, tc := range map[string]struct {
+		rtt      time.Duration
+		remote   time.Time
+		expClock *HostClock
+	}{
+		"in sync": {
+			rtt:      2 * time.Millisecond,
+			remote:   sent.Add(time.Millisecond),
+			expClock: &HostClock{RoundTrip: 2 * time.Millisecond},
+		},
+		"ahead": {
+			rtt:    2 * time.Millisecond,
+			remote: sent.Add(3 * time.Second),
+			expClock: &HostClock{
+				Offset:    3*time.Second - time.Millisecond,
+				RoundTrip: 2 * time.Millisecond,
+			},
+		},
+		"behind": {
+			rtt:    10 * time.Millisecond,
+			remote: sent.Add(-time.Minute),
+			expClock: &HostClock{
+				Offset:    -time.Minute - 5*time.Millisecond,
+				RoundTrip: 10 * time.Millisecond,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rt := rpcTiming{sent: sent, recv: sent.Add(tc.rtt)}
+
+			if diff := cmp.Diff(tc.expClock, rt.hostClock(tc.remote)); diff != "" {
+				t.Fatalf("unexpected clock (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_Heartbeat(t *testing.T) {
+	// Responses from the mock invoker are not timed individually, so the
+	// offsets are only accurate to within the duration of the test.
+	cmpApprox := cmp.Comparer(func(a, b time.Duration) bool {
+		d := a - b
+		return d > -time.Minute && d < time.Minute
+	})
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *HeartbeatReq
+		expResp *HeartbeatResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"local failure": {
+			req: &HeartbeatReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"nil message": {
+			req: &HeartbeatReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{Addr: "host1"},
+					},
+				},
+			},
+			expErr: errors.New("unpack"),
+		},
+		"remote failure, skewed host and config hash": {
+			req: &HeartbeatReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.HeartbeatResp{
+								Time:       time.Now().Add(time.Hour).UnixNano(),
+								ConfigHash: "abcd",
+							},
+						},
+						{
+							Addr: "host3",
+							Message: &ctlpb.HeartbeatResp{
+								Time: time.Now().UnixNano(),
+							},
+						},
+					},
+				},
+			},
+			expResp: &HeartbeatResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+				HostClocks: map[string]*HostClock{
+					"host2": {Offset: time.Hour},
+					"host3": {},
+				},
+				HostConfigHashes: map[string]string{
+					"host2": "abcd",
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			gotResp, gotErr := Heartbeat(context.TODO(), NewMockInvoker(log, mic), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := append(defResCmpOpts(), cmpApprox)
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
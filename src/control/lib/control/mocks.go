@@ -43,6 +43,7 @@ type (
 		UnaryResponse    *UnaryResponse
 		UnaryResponseSet []*UnaryResponse
 		HostResponses    HostResponseChan
+		Progress         ProgressReporter
 	}
 
 	// MockInvoker implements the Invoker interface in order
@@ -82,7 +83,7 @@ func (mi *MockInvoker) GetSystem() string {
 }
 
 func (mi *MockInvoker) InvokeUnaryRPC(ctx context.Context, uReq UnaryRequest) (*UnaryResponse, error) {
-	return invokeUnaryRPC(ctx, mi.log, mi, uReq, nil)
+	return invokeUnaryRPC(ctx, mi.log, mi, uReq, nil, mi.cfg.Progress)
 }
 
 func (mi *MockInvoker) InvokeUnaryRPCAsync(ctx context.Context, uReq UnaryRequest) (HostResponseChan, error) {
@@ -214,6 +215,15 @@ func mockHostStorageSet(t *testing.T, hosts string, pbResp *ctlpb.StorageScanRes
 	if err := convert.Types(pbResp.GetScm().GetNamespaces(), &hss.HostStorage.ScmNamespaces); err != nil {
 		t.Fatal(err)
 	}
+	if pbResp.GetNvme().GetCapabilities() != nil {
+		hss.HostStorage.NvmeCapabilities = new(storage.BdevCapabilities)
+		if err := convert.Types(pbResp.GetNvme().GetCapabilities(), hss.HostStorage.NvmeCapabilities); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(pbResp.GetOwners()) > 0 {
+		hss.HostStorage.DeviceOwners = newDeviceOwners(pbResp.GetOwners())
+	}
 
 	return hss
 }
@@ -411,6 +421,26 @@ func MockServerScanResp(t *testing.T, variant string) *ctlpb.StorageScanResp {
 			Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
 			Error:  "nvme scan failed",
 		}
+	case "withCapsA":
+		ssr.Nvme.Capabilities = &ctlpb.BdevCapabilities{
+			SpdkVersion: "v21.07",
+			DpdkVersion: "21.05.0",
+			Vmd:         true,
+			VmdEnabled:  true,
+			Zns:         true,
+			Cmb:         true,
+		}
+	case "withCapsB":
+		ssr.Nvme.Capabilities = &ctlpb.BdevCapabilities{
+			SpdkVersion: "v20.01",
+			DpdkVersion: "19.11.0",
+			Vmd:         true,
+		}
+	case "withOwners":
+		ssr.Owners = []*ctlpb.DeviceOwner{
+			{Device: "/dev/pmem0", Class: "dcpm", Instanceidx: 0},
+			{Device: "0000:81:00.0", Class: "nvme", Instanceidx: 0},
+		}
 	case "standard":
 	default:
 		t.Fatalf("MockServerScanResp(): variant %s unrecognized", variant)
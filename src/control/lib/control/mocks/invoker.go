@@ -0,0 +1,197 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+// Package mocks provides test doubles for the DAOS control API so that tools
+// embedding lib/control can be unit tested without a running DAOS system.
+//
+// An Invoker is passed to the control API functions in place of a
+// control.Client and returns the responses registered for each request type:
+//
+//	inv := mocks.NewInvoker(nil)
+//	inv.SetResponse((*control.SystemQueryReq)(nil),
+//		mocks.NewSystemQuery().AddMember(0, "host1:10001", system.MemberStateJoined).Build())
+//	resp, err := control.SystemQuery(ctx, inv, &control.SystemQueryReq{})
+package mocks
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// DebugLogger defines the logging interface required by an Invoker.
+type DebugLogger interface {
+	Debug(string)
+	Debugf(string, ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string)                  {}
+func (nopLogger) Debugf(string, ...interface{}) {}
+
+type mockResult struct {
+	resp *control.UnaryResponse
+	err  error
+}
+
+// Invoker implements the control.Invoker interface, returning the responses
+// registered for the type of each request and recording the requests invoked.
+// It is safe for concurrent use.
+type Invoker struct {
+	sync.Mutex
+	log      DebugLogger
+	sys      string
+	results  map[reflect.Type][]*mockResult
+	requests []control.UnaryRequest
+}
+
+var _ control.Invoker = (*Invoker)(nil)
+
+// NewInvoker returns an Invoker with no registered responses. If log is nil,
+// debug messages are discarded.
+func NewInvoker(log DebugLogger) *Invoker {
+	if log == nil {
+		log = nopLogger{}
+	}
+
+	return &Invoker{
+		log:     log,
+		sys:     build.DefaultSystemName,
+		results: make(map[reflect.Type][]*mockResult),
+	}
+}
+
+// SetSystem sets the system name returned by the Invoker.
+func (mi *Invoker) SetSystem(sys string) *Invoker {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.sys = sys
+	return mi
+}
+
+func (mi *Invoker) addResult(req control.UnaryRequest, result *mockResult) *Invoker {
+	mi.Lock()
+	defer mi.Unlock()
+
+	reqType := reflect.TypeOf(req)
+	mi.results[reqType] = append(mi.results[reqType], result)
+	return mi
+}
+
+// SetResponse registers the response to be returned for requests of the same
+// type as req, which may be a typed nil pointer e.g. (*control.PoolQueryReq)(nil).
+// If several responses are registered for a type they are returned in turn,
+// the last being returned for any further requests.
+func (mi *Invoker) SetResponse(req control.UnaryRequest, resp *control.UnaryResponse) *Invoker {
+	return mi.addResult(req, &mockResult{resp: resp})
+}
+
+// SetError registers an error to be returned for requests of the same type
+// as req, as if the request could not be sent.
+func (mi *Invoker) SetError(req control.UnaryRequest, err error) *Invoker {
+	return mi.addResult(req, &mockResult{err: err})
+}
+
+// SetMSUnavailable registers responses for the requests made by
+// control.StorageFormat to verify that the management service is not
+// running, allowing a format to proceed.
+func (mi *Invoker) SetMSUnavailable() *Invoker {
+	mi.SetResponse((*control.SystemQueryReq)(nil), MSErrorResponse(system.ErrRaftUnavail))
+	return mi.SetResponse((*control.SystemLockReq)(nil), MSErrorResponse(system.ErrRaftUnavail))
+}
+
+// Requests returns the requests invoked so far, in order.
+func (mi *Invoker) Requests() []control.UnaryRequest {
+	mi.Lock()
+	defer mi.Unlock()
+
+	return append([]control.UnaryRequest{}, mi.requests...)
+}
+
+// nextResult records the request and returns the result registered for its
+// type.
+func (mi *Invoker) nextResult(req control.UnaryRequest) (*mockResult, error) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.requests = append(mi.requests, req)
+
+	reqType := reflect.TypeOf(req)
+	queue := mi.results[reqType]
+	if len(queue) == 0 {
+		return nil, errors.Errorf("no mock response registered for %s", reqType)
+	}
+	if len(queue) > 1 {
+		mi.results[reqType] = queue[1:]
+	}
+
+	return queue[0], nil
+}
+
+// mockFor returns a control.MockInvoker which returns the result registered
+// for the request, so that the request is processed as it would be by a
+// control.Client.
+func (mi *Invoker) mockFor(req control.UnaryRequest) (*control.MockInvoker, error) {
+	result, err := mi.nextResult(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return control.NewMockInvoker(mi.log, &control.MockInvokerConfig{
+		Sys:           mi.GetSystem(),
+		UnaryResponse: result.resp,
+		UnaryError:    result.err,
+	}), nil
+}
+
+// Debug implements control.Invoker.
+func (mi *Invoker) Debug(msg string) {
+	mi.log.Debug(msg)
+}
+
+// Debugf implements control.Invoker.
+func (mi *Invoker) Debugf(fmtStr string, args ...interface{}) {
+	mi.log.Debugf(fmtStr, args...)
+}
+
+// GetSystem implements control.Invoker.
+func (mi *Invoker) GetSystem() string {
+	mi.Lock()
+	defer mi.Unlock()
+
+	return mi.sys
+}
+
+// InvokeUnaryRPC implements control.Invoker.
+func (mi *Invoker) InvokeUnaryRPC(ctx context.Context, req control.UnaryRequest) (*control.UnaryResponse, error) {
+	inv, err := mi.mockFor(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.InvokeUnaryRPC(ctx, req)
+}
+
+// InvokeUnaryRPCAsync implements control.Invoker.
+func (mi *Invoker) InvokeUnaryRPCAsync(ctx context.Context, req control.UnaryRequest) (control.HostResponseChan, error) {
+	inv, err := mi.mockFor(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.InvokeUnaryRPCAsync(ctx, req)
+}
+
+// SetConfig implements control.Invoker.
+func (mi *Invoker) SetConfig(_ *control.Config) {}
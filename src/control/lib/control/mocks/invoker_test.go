@@ -0,0 +1,112 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestMocks_Invoker(t *testing.T) {
+	for name, tc := range map[string]struct {
+		setup     func(*Invoker)
+		expLeader []string
+		expErr    error
+	}{
+		"no response registered": {
+			setup:  func(*Invoker) {},
+			expErr: errors.New("no mock response registered for *control.LeaderQueryReq"),
+		},
+		"response": {
+			setup: func(mi *Invoker) {
+				mi.SetResponse((*control.LeaderQueryReq)(nil),
+					MSResponse(&mgmtpb.LeaderQueryResp{CurrentLeader: "host1"}))
+			},
+			expLeader: []string{"host1", "host1"},
+		},
+		"responses returned in turn": {
+			setup: func(mi *Invoker) {
+				mi.SetResponse((*control.LeaderQueryReq)(nil),
+					MSResponse(&mgmtpb.LeaderQueryResp{CurrentLeader: "host1"}))
+				mi.SetResponse((*control.LeaderQueryReq)(nil),
+					MSResponse(&mgmtpb.LeaderQueryResp{CurrentLeader: "host2"}))
+			},
+			expLeader: []string{"host1", "host2"},
+		},
+		"invoke error": {
+			setup: func(mi *Invoker) {
+				mi.SetError((*control.LeaderQueryReq)(nil), errors.New("send failed"))
+			},
+			expErr: errors.New("send failed"),
+		},
+		"remote error": {
+			setup: func(mi *Invoker) {
+				mi.SetResponse((*control.LeaderQueryReq)(nil),
+					MSErrorResponse(errors.New("remote failed")))
+			},
+			expErr: errors.New("remote failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewInvoker(log)
+			tc.setup(mi)
+
+			var gotLeader []string
+			for i := 0; i < 2; i++ {
+				resp, err := control.LeaderQuery(context.TODO(), mi, new(control.LeaderQueryReq))
+				common.CmpErr(t, tc.expErr, err)
+				if tc.expErr != nil {
+					return
+				}
+				gotLeader = append(gotLeader, resp.Leader)
+			}
+			common.AssertStringsEqual(t, tc.expLeader, gotLeader, "unexpected leaders")
+			common.AssertEqual(t, 2, len(mi.Requests()), "unexpected number of requests")
+		})
+	}
+}
+
+func TestMocks_Invoker_System(t *testing.T) {
+	mi := NewInvoker(nil)
+	common.AssertEqual(t, build.DefaultSystemName, mi.GetSystem(), "unexpected default system")
+
+	mi.SetSystem("test_sys").
+		SetResponse((*control.SystemQueryReq)(nil), NewSystemQuery().Build())
+	if _, err := control.SystemQuery(context.TODO(), mi, new(control.SystemQueryReq)); err != nil {
+		t.Fatal(err)
+	}
+
+	common.AssertEqual(t, "test_sys", mi.GetSystem(), "unexpected system")
+	reqs := mi.Requests()
+	common.AssertEqual(t, 1, len(reqs), "unexpected number of requests")
+	if _, ok := reqs[0].(*control.SystemQueryReq); !ok {
+		t.Fatalf("unexpected request type %T", reqs[0])
+	}
+}
+
+func TestMocks_Invoker_SetMSUnavailable(t *testing.T) {
+	mi := NewInvoker(nil).SetMSUnavailable()
+
+	_, err := control.SystemQuery(context.TODO(), mi, &control.SystemQueryReq{
+		FailOnUnavailable: true,
+	})
+	if !system.IsUnavailable(err) {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package mocks
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// MSResponse returns a response to a management service request containing
+// the supplied message.
+func MSResponse(msg proto.Message) *control.UnaryResponse {
+	return control.MockMSResponse("", nil, msg)
+}
+
+// MSErrorResponse returns a response to a management service request which
+// failed with the supplied error, e.g. system.ErrRaftUnavail.
+func MSErrorResponse(err error) *control.UnaryResponse {
+	return control.MockMSResponse("", err, nil)
+}
+
+// hostResponses accumulates a response message or error for each host in
+// the order in which the hosts were added.
+type hostResponses struct {
+	responses []*control.HostResponse
+}
+
+func (hr *hostResponses) add(addr string, msg proto.Message, err error) {
+	hr.responses = append(hr.responses, &control.HostResponse{
+		Addr:    addr,
+		Message: msg,
+		Error:   err,
+	})
+}
+
+func (hr *hostResponses) build() *control.UnaryResponse {
+	return &control.UnaryResponse{
+		Responses: hr.responses,
+	}
+}
+
+// StorageScanBuilder builds the response to a control.StorageScanReq.
+type StorageScanBuilder struct {
+	hostResponses
+	err error
+}
+
+// NewStorageScan returns a StorageScanBuilder with no hosts.
+func NewStorageScan() *StorageScanBuilder {
+	return new(StorageScanBuilder)
+}
+
+// AddHost adds the results of a storage scan of the host.
+func (b *StorageScanBuilder) AddHost(addr string, nvme storage.NvmeControllers, scm storage.ScmModules, pmem storage.ScmNamespaces) *StorageScanBuilder {
+	pbResp := &ctlpb.StorageScanResp{
+		Nvme: new(ctlpb.ScanNvmeResp),
+		Scm:  new(ctlpb.ScanScmResp),
+	}
+	for _, err := range []error{
+		convert.Types(nvme, &pbResp.Nvme.Ctrlrs),
+		convert.Types(scm, &pbResp.Scm.Modules),
+		convert.Types(pmem, &pbResp.Scm.Namespaces),
+	} {
+		if err != nil && b.err == nil {
+			b.err = err
+		}
+	}
+
+	b.add(addr, pbResp, nil)
+	return b
+}
+
+// AddStandardHost adds a host with one NVMe SSD, one SCM module and one PMem
+// namespace.
+func (b *StorageScanBuilder) AddStandardHost(addr string) *StorageScanBuilder {
+	return b.AddHost(addr,
+		storage.NvmeControllers{storage.MockNvmeController()},
+		storage.ScmModules{storage.MockScmModule()},
+		storage.ScmNamespaces{storage.MockScmNamespace()})
+}
+
+// AddHostError adds a host which failed to respond to the scan.
+func (b *StorageScanBuilder) AddHostError(addr string, err error) *StorageScanBuilder {
+	b.add(addr, nil, err)
+	return b
+}
+
+// Build returns the response, or an error if the storage details of any host
+// could not be converted.
+func (b *StorageScanBuilder) Build() (*control.UnaryResponse, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.build(), nil
+}
+
+// StorageFormatBuilder builds the response to a control.StorageFormatReq.
+type StorageFormatBuilder struct {
+	hostResponses
+	hosts map[string]*ctlpb.StorageFormatResp
+}
+
+// NewStorageFormat returns a StorageFormatBuilder with no hosts.
+func NewStorageFormat() *StorageFormatBuilder {
+	return &StorageFormatBuilder{
+		hosts: make(map[string]*ctlpb.StorageFormatResp),
+	}
+}
+
+func (b *StorageFormatBuilder) host(addr string) *ctlpb.StorageFormatResp {
+	if resp, found := b.hosts[addr]; found {
+		return resp
+	}
+
+	resp := new(ctlpb.StorageFormatResp)
+	b.hosts[addr] = resp
+	b.add(addr, resp, nil)
+	return resp
+}
+
+// AddHost adds a host on which the SCM mountpoints and NVMe SSDs with the
+// given PCI addresses were formatted successfully.
+func (b *StorageFormatBuilder) AddHost(addr string, mounts []string, pciAddrs []string) *StorageFormatBuilder {
+	resp := b.host(addr)
+	for i, mnt := range mounts {
+		resp.Mrets = append(resp.Mrets, &ctlpb.ScmMountResult{
+			Mntpoint:    mnt,
+			Instanceidx: uint32(i),
+			State:       new(ctlpb.ResponseState),
+		})
+	}
+	for _, pciAddr := range pciAddrs {
+		resp.Crets = append(resp.Crets, &ctlpb.NvmeControllerResult{
+			PciAddr: pciAddr,
+			State:   new(ctlpb.ResponseState),
+		})
+	}
+
+	return b
+}
+
+// AddScmFailure adds a failure to format the SCM mountpoint on the host.
+func (b *StorageFormatBuilder) AddScmFailure(addr, mount string, err error) *StorageFormatBuilder {
+	resp := b.host(addr)
+	resp.Mrets = append(resp.Mrets, &ctlpb.ScmMountResult{
+		Mntpoint:    mount,
+		Instanceidx: uint32(len(resp.Mrets)),
+		State: &ctlpb.ResponseState{
+			Status: ctlpb.ResponseStatus_CTL_ERR_SCM,
+			Error:  err.Error(),
+		},
+	})
+
+	return b
+}
+
+// AddNvmeFailure adds a failure to format the NVMe SSD on the host.
+func (b *StorageFormatBuilder) AddNvmeFailure(addr, pciAddr string, err error) *StorageFormatBuilder {
+	resp := b.host(addr)
+	resp.Crets = append(resp.Crets, &ctlpb.NvmeControllerResult{
+		PciAddr: pciAddr,
+		State: &ctlpb.ResponseState{
+			Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
+			Error:  err.Error(),
+		},
+	})
+
+	return b
+}
+
+// AddHostError adds a host which failed to respond to the format.
+func (b *StorageFormatBuilder) AddHostError(addr string, err error) *StorageFormatBuilder {
+	b.add(addr, nil, err)
+	return b
+}
+
+// Build returns the response.
+func (b *StorageFormatBuilder) Build() *control.UnaryResponse {
+	return b.build()
+}
+
+// SystemQueryBuilder builds the response to a control.SystemQueryReq.
+type SystemQueryBuilder struct {
+	resp *mgmtpb.SystemQueryResp
+}
+
+// NewSystemQuery returns a SystemQueryBuilder with no members.
+func NewSystemQuery() *SystemQueryBuilder {
+	return &SystemQueryBuilder{
+		resp: new(mgmtpb.SystemQueryResp),
+	}
+}
+
+// AddMember adds a system member with the given rank, control address and
+// state.
+func (b *SystemQueryBuilder) AddMember(rank system.Rank, addr string, state system.MemberState) *SystemQueryBuilder {
+	b.resp.Members = append(b.resp.Members, &mgmtpb.SystemMember{
+		Rank:  rank.Uint32(),
+		Uuid:  common.MockUUID(int32(rank)),
+		Addr:  addr,
+		State: state.String(),
+	})
+
+	return b
+}
+
+// SetAbsent sets the ranks and hosts that were requested but are not
+// system members, as ranged lists e.g. "[4-7]".
+func (b *SystemQueryBuilder) SetAbsent(ranks, hosts string) *SystemQueryBuilder {
+	b.resp.Absentranks = ranks
+	b.resp.Absenthosts = hosts
+
+	return b
+}
+
+// Build returns the response.
+func (b *SystemQueryBuilder) Build() *control.UnaryResponse {
+	return MSResponse(b.resp)
+}
@@ -0,0 +1,108 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestMocks_StorageScanBuilder(t *testing.T) {
+	ur, err := NewStorageScan().
+		AddStandardHost("host1").
+		AddStandardHost("host2").
+		AddHostError("host3", errors.New("scan failed")).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mi := NewInvoker(nil).SetResponse((*control.StorageScanReq)(nil), ur)
+
+	resp, err := control.StorageScan(context.TODO(), mi, new(control.StorageScanReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// hosts with identical storage are grouped together
+	common.AssertEqual(t, 1, len(resp.HostStorage), "unexpected number of host storage sets")
+	for _, hss := range resp.HostStorage {
+		common.AssertEqual(t, "host[1-2]", hss.HostSet.String(), "unexpected hosts")
+		common.AssertEqual(t, 1, len(hss.HostStorage.NvmeDevices), "unexpected NVMe devices")
+		common.AssertEqual(t, 1, len(hss.HostStorage.ScmModules), "unexpected SCM modules")
+		common.AssertEqual(t, 1, len(hss.HostStorage.ScmNamespaces), "unexpected PMem namespaces")
+	}
+	common.AssertEqual(t, 1, len(resp.HostErrors), "unexpected number of host errors")
+	if _, found := resp.HostErrors["scan failed"]; !found {
+		t.Fatalf("expected scan failure, got %+v", resp.HostErrors)
+	}
+}
+
+func TestMocks_StorageFormatBuilder(t *testing.T) {
+	mi := NewInvoker(nil).
+		SetMSUnavailable().
+		SetResponse((*control.StorageFormatReq)(nil), NewStorageFormat().
+			AddHost("host1", []string{"/mnt/daos0"}, []string{"0000:81:00.0"}).
+			AddHost("host2", []string{"/mnt/daos0"}, nil).
+			AddNvmeFailure("host2", "0000:81:00.0", errors.New("nvme format failed")).
+			AddScmFailure("host3", "/mnt/daos0", errors.New("scm format failed")).
+			AddHostError("host4", errors.New("connection refused")).
+			Build())
+
+	resp, err := control.StorageFormat(context.TODO(), mi, new(control.StorageFormatReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every host which responded has a (possibly empty) set of results
+	common.AssertEqual(t, 3, len(resp.HostStorage), "unexpected number of host storage sets")
+	var responded int
+	for _, hss := range resp.HostStorage {
+		responded += hss.HostSet.Count()
+	}
+	common.AssertEqual(t, 3, responded, "unexpected number of hosts responding")
+	common.AssertEqual(t, 3, len(resp.HostErrors), "unexpected number of host errors")
+	for _, errStr := range []string{"nvme format failed", "scm format failed", "connection refused"} {
+		if _, found := resp.HostErrors[errStr]; !found {
+			t.Fatalf("expected %q host error, got %+v", errStr, resp.HostErrors)
+		}
+	}
+}
+
+func TestMocks_SystemQueryBuilder(t *testing.T) {
+	mi := NewInvoker(nil).SetResponse((*control.SystemQueryReq)(nil), NewSystemQuery().
+		AddMember(0, "10.0.0.1:10001", system.MemberStateJoined).
+		AddMember(1, "10.0.0.2:10001", system.MemberStateStopped).
+		SetAbsent("[4-7]", "").
+		Build())
+
+	resp, err := control.SystemQuery(context.TODO(), mi, new(control.SystemQueryReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	common.AssertEqual(t, 2, len(resp.Members), "unexpected number of members")
+	for i, exp := range []struct {
+		rank  system.Rank
+		addr  string
+		state system.MemberState
+	}{
+		{0, "10.0.0.1:10001", system.MemberStateJoined},
+		{1, "10.0.0.2:10001", system.MemberStateStopped},
+	} {
+		m := resp.Members[i]
+		common.AssertEqual(t, exp.rank, m.Rank, "unexpected rank")
+		common.AssertEqual(t, exp.addr, m.Addr.String(), "unexpected address")
+		common.AssertEqual(t, exp.state, m.State(), "unexpected state")
+	}
+	common.AssertEqual(t, "4-7", resp.AbsentRanks.String(), "unexpected absent ranks")
+}
@@ -31,6 +31,10 @@ type HostFabricInterface struct {
 	NumaNode    uint32
 	Priority    uint32
 	NetDevClass uint32
+	Alias       string
+	PhysFn      string // parent physical function of an SR-IOV virtual function
+	LinkState   string
+	LinkSpeed   uint32 // Mb/s, zero if unknown
 }
 
 func (hfi *HostFabricInterface) String() string {
@@ -63,6 +67,9 @@ func (hf *HostFabric) AddInterface(hfi *HostFabricInterface) {
 type HostFabricSet struct {
 	HostFabric *HostFabric
 	HostSet    *hostlist.HostSet
+	// HostLabels contains any site-specific labels reported by each host
+	// in the set.
+	HostLabels map[string]map[string]string `json:",omitempty"`
 }
 
 // NewHostFabricSet returns an initialized HostFabricSet for the given
@@ -141,6 +148,23 @@ func (nsr *NetworkScanResp) addHostResponse(hr *HostResponse) (err error) {
 		return err
 	}
 
+	if len(pbResp.GetLabels()) == 0 {
+		return
+	}
+	hk, err := hf.HashKey()
+	if err != nil {
+		return err
+	}
+	hfs := nsr.HostFabrics[hk]
+	if hfs.HostLabels == nil {
+		hfs.HostLabels = make(map[string]map[string]string)
+	}
+	labels := make(map[string]string)
+	for _, label := range pbResp.GetLabels() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	hfs.HostLabels[hr.Addr] = labels
+
 	return
 }
 
@@ -200,6 +224,9 @@ type (
 		retryableRequest
 		System   string
 		AllRanks bool
+		// AgentVersion is set by agents so that the MS can record the
+		// versions of the agents it serves.
+		AgentVersion string
 	}
 
 	// PrimaryServiceRank provides a rank->uri mapping for a DAOS
@@ -242,8 +269,9 @@ func (gair *GetAttachInfoResp) String() string {
 func GetAttachInfo(ctx context.Context, rpcClient UnaryInvoker, req *GetAttachInfoReq) (*GetAttachInfoResp, error) {
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).GetAttachInfo(ctx, &mgmtpb.GetAttachInfoReq{
-			Sys:      req.getSystem(rpcClient),
-			AllRanks: req.AllRanks,
+			Sys:          req.getSystem(rpcClient),
+			AllRanks:     req.AllRanks,
+			AgentVersion: req.AgentVersion,
 		})
 	})
 	req.retryTestFn = func(err error, _ uint) bool {
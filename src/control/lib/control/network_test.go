@@ -23,6 +23,7 @@ import (
 type mockFabricScan struct {
 	Hosts  string
 	Fabric *HostFabric
+	Labels map[string]map[string]string
 }
 
 func mockHostFabricMap(t *testing.T, scans ...*mockFabricScan) HostFabricMap {
@@ -32,6 +33,7 @@ func mockHostFabricMap(t *testing.T, scans ...*mockFabricScan) HostFabricMap {
 		hfs := &HostFabricSet{
 			HostFabric: scan.Fabric,
 			HostSet:    mockHostSet(t, scan.Hosts),
+			HostLabels: scan.Labels,
 		}
 
 		hk, err := hfs.HostFabric.HashKey()
@@ -251,6 +253,63 @@ func TestControl_NetworkScan(t *testing.T) {
 				}),
 			},
 		},
+		"two hosts; aliases and labels": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+							Message: &ctlpb.NetworkScanResp{
+								Interfaces: []*ctlpb.FabricInterface{
+									{
+										Provider: "test-provider",
+										Device:   "test-device",
+										Alias:    "hsn0",
+									},
+								},
+								Labels: []*ctlpb.HostLabel{
+									{Name: "rack", Value: "r1"},
+								},
+							},
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.NetworkScanResp{
+								Interfaces: []*ctlpb.FabricInterface{
+									{
+										Provider: "test-provider",
+										Device:   "test-device",
+										Alias:    "hsn0",
+									},
+								},
+								Labels: []*ctlpb.HostLabel{
+									{Name: "rack", Value: "r2"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &NetworkScanResp{
+				HostFabrics: mockHostFabricMap(t, &mockFabricScan{
+					Hosts: "host[1-2]",
+					Fabric: &HostFabric{
+						Interfaces: []*HostFabricInterface{
+							{
+								Provider: "test-provider",
+								Device:   "test-device",
+								Alias:    "hsn0",
+							},
+						},
+						Providers: []string{"test-provider"},
+					},
+					Labels: map[string]map[string]string{
+						"host1": {"rack": "r1"},
+						"host2": {"rack": "r2"},
+					},
+				}),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
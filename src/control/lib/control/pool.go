@@ -8,6 +8,7 @@ package control
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/security/auth"
@@ -130,19 +132,54 @@ type (
 		TotalBytes uint64
 		ScmRatio   float64
 		NumRanks   uint32
+		// MinDomains is the minimum number of fault domains that the
+		// pool ranks must span.
+		MinDomains uint32
+		// RankStrategy selects the target ranks when they are not
+		// supplied (spread, pack or balanced).
+		RankStrategy string
 		// manual params
 		Ranks     []system.Rank
 		ScmBytes  uint64
 		NvmeBytes uint64
+		// Properties are set on the new pool, overriding any system
+		// defaults that are not enforced.
+		Properties []*PoolProperty
+	}
+
+	// PoolProperty is a pool property name and value.
+	PoolProperty struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// PoolFaultDomain describes the pool target ranks within a fault domain.
+	PoolFaultDomain struct {
+		Domain string   `json:"domain"`
+		Ranks  []uint32 `json:"ranks"`
+	}
+
+	// PoolRankChoice describes why a target rank was selected for a pool.
+	PoolRankChoice struct {
+		Rank      uint32 `json:"rank"`
+		Domain    string `json:"domain"`
+		FreeBytes uint64 `json:"free_bytes"`
+		Failures  uint32 `json:"failures"`
+		Reason    string `json:"reason"`
 	}
 
 	// PoolCreateResp contains the response from a pool create request.
 	PoolCreateResp struct {
-		UUID      string   `json:"uuid"`
-		SvcReps   []uint32 `json:"svc_reps"`
-		TgtRanks  []uint32 `json:"tgt_ranks"`
-		ScmBytes  uint64   `json:"scm_bytes"`
-		NvmeBytes uint64   `json:"nvme_bytes"`
+		UUID         string             `json:"uuid"`
+		SvcReps      []uint32           `json:"svc_reps"`
+		TgtRanks     []uint32           `json:"tgt_ranks"`
+		ScmBytes     uint64             `json:"scm_bytes"`
+		NvmeBytes    uint64             `json:"nvme_bytes"`
+		FaultDomains []*PoolFaultDomain `json:"fault_domains"`
+		Properties   []*PoolProperty    `json:"properties"`
+		RankStrategy string             `json:"rank_strategy"`
+		RankChoices  []*PoolRankChoice  `json:"rank_choices"`
+		Timings      []*PhaseTiming     `json:"timings"`
 	}
 )
 
@@ -239,12 +276,15 @@ func PoolResolveID(ctx context.Context, rpcClient UnaryInvoker, req *PoolResolve
 	return prid, nil
 }
 
-// PoolDestroyReq contains the parameters for a pool destroy request.
+// PoolDestroyReq contains the parameters for a pool destroy request. If Delay
+// is set, the pool is disabled and only destroyed once the delay has elapsed,
+// allowing it to be restored in the meantime.
 type PoolDestroyReq struct {
 	msRequest
 	unaryRequest
 	UUID  string
 	Force bool
+	Delay time.Duration
 }
 
 // PoolDestroy performs a pool destroy operation on a DAOS Management Server instance.
@@ -252,11 +292,17 @@ func PoolDestroy(ctx context.Context, rpcClient UnaryInvoker, req *PoolDestroyRe
 	if err := checkUUID(req.UUID); err != nil {
 		return err
 	}
+	if req.Delay < 0 {
+		return errors.New("pool destroy delay must not be negative")
+	}
+	// round up so that a short delay does not result in an immediate destroy
+	delaySecs := uint64((req.Delay + time.Second - 1) / time.Second)
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).PoolDestroy(ctx, &mgmtpb.PoolDestroyReq{
 			Sys:   req.getSystem(rpcClient),
 			Uuid:  req.UUID,
 			Force: req.Force,
+			Delay: delaySecs,
 		})
 	})
 
@@ -275,12 +321,48 @@ func PoolDestroy(ctx context.Context, rpcClient UnaryInvoker, req *PoolDestroyRe
 	return nil
 }
 
-// PoolEvictReq contains the parameters for a pool evict request.
+// PoolRestoreReq contains the parameters for a pool restore request.
+type PoolRestoreReq struct {
+	msRequest
+	unaryRequest
+	UUID string
+}
+
+// PoolRestore re-enables a pool that is pending a delayed destroy.
+func PoolRestore(ctx context.Context, rpcClient UnaryInvoker, req *PoolRestoreReq) error {
+	if err := checkUUID(req.UUID); err != nil {
+		return err
+	}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).PoolRestore(ctx, &mgmtpb.PoolRestoreReq{
+			Sys:  req.getSystem(rpcClient),
+			Uuid: req.UUID,
+		})
+	})
+
+	rpcClient.Debugf("Restore DAOS pool request: %v\n", req)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	msResp, err := ur.getMSResponse()
+	if err != nil {
+		return errors.Wrap(err, "pool restore failed")
+	}
+	rpcClient.Debugf("Restore DAOS pool response: %s\n", msResp)
+
+	return nil
+}
+
+// PoolEvictReq contains the parameters for a pool evict request. If Handles
+// or Machine is set, only the matching pool handles are evicted.
 type PoolEvictReq struct {
 	msRequest
 	unaryRequest
 	UUID    string
 	Handles []string
+	Machine string
 }
 
 // PoolEvict performs a pool connection evict operation on a DAOS Management Server instance.
@@ -288,12 +370,21 @@ func PoolEvict(ctx context.Context, rpcClient UnaryInvoker, req *PoolEvictReq) e
 	if err := checkUUID(req.UUID); err != nil {
 		return err
 	}
+	for _, hdl := range req.Handles {
+		if err := checkUUID(hdl); err != nil {
+			return err
+		}
+	}
+	if len(req.Handles) > 0 && req.Machine != "" {
+		return errors.New("pool handles and machine may not both be specified")
+	}
 
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).PoolEvict(ctx, &mgmtpb.PoolEvictReq{
 			Sys:     req.getSystem(rpcClient),
 			Uuid:    req.UUID,
 			Handles: req.Handles,
+			Machine: req.Machine,
 		})
 	})
 
@@ -358,9 +449,66 @@ type (
 		Status int32  `json:"status"`
 		UUID   string `json:"uuid"`
 		PoolInfo
+		Targets []*PoolTargetInfo `json:"targets,omitempty"`
+	}
+
+	// PoolTargetInfo contains the state and space usage of a single pool
+	// target.
+	PoolTargetInfo struct {
+		Rank      system.Rank       `json:"rank"`
+		TgtIdx    uint32            `json:"tgt_idx"`
+		State     string            `json:"state"`
+		ScmTotal  uint64            `json:"scm_total"`
+		ScmFree   uint64            `json:"scm_free"`
+		NvmeTotal uint64            `json:"nvme_total"`
+		NvmeFree  uint64            `json:"nvme_free"`
+		Device    *PoolTargetDevice `json:"device,omitempty"`
+	}
+
+	// PoolTargetDevice describes the storage devices backing a pool
+	// target. NVMe fields are empty if the target has no NVMe SSD.
+	PoolTargetDevice struct {
+		NvmeUUID     string `json:"nvme_uuid,omitempty"`
+		NvmePciAddr  string `json:"nvme_pci_addr,omitempty"`
+		NvmeSerial   string `json:"nvme_serial,omitempty"`
+		ScmBlockdev  string `json:"scm_blockdev,omitempty"`
+		ScmNamespace string `json:"scm_namespace,omitempty"`
+		ScmMount     string `json:"scm_mount"`
+	}
+
+	// PoolQueryTargetsReq contains the parameters for a pool target query
+	// request.
+	PoolQueryTargetsReq struct {
+		unaryRequest
+		UUID        string
+		Rank        system.Rank
+		ShowDevices bool
+	}
+
+	// PoolQueryTargetsResp contains the results of a pool target query,
+	// ordered by rank and target index.
+	PoolQueryTargetsResp struct {
+		HostErrorsResp
+		Targets []*PoolTargetInfo `json:"targets"`
 	}
 )
 
+const (
+	// PoolTargetStateUpIn indicates that the target is up and in use.
+	PoolTargetStateUpIn = "UPIN"
+	// PoolTargetStateUp indicates that the target is being reintegrated.
+	PoolTargetStateUp = "UP"
+	// PoolTargetStateNew indicates that the target is being added.
+	PoolTargetStateNew = "NEW"
+	// PoolTargetStateDrain indicates that the target is being drained.
+	PoolTargetStateDrain = "DRAIN"
+	// PoolTargetStateDown indicates that the target is unavailable and
+	// may need rebuild.
+	PoolTargetStateDown = "DOWN"
+	// PoolTargetStateDownOut indicates that the target is unavailable.
+	PoolTargetStateDownOut = "DOWNOUT"
+)
+
 const (
 	// PoolRebuildStateIdle indicates that the rebuild process is idle.
 	PoolRebuildStateIdle PoolRebuildState = iota
@@ -426,6 +574,103 @@ func PoolQuery(ctx context.Context, rpcClient UnaryInvoker, req *PoolQueryReq) (
 	return pqr, convertMSResponse(ur, pqr)
 }
 
+// IsDown returns true if the target is not available for I/O.
+func (pti *PoolTargetInfo) IsDown() bool {
+	return pti.State == PoolTargetStateDown || pti.State == PoolTargetStateDownOut
+}
+
+// UsedPercent returns the percentage of target space in use. NVMe space is
+// reported if the target has any, otherwise SCM space is reported.
+func (pti *PoolTargetInfo) UsedPercent() float64 {
+	total, free := pti.NvmeTotal, pti.NvmeFree
+	if total == 0 {
+		total, free = pti.ScmTotal, pti.ScmFree
+	}
+	if total == 0 || free > total {
+		return 0
+	}
+	return float64(total-free) * 100 / float64(total)
+}
+
+func (pqtr *PoolQueryTargetsResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.PoolQueryTargetsResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	for _, rr := range pbResp.GetRanks() {
+		var tgts []*PoolTargetInfo
+		if err := convert.Types(rr.GetTargets(), &tgts); err != nil {
+			return pqtr.addHostError(hr.Addr, err)
+		}
+		devices := make(map[uint32]*PoolTargetDevice)
+		for _, pbDev := range rr.GetDevices() {
+			dev := new(PoolTargetDevice)
+			if err := convert.Types(pbDev, dev); err != nil {
+				return pqtr.addHostError(hr.Addr, err)
+			}
+			devices[pbDev.GetTgtIdx()] = dev
+		}
+		for _, tgt := range tgts {
+			tgt.Rank = system.Rank(rr.GetRank())
+			tgt.Device = devices[tgt.TgtIdx]
+		}
+		pqtr.Targets = append(pqtr.Targets, tgts...)
+	}
+
+	return nil
+}
+
+// PoolQueryTargets concurrently retrieves the state and space usage of each
+// target of the specified pool from the I/O Engines running on all hosts
+// supplied in the request's hostlist, or all configured hosts if not
+// explicitly specified.
+func PoolQueryTargets(ctx context.Context, rpcClient UnaryInvoker, req *PoolQueryTargetsReq) (*PoolQueryTargetsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if err := checkUUID(req.UUID); err != nil {
+		return nil, err
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).PoolQueryTargets(ctx, &ctlpb.PoolQueryTargetsReq{
+			Uuid:        req.UUID,
+			Rank:        req.Rank.Uint32(),
+			ShowDevices: req.ShowDevices,
+		})
+	})
+
+	rpcClient.Debugf("Query DAOS pool targets request: %v\n", req)
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pqtr := new(PoolQueryTargetsResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := pqtr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := pqtr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(pqtr.Targets, func(i, j int) bool {
+		if pqtr.Targets[i].Rank != pqtr.Targets[j].Rank {
+			return pqtr.Targets[i].Rank < pqtr.Targets[j].Rank
+		}
+		return pqtr.Targets[i].TgtIdx < pqtr.Targets[j].TgtIdx
+	})
+
+	return pqtr, nil
+}
+
 // PoolSetPropReq contains pool set-prop parameters.
 type PoolSetPropReq struct {
 	msRequest
@@ -0,0 +1,286 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+var (
+	ecSchemeRe = regexp.MustCompile(`^EC_([0-9]+)P([0-9]+)(G[0-9X]+)?$`)
+	rpSchemeRe = regexp.MustCompile(`^RP_([0-9]+)(G[0-9X]+)?$`)
+	noSchemeRe = regexp.MustCompile(`^(NONE|S([0-9]+|X))$`)
+)
+
+type (
+	// RedundancyScheme describes the data and parity (or replica) cells
+	// written for each stripe of an object.
+	RedundancyScheme struct {
+		Name        string `json:"name"`
+		DataCells   uint32 `json:"data_cells"`
+		ParityCells uint32 `json:"parity_cells"`
+	}
+
+	// PoolCheckFitReq contains the parameters for a request to check
+	// whether an additional allocation would fit in a pool.
+	PoolCheckFitReq struct {
+		UUID       string
+		Size       uint64
+		Redundancy *RedundancyScheme
+		// ScmRatio is the fraction of the allocation expected on SCM
+		// when the pool also has NVMe storage.
+		ScmRatio float64
+	}
+
+	// PoolTierFit describes whether an allocation fits in a storage tier.
+	PoolTierFit struct {
+		Required       uint64 `json:"required"`
+		PerTarget      uint64 `json:"per_target"`
+		Free           uint64 `json:"free"`
+		TargetsTooFull int    `json:"targets_too_full"`
+		Fits           bool   `json:"fits"`
+	}
+
+	// PoolRankFit describes whether the share of an allocation placed on
+	// a rank fits in the free space of its targets.
+	PoolRankFit struct {
+		Rank     system.Rank `json:"rank"`
+		Targets  int         `json:"targets"`
+		ScmNeed  uint64      `json:"scm_required"`
+		ScmFree  uint64      `json:"scm_free"`
+		NvmeNeed uint64      `json:"nvme_required"`
+		NvmeFree uint64      `json:"nvme_free"`
+		Fits     bool        `json:"fits"`
+	}
+
+	// PoolCheckFitResp contains the results of a pool allocation fit check.
+	PoolCheckFitResp struct {
+		HostErrorsResp
+		UUID       string            `json:"uuid"`
+		Size       uint64            `json:"size"`
+		Redundancy *RedundancyScheme `json:"redundancy"`
+		Scm        *PoolTierFit      `json:"scm"`
+		Nvme       *PoolTierFit      `json:"nvme"`
+		Ranks      []*PoolRankFit    `json:"ranks"`
+		Problems   []string          `json:"problems,omitempty"`
+		Fits       bool              `json:"fits"`
+	}
+)
+
+// ParseRedundancyScheme parses an object class redundancy scheme such as
+// "EC_4P2", "RP_3" or "none". Any group suffix (e.g. "GX") is ignored as
+// allocations are assumed to be spread across all pool targets.
+func ParseRedundancyScheme(in string) (*RedundancyScheme, error) {
+	name := strings.ToUpper(strings.TrimSpace(in))
+	parse := func(s string) (uint32, error) {
+		val, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid redundancy scheme %q", in)
+		}
+		return uint32(val), nil
+	}
+
+	switch {
+	case name == "" || noSchemeRe.MatchString(name):
+		return &RedundancyScheme{Name: "none", DataCells: 1}, nil
+	case ecSchemeRe.MatchString(name):
+		m := ecSchemeRe.FindStringSubmatch(name)
+		data, err := parse(m[1])
+		if err != nil {
+			return nil, err
+		}
+		parity, err := parse(m[2])
+		if err != nil {
+			return nil, err
+		}
+		if data == 0 {
+			return nil, errors.Errorf("invalid redundancy scheme %q: no data cells", in)
+		}
+		return &RedundancyScheme{
+			Name:        fmt.Sprintf("EC_%dP%d", data, parity),
+			DataCells:   data,
+			ParityCells: parity,
+		}, nil
+	case rpSchemeRe.MatchString(name):
+		m := rpSchemeRe.FindStringSubmatch(name)
+		replicas, err := parse(m[1])
+		if err != nil {
+			return nil, err
+		}
+		if replicas == 0 {
+			return nil, errors.Errorf("invalid redundancy scheme %q: no replicas", in)
+		}
+		return &RedundancyScheme{
+			Name:        fmt.Sprintf("RP_%d", replicas),
+			DataCells:   1,
+			ParityCells: replicas - 1,
+		}, nil
+	default:
+		return nil, errors.Errorf("invalid redundancy scheme %q "+
+			"(expected EC_<data>P<parity>, RP_<replicas> or none)", in)
+	}
+}
+
+// Width returns the number of cells written for each stripe.
+func (rs *RedundancyScheme) Width() uint32 {
+	return rs.DataCells + rs.ParityCells
+}
+
+// RawSize returns the number of bytes consumed by storing size bytes of
+// user data with the redundancy scheme.
+func (rs *RedundancyScheme) RawSize(size uint64) uint64 {
+	data := uint64(rs.DataCells)
+	return (size*uint64(rs.Width()) + data - 1) / data
+}
+
+func (rs *RedundancyScheme) String() string {
+	return rs.Name
+}
+
+func divCeil(a, b uint64) uint64 {
+	if b == 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
+
+// checkPoolFit calculates whether an allocation with the given parameters
+// fits in the free space of the supplied pool targets. The allocation is
+// assumed to be spread evenly across all targets which are up, so the
+// target with the least free space in each tier limits the fit.
+func checkPoolFit(req *PoolCheckFitReq, tgts []*PoolTargetInfo) *PoolCheckFitResp {
+	resp := &PoolCheckFitResp{
+		UUID:       req.UUID,
+		Size:       req.Size,
+		Redundancy: req.Redundancy,
+		Scm:        new(PoolTierFit),
+		Nvme:       new(PoolTierFit),
+	}
+
+	var upTgts []*PoolTargetInfo
+	var nvmeTotal uint64
+	for _, tgt := range tgts {
+		if tgt.IsDown() || tgt.State == PoolTargetStateDrain {
+			continue
+		}
+		upTgts = append(upTgts, tgt)
+		nvmeTotal += tgt.NvmeTotal
+	}
+	if len(upTgts) == 0 {
+		resp.Problems = append(resp.Problems, "pool has no available targets")
+		return resp
+	}
+
+	raw := req.Redundancy.RawSize(req.Size)
+	if nvmeTotal == 0 {
+		resp.Scm.Required = raw
+	} else {
+		resp.Scm.Required = uint64(float64(raw) * req.ScmRatio)
+		resp.Nvme.Required = raw
+	}
+	resp.Scm.PerTarget = divCeil(resp.Scm.Required, uint64(len(upTgts)))
+	resp.Nvme.PerTarget = divCeil(resp.Nvme.Required, uint64(len(upTgts)))
+
+	rankFits := make(map[system.Rank]*PoolRankFit)
+	for _, tgt := range upTgts {
+		rf, found := rankFits[tgt.Rank]
+		if !found {
+			rf = &PoolRankFit{Rank: tgt.Rank, Fits: true}
+			rankFits[tgt.Rank] = rf
+			resp.Ranks = append(resp.Ranks, rf)
+		}
+		rf.Targets++
+		rf.ScmNeed += resp.Scm.PerTarget
+		rf.ScmFree += tgt.ScmFree
+		rf.NvmeNeed += resp.Nvme.PerTarget
+		rf.NvmeFree += tgt.NvmeFree
+
+		resp.Scm.Free += tgt.ScmFree
+		resp.Nvme.Free += tgt.NvmeFree
+		if tgt.ScmFree < resp.Scm.PerTarget {
+			resp.Scm.TargetsTooFull++
+			rf.Fits = false
+		}
+		if tgt.NvmeFree < resp.Nvme.PerTarget {
+			resp.Nvme.TargetsTooFull++
+			rf.Fits = false
+		}
+	}
+
+	for _, tier := range []struct {
+		name string
+		fit  *PoolTierFit
+	}{
+		{"SCM", resp.Scm},
+		{"NVMe", resp.Nvme},
+	} {
+		tier.fit.Fits = tier.fit.TargetsTooFull == 0 && tier.fit.Free >= tier.fit.Required
+		if tier.fit.TargetsTooFull > 0 {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("%s: %d targets have less "+
+				"than the %s required per target", tier.name,
+				tier.fit.TargetsTooFull, humanize.Bytes(tier.fit.PerTarget)))
+		}
+	}
+
+	// each cell of a stripe is placed on a different rank
+	if width := int(req.Redundancy.Width()); len(resp.Ranks) < width {
+		resp.Problems = append(resp.Problems, fmt.Sprintf("%s requires %d ranks, "+
+			"%d available", req.Redundancy, width, len(resp.Ranks)))
+	}
+
+	resp.Fits = len(resp.Problems) == 0
+
+	return resp
+}
+
+// PoolCheckFit reports whether an allocation of the requested size and
+// redundancy scheme would fit in each storage tier and on each rank of the
+// specified pool, based on the current free space of the pool targets.
+// Nothing is allocated.
+func PoolCheckFit(ctx context.Context, rpcClient UnaryInvoker, req *PoolCheckFitReq) (*PoolCheckFitResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if err := checkUUID(req.UUID); err != nil {
+		return nil, err
+	}
+	if req.Size == 0 {
+		return nil, errors.New("allocation size must be greater than zero")
+	}
+	if req.ScmRatio < 0 || req.ScmRatio > 1 {
+		return nil, errors.Errorf("invalid SCM ratio %0.2f", req.ScmRatio)
+	}
+	if req.Redundancy == nil {
+		req.Redundancy = &RedundancyScheme{Name: "none", DataCells: 1}
+	}
+
+	tgtResp, err := PoolQueryTargets(ctx, rpcClient, &PoolQueryTargetsReq{
+		UUID: req.UUID,
+		Rank: system.NilRank,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := checkPoolFit(req, tgtResp.Targets)
+	resp.HostErrorsResp = tgtResp.HostErrorsResp
+	if resp.Errors() != nil {
+		// the free space of targets on failed hosts is unknown
+		resp.Fits = false
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,278 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_ParseRedundancyScheme(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in        string
+		expScheme *RedundancyScheme
+		expErr    error
+	}{
+		"empty": {
+			expScheme: &RedundancyScheme{Name: "none", DataCells: 1},
+		},
+		"single stripe": {
+			in:        "SX",
+			expScheme: &RedundancyScheme{Name: "none", DataCells: 1},
+		},
+		"erasure coded": {
+			in:        "ec_4p2gx",
+			expScheme: &RedundancyScheme{Name: "EC_4P2", DataCells: 4, ParityCells: 2},
+		},
+		"replicated": {
+			in:        "RP_3G1",
+			expScheme: &RedundancyScheme{Name: "RP_3", DataCells: 1, ParityCells: 2},
+		},
+		"no data cells": {
+			in:     "EC_0P2",
+			expErr: errors.New("no data cells"),
+		},
+		"no replicas": {
+			in:     "RP_0",
+			expErr: errors.New("no replicas"),
+		},
+		"unknown": {
+			in:     "mirror",
+			expErr: errors.New("invalid redundancy scheme"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotScheme, gotErr := ParseRedundancyScheme(tc.in)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expScheme, gotScheme); diff != "" {
+				t.Fatalf("unexpected scheme (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_RedundancyScheme_RawSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		scheme  string
+		size    uint64
+		expSize uint64
+	}{
+		"none":             {"none", 100, 100},
+		"replicated":       {"RP_3", 100, 300},
+		"erasure coded":    {"EC_4P2", 100, 150},
+		"rounded up cells": {"EC_8P1", 100, 113},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rs, err := ParseRedundancyScheme(tc.scheme)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expSize, rs.RawSize(tc.size), "unexpected raw size")
+		})
+	}
+}
+
+func TestControl_PoolCheckFit(t *testing.T) {
+	mockPBTargets := func(nvmeFree ...uint64) []*ctlpb.PoolTargetInfo {
+		var tgts []*ctlpb.PoolTargetInfo
+		for i, free := range nvmeFree {
+			state := "UPIN"
+			if free == 0 {
+				state = "DOWN"
+			}
+			tgts = append(tgts, &ctlpb.PoolTargetInfo{
+				TgtIdx:    uint32(i),
+				State:     state,
+				ScmTotal:  1000,
+				ScmFree:   500,
+				NvmeTotal: 10000,
+				NvmeFree:  free,
+			})
+		}
+		return tgts
+	}
+	mockQueryResp := func(ranks ...*ctlpb.PoolQueryTargetsResp_RankResp) *UnaryResponse {
+		return MockMSResponse("host1", nil, &ctlpb.PoolQueryTargetsResp{Ranks: ranks})
+	}
+	ec2p1 := &RedundancyScheme{Name: "EC_2P1", DataCells: 2, ParityCells: 1}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *PoolCheckFitReq
+		expResp *PoolCheckFitResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.PoolCheckFitReq request"),
+		},
+		"invalid UUID": {
+			req:    &PoolCheckFitReq{UUID: "bad", Size: 1},
+			expErr: errors.New("invalid UUID"),
+		},
+		"zero size": {
+			req:    &PoolCheckFitReq{UUID: common.MockUUID()},
+			expErr: errors.New("greater than zero"),
+		},
+		"invalid ratio": {
+			req:    &PoolCheckFitReq{UUID: common.MockUUID(), Size: 1, ScmRatio: 2},
+			expErr: errors.New("invalid SCM ratio"),
+		},
+		"local failure": {
+			req: &PoolCheckFitReq{UUID: common.MockUUID(), Size: 1},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"fits": {
+			req: &PoolCheckFitReq{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: ec2p1,
+				ScmRatio:   0.06,
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: mockQueryResp(
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 0, Targets: mockPBTargets(5000, 4000)},
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 1, Targets: mockPBTargets(3000, 0)},
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 2, Targets: mockPBTargets(3000)},
+				),
+			},
+			expResp: &PoolCheckFitResp{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: ec2p1,
+				Scm:        &PoolTierFit{Required: 720, PerTarget: 180, Free: 2000, Fits: true},
+				Nvme:       &PoolTierFit{Required: 12000, PerTarget: 3000, Free: 15000, Fits: true},
+				Ranks: []*PoolRankFit{
+					{Rank: 0, Targets: 2, ScmNeed: 360, ScmFree: 1000, NvmeNeed: 6000, NvmeFree: 9000, Fits: true},
+					{Rank: 1, Targets: 1, ScmNeed: 180, ScmFree: 500, NvmeNeed: 3000, NvmeFree: 3000, Fits: true},
+					{Rank: 2, Targets: 1, ScmNeed: 180, ScmFree: 500, NvmeNeed: 3000, NvmeFree: 3000, Fits: true},
+				},
+				Fits: true,
+			},
+		},
+		"target too full": {
+			req: &PoolCheckFitReq{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: ec2p1,
+				ScmRatio:   0.06,
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: mockQueryResp(
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 0, Targets: mockPBTargets(5000, 4000)},
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 1, Targets: mockPBTargets(2999)},
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 2, Targets: mockPBTargets(3000)},
+				),
+			},
+			expResp: &PoolCheckFitResp{
+				UUID:       common.MockUUID(),
+				Size:       8000,
+				Redundancy: ec2p1,
+				Scm:        &PoolTierFit{Required: 720, PerTarget: 180, Free: 2000, Fits: true},
+				Nvme:       &PoolTierFit{Required: 12000, PerTarget: 3000, Free: 14999, TargetsTooFull: 1},
+				Ranks: []*PoolRankFit{
+					{Rank: 0, Targets: 2, ScmNeed: 360, ScmFree: 1000, NvmeNeed: 6000, NvmeFree: 9000, Fits: true},
+					{Rank: 1, Targets: 1, ScmNeed: 180, ScmFree: 500, NvmeNeed: 3000, NvmeFree: 2999},
+					{Rank: 2, Targets: 1, ScmNeed: 180, ScmFree: 500, NvmeNeed: 3000, NvmeFree: 3000, Fits: true},
+				},
+				Problems: []string{"NVMe: 1 targets have less than the 3.0 kB required per target"},
+			},
+		},
+		"too few ranks": {
+			req: &PoolCheckFitReq{
+				UUID:       common.MockUUID(),
+				Size:       100,
+				Redundancy: ec2p1,
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: mockQueryResp(
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 0, Targets: mockPBTargets(5000)},
+					&ctlpb.PoolQueryTargetsResp_RankResp{Rank: 1, Targets: mockPBTargets(0)},
+				),
+			},
+			expResp: &PoolCheckFitResp{
+				UUID:       common.MockUUID(),
+				Size:       100,
+				Redundancy: ec2p1,
+				Scm:        &PoolTierFit{Free: 500, Fits: true},
+				Nvme:       &PoolTierFit{Required: 150, PerTarget: 150, Free: 5000, Fits: true},
+				Ranks: []*PoolRankFit{
+					{Rank: 0, Targets: 1, ScmFree: 500, NvmeNeed: 150, NvmeFree: 5000, Fits: true},
+				},
+				Problems: []string{"EC_2P1 requires 3 ranks, 1 available"},
+			},
+		},
+		"host error": {
+			req: &PoolCheckFitReq{UUID: common.MockUUID(), Size: 100},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.PoolQueryTargetsResp{
+								Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+									{Rank: 1, Targets: mockPBTargets(5000)},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &PoolCheckFitResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+				UUID:           common.MockUUID(),
+				Size:           100,
+				Redundancy:     &RedundancyScheme{Name: "none", DataCells: 1},
+				Scm:            &PoolTierFit{Free: 500, Fits: true},
+				Nvme:           &PoolTierFit{Required: 100, PerTarget: 100, Free: 5000, Fits: true},
+				Ranks: []*PoolRankFit{
+					{Rank: 1, Targets: 1, ScmFree: 500, NvmeNeed: 100, NvmeFree: 5000, Fits: true},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := PoolCheckFit(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("Unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -16,9 +16,11 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
 func TestControl_PoolDestroy(t *testing.T) {
@@ -183,6 +185,21 @@ func TestControl_PoolEvict(t *testing.T) {
 			},
 			expErr: errors.New("invalid UUID"),
 		},
+		"invalid handle": {
+			req: &PoolEvictReq{
+				UUID:    common.MockUUID(),
+				Handles: []string{"bad"},
+			},
+			expErr: errors.New("invalid UUID"),
+		},
+		"handles and machine": {
+			req: &PoolEvictReq{
+				UUID:    common.MockUUID(),
+				Handles: []string{common.MockUUID(1)},
+				Machine: "node1",
+			},
+			expErr: errors.New("may not both be specified"),
+		},
 		"success": {
 			req: &PoolEvictReq{
 				UUID: common.MockUUID(),
@@ -193,6 +210,17 @@ func TestControl_PoolEvict(t *testing.T) {
 				),
 			},
 		},
+		"machine success": {
+			req: &PoolEvictReq{
+				UUID:    common.MockUUID(),
+				Machine: "node1",
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&mgmtpb.PoolEvictResp{},
+				),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -301,6 +329,86 @@ func TestControl_PoolCreate(t *testing.T) {
 				TgtRanks: []uint32{0, 1, 2},
 			},
 		},
+		"success with fault domains": {
+			req: &PoolCreateReq{TotalBytes: 10, MinDomains: 2},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&mgmtpb.PoolCreateResp{
+						SvcReps:  []uint32{0, 1, 2},
+						TgtRanks: []uint32{0, 1, 2},
+						FaultDomains: []*mgmtpb.PoolFaultDomain{
+							{Domain: "/rack0", Ranks: []uint32{0, 2}},
+							{Domain: "/rack1", Ranks: []uint32{1}},
+						},
+					},
+				),
+			},
+			expResp: &PoolCreateResp{
+				SvcReps:  []uint32{0, 1, 2},
+				TgtRanks: []uint32{0, 1, 2},
+				FaultDomains: []*PoolFaultDomain{
+					{Domain: "/rack0", Ranks: []uint32{0, 2}},
+					{Domain: "/rack1", Ranks: []uint32{1}},
+				},
+			},
+		},
+		"success with rank choices and timings": {
+			req: &PoolCreateReq{TotalBytes: 10, NumRanks: 2, RankStrategy: "pack"},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&mgmtpb.PoolCreateResp{
+						SvcReps:      []uint32{0},
+						TgtRanks:     []uint32{0, 1},
+						RankStrategy: "pack",
+						RankChoices: []*mgmtpb.PoolRankChoice{
+							{Rank: 0, Domain: "/rack0", FreeBytes: 100, Reason: "packed"},
+							{Rank: 1, Domain: "/rack0", FreeBytes: 50, Failures: 1, Reason: "packed"},
+						},
+						Timings: []*mgmtpb.PoolCreateTiming{
+							{Phase: "rank selection", DurationUs: 2000},
+						},
+					},
+				),
+			},
+			expResp: &PoolCreateResp{
+				SvcReps:      []uint32{0},
+				TgtRanks:     []uint32{0, 1},
+				RankStrategy: "pack",
+				RankChoices: []*PoolRankChoice{
+					{Rank: 0, Domain: "/rack0", FreeBytes: 100, Reason: "packed"},
+					{Rank: 1, Domain: "/rack0", FreeBytes: 50, Failures: 1, Reason: "packed"},
+				},
+				Timings: []*PhaseTiming{
+					{Phase: "rank selection", DurationUs: 2000},
+				},
+			},
+		},
+		"success with properties": {
+			req: &PoolCreateReq{
+				TotalBytes: 10,
+				Properties: []*PoolProperty{{Name: "reclaim", Value: "time"}},
+			},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&mgmtpb.PoolCreateResp{
+						SvcReps:  []uint32{0},
+						TgtRanks: []uint32{0},
+						Properties: []*mgmtpb.PoolProperty{
+							{Name: "reclaim", Value: "time"},
+							{Name: "self_heal", Value: "exclude"},
+						},
+					},
+				),
+			},
+			expResp: &PoolCreateResp{
+				SvcReps:  []uint32{0},
+				TgtRanks: []uint32{0},
+				Properties: []*PoolProperty{
+					{Name: "reclaim", Value: "time"},
+					{Name: "self_heal", Value: "exclude"},
+				},
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -443,6 +551,198 @@ func TestControl_PoolQuery(t *testing.T) {
 	}
 }
 
+func TestControl_PoolQueryTargets(t *testing.T) {
+	mockPBTargets := func(states ...string) []*ctlpb.PoolTargetInfo {
+		var tgts []*ctlpb.PoolTargetInfo
+		for i, state := range states {
+			tgts = append(tgts, &ctlpb.PoolTargetInfo{
+				TgtIdx:    uint32(i),
+				State:     state,
+				ScmTotal:  1000,
+				ScmFree:   500,
+				NvmeTotal: 10000,
+				NvmeFree:  2500,
+			})
+		}
+		return tgts
+	}
+	mockTarget := func(rank system.Rank, idx uint32, state string) *PoolTargetInfo {
+		return &PoolTargetInfo{
+			Rank:      rank,
+			TgtIdx:    idx,
+			State:     state,
+			ScmTotal:  1000,
+			ScmFree:   500,
+			NvmeTotal: 10000,
+			NvmeFree:  2500,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *PoolQueryTargetsReq
+		expResp *PoolQueryTargetsResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.PoolQueryTargetsReq request"),
+		},
+		"invalid UUID": {
+			req: &PoolQueryTargetsReq{
+				UUID: "bad",
+			},
+			expErr: errors.New("invalid UUID"),
+		},
+		"local failure": {
+			req: &PoolQueryTargetsReq{UUID: common.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &PoolQueryTargetsReq{UUID: common.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &PoolQueryTargetsResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"multiple hosts; sorted by rank": {
+			req: &PoolQueryTargetsReq{UUID: common.MockUUID()},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host2",
+							Message: &ctlpb.PoolQueryTargetsResp{
+								Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+									{Rank: 2, Targets: mockPBTargets("UPIN", "DOWN")},
+								},
+							},
+						},
+						{
+							Addr: "host1",
+							Message: &ctlpb.PoolQueryTargetsResp{
+								Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+									{Rank: 1, Targets: mockPBTargets("UPIN")},
+									{Rank: 0, Targets: mockPBTargets("UPIN", "DRAIN")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expResp: &PoolQueryTargetsResp{
+				Targets: []*PoolTargetInfo{
+					mockTarget(0, 0, "UPIN"),
+					mockTarget(0, 1, "DRAIN"),
+					mockTarget(1, 0, "UPIN"),
+					mockTarget(2, 0, "UPIN"),
+					mockTarget(2, 1, "DOWN"),
+				},
+			},
+		},
+		"with devices": {
+			req: &PoolQueryTargetsReq{UUID: common.MockUUID(), ShowDevices: true},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, &ctlpb.PoolQueryTargetsResp{
+					Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+						{
+							Rank:    1,
+							Targets: mockPBTargets("UPIN", "DOWN"),
+							Devices: []*ctlpb.PoolTargetDevice{
+								{
+									TgtIdx:       0,
+									NvmeUuid:     common.MockUUID(1),
+									NvmePciAddr:  "0000:80:00.0",
+									NvmeSerial:   "serial0",
+									ScmBlockdev:  "pmem0",
+									ScmNamespace: common.MockUUID(2),
+									ScmMount:     "/mnt/daos",
+								},
+								{
+									TgtIdx:   1,
+									ScmMount: "/mnt/daos",
+								},
+							},
+						},
+					},
+				}),
+			},
+			expResp: &PoolQueryTargetsResp{
+				Targets: []*PoolTargetInfo{
+					func() *PoolTargetInfo {
+						tgt := mockTarget(1, 0, "UPIN")
+						tgt.Device = &PoolTargetDevice{
+							NvmeUUID:     common.MockUUID(1),
+							NvmePciAddr:  "0000:80:00.0",
+							NvmeSerial:   "serial0",
+							ScmBlockdev:  "pmem0",
+							ScmNamespace: common.MockUUID(2),
+							ScmMount:     "/mnt/daos",
+						}
+						return tgt
+					}(),
+					func() *PoolTargetInfo {
+						tgt := mockTarget(1, 1, "DOWN")
+						tgt.Device = &PoolTargetDevice{ScmMount: "/mnt/daos"}
+						return tgt
+					}(),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := PoolQueryTargets(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("Unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_PoolTargetInfo_UsedPercent(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tgt    *PoolTargetInfo
+		expPct float64
+	}{
+		"no space": {
+			tgt: &PoolTargetInfo{},
+		},
+		"scm only": {
+			tgt:    &PoolTargetInfo{ScmTotal: 100, ScmFree: 40},
+			expPct: 60,
+		},
+		"nvme preferred": {
+			tgt: &PoolTargetInfo{ScmTotal: 100, ScmFree: 40,
+				NvmeTotal: 1000, NvmeFree: 900},
+			expPct: 10,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expPct, tc.tgt.UsedPercent(), "unexpected used percent")
+		})
+	}
+}
+
 func TestPoolSetProp(t *testing.T) {
 	const (
 		testPropName          = "test-prop"
@@ -601,6 +901,28 @@ func TestPoolSetProp(t *testing.T) {
 	}
 }
 
+func mockListPools(idxs ...int32) []*mgmtpb.ListPoolsResp_Pool {
+	pools := make([]*mgmtpb.ListPoolsResp_Pool, 0, len(idxs))
+	for _, i := range idxs {
+		pools = append(pools, &mgmtpb.ListPoolsResp_Pool{
+			Uuid:    common.MockUUID(i),
+			SvcReps: []uint32{uint32(i)},
+		})
+	}
+	return pools
+}
+
+func mockPoolDiscovery(idxs ...int32) []*common.PoolDiscovery {
+	pools := make([]*common.PoolDiscovery, 0, len(idxs))
+	for _, i := range idxs {
+		pools = append(pools, &common.PoolDiscovery{
+			UUID:        common.MockUUID(i),
+			SvcReplicas: []uint32{uint32(i)},
+		})
+	}
+	return pools
+}
+
 func TestControl_ListPools(t *testing.T) {
 	for name, tc := range map[string]struct {
 		mic     *MockInvokerConfig
@@ -652,6 +974,54 @@ func TestControl_ListPools(t *testing.T) {
 				},
 			},
 		},
+		"multiple pages": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						Pools:      mockListPools(0, 1),
+						NextCursor: common.MockUUID(1),
+					}),
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						Pools:      mockListPools(2, 3),
+						NextCursor: common.MockUUID(3),
+					}),
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						Pools: mockListPools(4),
+					}),
+				},
+			},
+			expResp: &ListPoolsResp{
+				Pools: mockPoolDiscovery(0, 1, 2, 3, 4),
+			},
+		},
+		"cursor not advanced": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						Pools:      mockListPools(0, 1),
+						NextCursor: common.MockUUID(1),
+					}),
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						NextCursor: common.MockUUID(1),
+					}),
+				},
+			},
+			expResp: &ListPoolsResp{
+				Pools: mockPoolDiscovery(0, 1),
+			},
+		},
+		"failure after first page": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					MockMSResponse("host1", nil, &mgmtpb.ListPoolsResp{
+						Pools:      mockListPools(0, 1),
+						NextCursor: common.MockUUID(1),
+					}),
+					MockMSResponse("host1", errors.New("remote failed"), nil),
+				},
+			},
+			expErr: errors.New("remote failed"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -0,0 +1,73 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+)
+
+// ProgressReporter defines an interface to be implemented by types that
+// report the progress of a request as responses are received from each host.
+type ProgressReporter interface {
+	// Start is called each time the request is sent, with the phase
+	// describing the operation and the number of hosts it was sent to.
+	Start(phase string, hosts int)
+	// HostDone is called as each host response is received.
+	HostDone(addr string, err error)
+	// Finish is called once all responses have been gathered.
+	Finish()
+}
+
+type nopProgress struct{}
+
+func (nopProgress) Start(string, int)      {}
+func (nopProgress) HostDone(string, error) {}
+func (nopProgress) Finish()                {}
+
+// WithProgressReporter sets a ProgressReporter to be notified of the
+// progress of requests invoked by the client.
+func WithProgressReporter(pr ProgressReporter) ClientOption {
+	return func(c *Client) {
+		c.progress = pr
+	}
+}
+
+// requestPhase returns a human-readable description of the operation
+// performed by a request, derived from its type name, e.g. "storage scan"
+// for a *StorageScanReq.
+func requestPhase(req interface{}) string {
+	name := fmt.Sprintf("%T", req)
+	name = name[strings.LastIndex(name, ".")+1:]
+	name = strings.TrimSuffix(name, "Req")
+
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	return strings.ToLower(strings.Join(words, " "))
+}
+
+// countHosts returns the number of hosts in a host list which may contain
+// ranges.
+func countHosts(hosts []string) int {
+	set, err := hostlist.CreateSet(strings.Join(hosts, ","))
+	if err != nil {
+		return len(hosts)
+	}
+	return set.Count()
+}
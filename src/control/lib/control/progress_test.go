@@ -0,0 +1,98 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+type recordingProgress struct {
+	events []string
+}
+
+func (rp *recordingProgress) Start(phase string, hosts int) {
+	rp.events = append(rp.events, fmt.Sprintf("start %s %d", phase, hosts))
+}
+
+func (rp *recordingProgress) HostDone(addr string, err error) {
+	rp.events = append(rp.events, fmt.Sprintf("done %s %v", addr, err))
+}
+
+func (rp *recordingProgress) Finish() {
+	rp.events = append(rp.events, "finish")
+}
+
+func TestControl_requestPhase(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req      interface{}
+		expPhase string
+	}{
+		"storage scan": {
+			req:      &StorageScanReq{},
+			expPhase: "storage scan",
+		},
+		"system query": {
+			req:      &SystemQueryReq{},
+			expPhase: "system query",
+		},
+		"acronym": {
+			req:      &PoolGetACLReq{},
+			expPhase: "pool get acl",
+		},
+		"no req suffix": {
+			req:      &testRequest{},
+			expPhase: "test request",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expPhase, requestPhase(tc.req), "unexpected phase")
+		})
+	}
+}
+
+func TestControl_invokeUnaryRPC_Progress(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	hostErr := errors.New("host failed")
+	rp := &recordingProgress{}
+	mi := NewMockInvoker(log, &MockInvokerConfig{
+		UnaryResponse: &UnaryResponse{
+			Responses: []*HostResponse{
+				{Addr: "host1", Message: &MockMessage{}},
+				{Addr: "host2", Error: hostErr},
+				{Addr: "host3", Message: &MockMessage{}},
+			},
+		},
+		Progress: rp,
+	})
+
+	req := &StorageScanReq{}
+	req.SetHostList([]string{"host[1-3]"})
+	if _, err := mi.InvokeUnaryRPC(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	expEvents := []string{
+		"start storage scan 3",
+		"done host1 <nil>",
+		"done host2 host failed",
+		"done host3 <nil>",
+		"finish",
+	}
+	if diff := cmp.Diff(expEvents, rp.events); diff != "" {
+		t.Fatalf("unexpected progress events (-want, +got):\n%s\n", diff)
+	}
+}
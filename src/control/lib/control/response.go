@@ -8,8 +8,10 @@ package control
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/pkg/errors"
@@ -68,12 +70,53 @@ func (her *HostErrorsResp) Errors() error {
 			}
 		}
 
-		return errors.Errorf("%s had errors",
-			english.Plural(len(erroredHosts), "host", "hosts"))
+		return &HostErrorsError{
+			HostErrors: her.HostErrors,
+			numHosts:   len(erroredHosts),
+		}
 	}
 	return nil
 }
 
+// HostErrorsError is returned by HostErrorsResp.Errors() and retains the
+// per-host errors so that callers can determine the nature of the failures.
+type HostErrorsError struct {
+	HostErrors HostErrorsMap
+	numHosts   int
+}
+
+func (hee *HostErrorsError) Error() string {
+	return fmt.Sprintf("%s had errors", english.Plural(hee.numHosts, "host", "hosts"))
+}
+
+// Hosts returns the set of hosts that had errors.
+func (hee *HostErrorsError) Hosts() *hostlist.HostSet {
+	hosts := new(hostlist.HostSet)
+	for _, hes := range hee.HostErrors {
+		if err := hosts.MergeSet(hes.HostSet); err != nil {
+			continue
+		}
+	}
+
+	return hosts
+}
+
+// AllConnectionErrors returns true if the error for every host is a
+// connection error, i.e. none of the hosts could be contacted.
+func (hee *HostErrorsError) AllConnectionErrors() bool {
+	if len(hee.HostErrors) == 0 {
+		return false
+	}
+
+	for _, hes := range hee.HostErrors {
+		if !IsConnectionError(hes.HostError) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // HostErrorSet preserves the original hostError used
 // to create the map key.
 type HostErrorSet struct {
@@ -99,6 +142,30 @@ func (hem HostErrorsMap) MarshalJSON() ([]byte, error) {
 	return json.Marshal(out)
 }
 
+// UnmarshalJSON implements a custom unmarshaller for the output of
+// MarshalJSON. The original error types are not preserved.
+func (hem *HostErrorsMap) UnmarshalJSON(data []byte) error {
+	var in map[string]string
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	out := make(HostErrorsMap)
+	for errStr, hosts := range in {
+		hs, err := hostlist.CreateSet(hosts)
+		if err != nil {
+			return err
+		}
+		out[errStr] = &HostErrorSet{
+			HostSet:   hs,
+			HostError: errors.New(errStr),
+		}
+	}
+	*hem = out
+
+	return nil
+}
+
 // Add creates or updates the err/addr keyval pair.
 func (hem HostErrorsMap) Add(hostAddr string, hostErr error) (err error) {
 	if hostErr == nil {
@@ -149,6 +216,115 @@ func (hem HostErrorsMap) Keys() []string {
 	return keys
 }
 
+// HostWarningsResp is a response type containing a HostWarningsMap.
+type HostWarningsResp struct {
+	HostWarnings HostWarningsMap `json:"host_warnings,omitempty"`
+}
+
+func (hwr *HostWarningsResp) addHostWarnings(hostAddr string, warnings []string) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+	if hwr.HostWarnings == nil {
+		hwr.HostWarnings = make(HostWarningsMap)
+	}
+	for _, warning := range warnings {
+		if err := hwr.HostWarnings.Add(hostAddr, warning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetHostWarnings retrieves a HostWarningsMap from a response type.
+func (hwr *HostWarningsResp) GetHostWarnings() HostWarningsMap {
+	return hwr.HostWarnings
+}
+
+// HostWarningsMap provides a mapping from advisory messages reported with
+// otherwise successful results to the set of hosts reporting them.
+type HostWarningsMap map[string]*hostlist.HostSet
+
+// Add creates or updates the warning/addr keyval pair.
+func (hwm HostWarningsMap) Add(hostAddr, warning string) error {
+	if hs, exists := hwm[warning]; exists {
+		_, err := hs.Insert(hostAddr)
+		return err
+	}
+
+	hs, err := hostlist.CreateSet(hostAddr)
+	if err != nil {
+		return err
+	}
+	hwm[warning] = hs
+
+	return nil
+}
+
+// Keys returns a stable sorted slice of the warnings map keys.
+func (hwm HostWarningsMap) Keys() []string {
+	keys := make([]string, 0, len(hwm))
+	for warning := range hwm {
+		keys = append(keys, warning)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// PhaseTiming is the time taken by a phase of a server-side operation.
+type PhaseTiming struct {
+	Phase      string `json:"phase"`
+	DurationUs uint64 `json:"duration_us"`
+}
+
+// Duration returns the time taken by the phase.
+func (pt *PhaseTiming) Duration() time.Duration {
+	return time.Duration(pt.DurationUs) * time.Microsecond
+}
+
+// HostTimingsResp is a response type containing a HostTimingsMap.
+type HostTimingsResp struct {
+	HostTimings HostTimingsMap `json:"host_timings,omitempty"`
+}
+
+func (htr *HostTimingsResp) addHostTimings(hostAddr string, pbTimings []*ctlpb.PhaseTiming) error {
+	if len(pbTimings) == 0 {
+		return nil
+	}
+
+	var timings []*PhaseTiming
+	if err := convert.Types(pbTimings, &timings); err != nil {
+		return err
+	}
+	if htr.HostTimings == nil {
+		htr.HostTimings = make(HostTimingsMap)
+	}
+	htr.HostTimings[hostAddr] = timings
+
+	return nil
+}
+
+// GetHostTimings retrieves a HostTimingsMap from a response type.
+func (htr *HostTimingsResp) GetHostTimings() HostTimingsMap {
+	return htr.HostTimings
+}
+
+// HostTimingsMap provides a mapping from host address to the time taken by
+// each phase of an operation on that host.
+type HostTimingsMap map[string][]*PhaseTiming
+
+// Keys returns a stable sorted slice of the timings map keys.
+func (htm HostTimingsMap) Keys() []string {
+	keys := make([]string, 0, len(htm))
+	for addr := range htm {
+		keys = append(keys, addr)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 // UnaryResponse contains a slice of *HostResponse items returned
 // from synchronous unary RPC invokers.
 type UnaryResponse struct {
@@ -7,6 +7,7 @@
 package control
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -100,6 +101,143 @@ func TestControl_HostErrorsMap(t *testing.T) {
 			if diff := cmp.Diff(tc.expErrMap, hem, defResCmpOpts()...); diff != "" {
 				t.Fatalf("unexpected map (-want, +got):\n%s\n", diff)
 			}
+
+			// verify that the map survives a JSON round trip
+			data, err := json.Marshal(hem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotErrMap HostErrorsMap
+			if err := json.Unmarshal(data, &gotErrMap); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expErrMap, gotErrMap, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected unmarshalled map (-want, +got):\n%s\n", diff)
+			}
+			for errStr, hes := range gotErrMap {
+				common.AssertEqual(t, errStr, hes.HostError.Error(), "")
+			}
+		})
+	}
+}
+
+func TestControl_HostErrorsResp_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hostErrors  []*MockHostError
+		connErrors  []string
+		expErr      error
+		expAllConns bool
+	}{
+		"no errors": {},
+		"one host": {
+			hostErrors: []*MockHostError{{"host1", "whoops"}},
+			expErr:     errors.New("1 host had errors"),
+		},
+		"mixed errors": {
+			hostErrors: []*MockHostError{
+				{"host1", "whoops"},
+				{"host2", "oops"},
+			},
+			connErrors: []string{"host3"},
+			expErr:     errors.New("3 hosts had errors"),
+		},
+		"all connection errors": {
+			connErrors:  []string{"host1", "host2"},
+			expErr:      errors.New("2 hosts had errors"),
+			expAllConns: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &HostErrorsResp{
+				HostErrors: mockHostErrorsMap(t, tc.hostErrors...),
+			}
+			for _, host := range tc.connErrors {
+				if err := resp.addHostError(host, FaultConnectionRefused(host)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotErr := resp.Errors()
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr == nil {
+				return
+			}
+
+			hee, ok := gotErr.(*HostErrorsError)
+			if !ok {
+				t.Fatalf("expected %T, got %T", hee, gotErr)
+			}
+			common.AssertEqual(t, tc.expAllConns, hee.AllConnectionErrors(), "")
+		})
+	}
+}
+
+func TestControl_HostWarningsResp(t *testing.T) {
+	mockHostSet := func(hosts string) *hostlist.HostSet {
+		hs, err := hostlist.CreateSet(hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hs
+	}
+
+	for name, tc := range map[string]struct {
+		hostWarnings map[string][]string
+		expWarnings  HostWarningsMap
+		expKeys      []string
+		expJSON      string
+	}{
+		"no warnings": {
+			hostWarnings: map[string][]string{
+				"host1": nil,
+			},
+			expKeys: []string{},
+			expJSON: `{}`,
+		},
+		"two hosts same warning": {
+			hostWarnings: map[string][]string{
+				"host1": {"firmware outdated"},
+				"host2": {"firmware outdated"},
+			},
+			expWarnings: HostWarningsMap{
+				"firmware outdated": mockHostSet("host[1-2]"),
+			},
+			expKeys: []string{"firmware outdated"},
+			expJSON: `{"host_warnings":{"firmware outdated":"host[1-2]"}}`,
+		},
+		"two hosts different warnings": {
+			hostWarnings: map[string][]string{
+				"host1": {"firmware outdated", "too few hugepages"},
+				"host2": {"too few hugepages"},
+			},
+			expWarnings: HostWarningsMap{
+				"firmware outdated": mockHostSet("host1"),
+				"too few hugepages": mockHostSet("host[1-2]"),
+			},
+			expKeys: []string{"firmware outdated", "too few hugepages"},
+			expJSON: `{"host_warnings":{"firmware outdated":"host1","too few hugepages":"host[1-2]"}}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := new(HostWarningsResp)
+			for host, warnings := range tc.hostWarnings {
+				if err := resp.addHostWarnings(host, warnings); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.expWarnings, resp.GetHostWarnings(), defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected warnings (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expKeys, resp.GetHostWarnings().Keys()); diff != "" {
+				t.Fatalf("unexpected keys (-want, +got):\n%s\n", diff)
+			}
+
+			data, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expJSON, string(data), "")
 		})
 	}
 }
@@ -8,6 +8,7 @@ package control
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
@@ -94,8 +95,10 @@ type (
 	// Client implements the Invoker interface and should be provided to
 	// API methods to invoke RPCs.
 	Client struct {
-		config *Config
-		log    debugLogger
+		config   *Config
+		log      debugLogger
+		conns    *connPool
+		progress ProgressReporter
 	}
 
 	// ClientOption defines the signature for functional Client options.
@@ -116,6 +119,15 @@ func WithConfig(cfg *Config) ClientOption {
 	}
 }
 
+// WithConnectionReuse enables reuse of connections to each host by
+// subsequent requests. Connections that fail are redialled by the next
+// request. Cached connections are released by Close().
+func WithConnectionReuse() ClientOption {
+	return func(c *Client) {
+		c.conns = newConnPool()
+	}
+}
+
 // NewClient returns an initialized Client with its
 // parameters set by the provided ClientOption list.
 func NewClient(opts ...ClientOption) *Client {
@@ -144,9 +156,20 @@ func DefaultClient() *Client {
 }
 
 // SetConfig sets the client configuration for an
-// existing Client.
+// existing Client. Any cached connections are closed as they may have been
+// established with a different transport configuration.
 func (c *Client) SetConfig(cfg *Config) {
 	c.config = cfg
+	if c.conns != nil {
+		c.conns.closeAll()
+	}
+}
+
+// Close releases any connections cached by the client.
+func (c *Client) Close() {
+	if c.conns != nil {
+		c.conns.closeAll()
+	}
 }
 
 // GetConfig retrieves the system name from the client configuration and
@@ -178,7 +201,43 @@ func (c *Client) dialOptions() ([]grpc.DialOption, error) {
 	}
 	opts = append(opts, creds)
 
-	return opts, nil
+	return append(opts, c.config.Grpc.DialOptions()...), nil
+}
+
+// getConn returns a connection to the host, reusing a cached connection if
+// connection reuse is enabled.
+func (c *Client) getConn(ctx context.Context, hostAddr string) (*grpc.ClientConn, error) {
+	if c.conns != nil {
+		if conn := c.conns.get(hostAddr); conn != nil {
+			return conn, nil
+		}
+	}
+
+	opts, err := c.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.DialContext(ctx, hostAddr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.conns != nil {
+		return c.conns.put(hostAddr, conn), nil
+	}
+	return conn, nil
+}
+
+// releaseConn closes the connection to the host unless it is cached for
+// reuse, in which case it is only closed if the request failed because the
+// connection is broken.
+func (c *Client) releaseConn(hostAddr string, conn *grpc.ClientConn, reqErr error) {
+	switch {
+	case c.conns == nil:
+		conn.Close()
+	case isConnFailure(reqErr):
+		c.conns.evict(hostAddr, conn)
+	}
 }
 
 // setDeadlineIfUnset sets a deadline on the context unless there is already
@@ -221,14 +280,10 @@ func (c *Client) InvokeUnaryRPCAsync(parent context.Context, req UnaryRequest) (
 			wg.Add(1)
 			go func(hostAddr string) {
 				var msg proto.Message
-				opts, err := c.dialOptions()
+				conn, err := c.getConn(ctx, hostAddr)
 				if err == nil {
-					var conn *grpc.ClientConn
-					conn, err = grpc.DialContext(ctx, hostAddr, opts...)
-					if err == nil {
-						msg, err = req.getRPC()(ctx, conn)
-						conn.Close()
-					}
+					msg, err = req.getRPC()(ctx, conn)
+					c.releaseConn(hostAddr, conn, err)
 				}
 
 				select {
@@ -249,7 +304,12 @@ func (c *Client) InvokeUnaryRPCAsync(parent context.Context, req UnaryRequest) (
 // invokeUnaryRPC is the actual implementation which is called by the
 // real Client as well as the MockInvoker. This allows us to ensure that
 // the retry logic here gets adequate test coverage.
-func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker, req UnaryRequest, defaultHosts []string) (*UnaryResponse, error) {
+func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker, req UnaryRequest, defaultHosts []string, progress ProgressReporter) (*UnaryResponse, error) {
+	if progress == nil {
+		progress = nopProgress{}
+	}
+	defer progress.Finish()
+
 	gatherResponses := func(ctx context.Context, respChan chan *HostResponse, ur *UnaryResponse) error {
 		for {
 			select {
@@ -260,9 +320,11 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 					return nil
 				}
 				ur.Responses = append(ur.Responses, hr)
+				progress.HostDone(hr.Addr, hr.Error)
 			}
 		}
 	}
+	phase := requestPhase(req)
 
 	// Set a deadline for the request across all retries.
 	reqCtx, cancel := setDeadlineIfUnset(parentCtx, req)
@@ -271,6 +333,12 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 	// For non-MS requests, just keep things simple. Fan-out, fan-in,
 	// no retries possible.
 	if !req.isMSRequest() {
+		hosts := req.getHostList()
+		if len(hosts) == 0 {
+			hosts = defaultHosts
+		}
+		progress.Start(phase, countHosts(hosts))
+
 		respChan, err := c.InvokeUnaryRPCAsync(reqCtx, req)
 		if err != nil {
 			return nil, err
@@ -334,6 +402,12 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 			tryCtx, tryCancel = context.WithTimeout(reqCtx, tryTimeout)
 			defer tryCancel()
 		}
+		tryPhase := phase
+		if try > 0 {
+			tryPhase = fmt.Sprintf("%s (retry %d)", phase, try)
+		}
+		progress.Start(tryPhase, countHosts(req.getHostList()))
+
 		respChan, err := c.InvokeUnaryRPCAsync(tryCtx, req)
 		if isHardFailure(err, reqCtx) {
 			return nil, err
@@ -417,5 +491,5 @@ func invokeUnaryRPC(parentCtx context.Context, log debugLogger, c UnaryInvoker,
 // items which represent the success or failure of the RPC invocation for each host
 // in the request.
 func (c *Client) InvokeUnaryRPC(ctx context.Context, req UnaryRequest) (*UnaryResponse, error) {
-	return invokeUnaryRPC(ctx, c.log, c, req, c.config.HostList)
+	return invokeUnaryRPC(ctx, c.log, c, req, c.config.HostList, c.progress)
 }
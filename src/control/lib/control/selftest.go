@@ -0,0 +1,213 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type (
+	// NetworkSelfTestResult contains the fabric performance measured from
+	// a source rank for a single message size.
+	NetworkSelfTestResult struct {
+		SrcRank    system.Rank `json:"src_rank"`
+		MsgSize    uint32      `json:"msg_size"`   // bytes
+		Bandwidth  float64     `json:"bandwidth"`  // MB/s
+		Throughput float64     `json:"throughput"` // RPCs/s
+		LatMin     uint64      `json:"lat_min"`    // us
+		LatMedian  uint64      `json:"lat_median"` // us
+		LatMax     uint64      `json:"lat_max"`    // us
+		LatAvg     uint64      `json:"lat_avg"`    // us
+		Failures   uint32      `json:"failures"`
+	}
+
+	// NetworkSelfTestReq contains the parameters for a network self-test
+	// request. The test is driven from the single host in the request's
+	// hostlist.
+	NetworkSelfTestReq struct {
+		unaryRequest
+		SrcRanks    system.RankSet
+		DstRanks    system.RankSet
+		MsgSizes    []uint32
+		Repetitions uint32
+		MaxInflight uint32
+	}
+
+	// NetworkSelfTestResp contains the results of a network self-test.
+	NetworkSelfTestResp struct {
+		HostErrorsResp
+		Host    string                   `json:"host"`
+		Results []*NetworkSelfTestResult `json:"results"`
+	}
+
+	// StorageSelfTestResult contains the performance measured on a single
+	// NVMe SSD.
+	StorageSelfTestResult struct {
+		PciAddr   string  `json:"pci_addr"`
+		Iops      float64 `json:"iops"`
+		Bandwidth float64 `json:"bandwidth"` // MiB/s
+		LatAvg    float64 `json:"lat_avg"`   // us
+		LatMin    float64 `json:"lat_min"`   // us
+		LatMax    float64 `json:"lat_max"`   // us
+	}
+
+	// StorageSelfTestReq contains the parameters for a storage self-test
+	// request.
+	StorageSelfTestReq struct {
+		unaryRequest
+		PciAddrs   []string
+		Workload   string
+		IoSize     uint32
+		QueueDepth uint32
+		Duration   uint32 // seconds
+		Force      bool   // allow write workloads
+	}
+
+	// StorageSelfTestResp contains the results of a storage self-test,
+	// keyed by host address.
+	StorageSelfTestResp struct {
+		HostErrorsResp
+		HostResults map[string][]*StorageSelfTestResult `json:"host_results"`
+	}
+)
+
+// NetworkSelfTest measures fabric latency and bandwidth between the I/O
+// Engines of the requested source and destination ranks. The fabric URIs of
+// the ranks are first retrieved from the MS, then the test is run by the
+// control plane of the single host in the request's hostlist.
+func NetworkSelfTest(ctx context.Context, rpcClient UnaryInvoker, req *NetworkSelfTestReq) (*NetworkSelfTestResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if len(req.getHostList()) != 1 {
+		return nil, errors.New("network self-test must be run from exactly one host")
+	}
+
+	gaiReq := &GetAttachInfoReq{AllRanks: true}
+	gaiReq.SetSystem(req.getSystem(rpcClient))
+	gair, err := GetAttachInfo(ctx, rpcClient, gaiReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving rank URIs")
+	}
+
+	pbReq := &ctlpb.NetworkSelfTestReq{
+		Sys:         req.getSystem(rpcClient),
+		Provider:    gair.Provider,
+		SrcRanks:    req.SrcRanks.String(),
+		DstRanks:    req.DstRanks.String(),
+		MsgSizes:    req.MsgSizes,
+		Repetitions: req.Repetitions,
+		MaxInflight: req.MaxInflight,
+	}
+	for _, psr := range gair.ServiceRanks {
+		pbReq.RankUris = append(pbReq.RankUris, &ctlpb.RankUri{
+			Rank: psr.Rank,
+			Uri:  psr.Uri,
+		})
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).NetworkSelfTest(ctx, pbReq)
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(NetworkSelfTestResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := resp.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		pbResp, ok := hostResp.Message.(*ctlpb.NetworkSelfTestResp)
+		if !ok {
+			return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+		}
+		if err := convert.Types(pbResp.GetResults(), &resp.Results); err != nil {
+			if err := resp.addHostError(hostResp.Addr, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		resp.Host = hostResp.Addr
+	}
+
+	return resp, nil
+}
+
+func (ssr *StorageSelfTestResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.StorageSelfTestResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	var results []*StorageSelfTestResult
+	if err := convert.Types(pbResp.GetResults(), &results); err != nil {
+		return ssr.addHostError(hr.Addr, err)
+	}
+
+	if ssr.HostResults == nil {
+		ssr.HostResults = make(map[string][]*StorageSelfTestResult)
+	}
+	ssr.HostResults[hr.Addr] = results
+
+	return nil
+}
+
+// StorageSelfTest concurrently measures the performance of local NVMe SSDs
+// on all hosts supplied in the request's hostlist, or all configured hosts
+// if not explicitly specified. I/O Engines must be stopped on the hosts.
+func StorageSelfTest(ctx context.Context, rpcClient UnaryInvoker, req *StorageSelfTestReq) (*StorageSelfTestResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).StorageSelfTest(ctx, &ctlpb.StorageSelfTestReq{
+			PciAddrs:   req.PciAddrs,
+			Workload:   req.Workload,
+			IoSize:     req.IoSize,
+			QueueDepth: req.QueueDepth,
+			Duration:   req.Duration,
+			Force:      req.Force,
+		})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ssr := new(StorageSelfTestResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := ssr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := ssr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return ssr, nil
+}
@@ -0,0 +1,227 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_NetworkSelfTest(t *testing.T) {
+	attachInfoResp := MockMSResponse("host1", nil, &mgmtpb.GetAttachInfoResp{
+		RankUris: []*mgmtpb.GetAttachInfoResp_RankUri{
+			{Rank: 0, Uri: "ofi+sockets://10.0.0.1:31416"},
+			{Rank: 1, Uri: "ofi+sockets://10.0.0.2:31416"},
+		},
+		Provider: "ofi+sockets",
+	})
+	validReq := func() *NetworkSelfTestReq {
+		req := &NetworkSelfTestReq{
+			MsgSizes:    []uint32{4096},
+			Repetitions: 100,
+			MaxInflight: 16,
+		}
+		req.SetHostList([]string{"host2"})
+		return req
+	}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *NetworkSelfTestReq
+		expResp *NetworkSelfTestResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.NetworkSelfTestReq request"),
+		},
+		"no host": {
+			req:    &NetworkSelfTestReq{},
+			expErr: errors.New("exactly one host"),
+		},
+		"attach info failure": {
+			req: validReq(),
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("no leader"),
+			},
+			expErr: errors.New("no leader"),
+		},
+		"remote failure": {
+			req: validReq(),
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					attachInfoResp,
+					MockMSResponse("host2", errors.New("remote failed"), nil),
+				},
+			},
+			expResp: &NetworkSelfTestResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host2", "remote failed"}),
+			},
+		},
+		"success": {
+			req: validReq(),
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					attachInfoResp,
+					MockMSResponse("host2", nil, &ctlpb.NetworkSelfTestResp{
+						Results: []*ctlpb.NetworkSelfTestResult{
+							{
+								SrcRank:    0,
+								MsgSize:    4096,
+								Bandwidth:  812.5,
+								Throughput: 103980,
+								LatMin:     42,
+								LatMedian:  150,
+								LatMax:     977,
+								LatAvg:     153,
+								Failures:   1,
+							},
+						},
+					}),
+				},
+			},
+			expResp: &NetworkSelfTestResp{
+				Host: "host2",
+				Results: []*NetworkSelfTestResult{
+					{
+						SrcRank:    0,
+						MsgSize:    4096,
+						Bandwidth:  812.5,
+						Throughput: 103980,
+						LatMin:     42,
+						LatMedian:  150,
+						LatMax:     977,
+						LatAvg:     153,
+						Failures:   1,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := NetworkSelfTest(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_StorageSelfTest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *StorageSelfTestReq
+		expResp *StorageSelfTestResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.StorageSelfTestReq request"),
+		},
+		"local failure": {
+			req: &StorageSelfTestReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &StorageSelfTestReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &StorageSelfTestResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &StorageSelfTestReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, nil),
+			},
+			expErr: errors.New("unpack"),
+		},
+		"success": {
+			req: &StorageSelfTestReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StorageSelfTestResp{
+						Results: []*ctlpb.StorageSelfTestResult{
+							{
+								PciAddr:   "0000:81:00.0",
+								Iops:      25361.25,
+								Bandwidth: 3170.5,
+								LatAvg:    1261.5,
+								LatMin:    210.25,
+								LatMax:    8421.75,
+							},
+						},
+					},
+				),
+			},
+			expResp: &StorageSelfTestResp{
+				HostResults: map[string][]*StorageSelfTestResult{
+					"host1": {
+						{
+							PciAddr:   "0000:81:00.0",
+							Iops:      25361.25,
+							Bandwidth: 3170.5,
+							LatAvg:    1261.5,
+							LatMin:    210.25,
+							LatMax:    8421.75,
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := StorageSelfTest(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
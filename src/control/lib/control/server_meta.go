@@ -15,10 +15,15 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
 	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
+// smdDevicePageSize is the maximum number of devices requested from each host
+// in each SmdQuery RPC when listing devices.
+const smdDevicePageSize = 256
+
 type (
 	// SmdPool contains the per-server components of a DAOS pool.
 	SmdPool struct {
@@ -51,6 +56,12 @@ type (
 		ReplaceUUID      string // UUID of new device to replace storage
 		NoReint          bool   // for device replacement
 		Identify         bool   // for VMD LED device identification
+		StateFilter      string // only list devices in this state
+		HostFilter       string // only list devices on hosts in this hostlist
+		ModelFilter      string // only list devices with model containing this
+		MinTemp          uint32 // only list devices at or above this temperature (K)
+		MinMediaErrs     uint64 // only list devices with at least this many media errors
+		MinIoErrs        uint32 // only list devices with at least this many I/O errors
 	}
 
 	// SmdQueryResp represents the results of performing
@@ -89,8 +100,13 @@ func (sqr *SmdQueryResp) addHostResponse(hr *HostResponse) (err error) {
 		if err = convert.Types(rResp.GetDevices(), &rDevices); err != nil {
 			return
 		}
-		for _, dev := range rDevices {
+		for i, dev := range rDevices {
 			dev.Rank = rank
+			// blobstore usage is reported with device health
+			if health := rResp.GetDevices()[i].GetHealth(); health != nil {
+				dev.TotalBytes = health.GetTotalBytes()
+				dev.AvailBytes = health.GetAvailBytes()
+			}
 			hs.SmdInfo.Devices = append(hs.SmdInfo.Devices, dev)
 		}
 
@@ -131,6 +147,11 @@ func SmdQuery(ctx context.Context, rpcClient UnaryInvoker, req *SmdQueryReq) (*S
 			return nil, errors.Wrap(err, "bad new device UUID for replacement")
 		}
 	}
+	if req.HostFilter != "" {
+		if _, err := hostlist.CreateSet(req.HostFilter); err != nil {
+			return nil, errors.Wrap(err, "bad host filter")
+		}
+	}
 
 	pbReq := new(ctlpb.SmdQueryReq)
 	if err := convert.Types(req, pbReq); err != nil {
@@ -150,6 +171,13 @@ func SmdQuery(ctx context.Context, rpcClient UnaryInvoker, req *SmdQueryReq) (*S
 		}
 	}
 
+	// device listings are paged to bound the size of each host response
+	paged := req.OmitPools && !req.OmitDevices && !req.SetFaulty &&
+		req.ReplaceUUID == "" && !req.Identify
+	if paged {
+		pbReq.Limit = smdDevicePageSize
+	}
+
 	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
 	if err != nil {
 		return nil, err
@@ -157,6 +185,9 @@ func SmdQuery(ctx context.Context, rpcClient UnaryInvoker, req *SmdQueryReq) (*S
 
 	sqr := new(SmdQueryResp)
 	for _, hostResp := range ur.Responses {
+		if hostResp.Error == nil && paged {
+			hostResp.Error = querySmdDevicePages(ctx, rpcClient, pbReq, hostResp)
+		}
 		if hostResp.Error != nil {
 			if err := sqr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
 				return nil, err
@@ -171,3 +202,62 @@ func SmdQuery(ctx context.Context, rpcClient UnaryInvoker, req *SmdQueryReq) (*S
 
 	return sqr, nil
 }
+
+// querySmdDevicePages retrieves any remaining pages of devices from the host
+// that returned the supplied response and merges them into the response.
+func querySmdDevicePages(ctx context.Context, rpcClient UnaryInvoker, pbReq *ctlpb.SmdQueryReq, hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.SmdQueryResp)
+	if !ok {
+		return nil // reported when adding the host response
+	}
+
+	for cursor := pbResp.NextCursor; cursor != ""; {
+		pageReq := proto.Clone(pbReq).(*ctlpb.SmdQueryReq)
+		pageReq.Cursor = cursor
+
+		hostReq := new(SmdQueryReq)
+		hostReq.SetHostList([]string{hr.Addr})
+		hostReq.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+			return ctlpb.NewCtlSvcClient(conn).SmdQuery(ctx, pageReq)
+		})
+
+		ur, err := rpcClient.InvokeUnaryRPC(ctx, hostReq)
+		if err != nil {
+			return err
+		}
+		if len(ur.Responses) != 1 {
+			return errors.Errorf("expected 1 response to device query, got %d",
+				len(ur.Responses))
+		}
+		if ur.Responses[0].Error != nil {
+			return ur.Responses[0].Error
+		}
+		page, ok := ur.Responses[0].Message.(*ctlpb.SmdQueryResp)
+		if !ok {
+			return errors.Errorf("unable to unpack message: %+v", ur.Responses[0].Message)
+		}
+
+		for _, pageRank := range page.Ranks {
+			merged := false
+			for _, rResp := range pbResp.Ranks {
+				if rResp.Rank == pageRank.Rank {
+					rResp.Devices = append(rResp.Devices, pageRank.Devices...)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				pbResp.Ranks = append(pbResp.Ranks, pageRank)
+			}
+		}
+
+		// a cursor that does not advance would loop forever
+		if page.NextCursor == cursor {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	pbResp.NextCursor = ""
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
@@ -45,6 +46,23 @@ func mockSmdQueryMap(t *testing.T, mocks ...*mockSmdQueryResp) HostStorageMap {
 	return hsm
 }
 
+func mockSmdQueryRankResp(rank uint32, devIdx int32) *ctlpb.SmdQueryResp_RankResp {
+	return &ctlpb.SmdQueryResp_RankResp{
+		Rank: rank,
+		Devices: []*ctlpb.SmdQueryResp_Device{
+			{Uuid: common.MockUUID(devIdx), TgtIds: []int32{0}},
+		},
+	}
+}
+
+func mockSmdDevice(rank uint32, devIdx int32) *storage.SmdDevice {
+	return &storage.SmdDevice{
+		UUID:      common.MockUUID(devIdx),
+		Rank:      system.Rank(rank),
+		TargetIDs: []int32{0},
+	}
+}
+
 func TestControl_SmdQuery(t *testing.T) {
 	for name, tc := range map[string]struct {
 		mic     *MockInvokerConfig
@@ -85,6 +103,13 @@ func TestControl_SmdQuery(t *testing.T) {
 			},
 			expErr: errors.New("invalid UUID"),
 		},
+		"invalid host filter": {
+			req: &SmdQueryReq{
+				OmitPools:  true,
+				HostFilter: "host-[1-",
+			},
+			expErr: errors.New("bad host filter"),
+		},
 		"set-faulty with > 1 host": {
 			req: &SmdQueryReq{
 				unaryRequest: unaryRequest{
@@ -209,6 +234,116 @@ func TestControl_SmdQuery(t *testing.T) {
 				}),
 			},
 		},
+		"list devices paged": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					{
+						Responses: []*HostResponse{
+							{
+								Addr: "host-0",
+								Message: &ctlpb.SmdQueryResp{
+									Ranks: []*ctlpb.SmdQueryResp_RankResp{
+										mockSmdQueryRankResp(0, 0),
+									},
+									NextCursor: "0:" + common.MockUUID(0),
+								},
+							},
+							{
+								Addr: "host-1",
+								Message: &ctlpb.SmdQueryResp{
+									Ranks: []*ctlpb.SmdQueryResp_RankResp{
+										mockSmdQueryRankResp(2, 4),
+									},
+								},
+							},
+						},
+					},
+					{
+						Responses: []*HostResponse{
+							{
+								Addr: "host-0",
+								Message: &ctlpb.SmdQueryResp{
+									Ranks: []*ctlpb.SmdQueryResp_RankResp{
+										mockSmdQueryRankResp(0, 1),
+										mockSmdQueryRankResp(1, 2),
+									},
+									NextCursor: "1:" + common.MockUUID(2),
+								},
+							},
+						},
+					},
+					{
+						Responses: []*HostResponse{
+							{
+								Addr: "host-0",
+								Message: &ctlpb.SmdQueryResp{
+									Ranks: []*ctlpb.SmdQueryResp_RankResp{
+										mockSmdQueryRankResp(1, 3),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			req: &SmdQueryReq{OmitPools: true},
+			expResp: &SmdQueryResp{
+				HostStorage: mockSmdQueryMap(t,
+					&mockSmdQueryResp{
+						Hosts: "host-0",
+						SmdInfo: &SmdInfo{
+							Devices: []*storage.SmdDevice{
+								mockSmdDevice(0, 0),
+								mockSmdDevice(0, 1),
+								mockSmdDevice(1, 2),
+								mockSmdDevice(1, 3),
+							},
+							Pools: make(map[string][]*SmdPool),
+						},
+					},
+					&mockSmdQueryResp{
+						Hosts: "host-1",
+						SmdInfo: &SmdInfo{
+							Devices: []*storage.SmdDevice{
+								mockSmdDevice(2, 4),
+							},
+							Pools: make(map[string][]*SmdPool),
+						},
+					},
+				),
+			},
+		},
+		"list devices page fails": {
+			mic: &MockInvokerConfig{
+				UnaryResponseSet: []*UnaryResponse{
+					{
+						Responses: []*HostResponse{
+							{
+								Addr: "host-0",
+								Message: &ctlpb.SmdQueryResp{
+									Ranks: []*ctlpb.SmdQueryResp_RankResp{
+										mockSmdQueryRankResp(0, 0),
+									},
+									NextCursor: "0:" + common.MockUUID(0),
+								},
+							},
+						},
+					},
+					{
+						Responses: []*HostResponse{
+							{
+								Addr:  "host-0",
+								Error: errors.New("remote failed"),
+							},
+						},
+					},
+				},
+			},
+			req: &SmdQueryReq{OmitPools: true},
+			expResp: &SmdQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host-0", "remote failed"}),
+			},
+		},
 		"device health": {
 			mic: &MockInvokerConfig{
 				UnaryResponse: &UnaryResponse{
@@ -236,6 +371,8 @@ func TestControl_SmdQuery(t *testing.T) {
 													ReadOnlyWarn:       true,
 													DevReliabilityWarn: true,
 													VolatileMemWarn:    true,
+													TotalBytes:         humanize.TByte,
+													AvailBytes:         humanize.GByte,
 												},
 											},
 										},
@@ -253,9 +390,11 @@ func TestControl_SmdQuery(t *testing.T) {
 					SmdInfo: &SmdInfo{
 						Devices: []*storage.SmdDevice{
 							{
-								UUID:      common.MockUUID(0),
-								Rank:      system.Rank(0),
-								TargetIDs: []int32{0},
+								UUID:       common.MockUUID(0),
+								Rank:       system.Rank(0),
+								TargetIDs:  []int32{0},
+								TotalBytes: humanize.TByte,
+								AvailBytes: humanize.GByte,
 								Health: &storage.NvmeHealth{
 									Temperature:     2,
 									MediaErrors:     3,
@@ -0,0 +1,931 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// simUUIDSpace is the namespace from which the UUIDs of simulated pools and
+// members are derived, so that they are stable across invocations.
+var simUUIDSpace = uuid.MustParse("4a1c5e2b-8d3f-4c6a-9b7e-0f2d1a3c5e7b")
+
+type (
+	// SimBytes is a capacity in a simulated topology, given either as a
+	// number of bytes or as a human readable size e.g. "4TB".
+	SimBytes uint64
+
+	// SimScm describes the SCM tier of a simulated engine.
+	SimScm struct {
+		Mount string   `yaml:"mount"`
+		Size  SimBytes `yaml:"size"`
+		Used  SimBytes `yaml:"used,omitempty"`
+	}
+
+	// SimSSD describes an NVMe SSD of a simulated engine.
+	SimSSD struct {
+		PciAddr string   `yaml:"pci_addr"`
+		Model   string   `yaml:"model,omitempty"`
+		Size    SimBytes `yaml:"size"`
+		Used    SimBytes `yaml:"used,omitempty"`
+	}
+
+	// SimEngine describes an I/O Engine of a simulated host.
+	SimEngine struct {
+		Rank     system.Rank `yaml:"rank"`
+		State    string      `yaml:"state,omitempty"`
+		Targets  int         `yaml:"targets,omitempty"`
+		NumaNode uint32      `yaml:"numa_node,omitempty"`
+		Scm      SimScm      `yaml:"scm"`
+		Nvme     []*SimSSD   `yaml:"nvme,omitempty"`
+		state    system.MemberState
+	}
+
+	// SimInterface describes a fabric interface of a simulated host.
+	SimInterface struct {
+		Device   string `yaml:"device"`
+		Provider string `yaml:"provider"`
+		NumaNode uint32 `yaml:"numa_node,omitempty"`
+	}
+
+	// SimHost describes a server in a simulated topology. The IP address is
+	// reported in the addresses of system members, if it is not set one is
+	// assigned from 10.0.0.0/8.
+	SimHost struct {
+		Addr        string          `yaml:"addr"`
+		IP          string          `yaml:"ip,omitempty"`
+		FaultDomain string          `yaml:"fault_domain,omitempty"`
+		Interfaces  []*SimInterface `yaml:"interfaces,omitempty"`
+		Engines     []*SimEngine    `yaml:"engines,omitempty"`
+	}
+
+	// SimPool describes a pool in a simulated topology. Ranks are given as
+	// ranged lists e.g. "0-3", by default the pool spans all ranks and the
+	// first of them hosts the pool service.
+	SimPool struct {
+		Label    string   `yaml:"label,omitempty"`
+		UUID     string   `yaml:"uuid,omitempty"`
+		State    string   `yaml:"state,omitempty"`
+		Ranks    string   `yaml:"ranks,omitempty"`
+		SvcRanks string   `yaml:"svc_ranks,omitempty"`
+		ScmSize  SimBytes `yaml:"scm_size"`
+		ScmUsed  SimBytes `yaml:"scm_used,omitempty"`
+		NvmeSize SimBytes `yaml:"nvme_size,omitempty"`
+		NvmeUsed SimBytes `yaml:"nvme_used,omitempty"`
+		ranks    []system.Rank
+		svcRanks []system.Rank
+	}
+
+	// SimTopology describes a synthetic DAOS system. The first of the
+	// access points is the management service leader.
+	SimTopology struct {
+		SystemName   string     `yaml:"name,omitempty"`
+		AccessPoints []string   `yaml:"access_points,omitempty"`
+		Hosts        []*SimHost `yaml:"hosts"`
+		Pools        []*SimPool `yaml:"pools,omitempty"`
+	}
+)
+
+// UnmarshalYAML implements yaml.Unmarshaler on SimBytes type.
+func (sb *SimBytes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var size string
+	if err := unmarshal(&size); err != nil {
+		return err
+	}
+
+	bytes, err := humanize.ParseBytes(size)
+	if err != nil {
+		return errors.Wrapf(err, "invalid size %q", size)
+	}
+	*sb = SimBytes(bytes)
+
+	return nil
+}
+
+func simMemberState(in string) (system.MemberState, error) {
+	if in == "" {
+		return system.MemberStateJoined, nil
+	}
+
+	for state := system.MemberStateAwaitFormat; state <= system.MemberStateExcluded; state <<= 1 {
+		if strings.EqualFold(in, state.String()) {
+			return state, nil
+		}
+	}
+
+	return system.MemberStateUnknown, errors.Errorf("invalid member state %q", in)
+}
+
+func simUUID(kind, name string) string {
+	return uuid.NewSHA1(simUUIDSpace, []byte(kind+"/"+name)).String()
+}
+
+// LoadSimTopology reads and validates the simulated topology at the given
+// path.
+func LoadSimTopology(path string) (*SimTopology, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read simulated topology")
+	}
+
+	topo := new(SimTopology)
+	if err := yaml.UnmarshalStrict(data, topo); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse simulated topology %s", path)
+	}
+
+	if err := topo.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "simulated topology %s", path)
+	}
+
+	return topo, nil
+}
+
+// Validate sanity checks the topology and fills in default values.
+func (st *SimTopology) Validate() error {
+	if len(st.Hosts) == 0 {
+		return errors.New("no hosts defined")
+	}
+
+	hosts := make(map[string]bool)
+	ranks := make(map[system.Rank]bool)
+	var allRanks []system.Rank
+	for _, host := range st.Hosts {
+		addrs, err := common.ParseHostList([]string{host.Addr}, build.DefaultControlPort)
+		if err != nil {
+			return errors.Wrapf(err, "host %q", host.Addr)
+		}
+		host.Addr = addrs[0]
+		if hosts[host.Addr] {
+			return errors.Errorf("duplicate host %s", host.Addr)
+		}
+		hosts[host.Addr] = true
+
+		if host.IP == "" {
+			host.IP = simHostIP(host.Addr, len(hosts))
+		}
+		if net.ParseIP(host.IP) == nil {
+			return errors.Errorf("host %s: invalid ip %q", host.Addr, host.IP)
+		}
+
+		if host.FaultDomain == "" {
+			host.FaultDomain = "/" + simHostname(host.Addr)
+		}
+		if _, err := system.NewFaultDomainFromString(host.FaultDomain); err != nil {
+			return errors.Wrapf(err, "host %s", host.Addr)
+		}
+
+		for _, engine := range host.Engines {
+			if ranks[engine.Rank] {
+				return errors.Errorf("duplicate rank %d", engine.Rank)
+			}
+			ranks[engine.Rank] = true
+			allRanks = append(allRanks, engine.Rank)
+
+			if engine.state, err = simMemberState(engine.State); err != nil {
+				return errors.Wrapf(err, "rank %d", engine.Rank)
+			}
+			if engine.Targets == 0 {
+				engine.Targets = defaultTargetCount
+			}
+			if engine.Scm.Used > engine.Scm.Size {
+				return errors.Errorf("rank %d: scm used exceeds size", engine.Rank)
+			}
+			for _, ssd := range engine.Nvme {
+				if ssd.Used > ssd.Size {
+					return errors.Errorf("rank %d: nvme %s used exceeds size",
+						engine.Rank, ssd.PciAddr)
+				}
+			}
+		}
+	}
+	sort.Slice(allRanks, func(i, j int) bool { return allRanks[i] < allRanks[j] })
+
+	if len(st.AccessPoints) == 0 {
+		st.AccessPoints = []string{st.Hosts[0].Addr}
+	}
+	aps, err := common.ParseHostList(st.AccessPoints, build.DefaultControlPort)
+	if err != nil {
+		return errors.Wrap(err, "access points")
+	}
+	for _, ap := range aps {
+		if !hosts[ap] {
+			return errors.Errorf("access point %s is not a host", ap)
+		}
+	}
+	st.AccessPoints = aps
+
+	labels := make(map[string]bool)
+	uuids := make(map[string]bool)
+	for _, pool := range st.Pools {
+		if err := pool.validate(ranks, allRanks); err != nil {
+			return err
+		}
+		if pool.Label != "" && labels[pool.Label] {
+			return errors.Errorf("duplicate pool label %q", pool.Label)
+		}
+		if uuids[pool.UUID] {
+			return errors.Errorf("duplicate pool uuid %s", pool.UUID)
+		}
+		labels[pool.Label] = true
+		uuids[pool.UUID] = true
+	}
+
+	return nil
+}
+
+func (sp *SimPool) validate(members map[system.Rank]bool, allRanks []system.Rank) error {
+	switch {
+	case sp.UUID != "":
+		if _, err := uuid.Parse(sp.UUID); err != nil {
+			return errors.Wrapf(err, "pool %q", sp.Label)
+		}
+	case sp.Label != "":
+		sp.UUID = simUUID("pool", sp.Label)
+	default:
+		return errors.New("pool requires a label or uuid")
+	}
+	if sp.State == "" {
+		sp.State = system.PoolServiceStateReady.String()
+	}
+
+	parseRanks := func(ranks string, defRanks []system.Rank) ([]system.Rank, error) {
+		if ranks == "" {
+			return defRanks, nil
+		}
+		rs, err := system.CreateRankSet(ranks)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pool %s", sp.UUID)
+		}
+		for _, rank := range rs.Ranks() {
+			if !members[rank] {
+				return nil, errors.Errorf("pool %s: rank %d is not a system member",
+					sp.UUID, rank)
+			}
+		}
+		return rs.Ranks(), nil
+	}
+
+	var err error
+	if sp.ranks, err = parseRanks(sp.Ranks, allRanks); err != nil {
+		return err
+	}
+	if len(sp.ranks) == 0 {
+		return errors.Errorf("pool %s has no ranks", sp.UUID)
+	}
+	if sp.svcRanks, err = parseRanks(sp.SvcRanks, sp.ranks[:1]); err != nil {
+		return err
+	}
+	for _, svcRank := range sp.svcRanks {
+		if !sp.hasRank(svcRank) {
+			return errors.Errorf("pool %s: service rank %d is not a pool rank",
+				sp.UUID, svcRank)
+		}
+	}
+
+	if sp.ScmUsed > sp.ScmSize || sp.NvmeUsed > sp.NvmeSize {
+		return errors.Errorf("pool %s: used space exceeds size", sp.UUID)
+	}
+
+	return nil
+}
+
+func (sp *SimPool) hasRank(rank system.Rank) bool {
+	for _, r := range sp.ranks {
+		if r == rank {
+			return true
+		}
+	}
+	return false
+}
+
+// simHostIP returns the address of the host if it is an IP address, or else
+// one derived from the host's index in the topology.
+func simHostIP(addr string, idx int) string {
+	if ip := net.ParseIP(simHostname(addr)); ip != nil {
+		return ip.String()
+	}
+	return net.IPv4(10, byte(idx>>16), byte(idx>>8), byte(idx)).String()
+}
+
+func simHostname(addr string) string {
+	return strings.Split(addr, ":")[0]
+}
+
+func (st *SimTopology) findHost(addr string) *SimHost {
+	for _, host := range st.Hosts {
+		if host.Addr == addr {
+			return host
+		}
+	}
+	return nil
+}
+
+func (st *SimTopology) findEngine(rank system.Rank) (*SimHost, *SimEngine) {
+	for _, host := range st.Hosts {
+		for _, engine := range host.Engines {
+			if engine.Rank == rank {
+				return host, engine
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (st *SimTopology) findPool(id string) *SimPool {
+	for _, pool := range st.Pools {
+		if pool.UUID == id || (pool.Label != "" && pool.Label == id) {
+			return pool
+		}
+	}
+	return nil
+}
+
+// SimulatedRequestError indicates that a request which would have modified
+// the system was validated against a simulated topology but not executed.
+type SimulatedRequestError struct {
+	Request string
+}
+
+func (sre *SimulatedRequestError) Error() string {
+	return fmt.Sprintf("simulation: %s request is valid but was not executed", sre.Request)
+}
+
+// IsSimulatedRequest returns true if the error indicates that a request was
+// validated by a Simulator but not executed.
+func IsSimulatedRequest(err error) bool {
+	var sre *SimulatedRequestError
+	return errors.As(err, &sre)
+}
+
+// Simulator implements the Invoker interface, serving read-only requests
+// from a simulated topology and validating requests that would modify the
+// system without executing them. No requests are sent over the network.
+type Simulator struct {
+	log  debugLogger
+	topo *SimTopology
+	sys  string
+}
+
+var _ Invoker = (*Simulator)(nil)
+
+// NewSimulator returns a Simulator serving requests from the given topology.
+func NewSimulator(log debugLogger, topo *SimTopology) *Simulator {
+	if log == nil {
+		log = defaultLogger
+	}
+
+	return &Simulator{
+		log:  log,
+		topo: topo,
+		sys:  build.DefaultSystemName,
+	}
+}
+
+// Debug implements Invoker.
+func (s *Simulator) Debug(msg string) {
+	s.log.Debug(msg)
+}
+
+// Debugf implements Invoker.
+func (s *Simulator) Debugf(fmtStr string, args ...interface{}) {
+	s.log.Debugf(fmtStr, args...)
+}
+
+// GetSystem implements Invoker.
+func (s *Simulator) GetSystem() string {
+	if s.topo.SystemName != "" {
+		return s.topo.SystemName
+	}
+	return s.sys
+}
+
+// SetConfig implements Invoker. Only the system name is used, the hosts are
+// those of the simulated topology.
+func (s *Simulator) SetConfig(cfg *Config) {
+	if cfg != nil && cfg.SystemName != "" {
+		s.sys = cfg.SystemName
+	}
+}
+
+// InvokeUnaryRPC implements Invoker.
+func (s *Simulator) InvokeUnaryRPC(_ context.Context, req UnaryRequest) (*UnaryResponse, error) {
+	return s.simulate(req)
+}
+
+// InvokeUnaryRPCAsync implements Invoker.
+func (s *Simulator) InvokeUnaryRPCAsync(ctx context.Context, req UnaryRequest) (HostResponseChan, error) {
+	ur, err := s.simulate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(HostResponseChan, len(ur.Responses))
+	for _, hr := range ur.Responses {
+		responses <- hr
+	}
+	close(responses)
+
+	return responses, nil
+}
+
+func simRequestName(req UnaryRequest) string {
+	return strings.TrimSuffix(strings.TrimPrefix(fmt.Sprintf("%T", req), "*control."), "Req")
+}
+
+// simulate returns the response to a read-only request, or validates any
+// other request against the topology.
+func (s *Simulator) simulate(req UnaryRequest) (*UnaryResponse, error) {
+	s.log.Debugf("simulating %s request", simRequestName(req))
+
+	var hosts []string
+	if !req.isMSRequest() {
+		var err error
+		if hosts, err = common.ParseHostList(req.getHostList(), build.DefaultControlPort); err != nil {
+			return nil, err
+		}
+		if len(hosts) == 0 {
+			for _, host := range s.topo.Hosts {
+				hosts = append(hosts, host.Addr)
+			}
+		}
+	}
+
+	switch r := req.(type) {
+	case *SystemQueryReq:
+		return s.msResponse(s.systemQuery(r))
+	case *LeaderQueryReq:
+		return s.msResponse(&mgmtpb.LeaderQueryResp{
+			CurrentLeader: s.topo.AccessPoints[0],
+			Replicas:      s.topo.AccessPoints,
+		}, nil)
+	case *ListPoolsReq:
+		return s.msResponse(s.listPools(), nil)
+	case *PoolResolveIDReq:
+		pool := s.topo.findPool(r.HumanID)
+		if pool == nil {
+			return s.msResponse(nil, errors.Errorf("unable to find pool service with "+
+				"human-friendly id %q", r.HumanID))
+		}
+		return s.msResponse(&mgmtpb.PoolResolveIDResp{Uuid: pool.UUID}, nil)
+	case *PoolQueryReq:
+		return s.msResponse(s.poolQuery(r.UUID))
+	case *StorageScanReq:
+		return s.hostResponses(hosts, s.storageScan)
+	case *NetworkScanReq:
+		return s.hostResponses(hosts, s.networkScan)
+	}
+
+	if err := s.validate(req, hosts); err != nil {
+		return nil, err
+	}
+
+	return nil, &SimulatedRequestError{Request: simRequestName(req)}
+}
+
+func (s *Simulator) msResponse(msg proto.Message, err error) (*UnaryResponse, error) {
+	return &UnaryResponse{
+		fromMS: true,
+		Responses: []*HostResponse{
+			{Addr: s.topo.AccessPoints[0], Message: msg, Error: err},
+		},
+	}, nil
+}
+
+// hostResponses returns the response of each host in the list, hosts that are
+// not in the topology fail as if their addresses could not be resolved.
+func (s *Simulator) hostResponses(hosts []string, hostMsg func(*SimHost) (proto.Message, error)) (*UnaryResponse, error) {
+	ur := new(UnaryResponse)
+	for _, addr := range hosts {
+		hr := &HostResponse{Addr: addr}
+		if host := s.topo.findHost(addr); host != nil {
+			hr.Message, hr.Error = hostMsg(host)
+		} else {
+			hr.Error = FaultConnectionBadHost(addr)
+		}
+		ur.Responses = append(ur.Responses, hr)
+	}
+
+	return ur, nil
+}
+
+func (s *Simulator) systemQuery(req *SystemQueryReq) (*mgmtpb.SystemQueryResp, error) {
+	resp := new(mgmtpb.SystemQueryResp)
+
+	absentRanks := make(map[system.Rank]bool)
+	for _, rank := range req.Ranks.Ranks() {
+		absentRanks[rank] = true
+	}
+	absentHosts := make(map[string]bool)
+	if req.Hosts.Count() > 0 {
+		for _, host := range req.Hosts.Slice() {
+			absentHosts[host] = true
+		}
+	}
+	wantRanks := len(absentRanks) != 0
+	wantHosts := len(absentHosts) != 0
+
+	var matchedRanks []system.Rank
+	for _, host := range s.topo.Hosts {
+		hostname := simHostname(host.Addr)
+		if wantHosts && !absentHosts[hostname] {
+			continue
+		}
+		delete(absentHosts, hostname)
+
+		for _, engine := range host.Engines {
+			if wantRanks && !absentRanks[engine.Rank] {
+				continue
+			}
+			matchedRanks = append(matchedRanks, engine.Rank)
+
+			resp.Members = append(resp.Members, &mgmtpb.SystemMember{
+				Addr:        fmt.Sprintf("%s:%s", host.IP, strings.Split(host.Addr, ":")[1]),
+				Uuid:        simUUID("rank", engine.Rank.String()),
+				Rank:        engine.Rank.Uint32(),
+				State:       engine.state.String(),
+				FaultDomain: host.FaultDomain,
+			})
+		}
+	}
+	for _, rank := range matchedRanks {
+		delete(absentRanks, rank)
+	}
+
+	var ranks []system.Rank
+	for rank := range absentRanks {
+		ranks = append(ranks, rank)
+	}
+	resp.Absentranks = system.RankSetFromRanks(ranks).String()
+
+	var hosts []string
+	for host := range absentHosts {
+		hosts = append(hosts, host)
+	}
+	hs, err := hostlist.CreateSet(strings.Join(hosts, ","))
+	if err != nil {
+		return nil, err
+	}
+	resp.Absenthosts = hs.String()
+
+	return resp, nil
+}
+
+func (s *Simulator) listPools() *mgmtpb.ListPoolsResp {
+	resp := new(mgmtpb.ListPoolsResp)
+	for _, pool := range s.topo.Pools {
+		svcReps := make([]uint32, 0, len(pool.svcRanks))
+		for _, rank := range pool.svcRanks {
+			svcReps = append(svcReps, rank.Uint32())
+		}
+		resp.Pools = append(resp.Pools, &mgmtpb.ListPoolsResp_Pool{
+			Uuid:    pool.UUID,
+			SvcReps: svcReps,
+			State:   pool.State,
+		})
+	}
+
+	return resp
+}
+
+func simUsage(total, used SimBytes, nrTargets uint32) *mgmtpb.StorageUsageStats {
+	free := uint64(total - used)
+	perTarget := free / uint64(nrTargets)
+	return &mgmtpb.StorageUsageStats{
+		Total: uint64(total),
+		Free:  free,
+		Min:   perTarget,
+		Max:   perTarget,
+		Mean:  perTarget,
+	}
+}
+
+func (s *Simulator) poolQuery(poolUUID string) (*mgmtpb.PoolQueryResp, error) {
+	pool := s.topo.findPool(poolUUID)
+	if pool == nil {
+		return nil, errors.Wrapf(drpc.DaosNonexistant, "pool %s", poolUUID)
+	}
+
+	resp := &mgmtpb.PoolQueryResp{
+		Uuid:       pool.UUID,
+		TotalNodes: uint32(len(pool.ranks)),
+		Version:    1,
+		Leader:     pool.svcRanks[0].Uint32(),
+		Rebuild:    &mgmtpb.PoolRebuildStatus{State: mgmtpb.PoolRebuildStatus_IDLE},
+	}
+	for _, rank := range pool.ranks {
+		_, engine := s.topo.findEngine(rank)
+		resp.TotalTargets += uint32(engine.Targets)
+		if engine.state&system.AvailableMemberFilter != 0 {
+			resp.ActiveTargets += uint32(engine.Targets)
+		} else {
+			resp.DisabledTargets += uint32(engine.Targets)
+		}
+	}
+	resp.Scm = simUsage(pool.ScmSize, pool.ScmUsed, resp.TotalTargets)
+	resp.Nvme = simUsage(pool.NvmeSize, pool.NvmeUsed, resp.TotalTargets)
+
+	return resp, nil
+}
+
+func (s *Simulator) storageScan(host *SimHost) (proto.Message, error) {
+	var ctrlrs storage.NvmeControllers
+	var namespaces storage.ScmNamespaces
+	for idx, engine := range host.Engines {
+		namespaces = append(namespaces, &storage.ScmNamespace{
+			UUID:        simUUID("pmem", fmt.Sprintf("%s/%d", host.Addr, idx)),
+			BlockDevice: fmt.Sprintf("pmem%d", idx),
+			Name:        fmt.Sprintf("namespace%d.0", idx),
+			NumaNode:    engine.NumaNode,
+			Size:        uint64(engine.Scm.Size),
+			Mount: &storage.ScmMountPoint{
+				Path:       engine.Scm.Mount,
+				TotalBytes: uint64(engine.Scm.Size),
+				AvailBytes: uint64(engine.Scm.Size - engine.Scm.Used),
+			},
+		})
+
+		for _, ssd := range engine.Nvme {
+			ctrlrs = append(ctrlrs, &storage.NvmeController{
+				Model:    ssd.Model,
+				Serial:   simUUID("ssd", host.Addr+"/"+ssd.PciAddr)[:8],
+				PciAddr:  ssd.PciAddr,
+				SocketID: int32(engine.NumaNode),
+				Namespaces: []*storage.NvmeNamespace{
+					{ID: 1, Size: uint64(ssd.Size)},
+				},
+				SmdDevices: []*storage.SmdDevice{
+					{
+						UUID:       simUUID("smd", host.Addr+"/"+ssd.PciAddr),
+						State:      "NORMAL",
+						Rank:       engine.Rank,
+						TotalBytes: uint64(ssd.Size),
+						AvailBytes: uint64(ssd.Size - ssd.Used),
+						TrAddr:     ssd.PciAddr,
+					},
+				},
+			})
+		}
+	}
+
+	resp := &ctlpb.StorageScanResp{
+		Nvme: new(ctlpb.ScanNvmeResp),
+		Scm:  new(ctlpb.ScanScmResp),
+	}
+	if err := convert.Types(ctrlrs, &resp.Nvme.Ctrlrs); err != nil {
+		return nil, err
+	}
+	if err := convert.Types(namespaces, &resp.Scm.Namespaces); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (s *Simulator) networkScan(host *SimHost) (proto.Message, error) {
+	resp := new(ctlpb.NetworkScanResp)
+	numaNodes := make(map[uint32]bool)
+	for _, iface := range host.Interfaces {
+		resp.Interfaces = append(resp.Interfaces, &ctlpb.FabricInterface{
+			Provider: iface.Provider,
+			Device:   iface.Device,
+			Numanode: iface.NumaNode,
+		})
+		numaNodes[iface.NumaNode] = true
+	}
+	resp.Numacount = int32(len(numaNodes))
+
+	return resp, nil
+}
+
+// validate checks that a request which would modify the system is consistent
+// with the topology.
+func (s *Simulator) validate(req UnaryRequest, hosts []string) error {
+	for _, addr := range hosts {
+		if s.topo.findHost(addr) == nil {
+			return FaultConnectionBadHost(addr)
+		}
+	}
+
+	switch r := req.(type) {
+	case *PoolCreateReq:
+		return s.validatePoolCreate(r)
+	case *PoolDestroyReq:
+		return s.checkPool(r.UUID)
+	case *PoolRestoreReq:
+		return s.checkPool(r.UUID)
+	case *PoolEvictReq:
+		return s.checkPool(r.UUID)
+	case *PoolSetPropReq:
+		return s.checkPool(r.UUID)
+	case *PoolOverwriteACLReq:
+		return s.checkPool(r.UUID)
+	case *PoolUpdateACLReq:
+		return s.checkPool(r.UUID)
+	case *PoolDeleteACLReq:
+		return s.checkPool(r.UUID)
+	case *ContSetOwnerReq:
+		return s.checkPool(r.PoolUUID)
+	case *PoolExcludeReq:
+		return s.checkPoolRank(r.UUID, r.Rank)
+	case *PoolDrainReq:
+		return s.checkPoolRank(r.UUID, r.Rank)
+	case *PoolReintegrateReq:
+		return s.checkPoolRank(r.UUID, r.Rank)
+	case *PoolExtendReq:
+		return s.validatePoolExtend(r)
+	case *CheckStartReq:
+		for _, id := range r.Pools {
+			if err := s.checkPool(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *SystemStartReq:
+		return s.checkSysRequest(&r.sysRequest)
+	case *SystemStopReq:
+		return s.checkSysRequest(&r.sysRequest)
+	case *SystemExcludeReq:
+		return s.checkSysRequest(&r.sysRequest)
+	case *SystemMaintenanceReq:
+		return s.checkSysRequest(&r.sysRequest)
+	case *SystemSetFaultDomainReq:
+		return s.checkSysRequest(&r.sysRequest)
+	case *SystemEraseReq, *SystemLockReq, *CheckRepairReq, *StorageFormatReq,
+		*StoragePrepareReq, *FirmwareUpdateReq:
+		return nil
+	default:
+		return errors.Errorf("%s requests are not supported in simulation mode",
+			simRequestName(req))
+	}
+}
+
+func (s *Simulator) checkPool(id string) error {
+	if s.topo.findPool(id) == nil {
+		return errors.Wrapf(drpc.DaosNonexistant, "pool %s", id)
+	}
+	return nil
+}
+
+func (s *Simulator) checkRank(rank system.Rank) error {
+	if _, engine := s.topo.findEngine(rank); engine == nil {
+		return errors.Errorf("rank %d is not a system member", rank)
+	}
+	return nil
+}
+
+func (s *Simulator) checkPoolRank(id string, rank system.Rank) error {
+	if err := s.checkPool(id); err != nil {
+		return err
+	}
+	if err := s.checkRank(rank); err != nil {
+		return err
+	}
+	if !s.topo.findPool(id).hasRank(rank) {
+		return errors.Errorf("rank %d is not a rank of pool %s", rank, id)
+	}
+	return nil
+}
+
+func (s *Simulator) checkSysRequest(req *sysRequest) error {
+	for _, rank := range req.Ranks.Ranks() {
+		if err := s.checkRank(rank); err != nil {
+			return err
+		}
+	}
+
+	if req.Hosts.Count() == 0 {
+		return nil
+	}
+	for _, hostname := range req.Hosts.Slice() {
+		var found bool
+		for _, host := range s.topo.Hosts {
+			if simHostname(host.Addr) == hostname {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Errorf("host %s is not a system member", hostname)
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) validatePoolCreate(req *PoolCreateReq) error {
+	if req.Label != "" && s.topo.findPool(req.Label) != nil {
+		return errors.Wrapf(drpc.DaosExists, "pool label %q", req.Label)
+	}
+
+	ranks := req.Ranks
+	if len(ranks) == 0 {
+		for _, host := range s.topo.Hosts {
+			for _, engine := range host.Engines {
+				if engine.state&system.AvailableMemberFilter != 0 {
+					ranks = append(ranks, engine.Rank)
+				}
+			}
+		}
+		if req.NumRanks > 0 && int(req.NumRanks) < len(ranks) {
+			ranks = ranks[:req.NumRanks]
+		}
+	}
+	if len(ranks) == 0 {
+		return errors.New("no available ranks to create the pool on")
+	}
+	if req.NumRanks > uint32(len(ranks)) {
+		return errors.Errorf("%d ranks requested but only %d are available",
+			req.NumRanks, len(ranks))
+	}
+	if req.NumSvcReps > uint32(len(ranks)) {
+		return errors.Errorf("%d service replicas requested for a pool of %d ranks",
+			req.NumSvcReps, len(ranks))
+	}
+
+	// manual sizes are allocated on each rank, the total size is spread
+	// across all of them
+	var totalFree uint64
+	minScmFree, minNvmeFree := uint64(math.MaxUint64), uint64(math.MaxUint64)
+	for _, rank := range ranks {
+		_, engine := s.topo.findEngine(rank)
+		if engine == nil {
+			return errors.Errorf("rank %d is not a system member", rank)
+		}
+		if engine.state&system.AvailableMemberFilter == 0 {
+			return errors.Errorf("rank %d is not available (%s)", rank, engine.state)
+		}
+
+		scmFree := uint64(engine.Scm.Size - engine.Scm.Used)
+		var nvmeFree uint64
+		for _, ssd := range engine.Nvme {
+			nvmeFree += uint64(ssd.Size - ssd.Used)
+		}
+		if scmFree < minScmFree {
+			minScmFree = scmFree
+		}
+		if nvmeFree < minNvmeFree {
+			minNvmeFree = nvmeFree
+		}
+		totalFree += scmFree + nvmeFree
+	}
+
+	for _, check := range []struct {
+		kind      string
+		req, free uint64
+	}{
+		{"total", req.TotalBytes, totalFree},
+		{"per-rank scm", req.ScmBytes, minScmFree},
+		{"per-rank nvme", req.NvmeBytes, minNvmeFree},
+	} {
+		if check.req > check.free {
+			return errors.Wrapf(drpc.DaosNoSpace, "%s size %s requested, %s available",
+				check.kind, humanize.IBytes(check.req), humanize.IBytes(check.free))
+		}
+	}
+
+	return nil
+}
+
+func (s *Simulator) validatePoolExtend(req *PoolExtendReq) error {
+	if err := s.checkPool(req.UUID); err != nil {
+		return err
+	}
+
+	pool := s.topo.findPool(req.UUID)
+	for _, rank := range req.Ranks {
+		if err := s.checkRank(rank); err != nil {
+			return err
+		}
+		if pool.hasRank(rank) {
+			return errors.Errorf("rank %d is already a rank of pool %s", rank, req.UUID)
+		}
+	}
+
+	return nil
+}
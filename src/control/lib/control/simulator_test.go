@@ -0,0 +1,300 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const simExample = "../../../../utils/config/examples/dmg_simulate.yml"
+
+const simTopology = `
+hosts:
+- addr: host1
+  engines:
+  - rank: 0
+    scm: {mount: /mnt/daos0, size: 100GB}
+    nvme:
+    - {pci_addr: "0000:81:00.0", size: 1TB}
+  - rank: 1
+    state: excluded
+    scm: {mount: /mnt/daos1, size: 100GB}
+- addr: host2:10002
+  engines:
+  - rank: 2
+    targets: 8
+    scm: {mount: /mnt/daos0, size: 100GB, used: 50GB}
+pools:
+- label: tank
+  ranks: 0-1
+  scm_size: 10GB
+  scm_used: 2GB
+`
+
+func writeSimTopology(t *testing.T, dir, topo string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "topology.yml")
+	if err := ioutil.WriteFile(path, []byte(topo), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestControl_LoadSimTopology(t *testing.T) {
+	for name, tc := range map[string]struct {
+		topo   string
+		expErr error
+	}{
+		"valid": {
+			topo: simTopology,
+		},
+		"no hosts": {
+			topo:   "name: daos_server",
+			expErr: errors.New("no hosts"),
+		},
+		"unknown field": {
+			topo:   "hosts:\n- addr: host1\n  ranks: 2",
+			expErr: errors.New("not found"),
+		},
+		"duplicate host": {
+			topo:   "hosts:\n- addr: host1\n- addr: host1:10001",
+			expErr: errors.New("duplicate host"),
+		},
+		"duplicate rank": {
+			topo: "hosts:\n- addr: host1\n  engines:\n  - rank: 1\n" +
+				"- addr: host2\n  engines:\n  - rank: 1",
+			expErr: errors.New("duplicate rank 1"),
+		},
+		"bad state": {
+			topo:   "hosts:\n- addr: host1\n  engines:\n  - rank: 1\n    state: dancing",
+			expErr: errors.New("invalid member state"),
+		},
+		"bad size": {
+			topo:   "hosts:\n- addr: host1\n  engines:\n  - {rank: 1, scm: {size: lots}}",
+			expErr: errors.New("invalid size"),
+		},
+		"used exceeds size": {
+			topo:   "hosts:\n- addr: host1\n  engines:\n  - {rank: 1, scm: {size: 1GB, used: 2GB}}",
+			expErr: errors.New("used exceeds size"),
+		},
+		"access point not a host": {
+			topo:   "access_points: [host2]\nhosts:\n- addr: host1",
+			expErr: errors.New("not a host"),
+		},
+		"pool without id": {
+			topo:   "hosts:\n- addr: host1\n  engines:\n  - rank: 0\npools:\n- scm_size: 1GB",
+			expErr: errors.New("label or uuid"),
+		},
+		"pool on unknown rank": {
+			topo: "hosts:\n- addr: host1\n  engines:\n  - rank: 0\n" +
+				"pools:\n- {label: tank, ranks: 0-1}",
+			expErr: errors.New("rank 1 is not a system member"),
+		},
+		"pool service rank outside pool": {
+			topo: "hosts:\n- addr: host1\n  engines:\n  - rank: 0\n  - rank: 1\n" +
+				"pools:\n- {label: tank, ranks: 0, svc_ranks: 1}",
+			expErr: errors.New("not a pool rank"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			_, err := LoadSimTopology(writeSimTopology(t, testDir, tc.topo))
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestControl_LoadSimTopology_example(t *testing.T) {
+	topo, err := LoadSimTopology(simExample)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	common.AssertEqual(t, 2, len(topo.Hosts), "unexpected host count")
+	common.AssertEqual(t, "server-1:10001", topo.AccessPoints[0], "unexpected leader")
+}
+
+func newTestSimulator(t *testing.T, log logging.Logger) *Simulator {
+	t.Helper()
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	topo, err := LoadSimTopology(writeSimTopology(t, testDir, simTopology))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSimulator(log, topo)
+}
+
+func TestControl_Simulator_readOnly(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	sim := newTestSimulator(t, log)
+	ctx := context.Background()
+
+	sqResp, err := SystemQuery(ctx, sim, new(SystemQueryReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 3, len(sqResp.Members), "unexpected member count")
+	common.AssertEqual(t, system.MemberStateExcluded, sqResp.Members[1].State(),
+		"unexpected member state")
+	common.AssertEqual(t, "10.0.0.2:10002", sqResp.Members[2].Addr.String(),
+		"unexpected member address")
+
+	req := new(SystemQueryReq)
+	req.Ranks.ReplaceSet(system.MustCreateRankSet("2-3"))
+	sqResp, err = SystemQuery(ctx, sim, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, "3", sqResp.AbsentRanks.String(), "unexpected absent ranks")
+	common.AssertEqual(t, 1, len(sqResp.Members), "unexpected filtered member count")
+
+	lpResp, err := ListPools(ctx, sim, new(ListPoolsReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 1, len(lpResp.Pools), "unexpected pool count")
+	poolUUID := lpResp.Pools[0].UUID
+
+	rResp, err := PoolResolveID(ctx, sim, &PoolResolveIDReq{HumanID: "tank"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, poolUUID, rResp.UUID, "unexpected resolved uuid")
+
+	_, err = PoolResolveID(ctx, sim, &PoolResolveIDReq{HumanID: "pond"})
+	common.CmpErr(t, errors.New("unable to find pool"), err)
+
+	pqResp, err := PoolQuery(ctx, sim, &PoolQueryReq{UUID: poolUUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, uint32(2*defaultTargetCount), pqResp.TotalTargets,
+		"unexpected total targets")
+	common.AssertEqual(t, uint32(defaultTargetCount), pqResp.DisabledTargets,
+		"unexpected disabled targets")
+	common.AssertEqual(t, uint64(8*humanize.GByte), pqResp.Scm.Free, "unexpected scm free")
+
+	ssResp, err := StorageScan(ctx, sim, new(StorageScanReq))
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 2, len(ssResp.HostStorage), "unexpected host storage count")
+	common.AssertEqual(t, 0, len(ssResp.GetHostErrors()), "unexpected host errors")
+
+	req2 := new(StorageScanReq)
+	req2.SetHostList([]string{"host1", "host9"})
+	ssResp, err = StorageScan(ctx, sim, req2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 1, len(ssResp.GetHostErrors()), "expected host error")
+}
+
+func TestControl_Simulator_validate(t *testing.T) {
+	tankUUID := simUUID("pool", "tank")
+
+	for name, tc := range map[string]struct {
+		req    UnaryRequest
+		expErr error
+	}{
+		"pool create": {
+			req: &PoolCreateReq{Label: "pond", ScmBytes: humanize.GByte},
+		},
+		"pool create existing label": {
+			req:    &PoolCreateReq{Label: "tank", ScmBytes: humanize.GByte},
+			expErr: drpc.DaosExists,
+		},
+		"pool create on unavailable rank": {
+			req:    &PoolCreateReq{Ranks: []system.Rank{1}, ScmBytes: humanize.GByte},
+			expErr: errors.New("rank 1 is not available"),
+		},
+		"pool create too large": {
+			req:    &PoolCreateReq{ScmBytes: 60 * humanize.GByte},
+			expErr: drpc.DaosNoSpace,
+		},
+		"pool create too many service replicas": {
+			req:    &PoolCreateReq{ScmBytes: humanize.GByte, NumSvcReps: 3},
+			expErr: errors.New("service replicas"),
+		},
+		"pool destroy": {
+			req: &PoolDestroyReq{UUID: tankUUID},
+		},
+		"pool destroy unknown pool": {
+			req:    &PoolDestroyReq{UUID: common.MockUUID(9)},
+			expErr: drpc.DaosNonexistant,
+		},
+		"pool exclude": {
+			req: &PoolExcludeReq{UUID: tankUUID, Rank: 1},
+		},
+		"pool exclude rank not in pool": {
+			req:    &PoolExcludeReq{UUID: tankUUID, Rank: 2},
+			expErr: errors.New("not a rank of pool"),
+		},
+		"pool extend": {
+			req: &PoolExtendReq{UUID: tankUUID, Ranks: []system.Rank{2}},
+		},
+		"pool extend existing rank": {
+			req:    &PoolExtendReq{UUID: tankUUID, Ranks: []system.Rank{0}},
+			expErr: errors.New("already a rank"),
+		},
+		"system stop unknown rank": {
+			req: func() UnaryRequest {
+				req := new(SystemStopReq)
+				req.Ranks.ReplaceSet(system.MustCreateRankSet("5"))
+				return req
+			}(),
+			expErr: errors.New("rank 5 is not a system member"),
+		},
+		"storage format unknown host": {
+			req: func() UnaryRequest {
+				req := new(StorageFormatReq)
+				req.SetHostList([]string{"host9"})
+				return req
+			}(),
+			expErr: FaultConnectionBadHost("host9:10001"),
+		},
+		"unsupported request": {
+			req:    new(SmdQueryReq),
+			expErr: errors.New("not supported in simulation mode"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			sim := newTestSimulator(t, log)
+
+			_, err := sim.InvokeUnaryRPC(context.Background(), tc.req)
+			if tc.expErr == nil {
+				if !IsSimulatedRequest(err) {
+					t.Fatalf("expected simulated request error, got %v", err)
+				}
+				return
+			}
+			common.CmpErr(t, tc.expErr, err)
+		})
+	}
+}
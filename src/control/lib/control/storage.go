@@ -10,6 +10,8 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/hashstructure/v2"
 	"github.com/pkg/errors"
@@ -55,6 +57,18 @@ type HostStorage struct {
 	// RebootRequired indicates that a host reboot is necessary in order
 	// to achieve some goal (SCM prep, etc.)
 	RebootRequired bool `json:"reboot_required"`
+
+	// NvmeCapabilities describes the SPDK and DPDK libraries used by the
+	// host to access NVMe SSDs, if requested.
+	NvmeCapabilities *storage.BdevCapabilities `json:"nvme_capabilities,omitempty"`
+
+	// NvmePrepareStatus describes the driver binding of the NVMe SSDs on
+	// the host and the last prepare or reset performed, if requested.
+	NvmePrepareStatus *storage.NvmePrepareStatus `json:"nvme_prepare_status,omitempty"`
+
+	// DeviceOwners contains the engine instances recorded in the host's
+	// device ledger as owning its storage devices.
+	DeviceOwners []*DeviceOwner `json:"device_owners,omitempty"`
 }
 
 // HashKey returns a uint64 value suitable for use as a key into
@@ -167,6 +181,13 @@ func (ssp *StorageScanResp) addHostResponse(hr *HostResponse) error {
 		}
 	}
 
+	if nvmeResp.GetCapabilities() != nil {
+		hs.NvmeCapabilities = new(storage.BdevCapabilities)
+		if err := convert.Types(nvmeResp.GetCapabilities(), hs.NvmeCapabilities); err != nil {
+			return err
+		}
+	}
+
 	scmResp := pbResp.GetScm()
 	scmState := scmResp.GetState()
 	switch scmState.GetStatus() {
@@ -190,6 +211,10 @@ func (ssp *StorageScanResp) addHostResponse(hr *HostResponse) error {
 		}
 	}
 
+	if len(pbResp.GetOwners()) > 0 {
+		hs.DeviceOwners = newDeviceOwners(pbResp.GetOwners())
+	}
+
 	if ssp.HostStorage == nil {
 		ssp.HostStorage = make(HostStorageMap)
 	}
@@ -253,6 +278,9 @@ type (
 		NrHugePages  int32
 		TargetUser   string
 		Reset        bool
+		// Status requests the current driver binding of the NVMe SSDs
+		// on each host, nothing is prepared or reset.
+		Status bool
 	}
 
 	// ScmPrepareReq contains the parameters for a SCM prepare request.
@@ -270,6 +298,8 @@ type (
 	// StoragePrepareResp contains the response from a storage prepare request.
 	StoragePrepareResp struct {
 		HostErrorsResp
+		HostWarningsResp
+		HostTimingsResp
 		HostStorage HostStorageMap
 	}
 )
@@ -288,6 +318,11 @@ func (spr *StoragePrepareResp) addHostResponse(hr *HostResponse) (err error) {
 		if err := spr.addHostError(hr.Addr, errors.New(pbErr)); err != nil {
 			return err
 		}
+	} else if pbResp.GetNvme().GetBindState() != "" {
+		hs.NvmePrepareStatus = new(storage.NvmePrepareStatus)
+		if err := convert.Types(pbResp.GetNvme(), hs.NvmePrepareStatus); err != nil {
+			return spr.addHostError(hr.Addr, err)
+		}
 	}
 
 	if pbResp.GetScm().GetState().GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
@@ -303,6 +338,13 @@ func (spr *StoragePrepareResp) addHostResponse(hr *HostResponse) (err error) {
 		hs.RebootRequired = pbResp.GetScm().GetRebootrequired()
 	}
 
+	if err := spr.addHostWarnings(hr.Addr, pbResp.GetWarnings()); err != nil {
+		return err
+	}
+	if err := spr.addHostTimings(hr.Addr, pbResp.GetTimings()); err != nil {
+		return err
+	}
+
 	if spr.HostStorage == nil {
 		spr.HostStorage = make(HostStorageMap)
 	}
@@ -350,11 +392,23 @@ type (
 	StorageFormatReq struct {
 		unaryRequest
 		Reformat bool
+		Restart  bool
+		// MaxParallel limits the number of hosts formatted at once,
+		// all hosts are formatted concurrently if zero.
+		MaxParallel uint
+		// MaxFailures is the number of hosts that may fail to format
+		// before the remaining hosts are skipped, no limit if nil.
+		MaxFailures *uint
+		// AbortMultiDomain skips the remaining hosts once hosts in
+		// more than one fault domain have failed to format.
+		AbortMultiDomain bool
 	}
 
 	// StorageFormatResp contains the response from a storage format request.
 	StorageFormatResp struct {
 		HostErrorsResp
+		HostWarningsResp
+		HostTimingsResp
 		HostStorage HostStorageMap
 	}
 )
@@ -414,6 +468,13 @@ func (sfr *StorageFormatResp) addHostResponse(hr *HostResponse) (err error) {
 		}
 	}
 
+	if err := sfr.addHostWarnings(hr.Addr, pbResp.GetWarnings()); err != nil {
+		return err
+	}
+	if err := sfr.addHostTimings(hr.Addr, pbResp.GetTimings()); err != nil {
+		return err
+	}
+
 	if sfr.HostStorage == nil {
 		sfr.HostStorage = make(HostStorageMap)
 	}
@@ -474,6 +535,125 @@ func checkFormatReq(ctx context.Context, rpcClient UnaryInvoker, req *StorageFor
 	return nil
 }
 
+// formatLimiter throttles a storage format across hosts, limiting the number
+// of hosts formatted at once and refusing to format any further hosts once the
+// failures seen suggest that something is systemically wrong.
+type formatLimiter struct {
+	sync.Mutex
+	slots            chan struct{}
+	maxFailures      *uint
+	abortMultiDomain bool
+	failures         uint
+	failedDomains    map[string]struct{}
+	abortReason      string
+}
+
+func newFormatLimiter(req *StorageFormatReq) *formatLimiter {
+	fl := &formatLimiter{
+		maxFailures:      req.MaxFailures,
+		abortMultiDomain: req.AbortMultiDomain,
+		failedDomains:    make(map[string]struct{}),
+	}
+	if req.MaxParallel > 0 {
+		fl.slots = make(chan struct{}, req.MaxParallel)
+	}
+
+	return fl
+}
+
+// acquire blocks until a host may be formatted, an error is returned if the
+// format has been aborted in the meantime.
+func (fl *formatLimiter) acquire(ctx context.Context) error {
+	if fl.slots != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case fl.slots <- struct{}{}:
+		}
+	}
+
+	fl.Lock()
+	defer fl.Unlock()
+
+	if fl.abortReason != "" {
+		fl.freeSlot()
+		return FaultFormatAborted(fl.abortReason)
+	}
+
+	return nil
+}
+
+// release records the result of formatting a host and allows another host to
+// be formatted.
+func (fl *formatLimiter) release(resp *ctlpb.StorageFormatResp, err error) {
+	fl.Lock()
+	defer fl.Unlock()
+	defer fl.freeSlot()
+
+	if err == nil && !formatFailed(resp) {
+		return
+	}
+
+	fl.failures++
+	// the fault domain of a host that could not be reached is unknown
+	if fd := resp.GetFaultDomain(); fd != "" {
+		fl.failedDomains[fd] = struct{}{}
+	}
+
+	switch {
+	case fl.abortReason != "":
+	case fl.maxFailures != nil && fl.failures > *fl.maxFailures:
+		fl.abortReason = fmt.Sprintf("%d hosts failed to format, exceeding the limit of %d",
+			fl.failures, *fl.maxFailures)
+	case fl.abortMultiDomain && len(fl.failedDomains) > 1:
+		fl.abortReason = fmt.Sprintf("hosts in %d fault domains failed to format",
+			len(fl.failedDomains))
+	}
+}
+
+func (fl *formatLimiter) freeSlot() {
+	if fl.slots != nil {
+		<-fl.slots
+	}
+}
+
+// setFormatTimeout extends the timeout of a format throttled by MaxParallel so
+// that hosts waiting for others to be formatted don't time out. Each wave of
+// hosts formatted at once is allowed the default request timeout.
+func setFormatTimeout(req *StorageFormatReq) error {
+	if req.MaxParallel == 0 || !req.getDeadline().IsZero() {
+		return nil
+	}
+
+	hosts, err := common.ParseHostList(req.getHostList(), build.DefaultControlPort)
+	if err != nil {
+		return err
+	}
+	waves := (len(hosts) + int(req.MaxParallel) - 1) / int(req.MaxParallel)
+	if waves > 1 {
+		req.SetTimeout(time.Duration(waves) * defaultRequestTimeout)
+	}
+
+	return nil
+}
+
+// formatFailed returns true if the format of any SCM or NVMe device on the
+// host did not succeed.
+func formatFailed(resp *ctlpb.StorageFormatResp) bool {
+	for _, mr := range resp.GetMrets() {
+		if mr.GetState().GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
+			return true
+		}
+	}
+	for _, cr := range resp.GetCrets() {
+		if cr.GetState().GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
+			return true
+		}
+	}
+
+	return false
+}
+
 // StorageFormat concurrently performs storage preparation steps across
 // all hosts supplied in the request's hostlist, or all configured hosts
 // if not explicitly specified. The function blocks until all results
@@ -483,13 +663,28 @@ func StorageFormat(ctx context.Context, rpcClient UnaryInvoker, req *StorageForm
 	if err := checkFormatReq(ctx, rpcClient, req); err != nil {
 		return nil, err
 	}
+	if err := setFormatTimeout(req); err != nil {
+		return nil, err
+	}
+	unlock, err := lockFormatHosts(ctx, rpcClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	pbReq := new(ctlpb.StorageFormatReq)
 	if err := convert.Types(req, pbReq); err != nil {
 		return nil, err
 	}
+	limiter := newFormatLimiter(req)
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
-		return ctlpb.NewCtlSvcClient(conn).StorageFormat(ctx, pbReq)
+		if err := limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := ctlpb.NewCtlSvcClient(conn).StorageFormat(ctx, pbReq)
+		limiter.release(resp, err)
+
+		return resp, err
 	})
 
 	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
@@ -513,3 +708,262 @@ func StorageFormat(ctx context.Context, rpcClient UnaryInvoker, req *StorageForm
 
 	return sfr, nil
 }
+
+type (
+	// DeviceOwner describes the engine instance recorded as owning a
+	// storage device on a host.
+	DeviceOwner struct {
+		Device      string `json:"device"`
+		Class       string `json:"class"`
+		InstanceIdx uint32 `json:"instance_idx"`
+	}
+
+	// StorageOwnershipReq contains the parameters for a storage ownership
+	// query request.
+	StorageOwnershipReq struct {
+		unaryRequest
+	}
+
+	// StorageOwnershipResp contains the results of a storage ownership
+	// query, keyed by host address.
+	StorageOwnershipResp struct {
+		HostErrorsResp
+		HostOwners map[string][]*DeviceOwner `json:"host_owners"`
+	}
+)
+
+// newDeviceOwners converts device ledger entries received from a host.
+func newDeviceOwners(pbOwners []*ctlpb.DeviceOwner) []*DeviceOwner {
+	owners := make([]*DeviceOwner, 0, len(pbOwners))
+	for _, pbOwner := range pbOwners {
+		owners = append(owners, &DeviceOwner{
+			Device:      pbOwner.GetDevice(),
+			Class:       pbOwner.GetClass(),
+			InstanceIdx: pbOwner.GetInstanceidx(),
+		})
+	}
+
+	return owners
+}
+
+func (sor *StorageOwnershipResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.StorageOwnershipResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	if sor.HostOwners == nil {
+		sor.HostOwners = make(map[string][]*DeviceOwner)
+	}
+	sor.HostOwners[hr.Addr] = newDeviceOwners(pbResp.GetOwners())
+
+	return nil
+}
+
+// StorageOwnershipQuery concurrently retrieves the storage device ownership
+// ledgers from all hosts supplied in the request's hostlist, or all configured
+// hosts if not explicitly specified.
+func StorageOwnershipQuery(ctx context.Context, rpcClient UnaryInvoker, req *StorageOwnershipReq) (*StorageOwnershipResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).StorageOwnershipQuery(ctx, &ctlpb.StorageOwnershipReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sor := new(StorageOwnershipResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := sor.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := sor.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return sor, nil
+}
+
+type (
+	// NvmeVerifyResult describes the progress of the read verification of
+	// an NVMe namespace performed after format.
+	NvmeVerifyResult struct {
+		PciAddr       string   `json:"pci_addr"`
+		NsID          uint32   `json:"ns_id"`
+		InstanceIdx   uint32   `json:"instance_idx"`
+		State         string   `json:"state"`
+		TotalBytes    uint64   `json:"total_bytes"`
+		VerifiedBytes uint64   `json:"verified_bytes"`
+		NumBadLBAs    uint64   `json:"num_bad_lbas"`
+		BadLBAs       []uint64 `json:"bad_lbas"`
+		Error         string   `json:"error,omitempty"`
+		Started       int64    `json:"started"`
+		Finished      int64    `json:"finished"`
+	}
+
+	// HostStorageVerify describes the read verification of NVMe namespaces
+	// on a host.
+	HostStorageVerify struct {
+		Rate    uint64              `json:"rate"`
+		Results []*NvmeVerifyResult `json:"results"`
+	}
+
+	// StorageVerifyReq contains the parameters for a storage verify query
+	// request.
+	StorageVerifyReq struct {
+		unaryRequest
+	}
+
+	// StorageVerifyResp contains the results of a storage verify query,
+	// keyed by host address.
+	StorageVerifyResp struct {
+		HostErrorsResp
+		HostVerify map[string]*HostStorageVerify `json:"host_verify"`
+	}
+)
+
+func (svr *StorageVerifyResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.StorageVerifyResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	hv := &HostStorageVerify{
+		Rate:    pbResp.GetRate(),
+		Results: make([]*NvmeVerifyResult, 0, len(pbResp.GetResults())),
+	}
+	for _, pbResult := range pbResp.GetResults() {
+		hv.Results = append(hv.Results, &NvmeVerifyResult{
+			PciAddr:       pbResult.GetPciAddr(),
+			NsID:          pbResult.GetNsId(),
+			InstanceIdx:   pbResult.GetInstanceidx(),
+			State:         pbResult.GetState(),
+			TotalBytes:    pbResult.GetTotalBytes(),
+			VerifiedBytes: pbResult.GetVerifiedBytes(),
+			NumBadLBAs:    pbResult.GetNumBadLbas(),
+			BadLBAs:       pbResult.GetBadLbas(),
+			Error:         pbResult.GetError(),
+			Started:       pbResult.GetStarted(),
+			Finished:      pbResult.GetFinished(),
+		})
+	}
+
+	if svr.HostVerify == nil {
+		svr.HostVerify = make(map[string]*HostStorageVerify)
+	}
+	svr.HostVerify[hr.Addr] = hv
+
+	return nil
+}
+
+// StorageVerifyQuery concurrently retrieves the progress of the NVMe read
+// verification performed after format from all hosts supplied in the request's
+// hostlist, or all configured hosts if not explicitly specified.
+func StorageVerifyQuery(ctx context.Context, rpcClient UnaryInvoker, req *StorageVerifyReq) (*StorageVerifyResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).StorageVerifyQuery(ctx, &ctlpb.StorageVerifyReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	svr := new(StorageVerifyResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := svr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := svr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return svr, nil
+}
+
+type (
+	// BdevCapabilitiesReq contains the parameters for a bdev capabilities
+	// query request.
+	BdevCapabilitiesReq struct {
+		unaryRequest
+	}
+
+	// BdevCapabilitiesResp contains the results of a bdev capabilities
+	// query, keyed by host address.
+	BdevCapabilitiesResp struct {
+		HostErrorsResp
+		HostCapabilities map[string]*storage.BdevCapabilities `json:"host_capabilities"`
+	}
+)
+
+func (bcr *BdevCapabilitiesResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.BdevCapabilitiesResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	caps := new(storage.BdevCapabilities)
+	if err := convert.Types(pbResp.GetCapabilities(), caps); err != nil {
+		return err
+	}
+
+	if bcr.HostCapabilities == nil {
+		bcr.HostCapabilities = make(map[string]*storage.BdevCapabilities)
+	}
+	bcr.HostCapabilities[hr.Addr] = caps
+
+	return nil
+}
+
+// BdevCapabilitiesQuery concurrently retrieves the SPDK and DPDK versions and
+// supported NVMe features from all hosts supplied in the request's hostlist,
+// or all configured hosts if not explicitly specified.
+func BdevCapabilitiesQuery(ctx context.Context, rpcClient UnaryInvoker, req *BdevCapabilitiesReq) (*BdevCapabilitiesResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).BdevCapabilitiesQuery(ctx, &ctlpb.BdevCapabilitiesReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bcr := new(BdevCapabilitiesResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := bcr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := bcr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return bcr, nil
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
@@ -151,6 +152,9 @@ func TestControl_StorageScan(t *testing.T) {
 		bothFailed     = MockServerScanResp(t, "bothFailed")
 		nvmeBasicA     = MockServerScanResp(t, "nvmeBasicA")
 		nvmeBasicB     = MockServerScanResp(t, "nvmeBasicB")
+		withCapsA      = MockServerScanResp(t, "withCapsA")
+		withCapsB      = MockServerScanResp(t, "withCapsB")
+		withOwners     = MockServerScanResp(t, "withOwners")
 	)
 	for name, tc := range map[string]struct {
 		mic         *MockInvokerConfig
@@ -338,6 +342,52 @@ func TestControl_StorageScan(t *testing.T) {
 				HostStorage:    MockHostStorageMap(t, &MockStorageScan{"host1", withSpaceUsage}),
 			},
 		},
+		"two hosts different nvme capabilities": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: withCapsA,
+						},
+						{
+							Addr:    "host2",
+							Message: withCapsB,
+						},
+					},
+				},
+			},
+			expResponse: &StorageScanResp{
+				HostErrorsResp: MockHostErrorsResp(t),
+				HostStorage: MockHostStorageMap(t,
+					&MockStorageScan{"host1", withCapsA},
+					&MockStorageScan{"host2", withCapsB},
+				),
+			},
+		},
+		"two hosts different device owners": {
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:    "host1",
+							Message: standard,
+						},
+						{
+							Addr:    "host2",
+							Message: withOwners,
+						},
+					},
+				},
+			},
+			expResponse: &StorageScanResp{
+				HostErrorsResp: MockHostErrorsResp(t),
+				HostStorage: MockHostStorageMap(t,
+					&MockStorageScan{"host1", standard},
+					&MockStorageScan{"host2", withOwners},
+				),
+			},
+		},
 		"two hosts same scan": {
 			mic: &MockInvokerConfig{
 				UnaryResponse: &UnaryResponse{
@@ -427,6 +477,131 @@ func TestControl_StorageScan(t *testing.T) {
 	}
 }
 
+func TestControl_StoragePrepare(t *testing.T) {
+	mockStorageMap := func(t *testing.T, hs *HostStorage) HostStorageMap {
+		t.Helper()
+
+		hsm := make(HostStorageMap)
+		if err := hsm.Add("host1", hs); err != nil {
+			t.Fatal(err)
+		}
+		return hsm
+	}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *StoragePrepareReq
+		expResp *StoragePrepareResp
+		expErr  error
+	}{
+		"local failure": {
+			req: &StoragePrepareReq{NVMe: &NvmePrepareReq{}},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"nvme failure": {
+			req: &StoragePrepareReq{NVMe: &NvmePrepareReq{}},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StoragePrepareResp{
+						Nvme: &ctlpb.PrepareNvmeResp{
+							State: &ctlpb.ResponseState{
+								Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
+								Error:  "nvme prep error",
+							},
+						},
+					},
+				),
+			},
+			expResp: &StoragePrepareResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "nvme prep error"}),
+				HostStorage:    mockStorageMap(t, &HostStorage{}),
+			},
+		},
+		"timings": {
+			req: &StoragePrepareReq{NVMe: &NvmePrepareReq{}},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StoragePrepareResp{
+						Nvme: &ctlpb.PrepareNvmeResp{State: new(ctlpb.ResponseState)},
+						Scm:  &ctlpb.PrepareScmResp{State: new(ctlpb.ResponseState)},
+						Timings: []*ctlpb.PhaseTiming{
+							{Phase: "nvme prepare", DurationUs: 1500000},
+						},
+					},
+				),
+			},
+			expResp: &StoragePrepareResp{
+				HostTimingsResp: HostTimingsResp{
+					HostTimings: HostTimingsMap{
+						"host1": {{Phase: "nvme prepare", DurationUs: 1500000}},
+					},
+				},
+				HostStorage: mockStorageMap(t, &HostStorage{}),
+			},
+		},
+		"nvme status": {
+			req: &StoragePrepareReq{NVMe: &NvmePrepareReq{Status: true}},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StoragePrepareResp{
+						Nvme: &ctlpb.PrepareNvmeResp{
+							State:     new(ctlpb.ResponseState),
+							BindState: "partially-bound",
+							Bindings: []*ctlpb.PrepareNvmeResp_Binding{
+								{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: "spdk-bound"},
+								{PciAddr: "0000:82:00.0", Driver: "nvme", State: "kernel-bound"},
+							},
+							LastOp:      "reset",
+							LastOpTime:  1634300000,
+							LastOpError: "spdk reset failed",
+						},
+					},
+				),
+			},
+			expResp: &StoragePrepareResp{
+				HostStorage: mockStorageMap(t, &HostStorage{
+					NvmePrepareStatus: &storage.NvmePrepareStatus{
+						BindState: storage.NvmeBindStatePartial,
+						Bindings: storage.NvmeBindings{
+							{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+							{PciAddr: "0000:82:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+						},
+						LastOp:      "reset",
+						LastOpTime:  1634300000,
+						LastOpError: "spdk reset failed",
+					},
+				}),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := StoragePrepare(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_StorageFormat(t *testing.T) {
 	for name, tc := range map[string]struct {
 		mic         *MockInvokerConfig
@@ -801,3 +976,431 @@ func TestControl_checkFormatReq(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_formatLimiter(t *testing.T) {
+	mockFormatResp := func(domain string, failed bool) *ctlpb.StorageFormatResp {
+		status := ctlpb.ResponseStatus_CTL_SUCCESS
+		if failed {
+			status = ctlpb.ResponseStatus_CTL_ERR_SCM
+		}
+		return &ctlpb.StorageFormatResp{
+			Mrets: []*ctlpb.ScmMountResult{
+				{Mntpoint: "/mnt/daos", State: &ctlpb.ResponseState{Status: status}},
+			},
+			FaultDomain: domain,
+		}
+	}
+	maxFailures := func(n uint) *uint {
+		return &n
+	}
+
+	type formatResult struct {
+		resp *ctlpb.StorageFormatResp
+		err  error
+	}
+
+	for name, tc := range map[string]struct {
+		req     *StorageFormatReq
+		results []formatResult
+		expErr  error
+	}{
+		"no limits": {
+			req: &StorageFormatReq{},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", true)},
+				{resp: mockFormatResp("/rack1", true)},
+				{err: errors.New("unreachable")},
+			},
+		},
+		"failures within limit": {
+			req: &StorageFormatReq{MaxFailures: maxFailures(1)},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", false)},
+				{resp: mockFormatResp("/rack0", true)},
+			},
+		},
+		"failures exceed limit": {
+			req: &StorageFormatReq{MaxFailures: maxFailures(1)},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", true)},
+				{err: errors.New("unreachable")},
+			},
+			expErr: FaultFormatAborted("2 hosts failed to format, exceeding the limit of 1"),
+		},
+		"abort on first failure": {
+			req: &StorageFormatReq{MaxFailures: maxFailures(0)},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", true)},
+			},
+			expErr: FaultFormatAborted("1 hosts failed to format, exceeding the limit of 0"),
+		},
+		"failures in single domain": {
+			req: &StorageFormatReq{AbortMultiDomain: true},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", true)},
+				{resp: mockFormatResp("/rack1", false)},
+				{resp: mockFormatResp("/rack0", true)},
+				{err: errors.New("unreachable")},
+			},
+		},
+		"failures in multiple domains": {
+			req: &StorageFormatReq{AbortMultiDomain: true},
+			results: []formatResult{
+				{resp: mockFormatResp("/rack0", true)},
+				{resp: mockFormatResp("/rack1", true)},
+			},
+			expErr: FaultFormatAborted("hosts in 2 fault domains failed to format"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fl := newFormatLimiter(tc.req)
+
+			for _, res := range tc.results {
+				if err := fl.acquire(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+				fl.release(res.resp, res.err)
+			}
+
+			common.CmpErr(t, tc.expErr, fl.acquire(context.Background()))
+		})
+	}
+}
+
+func TestControl_formatLimiter_MaxParallel(t *testing.T) {
+	fl := newFormatLimiter(&StorageFormatReq{MaxParallel: 2})
+
+	for i := 0; i < 2; i++ {
+		if err := fl.acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	common.CmpErr(t, context.DeadlineExceeded, fl.acquire(ctx))
+
+	fl.release(&ctlpb.StorageFormatResp{}, nil)
+	if err := fl.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestControl_setFormatTimeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		hosts       string
+		maxParallel uint
+		timeout     time.Duration
+		expTimeout  time.Duration
+	}{
+		"not throttled": {
+			hosts: "host[1-8]",
+		},
+		"single wave": {
+			hosts:       "host[1-4]",
+			maxParallel: 4,
+		},
+		"partial last wave": {
+			hosts:       "host[1-9]",
+			maxParallel: 4,
+			expTimeout:  3 * defaultRequestTimeout,
+		},
+		"timeout already set": {
+			hosts:       "host[1-9]",
+			maxParallel: 1,
+			timeout:     time.Minute,
+			expTimeout:  time.Minute,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := &StorageFormatReq{MaxParallel: tc.maxParallel}
+			req.SetHostList([]string{tc.hosts})
+			if tc.timeout > 0 {
+				req.SetTimeout(tc.timeout)
+			}
+
+			if err := setFormatTimeout(req); err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.expTimeout == 0 {
+				common.AssertTrue(t, req.getDeadline().IsZero(), "unexpected deadline")
+				return
+			}
+			gotTimeout := time.Until(req.getDeadline())
+			if gotTimeout > tc.expTimeout || gotTimeout < tc.expTimeout-time.Minute {
+				t.Fatalf("expected timeout of %s, got %s", tc.expTimeout, gotTimeout)
+			}
+		})
+	}
+}
+
+func TestControl_StorageOwnershipQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *StorageOwnershipReq
+		expResp *StorageOwnershipResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.StorageOwnershipReq request"),
+		},
+		"local failure": {
+			req: &StorageOwnershipReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &StorageOwnershipReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &StorageOwnershipResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &StorageOwnershipReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, nil),
+			},
+			expErr: errors.New("unpack"),
+		},
+		"success": {
+			req: &StorageOwnershipReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StorageOwnershipResp{
+						Owners: []*ctlpb.DeviceOwner{
+							{Device: "/dev/pmem0", Class: "dcpm", Instanceidx: 0},
+							{Device: "0000:81:00.0", Class: "nvme", Instanceidx: 1},
+						},
+					},
+				),
+			},
+			expResp: &StorageOwnershipResp{
+				HostOwners: map[string][]*DeviceOwner{
+					"host1": {
+						{Device: "/dev/pmem0", Class: "dcpm", InstanceIdx: 0},
+						{Device: "0000:81:00.0", Class: "nvme", InstanceIdx: 1},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := StorageOwnershipQuery(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_StorageVerifyQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *StorageVerifyReq
+		expResp *StorageVerifyResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.StorageVerifyReq request"),
+		},
+		"local failure": {
+			req: &StorageVerifyReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &StorageVerifyReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &StorageVerifyResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &StorageVerifyReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, nil),
+			},
+			expErr: errors.New("unpack"),
+		},
+		"success": {
+			req: &StorageVerifyReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.StorageVerifyResp{
+						Rate: 100,
+						Results: []*ctlpb.NvmeVerifyResult{
+							{
+								PciAddr:       "0000:81:00.0",
+								NsId:          1,
+								Instanceidx:   1,
+								State:         "done",
+								TotalBytes:    4096,
+								VerifiedBytes: 4096,
+								NumBadLbas:    1,
+								BadLbas:       []uint64{3},
+								Started:       10,
+								Finished:      20,
+							},
+						},
+					},
+				),
+			},
+			expResp: &StorageVerifyResp{
+				HostVerify: map[string]*HostStorageVerify{
+					"host1": {
+						Rate: 100,
+						Results: []*NvmeVerifyResult{
+							{
+								PciAddr:       "0000:81:00.0",
+								NsID:          1,
+								InstanceIdx:   1,
+								State:         "done",
+								TotalBytes:    4096,
+								VerifiedBytes: 4096,
+								NumBadLBAs:    1,
+								BadLBAs:       []uint64{3},
+								Started:       10,
+								Finished:      20,
+							},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := StorageVerifyQuery(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_BdevCapabilitiesQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *BdevCapabilitiesReq
+		expResp *BdevCapabilitiesResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil *control.BdevCapabilitiesReq request"),
+		},
+		"local failure": {
+			req: &BdevCapabilitiesReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &BdevCapabilitiesReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", errors.New("remote failed"), nil),
+			},
+			expResp: &BdevCapabilitiesResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &BdevCapabilitiesReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil, nil),
+			},
+			expErr: errors.New("unpack"),
+		},
+		"success": {
+			req: &BdevCapabilitiesReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: MockMSResponse("host1", nil,
+					&ctlpb.BdevCapabilitiesResp{
+						Capabilities: &ctlpb.BdevCapabilities{
+							SpdkVersion: "v21.07",
+							DpdkVersion: "21.05.0",
+							Vmd:         true,
+							Zns:         true,
+							Cmb:         true,
+						},
+					},
+				),
+			},
+			expResp: &BdevCapabilitiesResp{
+				HostCapabilities: map[string]*storage.BdevCapabilities{
+					"host1": {
+						SpdkVersion: "v21.07",
+						DpdkVersion: "21.05.0",
+						Vmd:         true,
+						Zns:         true,
+						Cmb:         true,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := BdevCapabilitiesQuery(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -97,6 +97,7 @@ type SystemJoinReq struct {
 	NumContexts uint32              `json:"Nctxs"`
 	FaultDomain *system.FaultDomain `json:"SrvFaultDomain"`
 	InstanceIdx uint32              `json:"Idx"`
+	Hostname    string
 }
 
 // MarshalJSON packs SystemJoinResp struct into a JSON message.
@@ -165,12 +166,14 @@ type SystemQueryReq struct {
 	sysRequest
 	retryableRequest
 	FailOnUnavailable bool // Fail without retrying if the MS is unavailable.
+	Agents            bool // Include the agents known to the MS.
 }
 
 // SystemQueryResp contains the request response.
 type SystemQueryResp struct {
 	sysResponse
-	Members system.Members `json:"members"`
+	Members system.Members        `json:"members"`
+	Agents  []*system.AgentRecord `json:"agents,omitempty"`
 }
 
 // UnmarshalJSON unpacks JSON message into SystemQueryResp struct.
@@ -214,6 +217,7 @@ func SystemQuery(ctx context.Context, rpcClient UnaryInvoker, req *SystemQueryRe
 	pbReq.Hosts = req.Hosts.String()
 	pbReq.Ranks = req.Ranks.String()
 	pbReq.Sys = req.getSystem(rpcClient)
+	pbReq.Agents = req.Agents
 
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).SystemQuery(ctx, pbReq)
@@ -434,6 +438,7 @@ type SystemEraseReq struct {
 	msRequest
 	unaryRequest
 	retryableRequest
+	NvmeTrim bool // deallocate NVMe SSDs of erased ranks
 }
 
 // SystemEraseResp contains the results of a system erase request.
@@ -495,6 +500,7 @@ func SystemErase(ctx context.Context, rpcClient UnaryInvoker, req *SystemEraseRe
 
 	pbReq := new(mgmtpb.SystemEraseReq)
 	pbReq.Sys = req.getSystem(rpcClient)
+	pbReq.NvmeTrim = req.NvmeTrim
 
 	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
 		return mgmtpb.NewMgmtSvcClient(conn).SystemErase(ctx, pbReq)
@@ -544,6 +550,342 @@ func SystemErase(ctx context.Context, rpcClient UnaryInvoker, req *SystemEraseRe
 	return resp, nil
 }
 
+// MaintenanceAction identifies the operation requested on system
+// maintenance windows.
+type MaintenanceAction int32
+
+const (
+	// MaintenanceList lists the active maintenance windows.
+	MaintenanceList MaintenanceAction = iota
+	// MaintenanceStart starts a new maintenance window.
+	MaintenanceStart
+	// MaintenanceEnd ends the active maintenance windows.
+	MaintenanceEnd
+)
+
+// MaintenanceWindow describes a period during which a set of system members
+// is undergoing administrative maintenance.
+type MaintenanceWindow struct {
+	ID     string        `json:"id"`
+	Hosts  string        `json:"hosts"`
+	Ranks  []system.Rank `json:"ranks"`
+	Reason string        `json:"reason"`
+	Start  time.Time     `json:"start"`
+	End    time.Time     `json:"end"`
+}
+
+// SystemMaintenanceReq contains the inputs for the system maintenance request.
+type SystemMaintenanceReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	Action   MaintenanceAction
+	Duration time.Duration
+	Reason   string
+}
+
+// SystemMaintenanceResp contains the request response.
+type SystemMaintenanceResp struct {
+	sysResponse
+	Windows []*MaintenanceWindow `json:"windows"`
+}
+
+// UnmarshalJSON unpacks JSON message into SystemMaintenanceResp struct.
+func (resp *SystemMaintenanceResp) UnmarshalJSON(data []byte) error {
+	type Alias SystemMaintenanceResp
+	aux := &struct {
+		AbsentHosts string
+		*Alias
+	}{
+		Alias: (*Alias)(resp),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	return resp.getAbsentHostsRanks(aux.AbsentHosts, "")
+}
+
+// Errors returns a single error combining all error messages associated with a
+// system maintenance response.
+func (resp *SystemMaintenanceResp) Errors() error {
+	return resp.getAbsentHostsRanksErrors()
+}
+
+// SystemMaintenance starts, ends or lists maintenance windows recorded by the
+// MS. While a window is active, the ranks on the given hosts will not be
+// excluded from the system if they become unresponsive.
+func SystemMaintenance(ctx context.Context, rpcClient UnaryInvoker, req *SystemMaintenanceReq) (*SystemMaintenanceResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemMaintenanceReq{
+		Sys:      req.getSystem(rpcClient),
+		Action:   mgmtpb.SystemMaintenanceReq_Action(req.Action),
+		Hosts:    req.Hosts.String(),
+		Duration: uint64(req.Duration.Seconds()),
+		Reason:   req.Reason,
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemMaintenance(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system maintenance request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemMaintenanceResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// SystemExcludeReq contains the inputs for the system exclude request.
+type SystemExcludeReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	Clear bool
+}
+
+// SystemExcludeResp contains the request response.
+type SystemExcludeResp struct {
+	sysResponse
+	Results system.MemberResults
+}
+
+// UnmarshalJSON unpacks JSON message into SystemExcludeResp struct.
+func (resp *SystemExcludeResp) UnmarshalJSON(data []byte) error {
+	type Alias SystemExcludeResp
+	aux := &struct {
+		AbsentHosts string
+		AbsentRanks string
+		*Alias
+	}{
+		Alias: (*Alias)(resp),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := resp.getAbsentHostsRanks(aux.AbsentHosts, aux.AbsentRanks); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Errors returns a single error combining all error messages associated with a
+// system exclude response.
+func (resp *SystemExcludeResp) Errors() error {
+	return concatSysErrs(resp.getAbsentHostsRanksErrors(), resp.Results.Errors())
+}
+
+// SystemExclude administratively excludes the selected ranks from the DAOS
+// system, or clears an existing exclusion if Clear is set in the request.
+// Excluded ranks are refused when attempting to rejoin the system.
+func SystemExclude(ctx context.Context, rpcClient UnaryInvoker, req *SystemExcludeReq) (*SystemExcludeResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := new(mgmtpb.SystemExcludeReq)
+	pbReq.Hosts = req.Hosts.String()
+	pbReq.Ranks = req.Ranks.String()
+	pbReq.Sys = req.getSystem(rpcClient)
+	pbReq.Clear = req.Clear
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemExclude(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system exclude request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemExcludeResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// SystemSetFaultDomainReq contains the inputs for the system set fault domain
+// request.
+type SystemSetFaultDomainReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	Domain string
+	Clear  bool
+}
+
+// SystemSetFaultDomainResp contains the request response.
+type SystemSetFaultDomainResp struct {
+	sysResponse
+	Results system.MemberResults
+}
+
+// UnmarshalJSON unpacks JSON message into SystemSetFaultDomainResp struct.
+func (resp *SystemSetFaultDomainResp) UnmarshalJSON(data []byte) error {
+	type Alias SystemSetFaultDomainResp
+	aux := &struct {
+		AbsentHosts string
+		AbsentRanks string
+		*Alias
+	}{
+		Alias: (*Alias)(resp),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := resp.getAbsentHostsRanks(aux.AbsentHosts, aux.AbsentRanks); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Errors returns a single error combining all error messages associated with a
+// system set fault domain response.
+func (resp *SystemSetFaultDomainResp) Errors() error {
+	return concatSysErrs(resp.getAbsentHostsRanksErrors(), resp.Results.Errors())
+}
+
+// SystemSetFaultDomain assigns a fault domain to the selected ranks, overriding
+// the fault domain reported by their servers, or clears an existing assignment
+// if Clear is set in the request. Assignments persist across rank rejoins.
+func SystemSetFaultDomain(ctx context.Context, rpcClient UnaryInvoker, req *SystemSetFaultDomainReq) (*SystemSetFaultDomainResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := new(mgmtpb.SystemSetFaultDomainReq)
+	pbReq.Hosts = req.Hosts.String()
+	pbReq.Ranks = req.Ranks.String()
+	pbReq.Sys = req.getSystem(rpcClient)
+	pbReq.Domain = req.Domain
+	pbReq.Clear = req.Clear
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemSetFaultDomain(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system set fault domain request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemSetFaultDomainResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// SystemActivateSpareReq contains the inputs for the system activate spare
+// request.
+type SystemActivateSpareReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	Rank system.Rank
+}
+
+// SystemActivateSpareResp describes the spare engine that replaced the rank.
+type SystemActivateSpareResp struct {
+	Host            string   `json:"host"`
+	SpareIdx        uint32   `json:"spare_idx"`
+	FailedIdx       uint32   `json:"failed_idx"`
+	ReleasedDevices []string `json:"released_devices"`
+	State           string   `json:"state"`
+	SpareRank       uint32   `json:"spare_rank"`
+}
+
+// SystemActivateSpare replaces a stopped or failed rank with a spare engine
+// on the same server. The spare joins the system as a new rank and the failed
+// rank is excluded so that its data is rebuilt, the storage devices of the
+// failed engine are released so that they can be serviced.
+func SystemActivateSpare(ctx context.Context, rpcClient UnaryInvoker, req *SystemActivateSpareReq) (*SystemActivateSpareResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemActivateSpareReq{
+		Sys:  req.getSystem(rpcClient),
+		Rank: req.Rank.Uint32(),
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemActivateSpare(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system activate spare request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemActivateSpareResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// PoolPropDefault is a system-level default for a pool property, applied to
+// pools created without a value for the property. An enforced default may not
+// be overridden when a pool is created.
+type PoolPropDefault struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Enforced bool   `json:"enforced"`
+}
+
+// SystemPoolDefaultsReq contains the inputs for the system pool defaults
+// request. A request that neither sets nor unsets defaults returns the
+// current defaults.
+type SystemPoolDefaultsReq struct {
+	unaryRequest
+	msRequest
+	Set   []*PoolPropDefault
+	Unset []string
+}
+
+// SystemPoolDefaultsResp contains the pool property defaults in effect after
+// the request.
+type SystemPoolDefaultsResp struct {
+	Defaults []*PoolPropDefault `json:"defaults"`
+}
+
+// SystemPoolDefaults sets, unsets or queries the system-level pool property
+// defaults recorded in the MS.
+func SystemPoolDefaults(ctx context.Context, rpcClient UnaryInvoker, req *SystemPoolDefaultsReq) (*SystemPoolDefaultsResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &mgmtpb.SystemPoolDefaultsReq{
+		Sys:   req.getSystem(rpcClient),
+		Unset: req.Unset,
+	}
+	for _, pd := range req.Set {
+		pbReq.Set = append(pbReq.Set, &mgmtpb.PoolPropDefault{
+			Name:     pd.Name,
+			Value:    pd.Value,
+			Enforced: pd.Enforced,
+		})
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemPoolDefaults(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system pool defaults request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemPoolDefaultsResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
 // LeaderQueryReq contains the inputs for the leader query request.
 type LeaderQueryReq struct {
 	unaryRequest
@@ -576,6 +918,10 @@ func LeaderQuery(ctx context.Context, rpcClient UnaryInvoker, req *LeaderQueryRe
 	return resp, convertMSResponse(ur, resp)
 }
 
+// listPoolsPageSize is the maximum number of pools requested in each
+// ListPools RPC.
+const listPoolsPageSize = 1000
+
 // ListPoolsReq contains the inputs for the list pools command.
 type ListPoolsReq struct {
 	unaryRequest
@@ -590,29 +936,54 @@ type ListPoolsResp struct {
 }
 
 // ListPools fetches the list of all pools and their service replicas from the
-// system.
+// system. Pools are fetched in pages of at most listPoolsPageSize so that the
+// size of each response remains bounded in systems with many pools.
 func ListPools(ctx context.Context, rpcClient UnaryInvoker, req *ListPoolsReq) (*ListPoolsResp, error) {
-	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
-		return mgmtpb.NewMgmtSvcClient(conn).ListPools(ctx, &mgmtpb.ListPoolsReq{
-			Sys: req.getSystem(rpcClient),
+	resp := new(ListPoolsResp)
+
+	var cursor string
+	for {
+		pbReq := &mgmtpb.ListPoolsReq{
+			Sys:    req.getSystem(rpcClient),
+			Cursor: cursor,
+			Limit:  listPoolsPageSize,
+		}
+		req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+			return mgmtpb.NewMgmtSvcClient(conn).ListPools(ctx, pbReq)
 		})
-	})
-	rpcClient.Debugf("DAOS system list-pools request: %s", req)
+		rpcClient.Debugf("DAOS system list-pools request: %s", pbReq)
 
-	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
-	if err != nil {
-		return nil, err
-	}
+		ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+		if err != nil {
+			return nil, err
+		}
 
-	resp := new(ListPoolsResp)
-	return resp, convertMSResponse(ur, resp)
+		pbResp := new(mgmtpb.ListPoolsResp)
+		if err := convertMSResponse(ur, pbResp); err != nil {
+			return nil, err
+		}
+
+		page := new(ListPoolsResp)
+		if err := convert.Types(pbResp, page); err != nil {
+			return nil, err
+		}
+		resp.Status = page.Status
+		resp.Pools = append(resp.Pools, page.Pools...)
+
+		// a cursor that does not advance would loop forever
+		if pbResp.NextCursor == "" || pbResp.NextCursor == cursor {
+			return resp, nil
+		}
+		cursor = pbResp.NextCursor
+	}
 }
 
 // RanksReq contains the parameters for a system ranks request.
 type RanksReq struct {
 	unaryRequest
-	Ranks string
-	Force bool
+	Ranks    string
+	Force    bool
+	NvmeTrim bool `json:"nvme_trim"`
 }
 
 // RanksResp contains the response from a system ranks request.
@@ -772,3 +1143,64 @@ func PingRanks(ctx context.Context, rpcClient UnaryInvoker, req *RanksReq) (*Ran
 
 	return invokeRPCFanout(ctx, rpcClient, req)
 }
+
+// ActivateSpareReq contains the parameters for an activate spare request.
+type ActivateSpareReq struct {
+	unaryRequest
+	Rank system.Rank
+}
+
+// ActivateSpareResp describes the spare engine that replaced the rank on the
+// host.
+type ActivateSpareResp struct {
+	Host            string   `json:"host"`
+	SpareIdx        uint32   `json:"spare_idx"`
+	FailedIdx       uint32   `json:"failed_idx"`
+	ReleasedDevices []string `json:"released_devices"`
+	State           string   `json:"state"`
+	SpareRank       uint32   `json:"spare_rank"`
+}
+
+// ActivateSpare replaces a stopped rank with a spare engine on the single host
+// supplied in the request's hostlist.
+//
+// This is called from SystemActivateSpare in server/mgmt_system.go with the
+// host of the rank in the request parameter and blocks until the spare is
+// ready or the host times out waiting for it to start.
+func ActivateSpare(ctx context.Context, rpcClient UnaryInvoker, req *ActivateSpareReq) (*ActivateSpareResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	pbReq := &ctlpb.ActivateSpareReq{Rank: req.Rank.Uint32()}
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).ActivateSpare(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS activate spare request: %+v", req)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(ur.Responses) != 1 {
+		return nil, errors.Errorf("expected 1 response to activate spare, got %d",
+			len(ur.Responses))
+	}
+	hostResp := ur.Responses[0]
+	if hostResp.Error != nil {
+		return nil, hostResp.Error
+	}
+
+	pbResp, ok := hostResp.Message.(*ctlpb.ActivateSpareResp)
+	if !ok {
+		return nil, errors.Errorf("unable to unpack message: %+v", hostResp.Message)
+	}
+
+	resp := new(ActivateSpareResp)
+	if err := convert.Types(pbResp, resp); err != nil {
+		return nil, err
+	}
+	resp.Host = hostResp.Addr
+
+	return resp, nil
+}
@@ -0,0 +1,279 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// SystemExtendReq contains the inputs for the system extend request.
+type SystemExtendReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	NewHosts *hostlist.HostSet // Hosts being added to the system
+	Pools    []string          // Labels or UUIDs of pools to extend onto the new ranks
+	Interval time.Duration     // Time between checks for the new hosts joining
+	Timeout  time.Duration     // Give up waiting for joins after this long, zero waits forever
+}
+
+// SystemExtendPoolResult describes the outcome of extending a pool onto the
+// ranks of the new hosts.
+type SystemExtendPoolResult struct {
+	ID    string `json:"id"`
+	UUID  string `json:"uuid,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// SystemExtendResp contains the summary of a system extend operation.
+type SystemExtendResp struct {
+	NewHosts     string                    `json:"new_hosts"`
+	Joined       bool                      `json:"joined"`
+	NewRanks     string                    `json:"new_ranks,omitempty"`
+	PendingRanks string                    `json:"pending_ranks,omitempty"`
+	LastError    string                    `json:"last_error,omitempty"`
+	Pools        []*SystemExtendPoolResult `json:"pools,omitempty"`
+}
+
+// Errors returns an error summarizing any pools that could not be extended.
+func (resp *SystemExtendResp) Errors() error {
+	var failed []string
+	for _, pr := range resp.Pools {
+		if pr.Error != "" {
+			failed = append(failed, pr.ID)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return errors.Errorf("failed to extend pools: %s", strings.Join(failed, ","))
+}
+
+// checkCompatibility verifies that all of the new hosts are reachable and
+// that the versions of software installed on them match those on the hosts
+// of existing system members.
+func (req *SystemExtendReq) checkCompatibility(ctx context.Context, rpcClient UnaryInvoker) error {
+	queryReq := new(SystemQueryReq)
+	queryReq.SetSystem(req.Sys)
+	queryReq.SetHostList(req.getHostList())
+
+	queryResp, err := SystemQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return errors.Wrap(err, "querying existing members")
+	}
+	if len(queryResp.Members) == 0 {
+		return errors.New("no existing system members found")
+	}
+
+	existingAddrs := make(map[string]struct{})
+	for _, m := range queryResp.Members {
+		existingAddrs[m.Addr.String()] = struct{}{}
+	}
+	existingReq := new(VersionQueryReq)
+	for addr := range existingAddrs {
+		existingReq.HostList = append(existingReq.HostList, addr)
+	}
+	sort.Strings(existingReq.HostList)
+	existingResp, err := VersionQuery(ctx, rpcClient, existingReq)
+	if err != nil {
+		return errors.Wrap(err, "querying versions on existing hosts")
+	}
+
+	newReq := new(VersionQueryReq)
+	newReq.SetHostList(req.NewHosts.Slice())
+	newResp, err := VersionQuery(ctx, rpcClient, newReq)
+	if err != nil {
+		return errors.Wrap(err, "querying versions on new hosts")
+	}
+	if len(newResp.HostErrors) > 0 {
+		unreachable := hostlist.MustCreateSet("")
+		for _, hes := range newResp.HostErrors {
+			if err := unreachable.MergeSet(hes.HostSet); err != nil {
+				return err
+			}
+		}
+		return errors.Errorf("new hosts unreachable: %s", unreachable)
+	}
+
+	existingSkew := make(map[string]struct{})
+	for _, name := range existingResp.HostVersions.Skewed() {
+		existingSkew[name] = struct{}{}
+	}
+
+	combined := make(HostVersionMap)
+	for _, hvm := range []HostVersionMap{existingResp.HostVersions, newResp.HostVersions} {
+		for _, hvs := range hvm {
+			for _, addr := range hvs.HostSet.Slice() {
+				if err := combined.Add(addr, hvs.HostVersions); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var mismatched []string
+	for _, name := range combined.Skewed() {
+		if _, skewed := existingSkew[name]; !skewed {
+			mismatched = append(mismatched, name)
+		}
+	}
+	if len(mismatched) > 0 {
+		return errors.Errorf("versions on new hosts differ from existing hosts: %s",
+			strings.Join(mismatched, ", "))
+	}
+
+	return nil
+}
+
+// resolvePools returns the UUIDs of the requested pools.
+func (req *SystemExtendReq) resolvePools(ctx context.Context, rpcClient UnaryInvoker) ([]string, error) {
+	uuids := make([]string, 0, len(req.Pools))
+	for _, id := range req.Pools {
+		if _, err := uuid.Parse(id); err == nil {
+			uuids = append(uuids, id)
+			continue
+		}
+
+		resolveReq := &PoolResolveIDReq{HumanID: id}
+		resolveReq.SetSystem(req.Sys)
+		resolveReq.SetHostList(req.getHostList())
+		resolveResp, err := PoolResolveID(ctx, rpcClient, resolveReq)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving pool %s", id)
+		}
+		uuids = append(uuids, resolveResp.UUID)
+	}
+
+	return uuids, nil
+}
+
+// newRanks returns the ranks of the members running on the new hosts.
+func (req *SystemExtendReq) newRanks(ctx context.Context, rpcClient UnaryInvoker) ([]system.Rank, error) {
+	queryReq := new(SystemQueryReq)
+	queryReq.SetSystem(req.Sys)
+	queryReq.SetHostList(req.getHostList())
+	queryReq.Hosts.ReplaceSet(req.NewHosts)
+
+	queryResp, err := SystemQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make([]system.Rank, 0, len(queryResp.Members))
+	for _, m := range queryResp.Members {
+		ranks = append(ranks, m.Rank)
+	}
+	if len(ranks) == 0 {
+		return nil, errors.New("no ranks found on new hosts")
+	}
+
+	return ranks, nil
+}
+
+// extendPool adds the given ranks to a pool, using the per-rank pool size
+// reported by a pool query.
+func (req *SystemExtendReq) extendPool(ctx context.Context, rpcClient UnaryInvoker, poolUUID string, ranks []system.Rank) error {
+	queryReq := &PoolQueryReq{UUID: poolUUID}
+	queryReq.SetSystem(req.Sys)
+	queryReq.SetHostList(req.getHostList())
+
+	queryResp, err := PoolQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return errors.Wrap(err, "querying pool")
+	}
+	if queryResp.TotalNodes == 0 || queryResp.Scm == nil {
+		return errors.New("pool query returned no size information")
+	}
+
+	extendReq := &PoolExtendReq{
+		UUID:     poolUUID,
+		Ranks:    ranks,
+		ScmBytes: queryResp.Scm.Total / uint64(queryResp.TotalNodes),
+	}
+	if queryResp.Nvme != nil {
+		extendReq.NvmeBytes = queryResp.Nvme.Total / uint64(queryResp.TotalNodes)
+	}
+	extendReq.SetSystem(req.Sys)
+	extendReq.SetHostList(req.getHostList())
+
+	return PoolExtend(ctx, rpcClient, extendReq)
+}
+
+// SystemExtend orchestrates the addition of new hosts to a running DAOS
+// system. Compatibility of the new hosts with the existing system members is
+// verified, then the call blocks until the engines on the new hosts have
+// joined and finally the requested pools are extended onto the new ranks.
+//
+// If the new hosts do not join before the timeout expires the response is
+// returned with Joined set to false and no pools are extended. Failures to
+// extend individual pools are recorded in the response.
+func SystemExtend(ctx context.Context, rpcClient UnaryInvoker, req *SystemExtendReq) (*SystemExtendResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.NewHosts == nil || req.NewHosts.Count() == 0 {
+		return nil, errors.New("no new hosts specified")
+	}
+
+	if err := req.checkCompatibility(ctx, rpcClient); err != nil {
+		return nil, errors.Wrap(err, "new hosts are incompatible")
+	}
+	poolUUIDs, err := req.resolvePools(ctx, rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	waitReq := &SystemWaitReq{
+		Condition: WaitRanksJoined,
+		Interval:  req.Interval,
+		Timeout:   req.Timeout,
+	}
+	waitReq.SetSystem(req.Sys)
+	waitReq.SetHostList(req.getHostList())
+	waitReq.Hosts.ReplaceSet(req.NewHosts)
+
+	waitResp, err := SystemWait(ctx, rpcClient, waitReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &SystemExtendResp{
+		NewHosts:     req.NewHosts.String(),
+		Joined:       waitResp.Met,
+		PendingRanks: waitResp.PendingRanks,
+		LastError:    waitResp.LastError,
+	}
+	if !resp.Joined {
+		return resp, nil
+	}
+
+	ranks, err := req.newRanks(ctx, rpcClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying new ranks")
+	}
+	resp.NewRanks = system.RankSetFromRanks(ranks).String()
+
+	for i, poolUUID := range poolUUIDs {
+		pr := &SystemExtendPoolResult{ID: req.Pools[i], UUID: poolUUID}
+		if err := req.extendPool(ctx, rpcClient, poolUUID, ranks); err != nil {
+			pr.Error = err.Error()
+		}
+		resp.Pools = append(resp.Pools, pr)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,222 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_SystemExtend(t *testing.T) {
+	msAddr := "10.0.0.1:10001"
+	mockQueryResp := func(firstRank uint32, addr string, states ...system.MemberState) *UnaryResponse {
+		resp := new(mgmtpb.SystemQueryResp)
+		for i, state := range states {
+			rank := firstRank + uint32(i)
+			resp.Members = append(resp.Members, &mgmtpb.SystemMember{
+				Rank:  rank,
+				Uuid:  common.MockUUID(int32(rank)),
+				State: state.String(),
+				Addr:  addr,
+			})
+		}
+		return MockMSResponse(msAddr, nil, resp)
+	}
+	existingResp := mockQueryResp(0, msAddr, system.MemberStateJoined, system.MemberStateJoined)
+	mockVersionResp := func(addr string, version string) *UnaryResponse {
+		return &UnaryResponse{
+			Responses: []*HostResponse{
+				{
+					Addr: addr,
+					Message: &ctlpb.VersionQueryResp{
+						Components: []*ctlpb.ComponentVersion{
+							{Name: "daos_server", Version: version},
+						},
+					},
+				},
+			},
+		}
+	}
+	existingVersions := mockVersionResp(msAddr, "2.0.0")
+	newVersions := mockVersionResp("new1:10001", "2.0.0")
+	newJoined := mockQueryResp(2, "10.0.0.2:10001", system.MemberStateJoined)
+	resolveResp := MockMSResponse(msAddr, nil, &mgmtpb.PoolResolveIDResp{
+		Uuid: common.MockUUID(1),
+	})
+	poolQueryResp := MockMSResponse(msAddr, nil, &mgmtpb.PoolQueryResp{
+		TotalNodes: 2,
+		Scm:        &mgmtpb.StorageUsageStats{Total: 2 << 30},
+		Nvme:       &mgmtpb.StorageUsageStats{Total: 20 << 30},
+	})
+	extendResp := MockMSResponse(msAddr, nil, &mgmtpb.PoolExtendResp{})
+	extendReq := func(pools ...string) *SystemExtendReq {
+		return &SystemExtendReq{
+			NewHosts: hostlist.MustCreateSet("new1"),
+			Pools:    pools,
+			Interval: time.Millisecond,
+			Timeout:  time.Minute,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req     *SystemExtendReq
+		uResps  []*UnaryResponse
+		uResp   *UnaryResponse
+		expResp *SystemExtendResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemExtendReq request"),
+		},
+		"no new hosts": {
+			req:    &SystemExtendReq{Interval: time.Millisecond},
+			expErr: errors.New("no new hosts"),
+		},
+		"no existing members": {
+			req:    extendReq(),
+			uResps: []*UnaryResponse{MockMSResponse(msAddr, nil, &mgmtpb.SystemQueryResp{})},
+			expErr: errors.New("no existing system members"),
+		},
+		"new host unreachable": {
+			req: extendReq(),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				{
+					Responses: []*HostResponse{
+						{Addr: "new1:10001", Error: errors.New("connection refused")},
+					},
+				},
+			},
+			expErr: errors.New("new hosts unreachable: new1:10001"),
+		},
+		"version mismatch": {
+			req: extendReq(),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				mockVersionResp("new1:10001", "2.2.0"),
+			},
+			expErr: errors.New("differ from existing hosts: daos_server"),
+		},
+		"unknown pool label": {
+			req: extendReq("tank"),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				newVersions,
+				MockMSResponse(msAddr, errors.New("not found"), nil),
+			},
+			expErr: errors.New("resolving pool tank"),
+		},
+		"timeout waiting for join": {
+			req: func() *SystemExtendReq {
+				req := extendReq("tank")
+				req.Timeout = 20 * time.Millisecond
+				return req
+			}(),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				newVersions,
+				resolveResp,
+			},
+			uResp: mockQueryResp(2, "10.0.0.2:10001", system.MemberStateStarting),
+			expResp: &SystemExtendResp{
+				NewHosts:     "new1",
+				PendingRanks: "2",
+			},
+		},
+		"joined without pools": {
+			req: extendReq(),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				newVersions,
+				newJoined,
+				newJoined,
+			},
+			expResp: &SystemExtendResp{
+				NewHosts: "new1",
+				Joined:   true,
+				NewRanks: "2",
+			},
+		},
+		"joined and pools extended": {
+			req: extendReq("tank", common.MockUUID(2)),
+			uResps: []*UnaryResponse{
+				existingResp,
+				existingVersions,
+				newVersions,
+				resolveResp,
+				newJoined,
+				newJoined,
+				poolQueryResp,
+				extendResp,
+				poolQueryResp,
+				MockMSResponse(msAddr, errors.New("extend failed"), nil),
+			},
+			expResp: &SystemExtendResp{
+				NewHosts: "new1",
+				Joined:   true,
+				NewRanks: "2",
+				Pools: []*SystemExtendPoolResult{
+					{ID: "tank", UUID: common.MockUUID(1)},
+					{
+						ID:    common.MockUUID(2),
+						UUID:  common.MockUUID(2),
+						Error: "pool extend failed: extend failed",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+				UnaryResponse:    tc.uResp,
+			})
+
+			gotResp, gotErr := SystemExtend(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemExtendResp_Errors(t *testing.T) {
+	resp := &SystemExtendResp{
+		Pools: []*SystemExtendPoolResult{
+			{ID: "tank"},
+			{ID: "pond", Error: "failed"},
+		},
+	}
+	common.CmpErr(t, errors.New("failed to extend pools: pond"), resp.Errors())
+
+	resp.Pools[1].Error = ""
+	common.CmpErr(t, nil, resp.Errors())
+}
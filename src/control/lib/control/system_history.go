@@ -0,0 +1,201 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/build"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// OperationRecord describes an administrative operation recorded in the MS
+// operation history.
+type OperationRecord struct {
+	ID         uint64    `json:"id"`
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	Initiator  string    `json:"initiator"`
+	Address    string    `json:"address"`
+	Host       string    `json:"host"`
+	Parameters string    `json:"parameters"`
+	Result     string    `json:"result"`
+	Failed     bool      `json:"failed"`
+}
+
+// CEF generates a representation of the operation record in ArcSight Common
+// Event Format.
+func (rec *OperationRecord) CEF() string {
+	outcome, sev := "success", 3
+	if rec.Failed {
+		outcome, sev = "failure", 6
+	}
+	srcIP, srcPort, err := net.SplitHostPort(rec.Address)
+	if err != nil {
+		srcIP, srcPort = "", ""
+	}
+
+	return (&events.CEFRecord{
+		Product:     events.SyslogAppName,
+		Version:     build.DaosVersion,
+		SignatureID: rec.Operation,
+		Name:        "DAOS administrative operation " + rec.Operation,
+		Severity:    sev,
+		Extension: []events.SIEMField{
+			{Name: "rt", Value: events.CEFTime(rec.Time)},
+			{Name: "externalId", Value: fmt.Sprintf("%d", rec.ID)},
+			{Name: "dvchost", Value: rec.Host},
+			{Name: "act", Value: rec.Operation},
+			{Name: "suser", Value: rec.Initiator},
+			{Name: "src", Value: srcIP},
+			{Name: "spt", Value: srcPort},
+			{Name: "outcome", Value: outcome},
+			{Name: "cs1Label", Value: "parameters"},
+			{Name: "cs1", Value: rec.Parameters},
+			{Name: "msg", Value: rec.Result},
+		},
+	}).String()
+}
+
+// RFC5424 generates a representation of the operation record as a syslog
+// message with the record fields in structured data as per RFC5424.
+func (rec *OperationRecord) RFC5424() string {
+	prio := syslog.LOG_NOTICE
+	if rec.Failed {
+		prio = syslog.LOG_WARNING
+	}
+
+	return (&events.SyslogMessage{
+		Priority:  prio | syslog.LOG_DAEMON,
+		Timestamp: rec.Time,
+		Hostname:  rec.Host,
+		MsgID:     rec.Operation,
+		SDName:    "history",
+		SDParams: []events.SIEMField{
+			{Name: "id", Value: fmt.Sprintf("%d", rec.ID)},
+			{Name: "initiator", Value: rec.Initiator},
+			{Name: "addr", Value: rec.Address},
+			{Name: "failed", Value: fmt.Sprintf("%t", rec.Failed)},
+			{Name: "params", Value: rec.Parameters},
+		},
+		Msg: rec.Result,
+	}).String()
+}
+
+// SystemHistoryReq contains the filters for the system history request.
+type SystemHistoryReq struct {
+	unaryRequest
+	msRequest
+	Operation  string    // Only return records of this operation
+	Initiator  string    // Only return records from this initiator
+	Since      time.Time // Only return records after this time
+	FailedOnly bool      // Only return records of failed operations
+	Limit      int       // Maximum number of most recent records to return
+}
+
+// SystemHistoryResp contains the matching operation records, oldest first.
+type SystemHistoryResp struct {
+	Operations []*OperationRecord `json:"operations"`
+}
+
+// SystemHistory queries the history of administrative operations recorded
+// in the MS.
+func SystemHistory(ctx context.Context, rpcClient UnaryInvoker, req *SystemHistoryReq) (*SystemHistoryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+	if req.Limit < 0 {
+		return nil, errors.New("history limit must not be negative")
+	}
+
+	pbReq := &mgmtpb.SystemHistoryReq{
+		Sys:       req.getSystem(rpcClient),
+		Operation: req.Operation,
+		Initiator: req.Initiator,
+		Failed:    req.FailedOnly,
+		Limit:     uint32(req.Limit),
+	}
+	if !req.Since.IsZero() {
+		pbReq.Since = req.Since.Format(time.RFC3339)
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemHistory(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system history request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemHistoryResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// SystemHistoryRecordReq contains an operation performed on a server to be
+// recorded in the MS operation history.
+type SystemHistoryRecordReq struct {
+	unaryRequest
+	msRequest
+	retryableRequest
+	Record *OperationRecord
+}
+
+// SystemHistoryRecord records an administrative operation performed on a
+// server in the MS operation history. Recording is best-effort, so the
+// request fails rather than retrying if the MS is unavailable.
+func SystemHistoryRecord(ctx context.Context, rpcClient UnaryInvoker, req *SystemHistoryRecordReq) error {
+	if req == nil {
+		return errors.Errorf("nil %T request", req)
+	}
+	if req.Record == nil {
+		return errors.New("nil operation record")
+	}
+
+	pbReq := &mgmtpb.SystemHistoryRecordReq{
+		Sys: req.getSystem(rpcClient),
+		Operation: &mgmtpb.SystemOperation{
+			Time:       req.Record.Time.Format(time.RFC3339),
+			Operation:  req.Record.Operation,
+			Initiator:  req.Record.Initiator,
+			Address:    req.Record.Address,
+			Host:       req.Record.Host,
+			Parameters: req.Record.Parameters,
+			Result:     req.Record.Result,
+			Failed:     req.Record.Failed,
+		},
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemHistoryRecord(ctx, pbReq)
+	})
+	req.retryTestFn = func(err error, _ uint) bool {
+		return system.IsUnavailable(err) || IsConnectionError(err)
+	}
+	req.retryFn = func(_ context.Context, _ uint) error {
+		return system.ErrRaftUnavail
+	}
+	rpcClient.Debugf("DAOS system history record request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return convertMSResponse(ur, new(mgmtpb.SystemHistoryRecordResp))
+}
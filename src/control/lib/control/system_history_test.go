@@ -0,0 +1,177 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_SystemHistory(t *testing.T) {
+	opTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *SystemHistoryReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemHistoryResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemHistoryReq request"),
+		},
+		"negative limit": {
+			req:    &SystemHistoryReq{Limit: -1},
+			expErr: errors.New("must not be negative"),
+		},
+		"local failure": {
+			req:    new(SystemHistoryReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemHistoryReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"records": {
+			req: &SystemHistoryReq{
+				Operation: "PoolCreate",
+				Since:     opTime.Add(-time.Hour),
+			},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemHistoryResp{
+					Operations: []*mgmtpb.SystemOperation{
+						{
+							Id:         7,
+							Time:       opTime.Format(time.RFC3339),
+							Operation:  "PoolCreate",
+							Initiator:  "admin",
+							Address:    "10.0.0.9:41234",
+							Host:       "host1",
+							Parameters: `label:"tank"`,
+							Result:     "failed: DER_NOSPACE(-1007): No space on storage target",
+							Failed:     true,
+						},
+					},
+				}),
+			expResp: &SystemHistoryResp{
+				Operations: []*OperationRecord{
+					{
+						ID:         7,
+						Time:       opTime,
+						Operation:  "PoolCreate",
+						Initiator:  "admin",
+						Address:    "10.0.0.9:41234",
+						Host:       "host1",
+						Parameters: `label:"tank"`,
+						Result:     "failed: DER_NOSPACE(-1007): No space on storage target",
+						Failed:     true,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemHistory(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemHistoryRecord(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req    *SystemHistoryRecordReq
+		uResp  *UnaryResponse
+		expErr error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemHistoryRecordReq request"),
+		},
+		"nil record": {
+			req:    new(SystemHistoryRecordReq),
+			expErr: errors.New("nil operation record"),
+		},
+		"remote failure": {
+			req: &SystemHistoryRecordReq{
+				Record: &OperationRecord{Operation: "StorageFormat"},
+			},
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"success": {
+			req: &SystemHistoryRecordReq{
+				Record: &OperationRecord{Operation: "StorageFormat"},
+			},
+			uResp: MockMSResponse("host1", nil, &mgmtpb.SystemHistoryRecordResp{}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponse: tc.uResp,
+			})
+
+			gotErr := SystemHistoryRecord(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
+
+func TestControl_OperationRecord_Formats(t *testing.T) {
+	rec := &OperationRecord{
+		ID:         7,
+		Time:       time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		Operation:  "pool-destroy",
+		Initiator:  "admin",
+		Address:    "10.0.0.9:41234",
+		Host:       "host1",
+		Parameters: `uuid:"foo"`,
+		Result:     "failed: DER_BUSY(-1012): Device or resource busy",
+		Failed:     true,
+	}
+
+	expCEF := "CEF:0|DAOS|daos_server|unset|pool-destroy|DAOS administrative operation pool-destroy|6|" +
+		"rt=1622548800000 externalId=7 dvchost=host1 act=pool-destroy suser=admin src=10.0.0.9 spt=41234 " +
+		`outcome=failure cs1Label=parameters cs1=uuid:"foo" msg=failed: DER_BUSY(-1012): Device or resource busy`
+	if diff := cmp.Diff(expCEF, rec.CEF()); diff != "" {
+		t.Fatalf("unexpected CEF output (-want, +got):\n%s\n", diff)
+	}
+
+	expSyslog := "<28>1 2021-06-01T12:00:00.000000Z host1 daos_server - pool-destroy " +
+		`[history@343 id="7" initiator="admin" addr="10.0.0.9:41234" failed="true" params="uuid:\"foo\""] ` +
+		"failed: DER_BUSY(-1012): Device or resource busy"
+	if diff := cmp.Diff(expSyslog, rec.RFC5424()); diff != "" {
+		t.Fatalf("unexpected RFC5424 output (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,194 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// formatLockPrefix prefixes the names of the locks held on each host
+	// while its storage is being formatted.
+	formatLockPrefix = "storage-format:"
+	// formatLockTTL is the time after which a format lock expires if it
+	// is not released, e.g. because dmg was killed mid-format.
+	formatLockTTL = 30 * time.Minute
+)
+
+// LockAction identifies the operation requested on administrative locks.
+type LockAction int32
+
+const (
+	// LockList lists the held locks.
+	LockList LockAction = iota
+	// LockAcquire acquires the named locks.
+	LockAcquire
+	// LockRelease releases the named locks.
+	LockRelease
+)
+
+// AdminLock describes an administrative lock held in the MS.
+type AdminLock struct {
+	Name     string    `json:"name"`
+	Owner    string    `json:"owner"`
+	Reason   string    `json:"reason"`
+	Acquired time.Time `json:"acquired"`
+	Expires  time.Time `json:"expires"`
+}
+
+// SystemLockReq contains the inputs for the system lock request.
+type SystemLockReq struct {
+	unaryRequest
+	msRequest
+	retryableRequest
+	Action            LockAction
+	Names             []string
+	Owner             string
+	TTL               time.Duration
+	Reason            string
+	Force             bool
+	FailOnUnavailable bool // Fail without retrying if the MS is unavailable.
+}
+
+// SystemLockResp contains the locks affected by the request.
+type SystemLockResp struct {
+	Locks []*AdminLock `json:"locks"`
+}
+
+// DefaultLockOwner returns an owner identity for administrative locks made
+// up of the current user and host names.
+func DefaultLockOwner() string {
+	userName := "unknown"
+	if u, err := user.Current(); err == nil {
+		userName = u.Username
+	}
+	hostName, err := os.Hostname()
+	if err != nil {
+		hostName = "unknown"
+	}
+
+	return fmt.Sprintf("%s@%s", userName, hostName)
+}
+
+// SystemLock acquires, releases or lists the administrative locks held in
+// the MS. Locks are advisory and may be used to prevent conflicting
+// administrative operations from being run concurrently. Acquisition of
+// multiple locks is atomic; if any of them is already held, none are
+// acquired. A lock that is not released expires once its TTL has elapsed.
+func SystemLock(ctx context.Context, rpcClient UnaryInvoker, req *SystemLockReq) (*SystemLockResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	owner := req.Owner
+	if owner == "" && req.Action != LockList {
+		owner = DefaultLockOwner()
+	}
+
+	pbReq := &mgmtpb.SystemLockReq{
+		Sys:    req.getSystem(rpcClient),
+		Action: mgmtpb.SystemLockReq_Action(req.Action),
+		Names:  req.Names,
+		Owner:  owner,
+		Ttl:    uint64(req.TTL.Seconds()),
+		Reason: req.Reason,
+		Force:  req.Force,
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemLock(ctx, pbReq)
+	})
+	req.retryTestFn = func(err error, _ uint) bool {
+		return req.FailOnUnavailable &&
+			(system.IsUnavailable(err) || IsConnectionError(err))
+	}
+	req.retryFn = func(_ context.Context, _ uint) error {
+		if req.FailOnUnavailable {
+			return system.ErrRaftUnavail
+		}
+		return nil
+	}
+	rpcClient.Debugf("DAOS system lock request: %+v", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(SystemLockResp)
+	return resp, convertMSResponse(ur, resp)
+}
+
+// lockFormatHosts acquires a lock on each of the hosts in the format request
+// hostlist so that the storage on a host cannot be formatted by two
+// administrators at once. The returned function releases the locks.
+//
+// Callers should set the hostlist resolved from their configuration, a request
+// without one is not locked. Such a request formats the whole system, which
+// checkFormatReq only permits while the MS is not running, so no locks could be
+// taken. Formatting is also permitted while the MS is not running, in which
+// case no locks can be taken and the format proceeds unlocked.
+func lockFormatHosts(ctx context.Context, rpcClient UnaryInvoker, req *StorageFormatReq) (func(), error) {
+	noop := func() {}
+
+	hosts, err := common.ParseHostList(req.getHostList(), build.DefaultControlPort)
+	if err != nil || len(hosts) == 0 {
+		return noop, err
+	}
+
+	// hold the locks for at least as long as the format may take
+	ttl := formatLockTTL
+	if dl := req.getDeadline(); !dl.IsZero() && time.Until(dl) > ttl {
+		ttl = time.Until(dl)
+	}
+
+	owner := fmt.Sprintf("%s[%d]", DefaultLockOwner(), os.Getpid())
+	lockReq := &SystemLockReq{
+		Action:            LockAcquire,
+		Owner:             owner,
+		TTL:               ttl,
+		Reason:            "storage format",
+		FailOnUnavailable: true,
+	}
+	for _, host := range hosts {
+		lockReq.Names = append(lockReq.Names, formatLockPrefix+host)
+	}
+
+	_, err = SystemLock(ctx, rpcClient, lockReq)
+	switch {
+	case err == nil:
+	case system.IsUnavailable(err) || err == errMSConnectionFailure:
+		rpcClient.Debugf("format locks not acquired: %s", err)
+		return noop, nil
+	default:
+		return nil, errors.Wrap(err, "locking hosts for format")
+	}
+
+	return func() {
+		releaseReq := &SystemLockReq{
+			Action: LockRelease,
+			Names:  lockReq.Names,
+			Owner:  owner,
+		}
+		if _, err := SystemLock(ctx, rpcClient, releaseReq); err != nil {
+			rpcClient.Debugf("failed to release format locks: %s", err)
+		}
+	}, nil
+}
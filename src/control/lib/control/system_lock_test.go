@@ -0,0 +1,158 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_SystemLock(t *testing.T) {
+	acquired := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *SystemLockReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemLockResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemLockReq request"),
+		},
+		"local failure": {
+			req:    new(SystemLockReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemLockReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"locks": {
+			req: &SystemLockReq{
+				Action: LockAcquire,
+				Names:  []string{"a"},
+				TTL:    time.Hour,
+			},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemLockResp{
+					Locks: []*mgmtpb.SystemLock{
+						{
+							Name:     "a",
+							Owner:    "admin@host1",
+							Reason:   "test",
+							Acquired: acquired.Format(time.RFC3339),
+							Expires:  acquired.Add(time.Hour).Format(time.RFC3339),
+						},
+					},
+				}),
+			expResp: &SystemLockResp{
+				Locks: []*AdminLock{
+					{
+						Name:     "a",
+						Owner:    "admin@host1",
+						Reason:   "test",
+						Acquired: acquired,
+						Expires:  acquired.Add(time.Hour),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemLock(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_lockFormatHosts(t *testing.T) {
+	for name, tc := range map[string]struct {
+		reqHosts  []string
+		uResps    []*UnaryResponse
+		expErr    error
+		expInvoke int
+	}{
+		"no hostlist": {},
+		"MS unavailable": {
+			reqHosts: []string{"host1"},
+			uResps: []*UnaryResponse{
+				MockMSResponse("", system.ErrRaftUnavail, nil),
+			},
+			expInvoke: 1,
+		},
+		"MS not contactable": {
+			reqHosts: []string{"host1"},
+			uResps: []*UnaryResponse{
+				MockMSResponse("", errMSConnectionFailure, nil),
+			},
+			expInvoke: 1,
+		},
+		"already locked": {
+			reqHosts: []string{"host1", "host2"},
+			uResps: []*UnaryResponse{
+				MockMSResponse("", errors.New(`lock "storage-format:host2:10001" is held`), nil),
+			},
+			expErr: errors.New("locking hosts for format"),
+		},
+		"locked and released": {
+			reqHosts: []string{"host1", "host2"},
+			uResps: []*UnaryResponse{
+				MockMSResponse("", nil, &mgmtpb.SystemLockResp{}),
+				MockMSResponse("", nil, &mgmtpb.SystemLockResp{}),
+			},
+			expInvoke: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+			})
+
+			req := new(StorageFormatReq)
+			req.SetHostList(tc.reqHosts)
+			unlock, gotErr := lockFormatHosts(context.TODO(), mi, req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			unlock()
+
+			common.AssertEqual(t, tc.expInvoke, mi.invokeCount, "unexpected number of lock requests")
+		})
+	}
+}
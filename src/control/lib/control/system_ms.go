@@ -0,0 +1,133 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+type (
+	// MSReplicaStatus describes the raft status of a Management Service
+	// replica.
+	MSReplicaStatus struct {
+		Addr              string `json:"addr"`
+		State             string `json:"state"`
+		Leader            string `json:"leader"`
+		Term              uint64 `json:"term"`
+		LastIndex         uint64 `json:"last_index"`
+		CommitIndex       uint64 `json:"commit_index"`
+		AppliedIndex      uint64 `json:"applied_index"`
+		LastSnapshotIndex uint64 `json:"last_snapshot_index"`
+		LastSnapshotTime  string `json:"last_snapshot_time"`
+		DBSize            uint64 `json:"db_size"`
+		// Number of log entries committed by the most advanced replica
+		// that have not yet been applied by this replica.
+		Lag uint64 `json:"lag"`
+	}
+
+	// MSHealthQueryReq contains the inputs for the Management Service
+	// health query.
+	MSHealthQueryReq struct {
+		unaryRequest
+		msRequest
+	}
+
+	// MSHealthQueryResp contains the current Management Service leader and
+	// the raft status of each replica that responded.
+	MSHealthQueryResp struct {
+		HostErrorsResp
+		Leader   string             `json:"leader"`
+		Replicas []*MSReplicaStatus `json:"replicas"`
+	}
+
+	// msReplicaQueryReq is sent to each replica rather than to the
+	// Management Service leader.
+	msReplicaQueryReq struct {
+		unaryRequest
+	}
+)
+
+// setLag calculates the replication lag of each replica relative to the
+// highest commit index reported.
+func (resp *MSHealthQueryResp) setLag() {
+	var commitIndex uint64
+	for _, rs := range resp.Replicas {
+		if rs.CommitIndex > commitIndex {
+			commitIndex = rs.CommitIndex
+		}
+	}
+	for _, rs := range resp.Replicas {
+		rs.Lag = 0
+		if commitIndex > rs.AppliedIndex {
+			rs.Lag = commitIndex - rs.AppliedIndex
+		}
+	}
+}
+
+// MSHealthQuery requests the current Management Service leader and replica
+// list and then queries the raft status of each replica directly, so that
+// replicas that have fallen behind or lost contact with the leader can be
+// identified.
+func MSHealthQuery(ctx context.Context, rpcClient UnaryInvoker, req *MSHealthQueryReq) (*MSHealthQueryResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	leaderReq := new(LeaderQueryReq)
+	leaderReq.SetSystem(req.Sys)
+	leaderReq.SetHostList(req.getHostList())
+	leaderResp, err := LeaderQuery(ctx, rpcClient, leaderReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying MS leader")
+	}
+	if len(leaderResp.Replicas) == 0 {
+		return nil, errors.New("no MS replicas found")
+	}
+
+	replicaReq := new(msReplicaQueryReq)
+	replicaReq.SetHostList(leaderResp.Replicas)
+	pbReq := &mgmtpb.SystemReplicaQueryReq{Sys: req.getSystem(rpcClient)}
+	replicaReq.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return mgmtpb.NewMgmtSvcClient(conn).SystemReplicaQuery(ctx, pbReq)
+	})
+	rpcClient.Debugf("DAOS system replica-query request: %s", pbReq)
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, replicaReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MSHealthQueryResp{Leader: leaderResp.Leader}
+	for _, hr := range ur.Responses {
+		if hr.Error != nil {
+			if err := resp.addHostError(hr.Addr, hr.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		rs := &MSReplicaStatus{Addr: hr.Addr}
+		if err := convert.Types(hr.Message, rs); err != nil {
+			return nil, errors.Wrapf(err, "converting replica status from %s", hr.Addr)
+		}
+		resp.Replicas = append(resp.Replicas, rs)
+	}
+	sort.Slice(resp.Replicas, func(i, j int) bool {
+		return resp.Replicas[i].Addr < resp.Replicas[j].Addr
+	})
+	resp.setLag()
+
+	return resp, nil
+}
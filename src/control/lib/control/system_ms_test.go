@@ -0,0 +1,141 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestControl_MSHealthQuery(t *testing.T) {
+	replicas := []string{"10.0.0.1:10001", "10.0.0.2:10001", "10.0.0.3:10001"}
+	leaderResp := MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.LeaderQueryResp{
+		CurrentLeader: replicas[0],
+		Replicas:      replicas,
+	})
+
+	for name, tc := range map[string]struct {
+		req     *MSHealthQueryReq
+		uResps  []*UnaryResponse
+		expResp *MSHealthQueryResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.MSHealthQueryReq request"),
+		},
+		"leader query fails": {
+			req: new(MSHealthQueryReq),
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", errors.New("remote failed"), nil),
+			},
+			expErr: errors.New("querying MS leader: remote failed"),
+		},
+		"no replicas": {
+			req: new(MSHealthQueryReq),
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.LeaderQueryResp{}),
+			},
+			expErr: errors.New("no MS replicas found"),
+		},
+		"replica lag and failure": {
+			req: new(MSHealthQueryReq),
+			uResps: []*UnaryResponse{
+				leaderResp,
+				{
+					Responses: []*HostResponse{
+						{
+							Addr: replicas[1],
+							Message: &mgmtpb.SystemReplicaQueryResp{
+								State:        "Follower",
+								Leader:       replicas[0],
+								Term:         4,
+								LastIndex:    95,
+								CommitIndex:  95,
+								AppliedIndex: 90,
+								DbSize:       65536,
+							},
+						},
+						{
+							Addr: replicas[0],
+							Message: &mgmtpb.SystemReplicaQueryResp{
+								State:             "Leader",
+								Leader:            replicas[0],
+								Term:              4,
+								LastIndex:         100,
+								CommitIndex:       100,
+								AppliedIndex:      100,
+								LastSnapshotIndex: 96,
+								LastSnapshotTime:  "2021-06-01T12:00:00Z",
+								DbSize:            65536,
+							},
+						},
+						{
+							Addr:  replicas[2],
+							Error: errors.New("unreachable"),
+						},
+					},
+				},
+			},
+			expResp: &MSHealthQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t,
+					&MockHostError{Hosts: replicas[2], Error: "unreachable"}),
+				Leader: replicas[0],
+				Replicas: []*MSReplicaStatus{
+					{
+						Addr:              replicas[0],
+						State:             "Leader",
+						Leader:            replicas[0],
+						Term:              4,
+						LastIndex:         100,
+						CommitIndex:       100,
+						AppliedIndex:      100,
+						LastSnapshotIndex: 96,
+						LastSnapshotTime:  "2021-06-01T12:00:00Z",
+						DBSize:            65536,
+					},
+					{
+						Addr:         replicas[1],
+						State:        "Follower",
+						Leader:       replicas[0],
+						Term:         4,
+						LastIndex:    95,
+						CommitIndex:  95,
+						AppliedIndex: 90,
+						DBSize:       65536,
+						Lag:          10,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+			})
+
+			gotResp, gotErr := MSHealthQuery(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ package control
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -657,6 +658,159 @@ func TestControl_SystemQuery(t *testing.T) {
 	}
 }
 
+func TestControl_SystemMaintenance(t *testing.T) {
+	testRespHS := new(SystemMaintenanceResp)
+	testRespHS.AbsentHosts.ReplaceSet(hostlist.MustCreateSet("foo-[1-3]"))
+
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req     *SystemMaintenanceReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemMaintenanceResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemMaintenanceReq request"),
+		},
+		"local failure": {
+			req:    new(SystemMaintenanceReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemMaintenanceReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"absent hosts": {
+			req: &SystemMaintenanceReq{Action: MaintenanceStart},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemMaintenanceResp{
+					Absenthosts: "foo-[1-3]",
+				}),
+			expResp: testRespHS,
+		},
+		"windows": {
+			req: &SystemMaintenanceReq{Action: MaintenanceStart},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemMaintenanceResp{
+					Windows: []*mgmtpb.MaintenanceWindow{
+						{
+							Id:     common.MockUUID(1),
+							Hosts:  "foo-1",
+							Ranks:  []uint32{0, 1},
+							Reason: "test",
+							Start:  start.Format(time.RFC3339),
+							End:    start.Add(time.Hour).Format(time.RFC3339),
+						},
+					},
+				}),
+			expResp: &SystemMaintenanceResp{
+				Windows: []*MaintenanceWindow{
+					{
+						ID:     common.MockUUID(1),
+						Hosts:  "foo-1",
+						Ranks:  []system.Rank{0, 1},
+						Reason: "test",
+						Start:  start,
+						End:    start.Add(time.Hour),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemMaintenance(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{cmpopts.IgnoreUnexported(SystemMaintenanceResp{})}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expResp.AbsentHosts.String(), gotResp.AbsentHosts.String()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemPoolDefaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemPoolDefaultsReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemPoolDefaultsResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemPoolDefaultsReq request"),
+		},
+		"local failure": {
+			req:    new(SystemPoolDefaultsReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemPoolDefaultsReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"defaults": {
+			req: &SystemPoolDefaultsReq{
+				Set: []*PoolPropDefault{{Name: "self_heal", Value: "exclude", Enforced: true}},
+			},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemPoolDefaultsResp{
+					Defaults: []*mgmtpb.PoolPropDefault{
+						{Name: "reclaim", Value: "lazy"},
+						{Name: "self_heal", Value: "exclude", Enforced: true},
+					},
+				}),
+			expResp: &SystemPoolDefaultsResp{
+				Defaults: []*PoolPropDefault{
+					{Name: "reclaim", Value: "lazy"},
+					{Name: "self_heal", Value: "exclude", Enforced: true},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemPoolDefaults(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_SystemQueryRespErrors(t *testing.T) {
 	for name, tc := range map[string]struct {
 		absentHosts string
@@ -690,6 +844,314 @@ func TestControl_SystemQueryRespErrors(t *testing.T) {
 	}
 }
 
+func TestControl_SystemExclude(t *testing.T) {
+	testRS := system.MustCreateRankSet("1-23")
+	testReqRS := new(SystemExcludeReq)
+	testReqRS.Ranks.ReplaceSet(testRS)
+	testRespRS := new(SystemExcludeResp)
+	testRespRS.AbsentRanks.ReplaceSet(testRS)
+
+	for name, tc := range map[string]struct {
+		req     *SystemExcludeReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemExcludeResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemExcludeReq request"),
+		},
+		"local failure": {
+			req:    new(SystemExcludeReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemExcludeReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"request absent rank set": {
+			req: testReqRS,
+			uResp: MockMSResponse("0.0.0.0", nil,
+				&mgmtpb.SystemExcludeResp{
+					Absentranks: "1-23",
+				}),
+			expResp: testRespRS,
+		},
+		"exclude and clear results": {
+			req: &SystemExcludeReq{Clear: true},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemExcludeResp{
+					Results: []*sharedpb.RankResult{
+						{
+							Rank:   1,
+							Action: "clear-exclude",
+							State:  system.MemberStateStopped.String(),
+						},
+						{
+							Rank:    2,
+							Action:  "clear-exclude",
+							State:   system.MemberStateJoined.String(),
+							Errored: true,
+							Msg:     "rank 2 is not excluded",
+						},
+					},
+				},
+			),
+			expResp: &SystemExcludeResp{
+				Results: system.MemberResults{
+					system.NewMemberResult(1, nil, system.MemberStateStopped, "clear-exclude"),
+					system.NewMemberResult(2, errors.New("rank 2 is not excluded"),
+						system.MemberStateJoined, "clear-exclude"),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemExclude(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{cmpopts.IgnoreUnexported(SystemExcludeResp{}, system.MemberResult{})}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expResp.AbsentRanks.String(), gotResp.AbsentRanks.String()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemSetFaultDomain(t *testing.T) {
+	testRS := system.MustCreateRankSet("1-23")
+	testReqRS := new(SystemSetFaultDomainReq)
+	testReqRS.Ranks.ReplaceSet(testRS)
+	testRespRS := new(SystemSetFaultDomainResp)
+	testRespRS.AbsentRanks.ReplaceSet(testRS)
+
+	for name, tc := range map[string]struct {
+		req     *SystemSetFaultDomainReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemSetFaultDomainResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemSetFaultDomainReq request"),
+		},
+		"local failure": {
+			req:    new(SystemSetFaultDomainReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemSetFaultDomainReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"request absent rank set": {
+			req: testReqRS,
+			uResp: MockMSResponse("0.0.0.0", nil,
+				&mgmtpb.SystemSetFaultDomainResp{
+					Absentranks: "1-23",
+				}),
+			expResp: testRespRS,
+		},
+		"set results": {
+			req: &SystemSetFaultDomainReq{Domain: "/rack1/host1"},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemSetFaultDomainResp{
+					Results: []*sharedpb.RankResult{
+						{
+							Rank:   1,
+							Action: "set-fault-domain",
+							State:  system.MemberStateStopped.String(),
+						},
+						{
+							Rank:    2,
+							Action:  "set-fault-domain",
+							State:   system.MemberStateJoined.String(),
+							Errored: true,
+							Msg:     `fault domain "/rack1/host1" has 2 layers, need 1`,
+						},
+					},
+				},
+			),
+			expResp: &SystemSetFaultDomainResp{
+				Results: system.MemberResults{
+					system.NewMemberResult(1, nil, system.MemberStateStopped, "set-fault-domain"),
+					system.NewMemberResult(2, errors.New(`fault domain "/rack1/host1" has 2 layers, need 1`),
+						system.MemberStateJoined, "set-fault-domain"),
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemSetFaultDomain(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{cmpopts.IgnoreUnexported(SystemSetFaultDomainResp{}, system.MemberResult{})}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expResp.AbsentRanks.String(), gotResp.AbsentRanks.String()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_SystemActivateSpare(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *SystemActivateSpareReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *SystemActivateSpareResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.SystemActivateSpareReq request"),
+		},
+		"local failure": {
+			req:    new(SystemActivateSpareReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(SystemActivateSpareReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"spare activated": {
+			req: &SystemActivateSpareReq{Rank: 2},
+			uResp: MockMSResponse("10.0.0.1:10001", nil,
+				&mgmtpb.SystemActivateSpareResp{
+					Host:            "10.0.0.2:10001",
+					SpareIdx:        1,
+					ReleasedDevices: []string{"0000:81:00.0"},
+					State:           system.MemberStateReady.String(),
+				}),
+			expResp: &SystemActivateSpareResp{
+				Host:            "10.0.0.2:10001",
+				SpareIdx:        1,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateReady.String(),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := SystemActivateSpare(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_ActivateSpare(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req     *ActivateSpareReq
+		uErr    error
+		uResp   *UnaryResponse
+		expResp *ActivateSpareResp
+		expErr  error
+	}{
+		"nil req": {
+			req:    nil,
+			expErr: errors.New("nil *control.ActivateSpareReq request"),
+		},
+		"local failure": {
+			req:    new(ActivateSpareReq),
+			uErr:   errors.New("local failed"),
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req:    new(ActivateSpareReq),
+			uResp:  MockMSResponse("host1", errors.New("remote failed"), nil),
+			expErr: errors.New("remote failed"),
+		},
+		"no response": {
+			req:    new(ActivateSpareReq),
+			uResp:  new(UnaryResponse),
+			expErr: errors.New("expected 1 response"),
+		},
+		"spare activated": {
+			req: &ActivateSpareReq{Rank: 2},
+			uResp: MockMSResponse("host1", nil,
+				&ctlpb.ActivateSpareResp{
+					SpareIdx:        1,
+					ReleasedDevices: []string{"0000:81:00.0"},
+					State:           system.MemberStateReady.String(),
+				}),
+			expResp: &ActivateSpareResp{
+				Host:            "host1",
+				SpareIdx:        1,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateReady.String(),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryError:    tc.uErr,
+				UnaryResponse: tc.uResp,
+			})
+
+			gotResp, gotErr := ActivateSpare(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestControl_SystemStart(t *testing.T) {
 	testHS := hostlist.MustCreateSet("foo-[1-23]")
 	testReqHS := new(SystemStartReq)
@@ -0,0 +1,208 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// SystemWaitCondition identifies the system state waited for by SystemWait.
+type SystemWaitCondition string
+
+const (
+	// WaitRanksJoined is met when all selected ranks have joined the system.
+	WaitRanksJoined SystemWaitCondition = "joined"
+	// WaitRanksStopped is met when all selected ranks have stopped, either
+	// cleanly or with errors.
+	WaitRanksStopped SystemWaitCondition = "stopped"
+	// WaitRebuildIdle is met when no pool in the system is rebuilding.
+	WaitRebuildIdle SystemWaitCondition = "rebuild-idle"
+)
+
+// memberStates returns the member states satisfying a rank wait condition.
+func (swc SystemWaitCondition) memberStates() system.MemberState {
+	switch swc {
+	case WaitRanksJoined:
+		return system.MemberStateJoined
+	case WaitRanksStopped:
+		return system.MemberStateStopped | system.MemberStateErrored
+	}
+	return system.MemberStateUnknown
+}
+
+// SystemWaitReq contains the inputs for the system wait request.
+type SystemWaitReq struct {
+	unaryRequest
+	msRequest
+	sysRequest
+	Condition SystemWaitCondition
+	Interval  time.Duration // Time between checks of the condition
+	Timeout   time.Duration // Give up waiting after this long, zero waits forever
+}
+
+// SystemWaitResp contains the status of the wait condition when SystemWait
+// returned.
+type SystemWaitResp struct {
+	Condition    SystemWaitCondition `json:"condition"`
+	Met          bool                `json:"met"`
+	Checks       int                 `json:"checks"`
+	ElapsedSecs  float64             `json:"elapsed_secs"`
+	PendingRanks string              `json:"pending_ranks,omitempty"`
+	PendingPools []string            `json:"pending_pools,omitempty"`
+	LastError    string              `json:"last_error,omitempty"`
+}
+
+// checkRanks queries the states of the selected ranks and records those not
+// yet in a state satisfying the wait condition.
+func (req *SystemWaitReq) checkRanks(ctx context.Context, rpcClient UnaryInvoker, resp *SystemWaitResp) error {
+	queryReq := &SystemQueryReq{FailOnUnavailable: true}
+	queryReq.SetSystem(req.Sys)
+	queryReq.SetHostList(req.getHostList())
+	queryReq.Ranks.ReplaceSet(&req.Ranks)
+	queryReq.Hosts.ReplaceSet(&req.Hosts)
+
+	queryResp, err := SystemQuery(ctx, rpcClient, queryReq)
+	if err != nil {
+		return err
+	}
+
+	// ranks not yet known to the system are pending rather than failures
+	pending := system.MustCreateRankSet("")
+	pending.ReplaceSet(&queryResp.AbsentRanks)
+	for _, m := range queryResp.Members {
+		if m.State()&req.Condition.memberStates() == 0 {
+			pending.Add(m.Rank)
+		}
+	}
+	resp.PendingRanks = pending.String()
+
+	if queryResp.AbsentHosts.Count() > 0 {
+		return errors.Errorf("non-existent hosts %s", queryResp.AbsentHosts.String())
+	}
+	if len(queryResp.Members) == 0 && pending.Count() == 0 {
+		return errors.New("no system members found")
+	}
+
+	return nil
+}
+
+// checkRebuild queries each pool in the system and records those with a
+// rebuild in progress.
+func (req *SystemWaitReq) checkRebuild(ctx context.Context, rpcClient UnaryInvoker, resp *SystemWaitResp) error {
+	listReq := new(ListPoolsReq)
+	listReq.SetSystem(req.Sys)
+	listReq.SetHostList(req.getHostList())
+
+	listResp, err := ListPools(ctx, rpcClient, listReq)
+	if err != nil {
+		return err
+	}
+
+	resp.PendingPools = nil
+	for _, pool := range listResp.Pools {
+		queryReq := &PoolQueryReq{UUID: pool.UUID}
+		queryReq.SetSystem(req.Sys)
+		queryReq.SetHostList(req.getHostList())
+
+		queryResp, err := PoolQuery(ctx, rpcClient, queryReq)
+		if err != nil {
+			resp.PendingPools = append(resp.PendingPools, pool.UUID)
+			return errors.Wrapf(err, "pool %s", pool.UUID)
+		}
+		if queryResp.Rebuild != nil && queryResp.Rebuild.State == PoolRebuildStateBusy {
+			resp.PendingPools = append(resp.PendingPools, pool.UUID)
+		}
+	}
+	sort.Strings(resp.PendingPools)
+
+	return nil
+}
+
+// check returns true if the wait condition has been met.
+func (req *SystemWaitReq) check(ctx context.Context, rpcClient UnaryInvoker, resp *SystemWaitResp) (bool, error) {
+	if req.Condition == WaitRebuildIdle {
+		if err := req.checkRebuild(ctx, rpcClient, resp); err != nil {
+			return false, err
+		}
+		return len(resp.PendingPools) == 0, nil
+	}
+
+	if err := req.checkRanks(ctx, rpcClient, resp); err != nil {
+		return false, err
+	}
+	return resp.PendingRanks == "", nil
+}
+
+// SystemWait blocks until the requested condition is met by the DAOS system,
+// checking it at the requested interval.
+//
+// Failures to check the condition, e.g. due to the management service being
+// unavailable during system bring-up, do not end the wait. If the condition
+// is not met before the timeout expires the response is returned with Met set
+// to false, the pending ranks or pools and the last failure, if any.
+func SystemWait(ctx context.Context, rpcClient UnaryInvoker, req *SystemWaitReq) (*SystemWaitResp, error) {
+	if req == nil {
+		return nil, errors.Errorf("nil %T request", req)
+	}
+
+	switch req.Condition {
+	case WaitRanksJoined, WaitRanksStopped:
+	case WaitRebuildIdle:
+		if req.Ranks.Count() > 0 || req.Hosts.Count() > 0 {
+			return nil, errors.Errorf("ranks or hosts cannot be selected when waiting for %s",
+				req.Condition)
+		}
+	default:
+		return nil, errors.Errorf("unknown wait condition %q", req.Condition)
+	}
+	if req.Interval <= 0 {
+		return nil, errors.New("wait interval must be greater than zero")
+	}
+
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	resp := &SystemWaitResp{Condition: req.Condition}
+	for {
+		resp.Checks++
+		met, err := req.check(ctx, rpcClient, resp)
+		resp.ElapsedSecs = time.Since(start).Seconds()
+		switch {
+		case err == nil:
+			resp.LastError = ""
+		case ctx.Err() != nil:
+			// the check was interrupted by the end of the wait, so
+			// report the outcome of the previous check
+		default:
+			rpcClient.Debugf("system wait check failed: %s", err)
+			resp.LastError = err.Error()
+		}
+		if met {
+			resp.Met = true
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if req.Timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+				return resp, nil
+			}
+			return nil, ctx.Err()
+		case <-time.After(req.Interval):
+		}
+	}
+}
@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestControl_SystemWait(t *testing.T) {
+	mockQueryResp := func(absentRanks string, states ...system.MemberState) *UnaryResponse {
+		resp := &mgmtpb.SystemQueryResp{Absentranks: absentRanks}
+		for i, state := range states {
+			resp.Members = append(resp.Members, &mgmtpb.SystemMember{
+				Rank:  uint32(i),
+				Uuid:  common.MockUUID(int32(i)),
+				State: state.String(),
+				Addr:  "10.0.0.1:10001",
+			})
+		}
+		return MockMSResponse("10.0.0.1:10001", nil, resp)
+	}
+	mockPoolResp := func(state mgmtpb.PoolRebuildStatus_State) *UnaryResponse {
+		return MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.PoolQueryResp{
+			Rebuild: &mgmtpb.PoolRebuildStatus{State: state},
+		})
+	}
+	listPoolsResp := MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.ListPoolsResp{
+		Pools: []*mgmtpb.ListPoolsResp_Pool{
+			{Uuid: common.MockUUID(1)},
+			{Uuid: common.MockUUID(2)},
+		},
+	})
+	rankReq := func(cond SystemWaitCondition, timeout time.Duration) *SystemWaitReq {
+		return &SystemWaitReq{
+			Condition: cond,
+			Interval:  time.Millisecond,
+			Timeout:   timeout,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req     *SystemWaitReq
+		uResps  []*UnaryResponse
+		uResp   *UnaryResponse
+		expResp *SystemWaitResp
+		expErr  error
+	}{
+		"nil req": {
+			expErr: errors.New("nil *control.SystemWaitReq request"),
+		},
+		"unknown condition": {
+			req:    rankReq("running", 0),
+			expErr: errors.New("unknown wait condition"),
+		},
+		"zero interval": {
+			req:    &SystemWaitReq{Condition: WaitRanksJoined},
+			expErr: errors.New("interval must be greater than zero"),
+		},
+		"rebuild idle with ranks": {
+			req: func() *SystemWaitReq {
+				req := rankReq(WaitRebuildIdle, 0)
+				req.Ranks.Add(1)
+				return req
+			}(),
+			expErr: errors.New("ranks or hosts cannot be selected"),
+		},
+		"ranks joined": {
+			req: rankReq(WaitRanksJoined, time.Minute),
+			uResps: []*UnaryResponse{
+				MockMSResponse("10.0.0.1:10001", system.ErrRaftUnavail, nil),
+				mockQueryResp("", system.MemberStateReady, system.MemberStateStarting),
+			},
+			uResp: mockQueryResp("", system.MemberStateJoined, system.MemberStateJoined),
+			expResp: &SystemWaitResp{
+				Condition: WaitRanksJoined,
+				Met:       true,
+				Checks:    3,
+			},
+		},
+		"ranks stopped with errors": {
+			req:   rankReq(WaitRanksStopped, time.Minute),
+			uResp: mockQueryResp("", system.MemberStateStopped, system.MemberStateErrored),
+			expResp: &SystemWaitResp{
+				Condition: WaitRanksStopped,
+				Met:       true,
+				Checks:    1,
+			},
+		},
+		"timeout with pending and absent ranks": {
+			req:   rankReq(WaitRanksJoined, 20*time.Millisecond),
+			uResp: mockQueryResp("3", system.MemberStateJoined, system.MemberStateStopped),
+			expResp: &SystemWaitResp{
+				Condition:    WaitRanksJoined,
+				PendingRanks: "1,3",
+			},
+		},
+		"rebuild idle": {
+			req: rankReq(WaitRebuildIdle, time.Minute),
+			uResps: []*UnaryResponse{
+				listPoolsResp,
+				mockPoolResp(mgmtpb.PoolRebuildStatus_BUSY),
+				mockPoolResp(mgmtpb.PoolRebuildStatus_IDLE),
+				listPoolsResp,
+				mockPoolResp(mgmtpb.PoolRebuildStatus_DONE),
+				mockPoolResp(mgmtpb.PoolRebuildStatus_IDLE),
+			},
+			expResp: &SystemWaitResp{
+				Condition: WaitRebuildIdle,
+				Met:       true,
+				Checks:    2,
+			},
+		},
+		"timeout with rebuild busy": {
+			req: rankReq(WaitRebuildIdle, 20*time.Millisecond),
+			uResps: []*UnaryResponse{
+				listPoolsResp,
+				mockPoolResp(mgmtpb.PoolRebuildStatus_BUSY),
+			},
+			uResp: MockMSResponse("10.0.0.1:10001", errors.New("remote failed"), nil),
+			expResp: &SystemWaitResp{
+				Condition: WaitRebuildIdle,
+				// pools pending when the checks began failing are retained
+				PendingPools: []string{common.MockUUID(1), common.MockUUID(2)},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mi := NewMockInvoker(log, &MockInvokerConfig{
+				UnaryResponseSet: tc.uResps,
+				UnaryResponse:    tc.uResp,
+			})
+
+			gotResp, gotErr := SystemWait(context.TODO(), mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreFields(SystemWaitResp{}, "ElapsedSecs"),
+			}
+			if !tc.expResp.Met {
+				// the number of checks made before timing out varies and
+				// the last check may be cut short by the timeout
+				cmpOpts = append(cmpOpts,
+					cmpopts.IgnoreFields(SystemWaitResp{}, "Checks", "LastError"))
+			}
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -9,14 +9,18 @@ package control
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	pclient "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
 )
 
 // httpScrapeFn is the function that is used to scrape content from an HTTP
@@ -362,3 +366,120 @@ func metricsLabelsToMap(m *pclient.Metric) map[string]string {
 	}
 	return result
 }
+
+type (
+	// MetricsSnapshotReq is used to capture a snapshot of all metrics from a
+	// set of hosts.
+	MetricsSnapshotReq struct {
+		Hosts []string // hosts to capture telemetry data from
+		Port  uint32   // port to use for collecting telemetry data
+	}
+
+	// HostMetricsSnapshot contains all metrics collected from a host at a
+	// single point in time.
+	HostMetricsSnapshot struct {
+		Host      string
+		Timestamp time.Time
+		families  pbMetricMap
+	}
+
+	// MetricsSnapshotResp contains the snapshots of the hosts that could be
+	// scraped and the errors for those that could not.
+	MetricsSnapshotResp struct {
+		Snapshots  []*HostMetricsSnapshot
+		HostErrors map[string]error
+	}
+)
+
+// MetricsSnapshot captures all metrics published by each of the requested
+// DAOS nodes. Hosts are scraped concurrently, and failure to scrape a host
+// is reported in the response rather than failing the request.
+func MetricsSnapshot(ctx context.Context, req *MetricsSnapshotReq) (*MetricsSnapshotResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	if len(req.Hosts) == 0 {
+		return nil, errors.New("hosts must be specified")
+	}
+
+	if req.Port == 0 {
+		return nil, errors.New("port must be specified")
+	}
+
+	snapshots := make([]*HostMetricsSnapshot, len(req.Hosts))
+	scrapeErrs := make([]error, len(req.Hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range req.Hosts {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+
+			ts := time.Now()
+			scraped, err := scrapeMetrics(ctx, host, req.Port)
+			if err != nil {
+				scrapeErrs[i] = errors.Wrap(err, "unable to capture metrics")
+				return
+			}
+			snapshots[i] = &HostMetricsSnapshot{
+				Host:      host,
+				Timestamp: ts,
+				families:  scraped,
+			}
+		}(i, host)
+	}
+	wg.Wait()
+
+	resp := &MetricsSnapshotResp{
+		HostErrors: make(map[string]error),
+	}
+	for i, host := range req.Hosts {
+		if scrapeErrs[i] != nil {
+			resp.HostErrors[host] = scrapeErrs[i]
+			continue
+		}
+		resp.Snapshots = append(resp.Snapshots, snapshots[i])
+	}
+
+	return resp, nil
+}
+
+// MetricCount returns the number of metrics in the snapshot.
+func (hms *HostMetricsSnapshot) MetricCount() (count int) {
+	for _, mf := range hms.families {
+		count += len(mf.GetMetric())
+	}
+	return
+}
+
+// WriteOpenMetrics writes the snapshot in the OpenMetrics text format. Each
+// sample is stamped with the time of the snapshot and labelled with the host
+// it was captured from, so that snapshots of several hosts can be imported
+// into the same time-series database.
+func (hms *HostMetricsSnapshot) WriteOpenMetrics(w io.Writer) error {
+	enc := expfmt.NewEncoder(w, expfmt.FmtOpenMetrics)
+
+	tsMs := hms.Timestamp.UnixNano() / int64(time.Millisecond)
+	for _, name := range hms.families.Keys() {
+		mf := hms.families[name]
+		for _, m := range mf.Metric {
+			m.TimestampMs = proto.Int64(tsMs)
+			if _, found := metricsLabelsToMap(m)["instance"]; !found {
+				m.Label = append(m.Label, &pclient.LabelPair{
+					Name:  proto.String("instance"),
+					Value: proto.String(hms.Host),
+				})
+			}
+		}
+
+		if err := enc.Encode(mf); err != nil {
+			return errors.Wrapf(err, "encoding metric %q", name)
+		}
+	}
+
+	if closer, ok := enc.(expfmt.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -576,3 +577,126 @@ func TestControl_MetricsQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestControl_MetricsSnapshot(t *testing.T) {
+	testMetricFam := []*pclient.MetricFamily{
+		newTestMetricFamily("my_counter", "this is the counter help", pclient.MetricType_COUNTER),
+		newTestMetricFamily("my_gauge", "this is the gauge help", pclient.MetricType_GAUGE),
+	}
+
+	for _, mf := range testMetricFam {
+		addTestMetric(mf)
+	}
+
+	for name, tc := range map[string]struct {
+		scrapeFn     func(context.Context, *url.URL, httpGetFn) ([]byte, error)
+		req          *MetricsSnapshotReq
+		expHosts     []string
+		expHostErrs  []string
+		expMetricCnt int
+		expErr       error
+	}{
+		"nil request": {
+			expErr: errors.New("nil"),
+		},
+		"no hosts": {
+			req:    &MetricsSnapshotReq{Port: 2525},
+			expErr: errors.New("hosts must be specified"),
+		},
+		"no port": {
+			req:    &MetricsSnapshotReq{Hosts: []string{"host1"}},
+			expErr: errors.New("port must be specified"),
+		},
+		"all hosts": {
+			req: &MetricsSnapshotReq{
+				Hosts: []string{"host1", "host2"},
+				Port:  9191,
+			},
+			scrapeFn:     mockScrapeFnSuccess(t, testMetricFam...),
+			expHosts:     []string{"host1", "host2"},
+			expMetricCnt: 2,
+		},
+		"one host failed": {
+			req: &MetricsSnapshotReq{
+				Hosts: []string{"host1", "host2", "host3"},
+				Port:  9191,
+			},
+			scrapeFn: func(ctx context.Context, u *url.URL, get httpGetFn) ([]byte, error) {
+				if u.Host == "host2:9191" {
+					return nil, errors.New("mock scrape")
+				}
+				return mockScrapeFnSuccess(t, testMetricFam...)(ctx, u, get)
+			},
+			expHosts:     []string{"host1", "host3"},
+			expHostErrs:  []string{"host2"},
+			expMetricCnt: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			oldScrapeFn := httpScrapeFn
+			httpScrapeFn = tc.scrapeFn
+			defer func() {
+				httpScrapeFn = oldScrapeFn
+			}()
+
+			resp, err := MetricsSnapshot(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotHosts []string
+			for _, hms := range resp.Snapshots {
+				gotHosts = append(gotHosts, hms.Host)
+				common.AssertEqual(t, tc.expMetricCnt, hms.MetricCount(), "unexpected metric count")
+				if hms.Timestamp.IsZero() {
+					t.Fatal("snapshot timestamp not set")
+				}
+			}
+			common.AssertStringsEqual(t, tc.expHosts, gotHosts, "unexpected snapshot hosts")
+
+			var gotErrHosts []string
+			for host, hostErr := range resp.HostErrors {
+				gotErrHosts = append(gotErrHosts, host)
+				common.CmpErr(t, errors.New("unable to capture metrics: mock scrape"), hostErr)
+			}
+			common.AssertStringsEqual(t, tc.expHostErrs, gotErrHosts, "unexpected host errors")
+		})
+	}
+}
+
+func TestControl_HostMetricsSnapshot_WriteOpenMetrics(t *testing.T) {
+	counter := newTestMetricFamily("my_counter_total", "this is the counter help", pclient.MetricType_COUNTER)
+	counter.Metric = append(counter.Metric, newTestPBCounter(42))
+	gauge := newTestMetricFamily("my_gauge", "this is the gauge help", pclient.MetricType_GAUGE)
+	gauge.Metric = append(gauge.Metric, newTestPBGauge(7))
+	gauge.Metric[0].Label = []*pclient.LabelPair{
+		{Name: proto.String("rank"), Value: proto.String("1")},
+	}
+
+	hms := &HostMetricsSnapshot{
+		Host:      "host1",
+		Timestamp: time.Unix(1622541600, 0),
+		families: pbMetricMap{
+			"my_gauge":         gauge,
+			"my_counter_total": counter,
+		},
+	}
+
+	var b strings.Builder
+	if err := hms.WriteOpenMetrics(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	expOut := `# HELP my_counter this is the counter help
+# TYPE my_counter counter
+my_counter_total{instance="host1"} 42.0 1.6225416e+09
+# HELP my_gauge this is the gauge help
+# TYPE my_gauge gauge
+my_gauge{rank="1",instance="host1"} 7.0 1.6225416e+09
+# EOF
+`
+	if diff := cmp.Diff(expOut, b.String()); diff != "" {
+		t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,340 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// ComponentVersion describes the version of a software component installed
+// on a host.
+type ComponentVersion struct {
+	Name     string
+	Version  string
+	Revision string
+	Error    string
+}
+
+// HostVersions describes the versions of software components installed on a
+// host.
+type HostVersions struct {
+	Components []*ComponentVersion
+}
+
+// HashKey returns a uint64 value suitable for use as a key into
+// a map of HostVersions.
+func (hv *HostVersions) HashKey() (uint64, error) {
+	return hashstructure.Hash(hv, hashstructure.FormatV2, nil)
+}
+
+// HostVersionSet contains a HostVersions inventory and the set of hosts
+// matching this inventory.
+type HostVersionSet struct {
+	HostVersions *HostVersions
+	HostSet      *hostlist.HostSet
+}
+
+// NewHostVersionSet returns an initialized HostVersionSet for the given
+// host address and HostVersions inventory.
+func NewHostVersionSet(hostAddr string, hv *HostVersions) (*HostVersionSet, error) {
+	hostSet, err := hostlist.CreateSet(hostAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &HostVersionSet{
+		HostVersions: hv,
+		HostSet:      hostSet,
+	}, nil
+}
+
+// HostVersionMap provides a map of HostVersions keys to HostVersionSet values.
+type HostVersionMap map[uint64]*HostVersionSet
+
+// Add inserts the given host address to a matching HostVersionSet or
+// creates a new one.
+func (hvm HostVersionMap) Add(hostAddr string, hv *HostVersions) (err error) {
+	hk, err := hv.HashKey()
+	if err != nil {
+		return err
+	}
+	if _, exists := hvm[hk]; !exists {
+		hvm[hk], err = NewHostVersionSet(hostAddr, hv)
+		return
+	}
+	_, err = hvm[hk].HostSet.Insert(hostAddr)
+	return
+}
+
+// Keys returns a set of version map keys sorted by hosts.
+func (hvm HostVersionMap) Keys() []uint64 {
+	sets := make([]string, 0, len(hvm))
+	keys := make([]uint64, len(hvm))
+	setToKeys := make(map[string]uint64)
+	for key, hvs := range hvm {
+		rs := hvs.HostSet.RangedString()
+		sets = append(sets, rs)
+		setToKeys[rs] = key
+	}
+	sort.Strings(sets)
+	for i, set := range sets {
+		keys[i] = setToKeys[set]
+	}
+	return keys
+}
+
+// Skewed returns the sorted names of components whose version or revision
+// differs between hosts.
+func (hvm HostVersionMap) Skewed() []string {
+	seen := make(map[string]ComponentVersion)
+	skewed := make(map[string]struct{})
+	for _, hvs := range hvm {
+		for _, cv := range hvs.HostVersions.Components {
+			prev, exists := seen[cv.Name]
+			if !exists {
+				seen[cv.Name] = *cv
+				continue
+			}
+			if prev.Version != cv.Version || prev.Revision != cv.Revision {
+				skewed[cv.Name] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(skewed))
+	for name := range skewed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// daosComponents lists the components built from the DAOS source tree, whose
+// versions must all be from the same release.
+var daosComponents = []string{"daos_server", "daos_engine", "daos_agent"}
+
+type (
+	// VersionHosts lists the hosts reporting a version of a component.
+	VersionHosts struct {
+		Version  string            `json:"version"`
+		Revision string            `json:"revision"`
+		Hosts    *hostlist.HostSet `json:"hosts"`
+	}
+
+	// ComponentSkew lists the versions of a component found across the
+	// system, ordered by version.
+	ComponentSkew struct {
+		Name     string          `json:"name"`
+		Versions []*VersionHosts `json:"versions"`
+	}
+
+	// VersionSkewReport describes the versions of software components
+	// found on the servers and known agents of a system.
+	VersionSkewReport struct {
+		HostErrorsResp
+		Components []*ComponentSkew `json:"components"`
+		// Releases lists the major.minor DAOS releases found, ordered
+		// by version.
+		Releases []string `json:"releases"`
+	}
+)
+
+// Skewed returns true if the component version or revision differs between
+// hosts.
+func (cs *ComponentSkew) Skewed() bool {
+	return len(cs.Versions) > 1
+}
+
+// OutsideWindow returns true if the DAOS components are from more than one
+// major.minor release, which is outside the supported window.
+func (vsr *VersionSkewReport) OutsideWindow() bool {
+	return len(vsr.Releases) > 1
+}
+
+// daosRelease returns the major.minor release of a DAOS version string, or
+// the whole string if it is not in the expected format.
+func daosRelease(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// NewVersionSkewReport builds a report of the component versions from the
+// given server versions and agent records. The DAOS components are always
+// reported so that the releases in use are shown, other components are only
+// reported if their versions differ between hosts. Components whose version
+// could not be determined are ignored.
+func NewVersionSkewReport(hvm HostVersionMap, agents []*system.AgentRecord) (*VersionSkewReport, error) {
+	type versionKey struct {
+		version  string
+		revision string
+	}
+	found := make(map[string]map[versionKey]*hostlist.HostSet)
+	add := func(name, version, revision string, hosts *hostlist.HostSet) error {
+		if _, exists := found[name]; !exists {
+			found[name] = make(map[versionKey]*hostlist.HostSet)
+		}
+		key := versionKey{version: version, revision: revision}
+		if _, exists := found[name][key]; !exists {
+			found[name][key] = hostlist.MustCreateSet("")
+		}
+		return found[name][key].MergeSet(hosts)
+	}
+
+	for _, hvs := range hvm {
+		for _, cv := range hvs.HostVersions.Components {
+			if cv.Error != "" {
+				continue
+			}
+			if err := add(cv.Name, cv.Version, cv.Revision, hvs.HostSet); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, ar := range agents {
+		hosts, err := hostlist.CreateSet(ar.Host)
+		if err != nil {
+			return nil, err
+		}
+		if err := add("daos_agent", ar.Version, "", hosts); err != nil {
+			return nil, err
+		}
+	}
+
+	newSkew := func(name string) *ComponentSkew {
+		cs := &ComponentSkew{Name: name}
+		for key, hosts := range found[name] {
+			cs.Versions = append(cs.Versions, &VersionHosts{
+				Version:  key.version,
+				Revision: key.revision,
+				Hosts:    hosts,
+			})
+		}
+		sort.Slice(cs.Versions, func(i, j int) bool {
+			vi, vj := cs.Versions[i], cs.Versions[j]
+			if vi.Version != vj.Version {
+				return vi.Version < vj.Version
+			}
+			return vi.Revision < vj.Revision
+		})
+		return cs
+	}
+
+	vsr := new(VersionSkewReport)
+	releases := make(map[string]struct{})
+	for _, name := range daosComponents {
+		if _, exists := found[name]; !exists {
+			continue
+		}
+		cs := newSkew(name)
+		for _, vh := range cs.Versions {
+			releases[daosRelease(vh.Version)] = struct{}{}
+		}
+		vsr.Components = append(vsr.Components, cs)
+		delete(found, name)
+	}
+
+	var others []string
+	for name := range found {
+		others = append(others, name)
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		if cs := newSkew(name); cs.Skewed() {
+			vsr.Components = append(vsr.Components, cs)
+		}
+	}
+
+	for release := range releases {
+		vsr.Releases = append(vsr.Releases, release)
+	}
+	sort.Strings(vsr.Releases)
+
+	return vsr, nil
+}
+
+type (
+	// VersionQueryReq contains the parameters for a version query request.
+	VersionQueryReq struct {
+		unaryRequest
+	}
+
+	// VersionQueryResp contains the results of a version query.
+	VersionQueryResp struct {
+		HostErrorsResp
+		HostVersions HostVersionMap
+	}
+)
+
+// addHostResponse is responsible for validating the given HostResponse
+// and adding it to the VersionQueryResp.
+func (vqr *VersionQueryResp) addHostResponse(hr *HostResponse) error {
+	pbResp, ok := hr.Message.(*ctlpb.VersionQueryResp)
+	if !ok {
+		return errors.Errorf("unable to unpack message: %+v", hr.Message)
+	}
+
+	hv := new(HostVersions)
+	if err := convert.Types(pbResp.GetComponents(), &hv.Components); err != nil {
+		return vqr.addHostError(hr.Addr, err)
+	}
+
+	if vqr.HostVersions == nil {
+		vqr.HostVersions = make(HostVersionMap)
+	}
+
+	return vqr.HostVersions.Add(hr.Addr, hv)
+}
+
+// VersionQuery concurrently retrieves the versions of DAOS and dependent
+// software components installed on all hosts supplied in the request's
+// hostlist, or all configured hosts if not explicitly specified. Hosts
+// reporting identical inventories are grouped together.
+func VersionQuery(ctx context.Context, rpcClient UnaryInvoker, req *VersionQueryReq) (*VersionQueryResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	req.setRPC(func(ctx context.Context, conn *grpc.ClientConn) (proto.Message, error) {
+		return ctlpb.NewCtlSvcClient(conn).VersionQuery(ctx, &ctlpb.VersionQueryReq{})
+	})
+
+	ur, err := rpcClient.InvokeUnaryRPC(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	vqr := new(VersionQueryResp)
+	for _, hostResp := range ur.Responses {
+		if hostResp.Error != nil {
+			if err := vqr.addHostError(hostResp.Addr, hostResp.Error); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if err := vqr.addHostResponse(hostResp); err != nil {
+			return nil, err
+		}
+	}
+
+	return vqr, nil
+}
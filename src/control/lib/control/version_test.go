@@ -0,0 +1,334 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type mockVersionQuery struct {
+	Hosts    string
+	Versions *HostVersions
+}
+
+func mockHostVersionMap(t *testing.T, queries ...*mockVersionQuery) HostVersionMap {
+	hvm := make(HostVersionMap)
+
+	for _, query := range queries {
+		hvs := &HostVersionSet{
+			HostVersions: query.Versions,
+			HostSet:      mockHostSet(t, query.Hosts),
+		}
+
+		hk, err := hvs.HostVersions.HashKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		hvm[hk] = hvs
+	}
+
+	return hvm
+}
+
+func TestControl_HostVersionMap_Skewed(t *testing.T) {
+	hv := func(engineVer, engineRev string) *HostVersions {
+		return &HostVersions{
+			Components: []*ComponentVersion{
+				{Name: "daos_engine", Version: engineVer, Revision: engineRev},
+				{Name: "spdk", Version: "v21.07"},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		hvm       HostVersionMap
+		expSkewed []string
+	}{
+		"empty": {
+			hvm:       HostVersionMap{},
+			expSkewed: []string{},
+		},
+		"no skew": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host[1-2]", Versions: hv("2.0.0", "abc")},
+			),
+			expSkewed: []string{},
+		},
+		"revision skew": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host1", Versions: hv("2.0.0", "abc")},
+				&mockVersionQuery{Hosts: "host2", Versions: hv("2.0.0", "def")},
+			),
+			expSkewed: []string{"daos_engine"},
+		},
+		"version skew": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host1", Versions: hv("2.0.0", "abc")},
+				&mockVersionQuery{Hosts: "host2", Versions: hv("2.0.1", "abc")},
+			),
+			expSkewed: []string{"daos_engine"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expSkewed, tc.hvm.Skewed()); diff != "" {
+				t.Fatalf("unexpected result (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestControl_NewVersionSkewReport(t *testing.T) {
+	hv := func(daosVer, spdkVer string) *HostVersions {
+		return &HostVersions{
+			Components: []*ComponentVersion{
+				{Name: "daos_server", Version: daosVer, Revision: "abc"},
+				{Name: "daos_engine", Version: daosVer, Revision: "abc"},
+				{Name: "spdk", Version: spdkVer},
+				{Name: "ndctl", Error: "not found"},
+			},
+		}
+	}
+	vh := func(ver, rev, hosts string) *VersionHosts {
+		return &VersionHosts{Version: ver, Revision: rev, Hosts: mockHostSet(t, hosts)}
+	}
+
+	for name, tc := range map[string]struct {
+		hvm              HostVersionMap
+		agents           []*system.AgentRecord
+		expReport        *VersionSkewReport
+		expOutsideWindow bool
+	}{
+		"empty": {
+			hvm:       HostVersionMap{},
+			expReport: &VersionSkewReport{},
+		},
+		"no skew": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host[1-2]", Versions: hv("2.0.0", "v21.07")},
+			),
+			agents: []*system.AgentRecord{
+				{Host: "client1", Version: "2.0.0"},
+			},
+			expReport: &VersionSkewReport{
+				Components: []*ComponentSkew{
+					{Name: "daos_server", Versions: []*VersionHosts{vh("2.0.0", "abc", "host[1-2]")}},
+					{Name: "daos_engine", Versions: []*VersionHosts{vh("2.0.0", "abc", "host[1-2]")}},
+					{Name: "daos_agent", Versions: []*VersionHosts{vh("2.0.0", "", "client1")}},
+				},
+				Releases: []string{"2.0"},
+			},
+		},
+		"skew within window": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host1", Versions: hv("2.0.0", "v21.07")},
+				&mockVersionQuery{Hosts: "host2", Versions: hv("2.0.1", "v21.10")},
+			),
+			agents: []*system.AgentRecord{
+				{Host: "client1", Version: "2.0.0"},
+				{Host: "client2", Version: "2.0.0"},
+			},
+			expReport: &VersionSkewReport{
+				Components: []*ComponentSkew{
+					{Name: "daos_server", Versions: []*VersionHosts{
+						vh("2.0.0", "abc", "host1"),
+						vh("2.0.1", "abc", "host2"),
+					}},
+					{Name: "daos_engine", Versions: []*VersionHosts{
+						vh("2.0.0", "abc", "host1"),
+						vh("2.0.1", "abc", "host2"),
+					}},
+					{Name: "daos_agent", Versions: []*VersionHosts{vh("2.0.0", "", "client[1-2]")}},
+					{Name: "spdk", Versions: []*VersionHosts{
+						vh("v21.07", "", "host1"),
+						vh("v21.10", "", "host2"),
+					}},
+				},
+				Releases: []string{"2.0"},
+			},
+		},
+		"agents outside window": {
+			hvm: mockHostVersionMap(t,
+				&mockVersionQuery{Hosts: "host[1-2]", Versions: hv("2.0.0", "v21.07")},
+			),
+			agents: []*system.AgentRecord{
+				{Host: "client1", Version: "2.2.0"},
+			},
+			expReport: &VersionSkewReport{
+				Components: []*ComponentSkew{
+					{Name: "daos_server", Versions: []*VersionHosts{vh("2.0.0", "abc", "host[1-2]")}},
+					{Name: "daos_engine", Versions: []*VersionHosts{vh("2.0.0", "abc", "host[1-2]")}},
+					{Name: "daos_agent", Versions: []*VersionHosts{vh("2.2.0", "", "client1")}},
+				},
+				Releases: []string{"2.0", "2.2"},
+			},
+			expOutsideWindow: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotReport, err := NewVersionSkewReport(tc.hvm, tc.agents)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expReport, gotReport, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expOutsideWindow, gotReport.OutsideWindow(),
+				"unexpected window result")
+		})
+	}
+}
+
+func TestControl_VersionQuery(t *testing.T) {
+	pbComponents := []*ctlpb.ComponentVersion{
+		{Name: "daos_server", Version: "2.0.0", Revision: "abc123"},
+		{Name: "ndctl", Error: "not found"},
+	}
+	components := []*ComponentVersion{
+		{Name: "daos_server", Version: "2.0.0", Revision: "abc123"},
+		{Name: "ndctl", Error: "not found"},
+	}
+
+	for name, tc := range map[string]struct {
+		mic     *MockInvokerConfig
+		req     *VersionQueryReq
+		expResp *VersionQueryResp
+		expErr  error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"local failure": {
+			req: &VersionQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryError: errors.New("local failed"),
+			},
+			expErr: errors.New("local failed"),
+		},
+		"remote failure": {
+			req: &VersionQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr:  "host1",
+							Error: errors.New("remote failed"),
+						},
+					},
+				},
+			},
+			expResp: &VersionQueryResp{
+				HostErrorsResp: MockHostErrorsResp(t, &MockHostError{"host1", "remote failed"}),
+			},
+		},
+		"nil message": {
+			req: &VersionQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+						},
+					},
+				},
+			},
+			expErr: errors.New("unpack"),
+		},
+		"two hosts; same versions": {
+			req: &VersionQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+							Message: &ctlpb.VersionQueryResp{
+								Components: pbComponents,
+							},
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.VersionQueryResp{
+								Components: pbComponents,
+							},
+						},
+					},
+				},
+			},
+			expResp: &VersionQueryResp{
+				HostVersions: mockHostVersionMap(t, &mockVersionQuery{
+					Hosts:    "host[1-2]",
+					Versions: &HostVersions{Components: components},
+				}),
+			},
+		},
+		"two hosts; different versions": {
+			req: &VersionQueryReq{},
+			mic: &MockInvokerConfig{
+				UnaryResponse: &UnaryResponse{
+					Responses: []*HostResponse{
+						{
+							Addr: "host1",
+							Message: &ctlpb.VersionQueryResp{
+								Components: pbComponents,
+							},
+						},
+						{
+							Addr: "host2",
+							Message: &ctlpb.VersionQueryResp{
+								Components: pbComponents[:1],
+							},
+						},
+					},
+				},
+			},
+			expResp: &VersionQueryResp{
+				HostVersions: mockHostVersionMap(t,
+					&mockVersionQuery{
+						Hosts:    "host1",
+						Versions: &HostVersions{Components: components},
+					},
+					&mockVersionQuery{
+						Hosts:    "host2",
+						Versions: &HostVersions{Components: components[:1]},
+					},
+				),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mic := tc.mic
+			if mic == nil {
+				mic = DefaultMockInvokerConfig()
+			}
+
+			ctx := context.TODO()
+			mi := NewMockInvoker(log, mic)
+
+			gotResp, gotErr := VersionQuery(ctx, mi, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, defResCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@
 package hostlist
 
 import (
+	"encoding/json"
 	"errors"
 	"sync"
 )
@@ -25,6 +26,26 @@ func (hs *HostSet) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + hs.RangedString() + `"`), nil
 }
 
+// UnmarshalJSON creates a HostSet from its JSON representation.
+func (hs *HostSet) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	hl, err := Create(str)
+	if err != nil {
+		return err
+	}
+	hl.Uniq()
+
+	hs.Lock()
+	defer hs.Unlock()
+	hs.list = hl
+
+	return nil
+}
+
 // MustCreateSet is like CreateSet but will panic on error.
 func MustCreateSet(stringHosts string) *HostSet {
 	hs, err := CreateSet(stringHosts)
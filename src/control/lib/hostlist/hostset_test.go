@@ -6,6 +6,7 @@
 package hostlist_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -204,6 +205,31 @@ func TestHostSet_ReplaceSet(t *testing.T) {
 	}
 }
 
+func TestHostSet_JSON(t *testing.T) {
+	hs, err := hostlist.CreateSet("host[1-8]:10001,host10:10001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(hs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotSet hostlist.HostSet
+	if err := json.Unmarshal(data, &gotSet); err != nil {
+		t.Fatal(err)
+	}
+	cmpOut(t, hs.String(), gotSet.String())
+
+	if err := json.Unmarshal([]byte(`"host[1-"`), &gotSet); err == nil {
+		t.Fatal("expected error for invalid hostlist")
+	}
+	if err := json.Unmarshal([]byte(`42`), &gotSet); err == nil {
+		t.Fatal("expected error for non-string value")
+	}
+}
+
 func TestHostSet_FuzzCrashers(t *testing.T) {
 	// Test against problematic inputs found by go-fuzz testing
 
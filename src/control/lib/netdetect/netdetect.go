@@ -104,6 +104,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -158,6 +159,9 @@ type FabricScan struct {
 	NUMANode    uint   `json:"numanode"`
 	Priority    int    `json:"priority"`
 	NetDevClass uint32 `json:"netdevclass"`
+	PhysFn      string `json:"physfn,omitempty"`
+	LinkState   string `json:"linkstate,omitempty"`
+	LinkSpeed   uint32 `json:"linkspeed,omitempty"` // Mb/s
 }
 
 func (fs *FabricScan) String() string {
@@ -183,6 +187,9 @@ type logger interface {
 
 var log logger = logging.NewStdoutLogger("netdetect")
 
+// sysfsNetRoot is the sysfs directory containing the network interfaces.
+var sysfsNetRoot = "/sys/class/net"
+
 // SetLogger sets the package-level logger
 func SetLogger(l logger) {
 	log = l
@@ -242,6 +249,78 @@ type netdetectContext struct {
 	numNUMANodes  int
 	coresPerNuma  int
 	deviceScanCfg DeviceScan
+	cached        *cachedTopology
+}
+
+// cachedTopology is a loaded hwloc topology shared between netdetect contexts.
+// The topology is destroyed once it has been invalidated and the last context
+// referencing it has been cleaned up.
+type cachedTopology struct {
+	ndc   netdetectContext
+	refs  int
+	stale bool
+}
+
+// topologyCache holds the process-wide topology. Discovering the topology with
+// hwloc is expensive so it is performed once and shared by all callers of Init
+// until InvalidateTopology is called.
+type topologyCache struct {
+	sync.Mutex
+	current *cachedTopology
+}
+
+var topoCache topologyCache
+
+// acquire returns a context referencing the cached topology, loading the
+// topology first if it is not already cached.
+func (tc *topologyCache) acquire() (netdetectContext, error) {
+	tc.Lock()
+	defer tc.Unlock()
+
+	if tc.current == nil {
+		ndc, err := loadContext()
+		if err != nil {
+			return netdetectContext{}, err
+		}
+		tc.current = &cachedTopology{ndc: ndc}
+		tc.current.ndc.cached = tc.current
+	}
+	tc.current.refs++
+
+	return tc.current.ndc, nil
+}
+
+// release drops a reference to the cached topology, destroying the topology
+// if it has been invalidated and is no longer referenced.
+func (tc *topologyCache) release(ct *cachedTopology) {
+	tc.Lock()
+	defer tc.Unlock()
+
+	if ct.refs > 0 {
+		ct.refs--
+	}
+	if ct.stale && ct.refs == 0 {
+		cleanUp(ct.ndc.topology)
+		ct.ndc.topology = nil
+	}
+}
+
+// invalidate drops the cached topology so that the next acquire reloads it.
+func (tc *topologyCache) invalidate() {
+	tc.Lock()
+	defer tc.Unlock()
+
+	ct := tc.current
+	if ct == nil {
+		return
+	}
+	tc.current = nil
+
+	ct.stale = true
+	if ct.refs == 0 {
+		cleanUp(ct.ndc.topology)
+		ct.ndc.topology = nil
+	}
 }
 
 func getContext(ctx context.Context) (*netdetectContext, error) {
@@ -256,24 +335,46 @@ func getContext(ctx context.Context) (*netdetectContext, error) {
 	return &ndc, nil
 }
 
+// Init returns a context referencing the process-wide hwloc topology, which is
+// discovered on first use and then shared by subsequent callers. Each context
+// returned by Init should be released with CleanUp.
 func Init(parent context.Context) (context.Context, error) {
-	var err error
-
 	if parent == nil {
 		parent = context.Background()
 	}
 
+	ndc, err := topoCache.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	return context.WithValue(parent, topologyKey, ndc), nil
+}
+
+// InvalidateTopology discards the cached hwloc topology so that it is
+// rediscovered by the next call to Init. Contexts already returned by Init
+// continue to use the previous topology until they are cleaned up.
+func InvalidateTopology() {
+	topoCache.invalidate()
+}
+
+// loadContext discovers the hwloc topology and populates a context with the
+// topology details used by netdetect queries.
+func loadContext() (netdetectContext, error) {
+	var err error
+
 	ndc := netdetectContext{}
 	ndc.topology, err = initLib()
 	if err != nil {
-		return nil, errors.Errorf("unable to initialize netdetect context: %v", err)
+		return ndc, errors.Errorf("unable to initialize netdetect context: %v", err)
 	}
 	ndc.numNUMANodes = numNUMANodes(ndc.topology)
 	ndc.numaAware = ndc.numNUMANodes > 0
 	if ndc.numaAware {
 		cores, err := getCoreCount(ndc.topology)
 		if err != nil {
-			return nil, err
+			cleanUp(ndc.topology)
+			return ndc, err
 		}
 		ndc.coresPerNuma = cores / ndc.numNUMANodes
 		log.Debugf("%d NUMA nodes detected with %d cores per node",
@@ -285,10 +386,10 @@ func Init(parent context.Context) (context.Context, error) {
 		ndc.deviceScanCfg.systemDeviceNames, ndc.deviceScanCfg.hwlocDeviceNames)
 	if err != nil {
 		cleanUp(ndc.topology)
-		return nil, err
+		return ndc, err
 	}
 
-	return context.WithValue(parent, topologyKey, ndc), nil
+	return ndc, nil
 }
 
 // HasNUMA returns true if the topology has NUMA node data
@@ -319,13 +420,19 @@ func CoresPerNuma(ctx context.Context) int {
 	return ndc.coresPerNuma
 }
 
-// Cleanup releases the hwloc topology resources
+// CleanUp releases the context's reference to the hwloc topology. The
+// topology resources are freed once the topology has been invalidated and is
+// no longer referenced by any context.
 func CleanUp(ctx context.Context) {
 	ndc, err := getContext(ctx)
 	if err != nil {
 		return
 	}
-	cleanUp(ndc.topology)
+	if ndc.cached == nil {
+		cleanUp(ndc.topology)
+		return
+	}
+	topoCache.release(ndc.cached)
 }
 
 // initLib initializes the hwloc library.
@@ -650,6 +757,38 @@ func GetNUMASocketIDForPid(ctx context.Context, pid int32) (int, error) {
 	return 0, errors.Errorf("NUMA Node data is unavailable.")
 }
 
+// GetNUMANodeCPUList returns the CPUs local to the given NUMA node as a list
+// of ranges, e.g. "0-17,36-53", suitable for use in a CPU binding.
+func GetNUMANodeCPUList(ctx context.Context, numaNode int) (string, error) {
+	ndc, err := getContext(ctx)
+	if err != nil {
+		return "", errors.Errorf("netdetect context was not initialized")
+	}
+
+	if !ndc.numaAware {
+		return "", errors.Errorf("NUMA Node data is unavailable.")
+	}
+
+	if numaNode < 0 || numaNode >= ndc.numNUMANodes {
+		return "", errors.Errorf("NUMA node %d is out of range (%d NUMA nodes)",
+			numaNode, ndc.numNUMANodes)
+	}
+
+	depth := C.hwloc_get_type_depth(ndc.topology, C.HWLOC_OBJ_NUMANODE)
+	numanode := C.cmpt_get_obj_by_depth(ndc.topology, C.int(depth), C.uint(numaNode))
+	if numanode == nil {
+		return "", errors.Errorf("NUMA Node data is unavailable.")
+	}
+
+	var cpuList *C.char
+	if C.hwloc_bitmap_list_asprintf(&cpuList, numanode.cpuset) <= 0 {
+		return "", errors.Errorf("there was no cpuset available for NUMA node %d", numaNode)
+	}
+	defer C.free(unsafe.Pointer(cpuList))
+
+	return C.GoString(cpuList), nil
+}
+
 // GetDeviceAlias is a wrapper for getDeviceAliasWithSystemList.  This interface
 // specifies an empty additionalSystemDevices list which allows for the default behavior we want
 // for normal use.  Test functions can call getDeviceAliasWithSystemList() directly and specify
@@ -681,13 +820,27 @@ func getDeviceAliasWithSystemList(ctx context.Context, device string, additional
 		return "lo", nil
 	}
 
-	// Add any additional system devices to the map
-	for _, deviceName := range additionalSystemDevices {
-		ndc.deviceScanCfg.systemDeviceNamesMap[deviceName] = struct{}{}
+	// Add any additional system devices to a copy of the map, the original
+	// is shared with other users of the cached topology.
+	if len(additionalSystemDevices) > 0 {
+		systemDeviceNamesMap := make(map[string]struct{})
+		for deviceName := range ndc.deviceScanCfg.systemDeviceNamesMap {
+			systemDeviceNamesMap[deviceName] = struct{}{}
+		}
+		for _, deviceName := range additionalSystemDevices {
+			systemDeviceNamesMap[deviceName] = struct{}{}
+		}
+		ndc.deviceScanCfg.systemDeviceNamesMap = systemDeviceNamesMap
 	}
 
 	node = getNodeAlias(ndc.deviceScanCfg)
 	if node == nil {
+		// Virtual functions absent from the topology provide their own
+		// fabric domain, which is found from sysfs instead.
+		if domain, err := getVFDomainName(device); err == nil && domain != "" {
+			log.Debugf("Device alias for virtual function %s is %s", device, domain)
+			return domain, nil
+		}
 		return "", errors.Errorf("unable to find an alias for: %s", ndc.deviceScanCfg.targetDevice)
 	}
 	log.Debugf("Device alias for %s is %s", device, C.GoString(node.name))
@@ -791,6 +944,31 @@ func GetAffinityForDevice(deviceScanCfg DeviceScan) (DeviceAffinity, error) {
 	}, nil
 }
 
+// getDeviceAffinity returns the affinity of the target device. SR-IOV virtual
+// functions are not always present in the hwloc topology, in which case the
+// affinity of the parent physical function is reported for the VF.
+func getDeviceAffinity(deviceScanCfg DeviceScan) (DeviceAffinity, error) {
+	vf := deviceScanCfg.targetDevice
+	if _, found := deviceScanCfg.hwlocDeviceNamesMap[vf]; found {
+		return GetAffinityForDevice(deviceScanCfg)
+	}
+
+	physFn, err := GetPhysicalFunction(vf)
+	if err != nil || physFn == "" {
+		return GetAffinityForDevice(deviceScanCfg)
+	}
+	log.Debugf("using affinity of physical function %s for virtual function %s", physFn, vf)
+
+	deviceScanCfg.targetDevice = physFn
+	deviceAffinity, err := GetAffinityForDevice(deviceScanCfg)
+	if err != nil {
+		return DeviceAffinity{}, errors.Wrapf(err, "virtual function %s", vf)
+	}
+	deviceAffinity.DeviceName = vf
+
+	return deviceAffinity, nil
+}
+
 // GetDeviceNames examines the network interfaces
 // and returns a []string identifying them by name
 func GetDeviceNames() ([]string, error) {
@@ -808,6 +986,15 @@ func GetDeviceNames() ([]string, error) {
 	return netNames, nil
 }
 
+// LibFabricVersion returns the major.minor version of the libfabric library
+// linked into the binary.
+func LibFabricVersion() string {
+	// equivalent of the FI_MAJOR/FI_MINOR macros, which cgo can't call
+	version := uint32(C.fi_version())
+
+	return fmt.Sprintf("%d.%d", version>>16, version&0xFFFF)
+}
+
 // GetSupportedProviders returns a []string containing all supported Mercury providers
 func GetSupportedProviders() []string {
 	return []string{"ofi+gni", "ofi+psm2", "ofi+tcp", "ofi+sockets", "ofi+verbs", "ofi_rxm"}
@@ -1087,7 +1274,7 @@ func ValidateNUMAConfig(ctx context.Context, device string, numaNode uint) error
 	}
 
 	ndc.deviceScanCfg.targetDevice = device
-	deviceAffinity, err := GetAffinityForDevice(ndc.deviceScanCfg)
+	deviceAffinity, err := getDeviceAffinity(ndc.deviceScanCfg)
 	if err != nil {
 		return err
 	}
@@ -1099,7 +1286,7 @@ func ValidateNUMAConfig(ctx context.Context, device string, numaNode uint) error
 }
 
 func createFabricScanEntry(deviceScanCfg DeviceScan, provider string, devCount int, resultsMap map[string]struct{}, excludeMap map[string]struct{}) (*FabricScan, error) {
-	deviceAffinity, err := GetAffinityForDevice(deviceScanCfg)
+	deviceAffinity, err := getDeviceAffinity(deviceScanCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -1123,12 +1310,30 @@ func createFabricScanEntry(deviceScanCfg DeviceScan, provider string, devCount i
 		return nil, err
 	}
 
+	physFn, err := GetPhysicalFunction(deviceAffinity.DeviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	linkState, err := GetLinkState(deviceAffinity.DeviceName)
+	if err != nil {
+		log.Debugf("unable to read link state of %s: %s", deviceAffinity.DeviceName, err)
+	}
+
+	linkSpeed, err := GetLinkSpeed(deviceAffinity.DeviceName)
+	if err != nil {
+		log.Debugf("unable to read link speed of %s: %s", deviceAffinity.DeviceName, err)
+	}
+
 	scanResults := &FabricScan{
 		Provider:    mercuryProviderList,
 		DeviceName:  deviceAffinity.DeviceName,
 		NUMANode:    deviceAffinity.NUMANode,
 		Priority:    devCount,
 		NetDevClass: devClass,
+		PhysFn:      physFn,
+		LinkState:   linkState,
+		LinkSpeed:   linkSpeed,
 	}
 
 	if _, skip := excludeMap[scanResults.DeviceName]; skip {
@@ -1261,7 +1466,7 @@ func ScanFabric(ctx context.Context, provider string, excludes ...string) ([]*Fa
 // Returns an integer value corresponding to its ARP protocol hardware identifier
 // found here: https://elixir.free-electrons.com/linux/v4.0/source/include/uapi/linux/if_arp.h#L29
 func GetDeviceClass(netdev string) (uint32, error) {
-	devClass, err := ioutil.ReadFile(fmt.Sprintf("/sys/class/net/%s/type", netdev))
+	devClass, err := ioutil.ReadFile(filepath.Join(sysfsNetRoot, netdev, "type"))
 	if err != nil {
 		return 0, err
 	}
@@ -14,6 +14,19 @@ import (
 	. "github.com/daos-stack/daos/src/control/common"
 )
 
+// useTopologyFile directs hwloc to load the topology from the given XML file
+// and discards any cached topology so that the file is used by the next Init.
+// The returned function restores the default topology.
+func useTopologyFile(path string) func() {
+	os.Setenv("HWLOC_XMLFILE", path)
+	InvalidateTopology()
+
+	return func() {
+		os.Unsetenv("HWLOC_XMLFILE")
+		InvalidateTopology()
+	}
+}
+
 // TestParseTopology uses XML topology data to simulate real systems.
 // hwloc will use this topology for queries instead of the local system
 // running the test.
@@ -97,8 +110,7 @@ func TestParseTopology(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
@@ -148,8 +160,7 @@ func TestScanFabricNoDevices(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
@@ -162,6 +173,42 @@ func TestScanFabricNoDevices(t *testing.T) {
 	}
 }
 
+// TestTopologyCache verifies that the hwloc topology is shared between contexts
+// until it is invalidated, and that contexts holding an invalidated topology
+// remain usable until they are cleaned up.
+func TestTopologyCache(t *testing.T) {
+	defer useTopologyFile("testdata/boro-84.xml")()
+
+	firstCtx, err := Init(context.Background())
+	AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
+	first, err := getContext(firstCtx)
+	AssertEqual(t, err, nil, "Failed to retrieve context")
+
+	secondCtx, err := Init(context.Background())
+	AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
+	second, err := getContext(secondCtx)
+	AssertEqual(t, err, nil, "Failed to retrieve context")
+	AssertEqual(t, second.cached == first.cached, true, "expected cached topology to be shared")
+	AssertEqual(t, first.cached.refs, 2, "unexpected topology reference count")
+	CleanUp(secondCtx)
+
+	InvalidateTopology()
+	AssertEqual(t, first.cached.stale, true, "expected topology to be invalidated")
+
+	thirdCtx, err := Init(context.Background())
+	AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
+	defer CleanUp(thirdCtx)
+	third, err := getContext(thirdCtx)
+	AssertEqual(t, err, nil, "Failed to retrieve context")
+	AssertEqual(t, third.cached != first.cached, true, "expected topology to be reloaded")
+
+	// The invalidated topology is still referenced by the first context.
+	AssertEqual(t, NumNumaNodes(firstCtx), third.numNUMANodes, "unexpected NUMA node count")
+	CleanUp(firstCtx)
+	AssertEqual(t, first.cached.refs, 0, "unexpected topology reference count")
+	AssertEqual(t, first.cached.ndc.topology == nil, true, "expected invalidated topology to be destroyed")
+}
+
 // TestScanFabric scans the fabric on the test system.  Even though we don't know how the test system is configured,
 // we do expect that libfabric is installed and will report at least one provider,device,numa record.
 // If we get at least one record and no errors, the test is successful.
@@ -241,8 +288,7 @@ func TestValidateNUMAConfigNonNumaAware(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
@@ -282,8 +328,7 @@ func TestNumaAware(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
@@ -316,8 +361,7 @@ func TestInitDeviceScan(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
 			AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
@@ -352,8 +396,7 @@ func TestGetAffinityForDeviceEdgeCases(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
@@ -406,8 +449,7 @@ func TestDeviceAliasErrors(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
 			AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
@@ -496,8 +538,7 @@ func TestDeviceAlias(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			_, err := os.Stat(tc.topology)
 			AssertEqual(t, err, nil, "unable to load xmlTopology")
-			os.Setenv("HWLOC_XMLFILE", tc.topology)
-			defer os.Unsetenv("HWLOC_XMLFILE")
+			defer useTopologyFile(tc.topology)()
 			netCtx, err := Init(context.Background())
 			defer CleanUp(netCtx)
 			AssertEqual(t, err, nil, "Failed to initialize NetDetectContext")
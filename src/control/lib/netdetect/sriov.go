@@ -0,0 +1,141 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package netdetect
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// LinkStateUp is the operational state of a network interface whose
+	// link is up.
+	LinkStateUp = "up"
+	// LinkStateDown is the operational state of a network interface whose
+	// link is down.
+	LinkStateDown = "down"
+)
+
+// VirtualFunction describes an SR-IOV virtual function network interface.
+type VirtualFunction struct {
+	Device    string
+	PhysFn    string
+	LinkState string
+}
+
+// listDeviceDir returns the sorted names of the entries in a subdirectory of
+// the PCI device backing the network interface, or nil if it does not exist.
+func listDeviceDir(netdev string, elem ...string) ([]string, error) {
+	dir := filepath.Join(append([]string{sysfsNetRoot, netdev, "device"}, elem...)...)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// GetLinkState returns the operational state of the network interface link,
+// e.g. "up" or "down".
+func GetLinkState(netdev string) (string, error) {
+	state, err := ioutil.ReadFile(filepath.Join(sysfsNetRoot, netdev, "operstate"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(state)), nil
+}
+
+// GetLinkSpeed returns the speed of the network interface link in Mb/s, or
+// zero if the speed is unknown (e.g. the link is down).
+func GetLinkSpeed(netdev string) (uint32, error) {
+	data, err := ioutil.ReadFile(filepath.Join(sysfsNetRoot, netdev, "speed"))
+	if err != nil {
+		return 0, err
+	}
+
+	speed, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || speed < 0 {
+		return 0, nil
+	}
+
+	return uint32(speed), nil
+}
+
+// GetPhysicalFunction returns the name of the network interface of the SR-IOV
+// physical function that the given interface is a virtual function of. An
+// empty string is returned if the interface is not a virtual function.
+func GetPhysicalFunction(netdev string) (string, error) {
+	if _, err := os.Stat(filepath.Join(sysfsNetRoot, netdev, "device", "physfn")); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	pfDevs, err := listDeviceDir(netdev, "physfn", "net")
+	if err != nil {
+		return "", err
+	}
+	if len(pfDevs) == 0 {
+		return "", errors.Errorf("no network interface found for physical function of %s", netdev)
+	}
+
+	return pfDevs[0], nil
+}
+
+// GetVirtualFunction returns the details of the given network interface if it
+// is an SR-IOV virtual function, or nil if it is not.
+func GetVirtualFunction(netdev string) (*VirtualFunction, error) {
+	pf, err := GetPhysicalFunction(netdev)
+	if err != nil || pf == "" {
+		return nil, err
+	}
+
+	state, err := GetLinkState(netdev)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading link state of %s", netdev)
+	}
+
+	return &VirtualFunction{
+		Device:    netdev,
+		PhysFn:    pf,
+		LinkState: state,
+	}, nil
+}
+
+// getVFDomainName returns the name of the fabric domain, e.g. "mlx5_2", that
+// is provided by the PCI device backing the virtual function, or an empty
+// string if the interface is not a virtual function or has no such domain.
+func getVFDomainName(netdev string) (string, error) {
+	physFn, err := GetPhysicalFunction(netdev)
+	if err != nil || physFn == "" {
+		return "", err
+	}
+
+	domains, err := listDeviceDir(netdev, "infiniband")
+	if err != nil || len(domains) == 0 {
+		return "", err
+	}
+
+	return domains[0], nil
+}
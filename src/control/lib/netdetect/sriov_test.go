@@ -0,0 +1,168 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package netdetect
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	. "github.com/daos-stack/daos/src/control/common"
+)
+
+// mockSysfsNet creates a minimal sysfs network interface tree containing an
+// SR-IOV physical function "ens1f0" with a virtual function "ens1f0v1" and
+// sets it as the sysfs root. The returned function restores the original root.
+func mockSysfsNet(t *testing.T, vfState string) func() {
+	t.Helper()
+
+	testDir, cleanup := CreateTestDir(t)
+
+	pfDev := filepath.Join(testDir, "devices", "0000:01:00.0")
+	vfDev := filepath.Join(testDir, "devices", "0000:01:00.2")
+	netRoot := filepath.Join(testDir, "net")
+	for _, dir := range []string{
+		filepath.Join(pfDev, "net", "ens1f0"),
+		filepath.Join(vfDev, "infiniband", "mlx5_2"),
+		filepath.Join(netRoot, "ens1f0"),
+		filepath.Join(netRoot, "ens1f0v1"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for link, target := range map[string]string{
+		filepath.Join(vfDev, "physfn"):               pfDev,
+		filepath.Join(netRoot, "ens1f0", "device"):   pfDev,
+		filepath.Join(netRoot, "ens1f0v1", "device"): vfDev,
+	} {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for iface, state := range map[string]string{
+		"ens1f0":   LinkStateUp,
+		"ens1f0v1": vfState,
+	} {
+		path := filepath.Join(netRoot, iface, "operstate")
+		if err := ioutil.WriteFile(path, []byte(state+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for iface, speed := range map[string]string{
+		"ens1f0":   "100000",
+		"ens1f0v1": "-1",
+	} {
+		path := filepath.Join(netRoot, iface, "speed")
+		if err := ioutil.WriteFile(path, []byte(speed+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origRoot := sysfsNetRoot
+	sysfsNetRoot = netRoot
+
+	return func() {
+		sysfsNetRoot = origRoot
+		cleanup()
+	}
+}
+
+func TestNetdetect_GetVirtualFunction(t *testing.T) {
+	for name, tc := range map[string]struct {
+		netdev    string
+		vfState   string
+		expVF     *VirtualFunction
+		expDomain string
+	}{
+		"physical function": {
+			netdev:  "ens1f0",
+			vfState: LinkStateUp,
+		},
+		"unknown interface": {
+			netdev:  "eth9",
+			vfState: LinkStateUp,
+		},
+		"virtual function": {
+			netdev:  "ens1f0v1",
+			vfState: LinkStateUp,
+			expVF: &VirtualFunction{
+				Device:    "ens1f0v1",
+				PhysFn:    "ens1f0",
+				LinkState: LinkStateUp,
+			},
+			expDomain: "mlx5_2",
+		},
+		"virtual function link down": {
+			netdev:  "ens1f0v1",
+			vfState: LinkStateDown,
+			expVF: &VirtualFunction{
+				Device:    "ens1f0v1",
+				PhysFn:    "ens1f0",
+				LinkState: LinkStateDown,
+			},
+			expDomain: "mlx5_2",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer mockSysfsNet(t, tc.vfState)()
+
+			gotVF, err := GetVirtualFunction(tc.netdev)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expVF, gotVF); diff != "" {
+				t.Fatalf("unexpected virtual function (-want, +got):\n%s\n", diff)
+			}
+
+			gotDomain, err := getVFDomainName(tc.netdev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertEqual(t, tc.expDomain, gotDomain, "unexpected VF domain name")
+		})
+	}
+}
+
+func TestNetdetect_GetLinkState(t *testing.T) {
+	defer mockSysfsNet(t, LinkStateDown)()
+
+	state, err := GetLinkState("ens1f0v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertEqual(t, LinkStateDown, state, "unexpected link state")
+
+	_, err = GetLinkState("eth9")
+	CmpErr(t, errors.New("no such file"), err)
+}
+
+func TestNetdetect_GetLinkSpeed(t *testing.T) {
+	defer mockSysfsNet(t, LinkStateUp)()
+
+	for iface, expSpeed := range map[string]uint32{
+		"ens1f0":   100000,
+		"ens1f0v1": 0, // speed unknown
+	} {
+		speed, err := GetLinkSpeed(iface)
+		if err != nil {
+			t.Fatal(err)
+		}
+		AssertEqual(t, expSpeed, speed, "unexpected link speed of "+iface)
+	}
+
+	_, err := GetLinkSpeed("eth9")
+	CmpErr(t, errors.New("no such file"), err)
+}
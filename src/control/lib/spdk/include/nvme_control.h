@@ -29,14 +29,30 @@ struct ret_t *
 nvme_wipe_namespaces(void);
 
 /**
- * Format NVMe controller namespace.
+ * Format NVMe controller namespace with the given LBA format.
  *
  * \param ctrlr_pci_addr PCI address of NVMe controller.
+ * \param lbaf Index of the LBA format reported by identify namespace.
  *
  * \return a pointer to a return struct (ret_t).
  */
 struct ret_t *
-nvme_format(char *ctrlr_pci_addr);
+nvme_format(char *ctrlr_pci_addr, unsigned int lbaf);
+
+/**
+ * Read a range of LBAs from an NVMe controller namespace to verify that the
+ * media is readable. The range is truncated at the end of the namespace.
+ *
+ * \param ctrlr_pci_addr PCI address of NVMe controller.
+ * \param ns_id Identifier of the namespace to be read.
+ * \param slba First LBA of the range to be read.
+ * \param nlb Number of LBAs in the range to be read.
+ *
+ * \return a pointer to a return struct (ret_t) listing unreadable LBAs.
+ */
+struct ret_t *
+nvme_verify(char *ctrlr_pci_addr, unsigned int ns_id, uint64_t slba,
+	    uint64_t nlb);
 
 /**
  * Update NVMe controller firmware.
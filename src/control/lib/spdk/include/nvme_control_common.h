@@ -50,12 +50,28 @@ struct ctrlr_t {
 	struct ctrlr_t			*next;
 };
 
+/** Maximum number of LBA formats reported per namespace */
+#define NVME_MAX_LBAF	16
+
+/**
+ * \brief NVMe namespace LBA format details
+ */
+struct lbaf_t {
+	uint32_t	data_size;
+	uint32_t	md_size;
+	uint32_t	rp;
+};
+
 /**
  * \brief NVMe namespace details
  */
 struct ns_t {
 	uint32_t	id;
 	uint64_t	size;
+	bool		zoned;
+	uint32_t	lbaf;
+	uint32_t	nlbaf;
+	struct lbaf_t	lbafs[NVME_MAX_LBAF];
 	struct ns_t    *next;
 };
 
@@ -72,13 +88,23 @@ struct wipe_res_t {
 	struct wipe_res_t	*next;
 };
 
+/**
+ * \brief Unreadable LBA found during namespace read verification and link to
+ * next list element.
+ */
+struct bad_lba_t {
+	uint64_t		 lba;
+	struct bad_lba_t	*next;
+};
+
 /**
  * \brief Return containing return code, controllers, namespaces, wwipe
- * results and info message
+ * results, unreadable LBAs and info message
  */
 struct ret_t {
 	struct ctrlr_t		*ctrlrs;
 	struct wipe_res_t	*wipe_results;
+	struct bad_lba_t	*bad_lbas;
 	int			 rc;
 	char			 info[BUFLEN];
 };
@@ -39,6 +39,9 @@ type MockNvmeCfg struct {
 	DiscoverErr    error
 	FormatRes      []*FormatResult
 	FormatErr      error
+	ReformatErr    error
+	VerifyBadLBAs  []uint64
+	VerifyErr      error
 	UpdateErr      error
 }
 
@@ -80,6 +83,29 @@ func (n *MockNvmeImpl) Format(log logging.Logger) ([]*FormatResult, error) {
 	return n.Cfg.FormatRes, nil
 }
 
+// Reformat applies an LBA format to the namespaces of a device, destructive
+// operation!
+func (n *MockNvmeImpl) Reformat(log logging.Logger, ctrlrPciAddr string, lbaf uint32) error {
+	if n.Cfg.ReformatErr != nil {
+		return n.Cfg.ReformatErr
+	}
+	log.Debugf("mock reformat nvme ssd: %q, lba format %d", ctrlrPciAddr, lbaf)
+
+	return nil
+}
+
+// Verify reads a range of LBAs from a device namespace and returns any that
+// are unreadable.
+func (n *MockNvmeImpl) Verify(log logging.Logger, ctrlrPciAddr string, nsID uint32, startLBA, numLBAs uint64) ([]uint64, error) {
+	if n.Cfg.VerifyErr != nil {
+		return nil, n.Cfg.VerifyErr
+	}
+	log.Debugf("mock verify nvme ssd: %q, namespace %d, lbas %d-%d", ctrlrPciAddr,
+		nsID, startLBA, startLBA+numLBAs-1)
+
+	return n.Cfg.VerifyBadLBAs, nil
+}
+
 // Update calls C.nvme_fwupdate to update controller firmware image.
 func (n *MockNvmeImpl) Update(log logging.Logger, ctrlrPciAddr string, path string, slot int32) error {
 	if n.Cfg.UpdateErr != nil {
@@ -41,6 +41,11 @@ type Nvme interface {
 	Discover(logging.Logger) (storage.NvmeControllers, error)
 	// Format NVMe controller namespaces
 	Format(logging.Logger) ([]*FormatResult, error)
+	// Reformat NVMe controller namespaces with a specific LBA format
+	Reformat(log logging.Logger, ctrlrPciAddr string, lbaf uint32) error
+	// Verify reads a range of LBAs from a namespace of an NVMe controller
+	// and returns any that are unreadable
+	Verify(log logging.Logger, ctrlrPciAddr string, nsID uint32, startLBA, numLBAs uint64) ([]uint64, error)
 	// CleanLockfiles removes SPDK lockfiles for specific PCI addresses
 	CleanLockfiles(logging.Logger, ...string) error
 	// Update updates the firmware on a specific PCI address and slot
@@ -136,6 +141,30 @@ func (n *NvmeImpl) Format(log logging.Logger) ([]*FormatResult, error) {
 		"NVMe Format(): C.nvme_wipe_namespaces()")
 }
 
+// Reformat applies an LBA format to the namespaces of the controller at the
+// given PCI address, destructive operation!
+func (n *NvmeImpl) Reformat(log logging.Logger, ctrlrPciAddr string, lbaf uint32) error {
+	csPci := C.CString(ctrlrPciAddr)
+	defer C.free(unsafe.Pointer(csPci))
+
+	_, err := collectCtrlrs(C.nvme_format(csPci, C.uint(lbaf)),
+		"NVMe Reformat(): C.nvme_format")
+
+	return err
+}
+
+// Verify reads the given range of LBAs from a namespace of the controller at
+// the given PCI address and returns the LBAs that could not be read.
+func (n *NvmeImpl) Verify(log logging.Logger, ctrlrPciAddr string, nsID uint32, startLBA, numLBAs uint64) ([]uint64, error) {
+	csPci := C.CString(ctrlrPciAddr)
+	defer C.free(unsafe.Pointer(csPci))
+
+	badLBAs, err := collectBadLBAs(C.nvme_verify(csPci, C.uint(nsID),
+		C.uint64_t(startLBA), C.uint64_t(numLBAs)), "NVMe Verify(): C.nvme_verify")
+
+	return badLBAs, wrapCleanError(err, n.CleanLockfiles(log, ctrlrPciAddr))
+}
+
 // Update updates the firmware image via SPDK in a given slot on the device.
 func (n *NvmeImpl) Update(log logging.Logger, ctrlrPciAddr string, path string, slot int32) error {
 	csPath := C.CString(path)
@@ -183,10 +212,23 @@ func c2GoDeviceHealth(health *C.struct_nvme_stats) *storage.NvmeHealth {
 
 // c2GoNamespace is a private translation function.
 func c2GoNamespace(ns *C.struct_ns_t) *storage.NvmeNamespace {
-	return &storage.NvmeNamespace{
-		ID:   uint32(ns.id),
-		Size: uint64(ns.size),
+	gns := &storage.NvmeNamespace{
+		ID:        uint32(ns.id),
+		Size:      uint64(ns.size),
+		Zoned:     bool(ns.zoned),
+		LbaFormat: uint32(ns.lbaf),
 	}
+
+	for i := 0; i < int(ns.nlbaf) && i < len(ns.lbafs); i++ {
+		gns.LbaFormats = append(gns.LbaFormats, &storage.NvmeLbaFormat{
+			Index:    uint32(i),
+			DataSize: uint32(ns.lbafs[i].data_size),
+			MetaSize: uint32(ns.lbafs[i].md_size),
+			RelPerf:  uint32(ns.lbafs[i].rp),
+		})
+	}
+
+	return gns
 }
 
 // c2GoFormatResult is a private translation function.
@@ -275,3 +317,24 @@ func collectFormatResults(retPtr *C.struct_ret_t, failMsg string) ([]*FormatResu
 
 	return fmtResults, nil
 }
+
+// collectBadLBAs parses return struct to collect slice of unreadable LBAs.
+func collectBadLBAs(retPtr *C.struct_ret_t, failMsg string) ([]uint64, error) {
+	if retPtr == nil {
+		return nil, errors.Wrap(FaultBindingRetNull, failMsg)
+	}
+
+	defer clean(retPtr)
+
+	if retPtr.rc != 0 {
+		return nil, errors.Wrap(FaultBindingFailed(int(retPtr.rc),
+			C.GoString(&retPtr.info[0])), failMsg)
+	}
+
+	var badLBAs []uint64
+	for badPtr := retPtr.bad_lbas; badPtr != nil; badPtr = badPtr.next {
+		badLBAs = append(badLBAs, uint64(badPtr.lba))
+	}
+
+	return badLBAs, nil
+}
@@ -6,6 +6,7 @@
 
 #include <spdk/stdinc.h>
 #include <spdk/nvme.h>
+#include <spdk/nvme_zns.h>
 #include <spdk/env.h>
 
 #include "nvme_control.h"
@@ -145,16 +146,35 @@ wipe_ctrlr(struct ctrlr_entry *centry, struct ns_entry *nentry)
 		data.result = LBA0_WRITE_PENDING;
 		data.ns_entry = nentry;
 
-		/** zero out the first 4K block */
-		rc = spdk_nvme_ns_cmd_write(nentry->ns, qpair,
-					    buf, 0 /** LBA start */,
-					    4096 / sector_size /** #LBAS */,
-					    write_complete, &data, 0);
-		if (rc != 0) {
-			snprintf(res->info, sizeof(res->info),
-				 "spdk_nvme_ns_cmd_write() (%d)\n", rc);
-			res->rc = -1;
-			break;
+		if (spdk_nvme_ns_get_csi(nentry->ns) == SPDK_NVME_CSI_ZNS) {
+			/**
+			 * zones can only be written sequentially from the
+			 * write pointer so reset all zones instead
+			 */
+			rc = spdk_nvme_zns_reset_zone(nentry->ns, qpair,
+						      0 /** slba */,
+						      true /** select all */,
+						      write_complete, &data);
+			if (rc != 0) {
+				snprintf(res->info, sizeof(res->info),
+					 "spdk_nvme_zns_reset_zone() (%d)\n",
+					 rc);
+				res->rc = -1;
+				break;
+			}
+		} else {
+			/** zero out the first 4K block */
+			rc = spdk_nvme_ns_cmd_write(nentry->ns, qpair,
+						    buf, 0 /** LBA start */,
+						    4096 / sector_size /** #LBAS */,
+						    write_complete, &data, 0);
+			if (rc != 0) {
+				snprintf(res->info, sizeof(res->info),
+					 "spdk_nvme_ns_cmd_write() (%d)\n",
+					 rc);
+				res->rc = -1;
+				break;
+			}
 		}
 
 		/** wait for command completion */
@@ -170,7 +190,7 @@ wipe_ctrlr(struct ctrlr_entry *centry, struct ns_entry *nentry)
 		/** check command result */
 		if (data.result != LBA0_WRITE_SUCCESS) {
 			snprintf(res->info, sizeof(res->info),
-				 "spdk_nvme_ns_cmd_write() failed\n");
+				 "namespace wipe failed\n");
 			res->rc = -1;
 			break;
 		}
@@ -260,27 +280,38 @@ nvme_wipe_namespaces(void)
 }
 
 struct ret_t *
-nvme_format(char *ctrlr_pci_addr)
+nvme_format(char *ctrlr_pci_addr, unsigned int lbaf)
 {
 	int					 nsid;
 	const struct spdk_nvme_ctrlr_data	*cdata;
+	const struct spdk_nvme_ns_data		*nsdata;
 	struct spdk_nvme_ns			*ns;
 	struct spdk_nvme_format			 format = {};
 	struct ctrlr_entry			*ctrlr_entry;
 	struct ret_t				*ret;
+	int					 rc;
 
 	ret = init_ret();
 
+	rc = spdk_nvme_probe(NULL, NULL, probe_cb, attach_cb, NULL);
+	if (rc < 0) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "spdk_nvme_probe() (%d)\n", rc);
+		cleanup(true);
+		ret->rc = -1;
+		return ret;
+	}
+
 	ret->rc = get_controller(&ctrlr_entry, ctrlr_pci_addr);
 	if (ret->rc != 0)
-		return ret;
+		goto out;
 
 	cdata = spdk_nvme_ctrlr_get_data(ctrlr_entry->ctrlr);
 	if (!cdata->oacs.format) {
 		snprintf(ret->info, sizeof(ret->info),
 			 "controller does not support format nvm command");
 		ret->rc = -NVMEC_ERR_NOT_SUPPORTED;
-		return ret;
+		goto out;
 	}
 
 	if (cdata->fna.format_all_ns) {
@@ -295,10 +326,19 @@ nvme_format(char *ctrlr_pci_addr)
 		snprintf(ret->info, sizeof(ret->info),
 			 "namespace with id %d not found", nsid);
 		ret->rc = -NVMEC_ERR_NS_NOT_FOUND;
-		return ret;
+		goto out;
+	}
+
+	/* verify the requested format is reported by identify namespace */
+	nsdata = spdk_nvme_ns_get_data(ns);
+	if (lbaf > nsdata->nlbaf) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "lba format %u not supported by namespace", lbaf);
+		ret->rc = -NVMEC_ERR_NOT_SUPPORTED;
+		goto out;
 	}
 
-	format.lbaf	= 0; /* LBA format defaulted to 0 */
+	format.lbaf	= lbaf;
 	format.ms	= 0; /* metadata xfer as part of separate buffer */
 	format.pi	= 0; /* protection information is not enabled */
 	format.pil	= 0; /* protection information location N/A */
@@ -307,7 +347,7 @@ nvme_format(char *ctrlr_pci_addr)
 	ret->rc = spdk_nvme_ctrlr_format(ctrlr_entry->ctrlr, nsid, &format);
 	if (ret->rc != 0) {
 		snprintf(ret->info, sizeof(ret->info), "format failed");
-		return ret;
+		goto out;
 	}
 
 	/* print address of device updated for verification purposes */
@@ -315,6 +355,166 @@ nvme_format(char *ctrlr_pci_addr)
 	       ctrlr_entry->pci_addr.domain, ctrlr_entry->pci_addr.bus,
 	       ctrlr_entry->pci_addr.dev, ctrlr_entry->pci_addr.func);
 
+out:
+	cleanup(true);
+	return ret;
+}
+
+enum verify_read_result {
+	VERIFY_READ_PENDING	= 0x0,
+	VERIFY_READ_SUCCESS	= 0x1,
+	VERIFY_READ_FAIL	= 0x2,
+};
+
+/** callback for read command completion when verifying a ns */
+static void
+verify_read_complete(void *arg, const struct spdk_nvme_cpl *completion)
+{
+	enum verify_read_result *result = arg;
+
+	if (spdk_nvme_cpl_is_success(completion))
+		*result = VERIFY_READ_SUCCESS;
+	else
+		*result = VERIFY_READ_FAIL;
+}
+
+/** read nlb LBAs from slba and wait for completion */
+static int
+verify_read(struct spdk_nvme_ns *ns, struct spdk_nvme_qpair *qpair, void *buf,
+	    uint64_t slba, uint32_t nlb, enum verify_read_result *result)
+{
+	int rc;
+
+	*result = VERIFY_READ_PENDING;
+
+	rc = spdk_nvme_ns_cmd_read(ns, qpair, buf, slba, nlb,
+				   verify_read_complete, result, 0);
+	if (rc != 0)
+		return rc;
+
+	while (*result == VERIFY_READ_PENDING) {
+		rc = spdk_nvme_qpair_process_completions(qpair, 0);
+		if (rc < 0)
+			return rc;
+	}
+
+	return 0;
+}
+
+struct ret_t *
+nvme_verify(char *ctrlr_pci_addr, unsigned int ns_id, uint64_t slba,
+	    uint64_t nlb)
+{
+	struct ctrlr_entry	*ctrlr_entry;
+	struct spdk_nvme_ns	*ns;
+	struct spdk_nvme_qpair	*qpair;
+	struct bad_lba_t	*bad, *last = NULL;
+	enum verify_read_result	 result;
+	struct ret_t		*ret;
+	uint64_t		 nsze, lba, end, i;
+	uint32_t		 sector_size, max_nlb, n;
+	void			*buf;
+	int			 rc;
+
+	ret = init_ret();
+
+	rc = spdk_nvme_probe(NULL, NULL, probe_cb, attach_cb, NULL);
+	if (rc < 0) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "spdk_nvme_probe() (%d)\n", rc);
+		cleanup(true);
+		ret->rc = -1;
+		return ret;
+	}
+
+	ret->rc = get_controller(&ctrlr_entry, ctrlr_pci_addr);
+	if (ret->rc != 0)
+		goto out;
+
+	ns = spdk_nvme_ctrlr_get_ns(ctrlr_entry->ctrlr, ns_id);
+	if (ns == NULL || !spdk_nvme_ns_is_active(ns)) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "namespace with id %u not found", ns_id);
+		ret->rc = -NVMEC_ERR_NS_NOT_FOUND;
+		goto out;
+	}
+
+	nsze = spdk_nvme_ns_get_num_sectors(ns);
+	if (slba >= nsze) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "lba %" PRIu64 " beyond end of namespace", slba);
+		ret->rc = -NVMEC_ERR_BAD_LBA;
+		goto out;
+	}
+	end = (nlb > nsze - slba) ? nsze : slba + nlb;
+
+	sector_size = spdk_nvme_ns_get_sector_size(ns);
+	max_nlb = spdk_nvme_ns_get_max_io_xfer_size(ns) / sector_size;
+	if (max_nlb == 0)
+		max_nlb = 1;
+
+	qpair = spdk_nvme_ctrlr_alloc_io_qpair(ctrlr_entry->ctrlr, NULL, 0);
+	if (qpair == NULL) {
+		snprintf(ret->info, sizeof(ret->info),
+			 "spdk_nvme_ctrlr_alloc_io_qpair()\n");
+		ret->rc = -NVMEC_ERR_ALLOC_IO_QPAIR;
+		goto out;
+	}
+
+	buf = spdk_dma_zmalloc((size_t)max_nlb * sector_size, 4096, NULL);
+	if (buf == NULL) {
+		snprintf(ret->info, sizeof(ret->info), "spdk_dma_zmalloc()\n");
+		ret->rc = -1;
+		spdk_nvme_ctrlr_free_io_qpair(qpair);
+		goto out;
+	}
+
+	for (lba = slba; lba < end; lba += n) {
+		n = (end - lba < max_nlb) ? (uint32_t)(end - lba) : max_nlb;
+
+		rc = verify_read(ns, qpair, buf, lba, n, &result);
+		if (rc != 0) {
+			snprintf(ret->info, sizeof(ret->info),
+				 "spdk_nvme_ns_cmd_read() (%d)\n", rc);
+			ret->rc = -1;
+			break;
+		}
+		if (result == VERIFY_READ_SUCCESS)
+			continue;
+
+		/** isolate the unreadable LBAs within the failed read */
+		for (i = lba; i < lba + n; i++) {
+			rc = verify_read(ns, qpair, buf, i, 1, &result);
+			if (rc != 0) {
+				snprintf(ret->info, sizeof(ret->info),
+					 "spdk_nvme_ns_cmd_read() (%d)\n", rc);
+				ret->rc = -1;
+				break;
+			}
+			if (result == VERIFY_READ_SUCCESS)
+				continue;
+
+			bad = calloc(1, sizeof(struct bad_lba_t));
+			if (bad == NULL) {
+				perror("bad_lba_t calloc");
+				exit(1);
+			}
+			bad->lba = i;
+			if (last == NULL)
+				ret->bad_lbas = bad;
+			else
+				last->next = bad;
+			last = bad;
+		}
+		if (ret->rc != 0)
+			break;
+	}
+
+	spdk_free(buf);
+	spdk_nvme_ctrlr_free_io_qpair(qpair);
+
+out:
+	cleanup(true);
 	return ret;
 }
 
@@ -128,6 +128,7 @@ clean_ret(struct ret_t *ret)
 	struct ctrlr_t		*cnext;
 	struct ns_t		*nnext;
 	struct wipe_res_t	*wrnext;
+	struct bad_lba_t	*blnext;
 
 	while (ret && (ret->wipe_results)) {
 		wrnext = ret->wipe_results->next;
@@ -135,6 +136,12 @@ clean_ret(struct ret_t *ret)
 		ret->wipe_results = wrnext;
 	}
 
+	while (ret && (ret->bad_lbas)) {
+		blnext = ret->bad_lbas->next;
+		free(ret->bad_lbas);
+		ret->bad_lbas = blnext;
+	}
+
 	while (ret && (ret->ctrlrs)) {
 		while (ret->ctrlrs->nss) {
 			nnext = ret->ctrlrs->nss->next;
@@ -252,7 +259,9 @@ copy_ctrlr_data(struct ctrlr_t *cdst, const struct spdk_nvme_ctrlr_data *cdata)
 static int
 collect_namespaces(struct ns_entry *ns_entry, struct ctrlr_t *ctrlr)
 {
-	struct ns_t	*ns_tmp;
+	const struct spdk_nvme_ns_data	*nsdata;
+	struct ns_t			*ns_tmp;
+	uint32_t			 i;
 
 	while (ns_entry) {
 		ns_tmp = calloc(1, sizeof(struct ns_t));
@@ -263,6 +272,20 @@ collect_namespaces(struct ns_entry *ns_entry, struct ctrlr_t *ctrlr)
 
 		ns_tmp->id = spdk_nvme_ns_get_id(ns_entry->ns);
 		ns_tmp->size = spdk_nvme_ns_get_size(ns_entry->ns);
+		ns_tmp->zoned = (spdk_nvme_ns_get_csi(ns_entry->ns) ==
+				 SPDK_NVME_CSI_ZNS);
+
+		/** nlbaf is a zero-based count of supported lba formats */
+		nsdata = spdk_nvme_ns_get_data(ns_entry->ns);
+		ns_tmp->lbaf = nsdata->flbas.format;
+		ns_tmp->nlbaf = nsdata->nlbaf + 1;
+		if (ns_tmp->nlbaf > NVME_MAX_LBAF)
+			ns_tmp->nlbaf = NVME_MAX_LBAF;
+		for (i = 0; i < ns_tmp->nlbaf; i++) {
+			ns_tmp->lbafs[i].data_size = 1 << nsdata->lbaf[i].lbads;
+			ns_tmp->lbafs[i].md_size = nsdata->lbaf[i].ms;
+			ns_tmp->lbafs[i].rp = nsdata->lbaf[i].rp;
+		}
 		ns_tmp->next = ctrlr->nss;
 		ctrlr->nss = ns_tmp;
 
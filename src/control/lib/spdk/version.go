@@ -0,0 +1,60 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package spdk
+
+/*
+#include <spdk/version.h>
+#include <rte_version.h>
+*/
+import "C"
+
+import "strings"
+
+// Version returns the version of the SPDK library linked into the binary.
+func Version() string {
+	return strings.TrimPrefix(C.SPDK_VERSION_STRING, "SPDK ")
+}
+
+// DPDKVersion returns the version of the DPDK library linked into the binary.
+func DPDKVersion() string {
+	return strings.TrimPrefix(C.GoString(C.rte_version()), "DPDK ")
+}
+
+// Features describes the optional NVMe features supported by an SPDK release.
+type Features struct {
+	VMD bool // Intel Volume Management Device
+	ZNS bool // zoned namespaces
+	CMB bool // controller memory buffer mapping
+}
+
+// featureReleases maps each optional feature to the SPDK release (major,
+// minor) in which it was introduced.
+var featureReleases = []struct {
+	major, minor int
+	set          func(*Features)
+}{
+	{19, 4, func(f *Features) { f.VMD = true }},
+	{20, 4, func(f *Features) { f.CMB = true }},
+	{20, 10, func(f *Features) { f.ZNS = true }},
+}
+
+func featuresForVersion(major, minor int) Features {
+	var f Features
+	for _, fr := range featureReleases {
+		if major > fr.major || (major == fr.major && minor >= fr.minor) {
+			fr.set(&f)
+		}
+	}
+
+	return f
+}
+
+// SupportedFeatures returns the optional NVMe features supported by the SPDK
+// library linked into the binary.
+func SupportedFeatures() Features {
+	return featuresForVersion(int(C.SPDK_VERSION_MAJOR), int(C.SPDK_VERSION_MINOR))
+}
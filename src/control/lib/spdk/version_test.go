@@ -0,0 +1,51 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package spdk
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSpdk_featuresForVersion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		major, minor int
+		expFeatures  Features
+	}{
+		"pre-vmd release": {
+			major: 19, minor: 1,
+		},
+		"vmd release": {
+			major: 19, minor: 4,
+			expFeatures: Features{VMD: true},
+		},
+		"cmb release": {
+			major: 20, minor: 4,
+			expFeatures: Features{VMD: true, CMB: true},
+		},
+		"pre-zns release": {
+			major: 20, minor: 7,
+			expFeatures: Features{VMD: true, CMB: true},
+		},
+		"zns release": {
+			major: 20, minor: 10,
+			expFeatures: Features{VMD: true, CMB: true, ZNS: true},
+		},
+		"later major release": {
+			major: 21, minor: 1,
+			expFeatures: Features{VMD: true, CMB: true, ZNS: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotFeatures := featuresForVersion(tc.major, tc.minor)
+			if diff := cmp.Diff(tc.expFeatures, gotFeatures); diff != "" {
+				t.Fatalf("unexpected features (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
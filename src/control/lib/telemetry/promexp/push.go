@@ -0,0 +1,259 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+// +build linux,amd64
+//
+
+package promexp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	defaultPushInterval   = time.Minute
+	defaultPushBatchSize  = 500
+	defaultPushRetryDelay = time.Second
+)
+
+type (
+	// Sample is a single metric value gathered to be pushed to a remote
+	// collector.
+	Sample struct {
+		Name      string
+		Labels    labelMap
+		Value     float64
+		Timestamp time.Time
+	}
+
+	// PushSink sends batches of samples to a remote collector.
+	PushSink interface {
+		Push(ctx context.Context, samples []*Sample) error
+	}
+
+	// PushExporterOpts control how often samples are pushed and how
+	// failures are handled.
+	PushExporterOpts struct {
+		Interval   time.Duration
+		BatchSize  int
+		MaxRetries int
+		// delay before the first retry of a failed batch, doubled
+		// for each subsequent retry
+		RetryDelay time.Duration
+	}
+
+	// PushExporter periodically gathers metrics and pushes them in
+	// batches to a sink, for sites where inbound scraping of storage
+	// nodes is not allowed.
+	PushExporter struct {
+		log      logging.Logger
+		name     string
+		gatherer prometheus.Gatherer
+		sink     PushSink
+		opts     PushExporterOpts
+	}
+
+	// permanentError indicates a push failure that will not succeed when
+	// retried, e.g. a request rejected by the collector.
+	permanentError struct {
+		error
+	}
+)
+
+func isPermanent(err error) bool {
+	_, ok := errors.Cause(err).(*permanentError)
+	return ok
+}
+
+// sortedKeys returns the label names in sorted order.
+func (lm labelMap) sortedKeys() []string {
+	keys := lm.keys()
+	sort.Strings(keys)
+	return keys
+}
+
+func newSample(name string, labels labelMap, value float64, ts time.Time) *Sample {
+	return &Sample{Name: name, Labels: labels, Value: value, Timestamp: ts}
+}
+
+func metricLabels(m *dto.Metric, extra ...string) labelMap {
+	labels := make(labelMap, len(m.GetLabel())+len(extra)/2)
+	for _, lp := range m.GetLabel() {
+		labels[lp.GetName()] = lp.GetValue()
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		labels[extra[i]] = extra[i+1]
+	}
+	return labels
+}
+
+func formatFloat(val float64) string {
+	switch {
+	case math.IsInf(val, 1):
+		return "+Inf"
+	case math.IsInf(val, -1):
+		return "-Inf"
+	}
+	return fmt.Sprint(val)
+}
+
+// GatherSamples flattens the metric families returned by the gatherer into
+// individual samples stamped with the given time. Summaries and histograms
+// are expanded into their quantile or bucket, sum and count series in the
+// same way as in the Prometheus exposition format.
+func GatherSamples(g prometheus.Gatherer, ts time.Time) ([]*Sample, error) {
+	families, err := g.Gather()
+	if err != nil && len(families) == 0 {
+		return nil, errors.Wrap(err, "gathering metrics")
+	}
+
+	var samples []*Sample
+	for _, mf := range families {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				samples = append(samples, newSample(name, metricLabels(m), m.GetCounter().GetValue(), ts))
+			case dto.MetricType_GAUGE:
+				samples = append(samples, newSample(name, metricLabels(m), m.GetGauge().GetValue(), ts))
+			case dto.MetricType_UNTYPED:
+				samples = append(samples, newSample(name, metricLabels(m), m.GetUntyped().GetValue(), ts))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					samples = append(samples, newSample(name,
+						metricLabels(m, "quantile", formatFloat(q.GetQuantile())), q.GetValue(), ts))
+				}
+				samples = append(samples,
+					newSample(name+"_sum", metricLabels(m), s.GetSampleSum(), ts),
+					newSample(name+"_count", metricLabels(m), float64(s.GetSampleCount()), ts))
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					samples = append(samples, newSample(name+"_bucket",
+						metricLabels(m, "le", formatFloat(b.GetUpperBound())),
+						float64(b.GetCumulativeCount()), ts))
+				}
+				samples = append(samples,
+					newSample(name+"_bucket", metricLabels(m, "le", "+Inf"), float64(h.GetSampleCount()), ts),
+					newSample(name+"_sum", metricLabels(m), h.GetSampleSum(), ts),
+					newSample(name+"_count", metricLabels(m), float64(h.GetSampleCount()), ts))
+			}
+		}
+	}
+
+	return samples, nil
+}
+
+// NewPushExporter returns an exporter that pushes the metrics of the gatherer
+// to the sink, name identifies the exporter in log messages.
+func NewPushExporter(log logging.Logger, name string, g prometheus.Gatherer, sink PushSink, opts PushExporterOpts) *PushExporter {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultPushInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultPushBatchSize
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = defaultPushRetryDelay
+	}
+
+	return &PushExporter{
+		log:      log,
+		name:     name,
+		gatherer: g,
+		sink:     sink,
+		opts:     opts,
+	}
+}
+
+// pushBatch sends a batch, retrying with exponential backoff until it succeeds,
+// fails permanently or the retries are exhausted.
+func (pe *PushExporter) pushBatch(ctx context.Context, batch []*Sample) error {
+	delay := pe.opts.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err := pe.sink.Push(ctx, batch)
+		if err == nil || isPermanent(err) || attempt >= pe.opts.MaxRetries {
+			return err
+		}
+		pe.log.Debugf("%s: push of %d samples failed (attempt %d): %s", pe.name, len(batch),
+			attempt+1, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// PushOnce gathers the current metrics and pushes them in batches. Batches
+// that cannot be pushed are dropped so that later batches are still sent, the
+// number of samples dropped is reported in the returned error.
+func (pe *PushExporter) PushOnce(ctx context.Context) error {
+	samples, err := GatherSamples(pe.gatherer, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var dropped int
+	var lastErr error
+	for start := 0; start < len(samples); start += pe.opts.BatchSize {
+		end := start + pe.opts.BatchSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+		if err := pe.pushBatch(ctx, samples[start:end]); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			dropped += end - start
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return errors.Wrapf(lastErr, "dropped %d of %d samples", dropped, len(samples))
+	}
+	return nil
+}
+
+// Run pushes metrics at the configured interval until the context is
+// canceled.
+func (pe *PushExporter) Run(ctx context.Context) {
+	pe.log.Debugf("%s: pushing metrics every %s", pe.name, pe.opts.Interval)
+
+	ticker := time.NewTicker(pe.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			pe.log.Debugf("%s: metric push stopped", pe.name)
+			return
+		case <-ticker.C:
+			if err := pe.PushOnce(ctx); err != nil && ctx.Err() == nil {
+				pe.log.Errorf("%s: metric push failed: %s", pe.name, err)
+			}
+		}
+	}
+}
+
+// sanitizeLabelValue replaces characters that have special meaning in the
+// statsd and graphite line protocols.
+var sanitizeLabelValue = strings.NewReplacer(" ", "_", ";", "_", "=", "_", ":", "_",
+	"|", "_", ",", "_", "#", "_", "\n", "_", "~", "_").Replace
@@ -0,0 +1,262 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+// +build linux,amd64
+//
+
+package promexp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	pushTimeout = 10 * time.Second
+	// maxStatsdPacket keeps statsd datagrams within a typical Ethernet MTU.
+	maxStatsdPacket = 1432
+	// maxSnappyLiteral is the largest literal that can be encoded with a
+	// two byte length in the snappy block format.
+	maxSnappyLiteral = 1 << 16
+)
+
+type (
+	// RemoteWriteSink pushes samples to a Prometheus remote-write
+	// endpoint.
+	RemoteWriteSink struct {
+		url    string
+		client *http.Client
+	}
+
+	// StatsdSink pushes samples as statsd gauges over UDP, labels are sent
+	// as DogStatsD tags.
+	StatsdSink struct {
+		address string
+		prefix  string
+	}
+
+	// GraphiteSink pushes samples using the graphite plaintext protocol
+	// over TCP, labels are sent as graphite tags.
+	GraphiteSink struct {
+		address string
+		prefix  string
+	}
+)
+
+// NewRemoteWriteSink returns a sink for the remote-write endpoint at url.
+func NewRemoteWriteSink(url string) *RemoteWriteSink {
+	return &RemoteWriteSink{
+		url:    url,
+		client: &http.Client{Timeout: pushTimeout},
+	}
+}
+
+// snappyEncode returns data in the snappy block format without compressing
+// it, which is valid input for any snappy decoder.
+func snappyEncode(data []byte) []byte {
+	out := protowire.AppendVarint(nil, uint64(len(data)))
+	for len(data) > 0 {
+		n := len(data)
+		if n > maxSnappyLiteral {
+			n = maxSnappyLiteral
+		}
+		switch l := n - 1; {
+		case l < 60:
+			out = append(out, byte(l<<2))
+		case l < 1<<8:
+			out = append(out, 60<<2, byte(l))
+		default:
+			out = append(out, 61<<2, byte(l), byte(l>>8))
+		}
+		out = append(out, data[:n]...)
+		data = data[n:]
+	}
+	return out
+}
+
+// encodeWriteRequest encodes samples as a remote-write WriteRequest protobuf
+// message, each sample being a time series with a single value.
+func encodeWriteRequest(samples []*Sample) []byte {
+	appendLabel := func(b []byte, name, value string) []byte {
+		var lb []byte
+		lb = protowire.AppendTag(lb, 1, protowire.BytesType)
+		lb = protowire.AppendString(lb, name)
+		lb = protowire.AppendTag(lb, 2, protowire.BytesType)
+		lb = protowire.AppendString(lb, value)
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		return protowire.AppendBytes(b, lb)
+	}
+
+	var req []byte
+	for _, s := range samples {
+		// labels must be sorted by name, __name__ sorts first
+		ts := appendLabel(nil, "__name__", s.Name)
+		for _, key := range s.Labels.sortedKeys() {
+			ts = appendLabel(ts, key, s.Labels[key])
+		}
+
+		var sb []byte
+		sb = protowire.AppendTag(sb, 1, protowire.Fixed64Type)
+		sb = protowire.AppendFixed64(sb, math.Float64bits(s.Value))
+		sb = protowire.AppendTag(sb, 2, protowire.VarintType)
+		sb = protowire.AppendVarint(sb, uint64(s.Timestamp.UnixNano()/int64(time.Millisecond)))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sb)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+
+	return req
+}
+
+// Push implements PushSink.
+func (rws *RemoteWriteSink) Push(ctx context.Context, samples []*Sample) error {
+	body := snappyEncode(encodeWriteRequest(samples))
+
+	req, err := http.NewRequest(http.MethodPost, rws.url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err}
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := rws.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+	err = errors.Errorf("remote write to %s: %s: %s", rws.url, resp.Status,
+		strings.TrimSpace(string(msg)))
+	// client errors other than rate limiting will not succeed on retry
+	if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+		return &permanentError{err}
+	}
+	return err
+}
+
+// NewStatsdSink returns a sink for the statsd server at address (host:port),
+// metric names are prefixed with prefix.
+func NewStatsdSink(address, prefix string) *StatsdSink {
+	return &StatsdSink{address: address, prefix: prefix}
+}
+
+func prefixedName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func (ss *StatsdSink) formatSample(s *Sample) string {
+	line := fmt.Sprintf("%s:%s|g", prefixedName(ss.prefix, s.Name), formatFloat(s.Value))
+
+	keys := s.Labels.sortedKeys()
+	if len(keys) == 0 {
+		return line
+	}
+	tags := make([]string, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, key+":"+sanitizeLabelValue(s.Labels[key]))
+	}
+	return line + "|#" + strings.Join(tags, ",")
+}
+
+// Push implements PushSink. Samples are packed into as few datagrams as
+// possible.
+func (ss *StatsdSink) Push(ctx context.Context, samples []*Sample) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", ss.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var pkt bytes.Buffer
+	flush := func() error {
+		if pkt.Len() == 0 {
+			return nil
+		}
+		_, err := conn.Write(pkt.Bytes())
+		pkt.Reset()
+		return err
+	}
+
+	for _, s := range samples {
+		line := ss.formatSample(s)
+		if pkt.Len() > 0 && pkt.Len()+1+len(line) > maxStatsdPacket {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if pkt.Len() > 0 {
+			pkt.WriteByte('\n')
+		}
+		pkt.WriteString(line)
+	}
+
+	return flush()
+}
+
+// NewGraphiteSink returns a sink for the graphite server at address
+// (host:port), metric names are prefixed with prefix.
+func NewGraphiteSink(address, prefix string) *GraphiteSink {
+	return &GraphiteSink{address: address, prefix: prefix}
+}
+
+func (gs *GraphiteSink) formatSample(s *Sample) string {
+	var path strings.Builder
+	path.WriteString(prefixedName(gs.prefix, s.Name))
+	for _, key := range s.Labels.sortedKeys() {
+		value := sanitizeLabelValue(s.Labels[key])
+		if value == "" {
+			// graphite tags may not have empty values
+			continue
+		}
+		path.WriteString(";" + key + "=" + value)
+	}
+
+	return fmt.Sprintf("%s %s %d\n", path.String(), formatFloat(s.Value), s.Timestamp.Unix())
+}
+
+// Push implements PushSink.
+func (gs *GraphiteSink) Push(ctx context.Context, samples []*Sample) error {
+	d := net.Dialer{Timeout: pushTimeout}
+	conn, err := d.DialContext(ctx, "tcp", gs.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(pushTimeout)); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, s := range samples {
+		buf.WriteString(gs.formatSample(s))
+	}
+	_, err = conn.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,397 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+// +build linux,amd64
+//
+
+package promexp
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+var testPushTime = time.Unix(1620000000, 0)
+
+func newTestRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+	gv := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "engine_free_bytes", Help: "free"},
+		[]string{"rank", "device"})
+	gv.WithLabelValues("0", "nvme 0").Set(1024)
+	gv.WithLabelValues("1", "nvme1").Set(2048)
+	c := prometheus.NewCounter(prometheus.CounterOpts{Name: "engine_ops_total", Help: "ops"})
+	c.Add(5)
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "rpc_seconds", Help: "rpc",
+		Buckets: []float64{0.1, 1}})
+	h.Observe(0.5)
+	for _, col := range []prometheus.Collector{gv, c, h} {
+		if err := reg.Register(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return reg
+}
+
+func expTestSamples() []*Sample {
+	return []*Sample{
+		{Name: "engine_free_bytes", Labels: labelMap{"device": "nvme 0", "rank": "0"}, Value: 1024, Timestamp: testPushTime},
+		{Name: "engine_free_bytes", Labels: labelMap{"device": "nvme1", "rank": "1"}, Value: 2048, Timestamp: testPushTime},
+		{Name: "engine_ops_total", Labels: labelMap{}, Value: 5, Timestamp: testPushTime},
+		{Name: "rpc_seconds_bucket", Labels: labelMap{"le": "0.1"}, Value: 0, Timestamp: testPushTime},
+		{Name: "rpc_seconds_bucket", Labels: labelMap{"le": "1"}, Value: 1, Timestamp: testPushTime},
+		{Name: "rpc_seconds_bucket", Labels: labelMap{"le": "+Inf"}, Value: 1, Timestamp: testPushTime},
+		{Name: "rpc_seconds_sum", Labels: labelMap{}, Value: 0.5, Timestamp: testPushTime},
+		{Name: "rpc_seconds_count", Labels: labelMap{}, Value: 1, Timestamp: testPushTime},
+	}
+}
+
+func TestPromExp_GatherSamples(t *testing.T) {
+	gotSamples, err := GatherSamples(newTestRegistry(t), testPushTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(expTestSamples(), gotSamples); diff != "" {
+		t.Fatalf("unexpected samples (-want, +got):\n%s\n", diff)
+	}
+}
+
+// snappyDecode decodes a snappy block consisting only of literals.
+func snappyDecode(t *testing.T, in []byte) []byte {
+	t.Helper()
+
+	size, n := protowire.ConsumeVarint(in)
+	if n < 0 {
+		t.Fatal("bad snappy length")
+	}
+	in = in[n:]
+
+	var out []byte
+	for len(in) > 0 {
+		tag := in[0]
+		if tag&3 != 0 {
+			t.Fatalf("unexpected snappy element type %d", tag&3)
+		}
+		l := int(tag >> 2)
+		in = in[1:]
+		switch l {
+		case 60:
+			l, in = int(in[0]), in[1:]
+		case 61:
+			l, in = int(in[0])|int(in[1])<<8, in[2:]
+		}
+		out = append(out, in[:l+1]...)
+		in = in[l+1:]
+	}
+	if uint64(len(out)) != size {
+		t.Fatalf("snappy length %d != %d", len(out), size)
+	}
+
+	return out
+}
+
+// decodeWriteRequest decodes a remote-write request into samples.
+func decodeWriteRequest(t *testing.T, in []byte) []*Sample {
+	t.Helper()
+
+	fields := func(b []byte, fn func(num protowire.Number, typ protowire.Type, val []byte, v uint64)) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			b = b[n:]
+			switch typ {
+			case protowire.BytesType:
+				val, n := protowire.ConsumeBytes(b)
+				fn(num, typ, val, 0)
+				b = b[n:]
+			case protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				fn(num, typ, nil, v)
+				b = b[n:]
+			case protowire.Fixed64Type:
+				v, n := protowire.ConsumeFixed64(b)
+				fn(num, typ, nil, v)
+				b = b[n:]
+			default:
+				t.Fatalf("unexpected wire type %d", typ)
+			}
+		}
+	}
+
+	var samples []*Sample
+	fields(in, func(_ protowire.Number, _ protowire.Type, ts []byte, _ uint64) {
+		s := &Sample{Labels: labelMap{}}
+		var names []string
+		fields(ts, func(num protowire.Number, _ protowire.Type, val []byte, _ uint64) {
+			switch num {
+			case 1:
+				var name, value string
+				fields(val, func(num protowire.Number, _ protowire.Type, val []byte, _ uint64) {
+					if num == 1 {
+						name = string(val)
+					} else {
+						value = string(val)
+					}
+				})
+				names = append(names, name)
+				if name == "__name__" {
+					s.Name = value
+				} else {
+					s.Labels[name] = value
+				}
+			case 2:
+				fields(val, func(num protowire.Number, _ protowire.Type, _ []byte, v uint64) {
+					if num == 1 {
+						s.Value = math.Float64frombits(v)
+					} else {
+						s.Timestamp = time.Unix(0, int64(v)*int64(time.Millisecond))
+					}
+				})
+			}
+		})
+		if !sort.StringsAreSorted(names) {
+			t.Fatalf("labels not sorted: %v", names)
+		}
+		samples = append(samples, s)
+	})
+
+	return samples
+}
+
+func TestPromExp_RemoteWriteSink(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status       int
+		expErr       error
+		expPermanent bool
+	}{
+		"success": {
+			status: http.StatusNoContent,
+		},
+		"bad request": {
+			status:       http.StatusBadRequest,
+			expErr:       errors.New("400 Bad Request: out of order sample"),
+			expPermanent: true,
+		},
+		"rate limited": {
+			status: http.StatusTooManyRequests,
+			expErr: errors.New("429 Too Many Requests"),
+		},
+		"server error": {
+			status: http.StatusServiceUnavailable,
+			expErr: errors.New("503 Service Unavailable"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var gotSamples []*Sample
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				common.AssertEqual(t, "snappy", r.Header.Get("Content-Encoding"), "content encoding")
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotSamples = decodeWriteRequest(t, snappyDecode(t, body))
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusBadRequest {
+					w.Write([]byte("out of order sample\n"))
+				}
+			}))
+			defer srv.Close()
+
+			gotErr := NewRemoteWriteSink(srv.URL).Push(context.TODO(), expTestSamples())
+			common.CmpErr(t, tc.expErr, gotErr)
+			common.AssertEqual(t, tc.expPermanent, isPermanent(gotErr), "permanent error")
+
+			if diff := cmp.Diff(expTestSamples(), gotSamples); diff != "" {
+				t.Fatalf("unexpected samples (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestPromExp_snappyEncode_LongLiteral(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 20000))
+	if diff := cmp.Diff(data, snappyDecode(t, snappyEncode(data))); diff != "" {
+		t.Fatalf("unexpected data (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestPromExp_StatsdSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := NewStatsdSink(conn.LocalAddr().String(), "daos").Push(context.TODO(), expTestSamples()[:3]); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, maxStatsdPacket)
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expLines := []string{
+		"daos.engine_free_bytes:1024|g|#device:nvme_0,rank:0",
+		"daos.engine_free_bytes:2048|g|#device:nvme1,rank:1",
+		"daos.engine_ops_total:5|g",
+	}
+	if diff := cmp.Diff(expLines, strings.Split(string(buf[:n]), "\n")); diff != "" {
+		t.Fatalf("unexpected lines (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestPromExp_GraphiteSink(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	var gotLines []string
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scn := bufio.NewScanner(conn)
+		for scn.Scan() {
+			gotLines = append(gotLines, scn.Text())
+		}
+	}()
+
+	if err := NewGraphiteSink(lis.Addr().String(), "daos").Push(context.TODO(), expTestSamples()[:4]); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	expLines := []string{
+		"daos.engine_free_bytes;device=nvme_0;rank=0 1024 1620000000",
+		"daos.engine_free_bytes;device=nvme1;rank=1 2048 1620000000",
+		"daos.engine_ops_total 5 1620000000",
+		"daos.rpc_seconds_bucket;le=0.1 0 1620000000",
+	}
+	if diff := cmp.Diff(expLines, gotLines); diff != "" {
+		t.Fatalf("unexpected lines (-want, +got):\n%s\n", diff)
+	}
+}
+
+type mockPushSink struct {
+	sync.Mutex
+	failures []error
+	batches  [][]*Sample
+	attempts int
+}
+
+func (ms *mockPushSink) Push(_ context.Context, samples []*Sample) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.attempts++
+	if len(ms.failures) > 0 {
+		err := ms.failures[0]
+		ms.failures = ms.failures[1:]
+		if err != nil {
+			return err
+		}
+	}
+	ms.batches = append(ms.batches, samples)
+	return nil
+}
+
+func TestPromExp_PushExporter_PushOnce(t *testing.T) {
+	for name, tc := range map[string]struct {
+		batchSize   int
+		maxRetries  int
+		failures    []error
+		expErr      error
+		expBatches  []int
+		expAttempts int
+	}{
+		"single batch": {
+			expBatches:  []int{8},
+			expAttempts: 1,
+		},
+		"batched": {
+			batchSize:   3,
+			expBatches:  []int{3, 3, 2},
+			expAttempts: 3,
+		},
+		"retried": {
+			batchSize:   5,
+			maxRetries:  2,
+			failures:    []error{errors.New("timeout"), errors.New("timeout")},
+			expBatches:  []int{5, 3},
+			expAttempts: 4,
+		},
+		"retries exhausted": {
+			batchSize:   5,
+			maxRetries:  1,
+			failures:    []error{errors.New("timeout"), errors.New("timeout")},
+			expErr:      errors.New("dropped 5 of 8 samples: timeout"),
+			expBatches:  []int{3},
+			expAttempts: 3,
+		},
+		"permanent failure not retried": {
+			batchSize:   5,
+			maxRetries:  3,
+			failures:    []error{&permanentError{errors.New("rejected")}},
+			expErr:      errors.New("dropped 5 of 8 samples: rejected"),
+			expBatches:  []int{3},
+			expAttempts: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			sink := &mockPushSink{failures: tc.failures}
+			pe := NewPushExporter(log, "test", newTestRegistry(t), sink, PushExporterOpts{
+				BatchSize:  tc.batchSize,
+				MaxRetries: tc.maxRetries,
+				RetryDelay: time.Millisecond,
+			})
+
+			common.CmpErr(t, tc.expErr, pe.PushOnce(context.TODO()))
+
+			gotBatches := make([]int, 0, len(sink.batches))
+			for _, batch := range sink.batches {
+				gotBatches = append(gotBatches, len(batch))
+			}
+			if diff := cmp.Diff(tc.expBatches, gotBatches); diff != "" {
+				t.Fatalf("unexpected batches (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expAttempts, sink.attempts, "push attempts")
+		})
+	}
+}
@@ -0,0 +1,53 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package txtfmt
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// CSVFormatter is a structure that formats string output for a table with
+// labeled columns as comma-separated values.
+type CSVFormatter struct {
+	titles []string
+}
+
+// Format generates a CSV output string for the set of table rows provided. It
+// includes a header record with column titles, and fills only the requested
+// columns in order. Missing values are left empty.
+func (f *CSVFormatter) Format(table []TableRow) (string, error) {
+	if len(f.titles) == 0 {
+		return "", nil // nothing to format
+	}
+
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+
+	records := make([][]string, 0, len(table)+1)
+	records = append(records, f.titles)
+	for _, row := range table {
+		record := make([]string, len(f.titles))
+		for i, title := range f.titles {
+			record[i] = row[title]
+		}
+		records = append(records, record)
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// NewCSVFormatter creates and instantiates a new CSVFormatter.
+func NewCSVFormatter(columnTitles ...string) *CSVFormatter {
+	return &CSVFormatter{
+		titles: columnTitles,
+	}
+}
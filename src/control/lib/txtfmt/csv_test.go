@@ -0,0 +1,70 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package txtfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCSVFormatter_Format(t *testing.T) {
+	for name, tc := range map[string]struct {
+		titles []string
+		table  []TableRow
+		expStr string
+	}{
+		"no titles": {
+			table: []TableRow{
+				{"One": "1"},
+			},
+			expStr: "",
+		},
+		"header only": {
+			titles: []string{"One", "Two"},
+			expStr: `
+One,Two
+`,
+		},
+		"missing values left empty": {
+			titles: []string{"One", "Two", "Three"},
+			table: []TableRow{
+				{"One": "1", "Three": "3"},
+				{"Two": "2"},
+			},
+			expStr: `
+One,Two,Three
+1,,3
+,2,
+`,
+		},
+		"values requiring quotes": {
+			titles: []string{"Host", "Labels"},
+			table: []TableRow{
+				{"Host": "host1", "Labels": "rack=r1, row=a"},
+				{"Host": "host2", "Labels": `say "hi"`},
+			},
+			expStr: `
+Host,Labels
+host1,"rack=r1, row=a"
+host2,"say ""hi"""
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewCSVFormatter(tc.titles...).Format(tc.table)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(strings.TrimLeft(tc.expStr, "\n"), got); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
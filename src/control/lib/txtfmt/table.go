@@ -10,9 +10,22 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
 )
 
+// ansiEscapeRegexp matches the ANSI escape sequences that set the color and
+// style of terminal output.
+var ansiEscapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// DisplayWidth returns the number of terminal columns taken by the supplied
+// string, ignoring any ANSI escape sequences.
+func DisplayWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscapeRegexp.ReplaceAllString(s, ""))
+}
+
 // TableRow is a map of string values to be printed, keyed by column title.
 type TableRow map[string]string
 
@@ -21,6 +34,7 @@ type TableRow map[string]string
 type TableFormatter struct {
 	titles []string
 	writer *tabwriter.Writer
+	dest   io.Writer
 	out    bytes.Buffer
 }
 
@@ -34,6 +48,7 @@ func (t *TableFormatter) Init() {
 // buffer.
 func (t *TableFormatter) InitWriter(w io.Writer) {
 	t.writer = tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	t.dest = w
 }
 
 // SetColumnTitles sets the ordered column titles for the table.
@@ -68,6 +83,11 @@ func (t *TableFormatter) Format(table []TableRow) string {
 		return "" // nothing to format
 	}
 
+	if hasEscapes(table) {
+		t.formatEscaped(table)
+		return t.out.String()
+	}
+
 	t.formatHeader()
 
 	for _, row := range table {
@@ -85,6 +105,57 @@ func (t *TableFormatter) Format(table []TableRow) string {
 	return t.out.String()
 }
 
+func hasEscapes(table []TableRow) bool {
+	for _, row := range table {
+		for _, value := range row {
+			if ansiEscapeRegexp.MatchString(value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// formatEscaped generates the same output as the tabwriter for rows with
+// values containing escape sequences, which the tabwriter would include in
+// the width of their columns.
+func (t *TableFormatter) formatEscaped(table []TableRow) {
+	lines := make([][]string, 0, len(table)+2)
+	dashes := make([]string, len(t.titles))
+	for i, title := range t.titles {
+		dashes[i] = strings.Repeat("-", len(title))
+	}
+	lines = append(lines, t.titles, dashes)
+	for _, row := range table {
+		line := make([]string, len(t.titles))
+		for i, title := range t.titles {
+			value, ok := row[title]
+			if !ok {
+				value = "None"
+			}
+			line[i] = value
+		}
+		lines = append(lines, line)
+	}
+
+	widths := make([]int, len(t.titles))
+	for _, line := range lines {
+		for i, value := range line {
+			if w := DisplayWidth(value); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	for _, line := range lines {
+		for i, value := range line {
+			fmt.Fprintf(t.dest, "%s%s", value, strings.Repeat(" ", widths[i]-DisplayWidth(value)+1))
+		}
+		fmt.Fprint(t.dest, "\n")
+	}
+}
+
 // NewTableFormatter creates and instantiates a new TableFormatter.
 func NewTableFormatter(columnTitles ...string) *TableFormatter {
 	f := &TableFormatter{}
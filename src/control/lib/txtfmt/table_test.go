@@ -80,6 +80,25 @@ func TestTableFormatter_SetColumnTitles(t *testing.T) {
 	}
 }
 
+func TestTxtfmt_DisplayWidth(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in       string
+		expWidth int
+	}{
+		"empty":       {},
+		"plain":       {in: "Joined", expWidth: 6},
+		"colored":     {in: "\x1b[1;31mExcluded\x1b[0m", expWidth: 8},
+		"multi-byte":  {in: "\u2717 Excluded", expWidth: 10},
+		"mixed runes": {in: "\x1b[33m\u26a0 Stopped\x1b[0m", expWidth: 9},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := DisplayWidth(tc.in); got != tc.expWidth {
+				t.Fatalf("expected width %d, got %d", tc.expWidth, got)
+			}
+		})
+	}
+}
+
 func TestTableFormatter_Format(t *testing.T) {
 	for name, tt := range map[string]struct {
 		titles         []string
@@ -179,6 +198,20 @@ Hosts    SCM Total             NVMe Total
 wolf-118 5.79TB (2 namespaces) 1.46TB (2 controllers) 
 `,
 		},
+		"escape sequences excluded from width": {
+			titles: []string{"Rank", "State"},
+			table: []TableRow{
+				{"Rank": "[0-1]", "State": "\x1b[32mJoined\x1b[0m"},
+				{"Rank": "2", "State": "\x1b[31mExcluded\x1b[0m"},
+				{"Rank": "3"},
+			},
+			expectedResult: "" +
+				"Rank  State    \n" +
+				"----  -----    \n" +
+				"[0-1] \x1b[32mJoined\x1b[0m   \n" +
+				"2     \x1b[31mExcluded\x1b[0m \n" +
+				"3     None     \n",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			f := NewTableFormatter(tt.titles...)
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -130,6 +131,10 @@ func ExecReq(parent context.Context, log logging.Logger, binPath string, req *Re
 	defer killChild()
 
 	child := exec.CommandContext(ctx, binPath)
+	started := time.Now()
+	defer func() {
+		traceExec(binPath, req, child, started, err)
+	}()
 	child.Stderr = &cmdLogger{
 		logFn:  log.Error,
 		prefix: binPath,
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -176,3 +177,54 @@ func TestPbin_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestPbin_Exec_Trace(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req         *pbin.Request
+		binPath     string
+		expExitCode int
+		expErr      error
+	}{
+		"success": {
+			req: &pbin.Request{
+				Method:  "ping",
+				Payload: []byte(`{"reply":"pong","Token":"secret"}`),
+			},
+		},
+		"failure": {
+			req: &pbin.Request{
+				Method:  "garbage",
+				Payload: []byte(`{"reply":"garbage"}`),
+			},
+			expExitCode: -1,
+			expErr:      errors.New("decode response"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var traces []*pbin.CallTrace
+			pbin.SetCallTracer(func(ct *pbin.CallTrace) {
+				traces = append(traces, ct)
+			})
+			defer pbin.SetCallTracer(nil)
+
+			os.Setenv(childModeEnvVar, childModeReqRes)
+			_, err := pbin.ExecReq(context.Background(), log, os.Args[0], tc.req)
+			common.CmpErr(t, tc.expErr, err)
+
+			if len(traces) != 1 {
+				t.Fatalf("expected 1 trace, got %d", len(traces))
+			}
+			trace := traces[0]
+			common.AssertEqual(t, os.Args[0], trace.Binary, "binary")
+			common.AssertEqual(t, tc.req.Method, trace.Method, "method")
+			common.AssertEqual(t, tc.expExitCode, trace.ExitCode, "exit code")
+			common.CmpErr(t, tc.expErr, trace.Error)
+			if strings.Contains(trace.Payload, "secret") {
+				t.Fatalf("payload not sanitized: %s", trace.Payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+package pbin
+
+import (
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// maxTracePayload is the length at which payloads recorded in call
+	// traces are truncated.
+	maxTracePayload = 1024
+
+	redactedValue = "[redacted]"
+)
+
+// sensitiveKeyRe matches payload keys whose values are not to be recorded.
+var sensitiveKeyRe = regexp.MustCompile(`(?i)(passw|secret|token|cred|key)`)
+
+type (
+	// CallTrace describes a completed request to a privileged binary.
+	CallTrace struct {
+		Binary   string
+		Method   string
+		Payload  string // sanitized request payload
+		Duration time.Duration
+		ExitCode int // -1 if the binary did not exit
+		Error    error
+	}
+
+	// CallTracer is called with the trace of each completed request to a
+	// privileged binary.
+	CallTracer func(*CallTrace)
+)
+
+var (
+	tracerMu sync.RWMutex
+	tracer   CallTracer
+)
+
+// SetCallTracer sets the function to be called with the trace of each
+// completed request to a privileged binary, a nil tracer disables tracing.
+func SetCallTracer(ct CallTracer) {
+	tracerMu.Lock()
+	defer tracerMu.Unlock()
+
+	tracer = ct
+}
+
+// traceExec records the completed execution of a request by a privileged
+// binary if a tracer has been set.
+func traceExec(binPath string, req *Request, child *exec.Cmd, started time.Time, err error) {
+	tracerMu.RLock()
+	defer tracerMu.RUnlock()
+
+	if tracer == nil {
+		return
+	}
+
+	exitCode := -1
+	if child.ProcessState != nil {
+		exitCode = child.ProcessState.ExitCode()
+	}
+
+	tracer(&CallTrace{
+		Binary:   binPath,
+		Method:   req.Method,
+		Payload:  sanitizePayload(req.Payload),
+		Duration: time.Since(started),
+		ExitCode: exitCode,
+		Error:    err,
+	})
+}
+
+// sanitizePayload returns the payload with the values of any keys that may
+// hold credentials redacted, truncated to a length suitable for logging.
+func sanitizePayload(payload json.RawMessage) string {
+	var decoded interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return truncatePayload(string(payload))
+	}
+
+	sanitized, err := json.Marshal(redactValues(decoded))
+	if err != nil {
+		return truncatePayload(string(payload))
+	}
+
+	return truncatePayload(string(sanitized))
+}
+
+func redactValues(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if sensitiveKeyRe.MatchString(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValues(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValues(val)
+		}
+	}
+
+	return in
+}
+
+func truncatePayload(payload string) string {
+	if len(payload) <= maxTracePayload {
+		return payload
+	}
+
+	return payload[:maxTracePayload] + "...(truncated)"
+}
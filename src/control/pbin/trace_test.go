@@ -0,0 +1,42 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+package pbin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestPbin_sanitizePayload(t *testing.T) {
+	for name, tc := range map[string]struct {
+		payload string
+		exp     string
+	}{
+		"empty": {},
+		"nothing sensitive": {
+			payload: `{"PCIAllowList":"0000:81:00.0","NrHugePages":4096}`,
+			exp:     `{"NrHugePages":4096,"PCIAllowList":"0000:81:00.0"}`,
+		},
+		"nested credentials": {
+			payload: `{"Path":"/tmp/fw.bin","Auth":[{"Password":"hunter2","APIToken":"abc"}]}`,
+			exp:     `{"Auth":[{"APIToken":"[redacted]","Password":"[redacted]"}],"Path":"/tmp/fw.bin"}`,
+		},
+		"not json": {
+			payload: `pong`,
+			exp:     `pong`,
+		},
+		"truncated": {
+			payload: `"` + strings.Repeat("a", maxTracePayload) + `"`,
+			exp:     `"` + strings.Repeat("a", maxTracePayload-1) + "...(truncated)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.exp, sanitizePayload([]byte(tc.payload)), "unexpected payload")
+		})
+	}
+}
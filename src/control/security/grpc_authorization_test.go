@@ -50,43 +50,66 @@ func inList(c Component, compList []Component) bool {
 func TestSecurity_ComponentHasAccess(t *testing.T) {
 	allComponents := []Component{ComponentUndefined, ComponentAdmin, ComponentAgent, ComponentServer}
 	testCases := map[string][]Component{
-		"/ctl.CtlSvc/StoragePrepare":     {ComponentAdmin},
-		"/ctl.CtlSvc/StorageScan":        {ComponentAdmin},
-		"/ctl.CtlSvc/StorageFormat":      {ComponentAdmin},
-		"/ctl.CtlSvc/NetworkScan":        {ComponentAdmin},
-		"/ctl.CtlSvc/FirmwareQuery":      {ComponentAdmin},
-		"/ctl.CtlSvc/FirmwareUpdate":     {ComponentAdmin},
-		"/ctl.CtlSvc/SmdQuery":           {ComponentAdmin},
-		"/ctl.CtlSvc/PrepShutdownRanks":  {ComponentServer},
-		"/ctl.CtlSvc/StopRanks":          {ComponentServer},
-		"/ctl.CtlSvc/PingRanks":          {ComponentServer},
-		"/ctl.CtlSvc/ResetFormatRanks":   {ComponentServer},
-		"/ctl.CtlSvc/StartRanks":         {ComponentServer},
-		"/mgmt.MgmtSvc/Join":             {ComponentServer},
-		"/mgmt.MgmtSvc/ClusterEvent":     {ComponentServer},
-		"/mgmt.MgmtSvc/LeaderQuery":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/SystemQuery":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/SystemStop":       {ComponentAdmin},
-		"/mgmt.MgmtSvc/SystemErase":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/SystemStart":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolCreate":       {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolDestroy":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolResolveID":    {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolQuery":        {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolSetProp":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolGetACL":       {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolOverwriteACL": {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolUpdateACL":    {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolDeleteACL":    {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolExclude":      {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolDrain":        {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolReintegrate":  {ComponentAdmin},
-		"/mgmt.MgmtSvc/PoolEvict":        {ComponentAdmin, ComponentAgent},
-		"/mgmt.MgmtSvc/PoolExtend":       {ComponentAdmin},
-		"/mgmt.MgmtSvc/GetAttachInfo":    {ComponentAgent},
-		"/mgmt.MgmtSvc/ListPools":        {ComponentAdmin},
-		"/mgmt.MgmtSvc/ListContainers":   {ComponentAdmin},
-		"/mgmt.MgmtSvc/ContSetOwner":     {ComponentAdmin},
+		"/ctl.CtlSvc/StoragePrepare":         {ComponentAdmin},
+		"/ctl.CtlSvc/StorageScan":            {ComponentAdmin},
+		"/ctl.CtlSvc/StorageFormat":          {ComponentAdmin},
+		"/ctl.CtlSvc/StorageOwnershipQuery":  {ComponentAdmin},
+		"/ctl.CtlSvc/StorageVerifyQuery":     {ComponentAdmin},
+		"/ctl.CtlSvc/BdevCapabilitiesQuery":  {ComponentAdmin},
+		"/ctl.CtlSvc/NetworkScan":            {ComponentAdmin},
+		"/ctl.CtlSvc/FirmwareQuery":          {ComponentAdmin},
+		"/ctl.CtlSvc/FirmwareUpdate":         {ComponentAdmin},
+		"/ctl.CtlSvc/SmdQuery":               {ComponentAdmin},
+		"/ctl.CtlSvc/PrepShutdownRanks":      {ComponentServer},
+		"/ctl.CtlSvc/StopRanks":              {ComponentServer},
+		"/ctl.CtlSvc/PingRanks":              {ComponentServer},
+		"/ctl.CtlSvc/ResetFormatRanks":       {ComponentServer},
+		"/ctl.CtlSvc/StartRanks":             {ComponentServer},
+		"/ctl.CtlSvc/ActivateSpare":          {ComponentServer},
+		"/ctl.CtlSvc/VersionQuery":           {ComponentAdmin},
+		"/ctl.CtlSvc/EngineStats":            {ComponentAdmin},
+		"/ctl.CtlSvc/PoolQueryTargets":       {ComponentAdmin},
+		"/ctl.CtlSvc/NetworkSelfTest":        {ComponentAdmin},
+		"/ctl.CtlSvc/StorageSelfTest":        {ComponentAdmin},
+		"/ctl.CtlSvc/Heartbeat":              {ComponentServer},
+		"/mgmt.MgmtSvc/Join":                 {ComponentServer},
+		"/mgmt.MgmtSvc/ClusterEvent":         {ComponentServer, ComponentAgent},
+		"/mgmt.MgmtSvc/LeaderQuery":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemQuery":          {ComponentAdmin, ComponentServer},
+		"/mgmt.MgmtSvc/SystemStop":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemErase":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemMaintenance":    {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemExclude":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemReplicaQuery":   {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemLock":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemSetFaultDomain": {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemActivateSpare":  {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemHistory":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemHistoryRecord":  {ComponentServer},
+		"/mgmt.MgmtSvc/SystemPoolDefaults":   {ComponentAdmin},
+		"/mgmt.MgmtSvc/CheckStart":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/CheckQuery":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/CheckRepair":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/SystemStart":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolCreate":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolDestroy":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolRestore":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolResolveID":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolQuery":            {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolSetProp":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolGetACL":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolOverwriteACL":     {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolUpdateACL":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolDeleteACL":        {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolExclude":          {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolDrain":            {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolReintegrate":      {ComponentAdmin},
+		"/mgmt.MgmtSvc/PoolEvict":            {ComponentAdmin, ComponentAgent},
+		"/mgmt.MgmtSvc/PoolExtend":           {ComponentAdmin},
+		"/mgmt.MgmtSvc/GetAttachInfo":        {ComponentAgent},
+		"/mgmt.MgmtSvc/ListPools":            {ComponentAdmin},
+		"/mgmt.MgmtSvc/ListContainers":       {ComponentAdmin},
+		"/mgmt.MgmtSvc/ContSetOwner":         {ComponentAdmin},
 	}
 
 	var missing []string
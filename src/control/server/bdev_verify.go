@@ -0,0 +1,275 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+)
+
+const (
+	// bdevVerifyChunkSize is the amount of data read from a namespace by
+	// each verify request forwarded to the privileged helper.
+	bdevVerifyChunkSize = humanize.GiByte
+	// bdevVerifyMaxBadLBAs is the number of unreadable LBAs recorded per
+	// namespace, further unreadable LBAs are only counted.
+	bdevVerifyMaxBadLBAs = 1024
+
+	verifyStatePending = "pending"
+	verifyStateRunning = "running"
+	verifyStateDone    = "done"
+	verifyStateFailed  = "failed"
+)
+
+// nsVerifyProgress records the progress of the read verification of an NVMe
+// namespace.
+type nsVerifyProgress struct {
+	pciAddr      string
+	nsID         uint32
+	engineIdx    uint32
+	sectorSize   uint32
+	numLBAs      uint64
+	state        string
+	verifiedLBAs uint64
+	numBadLBAs   uint64
+	badLBAs      []uint64
+	err          error
+	started      time.Time
+	finished     time.Time
+}
+
+// bdevVerifier reads the NVMe devices of engine instances in the background
+// after they have been formatted, to confirm that the media is readable before
+// any pools are created on them. Reads of all devices share a bandwidth limit
+// which is enforced by pausing between chunks, the progress of each namespace
+// is recorded until the devices of the instance are formatted again.
+type bdevVerifier struct {
+	sync.RWMutex
+	log        logging.Logger
+	provider   *bdev.Provider
+	rate       uint64 // bytes per second, zero if verification is disabled
+	readMu     sync.Mutex
+	sleep      func(time.Duration)
+	namespaces []*nsVerifyProgress
+	running    map[uint32]bool
+}
+
+func newBdevVerifier(log logging.Logger, provider *bdev.Provider, rateMiB int) *bdevVerifier {
+	var rate uint64
+	if rateMiB > 0 {
+		rate = uint64(rateMiB) * humanize.MiByte
+	}
+
+	return &bdevVerifier{
+		log:      log,
+		provider: provider,
+		rate:     rate,
+		sleep:    time.Sleep,
+		running:  make(map[uint32]bool),
+	}
+}
+
+// enabled returns true if devices should be verified after format.
+func (bv *bdevVerifier) enabled() bool {
+	return bv.rate > 0
+}
+
+// isRunning returns true if the devices of the instance are being verified.
+func (bv *bdevVerifier) isRunning(engineIdx uint32) bool {
+	bv.RLock()
+	defer bv.RUnlock()
+
+	return bv.running[engineIdx]
+}
+
+// start discards any progress recorded for the instance and verifies the
+// namespaces of the given devices in the background, calling done once all
+// have been read whether or not they could be verified.
+func (bv *bdevVerifier) start(engineIdx uint32, devices []string, done func()) {
+	bv.Lock()
+	var kept []*nsVerifyProgress
+	for _, nsp := range bv.namespaces {
+		if nsp.engineIdx != engineIdx {
+			kept = append(kept, nsp)
+		}
+	}
+	bv.namespaces = kept
+	bv.running[engineIdx] = true
+	bv.Unlock()
+
+	go func() {
+		bv.run(engineIdx, devices)
+
+		bv.Lock()
+		delete(bv.running, engineIdx)
+		bv.Unlock()
+
+		done()
+	}()
+}
+
+func (bv *bdevVerifier) run(engineIdx uint32, devices []string) {
+	namespaces := make([]*nsVerifyProgress, 0, len(devices))
+
+	resp, err := bv.provider.Scan(bdev.ScanRequest{DeviceList: devices, NoCache: true})
+	if err != nil {
+		err = errors.Wrap(err, "unable to scan devices to be verified")
+		for _, dev := range devices {
+			namespaces = append(namespaces, &nsVerifyProgress{
+				pciAddr:   dev,
+				engineIdx: engineIdx,
+				state:     verifyStateFailed,
+				err:       err,
+			})
+		}
+		bv.add(namespaces...)
+		return
+	}
+
+	for _, ctrlr := range resp.Controllers {
+		for _, ns := range ctrlr.Namespaces {
+			namespaces = append(namespaces, &nsVerifyProgress{
+				pciAddr:    ctrlr.PciAddr,
+				nsID:       ns.ID,
+				engineIdx:  engineIdx,
+				sectorSize: ns.SectorSize(),
+				numLBAs:    ns.Size / uint64(ns.SectorSize()),
+				state:      verifyStatePending,
+			})
+		}
+	}
+	bv.add(namespaces...)
+
+	for _, nsp := range namespaces {
+		bv.verifyNamespace(nsp)
+	}
+}
+
+func (bv *bdevVerifier) add(namespaces ...*nsVerifyProgress) {
+	bv.Lock()
+	defer bv.Unlock()
+
+	bv.namespaces = append(bv.namespaces, namespaces...)
+}
+
+// verifyNamespace reads the namespace in chunks, pausing after each chunk for
+// long enough to keep the average bandwidth within the limit.
+func (bv *bdevVerifier) verifyNamespace(nsp *nsVerifyProgress) {
+	bv.Lock()
+	nsp.state = verifyStateRunning
+	nsp.started = time.Now()
+	bv.Unlock()
+
+	bv.log.Infof("verifying NVMe device %s namespace %d (%s)", nsp.pciAddr, nsp.nsID,
+		humanize.IBytes(nsp.numLBAs*uint64(nsp.sectorSize)))
+
+	chunkLBAs := uint64(bdevVerifyChunkSize / nsp.sectorSize)
+	for lba := uint64(0); lba < nsp.numLBAs; lba += chunkLBAs {
+		numLBAs := chunkLBAs
+		if nsp.numLBAs-lba < numLBAs {
+			numLBAs = nsp.numLBAs - lba
+		}
+
+		resp, err := bv.readChunk(bdev.VerifyRequest{
+			PciAddr:  nsp.pciAddr,
+			NsID:     nsp.nsID,
+			StartLBA: lba,
+			NumLBAs:  numLBAs,
+		}, numLBAs*uint64(nsp.sectorSize))
+
+		bv.Lock()
+		if err != nil {
+			nsp.state = verifyStateFailed
+			nsp.err = err
+			nsp.finished = time.Now()
+			bv.Unlock()
+			bv.log.Errorf("verification of NVMe device %s namespace %d failed: %s",
+				nsp.pciAddr, nsp.nsID, err)
+			return
+		}
+		nsp.verifiedLBAs += numLBAs
+		nsp.numBadLBAs += uint64(len(resp.BadLBAs))
+		for _, bad := range resp.BadLBAs {
+			if len(nsp.badLBAs) == bdevVerifyMaxBadLBAs {
+				break
+			}
+			nsp.badLBAs = append(nsp.badLBAs, bad)
+		}
+		bv.Unlock()
+	}
+
+	bv.Lock()
+	nsp.state = verifyStateDone
+	nsp.finished = time.Now()
+	bv.Unlock()
+
+	if nsp.numBadLBAs > 0 {
+		bv.log.Errorf("NVMe device %s namespace %d has %d unreadable LBAs",
+			nsp.pciAddr, nsp.nsID, nsp.numBadLBAs)
+		return
+	}
+	bv.log.Infof("verified NVMe device %s namespace %d", nsp.pciAddr, nsp.nsID)
+}
+
+// readChunk forwards a verify request, serialized with those of other
+// namespaces so that the bandwidth limit applies to the server as a whole.
+func (bv *bdevVerifier) readChunk(req bdev.VerifyRequest, size uint64) (*bdev.VerifyResponse, error) {
+	bv.readMu.Lock()
+	defer bv.readMu.Unlock()
+
+	started := time.Now()
+	resp, err := bv.provider.Verify(req)
+	if err != nil {
+		return nil, err
+	}
+
+	minDuration := time.Duration(float64(size) / float64(bv.rate) * float64(time.Second))
+	if elapsed := time.Since(started); elapsed < minDuration {
+		bv.sleep(minDuration - elapsed)
+	}
+
+	return resp, nil
+}
+
+// results returns the recorded progress of each namespace.
+func (bv *bdevVerifier) results() []*ctlpb.NvmeVerifyResult {
+	bv.RLock()
+	defer bv.RUnlock()
+
+	results := make([]*ctlpb.NvmeVerifyResult, 0, len(bv.namespaces))
+	for _, nsp := range bv.namespaces {
+		result := &ctlpb.NvmeVerifyResult{
+			PciAddr:       nsp.pciAddr,
+			NsId:          nsp.nsID,
+			Instanceidx:   nsp.engineIdx,
+			State:         nsp.state,
+			TotalBytes:    nsp.numLBAs * uint64(nsp.sectorSize),
+			VerifiedBytes: nsp.verifiedLBAs * uint64(nsp.sectorSize),
+			NumBadLbas:    nsp.numBadLBAs,
+			BadLbas:       append([]uint64{}, nsp.badLBAs...),
+		}
+		if nsp.err != nil {
+			result.Error = nsp.err.Error()
+		}
+		if !nsp.started.IsZero() {
+			result.Started = nsp.started.Unix()
+		}
+		if !nsp.finished.IsZero() {
+			result.Finished = nsp.finished.Unix()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+)
+
+func TestServer_bdevVerifier(t *testing.T) {
+	pciAddr := "0000:80:00.0"
+	mockCtrlr := func(nsSize uint64) *storage.NvmeController {
+		return &storage.NvmeController{
+			PciAddr: pciAddr,
+			Namespaces: []*storage.NvmeNamespace{
+				{ID: 1, Size: nsSize},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		rateMiB    int
+		bmbc       *bdev.MockBackendConfig
+		expSleeps  int
+		expResults []*ctlpb.NvmeVerifyResult
+	}{
+		"scan fails": {
+			rateMiB: 1024,
+			bmbc: &bdev.MockBackendConfig{
+				ScanErr: errors.New("scan failed"),
+			},
+			expResults: []*ctlpb.NvmeVerifyResult{
+				{
+					PciAddr: pciAddr,
+					State:   verifyStateFailed,
+					Error:   "unable to scan devices to be verified: scan failed",
+				},
+			},
+		},
+		"verify fails": {
+			rateMiB: 1024,
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes: &bdev.ScanResponse{
+					Controllers: storage.NvmeControllers{mockCtrlr(2 * humanize.GiByte)},
+				},
+				VerifyErr: errors.New("verify failed"),
+			},
+			expResults: []*ctlpb.NvmeVerifyResult{
+				{
+					PciAddr:    pciAddr,
+					NsId:       1,
+					State:      verifyStateFailed,
+					TotalBytes: 2 * humanize.GiByte,
+					Error:      "verify failed",
+				},
+			},
+		},
+		"verified in chunks": {
+			rateMiB: 1024,
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes: &bdev.ScanResponse{
+					Controllers: storage.NvmeControllers{mockCtrlr(3 * humanize.GiByte)},
+				},
+			},
+			expSleeps: 3,
+			expResults: []*ctlpb.NvmeVerifyResult{
+				{
+					PciAddr:       pciAddr,
+					NsId:          1,
+					State:         verifyStateDone,
+					TotalBytes:    3 * humanize.GiByte,
+					VerifiedBytes: 3 * humanize.GiByte,
+				},
+			},
+		},
+		"bad lbas": {
+			rateMiB: 1024,
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes: &bdev.ScanResponse{
+					Controllers: storage.NvmeControllers{mockCtrlr(2 * humanize.GiByte)},
+				},
+				VerifyRes: &bdev.VerifyResponse{BadLBAs: []uint64{5, 9}},
+			},
+			expSleeps: 2,
+			expResults: []*ctlpb.NvmeVerifyResult{
+				{
+					PciAddr:       pciAddr,
+					NsId:          1,
+					State:         verifyStateDone,
+					TotalBytes:    2 * humanize.GiByte,
+					VerifiedBytes: 2 * humanize.GiByte,
+					NumBadLbas:    4,
+					BadLbas:       []uint64{5, 9, 5, 9},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			bv := newBdevVerifier(log, bdev.NewMockProvider(log, tc.bmbc), tc.rateMiB)
+			var sleeps int
+			bv.sleep = func(d time.Duration) {
+				sleeps++
+			}
+
+			if !bv.enabled() {
+				t.Fatal("expected verifier to be enabled")
+			}
+
+			done := make(chan struct{})
+			bv.start(0, []string{pciAddr}, func() { close(done) })
+
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for verification to complete")
+			}
+
+			if bv.isRunning(0) {
+				t.Fatal("expected verification to have completed")
+			}
+			common.AssertEqual(t, tc.expSleeps, sleeps, "unexpected number of pauses")
+
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreUnexported(ctlpb.NvmeVerifyResult{}),
+				cmpopts.IgnoreFields(ctlpb.NvmeVerifyResult{}, "Started", "Finished"),
+				cmpopts.EquateEmpty(),
+			}
+			if diff := cmp.Diff(tc.expResults, bv.results(), cmpOpts...); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_bdevVerifier_disabled(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	bv := newBdevVerifier(log, bdev.NewMockProvider(log, nil), 0)
+	if bv.enabled() {
+		t.Fatal("expected verifier to be disabled")
+	}
+}
@@ -0,0 +1,322 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// serverReformatKeys are the top-level parameters that can only be changed by
+// reformatting the storage of all engines.
+var serverReformatKeys = map[string]bool{
+	"name":         true,
+	"fault_path":   true,
+	"fault_cb":     true,
+	"bdev_include": true,
+	"bdev_exclude": true,
+}
+
+// engineReformatKeys are the engine parameters that can only be changed by
+// reformatting the storage of the engine. All storage tier parameters other
+// than those in storageRestartKeys also require reformat.
+var engineReformatKeys = map[string]bool{
+	"rank":          true,
+	"targets":       true,
+	"nr_xs_helpers": true,
+	"first_core":    true,
+}
+
+// storageRestartKeys are storage tier parameters that only require restart.
+var storageRestartKeys = map[string]bool{
+	"bdev_allow_zoned": true,
+}
+
+// propagatedKeys are top-level parameters that are copied into each engine
+// config, changes to them are only reported at the top level.
+var propagatedKeys = map[string]bool{
+	"name":               true,
+	"socket_dir":         true,
+	"modules":            true,
+	"provider":           true,
+	"crt_ctx_share_addr": true,
+	"crt_timeout":        true,
+}
+
+// ConfigChange describes a difference in a single parameter between two
+// server configs. Engine is the index of the engine section the parameter is
+// in or -1 for a top-level parameter.
+type ConfigChange struct {
+	Engine   int
+	Key      string
+	Old      string
+	New      string
+	Reformat bool
+}
+
+func (cc *ConfigChange) String() string {
+	action := "restart"
+	if cc.Reformat {
+		action = "reformat"
+	}
+
+	switch {
+	case cc.Old == "":
+		return fmt.Sprintf("+ %s: %s (%s)", cc.Key, cc.New, action)
+	case cc.New == "":
+		return fmt.Sprintf("- %s: %s (%s)", cc.Key, cc.Old, action)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s (%s)", cc.Key, cc.Old, cc.New, action)
+	}
+}
+
+// ConfigDiff is the set of differences between two server configs.
+type ConfigDiff struct {
+	Changes []*ConfigChange
+}
+
+// RequiresReformat returns true if any change requires storage reformat.
+func (cd *ConfigDiff) RequiresReformat() bool {
+	for _, cc := range cd.Changes {
+		if cc.Reformat {
+			return true
+		}
+	}
+	return false
+}
+
+// ReformatEngines returns the indices of the engines whose storage needs to
+// be reformatted for the changes to be applied, all engines are returned if a
+// top-level change requires reformat.
+func (cd *ConfigDiff) ReformatEngines(nrEngines int) []int {
+	engines := make(map[int]bool)
+	for _, cc := range cd.Changes {
+		if !cc.Reformat {
+			continue
+		}
+		if cc.Engine < 0 {
+			for i := 0; i < nrEngines; i++ {
+				engines[i] = true
+			}
+			continue
+		}
+		engines[cc.Engine] = true
+	}
+
+	indices := make([]int, 0, len(engines))
+	for idx := range engines {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	return indices
+}
+
+// toYAMLMap converts a config into a generic YAML document so that parameters
+// can be compared independently of the struct layout.
+func toYAMLMap(in interface{}) (yamlMap, error) {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(yamlMap)
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// normalizeYAML returns a canonical string representation of a YAML value in
+// which sequences of scalars are sorted, so that reordered lists compare equal.
+func normalizeYAML(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case yamlMap:
+		keys := make([]string, 0, len(v))
+		byKey := make(map[string]interface{}, len(v))
+		for k, mv := range v {
+			key := fmt.Sprint(k)
+			keys = append(keys, key)
+			byKey[key] = mv
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+": "+normalizeYAML(byKey[k]))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		sortable := true
+		for _, item := range v {
+			switch item.(type) {
+			case yamlMap, []interface{}:
+				sortable = false
+			}
+			items = append(items, normalizeYAML(item))
+		}
+		if sortable {
+			sort.Strings(items)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// diffYAMLMaps compares the top-level keys of two documents and returns the
+// keys that differ along with their normalized old and new values.
+func diffYAMLMaps(a, b yamlMap, skip ...string) []*ConfigChange {
+	skipKeys := make(map[string]bool)
+	for _, k := range skip {
+		skipKeys[k] = true
+	}
+
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[fmt.Sprint(k)] = true
+	}
+	for k := range b {
+		keys[fmt.Sprint(k)] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		if !skipKeys[k] {
+			sorted = append(sorted, k)
+		}
+	}
+	sort.Strings(sorted)
+
+	var changes []*ConfigChange
+	for _, k := range sorted {
+		oldVal, newVal := normalizeYAML(a[k]), normalizeYAML(b[k])
+		if oldVal != newVal {
+			changes = append(changes, &ConfigChange{Key: k, Old: oldVal, New: newVal})
+		}
+	}
+
+	return changes
+}
+
+// diffStorageTiers compares the storage tiers of an engine tier by tier. All
+// changes other than to the parameters in storageRestartKeys, or to the number
+// of tiers, require reformat.
+func diffStorageTiers(a, b interface{}) []*ConfigChange {
+	tiersA, _ := a.([]interface{})
+	tiersB, _ := b.([]interface{})
+
+	nrTiers := len(tiersA)
+	if len(tiersB) > nrTiers {
+		nrTiers = len(tiersB)
+	}
+
+	var changes []*ConfigChange
+	for i := 0; i < nrTiers; i++ {
+		key := fmt.Sprintf("storage[%d]", i)
+		if i >= len(tiersA) || i >= len(tiersB) {
+			var oldVal, newVal string
+			if i < len(tiersA) {
+				oldVal = normalizeYAML(tiersA[i])
+			} else {
+				newVal = normalizeYAML(tiersB[i])
+			}
+			changes = append(changes, &ConfigChange{
+				Key: key, Old: oldVal, New: newVal, Reformat: true,
+			})
+			continue
+		}
+
+		tierA, _ := tiersA[i].(yamlMap)
+		tierB, _ := tiersB[i].(yamlMap)
+		for _, cc := range diffYAMLMaps(tierA, tierB) {
+			cc.Reformat = !storageRestartKeys[cc.Key]
+			cc.Key = key + "." + cc.Key
+			changes = append(changes, cc)
+		}
+	}
+
+	return changes
+}
+
+// Diff performs a semantic comparison of two server configs. Both configs are
+// compared as they would be used by the server, after defaults have been
+// applied and per-engine storage parameters have been converted into storage
+// tiers, so differences in formatting, key ordering, list ordering and in how
+// default values are expressed are ignored. Each change is classified by
+// whether it requires the storage of the engine to be reformatted or just the
+// server to be restarted.
+func Diff(a, b *Server) (*ConfigDiff, error) {
+	docA, err := toYAMLMap(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "old config")
+	}
+	docB, err := toYAMLMap(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "new config")
+	}
+
+	cd := new(ConfigDiff)
+	topChanges := make(map[string]*ConfigChange)
+	for _, cc := range diffYAMLMaps(docA, docB, "engines", "path") {
+		cc.Engine = -1
+		cc.Reformat = serverReformatKeys[cc.Key]
+		topChanges[cc.Key] = cc
+		cd.Changes = append(cd.Changes, cc)
+	}
+
+	nrEngines := len(a.Engines)
+	if len(b.Engines) > nrEngines {
+		nrEngines = len(b.Engines)
+	}
+	for idx := 0; idx < nrEngines; idx++ {
+		switch {
+		case idx >= len(a.Engines):
+			cd.Changes = append(cd.Changes, &ConfigChange{
+				Engine: idx, Key: "engine", New: "added", Reformat: true,
+			})
+			continue
+		case idx >= len(b.Engines):
+			cd.Changes = append(cd.Changes, &ConfigChange{
+				Engine: idx, Key: "engine", Old: "removed",
+			})
+			continue
+		}
+
+		engA, err := toYAMLMap(a.Engines[idx])
+		if err != nil {
+			return nil, errors.Wrapf(err, "old config engine %d", idx)
+		}
+		engB, err := toYAMLMap(b.Engines[idx])
+		if err != nil {
+			return nil, errors.Wrapf(err, "new config engine %d", idx)
+		}
+
+		changes := diffYAMLMaps(engA, engB, "storage")
+		changes = append(changes, diffStorageTiers(engA["storage"], engB["storage"])...)
+		for _, cc := range changes {
+			if top, found := topChanges[cc.Key]; found && propagatedKeys[cc.Key] &&
+				top.Old == cc.Old && top.New == cc.New {
+				continue
+			}
+			cc.Engine = idx
+			if !strings.HasPrefix(cc.Key, "storage") {
+				cc.Reformat = engineReformatKeys[cc.Key]
+			}
+			cd.Changes = append(cd.Changes, cc)
+		}
+	}
+
+	return cd, nil
+}
@@ -0,0 +1,141 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestServerConfig_Diff(t *testing.T) {
+	base := strings.Join([]string{
+		"name: daos_server",
+		"access_points: ['ap-1', 'ap-2']",
+		"provider: ofi+sockets",
+		"engines:",
+		"- targets: 8",
+		"  first_core: 0",
+		"  fabric_iface: eth0",
+		"  fabric_iface_port: 31416",
+		"  log_mask: ERR",
+		"  env_vars: [A=1, B=2]",
+		"  scm_mount: /mnt/daos0",
+		"  scm_class: dcpm",
+		"  scm_list: [/dev/pmem0]",
+		"  bdev_class: nvme",
+		"  bdev_list: ['0000:81:00.0', '0000:82:00.0']",
+	}, "\n")
+
+	for name, tc := range map[string]struct {
+		newCfg         string
+		expChanges     []string
+		expReformatIdx []int
+	}{
+		"identical": {
+			newCfg:         base,
+			expReformatIdx: []int{},
+		},
+		"reordered, defaults explicit and storage tiers": {
+			newCfg: strings.Join([]string{
+				"provider: ofi+sockets",
+				"access_points: ['ap-2', 'ap-1']",
+				"engines:",
+				"- storage:",
+				"  - scm_class: dcpm",
+				"    scm_mount: /mnt/daos0",
+				"    scm_list: [/dev/pmem0]",
+				"  - bdev_class: nvme",
+				"    bdev_list: ['0000:82:00.0', '0000:81:00.0']",
+				"  env_vars: [B=2, A=1]",
+				"  log_mask: ERR",
+				"  fabric_iface_port: 31416",
+				"  fabric_iface: eth0",
+				"  targets: 8",
+			}, "\n"),
+			expReformatIdx: []int{},
+		},
+		"restart only": {
+			newCfg: strings.Replace(strings.Replace(base,
+				"log_mask: ERR", "log_mask: DEBUG", 1),
+				"provider: ofi+sockets", "provider: ofi+tcp", 1) +
+				"\n  bdev_allow_zoned: true",
+			expChanges: []string{
+				"-1 ~ provider: ofi+sockets -> ofi+tcp (restart)",
+				"0 ~ log_mask: ERR -> DEBUG (restart)",
+				"0 + storage[1].bdev_allow_zoned: true (restart)",
+			},
+			expReformatIdx: []int{},
+		},
+		"engine reformat": {
+			newCfg: strings.Replace(strings.Replace(base,
+				"targets: 8", "targets: 16", 1),
+				"scm_list: [/dev/pmem0]", "scm_list: [/dev/pmem1]", 1),
+			expChanges: []string{
+				"0 ~ targets: 8 -> 16 (reformat)",
+				"0 ~ storage[0].scm_list: [/dev/pmem0] -> [/dev/pmem1] (reformat)",
+			},
+			expReformatIdx: []int{0},
+		},
+		"system name change": {
+			newCfg: strings.Replace(base, "name: daos_server", "name: other", 1),
+			expChanges: []string{
+				"-1 ~ name: daos_server -> other (reformat)",
+			},
+			expReformatIdx: []int{0},
+		},
+		"engine added": {
+			newCfg: base + "\n" + strings.Join([]string{
+				"- targets: 8",
+				"  first_core: 0",
+				"  fabric_iface: eth1",
+				"  fabric_iface_port: 32416",
+				"  scm_mount: /mnt/daos1",
+				"  scm_class: dcpm",
+				"  scm_list: [/dev/pmem1]",
+			}, "\n"),
+			expChanges: []string{
+				"1 + engine: added (reformat)",
+			},
+			expReformatIdx: []int{1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			oldCfg := DefaultServer()
+			if err := oldCfg.Parse([]byte(base)); err != nil {
+				t.Fatal(err)
+			}
+			newCfg := DefaultServer()
+			if err := newCfg.Parse([]byte(tc.newCfg)); err != nil {
+				t.Fatal(err)
+			}
+
+			cd, err := Diff(oldCfg, newCfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotChanges []string
+			for _, cc := range cd.Changes {
+				gotChanges = append(gotChanges, fmt.Sprintf("%d %s", cc.Engine, cc))
+			}
+			if diff := cmp.Diff(tc.expChanges, gotChanges); diff != "" {
+				t.Fatalf("unexpected changes (-want, +got):\n%s\n", diff)
+			}
+
+			common.AssertEqual(t, len(tc.expReformatIdx) > 0, cd.RequiresReformat(),
+				"unexpected reformat required")
+			if diff := cmp.Diff(tc.expReformatIdx, cd.ReformatEngines(len(newCfg.Engines))); diff != "" {
+				t.Fatalf("unexpected reformat engines (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	engineTemplateKey = "engine_template"
+	engineCountKey    = "engine_count"
+	enginesKey        = "engines"
+
+	// Variables expanded in the string values of an engine template.
+	templateVarIndex    = "${INDEX}"
+	templateVarHostname = "${HOSTNAME}"
+
+	// logFileVarIndex is the engine index variable of a log_file template,
+	// which is expanded when the configuration is validated.
+	logFileVarIndex = "{{.EngineIdx}}"
+
+	// templateFabricPortStride is the distance between the fabric ports of
+	// consecutive engines generated from a template.
+	templateFabricPortStride = 1000
+)
+
+var (
+	templateHostname = os.Hostname
+	templateNow      = time.Now
+)
+
+// resolveEngineTemplate resolves any "engine_template" in the document.
+//
+// With "engine_count" set, the engines list is generated from engine_count
+// copies of the template. Otherwise the template is merged beneath each entry
+// of the engines list. In both cases the template variables are expanded in
+// the string values of each engine.
+func resolveEngineTemplate(doc yamlMap) error {
+	tmpl, hasTmpl := doc[engineTemplateKey]
+	count, hasCount := doc[engineCountKey]
+	delete(doc, engineTemplateKey)
+	delete(doc, engineCountKey)
+
+	if !hasTmpl {
+		if hasCount {
+			return errors.Errorf("%s requires %s", engineCountKey, engineTemplateKey)
+		}
+		return nil
+	}
+	tmplMap, ok := tmpl.(yamlMap)
+	if !ok {
+		return errors.Errorf("%s must be a mapping", engineTemplateKey)
+	}
+
+	hostname, err := templateHostname()
+	if err != nil {
+		return errors.Wrap(err, "resolving engine template")
+	}
+
+	if !hasCount {
+		return applyEngineTemplate(doc, tmplMap, hostname)
+	}
+
+	nr, ok := count.(int)
+	if !ok || nr <= 0 {
+		return errors.Errorf("%s must be a positive integer", engineCountKey)
+	}
+	if _, found := doc[enginesKey]; found {
+		return errors.Errorf("%s cannot be used with %s", engineCountKey, enginesKey)
+	}
+
+	engines := make([]interface{}, nr)
+	for i := range engines {
+		engine := expandTemplateVars(copyYAML(tmplMap), i, hostname).(yamlMap)
+		computeEngineParams(engine, tmplMap, i)
+		engines[i] = engine
+	}
+	doc[enginesKey] = engines
+
+	return nil
+}
+
+func applyEngineTemplate(doc yamlMap, tmpl yamlMap, hostname string) error {
+	engines, ok := doc[enginesKey].([]interface{})
+	if !ok {
+		return nil
+	}
+	for i, engine := range engines {
+		engineMap, ok := engine.(yamlMap)
+		if !ok {
+			return errors.Errorf("%s entry %d must be a mapping", enginesKey, i)
+		}
+		engines[i] = expandTemplateVars(mergeYAML(copyYAML(tmpl), engineMap), i, hostname)
+	}
+
+	return nil
+}
+
+// computeEngineParams sets the parameters of the engine with the given index
+// that must differ between engines generated from the same template, unless
+// the template already varies them with the index variable.
+func computeEngineParams(engine, tmpl yamlMap, idx int) {
+	if logFile, ok := tmpl["log_file"].(string); ok && !strings.Contains(logFile, templateVarIndex) &&
+		!strings.Contains(logFile, logFileVarIndex) {
+		ext := filepath.Ext(logFile)
+		engine["log_file"] = fmt.Sprintf("%s.%d%s", strings.TrimSuffix(logFile, ext), idx, ext)
+	}
+
+	if port, ok := tmpl["fabric_iface_port"].(int); ok {
+		engine["fabric_iface_port"] = port + idx*templateFabricPortStride
+	}
+
+	// Engines are spread across NUMA nodes unless the template pins them all
+	// to the same node, in which case they are given consecutive cores.
+	numaNode, found := tmpl["pinned_numa_node"]
+	if !found {
+		engine["pinned_numa_node"] = idx
+		return
+	}
+	if _, shared := numaNode.(int); !shared {
+		return
+	}
+	if firstCore, ok := tmpl["first_core"].(int); ok {
+		targets, _ := tmpl["targets"].(int)
+		helpers, _ := tmpl["nr_xs_helpers"].(int)
+		// one core for each target and helper xstream plus the system xstream
+		engine["first_core"] = firstCore + idx*(targets+helpers+1)
+	}
+}
+
+// expandTemplateVars returns a copy of the value with the template variables
+// replaced in all strings, strings consisting only of a number after expansion
+// are converted into integers.
+func expandTemplateVars(val interface{}, idx int, hostname string) interface{} {
+	switch v := val.(type) {
+	case string:
+		if !strings.Contains(v, templateVarIndex) && !strings.Contains(v, templateVarHostname) {
+			return v
+		}
+		expanded := strings.NewReplacer(
+			templateVarIndex, strconv.Itoa(idx),
+			templateVarHostname, hostname,
+		).Replace(v)
+		if n, err := strconv.Atoi(expanded); err == nil {
+			return n
+		}
+		return expanded
+	case yamlMap:
+		expanded := make(yamlMap, len(v))
+		for k, mv := range v {
+			expanded[k] = expandTemplateVars(mv, idx, hostname)
+		}
+		return expanded
+	case []interface{}:
+		expanded := make([]interface{}, len(v))
+		for i, sv := range v {
+			expanded[i] = expandTemplateVars(sv, idx, hostname)
+		}
+		return expanded
+	default:
+		return val
+	}
+}
@@ -0,0 +1,174 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServerConfig_EngineTemplate(t *testing.T) {
+	numaNode := func(n uint) *uint { return &n }
+
+	for name, tc := range map[string]struct {
+		cfg        []string
+		expErr     error
+		expEngines []*engine.Config
+	}{
+		"count with computed parameters": {
+			cfg: []string{
+				"engine_count: 2",
+				"engine_template:",
+				"  targets: 8",
+				"  nr_xs_helpers: 1",
+				"  first_core: 0",
+				"  fabric_iface: ib${INDEX}",
+				"  fabric_iface_port: 31416",
+				"  log_file: /tmp/daos_engine.log",
+				"  scm_mount: /mnt/daos${INDEX}",
+			},
+			expEngines: []*engine.Config{
+				engine.NewConfig().
+					WithTargetCount(8).
+					WithHelperStreamCount(1).
+					WithFabricInterface("ib0").
+					WithFabricInterfacePort(31416).
+					WithLogFile("/tmp/daos_engine.0.log").
+					WithScmMountPoint("/mnt/daos0").
+					WithPinnedNumaNode(numaNode(0)),
+				engine.NewConfig().
+					WithTargetCount(8).
+					WithHelperStreamCount(1).
+					WithFabricInterface("ib1").
+					WithFabricInterfacePort(32416).
+					WithLogFile("/tmp/daos_engine.1.log").
+					WithScmMountPoint("/mnt/daos1").
+					WithPinnedNumaNode(numaNode(1)),
+			},
+		},
+		"count with shared numa node": {
+			cfg: []string{
+				"engine_count: 2",
+				"engine_template:",
+				"  targets: 8",
+				"  nr_xs_helpers: 1",
+				"  first_core: 1",
+				"  pinned_numa_node: 0",
+				"  log_file: /tmp/${HOSTNAME}_engine_${INDEX}.log",
+			},
+			expEngines: []*engine.Config{
+				engine.NewConfig().
+					WithTargetCount(8).
+					WithHelperStreamCount(1).
+					WithServiceThreadCore(1).
+					WithLogFile("/tmp/foo_engine_0.log").
+					WithPinnedNumaNode(numaNode(0)),
+				engine.NewConfig().
+					WithTargetCount(8).
+					WithHelperStreamCount(1).
+					WithServiceThreadCore(11).
+					WithLogFile("/tmp/foo_engine_1.log").
+					WithPinnedNumaNode(numaNode(0)),
+			},
+		},
+		"numa node from variable": {
+			cfg: []string{
+				"engine_count: 2",
+				"engine_template:",
+				"  nr_xs_helpers: 2",
+				"  first_core: 1",
+				"  pinned_numa_node: ${INDEX}",
+			},
+			expEngines: []*engine.Config{
+				engine.NewConfig().
+					WithServiceThreadCore(1).
+					WithPinnedNumaNode(numaNode(0)),
+				engine.NewConfig().
+					WithServiceThreadCore(1).
+					WithPinnedNumaNode(numaNode(1)),
+			},
+		},
+		"variables expanded in merged engines": {
+			cfg: []string{
+				"engine_template:",
+				"  nr_xs_helpers: 2",
+				"  log_file: /tmp/daos_engine.${INDEX}.log",
+				"engines:",
+				"- fabric_iface: ib0",
+				"- fabric_iface: ib1",
+			},
+			expEngines: []*engine.Config{
+				engine.NewConfig().
+					WithFabricInterface("ib0").
+					WithLogFile("/tmp/daos_engine.0.log"),
+				engine.NewConfig().
+					WithFabricInterface("ib1").
+					WithLogFile("/tmp/daos_engine.1.log"),
+			},
+		},
+		"count without template": {
+			cfg:    []string{"engine_count: 2"},
+			expErr: errors.New("engine_count requires engine_template"),
+		},
+		"count with engines": {
+			cfg: []string{
+				"engine_count: 2",
+				"engine_template:",
+				"  targets: 8",
+				"engines:",
+				"- targets: 4",
+			},
+			expErr: errors.New("engine_count cannot be used with engines"),
+		},
+		"bad count": {
+			cfg: []string{
+				"engine_count: two",
+				"engine_template:",
+				"  targets: 8",
+			},
+			expErr: errors.New("engine_count must be a positive integer"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			templateHostname = func() (string, error) { return "foo", nil }
+			defer func() {
+				templateHostname = os.Hostname
+			}()
+
+			cfgPath := filepath.Join(testDir, "daos_server.yml")
+			data := []byte(strings.Join(tc.cfg, "\n"))
+			if err := ioutil.WriteFile(cfgPath, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := mockConfigFromFile(t, cfgPath)
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			// top-level settings are propagated to engines after the
+			// template is resolved
+			expCfg := DefaultServer().WithEngines(tc.expEngines...)
+			if diff := cmp.Diff(expCfg.Engines, cfg.Engines); diff != "" {
+				t.Fatalf("unexpected engines (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -85,6 +85,26 @@ var (
 		"fault domain callback executed but did not generate output",
 		"specify a valid fault domain callback script ('fault_cb' parameter) and restart the control server",
 	)
+	FaultConfigDiscoveryPluginNotFound = serverConfigFault(
+		code.ServerConfigDiscoveryPluginNotFound,
+		"hardware discovery plugin not found",
+		"specify a valid hardware discovery plugin ('discovery_plugin' parameter) and restart the control server",
+	)
+	FaultConfigDiscoveryPluginBadPerms = serverConfigFault(
+		code.ServerConfigDiscoveryPluginBadPerms,
+		"hardware discovery plugin cannot be executed",
+		"ensure that permissions for the DAOS server user are properly set on the hardware discovery plugin ('discovery_plugin' parameter) and restart the control server",
+	)
+	FaultConfigValidationHookNotFound = serverConfigFault(
+		code.ServerConfigValidationHookNotFound,
+		"validation hook not found",
+		"specify a valid validation hook executable ('validation_hook' parameter) and restart the control server",
+	)
+	FaultConfigValidationHookBadPerms = serverConfigFault(
+		code.ServerConfigValidationHookBadPerms,
+		"validation hook cannot be executed",
+		"ensure that permissions for the DAOS server user are properly set on the validation hook ('validation_hook' parameter) and restart the control server",
+	)
 	FaultConfigTooManyLayersInFaultDomain = serverConfigFault(
 		code.ServerConfigFaultDomainTooManyLayers,
 		"only a single fault domain layer below the root is supported",
@@ -135,6 +155,17 @@ func FaultConfigInvalidNetDevClass(curIdx int, primaryDevClass, thisDevClass uin
 	)
 }
 
+// FaultConfigVirtualFunctionLinkDown creates a Fault for the scenario where an
+// I/O Engine is configured to use an SR-IOV virtual function whose link is down.
+func FaultConfigVirtualFunctionLinkDown(curIdx int, iface, physFn string) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigVirtualFunctionLinkDown,
+		fmt.Sprintf("I/O Engine %d specifies fabric_iface %q, a virtual function of %q whose link is down",
+			curIdx, iface, physFn),
+		"enable the virtual function link or specify a different fabric_iface and restart",
+	)
+}
+
 func dupeValue(code code.Code, name string, curIdx, seenIdx int) *fault.Fault {
 	return serverConfigFault(code,
 		fmt.Sprintf("the %s value in I/O Engine %d is a duplicate of server %d", name, curIdx, seenIdx),
@@ -152,6 +183,81 @@ func FaultConfigFaultCallbackFailed(err error) *fault.Fault {
 	)
 }
 
+// FaultConfigDiscoveryPluginFailed creates a Fault for the scenario where the
+// hardware discovery plugin failed with some error.
+func FaultConfigDiscoveryPluginFailed(err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigDiscoveryPluginFailed,
+		fmt.Sprintf("hardware discovery plugin failed during execution: %s", err.Error()),
+		"ensure the hardware discovery plugin ('discovery_plugin' parameter) emits a valid JSON response and restart the control server",
+	)
+}
+
+// FaultConfigDiscoveryPluginInsecure creates a fault for the scenario where the
+// hardware discovery plugin path doesn't meet security requirements.
+func FaultConfigDiscoveryPluginInsecure(requiredDir string) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigDiscoveryPluginInsecure,
+		"hardware discovery plugin does not meet security requirements",
+		fmt.Sprintf("ensure that the 'discovery_plugin' path is under the parent directory %q, "+
+			"not a symbolic link, does not have the setuid bit set, and does not have "+
+			"write permissions for non-owners", requiredDir),
+	)
+}
+
+// FaultConfigValidationHookInsecure creates a fault for the scenario where the
+// validation hook path doesn't meet security requirements.
+func FaultConfigValidationHookInsecure(requiredDir string) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigValidationHookInsecure,
+		"validation hook does not meet security requirements",
+		fmt.Sprintf("ensure that the 'validation_hook' path is under the parent directory %q, "+
+			"not a symbolic link, does not have the setuid bit set, and does not have "+
+			"write permissions for non-owners", requiredDir),
+	)
+}
+
+// FaultConfigBadControlIface creates a Fault for the scenario where the
+// interface specified for the gRPC listener has no usable address.
+func FaultConfigBadControlIface(iface string, err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadControlIface,
+		fmt.Sprintf("unable to bind to control interface %q: %s", iface, err),
+		"specify an interface with an IPv4 address ('control_iface' parameter) and restart the control server",
+	)
+}
+
+// FaultConfigBadTelemetryIface creates a Fault for the scenario where the
+// interface specified for the telemetry exporter has no usable address.
+func FaultConfigBadTelemetryIface(iface string, err error) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadTelemetryIface,
+		fmt.Sprintf("unable to bind to telemetry interface %q: %s", iface, err),
+		"specify an interface with an IPv4 address ('telemetry_iface' parameter) and restart the control server",
+	)
+}
+
+// FaultConfigNoLogFile creates a Fault for the scenario where an I/O Engine
+// has no log file specified in the configuration.
+func FaultConfigNoLogFile(curIdx int) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigNoLogFile,
+		fmt.Sprintf("no log file specified for I/O Engine %d", curIdx),
+		"specify a log file path ('log_file' parameter) for each I/O Engine and restart the control server",
+	)
+}
+
+// FaultConfigBadNvmeDriver creates a Fault for the scenario where an
+// unsupported NVMe driver is specified in the configuration.
+func FaultConfigBadNvmeDriver(driver string) *fault.Fault {
+	return serverConfigFault(
+		code.ServerConfigBadNvmeDriver,
+		fmt.Sprintf("unsupported NVMe driver %q in configuration", driver),
+		fmt.Sprintf("specify one of %q, %q or %q as the NVMe driver ('nvme_driver' parameter) and restart the control server",
+			NvmeDriverAuto, NvmeDriverVFIO, NvmeDriverUIO),
+	)
+}
+
 // FaultConfigFaultCallbackInsecure creates a fault for the scenario where the
 // fault domain callback path doesn't meet security requirements.
 func FaultConfigFaultCallbackInsecure(requiredDir string) *fault.Fault {
@@ -0,0 +1,70 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultFormationQuorum  = 100
+	defaultFormationTimeout = 5 * time.Minute
+)
+
+// FormationBarrier describes an optional cluster-wide barrier applied when the
+// system is formed. After joining the system, engines wait until a quorum of
+// the expected ranks has also joined before completing setup and opening
+// pools, so that ranks on slow-booting hosts are not treated as failed.
+type FormationBarrier struct {
+	// number of ranks expected in the formed system
+	ExpectedRanks int `yaml:"expected_ranks"`
+	// percentage of expected ranks that must have joined, default 100
+	Quorum int `yaml:"quorum,omitempty"`
+	// maximum time to wait for the quorum before continuing setup
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Validate returns an error if the FormationBarrier contains invalid
+// parameters.
+func (fb *FormationBarrier) Validate() error {
+	if fb == nil {
+		return nil
+	}
+
+	switch {
+	case fb.ExpectedRanks <= 0:
+		return errors.New("formation_barrier: expected_ranks must be greater than zero")
+	case fb.Quorum < 0 || fb.Quorum > 100:
+		return errors.New("formation_barrier: quorum must be a percentage between 1 and 100")
+	case fb.Timeout < 0:
+		return errors.New("formation_barrier: timeout must not be negative")
+	}
+
+	return nil
+}
+
+// RequiredRanks returns the number of joined ranks needed to satisfy the
+// barrier quorum, rounded up.
+func (fb *FormationBarrier) RequiredRanks() int {
+	quorum := fb.Quorum
+	if quorum == 0 {
+		quorum = defaultFormationQuorum
+	}
+
+	return (fb.ExpectedRanks*quorum + 99) / 100
+}
+
+// WaitTimeout returns the maximum time to wait for the barrier quorum.
+func (fb *FormationBarrier) WaitTimeout() time.Duration {
+	if fb.Timeout == 0 {
+		return defaultFormationTimeout
+	}
+
+	return fb.Timeout
+}
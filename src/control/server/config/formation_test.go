@@ -0,0 +1,52 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_FormationBarrier(t *testing.T) {
+	for name, tc := range map[string]struct {
+		barrier     *FormationBarrier
+		expRequired int
+		expTimeout  time.Duration
+	}{
+		"defaults": {
+			barrier:     &FormationBarrier{ExpectedRanks: 16},
+			expRequired: 16,
+			expTimeout:  defaultFormationTimeout,
+		},
+		"quorum rounded up": {
+			barrier: &FormationBarrier{
+				ExpectedRanks: 10,
+				Quorum:        75,
+				Timeout:       time.Minute,
+			},
+			expRequired: 8,
+			expTimeout:  time.Minute,
+		},
+		"minimal quorum": {
+			barrier: &FormationBarrier{
+				ExpectedRanks: 3,
+				Quorum:        1,
+			},
+			expRequired: 1,
+			expTimeout:  defaultFormationTimeout,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.barrier.RequiredRanks(); got != tc.expRequired {
+				t.Fatalf("expected %d required ranks, got %d", tc.expRequired, got)
+			}
+			if got := tc.barrier.WaitTimeout(); got != tc.expTimeout {
+				t.Fatalf("expected timeout %s, got %s", tc.expTimeout, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Supported values for the grpc compression parameter.
+const (
+	GrpcCompressionNone = "none"
+	GrpcCompressionGzip = "gzip"
+)
+
+// GrpcConfig describes tuning parameters for the gRPC transport used by the
+// control plane. It is shared by the server and control client
+// configurations, unset values leave the gRPC defaults in place.
+type GrpcConfig struct {
+	// compressor applied to outgoing requests, responses are compressed
+	// with whichever compressor the request used
+	Compression string `yaml:"compression,omitempty"`
+	// maximum sizes in bytes of received and sent messages
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size,omitempty"`
+	MaxSendMsgSize int `yaml:"max_send_msg_size,omitempty"`
+	// interval without activity after which a keepalive ping is sent and
+	// the time to wait for the ping to be acknowledged
+	KeepaliveTime    time.Duration `yaml:"keepalive_time,omitempty"`
+	KeepaliveTimeout time.Duration `yaml:"keepalive_timeout,omitempty"`
+}
+
+// Validate returns an error if the GrpcConfig contains invalid parameters.
+func (gc *GrpcConfig) Validate() error {
+	if gc == nil {
+		return nil
+	}
+
+	switch gc.Compression {
+	case "", GrpcCompressionNone, GrpcCompressionGzip:
+	default:
+		return errors.Errorf("grpc: unsupported compression %q", gc.Compression)
+	}
+
+	switch {
+	case gc.MaxRecvMsgSize < 0:
+		return errors.New("grpc: max_recv_msg_size must not be negative")
+	case gc.MaxSendMsgSize < 0:
+		return errors.New("grpc: max_send_msg_size must not be negative")
+	case gc.KeepaliveTime < 0:
+		return errors.New("grpc: keepalive_time must not be negative")
+	case gc.KeepaliveTimeout < 0:
+		return errors.New("grpc: keepalive_timeout must not be negative")
+	case gc.KeepaliveTimeout > 0 && gc.KeepaliveTime == 0:
+		return errors.New("grpc: keepalive_timeout requires keepalive_time")
+	}
+
+	return nil
+}
+
+// DialOptions returns the gRPC client dial options for the GrpcConfig.
+func (gc *GrpcConfig) DialOptions() []grpc.DialOption {
+	if gc == nil {
+		return nil
+	}
+
+	var callOpts []grpc.CallOption
+	if gc.Compression == GrpcCompressionGzip {
+		callOpts = append(callOpts, grpc.UseCompressor(gzip.Name))
+	}
+	if gc.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(gc.MaxRecvMsgSize))
+	}
+	if gc.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(gc.MaxSendMsgSize))
+	}
+
+	var opts []grpc.DialOption
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	if gc.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    gc.KeepaliveTime,
+			Timeout: gc.KeepaliveTimeout,
+		}))
+	}
+
+	return opts
+}
+
+// ServerOptions returns the gRPC server options for the GrpcConfig.
+func (gc *GrpcConfig) ServerOptions() []grpc.ServerOption {
+	if gc == nil {
+		return nil
+	}
+
+	var opts []grpc.ServerOption
+	if gc.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(gc.MaxRecvMsgSize))
+	}
+	if gc.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(gc.MaxSendMsgSize))
+	}
+	if gc.KeepaliveTime > 0 {
+		opts = append(opts,
+			grpc.KeepaliveParams(keepalive.ServerParameters{
+				Time:    gc.KeepaliveTime,
+				Timeout: gc.KeepaliveTimeout,
+			}),
+			// allow clients to ping as often as the server does
+			grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             gc.KeepaliveTime,
+				PermitWithoutStream: true,
+			}),
+		)
+	}
+
+	return opts
+}
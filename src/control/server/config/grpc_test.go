@@ -0,0 +1,62 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestConfig_GrpcConfig_Options(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg        *GrpcConfig
+		expDialNr  int
+		expSrvOpNr int
+	}{
+		"nil": {},
+		"empty": {
+			cfg: &GrpcConfig{},
+		},
+		"compression only": {
+			cfg:       &GrpcConfig{Compression: GrpcCompressionGzip},
+			expDialNr: 1,
+		},
+		"compression disabled": {
+			cfg: &GrpcConfig{Compression: GrpcCompressionNone},
+		},
+		"message sizes": {
+			cfg:        &GrpcConfig{MaxRecvMsgSize: 1 << 20, MaxSendMsgSize: 1 << 20},
+			expDialNr:  1,
+			expSrvOpNr: 2,
+		},
+		"keepalive": {
+			cfg:        &GrpcConfig{KeepaliveTime: time.Minute},
+			expDialNr:  1,
+			expSrvOpNr: 2,
+		},
+		"all": {
+			cfg: &GrpcConfig{
+				Compression:      GrpcCompressionGzip,
+				MaxRecvMsgSize:   1 << 20,
+				MaxSendMsgSize:   1 << 20,
+				KeepaliveTime:    time.Minute,
+				KeepaliveTimeout: time.Second,
+			},
+			expDialNr:  2,
+			expSrvOpNr: 4,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expDialNr, len(tc.cfg.DialOptions()),
+				"unexpected number of dial options")
+			common.AssertEqual(t, tc.expSrvOpNr, len(tc.cfg.ServerOptions()),
+				"unexpected number of server options")
+		})
+	}
+}
@@ -0,0 +1,151 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const includeKey = "include"
+
+type yamlMap = map[interface{}]interface{}
+
+// resolveIncludes returns the YAML document with include directives and any
+// engine template resolved.
+//
+// Files listed under "include" are merged in order beneath the including
+// document and may themselves include other files. Mappings are merged key
+// by key with values from later files replacing those from earlier ones and
+// the including document taking precedence over all included files, all other
+// values including sequences are replaced entirely. Any engine template in
+// the result is then resolved.
+//
+// Relative paths are resolved against the directory of cfgPath or, if it is
+// empty, the current working directory.
+func resolveIncludes(data []byte, cfgPath string) ([]byte, error) {
+	var dir string
+	var seen []string
+	if cfgPath != "" {
+		dir = filepath.Dir(cfgPath)
+		seen = append(seen, cfgPath)
+	}
+
+	doc, err := loadFragment(data, dir, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveEngineTemplate(doc); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// hasIncludes returns true if the YAML document uses an include directive or
+// an engine template.
+func hasIncludes(data []byte) bool {
+	var directives struct {
+		Include        interface{} `yaml:"include"`
+		EngineTemplate interface{} `yaml:"engine_template"`
+		EngineCount    interface{} `yaml:"engine_count"`
+	}
+	if err := yaml.Unmarshal(data, &directives); err != nil {
+		return false
+	}
+
+	return directives.Include != nil || directives.EngineTemplate != nil ||
+		directives.EngineCount != nil
+}
+
+// loadFragment parses a YAML document and merges it on top of the documents
+// it includes, seen holds the files currently being included in order to
+// detect cycles.
+func loadFragment(data []byte, dir string, seen []string) (yamlMap, error) {
+	var doc yamlMap
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	incVal, found := doc[includeKey]
+	if !found {
+		return doc, nil
+	}
+	delete(doc, includeKey)
+
+	var includes []string
+	switch iv := incVal.(type) {
+	case string:
+		includes = []string{iv}
+	case []interface{}:
+		for _, inc := range iv {
+			s, ok := inc.(string)
+			if !ok {
+				return nil, errors.Errorf("%s entries must be file paths", includeKey)
+			}
+			includes = append(includes, s)
+		}
+	default:
+		return nil, errors.Errorf("%s must be a file path or a list of file paths", includeKey)
+	}
+
+	base := make(yamlMap)
+	for _, inc := range includes {
+		incPath := inc
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(dir, incPath)
+		}
+		for _, s := range seen {
+			if s == incPath {
+				return nil, errors.Errorf("%s of %q creates a cycle", includeKey, inc)
+			}
+		}
+
+		incData, err := ioutil.ReadFile(incPath)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "reading included file")
+		}
+		incDoc, err := loadFragment(incData, filepath.Dir(incPath), append(seen, incPath))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "included file %s", incPath)
+		}
+		base = mergeYAML(base, incDoc)
+	}
+
+	return mergeYAML(base, doc), nil
+}
+
+// mergeYAML merges src into dst, returning dst.
+func mergeYAML(dst, src yamlMap) yamlMap {
+	for k, sv := range src {
+		srcMap, srcIsMap := sv.(yamlMap)
+		dstMap, dstIsMap := dst[k].(yamlMap)
+		if srcIsMap && dstIsMap {
+			dst[k] = mergeYAML(dstMap, srcMap)
+			continue
+		}
+		dst[k] = sv
+	}
+
+	return dst
+}
+
+func copyYAML(src yamlMap) yamlMap {
+	dst := make(yamlMap, len(src))
+	for k, v := range src {
+		if m, ok := v.(yamlMap); ok {
+			v = copyYAML(m)
+		}
+		dst[k] = v
+	}
+
+	return dst
+}
@@ -0,0 +1,180 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestServerConfig_Includes(t *testing.T) {
+	base := strings.Join([]string{
+		"name: site",
+		"port: 10001",
+		"telemetry_port: 9191",
+		"transport_config:",
+		"  allow_insecure: true",
+		"  ca_cert: /etc/daos/certs/daosCA.crt",
+		"access_points: ['ap-1', 'ap-2', 'ap-3']",
+		"engine_template:",
+		"  targets: 8",
+		"  nr_xs_helpers: 2",
+		"  log_mask: INFO",
+	}, "\n")
+	override := strings.Join([]string{
+		"telemetry_port: 9192",
+		"transport_config:",
+		"  allow_insecure: false",
+		"access_points: ['ap-1']",
+	}, "\n")
+	engines := strings.Join([]string{
+		"engines:",
+		"- fabric_iface: ib0",
+		"  log_mask: DEBUG",
+		"- fabric_iface: ib1",
+	}, "\n")
+
+	for name, tc := range map[string]struct {
+		files  map[string]string
+		expErr error
+		check  func(t *testing.T, cfg *Server)
+	}{
+		"no includes": {
+			files: map[string]string{
+				"daos_server.yml": "name: host\n" + engines,
+			},
+			check: func(t *testing.T, cfg *Server) {
+				common.AssertEqual(t, "host", cfg.SystemName, "")
+				common.AssertEqual(t, 2, len(cfg.Engines), "")
+			},
+		},
+		"single include": {
+			files: map[string]string{
+				"base.yml":        base,
+				"daos_server.yml": "include: base.yml\n" + engines,
+			},
+			check: func(t *testing.T, cfg *Server) {
+				common.AssertEqual(t, "site", cfg.SystemName, "")
+				common.AssertEqual(t, 9191, cfg.TelemetryPort, "")
+				common.AssertEqual(t, true, cfg.TransportConfig.AllowInsecure, "")
+				common.AssertEqual(t, 3, len(cfg.AccessPoints), "")
+				common.AssertEqual(t, 8, cfg.Engines[0].TargetCount, "")
+				common.AssertEqual(t, 8, cfg.Engines[1].TargetCount, "")
+				common.AssertEqual(t, 2, cfg.Engines[1].HelperStreamCount, "")
+				common.AssertEqual(t, "DEBUG", cfg.Engines[0].LogMask,
+					"engine value should take precedence over template")
+				common.AssertEqual(t, "INFO", cfg.Engines[1].LogMask, "")
+				common.AssertEqual(t, "ib1", cfg.Engines[1].Fabric.Interface, "")
+				// top-level settings are still propagated to engines
+				common.AssertEqual(t, "site", cfg.Engines[0].SystemName, "")
+			},
+		},
+		"later includes and main file take precedence": {
+			files: map[string]string{
+				"base.yml":        base,
+				"override.yml":    override,
+				"daos_server.yml": "include: [base.yml, override.yml]\nport: 10002\n" + engines,
+			},
+			check: func(t *testing.T, cfg *Server) {
+				common.AssertEqual(t, 10002, cfg.ControlPort, "")
+				common.AssertEqual(t, 9192, cfg.TelemetryPort, "")
+				// mappings are merged, sequences are replaced
+				common.AssertEqual(t, false, cfg.TransportConfig.AllowInsecure, "")
+				common.AssertEqual(t, "/etc/daos/certs/daosCA.crt",
+					cfg.TransportConfig.CARootPath, "")
+				common.AssertEqual(t, []string{"ap-1"}, cfg.AccessPoints, "")
+			},
+		},
+		"nested include in subdirectory": {
+			files: map[string]string{
+				"common/base.yml": base,
+				"common/site.yml": "include: base.yml\nname: nested",
+				"daos_server.yml": "include: common/site.yml\n" + engines,
+			},
+			check: func(t *testing.T, cfg *Server) {
+				common.AssertEqual(t, "nested", cfg.SystemName, "")
+				common.AssertEqual(t, 9191, cfg.TelemetryPort, "")
+			},
+		},
+		"template without engines": {
+			files: map[string]string{
+				"base.yml":        base,
+				"daos_server.yml": "include: base.yml",
+			},
+			check: func(t *testing.T, cfg *Server) {
+				common.AssertEqual(t, 0, len(cfg.Engines), "")
+			},
+		},
+		"missing include": {
+			files: map[string]string{
+				"daos_server.yml": "include: base.yml",
+			},
+			expErr: errors.New("reading included file"),
+		},
+		"include cycle": {
+			files: map[string]string{
+				"base.yml":        "include: daos_server.yml",
+				"daos_server.yml": "include: base.yml",
+			},
+			expErr: errors.New("creates a cycle"),
+		},
+		"self include": {
+			files: map[string]string{
+				"daos_server.yml": "include: daos_server.yml",
+			},
+			expErr: errors.New("creates a cycle"),
+		},
+		"bad include value": {
+			files: map[string]string{
+				"daos_server.yml": "include: {base: base.yml}",
+			},
+			expErr: errors.New("must be a file path"),
+		},
+		"bad template": {
+			files: map[string]string{
+				"daos_server.yml": "engine_template: [targets]\n" + engines,
+			},
+			expErr: errors.New("engine_template must be a mapping"),
+		},
+		"unknown parameter in fragment": {
+			files: map[string]string{
+				"base.yml":        "not_a_parameter: 1",
+				"daos_server.yml": "include: base.yml",
+			},
+			expErr: errors.New("field not_a_parameter not found"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			for name, content := range tc.files {
+				path := filepath.Join(testDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := mockConfigFromFile(t, filepath.Join(testDir, "daos_server.yml"))
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+			tc.check(t, cfg)
+		})
+	}
+}
@@ -8,35 +8,55 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
 )
 
 const (
 	defaultRuntimeDir   = "/var/run/daos_server"
+	defaultLedgerFile   = "/var/lib/daos/device_ledger.yaml"
+	defaultPrepareFile  = "/var/lib/daos/prepare_state.yaml"
 	defaultConfigPath   = "../etc/daos_server.yml"
 	configOut           = ".daos_server.active.yml"
 	relConfExamplesPath = "../utils/config/examples/"
 )
 
+// DefaultClockSkewThreshold is the clock offset from an MS replica above which
+// a server is reported as skewed if not set in the config.
+const DefaultClockSkewThreshold = time.Second
+
+// Supported values for the nvme_driver config parameter.
+const (
+	NvmeDriverAuto = "auto"
+	NvmeDriverVFIO = "vfio-pci"
+	NvmeDriverUIO  = "uio_pci_generic"
+)
+
 type networkProviderValidation func(context.Context, string, string) error
 type networkNUMAValidation func(context.Context, string, uint) error
 type networkDeviceClass func(string) (uint32, error)
+type networkVirtualFunction func(string) (*netdetect.VirtualFunction, error)
 
 // ClientNetworkCfg elements are used by the libdaos clients to help initialize CaRT.
 // These settings bring coherence between the client and server network configuration.
@@ -47,29 +67,102 @@ type ClientNetworkCfg struct {
 	NetDevClass     uint32
 }
 
+// FaultPolicy describes the thresholds used by the management service to
+// automatically mark NVMe SSDs as FAULTY or exclude ranks from the system in
+// response to health events.
+type FaultPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	// number of I/O errors on a device within Window before it is marked FAULTY
+	DeviceErrorThreshold int `yaml:"device_error_threshold"`
+	// number of failures of a rank within Window before it is excluded
+	RankFailureThreshold int           `yaml:"rank_failure_threshold"`
+	Window               time.Duration `yaml:"window"`
+	// time after startup or a reversal during which no action is taken
+	GracePeriod       time.Duration `yaml:"grace_period"`
+	MaxActionsPerHour int           `yaml:"max_actions_per_hour"`
+}
+
+// DefaultFaultPolicy returns a disabled FaultPolicy populated with defaults.
+func DefaultFaultPolicy() FaultPolicy {
+	return FaultPolicy{
+		DeviceErrorThreshold: 10,
+		RankFailureThreshold: 3,
+		Window:               10 * time.Minute,
+		GracePeriod:          30 * time.Minute,
+		MaxActionsPerHour:    2,
+	}
+}
+
+// Validate returns an error if the FaultPolicy is enabled with invalid
+// parameters.
+func (fp *FaultPolicy) Validate() error {
+	if !fp.Enabled {
+		return nil
+	}
+
+	switch {
+	case fp.DeviceErrorThreshold <= 0:
+		return errors.New("fault_policy: device_error_threshold must be greater than zero")
+	case fp.RankFailureThreshold <= 0:
+		return errors.New("fault_policy: rank_failure_threshold must be greater than zero")
+	case fp.Window <= 0:
+		return errors.New("fault_policy: window must be greater than zero")
+	case fp.GracePeriod < 0:
+		return errors.New("fault_policy: grace_period must not be negative")
+	case fp.MaxActionsPerHour <= 0:
+		return errors.New("fault_policy: max_actions_per_hour must be greater than zero")
+	}
+
+	return nil
+}
+
 // Server describes configuration options for DAOS control plane.
 // See utils/config/daos_server.yml for parameter descriptions.
 type Server struct {
 	// control-specific
 	ControlPort     int                       `yaml:"port"`
+	ControlIface    string                    `yaml:"control_iface,omitempty"`
 	TransportConfig *security.TransportConfig `yaml:"transport_config"`
+	Grpc            *GrpcConfig               `yaml:"grpc,omitempty"`
+	// optional barrier delaying engine setup until the system has formed
+	FormationBarrier *FormationBarrier `yaml:"formation_barrier,omitempty"`
 	// support both "engines:" and "servers:" for backward compatibility
-	Servers             []*engine.Config `yaml:"servers"`
-	Engines             []*engine.Config `yaml:"engines"`
-	BdevInclude         []string         `yaml:"bdev_include,omitempty"`
-	BdevExclude         []string         `yaml:"bdev_exclude,omitempty"`
-	DisableVFIO         bool             `yaml:"disable_vfio"`
-	DisableVMD          bool             `yaml:"disable_vmd"`
-	NrHugepages         int              `yaml:"nr_hugepages"`
-	SetHugepages        bool             `yaml:"set_hugepages"`
-	ControlLogMask      ControlLogLevel  `yaml:"control_log_mask"`
-	ControlLogFile      string           `yaml:"control_log_file"`
-	ControlLogJSON      bool             `yaml:"control_log_json,omitempty"`
-	HelperLogFile       string           `yaml:"helper_log_file"`
-	FWHelperLogFile     string           `yaml:"firmware_helper_log_file"`
-	RecreateSuperblocks bool             `yaml:"recreate_superblocks"`
-	FaultPath           string           `yaml:"fault_path"`
-	TelemetryPort       int              `yaml:"telemetry_port"`
+	Servers             []*engine.Config       `yaml:"servers"`
+	Engines             []*engine.Config       `yaml:"engines"`
+	BdevInclude         []string               `yaml:"bdev_include,omitempty"`
+	BdevExclude         []string               `yaml:"bdev_exclude,omitempty"`
+	DisableVFIO         bool                   `yaml:"disable_vfio"`
+	NvmeDriver          string                 `yaml:"nvme_driver,omitempty"`
+	BdevVerifyRate      int                    `yaml:"bdev_verify_rate,omitempty"`
+	DisableVMD          bool                   `yaml:"disable_vmd"`
+	Containerized       bool                   `yaml:"containerized,omitempty"`
+	NrHugepages         int                    `yaml:"nr_hugepages"`
+	SetHugepages        bool                   `yaml:"set_hugepages"`
+	ControlLogMask      ControlLogLevel        `yaml:"control_log_mask"`
+	ControlLogFile      string                 `yaml:"control_log_file"`
+	ControlLogJSON      bool                   `yaml:"control_log_json,omitempty"`
+	HelperLogFile       string                 `yaml:"helper_log_file"`
+	FWHelperLogFile     string                 `yaml:"firmware_helper_log_file"`
+	HelperAuditLogFile  string                 `yaml:"helper_audit_log_file,omitempty"`
+	DeviceLedgerFile    string                 `yaml:"device_ledger_file,omitempty"`
+	PrepareStateFile    string                 `yaml:"prepare_state_file,omitempty"`
+	RecreateSuperblocks bool                   `yaml:"recreate_superblocks"`
+	NoPathFixup         bool                   `yaml:"no_path_fixup,omitempty"`
+	FaultPath           string                 `yaml:"fault_path"`
+	DiscoveryPlugin     string                 `yaml:"discovery_plugin,omitempty"`
+	InventoryWebhook    string                 `yaml:"inventory_webhook,omitempty"`
+	ValidationHook      string                 `yaml:"validation_hook,omitempty"`
+	TelemetryPort       int                    `yaml:"telemetry_port"`
+	TelemetryIface      string                 `yaml:"telemetry_iface,omitempty"`
+	TelemetryPush       []*TelemetryPushConfig `yaml:"telemetry_push,omitempty"`
+	HealthPort          int                    `yaml:"health_port,omitempty"`
+	SlowRPCThreshold    time.Duration          `yaml:"slow_rpc_threshold,omitempty"`
+	ClockSkewThreshold  time.Duration          `yaml:"clock_skew_threshold,omitempty"`
+	EventLogFormat      events.EventFormat     `yaml:"event_log_format,omitempty"`
+	FaultPolicy         FaultPolicy            `yaml:"fault_policy"`
+
+	// persistent storage for control plane data, otherwise held on scm
+	ControlMetadata storage.ControlMetadata `yaml:"control_metadata,omitempty"`
 
 	// duplicated in engine.Config
 	SystemName string              `yaml:"name"`
@@ -93,6 +186,9 @@ type Server struct {
 
 	// pointer to a function that retrieves the I/O Engine network device class
 	GetDeviceClassFn networkDeviceClass `yaml:"-"`
+
+	// pointer to a function that retrieves SR-IOV virtual function details
+	getVirtualFunctionFn networkVirtualFunction
 }
 
 // WithRecreateSuperblocks indicates that a missing superblock should not be treated as
@@ -120,6 +216,13 @@ func (cfg *Server) WithGetNetworkDeviceClass(fn networkDeviceClass) *Server {
 	return cfg
 }
 
+// WithGetNetworkVirtualFunction sets the function that retrieves details of
+// SR-IOV virtual function network devices
+func (cfg *Server) WithGetNetworkVirtualFunction(fn networkVirtualFunction) *Server {
+	cfg.getVirtualFunctionFn = fn
+	return cfg
+}
+
 // WithSystemName sets the system name.
 func (cfg *Server) WithSystemName(name string) *Server {
 	cfg.SystemName = name
@@ -246,6 +349,31 @@ func (cfg *Server) WithFaultCb(cb string) *Server {
 	return cfg
 }
 
+// WithDiscoveryPlugin sets the path to the hardware discovery plugin.
+func (cfg *Server) WithDiscoveryPlugin(plugin string) *Server {
+	cfg.DiscoveryPlugin = plugin
+	return cfg
+}
+
+// WithInventoryWebhook sets the URL that hardware inventory is exported to.
+func (cfg *Server) WithInventoryWebhook(url string) *Server {
+	cfg.InventoryWebhook = url
+	return cfg
+}
+
+// WithValidationHook sets the executable path or URL of the hook consulted
+// before destructive operations.
+func (cfg *Server) WithValidationHook(hook string) *Server {
+	cfg.ValidationHook = hook
+	return cfg
+}
+
+// WithControlMetadata sets the location of persistent control plane metadata.
+func (cfg *Server) WithControlMetadata(cm storage.ControlMetadata) *Server {
+	cfg.ControlMetadata = cm
+	return cfg
+}
+
 // WithBdevExclude sets the block device exclude list.
 func (cfg *Server) WithBdevExclude(bList ...string) *Server {
 	cfg.BdevExclude = bList
@@ -266,6 +394,21 @@ func (cfg *Server) WithDisableVFIO(disabled bool) *Server {
 	return cfg
 }
 
+// WithNvmeDriver sets the userspace driver that NVMe devices should be bound
+// to, by default the driver is selected automatically based on IOMMU
+// availability and running user privileges.
+func (cfg *Server) WithNvmeDriver(driver string) *Server {
+	cfg.NvmeDriver = driver
+	return cfg
+}
+
+// WithBdevVerifyRate sets the bandwidth limit in MiB/s of the read
+// verification of NVMe devices after format, zero disables verification.
+func (cfg *Server) WithBdevVerifyRate(rate int) *Server {
+	cfg.BdevVerifyRate = rate
+	return cfg
+}
+
 // WithDisableVMD indicates that vmd devices should not be used even if they
 // exist.
 func (cfg *Server) WithDisableVMD(disabled bool) *Server {
@@ -315,28 +458,128 @@ func (cfg *Server) WithFirmwareHelperLogFile(filePath string) *Server {
 	return cfg
 }
 
+// WithHelperAuditLogFile sets the path to the privileged helper audit logfile.
+func (cfg *Server) WithHelperAuditLogFile(filePath string) *Server {
+	cfg.HelperAuditLogFile = filePath
+	return cfg
+}
+
+// WithDeviceLedgerFile sets the path to the device ownership ledger.
+func (cfg *Server) WithDeviceLedgerFile(filePath string) *Server {
+	cfg.DeviceLedgerFile = filePath
+	return cfg
+}
+
+// WithPrepareStateFile sets the path to the record of the last storage
+// prepare or reset.
+func (cfg *Server) WithPrepareStateFile(filePath string) *Server {
+	cfg.PrepareStateFile = filePath
+	return cfg
+}
+
+// WithControlIface sets the network interface for the gRPC listener.
+func (cfg *Server) WithControlIface(iface string) *Server {
+	cfg.ControlIface = iface
+	return cfg
+}
+
+// WithTelemetryIface sets the network interface for the telemetry exporter.
+func (cfg *Server) WithTelemetryIface(iface string) *Server {
+	cfg.TelemetryIface = iface
+	return cfg
+}
+
 // WithTelemetryPort sets the port for the telemetry exporter.
 func (cfg *Server) WithTelemetryPort(port int) *Server {
 	cfg.TelemetryPort = port
 	return cfg
 }
 
+// WithGrpcConfig sets the gRPC transport tuning parameters.
+func (cfg *Server) WithGrpcConfig(gc *GrpcConfig) *Server {
+	cfg.Grpc = gc
+	return cfg
+}
+
+// WithFormationBarrier sets the barrier applied to engine setup while the
+// system is formed.
+func (cfg *Server) WithFormationBarrier(fb *FormationBarrier) *Server {
+	cfg.FormationBarrier = fb
+	return cfg
+}
+
+// WithContainerized sets whether the server is running in a container.
+func (cfg *Server) WithContainerized(containerized bool) *Server {
+	cfg.Containerized = containerized
+	return cfg
+}
+
+// WithNoPathFixup sets whether problems found with the ownership and
+// permissions of server paths are only reported rather than repaired.
+func (cfg *Server) WithNoPathFixup(noFixup bool) *Server {
+	cfg.NoPathFixup = noFixup
+	return cfg
+}
+
+// WithTelemetryPush sets the exporters that push metrics to remote collectors.
+func (cfg *Server) WithTelemetryPush(exporters ...*TelemetryPushConfig) *Server {
+	cfg.TelemetryPush = exporters
+	return cfg
+}
+
+// WithHealthPort sets the port for the HTTP health endpoints.
+func (cfg *Server) WithHealthPort(port int) *Server {
+	cfg.HealthPort = port
+	return cfg
+}
+
+// WithSlowRPCThreshold sets the duration above which handled gRPC requests
+// are logged.
+func (cfg *Server) WithSlowRPCThreshold(threshold time.Duration) *Server {
+	cfg.SlowRPCThreshold = threshold
+	return cfg
+}
+
+// WithClockSkewThreshold sets the clock offset from an MS replica above which
+// a server is reported as skewed.
+func (cfg *Server) WithClockSkewThreshold(threshold time.Duration) *Server {
+	cfg.ClockSkewThreshold = threshold
+	return cfg
+}
+
+// WithEventLogFormat sets the format in which RAS events are written to syslog.
+func (cfg *Server) WithEventLogFormat(format events.EventFormat) *Server {
+	cfg.EventLogFormat = format
+	return cfg
+}
+
+// WithFaultPolicy sets the policy for automatic device and rank exclusion.
+func (cfg *Server) WithFaultPolicy(fp FaultPolicy) *Server {
+	cfg.FaultPolicy = fp
+	return cfg
+}
+
 // DefaultServer creates a new instance of configuration struct
 // populated with defaults.
 func DefaultServer() *Server {
 	return &Server{
-		SystemName:         build.DefaultSystemName,
-		SocketDir:          defaultRuntimeDir,
-		AccessPoints:       []string{fmt.Sprintf("localhost:%d", build.DefaultControlPort)},
-		ControlPort:        build.DefaultControlPort,
-		TransportConfig:    security.DefaultServerTransportConfig(),
-		Hyperthreads:       false,
-		Path:               defaultConfigPath,
-		ControlLogMask:     ControlLogLevel(logging.LogLevelInfo),
-		validateProviderFn: netdetect.ValidateProviderStub,
-		validateNUMAFn:     netdetect.ValidateNUMAStub,
-		GetDeviceClassFn:   netdetect.GetDeviceClass,
-		DisableVMD:         true, // support currently unstable
+		SystemName:           build.DefaultSystemName,
+		SocketDir:            defaultRuntimeDir,
+		AccessPoints:         []string{fmt.Sprintf("localhost:%d", build.DefaultControlPort)},
+		ControlPort:          build.DefaultControlPort,
+		TransportConfig:      security.DefaultServerTransportConfig(),
+		Hyperthreads:         false,
+		Path:                 defaultConfigPath,
+		ControlLogMask:       ControlLogLevel(logging.LogLevelInfo),
+		DeviceLedgerFile:     defaultLedgerFile,
+		PrepareStateFile:     defaultPrepareFile,
+		validateProviderFn:   netdetect.ValidateProviderStub,
+		validateNUMAFn:       netdetect.ValidateNUMAStub,
+		GetDeviceClassFn:     netdetect.GetDeviceClass,
+		getVirtualFunctionFn: netdetect.GetVirtualFunction,
+		DisableVMD:           true, // support currently unstable
+		FaultPolicy:          DefaultFaultPolicy(),
+		ClockSkewThreshold:   DefaultClockSkewThreshold,
 	}
 }
 
@@ -351,7 +594,22 @@ func (cfg *Server) Load() error {
 		return errors.WithMessage(err, "reading file")
 	}
 
-	if err = yaml.UnmarshalStrict(bytes, cfg); err != nil {
+	return cfg.Parse(bytes)
+}
+
+// Parse populates the configuration from the supplied YAML document. Any
+// include directive is resolved relative to the directory of the config path,
+// if set.
+func (cfg *Server) Parse(bytes []byte) error {
+	if hasIncludes(bytes) {
+		resolved, err := resolveIncludes(bytes, cfg.Path)
+		if err != nil {
+			return errors.WithMessage(err, "resolving includes")
+		}
+		bytes = resolved
+	}
+
+	if err := yaml.UnmarshalStrict(bytes, cfg); err != nil {
 		return errors.WithMessage(err, "parse failed; config contains invalid "+
 			"parameters and may be out of date, see server config examples")
 	}
@@ -375,6 +633,35 @@ func (cfg *Server) SaveToFile(filename string) error {
 	return ioutil.WriteFile(filename, bytes, 0644)
 }
 
+// Hash returns a digest of the configuration which can be compared between
+// servers to detect configuration drift. The path the configuration was
+// loaded from is not included.
+func (cfg *Server) Hash() (string, error) {
+	cpy := *cfg
+	cpy.Path = ""
+
+	bytes, err := yaml.Marshal(&cpy)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bytes)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashFile loads the configuration file at the given path with defaults
+// applied and returns its hash, so that files which differ only in formatting
+// or comments have the same hash.
+func HashFile(path string) (string, error) {
+	cfg := DefaultServer()
+	cfg.Path = path
+	if err := cfg.Load(); err != nil {
+		return "", err
+	}
+
+	return cfg.Hash()
+}
+
 // SetPath sets the default path to the configuration file.
 func (cfg *Server) SetPath(inPath string) error {
 	newPath, err := common.ResolvePath(inPath, cfg.Path)
@@ -480,6 +767,82 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 		return FaultConfigBadControlPort
 	case cfg.TelemetryPort < 0:
 		return FaultConfigBadTelemetryPort
+	case cfg.TelemetryIface != "" && cfg.TelemetryPort == 0:
+		return errors.Errorf("telemetry_iface %q set without telemetry_port", cfg.TelemetryIface)
+	}
+
+	if err := cfg.FaultPolicy.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Grpc.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.FormationBarrier.Validate(); err != nil {
+		return err
+	}
+
+	for _, tpc := range cfg.TelemetryPush {
+		if err := tpc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if cfg.SlowRPCThreshold < 0 {
+		return errors.Errorf("invalid slow_rpc_threshold %s: must not be negative",
+			cfg.SlowRPCThreshold)
+	}
+
+	if cfg.BdevVerifyRate < 0 {
+		return errors.Errorf("invalid bdev_verify_rate %d: must not be negative",
+			cfg.BdevVerifyRate)
+	}
+
+	if cfg.ClockSkewThreshold <= 0 {
+		return errors.Errorf("invalid clock_skew_threshold %s: must be positive",
+			cfg.ClockSkewThreshold)
+	}
+
+	if _, err := events.ParseEventFormat(string(cfg.EventLogFormat)); err != nil {
+		return errors.Wrap(err, "invalid event_log_format")
+	}
+
+	switch {
+	case cfg.HealthPort < 0:
+		return errors.Errorf("invalid health_port %d: must not be negative", cfg.HealthPort)
+	case cfg.HealthPort != 0 &&
+		(cfg.HealthPort == cfg.ControlPort || cfg.HealthPort == cfg.TelemetryPort):
+		return errors.Errorf("invalid health_port %d: conflicts with control or telemetry port",
+			cfg.HealthPort)
+	}
+
+	if cfg.InventoryWebhook != "" {
+		u, err := url.Parse(cfg.InventoryWebhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("invalid inventory_webhook %q: must be an http or https URL",
+				cfg.InventoryWebhook)
+		}
+	}
+
+	if cfg.ValidationHook != "" {
+		u, err := url.Parse(cfg.ValidationHook)
+		isURL := err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+		if !isURL && !filepath.IsAbs(cfg.ValidationHook) {
+			return errors.Errorf("invalid validation_hook %q: must be an http or https URL or an absolute path",
+				cfg.ValidationHook)
+		}
+	}
+
+	switch cfg.NvmeDriver {
+	case "", NvmeDriverAuto, NvmeDriverUIO:
+	case NvmeDriverVFIO:
+		if cfg.DisableVFIO {
+			return errors.Errorf("disable_vfio: true conflicts with nvme_driver: %s",
+				cfg.NvmeDriver)
+		}
+	default:
+		return FaultConfigBadNvmeDriver(cfg.NvmeDriver)
 	}
 
 	// Update access point addresses with control port if port is not
@@ -508,11 +871,44 @@ func (cfg *Server) Validate(log logging.Logger) (err error) {
 		log.Info("\n*******\nNOTICE: Support for multiple access points is an alpha feature and is not well-tested!\n*******\n\n")
 	}
 
+	if err := cfg.ControlMetadata.Validate(); err != nil {
+		return err
+	}
+
+	hostname, err := templateHostname()
+	if err != nil {
+		return errors.Wrap(err, "resolving log_file template")
+	}
+	now := templateNow()
+
+	var spares int
 	for i, engine := range cfg.Engines {
+		if engine.Spare {
+			if engine.Rank != nil {
+				return errors.Errorf("I/O Engine %d failed config validation: "+
+					"rank may not be set on a spare engine", i)
+			}
+			spares++
+		}
 		engine.Fabric.Update(cfg.Fabric)
+		if cfg.ControlIface != "" && engine.Fabric.Interface == cfg.ControlIface {
+			log.Infof("NOTICE: control_iface %s is also the fabric_iface of I/O Engine %d, "+
+				"management traffic will not be segregated", cfg.ControlIface, i)
+		}
+		engine.Storage.ControlMetadata = cfg.ControlMetadata
 		if err := engine.Validate(); err != nil {
 			return errors.Wrapf(err, "I/O Engine %d failed config validation", i)
 		}
+		if engine.LogFile == "" {
+			return FaultConfigNoLogFile(i)
+		}
+		if err := engine.ExpandLogFile(hostname, i, now); err != nil {
+			return errors.Wrapf(err, "I/O Engine %d failed config validation", i)
+		}
+	}
+
+	if spares == len(cfg.Engines) {
+		return errors.New("at least one I/O Engine must not be configured as a spare")
 	}
 
 	if len(cfg.Engines) > 1 {
@@ -558,7 +954,7 @@ func (cfg *Server) validateMultiServerConfig(log logging.Logger) error {
 			seenValues[logConfig] = idx
 		}
 
-		scmConf := engine.Storage.SCM
+		scmConf := engine.Storage.SCM()
 		mountConfig := fmt.Sprintf("scm_mount:%s", scmConf.MountPoint)
 		if seenIn, exists := seenValues[mountConfig]; exists {
 			log.Debugf("%s in %d duplicates %d", mountConfig, idx, seenIn)
@@ -574,8 +970,7 @@ func (cfg *Server) validateMultiServerConfig(log logging.Logger) error {
 			seenScmSet[dev] = idx
 		}
 
-		bdevConf := engine.Storage.Bdev
-		for _, dev := range bdevConf.DeviceList {
+		for _, dev := range engine.Storage.Tiers.BdevDevices() {
 			if seenIn, exists := seenBdevSet[dev]; exists {
 				log.Debugf("bdev_list entry %s in %d overlaps %d", dev, idx, seenIn)
 				return FaultConfigOverlappingBdevDeviceList(idx, seenIn)
@@ -614,6 +1009,25 @@ func (cfg *Server) validateEngineFabric(ctx context.Context, cfgEngine *engine.C
 	return nil
 }
 
+// checkVirtualFunction ensures that an engine configured to use an SR-IOV
+// virtual function fabric interface can use it. The NUMA affinity of a virtual
+// function is that of its parent physical function.
+func (cfg *Server) checkVirtualFunction(index int, cfgEngine *engine.Config) error {
+	if cfg.getVirtualFunctionFn == nil {
+		return nil
+	}
+
+	vf, err := cfg.getVirtualFunctionFn(cfgEngine.Fabric.Interface)
+	if err != nil || vf == nil {
+		return err
+	}
+	if vf.LinkState == netdetect.LinkStateDown {
+		return FaultConfigVirtualFunctionLinkDown(index, vf.Device, vf.PhysFn)
+	}
+
+	return nil
+}
+
 // CheckFabric ensures engines in configuration have compatible parameter
 // values and returns fabric network device class for the configuration.
 func (cfg *Server) CheckFabric(ctx context.Context) (uint32, error) {
@@ -623,6 +1037,9 @@ func (cfg *Server) CheckFabric(ctx context.Context) (uint32, error) {
 		if err != nil {
 			return 0, err
 		}
+		if err := cfg.checkVirtualFunction(index, engine); err != nil {
+			return 0, err
+		}
 		if index == 0 {
 			netDevClass = ndc
 			if err := cfg.validateEngineFabric(ctx, engine); err != nil {
@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -23,10 +24,12 @@ import (
 
 	"github.com/daos-stack/daos/src/control/common"
 	. "github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/security"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
 )
 
 const (
@@ -106,11 +109,28 @@ func getDeviceClassStub(netdev string) (uint32, error) {
 		return netdetect.Infiniband, nil
 	case "ib1":
 		return netdetect.Infiniband, nil
+	case "ib0v1", "ib0v2":
+		return netdetect.Infiniband, nil
 	default:
 		return 0, nil
 	}
 }
 
+func getVirtualFunctionStub(netdev string) (*netdetect.VirtualFunction, error) {
+	switch netdev {
+	case "ib0v1":
+		return &netdetect.VirtualFunction{
+			Device: netdev, PhysFn: "ib0", LinkState: netdetect.LinkStateUp,
+		}, nil
+	case "ib0v2":
+		return &netdetect.VirtualFunction{
+			Device: netdev, PhysFn: "ib0", LinkState: netdetect.LinkStateDown,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
 func TestServerConfig_MarshalUnmarshal(t *testing.T) {
 	for name, tt := range map[string]struct {
 		inPath string
@@ -195,6 +215,7 @@ func TestServerConfig_Constructed(t *testing.T) {
 	// First, load a config based on the server config with all options uncommented.
 	testFile := filepath.Join(testDir, sConfigUncomment)
 	uncommentServerConfig(t, testFile)
+	maxRetries := 5
 	defaultCfg, err := mockConfigFromFile(t, testFile)
 	if err != nil {
 		t.Fatalf("failed to load %s: %s", testFile, err)
@@ -203,20 +224,38 @@ func TestServerConfig_Constructed(t *testing.T) {
 	var numaNode0 uint = 0
 	var numaNode1 uint = 1
 	var bypass = true
+	var noFlush = false
 
 	// Next, construct a config to compare against the first one. It should be
 	// possible to construct an identical configuration with the helpers.
 	constructed := DefaultServer().
 		WithControlPort(10001).
+		WithControlIface("eth0.100").
 		WithBdevInclude("0000:81:00.1", "0000:81:00.2", "0000:81:00.3").
 		WithBdevExclude("0000:81:00.1").
 		WithDisableVFIO(true). // vfio enabled by default
+		WithNvmeDriver("uio_pci_generic").
+		WithBdevVerifyRate(100).
 		WithDisableVMD(false). // vmd disabled by default
+		WithContainerized(true).
+		WithNoPathFixup(true).
 		WithNrHugePages(4096).
 		WithControlLogMask(ControlLogLevelError).
 		WithControlLogFile("/tmp/daos_server.log").
 		WithHelperLogFile("/tmp/daos_admin.log").
 		WithFirmwareHelperLogFile("/tmp/daos_firmware.log").
+		WithHelperAuditLogFile("/var/log/daos/daos_helper_audit.log").
+		WithDeviceLedgerFile("/var/lib/daos/device_ledger.yaml").
+		WithPrepareStateFile("/var/lib/daos/prepare_state.yaml").
+		WithFaultPolicy(FaultPolicy{
+			Enabled:              true,
+			DeviceErrorThreshold: 10,
+			RankFailureThreshold: 3,
+			Window:               10 * time.Minute,
+			GracePeriod:          30 * time.Minute,
+			MaxActionsPerHour:    2,
+		}).
+		WithControlMetadata(storage.ControlMetadata{Path: "/var/daos/config"}).
 		WithSystemName("daos_server").
 		WithSocketDir("./.daos/daos_server").
 		WithFabricProvider("ofi+verbs;ofi_rxm").
@@ -224,6 +263,40 @@ func TestServerConfig_Constructed(t *testing.T) {
 		WithCrtTimeout(30).
 		WithAccessPoints("hostname1").
 		WithFaultCb("./.daos/fd_callback").
+		WithDiscoveryPlugin("/etc/daos/discovery_plugin").
+		WithInventoryWebhook("https://cmdb.example.com/api/daos/inventory").
+		WithValidationHook("https://change.example.com/api/daos/validate").
+		WithTelemetryPort(9191).
+		WithTelemetryIface("eth1").
+		WithTelemetryPush(
+			&TelemetryPushConfig{
+				Type:     TelemetryPushRemoteWrite,
+				Address:  "https://metrics.example.com/api/v1/write",
+				Interval: 30 * time.Second,
+			},
+			&TelemetryPushConfig{
+				Type:       TelemetryPushGraphite,
+				Address:    "graphite.example.com:2003",
+				Prefix:     "daos",
+				BatchSize:  1000,
+				MaxRetries: &maxRetries,
+			}).
+		WithHealthPort(9192).
+		WithSlowRPCThreshold(30*time.Second).
+		WithClockSkewThreshold(500*time.Millisecond).
+		WithEventLogFormat(events.EventFormatRFC5424).
+		WithGrpcConfig(&GrpcConfig{
+			Compression:      GrpcCompressionGzip,
+			MaxRecvMsgSize:   64 << 20,
+			MaxSendMsgSize:   64 << 20,
+			KeepaliveTime:    30 * time.Second,
+			KeepaliveTimeout: 10 * time.Second,
+		}).
+		WithFormationBarrier(&FormationBarrier{
+			ExpectedRanks: 64,
+			Quorum:        90,
+			Timeout:       10 * time.Minute,
+		}).
 		WithFaultPath("/vcdu0/rack1/hostname").
 		WithHyperthreads(true). // hyper-threads disabled by default
 		WithProviderValidator(netdetect.ValidateProviderStub).
@@ -240,12 +313,16 @@ func TestServerConfig_Constructed(t *testing.T) {
 				WithScmRamdiskSize(16).
 				WithBdevClass("nvme").
 				WithBdevDeviceList("0000:81:00.0").
+				WithBdevLbaFormat("best-performance").
+				WithBdevLbaFormats(map[string]string{"0000:81:00.0": "4096"}).
 				WithFabricInterface("qib0").
 				WithFabricInterfacePort(20000).
 				WithPinnedNumaNode(&numaNode0).
 				WithBypassHealthChk(&bypass).
 				WithEnvVars("CRT_TIMEOUT=30").
 				WithLogFile("/tmp/daos_engine.0.log").
+				WithLogRotation("1GiB", 168*time.Hour).
+				WithShutdown(time.Minute, true, &noFlush).
 				WithLogMask("WARN"),
 			engine.NewConfig().
 				WithRank(1).
@@ -332,6 +409,23 @@ func replaceText(r io.Reader, w io.Writer, oldTxt, newTxt string) error {
 	return sc.Err()
 }
 
+func bdevRolesTiers() storage.TierConfigs {
+	return storage.TierConfigs{
+		{Scm: storage.ScmConfig{
+			MountPoint:  "/mnt/daos",
+			Class:       storage.ScmClassRAM,
+			RamdiskSize: 16,
+		}},
+		{Bdev: storage.BdevConfig{
+			Class:      storage.BdevClassNvme,
+			DeviceList: []string{common.MockPCIAddr(1)},
+			Roles: []storage.BdevRole{
+				storage.BdevRoleWAL, storage.BdevRoleMeta, storage.BdevRoleData,
+			},
+		}},
+	}
+}
+
 func TestServerConfig_Validation(t *testing.T) {
 	noopExtra := func(c *Server) *Server { return c }
 
@@ -463,15 +557,266 @@ func TestServerConfig_Validation(t *testing.T) {
 		},
 		"good telemetry port (zero)": {
 			extraConfig: func(c *Server) *Server {
-				return c.WithTelemetryPort(0)
+				return c.WithTelemetryPort(0).WithTelemetryIface("")
+			},
+		},
+		"good health port": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthPort(9192)
 			},
 		},
+		"unknown event log format": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithEventLogFormat("leef")
+			},
+			expErr: errors.New("invalid event_log_format"),
+		},
+		"negative slow rpc threshold": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithSlowRPCThreshold(-time.Second)
+			},
+			expErr: errors.New("slow_rpc_threshold"),
+		},
+		"zero clock skew threshold": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithClockSkewThreshold(0)
+			},
+			expErr: errors.New("clock_skew_threshold"),
+		},
+		"bad health port (negative)": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthPort(-1)
+			},
+			expErr: errors.New("must not be negative"),
+		},
+		"bad health port (control port)": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithHealthPort(c.ControlPort)
+			},
+			expErr: errors.New("conflicts"),
+		},
+		"good telemetry iface": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(9191).WithTelemetryIface("eth1")
+			},
+		},
+		"telemetry iface without port": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithTelemetryPort(0).WithTelemetryIface("eth1")
+			},
+			expErr: errors.New("without telemetry_port"),
+		},
 		"bad telemetry port (negative)": {
 			extraConfig: func(c *Server) *Server {
 				return c.WithTelemetryPort(-123)
 			},
 			expErr: FaultConfigBadTelemetryPort,
 		},
+		"good nvme driver": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDisableVFIO(false).WithNvmeDriver(NvmeDriverVFIO)
+			},
+		},
+		"bad nvme driver": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithNvmeDriver("nvme")
+			},
+			expErr: FaultConfigBadNvmeDriver("nvme"),
+		},
+		"vfio-pci nvme driver with vfio disabled": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithDisableVFIO(true).WithNvmeDriver(NvmeDriverVFIO)
+			},
+			expErr: errors.New("conflicts with nvme_driver"),
+		},
+		"bad bdev verify rate": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithBdevVerifyRate(-1)
+			},
+			expErr: errors.New("invalid bdev_verify_rate"),
+		},
+		"fault policy disabled with invalid values": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFaultPolicy(FaultPolicy{})
+			},
+		},
+		"fault policy enabled with defaults": {
+			extraConfig: func(c *Server) *Server {
+				fp := DefaultFaultPolicy()
+				fp.Enabled = true
+				return c.WithFaultPolicy(fp)
+			},
+		},
+		"fault policy zero device threshold": {
+			extraConfig: func(c *Server) *Server {
+				fp := DefaultFaultPolicy()
+				fp.Enabled = true
+				fp.DeviceErrorThreshold = 0
+				return c.WithFaultPolicy(fp)
+			},
+			expErr: errors.New("device_error_threshold"),
+		},
+		"grpc unsupported compression": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithGrpcConfig(&GrpcConfig{Compression: "snappy"})
+			},
+			expErr: errors.New("unsupported compression"),
+		},
+		"grpc negative message size": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithGrpcConfig(&GrpcConfig{MaxRecvMsgSize: -1})
+			},
+			expErr: errors.New("max_recv_msg_size"),
+		},
+		"grpc keepalive timeout without time": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithGrpcConfig(&GrpcConfig{KeepaliveTimeout: time.Second})
+			},
+			expErr: errors.New("requires keepalive_time"),
+		},
+		"grpc valid": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithGrpcConfig(&GrpcConfig{
+					Compression:    GrpcCompressionGzip,
+					MaxRecvMsgSize: 64 << 20,
+					KeepaliveTime:  time.Minute,
+				})
+			},
+		},
+		"formation barrier no expected ranks": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFormationBarrier(&FormationBarrier{})
+			},
+			expErr: errors.New("expected_ranks"),
+		},
+		"formation barrier bad quorum": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFormationBarrier(&FormationBarrier{
+					ExpectedRanks: 8,
+					Quorum:        101,
+				})
+			},
+			expErr: errors.New("quorum"),
+		},
+		"formation barrier negative timeout": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFormationBarrier(&FormationBarrier{
+					ExpectedRanks: 8,
+					Timeout:       -time.Second,
+				})
+			},
+			expErr: errors.New("timeout"),
+		},
+		"formation barrier valid": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithFormationBarrier(&FormationBarrier{ExpectedRanks: 8})
+			},
+		},
+		"fault policy zero window": {
+			extraConfig: func(c *Server) *Server {
+				fp := DefaultFaultPolicy()
+				fp.Enabled = true
+				fp.Window = 0
+				return c.WithFaultPolicy(fp)
+			},
+			expErr: errors.New("window"),
+		},
+		"fault policy negative grace period": {
+			extraConfig: func(c *Server) *Server {
+				fp := DefaultFaultPolicy()
+				fp.Enabled = true
+				fp.GracePeriod = -time.Second
+				return c.WithFaultPolicy(fp)
+			},
+			expErr: errors.New("grace_period"),
+		},
+		"fault policy zero action limit": {
+			extraConfig: func(c *Server) *Server {
+				fp := DefaultFaultPolicy()
+				fp.Enabled = true
+				fp.MaxActionsPerHour = 0
+				return c.WithFaultPolicy(fp)
+			},
+			expErr: errors.New("max_actions_per_hour"),
+		},
+		"good inventory webhook": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithInventoryWebhook("http://cmdb:8080/inventory")
+			},
+		},
+		"inventory webhook bad scheme": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithInventoryWebhook("ftp://cmdb/inventory")
+			},
+			expErr: errors.New("invalid inventory_webhook"),
+		},
+		"inventory webhook missing host": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithInventoryWebhook("cmdb/inventory")
+			},
+			expErr: errors.New("invalid inventory_webhook"),
+		},
+		"validation hook url": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithValidationHook("https://change.example.com/daos/validate")
+			},
+		},
+		"validation hook path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithValidationHook("/etc/daos/validation_hook")
+			},
+		},
+		"validation hook relative path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithValidationHook("validation_hook")
+			},
+			expErr: errors.New("invalid validation_hook"),
+		},
+		"validation hook bad scheme": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithValidationHook("ftp://change/validate")
+			},
+			expErr: errors.New("invalid validation_hook"),
+		},
+		"bdev roles": {
+			extraConfig: func(c *Server) *Server {
+				c.Engines[0].Storage.Tiers = bdevRolesTiers()
+				return c
+			},
+			expErr: errors.New("bdev_roles not supported"),
+		},
+		"spare engine": {
+			extraConfig: func(c *Server) *Server {
+				c.Engines[1].Rank = nil
+				c.Engines[1].WithSpare(true)
+				return c
+			},
+		},
+		"spare engine with rank": {
+			extraConfig: func(c *Server) *Server {
+				c.Engines[1].WithSpare(true)
+				return c
+			},
+			expErr: errors.New("rank may not be set on a spare engine"),
+		},
+		"all engines spare": {
+			extraConfig: func(c *Server) *Server {
+				for _, ec := range c.Engines {
+					ec.Rank = nil
+					ec.WithSpare(true)
+				}
+				return c
+			},
+			expErr: errors.New("at least one I/O Engine must not be configured as a spare"),
+		},
+		"relative control metadata path": {
+			extraConfig: func(c *Server) *Server {
+				return c.WithControlMetadata(storage.ControlMetadata{
+					Path: "daos/config",
+				})
+			},
+			expErr: errors.New("must be absolute"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -692,7 +1037,7 @@ func TestServerConfig_DuplicateValues(t *testing.T) {
 		"duplicate scm_mount": {
 			configA: configA(),
 			configB: configB().
-				WithScmMountPoint(configA().Storage.SCM.MountPoint),
+				WithScmMountPoint(configA().Storage.SCM().MountPoint),
 			expErr: FaultConfigDuplicateScmMount(1, 0),
 		},
 		"duplicate scm_list": {
@@ -736,6 +1081,121 @@ func TestServerConfig_DuplicateValues(t *testing.T) {
 	}
 }
 
+func TestServerConfig_LogFileTemplate(t *testing.T) {
+	templateHostname = func() (string, error) { return "foo", nil }
+	templateNow = func() time.Time { return time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() {
+		templateHostname = os.Hostname
+		templateNow = time.Now
+	}()
+
+	configA := func() *engine.Config {
+		return engine.NewConfig().
+			WithFabricInterface("a").
+			WithFabricInterfacePort(42).
+			WithScmClass("ram").
+			WithScmRamdiskSize(1).
+			WithScmMountPoint("a")
+	}
+	configB := func() *engine.Config {
+		return engine.NewConfig().
+			WithFabricInterface("b").
+			WithFabricInterfacePort(42).
+			WithScmClass("ram").
+			WithScmRamdiskSize(1).
+			WithScmMountPoint("b")
+	}
+
+	for name, tc := range map[string]struct {
+		configA     *engine.Config
+		configB     *engine.Config
+		expLogFiles []string
+		expErr      error
+	}{
+		"no templates": {
+			configA:     configA().WithLogFile("/tmp/a.log"),
+			configB:     configB().WithLogFile("/tmp/b.log"),
+			expLogFiles: []string{"/tmp/a.log", "/tmp/b.log"},
+		},
+		"templates expanded": {
+			configA: configA().WithLogFile("/tmp/{{.Hostname}}/{{.Date}}/engine{{.EngineIdx}}.log"),
+			configB: configB().WithLogFile("/tmp/{{.Hostname}}/{{.Date}}/engine{{.EngineIdx}}.log"),
+			expLogFiles: []string{
+				"/tmp/foo/2021-06-01/engine0.log",
+				"/tmp/foo/2021-06-01/engine1.log",
+			},
+		},
+		"missing log file": {
+			configA: configA().WithLogFile("/tmp/a.log"),
+			configB: configB(),
+			expErr:  FaultConfigNoLogFile(1),
+		},
+		"unknown template variable": {
+			configA: configA().WithLogFile("/tmp/{{.Rank}}.log"),
+			configB: configB().WithLogFile("/tmp/b.log"),
+			expErr:  errors.New("invalid log_file template"),
+		},
+		"unterminated template": {
+			configA: configA().WithLogFile("/tmp/{{.Hostname.log"),
+			configB: configB().WithLogFile("/tmp/b.log"),
+			expErr:  errors.New("invalid log_file template"),
+		},
+		"duplicate after expansion": {
+			configA: configA().WithLogFile("/tmp/{{.Hostname}}.log"),
+			configB: configB().WithLogFile("/tmp/{{.Hostname}}.log"),
+			expErr:  FaultConfigDuplicateLogFile(1, 0),
+		},
+		"valid rotation": {
+			configA: configA().WithLogFile("/tmp/a.log").
+				WithLogRotation("512MiB", 24*time.Hour),
+			configB:     configB().WithLogFile("/tmp/b.log"),
+			expLogFiles: []string{"/tmp/a.log", "/tmp/b.log"},
+		},
+		"rotation size too small": {
+			configA: configA().WithLogFile("/tmp/a.log").
+				WithLogRotation("1KB", 0),
+			configB: configB().WithLogFile("/tmp/b.log"),
+			expErr:  errors.New("must be at least"),
+		},
+		"rotation bad size": {
+			configA: configA().WithLogFile("/tmp/a.log").
+				WithLogRotation("large", 0),
+			configB: configB().WithLogFile("/tmp/b.log"),
+			expErr:  errors.New("invalid max_size"),
+		},
+		"rotation negative age": {
+			configA: configA().WithLogFile("/tmp/a.log").
+				WithLogRotation("", -time.Hour),
+			configB: configB().WithLogFile("/tmp/b.log"),
+			expErr:  errors.New("invalid max_age"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer ShowBufferOnFailure(t, buf)
+
+			conf := DefaultServer().
+				WithFabricProvider("test").
+				WithGetNetworkDeviceClass(getDeviceClassStub).
+				WithEngines(tc.configA, tc.configB)
+
+			gotErr := conf.Validate(log)
+			CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			var gotLogFiles []string
+			for _, ec := range conf.Engines {
+				gotLogFiles = append(gotLogFiles, ec.LogFile)
+			}
+			if diff := cmp.Diff(tc.expLogFiles, gotLogFiles); diff != "" {
+				t.Fatalf("unexpected log files (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestServerConfig_NetworkDeviceClass(t *testing.T) {
 	configA := func() *engine.Config {
 		return engine.NewConfig().
@@ -802,11 +1262,26 @@ func TestServerConfig_NetworkDeviceClass(t *testing.T) {
 				WithFabricInterface("ib0"),
 			expErr: FaultConfigInvalidNetDevClass(1, netdetect.Ether, netdetect.Infiniband, "ib0"),
 		},
+		"successful validation with virtual function": {
+			configA: configA().
+				WithFabricInterface("ib0v1"),
+			configB: configB().
+				WithFabricInterface("ib1"),
+			expNetDevCls: netdetect.Infiniband,
+		},
+		"virtual function with link down": {
+			configA: configA().
+				WithFabricInterface("ib1"),
+			configB: configB().
+				WithFabricInterface("ib0v2"),
+			expErr: FaultConfigVirtualFunctionLinkDown(1, "ib0v2", "ib0"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			gotNetDevCls, gotErr := DefaultServer().
 				WithFabricProvider("test").
 				WithGetNetworkDeviceClass(getDeviceClassStub).
+				WithGetNetworkVirtualFunction(getVirtualFunctionStub).
 				WithEngines(tc.configA, tc.configB).
 				CheckFabric(context.Background())
 
@@ -853,3 +1328,58 @@ func TestServerConfig_SaveActiveConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestServerConfig_Hash(t *testing.T) {
+	testDir, cleanup := CreateTestDir(t)
+	defer cleanup()
+
+	for name, tc := range map[string]struct {
+		inTxt   string
+		outTxt  string
+		expSame bool
+	}{
+		"same content": {
+			expSame: true,
+		},
+		"comment differs": {
+			inTxt:   "name: daos_server",
+			outTxt:  "name: daos_server # edited",
+			expSame: true,
+		},
+		"value differs": {
+			inTxt:  "nr_hugepages: 4096",
+			outTxt: "nr_hugepages: 8192",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fileA := filepath.Join(testDir, "a_"+sConfigUncomment)
+			fileB := filepath.Join(testDir, "b_"+sConfigUncomment)
+			uncommentServerConfig(t, fileA)
+			uncommentServerConfig(t, fileB)
+			if tc.inTxt != "" {
+				replaceFile(t, fileB, tc.inTxt, tc.outTxt)
+			}
+
+			cfgA, err := mockConfigFromFile(t, fileA)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfgB, err := mockConfigFromFile(t, fileB)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hashA, err := cfgA.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			hashB, err := cfgB.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			AssertEqual(t, tc.expSame, hashA == hashB,
+				fmt.Sprintf("unexpected hash comparison %s vs %s", hashA, hashB))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Supported telemetry push exporter types.
+const (
+	TelemetryPushRemoteWrite = "remote_write"
+	TelemetryPushStatsd      = "statsd"
+	TelemetryPushGraphite    = "graphite"
+)
+
+const (
+	defaultTelemetryPushInterval   = time.Minute
+	defaultTelemetryPushBatchSize  = 500
+	defaultTelemetryPushMaxRetries = 3
+	defaultTelemetryPushPrefix     = "daos"
+	minTelemetryPushInterval       = time.Second
+)
+
+// TelemetryPushConfig describes an exporter that periodically pushes the
+// server's metrics to a remote collector, for sites where storage nodes cannot
+// be scraped.
+type TelemetryPushConfig struct {
+	// one of remote_write, statsd or graphite
+	Type string `yaml:"type"`
+	// URL of a Prometheus remote-write endpoint, or host:port of a statsd
+	// (UDP) or graphite (TCP plaintext) server
+	Address string `yaml:"address"`
+	// time between pushes, default 1m
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// maximum number of samples sent in a single request, default 500
+	BatchSize int `yaml:"batch_size,omitempty"`
+	// number of times a failed batch is retried before being dropped,
+	// default 3
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// metric name prefix for statsd and graphite, default "daos"
+	Prefix string `yaml:"prefix,omitempty"`
+}
+
+// Validate returns an error if the TelemetryPushConfig contains invalid
+// parameters.
+func (tpc *TelemetryPushConfig) Validate() error {
+	if tpc == nil {
+		return errors.New("telemetry_push: empty exporter entry")
+	}
+
+	switch tpc.Type {
+	case TelemetryPushRemoteWrite:
+		u, err := url.Parse(tpc.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Errorf("telemetry_push: invalid %s address %q: must be an http or https URL",
+				tpc.Type, tpc.Address)
+		}
+	case TelemetryPushStatsd, TelemetryPushGraphite:
+		if _, _, err := net.SplitHostPort(tpc.Address); err != nil {
+			return errors.Errorf("telemetry_push: invalid %s address %q: must be host:port",
+				tpc.Type, tpc.Address)
+		}
+	default:
+		return errors.Errorf("telemetry_push: unknown type %q (must be one of %s, %s or %s)",
+			tpc.Type, TelemetryPushRemoteWrite, TelemetryPushStatsd, TelemetryPushGraphite)
+	}
+
+	switch {
+	case tpc.Interval != 0 && tpc.Interval < minTelemetryPushInterval:
+		return errors.Errorf("telemetry_push: interval must be at least %s", minTelemetryPushInterval)
+	case tpc.BatchSize < 0:
+		return errors.New("telemetry_push: batch_size must not be negative")
+	case tpc.MaxRetries != nil && *tpc.MaxRetries < 0:
+		return errors.New("telemetry_push: max_retries must not be negative")
+	}
+
+	return nil
+}
+
+// PushInterval returns the time between pushes.
+func (tpc *TelemetryPushConfig) PushInterval() time.Duration {
+	if tpc.Interval == 0 {
+		return defaultTelemetryPushInterval
+	}
+
+	return tpc.Interval
+}
+
+// PushBatchSize returns the maximum number of samples per request.
+func (tpc *TelemetryPushConfig) PushBatchSize() int {
+	if tpc.BatchSize == 0 {
+		return defaultTelemetryPushBatchSize
+	}
+
+	return tpc.BatchSize
+}
+
+// PushMaxRetries returns the number of retries of a failed batch.
+func (tpc *TelemetryPushConfig) PushMaxRetries() int {
+	if tpc.MaxRetries == nil {
+		return defaultTelemetryPushMaxRetries
+	}
+
+	return *tpc.MaxRetries
+}
+
+// PushPrefix returns the metric name prefix for statsd and graphite.
+func (tpc *TelemetryPushConfig) PushPrefix() string {
+	if tpc.Prefix == "" {
+		return defaultTelemetryPushPrefix
+	}
+
+	return tpc.Prefix
+}
@@ -0,0 +1,89 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestConfig_TelemetryPushConfig(t *testing.T) {
+	zero := 0
+
+	for name, tc := range map[string]struct {
+		tpc           *TelemetryPushConfig
+		expErr        error
+		expInterval   time.Duration
+		expBatchSize  int
+		expMaxRetries int
+		expPrefix     string
+	}{
+		"nil": {
+			expErr: errors.New("empty exporter entry"),
+		},
+		"unknown type": {
+			tpc:    &TelemetryPushConfig{Type: "influx", Address: "localhost:8086"},
+			expErr: errors.New("unknown type \"influx\""),
+		},
+		"remote write defaults": {
+			tpc:           &TelemetryPushConfig{Type: TelemetryPushRemoteWrite, Address: "https://prom/api/v1/write"},
+			expInterval:   defaultTelemetryPushInterval,
+			expBatchSize:  defaultTelemetryPushBatchSize,
+			expMaxRetries: defaultTelemetryPushMaxRetries,
+			expPrefix:     defaultTelemetryPushPrefix,
+		},
+		"remote write not a url": {
+			tpc:    &TelemetryPushConfig{Type: TelemetryPushRemoteWrite, Address: "prom:9090"},
+			expErr: errors.New("must be an http or https URL"),
+		},
+		"statsd no port": {
+			tpc:    &TelemetryPushConfig{Type: TelemetryPushStatsd, Address: "statsd"},
+			expErr: errors.New("must be host:port"),
+		},
+		"graphite all set": {
+			tpc: &TelemetryPushConfig{
+				Type:       TelemetryPushGraphite,
+				Address:    "graphite:2003",
+				Interval:   10 * time.Second,
+				BatchSize:  100,
+				MaxRetries: &zero,
+				Prefix:     "site.daos",
+			},
+			expInterval:   10 * time.Second,
+			expBatchSize:  100,
+			expMaxRetries: 0,
+			expPrefix:     "site.daos",
+		},
+		"interval too short": {
+			tpc: &TelemetryPushConfig{Type: TelemetryPushStatsd, Address: "statsd:8125",
+				Interval: time.Millisecond},
+			expErr: errors.New("interval must be at least 1s"),
+		},
+		"negative batch size": {
+			tpc: &TelemetryPushConfig{Type: TelemetryPushStatsd, Address: "statsd:8125",
+				BatchSize: -1},
+			expErr: errors.New("batch_size must not be negative"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.tpc.Validate()
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expInterval, tc.tpc.PushInterval(), "interval")
+			common.AssertEqual(t, tc.expBatchSize, tc.tpc.PushBatchSize(), "batch size")
+			common.AssertEqual(t, tc.expMaxRetries, tc.tpc.PushMaxRetries(), "max retries")
+			common.AssertEqual(t, tc.expPrefix, tc.tpc.PushPrefix(), "prefix")
+		})
+	}
+}
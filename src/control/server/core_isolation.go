@@ -0,0 +1,403 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+// irqHeavyFactor is how many times the mean per-core interrupt count a core
+// needs to have serviced to be considered to have heavy IRQ activity.
+const irqHeavyFactor = 2
+
+// CoreIsolationReport describes how the cores used by each engine relate to
+// the CPU isolation boot parameters of the kernel and to interrupt activity.
+type CoreIsolationReport struct {
+	IsolCPUs []int                  `json:"isolcpus"`
+	NoHzFull []int                  `json:"nohz_full"`
+	RcuNoCbs []int                  `json:"rcu_nocbs"`
+	IrqHeavy []int                  `json:"irq_heavy_cores"`
+	Engines  []*EngineCoreIsolation `json:"engines"`
+	Warnings []string               `json:"warnings"`
+}
+
+// EngineCoreIsolation describes the cores used by an engine and those which
+// are not covered by the CPU isolation boot parameters.
+type EngineCoreIsolation struct {
+	Index          int   `json:"index"`
+	ServiceCore    int   `json:"service_core"`
+	EngineCores    []int `json:"engine_cores"`
+	NotIsolated    []int `json:"not_isolated"`
+	NotNoHzFull    []int `json:"not_nohz_full"`
+	NotRcuNoCbs    []int `json:"not_rcu_nocbs"`
+	ServiceIrqs    []int `json:"service_core_irqs"`
+	ServiceIrqBusy bool  `json:"service_core_irq_heavy"`
+}
+
+// parseCPUList parses a kernel CPU list (e.g. "0-3,8,10-11") and returns the
+// sorted CPU indices.
+func parseCPUList(list string) ([]int, error) {
+	cpus := []int{}
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return cpus, nil
+	}
+
+	for _, field := range strings.Split(list, ",") {
+		bounds := strings.SplitN(field, "-", 2)
+		lo, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, errors.Errorf("invalid cpu list %q", list)
+		}
+		hi := lo
+		if len(bounds) == 2 {
+			if hi, err = strconv.Atoi(bounds[1]); err != nil || hi < lo {
+				return nil, errors.Errorf("invalid cpu list %q", list)
+			}
+		}
+		for cpu := lo; cpu <= hi; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	sort.Ints(cpus)
+
+	return cpus, nil
+}
+
+// parseIsolationParams extracts the CPU lists of the isolcpus, nohz_full and
+// rcu_nocbs parameters from the contents of /proc/cmdline. Flags preceding the
+// CPU list of isolcpus (e.g. "domain,managed_irq,2-5") are ignored.
+func parseIsolationParams(input io.Reader, cir *CoreIsolationReport) error {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	for _, param := range strings.Fields(string(data)) {
+		keyVal := strings.SplitN(param, "=", 2)
+		if len(keyVal) != 2 {
+			continue
+		}
+
+		var dest *[]int
+		val := keyVal[1]
+		switch keyVal[0] {
+		case "isolcpus":
+			dest = &cir.IsolCPUs
+			fields := strings.Split(val, ",")
+			for len(fields) > 0 && fields[0] != "" && (fields[0][0] < '0' || fields[0][0] > '9') {
+				fields = fields[1:]
+			}
+			val = strings.Join(fields, ",")
+		case "nohz_full":
+			dest = &cir.NoHzFull
+		case "rcu_nocbs":
+			dest = &cir.RcuNoCbs
+		default:
+			continue
+		}
+
+		cpus, err := parseCPUList(val)
+		if err != nil {
+			return errors.Wrapf(err, "parsing %s", keyVal[0])
+		}
+		*dest = cpus
+	}
+
+	return nil
+}
+
+// parseInterruptCounts returns the total number of interrupts serviced by each
+// CPU from the contents of /proc/interrupts.
+func parseInterruptCounts(input io.Reader) (map[int]uint64, error) {
+	scn := bufio.NewScanner(input)
+	if !scn.Scan() {
+		return nil, errors.Wrap(scn.Err(), "missing interrupts header")
+	}
+
+	var cpus []int
+	for _, field := range strings.Fields(scn.Text()) {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(field, "CPU"))
+		if err != nil {
+			return nil, errors.Errorf("unable to parse interrupts header %q", scn.Text())
+		}
+		cpus = append(cpus, cpu)
+	}
+
+	counts := make(map[int]uint64)
+	for scn.Scan() {
+		fields := strings.Fields(scn.Text())
+		if len(fields) < 2 || !strings.HasSuffix(fields[0], ":") {
+			continue
+		}
+		for i, field := range fields[1:] {
+			if i >= len(cpus) {
+				break
+			}
+			n, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				// end of per-cpu columns (e.g. "ERR: 0")
+				break
+			}
+			counts[cpus[i]] += n
+		}
+	}
+
+	return counts, scn.Err()
+}
+
+// irqHeavyCores returns the cores which have serviced at least irqHeavyFactor
+// times the mean number of interrupts per core.
+func irqHeavyCores(counts map[int]uint64) []int {
+	heavy := []int{}
+	if len(counts) == 0 {
+		return heavy
+	}
+
+	var total uint64
+	for _, n := range counts {
+		total += n
+	}
+	mean := total / uint64(len(counts))
+
+	for cpu, n := range counts {
+		if n > 0 && n >= mean*irqHeavyFactor {
+			heavy = append(heavy, cpu)
+		}
+	}
+	sort.Ints(heavy)
+
+	return heavy
+}
+
+// getIrqAffinities returns the CPU affinity of each IRQ listed under procRoot.
+func getIrqAffinities(procRoot string) (map[int][]int, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(procRoot, "irq"))
+	if err != nil {
+		return nil, err
+	}
+
+	affinities := make(map[int][]int)
+	for _, entry := range entries {
+		irq, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(procRoot, "irq", entry.Name(),
+			"smp_affinity_list"))
+		if err != nil {
+			continue
+		}
+		cpus, err := parseCPUList(string(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "irq %d", irq)
+		}
+		affinities[irq] = cpus
+	}
+
+	return affinities, nil
+}
+
+// getNumaNodeCPUs returns the CPUs of the given NUMA node.
+func getNumaNodeCPUs(sysRoot string, node uint) ([]int, error) {
+	data, err := ioutil.ReadFile(filepath.Join(sysRoot,
+		fmt.Sprintf("devices/system/node/node%d/cpulist", node)))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCPUList(string(data))
+}
+
+// engineCores returns the cores that an engine binds its service threads and
+// its target and helper xstreams to. The service threads run on first_core
+// and the remaining xstreams on the cores that follow it. If the engine is
+// pinned to a NUMA node, core indices are relative to the CPUs of that node.
+func engineCores(sysRoot string, cfg *engine.Config) ([]int, error) {
+	nrCores := 1 + cfg.TargetCount + cfg.HelperStreamCount
+
+	var nodeCPUs []int
+	if numa, err := cfg.Fabric.GetNumaNode(); err == nil {
+		if nodeCPUs, err = getNumaNodeCPUs(sysRoot, numa); err != nil {
+			return nil, errors.Wrapf(err, "numa node %d cpus", numa)
+		}
+	}
+
+	cores := make([]int, 0, nrCores)
+	for i := cfg.ServiceThreadCore; i < cfg.ServiceThreadCore+nrCores; i++ {
+		if nodeCPUs == nil {
+			cores = append(cores, i)
+			continue
+		}
+		if i >= len(nodeCPUs) {
+			return nil, errors.Errorf("engine needs %d cores from core %d but numa node has %d",
+				nrCores, cfg.ServiceThreadCore, len(nodeCPUs))
+		}
+		cores = append(cores, nodeCPUs[i])
+	}
+
+	return cores, nil
+}
+
+// coresMissingFrom returns the cores that are not included in set.
+func coresMissingFrom(cores, set []int) []int {
+	inSet := make(map[int]bool)
+	for _, c := range set {
+		inSet[c] = true
+	}
+
+	missing := []int{}
+	for _, c := range cores {
+		if !inSet[c] {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+func getCoreIsolationReport(procRoot, sysRoot string, cfg *config.Server) (*CoreIsolationReport, error) {
+	cir := &CoreIsolationReport{
+		IsolCPUs: []int{},
+		NoHzFull: []int{},
+		RcuNoCbs: []int{},
+		Engines:  []*EngineCoreIsolation{},
+		Warnings: []string{},
+	}
+
+	f, err := os.Open(filepath.Join(procRoot, "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := parseIsolationParams(f, cir); err != nil {
+		return nil, err
+	}
+
+	f, err = os.Open(filepath.Join(procRoot, "interrupts"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	counts, err := parseInterruptCounts(f)
+	if err != nil {
+		return nil, err
+	}
+	cir.IrqHeavy = irqHeavyCores(counts)
+
+	affinities, err := getIrqAffinities(procRoot)
+	if err != nil {
+		return nil, err
+	}
+	nrCPUs := len(counts)
+
+	for idx, engineCfg := range cfg.Engines {
+		cores, err := engineCores(sysRoot, engineCfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "engine %d", idx)
+		}
+
+		eci := &EngineCoreIsolation{
+			Index:       idx,
+			ServiceCore: cores[0],
+			EngineCores: cores,
+			ServiceIrqs: []int{},
+		}
+
+		// only report coverage for the parameters which have been set
+		if len(cir.IsolCPUs) > 0 {
+			eci.NotIsolated = coresMissingFrom(cores, cir.IsolCPUs)
+		}
+		if len(cir.NoHzFull) > 0 {
+			eci.NotNoHzFull = coresMissingFrom(cores, cir.NoHzFull)
+		}
+		if len(cir.RcuNoCbs) > 0 {
+			eci.NotRcuNoCbs = coresMissingFrom(cores, cir.RcuNoCbs)
+		}
+
+		// IRQs which have been steered to a subset of CPUs that includes
+		// the service core, rather than left to be balanced across all
+		for irq, cpus := range affinities {
+			if len(cpus) < nrCPUs && len(coresMissingFrom([]int{eci.ServiceCore}, cpus)) == 0 {
+				eci.ServiceIrqs = append(eci.ServiceIrqs, irq)
+			}
+		}
+		sort.Ints(eci.ServiceIrqs)
+
+		eci.ServiceIrqBusy = len(coresMissingFrom([]int{eci.ServiceCore}, cir.IrqHeavy)) == 0
+
+		cir.Engines = append(cir.Engines, eci)
+		cir.Warnings = append(cir.Warnings, eci.warnings()...)
+	}
+
+	return cir, nil
+}
+
+func (eci *EngineCoreIsolation) warnings() []string {
+	var warnings []string
+
+	for _, chk := range []struct {
+		param   string
+		missing []int
+	}{
+		{"isolcpus", eci.NotIsolated},
+		{"nohz_full", eci.NotNoHzFull},
+		{"rcu_nocbs", eci.NotRcuNoCbs},
+	} {
+		if len(chk.missing) > 0 {
+			warnings = append(warnings, fmt.Sprintf("engine %d: cores %v not in kernel %s list",
+				eci.Index, chk.missing, chk.param))
+		}
+	}
+
+	if eci.ServiceIrqBusy {
+		msg := fmt.Sprintf("engine %d: service threads on core %d share the core with heavy IRQ activity",
+			eci.Index, eci.ServiceCore)
+		if len(eci.ServiceIrqs) > 0 {
+			msg += fmt.Sprintf(" (IRQs %v have affinity to the core)", eci.ServiceIrqs)
+		}
+		warnings = append(warnings, msg)
+	}
+
+	return warnings
+}
+
+// GetCoreIsolationReport compares the cores configured for each engine with
+// the isolcpus, nohz_full and rcu_nocbs kernel boot parameters and with the
+// interrupt activity and IRQ affinity of the host.
+func GetCoreIsolationReport(cfg *config.Server) (*CoreIsolationReport, error) {
+	return getCoreIsolationReport(defaultProcRoot, defaultSysRoot, cfg)
+}
+
+// checkCoreIsolation logs warnings for engine cores that are not isolated
+// from the kernel or that share a core with heavy IRQ activity. Failure to
+// build the report is not fatal as the check is advisory.
+func checkCoreIsolation(log logging.Logger, cfg *config.Server) {
+	cir, err := GetCoreIsolationReport(cfg)
+	if err != nil {
+		log.Debugf("core isolation check skipped: %s", err)
+		return
+	}
+
+	for _, msg := range cir.Warnings {
+		log.Infof("core isolation: %s", msg)
+	}
+}
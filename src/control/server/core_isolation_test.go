@@ -0,0 +1,230 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_parseCPUList(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in      string
+		expCPUs []int
+		expErr  error
+	}{
+		"empty": {
+			expCPUs: []int{},
+		},
+		"single": {
+			in:      "3\n",
+			expCPUs: []int{3},
+		},
+		"ranges and singles": {
+			in:      "8,0-3,10-11",
+			expCPUs: []int{0, 1, 2, 3, 8, 10, 11},
+		},
+		"reversed range": {
+			in:     "3-1",
+			expErr: errors.New("invalid cpu list"),
+		},
+		"garbage": {
+			in:     "a-b",
+			expErr: errors.New("invalid cpu list"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotCPUs, gotErr := parseCPUList(tc.in)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCPUs, gotCPUs); diff != "" {
+				t.Fatalf("unexpected cpus (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_parseIsolationParams(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in     string
+		expCIR *CoreIsolationReport
+		expErr error
+	}{
+		"no isolation": {
+			in:     "BOOT_IMAGE=/vmlinuz root=/dev/sda1 quiet\n",
+			expCIR: &CoreIsolationReport{},
+		},
+		"all params": {
+			in: "root=/dev/sda1 isolcpus=2-5 nohz_full=2-5,8 rcu_nocbs=2-3\n",
+			expCIR: &CoreIsolationReport{
+				IsolCPUs: []int{2, 3, 4, 5},
+				NoHzFull: []int{2, 3, 4, 5, 8},
+				RcuNoCbs: []int{2, 3},
+			},
+		},
+		"isolcpus with flags": {
+			in: "isolcpus=domain,managed_irq,1,4-5",
+			expCIR: &CoreIsolationReport{
+				IsolCPUs: []int{1, 4, 5},
+			},
+		},
+		"bad nohz_full": {
+			in:     "nohz_full=x",
+			expErr: errors.New("parsing nohz_full"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotCIR := new(CoreIsolationReport)
+			gotErr := parseIsolationParams(strings.NewReader(tc.in), gotCIR)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCIR, gotCIR); diff != "" {
+				t.Fatalf("unexpected params (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_parseInterruptCounts(t *testing.T) {
+	in := `           CPU0       CPU1       CPU2       CPU3
+  0:         22          0          0          0   IO-APIC   2-edge      timer
+ 24:          0       9000          0        100   PCI-MSI 1048576-edge      nvme0q0
+NMI:          1          2          3          4   Non-maskable interrupts
+ERR:          0
+`
+	gotCounts, err := parseInterruptCounts(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expCounts := map[int]uint64{0: 23, 1: 9002, 2: 3, 3: 104}
+	if diff := cmp.Diff(expCounts, gotCounts); diff != "" {
+		t.Fatalf("unexpected counts (-want, +got):\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]int{1}, irqHeavyCores(gotCounts)); diff != "" {
+		t.Fatalf("unexpected heavy cores (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_getCoreIsolationReport(t *testing.T) {
+	interrupts := `           CPU0       CPU1       CPU2       CPU3       CPU4       CPU5       CPU6       CPU7
+  0:        100        100        100        100        100        100        100        100   IO-APIC   timer
+ 24:          0          0          0          0       9000          0          0          0   PCI-MSI   nvme0q0
+`
+	numaNode := uint(1)
+
+	for name, tc := range map[string]struct {
+		cmdline     string
+		engineCfgs  []*engine.Config
+		expEngines  []*EngineCoreIsolation
+		expWarnings []string
+		expErr      error
+	}{
+		"no isolation; service core quiet": {
+			cmdline: "root=/dev/sda1",
+			engineCfgs: []*engine.Config{
+				engine.NewConfig().WithTargetCount(2).WithHelperStreamCount(0),
+			},
+			expEngines: []*EngineCoreIsolation{
+				{
+					EngineCores: []int{0, 1, 2},
+					ServiceIrqs: []int{},
+				},
+			},
+			expWarnings: []string{},
+		},
+		"partially isolated; service core irq heavy": {
+			cmdline: "isolcpus=5-7 nohz_full=4-7",
+			engineCfgs: []*engine.Config{
+				engine.NewConfig().WithTargetCount(2).WithHelperStreamCount(0).
+					WithServiceThreadCore(4),
+			},
+			expEngines: []*EngineCoreIsolation{
+				{
+					ServiceCore:    4,
+					EngineCores:    []int{4, 5, 6},
+					NotIsolated:    []int{4},
+					NotNoHzFull:    []int{},
+					ServiceIrqs:    []int{24},
+					ServiceIrqBusy: true,
+				},
+			},
+			expWarnings: []string{
+				"engine 0: cores [4] not in kernel isolcpus list",
+				"engine 0: service threads on core 4 share the core with heavy IRQ activity (IRQs [24] have affinity to the core)",
+			},
+		},
+		"pinned numa node": {
+			cmdline: "isolcpus=4-7",
+			engineCfgs: []*engine.Config{
+				engine.NewConfig().WithTargetCount(2).WithHelperStreamCount(1).
+					WithServiceThreadCore(0).WithPinnedNumaNode(&numaNode),
+			},
+			expEngines: []*EngineCoreIsolation{
+				{
+					ServiceCore:    4,
+					EngineCores:    []int{4, 5, 6, 7},
+					NotIsolated:    []int{},
+					ServiceIrqs:    []int{24},
+					ServiceIrqBusy: true,
+				},
+			},
+			expWarnings: []string{
+				"engine 0: service threads on core 4 share the core with heavy IRQ activity (IRQs [24] have affinity to the core)",
+			},
+		},
+		"too many cores for numa node": {
+			engineCfgs: []*engine.Config{
+				engine.NewConfig().WithTargetCount(4).WithHelperStreamCount(0).
+					WithPinnedNumaNode(&numaNode),
+			},
+			expErr: errors.New("engine 0: engine needs 5 cores"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testRoot, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+			sysRoot := filepath.Join(testRoot, "sys")
+			procRoot := filepath.Join(testRoot, "proc")
+
+			writeTestFile(t, filepath.Join(procRoot, "cmdline"), tc.cmdline+"\n")
+			writeTestFile(t, filepath.Join(procRoot, "interrupts"), interrupts)
+			writeTestFile(t, filepath.Join(procRoot, "irq/0/smp_affinity_list"), "0-7\n")
+			writeTestFile(t, filepath.Join(procRoot, "irq/24/smp_affinity_list"), "4\n")
+			writeTestFile(t, filepath.Join(sysRoot, "devices/system/node/node1/cpulist"), "4-7\n")
+
+			cfg := config.DefaultServer().WithEngines(tc.engineCfgs...)
+
+			gotCIR, gotErr := getCoreIsolationReport(procRoot, sysRoot, cfg)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expEngines, gotCIR.Engines); diff != "" {
+				t.Fatalf("unexpected engines (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expWarnings, gotCIR.Warnings); diff != "" {
+				t.Fatalf("unexpected warnings (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
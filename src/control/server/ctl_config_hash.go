@@ -0,0 +1,87 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// configHashInterval is the interval at which a server rehashes its config
+// file so that edits are reported before the server is restarted.
+const configHashInterval = time.Minute
+
+// configHash holds the hash of the server config file, which is reported to
+// the MS replicas with each heartbeat so that servers whose config differs
+// from the rest of the system can be identified. The file is reloaded rather
+// than the running config hashed as the running config is modified during
+// validation, and the hash must match that of an unmodified reference file.
+type configHash struct {
+	sync.RWMutex
+	log  logging.Logger
+	path string
+	hash string
+}
+
+func newConfigHash(log logging.Logger, path string) *configHash {
+	return &configHash{
+		log:  log,
+		path: path,
+	}
+}
+
+// get returns the latest hash, or an empty string if the config file has not
+// been hashed.
+func (ch *configHash) get() string {
+	ch.RLock()
+	defer ch.RUnlock()
+
+	return ch.hash
+}
+
+// update rehashes the config file. The previous hash is retained if the file
+// cannot be loaded.
+func (ch *configHash) update() {
+	hash, err := config.HashFile(ch.path)
+	if err != nil {
+		ch.log.Debugf("failed to hash config file %s: %s", ch.path, err)
+		return
+	}
+
+	ch.Lock()
+	defer ch.Unlock()
+
+	if ch.hash != "" && ch.hash != hash {
+		ch.log.Infof("config file %s has changed since it was last hashed", ch.path)
+	}
+	ch.hash = hash
+}
+
+func (ch *configHash) startLoop(ctx context.Context) {
+	ch.log.Debug("starting configHashLoop")
+	ch.update()
+	go ch.loop(ctx)
+}
+
+func (ch *configHash) loop(parent context.Context) {
+	hashTimer := time.NewTicker(configHashInterval)
+	defer hashTimer.Stop()
+
+	for {
+		select {
+		case <-parent.Done():
+			ch.log.Debug("stopped configHashLoop")
+			return
+		case <-hashTimer.C:
+			ch.update()
+		}
+	}
+}
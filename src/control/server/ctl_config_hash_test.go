@@ -0,0 +1,63 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_ControlService_Heartbeat_ConfigHash(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	cfgPath := filepath.Join(testDir, "daos_server.yml")
+	writeCfg := func(content string) {
+		if err := ioutil.WriteFile(cfgPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	heartbeatHash := func(cs *ControlService) string {
+		resp, err := cs.Heartbeat(context.TODO(), &ctlpb.HeartbeatReq{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.ConfigHash
+	}
+
+	cs := &ControlService{cfgHash: newConfigHash(log, cfgPath)}
+	common.AssertEqual(t, "", heartbeatHash(cs), "hash reported before config hashed")
+
+	writeCfg("name: daos_server\nnr_hugepages: 4096\n")
+	cs.cfgHash.update()
+	first := heartbeatHash(cs)
+	common.AssertTrue(t, first != "", "no hash reported after config hashed")
+
+	// The hash is of the loaded config so formatting changes are ignored.
+	writeCfg("# comment\nname:   daos_server\nnr_hugepages: 4096\n")
+	cs.cfgHash.update()
+	common.AssertEqual(t, first, heartbeatHash(cs), "hash changed by formatting")
+
+	writeCfg("name: daos_server\nnr_hugepages: 8192\n")
+	cs.cfgHash.update()
+	second := heartbeatHash(cs)
+	common.AssertTrue(t, second != first, "hash unchanged by edit")
+
+	// An invalid file retains the previous hash.
+	writeCfg("bad_key: true\n")
+	cs.cfgHash.update()
+	common.AssertEqual(t, second, heartbeatHash(cs), "hash changed by invalid config")
+}
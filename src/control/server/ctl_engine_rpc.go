@@ -0,0 +1,182 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+// EngineStats implements the method defined for the Control Service.
+//
+// Retrieve runtime scheduling statistics for each xstream of the I/O Engine
+// instances running on this host.
+func (svc *ControlService) EngineStats(ctx context.Context, req *ctlpb.EngineStatsReq) (*ctlpb.EngineStatsResp, error) {
+	svc.log.Debugf("CtlSvc.EngineStats dispatch, req:%+v\n", req)
+
+	if !svc.harness.isStarted() {
+		return nil, FaultHarnessNotStarted
+	}
+	if len(svc.harness.readyRanks()) == 0 {
+		return nil, FaultDataPlaneNotStarted
+	}
+
+	resp := new(ctlpb.EngineStatsResp)
+	for _, srv := range svc.harness.Instances() {
+		if !srv.isReady() {
+			svc.log.Debugf("skipping not-ready instance")
+			continue
+		}
+
+		srvRank, err := srv.GetRank()
+		if err != nil {
+			return nil, err
+		}
+		if !queryRank(req.GetRank(), srvRank) {
+			continue
+		}
+
+		xsResp, err := srv.getXstreamStats(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rank %d", srvRank)
+		}
+
+		resp.Ranks = append(resp.Ranks, &ctlpb.EngineStatsResp_RankResp{
+			Rank:     srvRank.Uint32(),
+			Xstreams: xsResp.Xstreams,
+		})
+	}
+
+	svc.log.Debugf("CtlSvc.EngineStats dispatch, resp:%+v\n", resp)
+	return resp, nil
+}
+
+// PoolQueryTargets implements the method defined for the Control Service.
+//
+// Retrieve state and space usage of the targets of a pool which are local to
+// the I/O Engine instances running on this host. Instances which do not host
+// any of the pool's targets are omitted from the response.
+func (svc *ControlService) PoolQueryTargets(ctx context.Context, req *ctlpb.PoolQueryTargetsReq) (*ctlpb.PoolQueryTargetsResp, error) {
+	svc.log.Debugf("CtlSvc.PoolQueryTargets dispatch, req:%+v\n", req)
+
+	if !svc.harness.isStarted() {
+		return nil, FaultHarnessNotStarted
+	}
+	if len(svc.harness.readyRanks()) == 0 {
+		return nil, FaultDataPlaneNotStarted
+	}
+
+	resp := new(ctlpb.PoolQueryTargetsResp)
+	for _, srv := range svc.harness.Instances() {
+		if !srv.isReady() {
+			svc.log.Debugf("skipping not-ready instance")
+			continue
+		}
+
+		srvRank, err := srv.GetRank()
+		if err != nil {
+			return nil, err
+		}
+		if !queryRank(req.GetRank(), srvRank) {
+			continue
+		}
+
+		tgtResp, err := srv.getPoolTargets(ctx, req.GetUuid())
+		if err != nil {
+			return nil, errors.Wrapf(err, "rank %d", srvRank)
+		}
+		if tgtResp == nil {
+			continue
+		}
+
+		rResp := &ctlpb.PoolQueryTargetsResp_RankResp{
+			Rank:    srvRank.Uint32(),
+			Targets: tgtResp.Targets,
+		}
+		if req.GetShowDevices() {
+			rResp.Devices, err = svc.poolTargetDevices(ctx, srv, tgtResp.Targets)
+			if err != nil {
+				return nil, errors.Wrapf(err, "rank %d", srvRank)
+			}
+		}
+		resp.Ranks = append(resp.Ranks, rResp)
+	}
+
+	svc.log.Debugf("CtlSvc.PoolQueryTargets dispatch, resp:%+v\n", resp)
+	return resp, nil
+}
+
+// poolTargetDevices resolves the NVMe SSD and SCM namespace backing each of
+// the given targets of an engine. Serial numbers and namespace UUIDs are taken
+// from the (cached) storage scan results and are omitted if the scan fails.
+func (svc *ControlService) poolTargetDevices(ctx context.Context, srv *EngineInstance, tgts []*ctlpb.PoolTargetInfo) ([]*ctlpb.PoolTargetDevice, error) {
+	listDevsResp, err := srv.listSmdDevices(ctx, new(ctlpb.SmdDevReq))
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make(map[string]string)
+	if len(listDevsResp.Devices) > 0 {
+		nvmeResp, err := svc.NvmeScan(bdev.ScanRequest{})
+		if err != nil {
+			svc.log.Debugf("nvme scan for target devices: %s", err)
+		} else {
+			for _, c := range nvmeResp.Controllers {
+				serials[c.PciAddr] = c.Serial
+			}
+		}
+	}
+
+	tgtDevs := make(map[int32]*ctlpb.SmdDevResp_Device)
+	for _, dev := range listDevsResp.Devices {
+		for _, tgtID := range dev.TgtIds {
+			tgtDevs[tgtID] = dev
+		}
+	}
+
+	scmCfg := srv.scmConfig()
+	var scmBlockdev, scmNamespace string
+	if scmCfg.Class == storage.ScmClassDCPM && len(scmCfg.DeviceList) > 0 {
+		scmBlockdev = filepath.Base(scmCfg.DeviceList[0])
+
+		scmResp, err := svc.ScmScan(scm.ScanRequest{})
+		if err != nil {
+			svc.log.Debugf("scm scan for target devices: %s", err)
+		} else {
+			for _, ns := range scmResp.Namespaces {
+				if ns.BlockDevice == scmBlockdev {
+					scmNamespace = ns.UUID
+				}
+			}
+		}
+	}
+
+	devices := make([]*ctlpb.PoolTargetDevice, 0, len(tgts))
+	for _, tgt := range tgts {
+		td := &ctlpb.PoolTargetDevice{
+			TgtIdx:       tgt.TgtIdx,
+			ScmBlockdev:  scmBlockdev,
+			ScmNamespace: scmNamespace,
+			ScmMount:     scmCfg.MountPoint,
+		}
+		if dev, found := tgtDevs[int32(tgt.TgtIdx)]; found {
+			td.NvmeUuid = dev.Uuid
+			td.NvmePciAddr = dev.TrAddr
+			td.NvmeSerial = serials[dev.TrAddr]
+		}
+		devices = append(devices, td)
+	}
+
+	return devices, nil
+}
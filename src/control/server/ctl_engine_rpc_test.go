@@ -0,0 +1,517 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_CtlSvc_EngineStats(t *testing.T) {
+	mockXstreams := func(tgtID int32) []*ctlpb.XstreamStats {
+		return []*ctlpb.XstreamStats{
+			{
+				XsId:      0,
+				TgtId:     -1,
+				Name:      "daos_sys_0",
+				TotalTime: 1000,
+				RelaxTime: 900,
+				Cycles:    42,
+			},
+			{
+				XsId:       2,
+				TgtId:      tgtID,
+				Name:       "daos_io_0",
+				Main:       true,
+				UltsQueued: 3,
+				TotalTime:  1000,
+				RelaxTime:  100,
+				Cycles:     4242,
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req            *ctlpb.EngineStatsReq
+		junkResp       bool
+		drpcResps      map[int][]*mockDrpcResponse
+		harnessStopped bool
+		ioStopped      bool
+		expResp        *ctlpb.EngineStatsResp
+		expErr         error
+	}{
+		"dRPC send fails": {
+			req: &ctlpb.EngineStatsReq{Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.XstreamStatsReq{},
+						Error:   errors.New("send failure"),
+					},
+				},
+			},
+			expErr: errors.New("send failure"),
+		},
+		"dRPC resp fails": {
+			req:      &ctlpb.EngineStatsReq{Rank: uint32(system.NilRank)},
+			junkResp: true,
+			expErr:   errors.New("unmarshal"),
+		},
+		"DAOS failure": {
+			req: &ctlpb.EngineStatsReq{Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.XstreamStatsResp{
+							Status: int32(drpc.DaosNoSpace),
+						},
+					},
+				},
+			},
+			expErr: drpc.DaosNoSpace,
+		},
+		"all ranks": {
+			req: &ctlpb.EngineStatsReq{Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.XstreamStatsResp{
+							Xstreams: mockXstreams(0),
+						},
+					},
+				},
+				1: {
+					{
+						Message: &ctlpb.XstreamStatsResp{
+							Xstreams: mockXstreams(0),
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.EngineStatsResp{
+				Ranks: []*ctlpb.EngineStatsResp_RankResp{
+					{Rank: 0, Xstreams: mockXstreams(0)},
+					{Rank: 1, Xstreams: mockXstreams(0)},
+				},
+			},
+		},
+		"single rank": {
+			req: &ctlpb.EngineStatsReq{Rank: 1},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {},
+				1: {
+					{
+						Message: &ctlpb.XstreamStatsResp{
+							Xstreams: mockXstreams(0),
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.EngineStatsResp{
+				Ranks: []*ctlpb.EngineStatsResp_RankResp{
+					{Rank: 1, Xstreams: mockXstreams(0)},
+				},
+			},
+		},
+		"harness not started": {
+			req:            &ctlpb.EngineStatsReq{},
+			harnessStopped: true,
+			expErr:         FaultHarnessNotStarted,
+		},
+		"i/o engine not started": {
+			req:       &ctlpb.EngineStatsReq{},
+			ioStopped: true,
+			expErr:    FaultDataPlaneNotStarted,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			engineCount := len(tc.drpcResps)
+			if engineCount == 0 {
+				engineCount = 1
+			}
+
+			cfg := config.DefaultServer()
+			for i := 0; i < engineCount; i++ {
+				cfg.Engines = append(cfg.Engines, engine.NewConfig().WithTargetCount(1).WithRank(uint32(i)))
+			}
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc.harness.started.SetTrue()
+
+			for i, srv := range svc.harness.instances {
+				cfg := new(mockDrpcClientConfig)
+				if tc.junkResp {
+					cfg.setSendMsgResponse(drpc.Status_SUCCESS, makeBadBytes(42), nil)
+				} else if len(tc.drpcResps) > i {
+					for _, mock := range tc.drpcResps[i] {
+						cfg.setSendMsgResponseList(t, mock)
+					}
+				}
+				srv.setDrpcClient(newMockDrpcClient(cfg))
+				srv.ready.SetTrue()
+			}
+			if tc.harnessStopped {
+				svc.harness.started.SetFalse()
+			}
+			if tc.ioStopped {
+				for _, srv := range svc.harness.instances {
+					srv.ready.SetFalse()
+				}
+			}
+
+			gotResp, gotErr := svc.EngineStats(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_PoolQueryTargets(t *testing.T) {
+	mockTargets := func(state string) []*ctlpb.PoolTargetInfo {
+		return []*ctlpb.PoolTargetInfo{
+			{
+				TgtIdx:    0,
+				State:     "UPIN",
+				ScmTotal:  1000,
+				ScmFree:   500,
+				NvmeTotal: 10000,
+				NvmeFree:  9000,
+			},
+			{
+				TgtIdx:    1,
+				State:     state,
+				ScmTotal:  1000,
+				ScmFree:   100,
+				NvmeTotal: 10000,
+				NvmeFree:  1000,
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req            *ctlpb.PoolQueryTargetsReq
+		junkResp       bool
+		drpcResps      map[int][]*mockDrpcResponse
+		harnessStopped bool
+		ioStopped      bool
+		expResp        *ctlpb.PoolQueryTargetsResp
+		expErr         error
+	}{
+		"dRPC send fails": {
+			req: &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.PoolTargetsReq{},
+						Error:   errors.New("send failure"),
+					},
+				},
+			},
+			expErr: errors.New("send failure"),
+		},
+		"dRPC resp fails": {
+			req:      &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: uint32(system.NilRank)},
+			junkResp: true,
+			expErr:   errors.New("unmarshal"),
+		},
+		"DAOS failure": {
+			req: &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Status: int32(drpc.DaosNoSpace),
+						},
+					},
+				},
+			},
+			expErr: drpc.DaosNoSpace,
+		},
+		"pool not on rank": {
+			req: &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Status: int32(drpc.DaosNonexistant),
+						},
+					},
+				},
+				1: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Targets: mockTargets("DOWN"),
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.PoolQueryTargetsResp{
+				Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+					{Rank: 1, Targets: mockTargets("DOWN")},
+				},
+			},
+		},
+		"all ranks": {
+			req: &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: uint32(system.NilRank)},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Targets: mockTargets("UPIN"),
+						},
+					},
+				},
+				1: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Targets: mockTargets("DOWN"),
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.PoolQueryTargetsResp{
+				Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+					{Rank: 0, Targets: mockTargets("UPIN")},
+					{Rank: 1, Targets: mockTargets("DOWN")},
+				},
+			},
+		},
+		"single rank": {
+			req: &ctlpb.PoolQueryTargetsReq{Uuid: common.MockUUID(), Rank: 1},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {},
+				1: {
+					{
+						Message: &ctlpb.PoolTargetsResp{
+							Targets: mockTargets("UPIN"),
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.PoolQueryTargetsResp{
+				Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+					{Rank: 1, Targets: mockTargets("UPIN")},
+				},
+			},
+		},
+		"harness not started": {
+			req:            &ctlpb.PoolQueryTargetsReq{},
+			harnessStopped: true,
+			expErr:         FaultHarnessNotStarted,
+		},
+		"i/o engine not started": {
+			req:       &ctlpb.PoolQueryTargetsReq{},
+			ioStopped: true,
+			expErr:    FaultDataPlaneNotStarted,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			engineCount := len(tc.drpcResps)
+			if engineCount == 0 {
+				engineCount = 1
+			}
+
+			cfg := config.DefaultServer()
+			for i := 0; i < engineCount; i++ {
+				cfg.Engines = append(cfg.Engines, engine.NewConfig().WithTargetCount(2).WithRank(uint32(i)))
+			}
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc.harness.started.SetTrue()
+
+			for i, srv := range svc.harness.instances {
+				cfg := new(mockDrpcClientConfig)
+				if tc.junkResp {
+					cfg.setSendMsgResponse(drpc.Status_SUCCESS, makeBadBytes(42), nil)
+				} else if len(tc.drpcResps) > i {
+					for _, mock := range tc.drpcResps[i] {
+						cfg.setSendMsgResponseList(t, mock)
+					}
+				}
+				srv.setDrpcClient(newMockDrpcClient(cfg))
+				srv.ready.SetTrue()
+			}
+			if tc.harnessStopped {
+				svc.harness.started.SetFalse()
+			}
+			if tc.ioStopped {
+				for _, srv := range svc.harness.instances {
+					srv.ready.SetFalse()
+				}
+			}
+
+			gotResp, gotErr := svc.PoolQueryTargets(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_PoolQueryTargets_showDevices(t *testing.T) {
+	targets := []*ctlpb.PoolTargetInfo{
+		{TgtIdx: 0, State: "UPIN"},
+		{TgtIdx: 1, State: "DOWN"},
+		{TgtIdx: 2, State: "UPIN"},
+	}
+	ctrlr := storage.MockNvmeController(1)
+
+	for name, tc := range map[string]struct {
+		nvmeScanErr error
+		expDevices  []*ctlpb.PoolTargetDevice
+	}{
+		"devices resolved": {
+			expDevices: []*ctlpb.PoolTargetDevice{
+				{
+					TgtIdx:       0,
+					NvmeUuid:     common.MockUUID(1),
+					NvmePciAddr:  ctrlr.PciAddr,
+					NvmeSerial:   ctrlr.Serial,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+				{
+					TgtIdx:       1,
+					NvmeUuid:     common.MockUUID(1),
+					NvmePciAddr:  ctrlr.PciAddr,
+					NvmeSerial:   ctrlr.Serial,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+				{
+					// target without an NVMe device
+					TgtIdx:       2,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+			},
+		},
+		"nvme scan fails": {
+			nvmeScanErr: errors.New("scan failed"),
+			expDevices: []*ctlpb.PoolTargetDevice{
+				{
+					TgtIdx:       0,
+					NvmeUuid:     common.MockUUID(1),
+					NvmePciAddr:  ctrlr.PciAddr,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+				{
+					TgtIdx:       1,
+					NvmeUuid:     common.MockUUID(1),
+					NvmePciAddr:  ctrlr.PciAddr,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+				{
+					TgtIdx:       2,
+					ScmBlockdev:  "pmem1",
+					ScmNamespace: common.MockUUID(1),
+					ScmMount:     "/mnt/daos",
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer().WithEngines(
+				engine.NewConfig().
+					WithRank(0).
+					WithTargetCount(3).
+					WithScmClass("dcpm").
+					WithScmDeviceList("/dev/pmem1").
+					WithScmMountPoint("/mnt/daos"),
+			)
+			svc := mockControlService(t, log, cfg,
+				&bdev.MockBackendConfig{
+					ScanRes: &bdev.ScanResponse{
+						Controllers: storage.NvmeControllers{ctrlr},
+					},
+					ScanErr: tc.nvmeScanErr,
+				},
+				&scm.MockBackendConfig{
+					DiscoverRes:         storage.ScmModules{storage.MockScmModule()},
+					GetPmemNamespaceRes: storage.ScmNamespaces{storage.MockScmNamespace(1)},
+					StartingState:       storage.ScmStateNoCapacity,
+				}, nil)
+			svc.harness.started.SetTrue()
+
+			srv := svc.harness.instances[0]
+			dcc := new(mockDrpcClientConfig)
+			dcc.setSendMsgResponseList(t, &mockDrpcResponse{
+				Message: &ctlpb.PoolTargetsResp{Targets: targets},
+			})
+			dcc.setSendMsgResponseList(t, &mockDrpcResponse{
+				Message: &ctlpb.SmdDevResp{
+					Devices: []*ctlpb.SmdDevResp_Device{
+						{
+							Uuid:   common.MockUUID(1),
+							TgtIds: []int32{0, 1},
+							TrAddr: ctrlr.PciAddr,
+						},
+					},
+				},
+			})
+			srv.setDrpcClient(newMockDrpcClient(dcc))
+			srv.ready.SetTrue()
+
+			gotResp, gotErr := svc.PoolQueryTargets(context.TODO(), &ctlpb.PoolQueryTargetsReq{
+				Uuid:        common.MockUUID(),
+				Rank:        uint32(system.NilRank),
+				ShowDevices: true,
+			})
+			if gotErr != nil {
+				t.Fatal(gotErr)
+			}
+
+			expResp := &ctlpb.PoolQueryTargetsResp{
+				Ranks: []*ctlpb.PoolQueryTargetsResp_RankResp{
+					{Rank: 0, Targets: targets, Devices: tc.expDevices},
+				},
+			}
+			if diff := cmp.Diff(expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
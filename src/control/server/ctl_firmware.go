@@ -143,6 +143,7 @@ func (svc *ControlService) updateSCM(pbReq *ctlpb.FirmwareUpdateReq, pbResp *ctl
 		FirmwareRev:  pbReq.FirmwareRev,
 		ModelID:      pbReq.ModelID,
 		DeviceUIDs:   pbReq.DeviceIDs,
+		Activate:     pbReq.Activate,
 	})
 	if err != nil {
 		return err
@@ -161,6 +162,10 @@ func (svc *ControlService) updateSCM(pbReq *ctlpb.FirmwareUpdateReq, pbResp *ctl
 }
 
 func (svc *ControlService) updateNVMe(pbReq *ctlpb.FirmwareUpdateReq, pbResp *ctlpb.FirmwareUpdateResp) error {
+	if pbReq.Activate {
+		return errors.New("firmware activation is only supported for SCM devices")
+	}
+
 	updateResp, err := svc.bdev.UpdateFirmware(bdev.FirmwareUpdateRequest{
 		FirmwarePath: pbReq.FirmwarePath,
 		FirmwareRev:  pbReq.FirmwareRev,
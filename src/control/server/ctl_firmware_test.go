@@ -619,6 +619,40 @@ func TestCtlSvc_FirmwareUpdate(t *testing.T) {
 				},
 			},
 		},
+		"SCM - staged version reported": {
+			req: ctlpb.FirmwareUpdateReq{
+				Type:         ctlpb.FirmwareUpdateReq_SCM,
+				FirmwarePath: "/some/path",
+				DeviceIDs:    []string{"Device1"},
+			},
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: mockSCM,
+				GetFirmwareStatusRes: &storage.ScmFirmwareInfo{
+					ActiveVersion: "FWRev1",
+					StagedVersion: "FWRev9",
+					UpdateStatus:  storage.ScmUpdateStatusStaged,
+				},
+			},
+			expResp: &ctlpb.FirmwareUpdateResp{
+				ScmResults: []*ctlpb.ScmFirmwareUpdateResp{
+					{
+						Module:        mockPbSCM[1],
+						StagedVersion: "FWRev9",
+					},
+				},
+			},
+		},
+		"NVMe - activate not supported": {
+			req: ctlpb.FirmwareUpdateReq{
+				Type:         ctlpb.FirmwareUpdateReq_NVMe,
+				FirmwarePath: "/some/path",
+				Activate:     true,
+			},
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes: &bdev.ScanResponse{Controllers: mockNVMe},
+			},
+			expErr: errors.New("only supported for SCM"),
+		},
 		"SCM - filter by FW rev": {
 			req: ctlpb.FirmwareUpdateReq{
 				Type:         ctlpb.FirmwareUpdateReq_SCM,
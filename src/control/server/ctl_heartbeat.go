@@ -0,0 +1,27 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+)
+
+// Heartbeat returns the wall clock time of the server so that the MS replicas
+// can measure the clock skew between servers, along with the hash of the
+// server config file so that config drift can be detected.
+func (c *ControlService) Heartbeat(_ context.Context, _ *ctlpb.HeartbeatReq) (*ctlpb.HeartbeatResp, error) {
+	resp := &ctlpb.HeartbeatResp{Time: time.Now().UnixNano()}
+	if c.cfgHash != nil {
+		resp.ConfigHash = c.cfgHash.get()
+	}
+
+	return resp, nil
+}
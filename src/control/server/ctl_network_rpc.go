@@ -7,6 +7,7 @@
 package server
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -57,8 +58,48 @@ func (c *ControlService) NetworkScan(ctx context.Context, req *ctlpb.NetworkScan
 	resp.Numacount = int32(netdetect.NumNumaNodes(netCtx))
 	resp.Corespernuma = int32(netdetect.CoresPerNuma(netCtx))
 
+	if c.discover != nil {
+		devices := make([]string, 0, len(resp.Interfaces))
+		for _, fi := range resp.Interfaces {
+			devices = append(devices, fi.Device)
+		}
+		dd, err := c.discover(ctx, devices)
+		if err != nil {
+			// discovery data is supplementary, don't fail the scan
+			c.log.Errorf("hardware discovery plugin: %s", err)
+		} else {
+			applyDiscoveryData(resp, dd)
+		}
+	}
+
 	c.log.Debugf("NetworkScanResp: %d NUMA nodes with %d cores each",
 		resp.GetNumacount(), resp.GetCorespernuma())
 
 	return resp, nil
 }
+
+// applyDiscoveryData merges site-specific hardware discovery data into the
+// supplied network scan response.
+func applyDiscoveryData(resp *ctlpb.NetworkScanResp, dd *discoveryData) {
+	if dd == nil {
+		return
+	}
+
+	for _, fi := range resp.Interfaces {
+		fi.Alias = dd.InterfaceAliases[fi.Device]
+	}
+
+	names := make([]string, 0, len(dd.Labels))
+	for name := range dd.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp.Labels = make([]*ctlpb.HostLabel, 0, len(names))
+	for _, name := range names {
+		resp.Labels = append(resp.Labels, &ctlpb.HostLabel{
+			Name:  name,
+			Value: dd.Labels[name],
+		})
+	}
+}
@@ -157,9 +157,11 @@ func (svc *ControlService) memberStateResults(instances []*EngineInstance, tgtSt
 // StopRanks implements the method defined for the Management Service.
 //
 // Stop data-plane instance(s) managed by control-plane identified by unique
-// rank(s). After attempting to stop instances through harness (when either all
-// instances are stopped or timeout has occurred), populate response results
-// based on local instance state.
+// rank(s). Instances are sent SIGTERM and given the longest of their shutdown
+// grace periods to exit, after which those configured to escalate are sent
+// SIGKILL. Forced stops send SIGKILL immediately. Once all instances are
+// stopped or the timeout has occurred, populate response results based on
+// local instance state.
 func (svc *ControlService) StopRanks(ctx context.Context, req *ctlpb.RanksReq) (*ctlpb.RanksResp, error) {
 	if req == nil {
 		return nil, errors.New("nil request")
@@ -169,7 +171,7 @@ func (svc *ControlService) StopRanks(ctx context.Context, req *ctlpb.RanksReq) (
 	}
 	svc.log.Debugf("MgmtSvc.StopRanks dispatch, req:%+v\n", *req)
 
-	signal := syscall.SIGINT
+	signal := syscall.SIGTERM
 	if req.Force {
 		signal = syscall.SIGKILL
 	}
@@ -192,16 +194,24 @@ func (svc *ControlService) StopRanks(ctx context.Context, req *ctlpb.RanksReq) (
 		}
 	}
 
-	// ignore poll results as we gather state immediately after
-	if _, err = pollInstanceState(ctx, instances,
-		func(s *EngineInstance) bool { return !s.isStarted() },
-		svc.harness.rankReqTimeout); err != nil {
+	timeout := svc.harness.rankReqTimeout
+	if !req.Force {
+		timeout = shutdownGracePeriod(instances)
+	}
+	isStopped := func(s *EngineInstance) bool { return !s.isStarted() }
 
+	stopped, err := pollInstanceState(ctx, instances, isStopped, timeout)
+	if err != nil {
 		return nil, err
 	}
+	if !stopped && !req.Force {
+		if err := svc.escalateStop(ctx, instances, timeout); err != nil {
+			return nil, err
+		}
+	}
 
 	results, err := svc.memberStateResults(instances, system.MemberStateStopped, "system stop",
-		"system stop: rank failed to stop within "+svc.harness.rankReqTimeout.String())
+		"system stop: rank failed to stop within "+timeout.String())
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +225,32 @@ func (svc *ControlService) StopRanks(ctx context.Context, req *ctlpb.RanksReq) (
 	return resp, nil
 }
 
+// escalateStop sends SIGKILL to the instances which are configured to escalate
+// and have not exited within their shutdown grace period, then waits for them
+// to exit.
+func (svc *ControlService) escalateStop(ctx context.Context, instances []*EngineInstance, gracePeriod time.Duration) error {
+	var killed []*EngineInstance
+	for _, srv := range instances {
+		if !srv.isStarted() || !srv.runner.GetConfig().Shutdown.Escalate {
+			continue
+		}
+		svc.log.Errorf("instance %d did not stop within %s, sending SIGKILL", srv.Index(),
+			gracePeriod)
+		if err := srv.Stop(syscall.SIGKILL); err != nil {
+			return errors.Wrapf(err, "sending %s", syscall.SIGKILL)
+		}
+		killed = append(killed, srv)
+	}
+	if len(killed) == 0 {
+		return nil
+	}
+
+	// ignore poll results as state is gathered immediately after
+	_, err := pollInstanceState(ctx, killed,
+		func(s *EngineInstance) bool { return !s.isStarted() }, svc.harness.rankReqTimeout)
+	return err
+}
+
 func (svc *ControlService) queryLocalRanks(ctx context.Context, req *ctlpb.RanksReq) ([]*system.MemberResult, error) {
 	if req.Force {
 		return svc.drpcOnLocalRanks(ctx, req, drpc.MethodPingRank)
@@ -280,9 +316,11 @@ func (svc *ControlService) PingRanks(ctx context.Context, req *ctlpb.RanksReq) (
 // by harness.
 //
 // Reset formatted state of data-plane instance(s) managed by control-plane
-// identified by unique rank(s). After attempting to reset instances through
-// harness (when either all instances are awaiting format or timeout has
-// occurred), populate response results based on local instance state.
+// identified by unique rank(s) by removing their superblock and SCM metadata
+// and, if requested, deallocating their NVMe SSDs. After attempting to reset
+// instances through harness (when either all instances are awaiting format or
+// timeout has occurred), populate response results based on local instance
+// state and any failure to remove metadata.
 func (svc *ControlService) ResetFormatRanks(ctx context.Context, req *ctlpb.RanksReq) (*ctlpb.RanksResp, error) {
 	if req == nil {
 		return nil, errors.New("nil request")
@@ -298,6 +336,7 @@ func (svc *ControlService) ResetFormatRanks(ctx context.Context, req *ctlpb.Rank
 	}
 
 	savedRanks := make(map[uint32]system.Rank) // instance idx to system rank
+	eraseErrs := make(map[uint32]error)        // instance idx to erase error
 	for _, srv := range instances {
 		rank, err := srv.GetRank()
 		if err != nil {
@@ -311,6 +350,11 @@ func (svc *ControlService) ResetFormatRanks(ctx context.Context, req *ctlpb.Rank
 		if err := srv.RemoveSuperblock(); err != nil {
 			return nil, err
 		}
+		if err := srv.removeScmMetadata(); err != nil {
+			eraseErrs[srv.Index()] = err
+		} else if req.GetNvmeTrim() {
+			eraseErrs[srv.Index()] = srv.trimBdevs(svc.bdev)
+		}
 		srv.requestStart(ctx)
 	}
 
@@ -324,9 +368,9 @@ func (svc *ControlService) ResetFormatRanks(ctx context.Context, req *ctlpb.Rank
 	// rank cannot be pulled from superblock so use saved value
 	results := make(system.MemberResults, 0, len(instances))
 	for _, srv := range instances {
-		var err error
+		err := eraseErrs[srv.Index()]
 		state := srv.LocalState()
-		if state != system.MemberStateAwaitFormat {
+		if err == nil && state != system.MemberStateAwaitFormat {
 			err = errors.Errorf("want %s, got %s", system.MemberStateAwaitFormat, state)
 		}
 
@@ -392,3 +436,52 @@ func (svc *ControlService) StartRanks(ctx context.Context, req *ctlpb.RanksReq)
 
 	return resp, nil
 }
+
+// ActivateSpare implements the method defined for the Management Service.
+//
+// Replace a stopped data-plane instance identified by rank with a spare
+// instance managed by the same control-plane. The spare joins the system as a
+// new rank, the storage devices of the failed instance are released from the
+// device ledger and the response is populated once the spare is ready or
+// a timeout has occurred.
+func (svc *ControlService) ActivateSpare(ctx context.Context, req *ctlpb.ActivateSpareReq) (*ctlpb.ActivateSpareResp, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	svc.log.Debugf("MgmtSvc.ActivateSpare dispatch, req:%+v\n", *req)
+
+	failed, spare, err := svc.harness.activateSpare(ctx, system.Rank(req.GetRank()))
+	if err != nil {
+		return nil, err
+	}
+
+	released := svc.ledger.releaseEngine(failed.Index())
+	if err := svc.ledger.save(); err != nil {
+		svc.log.Errorf("failed to save device ledger: %s", err)
+	}
+
+	// ignore poll results as we gather state immediately after
+	if _, err = pollInstanceState(ctx, []*EngineInstance{spare}, (*EngineInstance).isReady,
+		svc.harness.rankStartTimeout); err != nil {
+
+		return nil, err
+	}
+
+	// rank is assigned when the spare joins the system
+	spareRank, err := spare.GetRank()
+	if err != nil {
+		spareRank = system.NilRank
+	}
+
+	resp := &ctlpb.ActivateSpareResp{
+		SpareIdx:        spare.Index(),
+		FailedIdx:       failed.Index(),
+		ReleasedDevices: released,
+		State:           spare.LocalState().String(),
+		SpareRank:       spareRank.Uint32(),
+	}
+
+	svc.log.Debugf("MgmtSvc.ActivateSpare dispatch, resp:%+v\n", *resp)
+
+	return resp, nil
+}
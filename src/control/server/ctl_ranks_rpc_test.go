@@ -9,6 +9,7 @@ package server
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"testing"
@@ -28,6 +29,8 @@ import (
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -257,6 +260,7 @@ func TestServer_CtlSvc_StopRanks(t *testing.T) {
 		missingSB        bool
 		engineCount      int
 		instancesStopped bool
+		escalate         bool
 		req              *ctlpb.RanksReq
 		signal           os.Signal
 		signalErr        error
@@ -296,7 +300,16 @@ func TestServer_CtlSvc_StopRanks(t *testing.T) {
 		},
 		"instances started": { // unsuccessful result for kill
 			req:            &ctlpb.RanksReq{Ranks: "0-3"},
-			expSignalsSent: map[uint32]os.Signal{0: syscall.SIGINT, 1: syscall.SIGINT},
+			expSignalsSent: map[uint32]os.Signal{0: syscall.SIGTERM, 1: syscall.SIGTERM},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 1, State: msErrored, Errored: true},
+				{Rank: 2, State: msErrored, Errored: true},
+			},
+		},
+		"instances started; escalate after grace period": {
+			req:            &ctlpb.RanksReq{Ranks: "0-3"},
+			escalate:       true,
+			expSignalsSent: map[uint32]os.Signal{0: syscall.SIGKILL, 1: syscall.SIGKILL},
 			expResults: []*sharedpb.RankResult{
 				{Rank: 1, State: msErrored, Errored: true},
 				{Rank: 2, State: msErrored, Errored: true},
@@ -383,7 +396,8 @@ func TestServer_CtlSvc_StopRanks(t *testing.T) {
 						common.NormalExit)
 				}
 				trc.SignalErr = tc.signalErr
-				srv.runner = engine.NewTestRunner(trc, engine.NewConfig())
+				srv.runner = engine.NewTestRunner(trc, engine.NewConfig().
+					WithShutdown(50*time.Millisecond, tc.escalate, nil))
 				srv.setIndex(uint32(i))
 
 				srv._superblock.Rank = new(system.Rank)
@@ -645,6 +659,7 @@ func TestServer_CtlSvc_ResetFormatRanks(t *testing.T) {
 		engineCount      int
 		instancesStarted bool
 		startFails       bool
+		bmbc             *bdev.MockBackendConfig
 		req              *ctlpb.RanksReq
 		ctxTimeout       time.Duration
 		expResults       []*sharedpb.RankResult
@@ -684,6 +699,28 @@ func TestServer_CtlSvc_ResetFormatRanks(t *testing.T) {
 				{Rank: 2, State: msWaitFormat},
 			},
 		},
+		"instances reach wait format with nvme trim": {
+			req: &ctlpb.RanksReq{Ranks: "0-3", NvmeTrim: true},
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes:   &bdev.ScanResponse{},
+				FormatRes: &bdev.FormatResponse{},
+			},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 1, State: msWaitFormat},
+				{Rank: 2, State: msWaitFormat},
+			},
+		},
+		"nvme trim fails": {
+			req: &ctlpb.RanksReq{Ranks: "0-3", NvmeTrim: true},
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes:   &bdev.ScanResponse{},
+				FormatErr: errors.New("format failed"),
+			},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 1, State: msWaitFormat, Errored: true},
+				{Rank: 2, State: msWaitFormat, Errored: true},
+			},
+		},
 		"instances stay stopped": {
 			req:        &ctlpb.RanksReq{Ranks: "0-3"},
 			startFails: true,
@@ -707,7 +744,7 @@ func TestServer_CtlSvc_ResetFormatRanks(t *testing.T) {
 				engine.NewConfig().WithTargetCount(1),
 				engine.NewConfig().WithTargetCount(1),
 			)
-			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc := mockControlService(t, log, cfg, tc.bmbc, nil, nil)
 
 			for i, srv := range svc.harness.instances {
 				if tc.missingSB {
@@ -717,7 +754,16 @@ func TestServer_CtlSvc_ResetFormatRanks(t *testing.T) {
 
 				testDir, cleanup := common.CreateTestDir(t)
 				defer cleanup()
-				engineCfg := engine.NewConfig().WithScmMountPoint(testDir)
+				engineCfg := engine.NewConfig().WithScmMountPoint(testDir).
+					WithBdevClass("nvme").
+					WithBdevDeviceList(storage.MockNvmeController(int32(i)).PciAddr)
+
+				// engine metadata to be removed and raft db to be retained
+				for _, dir := range []string{"daos_sys", raftDirName} {
+					if err := os.Mkdir(filepath.Join(testDir, dir), 0755); err != nil {
+						t.Fatal(err)
+					}
+				}
 
 				trc := &engine.TestRunnerConfig{}
 				if tc.instancesStarted {
@@ -767,6 +813,17 @@ func TestServer_CtlSvc_ResetFormatRanks(t *testing.T) {
 			if diff := cmp.Diff(tc.expResults, gotResp.Results, defRankCmpOpts...); diff != "" {
 				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
 			}
+
+			for _, result := range gotResp.Results {
+				// instance index is one less than rank
+				mntPoint := svc.harness.instances[result.Rank-1].scmConfig().MountPoint
+				if _, err := os.Stat(filepath.Join(mntPoint, "daos_sys")); !os.IsNotExist(err) {
+					t.Fatalf("expected SCM metadata to be removed from %s (err: %v)", mntPoint, err)
+				}
+				if _, err := os.Stat(filepath.Join(mntPoint, raftDirName)); err != nil {
+					t.Fatalf("expected raft db to be retained in %s: %s", mntPoint, err)
+				}
+			}
 		})
 	}
 }
@@ -901,3 +958,166 @@ func TestServer_CtlSvc_StartRanks(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_CtlSvc_ActivateSpare(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req           *ctlpb.ActivateSpareReq
+		failedStarted bool
+		noSpare       bool
+		spareFails    bool
+		expResp       *ctlpb.ActivateSpareResp
+		expErr        error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"rank not found": {
+			req:    &ctlpb.ActivateSpareReq{Rank: 3},
+			expErr: errors.New("rank 3 not managed"),
+		},
+		"failed rank still running": {
+			req:           &ctlpb.ActivateSpareReq{Rank: 1},
+			failedStarted: true,
+			expErr:        FaultInstancesNotStopped("activate spare", 1),
+		},
+		"no spare": {
+			req:     &ctlpb.ActivateSpareReq{Rank: 1},
+			noSpare: true,
+			expErr:  FaultNoSpareEngine(1),
+		},
+		"spare stays stopped": {
+			req:        &ctlpb.ActivateSpareReq{Rank: 1},
+			spareFails: true,
+			expResp: &ctlpb.ActivateSpareResp{
+				SpareIdx:        1,
+				FailedIdx:       0,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateStopped.String(),
+				SpareRank:       uint32(system.NilRank),
+			},
+		},
+		"spare activated": {
+			req: &ctlpb.ActivateSpareReq{Rank: 1},
+			expResp: &ctlpb.ActivateSpareResp{
+				SpareIdx:        1,
+				FailedIdx:       0,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateReady.String(),
+				SpareRank:       4,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer().WithEngines(
+				engine.NewConfig().WithTargetCount(1),
+				engine.NewConfig().WithTargetCount(1),
+			)
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			if err := svc.ledger.claim(0, map[string]string{"0000:81:00.0": "nvme"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := svc.ledger.claim(1, map[string]string{"0000:82:00.0": "nvme"}); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			spareUUID := common.MockUUID(2)
+			for i, srv := range svc.harness.instances {
+				testDir, cleanup := common.CreateTestDir(t)
+				defer cleanup()
+				engineCfg := engine.NewConfig().WithScmMountPoint(testDir)
+
+				trc := &engine.TestRunnerConfig{}
+				if i == 0 && tc.failedStarted {
+					trc.Running.SetTrue()
+				}
+				srv.runner = engine.NewTestRunner(trc, engineCfg)
+				srv.setIndex(uint32(i))
+
+				superblock := &Superblock{
+					Version: superblockVersion,
+					UUID:    common.MockUUID(int32(i + 1)),
+					System:  "test",
+				}
+				if i == 0 {
+					superblock.Rank = new(system.Rank)
+					*superblock.Rank = system.Rank(1)
+					superblock.ValidRank = true
+
+					// mimic srv.Run, receive request to restart
+					go func(s *EngineInstance) {
+						select {
+						case <-ctx.Done():
+						case <-s.startRequested:
+						}
+					}(srv)
+				} else {
+					superblock.Spare = !tc.noSpare
+
+					// mimic srv.run, set "ready" on spare activation
+					srv.waitSpare.SetTrue()
+					go func(s *EngineInstance, startFails bool) {
+						select {
+						case <-ctx.Done():
+							return
+						case <-s.spareActivated:
+						}
+						s.waitSpare.SetFalse()
+						if startFails {
+							return
+						}
+						if err := s.runner.Start(ctx, make(chan error, 1)); err != nil {
+							return
+						}
+						// mimic rank assignment on joining the system
+						sb := *s.getSuperblock()
+						sb.Rank = system.NewRankPtr(4)
+						sb.ValidRank = true
+						s.setSuperblock(&sb)
+						s.ready.SetTrue()
+					}(srv, tc.spareFails)
+				}
+				srv.setSuperblock(superblock)
+				if err := srv.WriteSuperblock(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc.harness.rankStartTimeout = 50 * time.Millisecond
+
+			gotResp, gotErr := svc.ActivateSpare(ctx, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+
+			failed, spare := svc.harness.instances[0], svc.harness.instances[1]
+			if failed.getSuperblock() != nil {
+				t.Fatal("expected superblock of failed instance to be removed")
+			}
+			sb, err := ReadSuperblock(spare.superblockPath())
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, spareUUID, sb.UUID, "spare superblock UUID")
+			common.AssertTrue(t, sb.Rank == nil && !sb.ValidRank,
+				"expected spare to join as a new rank")
+			common.AssertTrue(t, !sb.Spare, "expected spare flag to be cleared")
+
+			expOwners := []*DeviceOwner{
+				{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+			}
+			if diff := cmp.Diff(expOwners, svc.ledger.Owners()); diff != "" {
+				t.Fatalf("unexpected ledger owners (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
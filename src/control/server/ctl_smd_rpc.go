@@ -9,7 +9,10 @@ package server
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -17,6 +20,8 @@ import (
 	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/hostlist"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
@@ -28,7 +33,109 @@ func queryRank(reqRank uint32, srvRank system.Rank) bool {
 	return rr.Equals(srvRank)
 }
 
+// hostInFilter returns true if the local hostname, either fully qualified or
+// short, is within the supplied hostlist.
+func hostInFilter(filter string) (bool, error) {
+	hl, err := hostlist.Create(filter)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid host filter")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return false, err
+	}
+	if _, found := hl.Find(hostname); found {
+		return true, nil
+	}
+	_, found := hl.Find(strings.Split(hostname, ".")[0])
+
+	return found, nil
+}
+
+// hasSmdDeviceFilter returns true if the request constrains devices by
+// attributes other than UUID, rank or target.
+func hasSmdDeviceFilter(req *ctlpb.SmdQueryReq) bool {
+	return req.StateFilter != "" || req.HostFilter != "" || req.ModelFilter != "" ||
+		hasHealthFilter(req)
+}
+
+// hasHealthFilter returns true if the request constrains devices by health.
+func hasHealthFilter(req *ctlpb.SmdQueryReq) bool {
+	return req.MinTemp > 0 || req.MinMediaErrs > 0 || req.MinIoErrs > 0
+}
+
+// smdDeviceModels returns the model of each NVMe controller keyed by PCI
+// address.
+func (svc *ControlService) smdDeviceModels() (map[string]string, error) {
+	nvmeResp, err := svc.NvmeScan(bdev.ScanRequest{})
+	if err != nil {
+		return nil, errors.Wrap(err, "nvme scan for device models")
+	}
+
+	models := make(map[string]string)
+	for _, c := range nvmeResp.Controllers {
+		models[c.PciAddr] = c.Model
+	}
+
+	return models, nil
+}
+
+// filterSmdDevices returns the devices of a rank which match the state, model
+// and health constraints in the request. Health is retrieved for the devices
+// only if the request constrains devices by health.
+func filterSmdDevices(ctx context.Context, req *ctlpb.SmdQueryReq, srv *EngineInstance, models map[string]string, devices []*ctlpb.SmdQueryResp_Device) ([]*ctlpb.SmdQueryResp_Device, error) {
+	filtered := make([]*ctlpb.SmdQueryResp_Device, 0, len(devices))
+	for _, dev := range devices {
+		if req.StateFilter != "" && !strings.EqualFold(dev.State, req.StateFilter) {
+			continue
+		}
+		if req.ModelFilter != "" && !strings.Contains(models[dev.TrAddr], req.ModelFilter) {
+			continue
+		}
+
+		if hasHealthFilter(req) {
+			health, err := srv.getBioHealth(ctx, &ctlpb.BioHealthReq{
+				DevUuid: dev.Uuid,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "device %s", dev)
+			}
+			ioErrs := health.BioReadErrs + health.BioWriteErrs +
+				health.BioUnmapErrs + health.ChecksumErrs
+			if health.Temperature < req.MinTemp ||
+				health.MediaErrs < req.MinMediaErrs || ioErrs < req.MinIoErrs {
+				continue
+			}
+			dev.Health = health
+		}
+
+		filtered = append(filtered, dev)
+	}
+
+	return filtered, nil
+}
+
 func (svc *ControlService) querySmdDevices(ctx context.Context, req *ctlpb.SmdQueryReq, resp *ctlpb.SmdQueryResp) error {
+	if req.HostFilter != "" {
+		match, err := hostInFilter(req.HostFilter)
+		if err != nil {
+			return err
+		}
+		if !match {
+			return nil
+		}
+	}
+
+	var models map[string]string
+	if req.ModelFilter != "" {
+		var err error
+		if models, err = svc.smdDeviceModels(); err != nil {
+			return err
+		}
+	}
+
+	rankSrvs := make(map[uint32]*EngineInstance)
 	for _, srv := range svc.harness.Instances() {
 		if !srv.isReady() {
 			svc.log.Debugf("skipping not-ready instance")
@@ -45,6 +152,7 @@ func (svc *ControlService) querySmdDevices(ctx context.Context, req *ctlpb.SmdQu
 
 		rResp := new(ctlpb.SmdQueryResp_RankResp)
 		rResp.Rank = srvRank.Uint32()
+		rankSrvs[rResp.Rank] = srv
 
 		listDevsResp, err := srv.listSmdDevices(ctx, new(ctlpb.SmdDevReq))
 		if err != nil {
@@ -91,12 +199,29 @@ func (svc *ControlService) querySmdDevices(ctx context.Context, req *ctlpb.SmdQu
 			}
 		}
 
-		if !req.IncludeBioHealth {
-			continue
+		rResp.Devices, err = filterSmdDevices(ctx, req, srv, models, rResp.Devices)
+		if err != nil {
+			return errors.Wrapf(err, "rank %d", srvRank)
+		}
+	}
+
+	if req.Cursor != "" || req.Limit > 0 {
+		if err := pageSmdDevices(resp, req.Cursor, req.Limit); err != nil {
+			return err
 		}
+	}
 
+	// only retrieve health for the devices being returned
+	for _, rResp := range resp.Ranks {
 		for _, dev := range rResp.Devices {
-			health, err := srv.getBioHealth(ctx, &ctlpb.BioHealthReq{
+			if !req.IncludeBioHealth {
+				dev.Health = nil
+				continue
+			}
+			if dev.Health != nil {
+				continue
+			}
+			health, err := rankSrvs[rResp.Rank].getBioHealth(ctx, &ctlpb.BioHealthReq{
 				DevUuid: dev.Uuid,
 			})
 			if err != nil {
@@ -108,6 +233,79 @@ func (svc *ControlService) querySmdDevices(ctx context.Context, req *ctlpb.SmdQu
 	return nil
 }
 
+// smdDeviceCursor returns the cursor identifying a device on a rank, devices
+// are paged in order of rank and then device UUID.
+func smdDeviceCursor(rank uint32, devUUID string) string {
+	return fmt.Sprintf("%d:%s", rank, devUUID)
+}
+
+func parseSmdDeviceCursor(cursor string) (uint32, string, error) {
+	fields := strings.SplitN(cursor, ":", 2)
+	if len(fields) != 2 {
+		return 0, "", errors.Errorf("invalid device cursor %q", cursor)
+	}
+	rank, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return 0, "", errors.Errorf("invalid device cursor %q", cursor)
+	}
+
+	return uint32(rank), fields[1], nil
+}
+
+// pageSmdDevices trims the devices in the response to those following the
+// supplied cursor, up to limit devices if nonzero. Ranks without devices in
+// the page are removed and the cursor for the next page is set in the
+// response if further devices remain.
+func pageSmdDevices(resp *ctlpb.SmdQueryResp, cursor string, limit uint32) error {
+	var curRank uint32
+	var curUUID string
+	if cursor != "" {
+		var err error
+		if curRank, curUUID, err = parseSmdDeviceCursor(cursor); err != nil {
+			return err
+		}
+	}
+
+	sort.Slice(resp.Ranks, func(i, j int) bool {
+		return resp.Ranks[i].Rank < resp.Ranks[j].Rank
+	})
+
+	var count uint32
+	var lastCursor string
+	ranks := make([]*ctlpb.SmdQueryResp_RankResp, 0, len(resp.Ranks))
+	for _, rResp := range resp.Ranks {
+		sort.Slice(rResp.Devices, func(i, j int) bool {
+			return rResp.Devices[i].Uuid < rResp.Devices[j].Uuid
+		})
+
+		var devices []*ctlpb.SmdQueryResp_Device
+		for _, dev := range rResp.Devices {
+			if cursor != "" && (rResp.Rank < curRank ||
+				(rResp.Rank == curRank && dev.Uuid <= curUUID)) {
+				continue
+			}
+			if limit > 0 && count == limit {
+				resp.NextCursor = lastCursor
+				break
+			}
+			devices = append(devices, dev)
+			lastCursor = smdDeviceCursor(rResp.Rank, dev.Uuid)
+			count++
+		}
+
+		if len(devices) > 0 {
+			rResp.Devices = devices
+			ranks = append(ranks, rResp)
+		}
+		if resp.NextCursor != "" {
+			break
+		}
+	}
+	resp.Ranks = ranks
+
+	return nil
+}
+
 func (svc *ControlService) querySmdPools(ctx context.Context, req *ctlpb.SmdQueryReq, resp *ctlpb.SmdQueryResp) error {
 	for _, srv := range svc.harness.Instances() {
 		if !srv.isReady() {
@@ -375,6 +573,12 @@ func (svc *ControlService) SmdQuery(ctx context.Context, req *ctlpb.SmdQueryReq)
 	if req.Target != "" && req.Rank == uint32(system.NilRank) {
 		return nil, errors.New("Target is invalid without Rank")
 	}
+	if (req.Cursor != "" || req.Limit > 0) && (req.OmitDevices || !req.OmitPools) {
+		return nil, errors.New("pagination is only supported when querying devices")
+	}
+	if hasSmdDeviceFilter(req) && (req.OmitDevices || !req.OmitPools) {
+		return nil, errors.New("device filters are only supported when querying devices")
+	}
 
 	resp := new(ctlpb.SmdQueryResp)
 	if !req.OmitDevices {
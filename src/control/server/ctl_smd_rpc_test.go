@@ -8,6 +8,7 @@ package server
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,15 +20,54 @@ import (
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
 	"github.com/daos-stack/daos/src/control/system"
 )
 
+func mockSmdQueryDevices(idxs ...int32) []*ctlpb.SmdQueryResp_Device {
+	devs := make([]*ctlpb.SmdQueryResp_Device, 0, len(idxs))
+	for _, i := range idxs {
+		devs = append(devs, &ctlpb.SmdQueryResp_Device{Uuid: common.MockUUID(i)})
+	}
+	return devs
+}
+
 func TestServer_CtlSvc_SmdQuery(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pagedDevResps := map[int][]*mockDrpcResponse{
+		0: {
+			{
+				Message: &ctlpb.SmdDevResp{
+					Devices: []*ctlpb.SmdDevResp_Device{
+						{Uuid: common.MockUUID(2)},
+						{Uuid: common.MockUUID(0)},
+					},
+				},
+			},
+		},
+		1: {
+			{
+				Message: &ctlpb.SmdDevResp{
+					Devices: []*ctlpb.SmdDevResp_Device{
+						{Uuid: common.MockUUID(3)},
+						{Uuid: common.MockUUID(1)},
+					},
+				},
+			},
+		},
+	}
+
 	for name, tc := range map[string]struct {
 		setupAP        bool
 		req            *ctlpb.SmdQueryReq
 		junkResp       bool
 		drpcResps      map[int][]*mockDrpcResponse
+		bmbc           *bdev.MockBackendConfig
 		harnessStopped bool
 		ioStopped      bool
 		expResp        *ctlpb.SmdQueryResp
@@ -561,6 +601,271 @@ func TestServer_CtlSvc_SmdQuery(t *testing.T) {
 			},
 			expErr: errors.New("invalid"),
 		},
+		"list-devices (paginated first page)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools: true,
+				Rank:      uint32(system.NilRank),
+				Limit:     3,
+			},
+			drpcResps: pagedDevResps,
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Rank:    0,
+						Devices: mockSmdQueryDevices(0, 2),
+					},
+					{
+						Rank:    1,
+						Devices: mockSmdQueryDevices(1),
+					},
+				},
+				NextCursor: "1:" + common.MockUUID(1),
+			},
+		},
+		"list-devices (paginated last page)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools: true,
+				Rank:      uint32(system.NilRank),
+				Cursor:    "1:" + common.MockUUID(1),
+				Limit:     3,
+			},
+			drpcResps: pagedDevResps,
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Rank:    1,
+						Devices: mockSmdQueryDevices(3),
+					},
+				},
+			},
+		},
+		"list-devices (paginated exact page)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools: true,
+				Rank:      uint32(system.NilRank),
+				Cursor:    "0:" + common.MockUUID(2),
+				Limit:     2,
+			},
+			drpcResps: pagedDevResps,
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Rank:    1,
+						Devices: mockSmdQueryDevices(1, 3),
+					},
+				},
+			},
+		},
+		"list-devices (paginated with health)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:        true,
+				Rank:             uint32(system.NilRank),
+				Limit:            1,
+				IncludeBioHealth: true,
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					pagedDevResps[0][0],
+					{
+						Message: &ctlpb.BioHealthResp{Temperature: 300},
+					},
+				},
+				1: pagedDevResps[1],
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Rank: 0,
+						Devices: []*ctlpb.SmdQueryResp_Device{
+							{
+								Uuid:   common.MockUUID(0),
+								Health: &ctlpb.BioHealthResp{Temperature: 300},
+							},
+						},
+					},
+				},
+				NextCursor: "0:" + common.MockUUID(0),
+			},
+		},
+		"list-devices (bad cursor)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools: true,
+				Rank:      uint32(system.NilRank),
+				Cursor:    common.MockUUID(1),
+			},
+			drpcResps: pagedDevResps,
+			expErr:    errors.New("invalid device cursor"),
+		},
+		"list-devices (filter by state)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:   true,
+				Rank:        uint32(system.NilRank),
+				StateFilter: "faulty",
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.SmdDevResp{
+							Devices: []*ctlpb.SmdDevResp_Device{
+								{Uuid: common.MockUUID(0), State: "NORMAL"},
+								{Uuid: common.MockUUID(1), State: "FAULTY"},
+							},
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Devices: []*ctlpb.SmdQueryResp_Device{
+							{Uuid: common.MockUUID(1), State: "FAULTY"},
+						},
+					},
+				},
+			},
+		},
+		"list-devices (filter by model)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:   true,
+				Rank:        uint32(system.NilRank),
+				ModelFilter: "P4510",
+			},
+			bmbc: &bdev.MockBackendConfig{
+				ScanRes: &bdev.ScanResponse{
+					Controllers: storage.NvmeControllers{
+						{PciAddr: "0000:80:00.0", Model: "INTEL SSDPE2KX040T8 P4510"},
+						{PciAddr: "0000:81:00.0", Model: "INTEL SSDPF21Q400GB P5800X"},
+					},
+				},
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					{
+						Message: &ctlpb.SmdDevResp{
+							Devices: []*ctlpb.SmdDevResp_Device{
+								{Uuid: common.MockUUID(0), TrAddr: "0000:80:00.0"},
+								{Uuid: common.MockUUID(1), TrAddr: "0000:81:00.0"},
+							},
+						},
+					},
+				},
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Devices: []*ctlpb.SmdQueryResp_Device{
+							{Uuid: common.MockUUID(0), TrAddr: "0000:80:00.0"},
+						},
+					},
+				},
+			},
+		},
+		"list-devices (filter by health)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:    true,
+				Rank:         uint32(system.NilRank),
+				MinTemp:      330,
+				MinMediaErrs: 1,
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					pagedDevResps[0][0],
+					{
+						Message: &ctlpb.BioHealthResp{Temperature: 340, MediaErrs: 2},
+					},
+					{
+						Message: &ctlpb.BioHealthResp{Temperature: 340},
+					},
+				},
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Devices: mockSmdQueryDevices(2),
+					},
+				},
+			},
+		},
+		"list-devices (filter by health with health)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:        true,
+				Rank:             uint32(system.NilRank),
+				MinIoErrs:        3,
+				IncludeBioHealth: true,
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: {
+					pagedDevResps[0][0],
+					{
+						Message: &ctlpb.BioHealthResp{BioReadErrs: 1, ChecksumErrs: 2},
+					},
+					{
+						Message: &ctlpb.BioHealthResp{BioWriteErrs: 2},
+					},
+				},
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Devices: []*ctlpb.SmdQueryResp_Device{
+							{
+								Uuid:   common.MockUUID(2),
+								Health: &ctlpb.BioHealthResp{BioReadErrs: 1, ChecksumErrs: 2},
+							},
+						},
+					},
+				},
+			},
+		},
+		"list-devices (filter by local host)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:  true,
+				Rank:       uint32(system.NilRank),
+				HostFilter: hostname,
+			},
+			drpcResps: map[int][]*mockDrpcResponse{
+				0: pagedDevResps[0],
+			},
+			expResp: &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Devices: mockSmdQueryDevices(2, 0),
+					},
+				},
+			},
+		},
+		"list-devices (filter by other host)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:  true,
+				Rank:       uint32(system.NilRank),
+				HostFilter: "no-such-host-[1-3]",
+			},
+			drpcResps: pagedDevResps,
+			expResp:   &ctlpb.SmdQueryResp{},
+		},
+		"list-devices (bad host filter)": {
+			req: &ctlpb.SmdQueryReq{
+				OmitPools:  true,
+				Rank:       uint32(system.NilRank),
+				HostFilter: "host-[1-",
+			},
+			drpcResps: pagedDevResps,
+			expErr:    errors.New("invalid host filter"),
+		},
+		"filtered pool query": {
+			req: &ctlpb.SmdQueryReq{
+				Rank:        uint32(system.NilRank),
+				StateFilter: "FAULTY",
+			},
+			expErr: errors.New("only supported when querying devices"),
+		},
+		"paginated pool query": {
+			req: &ctlpb.SmdQueryReq{
+				Rank:  uint32(system.NilRank),
+				Limit: 1,
+			},
+			expErr: errors.New("only supported when querying devices"),
+		},
 		"ambiguous UUID": {
 			req: &ctlpb.SmdQueryReq{
 				Rank: uint32(system.NilRank),
@@ -592,7 +897,7 @@ func TestServer_CtlSvc_SmdQuery(t *testing.T) {
 			for i := 0; i < engineCount; i++ {
 				cfg.Engines = append(cfg.Engines, engine.NewConfig().WithTargetCount(1).WithRank(uint32(i)))
 			}
-			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			svc := mockControlService(t, log, cfg, tc.bmbc, nil, nil)
 			svc.harness.started.SetTrue()
 
 			for i, srv := range svc.harness.instances {
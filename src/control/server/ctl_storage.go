@@ -7,7 +7,9 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -25,6 +27,17 @@ type StorageControlService struct {
 	bdev            *bdev.Provider
 	scm             *scm.Provider
 	instanceStorage []*engine.StorageConfig
+	inventory       *inventoryExporter
+	// findConflictingProcs identifies other processes using SPDK/DPDK
+	// resources, checks are skipped if unset.
+	findConflictingProcs findConflictingProcsFn
+	// getHugePageInfo reports the hugepages allocated on the host, the
+	// allocation made by prepare is not verified if unset.
+	getHugePageInfo getHugePageInfoFn
+	// prepareState records the last NVMe prepare or reset.
+	prepareState *prepareState
+	// ledger records the engine instance owning each storage device.
+	ledger *deviceLedger
 }
 
 // NewStorageControlService returns an initialized *StorageControlService
@@ -39,6 +52,63 @@ func NewStorageControlService(log logging.Logger, bdev *bdev.Provider, scm *scm.
 		bdev:            bdev,
 		scm:             scm,
 		instanceStorage: instanceStorage,
+
+		findConflictingProcs: defaultFindConflictingProcs,
+		getHugePageInfo:      getHugePageInfo,
+		prepareState:         newPrepareState(log, ""),
+		ledger:               newDeviceLedger(log, ""),
+	}
+}
+
+// WithPrepareStateFile sets the path at which the last NVMe prepare or reset
+// performed on the host is recorded.
+func (c *StorageControlService) WithPrepareStateFile(path string) *StorageControlService {
+	c.prepareState = newPrepareState(c.log, path)
+	return c
+}
+
+// WithDeviceLedgerFile sets the path at which the engine instance owning each
+// storage device is recorded.
+func (c *StorageControlService) WithDeviceLedgerFile(path string) *StorageControlService {
+	c.ledger = newDeviceLedger(c.log, path)
+	return c
+}
+
+// releaseDevices removes the device ledger entries for which the supplied
+// function returns true so that the devices may be claimed by any engine.
+// Failure to update the ledger is only logged as the operation that made the
+// devices available has already been performed.
+func (c *StorageControlService) releaseDevices(match func(*DeviceOwner) bool) {
+	if err := c.ledger.load(); err != nil {
+		c.log.Errorf("failed to load device ledger: %s", err)
+		return
+	}
+
+	released := c.ledger.release(match)
+	if len(released) == 0 {
+		return
+	}
+	if err := c.ledger.save(); err != nil {
+		c.log.Errorf("failed to save device ledger: %s", err)
+		return
+	}
+	c.log.Infof("released %s from device ledger", strings.Join(released, ", "))
+}
+
+// resetNvmeMatcher returns a function identifying the device ledger entries of
+// the NVMe SSDs reset by the given request.
+func resetNvmeMatcher(req bdev.PrepareRequest) func(*DeviceOwner) bool {
+	allowed := strings.Fields(req.PCIAllowlist)
+	blocked := strings.Fields(req.PCIBlocklist)
+
+	return func(owner *DeviceOwner) bool {
+		if owner.Class != storage.BdevClassNvme.String() {
+			return false
+		}
+		if len(allowed) > 0 && !common.Includes(allowed, owner.Device) {
+			return false
+		}
+		return !common.Includes(blocked, owner.Device)
 	}
 }
 
@@ -119,6 +189,20 @@ func canAccessBdevs(cfgBdevs []string, scanResp *bdev.ScanResponse) ([]string, b
 	return missing, len(missing) == 0
 }
 
+// findZonedBdevs returns the addresses of any specified Bdevs which have
+// zoned (ZNS) namespaces in the scan response.
+func findZonedBdevs(cfgBdevs []string, scanResp *bdev.ScanResponse) []string {
+	var zoned []string
+
+	for _, ctrlr := range scanResp.Controllers {
+		if ctrlr.Zoned() && common.Includes(cfgBdevs, ctrlr.PciAddr) {
+			zoned = append(zoned, ctrlr.PciAddr)
+		}
+	}
+
+	return zoned
+}
+
 // checkCfgBdevs performs validation on NVMe returned from initial scan.
 func (c *StorageControlService) checkCfgBdevs(scanResp *bdev.ScanResponse) error {
 	if scanResp == nil {
@@ -129,31 +213,40 @@ func (c *StorageControlService) checkCfgBdevs(scanResp *bdev.ScanResponse) error
 	}
 
 	for idx, storageCfg := range c.instanceStorage {
-		cfgBdevs := storageCfg.Bdev.GetNvmeDevs()
-		if len(cfgBdevs) == 0 {
-			continue
-		}
+		for _, bdevCfg := range storageCfg.Tiers.BdevConfigs() {
+			cfgBdevs := bdevCfg.GetNvmeDevs()
+			if len(cfgBdevs) == 0 {
+				continue
+			}
 
-		if !c.bdev.IsVMDDisabled() {
-			c.log.Debug("VMD detected, processing PCI addresses")
-			newBdevs, err := substBdevVmdAddrs(cfgBdevs, scanResp)
-			if err != nil {
-				return err
+			if !c.bdev.IsVMDDisabled() {
+				c.log.Debug("VMD detected, processing PCI addresses")
+				newBdevs, err := substBdevVmdAddrs(cfgBdevs, scanResp)
+				if err != nil {
+					return err
+				}
+				if len(newBdevs) == 0 {
+					return errors.New("unexpected empty bdev list returned " +
+						"check vmd address has backing devices")
+				}
+				c.log.Debugf("instance %d: subst vmd addrs %v->%v",
+					idx, cfgBdevs, newBdevs)
+				cfgBdevs = newBdevs
+				bdevCfg.DeviceList = cfgBdevs
 			}
-			if len(newBdevs) == 0 {
-				return errors.New("unexpected empty bdev list returned " +
-					"check vmd address has backing devices")
+
+			// fail if config specified nvme devices are inaccessible
+			missing, ok := canAccessBdevs(cfgBdevs, scanResp)
+			if !ok {
+				return FaultBdevNotFound(missing)
 			}
-			c.log.Debugf("instance %d: subst vmd addrs %v->%v",
-				idx, cfgBdevs, newBdevs)
-			cfgBdevs = newBdevs
-			c.instanceStorage[idx].Bdev.DeviceList = cfgBdevs
-		}
 
-		// fail if config specified nvme devices are inaccessible
-		missing, ok := canAccessBdevs(cfgBdevs, scanResp)
-		if !ok {
-			return FaultBdevNotFound(missing)
+			if bdevCfg.AllowZoned {
+				continue
+			}
+			if zoned := findZonedBdevs(cfgBdevs, scanResp); len(zoned) != 0 {
+				return FaultBdevZonedNamespace(zoned)
+			}
 		}
 	}
 
@@ -168,7 +261,7 @@ func (c *StorageControlService) Setup() error {
 
 	// don't scan if using emulated NVMe
 	for _, storageCfg := range c.instanceStorage {
-		if storageCfg.Bdev.Class != storage.BdevClassNvme {
+		if storageCfg.Tiers.BdevClass() != storage.BdevClassNvme {
 			return nil
 		}
 	}
@@ -190,7 +283,51 @@ func (c *StorageControlService) Setup() error {
 //
 // Suitable for commands invoked directly on server, not over gRPC.
 func (c *StorageControlService) NvmePrepare(req bdev.PrepareRequest) (*bdev.PrepareResponse, error) {
-	return c.bdev.Prepare(req)
+	// Rebinding devices or resizing hugepages in use by another process
+	// would either fail or disrupt that process.
+	if err := checkConflictingProcesses(c.log, c.findConflictingProcs,
+		strings.Fields(req.PCIAllowlist)); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.bdev.Prepare(req)
+	c.recordNvmePrepare(req.ResetOnly, err)
+
+	// reset SSDs are no longer held by any engine
+	if req.ResetOnly && err == nil {
+		c.releaseDevices(resetNvmeMatcher(req))
+	}
+
+	return resp, err
+}
+
+// recordNvmePrepare persists the outcome of an NVMe prepare or reset along with
+// the resulting device bindings. Failure to do so is only logged as the
+// operation has already been performed.
+func (c *StorageControlService) recordNvmePrepare(reset bool, opErr error) {
+	op := prepareOpPrepare
+	if reset {
+		op = prepareOpReset
+	}
+
+	bindings, err := c.bdev.Bindings()
+	if err != nil {
+		c.log.Debugf("unable to read nvme device bindings: %s", err)
+	}
+	if err := c.prepareState.record(op, opErr, bindings); err != nil {
+		c.log.Errorf("failed to save prepare state: %s", err)
+	}
+}
+
+// NvmePrepareStatus returns the current driver binding of the NVMe SSDs on the
+// host along with the last recorded prepare or reset, no changes are made.
+func (c *StorageControlService) NvmePrepareStatus() (*storage.NvmePrepareStatus, error) {
+	bindings, err := c.bdev.Bindings()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.prepareState.status(bindings)
 }
 
 // GetScmState performs required initialization and returns current state
@@ -205,15 +342,44 @@ func (c *StorageControlService) GetScmState() (storage.ScmState, error) {
 // Suitable for commands invoked directly on server, not over gRPC.
 func (c *StorageControlService) ScmPrepare(req scm.PrepareRequest) (*scm.PrepareResponse, error) {
 	// transition to the next state in SCM preparation
-	return c.scm.Prepare(req)
+	resp, err := c.scm.Prepare(req)
+
+	// namespaces have been removed so release ownership of them
+	if req.Reset && err == nil {
+		c.releaseDevices(func(owner *DeviceOwner) bool {
+			return owner.Class == storage.ScmClassDCPM.String()
+		})
+	}
+
+	return resp, err
 }
 
 // NvmeScan scans locally attached SSDs.
+//
+// The inventory is only updated from scans of all controllers usable by DAOS,
+// scans filtered by device or including kernel-bound controllers would
+// otherwise be exported as changes in the inventory.
 func (c *StorageControlService) NvmeScan(req bdev.ScanRequest) (*bdev.ScanResponse, error) {
-	return c.bdev.Scan(req)
+	resp, err := c.bdev.Scan(req)
+	if err == nil && len(req.DeviceList) == 0 && !req.KernelBound {
+		c.inventory.update(context.Background(), nil, resp)
+	}
+
+	return resp, err
+}
+
+// NvmeCapabilities returns the versions and supported features of the SPDK
+// library used to access locally attached SSDs.
+func (c *StorageControlService) NvmeCapabilities() *storage.BdevCapabilities {
+	return c.bdev.Capabilities()
 }
 
 // ScmScan scans locally attached modules, namespaces and state of DCPM config.
 func (c *StorageControlService) ScmScan(req scm.ScanRequest) (*scm.ScanResponse, error) {
-	return c.scm.Scan(req)
+	resp, err := c.scm.Scan(req)
+	if err == nil && len(req.DeviceList) == 0 {
+		c.inventory.update(context.Background(), resp, nil)
+	}
+
+	return resp, err
 }
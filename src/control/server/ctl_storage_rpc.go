@@ -17,6 +17,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/daos-stack/daos/src/control/common/proto"
+	"github.com/daos-stack/daos/src/control/common/proto/convert"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/server/storage"
@@ -27,6 +28,11 @@ import (
 const (
 	msgFormatErr      = "instance %d: failure formatting storage, check RPC response for details"
 	msgNvmeFormatSkip = "NVMe format skipped on instance %d as SCM format did not complete"
+	msgVerifyRunning  = "instance %d: NVMe read verification in progress, retry format once complete"
+	msgScmFormatDone  = "SCM format skipped on instance %d as it was formatted by a previous request"
+	msgNvmeFormatDone = "NVMe format skipped on instance %d as device was formatted by a previous request"
+	msgHugePagesShort = "%d hugepages allocated, fewer than the %d requested"
+	msgFirmwareOld    = "NVMe controller %s (%s) running firmware %s, older than %s on other controllers of the same model"
 )
 
 // newResponseState creates, populates and returns ResponseState.
@@ -42,32 +48,45 @@ func newResponseState(inErr error, badStatus ctlpb.ResponseStatus, infoMsg strin
 	return rs
 }
 
+// lookupTargetUser returns the user that NVMe storage is to be prepared for,
+// the user running the server if none is given.
+func lookupTargetUser(name string) (*user.User, error) {
+	if name == "" {
+		return user.Current()
+	}
+	return user.Lookup(name)
+}
+
 // TODO: de-duplicate logic to populate prepare request from server config after
 //       DAOS-7002 is completed
-func updateNvmePrepareReq(req *bdev.PrepareRequest, cfg *config.Server) {
+func updateNvmePrepareReq(req *bdev.PrepareRequest, cfg *config.Server, targetUser *user.User, iommuEnabled bool) error {
 	if req.HugePageCount == 0 {
 		req.HugePageCount = minHugePageCount
 		if cfgHasBdevs(cfg) {
 			// The config value is intended to be per-engine, so we
 			// need to adjust based on the number of engines.
 			req.HugePageCount = cfg.NrHugepages * len(cfg.Engines)
+			req.HugeNodePages = engineHugeNodePages(cfg)
 		}
 	}
-	if req.TargetUser == "" {
-		if runningUser, err := user.Current(); err == nil {
-			req.TargetUser = runningUser.Username
-		}
-	}
+	req.TargetUser = targetUser.Username
 	if req.PCIAllowlist == "" {
 		req.PCIAllowlist = strings.Join(cfg.BdevInclude, " ")
 	}
 	req.PCIBlocklist = strings.Join(cfg.BdevExclude, " ")
-	req.DisableVFIO = cfg.DisableVFIO
-	req.DisableVMD = cfg.DisableVMD || cfg.DisableVFIO || !iommuDetected()
+
+	driver, err := selectNvmeDriver(cfg, targetUser.Uid == "0", iommuEnabled)
+	if err != nil && cfgHasBdevs(cfg) {
+		return err
+	}
+	setPrepareDriver(req, cfg, driver)
+
+	return nil
 }
 
-// doNvmePrepare issues prepare request and returns response.
-func (c *ControlService) doNvmePrepare(pbReq *ctlpb.PrepareNvmeReq) *ctlpb.PrepareNvmeResp {
+// doNvmePrepare issues prepare request and returns response along with any
+// warnings about the resulting hugepage allocation.
+func (c *ControlService) doNvmePrepare(pbReq *ctlpb.PrepareNvmeReq) (*ctlpb.PrepareNvmeResp, []string) {
 	c.log.Debugf("performing nvme prep %v", pbReq)
 	pnr := new(ctlpb.PrepareNvmeResp)
 
@@ -80,15 +99,105 @@ func (c *ControlService) doNvmePrepare(pbReq *ctlpb.PrepareNvmeReq) *ctlpb.Prepa
 	}
 
 	if !req.ResetOnly {
-		updateNvmePrepareReq(&req, c.srvCfg)
+		targetUser, err := lookupTargetUser(req.TargetUser)
+		if err == nil {
+			err = updateNvmePrepareReq(&req, c.srvCfg, targetUser, iommuDetected())
+		}
+		if err != nil {
+			pnr.State = newResponseState(err, ctlpb.ResponseStatus_CTL_ERR_NVME, "")
+			return pnr, nil
+		}
+	}
+
+	resp, err := c.NvmePrepare(req)
+	pnr.State = newResponseState(err, ctlpb.ResponseStatus_CTL_ERR_NVME, "")
+	if err != nil || req.ResetOnly {
+		return pnr, nil
 	}
 
-	_, err := c.NvmePrepare(req)
+	warnings := nodeHugePageWarnings(defaultProcRoot, resp)
+	return pnr, append(warnings, c.checkPreparedHugePages(req.HugePageCount)...)
+}
+
+// nvmePrepareStatus returns the current driver binding of the NVMe SSDs on
+// the host and the last recorded prepare or reset, no changes are made.
+func (c *ControlService) nvmePrepareStatus() *ctlpb.PrepareNvmeResp {
+	pnr := new(ctlpb.PrepareNvmeResp)
+
+	nps, err := c.NvmePrepareStatus()
+	if err == nil {
+		err = convert.Types(nps, pnr)
+	}
 	pnr.State = newResponseState(err, ctlpb.ResponseStatus_CTL_ERR_NVME, "")
 
 	return pnr
 }
 
+// checkPreparedHugePages returns a warning if fewer hugepages have been
+// allocated on the host than were requested.
+func (c *StorageControlService) checkPreparedHugePages(requested int) []string {
+	if c.getHugePageInfo == nil || requested <= 0 {
+		return nil
+	}
+
+	hpi, err := c.getHugePageInfo()
+	if err != nil {
+		c.log.Debugf("unable to verify hugepage allocation: %s", err)
+		return nil
+	}
+	if hpi.Total >= requested {
+		return nil
+	}
+
+	return []string{fmt.Sprintf(msgHugePagesShort, hpi.Total, requested)}
+}
+
+// outdatedFirmwareWarnings returns a warning for each of the selected NVMe
+// controllers running an older firmware revision than another controller of
+// the same model. Revisions are compared lexically as vendors use fixed-width
+// revision strings.
+func outdatedFirmwareWarnings(ctrlrs storage.NvmeControllers, selected map[string]bool) []string {
+	latest := make(map[string]string)
+	for _, ctrlr := range ctrlrs {
+		if ctrlr.FwRev > latest[ctrlr.Model] {
+			latest[ctrlr.Model] = ctrlr.FwRev
+		}
+	}
+
+	var warnings []string
+	for _, ctrlr := range ctrlrs {
+		if selected[ctrlr.PciAddr] && ctrlr.FwRev < latest[ctrlr.Model] {
+			warnings = append(warnings, fmt.Sprintf(msgFirmwareOld, ctrlr.PciAddr,
+				ctrlr.Model, ctrlr.FwRev, latest[ctrlr.Model]))
+		}
+	}
+
+	return warnings
+}
+
+// checkFormattedFirmware returns a warning for each NVMe controller formatted
+// without error that is running outdated firmware, controller details are
+// taken from the scan results held by the bdev provider.
+func (c *StorageControlService) checkFormattedFirmware(crets []*ctlpb.NvmeControllerResult) []string {
+	selected := make(map[string]bool)
+	for _, cret := range crets {
+		if cret.GetPciAddr() != "" && cret.GetState().GetStatus() == ctlpb.ResponseStatus_CTL_SUCCESS {
+			selected[cret.GetPciAddr()] = true
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	resp, err := c.NvmeScan(bdev.ScanRequest{})
+	if err != nil {
+		c.log.Debugf("unable to check NVMe firmware revisions: %s", err)
+		return nil
+	}
+
+	return outdatedFirmwareWarnings(resp.Controllers, selected)
+}
+
 // newPrepareScmResp sets protobuf SCM prepare response with results.
 func newPrepareScmResp(inResp *scm.PrepareResponse, inErr error) (*ctlpb.PrepareScmResp, error) {
 	outResp := new(ctlpb.PrepareScmResp)
@@ -133,6 +242,12 @@ func (c *ControlService) StoragePrepare(ctx context.Context, req *ctlpb.StorageP
 
 	resp := new(ctlpb.StoragePrepareResp)
 
+	// status query makes no changes so is permitted while instances run
+	if req.GetNvme().GetStatus() {
+		resp.Nvme = c.nvmePrepareStatus()
+		return resp, nil
+	}
+
 	for _, ei := range c.harness.Instances() {
 		if ei.isStarted() {
 			return nil, errors.Errorf("instance %d: can't prepare storage if running",
@@ -140,16 +255,22 @@ func (c *ControlService) StoragePrepare(ctx context.Context, req *ctlpb.StorageP
 		}
 	}
 
+	timing := new(opTiming)
 	if req.Nvme != nil {
-		resp.Nvme = c.doNvmePrepare(req.Nvme)
+		nvmeDone := timing.start("nvme prepare")
+		resp.Nvme, resp.Warnings = c.doNvmePrepare(req.Nvme)
+		nvmeDone()
 	}
 	if req.Scm != nil {
+		scmDone := timing.start("scm prepare")
 		respScm, err := c.doScmPrepare(req.Scm)
 		if err != nil {
 			return nil, err
 		}
+		scmDone()
 		resp.Scm = respScm
 	}
+	resp.Timings = timing.ctlTimings()
 
 	return resp, nil
 }
@@ -180,7 +301,7 @@ func (c *ControlService) scanInstanceBdevs(ctx context.Context) (*bdev.ScanRespo
 	instances := c.harness.Instances()
 
 	for _, srv := range instances {
-		nvmeDevs := c.instanceStorage[srv.Index()].Bdev.GetNvmeDevs()
+		nvmeDevs := c.instanceStorage[srv.Index()].Tiers.NvmeDevices()
 		if len(nvmeDevs) == 0 {
 			continue
 		}
@@ -278,17 +399,38 @@ func (c *ControlService) scanBdevs(ctx context.Context, req *ctlpb.ScanNvmeReq)
 		return nil, errors.New("nil bdev request")
 	}
 
+	var resp *bdev.ScanResponse
+	var err error
 	if req.Health || req.Meta {
 		// filter results based on config file bdev_list contents
-		resp, err := c.scanInstanceBdevs(ctx)
+		resp, err = c.scanInstanceBdevs(ctx)
+	} else {
+		// return cached results for all bdevs along with any still
+		// bound to the kernel so that they are shown before prepare
+		resp, err = c.NvmeScan(bdev.ScanRequest{KernelBound: true})
+	}
 
-		return newScanNvmeResp(req, resp, err)
+	pbResp, err := newScanNvmeResp(req, resp, err)
+	if err != nil || req.Basic {
+		return pbResp, err
 	}
 
-	// return cached results for all bdevs
-	resp, err := c.NvmeScan(bdev.ScanRequest{})
+	pbResp.Capabilities, err = newBdevCapabilities(c.NvmeCapabilities())
+	if err != nil {
+		return nil, err
+	}
 
-	return newScanNvmeResp(req, resp, err)
+	return pbResp, nil
+}
+
+// newBdevCapabilities converts native bdev capabilities to protobuf.
+func newBdevCapabilities(bc *storage.BdevCapabilities) (*ctlpb.BdevCapabilities, error) {
+	pbCaps := new(ctlpb.BdevCapabilities)
+	if err := convert.Types(bc, pbCaps); err != nil {
+		return nil, errors.Wrap(err, "converting bdev capabilities")
+	}
+
+	return pbCaps, nil
 }
 
 // newScanScmResp sets protobuf SCM scan response with module or namespace info.
@@ -418,6 +560,7 @@ func (c *ControlService) StorageScan(ctx context.Context, req *ctlpb.StorageScan
 		return nil, err
 	}
 	resp.Scm = respScm
+	resp.Owners = c.ledgerOwners()
 
 	c.log.Debug("responding to StorageScan RPC")
 
@@ -434,6 +577,7 @@ func (c *ControlService) StorageScan(ctx context.Context, req *ctlpb.StorageScan
 // and nvme controllers.
 func (c *ControlService) StorageFormat(ctx context.Context, req *ctlpb.StorageFormatReq) (*ctlpb.StorageFormatResp, error) {
 	instances := c.harness.Instances()
+	timing := new(opTiming)
 	resp := new(ctlpb.StorageFormatResp)
 	resp.Mrets = make([]*ctlpb.ScmMountResult, 0, len(instances))
 	resp.Crets = make([]*ctlpb.NvmeControllerResult, 0, len(instances))
@@ -441,12 +585,62 @@ func (c *ControlService) StorageFormat(ctx context.Context, req *ctlpb.StorageFo
 
 	c.log.Debugf("received StorageFormat RPC %v", req)
 
+	// report the fault domain so that the client can tell whether failures
+	// are confined to a single domain
+	if c.harness.faultDomain != nil {
+		resp.FaultDomain = c.harness.faultDomain.String()
+	}
+
+	for _, srv := range instances {
+		if c.verifier.isRunning(srv.Index()) {
+			return nil, errors.Errorf(msgVerifyRunning, srv.Index())
+		}
+	}
+
+	// Progress recorded by a previous request that did not complete on all
+	// devices of an instance is resumed unless a reformat or restart has
+	// been requested. On restart, storage formatted by the previous request
+	// is formatted again.
+	restart := make(map[uint32]bool)
+	for _, srv := range instances {
+		if !req.Reformat && !req.Restart {
+			continue
+		}
+		restart[srv.Index()] = req.Restart && c.formatState.hasState(srv.Index())
+		c.formatState.clear(srv.Index())
+	}
+
+	// A reformat erases the storage of each instance, so devices recorded
+	// as owned by another engine are reassigned to the instance that is now
+	// configured to use them.
+	if req.Reformat {
+		c.reassignEngineDevices(instances)
+	}
+
 	// TODO: enable per-instance formatting
+	scmDone := timing.start("scm format")
 	formatting := 0
 	for _, srv := range instances {
 		formatting++
 		go func(s *EngineInstance) {
-			scmChan <- s.StorageFormatSCM(ctx, req.Reformat)
+			// refuse to format devices recorded as owned by another engine
+			bdevCfg := s.bdevConfig()
+			devices := engineDevices(s.scmConfig(), &bdevCfg)
+			if err := c.ledger.verify(s.Index(), devices); err != nil {
+				scmChan <- s.newMntRet(err)
+				return
+			}
+			if c.formatState.scmFormatted(s.Index()) {
+				ret := s.newMntRet(nil)
+				ret.State.Info = fmt.Sprintf(msgScmFormatDone, s.Index())
+				scmChan <- ret
+				return
+			}
+			ret := s.StorageFormatSCM(ctx, req.Reformat || restart[s.Index()])
+			if ret.GetState().GetStatus() == ctlpb.ResponseStatus_CTL_SUCCESS {
+				c.formatState.setScmFormatted(s.Index())
+			}
+			scmChan <- ret
 		}(srv)
 	}
 
@@ -463,6 +657,16 @@ func (c *ControlService) StorageFormat(ctx context.Context, req *ctlpb.StorageFo
 			resp.Mrets = append(resp.Mrets, scmResult)
 		}
 	}
+	scmDone()
+
+	// Collect devices claimed by running engines to prevent them from being
+	// formatted unless a reformat has been requested.
+	engineClaims := make(map[string]string)
+	for _, srv := range instances {
+		for dev, claimant := range srv.bdevClaims() {
+			engineClaims[dev] = claimant
+		}
+	}
 
 	// TODO: perform bdev format in parallel
 	for _, srv := range instances {
@@ -479,14 +683,25 @@ func (c *ControlService) StorageFormat(ctx context.Context, req *ctlpb.StorageFo
 			}
 			continue
 		}
-		// SCM formatted correctly on this instance, format NVMe
-		cResults := srv.StorageFormatNVMe(c.bdev)
+		// SCM formatted correctly on this instance, format NVMe unless
+		// the devices are held by processes other than the engines
+		if err := checkConflictingProcesses(c.log, c.findConflictingProcs,
+			srv.bdevConfig().DeviceList); err != nil {
+			resp.Crets = append(resp.Crets, srv.newCret("", err))
+			instanceErrored[srv.Index()] = true
+			continue
+		}
+		cResults := srv.StorageFormatNVMe(c.bdev, engineClaims, c.formatState, req.Reformat, timing)
 		if cResults.HasErrors() {
 			instanceErrored[srv.Index()] = true
 		}
 		resp.Crets = append(resp.Crets, cResults...)
 	}
 
+	fwDone := timing.start("firmware check")
+	resp.Warnings = c.checkFormattedFirmware(resp.Crets)
+	fwDone()
+
 	// Notify storage ready for instances formatted without error.
 	// Block until all instances have formatted NVMe to avoid
 	// VFIO device or resource busy when starting I/O Engines
@@ -497,8 +712,87 @@ func (c *ControlService) StorageFormat(ctx context.Context, req *ctlpb.StorageFo
 			srv.log.Errorf(msgFormatErr, srv.Index())
 			continue
 		}
+		c.formatState.clear(srv.Index())
+
+		// Read back NVMe devices before the engine claims them, storage
+		// is reported ready once all devices have been read.
+		bdevCfg := srv.bdevConfig()
+		if c.verifier.enabled() && bdevCfg.Class == storage.BdevClassNvme &&
+			len(bdevCfg.DeviceList) > 0 {
+			c.verifier.start(srv.Index(), bdevCfg.DeviceList, srv.NotifyStorageReady)
+			continue
+		}
 		srv.NotifyStorageReady()
 	}
+	resp.Timings = timing.ctlTimings()
 
 	return resp, nil
 }
+
+// StorageVerifyQuery returns the progress of the read verification of NVMe
+// devices performed after format.
+func (c *ControlService) StorageVerifyQuery(ctx context.Context, req *ctlpb.StorageVerifyReq) (*ctlpb.StorageVerifyResp, error) {
+	c.log.Debugf("received StorageVerifyQuery RPC %v", req)
+
+	return &ctlpb.StorageVerifyResp{
+		Rate:    c.verifier.rate / humanize.MiByte,
+		Results: c.verifier.results(),
+	}, nil
+}
+
+// reassignEngineDevices records each instance as the owner of the storage
+// devices assigned to it in the server configuration.
+func (c *ControlService) reassignEngineDevices(instances []*EngineInstance) {
+	var reassigned []string
+	for _, srv := range instances {
+		bdevCfg := srv.bdevConfig()
+		devices := engineDevices(srv.scmConfig(), &bdevCfg)
+		for _, dev := range c.ledger.reassign(srv.Index(), devices) {
+			reassigned = append(reassigned, fmt.Sprintf("%s to instance %d", dev, srv.Index()))
+		}
+	}
+	if len(reassigned) == 0 {
+		return
+	}
+
+	if err := c.ledger.save(); err != nil {
+		c.log.Errorf("failed to save device ledger: %s", err)
+	}
+	c.log.Infof("reassigned %s in device ledger", strings.Join(reassigned, ", "))
+}
+
+// ledgerOwners returns the engine instances recorded in the device ledger as
+// owning storage devices on this server.
+func (c *ControlService) ledgerOwners() []*ctlpb.DeviceOwner {
+	var owners []*ctlpb.DeviceOwner
+	for _, owner := range c.ledger.Owners() {
+		owners = append(owners, &ctlpb.DeviceOwner{
+			Device:      owner.Device,
+			Class:       owner.Class,
+			Instanceidx: owner.Engine,
+		})
+	}
+
+	return owners
+}
+
+// StorageOwnershipQuery returns the engine instances recorded in the device
+// ledger as owning storage devices on this server.
+func (c *ControlService) StorageOwnershipQuery(ctx context.Context, req *ctlpb.StorageOwnershipReq) (*ctlpb.StorageOwnershipResp, error) {
+	c.log.Debugf("received StorageOwnershipQuery RPC %v", req)
+
+	return &ctlpb.StorageOwnershipResp{Owners: c.ledgerOwners()}, nil
+}
+
+// BdevCapabilitiesQuery returns the versions of the SPDK and DPDK libraries
+// used by this server to access NVMe SSDs and the features they support.
+func (c *ControlService) BdevCapabilitiesQuery(ctx context.Context, req *ctlpb.BdevCapabilitiesReq) (*ctlpb.BdevCapabilitiesResp, error) {
+	c.log.Debugf("received BdevCapabilitiesQuery RPC %v", req)
+
+	pbCaps, err := newBdevCapabilities(c.NvmeCapabilities())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctlpb.BdevCapabilitiesResp{Capabilities: pbCaps}, nil
+}
@@ -68,6 +68,12 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 				ScanRes: &bdev.ScanResponse{
 					Controllers: storage.NvmeControllers{ctrlr},
 				},
+				Capabilities: &storage.BdevCapabilities{
+					SpdkVersion: "v21.07",
+					DpdkVersion: "21.05.0",
+					Vmd:         true,
+					Zns:         true,
+				},
 			},
 			smbc: &scm.MockBackendConfig{
 				DiscoverRes:         storage.ScmModules{storage.MockScmModule()},
@@ -77,6 +83,12 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 				Nvme: &ctlpb.ScanNvmeResp{
 					Ctrlrs: proto.NvmeControllers{ctrlrPB},
 					State:  new(ctlpb.ResponseState),
+					Capabilities: &ctlpb.BdevCapabilities{
+						SpdkVersion: "v21.07",
+						DpdkVersion: "21.05.0",
+						Vmd:         true,
+						Zns:         true,
+					},
 				},
 				Scm: &ctlpb.ScanScmResp{
 					Namespaces: proto.ScmNamespaces{proto.MockScmNamespace()},
@@ -95,8 +107,9 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{ctrlrPB},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{ctrlrPB},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					Modules: proto.ScmModules{proto.MockScmModule()},
@@ -118,6 +131,7 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 						Error:  "spdk scan failed",
 						Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
 					},
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					Namespaces: proto.ScmNamespaces{proto.MockScmNamespace()},
@@ -136,8 +150,9 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{ctrlrPB},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{ctrlrPB},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: &ctlpb.ResponseState{
@@ -160,6 +175,7 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 						Error:  "spdk scan failed",
 						Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
 					},
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: &ctlpb.ResponseState{
@@ -183,8 +199,9 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{ctrlrPBwHealth},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{ctrlrPBwHealth},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: new(ctlpb.ResponseState),
@@ -207,8 +224,9 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
 					// response should not contain duplicates
-					Ctrlrs: proto.NvmeControllers{ctrlrPBwHealth},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{ctrlrPBwHealth},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: new(ctlpb.ResponseState),
@@ -229,8 +247,9 @@ func TestServer_CtlSvc_StorageScan_PreIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{ctrlrPB},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{ctrlrPB},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: new(ctlpb.ResponseState),
@@ -445,8 +464,9 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{newCtrlrPBwHealth(1)},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{newCtrlrPBwHealth(1)},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -469,8 +489,9 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					Ctrlrs: proto.NvmeControllers{newCtrlrPBwMeta(1)},
-					State:  new(ctlpb.ResponseState),
+					Ctrlrs:       proto.NvmeControllers{newCtrlrPBwMeta(1)},
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -511,7 +532,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 						newCtrlrPBwHealth(1),
 						newCtrlrPBwHealth(2),
 					},
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -552,7 +574,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 						newCtrlrPBwMeta(1),
 						newCtrlrPBwMeta(2),
 					},
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -599,7 +622,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 						newCtrlrPBwMeta(1),
 						newCtrlrPBwMeta(2),
 					},
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -642,7 +666,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 						newCtrlrPBwMeta(1, 1, 2),
 						newCtrlrPBwMeta(2, 3, 4),
 					},
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{State: new(ctlpb.ResponseState)},
 			},
@@ -670,7 +695,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					Namespaces: proto.ScmNamespaces{mockPbScmNamespace},
@@ -701,7 +727,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					State: &ctlpb.ResponseState{
@@ -734,7 +761,8 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 			},
 			expResp: ctlpb.StorageScanResp{
 				Nvme: &ctlpb.ScanNvmeResp{
-					State: new(ctlpb.ResponseState),
+					State:        new(ctlpb.ResponseState),
+					Capabilities: new(ctlpb.BdevCapabilities),
 				},
 				Scm: &ctlpb.ScanScmResp{
 					Namespaces: proto.ScmNamespaces{
@@ -824,12 +852,76 @@ func TestServer_CtlSvc_StorageScan_PostIOStart(t *testing.T) {
 	}
 }
 
-func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
+func TestServer_CtlSvc_BdevCapabilitiesQuery(t *testing.T) {
 	for name, tc := range map[string]struct {
 		bmbc    *bdev.MockBackendConfig
-		smbc    *scm.MockBackendConfig
-		req     ctlpb.StoragePrepareReq
-		expResp *ctlpb.StoragePrepareResp
+		expResp *ctlpb.BdevCapabilitiesResp
+	}{
+		"no features": {
+			bmbc: &bdev.MockBackendConfig{
+				Capabilities: &storage.BdevCapabilities{
+					SpdkVersion: "v19.01",
+					DpdkVersion: "18.11.0",
+				},
+			},
+			expResp: &ctlpb.BdevCapabilitiesResp{
+				Capabilities: &ctlpb.BdevCapabilities{
+					SpdkVersion: "v19.01",
+					DpdkVersion: "18.11.0",
+				},
+			},
+		},
+		"all features": {
+			bmbc: &bdev.MockBackendConfig{
+				Capabilities: &storage.BdevCapabilities{
+					SpdkVersion: "v21.07",
+					DpdkVersion: "21.05.0",
+					Vmd:         true,
+					VmdEnabled:  true,
+					Zns:         true,
+					Cmb:         true,
+				},
+			},
+			expResp: &ctlpb.BdevCapabilitiesResp{
+				Capabilities: &ctlpb.BdevCapabilities{
+					SpdkVersion: "v21.07",
+					DpdkVersion: "21.05.0",
+					Vmd:         true,
+					VmdEnabled:  true,
+					Zns:         true,
+					Cmb:         true,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cs := mockControlService(t, log, config.DefaultServer(), tc.bmbc, nil, nil)
+
+			resp, err := cs.BdevCapabilitiesQuery(context.TODO(), new(ctlpb.BdevCapabilitiesReq))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expResp, resp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
+	for name, tc := range map[string]struct {
+		bmbc          *bdev.MockBackendConfig
+		smbc          *scm.MockBackendConfig
+		conflictProcs []*conflictingProcess
+		hugePages     *hugePageInfo
+		ledger        []*DeviceOwner
+		req           ctlpb.StoragePrepareReq
+		expResp       *ctlpb.StoragePrepareResp
+		expOwners     []*DeviceOwner
 	}{
 		"success": {
 			smbc: &scm.MockBackendConfig{
@@ -867,6 +959,27 @@ func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
 				Scm:  nil,
 			},
 		},
+		"nvme only; fewer hugepages allocated than requested": {
+			hugePages: &hugePageInfo{Total: 8},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{State: new(ctlpb.ResponseState)},
+				Warnings: []string{
+					fmt.Sprintf(msgHugePagesShort, 8, minHugePageCount),
+				},
+			},
+		},
+		"nvme only; requested hugepages allocated": {
+			hugePages: &hugePageInfo{Total: minHugePageCount},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{State: new(ctlpb.ResponseState)},
+			},
+		},
 		"success with pmem devices": {
 			smbc: &scm.MockBackendConfig{
 				DiscoverRes:      storage.ScmModules{storage.MockScmModule()},
@@ -907,6 +1020,144 @@ func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
 				},
 			},
 		},
+		"nvme held by other process": {
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: storage.ScmModules{storage.MockScmModule()},
+			},
+			conflictProcs: []*conflictingProcess{
+				{Pid: 42, Name: "ovs-vswitchd", HugetlbKb: 2048, Devices: []string{"0000:81:00.0"}},
+				{Pid: 43, Name: "spdk_tgt", HugetlbKb: 2048},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{},
+				Scm:  &ctlpb.PrepareScmReq{},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{
+					State: &ctlpb.ResponseState{
+						Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
+						Error: FaultConflictingProcesses([]string{
+							"pid 42 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+						}).Error(),
+					},
+				},
+				Scm: &ctlpb.PrepareScmResp{
+					State: new(ctlpb.ResponseState),
+				},
+			},
+		},
+		"nvme status": {
+			bmbc: &bdev.MockBackendConfig{
+				Bindings: storage.NvmeBindings{
+					{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+					{PciAddr: "0000:82:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+				},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{Status: true},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{
+					State:     new(ctlpb.ResponseState),
+					BindState: string(storage.NvmeBindStatePartial),
+					Bindings: []*ctlpb.PrepareNvmeResp_Binding{
+						{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: "spdk-bound"},
+						{PciAddr: "0000:82:00.0", Driver: "nvme", State: "kernel-bound"},
+					},
+				},
+			},
+		},
+		"nvme status; bindings unreadable": {
+			bmbc: &bdev.MockBackendConfig{
+				BindingsErr: errors.New("reading pci devices"),
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{Status: true},
+				Scm:  &ctlpb.PrepareScmReq{},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{
+					State: &ctlpb.ResponseState{
+						Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
+						Error:  "reading pci devices",
+					},
+				},
+			},
+		},
+		"nvme reset releases ssds from ledger": {
+			ledger: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{Reset_: true},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{State: new(ctlpb.ResponseState)},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+			},
+		},
+		"nvme reset of allowed ssd releases it from ledger": {
+			ledger: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{
+					Reset_:       true,
+					PciAllowList: "0000:81:00.0",
+				},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{State: new(ctlpb.ResponseState)},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+			},
+		},
+		"failed nvme reset retains ssds in ledger": {
+			bmbc: &bdev.MockBackendConfig{
+				PrepareResetErr: errors.New("nvme reset error"),
+			},
+			ledger: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Nvme: &ctlpb.PrepareNvmeReq{Reset_: true},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Nvme: &ctlpb.PrepareNvmeResp{
+					State: &ctlpb.ResponseState{
+						Status: ctlpb.ResponseStatus_CTL_ERR_NVME,
+						Error:  "bdev prepare reset: nvme reset error",
+					},
+				},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+		},
+		"scm reset releases pmem from ledger": {
+			smbc: &scm.MockBackendConfig{
+				DiscoverRes: storage.ScmModules{storage.MockScmModule()},
+			},
+			ledger: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+			req: ctlpb.StoragePrepareReq{
+				Scm: &ctlpb.PrepareScmReq{Reset_: true},
+			},
+			expResp: &ctlpb.StoragePrepareResp{
+				Scm: &ctlpb.PrepareScmResp{State: new(ctlpb.ResponseState)},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+		},
 		"fail nvme prep": {
 			bmbc: &bdev.MockBackendConfig{
 				PrepareErr: errors.New("nvme prep error"),
@@ -937,6 +1188,21 @@ func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
 
 			config := config.DefaultServer()
 			cs := mockControlService(t, log, config, tc.bmbc, tc.smbc, nil)
+			cs.findConflictingProcs = func() ([]*conflictingProcess, error) {
+				return tc.conflictProcs, nil
+			}
+			if tc.hugePages != nil {
+				cs.getHugePageInfo = func() (*hugePageInfo, error) {
+					return tc.hugePages, nil
+				}
+			}
+			for _, owner := range tc.ledger {
+				if err := cs.ledger.claim(owner.Engine, map[string]string{
+					owner.Device: owner.Class,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
 			_ = new(ctlpb.StoragePrepareResp)
 
 			// runs discovery for nvme & scm
@@ -950,9 +1216,36 @@ func TestServer_CtlSvc_StoragePrepare(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tc.expResp, resp, common.DefaultCmpOpts()...); diff != "" {
+			// durations vary so only the timed phases are compared, a
+			// status query makes no changes and so is not timed
+			var expPhases, gotPhases []string
+			if !tc.req.GetNvme().GetStatus() {
+				if tc.req.Nvme != nil {
+					expPhases = append(expPhases, "nvme prepare")
+				}
+				if tc.req.Scm != nil {
+					expPhases = append(expPhases, "scm prepare")
+				}
+			}
+			for _, pt := range resp.Timings {
+				gotPhases = append(gotPhases, pt.Phase)
+			}
+			if diff := cmp.Diff(expPhases, gotPhases); diff != "" {
+				t.Fatalf("unexpected timed phases (-want, +got):\n%s\n", diff)
+			}
+
+			cmpOpts := append(common.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&ctlpb.StoragePrepareResp{}, "timings"))
+			if diff := cmp.Diff(tc.expResp, resp, cmpOpts...); diff != "" {
 				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
 			}
+
+			if tc.ledger == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.expOwners, cs.ledger.Owners()); diff != "" {
+				t.Fatalf("unexpected ledger owners (-want, +got):\n%s\n", diff)
+			}
 		})
 	}
 }
@@ -1530,11 +1823,20 @@ func TestServer_CtlSvc_StorageFormat(t *testing.T) {
 				}
 			}
 
+			cs.harness.WithFaultDomain(system.MustCreateFaultDomain("rack0", "host0"))
+
 			resp, fmtErr := cs.StorageFormat(context.TODO(), &ctlpb.StorageFormatReq{Reformat: tc.reformat})
 			if fmtErr != nil {
 				t.Fatal(fmtErr)
 			}
 
+			common.AssertEqual(t, "/rack0/host0", resp.FaultDomain, "fault domain")
+			if len(resp.Timings) < 2 {
+				t.Fatalf("expected scm format and firmware check timings, got %+v", resp.Timings)
+			}
+			common.AssertEqual(t, "scm format", resp.Timings[0].Phase, "first timed phase")
+			common.AssertEqual(t, "firmware check", resp.Timings[len(resp.Timings)-1].Phase,
+				"last timed phase")
 			common.AssertEqual(t, len(tc.expResp.Crets), len(resp.Crets),
 				"number of controller results")
 			common.AssertEqual(t, len(tc.expResp.Mrets), len(resp.Mrets),
@@ -1566,3 +1868,289 @@ func TestServer_CtlSvc_StorageFormat(t *testing.T) {
 		})
 	}
 }
+
+// formatStep is a storage format request and its expected results, expCrets
+// and expMret hold the info message of successful results or "error" for
+// failed results.
+type formatStep struct {
+	req      *ctlpb.StorageFormatReq
+	devResp  bdev.DeviceFormatResponses
+	expMret  string
+	expCrets map[string]string
+	expState bool
+}
+
+func TestServer_CtlSvc_StorageFormat_Resume(t *testing.T) {
+	pci0 := storage.MockNvmeController(0).PciAddr
+	pci1 := storage.MockNvmeController(1).PciAddr
+	devResps := func(failed ...string) bdev.DeviceFormatResponses {
+		dfr := bdev.DeviceFormatResponses{
+			pci0: &bdev.DeviceFormatResponse{Formatted: true},
+			pci1: &bdev.DeviceFormatResponse{Formatted: true},
+		}
+		for _, dev := range failed {
+			dfr[dev] = &bdev.DeviceFormatResponse{
+				Error: bdev.FaultFormatError(dev, errors.New("failed")),
+			}
+		}
+		return dfr
+	}
+	partialFormat := formatStep{
+		req:     &ctlpb.StorageFormatReq{},
+		devResp: devResps(pci1),
+		expCrets: map[string]string{
+			pci0: "",
+			pci1: "error",
+		},
+		expState: true,
+	}
+
+	// The mock backend returns devResp regardless of the devices in the
+	// request, so it only contains the devices expected to be formatted.
+	for name, tc := range map[string]struct {
+		steps []formatStep
+	}{
+		"complete format": {
+			steps: []formatStep{
+				{
+					req:      &ctlpb.StorageFormatReq{},
+					devResp:  devResps(),
+					expCrets: map[string]string{pci0: "", pci1: ""},
+				},
+			},
+		},
+		"resumed after partial failure": {
+			steps: []formatStep{
+				partialFormat,
+				{
+					req: &ctlpb.StorageFormatReq{},
+					devResp: bdev.DeviceFormatResponses{
+						pci1: &bdev.DeviceFormatResponse{Formatted: true},
+					},
+					expMret: fmt.Sprintf(msgScmFormatDone, 0),
+					expCrets: map[string]string{
+						pci0: fmt.Sprintf(msgNvmeFormatDone, 0),
+						pci1: "",
+					},
+				},
+			},
+		},
+		"resumed after repeated failure": {
+			steps: []formatStep{
+				partialFormat,
+				{
+					req: &ctlpb.StorageFormatReq{},
+					devResp: bdev.DeviceFormatResponses{
+						pci1: devResps(pci1)[pci1],
+					},
+					expMret: fmt.Sprintf(msgScmFormatDone, 0),
+					expCrets: map[string]string{
+						pci0: fmt.Sprintf(msgNvmeFormatDone, 0),
+						pci1: "error",
+					},
+					expState: true,
+				},
+			},
+		},
+		"restart after partial failure": {
+			steps: []formatStep{
+				partialFormat,
+				{
+					req:      &ctlpb.StorageFormatReq{Restart: true},
+					devResp:  devResps(),
+					expCrets: map[string]string{pci0: "", pci1: ""},
+				},
+			},
+		},
+		"reformat after partial failure": {
+			steps: []formatStep{
+				partialFormat,
+				{
+					req:      &ctlpb.StorageFormatReq{Reformat: true},
+					devResp:  devResps(),
+					expCrets: map[string]string{pci0: "", pci1: ""},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			mountPoint := filepath.Join(testDir, "mnt", "daos")
+			if err := os.MkdirAll(filepath.Dir(mountPoint), 0777); err != nil {
+				t.Fatal(err)
+			}
+			cfg := config.DefaultServer().WithEngines(
+				engine.NewConfig().
+					WithScmMountPoint(mountPoint).
+					WithScmClass(storage.ScmClassRAM.String()).
+					WithScmRamdiskSize(6).
+					WithBdevClass(storage.BdevClassNvme.String()).
+					WithBdevDeviceList(pci0, pci1),
+			)
+			cs := mockControlServiceNoSB(t, log, cfg, nil, nil, &scm.MockSysConfig{})
+
+			for i, step := range tc.steps {
+				cs.bdev = bdev.NewMockProvider(log, &bdev.MockBackendConfig{
+					FormatRes: &bdev.FormatResponse{
+						DeviceResponses: step.devResp,
+					},
+				})
+
+				resp, err := cs.StorageFormat(context.TODO(), step.req)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				fmtResult := func(state *ctlpb.ResponseState) string {
+					if state.GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
+						return "error"
+					}
+					return state.GetInfo()
+				}
+				gotCrets := make(map[string]string)
+				for _, cr := range resp.Crets {
+					gotCrets[cr.PciAddr] = fmtResult(cr.State)
+				}
+				if diff := cmp.Diff(step.expCrets, gotCrets); diff != "" {
+					t.Fatalf("step %d: unexpected controller results (-want, +got):\n%s\n", i, diff)
+				}
+				common.AssertEqual(t, 1, len(resp.Mrets), "number of mount results")
+				common.AssertEqual(t, step.expMret, fmtResult(resp.Mrets[0].State),
+					fmt.Sprintf("step %d: unexpected mount result", i))
+				common.AssertEqual(t, step.expState, cs.formatState.hasState(0),
+					fmt.Sprintf("step %d: unexpected format state", i))
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_reassignEngineDevices(t *testing.T) {
+	engineCfg := func(scmDev string, bdevs ...string) *engine.Config {
+		return engine.NewConfig().
+			WithScmClass("dcpm").
+			WithScmMountPoint("/mnt/daos").
+			WithScmDeviceList(scmDev).
+			WithBdevClass("nvme").
+			WithBdevDeviceList(bdevs...)
+	}
+
+	for name, tc := range map[string]struct {
+		ledger    []*DeviceOwner
+		expOwners []*DeviceOwner
+	}{
+		"ledger matches config": {
+			ledger: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "/dev/pmem1", Class: "dcpm", Engine: 1},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:da:00.0", Class: "nvme", Engine: 1},
+			},
+		},
+		"ssd moved to another engine": {
+			ledger: []*DeviceOwner{
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 1},
+				{Device: "0000:da:00.0", Class: "nvme", Engine: 0},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "/dev/pmem1", Class: "dcpm", Engine: 1},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:da:00.0", Class: "nvme", Engine: 1},
+			},
+		},
+		"ssd no longer configured": {
+			ledger: []*DeviceOwner{
+				{Device: "0000:5e:00.0", Class: "nvme", Engine: 1},
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "/dev/pmem1", Class: "dcpm", Engine: 1},
+				{Device: "0000:5e:00.0", Class: "nvme", Engine: 1},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:da:00.0", Class: "nvme", Engine: 1},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer().WithEngines(
+				engineCfg("/dev/pmem0", "0000:81:00.0"),
+				engineCfg("/dev/pmem1", "0000:da:00.0"),
+			)
+			cs := mockControlService(t, log, cfg, nil, nil, nil)
+			for _, owner := range tc.ledger {
+				if err := cs.ledger.claim(owner.Engine, map[string]string{
+					owner.Device: owner.Class,
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cs.reassignEngineDevices(cs.harness.Instances())
+
+			if diff := cmp.Diff(tc.expOwners, cs.ledger.Owners()); diff != "" {
+				t.Fatalf("unexpected ledger owners (-want, +got):\n%s\n", diff)
+			}
+			for _, srv := range cs.harness.Instances() {
+				bdevCfg := srv.bdevConfig()
+				devices := engineDevices(srv.scmConfig(), &bdevCfg)
+				if err := cs.ledger.verify(srv.Index(), devices); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_outdatedFirmwareWarnings(t *testing.T) {
+	mockCtrlr := func(pciAddr, model, fwRev string) *storage.NvmeController {
+		return &storage.NvmeController{PciAddr: pciAddr, Model: model, FwRev: fwRev}
+	}
+	ctrlrs := storage.NvmeControllers{
+		mockCtrlr("0000:81:00.0", "modelA", "VDV10170"),
+		mockCtrlr("0000:82:00.0", "modelA", "VDV10131"),
+		mockCtrlr("0000:83:00.0", "modelB", "GDC5302Q"),
+		mockCtrlr("0000:84:00.0", "modelB", "GDC5302Q"),
+	}
+
+	for name, tc := range map[string]struct {
+		selected    map[string]bool
+		expWarnings []string
+	}{
+		"none selected": {},
+		"latest revisions selected": {
+			selected: map[string]bool{
+				"0000:81:00.0": true,
+				"0000:83:00.0": true,
+				"0000:84:00.0": true,
+			},
+		},
+		"outdated revision selected": {
+			selected: map[string]bool{
+				"0000:81:00.0": true,
+				"0000:82:00.0": true,
+			},
+			expWarnings: []string{
+				fmt.Sprintf(msgFirmwareOld, "0000:82:00.0", "modelA", "VDV10131", "VDV10170"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotWarnings := outdatedFirmwareWarnings(ctrlrs, tc.selected)
+
+			if diff := cmp.Diff(tc.expWarnings, gotWarnings); diff != "" {
+				t.Fatalf("unexpected warnings (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
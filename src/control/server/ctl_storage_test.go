@@ -33,6 +33,7 @@ func TestServer_CtlSvc_checkCfgBdevs(t *testing.T) {
 	for name, tc := range map[string]struct {
 		numEngines      int
 		vmdEnabled      bool
+		allowZoned      bool
 		inScanResp      *bdev.ScanResponse
 		inCfgBdevLists  [][]string
 		expCfgBdevLists [][]string
@@ -101,6 +102,27 @@ func TestServer_CtlSvc_checkCfgBdevs(t *testing.T) {
 				{"0000:8d:00.0", "0000:8b:00.0", "0000:8c:00.0", "0000:8f:00.0"},
 			},
 		},
+		"zoned ssd in cfg bdev list": {
+			inScanResp: &bdev.ScanResponse{
+				Controllers: append(scanCtrlrs, &storage.NvmeController{
+					PciAddr:    "0000:81:00.0",
+					Namespaces: []*storage.NvmeNamespace{{ID: 1, Zoned: true}},
+				}),
+			},
+			inCfgBdevLists: [][]string{{"0000:90:00.0", "0000:81:00.0"}},
+			expErr:         FaultBdevZonedNamespace([]string{"0000:81:00.0"}),
+		},
+		"zoned ssd in cfg bdev list; zoned allowed": {
+			allowZoned: true,
+			inScanResp: &bdev.ScanResponse{
+				Controllers: append(scanCtrlrs, &storage.NvmeController{
+					PciAddr:    "0000:81:00.0",
+					Namespaces: []*storage.NvmeNamespace{{ID: 1, Zoned: true}},
+				}),
+			},
+			inCfgBdevLists:  [][]string{{"0000:90:00.0", "0000:81:00.0"}},
+			expCfgBdevLists: [][]string{{"0000:90:00.0", "0000:81:00.0"}},
+		},
 		"unexpected scan": {
 			numEngines: 2,
 			inScanResp: &bdev.ScanResponse{
@@ -132,7 +154,8 @@ func TestServer_CtlSvc_checkCfgBdevs(t *testing.T) {
 			for idx := 0; idx < tc.numEngines; idx++ {
 				testCfg.Engines[idx] = engine.NewConfig().
 					WithBdevClass("nvme").
-					WithBdevDeviceList(tc.inCfgBdevLists[idx]...)
+					WithBdevDeviceList(tc.inCfgBdevLists[idx]...).
+					WithBdevAllowZoned(tc.allowZoned)
 			}
 
 			mbc := &bdev.MockBackendConfig{VmdEnabled: tc.vmdEnabled}
@@ -154,7 +177,7 @@ func TestServer_CtlSvc_checkCfgBdevs(t *testing.T) {
 			}
 
 			for idx := 0; idx < tc.numEngines; idx++ {
-				cfgBdevs := cs.instanceStorage[idx].Bdev.GetNvmeDevs()
+				cfgBdevs := cs.instanceStorage[idx].Tiers.NvmeDevices()
 				diff := cmp.Diff(tc.expCfgBdevLists[idx], cfgBdevs)
 				if diff != "" {
 					t.Fatalf("unexpected device list (-want, +got):\n%s\n",
@@ -20,9 +20,14 @@ import (
 type ControlService struct {
 	ctlpb.UnimplementedCtlSvcServer
 	StorageControlService
-	harness *EngineHarness
-	srvCfg  *config.Server
-	events  *events.PubSub
+	harness     *EngineHarness
+	srvCfg      *config.Server
+	events      *events.PubSub
+	formatState *formatState
+	verifier    *bdevVerifier
+	discover    discoveryFn
+	runSelfTest selfTestRunFn
+	cfgHash     *configHash
 }
 
 // NewControlService returns ControlService to be used as gRPC control service
@@ -31,12 +36,20 @@ func NewControlService(log logging.Logger, h *EngineHarness,
 	bp *bdev.Provider, sp *scm.Provider,
 	cfg *config.Server, e *events.PubSub) *ControlService {
 
-	scs := NewStorageControlService(log, bp, sp, cfg.Engines)
+	scs := NewStorageControlService(log, bp, sp, cfg.Engines).
+		WithPrepareStateFile(cfg.PrepareStateFile).
+		WithDeviceLedgerFile(cfg.DeviceLedgerFile)
+	scs.inventory = newInventoryExporter(log, cfg)
 
 	return &ControlService{
 		StorageControlService: *scs,
 		harness:               h,
 		srvCfg:                cfg,
 		events:                e,
+		formatState:           newFormatState(),
+		verifier:              newBdevVerifier(log, bp, cfg.BdevVerifyRate),
+		discover:              newDiscoveryFn(cfg),
+		runSelfTest:           runSelfTestCmd,
+		cfgHash:               newConfigHash(log, cfg.Path),
 	}
 }
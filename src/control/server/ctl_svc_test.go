@@ -39,12 +39,20 @@ func mockControlService(t *testing.T, log logging.Logger, cfg *config.Server, bm
 		harness: &EngineHarness{
 			log: log,
 		},
-		events: events.NewPubSub(context.TODO(), log),
-		srvCfg: cfg,
+		events:      events.NewPubSub(context.TODO(), log),
+		srvCfg:      cfg,
+		formatState: newFormatState(),
 	}
+	cs.verifier = newBdevVerifier(log, cs.bdev, cfg.BdevVerifyRate)
+	cs.findConflictingProcs = func() ([]*conflictingProcess, error) {
+		return nil, nil
+	}
+	cs.getHugePageInfo = nil
 
 	for _, engineCfg := range cfg.Engines {
-		bp, err := bdev.NewClassProvider(log, "", &engineCfg.Storage.Bdev)
+		bp, err := bdev.NewClassProvider(log, "", &bdev.ClassConfig{
+			Tiers: engineCfg.Storage.Tiers.BdevConfigs(),
+		})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -0,0 +1,120 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/daos-stack/daos/src/control/build"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/lib/netdetect"
+	"github.com/daos-stack/daos/src/control/lib/spdk"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+// versionGetter returns the version and source revision of a software
+// component, revision may be empty if unknown.
+type versionGetter func(context.Context) (version, revision string, err error)
+
+type componentVersionGetter struct {
+	name string
+	get  versionGetter
+}
+
+// componentVersionGetters lists the software components whose versions are
+// reported in response to a VersionQuery, in the order they are reported.
+var componentVersionGetters = []componentVersionGetter{
+	{name: "daos_server", get: getServerVersion},
+	{name: "daos_engine", get: getEngineVersion},
+	{name: "spdk", get: getSPDKVersion},
+	{name: "dpdk", get: getDPDKVersion},
+	{name: "libfabric", get: getLibFabricVersion},
+	{name: "ndctl", get: getNdctlVersion},
+}
+
+func getServerVersion(_ context.Context) (string, string, error) {
+	return build.DaosVersion, build.Revision, nil
+}
+
+func getEngineVersion(ctx context.Context) (string, string, error) {
+	ver, err := engine.Version(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	// engine is built from the same source tree as the server
+	return ver, build.Revision, nil
+}
+
+func getSPDKVersion(_ context.Context) (string, string, error) {
+	return spdk.Version(), "", nil
+}
+
+func getDPDKVersion(_ context.Context) (string, string, error) {
+	return spdk.DPDKVersion(), "", nil
+}
+
+func getLibFabricVersion(_ context.Context) (string, string, error) {
+	return netdetect.LibFabricVersion(), "", nil
+}
+
+func getNdctlVersion(ctx context.Context) (string, string, error) {
+	cmdPath, err := exec.LookPath("ndctl")
+	if err != nil {
+		return "", "", errors.Wrap(err, "unable to find ndctl")
+	}
+
+	out, err := exec.CommandContext(ctx, cmdPath, "--version").Output()
+	if err != nil {
+		return "", "", errors.Wrap(err, "ndctl --version")
+	}
+
+	return strings.TrimSpace(string(out)), "", nil
+}
+
+// queryComponentVersions calls each of the supplied getters and returns the
+// results, errors are reported per-component rather than failing the query.
+func queryComponentVersions(ctx context.Context, getters []componentVersionGetter) []*ctlpb.ComponentVersion {
+	components := make([]*ctlpb.ComponentVersion, 0, len(getters))
+	for _, g := range getters {
+		cv := &ctlpb.ComponentVersion{Name: g.name}
+
+		ver, rev, err := g.get(ctx)
+		if err != nil {
+			cv.Error = err.Error()
+		} else {
+			cv.Version = ver
+			cv.Revision = rev
+		}
+
+		components = append(components, cv)
+	}
+
+	return components
+}
+
+// VersionQuery retrieves versions of DAOS and dependent software components
+// installed on the server.
+func (c *ControlService) VersionQuery(ctx context.Context, req *ctlpb.VersionQueryReq) (*ctlpb.VersionQueryResp, error) {
+	c.log.Debug("received VersionQuery RPC")
+
+	resp := &ctlpb.VersionQueryResp{
+		Components: queryComponentVersions(ctx, componentVersionGetters),
+	}
+
+	for _, cv := range resp.Components {
+		if cv.Error != "" {
+			c.log.Debugf("failed to determine %s version: %s", cv.Name, cv.Error)
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_queryComponentVersions(t *testing.T) {
+	getVersion := func(ver, rev string, err error) versionGetter {
+		return func(_ context.Context) (string, string, error) {
+			return ver, rev, err
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		getters []componentVersionGetter
+		expResp []*ctlpb.ComponentVersion
+	}{
+		"no getters": {
+			expResp: []*ctlpb.ComponentVersion{},
+		},
+		"mixed results": {
+			getters: []componentVersionGetter{
+				{name: "daos_server", get: getVersion("2.0.0", "abc123", nil)},
+				{name: "spdk", get: getVersion("v21.07", "", nil)},
+				{name: "ndctl", get: getVersion("", "", errors.New("not found"))},
+			},
+			expResp: []*ctlpb.ComponentVersion{
+				{Name: "daos_server", Version: "2.0.0", Revision: "abc123"},
+				{Name: "spdk", Version: "v21.07"},
+				{Name: "ndctl", Error: "not found"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotResp := queryComponentVersions(context.TODO(), tc.getters)
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected result (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_VersionQuery(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	cs := mockControlService(t, log, nil, nil, nil, nil)
+
+	resp, err := cs.VersionQuery(context.TODO(), &ctlpb.VersionQueryReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Components) != len(componentVersionGetters) {
+		t.Fatalf("expected %d components, got %d", len(componentVersionGetters),
+			len(resp.Components))
+	}
+
+	expServer := &ctlpb.ComponentVersion{
+		Name:     "daos_server",
+		Version:  build.DaosVersion,
+		Revision: build.Revision,
+	}
+	if diff := cmp.Diff(expServer, resp.Components[0], common.DefaultCmpOpts()...); diff != "" {
+		t.Fatalf("unexpected result (-want, +got):\n%s\n", diff)
+	}
+}
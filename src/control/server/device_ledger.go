@@ -0,0 +1,292 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const deviceLedgerVersion = 1
+
+// DeviceOwner records the engine instance which owns a storage device.
+type DeviceOwner struct {
+	Device string `yaml:"device"`
+	Class  string `yaml:"class"`
+	Engine uint32 `yaml:"engine"`
+}
+
+// deviceLedgerFile is the on-disk representation of the device ledger.
+type deviceLedgerFile struct {
+	Version uint8          `yaml:"version"`
+	Owners  []*DeviceOwner `yaml:"owners"`
+}
+
+// deviceLedger is a persistent record mapping storage devices (PCI addresses,
+// pmem namespaces, etc.) to the engine instances that own them. It is used to
+// prevent a device from being used by a different engine than the one that
+// originally claimed it, e.g. after a configuration change.
+type deviceLedger struct {
+	sync.RWMutex
+	log    logging.Logger
+	path   string
+	loaded bool
+	owners map[string]*DeviceOwner
+}
+
+// newDeviceLedger returns an initialized, empty device ledger that will be
+// persisted at the given path, an empty path results in a ledger that is not
+// persisted.
+func newDeviceLedger(log logging.Logger, path string) *deviceLedger {
+	return &deviceLedger{
+		log:    log,
+		path:   path,
+		owners: make(map[string]*DeviceOwner),
+	}
+}
+
+// load populates the ledger from its persistent file, a missing file results
+// in an empty ledger. The file is only read once, subsequent changes are made
+// to the ledger in memory and persisted with save.
+func (dl *deviceLedger) load() error {
+	dl.Lock()
+	defer dl.Unlock()
+
+	if dl.path == "" || dl.loaded {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(dl.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			dl.loaded = true
+			return nil
+		}
+		return errors.Wrapf(err, "reading device ledger %s", dl.path)
+	}
+
+	var lf deviceLedgerFile
+	if err := yaml.Unmarshal(data, &lf); err != nil {
+		return errors.Wrapf(err, "parsing device ledger %s", dl.path)
+	}
+	if lf.Version != deviceLedgerVersion {
+		return errors.Errorf("device ledger %s has unsupported version %d",
+			dl.path, lf.Version)
+	}
+
+	dl.owners = make(map[string]*DeviceOwner)
+	for _, owner := range lf.Owners {
+		dl.owners[owner.Device] = owner
+	}
+	dl.loaded = true
+
+	return nil
+}
+
+// save persists the ledger to its file, the file is replaced atomically.
+func (dl *deviceLedger) save() error {
+	if dl.path == "" {
+		return nil
+	}
+
+	dl.RLock()
+	lf := deviceLedgerFile{
+		Version: deviceLedgerVersion,
+		Owners:  dl.sortedOwners(),
+	}
+	dl.RUnlock()
+
+	data, err := yaml.Marshal(lf)
+	if err != nil {
+		return errors.Wrap(err, "marshal device ledger")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dl.path), 0755); err != nil {
+		return errors.Wrapf(err, "creating device ledger directory")
+	}
+
+	tmpPath := dl.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return errors.Wrapf(err, "writing device ledger %s", tmpPath)
+	}
+
+	return errors.Wrapf(os.Rename(tmpPath, dl.path), "replacing device ledger %s", dl.path)
+}
+
+// sortedOwners returns the ledger entries sorted by device, caller must hold
+// the ledger lock.
+func (dl *deviceLedger) sortedOwners() []*DeviceOwner {
+	owners := make([]*DeviceOwner, 0, len(dl.owners))
+	for _, owner := range dl.owners {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].Device < owners[j].Device
+	})
+
+	return owners
+}
+
+// Owners returns a copy of the ledger entries sorted by device.
+func (dl *deviceLedger) Owners() []*DeviceOwner {
+	dl.RLock()
+	defer dl.RUnlock()
+
+	owners := dl.sortedOwners()
+	for i, owner := range owners {
+		copied := *owner
+		owners[i] = &copied
+	}
+
+	return owners
+}
+
+// engineDevices returns the storage devices assigned to an engine in its
+// storage configuration, keyed by device with the device class as value.
+func engineDevices(scmCfg storage.ScmConfig, bdevCfgs ...*storage.BdevConfig) map[string]string {
+	devices := make(map[string]string)
+	if scmCfg.Class == storage.ScmClassDCPM {
+		for _, dev := range scmCfg.DeviceList {
+			devices[dev] = scmCfg.Class.String()
+		}
+	}
+	for _, bdevCfg := range bdevCfgs {
+		if bdevCfg.Class == storage.BdevClassMalloc {
+			continue
+		}
+		for _, dev := range bdevCfg.DeviceList {
+			devices[dev] = bdevCfg.Class.String()
+		}
+	}
+
+	return devices
+}
+
+// verify checks that none of the given devices are owned by an engine other
+// than the one specified.
+func (dl *deviceLedger) verify(idx uint32, devices map[string]string) error {
+	dl.RLock()
+	defer dl.RUnlock()
+
+	devs := make([]string, 0, len(devices))
+	for dev := range devices {
+		devs = append(devs, dev)
+	}
+	sort.Strings(devs)
+
+	for _, dev := range devs {
+		if owner, exists := dl.owners[dev]; exists && owner.Engine != idx {
+			return FaultDeviceOwnedByOtherEngine(dev, owner.Engine, idx)
+		}
+	}
+
+	return nil
+}
+
+// claim records the given engine as the owner of the given devices, failing
+// if any of the devices are already owned by another engine.
+func (dl *deviceLedger) claim(idx uint32, devices map[string]string) error {
+	if err := dl.verify(idx, devices); err != nil {
+		return err
+	}
+
+	dl.Lock()
+	defer dl.Unlock()
+
+	for dev, class := range devices {
+		dl.owners[dev] = &DeviceOwner{
+			Device: dev,
+			Class:  class,
+			Engine: idx,
+		}
+	}
+
+	return nil
+}
+
+// reassign records the given engine as the owner of the given devices,
+// replacing any existing owner, and returns the devices that were previously
+// owned by another engine in sorted order.
+func (dl *deviceLedger) reassign(idx uint32, devices map[string]string) []string {
+	dl.Lock()
+	defer dl.Unlock()
+
+	reassigned := []string{}
+	for dev, class := range devices {
+		if owner, exists := dl.owners[dev]; exists && owner.Engine != idx {
+			reassigned = append(reassigned, dev)
+		}
+		dl.owners[dev] = &DeviceOwner{
+			Device: dev,
+			Class:  class,
+			Engine: idx,
+		}
+	}
+	sort.Strings(reassigned)
+
+	return reassigned
+}
+
+// release removes the ledger entries for which the supplied function returns
+// true and returns the released devices in sorted order.
+func (dl *deviceLedger) release(match func(*DeviceOwner) bool) []string {
+	dl.Lock()
+	defer dl.Unlock()
+
+	released := []string{}
+	for dev, owner := range dl.owners {
+		if match(owner) {
+			released = append(released, dev)
+			delete(dl.owners, dev)
+		}
+	}
+	sort.Strings(released)
+
+	return released
+}
+
+// releaseEngine removes the ledger entries for all devices owned by the given
+// engine and returns the released devices in sorted order.
+func (dl *deviceLedger) releaseEngine(idx uint32) []string {
+	return dl.release(func(owner *DeviceOwner) bool {
+		return owner.Engine == idx
+	})
+}
+
+// claimEngineDevices loads the ledger and records ownership of the storage
+// devices assigned to each engine in the supplied configuration, failing if
+// any device was previously claimed by a different engine.
+func (dl *deviceLedger) claimEngineDevices(engineCfgs []*engine.Config) error {
+	if err := dl.load(); err != nil {
+		return err
+	}
+
+	for idx, cfg := range engineCfgs {
+		devices := engineDevices(cfg.Storage.SCM(), cfg.Storage.Tiers.BdevConfigs()...)
+		if err := dl.claim(uint32(idx), devices); err != nil {
+			return err
+		}
+	}
+
+	if err := dl.save(); err != nil {
+		// ledger can't be persisted but ownership is still enforced
+		// within this process
+		dl.log.Errorf("failed to save device ledger: %s", err)
+	}
+
+	return nil
+}
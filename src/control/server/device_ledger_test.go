@@ -0,0 +1,236 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_deviceLedger_claimEngineDevices(t *testing.T) {
+	engineCfg := func(scmDev string, bdevs ...string) *engine.Config {
+		return engine.NewConfig().
+			WithScmClass("dcpm").
+			WithScmDeviceList(scmDev).
+			WithBdevClass("nvme").
+			WithBdevDeviceList(bdevs...)
+	}
+
+	for name, tc := range map[string]struct {
+		ledgerData string
+		engineCfgs []*engine.Config
+		expOwners  []*DeviceOwner
+		expErr     error
+	}{
+		"no ledger; no engines": {
+			expOwners: []*DeviceOwner{},
+		},
+		"no ledger; two engines": {
+			engineCfgs: []*engine.Config{
+				engineCfg("/dev/pmem0", "0000:81:00.0"),
+				engineCfg("/dev/pmem1", "0000:da:00.0"),
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "/dev/pmem1", Class: "dcpm", Engine: 1},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+				{Device: "0000:da:00.0", Class: "nvme", Engine: 1},
+			},
+		},
+		"ledger matches config": {
+			ledgerData: `
+version: 1
+owners:
+- device: 0000:81:00.0
+  class: nvme
+  engine: 0
+`,
+			engineCfgs: []*engine.Config{
+				engineCfg("/dev/pmem0", "0000:81:00.0"),
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+		},
+		"ledger retains devices no longer configured": {
+			ledgerData: `
+version: 1
+owners:
+- device: 0000:5e:00.0
+  class: nvme
+  engine: 1
+`,
+			engineCfgs: []*engine.Config{
+				engineCfg("/dev/pmem0", "0000:81:00.0"),
+			},
+			expOwners: []*DeviceOwner{
+				{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+				{Device: "0000:5e:00.0", Class: "nvme", Engine: 1},
+				{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+			},
+		},
+		"device moved to another engine": {
+			ledgerData: `
+version: 1
+owners:
+- device: 0000:81:00.0
+  class: nvme
+  engine: 0
+`,
+			engineCfgs: []*engine.Config{
+				engineCfg("/dev/pmem0", "0000:5e:00.0"),
+				engineCfg("/dev/pmem1", "0000:81:00.0"),
+			},
+			expErr: FaultDeviceOwnedByOtherEngine("0000:81:00.0", 0, 1),
+		},
+		"unsupported ledger version": {
+			ledgerData: "version: 42\n",
+			expErr:     errors.New("unsupported version"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			ledgerPath := filepath.Join(testDir, "ledger.yaml")
+			if tc.ledgerData != "" {
+				if err := ioutil.WriteFile(ledgerPath, []byte(tc.ledgerData), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			dl := newDeviceLedger(log, ledgerPath)
+			gotErr := dl.claimEngineDevices(tc.engineCfgs)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expOwners, dl.Owners()); diff != "" {
+				t.Fatalf("unexpected owners (-want, +got):\n%s\n", diff)
+			}
+
+			// reload from disk to verify persistence
+			reloaded := newDeviceLedger(log, ledgerPath)
+			if err := reloaded.load(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expOwners, reloaded.Owners()); diff != "" {
+				t.Fatalf("unexpected persisted owners (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_deviceLedger_release(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	dl := newDeviceLedger(log, "")
+	if err := dl.claim(0, map[string]string{
+		"/dev/pmem0":   "dcpm",
+		"0000:81:00.0": "nvme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	released := dl.release(func(owner *DeviceOwner) bool {
+		return owner.Class == "dcpm"
+	})
+
+	if diff := cmp.Diff([]string{"/dev/pmem0"}, released); diff != "" {
+		t.Fatalf("unexpected released devices (-want, +got):\n%s\n", diff)
+	}
+	expOwners := []*DeviceOwner{
+		{Device: "0000:81:00.0", Class: "nvme", Engine: 0},
+	}
+	if diff := cmp.Diff(expOwners, dl.Owners()); diff != "" {
+		t.Fatalf("unexpected owners (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_deviceLedger_reassign(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	dl := newDeviceLedger(log, "")
+	if err := dl.claim(0, map[string]string{
+		"/dev/pmem0":   "dcpm",
+		"0000:81:00.0": "nvme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dl.claim(1, map[string]string{
+		"0000:82:00.0": "nvme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	reassigned := dl.reassign(1, map[string]string{
+		"0000:81:00.0": "nvme",
+		"0000:82:00.0": "nvme",
+		"0000:83:00.0": "nvme",
+	})
+
+	if diff := cmp.Diff([]string{"0000:81:00.0"}, reassigned); diff != "" {
+		t.Fatalf("unexpected reassigned devices (-want, +got):\n%s\n", diff)
+	}
+	expOwners := []*DeviceOwner{
+		{Device: "/dev/pmem0", Class: "dcpm", Engine: 0},
+		{Device: "0000:81:00.0", Class: "nvme", Engine: 1},
+		{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+		{Device: "0000:83:00.0", Class: "nvme", Engine: 1},
+	}
+	if diff := cmp.Diff(expOwners, dl.Owners()); diff != "" {
+		t.Fatalf("unexpected owners (-want, +got):\n%s\n", diff)
+	}
+	if err := dl.verify(1, map[string]string{"0000:81:00.0": "nvme"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_deviceLedger_releaseEngine(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	dl := newDeviceLedger(log, "")
+	if err := dl.claim(0, map[string]string{
+		"/dev/pmem0":   "dcpm",
+		"0000:81:00.0": "nvme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dl.claim(1, map[string]string{
+		"0000:82:00.0": "nvme",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	released := dl.releaseEngine(0)
+
+	if diff := cmp.Diff([]string{"/dev/pmem0", "0000:81:00.0"}, released); diff != "" {
+		t.Fatalf("unexpected released devices (-want, +got):\n%s\n", diff)
+	}
+	expOwners := []*DeviceOwner{
+		{Device: "0000:82:00.0", Class: "nvme", Engine: 1},
+	}
+	if diff := cmp.Diff(expOwners, dl.Owners()); diff != "" {
+		t.Fatalf("unexpected owners (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,136 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const (
+	discoveryPluginVersion = 1
+	discoveryPluginTimeout = 10 * time.Second
+)
+
+var (
+	// labelNameRe matches valid Prometheus label names.
+	labelNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// reservedLabels are label names already applied to exported telemetry.
+	reservedLabels = map[string]struct{}{
+		"rank":    {},
+		"target":  {},
+		"context": {},
+		"pool":    {},
+	}
+)
+
+// discoveryPluginReq is the request passed to a hardware discovery plugin as
+// JSON on stdin.
+type discoveryPluginReq struct {
+	Version    int      `json:"version"`
+	Hostname   string   `json:"hostname"`
+	Interfaces []string `json:"interfaces"`
+}
+
+// discoveryData is the site-specific hardware discovery data returned by a
+// hardware discovery plugin as JSON on stdout.
+type discoveryData struct {
+	// InterfaceAliases maps network interface names to custom names.
+	InterfaceAliases map[string]string `json:"interface_aliases"`
+	// Labels contains arbitrary host attributes, e.g. rack, row or asset tag.
+	Labels map[string]string `json:"labels"`
+}
+
+func (dd *discoveryData) validate() error {
+	for name := range dd.Labels {
+		if !labelNameRe.MatchString(name) {
+			return errors.Errorf("invalid label name %q", name)
+		}
+		if _, reserved := reservedLabels[name]; reserved {
+			return errors.Errorf("label name %q is reserved", name)
+		}
+	}
+
+	return nil
+}
+
+// discoveryFn retrieves site-specific hardware discovery data for the given
+// network interfaces.
+type discoveryFn func(ctx context.Context, interfaces []string) (*discoveryData, error)
+
+// newDiscoveryFn returns a discoveryFn which runs the hardware discovery plugin
+// specified in the supplied config, or nil if no plugin is configured.
+func newDiscoveryFn(cfg *config.Server) discoveryFn {
+	if cfg == nil || cfg.DiscoveryPlugin == "" {
+		return nil
+	}
+
+	return func(ctx context.Context, interfaces []string) (*discoveryData, error) {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+
+		return runDiscoveryPlugin(ctx, cfg.DiscoveryPlugin, build.ConfigDir,
+			&discoveryPluginReq{
+				Version:    discoveryPluginVersion,
+				Hostname:   hostname,
+				Interfaces: interfaces,
+			})
+	}
+}
+
+// runDiscoveryPlugin executes the plugin at the given path, passing the request
+// on stdin and decoding the discovery data written to stdout.
+func runDiscoveryPlugin(ctx context.Context, path, requiredDir string, req *discoveryPluginReq) (*discoveryData, error) {
+	if err := checkExecutable(path, requiredDir, execFaults{
+		notFound: config.FaultConfigDiscoveryPluginNotFound,
+		badPerms: config.FaultConfigDiscoveryPluginBadPerms,
+		insecure: config.FaultConfigDiscoveryPluginInsecure,
+	}); err != nil {
+		return nil, err
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, discoveryPluginTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(input)
+	output, err := cmd.Output()
+	if os.IsPermission(err) {
+		return nil, config.FaultConfigDiscoveryPluginBadPerms
+	} else if err != nil {
+		return nil, config.FaultConfigDiscoveryPluginFailed(err)
+	}
+
+	dd := new(discoveryData)
+	if err := json.Unmarshal(output, dd); err != nil {
+		return nil, config.FaultConfigDiscoveryPluginFailed(
+			errors.Wrap(err, "decoding plugin output"))
+	}
+	if err := dd.validate(); err != nil {
+		return nil, config.FaultConfigDiscoveryPluginFailed(err)
+	}
+
+	return dd, nil
+}
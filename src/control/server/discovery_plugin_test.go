@@ -0,0 +1,154 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_runDiscoveryPlugin(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	createPlugin := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		createScriptFile(t, path, 0755, content)
+		return path
+	}
+
+	goodPlugin := createPlugin("good.sh",
+		`cat > /dev/null; echo '{"interface_aliases": {"ib0": "hsn0"}, "labels": {"rack": "r12"}}'`)
+	echoPlugin := createPlugin("echo.sh",
+		`echo "{\"labels\": {\"hostname\": \"$(cat | sed -e 's/.*"hostname":"\([^"]*\)".*/\1/')\"}}"`)
+	junkPlugin := createPlugin("junk.sh", "echo junk")
+	badLabelPlugin := createPlugin("badlabel.sh", `echo '{"labels": {"asset-tag": "A1"}}'`)
+	reservedPlugin := createPlugin("reserved.sh", `echo '{"labels": {"rank": "1"}}'`)
+	failPlugin := createPlugin("fail.sh", "exit 2")
+
+	tooLaxPlugin := filepath.Join(tmpDir, "toolax.sh")
+	createScriptFile(t, tooLaxPlugin, 0666, "echo '{}'")
+
+	for name, tc := range map[string]struct {
+		path    string
+		expData *discoveryData
+		expErr  error
+	}{
+		"missing plugin": {
+			path:   filepath.Join(tmpDir, "notarealfile"),
+			expErr: config.FaultConfigDiscoveryPluginNotFound,
+		},
+		"insecure plugin": {
+			path:   tooLaxPlugin,
+			expErr: config.FaultConfigDiscoveryPluginInsecure(tmpDir),
+		},
+		"plugin fails": {
+			path:   failPlugin,
+			expErr: config.FaultConfigDiscoveryPluginFailed(errors.New("exit status 2")),
+		},
+		"invalid output": {
+			path:   junkPlugin,
+			expErr: errors.New("decoding plugin output"),
+		},
+		"invalid label name": {
+			path:   badLabelPlugin,
+			expErr: errors.New("invalid label name"),
+		},
+		"reserved label name": {
+			path:   reservedPlugin,
+			expErr: errors.New("is reserved"),
+		},
+		"request passed on stdin": {
+			path: echoPlugin,
+			expData: &discoveryData{
+				Labels: map[string]string{"hostname": "host1"},
+			},
+		},
+		"success": {
+			path: goodPlugin,
+			expData: &discoveryData{
+				InterfaceAliases: map[string]string{"ib0": "hsn0"},
+				Labels:           map[string]string{"rack": "r12"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := &discoveryPluginReq{
+				Version:    discoveryPluginVersion,
+				Hostname:   "host1",
+				Interfaces: []string{"ib0"},
+			}
+
+			gotData, gotErr := runDiscoveryPlugin(context.TODO(), tc.path, tmpDir, req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expData, gotData); diff != "" {
+				t.Fatalf("unexpected discovery data (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_applyDiscoveryData(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data    *discoveryData
+		expResp *ctlpb.NetworkScanResp
+	}{
+		"nil data": {
+			expResp: &ctlpb.NetworkScanResp{
+				Interfaces: []*ctlpb.FabricInterface{
+					{Device: "ib0"}, {Device: "eth0"},
+				},
+			},
+		},
+		"aliases and labels": {
+			data: &discoveryData{
+				InterfaceAliases: map[string]string{"ib0": "hsn0"},
+				Labels: map[string]string{
+					"row":       "b",
+					"asset_tag": "A1234",
+					"rack":      "r12",
+				},
+			},
+			expResp: &ctlpb.NetworkScanResp{
+				Interfaces: []*ctlpb.FabricInterface{
+					{Device: "ib0", Alias: "hsn0"}, {Device: "eth0"},
+				},
+				Labels: []*ctlpb.HostLabel{
+					{Name: "asset_tag", Value: "A1234"},
+					{Name: "rack", Value: "r12"},
+					{Name: "row", Value: "b"},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &ctlpb.NetworkScanResp{
+				Interfaces: []*ctlpb.FabricInterface{
+					{Device: "ib0"}, {Device: "eth0"},
+				},
+			}
+
+			applyDiscoveryData(resp, tc.data)
+
+			if diff := cmp.Diff(tc.expResp, resp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -7,7 +7,9 @@
 package engine
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -21,23 +23,80 @@ const maxHelperStreamCount = 2
 // engine's configuration.
 var ErrNoPinnedNumaNode = errors.New("pinned NUMA node was not configured")
 
-// StorageConfig encapsulates an I/O Engine's storage configuration.
+// StorageConfig encapsulates an I/O Engine's storage configuration, an ordered
+// list of storage tiers starting with the SCM tier, together with the engine
+// parameters derived from the block device tiers.
 type StorageConfig struct {
-	SCM  storage.ScmConfig  `yaml:",inline"`
-	Bdev storage.BdevConfig `yaml:",inline"`
+	Tiers       storage.TierConfigs `yaml:"storage,omitempty"`
+	ConfigPath  string              `yaml:"-" cmdLongFlag:"--nvme" cmdShortFlag:"-n"`
+	VosEnv      string              `yaml:"-" cmdEnv:"VOS_BDEV_CLASS"`
+	MemSize     int                 `yaml:"-" cmdLongFlag:"--mem_size,nonzero" cmdShortFlag:"-r,nonzero"`
+	VmdDisabled bool                `yaml:"-"` // set during start-up
+	Hostname    string              `yaml:"-"` // used when generating templates
+	// ControlMetadata is set from the server config.
+	ControlMetadata storage.ControlMetadata `yaml:"-"`
 }
 
 // Validate ensures that the configuration meets minimum standards.
 func (sc *StorageConfig) Validate() error {
-	if err := sc.SCM.Validate(); err != nil {
-		return errors.Wrap(err, "scm config validation failed")
-	}
-	if err := sc.Bdev.Validate(); err != nil {
-		return errors.Wrap(err, "bdev config validation failed")
+	if err := sc.Tiers.Validate(); err != nil {
+		return errors.Wrap(err, "storage tier config validation failed")
 	}
 	return nil
 }
 
+// SCM returns the configuration of the SCM tier. An empty configuration is
+// returned if no SCM tier has been set.
+func (sc *StorageConfig) SCM() storage.ScmConfig {
+	if scmCfg := sc.Tiers.ScmConfig(); scmCfg != nil {
+		return *scmCfg
+	}
+	return storage.ScmConfig{}
+}
+
+// scmTier returns the SCM tier, adding it as the first tier if necessary.
+func (sc *StorageConfig) scmTier() *storage.TierConfig {
+	if len(sc.Tiers) == 0 || sc.Tiers[0].IsBdev() {
+		sc.Tiers = append(storage.TierConfigs{new(storage.TierConfig)}, sc.Tiers...)
+	}
+	return sc.Tiers[0]
+}
+
+// bdevTier returns the first block device tier, adding it after any existing
+// tiers if necessary.
+func (sc *StorageConfig) bdevTier() *storage.TierConfig {
+	for _, tc := range sc.Tiers {
+		if tc.IsBdev() {
+			return tc
+		}
+	}
+	if n := len(sc.Tiers); n > 1 && !sc.Tiers[n-1].IsSCM() {
+		return sc.Tiers[n-1]
+	}
+	tc := new(storage.TierConfig)
+	sc.Tiers = append(sc.Tiers, tc)
+	return tc
+}
+
+// legacyStorageConfig holds the storage parameters of configurations written
+// before storage tiers were introduced, which describe one SCM tier and at
+// most one block device tier.
+type legacyStorageConfig struct {
+	SCM  storage.ScmConfig  `yaml:",inline"`
+	Bdev storage.BdevConfig `yaml:",inline"`
+}
+
+// tiers returns the storage tiers described by the legacy parameters.
+func (lsc *legacyStorageConfig) tiers() storage.TierConfigs {
+	var tiers storage.TierConfigs
+	for _, tc := range []*storage.TierConfig{{Scm: lsc.SCM}, {Bdev: lsc.Bdev}} {
+		if tc.IsSCM() || tc.IsBdev() {
+			tiers = append(tiers, tc)
+		}
+	}
+	return tiers
+}
+
 // FabricConfig encapsulates networking fabric configuration.
 type FabricConfig struct {
 	Provider        string `yaml:"provider,omitempty" cmdEnv:"CRT_PHY_ADDR_STR"`
@@ -158,20 +217,51 @@ func mergeEnvVars(curVars []string, newVars []string) (merged []string) {
 
 // Config encapsulates an I/O Engine's configuration.
 type Config struct {
-	Rank              *system.Rank  `yaml:"rank,omitempty"`
-	Modules           string        `yaml:"modules,omitempty" cmdLongFlag:"--modules" cmdShortFlag:"-m"`
-	TargetCount       int           `yaml:"targets,omitempty" cmdLongFlag:"--targets,nonzero" cmdShortFlag:"-t,nonzero"`
-	HelperStreamCount int           `yaml:"nr_xs_helpers" cmdLongFlag:"--xshelpernr" cmdShortFlag:"-x"`
-	ServiceThreadCore int           `yaml:"first_core" cmdLongFlag:"--firstcore,nonzero" cmdShortFlag:"-f,nonzero"`
-	SystemName        string        `yaml:"name,omitempty" cmdLongFlag:"--group" cmdShortFlag:"-g"`
-	SocketDir         string        `yaml:"socket_dir,omitempty" cmdLongFlag:"--socket_dir" cmdShortFlag:"-d"`
-	LogMask           string        `yaml:"log_mask,omitempty" cmdEnv:"D_LOG_MASK"`
-	LogFile           string        `yaml:"log_file,omitempty" cmdEnv:"D_LOG_FILE"`
-	Storage           StorageConfig `yaml:",inline"`
-	Fabric            FabricConfig  `yaml:",inline"`
-	EnvVars           []string      `yaml:"env_vars,omitempty"`
-	EnvPassThrough    []string      `yaml:"env_pass_through,omitempty"`
-	Index             uint32        `yaml:"-" cmdLongFlag:"--instance_idx" cmdShortFlag:"-I"`
+	Rank              *system.Rank      `yaml:"rank,omitempty"`
+	Spare             bool              `yaml:"spare,omitempty"`
+	Modules           string            `yaml:"modules,omitempty" cmdLongFlag:"--modules" cmdShortFlag:"-m"`
+	TargetCount       int               `yaml:"targets,omitempty" cmdLongFlag:"--targets,nonzero" cmdShortFlag:"-t,nonzero"`
+	HelperStreamCount int               `yaml:"nr_xs_helpers" cmdLongFlag:"--xshelpernr" cmdShortFlag:"-x"`
+	ServiceThreadCore int               `yaml:"first_core" cmdLongFlag:"--firstcore,nonzero" cmdShortFlag:"-f,nonzero"`
+	SystemName        string            `yaml:"name,omitempty" cmdLongFlag:"--group" cmdShortFlag:"-g"`
+	SocketDir         string            `yaml:"socket_dir,omitempty" cmdLongFlag:"--socket_dir" cmdShortFlag:"-d"`
+	LogMask           string            `yaml:"log_mask,omitempty" cmdEnv:"D_LOG_MASK"`
+	LogFile           string            `yaml:"log_file,omitempty" cmdEnv:"D_LOG_FILE"`
+	LogRotation       LogRotationConfig `yaml:"log_rotation,omitempty"`
+	Shutdown          ShutdownConfig    `yaml:"shutdown,omitempty"`
+	Storage           StorageConfig     `yaml:",inline"`
+	Fabric            FabricConfig      `yaml:",inline"`
+	EnvVars           []string          `yaml:"env_vars,omitempty"`
+	EnvPassThrough    []string          `yaml:"env_pass_through,omitempty"`
+	Index             uint32            `yaml:"-" cmdLongFlag:"--instance_idx" cmdShortFlag:"-I"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler on Config type. Storage parameters
+// set directly in the engine section are converted into storage tiers.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type configAlias Config
+	aux := struct {
+		Config configAlias         `yaml:",inline"`
+		Legacy legacyStorageConfig `yaml:",inline"`
+	}{
+		Config: configAlias(*c),
+	}
+	if err := unmarshal(&aux); err != nil {
+		return err
+	}
+	*c = Config(aux.Config)
+
+	legacyTiers := aux.Legacy.tiers()
+	if len(legacyTiers) == 0 {
+		return nil
+	}
+	if len(c.Storage.Tiers) > 0 {
+		return errors.New("engine scm_* and bdev_* parameters may not be set " +
+			"together with storage tiers")
+	}
+	c.Storage.Tiers = legacyTiers
+
+	return nil
 }
 
 // NewConfig returns an I/O Engine config.
@@ -191,6 +281,30 @@ func (c *Config) Validate() error {
 		return errors.Wrap(err, "storage config validation failed")
 	}
 
+	if _, err := expandLogFile(c.LogFile, LogFileVars{}); err != nil {
+		return err
+	}
+
+	if err := c.LogRotation.Validate(); err != nil {
+		return errors.Wrap(err, "log_rotation config validation failed")
+	}
+
+	if err := c.Shutdown.Validate(); err != nil {
+		return errors.Wrap(err, "shutdown config validation failed")
+	}
+
+	return nil
+}
+
+// ExpandLogFile replaces the template variables in the log file path with the
+// values for the engine with the given index on the given host.
+func (c *Config) ExpandLogFile(hostname string, idx int, now time.Time) error {
+	logFile, err := expandLogFile(c.LogFile, newLogFileVars(hostname, idx, now))
+	if err != nil {
+		return err
+	}
+	c.LogFile = logFile
+
 	return nil
 }
 
@@ -208,6 +322,17 @@ func (c *Config) CmdLineEnv() ([]string, error) {
 		return nil, err
 	}
 
+	logSize, err := c.LogRotation.maxSizeBytes()
+	if err != nil {
+		return nil, err
+	}
+	if logSize > 0 {
+		tagEnv = append(tagEnv, fmt.Sprintf("%s=%d", logSizeEnv, logSize))
+	}
+	if !c.Shutdown.flushBeforeExit() {
+		tagEnv = append(tagEnv, shutdownFlushEnv+"=0")
+	}
+
 	return mergeEnvVars(c.EnvVars, tagEnv), nil
 }
 
@@ -245,6 +370,12 @@ func (c *Config) WithRank(r uint32) *Config {
 	return c
 }
 
+// WithSpare sets whether the instance is held in standby as a warm spare.
+func (c *Config) WithSpare(spare bool) *Config {
+	c.Spare = spare
+	return c
+}
+
 // WithSystemName sets the system name to which the instance belongs.
 func (c *Config) WithSystemName(name string) *Config {
 	c.SystemName = name
@@ -253,7 +384,7 @@ func (c *Config) WithSystemName(name string) *Config {
 
 // WithHostname sets the hostname to be used when generating NVMe configurations.
 func (c *Config) WithHostname(name string) *Config {
-	c.Storage.Bdev.Hostname = name
+	c.Storage.Hostname = name
 	return c
 }
 
@@ -265,56 +396,80 @@ func (c *Config) WithSocketDir(dir string) *Config {
 
 // WithScmClass defines the type of SCM storage to be configured.
 func (c *Config) WithScmClass(scmClass string) *Config {
-	c.Storage.SCM.Class = storage.ScmClass(scmClass)
+	c.Storage.scmTier().Scm.Class = storage.ScmClass(scmClass)
 	return c
 }
 
 // WithScmMountPoint sets the path to the device used for SCM storage.
 func (c *Config) WithScmMountPoint(scmPath string) *Config {
-	c.Storage.SCM.MountPoint = scmPath
+	c.Storage.scmTier().Scm.MountPoint = scmPath
 	return c
 }
 
 // WithScmRamdiskSize sets the size (in GB) of the ramdisk used
 // to emulate SCM (no effect if ScmClass is not RAM).
 func (c *Config) WithScmRamdiskSize(size int) *Config {
-	c.Storage.SCM.RamdiskSize = size
+	c.Storage.scmTier().Scm.RamdiskSize = size
 	return c
 }
 
 // WithScmDeviceList sets the list of devices to be used for SCM storage.
 func (c *Config) WithScmDeviceList(devices ...string) *Config {
-	c.Storage.SCM.DeviceList = devices
+	c.Storage.scmTier().Scm.DeviceList = devices
+	return c
+}
+
+// WithStorage replaces the storage tiers with the supplied list.
+func (c *Config) WithStorage(tiers ...*storage.TierConfig) *Config {
+	c.Storage.Tiers = tiers
 	return c
 }
 
 // WithBdevClass defines the type of block device storage to be used.
 func (c *Config) WithBdevClass(bdevClass string) *Config {
-	c.Storage.Bdev.Class = storage.BdevClass(bdevClass)
+	c.Storage.bdevTier().Bdev.Class = storage.BdevClass(bdevClass)
 	return c
 }
 
 // WithBdevDeviceList sets the list of block devices to be used.
 func (c *Config) WithBdevDeviceList(devices ...string) *Config {
-	c.Storage.Bdev.DeviceList = devices
+	c.Storage.bdevTier().Bdev.DeviceList = devices
+	return c
+}
+
+// WithBdevAllowZoned permits NVMe SSDs with zoned (ZNS) namespaces to be used.
+func (c *Config) WithBdevAllowZoned(allow bool) *Config {
+	c.Storage.bdevTier().Bdev.AllowZoned = allow
+	return c
+}
+
+// WithBdevLbaFormat sets the LBA format NVMe SSD namespaces are reformatted to.
+func (c *Config) WithBdevLbaFormat(lbaFormat string) *Config {
+	c.Storage.bdevTier().Bdev.LbaFormat = lbaFormat
+	return c
+}
+
+// WithBdevLbaFormats sets the LBA formats of individual NVMe SSDs.
+func (c *Config) WithBdevLbaFormats(lbaFormats map[string]string) *Config {
+	c.Storage.bdevTier().Bdev.LbaFormats = lbaFormats
 	return c
 }
 
 // WithBdevDeviceCount sets the number of devices to be created when BdevClass is malloc.
 func (c *Config) WithBdevDeviceCount(count int) *Config {
-	c.Storage.Bdev.DeviceCount = count
+	c.Storage.bdevTier().Bdev.DeviceCount = count
 	return c
 }
 
 // WithBdevFileSize sets the backing file size (used when BdevClass is malloc or file).
 func (c *Config) WithBdevFileSize(size int) *Config {
-	c.Storage.Bdev.FileSize = size
+	c.Storage.bdevTier().Bdev.FileSize = size
 	return c
 }
 
 // WithBdevConfigPath sets the path to the generated NVMe config file used by SPDK.
 func (c *Config) WithBdevConfigPath(cfgPath string) *Config {
-	c.Storage.Bdev.ConfigPath = cfgPath
+	c.Storage.ConfigPath = cfgPath
 	return c
 }
 
@@ -390,6 +545,22 @@ func (c *Config) WithLogFile(logPath string) *Config {
 	return c
 }
 
+// WithShutdown sets the shutdown parameters for this instance.
+func (c *Config) WithShutdown(gracePeriod time.Duration, escalate bool, flush *bool) *Config {
+	c.Shutdown = ShutdownConfig{
+		GracePeriod:     gracePeriod,
+		Escalate:        escalate,
+		FlushBeforeExit: flush,
+	}
+	return c
+}
+
+// WithLogRotation sets the log file rotation parameters for this instance.
+func (c *Config) WithLogRotation(maxSize string, maxAge time.Duration) *Config {
+	c.LogRotation = LogRotationConfig{MaxSize: maxSize, MaxAge: maxAge}
+	return c
+}
+
 // WithLogMask sets the DAOS logging mask to be used by this instance.
 func (c *Config) WithLogMask(logMask string) *Config {
 	c.LogMask = logMask
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -18,6 +19,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/storage"
 )
 
 var update = flag.Bool("update", false, "update .golden files")
@@ -119,12 +121,12 @@ func TestConfigHasEnvVar(t *testing.T) {
 	}
 }
 
-func TestConstructedConfig(t *testing.T) {
+// constructedConfig returns a config with all values set regardless of
+// validity.
+func constructedConfig() *Config {
 	var numaNode uint = 8
-	goldenPath := "testdata/full.golden"
 
-	// just set all values regardless of validity
-	constructed := NewConfig().
+	return NewConfig().
 		WithRank(37).
 		WithSystemName("foo").
 		WithSocketDir("/foo/bar").
@@ -148,6 +150,11 @@ func TestConstructedConfig(t *testing.T) {
 		WithHelperStreamCount(1).
 		WithPinnedNumaNode(&numaNode).
 		WithBypassHealthChk(nil)
+}
+
+func TestConstructedConfig(t *testing.T) {
+	goldenPath := "testdata/full.golden"
+	constructed := constructedConfig()
 
 	if *update {
 		outFile, err := os.Create(goldenPath)
@@ -176,6 +183,191 @@ func TestConstructedConfig(t *testing.T) {
 	}
 }
 
+func TestEngine_LegacyStorageConfig(t *testing.T) {
+	fromDisk := &Config{}
+	file, err := os.Open("testdata/legacy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := yaml.NewDecoder(file)
+	if err := d.Decode(fromDisk); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(fromDisk, constructedConfig(), cmpOpts()...); diff != "" {
+		t.Fatalf("(-want, +got):\n%s", diff)
+	}
+}
+
+func TestEngine_StorageConfigUnmarshal(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in       string
+		expTiers storage.TierConfigs
+		expErr   error
+	}{
+		"legacy": {
+			in: `
+scm_mount: /mnt/daos
+scm_class: ram
+scm_size: 16
+bdev_class: nvme
+bdev_list: ["0000:81:00.0"]
+`,
+			expTiers: storage.TierConfigs{
+				{Scm: storage.ScmConfig{
+					MountPoint:  "/mnt/daos",
+					Class:       storage.ScmClassRAM,
+					RamdiskSize: 16,
+				}},
+				{Bdev: storage.BdevConfig{
+					Class:      storage.BdevClassNvme,
+					DeviceList: []string{"0000:81:00.0"},
+				}},
+			},
+		},
+		"multiple tiers": {
+			in: `
+storage:
+- scm_mount: /mnt/daos
+  scm_class: dcpm
+  scm_list: [/dev/pmem0]
+- bdev_class: nvme
+  bdev_list: ["0000:81:00.0"]
+  bdev_roles: [wal, meta]
+- bdev_class: nvme
+  bdev_list: ["0000:82:00.0", "0000:83:00.0"]
+  bdev_roles: [data]
+`,
+			expTiers: storage.TierConfigs{
+				{Scm: storage.ScmConfig{
+					MountPoint: "/mnt/daos",
+					Class:      storage.ScmClassDCPM,
+					DeviceList: []string{"/dev/pmem0"},
+				}},
+				{Bdev: storage.BdevConfig{
+					Class:      storage.BdevClassNvme,
+					DeviceList: []string{"0000:81:00.0"},
+					Roles:      storage.BdevRoles{storage.BdevRoleWAL, storage.BdevRoleMeta},
+				}},
+				{Bdev: storage.BdevConfig{
+					Class:      storage.BdevClassNvme,
+					DeviceList: []string{"0000:82:00.0", "0000:83:00.0"},
+					Roles:      storage.BdevRoles{storage.BdevRoleData},
+				}},
+			},
+		},
+		"unknown role": {
+			in: `
+storage:
+- scm_mount: /mnt/daos
+  scm_class: ram
+  scm_size: 16
+- bdev_class: nvme
+  bdev_roles: [cache]
+`,
+			expErr: errors.New("bdev_roles"),
+		},
+		"legacy and tiers": {
+			in: `
+scm_mount: /mnt/daos
+storage:
+- scm_mount: /mnt/daos
+  scm_class: ram
+  scm_size: 16
+`,
+			expErr: errors.New("storage"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := new(Config)
+			gotErr := yaml.UnmarshalStrict([]byte(tc.in), cfg)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expTiers, cfg.Storage.Tiers); diff != "" {
+				t.Fatalf("unexpected tiers (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEngine_StorageTierValidation(t *testing.T) {
+	scmTier := func() *storage.TierConfig {
+		return &storage.TierConfig{Scm: storage.ScmConfig{
+			MountPoint: "/mnt/daos",
+			Class:      storage.ScmClassDCPM,
+			DeviceList: []string{"/dev/pmem0"},
+		}}
+	}
+	nvmeTier := func(roles storage.BdevRoles, addrs ...int32) *storage.TierConfig {
+		tier := &storage.TierConfig{Bdev: storage.BdevConfig{
+			Class: storage.BdevClassNvme,
+			Roles: roles,
+		}}
+		for _, addr := range addrs {
+			tier.Bdev.DeviceList = append(tier.Bdev.DeviceList, common.MockPCIAddr(addr))
+		}
+		return tier
+	}
+	baseValidConfig := func() *Config {
+		return NewConfig().
+			WithFabricProvider("test"). // valid enough to pass "not-blank" test
+			WithFabricInterface("test").
+			WithFabricInterfacePort(42)
+	}
+	walMeta := storage.BdevRoles{storage.BdevRoleWAL, storage.BdevRoleMeta}
+	data := storage.BdevRoles{storage.BdevRoleData}
+
+	for name, tc := range map[string]struct {
+		cfg    *Config
+		expErr error
+	}{
+		"scm only": {
+			cfg: baseValidConfig().WithStorage(scmTier()),
+		},
+		"three tiers": {
+			cfg: baseValidConfig().WithStorage(scmTier(),
+				nvmeTier(nil, 1), nvmeTier(nil, 2, 3)),
+		},
+		"four tiers": {
+			cfg: baseValidConfig().WithStorage(scmTier(),
+				nvmeTier(nil, 1), nvmeTier(nil, 2), nvmeTier(nil, 3)),
+		},
+		"scm not first": {
+			cfg:    baseValidConfig().WithStorage(nvmeTier(nil, 1), scmTier()),
+			expErr: errors.New("first storage tier"),
+		},
+		"multiple scm tiers": {
+			cfg:    baseValidConfig().WithStorage(scmTier(), scmTier()),
+			expErr: errors.New("scm"),
+		},
+		"roles set": {
+			cfg: baseValidConfig().WithStorage(scmTier(),
+				nvmeTier(walMeta, 1), nvmeTier(data, 2)),
+			expErr: errors.New("bdev_roles not supported"),
+		},
+		"device in multiple tiers": {
+			cfg: baseValidConfig().WithStorage(scmTier(),
+				nvmeTier(nil, 1), nvmeTier(nil, 1)),
+			expErr: errors.New("repeated"),
+		},
+		"mixed classes": {
+			cfg: baseValidConfig().WithStorage(scmTier(),
+				nvmeTier(nil, 1), &storage.TierConfig{Bdev: storage.BdevConfig{
+					Class:      storage.BdevClassKdev,
+					DeviceList: []string{"/dev/sdb"},
+				}}),
+			expErr: errors.New("class"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.CmpErr(t, tc.expErr, tc.cfg.Validate())
+		})
+	}
+}
+
 func TestEngine_ScmConfigValidation(t *testing.T) {
 	baseValidConfig := func() *Config {
 		return NewConfig().
@@ -188,8 +380,14 @@ func TestEngine_ScmConfigValidation(t *testing.T) {
 		cfg    *Config
 		expErr error
 	}{
-		"missing scm_mount": {
+		"missing scm tier": {
 			cfg:    baseValidConfig(),
+			expErr: errors.New("scm tier"),
+		},
+		"missing scm_mount": {
+			cfg: baseValidConfig().
+				WithScmClass("ram").
+				WithScmRamdiskSize(1),
 			expErr: errors.New("scm_mount"),
 		},
 		"missing scm_class": {
@@ -315,6 +513,47 @@ func TestEngine_BdevConfigValidation(t *testing.T) {
 				WithBdevClass("file"),
 			expErr: errors.New("file requires non-zero bdev_size"),
 		},
+		"allow zoned with nvme class": {
+			cfg: baseValidConfig().
+				WithBdevClass("nvme").
+				WithBdevDeviceList(common.MockPCIAddr(1)).
+				WithBdevAllowZoned(true),
+		},
+		"allow zoned with kdev class": {
+			cfg: baseValidConfig().
+				WithBdevClass("kdev").
+				WithBdevDeviceList("/dev/sdb").
+				WithBdevAllowZoned(true),
+			expErr: errors.New("bdev_allow_zoned not supported with bdev_class kdev"),
+		},
+		"lba format policy": {
+			cfg: baseValidConfig().
+				WithBdevClass("nvme").
+				WithBdevDeviceList(common.MockPCIAddr(1), common.MockPCIAddr(2)).
+				WithBdevLbaFormat("best-performance").
+				WithBdevLbaFormats(map[string]string{common.MockPCIAddr(2): "512+8"}),
+		},
+		"invalid lba format": {
+			cfg: baseValidConfig().
+				WithBdevClass("nvme").
+				WithBdevDeviceList(common.MockPCIAddr(1)).
+				WithBdevLbaFormat("1024"),
+			expErr: errors.New("invalid LBA format"),
+		},
+		"lba format for device not in list": {
+			cfg: baseValidConfig().
+				WithBdevClass("nvme").
+				WithBdevDeviceList(common.MockPCIAddr(1)).
+				WithBdevLbaFormats(map[string]string{common.MockPCIAddr(2): "4096"}),
+			expErr: errors.New("not in bdev_list"),
+		},
+		"lba format with file class": {
+			cfg: baseValidConfig().
+				WithBdevClass("file").
+				WithBdevFileSize(10).
+				WithBdevLbaFormat("4096"),
+			expErr: errors.New("bdev_lba_format not supported with bdev_class file"),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			common.CmpErr(t, tc.expErr, tc.cfg.Validate())
@@ -402,6 +641,7 @@ func TestConfigToCmdVals(t *testing.T) {
 		index           = 2
 		pinnedNumaNode  = uint(1)
 		bypass          = true
+		flush           = false
 		crtCtxShareAddr = uint32(1)
 		crtTimeout      = uint32(30)
 	)
@@ -419,6 +659,8 @@ func TestConfigToCmdVals(t *testing.T) {
 		WithSocketDir(socketDir).
 		WithLogFile(logFile).
 		WithLogMask(logMask).
+		WithLogRotation("1GiB", 0).
+		WithShutdown(time.Minute, true, &flush).
 		WithBdevConfigPath(cfgPath).
 		WithSystemName(systemName).
 		WithCrtCtxShareAddr(crtCtxShareAddr).
@@ -445,6 +687,8 @@ func TestConfigToCmdVals(t *testing.T) {
 		"CRT_PHY_ADDR_STR=" + provider,
 		"D_LOG_FILE=" + logFile,
 		"D_LOG_MASK=" + logMask,
+		"D_LOG_SIZE=1073741824",
+		"DAOS_SHUTDOWN_FLUSH=0",
 		"CRT_TIMEOUT=" + strconv.FormatUint(uint64(crtTimeout), 10),
 		"CRT_CTX_SHARE_ADDR=" + strconv.FormatUint(uint64(crtCtxShareAddr), 10),
 	}
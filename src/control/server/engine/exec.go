@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -44,7 +46,7 @@ func (r *Runner) run(ctx context.Context, args, env []string) error {
 		return errors.Wrapf(err, "can't start %s", engineBin)
 	}
 
-	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd := exec.Command(binPath, args...)
 	cmd.Stdout = &cmdLogger{
 		logFn:  r.log.Info,
 		prefix: fmt.Sprintf("%s:%d", engineBin, r.Config.Index),
@@ -79,9 +81,42 @@ func (r *Runner) run(ctx context.Context, args, env []string) error {
 	r.running.SetTrue()
 	defer r.running.SetFalse()
 
+	exited := make(chan struct{})
+	defer close(exited)
+	go func() {
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			r.shutdown(cmd.Process, exited)
+		}
+	}()
+
 	return errors.Wrapf(common.GetExitStatus(cmd.Wait()), "%s exited", binPath)
 }
 
+// shutdown stops the engine process when the runner context is canceled. The
+// engine is sent SIGTERM and killed if it has not exited within the grace
+// period as daos_server is shutting down.
+func (r *Runner) shutdown(proc *os.Process, exited <-chan struct{}) {
+	gracePeriod := r.Config.Shutdown.GetGracePeriod()
+
+	r.log.Debugf("%s:%d shutting down, grace period %s", engineBin, r.Config.Index, gracePeriod)
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		r.log.Debugf("%s:%d failed to send %s: %s", engineBin, r.Config.Index,
+			syscall.SIGTERM, err)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(gracePeriod):
+		r.log.Errorf("%s:%d did not exit within %s, killing", engineBin, r.Config.Index,
+			gracePeriod)
+		if err := proc.Kill(); err != nil {
+			r.log.Debugf("%s:%d failed to kill: %s", engineBin, r.Config.Index, err)
+		}
+	}
+}
+
 // Start asynchronously starts the Engine instance.
 func (r *Runner) Start(ctx context.Context, errOut chan<- error) error {
 	args, err := r.Config.CmdLineArgs()
@@ -94,6 +129,14 @@ func (r *Runner) Start(ctx context.Context, errOut chan<- error) error {
 	}
 	env = mergeEnvVars(cleanEnvVars(os.Environ(), r.Config.EnvPassThrough), env)
 
+	rotated, err := prepareLogFile(r.Config.LogFile, r.Config.LogRotation, time.Now())
+	if err != nil {
+		return errors.Wrapf(err, "preparing log file %s", r.Config.LogFile)
+	}
+	if rotated {
+		r.log.Debugf("%s:%d rotated log file %s", engineBin, r.Config.Index, r.Config.LogFile)
+	}
+
 	go func() {
 		errOut <- r.run(ctx, args, env)
 	}()
@@ -117,6 +160,16 @@ func (r *Runner) Signal(signal os.Signal) error {
 	return r.cmd.Process.Signal(signal)
 }
 
+// GetPid returns the PID of the running engine process, or zero if the engine
+// is not running.
+func (r *Runner) GetPid() uint64 {
+	if !r.IsRunning() || r.cmd == nil || r.cmd.Process == nil {
+		return 0
+	}
+
+	return uint64(r.cmd.Process.Pid)
+}
+
 // GetLastPid returns the PID after runner has exited, return
 // zero if no cmd or ProcessState exists.
 func (r *Runner) GetLastPid() uint64 {
@@ -131,3 +184,30 @@ func (r *Runner) GetLastPid() uint64 {
 func (r *Runner) GetConfig() *Config {
 	return r.Config
 }
+
+// Version returns the version reported by the I/O Engine binary.
+func Version(ctx context.Context) (string, error) {
+	binPath, err := common.FindBinary(engineBin)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't find %s", engineBin)
+	}
+
+	out, err := exec.CommandContext(ctx, binPath, "--version").Output()
+	if err != nil {
+		return "", errors.Wrapf(common.GetExitStatus(err),
+			"%s --version failed", binPath)
+	}
+
+	return parseVersion(string(out))
+}
+
+// parseVersion extracts the version from engine --version output, e.g.
+// "DAOS I/O Engine v2.0.0".
+func parseVersion(out string) (string, error) {
+	fields := strings.Fields(out)
+	if len(fields) == 0 || !strings.HasPrefix(fields[len(fields)-1], "v") {
+		return "", errors.Errorf("unexpected %s version output %q", engineBin, out)
+	}
+
+	return strings.TrimPrefix(fields[len(fields)-1], "v"), nil
+}
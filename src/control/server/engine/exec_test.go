@@ -12,10 +12,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -48,6 +50,10 @@ func TestMain(m *testing.M) {
 	case "RunnerContextExit":
 		time.Sleep(30 * time.Second)
 		os.Exit(1)
+	case "RunnerIgnoreTerm":
+		signal.Ignore(syscall.SIGTERM)
+		time.Sleep(30 * time.Second)
+		os.Exit(1)
 	}
 }
 
@@ -114,6 +120,41 @@ func TestRunnerContextExit(t *testing.T) {
 	}
 }
 
+func TestRunnerContextExitKill(t *testing.T) {
+	createFakeBinary(t)
+
+	// set this to control the behavior in TestMain()
+	os.Setenv(testModeVar, "RunnerIgnoreTerm")
+
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	cfg := NewConfig().
+		WithEnvPassThrough(testModeVar, "LD_LIBRARY_PATH").
+		WithShutdown(100*time.Millisecond, false, nil)
+	cfg.Index = 9
+
+	runner := NewRunner(log, cfg)
+	errOut := make(chan error)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := runner.Start(ctx, errOut); err != nil {
+		t.Fatal(err)
+	}
+	// give the process time to ignore SIGTERM
+	time.Sleep(time.Second)
+	cancel()
+
+	select {
+	case err := <-errOut:
+		if !strings.Contains(err.Error(), "killed") {
+			t.Fatalf("expected process to be killed, got %s", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("process was not killed after grace period")
+	}
+}
+
 func TestRunnerNormalExit(t *testing.T) {
 	var numaNode uint = 1
 	var bypass bool = false
@@ -195,3 +236,31 @@ func TestRunnerNormalExit(t *testing.T) {
 		t.Fatalf("wanted %q; got %q", wantEnv, gotEnv)
 	}
 }
+
+func TestEngine_parseVersion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		out    string
+		expVer string
+		expErr error
+	}{
+		"empty": {
+			expErr: errors.New("unexpected"),
+		},
+		"garbage": {
+			out:    "Usage: daos_engine -h\n",
+			expErr: errors.New("unexpected"),
+		},
+		"success": {
+			out:    "DAOS I/O Engine v2.0.0\n",
+			expVer: "2.0.0",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotVer, gotErr := parseVersion(tc.out)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if gotVer != tc.expVer {
+				t.Fatalf("wanted %q; got %q", tc.expVer, gotVer)
+			}
+		})
+	}
+}
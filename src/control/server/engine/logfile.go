@@ -0,0 +1,137 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+)
+
+const (
+	logSizeEnv = "D_LOG_SIZE"
+	// logSizeMin is the smallest log size honored by the engine.
+	logSizeMin = 1 << 20
+	// logBackupSuffix is appended to the name of a rotated log file, as
+	// done by the engine when the log exceeds the maximum size.
+	logBackupSuffix = ".old"
+	logDateLayout   = "2006-01-02"
+	logDirPerms     = 0755
+)
+
+// LogFileVars holds the values of the variables that may be used in an
+// engine log_file template, e.g. "/var/log/daos/{{.Hostname}}_{{.EngineIdx}}.log".
+type LogFileVars struct {
+	Hostname  string
+	EngineIdx int
+	Date      string
+}
+
+// newLogFileVars returns the log_file template variables for the engine with
+// the given index, the date is formatted as YYYY-MM-DD.
+func newLogFileVars(hostname string, idx int, now time.Time) LogFileVars {
+	return LogFileVars{
+		Hostname:  hostname,
+		EngineIdx: idx,
+		Date:      now.Format(logDateLayout),
+	}
+}
+
+// expandLogFile expands the template variables in the log file path.
+func expandLogFile(logFile string, vars LogFileVars) (string, error) {
+	if !strings.Contains(logFile, "{{") {
+		return logFile, nil
+	}
+
+	tmpl, err := template.New("log_file").Option("missingkey=error").Parse(logFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid log_file template %q", logFile)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, vars); err != nil {
+		return "", errors.Wrapf(err, "invalid log_file template %q", logFile)
+	}
+
+	return sb.String(), nil
+}
+
+// LogRotationConfig describes when the engine log file is rotated. The log is
+// renamed with a ".old" suffix, replacing any previous backup, once it exceeds
+// max_size or, when the engine is started, if it was last written more than
+// max_age ago.
+type LogRotationConfig struct {
+	MaxSize string        `yaml:"max_size,omitempty"`
+	MaxAge  time.Duration `yaml:"max_age,omitempty"`
+}
+
+// maxSizeBytes returns the maximum log size in bytes, or 0 if not set.
+func (lrc *LogRotationConfig) maxSizeBytes() (uint64, error) {
+	if lrc.MaxSize == "" {
+		return 0, nil
+	}
+
+	size, err := humanize.ParseBytes(lrc.MaxSize)
+	if err != nil {
+		return 0, errors.Errorf("invalid max_size %q", lrc.MaxSize)
+	}
+	if size < logSizeMin {
+		return 0, errors.Errorf("invalid max_size %q: must be at least %s", lrc.MaxSize,
+			humanize.IBytes(logSizeMin))
+	}
+
+	return size, nil
+}
+
+// Validate ensures that the rotation parameters are valid.
+func (lrc *LogRotationConfig) Validate() error {
+	if _, err := lrc.maxSizeBytes(); err != nil {
+		return err
+	}
+	if lrc.MaxAge < 0 {
+		return errors.Errorf("invalid max_age %s: must not be negative", lrc.MaxAge)
+	}
+
+	return nil
+}
+
+// prepareLogFile creates the directory of the log file if it does not exist
+// and rotates the log file if it is older than the maximum age.
+func prepareLogFile(logFile string, rotation LogRotationConfig, now time.Time) (rotated bool, err error) {
+	if logFile == "" {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(logFile), logDirPerms); err != nil {
+		return false, errors.Wrap(err, "creating log directory")
+	}
+
+	if rotation.MaxAge == 0 {
+		return false, nil
+	}
+
+	fi, err := os.Stat(logFile)
+	switch {
+	case os.IsNotExist(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	case now.Sub(fi.ModTime()) <= rotation.MaxAge:
+		return false, nil
+	}
+
+	if err := os.Rename(logFile, logFile+logBackupSuffix); err != nil {
+		return false, errors.Wrap(err, "rotating log file")
+	}
+
+	return true, nil
+}
@@ -0,0 +1,115 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestEngine_expandLogFile(t *testing.T) {
+	vars := newLogFileVars("foo", 1, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	for name, tc := range map[string]struct {
+		logFile string
+		expPath string
+		expErr  error
+	}{
+		"empty": {},
+		"no template": {
+			logFile: "/tmp/daos_engine.log",
+			expPath: "/tmp/daos_engine.log",
+		},
+		"all variables": {
+			logFile: "/var/log/daos/{{.Hostname}}/{{.Date}}/engine{{.EngineIdx}}.log",
+			expPath: "/var/log/daos/foo/2021-06-01/engine1.log",
+		},
+		"unknown variable": {
+			logFile: "/tmp/{{.Rank}}.log",
+			expErr:  errors.New("can't evaluate field Rank"),
+		},
+		"bad syntax": {
+			logFile: "/tmp/{{.Hostname.log",
+			expErr:  errors.New("invalid log_file template"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotPath, gotErr := expandLogFile(tc.logFile, vars)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+			common.AssertEqual(t, tc.expPath, gotPath, "unexpected log file path")
+		})
+	}
+}
+
+func TestEngine_prepareLogFile(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		logAge     time.Duration // zero if the log does not exist
+		maxAge     time.Duration
+		expRotated bool
+	}{
+		"new log": {
+			maxAge: time.Hour,
+		},
+		"no maximum age": {
+			logAge: 48 * time.Hour,
+		},
+		"recent log": {
+			logAge: time.Minute,
+			maxAge: time.Hour,
+		},
+		"old log": {
+			logAge:     2 * time.Hour,
+			maxAge:     time.Hour,
+			expRotated: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			logFile := filepath.Join(testDir, "sub", "dir", "engine.log")
+			if tc.logAge != 0 {
+				if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(logFile, []byte("old"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				mtime := now.Add(-tc.logAge)
+				if err := os.Chtimes(logFile, mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rotated, err := prepareLogFile(logFile, LogRotationConfig{MaxAge: tc.maxAge}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expRotated, rotated, "unexpected rotation")
+
+			if _, err := os.Stat(filepath.Dir(logFile)); err != nil {
+				t.Fatalf("log directory not created: %s", err)
+			}
+			_, err = os.Stat(logFile + logBackupSuffix)
+			common.AssertEqual(t, tc.expRotated, err == nil, "unexpected backup log")
+			_, err = os.Stat(logFile)
+			common.AssertEqual(t, tc.logAge != 0 && !tc.expRotated, err == nil, "unexpected log")
+		})
+	}
+}
@@ -20,6 +20,7 @@ type (
 		Running    atm.Bool
 		SignalCb   func(uint32, os.Signal)
 		SignalErr  error
+		Pid        uint64
 		LastPid    uint64
 		ErrChanCb  func() error
 		ErrChanErr error
@@ -65,6 +66,12 @@ func (tr *TestRunner) Start(ctx context.Context, errChan chan<- error) error {
 		tr.runnerCfg.Running.SetTrue()
 	}
 
+	// the engine is stopped when the runner context is canceled
+	go func() {
+		<-ctx.Done()
+		tr.runnerCfg.Running.SetFalse()
+	}()
+
 	return tr.runnerCfg.StartErr
 }
 
@@ -79,6 +86,10 @@ func (tr *TestRunner) IsRunning() bool {
 	return tr.runnerCfg.Running.IsTrue()
 }
 
+func (tr *TestRunner) GetPid() uint64 {
+	return tr.runnerCfg.Pid
+}
+
 func (tr *TestRunner) GetLastPid() uint64 {
 	return tr.runnerCfg.LastPid
 }
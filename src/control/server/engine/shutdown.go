@@ -0,0 +1,64 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultShutdownGracePeriod is the time given to an engine to exit
+	// after being signalled to shut down if no grace period is configured.
+	DefaultShutdownGracePeriod = 30 * time.Second
+	// maxShutdownGracePeriod bounds the grace period so that a system stop
+	// completes within the deadline of the requests issued by the MS.
+	maxShutdownGracePeriod = 2 * time.Minute
+	// shutdownFlushEnv is set in the engine environment to disable the
+	// flush of in-flight work when the engine is signalled to shut down.
+	shutdownFlushEnv = "DAOS_SHUTDOWN_FLUSH"
+)
+
+// ShutdownConfig describes how the engine is stopped. The engine is sent
+// SIGTERM and given grace_period to exit, after which it is sent SIGKILL if
+// escalate is set. When daos_server itself shuts down, engines which have not
+// exited within the grace period are always killed. With flush_before_exit
+// disabled, the engine exits as soon as it is signalled rather than draining
+// in-flight requests and closing its targets.
+type ShutdownConfig struct {
+	GracePeriod     time.Duration `yaml:"grace_period,omitempty"`
+	Escalate        bool          `yaml:"escalate,omitempty"`
+	FlushBeforeExit *bool         `yaml:"flush_before_exit,omitempty"`
+}
+
+// GetGracePeriod returns the configured grace period, or the default if none
+// has been set.
+func (sc *ShutdownConfig) GetGracePeriod() time.Duration {
+	if sc.GracePeriod == 0 {
+		return DefaultShutdownGracePeriod
+	}
+	return sc.GracePeriod
+}
+
+// flushBeforeExit returns true unless flushing before exit has been disabled.
+func (sc *ShutdownConfig) flushBeforeExit() bool {
+	return sc.FlushBeforeExit == nil || *sc.FlushBeforeExit
+}
+
+// Validate ensures that the shutdown parameters are valid.
+func (sc *ShutdownConfig) Validate() error {
+	if sc.GracePeriod < 0 {
+		return errors.Errorf("invalid grace_period %s: must not be negative", sc.GracePeriod)
+	}
+	if sc.GracePeriod > maxShutdownGracePeriod {
+		return errors.Errorf("invalid grace_period %s: must not exceed %s", sc.GracePeriod,
+			maxShutdownGracePeriod)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestEngine_ShutdownConfig(t *testing.T) {
+	noFlush := false
+
+	for name, tc := range map[string]struct {
+		cfg            ShutdownConfig
+		expErr         error
+		expGracePeriod time.Duration
+		expFlush       bool
+	}{
+		"defaults": {
+			expGracePeriod: DefaultShutdownGracePeriod,
+			expFlush:       true,
+		},
+		"custom": {
+			cfg: ShutdownConfig{
+				GracePeriod:     time.Minute,
+				Escalate:        true,
+				FlushBeforeExit: &noFlush,
+			},
+			expGracePeriod: time.Minute,
+		},
+		"negative grace period": {
+			cfg:    ShutdownConfig{GracePeriod: -time.Second},
+			expErr: errors.New("must not be negative"),
+		},
+		"grace period too long": {
+			cfg:    ShutdownConfig{GracePeriod: time.Hour},
+			expErr: errors.New("must not exceed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotErr := tc.cfg.Validate()
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expGracePeriod, tc.cfg.GetGracePeriod(),
+				"unexpected grace period")
+			common.AssertEqual(t, tc.expFlush, tc.cfg.flushBeforeExit(),
+				"unexpected flush setting")
+		})
+	}
+}
@@ -167,6 +167,19 @@ func parseCmdTags(in interface{}, tagFilter string, joiner joinFn, seenRefs refM
 			continue
 		}
 
+		// Untagged slices (e.g. storage tiers) may contain tagged
+		// structs, so process each element in turn.
+		if f.Type.Kind() == reflect.Slice {
+			for j := 0; j < fVal.Len(); j++ {
+				nested, err := parseCmdTags(fVal.Index(j).Interface(), tagFilter, joiner, seenRefs)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, nested...)
+			}
+			continue
+		}
+
 		nested, err := parseCmdTags(fVal.Interface(), tagFilter, joiner, seenRefs)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,256 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// rankFailureDedupWindow is the period within which multiple failure
+	// events for the same rank (e.g. engine died and SWIM dead) are counted
+	// as a single failure.
+	rankFailureDedupWindow = time.Minute
+	faultActionPeriod      = time.Hour
+)
+
+type faultDecision int
+
+const (
+	faultIgnore faultDecision = iota
+	faultAct
+	faultSuppressed
+)
+
+// faultPolicy tracks health events per subject (rank or device) and decides
+// when the configured thresholds have been crossed and an automatic action
+// should be taken.
+type faultPolicy struct {
+	sync.Mutex
+	cfg        config.FaultPolicy
+	now        func() time.Time
+	history    map[string][]time.Time
+	graceUntil map[string]time.Time
+	startGrace time.Time
+	actions    []time.Time
+}
+
+func newFaultPolicy(cfg config.FaultPolicy) *faultPolicy {
+	return &faultPolicy{
+		cfg:        cfg,
+		now:        time.Now,
+		history:    make(map[string][]time.Time),
+		graceUntil: make(map[string]time.Time),
+	}
+}
+
+func rankSubject(rank system.Rank) string {
+	return fmt.Sprintf("rank:%d", rank)
+}
+
+func deviceSubject(devUUID string) string {
+	return "device:" + devUUID
+}
+
+// start clears all tracked history and begins the policy grace period, it
+// should be called when this instance becomes the MS leader.
+func (fp *faultPolicy) start() {
+	fp.Lock()
+	defer fp.Unlock()
+
+	fp.history = make(map[string][]time.Time)
+	fp.startGrace = fp.now().Add(fp.cfg.GracePeriod)
+}
+
+// reset clears the history of the given subject and begins a grace period for
+// it, it should be called when an automatic action has been reversed.
+func (fp *faultPolicy) reset(subject string) {
+	fp.Lock()
+	defer fp.Unlock()
+
+	delete(fp.history, subject)
+	fp.graceUntil[subject] = fp.now().Add(fp.cfg.GracePeriod)
+}
+
+// failures returns the number of events recorded for the given subject within
+// the policy window.
+func (fp *faultPolicy) failures(subject string) int {
+	fp.Lock()
+	defer fp.Unlock()
+
+	now := fp.now()
+	var count int
+	for _, t := range fp.history[subject] {
+		if now.Sub(t) < fp.cfg.Window {
+			count++
+		}
+	}
+
+	return count
+}
+
+// record adds an event for the given subject and returns whether an action
+// should be taken. Events arriving within dedup of the previous one are
+// ignored.
+func (fp *faultPolicy) record(subject string, threshold int, dedup time.Duration) faultDecision {
+	if !fp.cfg.Enabled {
+		return faultIgnore
+	}
+
+	fp.Lock()
+	defer fp.Unlock()
+
+	now := fp.now()
+	if now.Before(fp.startGrace) || now.Before(fp.graceUntil[subject]) {
+		return faultIgnore
+	}
+
+	var recent []time.Time
+	for _, t := range fp.history[subject] {
+		if now.Sub(t) < fp.cfg.Window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) > 0 && now.Sub(recent[len(recent)-1]) < dedup {
+		fp.history[subject] = recent
+		return faultIgnore
+	}
+	recent = append(recent, now)
+
+	if len(recent) < threshold {
+		fp.history[subject] = recent
+		return faultIgnore
+	}
+	// threshold crossed, start counting afresh whatever the outcome
+	delete(fp.history, subject)
+
+	var actions []time.Time
+	for _, t := range fp.actions {
+		if now.Sub(t) < faultActionPeriod {
+			actions = append(actions, t)
+		}
+	}
+	if len(actions) >= fp.cfg.MaxActionsPerHour {
+		fp.actions = actions
+		return faultSuppressed
+	}
+	fp.actions = append(actions, now)
+
+	return faultAct
+}
+
+// applyFaultPolicy is registered on the MS leader to automatically exclude
+// ranks or mark NVMe SSDs FAULTY in response to repeated health events.
+func (svc *mgmtSvc) applyFaultPolicy(ctx context.Context, evt *events.RASEvent) {
+	rank := system.Rank(evt.Rank)
+	if !svc.faultPolicy.cfg.Enabled || rank.Equals(system.NilRank) {
+		return
+	}
+
+	// Ranks under maintenance are expected to fail, so don't count them.
+	if mw := rankMaintenanceWindow(svc.sysdb, rank); mw != nil {
+		return
+	}
+
+	cfg := svc.faultPolicy.cfg
+	switch evt.ID {
+	case events.RASEngineDied, events.RASSwimRankDead:
+		subject := rankSubject(rank)
+		switch svc.faultPolicy.record(subject, cfg.RankFailureThreshold, rankFailureDedupWindow) {
+		case faultAct:
+			go svc.autoExcludeRank(ctx, rank)
+		case faultSuppressed:
+			svc.publishAutoActionSuppressed(rank, subject)
+		}
+	case events.RASDeviceIOError:
+		if evt.HWID == "" {
+			return
+		}
+		subject := deviceSubject(evt.HWID)
+		switch svc.faultPolicy.record(subject, cfg.DeviceErrorThreshold, 0) {
+		case faultAct:
+			go svc.autoSetDeviceFaulty(ctx, rank, evt.HWID)
+		case faultSuppressed:
+			svc.publishAutoActionSuppressed(rank, subject)
+		}
+	}
+}
+
+func (svc *mgmtSvc) publishAutoActionSuppressed(rank system.Rank, subject string) {
+	msg := fmt.Sprintf("fault policy threshold crossed for %s but automatic action "+
+		"suppressed, limit of %d actions per hour reached", subject,
+		svc.faultPolicy.cfg.MaxActionsPerHour)
+	svc.log.Error(msg)
+	svc.events.Publish(events.NewGenericEvent(events.RASAutoActionSuppressed,
+		events.RASSeverityWarning, msg, "").WithRank(rank.Uint32()))
+}
+
+func (svc *mgmtSvc) autoExcludeRank(ctx context.Context, rank system.Rank) {
+	cfg := svc.faultPolicy.cfg
+	msg := fmt.Sprintf("rank %d automatically excluded after %d failures within %s",
+		rank, cfg.RankFailureThreshold, cfg.Window)
+
+	if err := svc.membership.ExcludeRank(rank, msg); err != nil {
+		svc.log.Errorf("fault policy: exclude rank %d: %s", rank, err)
+		return
+	}
+	svc.reqGroupUpdate(ctx)
+
+	svc.log.Info(msg)
+	svc.events.Publish(events.NewGenericEvent(events.RASRankExcluded,
+		events.RASSeverityWarning, msg, "").WithRank(rank.Uint32()))
+}
+
+// deviceSmdQuery issues the given device request to the host of the rank
+// that owns the device.
+func (svc *mgmtSvc) deviceSmdQuery(ctx context.Context, req *control.SmdQueryReq) error {
+	member, err := svc.membership.Get(req.Rank)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, systemReqTimeout)
+	defer cancel()
+
+	req.SetHostList([]string{member.Addr.String()})
+	resp, err := control.SmdQuery(ctx, svc.rpcClient, req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Errors()
+}
+
+func (svc *mgmtSvc) autoSetDeviceFaulty(ctx context.Context, rank system.Rank, devUUID string) {
+	cfg := svc.faultPolicy.cfg
+
+	if err := svc.deviceSmdQuery(ctx, &control.SmdQueryReq{
+		UUID:      devUUID,
+		Rank:      rank,
+		SetFaulty: true,
+	}); err != nil {
+		svc.log.Errorf("fault policy: set device %s faulty: %s", devUUID, err)
+		return
+	}
+
+	msg := fmt.Sprintf("device %s on rank %d automatically marked FAULTY after %d I/O "+
+		"errors within %s", devUUID, rank, cfg.DeviceErrorThreshold, cfg.Window)
+	svc.log.Info(msg)
+
+	evt := events.NewGenericEvent(events.RASDeviceSetFaulty, events.RASSeverityWarning,
+		msg, "").WithRank(rank.Uint32())
+	evt.HWID = devUUID
+	svc.events.Publish(evt)
+}
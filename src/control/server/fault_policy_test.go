@@ -0,0 +1,282 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_faultPolicy_record(t *testing.T) {
+	policyCfg := config.FaultPolicy{
+		Enabled:           true,
+		Window:            10 * time.Minute,
+		GracePeriod:       5 * time.Minute,
+		MaxActionsPerHour: 1,
+	}
+
+	type recordCall struct {
+		subject string
+		offset  time.Duration // since policy start
+		exp     faultDecision
+	}
+
+	for name, tc := range map[string]struct {
+		disabled bool
+		dedup    time.Duration
+		reset    string
+		calls    []recordCall
+	}{
+		"disabled": {
+			disabled: true,
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 7 * time.Minute, faultIgnore},
+			},
+		},
+		"ignored during start grace period": {
+			calls: []recordCall{
+				{"rank:1", time.Minute, faultIgnore},
+				{"rank:1", 2 * time.Minute, faultIgnore},
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 7 * time.Minute, faultAct},
+			},
+		},
+		"events outside window not counted": {
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 17 * time.Minute, faultIgnore},
+				{"rank:1", 18 * time.Minute, faultAct},
+			},
+		},
+		"subjects counted separately": {
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:2", 7 * time.Minute, faultIgnore},
+				{"rank:2", 8 * time.Minute, faultAct},
+			},
+		},
+		"duplicate events ignored": {
+			dedup: time.Minute,
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 6*time.Minute + time.Second, faultIgnore},
+				{"rank:1", 8 * time.Minute, faultAct},
+			},
+		},
+		"actions rate limited": {
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 7 * time.Minute, faultAct},
+				{"rank:2", 8 * time.Minute, faultIgnore},
+				{"rank:2", 9 * time.Minute, faultSuppressed},
+				{"rank:2", 70 * time.Minute, faultIgnore},
+				{"rank:2", 71 * time.Minute, faultAct},
+			},
+		},
+		"reset subject has grace period": {
+			reset: "rank:1",
+			calls: []recordCall{
+				{"rank:1", 6 * time.Minute, faultIgnore},
+				{"rank:1", 7 * time.Minute, faultIgnore},
+				{"rank:1", 11 * time.Minute, faultIgnore},
+				{"rank:1", 12 * time.Minute, faultAct},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := policyCfg
+			cfg.Enabled = !tc.disabled
+
+			start := time.Now()
+			now := start
+			fp := newFaultPolicy(cfg)
+			fp.now = func() time.Time { return now }
+			fp.start()
+			if tc.reset != "" {
+				now = start.Add(3 * time.Minute)
+				fp.reset(tc.reset)
+			}
+
+			for i, call := range tc.calls {
+				now = start.Add(call.offset)
+				got := fp.record(call.subject, 2, tc.dedup)
+				common.AssertEqual(t, call.exp, got,
+					fmt.Sprintf("unexpected decision for call %d", i))
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_applyFaultPolicy(t *testing.T) {
+	devUUID := common.MockUUID(1)
+	policyCfg := config.FaultPolicy{
+		Enabled:              true,
+		DeviceErrorThreshold: 2,
+		RankFailureThreshold: 2,
+		Window:               10 * time.Minute,
+		MaxActionsPerHour:    1,
+	}
+	rankDied := func(rank uint32) *events.RASEvent {
+		return events.NewEngineDiedEvent("foo", 0, rank, common.NormalExit, 1234)
+	}
+	excludedMsg := "rank 0 automatically excluded after 2 failures within 10m0s"
+	devErr := func(rank uint32) *events.RASEvent {
+		return events.NewDeviceIOErrorEvent("foo", 0, rank, 1, devUUID, "write")
+	}
+
+	smdResp := func(err error) *control.UnaryResponse {
+		return control.MockMSResponse(common.MockHostAddr(2).String(),
+			err, &ctlpb.SmdQueryResp{
+				Ranks: []*ctlpb.SmdQueryResp_RankResp{
+					{
+						Rank: 1,
+						Devices: []*ctlpb.SmdQueryResp_Device{
+							{Uuid: devUUID, State: "FAULTY"},
+						},
+					},
+				},
+			})
+	}
+
+	for name, tc := range map[string]struct {
+		disabled   bool
+		unaryErr   error
+		evts       []*events.RASEvent
+		expEvtIDs  []events.RASID
+		expMembers system.Members
+	}{
+		"disabled": {
+			disabled: true,
+			evts:     []*events.RASEvent{rankDied(0), rankDied(0)},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 2, "joined"),
+			},
+		},
+		"rank excluded": {
+			evts:      []*events.RASEvent{rankDied(0), rankDied(0)},
+			expEvtIDs: []events.RASID{events.RASRankExcluded},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "excluded").WithInfo(excludedMsg),
+				mockMember(t, 1, 2, "joined"),
+			},
+		},
+		"rank exclusion suppressed": {
+			evts: []*events.RASEvent{
+				rankDied(0), rankDied(0), rankDied(1), rankDied(1),
+			},
+			expEvtIDs: []events.RASID{
+				events.RASRankExcluded, events.RASAutoActionSuppressed,
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "excluded").WithInfo(excludedMsg),
+				mockMember(t, 1, 2, "joined"),
+			},
+		},
+		"device set faulty": {
+			evts:      []*events.RASEvent{devErr(1), devErr(1)},
+			expEvtIDs: []events.RASID{events.RASDeviceSetFaulty},
+		},
+		"device set faulty fails": {
+			unaryErr: errors.New("remote failed"),
+			evts:     []*events.RASEvent{devErr(1), devErr(1)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			svc := newTestMgmtSvc(t, log)
+			for _, m := range (system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 2, "joined"),
+			}) {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			svc.rpcClient = control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponseSet: []*control.UnaryResponse{
+					smdResp(tc.unaryErr),
+				},
+			})
+
+			cfg := policyCfg
+			cfg.Enabled = !tc.disabled
+			svc.faultPolicy = newFaultPolicy(cfg)
+
+			var wg sync.WaitGroup
+			wg.Add(len(tc.expEvtIDs))
+			var mu sync.Mutex
+			var gotEvtIDs []events.RASID
+			svc.events.Subscribe(events.RASTypeInfoOnly,
+				events.HandlerFunc(func(_ context.Context, evt *events.RASEvent) {
+					mu.Lock()
+					defer mu.Unlock()
+					gotEvtIDs = append(gotEvtIDs, evt.ID)
+					wg.Done()
+				}))
+			// drain group update requests
+			go func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-svc.groupUpdateReqs:
+					}
+				}
+			}()
+
+			// advance the policy clock beyond the rank failure
+			// deduplication window for each event
+			now := time.Now()
+			svc.faultPolicy.now = func() time.Time { return now }
+			for _, evt := range tc.evts {
+				now = now.Add(2 * rankFailureDedupWindow)
+				svc.applyFaultPolicy(ctx, evt)
+			}
+
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for events")
+			}
+
+			// automatic actions are taken asynchronously
+			mu.Lock()
+			sort.Slice(gotEvtIDs, func(i, j int) bool { return gotEvtIDs[i] < gotEvtIDs[j] })
+			common.AssertEqual(t, tc.expEvtIDs, gotEvtIDs, "unexpected events published")
+			mu.Unlock()
+			if tc.expMembers != nil {
+				checkMembers(t, tc.expMembers, svc.membership)
+			}
+		})
+	}
+}
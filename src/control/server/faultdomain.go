@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/server/config"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -85,6 +86,23 @@ func getFaultDomainFromCallback(path, requiredDir string) (*system.FaultDomain,
 }
 
 func checkFaultDomainCallback(path, requiredDir string) error {
+	return checkExecutable(path, requiredDir, execFaults{
+		notFound: config.FaultConfigFaultCallbackNotFound,
+		badPerms: config.FaultConfigFaultCallbackBadPerms,
+		insecure: config.FaultConfigFaultCallbackInsecure,
+	})
+}
+
+// execFaults holds the faults to be returned by checkExecutable.
+type execFaults struct {
+	notFound error
+	badPerms error
+	insecure func(string) *fault.Fault
+}
+
+// checkExecutable verifies that the executable at the given path is safe to be
+// run automatically by the server.
+func checkExecutable(path, requiredDir string, faults execFaults) error {
 	if path == "" {
 		return errors.New("no callback path supplied")
 	}
@@ -99,29 +117,29 @@ func checkFaultDomainCallback(path, requiredDir string) error {
 		return err
 	}
 	if !strings.HasPrefix(absScriptPath, absDir) {
-		return config.FaultConfigFaultCallbackInsecure(absDir)
+		return faults.insecure(absDir)
 	}
 
-	// Fault callback can't be an arbitrary command. Must point to a
-	// specific executable file.
+	// Callback can't be an arbitrary command. Must point to a specific
+	// executable file.
 	fi, err := os.Lstat(path)
 	if err != nil {
 		if os.IsPermission(err) {
-			return config.FaultConfigFaultCallbackBadPerms
+			return faults.badPerms
 		}
-		return config.FaultConfigFaultCallbackNotFound
+		return faults.notFound
 	}
 
 	// Symlinks and setuid scripts are potentially dangerous and shouldn't
 	// be automatically run.
 	mode := fi.Mode()
 	if mode&os.ModeSymlink != 0 || mode&os.ModeSetuid != 0 {
-		return config.FaultConfigFaultCallbackInsecure(absDir)
+		return faults.insecure(absDir)
 	}
 
 	// Script shouldn't be writable by non-owners.
 	if mode.Perm()&0022 != 0 {
-		return config.FaultConfigFaultCallbackInsecure(absDir)
+		return faults.insecure(absDir)
 	}
 
 	return nil
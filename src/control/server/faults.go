@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
@@ -28,13 +30,18 @@ var (
 	)
 	FaultIommuDisabled = serverFault(
 		code.ServerIommuDisabled,
-		"no IOMMU detected while running as non-root user with NVMe devices",
-		"enable IOMMU per the DAOS Admin Guide or run daos_server as root",
+		"no IOMMU detected while running as non-root user with NVMe devices, vfio-pci driver cannot be used",
+		"enable IOMMU in BIOS and on the kernel command line (intel_iommu=on or amd_iommu=on) per the DAOS Admin Guide, or run daos_server as root to use the uio_pci_generic driver",
 	)
 	FaultVfioDisabled = serverFault(
 		code.ServerVfioDisabled,
-		"disable_vfio: true in config while running as non-root user with NVMe devices",
-		"set disable_vfio: false or run daos_server as root",
+		"uio_pci_generic driver selected in config (disable_vfio: true or nvme_driver: uio_pci_generic) while running as non-root user with NVMe devices",
+		"set disable_vfio: false and nvme_driver: auto or run daos_server as root",
+	)
+	FaultVfioNoIommu = serverFault(
+		code.ServerVfioNoIommu,
+		"nvme_driver: vfio-pci in config but no IOMMU detected",
+		"enable IOMMU in BIOS and on the kernel command line (intel_iommu=on or amd_iommu=on) per the DAOS Admin Guide, or set nvme_driver: auto",
 	)
 	FaultHarnessNotStarted = serverFault(
 		code.ServerHarnessNotStarted,
@@ -64,6 +71,18 @@ func FaultInstancesNotStopped(action string, rank system.Rank) *fault.Fault {
 	)
 }
 
+// FaultSystemEraseRanksRunning creates a Fault for a system erase requested
+// while ranks are running.
+func FaultSystemEraseRanksRunning(ranks *system.RankSet) *fault.Fault {
+	return serverFault(
+		code.ServerSystemEraseRanksRunning,
+		fmt.Sprintf("system erase not supported when %s %s %s running",
+			english.PluralWord(ranks.Count(), "rank", "ranks"), ranks,
+			english.PluralWord(ranks.Count(), "is", "are")),
+		"stop the system with dmg system stop, then retry the erase",
+	)
+}
+
 func FaultPoolNvmeTooSmall(reqBytes uint64, targetCount int) *fault.Fault {
 	return serverFault(
 		code.ServerPoolNvmeTooSmall,
@@ -108,11 +127,53 @@ func FaultPoolDuplicateLabel(dupe string) *fault.Fault {
 	)
 }
 
+func FaultPoolInsufficientFaultDomains(minDomains, numDomains int) *fault.Fault {
+	return serverFault(
+		code.ServerPoolInsufficientFaultDomains,
+		fmt.Sprintf("pool requires ranks in at least %d fault domains, but only %d are available",
+			minDomains, numDomains),
+		"retry the request with more ranks or a lower minimum number of fault domains",
+	)
+}
+
+func FaultPoolProtected(poolUUID string) *fault.Fault {
+	return serverFault(
+		code.ServerPoolProtected,
+		fmt.Sprintf("pool %s is protected from being destroyed", poolUUID),
+		"unset the pool's protected property with 'dmg pool set-prop --name=protected --value=false' before destroying it",
+	)
+}
+
+func FaultPoolDisabled(poolUUID string, destroyAfter time.Time) *fault.Fault {
+	return serverFault(
+		code.ServerPoolDisabled,
+		fmt.Sprintf("pool %s is disabled and will be destroyed after %s", poolUUID,
+			destroyAfter.Format(time.RFC3339)),
+		"restore the pool with 'dmg pool restore' in order to use it",
+	)
+}
+
+func FaultPoolPropertyEnforced(name, value string) *fault.Fault {
+	return serverFault(
+		code.ServerPoolPropertyEnforced,
+		fmt.Sprintf("pool property %s is enforced by the system to be %q", name, value),
+		"create the pool without setting the property, or change the system default with 'dmg system set-pool-defaults'",
+	)
+}
+
+func FaultCheckRunning(runID uint64) *fault.Fault {
+	return serverFault(
+		code.ServerCheckRunning,
+		fmt.Sprintf("system check %d is already running", runID),
+		"wait for the running check to complete, monitoring its progress with 'dmg check query'",
+	)
+}
+
 func FaultInsufficientFreeHugePages(free, requested int) *fault.Fault {
 	return serverFault(
 		code.ServerInsufficientFreeHugePages,
 		fmt.Sprintf("requested %d hugepages; got %d", requested, free),
-		"reboot the system or manually clear /dev/hugepages as appropriate",
+		"reboot the system, compact memory with 'daos_server storage prepare --nvme-only --compact-hugepages' or manually clear /dev/hugepages as appropriate",
 	)
 }
 
@@ -132,6 +193,23 @@ func FaultBdevNotFound(bdevs []string) *fault.Fault {
 	)
 }
 
+func FaultBdevZonedNamespace(bdevs []string) *fault.Fault {
+	return serverFault(
+		code.ServerBdevZonedNamespace,
+		fmt.Sprintf("zoned (ZNS) NVMe SSD%s %v specified in server config", common.Pluralise("", len(bdevs)), bdevs),
+		"remove zoned SSDs from bdev_list or set bdev_allow_zoned in the engine storage config",
+	)
+}
+
+func FaultConflictingProcesses(procs []string) *fault.Fault {
+	return serverFault(
+		code.ServerConflictingProcesses,
+		fmt.Sprintf("SPDK/DPDK resources are in use by other processes: %s",
+			strings.Join(procs, "; ")),
+		"stop the listed processes (e.g. other SPDK applications or ovs-dpdk) or remove the devices they hold from the server config before retrying",
+	)
+}
+
 func FaultWrongSystem(reqName, sysName string) *fault.Fault {
 	return serverFault(
 		code.ServerWrongSystem,
@@ -140,6 +218,45 @@ func FaultWrongSystem(reqName, sysName string) *fault.Fault {
 	)
 }
 
+func FaultDeviceOwnedByOtherEngine(device string, owner, claimant uint32) *fault.Fault {
+	return serverFault(
+		code.ServerDeviceOwnedByOtherEngine,
+		fmt.Sprintf("device %s is owned by %s instance %d but is assigned to instance %d",
+			device, build.DataPlaneName, owner, claimant),
+		fmt.Sprintf("correct the server configuration so that %s is assigned to instance %d, or release it from the device ledger by resetting it with daos_server storage prepare --reset",
+			device, owner),
+	)
+}
+
+func FaultNoSpareEngine(rank system.Rank) *fault.Fault {
+	return serverFault(
+		code.ServerNoSpareEngine,
+		fmt.Sprintf("no spare %s instance available on this server to replace rank %d",
+			build.DataPlaneName, rank),
+		"configure an engine on the same server with spare: true and format its storage before retrying",
+	)
+}
+
+func FaultValidationHookDenied(op, reason string) *fault.Fault {
+	desc := fmt.Sprintf("%s denied by validation hook", op)
+	if reason != "" {
+		desc += ": " + reason
+	}
+	return serverFault(
+		code.ServerValidationHookDenied,
+		desc,
+		"obtain approval for the operation from the site change-management process and retry",
+	)
+}
+
+func FaultValidationHookFailed(op string, err error) *fault.Fault {
+	return serverFault(
+		code.ServerValidationHookFailed,
+		fmt.Sprintf("%s blocked as the validation hook failed: %s", op, err),
+		"check that the validation hook ('validation_hook' parameter) is reachable and working, then retry",
+	)
+}
+
 func serverFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "server",
@@ -0,0 +1,102 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sync"
+)
+
+// engineFormatState records the storage of an engine instance that has been
+// formatted successfully by a storage format request.
+type engineFormatState struct {
+	scmFormatted   bool
+	bdevsFormatted map[string]bool
+}
+
+// formatState tracks the per-device progress of storage format requests that
+// did not complete successfully on all devices of an engine instance, so that
+// a subsequent request only formats the devices that previously failed or
+// were not reached.
+//
+// The state of an instance is discarded once all of its storage has been
+// formatted and the instance has been notified that storage is ready.
+type formatState struct {
+	sync.RWMutex
+	engines map[uint32]*engineFormatState
+}
+
+func newFormatState() *formatState {
+	return &formatState{
+		engines: make(map[uint32]*engineFormatState),
+	}
+}
+
+func (fs *formatState) getEngine(idx uint32) *engineFormatState {
+	es, found := fs.engines[idx]
+	if !found {
+		es = &engineFormatState{
+			bdevsFormatted: make(map[string]bool),
+		}
+		fs.engines[idx] = es
+	}
+
+	return es
+}
+
+// hasState returns true if any format progress is recorded for the instance.
+func (fs *formatState) hasState(idx uint32) bool {
+	fs.RLock()
+	defer fs.RUnlock()
+
+	_, found := fs.engines[idx]
+	return found
+}
+
+// scmFormatted returns true if the SCM of the instance has been formatted by
+// a previous request.
+func (fs *formatState) scmFormatted(idx uint32) bool {
+	fs.RLock()
+	defer fs.RUnlock()
+
+	es, found := fs.engines[idx]
+	return found && es.scmFormatted
+}
+
+// setScmFormatted records that the SCM of the instance has been formatted.
+func (fs *formatState) setScmFormatted(idx uint32) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	fs.getEngine(idx).scmFormatted = true
+}
+
+// bdevFormatted returns true if the block device of the instance has been
+// formatted by a previous request.
+func (fs *formatState) bdevFormatted(idx uint32, dev string) bool {
+	fs.RLock()
+	defer fs.RUnlock()
+
+	es, found := fs.engines[idx]
+	return found && es.bdevsFormatted[dev]
+}
+
+// setBdevFormatted records that the block device of the instance has been
+// formatted.
+func (fs *formatState) setBdevFormatted(idx uint32, dev string) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	fs.getEngine(idx).bdevsFormatted[dev] = true
+}
+
+// clear discards any format progress recorded for the instance.
+func (fs *formatState) clear(idx uint32) {
+	fs.Lock()
+	defer fs.Unlock()
+
+	delete(fs.engines, idx)
+}
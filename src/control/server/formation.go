@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const formationPollInterval = 5 * time.Second
+
+type (
+	systemMembersFn    func(context.Context) (system.Members, error)
+	formationBarrierFn func(context.Context) error
+)
+
+// countAvailableMembers returns the number of members that have joined the
+// system and are not excluded.
+func countAvailableMembers(members system.Members) int {
+	var count int
+	for _, m := range members {
+		if m.State()&system.AvailableMemberFilter != 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// newFormationBarrierFn returns a formationBarrierFn that blocks until the
+// number of available system members reaches the quorum defined in the
+// barrier config, polling the membership with the given function at the given
+// interval. The barrier only delays setup, if the quorum is not reached
+// before the timeout a warning is logged and nil is returned.
+func newFormationBarrierFn(log logging.Logger, fb *config.FormationBarrier, getMembers systemMembersFn, interval time.Duration) formationBarrierFn {
+	return func(parent context.Context) error {
+		required := fb.RequiredRanks()
+		ctx, cancel := context.WithTimeout(parent, fb.WaitTimeout())
+		defer cancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		start := time.Now()
+		var available int
+		for {
+			members, err := getMembers(ctx)
+			if err != nil {
+				log.Debugf("formation barrier: system query failed: %s", err)
+			} else {
+				available = countAvailableMembers(members)
+				if available >= required {
+					log.Infof("formation barrier: %d/%d expected ranks available after %s",
+						available, fb.ExpectedRanks, time.Since(start).Round(time.Second))
+					return nil
+				}
+				log.Debugf("formation barrier: waiting for %d ranks, %d available",
+					required, available)
+			}
+
+			select {
+			case <-ctx.Done():
+				if parent.Err() != nil {
+					return parent.Err()
+				}
+				log.Errorf("formation barrier: timed out after %s with %d/%d ranks available "+
+					"(quorum %d), continuing setup", fb.WaitTimeout(), available,
+					fb.ExpectedRanks, required)
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_formationBarrier(t *testing.T) {
+	mockMembers := func(t *testing.T, states ...system.MemberState) system.Members {
+		members := make(system.Members, 0, len(states))
+		for i, state := range states {
+			members = append(members, system.MockMember(t, uint32(i), state))
+		}
+		return members
+	}
+
+	for name, tc := range map[string]struct {
+		barrier    *config.FormationBarrier
+		queries    []system.Members // returned by successive queries
+		queryErr   error
+		cancelCtx  bool
+		expQueries int
+		expLogMsg  string
+		expErr     error
+	}{
+		"quorum already reached": {
+			barrier: &config.FormationBarrier{ExpectedRanks: 2},
+			queries: []system.Members{
+				mockMembers(t, system.MemberStateJoined, system.MemberStateReady),
+			},
+			expQueries: 1,
+			expLogMsg:  "2/2 expected ranks available",
+		},
+		"quorum reached after polling": {
+			barrier: &config.FormationBarrier{ExpectedRanks: 4, Quorum: 75},
+			queries: []system.Members{
+				mockMembers(t, system.MemberStateJoined),
+				mockMembers(t, system.MemberStateJoined, system.MemberStateJoined,
+					system.MemberStateExcluded),
+				mockMembers(t, system.MemberStateJoined, system.MemberStateJoined,
+					system.MemberStateJoined),
+			},
+			expQueries: 3,
+			expLogMsg:  "3/4 expected ranks available",
+		},
+		"timeout continues setup": {
+			barrier: &config.FormationBarrier{
+				ExpectedRanks: 4,
+				Timeout:       50 * time.Millisecond,
+			},
+			queries: []system.Members{
+				mockMembers(t, system.MemberStateJoined),
+			},
+			expLogMsg: "timed out",
+		},
+		"query errors ignored until timeout": {
+			barrier: &config.FormationBarrier{
+				ExpectedRanks: 1,
+				Timeout:       50 * time.Millisecond,
+			},
+			queryErr:  errors.New("no leader"),
+			expLogMsg: "timed out",
+		},
+		"parent context canceled": {
+			barrier: &config.FormationBarrier{ExpectedRanks: 2},
+			queries: []system.Members{
+				mockMembers(t, system.MemberStateJoined),
+			},
+			cancelCtx: true,
+			expErr:    context.Canceled,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var queries int
+			getMembers := func(_ context.Context) (system.Members, error) {
+				queries++
+				if tc.cancelCtx {
+					cancel()
+				}
+				if tc.queryErr != nil {
+					return nil, tc.queryErr
+				}
+				idx := queries - 1
+				if idx >= len(tc.queries) {
+					idx = len(tc.queries) - 1
+				}
+				return tc.queries[idx], nil
+			}
+
+			barrierFn := newFormationBarrierFn(log, tc.barrier, getMembers, time.Millisecond)
+			gotErr := barrierFn(ctx)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.expQueries != 0 && queries != tc.expQueries {
+				t.Fatalf("expected %d queries, got %d", tc.expQueries, queries)
+			}
+			if !strings.Contains(buf.String(), tc.expLogMsg) {
+				t.Fatalf("expected to see %q in log, got %q", tc.expLogMsg, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// grpcMetrics holds per-method metrics for the control plane gRPC server.
+type grpcMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+func newGrpcMetrics() *grpcMetrics {
+	return &grpcMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "grpc",
+			Name:      "errors_total",
+			Help:      "Number of gRPC requests that returned an error, by method.",
+		}, []string{"method"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "control",
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to handle gRPC requests, by method.",
+			// control plane requests range from milliseconds for
+			// queries up to several minutes for storage format
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"method"}),
+	}
+}
+
+// registerGrpcMetrics registers gRPC server metrics with the given registerer,
+// returning the already registered metrics if present.
+func registerGrpcMetrics(reg prometheus.Registerer) (*grpcMetrics, error) {
+	gm := newGrpcMetrics()
+	if err := reg.Register(gm); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(*grpcMetrics); ok {
+				return existing, nil
+			}
+		}
+		return nil, errors.Wrap(err, "registering gRPC metrics")
+	}
+
+	return gm, nil
+}
+
+// Describe implements prometheus.Collector.
+func (gm *grpcMetrics) Describe(ch chan<- *prometheus.Desc) {
+	gm.requests.Describe(ch)
+	gm.errors.Describe(ch)
+	gm.latency.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (gm *grpcMetrics) Collect(ch chan<- prometheus.Metric) {
+	gm.requests.Collect(ch)
+	gm.errors.Collect(ch)
+	gm.latency.Collect(ch)
+}
+
+func (gm *grpcMetrics) observe(method string, err error, elapsed time.Duration) {
+	gm.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	if err != nil {
+		gm.errors.WithLabelValues(method).Inc()
+	}
+	gm.latency.WithLabelValues(method).Observe(elapsed.Seconds())
+}
+
+// rpcObserver records metrics for completed gRPC calls and logs those taking
+// longer than the slow RPC threshold, either of which may be disabled.
+type rpcObserver struct {
+	log           logging.Logger
+	metrics       *grpcMetrics
+	slowThreshold time.Duration
+}
+
+func (ro *rpcObserver) observe(ctx context.Context, method string, err error, elapsed time.Duration) {
+	if ro.metrics != nil {
+		ro.metrics.observe(method, err, elapsed)
+	}
+
+	if ro.slowThreshold <= 0 || elapsed < ro.slowThreshold {
+		return
+	}
+
+	peerAddr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		peerAddr = p.Addr.String()
+	}
+	ro.log.Infof("slow gRPC request: method %s from %s took %s (status %s)",
+		method, peerAddr, elapsed.Round(time.Millisecond), status.Code(err))
+}
+
+func (ro *rpcObserver) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+	ro.observe(ctx, info.FullMethod, err, time.Since(start))
+
+	return res, err
+}
+
+func (ro *rpcObserver) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	ro.observe(ss.Context(), info.FullMethod, err, time.Since(start))
+
+	return err
+}
@@ -0,0 +1,140 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func gatherTestMetrics(t *testing.T, reg *prometheus.Registry) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(rec,
+		httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(body)
+}
+
+func TestServer_rpcObserver_unaryInterceptor(t *testing.T) {
+	const method = "/mgmt.MgmtSvc/PoolCreate"
+
+	for name, tc := range map[string]struct {
+		slowThreshold time.Duration
+		handlerDelay  time.Duration
+		handlerErr    error
+		expMetrics    []string
+		expLogMsg     string
+		expNoLog      bool
+	}{
+		"fast success": {
+			slowThreshold: time.Hour,
+			expMetrics: []string{
+				`control_grpc_requests_total{code="OK",method="/mgmt.MgmtSvc/PoolCreate"} 1`,
+				`control_grpc_request_duration_seconds_count{method="/mgmt.MgmtSvc/PoolCreate"} 1`,
+			},
+			expNoLog: true,
+		},
+		"error counted": {
+			handlerErr: status.Error(codes.Unavailable, "no leader"),
+			expMetrics: []string{
+				`control_grpc_requests_total{code="Unavailable",method="/mgmt.MgmtSvc/PoolCreate"} 1`,
+				`control_grpc_errors_total{method="/mgmt.MgmtSvc/PoolCreate"} 1`,
+			},
+			expNoLog: true,
+		},
+		"slow request logged": {
+			slowThreshold: time.Millisecond,
+			handlerDelay:  5 * time.Millisecond,
+			handlerErr:    errors.New("failed"),
+			expMetrics: []string{
+				`control_grpc_requests_total{code="Unknown",method="/mgmt.MgmtSvc/PoolCreate"} 1`,
+			},
+			expLogMsg: "slow gRPC request: method /mgmt.MgmtSvc/PoolCreate from 10.0.0.1:1234",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			reg := prometheus.NewRegistry()
+			metrics, err := registerGrpcMetrics(reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			obs := &rpcObserver{
+				log:           log,
+				metrics:       metrics,
+				slowThreshold: tc.slowThreshold,
+			}
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+			})
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				time.Sleep(tc.handlerDelay)
+				return nil, tc.handlerErr
+			}
+
+			_, gotErr := obs.unaryInterceptor(ctx, nil,
+				&grpc.UnaryServerInfo{FullMethod: method}, handler)
+			common.CmpErr(t, tc.handlerErr, gotErr)
+
+			gotMetrics := gatherTestMetrics(t, reg)
+			for _, exp := range tc.expMetrics {
+				if !strings.Contains(gotMetrics, exp) {
+					t.Fatalf("expected %q in metrics, got:\n%s", exp, gotMetrics)
+				}
+			}
+
+			if tc.expNoLog && strings.Contains(buf.String(), "slow gRPC request") {
+				t.Fatalf("unexpected slow request log: %q", buf.String())
+			}
+			if !strings.Contains(buf.String(), tc.expLogMsg) {
+				t.Fatalf("expected %q in log, got %q", tc.expLogMsg, buf.String())
+			}
+		})
+	}
+}
+
+func TestServer_registerGrpcMetrics_AlreadyRegistered(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	first, err := registerGrpcMetrics(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := registerGrpcMetrics(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Fatal("expected existing metrics to be returned")
+	}
+}
@@ -25,6 +25,9 @@ import (
 const (
 	rankReqTimeout   = 30 * time.Second
 	rankStartTimeout = 2 * rankReqTimeout
+	// engineKillTimeout is the time allowed for an engine to exit after
+	// being sent SIGKILL.
+	engineKillTimeout = 5 * time.Second
 )
 
 // EngineHarness is responsible for managing Engine instances.
@@ -178,9 +181,37 @@ func (h *EngineHarness) Start(ctx context.Context, db *system.Database, ps *even
 	<-ctx.Done()
 	h.log.Debug("shutting down harness")
 
+	// Engine runners stop their engines once the context is canceled, wait
+	// for them to exit so that they are not killed along with this process
+	// before their grace period has expired.
+	exitTimeout := shutdownGracePeriod(instances) + engineKillTimeout
+	exitCtx, cancel := context.WithTimeout(context.Background(), exitTimeout)
+	defer cancel()
+	if exited, _ := pollInstanceState(exitCtx, instances,
+		func(ei *EngineInstance) bool { return !ei.isStarted() }, exitTimeout); !exited {
+		h.log.Errorf("engines did not exit within %s of shutdown", exitTimeout)
+	}
+
 	return ctx.Err()
 }
 
+// shutdownGracePeriod returns the longest shutdown grace period configured for
+// the given instances.
+func shutdownGracePeriod(instances []*EngineInstance) time.Duration {
+	var longest time.Duration
+	for _, ei := range instances {
+		cfg := ei.runner.GetConfig()
+		if cfg == nil {
+			continue
+		}
+		if gp := cfg.Shutdown.GetGracePeriod(); gp > longest {
+			longest = gp
+		}
+	}
+
+	return longest
+}
+
 // readyRanks returns rank assignment of configured harness instances that are
 // in a ready state. Rank assignments can be nil.
 func (h *EngineHarness) readyRanks() []system.Rank {
@@ -203,7 +203,7 @@ func TestServer_Harness_Start(t *testing.T) {
 			var instanceStarts uint32
 			harness := NewEngineHarness(log)
 			for i, engineCfg := range config.Engines {
-				if err := os.MkdirAll(engineCfg.Storage.SCM.MountPoint, 0777); err != nil {
+				if err := os.MkdirAll(engineCfg.Storage.SCM().MountPoint, 0777); err != nil {
 					t.Fatal(err)
 				}
 
@@ -218,7 +218,9 @@ func TestServer_Harness_Start(t *testing.T) {
 				}
 				runner := engine.NewTestRunner(tc.trc, engineCfg)
 				bdevProvider, err := bdev.NewClassProvider(log,
-					engineCfg.Storage.SCM.MountPoint, &engineCfg.Storage.Bdev)
+					engineCfg.Storage.SCM().MountPoint, &bdev.ClassConfig{
+						Tiers: engineCfg.Storage.Tiers.BdevConfigs(),
+					})
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -0,0 +1,184 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+	livezPath   = "/livez"
+
+	healthShutdownTimeout = time.Second
+)
+
+// msHealthSource provides the management service state reported by the
+// health endpoints.
+type msHealthSource interface {
+	IsReplica() bool
+	LeaderQuery() (string, []string, error)
+}
+
+type (
+	// engineHealth describes the local state of an engine.
+	engineHealth struct {
+		Index uint32       `json:"index"`
+		Rank  *system.Rank `json:"rank,omitempty"`
+		State string       `json:"state"`
+	}
+
+	// msHealth describes the management service as seen from this host,
+	// quorum can only be determined on MS replicas.
+	msHealth struct {
+		Replica bool   `json:"replica"`
+		Leader  string `json:"leader,omitempty"`
+		Quorum  bool   `json:"quorum"`
+	}
+
+	// healthReport is the document returned by the healthz endpoint.
+	healthReport struct {
+		Ready   bool            `json:"ready"`
+		Reasons []string        `json:"reasons,omitempty"`
+		Engines []*engineHealth `json:"engines"`
+		MS      *msHealth       `json:"management_service"`
+	}
+)
+
+// healthChecker reports the health of the control plane and the engines it
+// manages for consumption by orchestrators and load balancers.
+type healthChecker struct {
+	harness *EngineHarness
+	sysdb   msHealthSource
+}
+
+// report returns the current health of this server. The server is ready when
+// all configured engines are ready and, if this host is an MS replica, the
+// management service has a leader.
+func (hc *healthChecker) report() *healthReport {
+	hr := &healthReport{
+		Ready:   true,
+		Engines: []*engineHealth{},
+		MS:      &msHealth{Replica: hc.sysdb.IsReplica()},
+	}
+
+	for _, ei := range hc.harness.Instances() {
+		eh := &engineHealth{
+			Index: ei.Index(),
+			State: strings.ToLower(ei.LocalState().String()),
+		}
+		if rank, err := ei.GetRank(); err == nil {
+			eh.Rank = &rank
+		}
+		if ei.LocalState() != system.MemberStateReady {
+			hr.Ready = false
+			hr.Reasons = append(hr.Reasons, fmt.Sprintf("engine %d is %s", eh.Index, eh.State))
+		}
+		hr.Engines = append(hr.Engines, eh)
+	}
+
+	if hr.MS.Replica {
+		if leader, _, err := hc.sysdb.LeaderQuery(); err == nil && leader != "" {
+			hr.MS.Leader = leader
+			hr.MS.Quorum = true
+		}
+		if !hr.MS.Quorum {
+			hr.Ready = false
+			hr.Reasons = append(hr.Reasons, "management service has no leader")
+		}
+	}
+
+	return hr
+}
+
+func (hc *healthChecker) handler(log logging.Logger) http.Handler {
+	mux := http.NewServeMux()
+
+	// The control plane is alive if it is able to respond at all.
+	mux.HandleFunc(livezPath, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc(readyzPath, func(w http.ResponseWriter, r *http.Request) {
+		hr := hc.report()
+		if !hr.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, strings.Join(hr.Reasons, "\n"))
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, r *http.Request) {
+		hr := hc.report()
+		w.Header().Set("Content-Type", "application/json")
+		if !hr.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(hr); err != nil {
+			log.Errorf("health report: %s", err)
+		}
+	})
+
+	return mux
+}
+
+// startHealthServer starts serving the health endpoints on the given port and
+// returns a function to shut the server down.
+func startHealthServer(log logging.Logger, port int, hc *healthChecker) (func(), error) {
+	listenAddress := fmt.Sprintf("0.0.0.0:%d", port)
+	lis, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &http.Server{Handler: hc.handler(log)}
+	go func() {
+		log.Infof("health endpoints listening on %s", listenAddress)
+		err := srv.Serve(lis)
+		log.Debugf("health endpoints stopped: %s", err)
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), healthShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Infof("health server didn't shut down within timeout: %s", err)
+		}
+	}, nil
+}
+
+// registerHealthEndpoints starts the health endpoints if a port has been
+// configured, they are available while engines are starting so that
+// liveness can be reported.
+func registerHealthEndpoints(srv *server) error {
+	if srv.cfg.HealthPort == 0 {
+		return nil
+	}
+
+	hc := &healthChecker{
+		harness: srv.harness,
+		sysdb:   srv.sysdb,
+	}
+	cleanup, err := startHealthServer(srv.log, srv.cfg.HealthPort, hc)
+	if err != nil {
+		return err
+	}
+	srv.OnShutdown(cleanup)
+
+	return nil
+}
@@ -0,0 +1,170 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+type mockMSHealthSource struct {
+	replica bool
+	leader  string
+}
+
+func (m *mockMSHealthSource) IsReplica() bool {
+	return m.replica
+}
+
+func (m *mockMSHealthSource) LeaderQuery() (string, []string, error) {
+	return m.leader, nil, nil
+}
+
+func TestServer_healthChecker(t *testing.T) {
+	rank0 := system.Rank(0)
+
+	for name, tc := range map[string]struct {
+		engineCount int
+		notReady    bool
+		ms          *mockMSHealthSource
+		expReport   *healthReport
+		expStatus   int
+		expReadyz   string
+	}{
+		"no engines": {
+			ms: &mockMSHealthSource{},
+			expReport: &healthReport{
+				Ready:   true,
+				Engines: []*engineHealth{},
+				MS:      &msHealth{},
+			},
+			expStatus: http.StatusOK,
+			expReadyz: "ok",
+		},
+		"engines ready": {
+			engineCount: 2,
+			ms:          &mockMSHealthSource{},
+			expReport: &healthReport{
+				Ready: true,
+				Engines: []*engineHealth{
+					{Index: 0, Rank: &rank0, State: "ready"},
+					{Index: 1, Rank: &rank0, State: "ready"},
+				},
+				MS: &msHealth{},
+			},
+			expStatus: http.StatusOK,
+			expReadyz: "ok",
+		},
+		"engine not ready": {
+			engineCount: 1,
+			notReady:    true,
+			ms:          &mockMSHealthSource{},
+			expReport: &healthReport{
+				Reasons: []string{"engine 0 is starting"},
+				Engines: []*engineHealth{
+					{Index: 0, Rank: &rank0, State: "starting"},
+				},
+				MS: &msHealth{},
+			},
+			expStatus: http.StatusServiceUnavailable,
+			expReadyz: "engine 0 is starting",
+		},
+		"replica with leader": {
+			engineCount: 1,
+			ms:          &mockMSHealthSource{replica: true, leader: "10.0.0.1:10001"},
+			expReport: &healthReport{
+				Ready: true,
+				Engines: []*engineHealth{
+					{Index: 0, Rank: &rank0, State: "ready"},
+				},
+				MS: &msHealth{Replica: true, Leader: "10.0.0.1:10001", Quorum: true},
+			},
+			expStatus: http.StatusOK,
+			expReadyz: "ok",
+		},
+		"replica without leader": {
+			engineCount: 1,
+			ms:          &mockMSHealthSource{replica: true},
+			expReport: &healthReport{
+				Reasons: []string{"management service has no leader"},
+				Engines: []*engineHealth{
+					{Index: 0, Rank: &rank0, State: "ready"},
+				},
+				MS: &msHealth{Replica: true},
+			},
+			expStatus: http.StatusServiceUnavailable,
+			expReadyz: "management service has no leader",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			harness := NewEngineHarness(log)
+			for i := 0; i < tc.engineCount; i++ {
+				ei := newTestEngine(log, false)
+				ei.setIndex(uint32(i))
+				if tc.notReady {
+					ei.ready.SetFalse()
+				}
+				if err := harness.AddInstance(ei); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			hc := &healthChecker{harness: harness, sysdb: tc.ms}
+			srv := httptest.NewServer(hc.handler(log))
+			defer srv.Close()
+
+			get := func(path string) (int, string) {
+				t.Helper()
+				resp, err := http.Get(srv.URL + path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer resp.Body.Close()
+
+				if path == healthzPath {
+					var gotReport healthReport
+					if err := json.NewDecoder(resp.Body).Decode(&gotReport); err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(tc.expReport, &gotReport); diff != "" {
+						t.Fatalf("unexpected report (-want, +got):\n%s\n", diff)
+					}
+					return resp.StatusCode, ""
+				}
+				body, err := ioutil.ReadAll(resp.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return resp.StatusCode, strings.TrimSpace(string(body))
+			}
+
+			gotStatus, gotBody := get(livezPath)
+			common.AssertEqual(t, http.StatusOK, gotStatus, "livez status")
+			common.AssertEqual(t, "ok", gotBody, "livez body")
+
+			gotStatus, gotBody = get(readyzPath)
+			common.AssertEqual(t, tc.expStatus, gotStatus, "readyz status")
+			common.AssertEqual(t, tc.expReadyz, gotBody, "readyz body")
+
+			gotStatus, _ = get(healthzPath)
+			common.AssertEqual(t, tc.expStatus, gotStatus, "healthz status")
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/pbin"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+// helperMetrics holds per-method metrics for requests forwarded to the
+// privileged helpers.
+type helperMetrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+func newHelperMetrics() *helperMetrics {
+	return &helperMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "helper",
+			Name:      "requests_total",
+			Help:      "Number of requests forwarded to privileged helpers, by helper, method and status.",
+		}, []string{"helper", "method", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "control",
+			Subsystem: "helper",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by privileged helpers to handle requests, by helper and method.",
+			// helper requests range from milliseconds for a ping up
+			// to several minutes for NVMe format or firmware update
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"helper", "method"}),
+	}
+}
+
+// registerHelperMetrics registers privileged helper metrics with the given
+// registerer, returning the already registered metrics if present.
+func registerHelperMetrics(reg prometheus.Registerer) (*helperMetrics, error) {
+	hm := newHelperMetrics()
+	if err := reg.Register(hm); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			if existing, ok := are.ExistingCollector.(*helperMetrics); ok {
+				return existing, nil
+			}
+		}
+		return nil, errors.Wrap(err, "registering privileged helper metrics")
+	}
+
+	return hm, nil
+}
+
+// Describe implements prometheus.Collector.
+func (hm *helperMetrics) Describe(ch chan<- *prometheus.Desc) {
+	hm.requests.Describe(ch)
+	hm.latency.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (hm *helperMetrics) Collect(ch chan<- prometheus.Metric) {
+	hm.requests.Collect(ch)
+	hm.latency.Collect(ch)
+}
+
+func (hm *helperMetrics) observe(ct *pbin.CallTrace) {
+	helper := filepath.Base(ct.Binary)
+	hm.requests.WithLabelValues(helper, ct.Method, helperCallStatus(ct)).Inc()
+	hm.latency.WithLabelValues(helper, ct.Method).Observe(ct.Duration.Seconds())
+}
+
+func helperCallStatus(ct *pbin.CallTrace) string {
+	if ct.Error != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// helperAuditor records each request forwarded to a privileged helper in the
+// audit log and, if telemetry is enabled, in metrics.
+type helperAuditor struct {
+	log     logging.Logger
+	metrics *helperMetrics
+}
+
+func (ha *helperAuditor) trace(ct *pbin.CallTrace) {
+	if ha.metrics != nil {
+		ha.metrics.observe(ct)
+	}
+
+	errStr := "none"
+	if ct.Error != nil {
+		errStr = ct.Error.Error()
+	}
+	ha.log.Infof("helper audit: binary=%s method=%s status=%s exit_code=%d duration=%s payload=%s error=%q",
+		ct.Binary, ct.Method, helperCallStatus(ct), ct.ExitCode,
+		ct.Duration.Round(time.Millisecond), ct.Payload, errStr)
+}
+
+// setupHelperAudit starts tracing requests forwarded to the privileged
+// helpers. Records are written to the helper audit log file if one is
+// configured and to the control log otherwise. The returned function stops
+// tracing and closes the audit log file.
+func setupHelperAudit(log logging.Logger, cfg *config.Server) (func(), error) {
+	ha := &helperAuditor{log: log}
+	closeFile := func() {}
+
+	if telemetryEnabled(cfg) {
+		metrics, err := registerHelperMetrics(prometheus.DefaultRegisterer)
+		if err != nil {
+			return nil, err
+		}
+		ha.metrics = metrics
+	}
+
+	if cfg.HelperAuditLogFile != "" {
+		f, err := common.AppendFile(cfg.HelperAuditLogFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "create helper audit log file")
+		}
+		closeFile = func() {
+			if err := f.Close(); err != nil {
+				log.Errorf("closing helper audit log file: %s", err)
+			}
+		}
+
+		ha.log = logging.NewCombinedLogger(hostname(), f)
+		log.Infof("privileged helper requests audited to file %s", cfg.HelperAuditLogFile)
+	}
+
+	pbin.SetCallTracer(ha.trace)
+
+	return func() {
+		pbin.SetCallTracer(nil)
+		closeFile()
+	}, nil
+}
@@ -0,0 +1,121 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/pbin"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_helperAuditor_trace(t *testing.T) {
+	for name, tc := range map[string]struct {
+		trace      *pbin.CallTrace
+		expMetrics []string
+		expLogMsg  string
+	}{
+		"success": {
+			trace: &pbin.CallTrace{
+				Binary:   "/usr/bin/daos_admin",
+				Method:   "BdevPrepare",
+				Payload:  `{"TargetUser":"daos_server"}`,
+				Duration: 1500 * time.Millisecond,
+			},
+			expMetrics: []string{
+				`control_helper_requests_total{helper="daos_admin",method="BdevPrepare",status="success"} 1`,
+				`control_helper_request_duration_seconds_count{helper="daos_admin",method="BdevPrepare"} 1`,
+			},
+			expLogMsg: `helper audit: binary=/usr/bin/daos_admin method=BdevPrepare status=success exit_code=0 duration=1.5s payload={"TargetUser":"daos_server"} error="none"`,
+		},
+		"failure": {
+			trace: &pbin.CallTrace{
+				Binary:   "/usr/bin/daos_firmware",
+				Method:   "NvmeFirmwareUpdate",
+				Payload:  `{}`,
+				ExitCode: -1,
+				Error:    errors.New("permission denied"),
+			},
+			expMetrics: []string{
+				`control_helper_requests_total{helper="daos_firmware",method="NvmeFirmwareUpdate",status="error"} 1`,
+			},
+			expLogMsg: `status=error exit_code=-1 duration=0s payload={} error="permission denied"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			reg := prometheus.NewRegistry()
+			metrics, err := registerHelperMetrics(reg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ha := &helperAuditor{
+				log:     log,
+				metrics: metrics,
+			}
+
+			ha.trace(tc.trace)
+
+			gotMetrics := gatherTestMetrics(t, reg)
+			for _, exp := range tc.expMetrics {
+				if !strings.Contains(gotMetrics, exp) {
+					t.Fatalf("expected %q in metrics, got:\n%s", exp, gotMetrics)
+				}
+			}
+			if !strings.Contains(buf.String(), tc.expLogMsg) {
+				t.Fatalf("expected %q in log, got %q", tc.expLogMsg, buf.String())
+			}
+		})
+	}
+}
+
+func TestServer_setupHelperAudit(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	auditFile := filepath.Join(testDir, "helper_audit.log")
+	cfg := config.DefaultServer().WithHelperAuditLogFile(auditFile)
+
+	stop, err := setupHelperAudit(log, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the request is traced even though the helper could not be executed
+	_, err = pbin.ExecReq(context.Background(), log, filepath.Join(testDir, "daos_admin"),
+		&pbin.Request{Method: "Ping"})
+	if err == nil {
+		t.Fatal("expected exec of missing helper to fail")
+	}
+	stop()
+
+	audit, err := ioutil.ReadFile(auditFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(audit), "method=Ping status=error exit_code=-1") {
+		t.Fatalf("expected traced request in audit log, got %q", string(audit))
+	}
+	if strings.Contains(buf.String(), "helper audit:") {
+		t.Fatalf("unexpected audit record in control log: %q", buf.String())
+	}
+}
@@ -0,0 +1,281 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const defaultSysRoot = "/sys"
+
+// nodeHugePages describes the hugepages of one size on a NUMA node.
+type nodeHugePages struct {
+	Node       int
+	PageSizeKb int
+	Total      int
+	Free       int
+	Surplus    int
+}
+
+// hugePageKey identifies hugepages of one size on a NUMA node.
+type hugePageKey struct {
+	Node       int
+	PageSizeKb int
+}
+
+// parseHugePageDirSize returns the page size of a sysfs hugepages directory
+// named in the "hugepages-<size>kB" format.
+func parseHugePageDirSize(dir string) (int, error) {
+	name := filepath.Base(dir)
+	if !strings.HasPrefix(name, "hugepages-") || !strings.HasSuffix(name, "kB") {
+		return 0, errors.Errorf("unexpected hugepages directory %q", name)
+	}
+
+	return strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "hugepages-"), "kB"))
+}
+
+func readSysfsInt(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// getNodeHugePages returns the hugepages of each size on each NUMA node as
+// reported under sysRoot, ordered by node and page size.
+func getNodeHugePages(sysRoot string) ([]*nodeHugePages, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysRoot,
+		"devices/system/node/node*/hugepages/hugepages-*kB"))
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*nodeHugePages
+	for _, dir := range dirs {
+		nodeName := filepath.Base(filepath.Dir(filepath.Dir(dir)))
+		node, err := strconv.Atoi(strings.TrimPrefix(nodeName, "node"))
+		if err != nil {
+			return nil, errors.Errorf("unexpected NUMA node directory %q", nodeName)
+		}
+		pageSizeKb, err := parseHugePageDirSize(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		nhp := &nodeHugePages{Node: node, PageSizeKb: pageSizeKb}
+		for name, val := range map[string]*int{
+			"nr_hugepages":      &nhp.Total,
+			"free_hugepages":    &nhp.Free,
+			"surplus_hugepages": &nhp.Surplus,
+		} {
+			if *val, err = readSysfsInt(filepath.Join(dir, name)); err != nil {
+				return nil, err
+			}
+		}
+		nodes = append(nodes, nhp)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Node != nodes[j].Node {
+			return nodes[i].Node < nodes[j].Node
+		}
+		return nodes[i].PageSizeKb < nodes[j].PageSizeKb
+	})
+
+	return nodes, nil
+}
+
+// getReservedHugePages returns the number of reserved hugepages of each size
+// as reported under sysRoot. The kernel only tracks reservations system-wide.
+func getReservedHugePages(sysRoot string) (map[int]int, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysRoot, "kernel/mm/hugepages/hugepages-*kB"))
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := make(map[int]int)
+	for _, dir := range dirs {
+		pageSizeKb, err := parseHugePageDirSize(dir)
+		if err != nil {
+			return nil, err
+		}
+		if reserved[pageSizeKb], err = readSysfsInt(filepath.Join(dir, "resv_hugepages")); err != nil {
+			return nil, err
+		}
+	}
+
+	return reserved, nil
+}
+
+// parseNumaMapsHugePages returns the number of hugepages of each size mapped
+// on each NUMA node by a process, from the contents of its
+// /proc/<pid>/numa_maps file.
+func parseNumaMapsHugePages(input io.Reader) (map[hugePageKey]int, error) {
+	pages := make(map[hugePageKey]int)
+
+	scn := bufio.NewScanner(input)
+	for scn.Scan() {
+		// 7f5b40000000 default file=/dev/hugepages/spdk0map_0 huge dirty=512 N0=512 kernelpagesize_kB=2048
+		fields := strings.Fields(scn.Text())
+
+		isHuge := false
+		pageSizeKb := 0
+		nodePages := make(map[int]int)
+		for _, field := range fields {
+			if field == "huge" {
+				isHuge = true
+				continue
+			}
+			keyVal := strings.SplitN(field, "=", 2)
+			if len(keyVal) != 2 {
+				continue
+			}
+			switch {
+			case keyVal[0] == "kernelpagesize_kB":
+				parseInt(keyVal[1], &pageSizeKb)
+			case len(keyVal[0]) > 1 && keyVal[0][0] == 'N':
+				node, err := strconv.Atoi(keyVal[0][1:])
+				if err != nil {
+					continue
+				}
+				count, err := strconv.Atoi(keyVal[1])
+				if err != nil {
+					return nil, errors.Wrapf(err, "unable to parse numa_maps line %q",
+						scn.Text())
+				}
+				nodePages[node] += count
+			}
+		}
+		if !isHuge || pageSizeKb == 0 {
+			continue
+		}
+
+		for node, count := range nodePages {
+			pages[hugePageKey{Node: node, PageSizeKb: pageSizeKb}] += count
+		}
+	}
+
+	return pages, scn.Err()
+}
+
+// getProcessHugePages returns the number of hugepages of each size mapped on
+// each NUMA node by the process with the given pid.
+func getProcessHugePages(procRoot string, pid uint64) (map[hugePageKey]int, error) {
+	f, err := os.Open(filepath.Join(procRoot, strconv.FormatUint(pid, 10), "numa_maps"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseNumaMapsHugePages(f)
+}
+
+// hugePageMetrics exports the hugepages available on each NUMA node and those
+// mapped by each running engine, read from sysfs and procfs when collected.
+type hugePageMetrics struct {
+	log      logging.Logger
+	sysRoot  string
+	procRoot string
+	engines  []*EngineInstance
+
+	total    *prometheus.Desc
+	free     *prometheus.Desc
+	surplus  *prometheus.Desc
+	reserved *prometheus.Desc
+	engine   *prometheus.Desc
+}
+
+func newHugePageMetrics(log logging.Logger, engines []*EngineInstance) *hugePageMetrics {
+	nodeLabels := []string{"numa_node", "page_size_kb"}
+	return &hugePageMetrics{
+		log:      log,
+		sysRoot:  defaultSysRoot,
+		procRoot: defaultProcRoot,
+		engines:  engines,
+		total: prometheus.NewDesc(prometheus.BuildFQName("control", "hugepages", "total"),
+			"Number of hugepages allocated on each NUMA node.", nodeLabels, nil),
+		free: prometheus.NewDesc(prometheus.BuildFQName("control", "hugepages", "free"),
+			"Number of hugepages not yet mapped on each NUMA node.", nodeLabels, nil),
+		surplus: prometheus.NewDesc(prometheus.BuildFQName("control", "hugepages", "surplus"),
+			"Number of surplus hugepages allocated on each NUMA node.", nodeLabels, nil),
+		reserved: prometheus.NewDesc(prometheus.BuildFQName("control", "hugepages", "reserved"),
+			"Number of hugepages reserved for mappings but not yet faulted in, system-wide.",
+			[]string{"page_size_kb"}, nil),
+		engine: prometheus.NewDesc(prometheus.BuildFQName("control", "hugepages", "engine_mapped"),
+			"Number of hugepages mapped by each engine on each NUMA node.",
+			append([]string{"engine"}, nodeLabels...), nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (hpm *hugePageMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- hpm.total
+	ch <- hpm.free
+	ch <- hpm.surplus
+	ch <- hpm.reserved
+	ch <- hpm.engine
+}
+
+// Collect implements prometheus.Collector. Values which cannot be read are
+// omitted rather than failing the whole collection.
+func (hpm *hugePageMetrics) Collect(ch chan<- prometheus.Metric) {
+	nodes, err := getNodeHugePages(hpm.sysRoot)
+	if err != nil {
+		hpm.log.Debugf("unable to read NUMA node hugepages: %s", err)
+	}
+	for _, nhp := range nodes {
+		labels := []string{strconv.Itoa(nhp.Node), strconv.Itoa(nhp.PageSizeKb)}
+		ch <- prometheus.MustNewConstMetric(hpm.total, prometheus.GaugeValue,
+			float64(nhp.Total), labels...)
+		ch <- prometheus.MustNewConstMetric(hpm.free, prometheus.GaugeValue,
+			float64(nhp.Free), labels...)
+		ch <- prometheus.MustNewConstMetric(hpm.surplus, prometheus.GaugeValue,
+			float64(nhp.Surplus), labels...)
+	}
+
+	reserved, err := getReservedHugePages(hpm.sysRoot)
+	if err != nil {
+		hpm.log.Debugf("unable to read reserved hugepages: %s", err)
+	}
+	for pageSizeKb, count := range reserved {
+		ch <- prometheus.MustNewConstMetric(hpm.reserved, prometheus.GaugeValue,
+			float64(count), strconv.Itoa(pageSizeKb))
+	}
+
+	for _, ei := range hpm.engines {
+		pid := ei.runner.GetPid()
+		if pid == 0 {
+			continue
+		}
+		pages, err := getProcessHugePages(hpm.procRoot, pid)
+		if err != nil {
+			hpm.log.Debugf("unable to read hugepages of engine %d (pid %d): %s",
+				ei.Index(), pid, err)
+			continue
+		}
+		for key, count := range pages {
+			ch <- prometheus.MustNewConstMetric(hpm.engine, prometheus.GaugeValue,
+				float64(count), fmt.Sprint(ei.Index()), strconv.Itoa(key.Node),
+				strconv.Itoa(key.PageSizeKb))
+		}
+	}
+}
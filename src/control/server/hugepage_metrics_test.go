@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_parseNumaMapsHugePages(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input    string
+		expPages map[hugePageKey]int
+		expErr   error
+	}{
+		"empty": {
+			expPages: map[hugePageKey]int{},
+		},
+		"no hugepages": {
+			input:    "55d0c0a00000 default file=/usr/bin/daos_engine mapped=10 N0=10 kernelpagesize_kB=4\n",
+			expPages: map[hugePageKey]int{},
+		},
+		"hugepages on two nodes": {
+			input: strings.Join([]string{
+				"55d0c0a00000 default file=/usr/bin/daos_engine mapped=10 N0=10 kernelpagesize_kB=4",
+				"7f5b40000000 default file=/dev/hugepages/spdk0map_0 huge dirty=512 N0=512 kernelpagesize_kB=2048",
+				"7f5c40000000 default file=/dev/hugepages/spdk0map_1 huge dirty=256 N0=128 N1=128 kernelpagesize_kB=2048",
+				"7f5d40000000 default file=/dev/hugepages1G/map huge dirty=1 N1=1 kernelpagesize_kB=1048576",
+				"",
+			}, "\n"),
+			expPages: map[hugePageKey]int{
+				{Node: 0, PageSizeKb: 2048}:    640,
+				{Node: 1, PageSizeKb: 2048}:    128,
+				{Node: 1, PageSizeKb: 1048576}: 1,
+			},
+		},
+		"bad page count": {
+			input:  "7f5b40000000 default huge N0=lots kernelpagesize_kB=2048\n",
+			expErr: errors.New("unable to parse numa_maps line"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotPages, gotErr := parseNumaMapsHugePages(strings.NewReader(tc.input))
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expPages, gotPages); diff != "" {
+				t.Fatalf("unexpected pages (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_getNodeHugePages(t *testing.T) {
+	sysRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	for node, vals := range map[string][]string{
+		"node0": {"1024", "512", "0"},
+		"node1": {"1024", "1024", "2"},
+	} {
+		dir := filepath.Join(sysRoot, "devices/system/node", node, "hugepages/hugepages-2048kB")
+		writeTestFile(t, filepath.Join(dir, "nr_hugepages"), vals[0]+"\n")
+		writeTestFile(t, filepath.Join(dir, "free_hugepages"), vals[1]+"\n")
+		writeTestFile(t, filepath.Join(dir, "surplus_hugepages"), vals[2]+"\n")
+	}
+	writeTestFile(t, filepath.Join(sysRoot,
+		"kernel/mm/hugepages/hugepages-2048kB/resv_hugepages"), "16\n")
+
+	gotNodes, err := getNodeHugePages(sysRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expNodes := []*nodeHugePages{
+		{Node: 0, PageSizeKb: 2048, Total: 1024, Free: 512},
+		{Node: 1, PageSizeKb: 2048, Total: 1024, Free: 1024, Surplus: 2},
+	}
+	if diff := cmp.Diff(expNodes, gotNodes); diff != "" {
+		t.Fatalf("unexpected nodes (-want, +got):\n%s\n", diff)
+	}
+
+	gotReserved, err := getReservedHugePages(sysRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[int]int{2048: 16}, gotReserved); diff != "" {
+		t.Fatalf("unexpected reserved (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_hugePageMetrics_Collect(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+	sysRoot := filepath.Join(testRoot, "sys")
+	procRoot := filepath.Join(testRoot, "proc")
+
+	nodeDir := filepath.Join(sysRoot, "devices/system/node/node0/hugepages/hugepages-2048kB")
+	writeTestFile(t, filepath.Join(nodeDir, "nr_hugepages"), "1024\n")
+	writeTestFile(t, filepath.Join(nodeDir, "free_hugepages"), "512\n")
+	writeTestFile(t, filepath.Join(nodeDir, "surplus_hugepages"), "0\n")
+	writeTestFile(t, filepath.Join(sysRoot,
+		"kernel/mm/hugepages/hugepages-2048kB/resv_hugepages"), "16\n")
+	writeTestFile(t, filepath.Join(procRoot, "1234/numa_maps"),
+		"7f5b40000000 default file=/dev/hugepages/spdk0map_0 huge dirty=512 N0=512 kernelpagesize_kB=2048\n")
+
+	engines := make([]*EngineInstance, 3)
+	for i, pid := range []uint64{1234, 0, 5678} {
+		trc := &engine.TestRunnerConfig{Pid: pid}
+		engines[i] = NewEngineInstance(log, nil, nil, nil, engine.NewTestRunner(trc, engine.NewConfig()))
+		engines[i].setIndex(uint32(i))
+	}
+
+	hpm := newHugePageMetrics(log, engines)
+	hpm.sysRoot = sysRoot
+	hpm.procRoot = procRoot
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(hpm); err != nil {
+		t.Fatal(err)
+	}
+	gotMetrics := gatherTestMetrics(t, reg)
+
+	for _, exp := range []string{
+		`control_hugepages_total{numa_node="0",page_size_kb="2048"} 1024`,
+		`control_hugepages_free{numa_node="0",page_size_kb="2048"} 512`,
+		`control_hugepages_surplus{numa_node="0",page_size_kb="2048"} 0`,
+		`control_hugepages_reserved{page_size_kb="2048"} 16`,
+		`control_hugepages_engine_mapped{engine="0",numa_node="0",page_size_kb="2048"} 512`,
+	} {
+		if !strings.Contains(gotMetrics, exp) {
+			t.Errorf("expected %q in metrics:\n%s", exp, gotMetrics)
+		}
+	}
+	// engines which are not running or whose maps cannot be read are omitted
+	for _, notExp := range []string{`engine="1"`, `engine="2"`} {
+		if strings.Contains(gotMetrics, notExp) {
+			t.Errorf("unexpected %q in metrics:\n%s", notExp, gotMetrics)
+		}
+	}
+}
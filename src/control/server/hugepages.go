@@ -7,12 +7,34 @@ package server
 
 import (
 	"bufio"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+)
+
+// defaultHugetlbfsMount is where the hugetlbfs filesystem is expected to be
+// mounted when running in a container.
+const defaultHugetlbfsMount = "/dev/hugepages"
+
+const (
+	defaultProcRoot = "/proc"
+
+	// compactHugePagesHint is appended to advisory messages when free memory
+	// is too fragmented to satisfy a hugepage allocation.
+	compactHugePagesHint = "run 'daos_server storage prepare --nvme-only --compact-hugepages' " +
+		"to compact memory before hugepages are allocated"
 )
 
 type getHugePageInfoFn func() (*hugePageInfo, error)
@@ -88,3 +110,293 @@ func getHugePageInfo() (*hugePageInfo, error) {
 
 	return parseHugePageInfo(f)
 }
+
+// hugetlbfsPageInfo returns hugepage information derived from the statistics
+// of a size-limited hugetlbfs mount. Without a size limit the filesystem
+// reports no blocks, in which case nil is returned.
+func hugetlbfsPageInfo(st *unix.Statfs_t) *hugePageInfo {
+	if st.Blocks == 0 {
+		return nil
+	}
+
+	return &hugePageInfo{
+		Total:      int(st.Blocks),
+		Free:       int(st.Bfree),
+		PageSizeKb: int(st.Bsize / 1024),
+	}
+}
+
+// getHugetlbfsPageInfoFn returns a getHugePageInfoFn that reads hugepage
+// information from the hugetlbfs filesystem at the given mount point, as
+// /proc/meminfo reports host-wide values inside a container. If the mount
+// has no size limit, /proc/meminfo is used instead.
+func getHugetlbfsPageInfoFn(mountPoint string) getHugePageInfoFn {
+	return func() (*hugePageInfo, error) {
+		st := new(unix.Statfs_t)
+		if err := unix.Statfs(mountPoint, st); err != nil {
+			return nil, errors.Wrapf(err, "unable to stat hugetlbfs at %s", mountPoint)
+		}
+		if st.Type != unix.HUGETLBFS_MAGIC {
+			return nil, errors.Errorf("%s is not a hugetlbfs mount", mountPoint)
+		}
+
+		if hpi := hugetlbfsPageInfo(st); hpi != nil {
+			return hpi, nil
+		}
+		return getHugePageInfo()
+	}
+}
+
+// hugePageOrder returns the buddy allocator order of a hugepage of the given
+// size, i.e. log2 of the number of base pages making up a hugepage.
+func hugePageOrder(pageSizeKb, basePageSizeKb int) int {
+	order := 0
+	for n := pageSizeKb / basePageSizeKb; n > 1; n >>= 1 {
+		order++
+	}
+	return order
+}
+
+// parseBuddyInfo returns the number of physically contiguous free blocks of
+// the given order that are available across all nodes and zones, as reported
+// in the /proc/buddyinfo format. Free blocks of a higher order are counted as
+// multiple blocks of the requested order.
+func parseBuddyInfo(input io.Reader, order int) (int, error) {
+	nodeBlocks, err := parseNodeBuddyInfo(input, order)
+	if err != nil {
+		return 0, err
+	}
+
+	var blocks int
+	for _, count := range nodeBlocks {
+		blocks += count
+	}
+
+	return blocks, nil
+}
+
+// parseNodeBuddyInfo returns the number of physically contiguous free blocks
+// of the given order that are available on each NUMA node, as reported in the
+// /proc/buddyinfo format.
+func parseNodeBuddyInfo(input io.Reader, order int) (map[int]int, error) {
+	nodeBlocks := make(map[int]int)
+
+	scn := bufio.NewScanner(input)
+	for scn.Scan() {
+		// Node 0, zone   Normal   4096   2048   ...
+		fields := strings.Fields(scn.Text())
+		if len(fields) < 4 || fields[2] != "zone" {
+			continue
+		}
+		node, err := strconv.Atoi(strings.TrimSuffix(fields[1], ","))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse buddyinfo line %q", scn.Text())
+		}
+
+		for o, field := range fields[4:] {
+			if o < order {
+				continue
+			}
+			count, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to parse buddyinfo line %q", scn.Text())
+			}
+			nodeBlocks[node] += count << uint(o-order)
+		}
+	}
+
+	return nodeBlocks, scn.Err()
+}
+
+// getFreeHugePageBlocks returns the number of hugepages of the given size that
+// could currently be allocated from physically contiguous free memory.
+func getFreeHugePageBlocks(procRoot string, pageSizeKb int) (int, error) {
+	f, err := os.Open(filepath.Join(procRoot, "buddyinfo"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return parseBuddyInfo(f, hugePageOrder(pageSizeKb, os.Getpagesize()/1024))
+}
+
+// getNodeFreeHugePageBlocks returns the number of hugepages of the given size
+// that could currently be allocated from physically contiguous free memory on
+// each NUMA node.
+func getNodeFreeHugePageBlocks(procRoot string, pageSizeKb int) (map[int]int, error) {
+	f, err := os.Open(filepath.Join(procRoot, "buddyinfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseNodeBuddyInfo(f, hugePageOrder(pageSizeKb, os.Getpagesize()/1024))
+}
+
+// hugePageHolder describes a process that has hugepages mapped.
+type hugePageHolder struct {
+	Pid       int
+	Name      string
+	HugetlbKb int
+}
+
+func (hph *hugePageHolder) String() string {
+	return fmt.Sprintf("%s (pid %d): %d kB", hph.Name, hph.Pid, hph.HugetlbKb)
+}
+
+// parseHugePageHolder extracts hugepage usage from the contents of a
+// /proc/<pid>/status file, nil is returned if the process holds no hugepages.
+func parseHugePageHolder(pid int, input io.Reader) (*hugePageHolder, error) {
+	hph := &hugePageHolder{Pid: pid}
+
+	scn := bufio.NewScanner(input)
+	for scn.Scan() {
+		keyVal := strings.SplitN(scn.Text(), ":", 2)
+		if len(keyVal) < 2 {
+			continue
+		}
+
+		switch keyVal[0] {
+		case "Name":
+			hph.Name = strings.TrimSpace(keyVal[1])
+		case "HugetlbPages":
+			sf := strings.Fields(keyVal[1])
+			if len(sf) != 2 || sf[1] != "kB" {
+				return nil, errors.Errorf("unable to parse %q", keyVal[1])
+			}
+			parseInt(sf[0], &hph.HugetlbKb)
+		}
+	}
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+
+	if hph.HugetlbKb == 0 {
+		return nil, nil
+	}
+	return hph, nil
+}
+
+// getHugePageHolders scans the process status files under procRoot and returns
+// the processes that hold hugepages, largest consumers first. Processes that
+// exit during the scan are ignored.
+func getHugePageHolders(procRoot string) ([]*hugePageHolder, error) {
+	entries, err := ioutil.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []*hugePageHolder
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(procRoot, entry.Name(), "status"))
+		if err != nil {
+			continue
+		}
+		hph, err := parseHugePageHolder(pid, f)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "pid %d", pid)
+		}
+		if hph != nil {
+			holders = append(holders, hph)
+		}
+	}
+
+	sort.Slice(holders, func(i, j int) bool {
+		if holders[i].HugetlbKb != holders[j].HugetlbKb {
+			return holders[i].HugetlbKb > holders[j].HugetlbKb
+		}
+		return holders[i].Pid < holders[j].Pid
+	})
+
+	return holders, nil
+}
+
+// logHugePageAdvisory reports the likely reasons for a shortfall of free
+// hugepages: processes that are holding hugepages and, when the kernel cannot
+// allocate further hugepages, whether free memory is too fragmented to do so.
+func logHugePageAdvisory(log logging.Logger, procRoot string, hpi *hugePageInfo, required int) {
+	holders, err := getHugePageHolders(procRoot)
+	if err != nil {
+		log.Debugf("unable to determine hugepage holders: %s", err)
+	}
+	for _, hph := range holders {
+		log.Infof("hugepages held by %s", hph)
+	}
+
+	shortfall := required - hpi.Free
+	if shortfall <= 0 || hpi.PageSizeKb == 0 {
+		return
+	}
+
+	blocks, err := getFreeHugePageBlocks(procRoot, hpi.PageSizeKb)
+	if err != nil {
+		log.Debugf("unable to determine memory fragmentation: %s", err)
+		return
+	}
+	if blocks < shortfall {
+		log.Infof("free memory is too fragmented to allocate %d more %d kB hugepages "+
+			"(%d contiguous blocks available); %s", shortfall, hpi.PageSizeKb, blocks,
+			compactHugePagesHint)
+	}
+}
+
+// engineHugeNodePages returns the number of hugepages to allocate on each NUMA
+// node so that every engine has the configured number of hugepages on the node
+// it is pinned to. Nil is returned if any engine has no NUMA affinity, in which
+// case hugepages are allocated across all nodes.
+func engineHugeNodePages(cfg *config.Server) map[int]int {
+	if len(cfg.Engines) == 0 || cfg.NrHugepages <= 0 {
+		return nil
+	}
+
+	nodePages := make(map[int]int)
+	for _, ec := range cfg.Engines {
+		numa, err := ec.Fabric.GetNumaNode()
+		if err != nil {
+			return nil
+		}
+		nodePages[int(numa)] += cfg.NrHugepages
+	}
+
+	return nodePages
+}
+
+// nodeHugePageWarnings describes the NUMA nodes on which fewer hugepages were
+// allocated than requested by a per-node prepare, including whether free
+// memory on the node is too fragmented, and the nodes on which the shortfall
+// was allocated instead.
+func nodeHugePageWarnings(procRoot string, resp *bdev.PrepareResponse) []string {
+	if resp == nil || len(resp.HugeNodeAllocs) == 0 {
+		return nil
+	}
+
+	var nodeBlocks map[int]int
+	var warnings []string
+	for _, hna := range resp.HugeNodeAllocs {
+		switch {
+		case hna.Short():
+			msg := fmt.Sprintf("NUMA node %d: %d of %d requested hugepages allocated",
+				hna.Node, hna.Allocated, hna.Requested)
+			if nodeBlocks == nil && resp.HugePageSizeKb > 0 {
+				nodeBlocks, _ = getNodeFreeHugePageBlocks(procRoot, resp.HugePageSizeKb)
+			}
+			if blocks, found := nodeBlocks[hna.Node]; found && blocks < hna.Requested-hna.Allocated {
+				msg += fmt.Sprintf(", free memory on the node is too fragmented "+
+					"(%d contiguous blocks available); %s", blocks, compactHugePagesHint)
+			}
+			warnings = append(warnings, msg)
+		case hna.Allocated > hna.Requested:
+			warnings = append(warnings, fmt.Sprintf("NUMA node %d: %d hugepages allocated "+
+				"in place of those unavailable on other nodes, engines will use remote memory",
+				hna.Node, hna.Allocated-hna.Requested))
+		}
+	}
+
+	return warnings
+}
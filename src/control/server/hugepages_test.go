@@ -6,13 +6,22 @@
 package server
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
 
 	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
 )
 
 func TestServer_getHugePageInfo(t *testing.T) {
@@ -98,3 +107,340 @@ Hugepagesize:       1 GB
 		})
 	}
 }
+
+func TestServer_hugetlbfsPageInfo(t *testing.T) {
+	for name, tc := range map[string]struct {
+		statfs *unix.Statfs_t
+		expOut *hugePageInfo
+	}{
+		"no size limit": {
+			statfs: &unix.Statfs_t{Bsize: 2048 * 1024},
+		},
+		"2MB pagesize": {
+			statfs: &unix.Statfs_t{Bsize: 2048 * 1024, Blocks: 4096, Bfree: 1024},
+			expOut: &hugePageInfo{
+				Total:      4096,
+				Free:       1024,
+				PageSizeKb: 2048,
+			},
+		},
+		"1GB pagesize": {
+			statfs: &unix.Statfs_t{Bsize: 1 << 30, Blocks: 16, Bfree: 16},
+			expOut: &hugePageInfo{
+				Total:      16,
+				Free:       16,
+				PageSizeKb: 1 << 20,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotOut := hugetlbfsPageInfo(tc.statfs)
+			if diff := cmp.Diff(tc.expOut, gotOut); diff != "" {
+				t.Fatalf("unexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_getHugetlbfsPageInfo_notHugetlbfs(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	_, err := getHugetlbfsPageInfoFn(tmpDir)()
+	common.CmpErr(t, errors.New("not a hugetlbfs mount"), err)
+}
+
+func TestServer_hugePageOrder(t *testing.T) {
+	for name, tc := range map[string]struct {
+		pageSizeKb int
+		expOrder   int
+	}{
+		"2MB":  {2048, 9},
+		"1GB":  {1048576, 18},
+		"base": {4, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := hugePageOrder(tc.pageSizeKb, 4); got != tc.expOrder {
+				t.Fatalf("expected order %d, got %d", tc.expOrder, got)
+			}
+		})
+	}
+}
+
+func TestServer_parseBuddyInfo(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input     string
+		order     int
+		expBlocks int
+		expErr    error
+	}{
+		"empty": {},
+		"fragmented": {
+			input: `Node 0, zone      DMA      1      1      1      0      2      1      1      0      1      1      3
+Node 0, zone    DMA32   4512   3120    964    120     28      4      0      0      0      0      0
+Node 0, zone   Normal 120432  60213  30106   8221   1003     64      2      0      0      0      0
+`,
+			order:     9,
+			expBlocks: 1 + 3*2,
+		},
+		"multiple nodes": {
+			input: `Node 0, zone   Normal      0      0      0      0      0      0      0      0      0      4      1
+Node 1, zone   Normal      0      0      0      0      0      0      0      0      0      2      0
+`,
+			order:     9,
+			expBlocks: 4 + 2 + 2,
+		},
+		"garbage ignored": {
+			input:     "something else entirely\n",
+			order:     9,
+			expBlocks: 0,
+		},
+		"bad count": {
+			input:  "Node 0, zone   Normal      0      0      0      0      0      0      0      0      0      x      1\n",
+			order:  9,
+			expErr: errors.New("unable to parse"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotBlocks, gotErr := parseBuddyInfo(strings.NewReader(tc.input), tc.order)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if gotBlocks != tc.expBlocks {
+				t.Fatalf("expected %d blocks, got %d", tc.expBlocks, gotBlocks)
+			}
+		})
+	}
+}
+
+func TestServer_parseNodeBuddyInfo(t *testing.T) {
+	input := `Node 0, zone    DMA32      0      0      0      0      0      0      0      0      0      1      0
+Node 0, zone   Normal      0      0      0      0      0      0      0      0      0      4      1
+Node 1, zone   Normal      0      0      0      0      0      0      0      0      0      2      0
+`
+	gotBlocks, err := parseNodeBuddyInfo(strings.NewReader(input), 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(map[int]int{0: 1 + 4 + 2, 1: 2}, gotBlocks); diff != "" {
+		t.Fatalf("unexpected blocks (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_engineHugeNodePages(t *testing.T) {
+	numa0 := uint(0)
+	numa1 := uint(1)
+
+	for name, tc := range map[string]struct {
+		cfg          *config.Server
+		expNodePages map[int]int
+	}{
+		"no engines": {
+			cfg: config.DefaultServer().WithNrHugePages(4096),
+		},
+		"engine not pinned": {
+			cfg: config.DefaultServer().WithNrHugePages(4096).
+				WithEngines(
+					engine.NewConfig().WithPinnedNumaNode(&numa0),
+					engine.NewConfig(),
+				),
+		},
+		"engines on separate nodes": {
+			cfg: config.DefaultServer().WithNrHugePages(4096).
+				WithEngines(
+					engine.NewConfig().WithPinnedNumaNode(&numa0),
+					engine.NewConfig().WithPinnedNumaNode(&numa1),
+				),
+			expNodePages: map[int]int{0: 4096, 1: 4096},
+		},
+		"engines on same node": {
+			cfg: config.DefaultServer().WithNrHugePages(4096).
+				WithEngines(
+					engine.NewConfig().WithPinnedNumaNode(&numa1),
+					engine.NewConfig().WithPinnedNumaNode(&numa1),
+				),
+			expNodePages: map[int]int{1: 8192},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotNodePages := engineHugeNodePages(tc.cfg)
+
+			if diff := cmp.Diff(tc.expNodePages, gotNodePages); diff != "" {
+				t.Fatalf("unexpected node hugepages (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_nodeHugePageWarnings(t *testing.T) {
+	for name, tc := range map[string]struct {
+		buddyInfo   string
+		resp        *bdev.PrepareResponse
+		expWarnings []string
+	}{
+		"no response": {},
+		"global allocation": {
+			resp: &bdev.PrepareResponse{},
+		},
+		"all allocated": {
+			resp: &bdev.PrepareResponse{
+				HugePageSizeKb: 2048,
+				HugeNodeAllocs: []*bdev.HugeNodeAlloc{
+					{Node: 0, Requested: 4096, Allocated: 4096},
+					{Node: 1, Requested: 4096, Allocated: 4096},
+				},
+			},
+		},
+		"fragmented node with fallback": {
+			buddyInfo: `Node 0, zone   Normal   9999   9999   9999   9999   9999   9999   9999   9999   9999     10      0
+Node 1, zone   Normal      0      0      0      0      0      0      0      0      0      0   2048
+`,
+			resp: &bdev.PrepareResponse{
+				HugePageSizeKb: 2048,
+				HugeNodeAllocs: []*bdev.HugeNodeAlloc{
+					{Node: 0, Requested: 4096, Allocated: 3000},
+					{Node: 1, Requested: 4096, Allocated: 5192},
+				},
+			},
+			expWarnings: []string{
+				"NUMA node 0: 3000 of 4096 requested hugepages allocated, free memory on the " +
+					"node is too fragmented (10 contiguous blocks available); " +
+					compactHugePagesHint,
+				"NUMA node 1: 1096 hugepages allocated in place of those unavailable on " +
+					"other nodes, engines will use remote memory",
+			},
+		},
+		"short without fragmentation": {
+			buddyInfo: "Node 0, zone   Normal      0      0      0      0      0      0      0      0      0      0   2048\n",
+			resp: &bdev.PrepareResponse{
+				HugePageSizeKb: 2048,
+				HugeNodeAllocs: []*bdev.HugeNodeAlloc{
+					{Node: 0, Requested: 4096, Allocated: 3000},
+				},
+			},
+			expWarnings: []string{
+				"NUMA node 0: 3000 of 4096 requested hugepages allocated",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			if err := ioutil.WriteFile(filepath.Join(testDir, "buddyinfo"),
+				[]byte(tc.buddyInfo), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			gotWarnings := nodeHugePageWarnings(testDir, tc.resp)
+
+			if diff := cmp.Diff(tc.expWarnings, gotWarnings); diff != "" {
+				t.Fatalf("unexpected warnings (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func writeTestProcStatus(t *testing.T, procRoot, pid, name string, hugetlbKb int) {
+	t.Helper()
+
+	dir := filepath.Join(procRoot, pid)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	status := fmt.Sprintf("Name:\t%s\nState:\tS (sleeping)\nHugetlbPages:\t%8d kB\nThreads:\t1\n",
+		name, hugetlbKb)
+	if err := ioutil.WriteFile(filepath.Join(dir, "status"), []byte(status), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_getHugePageHolders(t *testing.T) {
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	writeTestProcStatus(t, testDir, "1", "systemd", 0)
+	writeTestProcStatus(t, testDir, "42", "daos_engine", 4096)
+	writeTestProcStatus(t, testDir, "7", "spdk_tgt", 8192)
+	writeTestProcStatus(t, testDir, "99", "daos_engine", 4096)
+	if err := os.MkdirAll(filepath.Join(testDir, "sys"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(testDir, "100"), 0755); err != nil {
+		t.Fatal(err) // process exited, no status
+	}
+
+	gotHolders, err := getHugePageHolders(testDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expHolders := []*hugePageHolder{
+		{Pid: 7, Name: "spdk_tgt", HugetlbKb: 8192},
+		{Pid: 42, Name: "daos_engine", HugetlbKb: 4096},
+		{Pid: 99, Name: "daos_engine", HugetlbKb: 4096},
+	}
+	if diff := cmp.Diff(expHolders, gotHolders); diff != "" {
+		t.Fatalf("unexpected holders (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_logHugePageAdvisory(t *testing.T) {
+	for name, tc := range map[string]struct {
+		buddyInfo  string
+		hpi        *hugePageInfo
+		required   int
+		expLogMsgs []string
+		expNoMsgs  []string
+	}{
+		"fragmented": {
+			buddyInfo: "Node 0, zone   Normal   9999   9999   9999   9999   9999   9999   9999   9999   9999      1      0\n",
+			hpi:       &hugePageInfo{Free: 2, PageSizeKb: 2048},
+			required:  8,
+			expLogMsgs: []string{
+				"daos_engine (pid 42): 4096 kB",
+				"too fragmented to allocate 6 more 2048 kB hugepages",
+				"--compact-hugepages",
+			},
+		},
+		"enough contiguous memory": {
+			buddyInfo: "Node 0, zone   Normal      0      0      0      0      0      0      0      0      0      0     64\n",
+			hpi:       &hugePageInfo{Free: 2, PageSizeKb: 2048},
+			required:  8,
+			expLogMsgs: []string{
+				"daos_engine (pid 42): 4096 kB",
+			},
+			expNoMsgs: []string{"fragmented"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			writeTestProcStatus(t, testDir, "42", "daos_engine", 4096)
+			if err := ioutil.WriteFile(filepath.Join(testDir, "buddyinfo"),
+				[]byte(tc.buddyInfo), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			logHugePageAdvisory(log, testDir, tc.hpi, tc.required)
+
+			for _, msg := range tc.expLogMsgs {
+				if !strings.Contains(buf.String(), msg) {
+					t.Fatalf("expected to see %q in log, got %q", msg, buf.String())
+				}
+			}
+			for _, msg := range tc.expNoMsgs {
+				if strings.Contains(buf.String(), msg) {
+					t.Fatalf("did not expect to see %q in log, got %q", msg, buf.String())
+				}
+			}
+		})
+	}
+}
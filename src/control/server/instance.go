@@ -11,10 +11,12 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
 	"github.com/daos-stack/daos/src/control/drpc"
@@ -26,12 +28,15 @@ import (
 	"github.com/daos-stack/daos/src/control/system"
 )
 
+const bioErrorTimeout = 10 * time.Second
+
 type (
 	systemJoinFn     func(context.Context, *control.SystemJoinReq) (*control.SystemJoinResp, error)
 	onAwaitFormatFn  func(context.Context, uint32, string) error
 	onStorageReadyFn func(context.Context) error
 	onReadyFn        func(context.Context) error
 	onInstanceExitFn func(context.Context, uint32, system.Rank, error, uint64) error
+	onBioErrorFn     func(context.Context, uint32, system.Rank, int32, string, string) error
 )
 
 // EngineInstance encapsulates control-plane specific configuration
@@ -52,13 +57,18 @@ type EngineInstance struct {
 	drpcReady         chan *srvpb.NotifyReadyReq
 	ready             atm.Bool
 	startRequested    chan bool
+	waitSpare         atm.Bool
+	spareActivated    chan bool
 	fsRoot            string
 	hostFaultDomain   *system.FaultDomain
 	joinSystem        systemJoinFn
+	waitFormation     formationBarrierFn
 	onAwaitFormat     []onAwaitFormatFn
 	onStorageReady    []onStorageReadyFn
 	onReady           []onReadyFn
 	onInstanceExit    []onInstanceExitFn
+	onBioError        []onBioErrorFn
+	startup           system.StartupTimeline // only accessed by the run loop
 
 	sync.RWMutex
 	// these must be protected by a mutex in order to
@@ -84,6 +94,7 @@ func NewEngineInstance(log logging.Logger,
 		drpcReady:         make(chan *srvpb.NotifyReadyReq),
 		storageReady:      make(chan bool),
 		startRequested:    make(chan bool),
+		spareActivated:    make(chan bool),
 	}
 }
 
@@ -137,6 +148,12 @@ func (ei *EngineInstance) OnInstanceExit(fns ...onInstanceExitFn) {
 	ei.onInstanceExit = append(ei.onInstanceExit, fns...)
 }
 
+// OnBioError adds a list of callbacks to invoke when the instance reports
+// a blob I/O error on one of its NVMe SSDs.
+func (ei *EngineInstance) OnBioError(fns ...onBioErrorFn) {
+	ei.onBioError = append(ei.onBioError, fns...)
+}
+
 // LocalState returns local perspective of the current instance state
 // (doesn't consider state info held by the global system membership).
 func (ei *EngineInstance) LocalState() system.MemberState {
@@ -201,6 +218,7 @@ func (ei *EngineInstance) determineRank(ctx context.Context, ready *srvpb.Notify
 		NumContexts: ready.GetNctxs(),
 		FaultDomain: ei.hostFaultDomain,
 		InstanceIdx: ei.Index(),
+		Hostname:    hostname(),
 	})
 	if err != nil {
 		return system.NilRank, false, err
@@ -253,17 +271,32 @@ func (ei *EngineInstance) updateFaultDomainInSuperblock() error {
 	return nil
 }
 
+// WithFormationBarrier adds a barrier to be waited on after the instance has
+// joined the system for the first time and before the engine is set up.
+func (ei *EngineInstance) WithFormationBarrier(fn formationBarrierFn) *EngineInstance {
+	ei.waitFormation = fn
+	return ei
+}
+
 // handleReady determines the instance rank and sends a SetRank dRPC request
-// to the Engine.
+// to the Engine. If a formation barrier is set and the rank is joining the
+// system for the first time, SetUp is deferred until the barrier has been
+// passed.
 func (ei *EngineInstance) handleReady(ctx context.Context, ready *srvpb.NotifyReadyReq) error {
 	if err := ei.updateFaultDomainInSuperblock(); err != nil {
 		ei.log.Error(err.Error()) // nonfatal
 	}
 
+	// Ranks that have already joined the system are restarting and are
+	// not held back by the formation barrier.
+	sb := ei.getSuperblock()
+	initialJoin := sb != nil && !sb.ValidRank
+
 	r, localJoin, err := ei.determineRank(ctx, ready)
 	if err != nil {
 		return err
 	}
+	ei.startup.Joined = time.Now()
 
 	// If the join was already processed because it ran on the same server,
 	// skip the rest of these steps.
@@ -275,6 +308,12 @@ func (ei *EngineInstance) handleReady(ctx context.Context, ready *srvpb.NotifyRe
 		return err
 	}
 
+	if ei.waitFormation != nil && initialJoin {
+		if err := ei.waitFormation(ctx); err != nil {
+			return err
+		}
+	}
+
 	if err := ei.callSetUp(ctx); err != nil {
 		return err
 	}
@@ -351,9 +390,73 @@ func (ei *EngineInstance) callSetUp(ctx context.Context) error {
 	return nil
 }
 
-// BioErrorNotify logs a blob I/O error.
+// BioErrorNotify logs a blob I/O error and invokes any registered callbacks.
 func (ei *EngineInstance) BioErrorNotify(bio *srvpb.BioErrorReq) {
 
 	ei.log.Errorf("I/O Engine instance %d (target %d) has detected blob I/O error! %v",
 		ei.Index(), bio.TgtId, bio)
+
+	if len(ei.onBioError) == 0 {
+		return
+	}
+
+	// The notification is received whilst the engine is waiting on the
+	// dRPC response, so resolve the device asynchronously.
+	go ei.handleBioError(bio)
+}
+
+func bioErrorType(bio *srvpb.BioErrorReq) string {
+	switch {
+	case bio.UnmapErr:
+		return "unmap"
+	case bio.ReadErr:
+		return "read"
+	case bio.WriteErr:
+		return "write"
+	default:
+		return "unknown"
+	}
+}
+
+// tgtDeviceUUID returns the UUID of the NVMe SSD backing the given target.
+func (ei *EngineInstance) tgtDeviceUUID(ctx context.Context, tgtID int32) (string, error) {
+	resp, err := ei.listSmdDevices(ctx, new(ctlpb.SmdDevReq))
+	if err != nil {
+		return "", err
+	}
+
+	for _, dev := range resp.Devices {
+		for _, id := range dev.TgtIds {
+			if id == tgtID {
+				return dev.Uuid, nil
+			}
+		}
+	}
+
+	return "", errors.Errorf("no device found for target %d", tgtID)
+}
+
+func (ei *EngineInstance) handleBioError(bio *srvpb.BioErrorReq) {
+	ctx, cancel := context.WithTimeout(context.Background(), bioErrorTimeout)
+	defer cancel()
+
+	engineIdx := ei.Index()
+	rank, err := ei.GetRank()
+	if err != nil {
+		ei.log.Debugf("instance %d: no rank (%s)", engineIdx, err)
+		return
+	}
+
+	devUUID, err := ei.tgtDeviceUUID(ctx, bio.TgtId)
+	if err != nil {
+		ei.log.Errorf("instance %d: resolve device for target %d: %s",
+			engineIdx, bio.TgtId, err)
+		return
+	}
+
+	for _, fn := range ei.onBioError {
+		if err := fn(ctx, engineIdx, rank, bio.TgtId, devUUID, bioErrorType(bio)); err != nil {
+			ei.log.Errorf("onBioError: %s", err)
+		}
+	}
 }
@@ -198,6 +198,48 @@ func (ei *EngineInstance) listSmdDevices(ctx context.Context, req *ctlpb.SmdDevR
 	return resp, nil
 }
 
+func (ei *EngineInstance) getXstreamStats(ctx context.Context) (*ctlpb.XstreamStatsResp, error) {
+	dresp, err := ei.CallDrpc(ctx, drpc.MethodXstreamStats, new(ctlpb.XstreamStatsReq))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(ctlpb.XstreamStatsResp)
+	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal XstreamStats response")
+	}
+
+	if resp.Status != 0 {
+		return nil, errors.Wrap(drpc.DaosStatus(resp.Status), "getXstreamStats failed")
+	}
+
+	return resp, nil
+}
+
+// getPoolTargets retrieves the state and space usage of the targets of the
+// given pool which are local to the instance. A nil response is returned if
+// the pool has no targets on the instance.
+func (ei *EngineInstance) getPoolTargets(ctx context.Context, poolUUID string) (*ctlpb.PoolTargetsResp, error) {
+	dresp, err := ei.CallDrpc(ctx, drpc.MethodPoolTargets, &ctlpb.PoolTargetsReq{Uuid: poolUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(ctlpb.PoolTargetsResp)
+	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal PoolTargets response")
+	}
+
+	switch drpc.DaosStatus(resp.Status) {
+	case drpc.DaosSuccess:
+		return resp, nil
+	case drpc.DaosNonexistant:
+		return nil, nil
+	default:
+		return nil, errors.Wrap(drpc.DaosStatus(resp.Status), "getPoolTargets failed")
+	}
+}
+
 // updateInUseBdevs updates-in-place the input list of controllers with
 // new NVMe health stats and SMD metadata info.
 //
@@ -8,9 +8,11 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -27,6 +29,7 @@ type EngineRunner interface {
 	Start(context.Context, chan<- error) error
 	IsRunning() bool
 	GetLastPid() uint64
+	GetPid() uint64
 	Signal(os.Signal) error
 	GetConfig() *engine.Config
 }
@@ -38,6 +41,7 @@ func (ei *EngineInstance) format(ctx context.Context, recreateSBs bool) error {
 	if err := ei.awaitStorageReady(ctx, recreateSBs); err != nil {
 		return err
 	}
+	ei.startup = system.StartupTimeline{StorageReady: time.Now()}
 	if err := ei.createSuperblock(recreateSBs); err != nil {
 		return err
 	}
@@ -83,6 +87,7 @@ func (ei *EngineInstance) waitReady(ctx context.Context, errChan chan error) err
 	case err := <-errChan:
 		return errors.Wrapf(err, "instance %d exited during start-up", ei.Index())
 	case ready := <-ei.awaitDrpcReady():
+		ei.startup.FabricUp = time.Now()
 		if err := ei.finishStartup(ctx, ready); err != nil {
 			return err
 		}
@@ -99,6 +104,7 @@ func (ei *EngineInstance) finishStartup(ctx context.Context, ready *srvpb.Notify
 	if err := ei.handleReady(ctx, ready); err != nil {
 		return err
 	}
+	ei.startup.PoolsRestored = time.Now()
 	// update engine target count to reflect allocated
 	// number of targets, not number requested when starting
 	ei.setTargetCount(int(ready.GetNtgts()))
@@ -132,6 +138,46 @@ func publishInstanceExitFn(publishFn func(*events.RASEvent), hostname string) on
 	}
 }
 
+// publishEngineStartedFn returns onReadyFn which will publish an event with
+// the startup timeline of the instance using the provided publish function.
+func publishEngineStartedFn(publishFn func(*events.RASEvent), hostname string, ei *EngineInstance) onReadyFn {
+	return func(_ context.Context) error {
+		// failing to report the timeline should not fail the startup
+		rank, err := ei.GetRank()
+		if err != nil {
+			ei.log.Errorf("instance %d: startup timeline not published: %s", ei.Index(), err)
+			return nil
+		}
+		timeline, err := json.Marshal(ei.startup)
+		if err != nil {
+			ei.log.Errorf("instance %d: startup timeline not published: %s", ei.Index(), err)
+			return nil
+		}
+
+		// forward to the MS so that the timeline is recorded against
+		// the system member
+		evt := events.NewEngineStartedEvent(hostname, ei.Index(), rank.Uint32(),
+			string(timeline))
+		publishFn(evt.WithForwardable(true))
+
+		return nil
+	}
+}
+
+// publishBioErrorFn returns onBioErrorFn which will publish a device I/O
+// error event using the provided publish function.
+func publishBioErrorFn(publishFn func(*events.RASEvent), hostname string) onBioErrorFn {
+	return func(_ context.Context, engineIdx uint32, rank system.Rank, tgtID int32, devUUID, errType string) error {
+		evt := events.NewDeviceIOErrorEvent(hostname, engineIdx, rank.Uint32(),
+			tgtID, devUUID, errType)
+
+		// forward to the MS so that the fault policy can be applied
+		publishFn(evt.WithForwardable(true))
+
+		return nil
+	}
+}
+
 func (ei *EngineInstance) exit(ctx context.Context, exitErr error) {
 	engineIdx := ei.Index()
 
@@ -187,6 +233,10 @@ func (ei *EngineInstance) run(parent context.Context, recreateSBs bool) error {
 		return err
 	}
 
+	if err := ei.awaitSpareActivation(ctx); err != nil {
+		return err
+	}
+
 	// Use the parent context here to avoid interfering with the shutdown
 	// logic in the runner.
 	if err := ei.start(parent, errChan); err != nil {
@@ -8,15 +8,20 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/system"
@@ -97,3 +102,178 @@ func TestIOEngineInstance_exit(t *testing.T) {
 		})
 	}
 }
+
+// TestIOEngineInstance_handleBioError establishes that a device I/O error
+// event is published for the device backing the failing target.
+func TestIOEngineInstance_handleBioError(t *testing.T) {
+	devUUID := common.MockUUID(1)
+
+	for name, tc := range map[string]struct {
+		noRank    bool
+		tgtID     int32
+		drpcResp  *mockDrpcResponse
+		expEvtMsg string
+	}{
+		"no rank": {
+			noRank: true,
+		},
+		"device list fails": {
+			drpcResp: &mockDrpcResponse{
+				Message: &ctlpb.SmdDevResp{},
+				Error:   errors.New("drpc failed"),
+			},
+		},
+		"no device for target": {
+			tgtID: 5,
+			drpcResp: &mockDrpcResponse{
+				Message: &ctlpb.SmdDevResp{
+					Devices: []*ctlpb.SmdDevResp_Device{
+						{Uuid: devUUID, TgtIds: []int32{0, 1}},
+					},
+				},
+			},
+		},
+		"event published": {
+			tgtID: 1,
+			drpcResp: &mockDrpcResponse{
+				Message: &ctlpb.SmdDevResp{
+					Devices: []*ctlpb.SmdDevResp_Device{
+						{Uuid: devUUID, TgtIds: []int32{0, 1}},
+					},
+				},
+			},
+			expEvtMsg: fmt.Sprintf("DAOS engine 0 target 1 detected read error on device %s",
+				devUUID),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var rxEvts []*events.RASEvent
+			fakePublish := func(evt *events.RASEvent) {
+				rxEvts = append(rxEvts, evt)
+			}
+
+			runner := engine.NewTestRunner(nil, &engine.Config{})
+			instance := NewEngineInstance(log, nil, nil, nil, runner)
+			if !tc.noRank {
+				instance.setSuperblock(&Superblock{
+					Rank: system.NewRankPtr(2), ValidRank: true,
+				})
+			}
+			cfg := new(mockDrpcClientConfig)
+			if tc.drpcResp != nil {
+				cfg.setSendMsgResponseList(t, tc.drpcResp)
+			}
+			instance.setDrpcClient(newMockDrpcClient(cfg))
+
+			instance.OnBioError(publishBioErrorFn(fakePublish, hostname()))
+			instance.handleBioError(&srvpb.BioErrorReq{
+				TgtId:   tc.tgtID,
+				ReadErr: true,
+			})
+
+			if tc.expEvtMsg == "" {
+				common.AssertEqual(t, 0, len(rxEvts), "unexpected events published")
+				return
+			}
+
+			common.AssertEqual(t, 1, len(rxEvts),
+				"unexpected number of events published")
+			evt := rxEvts[0]
+			common.AssertEqual(t, events.RASDeviceIOError, evt.ID, "unexpected event ID")
+			common.AssertEqual(t, uint32(2), evt.Rank, "unexpected event rank")
+			common.AssertEqual(t, devUUID, evt.HWID, "unexpected event hardware ID")
+			common.AssertTrue(t, evt.ShouldForward(), "expected event to be forwardable")
+			if diff := cmp.Diff(tc.expEvtMsg, evt.Msg); diff != "" {
+				t.Fatalf("unexpected message (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+// countingInvoker counts the unary RPCs made with the mock invoker.
+type countingInvoker struct {
+	*control.MockInvoker
+	count int
+}
+
+func (ci *countingInvoker) InvokeUnaryRPC(ctx context.Context, req control.UnaryRequest) (*control.UnaryResponse, error) {
+	ci.count++
+	return ci.MockInvoker.InvokeUnaryRPC(ctx, req)
+}
+
+// TestIOEngineInstance_publishEngineStarted establishes that the startup
+// timeline of the instance is published once it is ready.
+func TestIOEngineInstance_publishEngineStarted(t *testing.T) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeline := system.StartupTimeline{
+		StorageReady:  started,
+		FabricUp:      started.Add(time.Second),
+		Joined:        started.Add(2 * time.Second),
+		PoolsRestored: started.Add(3 * time.Second),
+	}
+
+	for name, tc := range map[string]struct {
+		noRank bool
+	}{
+		"no rank": {
+			noRank: true,
+		},
+		"event published": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var rxEvts []*events.RASEvent
+			fakePublish := func(evt *events.RASEvent) {
+				rxEvts = append(rxEvts, evt)
+			}
+
+			runner := engine.NewTestRunner(nil, &engine.Config{})
+			instance := NewEngineInstance(log, nil, nil, nil, runner)
+			instance.setIndex(1)
+			if !tc.noRank {
+				instance.setSuperblock(&Superblock{
+					Rank: system.NewRankPtr(2), ValidRank: true,
+				})
+			}
+			instance.startup = timeline
+
+			onReady := publishEngineStartedFn(fakePublish, hostname(), instance)
+			if err := onReady(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.noRank {
+				common.AssertEqual(t, 0, len(rxEvts), "unexpected events published")
+				return
+			}
+
+			common.AssertEqual(t, 1, len(rxEvts),
+				"unexpected number of events published")
+			evt := rxEvts[0]
+			common.AssertEqual(t, events.RASEngineStarted, evt.ID, "unexpected event ID")
+			common.AssertEqual(t, uint32(2), evt.Rank, "unexpected event rank")
+			common.AssertTrue(t, evt.ShouldForward(), "expected event to be forwardable")
+
+			var gotTimeline system.StartupTimeline
+			if err := json.Unmarshal([]byte(*evt.GetStrInfo()), &gotTimeline); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(timeline, gotTimeline); diff != "" {
+				t.Fatalf("unexpected timeline (-want, +got):\n%s\n", diff)
+			}
+
+			// the event is sent on to the MS by the event forwarder
+			invoker := &countingInvoker{
+				MockInvoker: control.DefaultMockInvoker(log),
+			}
+			control.NewEventForwarder(invoker, []string{"localhost"}).
+				OnEvent(context.Background(), evt)
+			common.AssertEqual(t, 1, invoker.count, "expected event to be forwarded to the MS")
+		})
+	}
+}
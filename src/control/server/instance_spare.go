@@ -0,0 +1,131 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// isSpare indicates whether the instance has been formatted as a spare and
+// has not yet been activated to replace a failed rank.
+func (ei *EngineInstance) isSpare() bool {
+	sb := ei.getSuperblock()
+
+	return sb != nil && sb.Spare
+}
+
+// isAwaitingSpareActivation indicates whether the instance has prepared its
+// storage and is standing by to replace a failed rank.
+func (ei *EngineInstance) isAwaitingSpareActivation() bool {
+	return ei.waitSpare.Load()
+}
+
+// awaitSpareActivation blocks a spare instance until it has been activated to
+// replace a failed rank, instances that are not spares return immediately.
+func (ei *EngineInstance) awaitSpareActivation(ctx context.Context) error {
+	if !ei.isSpare() {
+		return nil
+	}
+	idx := ei.Index()
+
+	ei.log.Infof("%s instance %d standing by as a spare", build.DataPlaneName, idx)
+
+	ei.waitSpare.SetTrue()
+	defer ei.waitSpare.SetFalse()
+
+	select {
+	case <-ctx.Done():
+		ei.log.Infof("%s instance %d spare not activated: %s", build.DataPlaneName, idx, ctx.Err())
+	case <-ei.spareActivated:
+		ei.log.Infof("%s instance %d spare activated", build.DataPlaneName, idx)
+	}
+
+	return ctx.Err()
+}
+
+// promoteSpare rewrites the superblock of a spare instance so that when
+// started it joins the system as a new rank. The spare keeps its own identity
+// as its storage holds none of the data of the rank it replaces.
+func (ei *EngineInstance) promoteSpare() error {
+	spareSB := ei.getSuperblock()
+	if spareSB == nil {
+		return errors.Errorf("instance %d: nil superblock", ei.Index())
+	}
+
+	sb := *spareSB
+	sb.Rank = nil
+	sb.ValidRank = false
+	sb.Spare = false
+
+	ei.setSuperblock(&sb)
+	if err := ei.WriteSuperblock(); err != nil {
+		ei.setSuperblock(spareSB)
+		return err
+	}
+
+	return nil
+}
+
+// activateSpare releases a spare instance on the same server as the stopped
+// instance holding the given rank so that the spare starts and joins the
+// system as a new rank. The superblock of the failed instance is removed so
+// that it awaits format and cannot rejoin with the rank, the failed and spare
+// instances are returned.
+func (h *EngineHarness) activateSpare(ctx context.Context, rank system.Rank) (*EngineInstance, *EngineInstance, error) {
+	var failed, spare *EngineInstance
+	for _, ei := range h.Instances() {
+		if r, err := ei.GetRank(); err == nil && r.Equals(rank) {
+			failed = ei
+			continue
+		}
+		if spare == nil && ei.isSpare() && ei.isAwaitingSpareActivation() {
+			spare = ei
+		}
+	}
+
+	if failed == nil {
+		return nil, nil, errors.Errorf("rank %d not managed by this server", rank)
+	}
+	if failed.isStarted() {
+		return nil, nil, FaultInstancesNotStopped("activate spare", rank)
+	}
+	if spare == nil {
+		return nil, nil, FaultNoSpareEngine(rank)
+	}
+
+	h.log.Infof("activating spare instance %d to replace rank %d on instance %d",
+		spare.Index(), rank, failed.Index())
+
+	failedSB, spareSB := failed.getSuperblock(), spare.getSuperblock()
+	if err := spare.promoteSpare(); err != nil {
+		return nil, nil, errors.Wrapf(err, "instance %d: promoting spare", spare.Index())
+	}
+	if err := failed.RemoveSuperblock(); err != nil {
+		failed.setSuperblock(failedSB)
+		spare.setSuperblock(spareSB)
+		if rbErr := spare.WriteSuperblock(); rbErr != nil {
+			h.log.Errorf("instance %d: failed to restore spare superblock: %s", spare.Index(), rbErr)
+		}
+		return nil, nil, errors.Wrapf(err, "instance %d: removing superblock", failed.Index())
+	}
+
+	// the failed instance will wait for its storage to be reformatted
+	failed.requestStart(ctx)
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case spare.spareActivated <- true:
+	}
+
+	return failed, spare, nil
+}
@@ -0,0 +1,82 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+func TestServer_Instance_awaitSpareActivation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spare    bool
+		activate bool
+		expErr   error
+	}{
+		"not a spare": {},
+		"spare activated": {
+			spare:    true,
+			activate: true,
+		},
+		"spare not activated": {
+			spare:  true,
+			expErr: context.Canceled,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			cfg := engine.NewConfig().
+				WithSystemName(t.Name()).
+				WithSpare(tc.spare).
+				WithScmClass("ram").
+				WithScmRamdiskSize(1).
+				WithScmMountPoint("mnt")
+			mp := scm.NewMockProvider(log, nil, &scm.MockSysConfig{IsMountedBool: true})
+			ei := NewEngineInstance(log, nil, mp, nil, engine.NewRunner(log, cfg))
+			ei.fsRoot = testDir
+
+			if err := ei.createSuperblock(false); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.spare, ei.isSpare(), "unexpected spare state")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			errCh := make(chan error)
+			go func() {
+				errCh <- ei.awaitSpareActivation(ctx)
+			}()
+
+			if tc.spare {
+				for !ei.isAwaitingSpareActivation() {
+					time.Sleep(time.Millisecond)
+				}
+				if tc.activate {
+					ei.spareActivated <- true
+				} else {
+					cancel()
+				}
+			}
+
+			common.CmpErr(t, tc.expErr, <-errCh)
+			common.AssertFalse(t, ei.isAwaitingSpareActivation(),
+				"expected instance to no longer be awaiting activation")
+		})
+	}
+}
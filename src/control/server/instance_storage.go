@@ -23,12 +23,19 @@ import (
 
 // scmConfig returns the scm configuration assigned to this instance.
 func (ei *EngineInstance) scmConfig() storage.ScmConfig {
-	return ei.runner.GetConfig().Storage.SCM
+	return ei.runner.GetConfig().Storage.SCM()
 }
 
-// bdevConfig returns the block device configuration assigned to this instance.
+// bdevConfig returns the block device configuration assigned to this instance
+// with the devices of all block device tiers combined in tier order.
 func (ei *EngineInstance) bdevConfig() storage.BdevConfig {
-	return ei.runner.GetConfig().Storage.Bdev
+	tiers := ei.runner.GetConfig().Storage.Tiers
+
+	return storage.BdevConfig{
+		Class:      tiers.BdevClass(),
+		DeviceList: tiers.BdevDevices(),
+		AllowZoned: tiers.BdevAllowZoned(),
+	}
 }
 
 // MountScmDevice mounts the configured SCM device (DCPM or ramdisk emulation)
@@ -177,9 +184,10 @@ func (ei *EngineInstance) awaitStorageReady(ctx context.Context, skipMissingSupe
 }
 
 func (ei *EngineInstance) logScmStorage() error {
-	scmMount := path.Dir(ei.superblockPath())
+	scmMount := ei.scmConfig().MountPoint
 
-	if scmMount != ei.scmConfig().MountPoint {
+	if !ei.runner.GetConfig().Storage.ControlMetadata.HasPath() &&
+		path.Dir(ei.superblockPath()) != scmMount {
 		return errors.New("superblock path doesn't match config mountpoint")
 	}
 
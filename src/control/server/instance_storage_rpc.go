@@ -9,6 +9,10 @@ package server
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
@@ -71,49 +75,163 @@ func (ei *EngineInstance) scmFormat(reformat bool) (*ctlpb.ScmMountResult, error
 		ei.log.Errorf("  format of %s failed: %s", scmStr, err)
 		return nil, err
 	}
+
+	// A superblock kept outside of SCM must also be removed so that the
+	// instance is formatted as new.
+	if ei.runner.GetConfig().Storage.ControlMetadata.HasPath() {
+		if err := ei.RemoveSuperblock(); err != nil && !os.IsNotExist(err) {
+			ei.log.Errorf("  removal of superblock %s failed: %s", ei.superblockPath(), err)
+			return nil, err
+		}
+	}
 	ei.log.Infof("Instance %d: finished format of %s", engineIdx, scmStr)
 
 	return ei.newMntRet(nil), nil
 }
 
-func (ei *EngineInstance) bdevFormat(p *bdev.Provider) (results proto.NvmeControllerResults) {
+func (ei *EngineInstance) bdevFormat(p *bdev.Provider, engineClaims map[string]string, fs *formatState, force bool, timing *opTiming) (results proto.NvmeControllerResults) {
 	engineIdx := ei.Index()
 	cfg := ei.bdevConfig()
 	results = make(proto.NvmeControllerResults, 0, len(cfg.DeviceList))
 
+	// Skip devices formatted by a previous request that failed to format
+	// the remaining devices of this instance.
+	var devices []string
+	for _, dev := range cfg.DeviceList {
+		if !fs.bdevFormatted(engineIdx, dev) {
+			devices = append(devices, dev)
+			continue
+		}
+		ret := ei.newCret(dev, nil)
+		ret.State.Info = fmt.Sprintf(msgNvmeFormatDone, engineIdx)
+		results = append(results, ret)
+	}
+
 	// A config with SCM and no block devices is valid.
-	if len(cfg.DeviceList) == 0 {
+	if len(devices) == 0 {
 		return
 	}
 
 	ei.log.Infof("Instance %d: starting format of %s block devices %v",
-		engineIdx, cfg.Class, cfg.DeviceList)
+		engineIdx, cfg.Class, devices)
 
+	started := time.Now()
 	res, err := p.Format(bdev.FormatRequest{
-		Class:      cfg.Class,
-		DeviceList: cfg.DeviceList,
-		MemSize:    cfg.MemSize,
+		Class:        cfg.Class,
+		DeviceList:   devices,
+		MemSize:      ei.runner.GetConfig().Storage.MemSize,
+		Force:        force,
+		EngineClaims: engineClaims,
+		AllowZoned:   cfg.AllowZoned,
+		LbaFormats:   ei.runner.GetConfig().Storage.Tiers.BdevLbaFormats(),
 	})
 	if err != nil {
 		results = append(results, ei.newCret("", err))
 		return
 	}
+	// separate the cost of SPDK initialization from that of the device work
+	if res.InitDuration > 0 {
+		timing.add(fmt.Sprintf("instance %d spdk init", engineIdx), res.InitDuration)
+	}
+	timing.add(fmt.Sprintf("instance %d nvme format", engineIdx), time.Since(started)-res.InitDuration)
 
 	for dev, status := range res.DeviceResponses {
 		// TODO DAOS-5828: passing status.Error directly triggers segfault
 		var err error
 		if status.Error != nil {
 			err = status.Error
+		} else {
+			fs.setBdevFormatted(engineIdx, dev)
 		}
 		results = append(results, ei.newCret(dev, err))
 	}
 
 	ei.log.Infof("Instance %d: finished format of %s block devices %v",
-		engineIdx, cfg.Class, cfg.DeviceList)
+		engineIdx, cfg.Class, devices)
 
 	return
 }
 
+// removeScmMetadata removes the engine metadata held on the SCM tier of a
+// stopped instance. The control plane raft database is retained as it is
+// removed separately by the management service.
+func (ei *EngineInstance) removeScmMetadata() error {
+	mntPoint := ei.scmConfig().MountPoint
+	if mntPoint == "" {
+		return nil
+	}
+	mntPoint = filepath.Join(ei.fsRoot, mntPoint)
+
+	entries, err := ioutil.ReadDir(mntPoint)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "read SCM mount")
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == raftDirName {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(mntPoint, entry.Name())); err != nil {
+			return errors.Wrap(err, "remove SCM metadata")
+		}
+	}
+	ei.log.Debugf("instance %d: removed SCM metadata from %s", ei.Index(), mntPoint)
+
+	return nil
+}
+
+// trimBdevs deallocates the block devices assigned to a stopped instance by
+// formatting them.
+func (ei *EngineInstance) trimBdevs(p *bdev.Provider) error {
+	cfg := ei.bdevConfig()
+	if len(cfg.DeviceList) == 0 {
+		return nil
+	}
+
+	ei.log.Infof("Instance %d: deallocating %s block devices %v",
+		ei.Index(), cfg.Class, cfg.DeviceList)
+
+	res, err := p.Format(bdev.FormatRequest{
+		Class:      cfg.Class,
+		DeviceList: cfg.DeviceList,
+		MemSize:    ei.runner.GetConfig().Storage.MemSize,
+		AllowZoned: cfg.AllowZoned,
+	})
+	if err != nil {
+		return errors.Wrap(err, "trim NVMe")
+	}
+
+	for dev, status := range res.DeviceResponses {
+		if status.Error != nil {
+			return errors.Wrapf(status.Error, "trim NVMe %s", dev)
+		}
+	}
+
+	return nil
+}
+
+// bdevClaims returns the block devices claimed by this instance if it is
+// running, keyed by device identifier with a description of the claimant.
+func (ei *EngineInstance) bdevClaims() map[string]string {
+	claims := make(map[string]string)
+	if !ei.isStarted() {
+		return claims
+	}
+
+	claimant := fmt.Sprintf("running %s instance %d", build.DataPlaneName, ei.Index())
+	if rank, err := ei.GetRank(); err == nil {
+		claimant = fmt.Sprintf("%s (rank %d)", claimant, rank)
+	}
+	for _, dev := range ei.bdevConfig().DeviceList {
+		claims[dev] = claimant
+	}
+
+	return claims
+}
+
 // StorageFormatSCM performs format on SCM and identifies if superblock needs
 // writing.
 func (ei *EngineInstance) StorageFormatSCM(ctx context.Context, reformat bool) (mResult *ctlpb.ScmMountResult) {
@@ -165,7 +283,13 @@ func (ei *EngineInstance) StorageFormatSCM(ctx context.Context, reformat bool) (
 }
 
 // StorageFormatNVMe performs format on NVMe if superblock needs writing.
-func (ei *EngineInstance) StorageFormatNVMe(bdevProvider *bdev.Provider) (cResults proto.NvmeControllerResults) {
+//
+// Unless force is set, devices present in engineClaims (i.e. claimed by
+// running engines) or locked by another live process will not be formatted.
+// Devices recorded in fs as formatted by a previous request are skipped and
+// devices formatted successfully are recorded. The time taken is added to
+// timing, if supplied.
+func (ei *EngineInstance) StorageFormatNVMe(bdevProvider *bdev.Provider, engineClaims map[string]string, fs *formatState, force bool, timing *opTiming) (cResults proto.NvmeControllerResults) {
 	ei.log.Infof("Formatting nvme storage for %s instance %d", build.DataPlaneName, ei.Index())
 
 	// If no superblock exists, format NVMe and populate response with results.
@@ -177,7 +301,7 @@ func (ei *EngineInstance) StorageFormatNVMe(bdevProvider *bdev.Provider) (cResul
 	}
 
 	if needsSuperblock {
-		cResults = ei.bdevFormat(bdevProvider)
+		cResults = ei.bdevFormat(bdevProvider, engineClaims, fs, force, timing)
 	}
 
 	return
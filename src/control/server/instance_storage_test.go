@@ -34,20 +34,20 @@ func TestIOEngineInstance_MountScmDevice(t *testing.T) {
 		goodMountPoint = testDir + "/mnt/daos"
 		ramCfg         = &engine.Config{
 			Storage: engine.StorageConfig{
-				SCM: storage.ScmConfig{
+				Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 					MountPoint:  goodMountPoint,
 					Class:       storage.ScmClassRAM,
 					RamdiskSize: 1,
-				},
+				}}},
 			},
 		}
 		dcpmCfg = &engine.Config{
 			Storage: engine.StorageConfig{
-				SCM: storage.ScmConfig{
+				Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 					MountPoint: goodMountPoint,
 					Class:      storage.ScmClassDCPM,
 					DeviceList: []string{"/dev/foo"},
-				},
+				}}},
 			},
 		}
 	)
@@ -94,10 +94,10 @@ func TestIOEngineInstance_MountScmDevice(t *testing.T) {
 		"mount dcpm fails (missing device)": {
 			engineCfg: &engine.Config{
 				Storage: engine.StorageConfig{
-					SCM: storage.ScmConfig{
+					Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 						MountPoint: goodMountPoint,
 						Class:      storage.ScmClassDCPM,
-					},
+					}}},
 				},
 			},
 			expErr: scm.FaultFormatInvalidDeviceCount,
@@ -128,20 +128,20 @@ func TestEngineInstance_NeedsScmFormat(t *testing.T) {
 	var (
 		ramCfg = &engine.Config{
 			Storage: engine.StorageConfig{
-				SCM: storage.ScmConfig{
+				Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 					MountPoint:  goodMountPoint,
 					Class:       storage.ScmClassRAM,
 					RamdiskSize: 1,
-				},
+				}}},
 			},
 		}
 		dcpmCfg = &engine.Config{
 			Storage: engine.StorageConfig{
-				SCM: storage.ScmConfig{
+				Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 					MountPoint: goodMountPoint,
 					Class:      storage.ScmClassDCPM,
 					DeviceList: []string{"/dev/foo"},
-				},
+				}}},
 			},
 		}
 	)
@@ -220,10 +220,10 @@ func TestEngineInstance_NeedsScmFormat(t *testing.T) {
 		"check dcpm fails (missing device)": {
 			engineCfg: &engine.Config{
 				Storage: engine.StorageConfig{
-					SCM: storage.ScmConfig{
+					Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 						MountPoint: goodMountPoint,
 						Class:      storage.ScmClassDCPM,
-					},
+					}}},
 				},
 			},
 			expErr: scm.FaultFormatInvalidDeviceCount,
@@ -277,11 +277,11 @@ func TestIOEngineInstance_awaitStorageReady(t *testing.T) {
 	errStarted := errors.New("already started")
 	dcpmCfg := &engine.Config{
 		Storage: engine.StorageConfig{
-			SCM: storage.ScmConfig{
+			Tiers: storage.TierConfigs{{Scm: storage.ScmConfig{
 				MountPoint: "/mnt/test",
 				Class:      storage.ScmClassDCPM,
 				DeviceList: []string{"/dev/foo"},
-			},
+			}}},
 		},
 	}
 
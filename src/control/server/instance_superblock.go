@@ -34,6 +34,7 @@ type Superblock struct {
 	URI             string
 	ValidRank       bool
 	HostFaultDomain string
+	Spare           bool
 }
 
 // TODO: Marshal/Unmarshal using a binary representation?
@@ -52,7 +53,13 @@ func (sb *Superblock) Unmarshal(raw []byte) error {
 	return yaml.Unmarshal(raw, sb)
 }
 
+// superblockPath returns the path of the instance superblock, which is held
+// in the control metadata directory if set, or on the SCM tier otherwise.
 func (ei *EngineInstance) superblockPath() string {
+	if cm := ei.runner.GetConfig().Storage.ControlMetadata; cm.HasPath() {
+		return filepath.Join(ei.fsRoot, cm.EngineDirectory(uint(ei.Index())), "superblock")
+	}
+
 	scmConfig := ei.scmConfig()
 	storagePath := scmConfig.MountPoint
 	if storagePath == "" {
@@ -138,6 +145,7 @@ func (ei *EngineInstance) createSuperblock(recreate bool) error {
 		Version: superblockVersion,
 		UUID:    u.String(),
 		System:  systemName,
+		Spare:   cfg.Spare,
 	}
 
 	if ei.hostFaultDomain != nil {
@@ -160,6 +168,10 @@ func (ei *EngineInstance) createSuperblock(recreate bool) error {
 // WriteSuperblock writes the instance's superblock
 // to storage.
 func (ei *EngineInstance) WriteSuperblock() error {
+	if err := os.MkdirAll(filepath.Dir(ei.superblockPath()), 0700); err != nil {
+		return errors.Wrap(err, "failed to create superblock directory")
+	}
+
 	return WriteSuperblock(ei.superblockPath(), ei.getSuperblock())
 }
 
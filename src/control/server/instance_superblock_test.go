@@ -15,6 +15,7 @@ import (
 	. "github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
 	"github.com/daos-stack/daos/src/control/server/storage/scm"
 	"github.com/daos-stack/daos/src/control/system"
 )
@@ -80,3 +81,50 @@ func TestServer_Instance_createSuperblock(t *testing.T) {
 		}
 	}
 }
+
+func TestServer_Instance_persistentSuperblock(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := CreateTestDir(t)
+	defer cleanup()
+
+	cfg := engine.NewConfig().
+		WithSystemName(t.Name()).
+		WithScmClass("ram").
+		WithScmRamdiskSize(1).
+		WithScmMountPoint("mnt")
+	cfg.Storage.ControlMetadata = storage.ControlMetadata{Path: "/ctl"}
+	r := engine.NewRunner(log, cfg)
+	mp := scm.NewMockProvider(log, nil, &scm.MockSysConfig{})
+	ei := NewEngineInstance(log, nil, mp, nil, r)
+	ei.fsRoot = testDir
+
+	expPath := filepath.Join(testDir, "ctl", "daos_control", "engine0", "superblock")
+	AssertEqual(t, expPath, ei.superblockPath(), "unexpected superblock path")
+
+	needsFormat, err := ei.NeedsScmFormat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertTrue(t, needsFormat, "expected format to be required without superblock")
+
+	if err := ei.createSuperblock(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(expPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// the ramdisk is unmounted and its contents lost on restart so the
+	// superblock having been kept must not mark the instance as formatted
+	mp = scm.NewMockProvider(log, nil, &scm.MockSysConfig{})
+	ei = NewEngineInstance(log, nil, mp, nil, r)
+	ei.fsRoot = testDir
+
+	needsFormat, err = ei.NeedsScmFormat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertTrue(t, needsFormat, "expected format to be required with superblock")
+}
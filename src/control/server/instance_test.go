@@ -7,6 +7,7 @@
 package server
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,7 +18,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	srvpb "github.com/daos-stack/daos/src/control/common/proto/srv"
+	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/logging"
 	"github.com/daos-stack/daos/src/control/server/engine"
 	"github.com/daos-stack/daos/src/control/system"
@@ -158,3 +161,56 @@ func TestServer_Instance_updateFaultDomainInSuperblock(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_Instance_handleReady_FormationBarrier(t *testing.T) {
+	for name, tc := range map[string]struct {
+		validRank bool
+		expWait   bool
+	}{
+		"initial join waits on barrier": {
+			expWait: true,
+		},
+		"restarted rank skips barrier": {
+			validRank: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanupDir := common.CreateTestDir(t)
+			defer cleanupDir()
+
+			var waited bool
+			inst := getTestEngineInstance(log).
+				WithHostFaultDomain(system.MustCreateFaultDomain("host")).
+				WithFormationBarrier(func(context.Context) error {
+					waited = true
+					return nil
+				})
+			inst.fsRoot = testDir
+			inst._superblock = &Superblock{
+				HostFaultDomain: "/host",
+				Rank:            system.NewRankPtr(1),
+				ValidRank:       tc.validRank,
+			}
+			if err := os.MkdirAll(filepath.Dir(inst.superblockPath()), 0755); err != nil {
+				t.Fatal(err)
+			}
+			inst.joinSystem = func(context.Context, *control.SystemJoinReq) (*control.SystemJoinResp, error) {
+				return &control.SystemJoinResp{Rank: 1, State: system.MemberStateJoined}, nil
+			}
+			cfg := new(mockDrpcClientConfig)
+			cfg.setSendMsgResponseList(t,
+				&mockDrpcResponse{Message: &mgmtpb.DaosResp{}},
+				&mockDrpcResponse{Message: &mgmtpb.DaosResp{}},
+			)
+			inst.setDrpcClient(newMockDrpcClient(cfg))
+
+			if err := inst.handleReady(context.TODO(), &srvpb.NotifyReadyReq{}); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expWait, waited, "unexpected formation barrier wait")
+		})
+	}
+}
@@ -8,10 +8,16 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	"github.com/daos-stack/daos/src/control/common"
 	"github.com/daos-stack/daos/src/control/drpc"
@@ -64,3 +70,64 @@ func TestServer_unaryStatusInterceptor(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_unaryAccessInterceptor(t *testing.T) {
+	peerCtx := func(cn string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{
+						{{Subject: pkix.Name{CommonName: cn}}},
+					},
+				},
+			},
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		ctx    context.Context
+		method string
+		expErr error
+	}{
+		"no peer": {
+			ctx:    context.Background(),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+			expErr: errors.New("no peer information"),
+		},
+		"admin system query": {
+			ctx:    peerCtx("admin"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+		},
+		"server join": {
+			ctx:    peerCtx("server"),
+			method: "/mgmt.MgmtSvc/Join",
+		},
+		"server system query for formation barrier": {
+			ctx:    peerCtx("server"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+		},
+		"server pool create": {
+			ctx:    peerCtx("server"),
+			method: "/mgmt.MgmtSvc/PoolCreate",
+			expErr: errors.New("server does not have permission"),
+		},
+		"agent system query": {
+			ctx:    peerCtx("agent"),
+			method: "/mgmt.MgmtSvc/SystemQuery",
+			expErr: errors.New("agent does not have permission"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var called bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			}
+
+			_, gotErr := unaryAccessInterceptor(tc.ctx, nil,
+				&grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			common.CmpErr(t, tc.expErr, gotErr)
+			common.AssertEqual(t, tc.expErr == nil, called, "unexpected handler call")
+		})
+	}
+}
@@ -0,0 +1,232 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+const (
+	inventorySchemaVersion = 1
+	inventoryExportTimeout = 10 * time.Second
+)
+
+type (
+	// inventoryScmModule describes an SCM module in an exported inventory.
+	inventoryScmModule struct {
+		SocketID         uint32 `json:"socket_id"`
+		ControllerID     uint32 `json:"controller_id"`
+		ChannelID        uint32 `json:"channel_id"`
+		ChannelPosition  uint32 `json:"channel_position"`
+		PhysicalID       uint32 `json:"physical_id"`
+		Capacity         uint64 `json:"capacity"`
+		UID              string `json:"uid"`
+		PartNumber       string `json:"part_number"`
+		FirmwareRevision string `json:"firmware_revision"`
+	}
+
+	// inventoryScmNamespace describes an SCM namespace in an exported
+	// inventory.
+	inventoryScmNamespace struct {
+		UUID        string `json:"uuid"`
+		BlockDevice string `json:"blockdev"`
+		NumaNode    uint32 `json:"numa_node"`
+		Size        uint64 `json:"size"`
+	}
+
+	// inventoryNvmeController describes an NVMe SSD in an exported
+	// inventory.
+	inventoryNvmeController struct {
+		PciAddr    string                   `json:"pci_addr"`
+		Model      string                   `json:"model"`
+		Serial     string                   `json:"serial"`
+		FwRev      string                   `json:"fw_rev"`
+		SocketID   int32                    `json:"socket_id"`
+		Namespaces []*storage.NvmeNamespace `json:"namespaces"`
+	}
+
+	// hostInventory is the document posted to the inventory webhook,
+	// the schema is described in the administration guide.
+	hostInventory struct {
+		Version         int                        `json:"version"`
+		System          string                     `json:"system"`
+		Hostname        string                     `json:"hostname"`
+		Timestamp       string                     `json:"timestamp"`
+		ScmModules      []*inventoryScmModule      `json:"scm_modules"`
+		ScmNamespaces   []*inventoryScmNamespace   `json:"scm_namespaces"`
+		NvmeControllers []*inventoryNvmeController `json:"nvme_controllers"`
+	}
+
+	// inventoryPostFn posts an inventory document to the webhook URL.
+	inventoryPostFn func(ctx context.Context, url string, body []byte) error
+)
+
+// inventoryExporter posts the local hardware inventory to an external asset
+// database whenever a storage scan reports a change in the inventory.
+type inventoryExporter struct {
+	sync.Mutex
+	log      logging.Logger
+	url      string
+	system   string
+	hostname string
+	post     inventoryPostFn
+	scm      *scm.ScanResponse
+	nvme     *bdev.ScanResponse
+	last     *hostInventory
+}
+
+// newInventoryExporter returns an inventoryExporter for the webhook specified
+// in the supplied config, or nil if no webhook is configured.
+func newInventoryExporter(log logging.Logger, cfg *config.Server) *inventoryExporter {
+	if cfg == nil || cfg.InventoryWebhook == "" {
+		return nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Errorf("inventory export: %s", err)
+	}
+
+	return &inventoryExporter{
+		log:      log,
+		url:      cfg.InventoryWebhook,
+		system:   cfg.SystemName,
+		hostname: hostname,
+		post:     postInventory,
+	}
+}
+
+func postInventory(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, inventoryExportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (ie *inventoryExporter) inventory() *hostInventory {
+	inv := &hostInventory{
+		Version:         inventorySchemaVersion,
+		System:          ie.system,
+		Hostname:        ie.hostname,
+		ScmModules:      []*inventoryScmModule{},
+		ScmNamespaces:   []*inventoryScmNamespace{},
+		NvmeControllers: []*inventoryNvmeController{},
+	}
+
+	for _, m := range ie.scm.Modules {
+		inv.ScmModules = append(inv.ScmModules, &inventoryScmModule{
+			SocketID:         m.SocketID,
+			ControllerID:     m.ControllerID,
+			ChannelID:        m.ChannelID,
+			ChannelPosition:  m.ChannelPosition,
+			PhysicalID:       m.PhysicalID,
+			Capacity:         m.Capacity,
+			UID:              m.UID,
+			PartNumber:       m.PartNumber,
+			FirmwareRevision: m.FirmwareRevision,
+		})
+	}
+	for _, ns := range ie.scm.Namespaces {
+		inv.ScmNamespaces = append(inv.ScmNamespaces, &inventoryScmNamespace{
+			UUID:        ns.UUID,
+			BlockDevice: ns.BlockDevice,
+			NumaNode:    ns.NumaNode,
+			Size:        ns.Size,
+		})
+	}
+	for _, c := range ie.nvme.Controllers {
+		inv.NvmeControllers = append(inv.NvmeControllers, &inventoryNvmeController{
+			PciAddr:    c.PciAddr,
+			Model:      c.Model,
+			Serial:     c.Serial,
+			FwRev:      c.FwRev,
+			SocketID:   c.SocketID,
+			Namespaces: c.Namespaces,
+		})
+	}
+
+	return inv
+}
+
+// update records the results of a storage scan and posts the resulting
+// inventory if it differs from that last exported. Either scan response may
+// be nil if only one class of storage was scanned, in which case the previous
+// result is retained.
+func (ie *inventoryExporter) update(ctx context.Context, scmResp *scm.ScanResponse, nvmeResp *bdev.ScanResponse) {
+	if ie == nil {
+		return
+	}
+
+	ie.Lock()
+	defer ie.Unlock()
+
+	if scmResp != nil {
+		ie.scm = scmResp
+	}
+	if nvmeResp != nil {
+		ie.nvme = nvmeResp
+	}
+
+	// wait until both classes of storage have been scanned to avoid
+	// exporting a partial inventory
+	if ie.scm == nil || ie.nvme == nil {
+		return
+	}
+
+	inv := ie.inventory()
+	if ie.last != nil && reflect.DeepEqual(ie.last, inv) {
+		return
+	}
+	inv.Timestamp = common.FormatTime(time.Now())
+
+	body, err := json.Marshal(inv)
+	if err != nil {
+		ie.log.Errorf("inventory export: %s", err)
+		return
+	}
+
+	if err := ie.post(ctx, ie.url, body); err != nil {
+		ie.log.Errorf("inventory export to %s: %s", ie.url, err)
+		return
+	}
+	ie.log.Debugf("inventory exported to %s", ie.url)
+
+	inv.Timestamp = ""
+	ie.last = inv
+}
@@ -0,0 +1,226 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+	"github.com/daos-stack/daos/src/control/server/storage/scm"
+)
+
+func TestServer_inventoryExporter(t *testing.T) {
+	scmResp := &scm.ScanResponse{
+		Modules:    storage.ScmModules{storage.MockScmModule(1)},
+		Namespaces: storage.ScmNamespaces{storage.MockScmNamespace(1)},
+	}
+	nc := storage.MockNvmeController(1)
+	nvmeResp := &bdev.ScanResponse{
+		Controllers: storage.NvmeControllers{nc},
+	}
+	changedNvmeResp := &bdev.ScanResponse{
+		Controllers: storage.NvmeControllers{nc, storage.MockNvmeController(2)},
+	}
+	// health statistics are not exported so don't constitute a change
+	healthNc := *nc
+	healthNc.HealthStats = storage.MockNvmeHealth(5)
+	healthNvmeResp := &bdev.ScanResponse{
+		Controllers: storage.NvmeControllers{&healthNc},
+	}
+
+	type scan struct {
+		scm  *scm.ScanResponse
+		nvme *bdev.ScanResponse
+	}
+
+	for name, tc := range map[string]struct {
+		status    int
+		scans     []scan
+		expPosts  int
+		expNvmeNr int
+	}{
+		"partial inventory not exported": {
+			scans: []scan{{nvme: nvmeResp}},
+		},
+		"exported when complete": {
+			scans:     []scan{{nvme: nvmeResp}, {scm: scmResp}},
+			expPosts:  1,
+			expNvmeNr: 1,
+		},
+		"unchanged inventory not re-exported": {
+			scans: []scan{
+				{scm: scmResp, nvme: nvmeResp},
+				{nvme: nvmeResp},
+				{nvme: healthNvmeResp},
+			},
+			expPosts:  1,
+			expNvmeNr: 1,
+		},
+		"changed inventory re-exported": {
+			scans: []scan{
+				{scm: scmResp, nvme: nvmeResp},
+				{nvme: changedNvmeResp},
+			},
+			expPosts:  2,
+			expNvmeNr: 2,
+		},
+		"failed export retried on next scan": {
+			status: http.StatusInternalServerError,
+			scans: []scan{
+				{scm: scmResp, nvme: nvmeResp},
+				{nvme: nvmeResp},
+			},
+			expPosts:  2,
+			expNvmeNr: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var gotPosts int
+			var gotInv hostInventory
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPosts++
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("unexpected content type %q", ct)
+				}
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := json.Unmarshal(body, &gotInv); err != nil {
+					t.Fatal(err)
+				}
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+			}))
+			defer srv.Close()
+
+			cfg := config.DefaultServer().WithInventoryWebhook(srv.URL)
+			ie := newInventoryExporter(log, cfg)
+			for _, s := range tc.scans {
+				ie.update(context.Background(), s.scm, s.nvme)
+			}
+
+			common.AssertEqual(t, tc.expPosts, gotPosts, "unexpected number of exports")
+			if tc.expPosts == 0 {
+				return
+			}
+
+			common.AssertEqual(t, inventorySchemaVersion, gotInv.Version, "")
+			common.AssertEqual(t, cfg.SystemName, gotInv.System, "")
+			if gotInv.Timestamp == "" {
+				t.Fatal("expected timestamp in exported inventory")
+			}
+			common.AssertEqual(t, tc.expNvmeNr, len(gotInv.NvmeControllers), "")
+
+			sm := storage.MockScmModule(1)
+			expModules := []*inventoryScmModule{
+				{
+					SocketID:         sm.SocketID,
+					ControllerID:     sm.ControllerID,
+					ChannelID:        sm.ChannelID,
+					ChannelPosition:  sm.ChannelPosition,
+					PhysicalID:       sm.PhysicalID,
+					Capacity:         sm.Capacity,
+					UID:              sm.UID,
+					PartNumber:       sm.PartNumber,
+					FirmwareRevision: sm.FirmwareRevision,
+				},
+			}
+			if diff := cmp.Diff(expModules, gotInv.ScmModules); diff != "" {
+				t.Fatalf("unexpected scm modules (-want, +got):\n%s\n", diff)
+			}
+
+			expCtrlr := &inventoryNvmeController{
+				PciAddr:    nc.PciAddr,
+				Model:      nc.Model,
+				Serial:     nc.Serial,
+				FwRev:      nc.FwRev,
+				SocketID:   nc.SocketID,
+				Namespaces: nc.Namespaces,
+			}
+			if diff := cmp.Diff(expCtrlr, gotInv.NvmeControllers[0]); diff != "" {
+				t.Fatalf("unexpected nvme controller (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_newInventoryExporter(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	if ie := newInventoryExporter(log, config.DefaultServer()); ie != nil {
+		t.Fatal("expected nil exporter without webhook")
+	}
+
+	// calls on nil exporter are harmless
+	var ie *inventoryExporter
+	ie.update(context.Background(), nil, nil)
+}
+
+func TestServer_StorageControlService_inventoryUpdate(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	ctrlrs := storage.NvmeControllers{storage.MockNvmeController(1), storage.MockNvmeController(2)}
+	scs := NewStorageControlService(log,
+		bdev.NewMockProvider(log, &bdev.MockBackendConfig{
+			ScanRes: &bdev.ScanResponse{Controllers: ctrlrs},
+		}),
+		scm.NewMockProvider(log, &scm.MockBackendConfig{
+			DiscoverRes: storage.ScmModules{storage.MockScmModule(1)},
+		}, nil),
+		nil)
+	scs.inventory = newInventoryExporter(log, config.DefaultServer().WithInventoryWebhook("mock"))
+
+	var gotNvmeNr []int
+	scs.inventory.post = func(_ context.Context, _ string, body []byte) error {
+		var inv hostInventory
+		if err := json.Unmarshal(body, &inv); err != nil {
+			t.Fatal(err)
+		}
+		gotNvmeNr = append(gotNvmeNr, len(inv.NvmeControllers))
+		return nil
+	}
+
+	if _, err := scs.ScmScan(scm.ScanRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	// filtered and kernel-bound scans don't update the inventory
+	if _, err := scs.NvmeScan(bdev.ScanRequest{DeviceList: []string{ctrlrs[0].PciAddr}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scs.NvmeScan(bdev.ScanRequest{KernelBound: true}); err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 0, len(gotNvmeNr), "unexpected export before unfiltered nvme scan")
+
+	if _, err := scs.NvmeScan(bdev.ScanRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scs.NvmeScan(bdev.ScanRequest{DeviceList: []string{ctrlrs[0].PciAddr}}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{len(ctrlrs)}, gotNvmeNr); diff != "" {
+		t.Fatalf("unexpected exports (-want, +got):\n%s\n", diff)
+	}
+}
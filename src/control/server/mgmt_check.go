@@ -0,0 +1,497 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	checkStatusRunning   = "running"
+	checkStatusCompleted = "completed"
+	checkStatusFailed    = "failed"
+
+	findingStatusPending  = "pending"
+	findingStatusRepaired = "repaired"
+	findingStatusIgnored  = "ignored"
+	findingStatusFailed   = "failed"
+
+	// checkClassPoolStaleState indicates that a pool has been left in a
+	// transitional state in the MS by an interrupted create or destroy.
+	checkClassPoolStaleState = "pool-stale-state"
+	// checkClassPoolSvcMissing indicates that the MS holds an entry for a
+	// pool whose pool service reports that the pool does not exist.
+	checkClassPoolSvcMissing = "pool-svc-missing"
+	// checkClassPoolSvcUnavailable indicates that the pool service of a
+	// pool could not be queried.
+	checkClassPoolSvcUnavailable = "pool-svc-unavailable"
+	// checkClassPoolSvcReplicas indicates that the pool service leader is
+	// not one of the pool service replicas recorded in the MS.
+	checkClassPoolSvcReplicas = "pool-svc-replicas"
+	// checkClassContMetadata indicates that the container metadata held by
+	// a pool service could not be read.
+	checkClassContMetadata = "cont-metadata"
+
+	checkActionRemovePool  = "remove-pool-entry"
+	checkActionDestroyPool = "destroy-pool"
+	checkActionAddReplica  = "add-svc-replica"
+	checkActionIgnore      = "ignore"
+
+	// checkStaleCreateAge is the age after which a pool entry left in the
+	// creating state by a create that is no longer in progress is
+	// considered stale.
+	checkStaleCreateAge = control.PoolCreateTimeout
+)
+
+// poolCreates tracks the pool creates in progress on the MS leader so that
+// the system check does not report the entries of pools still being created.
+type poolCreates struct {
+	sync.Mutex
+	inFlight map[uuid.UUID]struct{}
+}
+
+func newPoolCreates() *poolCreates {
+	return &poolCreates{inFlight: make(map[uuid.UUID]struct{})}
+}
+
+func (pc *poolCreates) start(id uuid.UUID) {
+	pc.Lock()
+	defer pc.Unlock()
+	pc.inFlight[id] = struct{}{}
+}
+
+func (pc *poolCreates) finish(id uuid.UUID) {
+	pc.Lock()
+	defer pc.Unlock()
+	delete(pc.inFlight, id)
+}
+
+func (pc *poolCreates) active(id uuid.UUID) bool {
+	pc.Lock()
+	defer pc.Unlock()
+	_, found := pc.inFlight[id]
+	return found
+}
+
+// checkFinding holds an inconsistency found by a check run along with the
+// details required to repair it.
+type checkFinding struct {
+	pb     *mgmtpb.CheckFinding
+	poolID uuid.UUID
+	leader system.Rank // pool service leader reported by the pool service
+}
+
+// systemCheck holds the state of the most recent check of the consistency of
+// the system metadata held by the MS, pool services and container services.
+// The state is held in memory on the MS leader as findings are only valid
+// for as long as the metadata they were derived from remains unchanged.
+type systemCheck struct {
+	sync.RWMutex
+	runID    uint64
+	status   string
+	checked  int
+	total    int
+	started  time.Time
+	finished time.Time
+	findings []*checkFinding
+	err      error
+}
+
+func newSystemCheck() *systemCheck {
+	return &systemCheck{}
+}
+
+// start begins a new check run, discarding the findings of the previous run.
+func (sc *systemCheck) start(total int, now time.Time) (uint64, error) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	if sc.status == checkStatusRunning {
+		return 0, FaultCheckRunning(sc.runID)
+	}
+
+	sc.runID++
+	sc.status = checkStatusRunning
+	sc.checked = 0
+	sc.total = total
+	sc.started = now
+	sc.finished = time.Time{}
+	sc.findings = nil
+	sc.err = nil
+
+	return sc.runID, nil
+}
+
+func (sc *systemCheck) addFinding(cf *checkFinding) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	cf.pb.Id = uint64(len(sc.findings) + 1)
+	cf.pb.Status = findingStatusPending
+	sc.findings = append(sc.findings, cf)
+}
+
+func (sc *systemCheck) poolChecked() {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.checked++
+}
+
+func (sc *systemCheck) finish(err error, now time.Time) {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.status = checkStatusCompleted
+	if err != nil {
+		sc.status = checkStatusFailed
+		sc.err = err
+	}
+	sc.finished = now
+}
+
+// finding returns the finding with the given ID from the most recent run. The
+// caller must hold the lock.
+func (sc *systemCheck) finding(id uint64) (*checkFinding, error) {
+	if sc.runID == 0 {
+		return nil, errors.New("no system check has been run")
+	}
+	if sc.status == checkStatusRunning {
+		return nil, FaultCheckRunning(sc.runID)
+	}
+	if id == 0 || id > uint64(len(sc.findings)) {
+		return nil, errors.Errorf("check %d has no finding %d", sc.runID, id)
+	}
+
+	return sc.findings[id-1], nil
+}
+
+func (sc *systemCheck) toPB() *mgmtpb.CheckQueryResp {
+	sc.RLock()
+	defer sc.RUnlock()
+
+	resp := &mgmtpb.CheckQueryResp{
+		RunId:        sc.runID,
+		Status:       sc.status,
+		PoolsChecked: uint32(sc.checked),
+		PoolsTotal:   uint32(sc.total),
+	}
+	if !sc.started.IsZero() {
+		resp.Started = sc.started.Format(time.RFC3339)
+	}
+	if !sc.finished.IsZero() {
+		resp.Finished = sc.finished.Format(time.RFC3339)
+	}
+	if sc.err != nil {
+		resp.Error = sc.err.Error()
+	}
+	for _, cf := range sc.findings {
+		resp.Findings = append(resp.Findings, proto.Clone(cf.pb).(*mgmtpb.CheckFinding))
+	}
+
+	return resp
+}
+
+// CheckStart implements the method defined for the Management Service.
+//
+// Start a check of the consistency of the pool entries in the system database
+// against the pool and container services. The check runs in the background
+// and its progress and findings are retrieved with CheckQuery.
+func (svc *mgmtSvc) CheckStart(ctx context.Context, req *mgmtpb.CheckStartReq) (*mgmtpb.CheckStartResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received CheckStart RPC: %+v", req)
+
+	pools, err := svc.checkPoolList(req.GetPools())
+	if err != nil {
+		return nil, err
+	}
+
+	runID, err := svc.check.start(len(pools), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	svc.log.Infof("starting system check %d of %d pools", runID, len(pools))
+
+	// The check outlives the request, so it is not bound to its context.
+	go svc.runCheck(context.Background(), pools)
+
+	return &mgmtpb.CheckStartResp{RunId: runID}, nil
+}
+
+// checkPoolList returns the pool entries to be checked, in UUID order.
+func (svc *mgmtSvc) checkPoolList(ids []string) ([]*system.PoolService, error) {
+	if len(ids) == 0 {
+		psList, err := svc.sysdb.PoolServiceList()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(psList, func(i, j int) bool {
+			return psList[i].PoolUUID.String() < psList[j].PoolUUID.String()
+		})
+		return psList, nil
+	}
+
+	psList := make([]*system.PoolService, 0, len(ids))
+	for _, id := range ids {
+		var ps *system.PoolService
+		poolUUID, err := uuid.Parse(id)
+		if err == nil {
+			ps, err = svc.sysdb.FindPoolServiceByUUID(poolUUID)
+		} else {
+			ps, err = svc.sysdb.FindPoolServiceByLabel(id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		psList = append(psList, ps)
+	}
+
+	return psList, nil
+}
+
+// runCheck checks each pool in turn, recording any inconsistencies found.
+// The check fails if the MS leadership is lost before it completes.
+func (svc *mgmtSvc) runCheck(ctx context.Context, pools []*system.PoolService) {
+	var err error
+	for _, ps := range pools {
+		if err = svc.sysdb.CheckLeader(); err != nil {
+			break
+		}
+
+		if cf := svc.checkPool(ctx, ps); cf != nil {
+			svc.log.Infof("system check: pool %s: %s", ps.PoolUUID, cf.pb.Description)
+			svc.check.addFinding(cf)
+		}
+		svc.check.poolChecked()
+	}
+
+	if err != nil {
+		svc.log.Errorf("system check failed: %s", err)
+	}
+	svc.check.finish(err, time.Now())
+}
+
+// newCheckFinding returns a finding for the pool. The first repair action is
+// suggested and the finding may always be ignored.
+func newCheckFinding(ps *system.PoolService, class, desc string, actions ...string) *checkFinding {
+	actions = append(actions, checkActionIgnore)
+	return &checkFinding{
+		pb: &mgmtpb.CheckFinding{
+			Class:       class,
+			PoolUuid:    ps.PoolUUID.String(),
+			PoolLabel:   ps.PoolLabel,
+			Description: desc,
+			Actions:     actions,
+			Suggested:   actions[0],
+		},
+		poolID: ps.PoolUUID,
+	}
+}
+
+// checkPool compares the MS entry for a pool with the state reported by its
+// pool service and returns the first inconsistency found, if any.
+func (svc *mgmtSvc) checkPool(ctx context.Context, ps *system.PoolService) *checkFinding {
+	switch ps.State {
+	case system.PoolServiceStateCreating:
+		// Entries without a creation time predate its recording and
+		// are treated as old.
+		if svc.poolCreates.active(ps.PoolUUID) || time.Since(ps.CreatedAt) < checkStaleCreateAge {
+			return nil
+		}
+		fallthrough
+	case system.PoolServiceStateDestroying:
+		return newCheckFinding(ps, checkClassPoolStaleState,
+			fmt.Sprintf("pool entry in the MS has been left in the %s state", ps.State),
+			checkActionDestroyPool)
+	case system.PoolServiceStateReady:
+	default:
+		return nil
+	}
+
+	qResp, err := svc.PoolQuery(ctx, &mgmtpb.PoolQueryReq{
+		Sys:  svc.sysdb.SystemName(),
+		Uuid: ps.PoolUUID.String(),
+	})
+	if err != nil {
+		return newCheckFinding(ps, checkClassPoolSvcUnavailable,
+			fmt.Sprintf("pool service could not be queried: %s", err))
+	}
+	switch drpc.DaosStatus(qResp.Status) {
+	case drpc.DaosSuccess:
+	case drpc.DaosNonexistant:
+		return newCheckFinding(ps, checkClassPoolSvcMissing,
+			"pool entry in the MS has no corresponding pool service",
+			checkActionRemovePool)
+	default:
+		return newCheckFinding(ps, checkClassPoolSvcUnavailable,
+			fmt.Sprintf("pool service could not be queried: %s", drpc.DaosStatus(qResp.Status)))
+	}
+
+	leader := system.Rank(qResp.Leader)
+	if !leader.InList(ps.Replicas) {
+		cf := newCheckFinding(ps, checkClassPoolSvcReplicas,
+			fmt.Sprintf("pool service leader rank %d is not a pool service replica in the MS (%v)",
+				leader, ps.Replicas),
+			checkActionAddReplica)
+		cf.leader = leader
+		return cf
+	}
+
+	dresp, err := svc.makePoolServiceCall(ctx, drpc.MethodListContainers, &mgmtpb.ListContReq{
+		Sys:  svc.sysdb.SystemName(),
+		Uuid: ps.PoolUUID.String(),
+	})
+	if err == nil {
+		lResp := new(mgmtpb.ListContResp)
+		if err = proto.Unmarshal(dresp.Body, lResp); err == nil && lResp.Status != 0 {
+			err = drpc.DaosStatus(lResp.Status)
+		}
+	}
+	if err != nil {
+		return newCheckFinding(ps, checkClassContMetadata,
+			fmt.Sprintf("container metadata could not be read: %s", err))
+	}
+
+	return nil
+}
+
+// CheckQuery implements the method defined for the Management Service.
+//
+// Return the status and findings of the most recent system check.
+func (svc *mgmtSvc) CheckQuery(ctx context.Context, req *mgmtpb.CheckQueryReq) (*mgmtpb.CheckQueryResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received CheckQuery RPC: %+v", req)
+
+	return svc.check.toPB(), nil
+}
+
+// CheckRepair implements the method defined for the Management Service.
+//
+// Take the requested repair action for a finding of the most recent system
+// check. The inconsistency is re-verified before it is repaired in case the
+// pool has changed since it was checked.
+func (svc *mgmtSvc) CheckRepair(ctx context.Context, req *mgmtpb.CheckRepairReq) (*mgmtpb.CheckRepairResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received CheckRepair RPC: %+v", req)
+
+	svc.check.Lock()
+	defer svc.check.Unlock()
+
+	cf, err := svc.check.finding(req.GetFinding())
+	if err != nil {
+		return nil, err
+	}
+	if cf.pb.Status != findingStatusPending {
+		return nil, errors.Errorf("finding %d has already been %s", cf.pb.Id, cf.pb.Status)
+	}
+
+	action := req.GetAction()
+	if action == "" {
+		action = cf.pb.Suggested
+	}
+	var valid bool
+	for _, a := range cf.pb.Actions {
+		valid = valid || a == action
+	}
+	if !valid {
+		return nil, errors.Errorf("invalid action %q for finding %d (valid actions: %v)",
+			action, cf.pb.Id, cf.pb.Actions)
+	}
+
+	cf.pb.Action = action
+	cf.pb.Status, cf.pb.Result = svc.repairFinding(ctx, cf, action)
+	svc.log.Infof("system check repair of finding %d (%s, pool %s): %s: %s", cf.pb.Id,
+		cf.pb.Class, cf.pb.PoolUuid, cf.pb.Status, cf.pb.Result)
+
+	return &mgmtpb.CheckRepairResp{
+		Finding: proto.Clone(cf.pb).(*mgmtpb.CheckFinding),
+	}, nil
+}
+
+// repairFinding takes the repair action for a finding and returns the
+// resulting finding status and a description of the outcome.
+func (svc *mgmtSvc) repairFinding(ctx context.Context, cf *checkFinding, action string) (string, string) {
+	if action == checkActionIgnore {
+		return findingStatusIgnored, "finding ignored"
+	}
+
+	ps, err := svc.sysdb.FindPoolServiceByUUID(cf.poolID)
+	if err != nil {
+		return findingStatusFailed, err.Error()
+	}
+	cur := svc.checkPool(ctx, ps)
+	if cur == nil || cur.pb.Class != cf.pb.Class {
+		return findingStatusFailed, "inconsistency no longer detected, run a new check"
+	}
+
+	switch action {
+	case checkActionRemovePool:
+		if err := svc.sysdb.RemovePoolService(ps.PoolUUID); err != nil {
+			return findingStatusFailed, err.Error()
+		}
+		return findingStatusRepaired, "pool entry removed from the MS"
+	case checkActionDestroyPool:
+		return svc.destroyStalePool(ctx, ps)
+	case checkActionAddReplica:
+		ps.Replicas = append(ps.Replicas, cur.leader)
+		if err := svc.sysdb.UpdatePoolService(ps); err != nil {
+			return findingStatusFailed, err.Error()
+		}
+		return findingStatusRepaired,
+			fmt.Sprintf("rank %d added to the pool service replicas in the MS", cur.leader)
+	default:
+		return findingStatusFailed, fmt.Sprintf("unhandled action %q", action)
+	}
+}
+
+// destroyStalePool retries the destroy of a pool left in a transitional state.
+// The pool is marked as destroying first so that the destroy is sent to all of
+// the ranks the pool was created on, as the MS may not have recorded the pool
+// service replicas. The pool entry is removed from the MS once the pool has
+// been destroyed.
+func (svc *mgmtSvc) destroyStalePool(ctx context.Context, ps *system.PoolService) (string, string) {
+	if ps.State != system.PoolServiceStateDestroying {
+		ps.State = system.PoolServiceStateDestroying
+		if err := svc.sysdb.UpdatePoolService(ps); err != nil {
+			return findingStatusFailed, err.Error()
+		}
+	}
+
+	resp, err := svc.PoolDestroy(ctx, &mgmtpb.PoolDestroyReq{
+		Sys:   svc.sysdb.SystemName(),
+		Uuid:  ps.PoolUUID.String(),
+		Force: true,
+	})
+	if err != nil {
+		return findingStatusFailed, err.Error()
+	}
+	if resp.Status != 0 {
+		return findingStatusFailed,
+			fmt.Sprintf("pool destroy failed: %s", drpc.DaosStatus(resp.Status))
+	}
+
+	return findingStatusRepaired, "pool destroyed and entry removed from the MS"
+}
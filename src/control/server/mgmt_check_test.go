@@ -0,0 +1,339 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	uuid "github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func setupMockCheckDrpcClient(t *testing.T, svc *mgmtSvc, mocks ...*mockDrpcResponse) {
+	cfg := new(mockDrpcClientConfig)
+	cfg.setSendMsgResponseList(t, mocks...)
+	svc.harness.instances[0].setDrpcClient(newMockDrpcClient(cfg))
+}
+
+func TestServer_MgmtSvc_checkPool(t *testing.T) {
+	queryOK := &mockDrpcResponse{Message: &mgmtpb.PoolQueryResp{}}
+	listOK := &mockDrpcResponse{Message: &mgmtpb.ListContResp{}}
+
+	for name, tc := range map[string]struct {
+		state      system.PoolServiceState
+		createdAt  time.Time
+		inFlight   bool
+		mocks      []*mockDrpcResponse
+		expClass   string
+		expActions []string
+	}{
+		"consistent": {
+			state: system.PoolServiceStateReady,
+			mocks: []*mockDrpcResponse{queryOK, listOK},
+		},
+		"disabled pool skipped": {
+			state: system.PoolServiceStateDisabled,
+		},
+		"recent creating": {
+			state:     system.PoolServiceStateCreating,
+			createdAt: time.Now(),
+		},
+		"old creating still in flight": {
+			state:     system.PoolServiceStateCreating,
+			createdAt: time.Now().Add(-2 * checkStaleCreateAge),
+			inFlight:  true,
+		},
+		"stale creating": {
+			state:      system.PoolServiceStateCreating,
+			createdAt:  time.Now().Add(-2 * checkStaleCreateAge),
+			expClass:   checkClassPoolStaleState,
+			expActions: []string{checkActionDestroyPool, checkActionIgnore},
+		},
+		"stale creating without creation time": {
+			state:      system.PoolServiceStateCreating,
+			expClass:   checkClassPoolStaleState,
+			expActions: []string{checkActionDestroyPool, checkActionIgnore},
+		},
+		"stale destroying": {
+			state:      system.PoolServiceStateDestroying,
+			expClass:   checkClassPoolStaleState,
+			expActions: []string{checkActionDestroyPool, checkActionIgnore},
+		},
+		"pool service missing": {
+			state: system.PoolServiceStateReady,
+			mocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{Status: int32(drpc.DaosNonexistant)}},
+			},
+			expClass:   checkClassPoolSvcMissing,
+			expActions: []string{checkActionRemovePool, checkActionIgnore},
+		},
+		"pool service query fails": {
+			state: system.PoolServiceStateReady,
+			mocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{}, Error: errors.New("send failed")},
+			},
+			expClass:   checkClassPoolSvcUnavailable,
+			expActions: []string{checkActionIgnore},
+		},
+		"leader not a replica": {
+			state: system.PoolServiceStateReady,
+			mocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{Leader: 1}},
+			},
+			expClass:   checkClassPoolSvcReplicas,
+			expActions: []string{checkActionAddReplica, checkActionIgnore},
+		},
+		"container list fails": {
+			state: system.PoolServiceStateReady,
+			mocks: []*mockDrpcResponse{
+				queryOK,
+				{Message: &mgmtpb.ListContResp{Status: int32(drpc.DaosIOError)}},
+			},
+			expClass:   checkClassContMetadata,
+			expActions: []string{checkActionIgnore},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			ps := &system.PoolService{
+				PoolUUID:  uuid.MustParse(common.MockUUID(1)),
+				State:     tc.state,
+				Replicas:  []system.Rank{0},
+				CreatedAt: tc.createdAt,
+			}
+			addTestPoolService(t, svc.sysdb, ps)
+			setupMockCheckDrpcClient(t, svc, tc.mocks...)
+			if tc.inFlight {
+				svc.poolCreates.start(ps.PoolUUID)
+			}
+
+			cf := svc.checkPool(context.TODO(), ps)
+			if tc.expClass == "" {
+				if cf != nil {
+					t.Fatalf("unexpected finding: %+v", cf.pb)
+				}
+				return
+			}
+			if cf == nil {
+				t.Fatalf("expected %q finding", tc.expClass)
+			}
+
+			common.AssertEqual(t, tc.expClass, cf.pb.Class, "unexpected class")
+			if diff := cmp.Diff(tc.expActions, cf.pb.Actions); diff != "" {
+				t.Fatalf("unexpected actions (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expActions[0], cf.pb.Suggested, "unexpected suggested action")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_CheckStart(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	svc := newTestMgmtSvc(t, log)
+	addTestPools(t, svc.sysdb, common.MockUUID(1))
+
+	if _, err := svc.CheckStart(context.TODO(), &mgmtpb.CheckStartReq{
+		Sys:   build.DefaultSystemName,
+		Pools: []string{"missing"},
+	}); !system.IsPoolNotFound(err) {
+		t.Fatalf("expected pool not found error, got %v", err)
+	}
+
+	if _, err := svc.check.start(1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	_, err := svc.CheckStart(context.TODO(), &mgmtpb.CheckStartReq{Sys: build.DefaultSystemName})
+	common.CmpErr(t, FaultCheckRunning(1), err)
+}
+
+func TestServer_MgmtSvc_CheckRepair(t *testing.T) {
+	missing := &mockDrpcResponse{
+		Message: &mgmtpb.PoolQueryResp{Status: int32(drpc.DaosNonexistant)},
+	}
+	notReplica := &mockDrpcResponse{Message: &mgmtpb.PoolQueryResp{Leader: 1}}
+
+	for name, tc := range map[string]struct {
+		state       system.PoolServiceState
+		checkMocks  []*mockDrpcResponse
+		repairMocks []*mockDrpcResponse
+		noCheck     bool
+		finding     uint64
+		action      string
+		expErr      error
+		expStatus   string
+		expState    system.PoolServiceState
+		expRemoved  bool
+		expReplicas []system.Rank
+	}{
+		"no check run": {
+			noCheck: true,
+			finding: 1,
+			expErr:  errors.New("no system check"),
+		},
+		"unknown finding": {
+			state:      system.PoolServiceStateReady,
+			checkMocks: []*mockDrpcResponse{missing},
+			finding:    2,
+			expErr:     errors.New("no finding 2"),
+		},
+		"invalid action": {
+			state:      system.PoolServiceStateReady,
+			checkMocks: []*mockDrpcResponse{missing},
+			finding:    1,
+			action:     checkActionAddReplica,
+			expErr:     errors.New("invalid action"),
+		},
+		"ignore": {
+			state:       system.PoolServiceStateReady,
+			checkMocks:  []*mockDrpcResponse{missing},
+			finding:     1,
+			action:      checkActionIgnore,
+			expStatus:   findingStatusIgnored,
+			expState:    system.PoolServiceStateReady,
+			expReplicas: []system.Rank{0},
+		},
+		"suggested action removes missing pool": {
+			state:       system.PoolServiceStateReady,
+			checkMocks:  []*mockDrpcResponse{missing},
+			repairMocks: []*mockDrpcResponse{missing},
+			finding:     1,
+			expStatus:   findingStatusRepaired,
+			expRemoved:  true,
+		},
+		"stale creating pool destroyed": {
+			state: system.PoolServiceStateCreating,
+			repairMocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolDestroyResp{}},
+			},
+			finding:    1,
+			expStatus:  findingStatusRepaired,
+			expRemoved: true,
+		},
+		"stale destroying pool destroyed": {
+			state: system.PoolServiceStateDestroying,
+			repairMocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolDestroyResp{}},
+			},
+			finding:    1,
+			action:     checkActionDestroyPool,
+			expStatus:  findingStatusRepaired,
+			expRemoved: true,
+		},
+		"stale pool destroy fails": {
+			state: system.PoolServiceStateCreating,
+			repairMocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolDestroyResp{Status: int32(drpc.DaosTimedOut)}},
+			},
+			finding:     1,
+			expStatus:   findingStatusFailed,
+			expState:    system.PoolServiceStateDestroying,
+			expReplicas: []system.Rank{0},
+		},
+		"leader added to replicas": {
+			state:       system.PoolServiceStateReady,
+			checkMocks:  []*mockDrpcResponse{notReplica},
+			repairMocks: []*mockDrpcResponse{notReplica},
+			finding:     1,
+			action:      checkActionAddReplica,
+			expStatus:   findingStatusRepaired,
+			expState:    system.PoolServiceStateReady,
+			expReplicas: []system.Rank{0, 1},
+		},
+		"inconsistency resolved before repair": {
+			state:      system.PoolServiceStateReady,
+			checkMocks: []*mockDrpcResponse{missing},
+			repairMocks: []*mockDrpcResponse{
+				{Message: &mgmtpb.PoolQueryResp{}},
+				{Message: &mgmtpb.ListContResp{}},
+			},
+			finding:     1,
+			expStatus:   findingStatusFailed,
+			expState:    system.PoolServiceStateReady,
+			expReplicas: []system.Rank{0},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			poolUUID := uuid.MustParse(common.MockUUID(1))
+			addTestPoolService(t, svc.sysdb, &system.PoolService{
+				PoolUUID: poolUUID,
+				State:    tc.state,
+				Replicas: []system.Rank{0},
+				Storage: &system.PoolServiceStorage{
+					CreationRankStr: "0-1",
+				},
+			})
+
+			if !tc.noCheck {
+				setupMockCheckDrpcClient(t, svc, tc.checkMocks...)
+				pools, err := svc.checkPoolList(nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := svc.check.start(len(pools), time.Now()); err != nil {
+					t.Fatal(err)
+				}
+				svc.runCheck(context.TODO(), pools)
+			}
+
+			setupMockCheckDrpcClient(t, svc, tc.repairMocks...)
+			resp, err := svc.CheckRepair(context.TODO(), &mgmtpb.CheckRepairReq{
+				Sys:     build.DefaultSystemName,
+				Finding: tc.finding,
+				Action:  tc.action,
+			})
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expStatus, resp.Finding.Status, resp.Finding.Result)
+			qResp := svc.check.toPB()
+			common.AssertEqual(t, tc.expStatus, qResp.Findings[0].Status, "finding status not updated")
+
+			ps, err := svc.sysdb.FindPoolServiceByUUID(poolUUID)
+			if tc.expRemoved {
+				if !system.IsPoolNotFound(err) {
+					t.Fatalf("expected pool to be removed, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expState, ps.State, "unexpected pool state")
+			if diff := cmp.Diff(tc.expReplicas, ps.Replicas); diff != "" {
+				t.Fatalf("unexpected replicas (-want, +got):\n%s\n", diff)
+			}
+
+			if _, err := svc.CheckRepair(context.TODO(), &mgmtpb.CheckRepairReq{
+				Sys:     build.DefaultSystemName,
+				Finding: tc.finding,
+			}); err == nil {
+				t.Fatal("expected error repairing finding twice")
+			}
+		})
+	}
+}
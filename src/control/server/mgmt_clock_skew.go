@@ -0,0 +1,146 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// clockSkewInterval is the interval at which MS replicas measure the clock
+// skew of the servers hosting joined ranks.
+const clockSkewInterval = 30 * time.Second
+
+// clockSkews holds the latest clock skew measured for each server, keyed by
+// control address. Measurements are held in memory on each MS replica rather
+// than in the system database as they are only meaningful relative to the
+// clock of the replica which made them.
+type clockSkews struct {
+	sync.RWMutex
+	threshold time.Duration
+	hosts     map[string]*system.ClockSkew
+}
+
+func newClockSkews(threshold time.Duration) *clockSkews {
+	return &clockSkews{
+		threshold: threshold,
+		hosts:     make(map[string]*system.ClockSkew),
+	}
+}
+
+// get returns a copy of the latest measurement for the host, or nil if the
+// host has not been measured.
+func (cs *clockSkews) get(addr string) *system.ClockSkew {
+	cs.RLock()
+	defer cs.RUnlock()
+
+	skew, found := cs.hosts[addr]
+	if !found {
+		return nil
+	}
+	cpy := *skew
+	return &cpy
+}
+
+func (svc *mgmtSvc) startClockSkewLoop(ctx context.Context) {
+	svc.log.Debug("starting clockSkewLoop")
+	go svc.clockSkewLoop(ctx)
+}
+
+func (svc *mgmtSvc) clockSkewLoop(parent context.Context) {
+	skewTimer := time.NewTicker(clockSkewInterval)
+	defer skewTimer.Stop()
+
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped clockSkewLoop")
+			return
+		case <-skewTimer.C:
+			svc.checkClockSkew(parent, time.Now())
+		}
+	}
+}
+
+// checkClockSkew measures the clock skew of the servers hosting joined ranks
+// and logs a warning when a server's offset first exceeds the threshold. Only
+// MS replicas measure skew, servers which fail to respond keep their previous
+// measurement. The config hashes reported in the heartbeats are also recorded.
+func (svc *mgmtSvc) checkClockSkew(ctx context.Context, now time.Time) {
+	if svc.sysdb.CheckReplica() != nil {
+		return
+	}
+
+	hostSet := make(map[string]struct{})
+	for _, m := range svc.membership.Members(nil) {
+		if m.State() == system.MemberStateJoined {
+			hostSet[m.Addr.String()] = struct{}{}
+		}
+	}
+	hosts := make([]string, 0, len(hostSet))
+	for host := range hostSet {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var clocks map[string]*control.HostClock
+	var cfgHashes map[string]string
+	if len(hosts) > 0 {
+		req := new(control.HeartbeatReq)
+		req.SetHostList(hosts)
+		resp, err := control.Heartbeat(ctx, svc.rpcClient, req)
+		if err != nil {
+			svc.log.Errorf("failed to measure clock skew: %s", err)
+			return
+		}
+		for _, hes := range resp.HostErrors {
+			svc.log.Debugf("failed to measure clock skew of %s: %s", hes.HostSet, hes.HostError)
+		}
+		clocks = resp.HostClocks
+		cfgHashes = resp.HostConfigHashes
+	}
+	svc.configHashes.update(svc.log, hosts, cfgHashes)
+
+	cs := svc.clockSkews
+	cs.Lock()
+	defer cs.Unlock()
+
+	skews := make(map[string]*system.ClockSkew)
+	for _, host := range hosts {
+		prev := cs.hosts[host]
+
+		clock, found := clocks[host]
+		if !found {
+			if prev != nil {
+				skews[host] = prev
+			}
+			continue
+		}
+
+		skew := &system.ClockSkew{
+			Offset:    clock.Offset,
+			RoundTrip: clock.RoundTrip,
+			Checked:   now,
+			Exceeded:  clock.Offset > cs.threshold || clock.Offset < -cs.threshold,
+		}
+		switch {
+		case skew.Exceeded && (prev == nil || !prev.Exceeded):
+			svc.log.Errorf("clock of %s is offset by %s, exceeding threshold of %s",
+				host, skew.Offset, cs.threshold)
+		case !skew.Exceeded && prev != nil && prev.Exceeded:
+			svc.log.Infof("clock of %s is offset by %s, within threshold of %s",
+				host, skew.Offset, cs.threshold)
+		}
+		skews[host] = skew
+	}
+	cs.hosts = skews
+}
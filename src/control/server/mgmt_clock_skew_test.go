@@ -0,0 +1,162 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_checkClockSkew(t *testing.T) {
+	now := time.Now()
+	host1 := common.MockHostAddr(1).String()
+	host2 := common.MockHostAddr(2).String()
+	host3 := common.MockHostAddr(3).String()
+
+	// The offsets measured from the mock responses depend on how long the
+	// test takes to run, so only compare them approximately.
+	cmpApprox := cmp.Comparer(func(a, b time.Duration) bool {
+		d := a - b
+		return d > -time.Minute && d < time.Minute
+	})
+
+	for name, tc := range map[string]struct {
+		members    system.Members
+		prev       map[string]*system.ClockSkew
+		prevHashes map[string]string
+		hostResp   []*control.HostResponse
+		expSkews   map[string]*system.ClockSkew
+		expHashes  map[string]string
+	}{
+		"no joined members": {
+			members: system.Members{
+				mockMember(t, 0, 1, "stopped"),
+			},
+			prev: map[string]*system.ClockSkew{
+				host1: {Checked: now.Add(-time.Minute)},
+			},
+			prevHashes: map[string]string{
+				host1: "abcd",
+			},
+			expSkews:  map[string]*system.ClockSkew{},
+			expHashes: map[string]string{},
+		},
+		"skew measured": {
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "joined"),
+				mockMember(t, 3, 3, "stopped"),
+			},
+			prev: map[string]*system.ClockSkew{
+				host3: {Checked: now.Add(-time.Minute)},
+			},
+			hostResp: []*control.HostResponse{
+				{
+					Addr:    host1,
+					Message: &ctlpb.HeartbeatResp{Time: now.UnixNano(), ConfigHash: "abcd"},
+				},
+				{
+					Addr:    host2,
+					Message: &ctlpb.HeartbeatResp{Time: now.Add(-time.Hour).UnixNano()},
+				},
+			},
+			expSkews: map[string]*system.ClockSkew{
+				host1: {Checked: now},
+				host2: {Offset: -time.Hour, Checked: now, Exceeded: true},
+			},
+			expHashes: map[string]string{
+				host1: "abcd",
+			},
+		},
+		"unresponsive host keeps previous measurement": {
+			members: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 2, "joined"),
+			},
+			prev: map[string]*system.ClockSkew{
+				host1: {Offset: time.Hour, Checked: now.Add(-time.Minute), Exceeded: true},
+				host2: {Offset: time.Hour, Checked: now.Add(-time.Minute), Exceeded: true},
+			},
+			prevHashes: map[string]string{
+				host1: "abcd",
+				host2: "abcd",
+			},
+			hostResp: []*control.HostResponse{
+				{
+					Addr:    host1,
+					Message: &ctlpb.HeartbeatResp{Time: now.UnixNano(), ConfigHash: "ef01"},
+				},
+				{
+					Addr:  host2,
+					Error: errors.New("remote failed"),
+				},
+			},
+			expSkews: map[string]*system.ClockSkew{
+				host1: {Checked: now},
+				host2: {Offset: time.Hour, Checked: now.Add(-time.Minute), Exceeded: true},
+			},
+			expHashes: map[string]string{
+				host1: "ef01",
+				host2: "abcd",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, tc.members, tc.hostResp)
+			svc.clockSkews = newClockSkews(time.Minute)
+			for host, skew := range tc.prev {
+				svc.clockSkews.hosts[host] = skew
+			}
+			for host, hash := range tc.prevHashes {
+				svc.configHashes.hosts[host] = hash
+			}
+
+			svc.checkClockSkew(context.TODO(), now)
+
+			if diff := cmp.Diff(tc.expSkews, svc.clockSkews.hosts, cmpApprox); diff != "" {
+				t.Fatalf("unexpected clock skews (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expHashes, svc.configHashes.hosts); diff != "" {
+				t.Fatalf("unexpected config hashes (-want, +got):\n%s\n", diff)
+			}
+
+			resp, err := svc.SystemQuery(context.TODO(), &mgmtpb.SystemQueryReq{
+				Sys: build.DefaultSystemName,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range resp.Members {
+				skew := tc.expSkews[m.Addr]
+				common.AssertEqual(t, skew != nil, m.ClockSkew != nil,
+					"unexpected clock skew presence for "+m.Addr)
+				if skew != nil {
+					common.AssertEqual(t, skew.Exceeded, m.ClockSkew.Exceeded,
+						"unexpected clock skew state for "+m.Addr)
+				}
+				common.AssertEqual(t, tc.expHashes[m.Addr], m.ConfigHash,
+					"unexpected config hash for "+m.Addr)
+			}
+		})
+	}
+}
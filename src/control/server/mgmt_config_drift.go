@@ -0,0 +1,73 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sync"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+// hostConfigHashes holds the latest config hash reported by each server in
+// its heartbeat, keyed by control address. Like clock skews, hashes are held
+// in memory on each MS replica rather than in the system database.
+type hostConfigHashes struct {
+	sync.RWMutex
+	hosts map[string]string
+}
+
+func newHostConfigHashes() *hostConfigHashes {
+	return &hostConfigHashes{
+		hosts: make(map[string]string),
+	}
+}
+
+// get returns the latest config hash reported by the host, or an empty string
+// if the host has not reported one.
+func (hch *hostConfigHashes) get(addr string) string {
+	hch.RLock()
+	defer hch.RUnlock()
+
+	return hch.hosts[addr]
+}
+
+// update replaces the recorded hashes with those reported by the given hosts.
+// Hosts which did not report a hash keep their previous one, hosts not in the
+// list are forgotten.
+func (hch *hostConfigHashes) update(log logging.Logger, hosts []string, reported map[string]string) {
+	hch.Lock()
+	defer hch.Unlock()
+
+	hashes := make(map[string]string)
+	for _, host := range hosts {
+		prev := hch.hosts[host]
+
+		hash, found := reported[host]
+		if !found {
+			if prev != "" {
+				hashes[host] = prev
+			}
+			continue
+		}
+
+		if prev != "" && prev != hash {
+			log.Infof("config hash of %s changed from %s to %s", host,
+				shortConfigHash(prev), shortConfigHash(hash))
+		}
+		hashes[host] = hash
+	}
+	hch.hosts = hashes
+}
+
+// shortConfigHash abbreviates a config hash for display.
+func shortConfigHash(hash string) string {
+	const shortLen = 12
+	if len(hash) > shortLen {
+		return hash[:shortLen]
+	}
+	return hash
+}
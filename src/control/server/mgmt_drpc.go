@@ -96,8 +96,9 @@ func (mod *srvModule) handleGetPoolServiceRanks(reqb []byte) ([]byte, error) {
 
 	resp := new(srvpb.GetPoolSvcResp)
 
+	// Disabled pools are pending destroy and may not be connected to.
 	ps, err := mod.sysdb.FindPoolServiceByUUID(uuid)
-	if err != nil {
+	if err != nil || ps.State == system.PoolServiceStateDisabled {
 		resp.Status = int32(drpc.DaosNonexistant)
 		mod.log.Debugf("GetPoolSvcResp: %+v", resp)
 		return proto.Marshal(resp)
@@ -121,7 +122,7 @@ func (mod *srvModule) handlePoolFindByLabel(reqb []byte) ([]byte, error) {
 	resp := new(srvpb.PoolFindByLabelResp)
 
 	ps, err := mod.sysdb.FindPoolServiceByLabel(req.GetLabel())
-	if err != nil {
+	if err != nil || ps.State == system.PoolServiceStateDisabled {
 		resp.Status = int32(drpc.DaosNonexistant)
 		mod.log.Debugf("PoolFindByLabelResp: %+v", resp)
 		return proto.Marshal(resp)
@@ -7,8 +7,8 @@
 package server
 
 import (
-	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +34,10 @@ const (
 	// MaxPoolServiceReps defines the maximum number of pool service
 	// replicas that may be configured when creating a pool.
 	MaxPoolServiceReps = 13
+
+	// poolPropProtected is the name of the pool property that prevents the
+	// pool from being destroyed.
+	poolPropProtected = "protected"
 )
 
 type poolServiceReq interface {
@@ -66,6 +70,9 @@ func (svc *mgmtSvc) getPoolServiceRanks(uuidStr string) ([]uint32, error) {
 		return nil, err
 	}
 
+	if ps.State == system.PoolServiceStateDisabled {
+		return nil, FaultPoolDisabled(ps.PoolUUID.String(), ps.DestroyAfter)
+	}
 	if ps.State != system.PoolServiceStateReady {
 		return nil, drpc.DaosTryAgain
 	}
@@ -175,11 +182,32 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 		return nil, FaultPoolDuplicateLabel(req.GetLabel())
 	}
 
+	defaults, err := svc.sysdb.PoolPropDefaults()
+	if err != nil {
+		return nil, err
+	}
+	props, err := resolvePoolCreateProps(req.GetProperties(), defaults)
+	if err != nil {
+		return nil, err
+	}
+	// The properties are set once the pool service is running rather than
+	// being passed to the engine with the create request.
+	req.Properties = nil
+
 	allRanks, err := svc.sysdb.MemberRanks(system.AvailableMemberFilter)
 	if err != nil {
 		return nil, err
 	}
 
+	rankDomains, err := svc.rankFaultDomains()
+	if err != nil {
+		return nil, err
+	}
+	minDomains := int(req.GetMindomains())
+
+	timing := new(opTiming)
+	var rankStrategy string
+	var rankChoices []*mgmtpb.PoolRankChoice
 	if len(req.GetRanks()) > 0 {
 		// If the request supplies a specific rank list, use it. Note that
 		// the rank list may include downed ranks, in which case the create
@@ -192,30 +220,49 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 			return nil, FaultPoolInvalidRanks(invalid)
 		}
 
+		if numDomains := len(groupRanksByDomain(reqRanks, rankDomains)); numDomains < minDomains {
+			return nil, FaultPoolInsufficientFaultDomains(minDomains, numDomains)
+		}
+
 		req.Ranks = system.RanksToUint32(reqRanks)
 	} else {
 		// Otherwise, create the pool across the requested number of
 		// available ranks in the system (if the request does not
-		// specify a number of ranks, all are used).
+		// specify a number of ranks, all are used), selected by free
+		// capacity, health and fault domain.
 		nRanks := len(allRanks)
 		if req.GetNumranks() > 0 {
 			nRanks = int(req.GetNumranks())
+		}
+
+		if minDomains > 0 {
+			groups := groupRanksByDomain(allRanks, rankDomains)
+			if len(groups) < minDomains {
+				return nil, FaultPoolInsufficientFaultDomains(minDomains, len(groups))
+			}
+			if nRanks < minDomains {
+				return nil, errors.Errorf("number of ranks (%d) is less than the minimum number of fault domains (%d)",
+					nRanks, minDomains)
+			}
+		}
 
-			// TODO (DAOS-6263): Improve rank selection algorithm.
-			// In the short term, we can just randomize the set of
-			// available ranks in order to avoid always choosing the
-			// first N ranks.
-			rand.Seed(time.Now().UnixNano())
-			rand.Shuffle(len(allRanks), func(i, j int) {
-				allRanks[i], allRanks[j] = allRanks[j], allRanks[i]
-			})
+		selectDone := timing.start("rank selection")
+		// No selection is made when all ranks are used, so skip
+		// the query of their SSDs.
+		candidates, err := svc.rankCandidates(ctx, allRanks, rankDomains, nRanks < len(allRanks))
+		if err != nil {
+			return nil, err
 		}
+		rankStrategy, rankChoices, err = selectPoolRanks(req, candidates, minDomains)
+		if err != nil {
+			return nil, err
+		}
+		selectDone()
 
-		req.Ranks = make([]uint32, nRanks)
-		for i := 0; i < nRanks; i++ {
-			req.Ranks[i] = allRanks[i].Uint32()
+		req.Ranks = make([]uint32, len(rankChoices))
+		for i, choice := range rankChoices {
+			req.Ranks[i] = choice.GetRank()
 		}
-		sort.Slice(req.Ranks, func(i, j int) bool { return req.Ranks[i] < req.Ranks[j] })
 	}
 
 	if len(req.GetRanks()) == 0 {
@@ -256,6 +303,9 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 
 	ps = system.NewPoolService(uuid, req.GetScmbytes(), req.GetNvmebytes(), system.RanksFromUint32(req.GetRanks()))
 	ps.PoolLabel = req.GetLabel()
+	ps.CreatedAt = time.Now()
+	svc.poolCreates.start(uuid)
+	defer svc.poolCreates.finish(uuid)
 	if err := svc.sysdb.AddPoolService(ps); err != nil {
 		return nil, err
 	}
@@ -298,10 +348,12 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 	}()
 
 	svc.log.Debugf("MgmtSvc.PoolCreate forwarding modified req:%+v\n", req)
+	engineDone := timing.start("engine pool create")
 	dresp, err := svc.harness.CallDrpc(ctx, drpc.MethodPoolCreate, req)
 	if err != nil {
 		return nil, err
 	}
+	engineDone()
 
 	if err = proto.Unmarshal(dresp.Body, resp); err != nil {
 		return nil, errors.Wrap(err, "unmarshal PoolCreate response")
@@ -313,22 +365,115 @@ func (svc *mgmtSvc) PoolCreate(ctx context.Context, req *mgmtpb.PoolCreateReq) (
 		}
 		return resp, nil
 	}
+
+	// Record the pool service replicas before any further steps so that a
+	// cleanup destroy is sent to them if those steps fail.
+	ps.Replicas = system.RanksFromUint32(resp.GetSvcReps())
+	if err = svc.sysdb.UpdatePoolService(ps); err != nil {
+		return nil, err
+	}
+
 	// let the caller know what was actually created
 	resp.TgtRanks = req.GetRanks()
 	resp.ScmBytes = req.Scmbytes
 	resp.NvmeBytes = req.Nvmebytes
+	groups := groupRanksByDomain(system.RanksFromUint32(req.GetRanks()), rankDomains)
+	resp.FaultDomains = make([]*mgmtpb.PoolFaultDomain, len(groups))
+	for i, group := range groups {
+		resp.FaultDomains[i] = &mgmtpb.PoolFaultDomain{
+			Domain: group.domain,
+			Ranks:  system.RanksToUint32(group.ranks),
+		}
+	}
+	sort.Slice(resp.FaultDomains, func(i, j int) bool {
+		return resp.FaultDomains[i].Domain < resp.FaultDomains[j].Domain
+	})
+	if len(rankChoices) > 0 {
+		resp.RankStrategy = rankStrategy
+		resp.RankChoices = rankChoices
+	}
+
+	propsDone := timing.start("set properties")
+	if err = svc.setPoolCreateProps(ctx, req.GetUuid(), resp.GetSvcReps(), props); err != nil {
+		return nil, err
+	}
+	propsDone()
+	resp.Properties = props
 
-	ps.Replicas = system.RanksFromUint32(resp.GetSvcReps())
 	ps.State = system.PoolServiceStateReady
+	for _, prop := range props {
+		if prop.GetName() == poolPropProtected {
+			ps.Protected = prop.GetValue() == "true"
+		}
+	}
 	if err := svc.sysdb.UpdatePoolService(ps); err != nil {
 		return nil, err
 	}
+	resp.Timings = timing.poolCreateTimings()
 
 	svc.log.Debugf("MgmtSvc.PoolCreate dispatch resp:%+v\n", resp)
 
 	return resp, nil
 }
 
+// rankFaultDomains returns the top-level fault domain of each member rank, which
+// is the unit across which pool targets are spread.
+func (svc *mgmtSvc) rankFaultDomains() (map[system.Rank]string, error) {
+	members, err := svc.sysdb.AllMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	rankDomains := make(map[system.Rank]string, len(members))
+	for _, m := range members {
+		rankDomains[m.Rank] = system.FaultDomainSeparator + m.FaultDomain.TopLevel()
+	}
+
+	return rankDomains, nil
+}
+
+type domainRanks struct {
+	domain string
+	ranks  []system.Rank
+}
+
+// groupRanksByDomain groups ranks by fault domain, with domains in order of
+// first appearance in the supplied ranks.
+func groupRanksByDomain(ranks []system.Rank, rankDomains map[system.Rank]string) []*domainRanks {
+	var groups []*domainRanks
+	byDomain := make(map[string]*domainRanks)
+	for _, rank := range ranks {
+		domain := rankDomains[rank]
+		group, found := byDomain[domain]
+		if !found {
+			group = &domainRanks{domain: domain}
+			byDomain[domain] = group
+			groups = append(groups, group)
+		}
+		group.ranks = append(group.ranks, rank)
+	}
+
+	return groups
+}
+
+// spreadRanksAcrossDomains orders ranks by taking one from each fault domain
+// in turn so that any prefix of the result covers as many domains as possible.
+func spreadRanksAcrossDomains(groups []*domainRanks) []system.Rank {
+	var spread []system.Rank
+	for i := 0; ; i++ {
+		added := false
+		for _, group := range groups {
+			if i < len(group.ranks) {
+				spread = append(spread, group.ranks[i])
+				added = true
+			}
+		}
+		if !added {
+			return spread
+		}
+	}
+}
+
 // PoolResolveID implements a handler for resolving a user-friendly Pool ID into
 // a UUID.
 func (svc *mgmtSvc) PoolResolveID(ctx context.Context, req *mgmtpb.PoolResolveIDReq) (*mgmtpb.PoolResolveIDResp, error) {
@@ -379,6 +524,13 @@ func (svc *mgmtSvc) PoolDestroy(ctx context.Context, req *mgmtpb.PoolDestroyReq)
 		return nil, err
 	}
 
+	if ps.Protected {
+		return nil, FaultPoolProtected(ps.PoolUUID.String())
+	}
+	if req.GetDelay() > 0 {
+		return svc.disablePool(ctx, ps, time.Duration(req.GetDelay())*time.Second)
+	}
+
 	inCleanupMode := false
 	if ps.State == system.PoolServiceStateDestroying {
 		// If we already tried to destroy the pool but it failed for some
@@ -428,6 +580,98 @@ func (svc *mgmtSvc) PoolDestroy(ctx context.Context, req *mgmtpb.PoolDestroyReq)
 	return resp, nil
 }
 
+// disablePool evicts all pool handles and disables the pool so that it is
+// destroyed once the delay has elapsed, unless it is restored before then.
+// New connections are refused while the pool is disabled.
+func (svc *mgmtSvc) disablePool(ctx context.Context, ps *system.PoolService, delay time.Duration) (*mgmtpb.PoolDestroyResp, error) {
+	switch ps.State {
+	case system.PoolServiceStateReady:
+	case system.PoolServiceStateDisabled:
+		return nil, FaultPoolDisabled(ps.PoolUUID.String(), ps.DestroyAfter)
+	default:
+		return nil, errors.Errorf("pool %s cannot be disabled in state %s", ps.PoolUUID, ps.State)
+	}
+
+	ps.State = system.PoolServiceStateDisabled
+	ps.DestroyAfter = time.Now().Add(delay)
+	if err := svc.sysdb.UpdatePoolService(ps); err != nil {
+		return nil, errors.Wrapf(err, "failed to update pool %s", ps.PoolUUID)
+	}
+
+	resp := new(mgmtpb.PoolDestroyResp)
+	err := svc.evictPoolHandles(ctx, ps, resp)
+	if err != nil || resp.Status != 0 {
+		// Re-enable the pool if the handles could not be evicted, so
+		// that the pool is not left inaccessible yet still in use.
+		ps.State = system.PoolServiceStateReady
+		ps.DestroyAfter = time.Time{}
+		if uErr := svc.sysdb.UpdatePoolService(ps); uErr != nil {
+			svc.log.Errorf("failed to re-enable pool %s: %s", ps.PoolUUID, uErr)
+		}
+		return resp, err
+	}
+
+	svc.log.Infof("pool %s disabled, will be destroyed after %s", ps.PoolUUID,
+		ps.DestroyAfter.Format(time.RFC3339))
+
+	return resp, nil
+}
+
+// evictPoolHandles evicts all handles of the pool, setting the status of the
+// response on failure.
+func (svc *mgmtSvc) evictPoolHandles(ctx context.Context, ps *system.PoolService, resp *mgmtpb.PoolDestroyResp) error {
+	req := &mgmtpb.PoolEvictReq{
+		Sys:      svc.sysdb.SystemName(),
+		Uuid:     ps.PoolUUID.String(),
+		SvcRanks: system.RanksToUint32(ps.Replicas),
+	}
+	dresp, err := svc.harness.CallDrpc(ctx, drpc.MethodPoolEvict, req)
+	if err != nil {
+		return err
+	}
+
+	evictResp := new(mgmtpb.PoolEvictResp)
+	if err = proto.Unmarshal(dresp.Body, evictResp); err != nil {
+		return errors.Wrap(err, "unmarshal PoolEvict response")
+	}
+	resp.Status = evictResp.Status
+
+	return nil
+}
+
+// PoolRestore re-enables a pool that is pending a delayed destroy.
+func (svc *mgmtSvc) PoolRestore(ctx context.Context, req *mgmtpb.PoolRestoreReq) (*mgmtpb.PoolRestoreResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("MgmtSvc.PoolRestore dispatch, req:%+v", req)
+
+	uuid, err := uuid.Parse(req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := svc.sysdb.FindPoolServiceByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if ps.State != system.PoolServiceStateDisabled {
+		return nil, errors.Errorf("pool %s is not pending destroy (state: %s)", uuid, ps.State)
+	}
+
+	ps.State = system.PoolServiceStateReady
+	ps.DestroyAfter = time.Time{}
+	if err := svc.sysdb.UpdatePoolService(ps); err != nil {
+		return nil, errors.Wrapf(err, "failed to update pool %s", uuid)
+	}
+
+	resp := new(mgmtpb.PoolRestoreResp)
+	svc.log.Debugf("MgmtSvc.PoolRestore dispatch, resp:%+v", resp)
+
+	return resp, nil
+}
+
 // PoolEvict implements the method defined for the Management Service.
 func (svc *mgmtSvc) PoolEvict(ctx context.Context, req *mgmtpb.PoolEvictReq) (*mgmtpb.PoolEvictResp, error) {
 	if err := svc.checkLeaderRequest(req); err != nil {
@@ -626,6 +870,50 @@ func resolvePoolPropVal(req *mgmtpb.PoolSetPropReq) (*mgmtpb.PoolSetPropReq, err
 	return newReq, nil
 }
 
+// setPoolProtected sets the protected property of a pool, which is only
+// stored in the MS.
+func (svc *mgmtSvc) setPoolProtected(req *mgmtpb.PoolSetPropReq) (*mgmtpb.PoolSetPropResp, error) {
+	var protected bool
+	switch val := req.GetValue().(type) {
+	case *mgmtpb.PoolSetPropReq_Strval:
+		var err error
+		if protected, err = strconv.ParseBool(strings.TrimSpace(val.Strval)); err != nil {
+			return nil, errors.Errorf("invalid %s value %q (valid values: true, false)",
+				poolPropProtected, val.Strval)
+		}
+	case *mgmtpb.PoolSetPropReq_Numval:
+		if val.Numval > 1 {
+			return nil, errors.Errorf("invalid %s value %d (valid values: 0, 1)",
+				poolPropProtected, val.Numval)
+		}
+		protected = val.Numval == 1
+	default:
+		return nil, errors.Errorf("missing %s value", poolPropProtected)
+	}
+
+	uuid, err := uuid.Parse(req.GetUuid())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse request uuid %q", req.GetUuid())
+	}
+	ps, err := svc.sysdb.FindPoolServiceByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	if ps.State == system.PoolServiceStateDisabled {
+		return nil, FaultPoolDisabled(ps.PoolUUID.String(), ps.DestroyAfter)
+	}
+
+	ps.Protected = protected
+	if err := svc.sysdb.UpdatePoolService(ps); err != nil {
+		return nil, errors.Wrapf(err, "failed to update pool %s", uuid)
+	}
+
+	return &mgmtpb.PoolSetPropResp{
+		Property: &mgmtpb.PoolSetPropResp_Name{Name: req.GetName()},
+		Value:    &mgmtpb.PoolSetPropResp_Strval{Strval: strconv.FormatBool(protected)},
+	}, nil
+}
+
 // PoolSetProp forwards a request to the I/O Engine to set a pool property.
 func (svc *mgmtSvc) PoolSetProp(ctx context.Context, req *mgmtpb.PoolSetPropReq) (*mgmtpb.PoolSetPropResp, error) {
 	if err := svc.checkLeaderRequest(req); err != nil {
@@ -633,6 +921,10 @@ func (svc *mgmtSvc) PoolSetProp(ctx context.Context, req *mgmtpb.PoolSetPropReq)
 	}
 	svc.log.Debugf("MgmtSvc.PoolSetProp dispatch, req:%+v", req)
 
+	if strings.EqualFold(strings.TrimSpace(req.GetName()), poolPropProtected) {
+		return svc.setPoolProtected(req)
+	}
+
 	newReq, err := resolvePoolPropVal(req)
 	if err != nil {
 		return nil, err
@@ -825,12 +1117,34 @@ func (svc *mgmtSvc) ListPools(ctx context.Context, req *mgmtpb.ListPoolsReq) (*m
 		return nil, err
 	}
 
+	// pools are listed in UUID order so that the UUID of the last pool
+	// returned can be used as the cursor for the next page
+	sort.Slice(psList, func(i, j int) bool {
+		return psList[i].PoolUUID.String() < psList[j].PoolUUID.String()
+	})
+	if req.Cursor != "" {
+		start := sort.Search(len(psList), func(i int) bool {
+			return psList[i].PoolUUID.String() > req.Cursor
+		})
+		psList = psList[start:]
+	}
+
 	resp := new(mgmtpb.ListPoolsResp)
+	if req.Limit > 0 && len(psList) > int(req.Limit) {
+		psList = psList[:req.Limit]
+		resp.NextCursor = psList[len(psList)-1].PoolUUID.String()
+	}
 	for _, ps := range psList {
-		resp.Pools = append(resp.Pools, &mgmtpb.ListPoolsResp_Pool{
-			Uuid:    ps.PoolUUID.String(),
-			SvcReps: system.RanksToUint32(ps.Replicas),
-		})
+		pool := &mgmtpb.ListPoolsResp_Pool{
+			Uuid:      ps.PoolUUID.String(),
+			SvcReps:   system.RanksToUint32(ps.Replicas),
+			State:     ps.State.String(),
+			Protected: ps.Protected,
+		}
+		if ps.State == system.PoolServiceStateDisabled {
+			pool.DestroyAfter = ps.DestroyAfter.Format(time.RFC3339)
+		}
+		resp.Pools = append(resp.Pools, pool)
 	}
 
 	svc.log.Debugf("MgmtSvc.ListPools dispatch, resp:%+v\n", resp)
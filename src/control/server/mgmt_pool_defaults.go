@@ -0,0 +1,193 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/protobuf/proto"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// poolCreateProps are the pool properties that may be set when a pool is
+// created and for which system-level defaults may be set.
+var poolCreateProps = []string{"reclaim", "self_heal", "space_rb", poolPropProtected}
+
+// newPoolSetPropReq returns a set-prop request for the named pool property,
+// with the value supplied as a number if it is numeric.
+func newPoolSetPropReq(poolUUID, name, value string) *mgmtpb.PoolSetPropReq {
+	req := &mgmtpb.PoolSetPropReq{Uuid: poolUUID}
+	req.SetPropertyName(name)
+	if num, err := strconv.ParseUint(value, 10, 64); err == nil {
+		req.SetValueNumber(num)
+	} else {
+		req.SetValueString(value)
+	}
+
+	return req
+}
+
+// checkPoolCreateProp validates a pool property to be set at pool create
+// time and returns its name and value in normalized form.
+func checkPoolCreateProp(name, value string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return "", "", errors.Errorf("missing value for pool property %q", name)
+	}
+
+	switch name {
+	case poolPropProtected:
+		protected, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", "", errors.Errorf("invalid %s value %q (valid values: true, false)",
+				poolPropProtected, value)
+		}
+		value = strconv.FormatBool(protected)
+	case "reclaim", "self_heal", "space_rb":
+		if _, err := resolvePoolPropVal(newPoolSetPropReq("", name, value)); err != nil {
+			return "", "", err
+		}
+	default:
+		return "", "", errors.Errorf("pool property %q cannot be set at pool create (valid properties: %s)",
+			name, strings.Join(poolCreateProps, ", "))
+	}
+
+	return name, value, nil
+}
+
+// resolvePoolCreateProps merges the properties supplied in a pool create
+// request with the system-level defaults. A default is applied to any
+// property not supplied in the request. A supplied property overrides the
+// default unless the default is enforced, in which case supplying a
+// different value is an error.
+func resolvePoolCreateProps(reqProps []*mgmtpb.PoolProperty, defaults []*system.PoolPropDefault) ([]*mgmtpb.PoolProperty, error) {
+	props := make(map[string]string)
+	for _, prop := range reqProps {
+		name, value, err := checkPoolCreateProp(prop.GetName(), prop.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		if _, dupe := props[name]; dupe {
+			return nil, errors.Errorf("pool property %q supplied more than once", name)
+		}
+		props[name] = value
+	}
+
+	for _, pd := range defaults {
+		value, found := props[pd.Name]
+		switch {
+		case !found:
+			props[pd.Name] = pd.Value
+		case pd.Enforced && value != pd.Value:
+			return nil, FaultPoolPropertyEnforced(pd.Name, pd.Value)
+		}
+	}
+
+	out := make([]*mgmtpb.PoolProperty, 0, len(props))
+	for name, value := range props {
+		out = append(out, &mgmtpb.PoolProperty{Name: name, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out, nil
+}
+
+// setPoolCreateProps sets the properties of a newly created pool via its
+// service replicas. The protected property is only stored in the MS and is
+// skipped.
+func (svc *mgmtSvc) setPoolCreateProps(ctx context.Context, poolUUID string, svcRanks []uint32, props []*mgmtpb.PoolProperty) error {
+	for _, prop := range props {
+		if prop.GetName() == poolPropProtected {
+			continue
+		}
+
+		req, err := resolvePoolPropVal(newPoolSetPropReq(poolUUID, prop.GetName(), prop.GetValue()))
+		if err != nil {
+			return err
+		}
+		req.SvcRanks = svcRanks
+
+		dresp, err := svc.makePoolServiceCall(ctx, drpc.MethodPoolSetProp, req)
+		if err != nil {
+			return errors.Wrapf(err, "setting pool property %s", prop.GetName())
+		}
+
+		resp := new(mgmtpb.PoolSetPropResp)
+		if err := proto.Unmarshal(dresp.Body, resp); err != nil {
+			return errors.Wrap(err, "unmarshal PoolSetProp response")
+		}
+		if resp.GetStatus() != 0 {
+			return errors.Wrapf(drpc.DaosStatus(resp.GetStatus()),
+				"setting pool property %s", prop.GetName())
+		}
+	}
+
+	return nil
+}
+
+// SystemPoolDefaults implements the method defined for the Management Service.
+//
+// Set, unset or query the system-level pool property defaults which are
+// applied to pools created without a value for the property.
+func (svc *mgmtSvc) SystemPoolDefaults(ctx context.Context, req *mgmtpb.SystemPoolDefaultsReq) (*mgmtpb.SystemPoolDefaultsResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemPoolDefaults RPC: %+v", req)
+
+	if len(req.GetSet()) > 0 || len(req.GetUnset()) > 0 {
+		set := make([]*system.PoolPropDefault, 0, len(req.GetSet()))
+		for _, pd := range req.GetSet() {
+			name, value, err := checkPoolCreateProp(pd.GetName(), pd.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, &system.PoolPropDefault{
+				Name:     name,
+				Value:    value,
+				Enforced: pd.GetEnforced(),
+			})
+		}
+
+		unset := make([]string, 0, len(req.GetUnset()))
+		for _, name := range req.GetUnset() {
+			unset = append(unset, strings.ToLower(strings.TrimSpace(name)))
+		}
+
+		if err := svc.sysdb.UpdatePoolPropDefaults(set, unset); err != nil {
+			return nil, err
+		}
+	}
+
+	defaults, err := svc.sysdb.PoolPropDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(mgmtpb.SystemPoolDefaultsResp)
+	for _, pd := range defaults {
+		resp.Defaults = append(resp.Defaults, &mgmtpb.PoolPropDefault{
+			Name:     pd.Name,
+			Value:    pd.Value,
+			Enforced: pd.Enforced,
+		})
+	}
+
+	svc.log.Debugf("Responding to SystemPoolDefaults RPC: %+v", resp)
+
+	return resp, nil
+}
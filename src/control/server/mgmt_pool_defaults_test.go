@@ -0,0 +1,215 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_resolvePoolCreateProps(t *testing.T) {
+	for name, tc := range map[string]struct {
+		reqProps []*mgmtpb.PoolProperty
+		defaults []*system.PoolPropDefault
+		expProps []*mgmtpb.PoolProperty
+		expErr   error
+	}{
+		"none": {
+			expProps: []*mgmtpb.PoolProperty{},
+		},
+		"request only, normalized": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: " Self_Heal", Value: "Rebuild "},
+				{Name: "space_rb", Value: "10"},
+				{Name: "protected", Value: "1"},
+			},
+			expProps: []*mgmtpb.PoolProperty{
+				{Name: "protected", Value: "true"},
+				{Name: "self_heal", Value: "rebuild"},
+				{Name: "space_rb", Value: "10"},
+			},
+		},
+		"defaults applied": {
+			defaults: []*system.PoolPropDefault{
+				{Name: "reclaim", Value: "lazy"},
+				{Name: "self_heal", Value: "exclude", Enforced: true},
+			},
+			expProps: []*mgmtpb.PoolProperty{
+				{Name: "reclaim", Value: "lazy"},
+				{Name: "self_heal", Value: "exclude"},
+			},
+		},
+		"default overridden": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "reclaim", Value: "time"},
+			},
+			defaults: []*system.PoolPropDefault{
+				{Name: "reclaim", Value: "lazy"},
+			},
+			expProps: []*mgmtpb.PoolProperty{
+				{Name: "reclaim", Value: "time"},
+			},
+		},
+		"enforced default matched": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "self_heal", Value: "EXCLUDE"},
+			},
+			defaults: []*system.PoolPropDefault{
+				{Name: "self_heal", Value: "exclude", Enforced: true},
+			},
+			expProps: []*mgmtpb.PoolProperty{
+				{Name: "self_heal", Value: "exclude"},
+			},
+		},
+		"enforced default overridden": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "self_heal", Value: "rebuild"},
+			},
+			defaults: []*system.PoolPropDefault{
+				{Name: "self_heal", Value: "exclude", Enforced: true},
+			},
+			expErr: FaultPoolPropertyEnforced("self_heal", "exclude"),
+		},
+		"duplicate property": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "reclaim", Value: "lazy"},
+				{Name: "RECLAIM", Value: "time"},
+			},
+			expErr: errors.New("more than once"),
+		},
+		"label not allowed": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "label", Value: "foo"},
+			},
+			expErr: errors.New("cannot be set at pool create"),
+		},
+		"bad value": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "space_rb", Value: "101"},
+			},
+			expErr: errors.New("invalid space_rb value"),
+		},
+		"missing value": {
+			reqProps: []*mgmtpb.PoolProperty{
+				{Name: "reclaim"},
+			},
+			expErr: errors.New("missing value"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotProps, gotErr := resolvePoolCreateProps(tc.reqProps, tc.defaults)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expProps, gotProps, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected properties (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemPoolDefaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nilReq      bool
+		prior       []*mgmtpb.SystemPoolDefaultsReq
+		req         *mgmtpb.SystemPoolDefaultsReq
+		expDefaults []*mgmtpb.PoolPropDefault
+		expErr      error
+	}{
+		"nil request": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"query empty": {
+			req: &mgmtpb.SystemPoolDefaultsReq{},
+		},
+		"set": {
+			req: &mgmtpb.SystemPoolDefaultsReq{
+				Set: []*mgmtpb.PoolPropDefault{
+					{Name: "self_heal", Value: "Exclude", Enforced: true},
+					{Name: "reclaim", Value: "lazy"},
+				},
+			},
+			expDefaults: []*mgmtpb.PoolPropDefault{
+				{Name: "reclaim", Value: "lazy"},
+				{Name: "self_heal", Value: "exclude", Enforced: true},
+			},
+		},
+		"unset and query": {
+			prior: []*mgmtpb.SystemPoolDefaultsReq{
+				{Set: []*mgmtpb.PoolPropDefault{
+					{Name: "self_heal", Value: "exclude"},
+					{Name: "reclaim", Value: "lazy"},
+				}},
+			},
+			req: &mgmtpb.SystemPoolDefaultsReq{
+				Unset: []string{"Reclaim"},
+			},
+			expDefaults: []*mgmtpb.PoolPropDefault{
+				{Name: "self_heal", Value: "exclude"},
+			},
+		},
+		"invalid property": {
+			req: &mgmtpb.SystemPoolDefaultsReq{
+				Set: []*mgmtpb.PoolPropDefault{
+					{Name: "ec_cell_sz", Value: "65536"},
+				},
+			},
+			expErr: errors.New("cannot be set at pool create"),
+		},
+		"invalid value": {
+			req: &mgmtpb.SystemPoolDefaultsReq{
+				Set: []*mgmtpb.PoolPropDefault{
+					{Name: "self_heal", Value: "sometimes"},
+				},
+			},
+			expErr: errors.New("unhandled self_heal type"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+
+			for _, pr := range tc.prior {
+				pr.Sys = build.DefaultSystemName
+				if _, err := svc.SystemPoolDefaults(context.TODO(), pr); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else if req.Sys == "" {
+				req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemPoolDefaults(context.TODO(), req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expDefaults, gotResp.Defaults, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected defaults (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// Strategies for selecting the target ranks of a pool when the create request
+// does not supply a rank list.
+const (
+	// poolRankStrategySpread spreads ranks across as many fault domains as
+	// possible, preferring the ranks with the most free capacity in each.
+	poolRankStrategySpread = "spread"
+	// poolRankStrategyPack fills as few fault domains as possible, preferring
+	// the ranks with the least free capacity so that capacity remains
+	// available for larger pools. As with the other strategies, ranks known
+	// to have too little free capacity for the pool are excluded.
+	poolRankStrategyPack = "pack"
+	// poolRankStrategyBalanced prefers the ranks with the most free capacity
+	// to even out utilization across the system.
+	poolRankStrategyBalanced = "balanced"
+
+	defaultPoolRankStrategy = poolRankStrategySpread
+
+	// rankUsageQueryTimeout bounds the query of SSD usage and health made
+	// when selecting pool ranks.
+	rankUsageQueryTimeout = 10 * time.Second
+)
+
+// rankCandidate holds the details of a rank considered for a new pool.
+type rankCandidate struct {
+	rank       system.Rank
+	domain     string
+	freeKnown  bool   // free capacity reported by the rank's SSDs
+	freeBytes  uint64 // free NVMe capacity
+	allocBytes uint64 // capacity allocated to existing pools
+	faultyDevs int    // SSDs not in the NORMAL state
+	failures   int    // failures recorded by the fault policy
+	reason     string // reason for the position in the strategy order
+	coversMin  bool   // picked to span the minimum number of fault domains
+}
+
+func (rc *rankCandidate) healthy() bool {
+	return rc.faultyDevs == 0 && rc.failures == 0
+}
+
+// moreFree returns true if a has more free capacity than b. Allocations to
+// existing pools are compared when free capacity was not reported by both.
+func moreFree(a, b *rankCandidate) bool {
+	if a.freeKnown && b.freeKnown && a.freeBytes != b.freeBytes {
+		return a.freeBytes > b.freeBytes
+	}
+	if a.allocBytes != b.allocBytes {
+		return a.allocBytes < b.allocBytes
+	}
+	return a.rank < b.rank
+}
+
+// rankDevices summarizes the SSDs of a rank.
+type rankDevices struct {
+	freeBytes  uint64
+	faultyDevs int
+}
+
+// rankDeviceUsage queries the hosts of the given ranks for the usage and state
+// of their SSDs.
+func (svc *mgmtSvc) rankDeviceUsage(ctx context.Context, ranks []system.Rank) (map[system.Rank]*rankDevices, error) {
+	if svc.rpcClient == nil {
+		return nil, errors.New("no control API client")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rankUsageQueryTimeout)
+	defer cancel()
+
+	req := &control.SmdQueryReq{
+		OmitPools:        true,
+		IncludeBioHealth: true,
+		Rank:             system.NilRank,
+	}
+	req.SetHostList(svc.membership.HostList(system.RankSetFromRanks(ranks)))
+	resp, err := control.SmdQuery(ctx, svc.rpcClient, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Errors(); err != nil {
+		svc.log.Debugf("pool rank selection: SSD query: %s", err)
+	}
+
+	usage := make(map[system.Rank]*rankDevices)
+	for _, hss := range resp.HostStorage {
+		if hss.HostStorage == nil || hss.HostStorage.SmdInfo == nil {
+			continue
+		}
+		for _, dev := range hss.HostStorage.SmdInfo.Devices {
+			rd, found := usage[dev.Rank]
+			if !found {
+				rd = new(rankDevices)
+				usage[dev.Rank] = rd
+			}
+			if dev.State != "NORMAL" {
+				rd.faultyDevs++
+				continue
+			}
+			rd.freeBytes += dev.AvailBytes
+		}
+	}
+
+	return usage, nil
+}
+
+// rankAllocations returns the capacity allocated on each rank to existing
+// pools.
+func (svc *mgmtSvc) rankAllocations() (map[system.Rank]uint64, error) {
+	pools, err := svc.sysdb.PoolServiceList()
+	if err != nil {
+		return nil, err
+	}
+
+	alloc := make(map[system.Rank]uint64)
+	for _, ps := range pools {
+		if ps.Storage == nil {
+			continue
+		}
+		for _, rank := range ps.Storage.CurrentRanks() {
+			alloc[rank] += ps.Storage.ScmPerRank + ps.Storage.NVMePerRank
+		}
+	}
+
+	return alloc, nil
+}
+
+// rankCandidates gathers the free capacity, health and fault domain of the
+// available ranks. The SSDs of the ranks are only queried if queryUsage is
+// set, i.e. when a subset of the ranks is to be selected.
+func (svc *mgmtSvc) rankCandidates(ctx context.Context, ranks []system.Rank, rankDomains map[system.Rank]string, queryUsage bool) ([]*rankCandidate, error) {
+	alloc, err := svc.rankAllocations()
+	if err != nil {
+		return nil, err
+	}
+
+	var usage map[system.Rank]*rankDevices
+	if queryUsage {
+		usage, err = svc.rankDeviceUsage(ctx, ranks)
+		if err != nil {
+			svc.log.Infof("pool rank selection: free capacity unavailable, using pool allocations: %s", err)
+		}
+	}
+
+	candidates := make([]*rankCandidate, len(ranks))
+	for i, rank := range ranks {
+		rc := &rankCandidate{
+			rank:       rank,
+			domain:     rankDomains[rank],
+			allocBytes: alloc[rank],
+			failures:   svc.faultPolicy.failures(rankSubject(rank)),
+		}
+		if rd, found := usage[rank]; found {
+			rc.freeKnown = true
+			rc.freeBytes = rd.freeBytes
+			rc.faultyDevs = rd.faultyDevs
+		}
+		candidates[i] = rc
+	}
+
+	return candidates, nil
+}
+
+// orderRankCandidates orders the candidates by preference according to the
+// given strategy, with unhealthy ranks last.
+func orderRankCandidates(candidates []*rankCandidate, strategy string) ([]*rankCandidate, error) {
+	ordered := make([]*rankCandidate, len(candidates))
+	copy(ordered, candidates)
+
+	switch strategy {
+	case poolRankStrategySpread:
+		sort.Slice(ordered, func(i, j int) bool { return moreFree(ordered[i], ordered[j]) })
+		byRank := make(map[system.Rank]*rankCandidate, len(ordered))
+		ranks := make([]system.Rank, len(ordered))
+		rankDomains := make(map[system.Rank]string, len(ordered))
+		for i, rc := range ordered {
+			byRank[rc.rank] = rc
+			ranks[i] = rc.rank
+			rankDomains[rc.rank] = rc.domain
+		}
+		for i, rank := range spreadRanksAcrossDomains(groupRanksByDomain(ranks, rankDomains)) {
+			ordered[i] = byRank[rank]
+			ordered[i].reason = "spread across fault domains"
+		}
+	case poolRankStrategyPack:
+		domainSize := make(map[string]int)
+		for _, rc := range ordered {
+			domainSize[rc.domain]++
+		}
+		sort.Slice(ordered, func(i, j int) bool {
+			a, b := ordered[i], ordered[j]
+			if a.domain != b.domain {
+				if domainSize[a.domain] != domainSize[b.domain] {
+					return domainSize[a.domain] > domainSize[b.domain]
+				}
+				return a.domain < b.domain
+			}
+			return moreFree(b, a)
+		})
+		for _, rc := range ordered {
+			rc.reason = "packed into fewest fault domains"
+		}
+	case poolRankStrategyBalanced:
+		sort.Slice(ordered, func(i, j int) bool { return moreFree(ordered[i], ordered[j]) })
+		for _, rc := range ordered {
+			rc.reason = "most free capacity"
+		}
+	default:
+		return nil, errors.Errorf("unknown rank selection strategy %q (valid strategies: %s)",
+			strategy, strings.Join([]string{poolRankStrategySpread, poolRankStrategyPack,
+				poolRankStrategyBalanced}, ", "))
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.healthy() != b.healthy() {
+			return a.healthy()
+		}
+		return a.failures+a.faultyDevs < b.failures+b.faultyDevs
+	})
+
+	return ordered, nil
+}
+
+// pickRanks returns the first n of the ordered candidates, ensuring that they
+// span at least minDomains fault domains.
+func pickRanks(ordered []*rankCandidate, n, minDomains int) []*rankCandidate {
+	picked := make([]bool, len(ordered))
+	seen := make(map[string]bool)
+	var selected []*rankCandidate
+	for i, rc := range ordered {
+		if len(seen) >= minDomains {
+			break
+		}
+		if !seen[rc.domain] {
+			seen[rc.domain] = true
+			picked[i] = true
+			rc.coversMin = true
+			selected = append(selected, rc)
+		}
+	}
+	for i, rc := range ordered {
+		if len(selected) >= n {
+			break
+		}
+		if !picked[i] {
+			selected = append(selected, rc)
+		}
+	}
+
+	return selected
+}
+
+// selectPoolRanks chooses the target ranks for a pool from the available
+// ranks according to the strategy in the request, excluding ranks known to
+// have insufficient free capacity. The rationale for each selected rank is
+// returned along with the strategy used.
+func selectPoolRanks(req *mgmtpb.PoolCreateReq, candidates []*rankCandidate, minDomains int) (string, []*mgmtpb.PoolRankChoice, error) {
+	strategy := req.GetRankstrategy()
+	if strategy == "" {
+		strategy = defaultPoolRankStrategy
+	}
+
+	nRanks := len(candidates)
+	if req.GetNumranks() > 0 {
+		nRanks = int(req.GetNumranks())
+	}
+	allRanks := nRanks == len(candidates)
+
+	var needBytes uint64
+	switch {
+	case req.GetNvmebytes() > 0:
+		needBytes = req.GetNvmebytes()
+	case req.GetTotalbytes() > 0 && nRanks > 0:
+		needBytes = req.GetTotalbytes() / uint64(nRanks)
+	}
+
+	ordered, err := orderRankCandidates(candidates, strategy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var eligible []*rankCandidate
+	for _, rc := range ordered {
+		if rc.freeKnown && rc.freeBytes < needBytes {
+			continue
+		}
+		eligible = append(eligible, rc)
+	}
+	if nRanks > len(eligible) {
+		return "", nil, errors.Errorf("%d ranks requested but only %d of %d available ranks have %s free",
+			nRanks, len(eligible), len(candidates), humanize.Bytes(needBytes))
+	}
+	selected := pickRanks(eligible, nRanks, minDomains)
+	domains := make(map[string]bool)
+	for _, rc := range selected {
+		domains[rc.domain] = true
+	}
+	if len(domains) < minDomains {
+		return "", nil, FaultPoolInsufficientFaultDomains(minDomains, len(domains))
+	}
+
+	choices := make([]*mgmtpb.PoolRankChoice, len(selected))
+	for i, rc := range selected {
+		reasons := []string{rc.reason}
+		switch {
+		case allRanks:
+			reasons[0] = "all available ranks used"
+		case rc.coversMin && strategy != poolRankStrategySpread:
+			reasons[0] = fmt.Sprintf("required to span %d fault domains", minDomains)
+		}
+		switch {
+		case rc.freeKnown:
+			reasons = append(reasons, humanize.Bytes(rc.freeBytes)+" free")
+		case rc.allocBytes > 0:
+			reasons = append(reasons, humanize.Bytes(rc.allocBytes)+" allocated to pools")
+		}
+		if rc.failures > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d recent failures", rc.failures))
+		}
+		if rc.faultyDevs > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d unhealthy SSDs", rc.faultyDevs))
+		}
+		reason := strings.Join(reasons, ", ")
+		choices[i] = &mgmtpb.PoolRankChoice{
+			Rank:      rc.rank.Uint32(),
+			Domain:    rc.domain,
+			FreeBytes: rc.freeBytes,
+			Failures:  uint32(rc.failures),
+			Reason:    reason,
+		}
+	}
+	sort.Slice(choices, func(i, j int) bool { return choices[i].Rank < choices[j].Rank })
+
+	return strategy, choices, nil
+}
@@ -0,0 +1,301 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_selectPoolRanks(t *testing.T) {
+	// rank, domain and free capacity of the available ranks
+	mockCandidates := func() []*rankCandidate {
+		return []*rankCandidate{
+			{rank: 0, domain: "/rack0", freeKnown: true, freeBytes: 4 * humanize.TByte},
+			{rank: 1, domain: "/rack0", freeKnown: true, freeBytes: 3 * humanize.TByte},
+			{rank: 2, domain: "/rack1", freeKnown: true, freeBytes: 2 * humanize.TByte},
+			{rank: 3, domain: "/rack1", freeKnown: true, freeBytes: 1 * humanize.TByte},
+			{rank: 4, domain: "/rack2", freeKnown: true, freeBytes: 500 * humanize.GByte},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req         *mgmtpb.PoolCreateReq
+		candidates  []*rankCandidate
+		minDomains  int
+		expStrategy string
+		expChoices  []*mgmtpb.PoolRankChoice
+		expErr      error
+	}{
+		"default strategy spreads across domains": {
+			req:         &mgmtpb.PoolCreateReq{Numranks: 3},
+			expStrategy: poolRankStrategySpread,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/rack0", FreeBytes: 4 * humanize.TByte, Reason: "spread across fault domains, 4.0 TB free"},
+				{Rank: 2, Domain: "/rack1", FreeBytes: 2 * humanize.TByte, Reason: "spread across fault domains, 2.0 TB free"},
+				{Rank: 4, Domain: "/rack2", FreeBytes: 500 * humanize.GByte, Reason: "spread across fault domains, 500 GB free"},
+			},
+		},
+		"balanced": {
+			req:         &mgmtpb.PoolCreateReq{Numranks: 3, Rankstrategy: poolRankStrategyBalanced},
+			expStrategy: poolRankStrategyBalanced,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/rack0", FreeBytes: 4 * humanize.TByte, Reason: "most free capacity, 4.0 TB free"},
+				{Rank: 1, Domain: "/rack0", FreeBytes: 3 * humanize.TByte, Reason: "most free capacity, 3.0 TB free"},
+				{Rank: 2, Domain: "/rack1", FreeBytes: 2 * humanize.TByte, Reason: "most free capacity, 2.0 TB free"},
+			},
+		},
+		"pack": {
+			req:         &mgmtpb.PoolCreateReq{Numranks: 2, Rankstrategy: poolRankStrategyPack},
+			expStrategy: poolRankStrategyPack,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/rack0", FreeBytes: 4 * humanize.TByte, Reason: "packed into fewest fault domains, 4.0 TB free"},
+				{Rank: 1, Domain: "/rack0", FreeBytes: 3 * humanize.TByte, Reason: "packed into fewest fault domains, 3.0 TB free"},
+			},
+		},
+		"pack skips ranks without enough free capacity": {
+			req: &mgmtpb.PoolCreateReq{
+				Numranks:     1,
+				Nvmebytes:    3500 * humanize.GByte,
+				Rankstrategy: poolRankStrategyPack,
+			},
+			expStrategy: poolRankStrategyPack,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/rack0", FreeBytes: 4 * humanize.TByte, Reason: "packed into fewest fault domains, 4.0 TB free"},
+			},
+		},
+		"pack spans min domains": {
+			req:         &mgmtpb.PoolCreateReq{Numranks: 2, Rankstrategy: poolRankStrategyPack},
+			minDomains:  2,
+			expStrategy: poolRankStrategyPack,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 1, Domain: "/rack0", FreeBytes: 3 * humanize.TByte, Reason: "required to span 2 fault domains, 3.0 TB free"},
+				{Rank: 3, Domain: "/rack1", FreeBytes: 1 * humanize.TByte, Reason: "required to span 2 fault domains, 1.0 TB free"},
+			},
+		},
+		"unhealthy ranks deprioritized": {
+			req: &mgmtpb.PoolCreateReq{Numranks: 2, Rankstrategy: poolRankStrategyBalanced},
+			candidates: func() []*rankCandidate {
+				candidates := mockCandidates()
+				candidates[0].failures = 2
+				candidates[1].faultyDevs = 1
+				return candidates
+			}(),
+			expStrategy: poolRankStrategyBalanced,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 2, Domain: "/rack1", FreeBytes: 2 * humanize.TByte, Reason: "most free capacity, 2.0 TB free"},
+				{Rank: 3, Domain: "/rack1", FreeBytes: 1 * humanize.TByte, Reason: "most free capacity, 1.0 TB free"},
+			},
+		},
+		"unhealthy ranks used when required": {
+			req: &mgmtpb.PoolCreateReq{Numranks: 1, Nvmebytes: 3500 * humanize.GByte},
+			candidates: func() []*rankCandidate {
+				candidates := mockCandidates()
+				candidates[0].failures = 2
+				return candidates
+			}(),
+			expStrategy: poolRankStrategySpread,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/rack0", FreeBytes: 4 * humanize.TByte, Failures: 2, Reason: "spread across fault domains, 4.0 TB free, 2 recent failures"},
+			},
+		},
+		"insufficient free capacity": {
+			req:    &mgmtpb.PoolCreateReq{Numranks: 3, Totalbytes: 7500 * humanize.GByte},
+			expErr: errors.New("3 ranks requested but only 2 of 5 available ranks have 2.5 TB free"),
+		},
+		"insufficient fault domains": {
+			req: &mgmtpb.PoolCreateReq{
+				Numranks:     2,
+				Nvmebytes:    2500 * humanize.GByte,
+				Rankstrategy: poolRankStrategyBalanced,
+			},
+			minDomains: 2,
+			expErr:     FaultPoolInsufficientFaultDomains(2, 1),
+		},
+		"unknown strategy": {
+			req:    &mgmtpb.PoolCreateReq{Rankstrategy: "random"},
+			expErr: errors.New("unknown rank selection strategy \"random\""),
+		},
+		"all ranks": {
+			req: &mgmtpb.PoolCreateReq{Rankstrategy: poolRankStrategyPack},
+			candidates: []*rankCandidate{
+				{rank: 0, domain: "/", freeKnown: true, freeBytes: humanize.TByte},
+				{rank: 1, domain: "/", freeKnown: true, freeBytes: humanize.TByte},
+			},
+			expStrategy: poolRankStrategyPack,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/", FreeBytes: humanize.TByte, Reason: "all available ranks used, 1.0 TB free"},
+				{Rank: 1, Domain: "/", FreeBytes: humanize.TByte, Reason: "all available ranks used, 1.0 TB free"},
+			},
+		},
+		"free capacity unknown; use pool allocations": {
+			req: &mgmtpb.PoolCreateReq{Numranks: 2, Rankstrategy: poolRankStrategyBalanced},
+			candidates: []*rankCandidate{
+				{rank: 0, domain: "/", allocBytes: humanize.TByte},
+				{rank: 1, domain: "/"},
+				{rank: 2, domain: "/", allocBytes: 2 * humanize.TByte},
+			},
+			expStrategy: poolRankStrategyBalanced,
+			expChoices: []*mgmtpb.PoolRankChoice{
+				{Rank: 0, Domain: "/", Reason: "most free capacity, 1.0 TB allocated to pools"},
+				{Rank: 1, Domain: "/", Reason: "most free capacity"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			candidates := tc.candidates
+			if candidates == nil {
+				candidates = mockCandidates()
+			}
+
+			gotStrategy, gotChoices, gotErr := selectPoolRanks(tc.req, candidates, tc.minDomains)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expStrategy, gotStrategy, "unexpected strategy")
+			if diff := cmp.Diff(tc.expChoices, gotChoices, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected rank choices (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_mgmtSvc_rankCandidates(t *testing.T) {
+	smdResp := control.MockMSResponse(common.MockHostAddr(1).String(), nil,
+		&ctlpb.SmdQueryResp{
+			Ranks: []*ctlpb.SmdQueryResp_RankResp{
+				{
+					Rank: 0,
+					Devices: []*ctlpb.SmdQueryResp_Device{
+						{
+							Uuid:   common.MockUUID(0),
+							State:  "NORMAL",
+							Health: &ctlpb.BioHealthResp{AvailBytes: humanize.TByte},
+						},
+						{
+							Uuid:   common.MockUUID(1),
+							State:  "NORMAL",
+							Health: &ctlpb.BioHealthResp{AvailBytes: humanize.TByte},
+						},
+					},
+				},
+				{
+					Rank: 1,
+					Devices: []*ctlpb.SmdQueryResp_Device{
+						{
+							Uuid:   common.MockUUID(2),
+							State:  "NORMAL",
+							Health: &ctlpb.BioHealthResp{AvailBytes: humanize.TByte},
+						},
+						{
+							Uuid:   common.MockUUID(3),
+							State:  "FAULTY",
+							Health: &ctlpb.BioHealthResp{AvailBytes: humanize.TByte},
+						},
+					},
+				},
+			},
+		})
+	rankDomains := map[system.Rank]string{0: "/rack0", 1: "/rack1"}
+
+	for name, tc := range map[string]struct {
+		smdResp       *control.UnaryResponse
+		noQuery       bool
+		expCandidates []*rankCandidate
+	}{
+		"free capacity and health reported": {
+			smdResp: smdResp,
+			expCandidates: []*rankCandidate{
+				{
+					rank: 0, domain: "/rack0", allocBytes: 2 * humanize.GByte,
+					freeKnown: true, freeBytes: 2 * humanize.TByte,
+				},
+				{
+					rank: 1, domain: "/rack1", allocBytes: 2 * humanize.GByte,
+					freeKnown: true, freeBytes: humanize.TByte, faultyDevs: 1, failures: 2,
+				},
+			},
+		},
+		"query fails; use pool allocations": {
+			smdResp: &control.UnaryResponse{},
+			expCandidates: []*rankCandidate{
+				{rank: 0, domain: "/rack0", allocBytes: 2 * humanize.GByte},
+				{rank: 1, domain: "/rack1", allocBytes: 2 * humanize.GByte, failures: 2},
+			},
+		},
+		"usage not queried": {
+			smdResp: smdResp,
+			noQuery: true,
+			expCandidates: []*rankCandidate{
+				{rank: 0, domain: "/rack0", allocBytes: 2 * humanize.GByte},
+				{rank: 1, domain: "/rack1", allocBytes: 2 * humanize.GByte, failures: 2},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			for _, m := range (system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "joined"),
+			}) {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := svc.sysdb.AddPoolService(&system.PoolService{
+				PoolUUID: uuid.MustParse(common.MockUUID(0)),
+				State:    system.PoolServiceStateReady,
+				Storage: &system.PoolServiceStorage{
+					CurrentRankStr: "0-1",
+					ScmPerRank:     humanize.GByte,
+					NVMePerRank:    humanize.GByte,
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			svc.rpcClient = control.NewMockInvoker(log, &control.MockInvokerConfig{
+				UnaryResponse: tc.smdResp,
+			})
+			svc.faultPolicy = newFaultPolicy(config.FaultPolicy{
+				Enabled: true,
+				Window:  time.Hour,
+			})
+			for i := 0; i < 2; i++ {
+				svc.faultPolicy.record(rankSubject(1), 10, 0)
+			}
+
+			got, err := svc.rankCandidates(context.Background(), []system.Rank{0, 1}, rankDomains, !tc.noQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expCandidates, got, cmp.AllowUnexported(rankCandidate{})); diff != "" {
+				t.Fatalf("unexpected candidates (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
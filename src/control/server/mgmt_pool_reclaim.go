@@ -0,0 +1,76 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"time"
+
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+// poolReclaimInterval is the interval at which the MS leader checks for
+// disabled pools whose destroy delay has elapsed.
+const poolReclaimInterval = time.Minute
+
+func (svc *mgmtSvc) startPoolReclaimLoop(ctx context.Context) {
+	svc.log.Debug("starting poolReclaimLoop")
+	go svc.poolReclaimLoop(ctx)
+}
+
+func (svc *mgmtSvc) poolReclaimLoop(parent context.Context) {
+	reclaimTimer := time.NewTicker(poolReclaimInterval)
+	defer reclaimTimer.Stop()
+
+	for {
+		select {
+		case <-parent.Done():
+			svc.log.Debug("stopped poolReclaimLoop")
+			return
+		case <-reclaimTimer.C:
+			svc.reclaimPools(parent, time.Now())
+		}
+	}
+}
+
+// reclaimPools destroys the disabled pools whose destroy delay has elapsed.
+// Failures are logged and the destroy is retried on the next interval. Only
+// the MS leader reclaims pools.
+func (svc *mgmtSvc) reclaimPools(ctx context.Context, now time.Time) {
+	if !svc.sysdb.IsLeader() {
+		return
+	}
+
+	psList, err := svc.sysdb.PoolServiceList()
+	if err != nil {
+		svc.log.Errorf("failed to list pools for reclaim: %s", err)
+		return
+	}
+
+	for _, ps := range psList {
+		if ps.State != system.PoolServiceStateDisabled || now.Before(ps.DestroyAfter) {
+			continue
+		}
+
+		svc.log.Infof("destroying disabled pool %s", ps.PoolUUID)
+		resp, err := svc.PoolDestroy(ctx, &mgmtpb.PoolDestroyReq{
+			Sys:   svc.sysdb.SystemName(),
+			Uuid:  ps.PoolUUID.String(),
+			Force: true,
+		})
+		if err != nil {
+			svc.log.Errorf("failed to destroy disabled pool %s: %s", ps.PoolUUID, err)
+			continue
+		}
+		if resp.Status != 0 {
+			svc.log.Errorf("failed to destroy disabled pool %s: %s", ps.PoolUUID,
+				drpc.DaosStatus(resp.Status))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	uuid "github.com/google/uuid"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_MgmtSvc_reclaimPools(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		pools        []*system.PoolService
+		expDestroyed []string
+	}{
+		"no pools": {},
+		"nothing to reclaim": {
+			pools: []*system.PoolService{
+				{
+					PoolUUID: uuid.MustParse(common.MockUUID(1)),
+					State:    system.PoolServiceStateReady,
+					Replicas: []system.Rank{0},
+				},
+				{
+					PoolUUID:     uuid.MustParse(common.MockUUID(2)),
+					State:        system.PoolServiceStateDisabled,
+					Replicas:     []system.Rank{0},
+					DestroyAfter: now.Add(time.Minute),
+				},
+			},
+		},
+		"expired pool destroyed": {
+			pools: []*system.PoolService{
+				{
+					PoolUUID: uuid.MustParse(common.MockUUID(1)),
+					State:    system.PoolServiceStateReady,
+					Replicas: []system.Rank{0},
+				},
+				{
+					PoolUUID:     uuid.MustParse(common.MockUUID(2)),
+					State:        system.PoolServiceStateDisabled,
+					Replicas:     []system.Rank{0},
+					DestroyAfter: now.Add(-time.Minute),
+				},
+			},
+			expDestroyed: []string{common.MockUUID(2)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			for _, ps := range tc.pools {
+				addTestPoolService(t, svc.sysdb, ps)
+			}
+			setupMockDrpcClient(svc, &mgmtpb.PoolDestroyResp{}, nil)
+
+			svc.reclaimPools(context.TODO(), now)
+
+			var gotDestroyed []string
+			for _, ps := range tc.pools {
+				_, err := svc.sysdb.FindPoolServiceByUUID(ps.PoolUUID)
+				if system.IsPoolNotFound(err) {
+					gotDestroyed = append(gotDestroyed, ps.PoolUUID.String())
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if diff := cmp.Diff(tc.expDestroyed, gotDestroyed); diff != "" {
+				t.Fatalf("unexpected destroyed pools (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -242,9 +242,12 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 		setupMockDrpc func(_ *mgmtSvc, _ error)
 		targetCount   int
 		memberCount   int
+		memberDomains []string
+		poolDefaults  []*system.PoolPropDefault
 		req           *mgmtpb.PoolCreateReq
 		expResp       *mgmtpb.PoolCreateResp
 		expErr        error
+		expDestroyed  []uint32 // svc ranks of cleanup destroy
 	}{
 		"nil request": {
 			expErr: errors.New("nil request"),
@@ -317,6 +320,14 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 				ScmBytes:  (100 * humanize.GiByte),
 				NvmeBytes: (10 * humanize.TByte),
 				TgtRanks:  []uint32{0, 1},
+				FaultDomains: []*mgmtpb.PoolFaultDomain{
+					{Domain: "/", Ranks: []uint32{0, 1}},
+				},
+				RankStrategy: "spread",
+				RankChoices: []*mgmtpb.PoolRankChoice{
+					{Rank: 0, Domain: "/", Reason: "all available ranks used"},
+					{Rank: 1, Domain: "/", Reason: "all available ranks used"},
+				},
 			},
 		},
 		"successful creation minimum size": {
@@ -330,6 +341,14 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 				ScmBytes:  (engine.ScmMinBytesPerTarget * 8),
 				NvmeBytes: (engine.NvmeMinBytesPerTarget * 8),
 				TgtRanks:  []uint32{0, 1},
+				FaultDomains: []*mgmtpb.PoolFaultDomain{
+					{Domain: "/", Ranks: []uint32{0, 1}},
+				},
+				RankStrategy: "spread",
+				RankChoices: []*mgmtpb.PoolRankChoice{
+					{Rank: 0, Domain: "/", Reason: "all available ranks used"},
+					{Rank: 1, Domain: "/", Reason: "all available ranks used"},
+				},
 			},
 		},
 		"successful creation auto size": {
@@ -342,8 +361,161 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 				ScmBytes:  ((100 * humanize.GiByte) * DefaultPoolScmRatio) / 2,
 				NvmeBytes: (100 * humanize.GiByte) / 2,
 				TgtRanks:  []uint32{0, 1},
+				FaultDomains: []*mgmtpb.PoolFaultDomain{
+					{Domain: "/", Ranks: []uint32{0, 1}},
+				},
+				RankStrategy: "spread",
+				RankChoices: []*mgmtpb.PoolRankChoice{
+					{Rank: 0, Domain: "/", Reason: "all available ranks used"},
+					{Rank: 1, Domain: "/", Reason: "all available ranks used"},
+				},
 			},
 		},
+		"successful creation min domains": {
+			targetCount:   8,
+			memberCount:   4,
+			memberDomains: []string{"/rack1/host1", "/rack0/host2", "/rack1/host3", "/rack0/host4"},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:       common.MockUUID(0),
+				Scmbytes:   100 * humanize.GiByte,
+				Nvmebytes:  10 * humanize.TByte,
+				Mindomains: 2,
+			},
+			expResp: &mgmtpb.PoolCreateResp{
+				ScmBytes:  (100 * humanize.GiByte),
+				NvmeBytes: (10 * humanize.TByte),
+				TgtRanks:  []uint32{0, 1, 2, 3},
+				FaultDomains: []*mgmtpb.PoolFaultDomain{
+					{Domain: "/rack0", Ranks: []uint32{1, 3}},
+					{Domain: "/rack1", Ranks: []uint32{0, 2}},
+				},
+				RankStrategy: "spread",
+				RankChoices: []*mgmtpb.PoolRankChoice{
+					{Rank: 0, Domain: "/rack1", Reason: "all available ranks used"},
+					{Rank: 1, Domain: "/rack0", Reason: "all available ranks used"},
+					{Rank: 2, Domain: "/rack1", Reason: "all available ranks used"},
+					{Rank: 3, Domain: "/rack0", Reason: "all available ranks used"},
+				},
+			},
+		},
+		"successful creation with properties and defaults": {
+			targetCount: 8,
+			poolDefaults: []*system.PoolPropDefault{
+				{Name: "reclaim", Value: "lazy"},
+				{Name: "protected", Value: "true", Enforced: true},
+			},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:      common.MockUUID(0),
+				Scmbytes:  100 * humanize.GiByte,
+				Nvmebytes: 10 * humanize.TByte,
+				Properties: []*mgmtpb.PoolProperty{
+					{Name: "self_heal", Value: "exclude"},
+				},
+			},
+			setupMockDrpc: func(svc *mgmtSvc, err error) {
+				cfg := new(mockDrpcClientConfig)
+				cfg.setSendMsgResponseList(t,
+					&mockDrpcResponse{Message: &mgmtpb.PoolCreateResp{SvcReps: []uint32{0}}},
+					&mockDrpcResponse{Message: &mgmtpb.PoolSetPropResp{}},
+					&mockDrpcResponse{Message: &mgmtpb.PoolSetPropResp{}},
+				)
+				svc.harness.instances[0].setDrpcClient(newMockDrpcClient(cfg))
+			},
+			expResp: &mgmtpb.PoolCreateResp{
+				SvcReps:   []uint32{0},
+				ScmBytes:  (100 * humanize.GiByte),
+				NvmeBytes: (10 * humanize.TByte),
+				TgtRanks:  []uint32{0, 1},
+				FaultDomains: []*mgmtpb.PoolFaultDomain{
+					{Domain: "/", Ranks: []uint32{0, 1}},
+				},
+				RankStrategy: "spread",
+				RankChoices: []*mgmtpb.PoolRankChoice{
+					{Rank: 0, Domain: "/", Reason: "all available ranks used"},
+					{Rank: 1, Domain: "/", Reason: "all available ranks used"},
+				},
+				Properties: []*mgmtpb.PoolProperty{
+					{Name: "protected", Value: "true"},
+					{Name: "reclaim", Value: "lazy"},
+					{Name: "self_heal", Value: "exclude"},
+				},
+			},
+		},
+		"failed property set destroys pool on replicas": {
+			targetCount: 8,
+			poolDefaults: []*system.PoolPropDefault{
+				{Name: "reclaim", Value: "lazy"},
+			},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:      common.MockUUID(0),
+				Scmbytes:  100 * humanize.GiByte,
+				Nvmebytes: 10 * humanize.TByte,
+			},
+			setupMockDrpc: func(svc *mgmtSvc, err error) {
+				cfg := new(mockDrpcClientConfig)
+				cfg.setSendMsgResponseList(t,
+					&mockDrpcResponse{Message: &mgmtpb.PoolCreateResp{SvcReps: []uint32{1}}},
+					&mockDrpcResponse{Message: &mgmtpb.PoolSetPropResp{Status: int32(drpc.DaosIOError)}},
+					&mockDrpcResponse{Message: &mgmtpb.PoolDestroyResp{}},
+				)
+				svc.harness.instances[0].setDrpcClient(newMockDrpcClient(cfg))
+			},
+			expErr:       drpc.DaosIOError,
+			expDestroyed: []uint32{1},
+		},
+		"failed creation enforced default overridden": {
+			targetCount: 8,
+			poolDefaults: []*system.PoolPropDefault{
+				{Name: "self_heal", Value: "exclude", Enforced: true},
+			},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:      common.MockUUID(0),
+				Scmbytes:  100 * humanize.GiByte,
+				Nvmebytes: 10 * humanize.TByte,
+				Properties: []*mgmtpb.PoolProperty{
+					{Name: "self_heal", Value: "rebuild"},
+				},
+			},
+			expErr: FaultPoolPropertyEnforced("self_heal", "exclude"),
+		},
+		"failed creation insufficient domains": {
+			targetCount:   8,
+			memberCount:   3,
+			memberDomains: []string{"/rack0/host1", "/rack0/host2", "/rack1/host3"},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:       common.MockUUID(0),
+				Scmbytes:   100 * humanize.GiByte,
+				Nvmebytes:  10 * humanize.TByte,
+				Mindomains: 3,
+			},
+			expErr: FaultPoolInsufficientFaultDomains(3, 2),
+		},
+		"failed creation ranks span insufficient domains": {
+			targetCount:   8,
+			memberCount:   3,
+			memberDomains: []string{"/rack0/host1", "/rack0/host2", "/rack1/host3"},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:       common.MockUUID(0),
+				Scmbytes:   100 * humanize.GiByte,
+				Nvmebytes:  10 * humanize.TByte,
+				Ranks:      []uint32{0, 1},
+				Mindomains: 2,
+			},
+			expErr: FaultPoolInsufficientFaultDomains(2, 1),
+		},
+		"failed creation fewer ranks than domains": {
+			targetCount:   8,
+			memberCount:   3,
+			memberDomains: []string{"/rack0/host1", "/rack1/host2", "/rack2/host3"},
+			req: &mgmtpb.PoolCreateReq{
+				Uuid:       common.MockUUID(0),
+				Scmbytes:   100 * humanize.GiByte,
+				Nvmebytes:  10 * humanize.TByte,
+				Numranks:   2,
+				Mindomains: 3,
+			},
+			expErr: errors.New("less than the minimum number of fault domains"),
+		},
 		"failed creation invalid ranks": {
 			targetCount: 1,
 			req: &mgmtpb.PoolCreateReq{
@@ -414,7 +586,17 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 			}
 			tc.mgmtSvc.log = log
 			for i := 0; i < numMembers; i++ {
-				if _, err := tc.mgmtSvc.membership.Add(system.MockMember(t, uint32(i), system.MemberStateJoined)); err != nil {
+				m := system.MockMember(t, uint32(i), system.MemberStateJoined)
+				if i < len(tc.memberDomains) {
+					m.WithFaultDomain(system.MustCreateFaultDomainFromString(tc.memberDomains[i]))
+				}
+				if _, err := tc.mgmtSvc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if len(tc.poolDefaults) > 0 {
+				if err := tc.mgmtSvc.sysdb.UpdatePoolPropDefaults(tc.poolDefaults, nil); err != nil {
 					t.Fatal(err)
 				}
 			}
@@ -434,17 +616,72 @@ func TestServer_MgmtSvc_PoolCreate(t *testing.T) {
 			defer pcCancel()
 			gotResp, gotErr := tc.mgmtSvc.PoolCreate(pcCtx, tc.req)
 			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expDestroyed != nil {
+				dc, err := tc.mgmtSvc.harness.instances[0].getDrpcClient()
+				if err != nil {
+					t.Fatal(err)
+				}
+				calls := dc.(*mockDrpcClient).calls
+				lastCall := calls[len(calls)-1]
+				common.AssertEqual(t, drpc.MethodPoolDestroy, lastCall.Method, "expected cleanup destroy")
+				destroyReq := new(mgmtpb.PoolDestroyReq)
+				if err := proto.Unmarshal(lastCall.Body, destroyReq); err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.expDestroyed, destroyReq.GetSvcRanks()); diff != "" {
+					t.Fatalf("unexpected destroy svc ranks (-want, +got)\n%s\n", diff)
+				}
+				if _, err := tc.mgmtSvc.sysdb.FindPoolServiceByUUID(uuid.MustParse(tc.req.GetUuid())); !system.IsPoolNotFound(err) {
+					t.Fatalf("expected pool entry to be removed, got %v", err)
+				}
+			}
 			if tc.expErr != nil {
 				return
 			}
 
-			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+			// durations vary so only the timed phases are compared
+			expPhases := []string{"engine pool create", "set properties"}
+			if len(tc.expResp.GetRankChoices()) > 0 {
+				expPhases = append([]string{"rank selection"}, expPhases...)
+			}
+			var gotPhases []string
+			for _, pt := range gotResp.GetTimings() {
+				gotPhases = append(gotPhases, pt.GetPhase())
+			}
+			if diff := cmp.Diff(expPhases, gotPhases); diff != "" {
+				t.Fatalf("unexpected timed phases (-want, +got)\n%s\n", diff)
+			}
+
+			cmpOpts := append(common.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&mgmtpb.PoolCreateResp{}, "timings"))
+			if diff := cmp.Diff(tc.expResp, gotResp, cmpOpts...); diff != "" {
 				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
 			}
 		})
 	}
 }
 
+func TestServer_spreadRanksAcrossDomains(t *testing.T) {
+	rankDomains := map[system.Rank]string{
+		0: "/rack0", 1: "/rack0", 2: "/rack0", 3: "/rack1", 4: "/rack2", 5: "/rack1",
+	}
+	ranks := []system.Rank{2, 0, 3, 1, 5, 4}
+
+	groups := groupRanksByDomain(ranks, rankDomains)
+	gotDomains := make([]string, len(groups))
+	for i, group := range groups {
+		gotDomains[i] = group.domain
+	}
+	if diff := cmp.Diff([]string{"/rack0", "/rack1", "/rack2"}, gotDomains); diff != "" {
+		t.Fatalf("unexpected domain order (-want, +got)\n%s\n", diff)
+	}
+
+	expRanks := []system.Rank{2, 3, 4, 0, 5, 1}
+	if diff := cmp.Diff(expRanks, spreadRanksAcrossDomains(groups)); diff != "" {
+		t.Fatalf("unexpected rank order (-want, +got)\n%s\n", diff)
+	}
+}
+
 func TestServer_MgmtSvc_PoolCreateDownRanks(t *testing.T) {
 	log, buf := logging.NewTestLogger(t.Name())
 	defer common.ShowBufferOnFailure(t, buf)
@@ -691,6 +928,43 @@ func TestServer_MgmtSvc_PoolDestroy(t *testing.T) {
 			},
 			expResp: &mgmtpb.PoolDestroyResp{},
 		},
+		"protected pool": {
+			req: &mgmtpb.PoolDestroyReq{Uuid: mockUUID, Force: true},
+			poolSvc: &system.PoolService{
+				PoolUUID:  uuid.MustParse(mockUUID),
+				Replicas:  []system.Rank{0, 1, 2},
+				State:     system.PoolServiceStateReady,
+				Protected: true,
+			},
+			expErr: FaultPoolProtected(mockUUID),
+		},
+		"delayed destroy disables pool": {
+			req:         &mgmtpb.PoolDestroyReq{Uuid: mockUUID, Delay: 3600},
+			expResp:     &mgmtpb.PoolDestroyResp{},
+			expSvcState: stateAddr(system.PoolServiceStateDisabled),
+		},
+		"delayed destroy fails to evict handles": {
+			req: &mgmtpb.PoolDestroyReq{Uuid: mockUUID, Delay: 3600},
+			setupMockDrpc: func(svc *mgmtSvc, err error) {
+				setupMockDrpcClient(svc, &mgmtpb.PoolEvictResp{
+					Status: int32(drpc.DaosMiscError),
+				}, nil)
+			},
+			expResp: &mgmtpb.PoolDestroyResp{
+				Status: int32(drpc.DaosMiscError),
+			},
+			expSvcState: stateAddr(system.PoolServiceStateReady),
+		},
+		"delayed destroy of disabled pool": {
+			req: &mgmtpb.PoolDestroyReq{Uuid: mockUUID, Delay: 3600},
+			poolSvc: &system.PoolService{
+				PoolUUID:     uuid.MustParse(mockUUID),
+				Replicas:     []system.Rank{0, 1, 2},
+				State:        system.PoolServiceStateDisabled,
+				DestroyAfter: time.Unix(1000, 0),
+			},
+			expErr: FaultPoolDisabled(mockUUID, time.Unix(1000, 0)),
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -982,9 +1256,16 @@ func TestListPools_Success(t *testing.T) {
 			Replicas: []system.Rank{0, 1, 2},
 		},
 		{
-			PoolUUID: uuid.MustParse(common.MockUUID(1)),
-			State:    system.PoolServiceStateReady,
-			Replicas: []system.Rank{0, 1, 2},
+			PoolUUID:  uuid.MustParse(common.MockUUID(1)),
+			State:     system.PoolServiceStateReady,
+			Replicas:  []system.Rank{0, 1, 2},
+			Protected: true,
+		},
+		{
+			PoolUUID:     uuid.MustParse(common.MockUUID(2)),
+			State:        system.PoolServiceStateDisabled,
+			Replicas:     []system.Rank{0, 1, 2},
+			DestroyAfter: time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
 		},
 	}
 	expectedResp := new(mgmtpb.ListPoolsResp)
@@ -994,10 +1275,16 @@ func TestListPools_Success(t *testing.T) {
 		if err := svc.sysdb.AddPoolService(ps); err != nil {
 			t.Fatal(err)
 		}
-		expectedResp.Pools = append(expectedResp.Pools, &mgmtpb.ListPoolsResp_Pool{
-			Uuid:    ps.PoolUUID.String(),
-			SvcReps: []uint32{0, 1, 2},
-		})
+		expPool := &mgmtpb.ListPoolsResp_Pool{
+			Uuid:      ps.PoolUUID.String(),
+			SvcReps:   []uint32{0, 1, 2},
+			State:     ps.State.String(),
+			Protected: ps.Protected,
+		}
+		if ps.State == system.PoolServiceStateDisabled {
+			expPool.DestroyAfter = "2021-06-01T12:00:00Z"
+		}
+		expectedResp.Pools = append(expectedResp.Pools, expPool)
 	}
 
 	resp, err := svc.ListPools(context.TODO(), newTestListPoolsReq())
@@ -1017,6 +1304,92 @@ func TestListPools_Success(t *testing.T) {
 	}
 }
 
+func TestListPools_Paginated(t *testing.T) {
+	poolResp := func(idxs ...int32) []*mgmtpb.ListPoolsResp_Pool {
+		var pools []*mgmtpb.ListPoolsResp_Pool
+		for _, i := range idxs {
+			pools = append(pools, &mgmtpb.ListPoolsResp_Pool{
+				Uuid:    common.MockUUID(i),
+				SvcReps: []uint32{0},
+				State:   system.PoolServiceStateReady.String(),
+			})
+		}
+		return pools
+	}
+
+	for name, tc := range map[string]struct {
+		cursor  string
+		limit   uint32
+		expResp *mgmtpb.ListPoolsResp
+	}{
+		"no limit": {
+			expResp: &mgmtpb.ListPoolsResp{Pools: poolResp(0, 1, 2, 3, 4)},
+		},
+		"first page": {
+			limit: 2,
+			expResp: &mgmtpb.ListPoolsResp{
+				Pools:      poolResp(0, 1),
+				NextCursor: common.MockUUID(1),
+			},
+		},
+		"middle page": {
+			cursor: common.MockUUID(1),
+			limit:  2,
+			expResp: &mgmtpb.ListPoolsResp{
+				Pools:      poolResp(2, 3),
+				NextCursor: common.MockUUID(3),
+			},
+		},
+		"last page": {
+			cursor:  common.MockUUID(3),
+			limit:   2,
+			expResp: &mgmtpb.ListPoolsResp{Pools: poolResp(4)},
+		},
+		"exact last page": {
+			cursor:  common.MockUUID(2),
+			limit:   2,
+			expResp: &mgmtpb.ListPoolsResp{Pools: poolResp(3, 4)},
+		},
+		"cursor of removed pool": {
+			cursor:  "00000002-0003-0000-0000-000000000000",
+			expResp: &mgmtpb.ListPoolsResp{Pools: poolResp(3, 4)},
+		},
+		"cursor past end": {
+			cursor:  common.MockUUID(4),
+			limit:   2,
+			expResp: &mgmtpb.ListPoolsResp{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			for _, i := range []int32{3, 1, 4, 0, 2} {
+				if err := svc.sysdb.AddPoolService(&system.PoolService{
+					PoolUUID: uuid.MustParse(common.MockUUID(i)),
+					State:    system.PoolServiceStateReady,
+					Replicas: []system.Rank{0},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := newTestListPoolsReq()
+			req.Cursor = tc.cursor
+			req.Limit = tc.limit
+			resp, err := svc.ListPools(context.TODO(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expResp, resp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("bad response (-want, +got): \n%s\n", diff)
+			}
+		})
+	}
+}
+
 func newTestGetACLReq() *mgmtpb.GetACLReq {
 	return &mgmtpb.GetACLReq{
 		Sys:  build.DefaultSystemName,
@@ -1628,6 +2001,151 @@ func getLastMockCall(svc *mgmtSvc) *drpc.Call {
 	return mi._drpcClient.(*mockDrpcClient).SendMsgInputCall
 }
 
+func TestServer_MgmtSvc_PoolSetProp_Protected(t *testing.T) {
+	for name, tc := range map[string]struct {
+		disabled     bool
+		req          *mgmtpb.PoolSetPropReq
+		expResp      *mgmtpb.PoolSetPropResp
+		expProtected bool
+		expErr       error
+	}{
+		"enable": {
+			req: propWithStrVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), "true"),
+			expResp: &mgmtpb.PoolSetPropResp{
+				Property: &mgmtpb.PoolSetPropResp_Name{Name: "protected"},
+				Value:    &mgmtpb.PoolSetPropResp_Strval{Strval: "true"},
+			},
+			expProtected: true,
+		},
+		"enable with number": {
+			req: propWithNumVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), 1),
+			expResp: &mgmtpb.PoolSetPropResp{
+				Property: &mgmtpb.PoolSetPropResp_Name{Name: "protected"},
+				Value:    &mgmtpb.PoolSetPropResp_Strval{Strval: "true"},
+			},
+			expProtected: true,
+		},
+		"disable": {
+			req: propWithStrVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), "false"),
+			expResp: &mgmtpb.PoolSetPropResp{
+				Property: &mgmtpb.PoolSetPropResp_Name{Name: "protected"},
+				Value:    &mgmtpb.PoolSetPropResp_Strval{Strval: "false"},
+			},
+		},
+		"invalid value": {
+			req:    propWithStrVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), "maybe"),
+			expErr: errors.New("invalid protected value"),
+		},
+		"invalid number": {
+			req:    propWithNumVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), 2),
+			expErr: errors.New("invalid protected value"),
+		},
+		"disabled pool": {
+			disabled: true,
+			req:      propWithStrVal(propWithName(new(mgmtpb.PoolSetPropReq), "protected"), "true"),
+			expErr:   FaultPoolDisabled(mockUUID, time.Time{}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ms := newTestMgmtSvc(t, log)
+			ps := &system.PoolService{
+				PoolUUID: uuid.MustParse(mockUUID),
+				State:    system.PoolServiceStateReady,
+				Replicas: []system.Rank{0},
+			}
+			if tc.disabled {
+				ps.State = system.PoolServiceStateDisabled
+			}
+			addTestPoolService(t, ms.sysdb, ps)
+
+			tc.req.Sys = build.DefaultSystemName
+			tc.req.Uuid = mockUUID
+
+			gotResp, gotErr := ms.PoolSetProp(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+
+			ps, err := ms.sysdb.FindPoolServiceByUUID(uuid.MustParse(mockUUID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expProtected, ps.Protected, "unexpected protected value")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_PoolRestore(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state  system.PoolServiceState
+		req    *mgmtpb.PoolRestoreReq
+		expErr error
+	}{
+		"wrong system": {
+			state:  system.PoolServiceStateDisabled,
+			req:    &mgmtpb.PoolRestoreReq{Sys: "bad", Uuid: mockUUID},
+			expErr: FaultWrongSystem("bad", build.DefaultSystemName),
+		},
+		"missing uuid": {
+			state:  system.PoolServiceStateDisabled,
+			req:    &mgmtpb.PoolRestoreReq{},
+			expErr: errors.New("invalid UUID"),
+		},
+		"unknown pool": {
+			state:  system.PoolServiceStateDisabled,
+			req:    &mgmtpb.PoolRestoreReq{Uuid: common.MockUUID(9)},
+			expErr: errors.New("unable to find pool service"),
+		},
+		"pool not disabled": {
+			state:  system.PoolServiceStateReady,
+			req:    &mgmtpb.PoolRestoreReq{Uuid: mockUUID},
+			expErr: errors.New("not pending destroy"),
+		},
+		"success": {
+			state: system.PoolServiceStateDisabled,
+			req:   &mgmtpb.PoolRestoreReq{Uuid: mockUUID},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ms := newTestMgmtSvc(t, log)
+			addTestPoolService(t, ms.sysdb, &system.PoolService{
+				PoolUUID:     uuid.MustParse(mockUUID),
+				State:        tc.state,
+				Replicas:     []system.Rank{0},
+				DestroyAfter: time.Now().Add(time.Hour),
+			})
+
+			if tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			_, gotErr := ms.PoolRestore(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			ps, err := ms.sysdb.FindPoolServiceByUUID(uuid.MustParse(mockUUID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, system.PoolServiceStateReady, ps.State, "unexpected pool state")
+			common.AssertTrue(t, ps.DestroyAfter.IsZero(), "destroy time not cleared")
+		})
+	}
+}
+
 func TestServer_MgmtSvc_PoolSetProp(t *testing.T) {
 	for name, tc := range map[string]struct {
 		setupMockDrpc func(_ *mgmtSvc, _ error)
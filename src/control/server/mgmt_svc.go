@@ -32,6 +32,11 @@ type mgmtSvc struct {
 	clientNetworkCfg *config.ClientNetworkCfg
 	joinReqs         joinReqChan
 	groupUpdateReqs  chan struct{}
+	faultPolicy      *faultPolicy
+	clockSkews       *clockSkews
+	configHashes     *hostConfigHashes
+	check            *systemCheck
+	poolCreates      *poolCreates
 }
 
 func newMgmtSvc(h *EngineHarness, m *system.Membership, s *system.Database, c control.UnaryInvoker, p *events.PubSub) *mgmtSvc {
@@ -45,6 +50,11 @@ func newMgmtSvc(h *EngineHarness, m *system.Membership, s *system.Database, c co
 		clientNetworkCfg: new(config.ClientNetworkCfg),
 		joinReqs:         make(joinReqChan),
 		groupUpdateReqs:  make(chan struct{}),
+		faultPolicy:      newFaultPolicy(config.FaultPolicy{}),
+		clockSkews:       newClockSkews(config.DefaultClockSkewThreshold),
+		configHashes:     newHostConfigHashes(),
+		check:            newSystemCheck(),
+		poolCreates:      newPoolCreates(),
 	}
 }
 
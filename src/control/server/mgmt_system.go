@@ -77,6 +77,10 @@ func (svc *mgmtSvc) GetAttachInfo(ctx context.Context, req *mgmtpb.GetAttachInfo
 	resp.NetDevClass = svc.clientNetworkCfg.NetDevClass
 	resp.MsRanks = system.RanksToUint32(groupMap.MSRanks)
 
+	if req.GetAgentVersion() != "" {
+		svc.recordAgent(ctx, req.GetAgentVersion())
+	}
+
 	// For resp.RankUris may be large, we make a resp copy with a limited
 	// number of rank URIs, to avoid flooding the debug log.
 	svc.log.Debugf("MgmtSvc.GetAttachInfo dispatch, resp:%+v len(RankUris):%d\n",
@@ -93,6 +97,37 @@ func (svc *mgmtSvc) GetAttachInfo(ctx context.Context, req *mgmtpb.GetAttachInfo
 	return resp, nil
 }
 
+// agentRecordInterval is the minimum interval between updates to the system
+// database recording that an agent with an unchanged version was seen.
+const agentRecordInterval = time.Hour
+
+// recordAgent records the version of the agent that sent the request in the
+// system database. Only the MS leader can update the database, so agents are
+// recorded on a best-effort basis when their requests are handled by it.
+func (svc *mgmtSvc) recordAgent(ctx context.Context, version string) {
+	if !svc.sysdb.IsLeader() {
+		return
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return
+	}
+	host := p.Addr.String()
+	if tcpAddr, ok := p.Addr.(*net.TCPAddr); ok {
+		host = tcpAddr.IP.String()
+	}
+
+	ar := &system.AgentRecord{
+		Host:     host,
+		Version:  version,
+		LastSeen: time.Now(),
+	}
+	if err := svc.sysdb.RecordAgent(ar, agentRecordInterval); err != nil {
+		svc.log.Debugf("failed to record agent %s: %s", host, err)
+	}
+}
+
 // LeaderQuery returns the system leader and access point replica details.
 func (svc *mgmtSvc) LeaderQuery(ctx context.Context, req *mgmtpb.LeaderQueryReq) (*mgmtpb.LeaderQueryResp, error) {
 	if err := svc.checkSystemRequest(req); err != nil {
@@ -114,6 +149,39 @@ func (svc *mgmtSvc) LeaderQuery(ctx context.Context, req *mgmtpb.LeaderQueryReq)
 	return resp, nil
 }
 
+// SystemReplicaQuery returns the raft status of the local MS replica.
+//
+// The request is handled by any replica rather than only the leader so that
+// the state of each replica can be compared when diagnosing quorum issues.
+func (svc *mgmtSvc) SystemReplicaQuery(ctx context.Context, req *mgmtpb.SystemReplicaQueryReq) (*mgmtpb.SystemReplicaQueryResp, error) {
+	if err := svc.checkReplicaRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("MgmtSvc.SystemReplicaQuery dispatch, req:%+v\n", req)
+
+	status, err := svc.sysdb.RaftStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &mgmtpb.SystemReplicaQueryResp{
+		State:             status.State,
+		Leader:            status.Leader,
+		Term:              status.Term,
+		LastIndex:         status.LastIndex,
+		CommitIndex:       status.CommitIndex,
+		AppliedIndex:      status.AppliedIndex,
+		LastSnapshotIndex: status.LastSnapshotIndex,
+		DbSize:            status.DBSize,
+	}
+	if !status.LastSnapshotTime.IsZero() {
+		resp.LastSnapshotTime = status.LastSnapshotTime.Format(time.RFC3339)
+	}
+
+	svc.log.Debugf("MgmtSvc.SystemReplicaQuery dispatch, resp:%+v\n", resp)
+	return resp, nil
+}
+
 // getPeerListenAddr combines peer ip from supplied context with input port.
 func getPeerListenAddr(ctx context.Context, listenAddrStr string) (*net.TCPAddr, error) {
 	p, ok := peer.FromContext(ctx)
@@ -261,6 +329,7 @@ func (svc *mgmtSvc) join(ctx context.Context, req *batchJoinRequest) *batchJoinR
 		FabricURI:      req.GetUri(),
 		FabricContexts: req.GetNctxs(),
 		FaultDomain:    fd,
+		Hostname:       req.GetHostname(),
 	})
 	if err != nil {
 		return &batchJoinResponse{joinErr: err}
@@ -432,6 +501,7 @@ type (
 		Method       systemRanksFunc
 		Hosts, Ranks string
 		Force        bool
+		NvmeTrim     bool
 	}
 
 	fanoutResponse struct {
@@ -512,7 +582,7 @@ func (svc *mgmtSvc) rpcFanout(parent context.Context, fanReq fanoutRequest, upda
 	defer cancel()
 
 	ranksReq := &control.RanksReq{
-		Ranks: hitRanks.String(), Force: fanReq.Force,
+		Ranks: hitRanks.String(), Force: fanReq.Force, NvmeTrim: fanReq.NvmeTrim,
 	}
 	ranksReq.SetHostList(svc.membership.HostList(hitRanks))
 	ranksResp, err := fanReq.Method(ctx, svc.rpcClient, ranksReq)
@@ -574,11 +644,26 @@ func (svc *mgmtSvc) SystemQuery(ctx context.Context, req *mgmtpb.SystemQueryReq)
 		Absentranks: missRanks.String(),
 		Absenthosts: missHosts.String(),
 	}
+
+	if req.GetAgents() {
+		agents, err := svc.sysdb.Agents()
+		if err != nil {
+			return nil, err
+		}
+		if err := convert.Types(agents, &resp.Agents); err != nil {
+			return nil, err
+		}
+	}
+
 	if hitRanks.Count() == 0 {
 		return resp, nil
 	}
 
 	members := svc.membership.Members(hitRanks)
+	for _, m := range members {
+		m.ClockSkew = svc.clockSkews.get(m.Addr.String())
+		m.ConfigHash = svc.configHashes.get(m.Addr.String())
+	}
 	if err := convert.Types(members, &resp.Members); err != nil {
 		return nil, err
 	}
@@ -588,6 +673,268 @@ func (svc *mgmtSvc) SystemQuery(ctx context.Context, req *mgmtpb.SystemQueryReq)
 	return resp, nil
 }
 
+func maintenanceWindowToPB(mw *system.MaintenanceWindow) *mgmtpb.MaintenanceWindow {
+	return &mgmtpb.MaintenanceWindow{
+		Id:     mw.ID.String(),
+		Hosts:  mw.Hosts,
+		Ranks:  system.RanksToUint32(mw.Ranks),
+		Reason: mw.Reason,
+		Start:  mw.Start.Format(time.RFC3339),
+		End:    mw.End.Format(time.RFC3339),
+	}
+}
+
+// SystemMaintenance implements the method defined for the Management Service.
+//
+// Start, end or list the maintenance windows recorded in the system database.
+// While a window is active, the ranks it covers are not marked dead in
+// response to SWIM events and any RAS events they raise are annotated with
+// the window on the MS leader.
+func (svc *mgmtSvc) SystemMaintenance(ctx context.Context, req *mgmtpb.SystemMaintenanceReq) (*mgmtpb.SystemMaintenanceResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemMaintenance RPC: %+v", req)
+
+	if req.Action == mgmtpb.SystemMaintenanceReq_START && req.Hosts == "" {
+		return nil, errors.New("hostlist required to start maintenance")
+	}
+	if req.Action == mgmtpb.SystemMaintenanceReq_START && req.Duration == 0 {
+		return nil, errors.New("non-zero duration required to start maintenance")
+	}
+
+	var ranks []system.Rank
+	resp := new(mgmtpb.SystemMaintenanceResp)
+	if req.Hosts != "" {
+		hitRanks, _, missHosts, err := svc.resolveRanks(req.Hosts, "")
+		if err != nil {
+			return nil, err
+		}
+		resp.Absenthosts = missHosts.String()
+		ranks = hitRanks.Ranks()
+		if len(ranks) == 0 {
+			return nil, errors.Errorf("no ranks found on hosts %s", req.Hosts)
+		}
+	}
+
+	now := time.Now()
+	switch req.Action {
+	case mgmtpb.SystemMaintenanceReq_START:
+		mw := &system.MaintenanceWindow{
+			ID:     uuid.New(),
+			Hosts:  req.Hosts,
+			Ranks:  ranks,
+			Reason: req.Reason,
+			Start:  now,
+			End:    now.Add(time.Duration(req.Duration) * time.Second),
+		}
+		if err := svc.sysdb.AddMaintenanceWindow(mw); err != nil {
+			return nil, err
+		}
+		resp.Windows = append(resp.Windows, maintenanceWindowToPB(mw))
+	case mgmtpb.SystemMaintenanceReq_END:
+		windows, err := svc.sysdb.ActiveMaintenanceWindows(now, ranks...)
+		if err != nil {
+			return nil, err
+		}
+		if len(windows) == 0 {
+			return nil, errors.New("no active maintenance windows found")
+		}
+		for _, mw := range windows {
+			mw.End = now
+			if err := svc.sysdb.UpdateMaintenanceWindow(mw); err != nil {
+				return nil, err
+			}
+			resp.Windows = append(resp.Windows, maintenanceWindowToPB(mw))
+		}
+	case mgmtpb.SystemMaintenanceReq_LIST:
+		windows, err := svc.sysdb.ActiveMaintenanceWindows(now, ranks...)
+		if err != nil {
+			return nil, err
+		}
+		for _, mw := range windows {
+			resp.Windows = append(resp.Windows, maintenanceWindowToPB(mw))
+		}
+	default:
+		return nil, errors.Errorf("unknown maintenance action %s", req.Action)
+	}
+
+	svc.log.Debugf("Responding to SystemMaintenance RPC: %+v", resp)
+
+	return resp, nil
+}
+
+func adminLockToPB(al *system.AdminLock) *mgmtpb.SystemLock {
+	return &mgmtpb.SystemLock{
+		Name:     al.Name,
+		Owner:    al.Owner,
+		Reason:   al.Reason,
+		Acquired: al.Acquired.Format(time.RFC3339),
+		Expires:  al.Expires.Format(time.RFC3339),
+	}
+}
+
+// SystemLock implements the method defined for the Management Service.
+//
+// Acquire, release or list the administrative locks recorded in the system
+// database. Locks are advisory; they are used to prevent conflicting
+// administrative operations from being run concurrently.
+func (svc *mgmtSvc) SystemLock(ctx context.Context, req *mgmtpb.SystemLockReq) (*mgmtpb.SystemLockResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemLock RPC: %+v", req)
+
+	if req.Action != mgmtpb.SystemLockReq_LIST {
+		if len(req.Names) == 0 {
+			return nil, errors.Errorf("lock names required to %s locks",
+				strings.ToLower(req.Action.String()))
+		}
+		if req.Owner == "" {
+			return nil, errors.Errorf("owner required to %s locks",
+				strings.ToLower(req.Action.String()))
+		}
+	}
+
+	now := time.Now()
+	resp := new(mgmtpb.SystemLockResp)
+	switch req.Action {
+	case mgmtpb.SystemLockReq_ACQUIRE:
+		if req.Ttl == 0 {
+			return nil, errors.New("non-zero ttl required to acquire locks")
+		}
+		var locks []*system.AdminLock
+		for _, name := range req.Names {
+			locks = append(locks, &system.AdminLock{
+				Name:     name,
+				Owner:    req.Owner,
+				Reason:   req.Reason,
+				Acquired: now,
+				Expires:  now.Add(time.Duration(req.Ttl) * time.Second),
+			})
+		}
+		if err := svc.sysdb.AcquireLocks(locks); err != nil {
+			return nil, err
+		}
+		for _, al := range locks {
+			resp.Locks = append(resp.Locks, adminLockToPB(al))
+		}
+	case mgmtpb.SystemLockReq_RELEASE:
+		locks, err := svc.sysdb.HeldLocks(now, req.Names...)
+		if err != nil {
+			return nil, err
+		}
+		if err := svc.sysdb.ReleaseLocks(req.Names, req.Owner, req.Force); err != nil {
+			return nil, err
+		}
+		for _, al := range locks {
+			resp.Locks = append(resp.Locks, adminLockToPB(al))
+		}
+	case mgmtpb.SystemLockReq_LIST:
+		locks, err := svc.sysdb.HeldLocks(now, req.Names...)
+		if err != nil {
+			return nil, err
+		}
+		for _, al := range locks {
+			resp.Locks = append(resp.Locks, adminLockToPB(al))
+		}
+	default:
+		return nil, errors.Errorf("unknown lock action %s", req.Action)
+	}
+
+	svc.log.Debugf("Responding to SystemLock RPC: %+v", resp)
+
+	return resp, nil
+}
+
+func operationRecordToPB(rec *system.OperationRecord) *mgmtpb.SystemOperation {
+	return &mgmtpb.SystemOperation{
+		Id:         rec.ID,
+		Time:       rec.Time.Format(time.RFC3339),
+		Operation:  rec.Operation,
+		Initiator:  rec.Initiator,
+		Address:    rec.Address,
+		Host:       rec.Host,
+		Parameters: rec.Parameters,
+		Result:     rec.Result,
+		Failed:     rec.Failed,
+	}
+}
+
+// SystemHistory implements the method defined for the Management Service.
+//
+// Return the administrative operations recorded in the system database that
+// match the request filters.
+func (svc *mgmtSvc) SystemHistory(ctx context.Context, req *mgmtpb.SystemHistoryReq) (*mgmtpb.SystemHistoryResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemHistory RPC: %+v", req)
+
+	filter := &system.HistoryFilter{
+		Operation:  req.Operation,
+		Initiator:  req.Initiator,
+		FailedOnly: req.Failed,
+		Limit:      int(req.Limit),
+	}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid since time")
+		}
+		filter.Since = since
+	}
+
+	records, err := svc.sysdb.OperationHistory(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(mgmtpb.SystemHistoryResp)
+	for _, rec := range records {
+		resp.Operations = append(resp.Operations, operationRecordToPB(rec))
+	}
+
+	svc.log.Debugf("Responding to SystemHistory RPC: %d records", len(resp.Operations))
+
+	return resp, nil
+}
+
+// SystemHistoryRecord implements the method defined for the Management Service.
+//
+// Record an administrative operation performed on a server, such as a storage
+// format, in the operation history.
+func (svc *mgmtSvc) SystemHistoryRecord(ctx context.Context, req *mgmtpb.SystemHistoryRecordReq) (*mgmtpb.SystemHistoryRecordResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemHistoryRecord RPC: %+v", req)
+
+	op := req.GetOperation()
+	if op == nil {
+		return nil, errors.New("no operation to record")
+	}
+	opTime, err := time.Parse(time.RFC3339, op.Time)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid operation time")
+	}
+
+	if err := svc.sysdb.RecordOperation(&system.OperationRecord{
+		Time:       opTime,
+		Operation:  op.Operation,
+		Initiator:  op.Initiator,
+		Address:    op.Address,
+		Host:       op.Host,
+		Parameters: op.Parameters,
+		Result:     op.Result,
+		Failed:     op.Failed,
+	}); err != nil {
+		return nil, err
+	}
+
+	return new(mgmtpb.SystemHistoryRecordResp), nil
+}
+
 func fanout2pbStopResp(act string, fr *fanoutResponse) (*mgmtpb.SystemStopResp, error) {
 	sr := &mgmtpb.SystemStopResp{}
 	sr.Absentranks = fr.AbsentRanks.String()
@@ -729,6 +1076,227 @@ func (svc *mgmtSvc) SystemStart(ctx context.Context, req *mgmtpb.SystemStartReq)
 	return
 }
 
+// SystemExclude implements the method defined for the Management Service.
+//
+// Administratively exclude the requested system members, or clear an existing
+// exclusion. Excluded members are removed from the CaRT group map and refused
+// when attempting to rejoin. Clearing an exclusion leaves the member stopped
+// and restarts the fault policy grace period for the rank.
+func (svc *mgmtSvc) SystemExclude(ctx context.Context, req *mgmtpb.SystemExcludeReq) (*mgmtpb.SystemExcludeResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemExclude RPC: %+v", req)
+
+	if req.GetHosts() == "" && req.GetRanks() == "" {
+		return nil, errors.New("ranklist or hostlist required")
+	}
+
+	hitRanks, missRanks, missHosts, err := svc.resolveRanks(req.GetHosts(), req.GetRanks())
+	if err != nil {
+		return nil, err
+	}
+
+	action := "exclude"
+	if req.GetClear() {
+		action = "clear-exclude"
+	}
+
+	var results system.MemberResults
+	var updated bool
+	for _, rank := range hitRanks.Ranks() {
+		var err error
+		state := system.MemberStateExcluded
+		if req.GetClear() {
+			state = system.MemberStateStopped
+			if err = svc.membership.ClearRankExclusion(rank); err == nil {
+				svc.faultPolicy.reset(rankSubject(rank))
+			}
+		} else {
+			err = svc.membership.ExcludeRank(rank, "administratively excluded")
+		}
+		if err != nil {
+			state = system.MemberStateUnknown
+			if m, gErr := svc.membership.Get(rank); gErr == nil {
+				state = m.State()
+			}
+		} else {
+			svc.log.Infof("rank %d: %s", rank, action)
+			updated = true
+		}
+		results = append(results, system.NewMemberResult(rank, err, state, action))
+	}
+
+	if updated {
+		svc.reqGroupUpdate(ctx)
+	}
+
+	resp := &mgmtpb.SystemExcludeResp{
+		Absentranks: missRanks.String(),
+		Absenthosts: missHosts.String(),
+	}
+	if err := convert.Types(results, &resp.Results); err != nil {
+		return nil, err
+	}
+
+	svc.log.Debugf("Responding to SystemExclude RPC: %+v", resp)
+
+	return resp, nil
+}
+
+// SystemSetFaultDomain assigns a fault domain to the selected ranks, overriding
+// the fault domain reported by their servers, or clears the assignment. Fault
+// domains are used when placing the targets of newly created pools.
+func (svc *mgmtSvc) SystemSetFaultDomain(ctx context.Context, req *mgmtpb.SystemSetFaultDomainReq) (*mgmtpb.SystemSetFaultDomainResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemSetFaultDomain RPC: %+v", req)
+
+	if req.GetHosts() == "" && req.GetRanks() == "" {
+		return nil, errors.New("ranklist or hostlist required")
+	}
+
+	var fd *system.FaultDomain
+	action := "clear-fault-domain"
+	if !req.GetClear() {
+		var err error
+		fd, err = system.NewFaultDomainFromString(req.GetDomain())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid fault domain %q", req.GetDomain())
+		}
+		action = "set-fault-domain"
+	}
+
+	hitRanks, missRanks, missHosts, err := svc.resolveRanks(req.GetHosts(), req.GetRanks())
+	if err != nil {
+		return nil, err
+	}
+
+	var results system.MemberResults
+	for _, rank := range hitRanks.Ranks() {
+		var err error
+		if req.GetClear() {
+			err = svc.membership.ClearRankFaultDomain(rank)
+		} else {
+			err = svc.membership.SetRankFaultDomain(rank, fd)
+		}
+		state := system.MemberStateUnknown
+		if m, gErr := svc.membership.Get(rank); gErr == nil {
+			state = m.State()
+		}
+		if err == nil {
+			svc.log.Infof("rank %d: %s %s", rank, action, fd)
+		}
+		results = append(results, system.NewMemberResult(rank, err, state, action))
+	}
+
+	resp := &mgmtpb.SystemSetFaultDomainResp{
+		Absentranks: missRanks.String(),
+		Absenthosts: missHosts.String(),
+	}
+	if err := convert.Types(results, &resp.Results); err != nil {
+		return nil, err
+	}
+
+	svc.log.Debugf("Responding to SystemSetFaultDomain RPC: %+v", resp)
+
+	return resp, nil
+}
+
+// SystemActivateSpare replaces a stopped or failed rank with a spare engine on
+// the same server. The spare holds none of the data of the rank and joins the
+// system as a new rank, the replaced rank is excluded from the system and from
+// the pools it belongs to so that its data is rebuilt on the remaining ranks.
+func (svc *mgmtSvc) SystemActivateSpare(ctx context.Context, req *mgmtpb.SystemActivateSpareReq) (*mgmtpb.SystemActivateSpareResp, error) {
+	if err := svc.checkLeaderRequest(req); err != nil {
+		return nil, err
+	}
+	svc.log.Debugf("Received SystemActivateSpare RPC: %+v", req)
+
+	rank := system.Rank(req.GetRank())
+	member, err := svc.membership.Get(rank)
+	if err != nil {
+		return nil, err
+	}
+
+	switch member.State() {
+	case system.MemberStateStopped, system.MemberStateErrored, system.MemberStateUnresponsive,
+		system.MemberStateExcluded:
+	default:
+		return nil, errors.Errorf("rank %d is %s, only a stopped or failed rank can be replaced by a spare",
+			rank, strings.ToLower(member.State().String()))
+	}
+
+	asReq := &control.ActivateSpareReq{Rank: rank}
+	asReq.SetHostList([]string{member.Addr.String()})
+	asCtx, cancel := context.WithTimeout(ctx, systemReqTimeout)
+	defer cancel()
+
+	asResp, err := control.ActivateSpare(asCtx, svc.rpcClient, asReq)
+	if err != nil {
+		return nil, err
+	}
+	svc.log.Infof("rank %d: replaced by spare instance %d on %s (spare rank %s, state %s)",
+		rank, asResp.SpareIdx, asResp.Host, system.NewRankPtr(asResp.SpareRank), asResp.State)
+
+	// The storage of the replaced rank has been released and it will not
+	// rejoin, keep it out of the system and rebuild its data.
+	if member.State() != system.MemberStateExcluded {
+		reason := fmt.Sprintf("replaced by spare engine %d on %s", asResp.SpareIdx, asResp.Host)
+		if err := svc.membership.ExcludeRank(rank, reason); err != nil {
+			return nil, err
+		}
+		svc.reqGroupUpdate(ctx)
+	}
+	svc.excludeRankFromPools(ctx, rank)
+
+	resp := &mgmtpb.SystemActivateSpareResp{
+		Host:            asResp.Host,
+		SpareIdx:        asResp.SpareIdx,
+		FailedIdx:       asResp.FailedIdx,
+		ReleasedDevices: asResp.ReleasedDevices,
+		State:           asResp.State,
+		SpareRank:       asResp.SpareRank,
+	}
+
+	svc.log.Debugf("Responding to SystemActivateSpare RPC: %+v", resp)
+
+	return resp, nil
+}
+
+// excludeRankFromPools excludes all targets of the given rank from the pools
+// it belongs to, triggering a rebuild of the data held by the rank. Failures
+// are logged as the rank may be excluded with dmg pool exclude.
+func (svc *mgmtSvc) excludeRankFromPools(ctx context.Context, rank system.Rank) {
+	psList, err := svc.sysdb.PoolServiceList()
+	if err != nil {
+		svc.log.Errorf("rank %d: failed to list pools for exclusion: %s", rank, err)
+		return
+	}
+
+	for _, ps := range psList {
+		if ps.State != system.PoolServiceStateReady || ps.Storage == nil ||
+			!rank.InList(ps.Storage.CurrentRanks()) {
+			continue
+		}
+
+		resp, err := svc.PoolExclude(ctx, &mgmtpb.PoolExcludeReq{
+			Sys:  svc.sysdb.SystemName(),
+			Uuid: ps.PoolUUID.String(),
+			Rank: rank.Uint32(),
+		})
+		if err == nil && resp.Status != 0 {
+			err = drpc.DaosStatus(resp.Status)
+		}
+		if err != nil {
+			svc.log.Errorf("rank %d: failed to exclude from pool %s: %s", rank, ps.PoolUUID, err)
+			continue
+		}
+		svc.log.Infof("rank %d: excluded from pool %s", rank, ps.PoolUUID)
+	}
+}
+
 // ClusterEvent management service gRPC handler receives ClusterEvent requests
 // from control-plane instances attempting to notify the MS of a cluster event
 // in the DAOS system (this handler should only get called on the MS leader).
@@ -801,10 +1369,23 @@ func (svc *mgmtSvc) SystemErase(ctx context.Context, pbReq *mgmtpb.SystemEraseRe
 		svc.eraseAndRestart(false)
 	}
 
+	// Metadata must not be removed from under running ranks.
+	running := &system.RankSet{}
+	for _, m := range svc.membership.Members(nil) {
+		if m.State()&(system.AvailableMemberFilter|system.MemberStateStarting|system.MemberStateStopping) != 0 {
+			running.Add(m.Rank)
+		}
+	}
+	if running.Count() > 0 {
+		return nil, FaultSystemEraseRanksRunning(running)
+	}
+
 	// On the leader, we should first tell all servers to prepare for
-	// reformat by wiping out their engine superblocks, etc.
+	// reformat by wiping out their engine superblocks and SCM metadata
+	// and, if requested, deallocating their NVMe SSDs.
 	fanResp, _, err := svc.rpcFanout(ctx, fanoutRequest{
-		Method: control.ResetFormatRanks,
+		Method:   control.ResetFormatRanks,
+		NvmeTrim: pbReq.GetNvmeTrim(),
 	}, false)
 	if err != nil {
 		return nil, err
@@ -16,13 +16,18 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/daos-stack/daos/src/control/build"
 	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
+	"github.com/daos-stack/daos/src/control/drpc"
 	"github.com/daos-stack/daos/src/control/events"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
@@ -204,6 +209,61 @@ func TestServer_MgmtSvc_GetAttachInfo(t *testing.T) {
 	}
 }
 
+func TestServer_MgmtSvc_recordAgent(t *testing.T) {
+	agentAddr := &net.TCPAddr{IP: net.ParseIP("10.0.1.1"), Port: 1234}
+
+	for name, tc := range map[string]struct {
+		ctx       context.Context
+		versions  []string
+		expAgents []*mgmtpb.AgentInfo
+	}{
+		"no peer": {
+			ctx:       context.TODO(),
+			versions:  []string{"2.0.0"},
+			expAgents: []*mgmtpb.AgentInfo{},
+		},
+		"agent recorded": {
+			ctx:      peer.NewContext(context.TODO(), &peer.Peer{Addr: agentAddr}),
+			versions: []string{"2.0.0"},
+			expAgents: []*mgmtpb.AgentInfo{
+				{Host: "10.0.1.1", Version: "2.0.0"},
+			},
+		},
+		"agent upgraded": {
+			ctx:      peer.NewContext(context.TODO(), &peer.Peer{Addr: agentAddr}),
+			versions: []string{"2.0.0", "2.2.0"},
+			expAgents: []*mgmtpb.AgentInfo{
+				{Host: "10.0.1.1", Version: "2.2.0"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, system.Members{}, []*control.HostResponse{})
+			for _, version := range tc.versions {
+				svc.recordAgent(tc.ctx, version)
+			}
+
+			resp, err := svc.SystemQuery(context.TODO(), &mgmtpb.SystemQueryReq{
+				Sys:    build.DefaultSystemName,
+				Agents: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cmpOpts := append(common.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&mgmtpb.AgentInfo{}, "last_seen"),
+			)
+			if diff := cmp.Diff(tc.expAgents, resp.Agents, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected agents (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func stateString(s system.MemberState) string {
 	return strings.ToLower(s.String())
 }
@@ -270,6 +330,68 @@ func TestServer_MgmtSvc_LeaderQuery(t *testing.T) {
 	}
 }
 
+func TestServer_MgmtSvc_SystemReplicaQuery(t *testing.T) {
+	localhost := common.LocalhostCtrlAddr()
+
+	for name, tc := range map[string]struct {
+		req    *mgmtpb.SystemReplicaQueryReq
+		expErr error
+	}{
+		"nil request": {
+			expErr: errors.New("nil request"),
+		},
+		"wrong system": {
+			req:    &mgmtpb.SystemReplicaQueryReq{Sys: "quack"},
+			expErr: FaultWrongSystem("quack", build.DefaultSystemName),
+		},
+		"successful query": {
+			req: &mgmtpb.SystemReplicaQueryReq{Sys: build.DefaultSystemName},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+			db, cleanup := system.TestDatabase(t, log)
+			defer cleanup()
+			svc.sysdb = db
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := db.Start(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			// wait for the bootstrap to finish
+			for {
+				if leader, _, _ := db.LeaderQuery(); leader != "" {
+					break
+				}
+				time.Sleep(250 * time.Millisecond)
+			}
+
+			gotResp, gotErr := svc.SystemReplicaQuery(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, "Leader", gotResp.State, "unexpected raft state")
+			common.AssertEqual(t, localhost.String(), gotResp.Leader, "unexpected leader")
+			if gotResp.Term == 0 || gotResp.CommitIndex == 0 {
+				t.Fatalf("expected non-zero term and commit index in %+v", gotResp)
+			}
+			if gotResp.AppliedIndex > gotResp.LastIndex {
+				t.Fatalf("applied index ahead of last index in %+v", gotResp)
+			}
+			if gotResp.DbSize == 0 {
+				t.Fatal("expected non-zero database size")
+			}
+		})
+	}
+}
+
 type eventsDispatched struct {
 	rx     []*events.RASEvent
 	cancel context.CancelFunc
@@ -409,6 +531,81 @@ func TestServer_MgmtSvc_getPeerListenAddr(t *testing.T) {
 	}
 }
 
+func TestServer_MgmtSvc_join(t *testing.T) {
+	curMember := mockMember(t, 0, 1, "joined").
+		WithFaultDomain(system.MustCreateFaultDomainFromString("/host1"))
+	curMember.FabricURI = "tcp://10.0.0.1:31416"
+	curMember.Hostname = "host1"
+	newAddr := common.MockHostAddr(2)
+
+	for name, tc := range map[string]struct {
+		req     *mgmtpb.JoinReq
+		expResp *mgmtpb.JoinResp
+		expErr  error
+	}{
+		"new member": {
+			req: &mgmtpb.JoinReq{
+				Uuid:           common.MockUUID(1),
+				Rank:           1,
+				Uri:            "tcp://10.0.0.2:31416",
+				SrvFaultDomain: "/host2",
+				Hostname:       "host2",
+			},
+			expResp: &mgmtpb.JoinResp{
+				State: mgmtpb.JoinResp_IN,
+				Rank:  1,
+			},
+		},
+		"dupe fabric URI": {
+			req: &mgmtpb.JoinReq{
+				Uuid:           common.MockUUID(1),
+				Rank:           1,
+				Uri:            curMember.FabricURI,
+				SrvFaultDomain: "/host2",
+				Hostname:       "host2",
+			},
+			expErr: system.FaultJoinDuplicateFabricURI(curMember.FabricURI, curMember.Rank),
+		},
+		"dupe hostname": {
+			req: &mgmtpb.JoinReq{
+				Uuid:           common.MockUUID(1),
+				Rank:           1,
+				Uri:            "tcp://10.0.0.2:31416",
+				SrvFaultDomain: "/host2",
+				Hostname:       curMember.Hostname,
+			},
+			expErr: system.FaultJoinDuplicateHostname(curMember.Hostname, newAddr,
+				curMember.Rank, curMember.Addr),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := mgmtSystemTestSetup(t, log, system.Members{curMember}, []*control.HostResponse{})
+
+			resp := svc.join(context.TODO(), &batchJoinRequest{
+				JoinReq:  *tc.req,
+				peerAddr: newAddr,
+			})
+			common.CmpErr(t, tc.expErr, resp.joinErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, &resp.JoinResp, protocmp.Transform()); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+
+			m, err := svc.membership.Get(system.Rank(resp.Rank))
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.req.Hostname, m.Hostname, "unexpected member hostname")
+		})
+	}
+}
+
 func mockMember(t *testing.T, r, a int32, s string) *system.Member {
 	t.Helper()
 
@@ -416,6 +613,7 @@ func mockMember(t *testing.T, r, a int32, s string) *system.Member {
 		"awaitformat":  system.MemberStateAwaitFormat,
 		"errored":      system.MemberStateErrored,
 		"evicted":      system.MemberStateEvicted,
+		"excluded":     system.MemberStateExcluded,
 		"joined":       system.MemberStateJoined,
 		"ready":        system.MemberStateReady,
 		"starting":     system.MemberStateStarting,
@@ -1030,6 +1228,816 @@ func TestServer_MgmtSvc_SystemQuery(t *testing.T) {
 	}
 }
 
+func TestServer_MgmtSvc_SystemMaintenance(t *testing.T) {
+	defaultMembers := system.Members{
+		mockMember(t, 0, 1, "joined"),
+		mockMember(t, 1, 1, "joined"),
+		mockMember(t, 2, 2, "joined"),
+		mockMember(t, 3, 2, "joined"),
+	}
+	startReq := func(hosts, reason string) *mgmtpb.SystemMaintenanceReq {
+		return &mgmtpb.SystemMaintenanceReq{
+			Sys:      build.DefaultSystemName,
+			Action:   mgmtpb.SystemMaintenanceReq_START,
+			Hosts:    hosts,
+			Duration: 3600,
+			Reason:   reason,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq     bool
+		started    []*mgmtpb.SystemMaintenanceReq
+		req        *mgmtpb.SystemMaintenanceReq
+		expWindows []*mgmtpb.MaintenanceWindow
+		expAbsent  string
+		expErr     error
+	}{
+		"nil req": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"start without hosts": {
+			req:    startReq("", "test"),
+			expErr: errors.New("hostlist required"),
+		},
+		"start without duration": {
+			req: &mgmtpb.SystemMaintenanceReq{
+				Action: mgmtpb.SystemMaintenanceReq_START,
+				Hosts:  "10.0.0.1",
+			},
+			expErr: errors.New("non-zero duration"),
+		},
+		"start on unknown hosts": {
+			req:    startReq("10.0.0.[4-5]", "test"),
+			expErr: errors.New("no ranks found"),
+		},
+		"start": {
+			req: startReq("10.0.0.[1,4]", "firmware update"),
+			expWindows: []*mgmtpb.MaintenanceWindow{
+				{Hosts: "10.0.0.[1,4]", Ranks: []uint32{0, 1}, Reason: "firmware update"},
+			},
+			expAbsent: "10.0.0.4",
+		},
+		"list none": {
+			req: &mgmtpb.SystemMaintenanceReq{},
+		},
+		"list all": {
+			started: []*mgmtpb.SystemMaintenanceReq{
+				startReq("10.0.0.1", "one"),
+				startReq("10.0.0.2", "two"),
+			},
+			req: &mgmtpb.SystemMaintenanceReq{},
+			expWindows: []*mgmtpb.MaintenanceWindow{
+				{Hosts: "10.0.0.1", Ranks: []uint32{0, 1}, Reason: "one"},
+				{Hosts: "10.0.0.2", Ranks: []uint32{2, 3}, Reason: "two"},
+			},
+		},
+		"list by host": {
+			started: []*mgmtpb.SystemMaintenanceReq{
+				startReq("10.0.0.1", "one"),
+				startReq("10.0.0.2", "two"),
+			},
+			req: &mgmtpb.SystemMaintenanceReq{Hosts: "10.0.0.2"},
+			expWindows: []*mgmtpb.MaintenanceWindow{
+				{Hosts: "10.0.0.2", Ranks: []uint32{2, 3}, Reason: "two"},
+			},
+		},
+		"end with no active windows": {
+			req: &mgmtpb.SystemMaintenanceReq{
+				Action: mgmtpb.SystemMaintenanceReq_END,
+			},
+			expErr: errors.New("no active maintenance"),
+		},
+		"end by host": {
+			started: []*mgmtpb.SystemMaintenanceReq{
+				startReq("10.0.0.1", "one"),
+				startReq("10.0.0.2", "two"),
+			},
+			req: &mgmtpb.SystemMaintenanceReq{
+				Action: mgmtpb.SystemMaintenanceReq_END,
+				Hosts:  "10.0.0.1",
+			},
+			expWindows: []*mgmtpb.MaintenanceWindow{
+				{Hosts: "10.0.0.1", Ranks: []uint32{0, 1}, Reason: "one"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mockResolver := func(_ string, addr string) (*net.TCPAddr, error) {
+				return map[string]*net.TCPAddr{
+						"10.0.0.1:10001": {IP: net.ParseIP("10.0.0.1"), Port: 10001},
+						"10.0.0.2:10001": {IP: net.ParseIP("10.0.0.2"), Port: 10001},
+					}[addr], map[string]error{
+						"10.0.0.4:10001": errors.New("bad lookup"),
+						"10.0.0.5:10001": errors.New("bad lookup"),
+					}[addr]
+			}
+
+			svc := newTestMgmtSvc(t, log)
+			svc.membership = svc.membership.WithTCPResolver(mockResolver)
+			for _, m := range defaultMembers {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, sr := range tc.started {
+				if _, err := svc.SystemMaintenance(context.TODO(), sr); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else if req.Sys == "" {
+				req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemMaintenance(context.TODO(), req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			// windows started within the same test may share a start time
+			sort.Slice(gotResp.Windows, func(i, j int) bool {
+				return gotResp.Windows[i].Hosts < gotResp.Windows[j].Hosts
+			})
+			cmpOpts := append(common.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&mgmtpb.MaintenanceWindow{}, "id", "start", "end"))
+			if diff := cmp.Diff(tc.expWindows, gotResp.Windows, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected windows (-want, +got)\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expAbsent, gotResp.Absenthosts, "absent hosts")
+
+			if tc.req.Action != mgmtpb.SystemMaintenanceReq_END {
+				return
+			}
+			// ended windows should no longer be reported as active
+			listResp, err := svc.SystemMaintenance(context.TODO(), &mgmtpb.SystemMaintenanceReq{
+				Sys:   build.DefaultSystemName,
+				Hosts: tc.req.Hosts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, 0, len(listResp.Windows), "windows still active after end")
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemLock(t *testing.T) {
+	acquireReq := func(owner string, names ...string) *mgmtpb.SystemLockReq {
+		return &mgmtpb.SystemLockReq{
+			Sys:    build.DefaultSystemName,
+			Action: mgmtpb.SystemLockReq_ACQUIRE,
+			Names:  names,
+			Owner:  owner,
+			Ttl:    3600,
+			Reason: "test",
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq   bool
+		acquired []*mgmtpb.SystemLockReq
+		req      *mgmtpb.SystemLockReq
+		expLocks []*mgmtpb.SystemLock
+		expHeld  []string
+		expErr   error
+	}{
+		"nil req": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"acquire without names": {
+			req:    acquireReq("admin1"),
+			expErr: errors.New("lock names required to acquire"),
+		},
+		"acquire without owner": {
+			req:    acquireReq("", "a"),
+			expErr: errors.New("owner required to acquire"),
+		},
+		"acquire without ttl": {
+			req: &mgmtpb.SystemLockReq{
+				Action: mgmtpb.SystemLockReq_ACQUIRE,
+				Names:  []string{"a"},
+				Owner:  "admin1",
+			},
+			expErr: errors.New("non-zero ttl"),
+		},
+		"acquire": {
+			req: acquireReq("admin1", "a", "b"),
+			expLocks: []*mgmtpb.SystemLock{
+				{Name: "a", Owner: "admin1", Reason: "test"},
+				{Name: "b", Owner: "admin1", Reason: "test"},
+			},
+			expHeld: []string{"a", "b"},
+		},
+		"acquire held": {
+			acquired: []*mgmtpb.SystemLockReq{acquireReq("admin1", "a")},
+			req:      acquireReq("admin2", "b", "a"),
+			expErr:   errors.New(`lock "a" is held by "admin1"`),
+		},
+		"list none": {
+			req: &mgmtpb.SystemLockReq{},
+		},
+		"list by name": {
+			acquired: []*mgmtpb.SystemLockReq{
+				acquireReq("admin1", "a"),
+				acquireReq("admin2", "b"),
+			},
+			req: &mgmtpb.SystemLockReq{Names: []string{"b"}},
+			expLocks: []*mgmtpb.SystemLock{
+				{Name: "b", Owner: "admin2", Reason: "test"},
+			},
+			expHeld: []string{"a", "b"},
+		},
+		"release": {
+			acquired: []*mgmtpb.SystemLockReq{acquireReq("admin1", "a", "b")},
+			req: &mgmtpb.SystemLockReq{
+				Action: mgmtpb.SystemLockReq_RELEASE,
+				Names:  []string{"a"},
+				Owner:  "admin1",
+			},
+			expLocks: []*mgmtpb.SystemLock{
+				{Name: "a", Owner: "admin1", Reason: "test"},
+			},
+			expHeld: []string{"b"},
+		},
+		"release held by other owner": {
+			acquired: []*mgmtpb.SystemLockReq{acquireReq("admin1", "a")},
+			req: &mgmtpb.SystemLockReq{
+				Action: mgmtpb.SystemLockReq_RELEASE,
+				Names:  []string{"a"},
+				Owner:  "admin2",
+			},
+			expErr: errors.New("held by"),
+		},
+		"force release held by other owner": {
+			acquired: []*mgmtpb.SystemLockReq{acquireReq("admin1", "a")},
+			req: &mgmtpb.SystemLockReq{
+				Action: mgmtpb.SystemLockReq_RELEASE,
+				Names:  []string{"a"},
+				Owner:  "admin2",
+				Force:  true,
+			},
+			expLocks: []*mgmtpb.SystemLock{
+				{Name: "a", Owner: "admin1", Reason: "test"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+
+			for _, ar := range tc.acquired {
+				if _, err := svc.SystemLock(context.TODO(), ar); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else if req.Sys == "" {
+				req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemLock(context.TODO(), req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			cmpOpts := append(common.DefaultCmpOpts(),
+				protocmp.IgnoreFields(&mgmtpb.SystemLock{}, "acquired", "expires"))
+			if diff := cmp.Diff(tc.expLocks, gotResp.Locks, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected locks (-want, +got)\n%s\n", diff)
+			}
+
+			listResp, err := svc.SystemLock(context.TODO(), &mgmtpb.SystemLockReq{
+				Sys: build.DefaultSystemName,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotHeld []string
+			for _, al := range listResp.Locks {
+				gotHeld = append(gotHeld, al.Name)
+			}
+			if diff := cmp.Diff(tc.expHeld, gotHeld); diff != "" {
+				t.Fatalf("unexpected held locks (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemHistory(t *testing.T) {
+	opTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockOp := func(op string, age time.Duration, failed bool) *mgmtpb.SystemOperation {
+		return &mgmtpb.SystemOperation{
+			Time:      opTime.Add(-age).Format(time.RFC3339),
+			Operation: op,
+			Initiator: "admin",
+			Host:      "host1",
+			Result:    "ok",
+			Failed:    failed,
+		}
+	}
+	withID := func(id uint64, op *mgmtpb.SystemOperation) *mgmtpb.SystemOperation {
+		out := proto.Clone(op).(*mgmtpb.SystemOperation)
+		out.Id = id
+		return out
+	}
+	recorded := []*mgmtpb.SystemOperation{
+		mockOp("storage-format", 2*time.Hour, false),
+		mockOp("pool-create", time.Hour, true),
+		mockOp("pool-create", time.Minute, false),
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq   bool
+		recorded []*mgmtpb.SystemOperation
+		req      *mgmtpb.SystemHistoryReq
+		expOps   []*mgmtpb.SystemOperation
+		expErr   error
+	}{
+		"nil req": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"empty": {
+			req: &mgmtpb.SystemHistoryReq{},
+		},
+		"bad record time": {
+			recorded: []*mgmtpb.SystemOperation{{Time: "yesterday", Operation: "pool-create"}},
+			req:      &mgmtpb.SystemHistoryReq{},
+			expErr:   errors.New("invalid operation time"),
+		},
+		"bad since": {
+			req:    &mgmtpb.SystemHistoryReq{Since: "yesterday"},
+			expErr: errors.New("invalid since time"),
+		},
+		"all": {
+			recorded: recorded,
+			req:      &mgmtpb.SystemHistoryReq{},
+			expOps: []*mgmtpb.SystemOperation{
+				withID(1, recorded[0]),
+				withID(2, recorded[1]),
+				withID(3, recorded[2]),
+			},
+		},
+		"filtered": {
+			recorded: recorded,
+			req: &mgmtpb.SystemHistoryReq{
+				Operation: "pool-create",
+				Since:     opTime.Add(-90 * time.Minute).Format(time.RFC3339),
+				Failed:    true,
+			},
+			expOps: []*mgmtpb.SystemOperation{withID(2, recorded[1])},
+		},
+		"limited": {
+			recorded: recorded,
+			req:      &mgmtpb.SystemHistoryReq{Limit: 1},
+			expOps:   []*mgmtpb.SystemOperation{withID(3, recorded[2])},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			svc := newTestMgmtSvc(t, log)
+
+			var gotErr error
+			for _, op := range tc.recorded {
+				_, gotErr = svc.SystemHistoryRecord(context.TODO(), &mgmtpb.SystemHistoryRecordReq{
+					Sys:       build.DefaultSystemName,
+					Operation: op,
+				})
+				if gotErr != nil {
+					break
+				}
+			}
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else if req.Sys == "" {
+				req.Sys = build.DefaultSystemName
+			}
+
+			var gotResp *mgmtpb.SystemHistoryResp
+			if gotErr == nil {
+				gotResp, gotErr = svc.SystemHistory(context.TODO(), req)
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expOps, gotResp.Operations, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected operations (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemExclude(t *testing.T) {
+	defaultMembers := system.Members{
+		mockMember(t, 0, 1, "joined"),
+		mockMember(t, 1, 1, "excluded"),
+		mockMember(t, 2, 2, "joined"),
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq     bool
+		req        *mgmtpb.SystemExcludeReq
+		expResults []*sharedpb.RankResult
+		expMembers system.Members
+		expAbsent  string
+		expErr     error
+	}{
+		"nil req": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"no ranks or hosts": {
+			req:    &mgmtpb.SystemExcludeReq{},
+			expErr: errors.New("ranklist or hostlist required"),
+		},
+		"exclude ranks": {
+			req: &mgmtpb.SystemExcludeReq{Ranks: "0-1,4"},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 0, Action: "exclude", State: "excluded"},
+				{
+					Rank: 1, Action: "exclude", State: "excluded", Errored: true,
+					Msg: "rank 1 is already excluded",
+				},
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "excluded").WithInfo("administratively excluded"),
+				mockMember(t, 1, 1, "excluded"),
+				mockMember(t, 2, 2, "joined"),
+			},
+			expAbsent: "4",
+		},
+		"exclude host": {
+			req: &mgmtpb.SystemExcludeReq{Hosts: "10.0.0.2"},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 2, Action: "exclude", State: "excluded"},
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "excluded"),
+				mockMember(t, 2, 2, "excluded").WithInfo("administratively excluded"),
+			},
+		},
+		"clear exclusion": {
+			req: &mgmtpb.SystemExcludeReq{Ranks: "0-1", Clear: true},
+			expResults: []*sharedpb.RankResult{
+				{
+					Rank: 0, Action: "clear-exclude", State: "joined", Errored: true,
+					Msg: "rank 0 is not excluded",
+				},
+				{Rank: 1, Action: "clear-exclude", State: "stopped"},
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
+				mockMember(t, 2, 2, "joined"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockResolver := func(_ string, addr string) (*net.TCPAddr, error) {
+				return map[string]*net.TCPAddr{
+					"10.0.0.1:10001": {IP: net.ParseIP("10.0.0.1"), Port: 10001},
+					"10.0.0.2:10001": {IP: net.ParseIP("10.0.0.2"), Port: 10001},
+				}[addr], nil
+			}
+
+			svc := newTestMgmtSvc(t, log)
+			svc.membership = svc.membership.WithTCPResolver(mockResolver)
+			for _, m := range defaultMembers {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// drain group update requests
+			go func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-svc.groupUpdateReqs:
+					}
+				}
+			}()
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else {
+				req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemExclude(ctx, req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResults, gotResp.Results, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected results (-want, +got)\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expAbsent, gotResp.Absentranks, "absent ranks")
+			checkMembers(t, tc.expMembers, svc.membership)
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemSetFaultDomain(t *testing.T) {
+	mockFD := func(domain string) *system.FaultDomain {
+		return system.MustCreateFaultDomainFromString(domain)
+	}
+	defaultMembers := func() system.Members {
+		return system.Members{
+			mockMember(t, 0, 1, "joined").WithFaultDomain(mockFD("/rack0/host1")),
+			mockMember(t, 1, 1, "joined").WithFaultDomain(mockFD("/rack0/host1")),
+			mockMember(t, 2, 2, "stopped").WithFaultDomain(mockFD("/rack0/host2")),
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		nilReq     bool
+		req        *mgmtpb.SystemSetFaultDomainReq
+		expResults []*sharedpb.RankResult
+		expMembers system.Members
+		expAbsent  string
+		expErr     error
+	}{
+		"nil req": {
+			nilReq: true,
+			expErr: errors.New("nil request"),
+		},
+		"no ranks or hosts": {
+			req:    &mgmtpb.SystemSetFaultDomainReq{Domain: "/rack1/host1"},
+			expErr: errors.New("ranklist or hostlist required"),
+		},
+		"invalid domain": {
+			req:    &mgmtpb.SystemSetFaultDomainReq{Ranks: "0", Domain: "rack1"},
+			expErr: errors.New("invalid fault domain"),
+		},
+		"set ranks": {
+			req: &mgmtpb.SystemSetFaultDomainReq{Ranks: "1,4", Domain: "/rack1/host1"},
+			expResults: []*sharedpb.RankResult{
+				{Rank: 1, Action: "set-fault-domain", State: "joined"},
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined").WithFaultDomain(mockFD("/rack0/host1")),
+				func() *system.Member {
+					m := mockMember(t, 1, 1, "joined").WithFaultDomain(mockFD("/rack1/host1"))
+					m.AdminFaultDomain = true
+					return m
+				}(),
+				mockMember(t, 2, 2, "stopped").WithFaultDomain(mockFD("/rack0/host2")),
+			},
+			expAbsent: "4",
+		},
+		"set host wrong depth": {
+			req: &mgmtpb.SystemSetFaultDomainReq{Hosts: "10.0.0.2", Domain: "/rack1"},
+			expResults: []*sharedpb.RankResult{
+				{
+					Rank: 2, Action: "set-fault-domain", State: "stopped", Errored: true,
+					Msg: `fault domain "/rack1" has 1 layers, need 2`,
+				},
+			},
+			expMembers: defaultMembers(),
+		},
+		"clear unassigned": {
+			req: &mgmtpb.SystemSetFaultDomainReq{Ranks: "0", Clear: true},
+			expResults: []*sharedpb.RankResult{
+				{
+					Rank: 0, Action: "clear-fault-domain", State: "joined", Errored: true,
+					Msg: "rank 0 has no assigned fault domain",
+				},
+			},
+			expMembers: defaultMembers(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mockResolver := func(_ string, addr string) (*net.TCPAddr, error) {
+				return map[string]*net.TCPAddr{
+					"10.0.0.1:10001": {IP: net.ParseIP("10.0.0.1"), Port: 10001},
+					"10.0.0.2:10001": {IP: net.ParseIP("10.0.0.2"), Port: 10001},
+				}[addr], nil
+			}
+
+			svc := newTestMgmtSvc(t, log)
+			svc.membership = svc.membership.WithTCPResolver(mockResolver)
+			for _, m := range defaultMembers() {
+				if _, err := svc.membership.Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req := tc.req
+			if tc.nilReq {
+				req = nil
+			} else {
+				req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemSetFaultDomain(context.TODO(), req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResults, gotResp.Results, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected results (-want, +got)\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expAbsent, gotResp.Absentranks, "absent ranks")
+			checkMembers(t, tc.expMembers, svc.membership)
+		})
+	}
+}
+
+func TestServer_MgmtSvc_SystemActivateSpare(t *testing.T) {
+	defaultMembers := func() system.Members {
+		return system.Members{
+			mockMember(t, 0, 1, "joined"),
+			mockMember(t, 1, 1, "stopped"),
+			mockMember(t, 2, 2, "excluded").WithInfo("auto-excluded"),
+		}
+	}
+	hr := func(a int32, resp *ctlpb.ActivateSpareResp, err error) *control.HostResponse {
+		return &control.HostResponse{
+			Addr:    common.MockHostAddr(a).String(),
+			Message: resp,
+			Error:   err,
+		}
+	}
+	spareResp := &ctlpb.ActivateSpareResp{
+		SpareIdx:        1,
+		ReleasedDevices: []string{"0000:81:00.0"},
+		State:           system.MemberStateReady.String(),
+		SpareRank:       3,
+	}
+	// pool 1 holds targets on all ranks, pool 2 only on rank 0
+	pools := func(t *testing.T, svc *mgmtSvc) {
+		for i, ranks := range [][]system.Rank{{0, 1, 2}, {0}} {
+			ps := system.NewPoolService(uuid.MustParse(common.MockUUID(int32(i+1))),
+				1, 1, ranks)
+			ps.State = system.PoolServiceStateReady
+			ps.Replicas = []system.Rank{0}
+			addTestPoolService(t, svc.sysdb, ps)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req         *mgmtpb.SystemActivateSpareReq
+		mResp       *control.HostResponse
+		expResp     *mgmtpb.SystemActivateSpareResp
+		expMembers  system.Members
+		expExcluded []string // pools the rank is excluded from
+		expErr      error
+	}{
+		"nil req": {
+			req:    (*mgmtpb.SystemActivateSpareReq)(nil),
+			expErr: errors.New("nil request"),
+		},
+		"unknown rank": {
+			req:    &mgmtpb.SystemActivateSpareReq{Rank: 5},
+			expErr: errors.New("unable to find member with rank 5"),
+		},
+		"rank joined": {
+			req:    &mgmtpb.SystemActivateSpareReq{Rank: 0},
+			expErr: errors.New("rank 0 is joined"),
+		},
+		"host fails to activate spare": {
+			req:    &mgmtpb.SystemActivateSpareReq{Rank: 2},
+			mResp:  hr(2, nil, FaultNoSpareEngine(2)),
+			expErr: FaultNoSpareEngine(2),
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
+				mockMember(t, 2, 2, "excluded").WithInfo("auto-excluded"),
+			},
+		},
+		"stopped rank replaced": {
+			req:   &mgmtpb.SystemActivateSpareReq{Rank: 1},
+			mResp: hr(1, spareResp, nil),
+			expResp: &mgmtpb.SystemActivateSpareResp{
+				Host:            common.MockHostAddr(1).String(),
+				SpareIdx:        1,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateReady.String(),
+				SpareRank:       3,
+			},
+			expMembers: system.Members{
+				mockMember(t, 0, 1, "joined"),
+				mockMember(t, 1, 1, "excluded").WithInfo(
+					"replaced by spare engine 1 on " + common.MockHostAddr(1).String()),
+				mockMember(t, 2, 2, "excluded").WithInfo("auto-excluded"),
+			},
+			expExcluded: []string{common.MockUUID(1)},
+		},
+		"excluded rank replaced": {
+			req:   &mgmtpb.SystemActivateSpareReq{Rank: 2},
+			mResp: hr(2, spareResp, nil),
+			expResp: &mgmtpb.SystemActivateSpareResp{
+				Host:            common.MockHostAddr(2).String(),
+				SpareIdx:        1,
+				ReleasedDevices: []string{"0000:81:00.0"},
+				State:           system.MemberStateReady.String(),
+				SpareRank:       3,
+			},
+			expMembers:  defaultMembers(),
+			expExcluded: []string{common.MockUUID(1)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var hostResps []*control.HostResponse
+			if tc.mResp != nil {
+				hostResps = append(hostResps, tc.mResp)
+			}
+			svc := mgmtSystemTestSetup(t, log, defaultMembers(), hostResps)
+			pools(t, svc)
+			cfg := new(mockDrpcClientConfig)
+			cfg.setSendMsgResponse(drpc.Status_SUCCESS, nil, nil)
+			mdc := newMockDrpcClient(cfg)
+			svc.harness.instances[0].setDrpcClient(mdc)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// drain group update requests
+			go func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-svc.groupUpdateReqs:
+					}
+				}
+			}()
+
+			if tc.req != nil && tc.req.Sys == "" {
+				tc.req.Sys = build.DefaultSystemName
+			}
+
+			gotResp, gotErr := svc.SystemActivateSpare(ctx, tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expMembers != nil {
+				checkMembers(t, tc.expMembers, svc.membership)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected response (-want, +got)\n%s\n", diff)
+			}
+
+			var gotExcluded []string
+			for _, call := range mdc.calls {
+				if call.Method != drpc.MethodPoolExclude {
+					continue
+				}
+				req := new(mgmtpb.PoolExcludeReq)
+				if err := proto.Unmarshal(call.Body, req); err != nil {
+					t.Fatal(err)
+				}
+				common.AssertEqual(t, tc.req.Rank, req.Rank, "unexpected excluded rank")
+				gotExcluded = append(gotExcluded, req.Uuid)
+			}
+			if diff := cmp.Diff(tc.expExcluded, gotExcluded); diff != "" {
+				t.Fatalf("unexpected pool exclusions (-want, +got)\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func TestServer_MgmtSvc_SystemStart(t *testing.T) {
 	hr := func(a int32, rrs ...*sharedpb.RankResult) *control.HostResponse {
 		return &control.HostResponse{
@@ -1445,10 +2453,19 @@ func TestServer_MgmtSvc_SystemErase(t *testing.T) {
 				mockMember(t, 3, 2, "awaitformat"),
 			},
 		},
-		"filtered and oversubscribed ranks": {
+		"ranks running": {
 			members: system.Members{
 				mockMember(t, 0, 1, "stopped"),
 				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 2, 2, "stopping"),
+				mockMember(t, 3, 2, "stopped"),
+			},
+			expErrMsg: FaultSystemEraseRanksRunning(system.MustCreateRankSet("1-2")).Error(),
+		},
+		"filtered and oversubscribed ranks": {
+			members: system.Members{
+				mockMember(t, 0, 1, "stopped"),
+				mockMember(t, 1, 1, "stopped"),
 				mockMember(t, 2, 2, "stopped"),
 				mockMember(t, 3, 2, "stopped"),
 			},
@@ -1469,7 +2486,7 @@ func TestServer_MgmtSvc_SystemErase(t *testing.T) {
 		"filtered and oversubscribed hosts": {
 			members: system.Members{
 				mockMember(t, 0, 1, "stopped"),
-				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
 				mockMember(t, 2, 2, "stopped"),
 				mockMember(t, 3, 2, "stopped"),
 			},
@@ -1482,7 +2499,7 @@ func TestServer_MgmtSvc_SystemErase(t *testing.T) {
 			},
 			expMembers: system.Members{
 				mockMember(t, 0, 1, "stopped"),
-				mockMember(t, 1, 1, "joined"),
+				mockMember(t, 1, 1, "stopped"),
 				mockMember(t, 2, 2, "stopped"),
 				mockMember(t, 3, 2, "awaitformat"),
 			},
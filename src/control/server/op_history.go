@@ -0,0 +1,239 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// maxHistoryParamsLen limits the size of the request parameters stored
+	// with each operation record.
+	maxHistoryParamsLen = 1024
+	// historyForwardTimeout limits the time spent forwarding a record of
+	// an operation performed on this server to the MS.
+	historyForwardTimeout = 10 * time.Second
+)
+
+// historyOp describes how an administrative operation is recorded in the
+// operation history.
+type historyOp struct {
+	name     string
+	msOnly   bool // only performed on the MS leader
+	resultFn func(resp interface{}) (string, bool)
+}
+
+// historyOps are the gRPC methods recorded in the operation history, keyed
+// by full method name.
+var historyOps = map[string]*historyOp{
+	"/ctl.CtlSvc/StorageFormat":   {name: "storage-format", resultFn: storageFormatResult},
+	"/ctl.CtlSvc/FirmwareUpdate":  {name: "firmware-update", resultFn: firmwareUpdateResult},
+	"/mgmt.MgmtSvc/PoolCreate":    {name: "pool-create", msOnly: true, resultFn: daosStatusResult},
+	"/mgmt.MgmtSvc/PoolDestroy":   {name: "pool-destroy", msOnly: true, resultFn: daosStatusResult},
+	"/mgmt.MgmtSvc/PoolRestore":   {name: "pool-restore", msOnly: true, resultFn: daosStatusResult},
+	"/mgmt.MgmtSvc/SystemStart":   {name: "system-start", msOnly: true, resultFn: rankResultsResult},
+	"/mgmt.MgmtSvc/SystemStop":    {name: "system-stop", msOnly: true, resultFn: rankResultsResult},
+	"/mgmt.MgmtSvc/SystemExclude": {name: "system-exclude", msOnly: true, resultFn: rankResultsResult},
+}
+
+func daosStatusResult(resp interface{}) (string, bool) {
+	sr, ok := resp.(interface{ GetStatus() int32 })
+	if !ok || sr.GetStatus() == 0 {
+		return "ok", false
+	}
+	return fmt.Sprintf("failed: %s", drpc.DaosStatus(sr.GetStatus())), true
+}
+
+func rankResultsResult(resp interface{}) (string, bool) {
+	rr, ok := resp.(interface{ GetResults() []*sharedpb.RankResult })
+	if !ok {
+		return "ok", false
+	}
+
+	var failed int
+	for _, r := range rr.GetResults() {
+		if r.GetErrored() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("failed on %d/%d ranks", failed, len(rr.GetResults())), true
+	}
+	return fmt.Sprintf("ok on %d ranks", len(rr.GetResults())), false
+}
+
+func storageFormatResult(resp interface{}) (string, bool) {
+	fr, ok := resp.(*ctlpb.StorageFormatResp)
+	if !ok {
+		return "ok", false
+	}
+
+	var total, failed int
+	for _, cr := range fr.GetCrets() {
+		total++
+		if cr.GetState().GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
+			failed++
+		}
+	}
+	for _, mr := range fr.GetMrets() {
+		total++
+		if mr.GetState().GetStatus() != ctlpb.ResponseStatus_CTL_SUCCESS {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("failed on %d/%d devices", failed, total), true
+	}
+	return fmt.Sprintf("ok on %d devices", total), false
+}
+
+func firmwareUpdateResult(resp interface{}) (string, bool) {
+	ur, ok := resp.(*ctlpb.FirmwareUpdateResp)
+	if !ok {
+		return "ok", false
+	}
+
+	var total, failed int
+	for _, sr := range ur.GetScmResults() {
+		total++
+		if sr.GetError() != "" {
+			failed++
+		}
+	}
+	for _, nr := range ur.GetNvmeResults() {
+		total++
+		if nr.GetError() != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("failed on %d/%d devices", failed, total), true
+	}
+	return fmt.Sprintf("ok on %d devices", total), false
+}
+
+// initiatorFromContext returns the identity of the client certificate and the
+// address of the peer that made the request.
+func initiatorFromContext(ctx context.Context) (initiator, addr string) {
+	initiator = "unauthenticated"
+	if comp, err := componentFromContext(ctx); err == nil {
+		initiator = comp.String()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+	}
+
+	return
+}
+
+func historyParams(req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+
+	params := prototext.MarshalOptions{}.Format(msg)
+	if len(params) > maxHistoryParamsLen {
+		params = params[:maxHistoryParamsLen] + "..."
+	}
+	return params
+}
+
+// opHistory records the administrative operations handled by the control
+// plane gRPC server in the operation history held by the MS. Operations
+// performed on the MS leader are recorded directly, other operations are
+// forwarded to the MS on a best-effort basis.
+type opHistory struct {
+	log       logging.Logger
+	sysdb     *system.Database
+	rpcClient control.UnaryInvoker
+	host      string
+}
+
+func (oh *opHistory) newRecord(ctx context.Context, op *historyOp, req, resp interface{}, err error) *system.OperationRecord {
+	rec := &system.OperationRecord{
+		Time:       time.Now(),
+		Operation:  op.name,
+		Host:       oh.host,
+		Parameters: historyParams(req),
+	}
+	rec.Initiator, rec.Address = initiatorFromContext(ctx)
+
+	if err != nil {
+		rec.Result = err.Error()
+		rec.Failed = true
+	} else {
+		rec.Result, rec.Failed = op.resultFn(resp)
+	}
+
+	return rec
+}
+
+// forward sends the record of an operation performed on this server to the
+// MS to be added to the history.
+func (oh *opHistory) forward(rec *system.OperationRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), historyForwardTimeout)
+	defer cancel()
+
+	err := control.SystemHistoryRecord(ctx, oh.rpcClient, &control.SystemHistoryRecordReq{
+		Record: &control.OperationRecord{
+			Time:       rec.Time,
+			Operation:  rec.Operation,
+			Initiator:  rec.Initiator,
+			Address:    rec.Address,
+			Host:       rec.Host,
+			Parameters: rec.Parameters,
+			Result:     rec.Result,
+			Failed:     rec.Failed,
+		},
+	})
+	if err != nil {
+		oh.log.Debugf("%s operation not recorded in history: %s", rec.Operation, err)
+	}
+}
+
+// record adds a record of the completed operation to the history.
+func (oh *opHistory) record(ctx context.Context, op *historyOp, req, resp interface{}, err error) {
+	if !oh.sysdb.IsLeader() {
+		// Operations that are only performed on the MS leader will
+		// have been rejected by this server.
+		if !op.msOnly && oh.rpcClient != nil {
+			go oh.forward(oh.newRecord(ctx, op, req, resp, err))
+		}
+		return
+	}
+
+	rec := oh.newRecord(ctx, op, req, resp, err)
+	if err := oh.sysdb.RecordOperation(rec); err != nil {
+		oh.log.Debugf("%s operation not recorded in history: %s", rec.Operation, err)
+	}
+}
+
+func (oh *opHistory) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+
+	if op, found := historyOps[info.FullMethod]; found {
+		oh.record(ctx, op, req, res, err)
+	}
+
+	return res, err
+}
@@ -0,0 +1,250 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	sharedpb "github.com/daos-stack/daos/src/control/common/proto/shared"
+	"github.com/daos-stack/daos/src/control/drpc"
+	"github.com/daos-stack/daos/src/control/lib/control"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_opHistory_results(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resultFn  func(interface{}) (string, bool)
+		resp      interface{}
+		expResult string
+		expFailed bool
+	}{
+		"daos status ok": {
+			resultFn:  daosStatusResult,
+			resp:      &mgmtpb.PoolCreateResp{},
+			expResult: "ok",
+		},
+		"daos status failed": {
+			resultFn:  daosStatusResult,
+			resp:      &mgmtpb.PoolDestroyResp{Status: int32(drpc.DaosNoSpace)},
+			expResult: "failed: " + drpc.DaosNoSpace.Error(),
+			expFailed: true,
+		},
+		"rank results ok": {
+			resultFn: rankResultsResult,
+			resp: &mgmtpb.SystemStopResp{
+				Results: []*sharedpb.RankResult{{Rank: 0}, {Rank: 1}},
+			},
+			expResult: "ok on 2 ranks",
+		},
+		"rank results failed": {
+			resultFn: rankResultsResult,
+			resp: &mgmtpb.SystemStartResp{
+				Results: []*sharedpb.RankResult{{Rank: 0, Errored: true}, {Rank: 1}},
+			},
+			expResult: "failed on 1/2 ranks",
+			expFailed: true,
+		},
+		"storage format failed": {
+			resultFn: storageFormatResult,
+			resp: &ctlpb.StorageFormatResp{
+				Crets: []*ctlpb.NvmeControllerResult{
+					{State: &ctlpb.ResponseState{Status: ctlpb.ResponseStatus_CTL_ERR_NVME}},
+				},
+				Mrets: []*ctlpb.ScmMountResult{
+					{State: &ctlpb.ResponseState{}},
+				},
+			},
+			expResult: "failed on 1/2 devices",
+			expFailed: true,
+		},
+		"firmware update ok": {
+			resultFn: firmwareUpdateResult,
+			resp: &ctlpb.FirmwareUpdateResp{
+				NvmeResults: []*ctlpb.NvmeFirmwareUpdateResp{{PciAddr: "0000:80:00.0"}},
+			},
+			expResult: "ok on 1 devices",
+		},
+		"firmware update failed": {
+			resultFn: firmwareUpdateResult,
+			resp: &ctlpb.FirmwareUpdateResp{
+				ScmResults: []*ctlpb.ScmFirmwareUpdateResp{{Error: "failed"}},
+			},
+			expResult: "failed on 1/1 devices",
+			expFailed: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotResult, gotFailed := tc.resultFn(tc.resp)
+			common.AssertEqual(t, tc.expResult, gotResult, "unexpected result")
+			common.AssertEqual(t, tc.expFailed, gotFailed, "unexpected failed")
+		})
+	}
+}
+
+func TestServer_opHistory_unaryInterceptor(t *testing.T) {
+	clientAddr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 9), Port: 41234}
+
+	for name, tc := range map[string]struct {
+		notLeader  bool
+		method     string
+		req        interface{}
+		resp       interface{}
+		handlerErr error
+		expRecords []*system.OperationRecord
+	}{
+		"not recorded": {
+			method: "/mgmt.MgmtSvc/PoolQuery",
+			req:    &mgmtpb.PoolQueryReq{Uuid: common.MockUUID(1)},
+			resp:   &mgmtpb.PoolQueryResp{},
+		},
+		"pool create": {
+			method: "/mgmt.MgmtSvc/PoolCreate",
+			req:    &mgmtpb.PoolCreateReq{Uuid: common.MockUUID(1)},
+			resp:   &mgmtpb.PoolCreateResp{},
+			expRecords: []*system.OperationRecord{
+				{
+					ID:        1,
+					Operation: "pool-create",
+					Initiator: "unauthenticated",
+					Address:   clientAddr.String(),
+					Host:      "host1",
+					Result:    "ok",
+				},
+			},
+		},
+		"system stop failed": {
+			method:     "/mgmt.MgmtSvc/SystemStop",
+			req:        &mgmtpb.SystemStopReq{Force: true},
+			handlerErr: errors.New("stop failed"),
+			expRecords: []*system.OperationRecord{
+				{
+					ID:        1,
+					Operation: "system-stop",
+					Initiator: "unauthenticated",
+					Address:   clientAddr.String(),
+					Host:      "host1",
+					Result:    "stop failed",
+					Failed:    true,
+				},
+			},
+		},
+		"ms operation on non-leader": {
+			notLeader: true,
+			method:    "/mgmt.MgmtSvc/PoolDestroy",
+			req:       &mgmtpb.PoolDestroyReq{Uuid: common.MockUUID(1)},
+			resp:      &mgmtpb.PoolDestroyResp{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var db *system.Database
+			if tc.notLeader {
+				var err error
+				if db, err = system.NewDatabase(log, &system.DatabaseConfig{}); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				db = system.MockDatabase(t, log)
+			}
+			oh := &opHistory{
+				log:   log,
+				sysdb: db,
+				host:  "host1",
+			}
+
+			ctx := peer.NewContext(context.TODO(), &peer.Peer{Addr: clientAddr})
+			handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+				return tc.resp, tc.handlerErr
+			}
+			gotResp, gotErr := oh.unaryInterceptor(ctx, tc.req,
+				&grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			common.CmpErr(t, tc.handlerErr, gotErr)
+			if gotResp != tc.resp {
+				t.Fatal("handler response not returned")
+			}
+
+			if tc.notLeader {
+				return
+			}
+			gotRecords, err := db.OperationHistory(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rec := range gotRecords {
+				if rec.Parameters != historyParams(tc.req) {
+					t.Fatalf("unexpected parameters %q", rec.Parameters)
+				}
+			}
+			cmpOpts := []cmp.Option{
+				cmpopts.IgnoreFields(system.OperationRecord{}, "Time", "Parameters"),
+			}
+			if diff := cmp.Diff(tc.expRecords, gotRecords, cmpOpts...); diff != "" {
+				t.Fatalf("unexpected records (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_opHistory_forward(t *testing.T) {
+	for name, tc := range map[string]struct {
+		uResp  *control.UnaryResponse
+		expLog string
+	}{
+		"recorded": {
+			uResp: control.MockMSResponse("10.0.0.1:10001", nil, &mgmtpb.SystemHistoryRecordResp{}),
+		},
+		"MS unavailable": {
+			uResp:  control.MockMSResponse("10.0.0.1:10001", system.ErrRaftUnavail, nil),
+			expLog: "storage-format operation not recorded in history",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			oh := &opHistory{
+				log: log,
+				rpcClient: control.NewMockInvoker(log, &control.MockInvokerConfig{
+					UnaryResponse: tc.uResp,
+				}),
+				host: "host1",
+			}
+			oh.forward(&system.OperationRecord{Operation: "storage-format"})
+
+			gotLog := strings.Contains(buf.String(), "not recorded in history")
+			common.AssertEqual(t, tc.expLog != "", gotLog, "unexpected log output")
+			if tc.expLog != "" && !strings.Contains(buf.String(), tc.expLog) {
+				t.Fatalf("expected %q in log", tc.expLog)
+			}
+		})
+	}
+}
+
+func TestServer_historyParams(t *testing.T) {
+	if got := historyParams("not a message"); got != "" {
+		t.Fatalf("unexpected params %q", got)
+	}
+
+	got := historyParams(&mgmtpb.PoolCreateReq{Uuid: strings.Repeat("x", 2*maxHistoryParamsLen)})
+	common.AssertEqual(t, maxHistoryParamsLen+3, len(got), "params not truncated")
+	common.AssertTrue(t, strings.HasSuffix(got, "..."), "missing truncation marker")
+}
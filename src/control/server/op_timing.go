@@ -0,0 +1,81 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+type opPhase struct {
+	name     string
+	duration time.Duration
+}
+
+// opTiming records the time taken by each phase of a long-running operation
+// so that it can be returned to the client. A nil *opTiming records nothing.
+type opTiming struct {
+	sync.Mutex
+	phases []opPhase
+}
+
+// add records the duration of the named phase.
+func (ot *opTiming) add(name string, duration time.Duration) {
+	if ot == nil {
+		return
+	}
+
+	ot.Lock()
+	defer ot.Unlock()
+
+	ot.phases = append(ot.phases, opPhase{name: name, duration: duration})
+}
+
+// start begins timing the named phase and returns a function that records its
+// duration when called.
+func (ot *opTiming) start(name string) func() {
+	began := time.Now()
+	return func() {
+		ot.add(name, time.Since(began))
+	}
+}
+
+// ctlTimings returns the recorded phases in the order in which they were added.
+func (ot *opTiming) ctlTimings() []*ctlpb.PhaseTiming {
+	ot.Lock()
+	defer ot.Unlock()
+
+	timings := make([]*ctlpb.PhaseTiming, 0, len(ot.phases))
+	for _, p := range ot.phases {
+		timings = append(timings, &ctlpb.PhaseTiming{
+			Phase:      p.name,
+			DurationUs: uint64(p.duration.Microseconds()),
+		})
+	}
+
+	return timings
+}
+
+// poolCreateTimings returns the recorded phases in the order in which they were
+// added.
+func (ot *opTiming) poolCreateTimings() []*mgmtpb.PoolCreateTiming {
+	ot.Lock()
+	defer ot.Unlock()
+
+	timings := make([]*mgmtpb.PoolCreateTiming, 0, len(ot.phases))
+	for _, p := range ot.phases {
+		timings = append(timings, &mgmtpb.PoolCreateTiming{
+			Phase:      p.name,
+			DurationUs: uint64(p.duration.Microseconds()),
+		})
+	}
+
+	return timings
+}
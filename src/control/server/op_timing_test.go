@@ -0,0 +1,49 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+)
+
+func TestServer_opTiming(t *testing.T) {
+	timing := new(opTiming)
+	timing.add("spdk init", 1500*time.Microsecond)
+	timing.add("nvme format", 2*time.Second)
+	done := timing.start("firmware check")
+	done()
+
+	ctlTimings := timing.ctlTimings()
+	common.AssertEqual(t, 3, len(ctlTimings), "number of timings")
+	common.AssertEqual(t, "firmware check", ctlTimings[2].Phase, "started phase")
+	if diff := cmp.Diff([]*ctlpb.PhaseTiming{
+		{Phase: "spdk init", DurationUs: 1500},
+		{Phase: "nvme format", DurationUs: 2000000},
+	}, ctlTimings[:2], common.DefaultCmpOpts()...); diff != "" {
+		t.Fatalf("unexpected timings (-want, +got):\n%s\n", diff)
+	}
+
+	pcTimings := timing.poolCreateTimings()
+	if diff := cmp.Diff([]*mgmtpb.PoolCreateTiming{
+		{Phase: "spdk init", DurationUs: 1500},
+		{Phase: "nvme format", DurationUs: 2000000},
+	}, pcTimings[:2], common.DefaultCmpOpts()...); diff != "" {
+		t.Fatalf("unexpected timings (-want, +got):\n%s\n", diff)
+	}
+
+	// a nil timing records nothing
+	var nilTiming *opTiming
+	nilTiming.add("scm format", time.Second)
+	nilTiming.start("scm format")()
+}
@@ -0,0 +1,324 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/pbin"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const (
+	selinuxEnforcePath = "/sys/fs/selinux/enforce"
+
+	// pathModeBits are the mode bits of a path which may be required.
+	pathModeBits = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+)
+
+// relabelFn checks the SELinux label of a path against the loaded policy and
+// returns whether it differed, the expected label is applied unless dryRun
+// is set.
+type relabelFn func(path string, dryRun bool) (bool, error)
+
+// pathPerms describes the ownership and permissions required of a path used
+// by the server. Paths which are shared with other users, such as the
+// directories holding log files, are only checked for access as repairing
+// them could affect other services.
+type pathPerms struct {
+	desc     string
+	path     string
+	dir      bool
+	uid      int         // required owner, -1 if not checked
+	perms    os.FileMode // permission bits which must be set
+	setuid   bool
+	access   uint32 // access the server user requires, if ownership is not checked
+	optional bool   // path is not required to exist
+}
+
+// pathIssue describes a path which did not meet its requirements.
+type pathIssue struct {
+	Path    string
+	Problem string
+	Fixed   bool
+}
+
+func (pi *pathIssue) String() string {
+	return fmt.Sprintf("%s: %s", pi.Path, pi.Problem)
+}
+
+// pathChecker verifies server paths against their requirements and repairs
+// them unless reporting only.
+type pathChecker struct {
+	log     logging.Logger
+	noFix   bool
+	chown   func(string, int, int) error
+	chmod   func(string, os.FileMode) error
+	access  func(string, uint32) error
+	relabel relabelFn
+	issues  []*pathIssue
+}
+
+func newPathChecker(log logging.Logger, noFix bool) *pathChecker {
+	return &pathChecker{
+		log:     log,
+		noFix:   noFix,
+		chown:   os.Chown,
+		chmod:   os.Chmod,
+		access:  unix.Access,
+		relabel: getRelabelFn(),
+	}
+}
+
+// restorecon applies the SELinux policy label to the given path.
+func restorecon(path string, dryRun bool) (bool, error) {
+	args := []string{"-v"}
+	if dryRun {
+		args = append(args, "-n")
+	}
+	out, err := exec.Command("restorecon", append(args, path)...).CombinedOutput()
+	if err != nil {
+		return false, errors.Wrapf(err, "restorecon: %s", bytes.TrimSpace(out))
+	}
+
+	return len(bytes.TrimSpace(out)) > 0, nil
+}
+
+// getRelabelFn returns a relabelFn if SELinux is enabled and the tools to
+// restore labels are installed.
+func getRelabelFn() relabelFn {
+	if _, err := os.Stat(selinuxEnforcePath); err != nil {
+		return nil
+	}
+	if _, err := exec.LookPath("restorecon"); err != nil {
+		return nil
+	}
+
+	return restorecon
+}
+
+// report records a problem with a path and applies the fix, if given,
+// unless reporting only.
+func (pc *pathChecker) report(pp *pathPerms, problem string, fix func() error) {
+	issue := &pathIssue{Path: pp.path, Problem: problem}
+	pc.issues = append(pc.issues, issue)
+
+	switch {
+	case fix == nil:
+		pc.log.Errorf("%s %s (not repairable)", pp.desc, issue)
+	case pc.noFix:
+		pc.log.Errorf("%s %s (not repaired)", pp.desc, issue)
+	default:
+		if err := fix(); err != nil {
+			pc.log.Errorf("%s %s (repair failed: %s)", pp.desc, issue, err)
+			return
+		}
+		issue.Fixed = true
+		pc.log.Infof("%s %s (repaired)", pp.desc, issue)
+	}
+}
+
+func (pc *pathChecker) check(pp *pathPerms) {
+	fi, err := os.Stat(pp.path)
+	if err != nil {
+		if os.IsNotExist(err) && pp.optional {
+			return
+		}
+		pc.report(pp, err.Error(), nil)
+		return
+	}
+
+	if fi.IsDir() != pp.dir {
+		kind := "a directory"
+		if !pp.dir {
+			kind = "a file"
+		}
+		pc.report(pp, "not "+kind, nil)
+		return
+	}
+
+	if pp.uid >= 0 {
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok && int(st.Uid) != pp.uid {
+			pc.report(pp, fmt.Sprintf("owned by uid %d, expected uid %d", st.Uid, pp.uid),
+				func() error {
+					return pc.chown(pp.path, pp.uid, -1)
+				})
+		}
+	}
+
+	// re-read the mode as changing the owner clears the setuid bit
+	if fi, err = os.Stat(pp.path); err != nil {
+		pc.report(pp, err.Error(), nil)
+		return
+	}
+	want := fi.Mode()&pathModeBits | pp.perms
+	if pp.setuid {
+		want |= os.ModeSetuid
+	}
+	if want != fi.Mode()&pathModeBits {
+		pc.report(pp, fmt.Sprintf("mode %s, expected %s", fi.Mode(), want),
+			func() error {
+				return pc.chmod(pp.path, want)
+			})
+	}
+
+	if pp.access != 0 {
+		if err := pc.access(pp.path, pp.access); err != nil {
+			pc.report(pp, fmt.Sprintf("not accessible by server user: %s", err), nil)
+		}
+	}
+
+	if pc.relabel == nil {
+		return
+	}
+	differs, err := pc.relabel(pp.path, true)
+	if err != nil {
+		pc.log.Debugf("unable to check SELinux label of %s: %s", pp.path, err)
+		return
+	}
+	if differs {
+		pc.report(pp, "SELinux label does not match policy", func() error {
+			_, err := pc.relabel(pp.path, false)
+			return err
+		})
+	}
+}
+
+// isEmptyDir returns true if the path is a directory with no entries.
+func isEmptyDir(path string) bool {
+	entries, err := ioutil.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
+
+// serverPathPerms returns the requirements of the paths used by the server
+// when running as the given user.
+func serverPathPerms(cfg *config.Server, usr *user.User) ([]*pathPerms, error) {
+	uid, err := strconv.Atoi(usr.Uid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid uid %q", usr.Uid)
+	}
+
+	var pps []*pathPerms
+	if cfg.SocketDir != "" {
+		pps = append(pps, &pathPerms{
+			desc:  "socket_dir",
+			path:  cfg.SocketDir,
+			dir:   true,
+			uid:   uid,
+			perms: 0700,
+		})
+	}
+
+	logFiles := []string{cfg.ControlLogFile, cfg.HelperAuditLogFile}
+	for _, engineCfg := range cfg.Engines {
+		logFiles = append(logFiles, engineCfg.LogFile)
+	}
+	logDirs := make(map[string]bool)
+	for _, logFile := range logFiles {
+		// templated paths are only known once the engine is started
+		if logFile == "" || strings.Contains(logFile, "{{") {
+			continue
+		}
+		pps = append(pps, &pathPerms{
+			desc:     "log file",
+			path:     logFile,
+			uid:      uid,
+			perms:    0600,
+			optional: true,
+		})
+
+		logDir := filepath.Dir(logFile)
+		if logDirs[logDir] {
+			continue
+		}
+		logDirs[logDir] = true
+		if _, err := os.Stat(logFile); err == nil {
+			continue
+		}
+		pps = append(pps, &pathPerms{
+			desc:     "log directory",
+			path:     logDir,
+			dir:      true,
+			uid:      -1,
+			access:   unix.W_OK | unix.X_OK,
+			optional: true,
+		})
+	}
+
+	for _, engineCfg := range cfg.Engines {
+		mountPoint := engineCfg.Storage.SCM().MountPoint
+		// an empty mountpoint has not yet been mounted or formatted
+		if mountPoint == "" || isEmptyDir(mountPoint) {
+			continue
+		}
+		pps = append(pps, &pathPerms{
+			desc:     "scm mountpoint",
+			path:     mountPoint,
+			dir:      true,
+			uid:      uid,
+			perms:    0700,
+			optional: true,
+		})
+	}
+
+	// helpers are not used when running in a container
+	if !cfg.Containerized {
+		for _, helper := range []string{pbin.DaosAdminName, pbin.DaosFWName} {
+			helperPath, err := common.FindBinary(helper)
+			if err != nil {
+				continue
+			}
+			pps = append(pps, &pathPerms{
+				desc:   "helper binary",
+				path:   helperPath,
+				uid:    0,
+				setuid: true,
+				access: unix.X_OK,
+			})
+		}
+	}
+
+	return pps, nil
+}
+
+// checkServerPaths verifies the ownership, permissions and SELinux labels of
+// the paths used by the server, repairing them unless the checker only
+// reports. Problems are logged rather than failing startup so that any
+// resulting failure is reported in context.
+func checkServerPaths(log logging.Logger, cfg *config.Server, pc *pathChecker) []*pathIssue {
+	usr, err := user.Current()
+	if err != nil {
+		log.Debugf("path check skipped: %s", err)
+		return nil
+	}
+
+	pps, err := serverPathPerms(cfg, usr)
+	if err != nil {
+		log.Debugf("path check skipped: %s", err)
+		return nil
+	}
+
+	for _, pp := range pps {
+		pc.check(pp)
+	}
+
+	return pc.issues
+}
@@ -0,0 +1,240 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+)
+
+func TestServer_pathChecker_check(t *testing.T) {
+	uid := os.Getuid()
+
+	for name, tc := range map[string]struct {
+		mode        os.FileMode // mode of the test directory, file if zero
+		missing     bool
+		pp          pathPerms
+		noFix       bool
+		accessErr   error
+		relabelled  bool
+		expIssues   []*pathIssue
+		expMode     os.FileMode
+		expChown    bool
+		expRelabels int
+	}{
+		"meets requirements": {
+			mode:    0755,
+			pp:      pathPerms{dir: true, uid: uid, perms: 0700},
+			expMode: 0755,
+		},
+		"missing optional": {
+			missing: true,
+			pp:      pathPerms{dir: true, uid: uid, optional: true},
+		},
+		"missing required": {
+			missing: true,
+			pp:      pathPerms{dir: true, uid: uid},
+			expIssues: []*pathIssue{
+				{Problem: "no such file or directory"},
+			},
+		},
+		"file instead of directory": {
+			pp: pathPerms{dir: true, uid: uid},
+			expIssues: []*pathIssue{
+				{Problem: "not a directory"},
+			},
+		},
+		"missing permissions repaired": {
+			mode: 0500,
+			pp:   pathPerms{dir: true, uid: uid, perms: 0700},
+			expIssues: []*pathIssue{
+				{Problem: "mode dr-x------, expected -rwx------", Fixed: true},
+			},
+			expMode: 0700,
+		},
+		"missing permissions reported": {
+			mode:  0500,
+			pp:    pathPerms{dir: true, uid: uid, perms: 0700},
+			noFix: true,
+			expIssues: []*pathIssue{
+				{Problem: "mode dr-x------, expected -rwx------"},
+			},
+			expMode: 0500,
+		},
+		"wrong owner repaired": {
+			mode: 0700,
+			pp:   pathPerms{dir: true, uid: uid + 1},
+			expIssues: []*pathIssue{
+				{
+					Problem: "owned by uid " + strconv.Itoa(uid) +
+						", expected uid " + strconv.Itoa(uid+1),
+					Fixed: true,
+				},
+			},
+			expMode:  0700,
+			expChown: true,
+		},
+		"not accessible": {
+			mode:      0700,
+			pp:        pathPerms{dir: true, uid: -1, access: 2},
+			accessErr: errors.New("permission denied"),
+			expIssues: []*pathIssue{
+				{Problem: "not accessible by server user: permission denied"},
+			},
+			expMode: 0700,
+		},
+		"selinux label repaired": {
+			mode:       0700,
+			pp:         pathPerms{dir: true, uid: uid},
+			relabelled: true,
+			expIssues: []*pathIssue{
+				{Problem: "SELinux label does not match policy", Fixed: true},
+			},
+			expMode:     0700,
+			expRelabels: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			tmpDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			testPath := filepath.Join(tmpDir, "test")
+			switch {
+			case tc.missing:
+			case tc.mode == 0:
+				testPath = common.CreateTestFile(t, tmpDir, "")
+			default:
+				if err := os.Mkdir(testPath, tc.mode); err != nil {
+					t.Fatal(err)
+				}
+				// set the mode explicitly as it is subject to umask
+				if err := os.Chmod(testPath, tc.mode); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var gotChown bool
+			var gotRelabels int
+			pc := newPathChecker(log, tc.noFix)
+			pc.chown = func(string, int, int) error {
+				gotChown = true
+				return nil
+			}
+			pc.access = func(string, uint32) error {
+				return tc.accessErr
+			}
+			pc.relabel = func(_ string, dryRun bool) (bool, error) {
+				if !dryRun {
+					gotRelabels++
+				}
+				return tc.relabelled, nil
+			}
+
+			pp := tc.pp
+			pp.desc = "test"
+			pp.path = testPath
+			pc.check(&pp)
+
+			if len(pc.issues) != len(tc.expIssues) {
+				t.Fatalf("expected %d issues, got %d: %v", len(tc.expIssues),
+					len(pc.issues), pc.issues)
+			}
+			for i, issue := range pc.issues {
+				common.AssertEqual(t, testPath, issue.Path, "path")
+				common.AssertTrue(t, strings.Contains(issue.Problem, tc.expIssues[i].Problem),
+					"unexpected problem: "+issue.Problem)
+				common.AssertEqual(t, tc.expIssues[i].Fixed, issue.Fixed, "fixed")
+			}
+			common.AssertEqual(t, tc.expChown, gotChown, "chown")
+			common.AssertEqual(t, tc.expRelabels, gotRelabels, "relabels")
+
+			if tc.expMode == 0 {
+				return
+			}
+			fi, err := os.Stat(testPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, tc.expMode, fi.Mode().Perm(), "mode")
+		})
+	}
+}
+
+func TestServer_serverPathPerms(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	usr, err := user.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	uid := os.Getuid()
+
+	sockDir := filepath.Join(tmpDir, "sock")
+	logDir := filepath.Join(tmpDir, "log")
+	scmMount := filepath.Join(tmpDir, "scm")
+	emptyMount := filepath.Join(tmpDir, "empty")
+	for _, dir := range []string{sockDir, logDir, scmMount, emptyMount} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	common.CreateTestFile(t, scmMount, "")
+
+	cfg := config.DefaultServer().
+		WithSocketDir(sockDir).
+		WithControlLogFile(filepath.Join(logDir, "daos_server.log")).
+		WithContainerized(true).
+		WithEngines(
+			engine.NewConfig().
+				WithLogFile(filepath.Join(logDir, "daos_engine.0.log")).
+				WithScmClass("ram").
+				WithScmMountPoint(scmMount),
+			engine.NewConfig().
+				WithLogFile(filepath.Join(logDir, "{{.Hostname}}.log")).
+				WithScmClass("ram").
+				WithScmMountPoint(emptyMount),
+		)
+
+	pps, err := serverPathPerms(cfg, usr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotPaths []string
+	for _, pp := range pps {
+		gotPaths = append(gotPaths, pp.desc+" "+pp.path)
+		if pp.desc != "log directory" && pp.uid != uid {
+			t.Fatalf("%s: expected uid %d, got %d", pp.path, uid, pp.uid)
+		}
+	}
+	expPaths := []string{
+		"socket_dir " + sockDir,
+		"log file " + filepath.Join(logDir, "daos_server.log"),
+		"log directory " + logDir,
+		"log file " + filepath.Join(logDir, "daos_engine.0.log"),
+		"scm mountpoint " + scmMount,
+	}
+	if diff := cmp.Diff(expPaths, gotPaths); diff != "" {
+		t.Fatalf("unexpected paths (-want, +got):\n%s\n", diff)
+	}
+}
@@ -0,0 +1,391 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+var pciAddrRe = regexp.MustCompile(`^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-9a-f]$`)
+
+// cpuHwmonNames are the names of the hwmon drivers reporting CPU temperatures.
+var cpuHwmonNames = map[string]bool{"coretemp": true, "k10temp": true, "zenpower": true}
+
+// raplPackage describes the energy counter of a CPU package as reported by
+// the RAPL powercap driver.
+type raplPackage struct {
+	Socket   int
+	EnergyUj uint64
+}
+
+// hwmonSensor describes a single temperature or power sensor reading from a
+// hwmon device.
+type hwmonSensor struct {
+	Label string
+	Value float64
+}
+
+// hwmonDevice describes a hwmon device and the sensors it reports. Socket is
+// -1 if the device is not associated with a CPU package and PCIAddr is empty
+// if it is not associated with a PCI device.
+type hwmonDevice struct {
+	Name    string
+	Socket  int
+	PCIAddr string
+	Temps   []*hwmonSensor
+	Power   []*hwmonSensor
+}
+
+func readSysfsString(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getRAPLPackages returns the package-level RAPL energy counters reported
+// under sysRoot, ordered by socket. Sub-zones such as core and dram are
+// not included.
+func getRAPLPackages(sysRoot string) ([]*raplPackage, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysRoot, "class/powercap/intel-rapl:*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*raplPackage
+	for _, dir := range dirs {
+		if strings.Count(filepath.Base(dir), ":") != 1 {
+			continue
+		}
+		name, err := readSysfsString(filepath.Join(dir, "name"))
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(name, "package-") {
+			continue
+		}
+		socket, err := strconv.Atoi(strings.TrimPrefix(name, "package-"))
+		if err != nil {
+			return nil, errors.Errorf("unexpected RAPL zone name %q", name)
+		}
+		energy, err := readSysfsString(filepath.Join(dir, "energy_uj"))
+		if err != nil {
+			return nil, err
+		}
+		pkg := &raplPackage{Socket: socket}
+		if pkg.EnergyUj, err = strconv.ParseUint(energy, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s energy", name)
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	sort.Slice(pkgs, func(i, j int) bool {
+		return pkgs[i].Socket < pkgs[j].Socket
+	})
+
+	return pkgs, nil
+}
+
+// pciAddrOfPath returns the address of the innermost PCI device in a
+// resolved sysfs device path, or an empty string if there is none.
+func pciAddrOfPath(path string) string {
+	for ; path != "/" && path != "."; path = filepath.Dir(path) {
+		if base := filepath.Base(path); pciAddrRe.MatchString(base) {
+			return base
+		}
+	}
+
+	return ""
+}
+
+// getHwmonSensors returns the readings of the sensors matching pattern
+// (e.g. "temp*_input") in a hwmon directory, ordered by label. Readings are
+// divided by scale and sensors without a label are labelled by file prefix.
+func getHwmonSensors(dir, pattern string, scale float64) ([]*hwmonSensor, error) {
+	files, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	var sensors []*hwmonSensor
+	for _, file := range files {
+		val, err := readSysfsString(file)
+		if err != nil {
+			return nil, err
+		}
+		num, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse %s", file)
+		}
+
+		prefix := strings.SplitN(filepath.Base(file), "_", 2)[0]
+		label, err := readSysfsString(filepath.Join(dir, prefix+"_label"))
+		if err != nil {
+			label = prefix
+		}
+		sensors = append(sensors, &hwmonSensor{Label: label, Value: num / scale})
+	}
+
+	sort.Slice(sensors, func(i, j int) bool {
+		return sensors[i].Label < sensors[j].Label
+	})
+
+	return sensors, nil
+}
+
+// hwmonSocket returns the CPU package that a CPU hwmon device reports on,
+// taken from the platform device instance for coretemp and from the NUMA
+// node of the PCI device otherwise.
+func hwmonSocket(devPath string) int {
+	if devPath == "" {
+		return -1
+	}
+	if strings.HasPrefix(filepath.Base(devPath), "coretemp.") {
+		if socket, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(devPath), "coretemp.")); err == nil {
+			return socket
+		}
+	}
+	if node, err := readSysfsInt(filepath.Join(devPath, "numa_node")); err == nil && node >= 0 {
+		return node
+	}
+
+	return -1
+}
+
+// getHwmonDevices returns the CPU, NVMe and power meter hwmon devices
+// reported under sysRoot. Temperatures are returned in degrees Celsius and
+// power in watts.
+func getHwmonDevices(sysRoot string) ([]*hwmonDevice, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysRoot, "class/hwmon/hwmon*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	var devs []*hwmonDevice
+	for _, dir := range dirs {
+		name, err := readSysfsString(filepath.Join(dir, "name"))
+		if err != nil {
+			return nil, err
+		}
+		devPath, err := filepath.EvalSymlinks(filepath.Join(dir, "device"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		dev := &hwmonDevice{Name: name, Socket: -1}
+		switch {
+		case cpuHwmonNames[name]:
+			dev.Socket = hwmonSocket(devPath)
+			if dev.Temps, err = getHwmonSensors(dir, "temp*_input", 1000); err != nil {
+				return nil, err
+			}
+		case name == "nvme":
+			dev.PCIAddr = pciAddrOfPath(devPath)
+			if dev.Temps, err = getHwmonSensors(dir, "temp*_input", 1000); err != nil {
+				return nil, err
+			}
+		case name == "power_meter":
+			// power meters report average power if input is unsupported
+			if dev.Power, err = getHwmonSensors(dir, "power*_input", 1e6); err != nil {
+				return nil, err
+			}
+			if len(dev.Power) == 0 {
+				if dev.Power, err = getHwmonSensors(dir, "power*_average", 1e6); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			continue
+		}
+		devs = append(devs, dev)
+	}
+
+	return devs, nil
+}
+
+// getCPUSocket returns the CPU package of a CPU as reported under sysRoot.
+func getCPUSocket(sysRoot string, cpu int) (int, error) {
+	return readSysfsInt(filepath.Join(sysRoot,
+		fmt.Sprintf("devices/system/cpu/cpu%d/topology/physical_package_id", cpu)))
+}
+
+// normalizePCIAddr returns a PCI address in the lower case domain-qualified
+// form used in sysfs.
+func normalizePCIAddr(addr string) string {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+	if strings.Count(addr, ":") == 1 {
+		addr = "0000:" + addr
+	}
+
+	return addr
+}
+
+// engineAttribution identifies an engine to which a device is attributed.
+type engineAttribution struct {
+	engine string
+	rank   string
+}
+
+// powerMetrics exports node power draw, CPU package energy and CPU and NVMe
+// temperatures, read from the powercap and hwmon sysfs interfaces when
+// collected. CPU packages and NVMe controllers used by an engine are
+// labelled with the index and rank of that engine.
+type powerMetrics struct {
+	log     logging.Logger
+	sysRoot string
+	engines []*EngineInstance
+
+	nodePower *prometheus.Desc
+	pkgEnergy *prometheus.Desc
+	cpuTemp   *prometheus.Desc
+	nvmeTemp  *prometheus.Desc
+}
+
+func newPowerMetrics(log logging.Logger, engines []*EngineInstance) *powerMetrics {
+	engineLabels := []string{"engine", "rank"}
+	return &powerMetrics{
+		log:     log,
+		sysRoot: defaultSysRoot,
+		engines: engines,
+		nodePower: prometheus.NewDesc(prometheus.BuildFQName("control", "power", "node_watts"),
+			"Power drawn by the node as reported by each power meter.", []string{"sensor"}, nil),
+		pkgEnergy: prometheus.NewDesc(prometheus.BuildFQName("control", "power", "package_energy_joules_total"),
+			"Energy consumed by each CPU package as reported by RAPL.",
+			append([]string{"socket"}, engineLabels...), nil),
+		cpuTemp: prometheus.NewDesc(prometheus.BuildFQName("control", "thermal", "cpu_celsius"),
+			"Temperature of each CPU sensor.",
+			append([]string{"socket", "sensor"}, engineLabels...), nil),
+		nvmeTemp: prometheus.NewDesc(prometheus.BuildFQName("control", "thermal", "nvme_celsius"),
+			"Temperature of each NVMe controller sensor.",
+			append([]string{"pci_address", "sensor"}, engineLabels...), nil),
+	}
+}
+
+// engineAttributions returns the engines using each CPU package and each
+// NVMe controller. Engines which cannot be attributed are skipped.
+func (pm *powerMetrics) engineAttributions() (map[int][]engineAttribution, map[string][]engineAttribution) {
+	sockets := make(map[int][]engineAttribution)
+	nvme := make(map[string][]engineAttribution)
+
+	for _, ei := range pm.engines {
+		cfg := ei.runner.GetConfig()
+		ea := engineAttribution{engine: fmt.Sprint(ei.Index())}
+		if rank, err := ei.GetRank(); err == nil {
+			ea.rank = rank.String()
+		}
+
+		cores, err := engineCores(pm.sysRoot, cfg)
+		if err != nil {
+			pm.log.Debugf("unable to determine cores of engine %d: %s", ei.Index(), err)
+		}
+		seen := make(map[int]bool)
+		for _, core := range cores {
+			socket, err := getCPUSocket(pm.sysRoot, core)
+			if err != nil {
+				pm.log.Debugf("unable to read package of cpu %d: %s", core, err)
+				continue
+			}
+			if !seen[socket] {
+				seen[socket] = true
+				sockets[socket] = append(sockets[socket], ea)
+			}
+		}
+
+		for _, dev := range cfg.Storage.Tiers.NvmeDevices() {
+			addr := normalizePCIAddr(dev)
+			nvme[addr] = append(nvme[addr], ea)
+		}
+	}
+
+	return sockets, nvme
+}
+
+// attrLabels returns the engine label values for each of the engines a
+// device is attributed to, or a single set of empty values if there are none.
+func (pm *powerMetrics) attrLabels(attrs []engineAttribution) [][]string {
+	if len(attrs) == 0 {
+		return [][]string{{"", ""}}
+	}
+
+	out := make([][]string, 0, len(attrs))
+	for _, ea := range attrs {
+		out = append(out, []string{ea.engine, ea.rank})
+	}
+	return out
+}
+
+// Describe implements prometheus.Collector.
+func (pm *powerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pm.nodePower
+	ch <- pm.pkgEnergy
+	ch <- pm.cpuTemp
+	ch <- pm.nvmeTemp
+}
+
+// Collect implements prometheus.Collector. A device used by several engines
+// is reported once for each of them. Values which cannot be read are omitted
+// rather than failing the whole collection.
+func (pm *powerMetrics) Collect(ch chan<- prometheus.Metric) {
+	sockets, nvme := pm.engineAttributions()
+
+	pkgs, err := getRAPLPackages(pm.sysRoot)
+	if err != nil {
+		pm.log.Debugf("unable to read RAPL energy counters: %s", err)
+	}
+	for _, pkg := range pkgs {
+		for _, attr := range pm.attrLabels(sockets[pkg.Socket]) {
+			ch <- prometheus.MustNewConstMetric(pm.pkgEnergy, prometheus.CounterValue,
+				float64(pkg.EnergyUj)/1e6, append([]string{strconv.Itoa(pkg.Socket)}, attr...)...)
+		}
+	}
+
+	devs, err := getHwmonDevices(pm.sysRoot)
+	if err != nil {
+		pm.log.Debugf("unable to read hwmon sensors: %s", err)
+	}
+	for _, dev := range devs {
+		for _, sensor := range dev.Power {
+			ch <- prometheus.MustNewConstMetric(pm.nodePower, prometheus.GaugeValue,
+				sensor.Value, sensor.Label)
+		}
+
+		switch {
+		case dev.Name == "nvme":
+			if dev.PCIAddr == "" {
+				continue
+			}
+			for _, sensor := range dev.Temps {
+				for _, attr := range pm.attrLabels(nvme[dev.PCIAddr]) {
+					ch <- prometheus.MustNewConstMetric(pm.nvmeTemp, prometheus.GaugeValue,
+						sensor.Value, append([]string{dev.PCIAddr, sensor.Label}, attr...)...)
+				}
+			}
+		case dev.Socket >= 0:
+			for _, sensor := range dev.Temps {
+				for _, attr := range pm.attrLabels(sockets[dev.Socket]) {
+					ch <- prometheus.MustNewConstMetric(pm.cpuTemp, prometheus.GaugeValue,
+						sensor.Value, append([]string{strconv.Itoa(dev.Socket), sensor.Label}, attr...)...)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+func TestServer_pciAddrOfPath(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path    string
+		expAddr string
+	}{
+		"empty": {},
+		"no pci device": {
+			path: "/sys/devices/platform/coretemp.0",
+		},
+		"nvme controller": {
+			path:    "/sys/devices/pci0000:80/0000:80:01.0/0000:81:00.0/nvme/nvme0",
+			expAddr: "0000:81:00.0",
+		},
+		"pci device": {
+			path:    "/sys/devices/pci0000:00/0000:00:18.3",
+			expAddr: "0000:00:18.3",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expAddr, pciAddrOfPath(tc.path), "pci address")
+		})
+	}
+}
+
+func TestServer_normalizePCIAddr(t *testing.T) {
+	for in, exp := range map[string]string{
+		"0000:81:00.0":  "0000:81:00.0",
+		"81:00.0":       "0000:81:00.0",
+		" 0000:AF:00.0": "0000:af:00.0",
+	} {
+		common.AssertEqual(t, exp, normalizePCIAddr(in), in)
+	}
+}
+
+func TestServer_powerMetrics_Collect(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	sysRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	for cpu, pkg := range []string{"0", "0", "1", "1"} {
+		writeTestFile(t, filepath.Join(sysRoot, "devices/system/cpu",
+			fmt.Sprintf("cpu%d", cpu), "topology/physical_package_id"), pkg+"\n")
+	}
+
+	raplDir := filepath.Join(sysRoot, "class/powercap")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:0/name"), "package-0\n")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:0/energy_uj"), "12500000\n")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:0:0/name"), "core\n")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:0:0/energy_uj"), "100\n")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:1/name"), "package-1\n")
+	writeTestFile(t, filepath.Join(raplDir, "intel-rapl:1/energy_uj"), "2000000\n")
+
+	for i, hw := range []struct {
+		name   string
+		device string
+		files  map[string]string
+	}{
+		{
+			name:   "coretemp",
+			device: "devices/platform/coretemp.0",
+			files:  map[string]string{"temp1_input": "45000", "temp1_label": "Package id 0"},
+		},
+		{
+			name:   "nvme",
+			device: "devices/pci0000:80/0000:80:01.0/0000:81:00.0/nvme/nvme0",
+			files:  map[string]string{"temp1_input": "38850", "temp1_label": "Composite"},
+		},
+		{
+			name:   "nvme",
+			device: "devices/pci0000:80/0000:80:02.0/0000:82:00.0/nvme/nvme1",
+			files:  map[string]string{"temp1_input": "40000"},
+		},
+		{
+			name:   "power_meter",
+			device: "devices/LNXSYSTM:00/ACPI000D:00",
+			files:  map[string]string{"power1_average": "350000000"},
+		},
+		{
+			name:   "acpitz",
+			device: "devices/virtual/thermal/thermal_zone0",
+			files:  map[string]string{"temp1_input": "27800"},
+		},
+	} {
+		dir := filepath.Join(sysRoot, "class/hwmon", fmt.Sprintf("hwmon%d", i))
+		writeTestFile(t, filepath.Join(dir, "name"), hw.name+"\n")
+		for file, content := range hw.files {
+			writeTestFile(t, filepath.Join(dir, file), content+"\n")
+		}
+		devPath := filepath.Join(sysRoot, hw.device)
+		if err := os.MkdirAll(devPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(devPath, filepath.Join(dir, "device")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engineCfgs := []*engine.Config{
+		engine.NewConfig().WithTargetCount(1).WithServiceThreadCore(0).
+			WithBdevClass("nvme").WithBdevDeviceList("81:00.0"),
+		engine.NewConfig().WithTargetCount(1).WithServiceThreadCore(2),
+	}
+	engines := make([]*EngineInstance, len(engineCfgs))
+	for i, cfg := range engineCfgs {
+		engines[i] = NewEngineInstance(log, nil, nil, nil,
+			engine.NewTestRunner(&engine.TestRunnerConfig{}, cfg))
+		engines[i].setIndex(uint32(i))
+	}
+	engines[0].setSuperblock(&Superblock{Rank: system.NewRankPtr(5)})
+
+	pm := newPowerMetrics(log, engines)
+	pm.sysRoot = sysRoot
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(pm); err != nil {
+		t.Fatal(err)
+	}
+	gotMetrics := gatherTestMetrics(t, reg)
+
+	for _, exp := range []string{
+		`control_power_node_watts{sensor="power1"} 350`,
+		`control_power_package_energy_joules_total{engine="0",rank="5",socket="0"} 12.5`,
+		`control_power_package_energy_joules_total{engine="1",rank="",socket="1"} 2`,
+		`control_thermal_cpu_celsius{engine="0",rank="5",sensor="Package id 0",socket="0"} 45`,
+		`control_thermal_nvme_celsius{engine="0",pci_address="0000:81:00.0",rank="5",sensor="Composite"} 38.85`,
+		`control_thermal_nvme_celsius{engine="",pci_address="0000:82:00.0",rank="",sensor="temp1"} 40`,
+	} {
+		if !strings.Contains(gotMetrics, exp) {
+			t.Errorf("expected %q in metrics:\n%s", exp, gotMetrics)
+		}
+	}
+	// RAPL sub-zones and unsupported hwmon devices are omitted
+	for _, notExp := range []string{"0.0001", "27.8"} {
+		if strings.Contains(gotMetrics, notExp) {
+			t.Errorf("unexpected %q in metrics:\n%s", notExp, gotMetrics)
+		}
+	}
+}
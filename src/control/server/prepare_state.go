@@ -0,0 +1,163 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	prepareRecordVersion = 1
+
+	prepareOpPrepare = "prepare"
+	prepareOpReset   = "reset"
+)
+
+// prepareDevice is the recorded driver binding of an NVMe SSD.
+type prepareDevice struct {
+	PciAddr string `yaml:"pci_addr"`
+	Driver  string `yaml:"driver"`
+}
+
+// prepareRecord is the on-disk representation of the last NVMe prepare or
+// reset operation performed on the host.
+type prepareRecord struct {
+	Version uint8                 `yaml:"version"`
+	Op      string                `yaml:"operation"`
+	Time    time.Time             `yaml:"time"`
+	Error   string                `yaml:"error,omitempty"`
+	State   storage.NvmeBindState `yaml:"state"`
+	Devices []*prepareDevice      `yaml:"devices"`
+}
+
+// prepareState is a persistent record of the last NVMe prepare or reset
+// operation performed on the host and the resulting driver binding of each
+// SSD. Device bindings do not survive a reboot, so the record together with
+// the current bindings lets an administrator tell whether the host was left
+// partially prepared, e.g. by an interrupted or failed operation, and whether
+// a reset is needed before OS maintenance.
+type prepareState struct {
+	sync.Mutex
+	log  logging.Logger
+	path string
+	last *prepareRecord
+}
+
+// newPrepareState returns an initialized prepare state that will be persisted
+// at the given path, an empty path results in a state that is not persisted.
+func newPrepareState(log logging.Logger, path string) *prepareState {
+	return &prepareState{
+		log:  log,
+		path: path,
+	}
+}
+
+// load returns the last recorded operation, reading it from the persistent
+// file if necessary. Nil is returned if no operation has been recorded.
+func (ps *prepareState) load() (*prepareRecord, error) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if ps.last != nil || ps.path == "" {
+		return ps.last, nil
+	}
+
+	data, err := ioutil.ReadFile(ps.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading prepare state %s", ps.path)
+	}
+
+	rec := new(prepareRecord)
+	if err := yaml.Unmarshal(data, rec); err != nil {
+		return nil, errors.Wrapf(err, "parsing prepare state %s", ps.path)
+	}
+	if rec.Version != prepareRecordVersion {
+		return nil, errors.Errorf("prepare state %s has unsupported version %d",
+			ps.path, rec.Version)
+	}
+	ps.last = rec
+
+	return rec, nil
+}
+
+// record stores the outcome of a prepare or reset operation along with the
+// device bindings that resulted from it, the file is replaced atomically.
+func (ps *prepareState) record(op string, opErr error, bindings storage.NvmeBindings) error {
+	rec := &prepareRecord{
+		Version: prepareRecordVersion,
+		Op:      op,
+		Time:    time.Now(),
+		State:   bindings.State(),
+	}
+	if opErr != nil {
+		rec.Error = opErr.Error()
+	}
+	for _, nb := range bindings {
+		rec.Devices = append(rec.Devices, &prepareDevice{
+			PciAddr: nb.PciAddr,
+			Driver:  nb.Driver,
+		})
+	}
+
+	ps.Lock()
+	defer ps.Unlock()
+
+	ps.last = rec
+	if ps.path == "" {
+		return nil
+	}
+
+	data, err := yaml.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "marshal prepare state")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ps.path), 0755); err != nil {
+		return errors.Wrapf(err, "creating prepare state directory")
+	}
+
+	tmpPath := ps.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return errors.Wrapf(err, "writing prepare state %s", tmpPath)
+	}
+
+	return errors.Wrapf(os.Rename(tmpPath, ps.path), "replacing prepare state %s", ps.path)
+}
+
+// status returns the current driver binding of the NVMe SSDs on the host
+// along with the last recorded prepare or reset operation.
+func (ps *prepareState) status(bindings storage.NvmeBindings) (*storage.NvmePrepareStatus, error) {
+	nps := &storage.NvmePrepareStatus{
+		BindState: bindings.State(),
+		Bindings:  bindings,
+	}
+
+	rec, err := ps.load()
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		nps.LastOp = rec.Op
+		nps.LastOpTime = uint64(rec.Time.Unix())
+		nps.LastOpError = rec.Error
+	}
+
+	return nps, nil
+}
@@ -0,0 +1,119 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestServer_prepareState(t *testing.T) {
+	spdkBound := storage.NvmeBindings{
+		{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+		{PciAddr: "0000:82:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+	}
+	partial := storage.NvmeBindings{
+		{PciAddr: "0000:81:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+		{PciAddr: "0000:82:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+	}
+
+	for name, tc := range map[string]struct {
+		fileContent string
+		op          string
+		opErr       error
+		recorded    storage.NvmeBindings
+		current     storage.NvmeBindings
+		expStatus   *storage.NvmePrepareStatus
+		expErr      error
+	}{
+		"nothing recorded": {
+			current: spdkBound,
+			expStatus: &storage.NvmePrepareStatus{
+				BindState: storage.NvmeBindStateSpdk,
+				Bindings:  spdkBound,
+			},
+		},
+		"prepare recorded": {
+			op:       prepareOpPrepare,
+			recorded: spdkBound,
+			current:  spdkBound,
+			expStatus: &storage.NvmePrepareStatus{
+				BindState: storage.NvmeBindStateSpdk,
+				Bindings:  spdkBound,
+				LastOp:    prepareOpPrepare,
+			},
+		},
+		"failed reset recorded": {
+			op:       prepareOpReset,
+			opErr:    errors.New("spdk reset failed"),
+			recorded: partial,
+			current:  partial,
+			expStatus: &storage.NvmePrepareStatus{
+				BindState:   storage.NvmeBindStatePartial,
+				Bindings:    partial,
+				LastOp:      prepareOpReset,
+				LastOpError: "spdk reset failed",
+			},
+		},
+		"unsupported version": {
+			fileContent: "version: 2\noperation: prepare\n",
+			expErr:      errors.New("unsupported version 2"),
+		},
+		"corrupt file": {
+			fileContent: "version: [\n",
+			expErr:      errors.New("parsing prepare state"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			path := filepath.Join(testDir, "state", "prepare_state.yaml")
+			if tc.fileContent != "" {
+				path = common.CreateTestFile(t, testDir, tc.fileContent)
+			}
+
+			if tc.op != "" {
+				if err := newPrepareState(log, path).record(tc.op, tc.opErr, tc.recorded); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := ioutil.ReadFile(path); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// reload from file to verify persistence
+			gotStatus, gotErr := newPrepareState(log, path).status(tc.current)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if tc.op != "" && gotStatus.LastOpTime == 0 {
+				t.Fatal("expected last op time to be set")
+			}
+			if diff := cmp.Diff(tc.expStatus, gotStatus,
+				cmp.FilterPath(func(p cmp.Path) bool {
+					return p.Last().String() == ".LastOpTime"
+				}, cmp.Ignore()),
+			); diff != "" {
+				t.Fatalf("unexpected status (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
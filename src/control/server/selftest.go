@@ -0,0 +1,345 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/system"
+)
+
+const (
+	// networkSelfTestBin is the CaRT self-test utility used to drive
+	// point-to-point tests between I/O Engines.
+	networkSelfTestBin = "self_test"
+	// storageSelfTestBin is the SPDK NVMe perf utility used to measure
+	// local SSD bandwidth.
+	storageSelfTestBin = "spdk_nvme_perf"
+)
+
+var (
+	stResultsRe  = regexp.MustCompile(`^Results for message size \((\d+)-\S+ (\d+)-\S+\)`)
+	stMasterRe   = regexp.MustCompile(`^Master Endpoint (\d+):\d+`)
+	stValueRe    = regexp.MustCompile(`^([A-Za-z0-9 ()/]+?)\s*:\s*([0-9.]+)$`)
+	perfTotalRe  = regexp.MustCompile(`^Total\s*:((?:\s+[0-9.]+){5})\s*$`)
+	perfWorkload = map[string]struct{}{
+		"read": {}, "write": {}, "randread": {}, "randwrite": {},
+	}
+)
+
+// selfTestRunFn executes the given self-test utility and returns its output.
+type selfTestRunFn func(ctx context.Context, env []string, bin string, args ...string) (string, error)
+
+// runSelfTestCmd executes the given self-test utility, resolving it from
+// PATH or alongside the running binary.
+func runSelfTestCmd(ctx context.Context, env []string, bin string, args ...string) (string, error) {
+	binPath, err := exec.LookPath(bin)
+	if err != nil {
+		binPath, err = common.GetAdjacentPath(bin)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to resolve path to %s", bin)
+		}
+		if _, err := os.Stat(binPath); err != nil {
+			return "", err
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "%s failed: %s", bin, out)
+	}
+
+	return string(out), nil
+}
+
+// writeAttachInfo writes the rank URIs in the format expected by
+// cart/crt_group.c:crt_group_config_load().
+func writeAttachInfo(dir, group string, uris []*ctlpb.RankUri) error {
+	var bld strings.Builder
+	fmt.Fprintf(&bld, "name %s\n", group)
+	fmt.Fprintf(&bld, "size %d\n", len(uris))
+	fmt.Fprintln(&bld, "all")
+	for _, ru := range uris {
+		fmt.Fprintf(&bld, "%d %s\n", ru.Rank, ru.Uri)
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, group+".attach_info_tmp"),
+		[]byte(bld.String()), 0600)
+}
+
+// endpointArg formats a rankset as a self_test endpoint specification.
+func endpointArg(rs *system.RankSet) string {
+	return strings.Trim(rs.String(), "[]") + ":0"
+}
+
+// parseNetworkSelfTest extracts the per-source, per-size results from the
+// output of the self_test utility.
+func parseNetworkSelfTest(out string) ([]*ctlpb.NetworkSelfTestResult, error) {
+	var results []*ctlpb.NetworkSelfTestResult
+	var size uint64
+	var cur *ctlpb.NetworkSelfTestResult
+
+	scn := bufio.NewScanner(strings.NewReader(out))
+	for scn.Scan() {
+		line := strings.TrimSpace(scn.Text())
+
+		if m := stResultsRe.FindStringSubmatch(line); m != nil {
+			// Report the larger of the send and reply sizes.
+			send, _ := strconv.ParseUint(m[1], 10, 32)
+			reply, _ := strconv.ParseUint(m[2], 10, 32)
+			size = send
+			if reply > size {
+				size = reply
+			}
+			cur = nil
+			continue
+		}
+		if m := stMasterRe.FindStringSubmatch(line); m != nil {
+			rank, _ := strconv.ParseUint(m[1], 10, 32)
+			cur = &ctlpb.NetworkSelfTestResult{
+				SrcRank: uint32(rank),
+				MsgSize: uint32(size),
+			}
+			results = append(results, cur)
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		m := stValueRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		val, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %q", line)
+		}
+		switch m[1] {
+		case "RPC Bandwidth (MB/sec)":
+			cur.Bandwidth = val
+		case "RPC Throughput (RPCs/sec)":
+			cur.Throughput = val
+		case "Min":
+			cur.LatMin = uint64(val)
+		case "Median":
+			cur.LatMedian = uint64(val)
+		case "Max":
+			cur.LatMax = uint64(val)
+		case "Average":
+			cur.LatAvg = uint64(val)
+		case "RPC Failures":
+			cur.Failures = uint32(val)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, errors.Errorf("no results found in %s output", networkSelfTestBin)
+	}
+
+	return results, nil
+}
+
+// parseStorageSelfTest extracts the summary result from the output of the
+// SPDK NVMe perf utility.
+func parseStorageSelfTest(pciAddr, out string) (*ctlpb.StorageSelfTestResult, error) {
+	scn := bufio.NewScanner(strings.NewReader(out))
+	for scn.Scan() {
+		m := perfTotalRe.FindStringSubmatch(strings.TrimSpace(scn.Text()))
+		if m == nil {
+			continue
+		}
+
+		var vals []float64
+		for _, field := range strings.Fields(m[1]) {
+			val, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing %q", field)
+			}
+			vals = append(vals, val)
+		}
+
+		return &ctlpb.StorageSelfTestResult{
+			PciAddr:   pciAddr,
+			Iops:      vals[0],
+			Bandwidth: vals[1],
+			LatAvg:    vals[2],
+			LatMin:    vals[3],
+			LatMax:    vals[4],
+		}, nil
+	}
+
+	return nil, errors.Errorf("no summary found in %s output for %s", storageSelfTestBin, pciAddr)
+}
+
+// NetworkSelfTest implements the method defined for the control service.
+//
+// Run the CaRT self-test utility from this host to measure fabric latency and
+// bandwidth between the I/O Engines of the requested source and destination
+// ranks. The fabric interface of the first local engine is used to reach the
+// source ranks.
+func (svc *ControlService) NetworkSelfTest(ctx context.Context, req *ctlpb.NetworkSelfTestReq) (*ctlpb.NetworkSelfTestResp, error) {
+	svc.log.Debugf("CtlSvc.NetworkSelfTest dispatch, req:%+v\n", req)
+
+	instances := svc.harness.Instances()
+	if len(instances) == 0 {
+		return nil, errors.New("no I/O Engines configured on host")
+	}
+
+	srcRanks, err := system.CreateRankSet(req.GetSrcRanks())
+	if err != nil {
+		return nil, errors.Wrap(err, "source ranks")
+	}
+	dstRanks, err := system.CreateRankSet(req.GetDstRanks())
+	if err != nil {
+		return nil, errors.Wrap(err, "destination ranks")
+	}
+	if srcRanks.Count() == 0 || dstRanks.Count() == 0 {
+		return nil, errors.New("source and destination ranks must be specified")
+	}
+	if len(req.GetMsgSizes()) == 0 || req.GetRepetitions() == 0 || req.GetMaxInflight() == 0 {
+		return nil, errors.New("message sizes, repetitions and max inflight must be non-zero")
+	}
+
+	known := make(map[system.Rank]struct{})
+	for _, ru := range req.GetRankUris() {
+		known[system.Rank(ru.Rank)] = struct{}{}
+	}
+	var unknown []system.Rank
+	for _, r := range append(srcRanks.Ranks(), dstRanks.Ranks()...) {
+		if _, found := known[r]; !found {
+			unknown = append(unknown, r)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, errors.Errorf("no fabric URI for ranks %s",
+			system.RankSetFromRanks(unknown).String())
+	}
+
+	dir, err := ioutil.TempDir("", "daos_self_test")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeAttachInfo(dir, req.GetSys(), req.GetRankUris()); err != nil {
+		return nil, errors.Wrap(err, "writing attach info")
+	}
+
+	sizes := make([]string, 0, len(req.GetMsgSizes()))
+	for _, size := range req.GetMsgSizes() {
+		sizes = append(sizes, strconv.FormatUint(uint64(size), 10))
+	}
+
+	fabric := instances[0].runner.GetConfig().Fabric
+	env := []string{
+		"CRT_PHY_ADDR_STR=" + req.GetProvider(),
+		"OFI_INTERFACE=" + fabric.Interface,
+	}
+	args := []string{
+		"--group-name", req.GetSys(),
+		"--singleton",
+		"--path", dir,
+		"--master-endpoint", endpointArg(srcRanks),
+		"--endpoint", endpointArg(dstRanks),
+		"--message-sizes", strings.Join(sizes, ","),
+		"--repetitions-per-size", strconv.FormatUint(uint64(req.GetRepetitions()), 10),
+		"--max-inflight-rpcs", strconv.FormatUint(uint64(req.GetMaxInflight()), 10),
+	}
+
+	out, err := svc.runSelfTest(ctx, env, networkSelfTestBin, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := parseNetworkSelfTest(out)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ctlpb.NetworkSelfTestResp{Results: results}
+	svc.log.Debugf("CtlSvc.NetworkSelfTest dispatch, resp:%+v\n", resp)
+
+	return resp, nil
+}
+
+// StorageSelfTest implements the method defined for the control service.
+//
+// Run the SPDK NVMe perf utility against each of the requested (or all
+// configured) local NVMe SSDs in turn. I/O Engines must be stopped so that
+// the devices are not in use. Write workloads overwrite the data on the SSDs
+// and are only run if forced.
+func (svc *ControlService) StorageSelfTest(ctx context.Context, req *ctlpb.StorageSelfTestReq) (*ctlpb.StorageSelfTestResp, error) {
+	svc.log.Debugf("CtlSvc.StorageSelfTest dispatch, req:%+v\n", req)
+
+	if _, found := perfWorkload[req.GetWorkload()]; !found {
+		return nil, errors.Errorf("invalid workload %q", req.GetWorkload())
+	}
+	if strings.HasSuffix(req.GetWorkload(), "write") && !req.GetForce() {
+		return nil, errors.Errorf("%s workload destroys any data on the tested SSDs, force is required",
+			req.GetWorkload())
+	}
+	if req.GetIoSize() == 0 || req.GetQueueDepth() == 0 || req.GetDuration() == 0 {
+		return nil, errors.New("io size, queue depth and duration must be non-zero")
+	}
+
+	pciAddrs := req.GetPciAddrs()
+	for _, ei := range svc.harness.Instances() {
+		if ei.isStarted() {
+			rank, err := ei.GetRank()
+			if err != nil {
+				return nil, errors.New("unidentified server rank is running")
+			}
+			return nil, FaultInstancesNotStopped("storage self-test", rank)
+		}
+		if len(req.GetPciAddrs()) == 0 && ei.bdevConfig().Class == storage.BdevClassNvme {
+			pciAddrs = append(pciAddrs, ei.bdevConfig().DeviceList...)
+		}
+	}
+	if len(pciAddrs) == 0 {
+		return nil, errors.New("no NVMe SSDs to test")
+	}
+
+	resp := new(ctlpb.StorageSelfTestResp)
+	for _, addr := range pciAddrs {
+		out, err := svc.runSelfTest(ctx, nil, storageSelfTestBin,
+			"-q", strconv.FormatUint(uint64(req.GetQueueDepth()), 10),
+			"-o", strconv.FormatUint(uint64(req.GetIoSize()), 10),
+			"-w", req.GetWorkload(),
+			"-t", strconv.FormatUint(uint64(req.GetDuration()), 10),
+			"-r", "trtype:PCIe traddr:"+addr)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := parseStorageSelfTest(addr, out)
+		if err != nil {
+			return nil, err
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	svc.log.Debugf("CtlSvc.StorageSelfTest dispatch, resp:%+v\n", resp)
+
+	return resp, nil
+}
@@ -0,0 +1,450 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const mockSelfTestOutput = `Self Test Parameters:
+  Group name to test against: daos_server
+  # endpoints:                2
+  Message sizes:              [(4096-BULK_GET 4096-BULK_PUT), (1048576-BULK_GET 1048576-BULK_PUT)]
+  Buffer addresses end with:  <Default>
+  Repetitions per size:       1000
+  Max inflight RPCs:          16
+
+##################################################
+Results for message size (4096-BULK_GET 4096-BULK_PUT) (max_inflight_rpcs = 16):
+
+Master Endpoint 0:0
+-------------------
+	RPC Bandwidth (MB/sec): 812.34
+	RPC Throughput (RPCs/sec): 103980
+	RPC Latencies (us):
+		Min    : 42
+		25th  %: 101
+		Median : 150
+		75th  %: 180
+		Max    : 977
+		Average: 153
+		Std Dev: 31.02
+	RPC Failures: 0
+
+	Endpoint results (rank:tag - Median Latency (us)):
+		1:0 - 150
+		2:0 - 151
+
+##################################################
+Results for message size (1048576-BULK_GET 1048576-BULK_PUT) (max_inflight_rpcs = 16):
+
+Master Endpoint 0:0
+-------------------
+	RPC Bandwidth (MB/sec): 11263.50
+	RPC Throughput (RPCs/sec): 5631
+	RPC Latencies (us):
+		Min    : 1200
+		25th  %: 2700
+		Median : 2800
+		75th  %: 2900
+		Max    : 5100
+		Average: 2810
+		Std Dev: 210.44
+	RPC Failures: 2
+
+	Endpoint results (rank:tag - Median Latency (us)):
+		1:0 - 2800
+		2:0 - 2799
+			Failures: 2
+
+`
+
+const mockPerfOutput = `Initializing NVMe Controllers
+Attached to NVMe Controller at 0000:81:00.0 [8086:0a54]
+Associating PCIE (0000:81:00.0) NSID 1 with lcore 0
+Initialization complete. Launching workers.
+========================================================
+                                                                           Latency(us)
+Device Information                                     :       IOPS      MiB/s    Average        min        max
+PCIE (0000:81:00.0) NSID 1 from core  0:   25361.20    3170.15    1261.52     210.11    8421.93
+========================================================
+Total                                                  :   25361.20    3170.15    1261.52     210.11    8421.93
+
+`
+
+func TestServer_parseNetworkSelfTest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		out        string
+		expResults []*ctlpb.NetworkSelfTestResult
+		expErr     error
+	}{
+		"no output": {
+			expErr: errors.New("no results"),
+		},
+		"all sizes failed": {
+			out: `Results for message size (4096-BULK_GET 4096-BULK_PUT) (max_inflight_rpcs = 16):
+
+Master Endpoint 3:0
+-------------------
+	RPC Bandwidth (MB/sec): 0.00
+	RPC Throughput (RPCs/sec): 0
+	All RPCs for this message size failed
+`,
+			expResults: []*ctlpb.NetworkSelfTestResult{
+				{SrcRank: 3, MsgSize: 4096},
+			},
+		},
+		"two sizes": {
+			out: mockSelfTestOutput,
+			expResults: []*ctlpb.NetworkSelfTestResult{
+				{
+					SrcRank:    0,
+					MsgSize:    4096,
+					Bandwidth:  812.34,
+					Throughput: 103980,
+					LatMin:     42,
+					LatMedian:  150,
+					LatMax:     977,
+					LatAvg:     153,
+				},
+				{
+					SrcRank:    0,
+					MsgSize:    1048576,
+					Bandwidth:  11263.50,
+					Throughput: 5631,
+					LatMin:     1200,
+					LatMedian:  2800,
+					LatMax:     5100,
+					LatAvg:     2810,
+					Failures:   2,
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotResults, gotErr := parseNetworkSelfTest(tc.out)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResults, gotResults, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected results (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_parseStorageSelfTest(t *testing.T) {
+	for name, tc := range map[string]struct {
+		out       string
+		expResult *ctlpb.StorageSelfTestResult
+		expErr    error
+	}{
+		"no output": {
+			expErr: errors.New("no summary"),
+		},
+		"success": {
+			out: mockPerfOutput,
+			expResult: &ctlpb.StorageSelfTestResult{
+				PciAddr:   "0000:81:00.0",
+				Iops:      25361.20,
+				Bandwidth: 3170.15,
+				LatAvg:    1261.52,
+				LatMin:    210.11,
+				LatMax:    8421.93,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotResult, gotErr := parseStorageSelfTest("0000:81:00.0", tc.out)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResult, gotResult, common.DefaultCmpOpts()...); diff != "" {
+				t.Fatalf("unexpected result (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestServer_CtlSvc_NetworkSelfTest(t *testing.T) {
+	mockURIs := []*ctlpb.RankUri{
+		{Rank: 0, Uri: "ofi+sockets://10.0.0.1:31416"},
+		{Rank: 1, Uri: "ofi+sockets://10.0.0.2:31416"},
+		{Rank: 2, Uri: "ofi+sockets://10.0.0.3:31416"},
+	}
+	validReq := func() *ctlpb.NetworkSelfTestReq {
+		return &ctlpb.NetworkSelfTestReq{
+			Sys:         "daos_server",
+			Provider:    "ofi+sockets",
+			RankUris:    mockURIs,
+			SrcRanks:    "0",
+			DstRanks:    "1-2",
+			MsgSizes:    []uint32{4096, 1048576},
+			Repetitions: 1000,
+			MaxInflight: 16,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		noEngines  bool
+		req        *ctlpb.NetworkSelfTestReq
+		runErr     error
+		runOut     string
+		expEnv     []string
+		expArgs    []string
+		expAttach  string
+		expResults int
+		expErr     error
+	}{
+		"no engines": {
+			noEngines: true,
+			req:       validReq(),
+			expErr:    errors.New("no I/O Engines"),
+		},
+		"missing ranks": {
+			req: func() *ctlpb.NetworkSelfTestReq {
+				req := validReq()
+				req.DstRanks = ""
+				return req
+			}(),
+			expErr: errors.New("must be specified"),
+		},
+		"unknown ranks": {
+			req: func() *ctlpb.NetworkSelfTestReq {
+				req := validReq()
+				req.DstRanks = "1-4"
+				return req
+			}(),
+			expErr: errors.New("no fabric URI for ranks 3-4"),
+		},
+		"zero repetitions": {
+			req: func() *ctlpb.NetworkSelfTestReq {
+				req := validReq()
+				req.Repetitions = 0
+				return req
+			}(),
+			expErr: errors.New("must be non-zero"),
+		},
+		"run fails": {
+			req:    validReq(),
+			runErr: errors.New("self_test failed"),
+			expErr: errors.New("self_test failed"),
+		},
+		"success": {
+			req:    validReq(),
+			runOut: mockSelfTestOutput,
+			expEnv: []string{"CRT_PHY_ADDR_STR=ofi+sockets", "OFI_INTERFACE=ib0"},
+			expArgs: []string{
+				"--group-name", "daos_server", "--singleton", "--path", "<dir>",
+				"--master-endpoint", "0:0", "--endpoint", "1-2:0",
+				"--message-sizes", "4096,1048576",
+				"--repetitions-per-size", "1000", "--max-inflight-rpcs", "16",
+			},
+			expAttach: `name daos_server
+size 3
+all
+0 ofi+sockets://10.0.0.1:31416
+1 ofi+sockets://10.0.0.2:31416
+2 ofi+sockets://10.0.0.3:31416
+`,
+			expResults: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			cfg := config.DefaultServer()
+			if !tc.noEngines {
+				cfg.Engines = append(cfg.Engines, engine.NewConfig().
+					WithTargetCount(1).WithFabricInterface("ib0"))
+			}
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+
+			var gotEnv, gotArgs []string
+			var gotAttach string
+			svc.runSelfTest = func(_ context.Context, env []string, bin string, args ...string) (string, error) {
+				common.AssertEqual(t, networkSelfTestBin, bin, "unexpected binary")
+				gotEnv = env
+				gotArgs = args
+				for i, arg := range args {
+					if arg == "--path" {
+						data, err := ioutil.ReadFile(filepath.Join(args[i+1], "daos_server.attach_info_tmp"))
+						if err != nil {
+							t.Fatal(err)
+						}
+						gotAttach = string(data)
+						args[i+1] = "<dir>"
+					}
+				}
+				return tc.runOut, tc.runErr
+			}
+
+			gotResp, gotErr := svc.NetworkSelfTest(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expEnv, gotEnv); diff != "" {
+				t.Fatalf("unexpected env (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expArgs, gotArgs); diff != "" {
+				t.Fatalf("unexpected args (-want, +got):\n%s\n", diff)
+			}
+			if diff := cmp.Diff(tc.expAttach, gotAttach); diff != "" {
+				t.Fatalf("unexpected attach info (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expResults, len(gotResp.Results), "unexpected result count")
+		})
+	}
+}
+
+func TestServer_CtlSvc_StorageSelfTest(t *testing.T) {
+	validReq := func() *ctlpb.StorageSelfTestReq {
+		return &ctlpb.StorageSelfTestReq{
+			Workload:   "read",
+			IoSize:     131072,
+			QueueDepth: 32,
+			Duration:   10,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		req        *ctlpb.StorageSelfTestReq
+		running    bool
+		bdevs      []string
+		runErr     error
+		expAddrs   []string
+		expResults int
+		expErr     error
+	}{
+		"bad workload": {
+			req: func() *ctlpb.StorageSelfTestReq {
+				req := validReq()
+				req.Workload = "trim"
+				return req
+			}(),
+			expErr: errors.New("invalid workload"),
+		},
+		"write workload without force": {
+			req: func() *ctlpb.StorageSelfTestReq {
+				req := validReq()
+				req.Workload = "randwrite"
+				return req
+			}(),
+			bdevs:  []string{"0000:81:00.0"},
+			expErr: errors.New("force is required"),
+		},
+		"forced write workload": {
+			req: func() *ctlpb.StorageSelfTestReq {
+				req := validReq()
+				req.Workload = "write"
+				req.Force = true
+				return req
+			}(),
+			bdevs:      []string{"0000:81:00.0"},
+			expAddrs:   []string{"0000:81:00.0"},
+			expResults: 1,
+		},
+		"zero duration": {
+			req: func() *ctlpb.StorageSelfTestReq {
+				req := validReq()
+				req.Duration = 0
+				return req
+			}(),
+			expErr: errors.New("must be non-zero"),
+		},
+		"engine running": {
+			req:     validReq(),
+			bdevs:   []string{"0000:81:00.0"},
+			running: true,
+			expErr:  FaultInstancesNotStopped("storage self-test", 0),
+		},
+		"no devices": {
+			req:    validReq(),
+			expErr: errors.New("no NVMe SSDs"),
+		},
+		"run fails": {
+			req:    validReq(),
+			bdevs:  []string{"0000:81:00.0"},
+			runErr: errors.New("perf failed"),
+			expErr: errors.New("perf failed"),
+		},
+		"configured devices": {
+			req:        validReq(),
+			bdevs:      []string{"0000:81:00.0", "0000:82:00.0"},
+			expAddrs:   []string{"0000:81:00.0", "0000:82:00.0"},
+			expResults: 2,
+		},
+		"requested devices": {
+			req: func() *ctlpb.StorageSelfTestReq {
+				req := validReq()
+				req.PciAddrs = []string{"0000:82:00.0"}
+				return req
+			}(),
+			bdevs:      []string{"0000:81:00.0", "0000:82:00.0"},
+			expAddrs:   []string{"0000:82:00.0"},
+			expResults: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			ec := engine.NewConfig().WithTargetCount(1).WithRank(0)
+			if len(tc.bdevs) > 0 {
+				ec = ec.WithBdevClass(storage.BdevClassNvme.String()).
+					WithBdevDeviceList(tc.bdevs...)
+			}
+			cfg := config.DefaultServer().WithEngines(ec)
+			svc := mockControlService(t, log, cfg, nil, nil, nil)
+			if !tc.running {
+				for i, srv := range svc.harness.instances {
+					srv.runner = engine.NewTestRunner(nil, cfg.Engines[i])
+				}
+			}
+
+			var gotAddrs []string
+			svc.runSelfTest = func(_ context.Context, _ []string, bin string, args ...string) (string, error) {
+				common.AssertEqual(t, storageSelfTestBin, bin, "unexpected binary")
+				addr := strings.TrimPrefix(args[len(args)-1], "trtype:PCIe traddr:")
+				gotAddrs = append(gotAddrs, addr)
+				return strings.Replace(mockPerfOutput, "0000:81:00.0", addr, -1), tc.runErr
+			}
+
+			gotResp, gotErr := svc.StorageSelfTest(context.TODO(), tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expAddrs, gotAddrs); diff != "" {
+				t.Fatalf("unexpected devices tested (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expResults, len(gotResp.Results), "unexpected result count")
+		})
+	}
+}
@@ -17,12 +17,15 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 
 	"github.com/daos-stack/daos/src/control/build"
 	ctlpb "github.com/daos-stack/daos/src/control/common/proto/ctl"
 	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
 	"github.com/daos-stack/daos/src/control/events"
+	"github.com/daos-stack/daos/src/control/fault"
+	"github.com/daos-stack/daos/src/control/fault/code"
 	"github.com/daos-stack/daos/src/control/lib/control"
 	"github.com/daos-stack/daos/src/control/lib/netdetect"
 	"github.com/daos-stack/daos/src/control/logging"
@@ -61,6 +64,7 @@ type server struct {
 	cfg         *config.Server
 	faultDomain *system.FaultDomain
 	ctlAddr     *net.TCPAddr
+	telemetryIP net.IP
 	netDevClass uint32
 	listener    net.Listener
 
@@ -86,6 +90,12 @@ func newServer(ctx context.Context, log *logging.LeveledLogger, cfg *config.Serv
 	// Create storage subsystem providers.
 	scmProvider := scm.DefaultProvider(log)
 	bdevProvider := bdev.DefaultProvider(log)
+	if cfg.Containerized {
+		// No privileged helper is available in a container, the server
+		// is expected to run with the necessary privileges itself.
+		scmProvider = scmProvider.WithForwardingDisabled()
+		bdevProvider = bdevProvider.WithForwardingDisabled()
+	}
 
 	return &server{
 		log:          log,
@@ -128,6 +138,7 @@ func (srv *server) createServices(ctx context.Context) error {
 	// Create rpcClient for inter-server communication.
 	cliCfg := control.DefaultConfig()
 	cliCfg.TransportConfig = srv.cfg.TransportConfig
+	cliCfg.Grpc = srv.cfg.Grpc
 	rpcClient := control.NewClient(
 		control.WithConfig(cliCfg),
 		control.WithClientLogger(srv.log))
@@ -136,12 +147,14 @@ func (srv *server) createServices(ctx context.Context) error {
 	srv.pubSub = events.NewPubSub(ctx, srv.log)
 	srv.OnShutdown(srv.pubSub.Close)
 	srv.evtForwarder = control.NewEventForwarder(rpcClient, srv.cfg.AccessPoints)
-	srv.evtLogger = control.NewEventLogger(srv.log)
+	srv.evtLogger = control.NewEventLogger(srv.log, srv.cfg.EventLogFormat)
 
 	srv.ctlSvc = NewControlService(srv.log, srv.harness, srv.bdevProvider, srv.scmProvider,
 		srv.cfg, srv.pubSub)
 
 	srv.mgmtSvc = newMgmtSvc(srv.harness, srv.membership, sysdb, rpcClient, srv.pubSub)
+	srv.mgmtSvc.faultPolicy = newFaultPolicy(srv.cfg.FaultPolicy)
+	srv.mgmtSvc.clockSkews = newClockSkews(srv.cfg.ClockSkewThreshold)
 
 	return nil
 }
@@ -168,7 +181,17 @@ func (srv *server) shutdown() {
 func (srv *server) initNetwork(ctx context.Context) error {
 	defer srv.logDuration(track("time to init network"))
 
-	ctlAddr, listener, err := createListener(srv.cfg.ControlPort, net.ResolveTCPAddr, net.Listen)
+	ctlIP, err := getListenIP(srv.cfg.ControlIface, getIfaceAddrs)
+	if err != nil {
+		return config.FaultConfigBadControlIface(srv.cfg.ControlIface, err)
+	}
+	telemetryIP, err := getListenIP(srv.cfg.TelemetryIface, getIfaceAddrs)
+	if err != nil {
+		return config.FaultConfigBadTelemetryIface(srv.cfg.TelemetryIface, err)
+	}
+	srv.telemetryIP = telemetryIP
+
+	ctlAddr, listener, err := createListener(ctlIP, srv.cfg.ControlPort, net.ResolveTCPAddr, net.Listen)
 	if err != nil {
 		return err
 	}
@@ -193,7 +216,32 @@ func (srv *server) initStorage() error {
 		return errors.Wrap(err, "unable to lookup current user")
 	}
 
-	if err := prepBdevStorage(srv, runningUser, iommuDetected(), getHugePageInfo); err != nil {
+	if err := srv.ctlSvc.ledger.claimEngineDevices(srv.cfg.Engines); err != nil {
+		return err
+	}
+
+	hpiGetter := getHugePageInfo
+	if srv.cfg.Containerized {
+		hpiGetter = getHugetlbfsPageInfoFn(defaultHugetlbfsMount)
+	}
+
+	if hasBdevs := cfgHasBdevs(srv.cfg); hasBdevs {
+		var devices []string
+		for _, engineCfg := range srv.cfg.Engines {
+			devices = append(devices, engineCfg.Storage.Tiers.BdevDevices()...)
+		}
+		if err := checkConflictingProcesses(srv.log, srv.ctlSvc.findConflictingProcs, devices); err != nil {
+			return err
+		}
+	}
+
+	if err := prepBdevStorage(srv, runningUser, iommuDetected(), hpiGetter); err != nil {
+		if f, ok := errors.Cause(err).(*fault.Fault); ok && f.Code == code.ServerInsufficientFreeHugePages {
+			if hpErr := checkHugepageHolders(srv.log, srv.ctlSvc.findConflictingProcs); hpErr != nil {
+				srv.log.Error(err.Error())
+				return hpErr
+			}
+		}
 		return err
 	}
 
@@ -211,16 +259,36 @@ func (srv *server) createEngine(ctx context.Context, idx int, cfg *engine.Config
 	}
 
 	// Indicate whether VMD devices have been detected and can be used.
-	cfg.Storage.Bdev.VmdDisabled = srv.bdevProvider.IsVMDDisabled()
+	cfg.Storage.VmdDisabled = srv.bdevProvider.IsVMDDisabled()
 
 	// TODO: ClassProvider should be encapsulated within bdevProvider
-	bcp, err := bdev.NewClassProvider(srv.log, cfg.Storage.SCM.MountPoint, &cfg.Storage.Bdev)
+	bcp, err := bdev.NewClassProvider(srv.log, cfg.Storage.SCM().MountPoint, &bdev.ClassConfig{
+		Tiers:       cfg.Storage.Tiers.BdevConfigs(),
+		Hostname:    cfg.Storage.Hostname,
+		VmdDisabled: cfg.Storage.VmdDisabled,
+	})
 	if err != nil {
 		return nil, err
 	}
+	cfg.Storage.ConfigPath = bcp.ConfigPath()
+	cfg.Storage.VosEnv = bcp.VosEnv()
 
 	engine := NewEngineInstance(srv.log, bcp, srv.scmProvider, joinFn,
 		engine.NewRunner(srv.log, cfg)).WithHostFaultDomain(srv.harness.faultDomain)
+	if fb := srv.cfg.FormationBarrier; fb != nil {
+		engine.WithFormationBarrier(newFormationBarrierFn(srv.log, fb,
+			func(ctxIn context.Context) (system.Members, error) {
+				req := new(control.SystemQueryReq)
+				req.SetHostList(srv.cfg.AccessPoints)
+				req.SetSystem(srv.cfg.SystemName)
+
+				resp, err := control.SystemQuery(ctxIn, srv.mgmtSvc.rpcClient, req)
+				if err != nil {
+					return nil, err
+				}
+				return resp.Members, nil
+			}, formationPollInterval))
+	}
 	if idx == 0 {
 		configureFirstEngine(ctx, engine, srv.sysdb, joinFn)
 	}
@@ -265,7 +333,28 @@ func (srv *server) addEngines(ctx context.Context) error {
 
 // setupGrpc creates a new grpc server and registers services.
 func (srv *server) setupGrpc() error {
-	srvOpts, err := getGrpcOpts(srv.cfg.TransportConfig)
+	obs := &rpcObserver{
+		log:           srv.log,
+		slowThreshold: srv.cfg.SlowRPCThreshold,
+	}
+	if telemetryEnabled(srv.cfg) {
+		metrics, err := registerGrpcMetrics(prometheus.DefaultRegisterer)
+		if err != nil {
+			return err
+		}
+		obs.metrics = metrics
+	}
+
+	hist := &opHistory{
+		log:       srv.log,
+		sysdb:     srv.sysdb,
+		rpcClient: srv.mgmtSvc.rpcClient,
+		host:      hostname(),
+	}
+
+	hook := newValidationHook(srv.log, srv.cfg, srv.sysdb)
+
+	srvOpts, err := getGrpcOpts(srv.cfg.TransportConfig, srv.cfg.Grpc, obs, hist, hook)
 	if err != nil {
 		return err
 	}
@@ -285,7 +374,8 @@ func (srv *server) setupGrpc() error {
 	if err != nil {
 		return err
 	}
-	srv.sysdb.ConfigureTransport(srv.grpcServer, tSec)
+	srv.sysdb.ConfigureTransport(srv.grpcServer,
+		append([]grpc.DialOption{tSec}, srv.cfg.Grpc.DialOptions()...)...)
 
 	return nil
 }
@@ -296,6 +386,7 @@ func (srv *server) registerEvents() {
 	srv.sysdb.OnLeadershipGained(func(ctx context.Context) error {
 		srv.log.Infof("MS leader running on %s", hostname())
 		srv.mgmtSvc.startJoinLoop(ctx)
+		srv.mgmtSvc.startPoolReclaimLoop(ctx)
 		registerLeaderSubscriptions(srv)
 		return nil
 	})
@@ -336,6 +427,14 @@ func Start(log *logging.LeveledLogger, cfg *config.Server) error {
 	if err != nil {
 		return err
 	}
+	checkCoreIsolation(log, cfg)
+	checkServerPaths(log, cfg, newPathChecker(log, cfg.NoPathFixup))
+
+	stopHelperAudit, err := setupHelperAudit(log, cfg)
+	if err != nil {
+		return err
+	}
+	defer stopHelperAudit()
 
 	// Create the root context here. All contexts should inherit from this one so
 	// that they can be shut down from one place.
@@ -369,6 +468,12 @@ func Start(log *logging.LeveledLogger, cfg *config.Server) error {
 	}
 
 	srv.registerEvents()
+	srv.mgmtSvc.startClockSkewLoop(ctx)
+	srv.ctlSvc.cfgHash.startLoop(ctx)
+
+	if err := registerHealthEndpoints(srv); err != nil {
+		return errors.Wrap(err, "start health endpoints")
+	}
 
 	return srv.start(ctx, shutdown)
 }
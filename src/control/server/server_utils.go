@@ -16,8 +16,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 
 	"github.com/daos-stack/daos/src/control/events"
@@ -38,6 +40,9 @@ type netListenFn func(string, string) (net.Listener, error)
 // resolveTCPFn is a type alias for the net.ResolveTCPAddr function signature.
 type resolveTCPFn func(string, string) (*net.TCPAddr, error)
 
+// ifaceAddrsFn returns the addresses assigned to the named network interface.
+type ifaceAddrsFn func(string) ([]net.Addr, error)
+
 const (
 	iommuPath        = "/sys/class/iommu"
 	minHugePageCount = 128
@@ -45,7 +50,7 @@ const (
 
 func cfgHasBdevs(cfg *config.Server) bool {
 	for _, engineCfg := range cfg.Engines {
-		if len(engineCfg.Storage.Bdev.DeviceList) > 0 {
+		if len(engineCfg.Storage.Tiers.BdevDevices()) > 0 {
 			return true
 		}
 	}
@@ -66,12 +71,19 @@ func cfgGetReplicas(cfg *config.Server, resolver resolveTCPFn) ([]*net.TCPAddr,
 	return dbReplicas, nil
 }
 
+// raftDirName is the name of the directory holding the control plane raft
+// database.
+const raftDirName = "control_raft"
+
 func cfgGetRaftDir(cfg *config.Server) string {
+	if cfg.ControlMetadata.HasPath() {
+		return filepath.Join(cfg.ControlMetadata.Directory(), raftDirName)
+	}
 	if len(cfg.Engines) == 0 {
 		return "" // can't save to SCM
 	}
 
-	return filepath.Join(cfg.Engines[0].Storage.SCM.MountPoint, "control_raft")
+	return filepath.Join(cfg.Engines[0].Storage.SCM().MountPoint, raftDirName)
 }
 
 func hostname() string {
@@ -94,8 +106,48 @@ func iommuDetected() bool {
 	return len(dmars) > 0
 }
 
-func createListener(ctlPort int, resolver resolveTCPFn, listener netListenFn) (*net.TCPAddr, net.Listener, error) {
-	ctlAddr, err := resolver("tcp", fmt.Sprintf("0.0.0.0:%d", ctlPort))
+func getIfaceAddrs(name string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if iface.Flags&net.FlagUp == 0 {
+		return nil, errors.New("interface is down")
+	}
+
+	return iface.Addrs()
+}
+
+// getListenIP returns the IPv4 address of the named interface to bind a
+// listener to, or the unspecified address if no interface is named so that
+// the listener is bound to all interfaces.
+func getListenIP(iface string, getAddrs ifaceAddrsFn) (net.IP, error) {
+	if iface == "" {
+		return net.IPv4zero, nil
+	}
+
+	addrs, err := getAddrs(iface)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		var ip net.IP
+		switch a := addr.(type) {
+		case *net.IPNet:
+			ip = a.IP
+		case *net.IPAddr:
+			ip = a.IP
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4, nil
+		}
+	}
+
+	return nil, errors.New("no IPv4 address assigned")
+}
+
+func createListener(ctlIP net.IP, ctlPort int, resolver resolveTCPFn, listener netListenFn) (*net.TCPAddr, net.Listener, error) {
+	ctlAddr, err := resolver("tcp", net.JoinHostPort(ctlIP.String(), fmt.Sprint(ctlPort)))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "unable to resolve daos_server control address")
 	}
@@ -165,6 +217,44 @@ func netInit(ctx context.Context, log *logging.LeveledLogger, cfg *config.Server
 	return netDevClass, nil
 }
 
+// selectNvmeDriver returns the userspace driver that NVMe devices should be
+// bound to. Unless overridden in config, vfio-pci is selected when an IOMMU is
+// available and uio_pci_generic otherwise, which requires root privileges.
+func selectNvmeDriver(cfg *config.Server, isRoot, iommuEnabled bool) (string, error) {
+	driver := cfg.NvmeDriver
+	if cfg.DisableVFIO {
+		driver = config.NvmeDriverUIO
+	}
+
+	switch driver {
+	case config.NvmeDriverUIO:
+		if !isRoot {
+			return "", FaultVfioDisabled
+		}
+	case config.NvmeDriverVFIO:
+		if !iommuEnabled {
+			return "", FaultVfioNoIommu
+		}
+	default:
+		if iommuEnabled {
+			return config.NvmeDriverVFIO, nil
+		}
+		if !isRoot {
+			return "", FaultIommuDisabled
+		}
+		return config.NvmeDriverUIO, nil
+	}
+
+	return driver, nil
+}
+
+// setPrepareDriver updates the prepare request to bind NVMe devices to the
+// given driver, VMD is only usable with the vfio-pci driver.
+func setPrepareDriver(req *bdev.PrepareRequest, cfg *config.Server, driver string) {
+	req.DisableVFIO = driver == config.NvmeDriverUIO
+	req.DisableVMD = cfg.DisableVMD || driver != config.NvmeDriverVFIO
+}
+
 func prepBdevStorage(srv *server, usr *user.User, iommuEnabled bool, hpiGetter getHugePageInfoFn) error {
 	// Perform an automatic prepare based on the values in the config file.
 	prepReq := bdev.PrepareRequest{
@@ -173,47 +263,66 @@ func prepBdevStorage(srv *server, usr *user.User, iommuEnabled bool, hpiGetter g
 		TargetUser:    usr.Username,
 		PCIAllowlist:  strings.Join(srv.cfg.BdevInclude, " "),
 		PCIBlocklist:  strings.Join(srv.cfg.BdevExclude, " "),
-		DisableVFIO:   srv.cfg.DisableVFIO,
-		DisableVMD:    srv.cfg.DisableVMD || srv.cfg.DisableVFIO || !iommuEnabled,
 		// TODO: pass vmd include list
 	}
 
 	hasBdevs := cfgHasBdevs(srv.cfg)
+
+	if srv.cfg.Containerized {
+		// Devices are expected to have been bound to a user-space driver
+		// and hugepages allocated before the container was started.
+		srv.log.Debug("containerized mode: skipping automatic NVMe prepare")
+		if hasBdevs {
+			prepReq.HugePageCount = srv.cfg.NrHugepages * len(srv.cfg.Engines)
+		}
+		return checkHugePages(srv.log, hpiGetter, hasBdevs, prepReq.HugePageCount)
+	}
+
+	// Perform the driver selection checks to avoid even trying a prepare
+	// if the system isn't configured properly.
+	driver, err := selectNvmeDriver(srv.cfg, usr.Uid == "0", iommuEnabled)
+	if err != nil {
+		if hasBdevs {
+			return err
+		}
+		srv.log.Debugf("no NVMe driver usable: %s", err)
+	} else {
+		srv.log.Debugf("selected NVMe driver %s", driver)
+	}
+	setPrepareDriver(&prepReq, srv.cfg, driver)
+
 	if hasBdevs {
 		// The config value is intended to be per-engine, so we need to adjust
 		// based on the number of engines.
 		prepReq.HugePageCount = srv.cfg.NrHugepages * len(srv.cfg.Engines)
-
-		// Perform these checks to avoid even trying a prepare if the system
-		// isn't configured properly.
-		if usr.Uid != "0" {
-			if srv.cfg.DisableVFIO {
-				return FaultVfioDisabled
-			}
-
-			if !iommuEnabled {
-				return FaultIommuDisabled
-			}
-		}
+		// Allocate each engine's hugepages on the NUMA node it is pinned to.
+		prepReq.HugeNodePages = engineHugeNodePages(srv.cfg)
 	}
 
 	// TODO: should be passing root context into prepare request to
 	//       facilitate cancellation.
 	srv.log.Debugf("automatic NVMe prepare req: %+v", prepReq)
-	if _, err := srv.bdevProvider.Prepare(prepReq); err != nil {
+	prepResp, err := srv.bdevProvider.Prepare(prepReq)
+	if err != nil {
 		srv.log.Errorf("automatic NVMe prepare failed (check configuration?)\n%s", err)
 	}
+	for _, msg := range nodeHugePageWarnings(defaultProcRoot, prepResp) {
+		srv.log.Info(msg)
+	}
 
+	// Double-check that we got the requested number of huge pages after prepare.
+	return checkHugePages(srv.log, hpiGetter, hasBdevs, prepReq.HugePageCount)
+}
+
+func checkHugePages(log logging.Logger, hpiGetter getHugePageInfoFn, hasBdevs bool, required int) error {
 	hugePages, err := hpiGetter()
 	if err != nil {
 		return errors.Wrap(err, "unable to read system hugepage info")
 	}
 
-	if hasBdevs {
-		// Double-check that we got the requested number of huge pages after prepare.
-		if hugePages.Free < prepReq.HugePageCount {
-			return FaultInsufficientFreeHugePages(hugePages.Free, prepReq.HugePageCount)
-		}
+	if hasBdevs && hugePages.Free < required {
+		logHugePageAdvisory(log, defaultProcRoot, hugePages, required)
+		return FaultInsufficientFreeHugePages(hugePages.Free, required)
 	}
 
 	return nil
@@ -242,6 +351,12 @@ func registerEngineCallbacks(engine *EngineInstance, pubSub *events.PubSub, allS
 	// Register callback to publish engine format requested events.
 	engine.OnAwaitFormat(publishFormatRequiredFn(pubSub.Publish, hostname()))
 
+	// Register callback to publish NVMe device I/O error events.
+	engine.OnBioError(publishBioErrorFn(pubSub.Publish, hostname()))
+
+	// Register callback to publish engine startup timeline events.
+	engine.OnReady(publishEngineStartedFn(pubSub.Publish, hostname(), engine))
+
 	var onceReady sync.Once
 	engine.OnReady(func(_ context.Context) error {
 		// Indicate that engine has been started, only do this
@@ -297,22 +412,63 @@ func configureFirstEngine(ctx context.Context, engine *EngineInstance, sysdb *sy
 // registerTelemetryCallbacks sets telemetry related callbacks to
 // be triggered when all engines have been started.
 func registerTelemetryCallbacks(ctx context.Context, srv *server) {
-	telemPort := srv.cfg.TelemetryPort
-	if telemPort == 0 {
+	if !telemetryEnabled(srv.cfg) {
 		return
 	}
 
 	srv.OnEnginesStarted(func(ctxIn context.Context) error {
-		srv.log.Debug("starting Prometheus exporter")
-		cleanup, err := startPrometheusExporter(ctxIn, srv.log, telemPort, srv.harness.Instances())
+		cleanupFns, err := regPromCollectors(ctxIn, srv.log, srv.harness.Instances(), srv.sysdb,
+			getTelemetryLabels(ctxIn, srv))
 		if err != nil {
 			return err
 		}
-		srv.OnShutdown(cleanup)
+		// detach engine sources after the exporters using them have stopped
+		defer srv.OnShutdown(func() {
+			for _, cleanup := range cleanupFns {
+				cleanup()
+			}
+		})
+
+		if srv.cfg.TelemetryPort != 0 {
+			srv.log.Debug("starting Prometheus exporter")
+			srv.OnShutdown(startPrometheusExporter(srv.log, srv.telemetryIP, srv.cfg.TelemetryPort))
+		}
+
+		if len(srv.cfg.TelemetryPush) > 0 {
+			srv.log.Debug("starting telemetry push exporters")
+			cleanup, err := startPushExporters(srv.log, prometheus.DefaultGatherer,
+				srv.cfg.TelemetryPush)
+			if err != nil {
+				return err
+			}
+			srv.OnShutdown(cleanup)
+		}
+
 		return nil
 	})
 }
 
+// getTelemetryLabels returns the host labels reported by the hardware discovery
+// plugin, if configured, to be applied to exported telemetry.
+func getTelemetryLabels(ctx context.Context, srv *server) map[string]string {
+	if srv.ctlSvc.discover == nil {
+		return nil
+	}
+
+	devices := make([]string, 0, len(srv.cfg.Engines))
+	for _, engineCfg := range srv.cfg.Engines {
+		devices = append(devices, engineCfg.Fabric.Interface)
+	}
+
+	dd, err := srv.ctlSvc.discover(ctx, devices)
+	if err != nil {
+		srv.log.Errorf("hardware discovery plugin: %s", err)
+		return nil
+	}
+
+	return dd.Labels
+}
+
 // registerFollowerSubscriptions stops handling received forwarded (in addition
 // to local) events and starts forwarding events to the new MS leader.
 // Log events on the host that they were raised (and first published) on.
@@ -323,17 +479,43 @@ func registerFollowerSubscriptions(srv *server) {
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.evtForwarder)
 }
 
+// rankMaintenanceWindow returns the maintenance window currently active for
+// the given rank, or nil if the rank is not under maintenance.
+func rankMaintenanceWindow(db *system.Database, rank system.Rank) *system.MaintenanceWindow {
+	windows, err := db.ActiveMaintenanceWindows(time.Now(), rank)
+	if err != nil || len(windows) == 0 {
+		return nil
+	}
+	return windows[0]
+}
+
 // registerLeaderSubscriptions stops forwarding events to MS and instead starts
 // handling received forwardede(and local) events.
 func registerLeaderSubscriptions(srv *server) {
 	srv.pubSub.Reset()
 	srv.pubSub.Subscribe(events.RASTypeAny, srv.evtLogger)
+	srv.pubSub.Subscribe(events.RASTypeAny,
+		events.HandlerFunc(func(_ context.Context, evt *events.RASEvent) {
+			// Annotate events raised by ranks under maintenance.
+			if mw := rankMaintenanceWindow(srv.sysdb, system.Rank(evt.Rank)); mw != nil {
+				srv.log.Infof("RAS event %s on rank %d (%s) during maintenance window %s (%s)",
+					evt.ID, evt.Rank, evt.Hostname, mw.ID, mw.Reason)
+			}
+		}))
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.membership)
 	srv.pubSub.Subscribe(events.RASTypeStateChange, srv.sysdb)
 	srv.pubSub.Subscribe(events.RASTypeStateChange,
 		events.HandlerFunc(func(ctx context.Context, evt *events.RASEvent) {
 			switch evt.ID {
 			case events.RASSwimRankDead:
+				// Ranks under maintenance are expected to go away, so
+				// don't exclude them from the system.
+				rank := system.Rank(evt.Rank)
+				if mw := rankMaintenanceWindow(srv.sysdb, rank); mw != nil {
+					srv.log.Infof("rank %d dead during maintenance window %s, not marking",
+						rank, mw.ID)
+					return
+				}
 				// Mark the rank as unavailable for membership in
 				// new pools, etc. Do group update on success.
 				if err := srv.membership.MarkRankDead(system.Rank(evt.Rank)); err == nil {
@@ -341,9 +523,15 @@ func registerLeaderSubscriptions(srv *server) {
 				}
 			}
 		}))
+
+	// Apply the automatic fault policy to health events, starting the grace
+	// period afresh as this instance has just become leader.
+	srv.mgmtSvc.faultPolicy.start()
+	srv.pubSub.Subscribe(events.RASTypeStateChange,
+		events.HandlerFunc(srv.mgmtSvc.applyFaultPolicy))
 }
 
-func getGrpcOpts(cfgTransport *security.TransportConfig) ([]grpc.ServerOption, error) {
+func getGrpcOpts(cfgTransport *security.TransportConfig, cfgGrpc *config.GrpcConfig, obs *rpcObserver, hist *opHistory, hook *validationHook) ([]grpc.ServerOption, error) {
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		unaryErrorInterceptor,
 		unaryStatusInterceptor,
@@ -351,11 +539,19 @@ func getGrpcOpts(cfgTransport *security.TransportConfig) ([]grpc.ServerOption, e
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		streamErrorInterceptor,
 	}
+	if obs != nil {
+		// outermost in the chain in order to observe the final status
+		// and the time spent in all other interceptors
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{obs.unaryInterceptor},
+			unaryInterceptors...)
+		streamInterceptors = append([]grpc.StreamServerInterceptor{obs.streamInterceptor},
+			streamInterceptors...)
+	}
 	tcOpt, err := security.ServerOptionForTransportConfig(cfgTransport)
 	if err != nil {
 		return nil, err
 	}
-	srvOpts := []grpc.ServerOption{tcOpt}
+	srvOpts := append([]grpc.ServerOption{tcOpt}, cfgGrpc.ServerOptions()...)
 
 	uintOpt, err := unaryInterceptorForTransportConfig(cfgTransport)
 	if err != nil {
@@ -364,6 +560,16 @@ func getGrpcOpts(cfgTransport *security.TransportConfig) ([]grpc.ServerOption, e
 	if uintOpt != nil {
 		unaryInterceptors = append(unaryInterceptors, uintOpt)
 	}
+	if hist != nil {
+		// innermost in the chain so that only requests which pass the
+		// access checks are recorded
+		unaryInterceptors = append(unaryInterceptors, hist.unaryInterceptor)
+	}
+	if hook != nil {
+		// inside the history interceptor so that operations denied by
+		// the hook are recorded
+		unaryInterceptors = append(unaryInterceptors, hook.unaryInterceptor)
+	}
 	sintOpt, err := streamInterceptorForTransportConfig(cfgTransport)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,277 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"net"
+	"os/user"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/server/engine"
+	"github.com/daos-stack/daos/src/control/server/storage"
+	"github.com/daos-stack/daos/src/control/server/storage/bdev"
+)
+
+func TestServer_selectNvmeDriver(t *testing.T) {
+	for name, tc := range map[string]struct {
+		disableVFIO  bool
+		nvmeDriver   string
+		isRoot       bool
+		iommuEnabled bool
+		expDriver    string
+		expErr       error
+	}{
+		"auto; iommu; non-root": {
+			iommuEnabled: true,
+			expDriver:    config.NvmeDriverVFIO,
+		},
+		"auto; iommu; root": {
+			isRoot:       true,
+			iommuEnabled: true,
+			expDriver:    config.NvmeDriverVFIO,
+		},
+		"auto; no iommu; root": {
+			nvmeDriver: config.NvmeDriverAuto,
+			isRoot:     true,
+			expDriver:  config.NvmeDriverUIO,
+		},
+		"auto; no iommu; non-root": {
+			nvmeDriver: config.NvmeDriverAuto,
+			expErr:     FaultIommuDisabled,
+		},
+		"vfio disabled; root": {
+			disableVFIO:  true,
+			isRoot:       true,
+			iommuEnabled: true,
+			expDriver:    config.NvmeDriverUIO,
+		},
+		"vfio disabled; non-root": {
+			disableVFIO:  true,
+			iommuEnabled: true,
+			expErr:       FaultVfioDisabled,
+		},
+		"uio override; non-root": {
+			nvmeDriver:   config.NvmeDriverUIO,
+			iommuEnabled: true,
+			expErr:       FaultVfioDisabled,
+		},
+		"vfio override; no iommu; root": {
+			nvmeDriver: config.NvmeDriverVFIO,
+			isRoot:     true,
+			expErr:     FaultVfioNoIommu,
+		},
+		"vfio override; iommu; non-root": {
+			nvmeDriver:   config.NvmeDriverVFIO,
+			iommuEnabled: true,
+			expDriver:    config.NvmeDriverVFIO,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultServer().
+				WithDisableVFIO(tc.disableVFIO).
+				WithNvmeDriver(tc.nvmeDriver)
+
+			gotDriver, gotErr := selectNvmeDriver(cfg, tc.isRoot, tc.iommuEnabled)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expDriver, gotDriver, "unexpected driver")
+		})
+	}
+}
+
+func TestServer_getListenIP(t *testing.T) {
+	for name, tc := range map[string]struct {
+		iface    string
+		addrs    []net.Addr
+		addrsErr error
+		expIP    net.IP
+		expErr   error
+	}{
+		"no interface": {
+			expIP: net.IPv4zero,
+		},
+		"interface not found": {
+			iface:    "eth9",
+			addrsErr: errors.New("no such network interface"),
+			expErr:   errors.New("no such network interface"),
+		},
+		"no addresses": {
+			iface:  "eth0",
+			expErr: errors.New("no IPv4 address"),
+		},
+		"ipv6 only": {
+			iface: "eth0",
+			addrs: []net.Addr{
+				&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+			},
+			expErr: errors.New("no IPv4 address"),
+		},
+		"first ipv4 address": {
+			iface: "eth0.100",
+			addrs: []net.Addr{
+				&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+				&net.IPNet{IP: net.ParseIP("10.0.100.5"), Mask: net.CIDRMask(24, 32)},
+				&net.IPAddr{IP: net.ParseIP("10.0.100.6")},
+			},
+			expIP: net.ParseIP("10.0.100.5").To4(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			getAddrs := func(iface string) ([]net.Addr, error) {
+				common.AssertEqual(t, tc.iface, iface, "unexpected interface")
+				return tc.addrs, tc.addrsErr
+			}
+
+			gotIP, gotErr := getListenIP(tc.iface, getAddrs)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expIP, gotIP, "unexpected listen address")
+		})
+	}
+}
+
+func TestServer_updateNvmePrepareReq(t *testing.T) {
+	bdevCfg := engine.NewConfig().
+		WithBdevClass(storage.BdevClassNvme.String()).
+		WithBdevDeviceList("0000:81:00.0")
+	numa0 := uint(0)
+	numa1 := uint(1)
+	daosUser := &user.User{Uid: "1000", Username: "daos"}
+	rootUser := &user.User{Uid: "0", Username: "root"}
+
+	for name, tc := range map[string]struct {
+		cfg          *config.Server
+		targetUser   *user.User
+		iommuEnabled bool
+		expReq       *bdev.PrepareRequest
+		expErr       error
+	}{
+		"iommu; vfio selected": {
+			cfg: config.DefaultServer().
+				WithDisableVMD(false),
+			targetUser:   daosUser,
+			iommuEnabled: true,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: minHugePageCount,
+				TargetUser:    "daos",
+			},
+		},
+		"no iommu; root; uio selected": {
+			cfg:        config.DefaultServer(),
+			targetUser: rootUser,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: minHugePageCount,
+				TargetUser:    "root",
+				DisableVFIO:   true,
+				DisableVMD:    true,
+			},
+		},
+		"no iommu; uid 0 with other name; uio selected": {
+			cfg:        config.DefaultServer(),
+			targetUser: &user.User{Uid: "0", Username: "toor"},
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: minHugePageCount,
+				TargetUser:    "toor",
+				DisableVFIO:   true,
+				DisableVMD:    true,
+			},
+		},
+		"no iommu; non-root; no bdevs": {
+			cfg:        config.DefaultServer(),
+			targetUser: daosUser,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: minHugePageCount,
+				TargetUser:    "daos",
+				DisableVMD:    true,
+			},
+		},
+		"no iommu; non-root; bdevs": {
+			cfg: config.DefaultServer().
+				WithEngines(bdevCfg),
+			targetUser: daosUser,
+			expErr:     FaultIommuDisabled,
+		},
+		"iommu; bdevs; engines pinned to numa nodes": {
+			cfg: config.DefaultServer().
+				WithNrHugePages(4096).
+				WithEngines(
+					engine.NewConfig().
+						WithBdevClass(storage.BdevClassNvme.String()).
+						WithBdevDeviceList("0000:81:00.0").
+						WithPinnedNumaNode(&numa0),
+					engine.NewConfig().
+						WithBdevClass(storage.BdevClassNvme.String()).
+						WithBdevDeviceList("0000:d8:00.0").
+						WithPinnedNumaNode(&numa1),
+				),
+			targetUser:   daosUser,
+			iommuEnabled: true,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: 8192,
+				HugeNodePages: map[int]int{0: 4096, 1: 4096},
+				TargetUser:    "daos",
+				DisableVMD:    true,
+			},
+		},
+		"iommu; bdevs; engine not pinned": {
+			cfg: config.DefaultServer().
+				WithNrHugePages(4096).
+				WithEngines(
+					engine.NewConfig().
+						WithBdevClass(storage.BdevClassNvme.String()).
+						WithBdevDeviceList("0000:81:00.0").
+						WithPinnedNumaNode(&numa0),
+					engine.NewConfig().
+						WithBdevClass(storage.BdevClassNvme.String()).
+						WithBdevDeviceList("0000:d8:00.0"),
+				),
+			targetUser:   daosUser,
+			iommuEnabled: true,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: 8192,
+				TargetUser:    "daos",
+				DisableVMD:    true,
+			},
+		},
+		"iommu; vmd disabled in config": {
+			cfg: config.DefaultServer().
+				WithDisableVMD(true),
+			targetUser:   daosUser,
+			iommuEnabled: true,
+			expReq: &bdev.PrepareRequest{
+				HugePageCount: minHugePageCount,
+				TargetUser:    "daos",
+				DisableVMD:    true,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := &bdev.PrepareRequest{TargetUser: tc.targetUser.Username}
+
+			gotErr := updateNvmePrepareReq(req, tc.cfg, tc.targetUser, tc.iommuEnabled)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expReq, req); diff != "" {
+				t.Fatalf("unexpected prepare request (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
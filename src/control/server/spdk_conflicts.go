@@ -0,0 +1,264 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+var (
+	vfioGroupRe   = regexp.MustCompile(`^/dev/vfio/([0-9]+)$`)
+	uioDeviceRe   = regexp.MustCompile(`^/dev/(uio[0-9]+)$`)
+	pciResourceRe = regexp.MustCompile(`^/sys/devices/.*/([0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-9a-f])/resource[0-9]+(_wc)?$`)
+)
+
+// conflictingProcess is a process, other than this server and the engines it
+// has started, holding resources that SPDK/DPDK needs exclusive access to.
+type conflictingProcess struct {
+	Pid       int
+	Name      string
+	HugetlbKb int
+	Devices   []string // PCI addresses of devices bound to vfio-pci or uio
+}
+
+func (cp *conflictingProcess) String() string {
+	var held []string
+	if cp.HugetlbKb > 0 {
+		held = append(held, fmt.Sprintf("%d kB of hugepages", cp.HugetlbKb))
+	}
+	held = append(held, cp.Devices...)
+
+	return fmt.Sprintf("pid %d (%s) holds %s", cp.Pid, cp.Name, strings.Join(held, ", "))
+}
+
+// holdsAny returns true if the process holds any of the given devices, or any
+// device at all if none are given.
+func (cp *conflictingProcess) holdsAny(devices []string) bool {
+	if len(devices) == 0 {
+		return len(cp.Devices) > 0
+	}
+	for _, dev := range cp.Devices {
+		if common.Includes(devices, dev) {
+			return true
+		}
+	}
+	return false
+}
+
+type findConflictingProcsFn func() ([]*conflictingProcess, error)
+
+// getParentPid returns the parent of a process from its stat file, the
+// command name in the second field may contain spaces or parentheses.
+func getParentPid(procRoot string, pid int) (int, error) {
+	data, err := ioutil.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, err
+	}
+
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 2 {
+		return 0, errors.Errorf("unexpected stat format for pid %d", pid)
+	}
+
+	return strconv.Atoi(fields[1])
+}
+
+// pciDevicesForPath returns the PCI addresses of the devices accessed through
+// a vfio group, a uio device or a PCI resource file.
+func pciDevicesForPath(sysRoot, path string) []string {
+	if m := vfioGroupRe.FindStringSubmatch(path); m != nil {
+		entries, err := ioutil.ReadDir(filepath.Join(sysRoot, "kernel/iommu_groups", m[1], "devices"))
+		if err != nil {
+			return nil
+		}
+		devices := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			devices = append(devices, entry.Name())
+		}
+		return devices
+	}
+	if m := uioDeviceRe.FindStringSubmatch(path); m != nil {
+		target, err := os.Readlink(filepath.Join(sysRoot, "class/uio", m[1], "device"))
+		if err != nil {
+			return nil
+		}
+		return []string{filepath.Base(target)}
+	}
+	if m := pciResourceRe.FindStringSubmatch(path); m != nil {
+		return []string{m[1]}
+	}
+
+	return nil
+}
+
+// getProcessResources inspects the hugepage usage, open files and memory
+// mappings of a process for resources used by SPDK/DPDK, nil is returned if
+// the process holds none.
+func getProcessResources(procRoot, sysRoot string, pid int) (*conflictingProcess, error) {
+	pidDir := filepath.Join(procRoot, strconv.Itoa(pid))
+
+	// Processes that exit while being inspected and, when not running as
+	// root, the files of other users' processes are ignored.
+	f, err := os.Open(filepath.Join(pidDir, "status"))
+	if err != nil {
+		return nil, nil
+	}
+	hph, err := parseHugePageHolder(pid, f)
+	f.Close()
+	if err != nil {
+		return nil, errors.Wrapf(err, "pid %d", pid)
+	}
+
+	devices := make(map[string]bool)
+	addPath := func(path string) {
+		for _, dev := range pciDevicesForPath(sysRoot, path) {
+			devices[dev] = true
+		}
+	}
+
+	if fds, err := ioutil.ReadDir(filepath.Join(pidDir, "fd")); err == nil {
+		for _, fd := range fds {
+			if target, err := os.Readlink(filepath.Join(pidDir, "fd", fd.Name())); err == nil {
+				addPath(target)
+			}
+		}
+	}
+
+	if f, err := os.Open(filepath.Join(pidDir, "maps")); err == nil {
+		scn := bufio.NewScanner(f)
+		for scn.Scan() {
+			fields := strings.Fields(scn.Text())
+			if len(fields) >= 6 {
+				addPath(fields[5])
+			}
+		}
+		f.Close()
+	}
+
+	if hph == nil && len(devices) == 0 {
+		return nil, nil
+	}
+
+	cp := &conflictingProcess{Pid: pid}
+	if hph != nil {
+		cp.Name, cp.HugetlbKb = hph.Name, hph.HugetlbKb
+	} else if comm, err := ioutil.ReadFile(filepath.Join(pidDir, "comm")); err == nil {
+		cp.Name = strings.TrimSpace(string(comm))
+	}
+	for dev := range devices {
+		cp.Devices = append(cp.Devices, dev)
+	}
+	sort.Strings(cp.Devices)
+
+	return cp, nil
+}
+
+// findConflictingProcesses returns the processes holding hugepages or NVMe
+// devices bound to a user-space driver, excluding the given process and its
+// children (the engines it has started).
+func findConflictingProcesses(procRoot, sysRoot string, selfPid int) ([]*conflictingProcess, error) {
+	entries, err := ioutil.ReadDir(procRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading process list")
+	}
+
+	var procs []*conflictingProcess
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == selfPid {
+			continue
+		}
+		if ppid, err := getParentPid(procRoot, pid); err != nil || ppid == selfPid {
+			continue
+		}
+
+		cp, err := getProcessResources(procRoot, sysRoot, pid)
+		if err != nil {
+			return nil, err
+		}
+		if cp != nil {
+			procs = append(procs, cp)
+		}
+	}
+
+	sort.Slice(procs, func(i, j int) bool { return procs[i].Pid < procs[j].Pid })
+
+	return procs, nil
+}
+
+func defaultFindConflictingProcs() ([]*conflictingProcess, error) {
+	return findConflictingProcesses(defaultProcRoot, defaultSysRoot, os.Getpid())
+}
+
+// checkConflictingProcesses returns a fault listing any processes that hold
+// the given devices, or any device bound to a user-space driver if none are
+// given, so that their owners can be identified instead of SPDK/DPDK failing
+// with EAL errors when the devices are claimed. A failure to inspect processes
+// is logged and otherwise ignored.
+func checkConflictingProcesses(log logging.Logger, findProcs findConflictingProcsFn, devices []string) error {
+	if findProcs == nil {
+		return nil
+	}
+
+	procs, err := findProcs()
+	if err != nil {
+		log.Debugf("unable to check for processes holding SPDK/DPDK resources: %s", err)
+		return nil
+	}
+
+	var conflicts []string
+	for _, cp := range procs {
+		if cp.holdsAny(devices) {
+			conflicts = append(conflicts, cp.String())
+		}
+	}
+	if len(conflicts) > 0 {
+		return FaultConflictingProcesses(conflicts)
+	}
+
+	return nil
+}
+
+// checkHugepageHolders returns a fault listing any processes holding hugepages
+// so that a shortage of free hugepages can be attributed to them.
+func checkHugepageHolders(log logging.Logger, findProcs findConflictingProcsFn) error {
+	if findProcs == nil {
+		return nil
+	}
+
+	procs, err := findProcs()
+	if err != nil {
+		log.Debugf("unable to check for processes holding hugepages: %s", err)
+		return nil
+	}
+
+	var holders []string
+	for _, cp := range procs {
+		if cp.HugetlbKb > 0 {
+			holders = append(holders, cp.String())
+		}
+	}
+	if len(holders) > 0 {
+		return FaultConflictingProcesses(holders)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestServer_findConflictingProcesses(t *testing.T) {
+	testRoot, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+	sysRoot := filepath.Join(testRoot, "sys")
+	procRoot := filepath.Join(testRoot, "proc")
+
+	symlink := func(target, link string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addProc := func(pid, ppid, comm, hugetlb string, fds map[string]string, maps string) {
+		t.Helper()
+		pidDir := filepath.Join(procRoot, pid)
+		writeTestFile(t, filepath.Join(pidDir, "stat"), pid+" ("+comm+") S "+ppid+" 1 1 0 -1\n")
+		writeTestFile(t, filepath.Join(pidDir, "status"),
+			"Name:\t"+comm+"\nPid:\t"+pid+"\nHugetlbPages:\t"+hugetlb+" kB\n")
+		writeTestFile(t, filepath.Join(pidDir, "comm"), comm+"\n")
+		writeTestFile(t, filepath.Join(pidDir, "maps"), maps)
+		if err := os.MkdirAll(filepath.Join(pidDir, "fd"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for fd, target := range fds {
+			symlink(target, filepath.Join(pidDir, "fd", fd))
+		}
+	}
+
+	writeTestFile(t, filepath.Join(sysRoot, "kernel/iommu_groups/12/devices/0000:81:00.0"), "")
+	symlink("../../../devices/pci0000:00/0000:00:03.0/0000:82:00.0",
+		filepath.Join(sysRoot, "class/uio/uio0/device"))
+
+	// this server and an engine it started
+	addProc("100", "1", "daos_server", "0", map[string]string{"3": "/dev/vfio/12"}, "")
+	addProc("101", "100", "daos_engine", "4096", map[string]string{"3": "/dev/vfio/12"}, "")
+	// unrelated process
+	addProc("200", "1", "bash", "0", map[string]string{"0": "/dev/pts/0"},
+		"00400000-00452000 r-xp 00000000 08:02 173521 /usr/bin/bash\n")
+	// ovs-dpdk holding a vfio group and hugepages
+	addProc("300", "1", "ovs-vswitchd", "2048", map[string]string{
+		"5": "/dev/vfio/vfio",
+		"6": "/dev/vfio/12",
+	}, "7f0000000000-7f0000200000 rw-s 00000000 00:2d 1 /dev/hugepages/rtemap_0\n")
+	// SPDK app using uio and a mapped BAR without hugepages
+	addProc("400", "1", "spdk tgt", "0", map[string]string{
+		"4": "/dev/uio0",
+	}, "7f1000000000-7f1000004000 rw-s 00000000 00:05 1 "+
+		"/sys/devices/pci0000:00/0000:00:03.0/0000:83:00.0/resource0\n")
+	// kernel thread with no accessible files
+	writeTestFile(t, filepath.Join(procRoot, "2/stat"), "2 (kthreadd) S 0 0 0 0 -1\n")
+	// hugepage user with no devices
+	addProc("500", "1", "postgres", "1048576", nil, "")
+
+	gotProcs, err := findConflictingProcesses(procRoot, sysRoot, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expProcs := []*conflictingProcess{
+		{Pid: 300, Name: "ovs-vswitchd", HugetlbKb: 2048, Devices: []string{"0000:81:00.0"}},
+		{Pid: 400, Name: "spdk tgt", Devices: []string{"0000:82:00.0", "0000:83:00.0"}},
+		{Pid: 500, Name: "postgres", HugetlbKb: 1048576},
+	}
+	if diff := cmp.Diff(expProcs, gotProcs); diff != "" {
+		t.Fatalf("unexpected processes (-want, +got):\n%s\n", diff)
+	}
+}
+
+func TestServer_checkConflictingProcesses(t *testing.T) {
+	procs := []*conflictingProcess{
+		{Pid: 300, Name: "ovs-vswitchd", HugetlbKb: 2048, Devices: []string{"0000:81:00.0"}},
+		{Pid: 400, Name: "spdk_tgt", HugetlbKb: 4096},
+	}
+
+	for name, tc := range map[string]struct {
+		procs     []*conflictingProcess
+		findErr   error
+		devices   []string
+		expErr    error
+		expHpErr  error
+		nilFinder bool
+	}{
+		"no finder": {
+			nilFinder: true,
+		},
+		"find failed": {
+			findErr: errors.New("no /proc"),
+		},
+		"no conflicts": {
+			devices: []string{"0000:82:00.0"},
+		},
+		"device not held": {
+			procs:   procs,
+			devices: []string{"0000:82:00.0"},
+			expHpErr: FaultConflictingProcesses([]string{
+				"pid 300 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+				"pid 400 (spdk_tgt) holds 4096 kB of hugepages",
+			}),
+		},
+		"device held": {
+			procs:   procs,
+			devices: []string{"0000:82:00.0", "0000:81:00.0"},
+			expErr: FaultConflictingProcesses([]string{
+				"pid 300 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+			}),
+			expHpErr: FaultConflictingProcesses([]string{
+				"pid 300 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+				"pid 400 (spdk_tgt) holds 4096 kB of hugepages",
+			}),
+		},
+		"any device held": {
+			procs: procs,
+			expErr: FaultConflictingProcesses([]string{
+				"pid 300 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+			}),
+			expHpErr: FaultConflictingProcesses([]string{
+				"pid 300 (ovs-vswitchd) holds 2048 kB of hugepages, 0000:81:00.0",
+				"pid 400 (spdk_tgt) holds 4096 kB of hugepages",
+			}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			findProcs := func() ([]*conflictingProcess, error) {
+				return tc.procs, tc.findErr
+			}
+			if tc.nilFinder {
+				findProcs = nil
+			}
+
+			common.CmpErr(t, tc.expErr, checkConflictingProcesses(log, findProcs, tc.devices))
+			common.CmpErr(t, tc.expHpErr, checkHugepageHolders(log, findProcs))
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -39,9 +40,10 @@ type (
 	}
 
 	spdkBackend struct {
-		log     logging.Logger
-		binding *spdkWrapper
-		script  *spdkSetupScript
+		log            logging.Logger
+		binding        *spdkWrapper
+		script         *spdkSetupScript
+		pciDevicesPath string
 	}
 
 	removeFn func(string) error
@@ -99,9 +101,10 @@ func (w *spdkWrapper) init(log logging.Logger, spdkOpts *spdk.EnvOptions) (func(
 
 func newBackend(log logging.Logger, sr *spdkSetupScript) *spdkBackend {
 	return &spdkBackend{
-		log:     log,
-		binding: &spdkWrapper{Env: &spdk.EnvImpl{}, Nvme: &spdk.NvmeImpl{}},
-		script:  sr,
+		log:            log,
+		binding:        &spdkWrapper{Env: &spdk.EnvImpl{}, Nvme: &spdk.NvmeImpl{}},
+		script:         sr,
+		pciDevicesPath: pciDevicesPath,
 	}
 }
 
@@ -119,6 +122,21 @@ func (b *spdkBackend) IsVMDDisabled() bool {
 	return b.binding.vmdDisabled
 }
 
+// Capabilities returns the versions of the SPDK and DPDK libraries linked into
+// the binary and the optional features supported by the SPDK release.
+func (b *spdkBackend) Capabilities() *storage.BdevCapabilities {
+	features := spdk.SupportedFeatures()
+
+	return &storage.BdevCapabilities{
+		SpdkVersion: spdk.Version(),
+		DpdkVersion: spdk.DPDKVersion(),
+		Vmd:         features.VMD,
+		VmdEnabled:  features.VMD && !b.IsVMDDisabled(),
+		Zns:         features.ZNS,
+		Cmb:         features.CMB,
+	}
+}
+
 // Scan discovers NVMe controllers accessible by SPDK.
 func (b *spdkBackend) Scan(req ScanRequest) (*ScanResponse, error) {
 	restoreOutput, err := b.binding.init(b.log, &spdk.EnvOptions{
@@ -134,6 +152,7 @@ func (b *spdkBackend) Scan(req ScanRequest) (*ScanResponse, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to discover nvme")
 	}
+	b.updatePciLinks(cs)
 
 	return &ScanResponse{Controllers: cs}, nil
 }
@@ -204,10 +223,12 @@ func (b *spdkBackend) formatNvme(req FormatRequest) (*FormatResponse, error) {
 		DisableVMD:     b.IsVMDDisabled(),
 	}
 
+	initStart := time.Now()
 	restoreOutput, err := b.binding.init(b.log, spdkOpts)
 	if err != nil {
 		return nil, err
 	}
+	initDuration := time.Since(initStart)
 	defer restoreOutput()
 	defer b.binding.FiniSPDKEnv(b.log, spdkOpts)
 	defer func() {
@@ -216,6 +237,8 @@ func (b *spdkBackend) formatNvme(req FormatRequest) (*FormatResponse, error) {
 		}
 	}()
 
+	reformatErrs := b.reformatNvme(req)
+
 	results, err := b.binding.Format(b.log)
 	if err != nil {
 		return nil, errors.Wrapf(err, "spdk format %v", req.DeviceList)
@@ -225,7 +248,41 @@ func (b *spdkBackend) formatNvme(req FormatRequest) (*FormatResponse, error) {
 		return nil, errors.New("empty results from spdk binding format request")
 	}
 
-	return b.formatRespFromResults(results)
+	resp, err := b.formatRespFromResults(results)
+	if err != nil {
+		return nil, err
+	}
+	resp.InitDuration = initDuration
+
+	for addr, err := range reformatErrs {
+		resp.DeviceResponses[addr] = &DeviceFormatResponse{
+			Error: FaultFormatError(addr, errors.Wrap(err, "LBA format")),
+		}
+	}
+
+	return resp, nil
+}
+
+// reformatNvme applies the LBA formats selected in the request to the
+// namespaces of the respective devices and returns any errors keyed by
+// device PCI address.
+func (b *spdkBackend) reformatNvme(req FormatRequest) map[string]error {
+	addrs := make([]string, 0, len(req.Reformat))
+	for addr := range req.Reformat {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	errs := make(map[string]error)
+	for _, addr := range addrs {
+		lbaf := req.Reformat[addr]
+		b.log.Debugf("applying LBA format %d to nvme device at %s", lbaf, addr)
+		if err := b.binding.Reformat(b.log, addr, lbaf); err != nil {
+			errs[addr] = err
+		}
+	}
+
+	return errs
 }
 
 // Format initializes the SPDK environment, defers the call to finalize the same
@@ -258,6 +315,37 @@ func (b *spdkBackend) Format(req FormatRequest) (*FormatResponse, error) {
 	}
 }
 
+// Verify initializes the SPDK environment for the requested device, defers the
+// call to finalize the same environment and reads the requested range of LBAs
+// from the device namespace.
+func (b *spdkBackend) Verify(req VerifyRequest) (*VerifyResponse, error) {
+	if req.PciAddr == "" {
+		return nil, FaultBadPCIAddr("")
+	}
+	if req.NumLBAs == 0 {
+		return new(VerifyResponse), nil
+	}
+
+	spdkOpts := &spdk.EnvOptions{
+		PciIncludeList: []string{req.PciAddr},
+		DisableVMD:     b.IsVMDDisabled(),
+	}
+
+	restoreOutput, err := b.binding.init(b.log, spdkOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer restoreOutput()
+	defer b.binding.FiniSPDKEnv(b.log, spdkOpts)
+
+	badLBAs, err := b.binding.Verify(b.log, req.PciAddr, req.NsID, req.StartLBA, req.NumLBAs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "spdk verify %s namespace %d", req.PciAddr, req.NsID)
+	}
+
+	return &VerifyResponse{BadLBAs: badLBAs}, nil
+}
+
 // detectVMD returns whether VMD devices have been found and a slice of VMD
 // PCI addresses if found.
 func detectVMD() ([]string, error) {
@@ -379,10 +467,12 @@ func (b *spdkBackend) vmdPrep(req PrepareRequest) (bool, error) {
 	return true, nil
 }
 
-// Prepare will cleanup any leftover hugepages owned by the target user and then
-// executes the SPDK setup.sh script to rebind PCI devices as selected by
+// Prepare will optionally compact system memory, cleanup any leftover hugepages
+// owned by the target user, optionally set up the permissions the target user
+// needs to run SPDK and then executes the SPDK setup.sh script to rebind PCI devices as selected by
 // bdev_include and bdev_exclude list filters provided in the server config file.
-// This will make the devices available though SPDK.
+// This will make the devices available though SPDK. If per-node hugepage counts
+// are requested, hugepages are then redistributed across NUMA nodes.
 func (b *spdkBackend) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 	b.log.Debugf("provider backend prepare %v", req)
 	resp := &PrepareResponse{}
@@ -392,6 +482,14 @@ func (b *spdkBackend) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 		return nil, errors.Wrapf(err, "lookup on local host")
 	}
 
+	if req.CompactHugePages {
+		// release reclaimable memory and defragment free pages so that
+		// contiguous regions are available for hugepage allocation
+		if err := compactMemory(b.log, procSysVMDir); err != nil {
+			return nil, errors.Wrap(err, "compact memory for hugepages")
+		}
+	}
+
 	if err := b.script.Prepare(req); err != nil {
 		return nil, errors.Wrap(err, "re-binding ssds to attach with spdk")
 	}
@@ -404,6 +502,14 @@ func (b *spdkBackend) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 		}
 	}
 
+	if req.SetupPermissions {
+		changes, err := newPermissionsSetup(b.log).apply(usr, !req.DisableVFIO)
+		if err != nil {
+			return nil, errors.Wrapf(err, "set up permissions for user %s", usr.Username)
+		}
+		resp.PermissionChanges = changes
+	}
+
 	if !req.DisableVMD {
 		vmdDetected, err := b.vmdPrep(req)
 		if err != nil {
@@ -412,9 +518,38 @@ func (b *spdkBackend) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 		resp.VmdDetected = vmdDetected
 	}
 
+	if len(req.HugeNodePages) > 0 {
+		// setup script allocates hugepages across all nodes, so
+		// redistribute them according to the per-node counts
+		if err := b.allocNodeHugePages(req, resp); err != nil {
+			return nil, errors.Wrap(err, "allocate hugepages per NUMA node")
+		}
+	}
+
 	return resp, nil
 }
 
+func (b *spdkBackend) allocNodeHugePages(req PrepareRequest, resp *PrepareResponse) error {
+	pageSizeKb, err := getHugePageSize(procMeminfo)
+	if err != nil {
+		return err
+	}
+	nodes, err := getNumaNodes(sysNodeDir)
+	if err != nil {
+		return err
+	}
+
+	allocs, err := allocNodeHugePages(nodes, req.HugeNodePages,
+		sysfsNodeHugePageSetter(b.log, sysNodeDir, pageSizeKb))
+	if err != nil {
+		return err
+	}
+	resp.HugePageSizeKb = pageSizeKb
+	resp.HugeNodeAllocs = allocs
+
+	return nil
+}
+
 func (b *spdkBackend) PrepareReset() error {
 	b.log.Debugf("provider backend prepare reset")
 	return b.script.Reset()
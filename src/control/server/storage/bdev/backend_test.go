@@ -28,6 +28,7 @@ func defCmpOpts() []cmp.Option {
 	return []cmp.Option{
 		// ignore these fields on most tests, as they are intentionally not stable
 		cmpopts.IgnoreFields(storage.NvmeController{}, "HealthStats", "Serial"),
+		cmpopts.IgnoreFields(FormatResponse{}, "InitDuration"),
 	}
 }
 
@@ -63,13 +64,18 @@ func backendWithMockBinding(log logging.Logger, mec spdk.MockEnvCfg, mnc spdk.Mo
 
 func TestBdev_Backend_Scan(t *testing.T) {
 	ctrlr1 := storage.MockNvmeController(1)
+	ctrlr1Degraded := storage.MockNvmeController(1)
+	ctrlr1Degraded.PciLink = &storage.NvmePciLink{
+		Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4,
+	}
 
 	for name, tc := range map[string]struct {
-		req     ScanRequest
-		mec     spdk.MockEnvCfg
-		mnc     spdk.MockNvmeCfg
-		expResp *ScanResponse
-		expErr  error
+		req      ScanRequest
+		mec      spdk.MockEnvCfg
+		mnc      spdk.MockNvmeCfg
+		pciLinks map[string]map[string]string
+		expResp  *ScanResponse
+		expErr   error
 	}{
 		"binding scan fail": {
 			mnc: spdk.MockNvmeCfg{
@@ -90,12 +96,37 @@ func TestBdev_Backend_Scan(t *testing.T) {
 				Controllers: storage.NvmeControllers{ctrlr1},
 			},
 		},
+		"binding scan success; degraded pci link": {
+			mnc: spdk.MockNvmeCfg{
+				DiscoverCtrlrs: storage.NvmeControllers{storage.MockNvmeController(1)},
+			},
+			pciLinks: map[string]map[string]string{
+				ctrlr1.PciAddr: {
+					"current_link_speed": "8.0 GT/s PCIe",
+					"current_link_width": "1",
+					"max_link_speed":     "8.0 GT/s PCIe",
+					"max_link_width":     "4",
+				},
+			},
+			req: ScanRequest{},
+			expResp: &ScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr1Degraded},
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(name)
 			defer common.ShowBufferOnFailure(t, buf)
 
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			for pciAddr, attrs := range tc.pciLinks {
+				writePciLinkAttrs(t, testDir, pciAddr, attrs)
+			}
+
 			b := backendWithMockBinding(log, tc.mec, tc.mnc)
+			b.pciDevicesPath = testDir
 
 			gotResp, gotErr := b.Scan(tc.req)
 			common.CmpErr(t, tc.expErr, gotErr)
@@ -284,6 +315,50 @@ func TestBdev_Backend_Format(t *testing.T) {
 				},
 			},
 		},
+		"reformat success": {
+			mnc: spdk.MockNvmeCfg{
+				FormatRes: []*spdk.FormatResult{
+					{CtrlrPCIAddr: pci1, NsID: 1},
+				},
+			},
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{pci1},
+				Reformat:   map[string]uint32{pci1: 1},
+			},
+			expResp: &FormatResponse{
+				DeviceResponses: DeviceFormatResponses{
+					pci1: &DeviceFormatResponse{
+						Formatted: true,
+					},
+				},
+			},
+		},
+		"reformat failure": {
+			mnc: spdk.MockNvmeCfg{
+				FormatRes: []*spdk.FormatResult{
+					{CtrlrPCIAddr: pci1, NsID: 1},
+					{CtrlrPCIAddr: pci2, NsID: 1},
+				},
+				ReformatErr: errors.New("format failed"),
+			},
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{pci1, pci2},
+				Reformat:   map[string]uint32{pci2: 1},
+			},
+			expResp: &FormatResponse{
+				DeviceResponses: DeviceFormatResponses{
+					pci1: &DeviceFormatResponse{
+						Formatted: true,
+					},
+					pci2: &DeviceFormatResponse{
+						Error: FaultFormatError(pci2,
+							errors.New("LBA format: format failed")),
+					},
+				},
+			},
+		},
 		"multiple namespaces on single controller success": {
 			mnc: spdk.MockNvmeCfg{
 				FormatRes: []*spdk.FormatResult{
@@ -421,6 +496,69 @@ func TestBdev_Backend_Update(t *testing.T) {
 	}
 }
 
+func TestBdev_Backend_Verify(t *testing.T) {
+	pciAddr := mockSpdkController().PciAddr
+
+	for name, tc := range map[string]struct {
+		req     VerifyRequest
+		mec     spdk.MockEnvCfg
+		mnc     spdk.MockNvmeCfg
+		expResp *VerifyResponse
+		expErr  error
+	}{
+		"missing pci address": {
+			req:    VerifyRequest{NumLBAs: 8},
+			expErr: FaultBadPCIAddr(""),
+		},
+		"empty range": {
+			req:     VerifyRequest{PciAddr: pciAddr},
+			expResp: &VerifyResponse{},
+		},
+		"init failed": {
+			req: VerifyRequest{PciAddr: pciAddr, NsID: 1, NumLBAs: 8},
+			mec: spdk.MockEnvCfg{
+				InitErr: errors.New("spdk init says no"),
+			},
+			expErr: errors.New("spdk init says no"),
+		},
+		"binding verify fail": {
+			req: VerifyRequest{PciAddr: pciAddr, NsID: 1, NumLBAs: 8},
+			mnc: spdk.MockNvmeCfg{
+				VerifyErr: errors.New("spdk says no"),
+			},
+			expErr: errors.New("spdk says no"),
+		},
+		"binding verify success": {
+			req:     VerifyRequest{PciAddr: pciAddr, NsID: 1, NumLBAs: 8},
+			expResp: &VerifyResponse{},
+		},
+		"unreadable lbas": {
+			req: VerifyRequest{PciAddr: pciAddr, NsID: 1, StartLBA: 8, NumLBAs: 8},
+			mnc: spdk.MockNvmeCfg{
+				VerifyBadLBAs: []uint64{9, 12},
+			},
+			expResp: &VerifyResponse{BadLBAs: []uint64{9, 12}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			b := backendWithMockBinding(log, tc.mec, tc.mnc)
+
+			gotResp, gotErr := b.Verify(tc.req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expResp, gotResp); diff != "" {
+				t.Fatalf("\nunexpected output (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 type mockFileInfo struct {
 	name    string
 	size    int64
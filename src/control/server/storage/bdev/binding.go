@@ -0,0 +1,171 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const (
+	// nvmePciClass is the PCI class code of NVM Express storage controllers.
+	nvmePciClass = "0x010802"
+	// sysfsSectorSize is the unit of block device sizes reported in sysfs.
+	sysfsSectorSize = 512
+)
+
+// nvmeNamespaceRegexp matches the sysfs names of the namespaces of a kernel
+// NVMe controller, e.g. nvme0n1 or nvme0c0n1 with native multipath enabled.
+var nvmeNamespaceRegexp = regexp.MustCompile(`^nvme[0-9]+(c[0-9]+)?n([0-9]+)$`)
+
+// readNvmeBindings returns the driver binding of each NVMe controller found
+// under the sysfs PCI devices root, sorted by PCI address.
+//
+// Devices behind a VMD domain have no sysfs entry while bound to SPDK and so
+// are not reported.
+func readNvmeBindings(sysRoot string) (storage.NvmeBindings, error) {
+	entries, err := ioutil.ReadDir(sysRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading pci devices")
+	}
+
+	bindings := make(storage.NvmeBindings, 0)
+	for _, entry := range entries {
+		devPath := filepath.Join(sysRoot, entry.Name())
+		class, err := readPciLinkAttr(devPath, "class")
+		if err != nil || !strings.HasPrefix(class, nvmePciClass) {
+			continue
+		}
+
+		var driver string
+		target, err := os.Readlink(filepath.Join(devPath, "driver"))
+		switch {
+		case err == nil:
+			driver = filepath.Base(target)
+		case !os.IsNotExist(err):
+			return nil, errors.Wrapf(err, "reading driver of %s", entry.Name())
+		}
+
+		bindings = append(bindings, &storage.NvmeBinding{
+			PciAddr: entry.Name(),
+			Driver:  driver,
+			State:   storage.NvmeBindStateFromDriver(driver),
+		})
+	}
+
+	return bindings, nil
+}
+
+// readKernelNvmeController reads the details of the NVMe controller with the
+// given PCI address from the attributes exported to sysfs by the kernel NVMe
+// driver.
+func readKernelNvmeController(sysRoot, pciAddr string) (*storage.NvmeController, error) {
+	devPath := filepath.Join(sysRoot, pciAddr)
+	ctrlr := &storage.NvmeController{
+		PciAddr:   pciAddr,
+		Driver:    storage.NvmeKernelDriver,
+		BindState: storage.NvmeBindStateKernel,
+	}
+
+	if numa, err := readPciLinkAttr(devPath, "numa_node"); err == nil {
+		if node, err := strconv.Atoi(numa); err == nil && node > 0 {
+			ctrlr.SocketID = int32(node)
+		}
+	}
+
+	// the kernel creates a single nvmeX entry for the controller
+	ctrlrDirs, err := filepath.Glob(filepath.Join(devPath, "nvme", "nvme*"))
+	if err != nil {
+		return nil, err
+	}
+	if len(ctrlrDirs) == 0 {
+		return ctrlr, nil
+	}
+	ctrlrPath := ctrlrDirs[0]
+
+	for _, attr := range []struct {
+		name string
+		dest *string
+	}{
+		{"model", &ctrlr.Model},
+		{"serial", &ctrlr.Serial},
+		{"firmware_rev", &ctrlr.FwRev},
+	} {
+		val, err := readPciLinkAttr(ctrlrPath, attr.name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s of %s", attr.name, pciAddr)
+		}
+		*attr.dest = val
+	}
+
+	entries, err := ioutil.ReadDir(ctrlrPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading namespaces of %s", pciAddr)
+	}
+	for _, entry := range entries {
+		matches := nvmeNamespaceRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		id, err := strconv.ParseUint(matches[2], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing namespace id of %s", entry.Name())
+		}
+		sizeStr, err := readPciLinkAttr(filepath.Join(ctrlrPath, entry.Name()), "size")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading size of %s", entry.Name())
+		}
+		sectors, err := strconv.ParseUint(sizeStr, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing size of %s", entry.Name())
+		}
+
+		ctrlr.Namespaces = append(ctrlr.Namespaces, &storage.NvmeNamespace{
+			ID:   uint32(id),
+			Size: sectors * sysfsSectorSize,
+		})
+	}
+	sort.Slice(ctrlr.Namespaces, func(i, j int) bool {
+		return ctrlr.Namespaces[i].ID < ctrlr.Namespaces[j].ID
+	})
+
+	return ctrlr, nil
+}
+
+// readKernelNvmeControllers returns the NVMe controllers found under the sysfs
+// PCI devices root which are bound to the kernel NVMe driver and so cannot be
+// discovered by SPDK, sorted by PCI address.
+func readKernelNvmeControllers(sysRoot string) (storage.NvmeControllers, error) {
+	bindings, err := readNvmeBindings(sysRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrlrs := make(storage.NvmeControllers, 0)
+	for _, nb := range bindings {
+		if nb.State != storage.NvmeBindStateKernel {
+			continue
+		}
+
+		ctrlr, err := readKernelNvmeController(sysRoot, nb.PciAddr)
+		if err != nil {
+			return nil, err
+		}
+		ctrlrs = append(ctrlrs, ctrlr)
+	}
+
+	return ctrlrs, nil
+}
@@ -0,0 +1,229 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestBdev_readNvmeBindings(t *testing.T) {
+	type mockDev struct {
+		class  string
+		driver string
+	}
+
+	for name, tc := range map[string]struct {
+		noRoot      bool
+		devs        map[string]mockDev
+		expBindings storage.NvmeBindings
+		expState    storage.NvmeBindState
+		expErr      error
+	}{
+		"missing sysfs root": {
+			noRoot: true,
+			expErr: errors.New("reading pci devices"),
+		},
+		"no devices": {
+			expBindings: storage.NvmeBindings{},
+			expState:    storage.NvmeBindStateUnknown,
+		},
+		"kernel bound": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {class: "0x010802", driver: "nvme"},
+				"0000:82:00.0": {class: "0x010802", driver: "nvme"},
+				"0000:00:1f.2": {class: "0x010601", driver: "ahci"},
+			},
+			expBindings: storage.NvmeBindings{
+				{PciAddr: "0000:81:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+				{PciAddr: "0000:82:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+			},
+			expState: storage.NvmeBindStateKernel,
+		},
+		"spdk bound": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {class: "0x010802", driver: "vfio-pci"},
+				"0000:82:00.0": {class: "0x010802", driver: "uio_pci_generic"},
+			},
+			expBindings: storage.NvmeBindings{
+				{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+				{PciAddr: "0000:82:00.0", Driver: "uio_pci_generic", State: storage.NvmeBindStateSpdk},
+			},
+			expState: storage.NvmeBindStateSpdk,
+		},
+		"partially bound": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {class: "0x010802", driver: "vfio-pci"},
+				"0000:82:00.0": {class: "0x010802", driver: "nvme"},
+				"0000:83:00.0": {class: "0x010802"},
+			},
+			expBindings: storage.NvmeBindings{
+				{PciAddr: "0000:81:00.0", Driver: "vfio-pci", State: storage.NvmeBindStateSpdk},
+				{PciAddr: "0000:82:00.0", Driver: "nvme", State: storage.NvmeBindStateKernel},
+				{PciAddr: "0000:83:00.0", State: storage.NvmeBindStateUnknown},
+			},
+			expState: storage.NvmeBindStatePartial,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			sysRoot := filepath.Join(testDir, "devices")
+			driversDir := filepath.Join(testDir, "drivers")
+			if !tc.noRoot {
+				if err := os.MkdirAll(sysRoot, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for addr, dev := range tc.devs {
+				writePciLinkAttrs(t, sysRoot, addr, map[string]string{"class": dev.class})
+				if dev.driver == "" {
+					continue
+				}
+				drvPath := filepath.Join(driversDir, dev.driver)
+				if err := os.MkdirAll(drvPath, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(drvPath, filepath.Join(sysRoot, addr, "driver")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotBindings, gotErr := readNvmeBindings(sysRoot)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expBindings, gotBindings); diff != "" {
+				t.Fatalf("unexpected bindings (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expState, gotBindings.State(), "unexpected state")
+		})
+	}
+}
+
+func TestBdev_readKernelNvmeControllers(t *testing.T) {
+	type mockDev struct {
+		driver string
+		attrs  map[string]map[string]string // relative path to attributes
+	}
+	kernelCtrlr := func(addr string, socket int32, ns ...*storage.NvmeNamespace) *storage.NvmeController {
+		return &storage.NvmeController{
+			PciAddr:    addr,
+			SocketID:   socket,
+			Driver:     storage.NvmeKernelDriver,
+			BindState:  storage.NvmeBindStateKernel,
+			Namespaces: ns,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		devs      map[string]mockDev
+		expCtrlrs storage.NvmeControllers
+		expErr    error
+	}{
+		"no devices": {
+			expCtrlrs: storage.NvmeControllers{},
+		},
+		"spdk bound devices skipped": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {driver: "vfio-pci"},
+			},
+			expCtrlrs: storage.NvmeControllers{},
+		},
+		"kernel bound devices": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {
+					driver: "nvme",
+					attrs: map[string]map[string]string{
+						"": {"numa_node": "1"},
+						"nvme/nvme0": {
+							"model":        "INTEL SSDPE2KE016T8",
+							"serial":       "PHLN000000001",
+							"firmware_rev": "VDV10170",
+						},
+						"nvme/nvme0/nvme0n10": {"size": "2048"},
+						"nvme/nvme0/nvme0n2":  {"size": "3125627568"},
+					},
+				},
+				"0000:82:00.0": {
+					driver: "nvme",
+					attrs: map[string]map[string]string{
+						"": {"numa_node": "-1"},
+					},
+				},
+				"0000:83:00.0": {driver: "vfio-pci"},
+			},
+			expCtrlrs: storage.NvmeControllers{
+				func() *storage.NvmeController {
+					c := kernelCtrlr("0000:81:00.0", 1,
+						&storage.NvmeNamespace{ID: 2, Size: 3125627568 * 512},
+						&storage.NvmeNamespace{ID: 10, Size: 2048 * 512})
+					c.Model = "INTEL SSDPE2KE016T8"
+					c.Serial = "PHLN000000001"
+					c.FwRev = "VDV10170"
+					return c
+				}(),
+				kernelCtrlr("0000:82:00.0", 0),
+			},
+		},
+		"missing controller attribute": {
+			devs: map[string]mockDev{
+				"0000:81:00.0": {
+					driver: "nvme",
+					attrs: map[string]map[string]string{
+						"nvme/nvme0": {"model": "INTEL SSDPE2KE016T8"},
+					},
+				},
+			},
+			expErr: errors.New("reading serial of 0000:81:00.0"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			sysRoot := filepath.Join(testDir, "devices")
+			driversDir := filepath.Join(testDir, "drivers")
+			if err := os.MkdirAll(sysRoot, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for addr, dev := range tc.devs {
+				writePciLinkAttrs(t, sysRoot, addr, map[string]string{"class": nvmePciClass})
+				for rel, attrs := range dev.attrs {
+					writePciLinkAttrs(t, sysRoot, filepath.Join(addr, rel), attrs)
+				}
+				drvPath := filepath.Join(driversDir, dev.driver)
+				if err := os.MkdirAll(drvPath, 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(drvPath, filepath.Join(sysRoot, addr, "driver")); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotCtrlrs, gotErr := readKernelNvmeControllers(sysRoot)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expCtrlrs, gotCtrlrs); diff != "" {
+				t.Fatalf("unexpected controllers (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
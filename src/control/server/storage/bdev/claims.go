@@ -0,0 +1,85 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// spdkLockDir is the directory in which SPDK creates per-device lockfiles when
+// a process claims an NVMe device.
+const spdkLockDir = "/var/tmp"
+
+// spdkLockPath returns the path of the SPDK lockfile for the given PCI address.
+func spdkLockPath(pciAddr string) string {
+	return filepath.Join(spdkLockDir, "spdk_pci_lock_"+pciAddr)
+}
+
+// spdkLockOwner returns the PID of the process holding the SPDK lock on the
+// device with the given PCI address, or zero if the device is not locked.
+func spdkLockOwner(pciAddr string) (int, error) {
+	f, err := os.Open(spdkLockPath(pciAddr))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	lk := unix.Flock_t{
+		Type:   unix.F_WRLCK,
+		Whence: int16(os.SEEK_SET),
+	}
+	if err := unix.FcntlFlock(f.Fd(), unix.F_GETLK, &lk); err != nil {
+		return 0, errors.Wrapf(err, "query lock on %s", f.Name())
+	}
+	if lk.Type == unix.F_UNLCK {
+		return 0, nil
+	}
+
+	return int(lk.Pid), nil
+}
+
+// pidAlive returns true if a process with the given PID exists.
+func pidAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := unix.Kill(pid, 0)
+
+	return err == nil || err == unix.EPERM
+}
+
+// checkDeviceClaims returns a fault for the first device in the request which
+// is claimed either by a running engine or by another live process holding
+// the device's SPDK lock.
+func (p *Provider) checkDeviceClaims(req FormatRequest) error {
+	for _, pciAddr := range req.DeviceList {
+		if claimant, claimed := req.EngineClaims[pciAddr]; claimed {
+			return FaultFormatDeviceInUse(pciAddr, claimant)
+		}
+
+		pid, err := p.getLockOwner(pciAddr)
+		if err != nil {
+			p.log.Debugf("unable to determine SPDK lock owner of %s: %s", pciAddr, err)
+			continue
+		}
+		if pid == 0 || pid == os.Getpid() || !p.isPidAlive(pid) {
+			continue
+		}
+
+		return FaultFormatDeviceInUse(pciAddr, fmt.Sprintf("process %d", pid))
+	}
+
+	return nil
+}
@@ -147,31 +147,69 @@ func bdevFileInit(log logging.Logger, c *storage.BdevConfig) error {
 	return nil
 }
 
+// templData contains the values used when generating config content from a
+// template.
+type templData struct {
+	*storage.BdevConfig
+	Hostname string
+}
+
 // genFromNvme takes NVMe device PCI addresses and generates config content
 // (output as string) from template.
-func genFromTempl(cfg *storage.BdevConfig, templ string) (out bytes.Buffer, err error) {
+func genFromTempl(data *templData, templ string) (out bytes.Buffer, err error) {
 	t := template.Must(template.New(confOut).Parse(templ))
-	err = t.Execute(&out, cfg)
+	err = t.Execute(&out, data)
 
 	return
 }
 
+// ClassConfig contains the parameters of the block device tiers of an engine
+// for which a single config file is generated.
+type ClassConfig struct {
+	Tiers       []*storage.BdevConfig
+	Hostname    string
+	VmdDisabled bool
+}
+
+// merged returns a single block device configuration describing the devices
+// of all tiers in tier order. Tiers are expected to share the same class.
+func (cc *ClassConfig) merged() *storage.BdevConfig {
+	merged := &storage.BdevConfig{DeviceList: []string{}}
+	for i, tier := range cc.Tiers {
+		if i == 0 {
+			merged.Class = tier.Class
+			merged.FileSize = tier.FileSize
+		}
+		merged.DeviceList = append(merged.DeviceList, tier.DeviceList...)
+		merged.DeviceCount += tier.DeviceCount
+	}
+
+	return merged
+}
+
 // ClassProvider implements functionality for a given bdev class
 type ClassProvider struct {
 	log     logging.Logger
-	cfg     *storage.BdevConfig
+	cfg     *ClassConfig
+	merged  *storage.BdevConfig
 	cfgPath string
+	vosEnv  string
 	bdev    bdev
 }
 
-// NewClassProvider returns a new ClassProvider reference for given bdev type.
-func NewClassProvider(log logging.Logger, cfgDir string, cfg *storage.BdevConfig) (*ClassProvider, error) {
+// NewClassProvider returns a new ClassProvider reference for the given block
+// device tiers, all of which are described in one generated config file.
+func NewClassProvider(log logging.Logger, cfgDir string, cfg *ClassConfig) (*ClassProvider, error) {
+	if cfg == nil {
+		cfg = new(ClassConfig)
+	}
 	p := &ClassProvider{
-		log: log,
-		cfg: cfg,
+		log:    log,
+		cfg:    cfg,
+		merged: cfg.merged(),
 	}
 
-	switch cfg.Class {
+	switch p.merged.Class {
 	case storage.BdevClassNone:
 		p.bdev = bdev{nvmeTempl, "", isEmptyList, isValidList, nilInit}
 	case storage.BdevClassNvme:
@@ -189,33 +227,43 @@ func NewClassProvider(log logging.Logger, cfgDir string, cfg *storage.BdevConfig
 	case storage.BdevClassFile:
 		p.bdev = bdev{fileTempl, "AIO", isEmptyList, isValidSize, bdevFileInit}
 	default:
-		return nil, errors.Errorf("unable to map %q to BdevClass", cfg.Class)
+		return nil, errors.Errorf("unable to map %q to BdevClass", p.merged.Class)
 	}
 
-	if msg := p.bdev.isEmpty(p.cfg); msg != "" {
-		log.Debugf("spdk %s: %s", cfg.Class, msg)
+	if msg := p.bdev.isEmpty(p.merged); msg != "" {
+		log.Debugf("spdk %s: %s", p.merged.Class, msg)
 		// No devices; no need to generate a config file
 		return p, nil
 	}
 
-	if msg := p.bdev.isValid(p.cfg); msg != "" {
-		log.Debugf("spdk %s: %s", cfg.Class, msg)
-		// Bad config; don't generate a config file
-		return nil, errors.Errorf("invalid nvme config: %s", msg)
+	for _, tier := range cfg.Tiers {
+		if msg := p.bdev.isValid(tier); msg != "" {
+			log.Debugf("spdk %s: %s", p.merged.Class, msg)
+			// Bad config; don't generate a config file
+			return nil, errors.Errorf("invalid nvme config: %s", msg)
+		}
 	}
 
 	// Config file required; set this so it gets generated later
 	p.cfgPath = filepath.Join(cfgDir, confOut)
+	p.vosEnv = p.bdev.vosEnv
 	log.Debugf("output bdev conf file set to %s", p.cfgPath)
 
-	// FIXME: Not really happy with having side-effects here, but trying
-	// not to change too much at once.
-	cfg.VosEnv = p.bdev.vosEnv
-	cfg.ConfigPath = p.cfgPath
-
 	return p, nil
 }
 
+// ConfigPath returns the path of the config file to be generated, or an empty
+// string if no config file is required.
+func (p *ClassProvider) ConfigPath() string {
+	return p.cfgPath
+}
+
+// VosEnv returns the value of VOS_BDEV_CLASS to be set in the environment of
+// the engine, or an empty string if no config file is required.
+func (p *ClassProvider) VosEnv() string {
+	return p.vosEnv
+}
+
 // GenConfigFile generates nvme config file for given bdev type to be consumed
 // by spdk.
 func (p *ClassProvider) GenConfigFile() error {
@@ -225,11 +273,16 @@ func (p *ClassProvider) GenConfigFile() error {
 		return nil
 	}
 
-	if err := p.bdev.init(p.log, p.cfg); err != nil {
-		return errors.Wrap(err, "bdev device init")
+	for _, tier := range p.cfg.Tiers {
+		if err := p.bdev.init(p.log, tier); err != nil {
+			return errors.Wrap(err, "bdev device init")
+		}
 	}
 
-	confBytes, err := genFromTempl(p.cfg, p.bdev.templ)
+	confBytes, err := genFromTempl(&templData{
+		BdevConfig: p.merged,
+		Hostname:   p.cfg.Hostname,
+	}, p.bdev.templ)
 	if err != nil {
 		return err
 	}
@@ -238,7 +291,7 @@ func (p *ClassProvider) GenConfigFile() error {
 		return errors.New("spdk: generated nvme config is unexpectedly empty")
 	}
 
-	p.log.Debugf("create %s with %v bdevs", p.cfgPath, p.cfg.DeviceList)
+	p.log.Debugf("create %s with %v bdevs", p.cfgPath, p.merged.DeviceList)
 
 	f, err := os.Create(p.cfgPath)
 	defer func() {
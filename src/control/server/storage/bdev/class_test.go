@@ -29,6 +29,7 @@ func TestParseBdev(t *testing.T) {
 		bdevVmdDisabled bool
 		bdevSize        int // relevant for MALLOC/FILE
 		bdevNumber      int // relevant for MALLOC
+		tierSplit       int // split bdevList into two tiers at index
 		vosEnv          string
 		wantBuf         []string
 		errMsg          string
@@ -106,6 +107,26 @@ func TestParseBdev(t *testing.T) {
 			},
 			vosEnv: "NVME",
 		},
+		"multiple tiers": {
+			bdevClass:       storage.BdevClassNvme,
+			bdevVmdDisabled: true,
+			bdevList:        []string{"0000:81:00.0", "0000:82:00.0", "0000:83:00.0"},
+			tierSplit:       1,
+			wantBuf: []string{
+				`[Nvme]`,
+				`    TransportID "trtype:PCIe traddr:0000:81:00.0" Nvme__0`,
+				`    TransportID "trtype:PCIe traddr:0000:82:00.0" Nvme__1`,
+				`    TransportID "trtype:PCIe traddr:0000:83:00.0" Nvme__2`,
+				`    RetryCount 4`,
+				`    TimeoutUsec 0`,
+				`    ActionOnTimeout None`,
+				`    AdminPollRate 100000`,
+				`    HotplugEnable No`,
+				`    HotplugPollRate 0`,
+				``,
+			},
+			vosEnv: "NVME",
+		},
 		"AIO file": {
 			bdevClass:       storage.BdevClassFile,
 			bdevVmdDisabled: true,
@@ -158,7 +179,6 @@ func TestParseBdev(t *testing.T) {
 			if tt.bdevClass != "" {
 				config.Class = tt.bdevClass
 			}
-			config.VmdDisabled = tt.bdevVmdDisabled
 
 			if len(tt.bdevList) != 0 {
 				switch tt.bdevClass {
@@ -188,7 +208,18 @@ func TestParseBdev(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
 			defer common.ShowBufferOnFailure(t, buf)
 
-			provider, err := NewClassProvider(log, testDir, &config)
+			tiers := []*storage.BdevConfig{&config}
+			if tt.tierSplit != 0 {
+				second := config
+				config.DeviceList = config.DeviceList[:tt.tierSplit]
+				second.DeviceList = second.DeviceList[tt.tierSplit:]
+				tiers = append(tiers, &second)
+			}
+
+			provider, err := NewClassProvider(log, testDir, &ClassConfig{
+				Tiers:       tiers,
+				VmdDisabled: tt.bdevVmdDisabled,
+			})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -197,14 +228,14 @@ func TestParseBdev(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if provider.cfgPath == "" {
+			if provider.ConfigPath() == "" {
 				if len(config.DeviceList) == 0 {
 					return
 				}
 				t.Fatal("provider cfgPath empty but device list isn't")
 			}
 
-			gotBuf, err := ioutil.ReadFile(provider.cfgPath)
+			gotBuf, err := ioutil.ReadFile(provider.ConfigPath())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -213,8 +244,8 @@ func TestParseBdev(t *testing.T) {
 				t.Fatalf("(-want, +got):\n%s", diff)
 			}
 
-			if config.VosEnv != tt.vosEnv {
-				t.Fatalf("expected VosEnv to be %q, but it was %q", tt.vosEnv, config.VosEnv)
+			if provider.VosEnv() != tt.vosEnv {
+				t.Fatalf("expected VosEnv to be %q, but it was %q", tt.vosEnv, provider.VosEnv())
 			}
 
 			// The remainder only applies to loopback file devices.
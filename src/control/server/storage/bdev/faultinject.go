@@ -0,0 +1,124 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// errInjectedFault is returned for operations failed by fault injection.
+var errInjectedFault = errors.New("injected fault")
+
+// faultBackend wraps a Backend and injects the errors described by a
+// storage.FaultInjection specification.
+//
+// Devices to fail format are identified either by PCI address or by their
+// 1-based position in the format request's device list.
+type faultBackend struct {
+	Backend
+	log    logging.Logger
+	faults *storage.FaultInjection
+}
+
+// withFaultInjection wraps the given backend with fault injection if it has
+// been requested through the environment and is enabled in this build.
+func withFaultInjection(log logging.Logger, b Backend) Backend {
+	faults, err := storage.FaultInjectionFromEnv(storage.BdevFaultsEnvVar)
+	if err != nil {
+		log.Errorf("bdev fault injection disabled: %s", err)
+		return b
+	}
+	if faults == nil {
+		return b
+	}
+
+	log.Infof("bdev fault injection enabled: %+v", *faults)
+	return newFaultBackend(log, b, faults)
+}
+
+func newFaultBackend(log logging.Logger, b Backend, faults *storage.FaultInjection) *faultBackend {
+	return &faultBackend{
+		Backend: b,
+		log:     log,
+		faults:  faults,
+	}
+}
+
+func (fb *faultBackend) Scan(req ScanRequest) (*ScanResponse, error) {
+	fb.faults.DelayScan()
+
+	resp, err := fb.Backend.Scan(req)
+	if err != nil || !fb.faults.CorruptHealth {
+		return resp, err
+	}
+
+	for _, ctrlr := range resp.Controllers {
+		fb.log.Debugf("injecting corrupt health stats for %s", ctrlr.PciAddr)
+		ctrlr.HealthStats = corruptHealth()
+	}
+
+	return resp, nil
+}
+
+func (fb *faultBackend) Format(req FormatRequest) (*FormatResponse, error) {
+	failed := make(DeviceFormatResponses)
+	var remaining []string
+	for i, dev := range req.DeviceList {
+		if fb.faults.FormatFails(dev) || fb.faults.FormatFails(strconv.Itoa(i+1)) {
+			fb.log.Debugf("injecting format failure for %s", dev)
+			failed[dev] = &DeviceFormatResponse{
+				Error: FaultFormatError(dev, errInjectedFault),
+			}
+			continue
+		}
+		remaining = append(remaining, dev)
+	}
+
+	if len(failed) == 0 {
+		return fb.Backend.Format(req)
+	}
+
+	resp := &FormatResponse{DeviceResponses: failed}
+	if len(remaining) == 0 {
+		return resp, nil
+	}
+
+	req.DeviceList = remaining
+	innerResp, err := fb.Backend.Format(req)
+	if err != nil {
+		return nil, err
+	}
+	for dev, devResp := range innerResp.DeviceResponses {
+		resp.DeviceResponses[dev] = devResp
+	}
+
+	return resp, nil
+}
+
+// corruptHealth returns health statistics with implausible values.
+func corruptHealth() *storage.NvmeHealth {
+	return &storage.NvmeHealth{
+		Temperature:     math.MaxUint32,
+		PowerOnHours:    math.MaxUint64,
+		MediaErrors:     math.MaxUint64,
+		ErrorLogEntries: math.MaxUint64,
+		UnsafeShutdowns: math.MaxUint64,
+		ReadErrors:      math.MaxUint32,
+		WriteErrors:     math.MaxUint32,
+		TempWarn:        true,
+		AvailSpareWarn:  true,
+		ReliabilityWarn: true,
+		ReadOnlyWarn:    true,
+		VolatileWarn:    true,
+	}
+}
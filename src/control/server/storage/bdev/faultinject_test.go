@@ -0,0 +1,145 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestBdev_faultBackend_Format(t *testing.T) {
+	devs := []string{"0000:80:00.0", "0000:81:00.0", "0000:82:00.0"}
+
+	for name, tc := range map[string]struct {
+		faults    *storage.FaultInjection
+		formatRes *FormatResponse
+		formatErr error
+		expFailed []string
+		expOK     []string
+		expErr    error
+	}{
+		"no format faults": {
+			faults: &storage.FaultInjection{},
+			formatRes: &FormatResponse{
+				DeviceResponses: DeviceFormatResponses{
+					devs[0]: {Formatted: true},
+					devs[1]: {Formatted: true},
+					devs[2]: {Formatted: true},
+				},
+			},
+			expOK: devs,
+		},
+		"fail nth device": {
+			faults: &storage.FaultInjection{FormatFail: []string{"2"}},
+			formatRes: &FormatResponse{
+				DeviceResponses: DeviceFormatResponses{
+					devs[0]: {Formatted: true},
+					devs[2]: {Formatted: true},
+				},
+			},
+			expFailed: []string{devs[1]},
+			expOK:     []string{devs[0], devs[2]},
+		},
+		"fail by pci address": {
+			faults: &storage.FaultInjection{FormatFail: []string{devs[2]}},
+			formatRes: &FormatResponse{
+				DeviceResponses: DeviceFormatResponses{
+					devs[0]: {Formatted: true},
+					devs[1]: {Formatted: true},
+				},
+			},
+			expFailed: []string{devs[2]},
+			expOK:     []string{devs[0], devs[1]},
+		},
+		"fail all devices": {
+			faults:    &storage.FaultInjection{FormatFail: []string{"1", "2", "3"}},
+			formatErr: errors.New("backend should not be called"),
+			expFailed: devs,
+		},
+		"backend error": {
+			faults:    &storage.FaultInjection{FormatFail: []string{"1"}},
+			formatErr: errors.New("backend failed"),
+			expErr:    errors.New("backend failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mb := NewMockBackend(&MockBackendConfig{
+				FormatRes: tc.formatRes,
+				FormatErr: tc.formatErr,
+			})
+			fb := newFaultBackend(log, mb, tc.faults)
+
+			resp, gotErr := fb.Format(FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: devs,
+			})
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, len(tc.expFailed)+len(tc.expOK),
+				len(resp.DeviceResponses), "number of device responses")
+			for _, dev := range tc.expFailed {
+				if resp.DeviceResponses[dev].Error == nil {
+					t.Fatalf("expected format of %s to fail", dev)
+				}
+			}
+			for _, dev := range tc.expOK {
+				if !resp.DeviceResponses[dev].Formatted {
+					t.Fatalf("expected format of %s to succeed", dev)
+				}
+			}
+		})
+	}
+}
+
+func TestBdev_faultBackend_Scan(t *testing.T) {
+	for name, tc := range map[string]struct {
+		faults    *storage.FaultInjection
+		expHealth *storage.NvmeHealth
+	}{
+		"healthy": {
+			faults:    &storage.FaultInjection{},
+			expHealth: storage.MockNvmeHealth(),
+		},
+		"corrupt health": {
+			faults:    &storage.FaultInjection{CorruptHealth: true},
+			expHealth: corruptHealth(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			mb := NewMockBackend(&MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{storage.MockNvmeController()},
+				},
+			})
+			fb := newFaultBackend(log, mb, tc.faults)
+
+			resp, err := fb.Scan(ScanRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expHealth, resp.Controllers[0].HealthStats); diff != "" {
+				t.Fatalf("unexpected health stats (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
+	"github.com/daos-stack/daos/src/control/server/storage"
 )
 
 var (
@@ -70,6 +71,36 @@ func FaultFormatError(pciAddress string, err error) *fault.Fault {
 	)
 }
 
+// FaultFormatDeviceInUse creates a Fault for the case where a device format
+// was refused because the device is claimed by a running process.
+func FaultFormatDeviceInUse(pciAddr, claimant string) *fault.Fault {
+	return bdevFault(
+		code.BdevFormatDeviceInUse,
+		fmt.Sprintf("NVMe device %q is in use by %s", pciAddr, claimant),
+		"stop the process using the device or retry the format with --force",
+	)
+}
+
+// FaultFormatZonedNamespace creates a Fault for the case where a device format
+// was refused because the device has zoned namespaces.
+func FaultFormatZonedNamespace(pciAddr string) *fault.Fault {
+	return bdevFault(
+		code.BdevFormatZonedNamespace,
+		fmt.Sprintf("NVMe device %q has zoned (ZNS) namespaces", pciAddr),
+		"remove the device from bdev_list or set bdev_allow_zoned in the engine storage config",
+	)
+}
+
+// FaultFormatLbaUnsupported creates a Fault for the case where a device format
+// was refused because the device does not support the requested LBA format.
+func FaultFormatLbaUnsupported(pciAddr string, spec *storage.LbaFormatSpec) *fault.Fault {
+	return bdevFault(
+		code.BdevFormatLbaUnsupported,
+		fmt.Sprintf("NVMe device %q does not support LBA format %s", pciAddr, spec),
+		"choose an LBA format supported by the device in bdev_lba_format or bdev_lba_formats",
+	)
+}
+
 func bdevFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "bdev",
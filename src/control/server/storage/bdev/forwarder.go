@@ -54,3 +54,14 @@ func (f *Forwarder) Format(req FormatRequest) (*FormatResponse, error) {
 
 	return res, nil
 }
+
+func (f *Forwarder) Verify(req VerifyRequest) (*VerifyResponse, error) {
+	req.Forwarded = true
+
+	res := new(VerifyResponse)
+	if err := f.SendReq("BdevVerify", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
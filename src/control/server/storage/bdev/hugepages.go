@@ -0,0 +1,204 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	procSysVMDir = "/proc/sys/vm"
+	procMeminfo  = "/proc/meminfo"
+	sysNodeDir   = "/sys/devices/system/node"
+
+	// dropCachesAll frees the page cache as well as reclaimable slab
+	// objects (dentries and inodes), only clean pages are dropped.
+	dropCachesAll = "3"
+	// compactAll triggers compaction of all zones on all nodes.
+	compactAll = "1"
+)
+
+// compactMemory attempts to make physically contiguous memory available for
+// hugepage allocation on a system that has become fragmented (typically after
+// a long uptime). Dirty pages are flushed first so that dropping caches does
+// not discard data, then the kernel is asked to compact free memory. Both
+// operations are non-destructive but may take some time on large systems.
+func compactMemory(log logging.Logger, vmDir string) error {
+	unix.Sync()
+
+	for _, op := range []struct {
+		file  string
+		value string
+	}{
+		{"drop_caches", dropCachesAll},
+		{"compact_memory", compactAll},
+	} {
+		path := filepath.Join(vmDir, op.file)
+		log.Debugf("writing %q to %s", op.value, path)
+		if err := ioutil.WriteFile(path, []byte(op.value), 0200); err != nil {
+			return errors.Wrapf(err, "write %s", path)
+		}
+	}
+
+	return nil
+}
+
+// HugeNodeAlloc describes the hugepages requested and allocated on a NUMA node
+// when hugepages are allocated per node.
+type HugeNodeAlloc struct {
+	Node      int `json:"node"`
+	Requested int `json:"requested"`
+	Allocated int `json:"allocated"`
+}
+
+// Short returns true if fewer hugepages were allocated on the node than were
+// requested.
+func (hna *HugeNodeAlloc) Short() bool {
+	return hna.Allocated < hna.Requested
+}
+
+// setNodeHugePagesFn sets the number of hugepages on a NUMA node and returns
+// the number that the kernel was able to allocate.
+type setNodeHugePagesFn func(node, count int) (int, error)
+
+// parseHugePageSize returns the default hugepage size in kB from the contents
+// of /proc/meminfo.
+func parseHugePageSize(input io.Reader) (int, error) {
+	scn := bufio.NewScanner(input)
+	for scn.Scan() {
+		keyVal := strings.SplitN(scn.Text(), ":", 2)
+		if len(keyVal) < 2 || keyVal[0] != "Hugepagesize" {
+			continue
+		}
+
+		sf := strings.Fields(keyVal[1])
+		if len(sf) != 2 || sf[1] != "kB" {
+			return 0, errors.Errorf("unable to parse %q", keyVal[1])
+		}
+		return strconv.Atoi(sf[0])
+	}
+	if err := scn.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, errors.New("hugepage size not found")
+}
+
+func getHugePageSize(meminfoPath string) (int, error) {
+	f, err := os.Open(meminfoPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return parseHugePageSize(f)
+}
+
+// getNumaNodes returns the NUMA nodes of the host in ascending order.
+func getNumaNodes(nodeDir string) ([]int, error) {
+	dirs, err := filepath.Glob(filepath.Join(nodeDir, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]int, 0, len(dirs))
+	for _, dir := range dirs {
+		node, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Ints(nodes)
+
+	return nodes, nil
+}
+
+// sysfsNodeHugePageSetter returns a setNodeHugePagesFn that writes the hugepage
+// count of the given size to the nr_hugepages file of the node under nodeDir,
+// then reads it back as the kernel may allocate fewer pages than requested.
+func sysfsNodeHugePageSetter(log logging.Logger, nodeDir string, pageSizeKb int) setNodeHugePagesFn {
+	return func(node, count int) (int, error) {
+		path := filepath.Join(nodeDir, fmt.Sprintf("node%d", node), "hugepages",
+			fmt.Sprintf("hugepages-%dkB", pageSizeKb), "nr_hugepages")
+
+		log.Debugf("writing %d to %s", count, path)
+		if err := ioutil.WriteFile(path, []byte(strconv.Itoa(count)), 0644); err != nil {
+			return 0, errors.Wrapf(err, "write %s", path)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+}
+
+// allocNodeHugePages sets the number of hugepages on each of the given NUMA
+// nodes to the count requested for it, nodes without a request are emptied.
+// Hugepages that cannot be allocated on the requested node, typically because
+// its free memory is too fragmented, are allocated on the other nodes instead
+// so that the total is met where possible. The outcome on every node is
+// returned so that shortfalls can be reported.
+func allocNodeHugePages(nodes []int, nodePages map[int]int, setPages setNodeHugePagesFn) ([]*HugeNodeAlloc, error) {
+	present := make(map[int]bool, len(nodes))
+	for _, node := range nodes {
+		present[node] = true
+	}
+	for node := range nodePages {
+		if !present[node] {
+			return nil, errors.Errorf("hugepages requested on NUMA node %d which is not present", node)
+		}
+	}
+
+	allocs := make([]*HugeNodeAlloc, 0, len(nodes))
+	var shortfall int
+	for _, node := range nodes {
+		hna := &HugeNodeAlloc{Node: node, Requested: nodePages[node]}
+
+		var err error
+		if hna.Allocated, err = setPages(node, hna.Requested); err != nil {
+			return nil, errors.Wrapf(err, "NUMA node %d", node)
+		}
+		if hna.Short() {
+			shortfall += hna.Requested - hna.Allocated
+		}
+		allocs = append(allocs, hna)
+	}
+
+	for _, hna := range allocs {
+		if shortfall <= 0 {
+			break
+		}
+		if hna.Short() {
+			continue
+		}
+
+		allocated, err := setPages(hna.Node, hna.Allocated+shortfall)
+		if err != nil {
+			return nil, errors.Wrapf(err, "NUMA node %d", hna.Node)
+		}
+		shortfall -= allocated - hna.Allocated
+		hna.Allocated = allocated
+	}
+
+	return allocs, nil
+}
@@ -0,0 +1,230 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestBdev_compactMemory(t *testing.T) {
+	for name, tc := range map[string]struct {
+		missingDir bool
+		expValues  map[string]string
+		expErr     error
+	}{
+		"missing vm dir": {
+			missingDir: true,
+			expErr:     errors.New("write"),
+		},
+		"success": {
+			expValues: map[string]string{
+				"drop_caches":    "3",
+				"compact_memory": "1",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			vmDir := filepath.Join(testDir, "vm")
+			if !tc.missingDir {
+				if err := os.Mkdir(vmDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				for _, f := range []string{"drop_caches", "compact_memory"} {
+					if err := ioutil.WriteFile(filepath.Join(vmDir, f), nil, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			gotErr := compactMemory(log, vmDir)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotValues := make(map[string]string)
+			for f := range tc.expValues {
+				b, err := ioutil.ReadFile(filepath.Join(vmDir, f))
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotValues[f] = string(b)
+			}
+			if diff := cmp.Diff(tc.expValues, gotValues); diff != "" {
+				t.Fatalf("unexpected values (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestBdev_parseHugePageSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input     string
+		expSizeKb int
+		expErr    error
+	}{
+		"missing": {
+			input:  "MemTotal:       196438836 kB\n",
+			expErr: errors.New("not found"),
+		},
+		"bad unit": {
+			input:  "Hugepagesize:       2 MB\n",
+			expErr: errors.New("unable to parse"),
+		},
+		"2MB pages": {
+			input:     "HugePages_Total:    4096\nHugepagesize:       2048 kB\nHugetlb:         8388608 kB\n",
+			expSizeKb: 2048,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotSizeKb, gotErr := parseHugePageSize(strings.NewReader(tc.input))
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expSizeKb, gotSizeKb, "unexpected hugepage size")
+		})
+	}
+}
+
+func TestBdev_sysfsNodeHugePageSetter(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	testDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	for _, node := range []string{"node1", "node0", "possible"} {
+		dir := filepath.Join(testDir, node, "hugepages", "hugepages-2048kB")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "nr_hugepages"), []byte("0\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nodes, err := getNumaNodes(testDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{0, 1}, nodes); diff != "" {
+		t.Fatalf("unexpected nodes (-want, +got):\n%s\n", diff)
+	}
+
+	setPages := sysfsNodeHugePageSetter(log, testDir, 2048)
+	gotCount, err := setPages(1, 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, 4096, gotCount, "unexpected hugepage count")
+
+	if _, err := setPages(2, 4096); err == nil {
+		t.Fatal("expected error setting hugepages on missing node")
+	}
+}
+
+func TestBdev_allocNodeHugePages(t *testing.T) {
+	for name, tc := range map[string]struct {
+		nodes     []int
+		nodePages map[int]int
+		capacity  map[int]int // hugepages that can be allocated on each node
+		setErr    error
+		expAllocs []*HugeNodeAlloc
+		expErr    error
+	}{
+		"node not present": {
+			nodes:     []int{0},
+			nodePages: map[int]int{1: 4096},
+			expErr:    errors.New("NUMA node 1 which is not present"),
+		},
+		"write failure": {
+			nodes:     []int{0},
+			nodePages: map[int]int{0: 4096},
+			setErr:    errors.New("permission denied"),
+			expErr:    errors.New("NUMA node 0: permission denied"),
+		},
+		"all allocated; unrequested node emptied": {
+			nodes:     []int{0, 1, 2},
+			nodePages: map[int]int{0: 4096, 1: 4096},
+			capacity:  map[int]int{0: 8192, 1: 8192, 2: 8192},
+			expAllocs: []*HugeNodeAlloc{
+				{Node: 0, Requested: 4096, Allocated: 4096},
+				{Node: 1, Requested: 4096, Allocated: 4096},
+				{Node: 2},
+			},
+		},
+		"fragmented node; shortfall allocated on other nodes": {
+			nodes:     []int{0, 1, 2},
+			nodePages: map[int]int{0: 4096, 1: 4096},
+			capacity:  map[int]int{0: 8192, 1: 3000, 2: 8192},
+			expAllocs: []*HugeNodeAlloc{
+				{Node: 0, Requested: 4096, Allocated: 5192},
+				{Node: 1, Requested: 4096, Allocated: 3000},
+				{Node: 2},
+			},
+		},
+		"shortfall spread over remaining nodes": {
+			nodes:     []int{0, 1, 2},
+			nodePages: map[int]int{0: 4096, 1: 4096},
+			capacity:  map[int]int{0: 4500, 1: 3000, 2: 8192},
+			expAllocs: []*HugeNodeAlloc{
+				{Node: 0, Requested: 4096, Allocated: 4500},
+				{Node: 1, Requested: 4096, Allocated: 3000},
+				{Node: 2, Allocated: 692},
+			},
+		},
+		"insufficient memory on all nodes": {
+			nodes:     []int{0, 1},
+			nodePages: map[int]int{0: 4096, 1: 4096},
+			capacity:  map[int]int{0: 1000, 1: 2000},
+			expAllocs: []*HugeNodeAlloc{
+				{Node: 0, Requested: 4096, Allocated: 1000},
+				{Node: 1, Requested: 4096, Allocated: 2000},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setPages := func(node, count int) (int, error) {
+				if tc.setErr != nil {
+					return 0, tc.setErr
+				}
+				if count > tc.capacity[node] {
+					return tc.capacity[node], nil
+				}
+				return count, nil
+			}
+
+			gotAllocs, gotErr := allocNodeHugePages(tc.nodes, tc.nodePages, setPages)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expAllocs, gotAllocs); diff != "" {
+				t.Fatalf("unexpected allocations (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
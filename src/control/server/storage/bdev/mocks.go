@@ -8,6 +8,7 @@ package bdev
 
 import (
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
 )
 
 type (
@@ -19,8 +20,15 @@ type (
 		FormatErr       error
 		ScanRes         *ScanResponse
 		ScanErr         error
+		VerifyRes       *VerifyResponse
+		VerifyErr       error
 		VmdEnabled      bool // set disabled by default
 		UpdateErr       error
+		Capabilities    *storage.BdevCapabilities
+		Bindings        storage.NvmeBindings
+		BindingsErr     error
+		KernelCtrlrs    storage.NvmeControllers
+		KernelCtrlrsErr error
 	}
 
 	MockBackend struct {
@@ -62,6 +70,14 @@ func (mb *MockBackend) Format(req FormatRequest) (*FormatResponse, error) {
 	return mb.cfg.FormatRes, mb.cfg.FormatErr
 }
 
+func (mb *MockBackend) Verify(req VerifyRequest) (*VerifyResponse, error) {
+	if mb.cfg.VerifyRes == nil {
+		mb.cfg.VerifyRes = new(VerifyResponse)
+	}
+
+	return mb.cfg.VerifyRes, mb.cfg.VerifyErr
+}
+
 func (mb *MockBackend) PrepareReset() error {
 	return mb.cfg.PrepareResetErr
 }
@@ -89,8 +105,31 @@ func (mb *MockBackend) UpdateFirmware(_ string, _ string, _ int32) error {
 	return mb.cfg.UpdateErr
 }
 
+func (mb *MockBackend) Capabilities() *storage.BdevCapabilities {
+	if mb.cfg.Capabilities == nil {
+		return &storage.BdevCapabilities{}
+	}
+
+	return mb.cfg.Capabilities
+}
+
 func NewMockProvider(log logging.Logger, mbc *MockBackendConfig) *Provider {
-	return NewProvider(log, NewMockBackend(mbc)).WithForwardingDisabled()
+	p := NewProvider(log, NewMockBackend(mbc)).WithForwardingDisabled()
+	p.getLockOwner = func(_ string) (int, error) { return 0, nil }
+	p.getBindings = func() (storage.NvmeBindings, error) {
+		if mbc == nil {
+			return storage.NvmeBindings{}, nil
+		}
+		return mbc.Bindings, mbc.BindingsErr
+	}
+	p.getKernel = func() (storage.NvmeControllers, error) {
+		if mbc == nil {
+			return storage.NvmeControllers{}, nil
+		}
+		return mbc.KernelCtrlrs, mbc.KernelCtrlrsErr
+	}
+
+	return p
 }
 
 func DefaultMockProvider(log logging.Logger) *Provider {
@@ -0,0 +1,154 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+const pciDevicesPath = "/sys/bus/pci/devices"
+
+// readPciLinkAttr returns the trimmed contents of a PCI device sysfs attribute.
+func readPciLinkAttr(devPath, attr string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(devPath, attr))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// parsePciLinkSpeed parses a sysfs link speed value e.g. "8.0 GT/s PCIe" and
+// returns the speed in GT/s.
+func parsePciLinkSpeed(val string) (float64, error) {
+	fields := strings.Fields(val)
+	if len(fields) < 2 || fields[1] != "GT/s" {
+		return 0, errors.Errorf("unexpected link speed format %q", val)
+	}
+
+	return strconv.ParseFloat(fields[0], 64)
+}
+
+// parsePciLinkWidth parses a sysfs link width value e.g. "4" and returns the
+// number of lanes.
+func parsePciLinkWidth(val string) (uint32, error) {
+	width, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		return 0, errors.Errorf("unexpected link width format %q", val)
+	}
+
+	return uint32(width), nil
+}
+
+// getPortMaxLink returns the maximum link speed and width of the port that the
+// device at the given sysfs path is attached to, the parent of the device in
+// the sysfs device hierarchy. Zero values are returned if the port doesn't
+// report them, e.g. when the device is attached directly to a host bridge.
+func getPortMaxLink(devPath string) (float64, uint32) {
+	realPath, err := filepath.EvalSymlinks(devPath)
+	if err != nil {
+		return 0, 0
+	}
+	portPath := filepath.Dir(realPath)
+
+	var speed float64
+	if val, err := readPciLinkAttr(portPath, "max_link_speed"); err == nil {
+		if speed, err = parsePciLinkSpeed(val); err != nil {
+			speed = 0
+		}
+	}
+	var width uint32
+	if val, err := readPciLinkAttr(portPath, "max_link_width"); err == nil {
+		if width, err = parsePciLinkWidth(val); err != nil {
+			width = 0
+		}
+	}
+
+	return speed, width
+}
+
+// getPciLink reads the negotiated and maximum PCIe link speed and width of the
+// device with the given PCI address from sysfs. The maximum is the lower of
+// that of the device and that of the port it is attached to, as the link
+// cannot train beyond either.
+//
+// Nil is returned without error if the device has no entry under the sysfs
+// root, e.g. for devices behind a VMD domain.
+func getPciLink(sysRoot, pciAddr string) (*storage.NvmePciLink, error) {
+	devPath := filepath.Join(sysRoot, pciAddr)
+	if _, err := os.Stat(devPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	vals := make(map[string]string)
+	for _, attr := range []string{
+		"current_link_speed", "current_link_width",
+		"max_link_speed", "max_link_width",
+	} {
+		val, err := readPciLinkAttr(devPath, attr)
+		if err != nil {
+			return nil, err
+		}
+		vals[attr] = val
+	}
+
+	link := new(storage.NvmePciLink)
+	var err error
+	if link.Speed, err = parsePciLinkSpeed(vals["current_link_speed"]); err != nil {
+		return nil, err
+	}
+	if link.Width, err = parsePciLinkWidth(vals["current_link_width"]); err != nil {
+		return nil, err
+	}
+	if link.MaxSpeed, err = parsePciLinkSpeed(vals["max_link_speed"]); err != nil {
+		return nil, err
+	}
+	if link.MaxWidth, err = parsePciLinkWidth(vals["max_link_width"]); err != nil {
+		return nil, err
+	}
+
+	portSpeed, portWidth := getPortMaxLink(devPath)
+	if portSpeed > 0 && portSpeed < link.MaxSpeed {
+		link.MaxSpeed = portSpeed
+	}
+	if portWidth > 0 && portWidth < link.MaxWidth {
+		link.MaxWidth = portWidth
+	}
+
+	return link, nil
+}
+
+// updatePciLinks populates the PCIe link status of each controller and warns
+// about any controller whose link has trained below its capability.
+func (b *spdkBackend) updatePciLinks(ctrlrs storage.NvmeControllers) {
+	for _, ctrlr := range ctrlrs {
+		link, err := getPciLink(b.pciDevicesPath, ctrlr.PciAddr)
+		if err != nil {
+			b.log.Debugf("reading pci link of %s: %s", ctrlr.PciAddr, err)
+			continue
+		}
+		if link == nil {
+			continue
+		}
+
+		if link.Degraded() {
+			b.log.Errorf("NVMe controller %s PCIe link degraded: %s", ctrlr.PciAddr, link)
+		}
+		ctrlr.PciLink = link
+	}
+}
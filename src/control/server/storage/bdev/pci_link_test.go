@@ -0,0 +1,215 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func writePciLinkAttrs(t *testing.T, root, pciAddr string, attrs map[string]string) {
+	t.Helper()
+
+	devPath := filepath.Join(root, pciAddr)
+	if err := os.MkdirAll(devPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for attr, val := range attrs {
+		if err := ioutil.WriteFile(filepath.Join(devPath, attr), []byte(val+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBdev_getPciLink(t *testing.T) {
+	pciAddr := "0000:81:00.0"
+	portAddr := "0000:80:03.0"
+
+	for name, tc := range map[string]struct {
+		attrs       map[string]string
+		portAttrs   map[string]string
+		expLink     *storage.NvmePciLink
+		expDegraded bool
+		expErr      error
+	}{
+		"no sysfs entry": {},
+		"full width and speed": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+				"current_link_width": "4",
+				"max_link_speed":     "8.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			expLink: &storage.NvmePciLink{
+				Speed: 8, Width: 4, MaxSpeed: 8, MaxWidth: 4,
+			},
+		},
+		"degraded width": {
+			attrs: map[string]string{
+				"current_link_speed": "16.0 GT/s PCIe",
+				"current_link_width": "1",
+				"max_link_speed":     "16.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			expLink: &storage.NvmePciLink{
+				Speed: 16, Width: 1, MaxSpeed: 16, MaxWidth: 4,
+			},
+			expDegraded: true,
+		},
+		"speed limited by port": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+				"current_link_width": "4",
+				"max_link_speed":     "16.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			portAttrs: map[string]string{
+				"max_link_speed": "8.0 GT/s PCIe",
+				"max_link_width": "16",
+			},
+			expLink: &storage.NvmePciLink{
+				Speed: 8, Width: 4, MaxSpeed: 8, MaxWidth: 4,
+			},
+		},
+		"width limited by port": {
+			attrs: map[string]string{
+				"current_link_speed": "16.0 GT/s PCIe",
+				"current_link_width": "2",
+				"max_link_speed":     "16.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			portAttrs: map[string]string{
+				"max_link_speed": "16.0 GT/s PCIe",
+				"max_link_width": "2",
+			},
+			expLink: &storage.NvmePciLink{
+				Speed: 16, Width: 2, MaxSpeed: 16, MaxWidth: 2,
+			},
+		},
+		"degraded below port capability": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+				"current_link_width": "1",
+				"max_link_speed":     "16.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			portAttrs: map[string]string{
+				"max_link_speed": "8.0 GT/s PCIe",
+				"max_link_width": "4",
+			},
+			expLink: &storage.NvmePciLink{
+				Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4,
+			},
+			expDegraded: true,
+		},
+		"port without link attributes": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+				"current_link_width": "4",
+				"max_link_speed":     "8.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			portAttrs: map[string]string{},
+			expLink: &storage.NvmePciLink{
+				Speed: 8, Width: 4, MaxSpeed: 8, MaxWidth: 4,
+			},
+		},
+		"missing attribute": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+			},
+			expErr: errors.New("no such file"),
+		},
+		"bad speed": {
+			attrs: map[string]string{
+				"current_link_speed": "Unknown",
+				"current_link_width": "4",
+				"max_link_speed":     "8.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			expErr: errors.New("unexpected link speed format"),
+		},
+		"bad width": {
+			attrs: map[string]string{
+				"current_link_speed": "8.0 GT/s PCIe",
+				"current_link_width": "x4",
+				"max_link_speed":     "8.0 GT/s PCIe",
+				"max_link_width":     "4",
+			},
+			expErr: errors.New("unexpected link width format"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			// mirror the sysfs layout, devices are linked to from the
+			// bus directory and nested under the port they attach to
+			busDir := filepath.Join(testDir, "bus")
+			portDir := filepath.Join(testDir, "devices", portAddr)
+			if err := os.MkdirAll(busDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if tc.portAttrs != nil {
+				writePciLinkAttrs(t, filepath.Dir(portDir), portAddr, tc.portAttrs)
+			}
+			if tc.attrs != nil {
+				writePciLinkAttrs(t, portDir, pciAddr, tc.attrs)
+				if err := os.Symlink(filepath.Join(portDir, pciAddr),
+					filepath.Join(busDir, pciAddr)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotLink, gotErr := getPciLink(busDir, pciAddr)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expLink, gotLink); diff != "" {
+				t.Fatalf("unexpected link (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expDegraded, gotLink.Degraded(), "unexpected degraded state")
+		})
+	}
+}
+
+func TestBdev_NvmePciLink_String(t *testing.T) {
+	for name, tc := range map[string]struct {
+		link   *storage.NvmePciLink
+		expStr string
+	}{
+		"nil": {
+			expStr: "N/A",
+		},
+		"full width and speed": {
+			link:   &storage.NvmePciLink{Speed: 8, Width: 4, MaxSpeed: 8, MaxWidth: 4},
+			expStr: "x4 8GT/s",
+		},
+		"degraded width": {
+			link:   &storage.NvmePciLink{Speed: 8, Width: 1, MaxSpeed: 8, MaxWidth: 4},
+			expStr: "x1 8GT/s (degraded, x4 8GT/s capable)",
+		},
+		"degraded speed": {
+			link:   &storage.NvmePciLink{Speed: 2.5, Width: 4, MaxSpeed: 8, MaxWidth: 4},
+			expStr: "x4 2.5GT/s (degraded, x4 8GT/s capable)",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expStr, tc.link.String(), "unexpected link string")
+		})
+	}
+}
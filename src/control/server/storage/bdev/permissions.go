@@ -0,0 +1,251 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+const (
+	vfioDevDir    = "/dev/vfio"
+	vfioGroupName = "vfio"
+	daosGroupName = "daos_server"
+	limitsDir     = "/etc/security/limits.d"
+	limitsFileFmt = "99-daos_server-%s.conf"
+	groupRWPerms  = 0060
+	groupRWXPerms = 0070
+	memlockLimit  = "unlimited"
+)
+
+// permissionsSetup grants a non-root user the access required to run SPDK:
+// membership of the group owning the VFIO device nodes, membership of the
+// daos_server group granted access to the hugetlbfs mount point and an
+// unlimited locked memory limit. Each step
+// inspects the current state first so that applying it repeatedly only
+// changes what is missing.
+type permissionsSetup struct {
+	log         logging.Logger
+	vfioDir     string
+	hugePageDir string
+	limitsDir   string
+	lookupGroup func(string) (*user.Group, error)
+	groupIds    func(*user.User) ([]string, error)
+	runCmd      func(string, ...string) error
+}
+
+func runSetupCmd(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "%s: %s", name, out)
+	}
+	return nil
+}
+
+func newPermissionsSetup(log logging.Logger) *permissionsSetup {
+	return &permissionsSetup{
+		log:         log,
+		vfioDir:     vfioDevDir,
+		hugePageDir: hugePageDir,
+		limitsDir:   limitsDir,
+		lookupGroup: user.LookupGroup,
+		groupIds:    (*user.User).GroupIds,
+		runCmd:      runSetupCmd,
+	}
+}
+
+// apply sets up the permissions required by the target user and returns a
+// description of each change made. VFIO group setup is skipped if the VFIO
+// driver is not to be used.
+func (ps *permissionsSetup) apply(usr *user.User, withVFIO bool) ([]string, error) {
+	var changes []string
+
+	if usr.Uid == "0" {
+		ps.log.Debug("target user is root, skipping permissions setup")
+		return changes, nil
+	}
+
+	if withVFIO {
+		grpChanges, err := ps.setupVFIOGroup(usr)
+		if err != nil {
+			return nil, errors.Wrap(err, "set up vfio group")
+		}
+		changes = append(changes, grpChanges...)
+	}
+
+	hpChanges, err := ps.setupHugePageGroup(usr)
+	if err != nil {
+		return nil, errors.Wrap(err, "set hugetlbfs mount permissions")
+	}
+	changes = append(changes, hpChanges...)
+
+	change, err := ps.setupMemlockLimit(usr)
+	if err != nil {
+		return nil, errors.Wrap(err, "set memlock limit")
+	}
+	if change != "" {
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// joinGroup ensures that the named group exists and that the user is a
+// member of it.
+func (ps *permissionsSetup) joinGroup(usr *user.User, name string) (*user.Group, []string, error) {
+	var changes []string
+
+	grp, err := ps.lookupGroup(name)
+	if _, unknown := err.(user.UnknownGroupError); unknown {
+		if err := ps.runCmd("groupadd", "--system", name); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("created group %s", name))
+		grp, err = ps.lookupGroup(name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gids, err := ps.groupIds(usr)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "get groups of user %s", usr.Username)
+	}
+	isMember := false
+	for _, gid := range gids {
+		if gid == grp.Gid {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		if err := ps.runCmd("usermod", "--append", "--groups", grp.Name, usr.Username); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("added user %s to group %s (takes effect on next login)",
+			usr.Username, grp.Name))
+	}
+
+	return grp, changes, nil
+}
+
+// setupVFIOGroup ensures that the user is a member of the vfio group and that
+// the VFIO device nodes are accessible by the group.
+func (ps *permissionsSetup) setupVFIOGroup(usr *user.User) ([]string, error) {
+	grp, changes, err := ps.joinGroup(usr, vfioGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	gid, err := strconv.Atoi(grp.Gid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse gid of group %s", grp.Name)
+	}
+	nodes, err := filepath.Glob(filepath.Join(ps.vfioDir, "*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		change, err := setGroupAccess(node, gid, groupRWPerms)
+		if err != nil {
+			return nil, err
+		}
+		if change != "" {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// setupHugePageGroup ensures that the user is a member of the daos_server
+// group and that the hugetlbfs mount point is accessible by the group. The
+// dedicated group is used rather than the primary group of the user so that
+// access isn't granted to unrelated users sharing that group.
+func (ps *permissionsSetup) setupHugePageGroup(usr *user.User) ([]string, error) {
+	grp, changes, err := ps.joinGroup(usr, daosGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	gid, err := strconv.Atoi(grp.Gid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse gid of group %s", grp.Name)
+	}
+	change, err := setGroupAccess(ps.hugePageDir, gid, groupRWXPerms)
+	if err != nil {
+		return nil, err
+	}
+	if change != "" {
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// setGroupAccess sets the group owner of path to gid and adds the given group
+// permission bits to its mode, returning a description of the change or an
+// empty string if none was needed.
+func setGroupAccess(path string, gid int, perms os.FileMode) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", errors.Errorf("unable to get owner of %s", path)
+	}
+
+	curMode := fi.Mode().Perm() | (fi.Mode() & os.ModeSticky)
+	newMode := curMode | perms
+	if int(st.Gid) == gid && newMode == curMode {
+		return "", nil
+	}
+
+	if err := os.Chown(path, -1, gid); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(path, newMode); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("set group of %s to %d and mode %04o->%04o", path, gid,
+		curMode.Perm(), newMode.Perm()), nil
+}
+
+// setupMemlockLimit writes a limits.d entry allowing the user to lock an
+// unlimited amount of memory, which SPDK requires for DMA buffers.
+func (ps *permissionsSetup) setupMemlockLimit(usr *user.User) (string, error) {
+	path := filepath.Join(ps.limitsDir, fmt.Sprintf(limitsFileFmt, usr.Username))
+	content := fmt.Sprintf("# written by daos_server storage prepare\n"+
+		"%[1]s soft memlock %[2]s\n%[1]s hard memlock %[2]s\n", usr.Username, memlockLimit)
+
+	cur, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil && string(cur) == content:
+		return "", nil
+	case err != nil && !os.IsNotExist(err):
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("wrote memlock limit for user %s to %s (takes effect on next login)",
+		usr.Username, path), nil
+}
@@ -0,0 +1,185 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package bdev
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestBdev_permissionsSetup_apply(t *testing.T) {
+	gid := strconv.Itoa(os.Getgid())
+	testUser := &user.User{Uid: "1000", Gid: gid, Username: "daos"}
+
+	for name, tc := range map[string]struct {
+		usr         *user.User
+		withVFIO    bool
+		groupExists bool
+		memberOf    []string
+		cmdErr      error
+		expCmds     []string
+		expChanges  []string
+		expErr      error
+	}{
+		"root user": {
+			usr:      &user.User{Uid: "0", Gid: "0", Username: "root"},
+			withVFIO: true,
+		},
+		"all changes needed": {
+			usr:      testUser,
+			withVFIO: true,
+			expCmds: []string{
+				"groupadd --system vfio",
+				"usermod --append --groups vfio daos",
+				"groupadd --system daos_server",
+				"usermod --append --groups daos_server daos",
+			},
+			expChanges: []string{
+				"created group vfio",
+				"added user daos to group vfio",
+				"vfio/0 to " + gid + " and mode 0600->0660",
+				"vfio/vfio to " + gid + " and mode 0600->0660",
+				"created group daos_server",
+				"added user daos to group daos_server",
+				"hugepages to " + gid + " and mode 0755->0775",
+				"wrote memlock limit for user daos",
+			},
+		},
+		"vfio disabled": {
+			usr: testUser,
+			expCmds: []string{
+				"groupadd --system daos_server",
+				"usermod --append --groups daos_server daos",
+			},
+			expChanges: []string{
+				"created group daos_server",
+				"added user daos to group daos_server",
+				"hugepages to " + gid + " and mode 0755->0775",
+				"wrote memlock limit for user daos",
+			},
+		},
+		"already a member": {
+			usr:         testUser,
+			withVFIO:    true,
+			groupExists: true,
+			memberOf:    []string{gid},
+			expChanges: []string{
+				"vfio/0 to " + gid + " and mode 0600->0660",
+				"vfio/vfio to " + gid + " and mode 0600->0660",
+				"hugepages to " + gid + " and mode 0755->0775",
+				"wrote memlock limit for user daos",
+			},
+		},
+		"groupadd fails": {
+			usr:      testUser,
+			withVFIO: true,
+			cmdErr:   errors.New("groupadd failed"),
+			expCmds:  []string{"groupadd --system vfio"},
+			expErr:   errors.New("groupadd failed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			ps := newPermissionsSetup(log)
+			ps.vfioDir = filepath.Join(testDir, "vfio")
+			ps.hugePageDir = filepath.Join(testDir, "hugepages")
+			ps.limitsDir = filepath.Join(testDir, "limits.d")
+			for _, dir := range []string{ps.vfioDir, ps.hugePageDir, ps.limitsDir} {
+				if err := os.Mkdir(dir, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, node := range []string{"vfio", "0"} {
+				if err := ioutil.WriteFile(filepath.Join(ps.vfioDir, node), nil, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			groupExists := map[string]bool{
+				vfioGroupName: tc.groupExists,
+				daosGroupName: tc.groupExists,
+			}
+			ps.lookupGroup = func(name string) (*user.Group, error) {
+				if !groupExists[name] {
+					return nil, user.UnknownGroupError(name)
+				}
+				return &user.Group{Gid: gid, Name: name}, nil
+			}
+			ps.groupIds = func(*user.User) ([]string, error) {
+				return tc.memberOf, nil
+			}
+			var gotCmds []string
+			ps.runCmd = func(name string, args ...string) error {
+				gotCmds = append(gotCmds, strings.Join(append([]string{name}, args...), " "))
+				if tc.cmdErr != nil {
+					return tc.cmdErr
+				}
+				if name == "groupadd" {
+					groupExists[args[len(args)-1]] = true
+				}
+				return nil
+			}
+
+			gotChanges, gotErr := ps.apply(tc.usr, tc.withVFIO)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expCmds, gotCmds); diff != "" {
+				t.Fatalf("unexpected commands (-want, +got):\n%s\n", diff)
+			}
+			if tc.expErr != nil {
+				return
+			}
+
+			if len(gotChanges) != len(tc.expChanges) {
+				t.Fatalf("expected %d changes, got %v", len(tc.expChanges), gotChanges)
+			}
+			for i, exp := range tc.expChanges {
+				if !strings.Contains(gotChanges[i], exp) {
+					t.Fatalf("expected change %d to contain %q, got %q", i, exp, gotChanges[i])
+				}
+			}
+			if len(tc.expChanges) == 0 {
+				return
+			}
+
+			limits, err := ioutil.ReadFile(filepath.Join(ps.limitsDir,
+				fmt.Sprintf(limitsFileFmt, tc.usr.Username)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(limits), "daos hard memlock unlimited") {
+				t.Fatalf("unexpected limits file content %q", limits)
+			}
+
+			// applying again should make no further changes
+			tc.memberOf = []string{gid}
+			gotChanges, err = ps.apply(tc.usr, tc.withVFIO)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(gotChanges) != 0 {
+				t.Fatalf("expected no changes on reapply, got %v", gotChanges)
+			}
+		})
+	}
+}
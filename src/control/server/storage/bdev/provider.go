@@ -9,6 +9,7 @@ package bdev
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -26,6 +27,9 @@ type (
 		DeviceList []string
 		DisableVMD bool
 		NoCache    bool
+		// KernelBound includes controllers bound to the kernel NVMe
+		// driver, which SPDK cannot discover, in the response.
+		KernelBound bool
 	}
 
 	// ScanResponse contains information gleaned during a successful Scan operation.
@@ -38,17 +42,32 @@ type (
 		pbin.ForwardableRequest
 		HugePageCount         int
 		DisableCleanHugePages bool
+		CompactHugePages      bool
 		PCIAllowlist          string
 		PCIBlocklist          string
 		TargetUser            string
 		ResetOnly             bool
 		DisableVFIO           bool
 		DisableVMD            bool
+		// SetupPermissions grants TargetUser the group memberships,
+		// hugetlbfs access and memlock limit needed to run SPDK.
+		SetupPermissions bool
+		// HugeNodePages maps NUMA nodes to the number of hugepages to
+		// allocate on each, nodes not listed are left without hugepages.
+		// HugePageCount should be the total of the per-node counts.
+		HugeNodePages map[int]int
 	}
 
 	// PrepareResponse contains the results of a successful Prepare operation.
 	PrepareResponse struct {
 		VmdDetected bool
+		// PermissionChanges describes each change made when setting up
+		// permissions for the target user.
+		PermissionChanges []string
+		// HugePageSizeKb and HugeNodeAllocs report the outcome of a
+		// per-node hugepage allocation.
+		HugePageSizeKb int
+		HugeNodeAllocs []*HugeNodeAlloc
 	}
 
 	// FormatRequest defines the parameters for a Format operation.
@@ -58,6 +77,20 @@ type (
 		DeviceList []string
 		MemSize    int // size MiB memory to be used by SPDK proc
 		DisableVMD bool
+		// Force format of devices claimed by running engines or processes.
+		Force bool
+		// EngineClaims maps PCI addresses of devices claimed by running
+		// engines to a description of the claimant.
+		EngineClaims map[string]string
+		// AllowZoned permits format of devices with zoned (ZNS)
+		// namespaces, the zones of which are reset.
+		AllowZoned bool
+		// LbaFormats maps PCI addresses of devices to the LBA format
+		// their namespaces should be reformatted to before use.
+		LbaFormats map[string]*storage.LbaFormatSpec
+		// Reformat maps PCI addresses of devices to the index of the
+		// LBA format to be applied, as selected by the provider.
+		Reformat map[string]uint32
 	}
 
 	// DeviceFormatRequest designs the parameters for a device-specific format.
@@ -79,6 +112,27 @@ type (
 	// FormatResponse contains the results of a Format operation.
 	FormatResponse struct {
 		DeviceResponses DeviceFormatResponses
+		// InitDuration is the time taken to initialize the SPDK
+		// environment before the devices were formatted.
+		InitDuration time.Duration
+	}
+
+	// VerifyRequest defines the parameters for a Verify operation, which
+	// reads a range of LBAs from a namespace of an NVMe device.
+	VerifyRequest struct {
+		pbin.ForwardableRequest
+		PciAddr    string
+		NsID       uint32
+		StartLBA   uint64
+		NumLBAs    uint64
+		DisableVMD bool
+	}
+
+	// VerifyResponse contains the results of a Verify operation.
+	VerifyResponse struct {
+		// BadLBAs lists the LBAs in the requested range that could
+		// not be read.
+		BadLBAs []uint64
 	}
 
 	// Backend defines a set of methods to be implemented by a Block Device backend.
@@ -89,7 +143,9 @@ type (
 		Format(FormatRequest) (*FormatResponse, error)
 		DisableVMD()
 		IsVMDDisabled() bool
+		Verify(VerifyRequest) (*VerifyResponse, error)
 		UpdateFirmware(pciAddr string, path string, slot int32) error
+		Capabilities() *storage.BdevCapabilities
 	}
 
 	// Provider encapsulates configuration and logic for interacting with a Block
@@ -97,24 +153,36 @@ type (
 	Provider struct {
 		sync.Mutex // ensure mutually exclusive access to scan cache
 		firmwareProvider
-		log       logging.Logger
-		backend   Backend
-		fwd       *Forwarder
-		scanCache *ScanResponse
+		log          logging.Logger
+		backend      Backend
+		fwd          *Forwarder
+		scanCache    *ScanResponse
+		getLockOwner func(string) (int, error)
+		isPidAlive   func(int) bool
+		getBindings  func() (storage.NvmeBindings, error)
+		getKernel    func() (storage.NvmeControllers, error)
 	}
 )
 
 // DefaultProvider returns an initialized *Provider suitable for use in production code.
 func DefaultProvider(log logging.Logger) *Provider {
-	return NewProvider(log, defaultBackend(log))
+	return NewProvider(log, withFaultInjection(log, defaultBackend(log)))
 }
 
 // NewProvider returns an initialized *Provider.
 func NewProvider(log logging.Logger, backend Backend) *Provider {
 	p := &Provider{
-		log:     log,
-		backend: backend,
-		fwd:     NewForwarder(log),
+		log:          log,
+		backend:      backend,
+		fwd:          NewForwarder(log),
+		getLockOwner: spdkLockOwner,
+		isPidAlive:   pidAlive,
+		getBindings: func() (storage.NvmeBindings, error) {
+			return readNvmeBindings(pciDevicesPath)
+		},
+		getKernel: func() (storage.NvmeControllers, error) {
+			return readKernelNvmeControllers(pciDevicesPath)
+		},
 	}
 	p.setupFirmwareProvider(log)
 	return p
@@ -139,6 +207,18 @@ func (p *Provider) IsVMDDisabled() bool {
 	return p.backend.IsVMDDisabled()
 }
 
+// Capabilities returns the versions and supported features of the SPDK
+// library used to access NVMe SSDs.
+func (p *Provider) Capabilities() *storage.BdevCapabilities {
+	return p.backend.Capabilities()
+}
+
+// Bindings returns the drivers that the NVMe SSDs on the host are currently
+// bound to. No privileges are required so the request is not forwarded.
+func (p *Provider) Bindings() (storage.NvmeBindings, error) {
+	return p.getBindings()
+}
+
 func (resp *ScanResponse) filter(pciFilter ...string) (int, *ScanResponse) {
 	var skipped int
 	out := make(storage.NvmeControllers, 0)
@@ -206,7 +286,35 @@ func forwardScan(req ScanRequest, cache *ScanResponse, scan scanFwdFn) (msg stri
 // system. Results will be cached at the provider and returned if
 // "NoCache" is set to "false" in the request. Returned results will be
 // filtered by request "DeviceList" and empty filter implies allowing all.
-func (p *Provider) Scan(req ScanRequest) (resp *ScanResponse, err error) {
+//
+// If "KernelBound" is set in the request, controllers bound to the kernel NVMe
+// driver are read from sysfs and added to the results. They are never cached
+// as their binding changes when storage is prepared.
+func (p *Provider) Scan(req ScanRequest) (*ScanResponse, error) {
+	resp, err := p.scan(req)
+	if err != nil || !req.KernelBound || req.IsForwarded() {
+		return resp, err
+	}
+
+	kernelCtrlrs, kErr := p.getKernel()
+	if kErr != nil {
+		p.log.Debugf("bdev scan: reading kernel-bound devices: %s", kErr)
+		return resp, nil
+	}
+
+	ctrlrs := make(storage.NvmeControllers, 0, len(resp.Controllers)+len(kernelCtrlrs))
+	ctrlrs = append(ctrlrs, resp.Controllers...)
+	for _, kc := range kernelCtrlrs {
+		if len(req.DeviceList) != 0 && !common.Includes(req.DeviceList, kc.PciAddr) {
+			continue
+		}
+		ctrlrs = ctrlrs.Update(kc)
+	}
+
+	return &ScanResponse{Controllers: ctrlrs}, nil
+}
+
+func (p *Provider) scan(req ScanRequest) (resp *ScanResponse, err error) {
 	if p.shouldForward(req) {
 		req.DisableVMD = p.IsVMDDisabled()
 
@@ -259,13 +367,45 @@ func (p *Provider) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 
 // Format attempts to initialize NVMe devices for use by DAOS.
 // Note that this is a no-op for non-NVMe devices.
+//
+// Unless forced, devices claimed by a running engine or by another live
+// process holding the device's SPDK lock will not be formatted. Devices with
+// zoned (ZNS) namespaces will only be formatted if AllowZoned is set.
+//
+// Devices with an LBA format requested are verified to support it and their
+// namespaces are reformatted to it if not already in use.
 func (p *Provider) Format(req FormatRequest) (*FormatResponse, error) {
 	if len(req.DeviceList) == 0 {
 		return nil, errors.New("empty DeviceList in FormatRequest")
 	}
 
+	if !req.Force && !req.IsForwarded() && req.Class == storage.BdevClassNvme {
+		if err := p.checkDeviceClaims(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if !req.AllowZoned && !req.IsForwarded() && req.Class == storage.BdevClassNvme {
+		if err := p.checkZonedDevices(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.LbaFormats) > 0 && !req.IsForwarded() && req.Class == storage.BdevClassNvme {
+		var err error
+		if req.Reformat, err = p.selectLbaFormats(req); err != nil {
+			return nil, err
+		}
+	}
+
 	if p.shouldForward(req) {
 		req.DisableVMD = p.IsVMDDisabled()
+		if len(req.Reformat) > 0 {
+			// cached namespace details are stale after reformat
+			p.Lock()
+			p.scanCache = nil
+			p.Unlock()
+		}
 		return p.fwd.Format(req)
 	}
 	// set vmd state on remote provider in forwarded request
@@ -275,3 +415,73 @@ func (p *Provider) Format(req FormatRequest) (*FormatResponse, error) {
 
 	return p.backend.Format(req)
 }
+
+// Verify reads a range of LBAs from a namespace of an NVMe device to confirm
+// that the media is readable, reporting any LBAs that could not be read. The
+// range is truncated at the end of the namespace.
+func (p *Provider) Verify(req VerifyRequest) (*VerifyResponse, error) {
+	if p.shouldForward(req) {
+		req.DisableVMD = p.IsVMDDisabled()
+		return p.fwd.Verify(req)
+	}
+	// set vmd state on remote provider in forwarded request
+	if req.IsForwarded() && req.DisableVMD {
+		p.disableVMD()
+	}
+
+	return p.backend.Verify(req)
+}
+
+// checkZonedDevices returns a fault if any of the devices in the request have
+// zoned namespaces. Zoned namespaces can only be written sequentially so the
+// format would otherwise fail when wiping the device.
+func (p *Provider) checkZonedDevices(req FormatRequest) error {
+	resp, err := p.Scan(ScanRequest{DeviceList: req.DeviceList})
+	if err != nil {
+		p.log.Debugf("unable to check for zoned namespaces: %s", err)
+		return nil
+	}
+
+	for _, ctrlr := range resp.Controllers {
+		if ctrlr.Zoned() {
+			return FaultFormatZonedNamespace(ctrlr.PciAddr)
+		}
+	}
+
+	return nil
+}
+
+// selectLbaFormats verifies through the namespace identify data of each
+// device with a requested LBA format that the format is supported, and
+// returns the index of the format to be applied to each device whose
+// namespaces are not already using it.
+func (p *Provider) selectLbaFormats(req FormatRequest) (map[string]uint32, error) {
+	resp, err := p.Scan(ScanRequest{DeviceList: req.DeviceList, NoCache: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to verify LBA format support")
+	}
+
+	reformat := make(map[string]uint32)
+	for _, ctrlr := range resp.Controllers {
+		spec, exists := req.LbaFormats[ctrlr.PciAddr]
+		if !exists {
+			continue
+		}
+
+		for _, ns := range ctrlr.Namespaces {
+			lbaf := spec.Select(ns)
+			if lbaf == nil {
+				return nil, FaultFormatLbaUnsupported(ctrlr.PciAddr, spec)
+			}
+			if lbaf.Index == ns.LbaFormat {
+				continue
+			}
+
+			p.log.Infof("NVMe device %s namespace %d will be reformatted to %s",
+				ctrlr.PciAddr, ns.ID, lbaf)
+			reformat[ctrlr.PciAddr] = lbaf.Index
+		}
+	}
+
+	return reformat, nil
+}
@@ -212,6 +212,9 @@ func TestBdevScan(t *testing.T) {
 	ctrlr1 := storage.MockNvmeController(1)
 	ctrlr2 := storage.MockNvmeController(2)
 	ctrlr3 := storage.MockNvmeController(3)
+	kernelCtrlr := storage.MockNvmeController(4)
+	kernelCtrlr.Driver = storage.NvmeKernelDriver
+	kernelCtrlr.BindState = storage.NvmeBindStateKernel
 
 	for name, tc := range map[string]struct {
 		req            ScanRequest
@@ -278,6 +281,61 @@ func TestBdevScan(t *testing.T) {
 			},
 			expErr: errors.New("scan failed"),
 		},
+		"kernel-bound devices not requested": {
+			req: ScanRequest{},
+			mbc: &MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{ctrlr1},
+				},
+				KernelCtrlrs: storage.NvmeControllers{kernelCtrlr},
+			},
+			expRes: &ScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr1},
+			},
+			expVMDDisabled: true,
+		},
+		"kernel-bound devices requested": {
+			req: ScanRequest{KernelBound: true},
+			mbc: &MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{ctrlr1},
+				},
+				KernelCtrlrs: storage.NvmeControllers{kernelCtrlr},
+			},
+			expRes: &ScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr1, kernelCtrlr},
+			},
+			expVMDDisabled: true,
+		},
+		"kernel-bound devices filtered by device list": {
+			req: ScanRequest{
+				KernelBound: true,
+				DeviceList:  []string{ctrlr1.PciAddr},
+			},
+			mbc: &MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{ctrlr1},
+				},
+				KernelCtrlrs: storage.NvmeControllers{kernelCtrlr},
+			},
+			expRes: &ScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr1},
+			},
+			expVMDDisabled: true,
+		},
+		"kernel-bound devices read failure": {
+			req: ScanRequest{KernelBound: true},
+			mbc: &MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{ctrlr1},
+				},
+				KernelCtrlrsErr: errors.New("sysfs read failed"),
+			},
+			expRes: &ScanResponse{
+				Controllers: storage.NvmeControllers{ctrlr1},
+			},
+			expVMDDisabled: true,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(name)
@@ -359,17 +417,164 @@ func TestBdevPrepare(t *testing.T) {
 
 func TestBdevFormat(t *testing.T) {
 	mockSingle := storage.MockNvmeController()
+	mockZoned := storage.MockNvmeController()
+	mockZoned.Namespaces[0].Zoned = true
+	mockLbaf := storage.MockNvmeController()
+	mockLbaf.Namespaces[0].LbaFormats = []*storage.NvmeLbaFormat{
+		{Index: 0, DataSize: 512, RelPerf: 2},
+		{Index: 1, DataSize: 4096},
+	}
+
+	mockFormatRes := &FormatResponse{
+		DeviceResponses: DeviceFormatResponses{
+			mockSingle.PciAddr: &DeviceFormatResponse{
+				Formatted: true,
+			},
+		},
+	}
 
 	for name, tc := range map[string]struct {
-		req    FormatRequest
-		mbc    *MockBackendConfig
-		expRes *FormatResponse
-		expErr error
+		req        FormatRequest
+		mbc        *MockBackendConfig
+		lockOwner  int
+		ownerAlive bool
+		expRes     *FormatResponse
+		expErr     error
 	}{
 		"empty input": {
 			req:    FormatRequest{},
 			expErr: errors.New("empty DeviceList"),
 		},
+		"NVMe claimed by running engine": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+				EngineClaims: map[string]string{
+					mockSingle.PciAddr: "running engine 0",
+				},
+			},
+			mbc:    &MockBackendConfig{FormatRes: mockFormatRes},
+			expErr: FaultFormatDeviceInUse(mockSingle.PciAddr, "running engine 0"),
+		},
+		"NVMe claimed by running engine; forced": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+				EngineClaims: map[string]string{
+					mockSingle.PciAddr: "running engine 0",
+				},
+				Force: true,
+			},
+			mbc:    &MockBackendConfig{FormatRes: mockFormatRes},
+			expRes: mockFormatRes,
+		},
+		"NVMe locked by live process": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+			},
+			mbc:        &MockBackendConfig{FormatRes: mockFormatRes},
+			lockOwner:  4242,
+			ownerAlive: true,
+			expErr:     FaultFormatDeviceInUse(mockSingle.PciAddr, "process 4242"),
+		},
+		"NVMe locked by live process; forced": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+				Force:      true,
+			},
+			mbc:        &MockBackendConfig{FormatRes: mockFormatRes},
+			lockOwner:  4242,
+			ownerAlive: true,
+			expRes:     mockFormatRes,
+		},
+		"NVMe locked by dead process": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+			},
+			mbc:       &MockBackendConfig{FormatRes: mockFormatRes},
+			lockOwner: 4242,
+			expRes:    mockFormatRes,
+		},
+		"NVMe with zoned namespace": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockZoned.PciAddr},
+			},
+			mbc: &MockBackendConfig{
+				ScanRes:   &ScanResponse{Controllers: storage.NvmeControllers{mockZoned}},
+				FormatRes: mockFormatRes,
+			},
+			expErr: FaultFormatZonedNamespace(mockZoned.PciAddr),
+		},
+		"NVMe with zoned namespace; allowed": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockZoned.PciAddr},
+				AllowZoned: true,
+			},
+			mbc: &MockBackendConfig{
+				ScanRes:   &ScanResponse{Controllers: storage.NvmeControllers{mockZoned}},
+				FormatRes: mockFormatRes,
+			},
+			expRes: mockFormatRes,
+		},
+		"NVMe scan fails; zoned check skipped": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockSingle.PciAddr},
+			},
+			mbc: &MockBackendConfig{
+				ScanErr:   errors.New("scan failed"),
+				FormatRes: mockFormatRes,
+			},
+			expRes: mockFormatRes,
+		},
+		"NVMe with unsupported LBA format": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockLbaf.PciAddr},
+				LbaFormats: map[string]*storage.LbaFormatSpec{
+					mockLbaf.PciAddr: {DataSize: 4096, MetaSize: 8},
+				},
+			},
+			mbc: &MockBackendConfig{
+				ScanRes:   &ScanResponse{Controllers: storage.NvmeControllers{mockLbaf}},
+				FormatRes: mockFormatRes,
+			},
+			expErr: FaultFormatLbaUnsupported(mockLbaf.PciAddr,
+				&storage.LbaFormatSpec{DataSize: 4096, MetaSize: 8}),
+		},
+		"NVMe with LBA format; scan fails": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockLbaf.PciAddr},
+				LbaFormats: map[string]*storage.LbaFormatSpec{
+					mockLbaf.PciAddr: {BestPerformance: true},
+				},
+			},
+			mbc: &MockBackendConfig{
+				ScanErr:   errors.New("scan failed"),
+				FormatRes: mockFormatRes,
+			},
+			expErr: errors.New("unable to verify LBA format support"),
+		},
+		"NVMe with supported LBA format": {
+			req: FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{mockLbaf.PciAddr},
+				LbaFormats: map[string]*storage.LbaFormatSpec{
+					mockLbaf.PciAddr: {BestPerformance: true},
+				},
+			},
+			mbc: &MockBackendConfig{
+				ScanRes:   &ScanResponse{Controllers: storage.NvmeControllers{mockLbaf}},
+				FormatRes: mockFormatRes,
+			},
+			expRes: mockFormatRes,
+		},
 		"NVMe success": {
 			req: FormatRequest{
 				Class:      storage.BdevClassNvme,
@@ -398,6 +603,8 @@ func TestBdevFormat(t *testing.T) {
 			defer common.ShowBufferOnFailure(t, buf)
 
 			p := NewMockProvider(log, tc.mbc)
+			p.getLockOwner = func(_ string) (int, error) { return tc.lockOwner, nil }
+			p.isPidAlive = func(_ int) bool { return tc.ownerAlive }
 
 			gotRes, gotErr := p.Format(tc.req)
 			common.CmpErr(t, tc.expErr, gotErr)
@@ -415,3 +622,88 @@ func TestBdevFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestBdevProvider_selectLbaFormats(t *testing.T) {
+	mockNs := func(current uint32) *storage.NvmeNamespace {
+		return &storage.NvmeNamespace{
+			ID:        1,
+			LbaFormat: current,
+			LbaFormats: []*storage.NvmeLbaFormat{
+				{Index: 0, DataSize: 512, RelPerf: 2},
+				{Index: 1, DataSize: 512, MetaSize: 8, RelPerf: 2},
+				{Index: 2, DataSize: 4096},
+				{Index: 3, DataSize: 4096, MetaSize: 8},
+			},
+		}
+	}
+	mockCtrlr := func(idx int32, current uint32) *storage.NvmeController {
+		ctrlr := storage.MockNvmeController(idx)
+		ctrlr.Namespaces = []*storage.NvmeNamespace{mockNs(current)}
+		return ctrlr
+	}
+	ctrlr1 := mockCtrlr(1, 0)
+	ctrlr2 := mockCtrlr(2, 2)
+	ctrlr3 := mockCtrlr(3, 0)
+
+	for name, tc := range map[string]struct {
+		lbaFormats  map[string]string
+		expReformat map[string]uint32
+		expErr      error
+	}{
+		"best performance": {
+			lbaFormats: map[string]string{
+				ctrlr1.PciAddr: "best-performance",
+				ctrlr2.PciAddr: "best-performance",
+			},
+			expReformat: map[string]uint32{ctrlr1.PciAddr: 2},
+		},
+		"explicit formats": {
+			lbaFormats: map[string]string{
+				ctrlr1.PciAddr: "512e",
+				ctrlr2.PciAddr: "4096+8",
+			},
+			expReformat: map[string]uint32{ctrlr2.PciAddr: 3},
+		},
+		"unsupported format": {
+			lbaFormats: map[string]string{
+				ctrlr3.PciAddr: "4096+16",
+			},
+			expErr: FaultFormatLbaUnsupported(ctrlr3.PciAddr,
+				&storage.LbaFormatSpec{DataSize: 4096, MetaSize: 16}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(name)
+			defer common.ShowBufferOnFailure(t, buf)
+
+			p := NewMockProvider(log, &MockBackendConfig{
+				ScanRes: &ScanResponse{
+					Controllers: storage.NvmeControllers{ctrlr1, ctrlr2, ctrlr3},
+				},
+			})
+
+			req := FormatRequest{
+				Class:      storage.BdevClassNvme,
+				DeviceList: []string{ctrlr1.PciAddr, ctrlr2.PciAddr, ctrlr3.PciAddr},
+				LbaFormats: make(map[string]*storage.LbaFormatSpec),
+			}
+			for addr, lbaFormat := range tc.lbaFormats {
+				spec, err := storage.ParseLbaFormatSpec(lbaFormat)
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.LbaFormats[addr] = spec
+			}
+
+			gotReformat, gotErr := p.selectLbaFormats(req)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expReformat, gotReformat); diff != "" {
+				t.Fatalf("unexpected reformat (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
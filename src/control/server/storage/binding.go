@@ -0,0 +1,105 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+// NvmeBindState describes which drivers the NVMe SSDs on a host are bound to.
+type NvmeBindState string
+
+const (
+	// NvmeBindStateUnknown indicates that no NVMe SSDs were found or that
+	// their driver binding could not be determined.
+	NvmeBindStateUnknown NvmeBindState = "unknown"
+	// NvmeBindStateKernel indicates that all NVMe SSDs are bound to the
+	// kernel NVMe driver, the state after a reset.
+	NvmeBindStateKernel NvmeBindState = "kernel-bound"
+	// NvmeBindStateSpdk indicates that all NVMe SSDs are bound to a
+	// userspace driver for use with SPDK, the state after a prepare.
+	NvmeBindStateSpdk NvmeBindState = "spdk-bound"
+	// NvmeBindStatePartial indicates that only some of the NVMe SSDs are
+	// bound to a userspace driver, e.g. after a prepare with an allow list
+	// or an interrupted prepare or reset.
+	NvmeBindStatePartial NvmeBindState = "partially-bound"
+)
+
+// NvmeKernelDriver is the kernel driver bound to NVMe SSDs not in use by SPDK.
+const NvmeKernelDriver = "nvme"
+
+// spdkDrivers are the userspace drivers that NVMe SSDs are bound to by prepare.
+var spdkDrivers = map[string]bool{
+	"vfio-pci":        true,
+	"uio_pci_generic": true,
+	"igb_uio":         true,
+}
+
+// NvmeBindStateFromDriver returns the binding state of a device bound to the
+// given driver.
+func NvmeBindStateFromDriver(driver string) NvmeBindState {
+	switch {
+	case driver == NvmeKernelDriver:
+		return NvmeBindStateKernel
+	case spdkDrivers[driver]:
+		return NvmeBindStateSpdk
+	default:
+		return NvmeBindStateUnknown
+	}
+}
+
+type (
+	// NvmeBinding describes the driver an NVMe SSD is bound to.
+	NvmeBinding struct {
+		PciAddr string        `json:"pci_addr"`
+		Driver  string        `json:"driver"`
+		State   NvmeBindState `json:"state"`
+	}
+
+	// NvmeBindings is a type alias for []*NvmeBinding.
+	NvmeBindings []*NvmeBinding
+
+	// NvmePrepareStatus describes the current driver binding of the NVMe
+	// SSDs on a host and the last prepare or reset recorded on the host.
+	NvmePrepareStatus struct {
+		BindState   NvmeBindState `json:"bind_state"`
+		Bindings    NvmeBindings  `json:"bindings"`
+		LastOp      string        `json:"last_op,omitempty"`
+		LastOpTime  uint64        `json:"last_op_time,omitempty"`
+		LastOpError string        `json:"last_op_error,omitempty"`
+	}
+)
+
+// State returns the combined binding state of the devices.
+func (nbs NvmeBindings) State() NvmeBindState {
+	if len(nbs) == 0 {
+		return NvmeBindStateUnknown
+	}
+
+	var kernel, spdk int
+	for _, nb := range nbs {
+		switch nb.State {
+		case NvmeBindStateKernel:
+			kernel++
+		case NvmeBindStateSpdk:
+			spdk++
+		}
+	}
+
+	switch {
+	case kernel == len(nbs):
+		return NvmeBindStateKernel
+	case spdk == len(nbs):
+		return NvmeBindStateSpdk
+	case kernel == 0 && spdk == 0:
+		return NvmeBindStateUnknown
+	default:
+		return NvmeBindStatePartial
+	}
+}
+
+// ResetRequired returns true if any of the NVMe SSDs on the host would need
+// to be returned to the kernel driver by a reset, e.g. before OS maintenance.
+func (nps *NvmePrepareStatus) ResetRequired() bool {
+	return nps.BindState == NvmeBindStateSpdk || nps.BindState == NvmeBindStatePartial
+}
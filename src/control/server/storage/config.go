@@ -7,6 +7,10 @@
 package storage
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/daos-stack/daos/src/control/common"
@@ -28,6 +32,10 @@ const (
 
 const (
 	maxScmDeviceLen = 1
+
+	// controlMetadataDir is created under the control metadata path to
+	// hold the persistent data of the control plane.
+	controlMetadataDir = "daos_control"
 )
 
 // ScmClass definitions.
@@ -69,6 +77,11 @@ type ScmConfig struct {
 	DeviceList  []string `yaml:"scm_list,omitempty"`
 }
 
+func (sc *ScmConfig) isEmpty() bool {
+	return sc.MountPoint == "" && sc.Class == ScmClassNone &&
+		sc.RamdiskSize == 0 && len(sc.DeviceList) == 0
+}
+
 // Validate sanity checks engine scm config parameters.
 func (sc *ScmConfig) Validate() error {
 	if sc.MountPoint == "" {
@@ -136,17 +149,120 @@ func (b BdevClass) String() string {
 	return string(b)
 }
 
-// BdevConfig represents a Block Device (NVMe, etc.) configuration entry.
+// BdevRole definitions.
+const (
+	BdevRoleWAL  BdevRole = "wal"
+	BdevRoleMeta BdevRole = "meta"
+	BdevRoleData BdevRole = "data"
+)
+
+// BdevRole specifies the use of a block device storage tier.
+type BdevRole string
+
+// UnmarshalYAML implements yaml.Unmarshaler on BdevRole type
+func (r *BdevRole) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var role string
+	if err := unmarshal(&role); err != nil {
+		return err
+	}
+
+	bdevRole := BdevRole(role)
+	switch bdevRole {
+	case BdevRoleWAL, BdevRoleMeta, BdevRoleData:
+		*r = bdevRole
+	default:
+		return errors.Errorf("bdev_roles value %q not supported in config (wal/meta/data)", bdevRole)
+	}
+	return nil
+}
+
+func (r BdevRole) String() string {
+	return string(r)
+}
+
+// BdevRoles is a list of the roles assigned to a block device storage tier.
+type BdevRoles []BdevRole
+
+// HasRole returns true if the list contains the given role.
+func (rs BdevRoles) HasRole(role BdevRole) bool {
+	for _, r := range rs {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (rs BdevRoles) String() string {
+	strs := make([]string, len(rs))
+	for i, r := range rs {
+		strs[i] = r.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// BdevConfig represents a Block Device (NVMe, etc.) storage tier configuration.
 type BdevConfig struct {
-	ConfigPath  string    `yaml:"-" cmdLongFlag:"--nvme" cmdShortFlag:"-n"`
 	Class       BdevClass `yaml:"bdev_class,omitempty"`
 	DeviceList  []string  `yaml:"bdev_list,omitempty"`
-	VmdDisabled bool      `yaml:"-"` // set during start-up
 	DeviceCount int       `yaml:"bdev_number,omitempty"`
 	FileSize    int       `yaml:"bdev_size,omitempty"`
-	MemSize     int       `yaml:"-" cmdLongFlag:"--mem_size,nonzero" cmdShortFlag:"-r,nonzero"`
-	VosEnv      string    `yaml:"-" cmdEnv:"VOS_BDEV_CLASS"`
-	Hostname    string    `yaml:"-"` // used when generating templates
+	Roles       BdevRoles `yaml:"bdev_roles,omitempty"`
+	// AllowZoned permits NVMe SSDs with zoned (ZNS) namespaces in
+	// DeviceList, the zones of which are reset when formatting.
+	AllowZoned bool `yaml:"bdev_allow_zoned,omitempty"`
+	// LbaFormat selects the LBA format that the namespaces of NVMe SSDs
+	// in DeviceList are reformatted to when formatting, either
+	// "best-performance" or a data size with optional metadata size.
+	LbaFormat string `yaml:"bdev_lba_format,omitempty"`
+	// LbaFormats overrides LbaFormat for individual SSDs in DeviceList.
+	LbaFormats map[string]string `yaml:"bdev_lba_formats,omitempty"`
+}
+
+func (bc *BdevConfig) isEmpty() bool {
+	return bc.Class == BdevClassNone && len(bc.DeviceList) == 0 &&
+		bc.DeviceCount == 0 && bc.FileSize == 0 && len(bc.Roles) == 0 &&
+		!bc.AllowZoned && bc.LbaFormat == "" && len(bc.LbaFormats) == 0
+}
+
+// lbaFormats returns the LBA format to be used for each device in DeviceList
+// which has one specified, keyed by PCI address.
+func (bc *BdevConfig) lbaFormats() (map[string]*LbaFormatSpec, error) {
+	if bc.LbaFormat == "" && len(bc.LbaFormats) == 0 {
+		return nil, nil
+	}
+	if bc.Class != BdevClassNvme {
+		return nil, errors.Errorf("bdev_lba_format not supported with bdev_class %s", bc.Class)
+	}
+
+	var def *LbaFormatSpec
+	if bc.LbaFormat != "" {
+		var err error
+		if def, err = ParseLbaFormatSpec(bc.LbaFormat); err != nil {
+			return nil, errors.Wrap(err, "bdev_lba_format")
+		}
+	}
+
+	specs := make(map[string]*LbaFormatSpec)
+	for pciAddr, lbaFormat := range bc.LbaFormats {
+		if !common.Includes(bc.DeviceList, pciAddr) {
+			return nil, errors.Errorf("bdev_lba_formats device %s not in bdev_list", pciAddr)
+		}
+		spec, err := ParseLbaFormatSpec(lbaFormat)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bdev_lba_formats device %s", pciAddr)
+		}
+		specs[pciAddr] = spec
+	}
+	if def != nil {
+		for _, pciAddr := range bc.DeviceList {
+			if _, exists := specs[pciAddr]; !exists {
+				specs[pciAddr] = def
+			}
+		}
+	}
+
+	return specs, nil
 }
 
 func (bc *BdevConfig) checkNonZeroFileSize() error {
@@ -163,6 +279,12 @@ func (bc *BdevConfig) Validate() error {
 	if common.StringSliceHasDuplicates(bc.DeviceList) {
 		return errors.New("bdev_list contains duplicate pci addresses")
 	}
+	if bc.AllowZoned && bc.Class != BdevClassNvme {
+		return errors.Errorf("bdev_allow_zoned not supported with bdev_class %s", bc.Class)
+	}
+	if _, err := bc.lbaFormats(); err != nil {
+		return err
+	}
 
 	switch bc.Class {
 	case BdevClassFile:
@@ -202,3 +324,200 @@ func (bc *BdevConfig) GetNvmeDevs() []string {
 
 	return []string{}
 }
+
+// TierConfig represents a single storage tier of an engine, which is either an
+// SCM tier or a block device tier.
+type TierConfig struct {
+	Scm  ScmConfig  `yaml:",inline"`
+	Bdev BdevConfig `yaml:",inline"`
+}
+
+// IsSCM returns true if SCM parameters are set in the tier.
+func (tc *TierConfig) IsSCM() bool {
+	return !tc.Scm.isEmpty()
+}
+
+// IsBdev returns true if block device parameters are set in the tier.
+func (tc *TierConfig) IsBdev() bool {
+	return !tc.Bdev.isEmpty()
+}
+
+// Validate sanity checks the parameters of a storage tier.
+func (tc *TierConfig) Validate() error {
+	switch {
+	case tc.IsSCM() && tc.IsBdev():
+		return errors.New("scm and bdev parameters may not be set in the same tier")
+	case tc.IsSCM():
+		return tc.Scm.Validate()
+	case tc.IsBdev():
+		return tc.Bdev.Validate()
+	default:
+		return errors.New("no scm or bdev parameters set")
+	}
+}
+
+// TierConfigs is an ordered list of storage tiers, from fastest to slowest.
+type TierConfigs []*TierConfig
+
+// ScmConfig returns the configuration of the SCM tier, or nil if there is none.
+func (tcs TierConfigs) ScmConfig() *ScmConfig {
+	for _, tc := range tcs {
+		if tc.IsSCM() {
+			return &tc.Scm
+		}
+	}
+	return nil
+}
+
+// BdevConfigs returns the configurations of the block device tiers in order.
+func (tcs TierConfigs) BdevConfigs() []*BdevConfig {
+	var bcs []*BdevConfig
+	for _, tc := range tcs {
+		if tc.IsBdev() {
+			bcs = append(bcs, &tc.Bdev)
+		}
+	}
+	return bcs
+}
+
+// BdevClass returns the class of the block device tiers, all of which share
+// the same class, or BdevClassNone if there are no block device tiers.
+func (tcs TierConfigs) BdevClass() BdevClass {
+	for _, bc := range tcs.BdevConfigs() {
+		return bc.Class
+	}
+	return BdevClassNone
+}
+
+// BdevDevices returns the devices of all block device tiers in tier order.
+func (tcs TierConfigs) BdevDevices() []string {
+	devices := []string{}
+	for _, bc := range tcs.BdevConfigs() {
+		devices = append(devices, bc.DeviceList...)
+	}
+	return devices
+}
+
+// BdevAllowZoned returns true if any block device tier permits zoned (ZNS)
+// SSDs. Per-tier enforcement is performed during config validation against
+// scanned devices.
+func (tcs TierConfigs) BdevAllowZoned() bool {
+	for _, bc := range tcs.BdevConfigs() {
+		if bc.AllowZoned {
+			return true
+		}
+	}
+	return false
+}
+
+// BdevLbaFormats returns the LBA format to be used for each block device with
+// one specified in any tier, keyed by PCI address.
+func (tcs TierConfigs) BdevLbaFormats() map[string]*LbaFormatSpec {
+	specs := make(map[string]*LbaFormatSpec)
+	for _, bc := range tcs.BdevConfigs() {
+		// invalid specifications are rejected by config validation
+		tierSpecs, err := bc.lbaFormats()
+		if err != nil {
+			continue
+		}
+		for pciAddr, spec := range tierSpecs {
+			specs[pciAddr] = spec
+		}
+	}
+	return specs
+}
+
+// NvmeDevices returns the devices of all block device tiers in tier order if
+// the tiers are of the nvme class.
+func (tcs TierConfigs) NvmeDevices() []string {
+	devices := []string{}
+	for _, bc := range tcs.BdevConfigs() {
+		devices = append(devices, bc.GetNvmeDevs()...)
+	}
+	return devices
+}
+
+// HasBdevRoles returns true if roles are assigned to the block device tiers.
+func (tcs TierConfigs) HasBdevRoles() bool {
+	for _, bc := range tcs.BdevConfigs() {
+		if len(bc.Roles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate sanity checks the storage tiers of an engine. The first tier must
+// be the only SCM tier and all block device tiers must share the same class.
+// Roles may not be assigned to block device tiers as the engine is supplied
+// the devices of all tiers together and cannot yet use them by role.
+func (tcs TierConfigs) Validate() error {
+	if len(tcs) == 0 || !tcs[0].IsSCM() {
+		return errors.New("first storage tier must be an scm tier")
+	}
+
+	for i, tc := range tcs {
+		if err := tc.Validate(); err != nil {
+			return errors.Wrapf(err, "tier %d", i)
+		}
+		if i > 0 && tc.IsSCM() {
+			return errors.Errorf("tier %d: only one scm tier may be set", i)
+		}
+	}
+
+	if tcs.HasBdevRoles() {
+		return errors.New("bdev_roles not supported by the engine, devices of all bdev " +
+			"tiers are used together")
+	}
+
+	bcs := tcs.BdevConfigs()
+	var devices []string
+	for _, bc := range bcs {
+		if bc.Class != bcs[0].Class {
+			return errors.Errorf("bdev tiers must share the same bdev_class, got %s and %s",
+				bcs[0].Class, bc.Class)
+		}
+		if bc.Class == BdevClassMalloc && bc.FileSize != bcs[0].FileSize {
+			return errors.Errorf("bdev tiers of class %s must share the same bdev_size",
+				bc.Class)
+		}
+		devices = append(devices, bc.DeviceList...)
+	}
+	if common.StringSliceHasDuplicates(devices) {
+		return errors.New("bdev_list entries may not be repeated across bdev tiers")
+	}
+
+	return nil
+}
+
+// ControlMetadata describes persistent storage for control plane data, such
+// as engine superblocks and the system database, that would otherwise be
+// held on the SCM tier. When the SCM tier is a ramdisk, the contents of which
+// are lost on restart, it keeps the system database across restarts.
+type ControlMetadata struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+// HasPath returns true if a control metadata path has been set.
+func (cm ControlMetadata) HasPath() bool {
+	return cm.Path != ""
+}
+
+// Directory returns the directory holding control plane data.
+func (cm ControlMetadata) Directory() string {
+	return filepath.Join(cm.Path, controlMetadataDir)
+}
+
+// EngineDirectory returns the directory holding the control plane data of
+// the engine with the given index.
+func (cm ControlMetadata) EngineDirectory(idx uint) string {
+	return filepath.Join(cm.Directory(), fmt.Sprintf("engine%d", idx))
+}
+
+// Validate sanity checks control metadata parameters.
+func (cm ControlMetadata) Validate() error {
+	if cm.HasPath() && !filepath.IsAbs(cm.Path) {
+		return errors.Errorf("control_metadata path %q must be absolute", cm.Path)
+	}
+	return nil
+}
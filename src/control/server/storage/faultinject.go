@@ -0,0 +1,134 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BdevFaultsEnvVar names the environment variable holding the fault
+	// injection specification applied to the NVMe storage backend.
+	BdevFaultsEnvVar = "DAOS_BDEV_FAULTS"
+	// ScmFaultsEnvVar names the environment variable holding the fault
+	// injection specification applied to the SCM storage backend.
+	ScmFaultsEnvVar = "DAOS_SCM_FAULTS"
+
+	faultKeyFormatFail    = "format_fail"
+	faultKeyScanDelay     = "scan_delay"
+	faultKeyCorruptHealth = "corrupt_health"
+)
+
+// FaultInjection describes errors to be injected by a storage backend in
+// order to exercise error handling without requiring faulty hardware.
+//
+// Injection is only honored in binaries built with the "fault_injection"
+// build tag.
+type FaultInjection struct {
+	// FormatFail lists devices which will fail format. Interpretation of
+	// each entry is backend specific.
+	FormatFail []string
+	// ScanDelay is the time to sleep before performing a scan.
+	ScanDelay time.Duration
+	// CorruptHealth indicates that scan results should be returned with
+	// implausible health or inventory data.
+	CorruptHealth bool
+}
+
+// ParseFaultInjection parses a fault injection specification of the form
+// "format_fail=<dev>,scan_delay=<duration>,corrupt_health". The format_fail
+// key may be repeated.
+func ParseFaultInjection(spec string) (*FaultInjection, error) {
+	fi := new(FaultInjection)
+
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		kv := strings.SplitN(field, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		val := ""
+		if len(kv) == 2 {
+			val = strings.TrimSpace(kv[1])
+		}
+
+		switch key {
+		case faultKeyFormatFail:
+			if val == "" {
+				return nil, errors.Errorf("fault %q requires a device", key)
+			}
+			fi.FormatFail = append(fi.FormatFail, val)
+		case faultKeyScanDelay:
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value for fault %q", key)
+			}
+			if d < 0 {
+				return nil, errors.Errorf("fault %q must not be negative", key)
+			}
+			fi.ScanDelay = d
+		case faultKeyCorruptHealth:
+			if val != "" {
+				return nil, errors.Errorf("fault %q takes no value", key)
+			}
+			fi.CorruptHealth = true
+		default:
+			return nil, errors.Errorf("unknown storage fault %q", key)
+		}
+	}
+
+	return fi, nil
+}
+
+// FaultInjectionFromEnv returns the fault injection specification held in
+// the named environment variable. Nil is returned if fault injection is not
+// enabled in this build or the variable is unset.
+func FaultInjectionFromEnv(envVar string) (*FaultInjection, error) {
+	if !FaultInjectionEnabled {
+		return nil, nil
+	}
+
+	spec, set := os.LookupEnv(envVar)
+	if !set {
+		return nil, nil
+	}
+
+	fi, err := ParseFaultInjection(spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", envVar)
+	}
+
+	return fi, nil
+}
+
+// FormatFails returns true if the given device identifier is listed as one
+// which should fail format.
+func (fi *FaultInjection) FormatFails(dev string) bool {
+	if fi == nil {
+		return false
+	}
+	for _, ff := range fi.FormatFail {
+		if ff == dev {
+			return true
+		}
+	}
+	return false
+}
+
+// DelayScan sleeps for the configured scan delay, if any.
+func (fi *FaultInjection) DelayScan() {
+	if fi == nil || fi.ScanDelay == 0 {
+		return
+	}
+	time.Sleep(fi.ScanDelay)
+}
@@ -0,0 +1,11 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+// +build !fault_injection
+
+package storage
+
+// FaultInjectionEnabled indicates that storage fault injection is built in.
+const FaultInjectionEnabled = false
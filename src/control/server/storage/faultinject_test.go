@@ -0,0 +1,86 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestStorage_ParseFaultInjection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec   string
+		expFI  *FaultInjection
+		expErr error
+	}{
+		"empty": {
+			expFI: &FaultInjection{},
+		},
+		"all faults": {
+			spec: "format_fail=2, format_fail=0000:81:00.0,scan_delay=5s,corrupt_health",
+			expFI: &FaultInjection{
+				FormatFail:    []string{"2", "0000:81:00.0"},
+				ScanDelay:     5 * time.Second,
+				CorruptHealth: true,
+			},
+		},
+		"format fail missing device": {
+			spec:   "format_fail=",
+			expErr: errors.New("requires a device"),
+		},
+		"bad scan delay": {
+			spec:   "scan_delay=soon",
+			expErr: errors.New("invalid value"),
+		},
+		"negative scan delay": {
+			spec:   "scan_delay=-1s",
+			expErr: errors.New("must not be negative"),
+		},
+		"corrupt health with value": {
+			spec:   "corrupt_health=1",
+			expErr: errors.New("takes no value"),
+		},
+		"unknown fault": {
+			spec:   "explode",
+			expErr: errors.New("unknown storage fault"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotFI, gotErr := ParseFaultInjection(tc.spec)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expFI, gotFI); diff != "" {
+				t.Fatalf("unexpected fault injection spec (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestStorage_FaultInjection_FormatFails(t *testing.T) {
+	var nilFI *FaultInjection
+	if nilFI.FormatFails("1") {
+		t.Fatal("nil spec should not fail format")
+	}
+
+	fi := &FaultInjection{FormatFail: []string{"1", "/mnt/daos1"}}
+	for dev, exp := range map[string]bool{
+		"1":          true,
+		"2":          false,
+		"/mnt/daos1": true,
+		"/mnt/daos0": false,
+	} {
+		common.AssertEqual(t, exp, fi.FormatFails(dev), dev)
+	}
+}
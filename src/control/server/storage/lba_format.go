@@ -0,0 +1,113 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LbaFormatBestPerformance is the LBA format policy which selects the format
+// with the best relative performance that has no metadata.
+const LbaFormatBestPerformance = "best-performance"
+
+// LbaFormatSpec describes the LBA format that the namespaces of an NVMe SSD
+// should be formatted with, either as a data and metadata size or as a policy.
+type LbaFormatSpec struct {
+	DataSize        uint32 `json:"data_size,omitempty"`
+	MetaSize        uint32 `json:"meta_size,omitempty"`
+	BestPerformance bool   `json:"best_performance,omitempty"`
+}
+
+// ParseLbaFormatSpec parses an LBA format specification. Accepted values are
+// "best-performance" or a data size with an optional metadata size in bytes,
+// e.g. "4096", "4k", "512e" or "512+8".
+func ParseLbaFormatSpec(in string) (*LbaFormatSpec, error) {
+	str := strings.ToLower(strings.TrimSpace(in))
+	if str == LbaFormatBestPerformance {
+		return &LbaFormatSpec{BestPerformance: true}, nil
+	}
+
+	fields := strings.SplitN(str, "+", 2)
+	spec := new(LbaFormatSpec)
+	switch fields[0] {
+	case "4k", "4096", "4kn":
+		spec.DataSize = 4096
+	case "512", "512e":
+		spec.DataSize = 512
+	default:
+		return nil, errors.Errorf("invalid LBA format %q (expected %s, 512 or 4096 "+
+			"with optional +<metadata size>)", in, LbaFormatBestPerformance)
+	}
+
+	if len(fields) == 2 {
+		ms, err := strconv.ParseUint(fields[1], 10, 16)
+		if err != nil {
+			return nil, errors.Errorf("invalid metadata size in LBA format %q", in)
+		}
+		spec.MetaSize = uint32(ms)
+	}
+
+	return spec, nil
+}
+
+func (lfs *LbaFormatSpec) String() string {
+	if lfs.BestPerformance {
+		return LbaFormatBestPerformance
+	}
+	if lfs.MetaSize != 0 {
+		return fmt.Sprintf("%d+%d", lfs.DataSize, lfs.MetaSize)
+	}
+	return fmt.Sprintf("%d", lfs.DataSize)
+}
+
+func (lbaf *NvmeLbaFormat) String() string {
+	return fmt.Sprintf("LBAF%d (%d+%d)", lbaf.Index, lbaf.DataSize, lbaf.MetaSize)
+}
+
+// SectorSize returns the data size of the LBA format in use by the namespace,
+// or 512 bytes if the formats supported by the namespace are unknown.
+func (ns *NvmeNamespace) SectorSize() uint32 {
+	for _, lbaf := range ns.LbaFormats {
+		if lbaf.Index == ns.LbaFormat && lbaf.DataSize != 0 {
+			return lbaf.DataSize
+		}
+	}
+
+	return 512
+}
+
+// Select returns the LBA format supported by the namespace which satisfies
+// the specification, or nil if the namespace supports no such format.
+//
+// The best performance policy prefers the format with the lowest relative
+// performance value, and then the largest data size, of those without
+// metadata.
+func (lfs *LbaFormatSpec) Select(ns *NvmeNamespace) *NvmeLbaFormat {
+	var selected *NvmeLbaFormat
+	for _, lbaf := range ns.LbaFormats {
+		if !lfs.BestPerformance {
+			if lbaf.DataSize == lfs.DataSize && lbaf.MetaSize == lfs.MetaSize {
+				return lbaf
+			}
+			continue
+		}
+
+		if lbaf.MetaSize != 0 {
+			continue
+		}
+		if selected == nil || lbaf.RelPerf < selected.RelPerf ||
+			(lbaf.RelPerf == selected.RelPerf && lbaf.DataSize > selected.DataSize) {
+			selected = lbaf
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,161 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+)
+
+func TestStorage_ParseLbaFormatSpec(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in      string
+		expSpec *LbaFormatSpec
+		expErr  error
+	}{
+		"best performance": {
+			in:      "Best-Performance",
+			expSpec: &LbaFormatSpec{BestPerformance: true},
+		},
+		"4k native": {
+			in:      "4kn",
+			expSpec: &LbaFormatSpec{DataSize: 4096},
+		},
+		"512 emulated": {
+			in:      "512e",
+			expSpec: &LbaFormatSpec{DataSize: 512},
+		},
+		"with metadata": {
+			in:      "4096+8",
+			expSpec: &LbaFormatSpec{DataSize: 4096, MetaSize: 8},
+		},
+		"empty": {
+			expErr: errors.New("invalid LBA format"),
+		},
+		"unsupported data size": {
+			in:     "1024",
+			expErr: errors.New("invalid LBA format"),
+		},
+		"invalid metadata size": {
+			in:     "512+x",
+			expErr: errors.New("invalid metadata size"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotSpec, gotErr := ParseLbaFormatSpec(tc.in)
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expSpec, gotSpec); diff != "" {
+				t.Fatalf("unexpected spec (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestStorage_LbaFormatSpec_Select(t *testing.T) {
+	ns := &NvmeNamespace{
+		LbaFormats: []*NvmeLbaFormat{
+			{Index: 0, DataSize: 512, RelPerf: 2},
+			{Index: 1, DataSize: 512, MetaSize: 8, RelPerf: 2},
+			{Index: 2, DataSize: 4096, RelPerf: 1},
+			{Index: 3, DataSize: 4096, MetaSize: 8, RelPerf: 0},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		spec   *LbaFormatSpec
+		ns     *NvmeNamespace
+		expIdx int // -1 if no format expected
+	}{
+		"no formats reported": {
+			spec:   &LbaFormatSpec{BestPerformance: true},
+			ns:     &NvmeNamespace{},
+			expIdx: -1,
+		},
+		"best performance ignores metadata formats": {
+			spec:   &LbaFormatSpec{BestPerformance: true},
+			ns:     ns,
+			expIdx: 2,
+		},
+		"best performance prefers larger data size": {
+			spec: &LbaFormatSpec{BestPerformance: true},
+			ns: &NvmeNamespace{
+				LbaFormats: []*NvmeLbaFormat{
+					{Index: 0, DataSize: 512},
+					{Index: 1, DataSize: 4096},
+				},
+			},
+			expIdx: 1,
+		},
+		"exact match": {
+			spec:   &LbaFormatSpec{DataSize: 512, MetaSize: 8},
+			ns:     ns,
+			expIdx: 1,
+		},
+		"unsupported": {
+			spec:   &LbaFormatSpec{DataSize: 4096, MetaSize: 16},
+			ns:     ns,
+			expIdx: -1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got := tc.spec.Select(tc.ns)
+			if tc.expIdx < 0 {
+				if got != nil {
+					t.Fatalf("expected no format, got %s", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected format, got nil")
+			}
+			common.AssertEqual(t, uint32(tc.expIdx), got.Index, "unexpected format index")
+		})
+	}
+}
+
+func TestStorage_NvmeNamespace_SectorSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ns      *NvmeNamespace
+		expSize uint32
+	}{
+		"no formats reported": {
+			ns:      &NvmeNamespace{LbaFormat: 1},
+			expSize: 512,
+		},
+		"format in use": {
+			ns: &NvmeNamespace{
+				LbaFormat: 1,
+				LbaFormats: []*NvmeLbaFormat{
+					{Index: 0, DataSize: 512},
+					{Index: 1, DataSize: 4096},
+				},
+			},
+			expSize: 4096,
+		},
+		"format in use not reported": {
+			ns: &NvmeNamespace{
+				LbaFormat: 2,
+				LbaFormats: []*NvmeLbaFormat{
+					{Index: 0, DataSize: 4096},
+				},
+			},
+			expSize: 512,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			common.AssertEqual(t, tc.expSize, tc.ns.SectorSize(), "unexpected sector size")
+		})
+	}
+}
@@ -0,0 +1,97 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+// errInjectedFault is returned for operations failed by fault injection.
+var errInjectedFault = errors.New("injected fault")
+
+// faultBackend wraps a Backend and injects the scan errors described by a
+// storage.FaultInjection specification. Format failures are injected by the
+// Provider as SCM formats do not pass through the Backend.
+type faultBackend struct {
+	Backend
+	log    logging.Logger
+	faults *storage.FaultInjection
+}
+
+// faultInjectionFromEnv returns the SCM fault injection specification if it
+// has been requested through the environment and is enabled in this build.
+func faultInjectionFromEnv(log logging.Logger) *storage.FaultInjection {
+	faults, err := storage.FaultInjectionFromEnv(storage.ScmFaultsEnvVar)
+	if err != nil {
+		log.Errorf("scm fault injection disabled: %s", err)
+		return nil
+	}
+	if faults != nil {
+		log.Infof("scm fault injection enabled: %+v", *faults)
+	}
+
+	return faults
+}
+
+// withFaultInjection wraps the given backend with fault injection if faults
+// have been specified.
+func withFaultInjection(log logging.Logger, b Backend, faults *storage.FaultInjection) Backend {
+	if faults == nil {
+		return b
+	}
+
+	return &faultBackend{
+		Backend: b,
+		log:     log,
+		faults:  faults,
+	}
+}
+
+func (fb *faultBackend) Discover() (storage.ScmModules, error) {
+	fb.faults.DelayScan()
+
+	modules, err := fb.Backend.Discover()
+	if err != nil || !fb.faults.CorruptHealth {
+		return modules, err
+	}
+
+	for _, module := range modules {
+		fb.log.Debugf("injecting corrupt details for module %s", module.UID)
+		module.Capacity = 0
+		module.UID = ""
+		module.FirmwareRevision = ""
+	}
+
+	return modules, nil
+}
+
+func (fb *faultBackend) GetPmemNamespaces() (storage.ScmNamespaces, error) {
+	namespaces, err := fb.Backend.GetPmemNamespaces()
+	if err != nil || !fb.faults.CorruptHealth {
+		return namespaces, err
+	}
+
+	for _, ns := range namespaces {
+		fb.log.Debugf("injecting corrupt details for namespace %s", ns.BlockDevice)
+		ns.Size = 0
+	}
+
+	return namespaces, nil
+}
+
+// formatFails returns true if format of the requested mountpoint or DCPM
+// device should fail due to fault injection.
+func (p *Provider) formatFails(req FormatRequest) bool {
+	if p.faults.FormatFails(req.Mountpoint) {
+		return true
+	}
+
+	return req.Dcpm != nil && p.faults.FormatFails(req.Dcpm.Device)
+}
@@ -0,0 +1,84 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/storage"
+)
+
+func TestScm_faultBackend_Discover(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	mb := NewMockBackend(&MockBackendConfig{
+		DiscoverRes:         storage.ScmModules{storage.MockScmModule()},
+		GetPmemNamespaceRes: storage.ScmNamespaces{storage.MockScmNamespace()},
+	})
+	fb := withFaultInjection(log, mb, &storage.FaultInjection{CorruptHealth: true})
+
+	modules, err := fb.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, uint64(0), modules[0].Capacity, "module capacity")
+	common.AssertEqual(t, "", modules[0].UID, "module uid")
+
+	namespaces, err := fb.GetPmemNamespaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, uint64(0), namespaces[0].Size, "namespace size")
+}
+
+func TestScm_Provider_formatFails(t *testing.T) {
+	for name, tc := range map[string]struct {
+		faults *storage.FaultInjection
+		req    FormatRequest
+		expErr error
+	}{
+		"no faults": {
+			req: FormatRequest{Mountpoint: "/mnt/daos0"},
+		},
+		"mountpoint fails": {
+			faults: &storage.FaultInjection{FormatFail: []string{"/mnt/daos0"}},
+			req:    FormatRequest{Mountpoint: "/mnt/daos0"},
+			expErr: errors.New("injected fault"),
+		},
+		"other mountpoint": {
+			faults: &storage.FaultInjection{FormatFail: []string{"/mnt/daos1"}},
+			req:    FormatRequest{Mountpoint: "/mnt/daos0"},
+		},
+		"dcpm device fails": {
+			faults: &storage.FaultInjection{FormatFail: []string{"/dev/pmem1"}},
+			req: FormatRequest{
+				Mountpoint: "/mnt/daos1",
+				Dcpm:       &DcpmParams{Device: "/dev/pmem1"},
+			},
+			expErr: errors.New("injected fault"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			p := DefaultMockProvider(log)
+			p.faults = tc.faults
+
+			var gotErr error
+			if p.formatFails(tc.req) {
+				gotErr = errInjectedFault
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+		})
+	}
+}
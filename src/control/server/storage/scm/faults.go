@@ -7,6 +7,7 @@ package scm
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
@@ -116,6 +117,18 @@ func FaultFormatMissingDevice(device string) *fault.Fault {
 	)
 }
 
+// FaultRegionsGoalMismatch creates a Fault for the case where the SCM regions
+// applied after a reboot do not match the AppDirect interleaved goal requested
+// during prepare, listing the mismatch found on each socket.
+func FaultRegionsGoalMismatch(mismatches []string) *fault.Fault {
+	return scmFault(
+		code.ScmRegionsGoalMismatch,
+		fmt.Sprintf("SCM regions do not match the requested AppDirect interleaved goal: %s",
+			strings.Join(mismatches, ", ")),
+		"reboot if the goal has not yet been applied, otherwise reset SCM with 'daos_server storage prepare --scm-only --reset', reboot and prepare again",
+	)
+}
+
 func scmFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "scm",
@@ -63,13 +63,16 @@ type (
 		FirmwarePath string   // location of the firmware binary
 		ModelID      string   // filter devices by model ID
 		FirmwareRev  string   // filter devices by current FW revision
+		Activate     bool     // activate staged firmware without a reboot
 	}
 
 	// ModuleFirmwareUpdateResult represents the result of a firmware update for
 	// a specific SCM module.
 	ModuleFirmwareUpdateResult struct {
-		Module storage.ScmModule
-		Error  string
+		Module        storage.ScmModule
+		Error         string
+		StagedVersion string // version pending activation, if any
+		Activated     bool   // true if the new firmware is already active
 	}
 
 	// FirmwareUpdateResponse contains the results of the firmware update.
@@ -189,17 +192,57 @@ func (p *Provider) UpdateFirmware(req FirmwareUpdateRequest) (*FirmwareUpdateRes
 	resp := &FirmwareUpdateResponse{
 		Results: make([]ModuleFirmwareUpdateResult, len(modules)),
 	}
+	staged := make([]bool, len(modules))
+	var anyStaged bool
 	for i, mod := range modules {
 		err = p.backend.UpdateFirmware(mod.UID, req.FirmwarePath)
 		resp.Results[i].Module = *mod
 		if err != nil {
 			resp.Results[i].Error = err.Error()
+			continue
+		}
+		staged[i] = true
+		anyStaged = true
+	}
+
+	var activateErr error
+	if req.Activate && anyStaged {
+		if activateErr = activateFirmware(p.ndBusPath); activateErr != nil {
+			p.log.Errorf("SCM firmware activation failed: %s", activateErr)
+		}
+	}
+
+	for i := range resp.Results {
+		if !staged[i] {
+			continue
+		}
+		p.updateResultStatus(&resp.Results[i], req.Activate && activateErr == nil)
+		if activateErr != nil {
+			resp.Results[i].Error = errors.Wrap(activateErr,
+				"firmware staged but not activated").Error()
 		}
 	}
 
 	return resp, nil
 }
 
+// updateResultStatus records the firmware version staged on the module after
+// an update, the module's firmware is considered active if no version remains
+// staged after a successful activation.
+func (p *Provider) updateResultStatus(res *ModuleFirmwareUpdateResult, activated bool) {
+	info, err := p.backend.GetFirmwareStatus(res.Module.UID)
+	if err != nil {
+		p.log.Debugf("getting firmware status of SCM module %s: %s", res.Module.UID, err)
+		return
+	}
+	if info == nil {
+		return
+	}
+
+	res.StagedVersion = info.StagedVersion
+	res.Activated = activated && info.StagedVersion == ""
+}
+
 // FirmwareForwarder forwards firmware requests to a privileged binary.
 type FirmwareForwarder struct {
 	pbin.Forwarder
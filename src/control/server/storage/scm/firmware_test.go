@@ -7,6 +7,10 @@
 package scm
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -248,11 +252,23 @@ func TestProvider_UpdateFirmware(t *testing.T) {
 	testErr := errors.New("test error")
 	testPath := "/some/path/file.bin"
 
+	stagedInfo := &storage.ScmFirmwareInfo{
+		ActiveVersion: "FWRev1",
+		StagedVersion: "FWRev9",
+		UpdateStatus:  storage.ScmUpdateStatusStaged,
+	}
+	activeInfo := &storage.ScmFirmwareInfo{
+		ActiveVersion: "FWRev9",
+		UpdateStatus:  storage.ScmUpdateStatusSuccess,
+	}
+
 	for name, tc := range map[string]struct {
-		input      FirmwareUpdateRequest
-		backendCfg *MockBackendConfig
-		expErr     error
-		expRes     *FirmwareUpdateResponse
+		input       FirmwareUpdateRequest
+		backendCfg  *MockBackendConfig
+		ndBusFw     map[string]string
+		expErr      error
+		expRes      *FirmwareUpdateResponse
+		expActivate string
 	}{
 		"empty path": {
 			expErr: errors.New("missing path to firmware file"),
@@ -291,6 +307,118 @@ func TestProvider_UpdateFirmware(t *testing.T) {
 				},
 			},
 		},
+		"staged version reported": {
+			input: FirmwareUpdateRequest{
+				FirmwarePath: testPath,
+				DeviceUIDs:   []string{"Device1"},
+			},
+			backendCfg: &MockBackendConfig{
+				DiscoverRes:          defaultModules,
+				GetFirmwareStatusRes: stagedInfo,
+			},
+			expRes: &FirmwareUpdateResponse{
+				Results: []ModuleFirmwareUpdateResult{
+					{
+						Module:        *defaultModules[0],
+						StagedVersion: "FWRev9",
+					},
+				},
+			},
+		},
+		"activate live": {
+			input: FirmwareUpdateRequest{
+				FirmwarePath: testPath,
+				DeviceUIDs:   []string{"Device1"},
+				Activate:     true,
+			},
+			backendCfg: &MockBackendConfig{
+				DiscoverRes:          defaultModules,
+				GetFirmwareStatusRes: activeInfo,
+			},
+			ndBusFw: map[string]string{
+				"activate":   "armed",
+				"capability": "live",
+			},
+			expRes: &FirmwareUpdateResponse{
+				Results: []ModuleFirmwareUpdateResult{
+					{
+						Module:    *defaultModules[0],
+						Activated: true,
+					},
+				},
+			},
+			expActivate: "live",
+		},
+		"activate without kernel support": {
+			input: FirmwareUpdateRequest{
+				FirmwarePath: testPath,
+				DeviceUIDs:   []string{"Device1"},
+				Activate:     true,
+			},
+			backendCfg: &MockBackendConfig{
+				DiscoverRes:          defaultModules,
+				GetFirmwareStatusRes: stagedInfo,
+			},
+			expRes: &FirmwareUpdateResponse{
+				Results: []ModuleFirmwareUpdateResult{
+					{
+						Module:        *defaultModules[0],
+						StagedVersion: "FWRev9",
+						Error:         "firmware staged but not activated: firmware activation not supported by the kernel",
+					},
+				},
+			},
+		},
+		"activate requires quiesce": {
+			input: FirmwareUpdateRequest{
+				FirmwarePath: testPath,
+				DeviceUIDs:   []string{"Device1"},
+				Activate:     true,
+			},
+			backendCfg: &MockBackendConfig{
+				DiscoverRes:          defaultModules,
+				GetFirmwareStatusRes: stagedInfo,
+			},
+			ndBusFw: map[string]string{
+				"activate":   "armed",
+				"capability": "quiesce",
+			},
+			expRes: &FirmwareUpdateResponse{
+				Results: []ModuleFirmwareUpdateResult{
+					{
+						Module:        *defaultModules[0],
+						StagedVersion: "FWRev9",
+						Error: "firmware staged but not activated: ndbus0 does not support " +
+							"live firmware activation (capability \"quiesce\")",
+					},
+				},
+			},
+			expActivate: "armed",
+		},
+		"activate after update failed": {
+			input: FirmwareUpdateRequest{
+				FirmwarePath: testPath,
+				DeviceUIDs:   []string{"Device1"},
+				Activate:     true,
+			},
+			backendCfg: &MockBackendConfig{
+				DiscoverRes:       defaultModules,
+				UpdateFirmwareErr: testErr,
+			},
+			ndBusFw: map[string]string{
+				"activate":   "idle",
+				"capability": "live",
+			},
+			expRes: &FirmwareUpdateResponse{
+				Results: []ModuleFirmwareUpdateResult{
+					{
+						Module: *defaultModules[0],
+						Error:  testErr.Error(),
+					},
+				},
+			},
+			expActivate: "idle",
+		},
 		"update failed": {
 			input: FirmwareUpdateRequest{
 				FirmwarePath: testPath,
@@ -432,7 +560,23 @@ func TestProvider_UpdateFirmware(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
 			defer common.ShowBufferOnFailure(t, buf)
 
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			fwDir := filepath.Join(testDir, "ndbus0", "firmware")
+			if tc.ndBusFw != nil {
+				if err := os.MkdirAll(fwDir, 0755); err != nil {
+					t.Fatal(err)
+				}
+				for attr, val := range tc.ndBusFw {
+					if err := ioutil.WriteFile(filepath.Join(fwDir, attr), []byte(val+"\n"), 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
 			p := NewMockProvider(log, tc.backendCfg, nil)
+			p.ndBusPath = testDir
 
 			res, err := p.UpdateFirmware(tc.input)
 
@@ -441,6 +585,15 @@ func TestProvider_UpdateFirmware(t *testing.T) {
 			if diff := cmp.Diff(tc.expRes, res); diff != "" {
 				t.Fatalf("unexpected response (-want, +got):\n%s\n", diff)
 			}
+
+			if tc.expActivate != "" {
+				data, err := ioutil.ReadFile(filepath.Join(fwDir, "activate"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				common.AssertEqual(t, tc.expActivate, strings.TrimSpace(string(data)),
+					"unexpected activate state")
+			}
 		})
 	}
 }
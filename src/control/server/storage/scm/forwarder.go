@@ -95,3 +95,15 @@ func (f *AdminForwarder) Prepare(req PrepareRequest) (*PrepareResponse, error) {
 
 	return res, nil
 }
+
+// VerifyRegions forwards a request to verify the SCM regions.
+func (f *AdminForwarder) VerifyRegions(req VerifyRequest) (*VerifyResponse, error) {
+	req.Forwarded = true
+
+	res := new(VerifyResponse)
+	if err := f.SendReq("ScmVerifyRegions", req, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
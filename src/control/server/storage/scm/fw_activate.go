@@ -0,0 +1,86 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package scm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ndBusPath is the sysfs location of the NVDIMM buses, each of which
+	// exposes the kernel firmware activation interface.
+	ndBusPath = "/sys/bus/nd/devices"
+
+	fwActivateLive  = "live"
+	fwActivateArmed = "armed"
+)
+
+func readNdBusAttr(fwDir, attr string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(fwDir, attr))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// activateFirmware triggers live activation of the firmware staged on the
+// PMem modules of each NVDIMM bus through the kernel firmware activation
+// interface, buses without armed modules are skipped.
+//
+// Activation requiring the quiescing of device I/O is not attempted.
+func activateFirmware(busPath string) error {
+	buses, err := filepath.Glob(filepath.Join(busPath, "ndbus*"))
+	if err != nil {
+		return err
+	}
+
+	var supported bool
+	for _, bus := range buses {
+		fwDir := filepath.Join(bus, "firmware")
+		if _, err := os.Stat(fwDir); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		supported = true
+
+		state, err := readNdBusAttr(fwDir, "activate")
+		if err != nil {
+			return err
+		}
+		if state != fwActivateArmed {
+			continue
+		}
+
+		capability, err := readNdBusAttr(fwDir, "capability")
+		if err != nil {
+			return err
+		}
+		if capability != fwActivateLive {
+			return errors.Errorf("%s does not support live firmware activation (capability %q)",
+				filepath.Base(bus), capability)
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(fwDir, "activate"),
+			[]byte(fwActivateLive), 0644); err != nil {
+			return errors.Wrapf(err, "activating firmware on %s", filepath.Base(bus))
+		}
+	}
+
+	if !supported {
+		return errors.New("firmware activation not supported by the kernel")
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/dustin/go-humanize"
@@ -79,6 +80,7 @@ func run(cmd string) (string, error) {
 const (
 	cmdShowIpmctlVersion = "ipmctl version"
 	cmdShowRegions       = "ipmctl show -d PersistentMemoryType,FreeCapacity -region"
+	cmdShowRegionDetails = "ipmctl show -d SocketID,PersistentMemoryType,Capacity,FreeCapacity -region"
 	cmdCreateRegions     = "ipmctl create -f -goal PersistentMemoryType=AppDirect"
 	cmdRemoveRegions     = "ipmctl create -f -goal MemoryMode=100"
 	cmdDeleteGoal        = "ipmctl delete -goal"
@@ -108,6 +110,10 @@ func (cr *cmdRunner) showRegions() (string, error) {
 	return cr.runCmd(cmdShowRegions)
 }
 
+func (cr *cmdRunner) showRegionDetails() (string, error) {
+	return cr.runCmd(cmdShowRegionDetails)
+}
+
 func (cr *cmdRunner) createRegions() (string, error) {
 	return cr.runCmd(cmdCreateRegions)
 }
@@ -450,6 +456,81 @@ func freeCapacity(text string) (uint64, error) {
 	return capacity, nil
 }
 
+// parseRegions takes output from ipmctl and returns the regions described.
+//
+// external tool commands return:
+// $ ipmctl show -d SocketID,PersistentMemoryType,Capacity,FreeCapacity -region
+//
+// ---ISetID=0x2aba7f4828ef2ccc---
+//    SocketID=0x0000
+//    PersistentMemoryType=AppDirect
+//    Capacity=3012.0 GiB
+//    FreeCapacity=3012.0 GiB
+// ---ISetID=0x81187f4881f02ccc---
+//    SocketID=0x0001
+//    PersistentMemoryType=AppDirect
+//    Capacity=3012.0 GiB
+//    FreeCapacity=3012.0 GiB
+func parseRegions(text string) (storage.ScmRegions, error) {
+	regions := storage.ScmRegions{}
+	if strings.Contains(text, outScmNoRegions) {
+		return regions, nil
+	}
+
+	var cur *storage.ScmRegion
+	for _, line := range strings.Split(text, "\n") {
+		entry := strings.TrimSpace(line)
+
+		if strings.HasPrefix(entry, "---ISetID=") {
+			cur = &storage.ScmRegion{
+				ID: strings.TrimSuffix(strings.TrimPrefix(entry, "---ISetID="), "---"),
+			}
+			regions = append(regions, cur)
+			continue
+		}
+
+		kv := strings.Split(entry, "=")
+		if len(kv) != 2 || cur == nil {
+			continue
+		}
+
+		var err error
+		switch kv[0] {
+		case "SocketID":
+			var id uint64
+			id, err = strconv.ParseUint(kv[1], 0, 32)
+			cur.SocketID = uint32(id)
+		case "PersistentMemoryType":
+			cur.PersistentMemoryType = kv[1]
+		case "Capacity":
+			cur.Capacity, err = humanize.ParseBytes(kv[1])
+		case "FreeCapacity":
+			cur.FreeCapacity, err = humanize.ParseBytes(kv[1])
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse region %s %s", cur.ID, kv[0])
+		}
+	}
+
+	return regions, nil
+}
+
+// GetPmemRegions returns the SCM regions configured on the local server.
+func (cr *cmdRunner) GetPmemRegions() (storage.ScmRegions, error) {
+	if err := cr.checkIpmctl(badIpmctlVers); err != nil {
+		return nil, err
+	}
+
+	out, err := cr.showRegionDetails()
+	if err != nil {
+		return nil, errors.WithMessage(err, "show region details cmd")
+	}
+
+	cr.log.Debugf("show region details output: %s\n", out)
+
+	return parseRegions(out)
+}
+
 // createNamespaces repeatedly creates namespaces until no free capacity.
 func (cr *cmdRunner) createNamespaces() (storage.ScmNamespaces, error) {
 	devs := make(storage.ScmNamespaces, 0)
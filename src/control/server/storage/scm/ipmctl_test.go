@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
@@ -386,6 +387,64 @@ func TestIpmctl_parseNamespaces(t *testing.T) {
 	}
 }
 
+func TestIpmctl_parseRegions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in         string
+		expRegions storage.ScmRegions
+		expErr     error
+	}{
+		"empty": {
+			expRegions: storage.ScmRegions{},
+		},
+		"no regions": {
+			in:         "\nThere are no Regions defined in the system.\n",
+			expRegions: storage.ScmRegions{},
+		},
+		"two sockets": {
+			in: strings.Join([]string{
+				"---ISetID=0x2aba7f4828ef2ccc---",
+				"   SocketID=0x0000",
+				"   PersistentMemoryType=AppDirect",
+				"   Capacity=3012.0 GiB",
+				"   FreeCapacity=3012.0 GiB",
+				"---ISetID=0x81187f4881f02ccc---",
+				"   SocketID=0x0001",
+				"   PersistentMemoryType=AppDirectNotInterleaved",
+				"   Capacity=502.0 GiB",
+				"   FreeCapacity=0.0 GiB",
+			}, "\n"),
+			expRegions: storage.ScmRegions{
+				{
+					ID:                   "0x2aba7f4828ef2ccc",
+					SocketID:             0,
+					PersistentMemoryType: "AppDirect",
+					Capacity:             3012 * humanize.GiByte,
+					FreeCapacity:         3012 * humanize.GiByte,
+				},
+				{
+					ID:                   "0x81187f4881f02ccc",
+					SocketID:             1,
+					PersistentMemoryType: "AppDirectNotInterleaved",
+					Capacity:             502 * humanize.GiByte,
+				},
+			},
+		},
+		"bad socket id": {
+			in:     "---ISetID=0x2aba7f4828ef2ccc---\n   SocketID=zero\n",
+			expErr: errors.New("SocketID"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			gotRegions, gotErr := parseRegions(tc.in)
+
+			CmpErr(t, tc.expErr, gotErr)
+			if diff := cmp.Diff(tc.expRegions, gotRegions); diff != "" {
+				t.Fatalf("unexpected region result (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 // TestIpmctl_GetPmemNamespaces tests the internals of prepScm, pass in mock runCmd to verify
 // behavior. Don't use mockPrepScm as we want to test prepScm logic.
 func TestIpmctl_GetPmemNamespaces(t *testing.T) {
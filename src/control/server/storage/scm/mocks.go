@@ -113,6 +113,8 @@ type MockBackendConfig struct {
 	DiscoverErr          error
 	GetPmemNamespaceRes  storage.ScmNamespaces
 	GetPmemNamespaceErr  error
+	GetPmemRegionsRes    storage.ScmRegions
+	GetPmemRegionsErr    error
 	GetPmemStateErr      error
 	StartingState        storage.ScmState
 	NextState            storage.ScmState
@@ -138,6 +140,28 @@ func (mb *MockBackend) GetPmemNamespaces() (storage.ScmNamespaces, error) {
 	return mb.cfg.GetPmemNamespaceRes, mb.cfg.GetPmemNamespaceErr
 }
 
+// GetPmemRegions returns the configured regions, or if none are configured
+// a single AppDirect region on each socket with discovered modules.
+func (mb *MockBackend) GetPmemRegions() (storage.ScmRegions, error) {
+	if mb.cfg.GetPmemRegionsRes != nil || mb.cfg.GetPmemRegionsErr != nil {
+		return mb.cfg.GetPmemRegionsRes, mb.cfg.GetPmemRegionsErr
+	}
+
+	regions := storage.ScmRegions{}
+	seen := make(map[uint32]bool)
+	for _, module := range mb.cfg.DiscoverRes {
+		if seen[module.SocketID] {
+			continue
+		}
+		seen[module.SocketID] = true
+		regions = append(regions, &storage.ScmRegion{
+			SocketID:             module.SocketID,
+			PersistentMemoryType: regionTypeAppDirect,
+		})
+	}
+	return regions, nil
+}
+
 func (mb *MockBackend) GetPmemState() (storage.ScmState, error) {
 	if mb.cfg.GetPmemStateErr != nil {
 		return storage.ScmStateUnknown, mb.cfg.GetPmemStateErr
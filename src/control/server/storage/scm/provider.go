@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"sync"
 
@@ -39,6 +40,8 @@ const (
 
 	ramFsType = fsTypeTmpfs
 
+	regionTypeAppDirect = "AppDirect"
+
 	MsgRebootRequired     = "A reboot is required to process new SCM memory allocation goals."
 	MsgNoModules          = "no SCM modules to prepare"
 	MsgNotInited          = "SCM storage could not be accessed"
@@ -61,6 +64,17 @@ type (
 		Namespaces     storage.ScmNamespaces
 	}
 
+	// VerifyRequest defines the parameters for a VerifyRegions operation.
+	VerifyRequest struct {
+		pbin.ForwardableRequest
+	}
+
+	// VerifyResponse contains the results of a successful VerifyRegions
+	// operation.
+	VerifyResponse struct {
+		Regions storage.ScmRegions
+	}
+
 	// ScanRequest defines the parameters for a Scan operation.
 	ScanRequest struct {
 		pbin.ForwardableRequest
@@ -129,6 +143,7 @@ type (
 		PrepReset(storage.ScmState) (bool, error)
 		GetPmemState() (storage.ScmState, error)
 		GetPmemNamespaces() (storage.ScmNamespaces, error)
+		GetPmemRegions() (storage.ScmRegions, error)
 		GetFirmwareStatus(deviceUID string) (*storage.ScmFirmwareInfo, error)
 		UpdateFirmware(deviceUID string, firmwarePath string) error
 	}
@@ -162,6 +177,8 @@ type (
 		sys     SystemProvider
 		fwd     *AdminForwarder
 		firmwareProvider
+		ndBusPath string
+		faults    *storage.FaultInjection
 	}
 )
 
@@ -327,19 +344,23 @@ func parseFsType(input string) (string, error) {
 // DefaultProvider returns an initialized *Provider suitable for use with production code.
 func DefaultProvider(log logging.Logger) *Provider {
 	lp := system.DefaultProvider()
-	p := &defaultSystemProvider{
+	sp := &defaultSystemProvider{
 		LinuxProvider: *lp,
 	}
-	return NewProvider(log, defaultCmdRunner(log), p)
+	faults := faultInjectionFromEnv(log)
+	p := NewProvider(log, withFaultInjection(log, defaultCmdRunner(log), faults), sp)
+	p.faults = faults
+	return p
 }
 
 // NewProvider returns an initialized *Provider.
 func NewProvider(log logging.Logger, backend Backend, sys SystemProvider) *Provider {
 	p := &Provider{
-		log:     log,
-		backend: backend,
-		sys:     sys,
-		fwd:     NewAdminForwarder(log),
+		log:       log,
+		backend:   backend,
+		sys:       sys,
+		fwd:       NewAdminForwarder(log),
+		ndBusPath: ndBusPath,
 	}
 	p.setupFirmwareProvider(log)
 	return p
@@ -509,6 +530,15 @@ func (p *Provider) Prepare(req PrepareRequest) (res *PrepareResponse, err error)
 		return
 	}
 
+	if p.currentState() == storage.ScmStateFreeCapacity {
+		// regions created by a goal have just been applied on reboot,
+		// check that they match the goal before creating namespaces
+		if _, err = p.verifyRegions(); err != nil {
+			res = nil
+			return
+		}
+	}
+
 	res.RebootRequired, res.Namespaces, err = p.backend.Prep(p.currentState())
 	if err != nil {
 		res = nil
@@ -521,6 +551,83 @@ func (p *Provider) Prepare(req PrepareRequest) (res *PrepareResponse, err error)
 	return
 }
 
+// regionGoalMismatches returns a description of each socket with modules whose
+// regions do not match the goal requested by Prepare, a single interleaved
+// AppDirect region per socket.
+func regionGoalMismatches(modules storage.ScmModules, regions storage.ScmRegions) []string {
+	socketRegions := make(map[uint32]storage.ScmRegions)
+	for _, region := range regions {
+		socketRegions[region.SocketID] = append(socketRegions[region.SocketID], region)
+	}
+
+	socketSet := make(map[uint32]bool)
+	for _, module := range modules {
+		socketSet[module.SocketID] = true
+	}
+	sockets := make([]uint32, 0, len(socketSet))
+	for socket := range socketSet {
+		sockets = append(sockets, socket)
+	}
+	sort.Slice(sockets, func(i, j int) bool { return sockets[i] < sockets[j] })
+
+	var mismatches []string
+	for _, socket := range sockets {
+		rs := socketRegions[socket]
+		switch {
+		case len(rs) == 0:
+			mismatches = append(mismatches, fmt.Sprintf("socket %d has no region", socket))
+		case len(rs) > 1:
+			mismatches = append(mismatches, fmt.Sprintf("socket %d has %d regions, expected 1",
+				socket, len(rs)))
+		case rs[0].PersistentMemoryType != regionTypeAppDirect:
+			mismatches = append(mismatches, fmt.Sprintf("socket %d has %s region, expected %s",
+				socket, rs[0].PersistentMemoryType, regionTypeAppDirect))
+		}
+	}
+
+	return mismatches
+}
+
+func (p *Provider) verifyRegions() (storage.ScmRegions, error) {
+	regions, err := p.backend.GetPmemRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	if mismatches := regionGoalMismatches(p.createScanResponse().Modules, regions); len(mismatches) > 0 {
+		return nil, FaultRegionsGoalMismatch(mismatches)
+	}
+
+	return regions, nil
+}
+
+// VerifyRegions checks that the SCM regions applied after the reboot that
+// follows goal creation in Prepare match the requested goal, returning a
+// fault listing the mismatched sockets if they do not.
+func (p *Provider) VerifyRegions(req VerifyRequest) (*VerifyResponse, error) {
+	if !p.isInitialized() {
+		if _, err := p.Scan(ScanRequest{}); err != nil {
+			return nil, err
+		}
+	}
+
+	if sr := p.createScanResponse(); len(sr.Modules) == 0 {
+		p.log.Info("skipping SCM region verification; no modules detected")
+		return &VerifyResponse{}, nil
+	}
+
+	if p.shouldForward(req) {
+		return p.fwd.VerifyRegions(req)
+	}
+
+	regions, err := p.verifyRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyResponse{Regions: regions}, nil
+}
+
 // CheckFormat attempts to determine whether or not the SCM specified in the
 // request is already formatted. If it is mounted, it is assumed to be formatted.
 // In the case of DCPM, the device is checked directly for the presence of a
@@ -618,6 +725,10 @@ func (p *Provider) Format(req FormatRequest) (*FormatResponse, error) {
 		return p.fwd.Format(req)
 	}
 
+	if p.formatFails(req) {
+		return nil, errors.Wrapf(errInjectedFault, "failed to format %s", req.Mountpoint)
+	}
+
 	if err := p.clearMount(req); err != nil {
 		return nil, errors.Wrap(err, "failed to clear existing mount")
 	}
@@ -138,9 +138,12 @@ func TestProviderPrepare(t *testing.T) {
 		getNamespaceRes  storage.ScmNamespaces
 		getStateErr      error
 		prepErr          error
+		prepNamespaceRes storage.ScmNamespaces
+		getRegionsRes    storage.ScmRegions
 		startState       storage.ScmState
 		expEndState      storage.ScmState
 		expResponse      *PrepareResponse
+		expErr           error
 	}{
 		"init scan fails": {
 			discoverErr: FaultDiscoveryFailed,
@@ -169,6 +172,22 @@ func TestProviderPrepare(t *testing.T) {
 				RebootRequired: true,
 			},
 		},
+		"create namespaces after reboot": {
+			startState:       storage.ScmStateFreeCapacity,
+			expEndState:      storage.ScmStateNoCapacity,
+			prepNamespaceRes: storage.ScmNamespaces{defaultNamespace},
+			expResponse: &PrepareResponse{
+				State:      storage.ScmStateNoCapacity,
+				Namespaces: storage.ScmNamespaces{defaultNamespace},
+			},
+		},
+		"regions do not match goal after reboot": {
+			startState: storage.ScmStateFreeCapacity,
+			getRegionsRes: storage.ScmRegions{
+				{SocketID: defaultModule.SocketID, PersistentMemoryType: "AppDirectNotInterleaved"},
+			},
+			expErr: errors.New("do not match the requested AppDirect interleaved goal"),
+		},
 		"prep with ndctl missing": {
 			getNamespaceErr: FaultMissingNdctl,
 		},
@@ -218,6 +237,8 @@ func TestProviderPrepare(t *testing.T) {
 				NextState:           tc.expEndState,
 				PrepNeedsReboot:     tc.shouldReboot,
 				PrepErr:             tc.prepErr,
+				PrepNamespaceRes:    tc.prepNamespaceRes,
+				GetPmemRegionsRes:   tc.getRegionsRes,
 			}
 			p := NewMockProvider(log, mbc, nil)
 
@@ -234,6 +255,10 @@ func TestProviderPrepare(t *testing.T) {
 			}
 
 			res, err := p.Prepare(PrepareRequest{Reset: tc.reset})
+			if tc.expErr != nil {
+				common.CmpErr(t, tc.expErr, err)
+				return
+			}
 			if err != nil {
 				switch err {
 				case FaultMissingNdctl:
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 
@@ -18,6 +19,7 @@ import (
 )
 
 // ScmState represents the probed state of SCM modules on the system.
+//
 //go:generate stringer -type=ScmState
 type ScmState int
 
@@ -54,6 +56,19 @@ type (
 	// ScmModules is a type alias for []ScmModule that implements fmt.Stringer.
 	ScmModules []*ScmModule
 
+	// ScmRegion represents a region of SCM module capacity configured by a
+	// memory allocation goal.
+	ScmRegion struct {
+		ID                   string `json:"iset_id"`
+		SocketID             uint32 `json:"socket_id"`
+		PersistentMemoryType string `json:"persistent_memory_type"`
+		Capacity             uint64 `json:"capacity"`
+		FreeCapacity         uint64 `json:"free_capacity"`
+	}
+
+	// ScmRegions is a type alias for []ScmRegion.
+	ScmRegions []*ScmRegion
+
 	// ScmMountPoint represents location SCM filesystem is mounted.
 	ScmMountPoint struct {
 		Info       string `json:"info"`
@@ -111,13 +126,39 @@ type (
 		ReliabilityWarn bool   `json:"dev_reliability_warn"`
 		ReadOnlyWarn    bool   `json:"read_only_warn"`
 		VolatileWarn    bool   `json:"volatile_mem_warn"`
+		// I/O stats, latencies in microseconds
+		IOInflight   uint32 `json:"io_inflight"`
+		IOQueueDepth uint32 `json:"io_queue_depth"`
+		ReadOps      uint64 `json:"read_ops"`
+		WriteOps     uint64 `json:"write_ops"`
+		ReadBytes    uint64 `json:"read_bytes"`
+		WriteBytes   uint64 `json:"write_bytes"`
+		ReadLatP50   uint64 `json:"read_lat_p50"`
+		ReadLatP99   uint64 `json:"read_lat_p99"`
+		WriteLatP50  uint64 `json:"write_lat_p50"`
+		WriteLatP99  uint64 `json:"write_lat_p99"`
 	}
 
 	// NvmeNamespace represents an individual NVMe namespace on a device and
 	// mirrors C.struct_ns_t.
 	NvmeNamespace struct {
-		ID   uint32 `json:"id"`
-		Size uint64 `json:"size"`
+		ID    uint32 `json:"id"`
+		Size  uint64 `json:"size"`
+		Zoned bool   `json:"zoned"`
+		// LbaFormat is the index of the LBA format in use.
+		LbaFormat  uint32           `json:"lba_format,omitempty"`
+		LbaFormats []*NvmeLbaFormat `json:"lba_formats,omitempty"`
+	}
+
+	// NvmeLbaFormat describes an LBA format supported by an NVMe namespace,
+	// as reported by Identify Namespace, and mirrors C.struct_lbaf_t.
+	NvmeLbaFormat struct {
+		Index    uint32 `json:"index"`
+		DataSize uint32 `json:"data_size"`
+		MetaSize uint32 `json:"meta_size"`
+		// RelPerf is the relative performance of the format, lower
+		// values indicate better performance.
+		RelPerf uint32 `json:"rel_perf"`
 	}
 
 	// SmdDevice contains DAOS storage device information, including
@@ -133,6 +174,16 @@ type (
 		TrAddr     string      `json:"tr_addr"`
 	}
 
+	// NvmePciLink describes the negotiated and maximum capable PCIe link of
+	// an NVMe device controller, speeds are given in GT/s. The maximum is
+	// limited to that of the port the controller is attached to.
+	NvmePciLink struct {
+		Speed    float64 `json:"speed"`
+		Width    uint32  `json:"width"`
+		MaxSpeed float64 `json:"max_speed"`
+		MaxWidth uint32  `json:"max_width"`
+	}
+
 	// NvmeController represents a NVMe device controller which includes health
 	// and namespace information and mirrors C.struct_ns_t.
 	NvmeController struct {
@@ -145,10 +196,26 @@ type (
 		HealthStats *NvmeHealth      `json:"health_stats"`
 		Namespaces  []*NvmeNamespace `hash:"set" json:"namespaces"`
 		SmdDevices  []*SmdDevice     `hash:"set" json:"smd_devices"`
+		PciLink     *NvmePciLink     `json:"pci_link,omitempty"`
+		// Driver and BindState are only set for controllers found
+		// bound to a kernel driver and so not accessible by SPDK.
+		Driver    string        `json:"driver,omitempty"`
+		BindState NvmeBindState `json:"bind_state,omitempty"`
 	}
 
 	// NvmeControllers is a type alias for []*NvmeController.
 	NvmeControllers []*NvmeController
+
+	// BdevCapabilities describes the versions of the SPDK and DPDK libraries
+	// used to access NVMe SSDs and the optional features they support.
+	BdevCapabilities struct {
+		SpdkVersion string `json:"spdk_version"`
+		DpdkVersion string `json:"dpdk_version"`
+		Vmd         bool   `json:"vmd"`
+		VmdEnabled  bool   `json:"vmd_enabled"`
+		Zns         bool   `json:"zns"`
+		Cmb         bool   `json:"cmb"`
+	}
 )
 
 const (
@@ -287,6 +354,82 @@ func (nch *NvmeHealth) TempF() float32 {
 	return (nch.TempC() * (9.0 / 5.0)) + 32.0
 }
 
+// Degraded returns true if the link has trained at a lower speed or width
+// than the maximum supported by both the device and its port.
+func (npl *NvmePciLink) Degraded() bool {
+	if npl == nil {
+		return false
+	}
+	return npl.Speed < npl.MaxSpeed || npl.Width < npl.MaxWidth
+}
+
+func (npl *NvmePciLink) String() string {
+	if npl == nil {
+		return "N/A"
+	}
+	link := fmt.Sprintf("x%d %gGT/s", npl.Width, npl.Speed)
+	if npl.Degraded() {
+		link += fmt.Sprintf(" (degraded, x%d %gGT/s capable)", npl.MaxWidth, npl.MaxSpeed)
+	}
+	return link
+}
+
+// Features returns the names of the optional features supported, VMD is
+// marked as disabled if supported but not enabled in the server config.
+func (bc *BdevCapabilities) Features() []string {
+	if bc == nil {
+		return nil
+	}
+
+	var features []string
+	if bc.Vmd {
+		vmd := "vmd"
+		if !bc.VmdEnabled {
+			vmd += " (disabled)"
+		}
+		features = append(features, vmd)
+	}
+	if bc.Zns {
+		features = append(features, "zns")
+	}
+	if bc.Cmb {
+		features = append(features, "cmb")
+	}
+
+	return features
+}
+
+func (bc *BdevCapabilities) String() string {
+	if bc == nil {
+		return "N/A"
+	}
+
+	features := "none"
+	if f := bc.Features(); len(f) != 0 {
+		features = strings.Join(f, ", ")
+	}
+
+	return fmt.Sprintf("SPDK %s, DPDK %s, features: %s", bc.SpdkVersion, bc.DpdkVersion, features)
+}
+
+// Zoned returns true if any of the controller's namespaces are zoned (ZNS)
+// and can therefore only be written sequentially.
+func (nc *NvmeController) Zoned() bool {
+	for _, ns := range nc.Namespaces {
+		if ns.Zoned {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KernelBound returns true if the controller is bound to the kernel NVMe
+// driver and must be prepared before it can be used by DAOS.
+func (nc *NvmeController) KernelBound() bool {
+	return nc.BindState == NvmeBindStateKernel
+}
+
 // UpdateSmd adds or updates SMD device entry for an NVMe Controller.
 func (nc *NvmeController) UpdateSmd(smdDev *SmdDevice) {
 	for idx := range nc.SmdDevices {
@@ -355,6 +498,17 @@ func (ncs NvmeControllers) PercentUsage() string {
 
 // Summary reports accumulated storage space and the number of controllers.
 func (ncs NvmeControllers) Summary() string {
+	var kernelBound int
+	for _, c := range ncs {
+		if c.KernelBound() {
+			kernelBound++
+		}
+	}
+	if kernelBound > 0 {
+		return fmt.Sprintf("%s (%d %s, %d kernel-bound)", humanize.Bytes(ncs.Capacity()),
+			len(ncs), common.Pluralise("controller", len(ncs)), kernelBound)
+	}
+
 	return fmt.Sprintf("%s (%d %s)", humanize.Bytes(ncs.Capacity()),
 		len(ncs), common.Pluralise("controller", len(ncs)))
 }
@@ -9,7 +9,9 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,9 +20,11 @@ import (
 
 	"github.com/daos-stack/daos/src/control/lib/telemetry/promexp"
 	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+	"github.com/daos-stack/daos/src/control/system"
 )
 
-func regPromEngineSources(ctx context.Context, log logging.Logger, engines []*EngineInstance) ([]func(), error) {
+func regPromEngineSources(ctx context.Context, log logging.Logger, engines []*EngineInstance, labels map[string]string) ([]func(), error) {
 	numEngines := len(engines)
 	if numEngines == 0 {
 		return []func(){}, nil
@@ -59,18 +63,46 @@ func regPromEngineSources(ctx context.Context, log logging.Logger, engines []*En
 	if err != nil {
 		return nil, err
 	}
-	prometheus.MustRegister(c)
+	// apply any site-specific host labels to all exported metrics
+	prometheus.WrapRegistererWith(labels, prometheus.DefaultRegisterer).MustRegister(c)
 
 	return cleanupFns, nil
 }
 
-func startPrometheusExporter(ctx context.Context, log logging.Logger, port int, engines []*EngineInstance) (func(), error) {
-	cleanupFns, err := regPromEngineSources(ctx, log, engines)
+// telemetryEnabled returns true if metrics are to be exported, either by
+// being scraped or pushed to remote collectors.
+func telemetryEnabled(cfg *config.Server) bool {
+	return cfg.TelemetryPort != 0 || len(cfg.TelemetryPush) > 0
+}
+
+// regPromCollectors registers the engine, hugepage, power and MS raft metric
+// collectors with the default registry and returns functions to detach the
+// engine sources.
+func regPromCollectors(ctx context.Context, log logging.Logger, engines []*EngineInstance, sysdb *system.Database, labels map[string]string) ([]func(), error) {
+	cleanupFns, err := regPromEngineSources(ctx, log, engines, labels)
 	if err != nil {
 		return nil, err
 	}
 
-	listenAddress := fmt.Sprintf("0.0.0.0:%d", port)
+	reg := prometheus.WrapRegistererWith(labels, prometheus.DefaultRegisterer)
+	for name, c := range map[string]prometheus.Collector{
+		"hugepage": newHugePageMetrics(log, engines),
+		"power":    newPowerMetrics(log, engines),
+		"raft":     sysdb.RaftMetrics(),
+	} {
+		if err := reg.Register(c); err != nil {
+			for _, cleanup := range cleanupFns {
+				cleanup()
+			}
+			return nil, errors.Wrapf(err, "registering %s metrics", name)
+		}
+	}
+
+	return cleanupFns, nil
+}
+
+func startPrometheusExporter(log logging.Logger, ip net.IP, port int) func() {
+	listenAddress := net.JoinHostPort(ip.String(), fmt.Sprint(port))
 
 	srv := http.Server{Addr: listenAddress}
 	http.Handle("/metrics", promhttp.HandlerFor(
@@ -106,9 +138,55 @@ func startPrometheusExporter(ctx context.Context, log logging.Logger, port int,
 		if err := srv.Shutdown(timedCtx); err != nil {
 			log.Infof("HTTP server didn't shut down within timeout: %s", err.Error())
 		}
+	}
+}
+
+// newPushSink returns the sink for a configured push exporter.
+func newPushSink(tpc *config.TelemetryPushConfig) (promexp.PushSink, error) {
+	switch tpc.Type {
+	case config.TelemetryPushRemoteWrite:
+		return promexp.NewRemoteWriteSink(tpc.Address), nil
+	case config.TelemetryPushStatsd:
+		return promexp.NewStatsdSink(tpc.Address, tpc.PushPrefix()), nil
+	case config.TelemetryPushGraphite:
+		return promexp.NewGraphiteSink(tpc.Address, tpc.PushPrefix()), nil
+	}
 
-		for _, cleanup := range cleanupFns {
-			cleanup()
+	return nil, errors.Errorf("unknown telemetry push type %q", tpc.Type)
+}
+
+// startPushExporters starts a goroutine for each configured exporter that
+// pushes the registered metrics until the returned function is called.
+func startPushExporters(log logging.Logger, gatherer prometheus.Gatherer, cfgs []*config.TelemetryPushConfig) (func(), error) {
+	exporters := make([]*promexp.PushExporter, 0, len(cfgs))
+	for _, tpc := range cfgs {
+		sink, err := newPushSink(tpc)
+		if err != nil {
+			return nil, err
 		}
+		exporters = append(exporters, promexp.NewPushExporter(log,
+			fmt.Sprintf("telemetry push (%s %s)", tpc.Type, tpc.Address), gatherer, sink,
+			promexp.PushExporterOpts{
+				Interval:   tpc.PushInterval(),
+				BatchSize:  tpc.PushBatchSize(),
+				MaxRetries: tpc.PushMaxRetries(),
+			}))
+	}
+
+	// The exporters outlive the context of the callback that starts them.
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, pe := range exporters {
+		wg.Add(1)
+		go func(pe *promexp.PushExporter) {
+			defer wg.Done()
+			pe.Run(ctx)
+		}(pe)
+	}
+
+	return func() {
+		log.Debug("Shutting down telemetry push exporters")
+		cancel()
+		wg.Wait()
 	}, nil
 }
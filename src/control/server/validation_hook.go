@@ -0,0 +1,245 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/daos-stack/daos/src/control/build"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+const (
+	validationHookVersion = 1
+	validationHookTimeout = 30 * time.Second
+	// maxHookReasonLen limits the length of the reason given by the
+	// validation hook for denying an operation.
+	maxHookReasonLen = 512
+)
+
+// validatedOp describes a destructive operation which must be approved by the
+// validation hook before being executed.
+type validatedOp struct {
+	name   string
+	msOnly bool // only performed on the MS leader
+}
+
+// validatedOps are the gRPC methods which must be approved by the validation
+// hook, keyed by full method name.
+var validatedOps = map[string]*validatedOp{
+	"/ctl.CtlSvc/StorageFormat": {name: "storage-format"},
+	"/mgmt.MgmtSvc/PoolDestroy": {name: "pool-destroy", msOnly: true},
+	"/mgmt.MgmtSvc/SystemErase": {name: "system-erase", msOnly: true},
+}
+
+// validationHookReq is the request passed to the validation hook, as JSON on
+// stdin for an executable or as the body of a POST request for a URL.
+type validationHookReq struct {
+	Version   int             `json:"version"`
+	System    string          `json:"system"`
+	Hostname  string          `json:"hostname"`
+	Operation string          `json:"operation"`
+	Initiator string          `json:"initiator"`
+	Request   json.RawMessage `json:"request"`
+}
+
+// validateFn submits an encoded validationHookReq to the hook, returning
+// whether the operation was approved and if not, the reason given by the hook.
+// An error is returned if the hook could not give a decision.
+type validateFn func(ctx context.Context, hook string, body []byte) (bool, string, error)
+
+// leaderChecker returns an error if this server is not the MS leader.
+type leaderChecker interface {
+	CheckLeader() error
+}
+
+// validationHook consults an external hook configured by the site before
+// destructive operations are executed, so that they can be gated by a
+// change-management process.
+type validationHook struct {
+	log      logging.Logger
+	hook     string
+	system   string
+	hostname string
+	leader   leaderChecker
+	validate validateFn
+}
+
+// newValidationHook returns a validationHook for the hook specified in the
+// supplied config, or nil if no hook is configured.
+func newValidationHook(log logging.Logger, cfg *config.Server, leader leaderChecker) *validationHook {
+	if cfg == nil || cfg.ValidationHook == "" {
+		return nil
+	}
+
+	vh := &validationHook{
+		log:      log,
+		hook:     cfg.ValidationHook,
+		system:   cfg.SystemName,
+		hostname: hostname(),
+		leader:   leader,
+		validate: func(ctx context.Context, hook string, body []byte) (bool, string, error) {
+			return runValidationExec(ctx, hook, build.ConfigDir, body)
+		},
+	}
+	if u, err := url.Parse(cfg.ValidationHook); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		vh.validate = postValidationReq
+	}
+
+	return vh
+}
+
+// hookReason returns the reason for a denial given in the hook output.
+func hookReason(out []byte) string {
+	reason := strings.TrimSpace(string(out))
+	if len(reason) > maxHookReasonLen {
+		reason = reason[:maxHookReasonLen] + "..."
+	}
+	return reason
+}
+
+// runValidationExec executes the hook at the given path, passing the request
+// on stdin. A zero exit status approves the operation, any other exit status
+// denies it with the output of the hook as the reason.
+func runValidationExec(ctx context.Context, path, requiredDir string, body []byte) (bool, string, error) {
+	if err := checkExecutable(path, requiredDir, execFaults{
+		notFound: config.FaultConfigValidationHookNotFound,
+		badPerms: config.FaultConfigValidationHookBadPerms,
+		insecure: config.FaultConfigValidationHookInsecure,
+	}); err != nil {
+		return false, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, validationHookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(body)
+	out, err := cmd.CombinedOutput()
+	if os.IsPermission(err) {
+		return false, "", config.FaultConfigValidationHookBadPerms
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return true, "", nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		return false, hookReason(out), nil
+	default:
+		return false, "", err
+	}
+}
+
+// postValidationReq posts the request to the hook URL. A 2xx status approves
+// the operation and a 4xx status denies it with the response body as the
+// reason, any other status is treated as a failure of the hook.
+func postValidationReq(ctx context.Context, url string, body []byte) (bool, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, validationHookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return true, "", nil
+	case resp.StatusCode >= 400 && resp.StatusCode <= 499:
+		out, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return false, "", err
+		}
+		reason := hookReason(out)
+		if reason == "" {
+			reason = resp.Status
+		}
+		return false, reason, nil
+	default:
+		return false, "", errors.Errorf("hook returned %s", resp.Status)
+	}
+}
+
+// check consults the hook, returning an error if the operation may not be
+// executed. Operations are blocked if the hook fails to give a decision.
+func (vh *validationHook) check(ctx context.Context, op *validatedOp, req interface{}) error {
+	params := []byte("{}")
+	if msg, ok := req.(proto.Message); ok {
+		var err error
+		if params, err = protojson.Marshal(msg); err != nil {
+			return FaultValidationHookFailed(op.name, err)
+		}
+	}
+
+	initiator, _ := initiatorFromContext(ctx)
+	body, err := json.Marshal(&validationHookReq{
+		Version:   validationHookVersion,
+		System:    vh.system,
+		Hostname:  vh.hostname,
+		Operation: op.name,
+		Initiator: initiator,
+		Request:   params,
+	})
+	if err != nil {
+		return FaultValidationHookFailed(op.name, err)
+	}
+
+	allowed, reason, err := vh.validate(ctx, vh.hook, body)
+	if err != nil {
+		vh.log.Errorf("validation hook %s: %s", vh.hook, err)
+		return FaultValidationHookFailed(op.name, err)
+	}
+	if !allowed {
+		vh.log.Infof("%s requested by %s denied by validation hook: %s", op.name, initiator, reason)
+		return FaultValidationHookDenied(op.name, reason)
+	}
+	vh.log.Debugf("%s requested by %s approved by validation hook", op.name, initiator)
+
+	return nil
+}
+
+func (vh *validationHook) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	op, found := validatedOps[info.FullMethod]
+	if !found {
+		return handler(ctx, req)
+	}
+
+	// MS requests received by a replica other than the leader are rejected
+	// by the handler, only the leader consults the hook
+	if op.msOnly && vh.leader.CheckLeader() != nil {
+		return handler(ctx, req)
+	}
+
+	if err := vh.check(ctx, op, req); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
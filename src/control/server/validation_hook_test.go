@@ -0,0 +1,251 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/daos-stack/daos/src/control/common"
+	mgmtpb "github.com/daos-stack/daos/src/control/common/proto/mgmt"
+	"github.com/daos-stack/daos/src/control/logging"
+	"github.com/daos-stack/daos/src/control/server/config"
+)
+
+func TestServer_runValidationExec(t *testing.T) {
+	tmpDir, cleanup := common.CreateTestDir(t)
+	defer cleanup()
+
+	createHook := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		createScriptFile(t, path, 0755, content)
+		return path
+	}
+
+	approveHook := createHook("approve.sh", "cat > /dev/null; exit 0")
+	denyHook := createHook("deny.sh", "cat > /dev/null; echo 'no change ticket'; exit 1")
+	opHook := createHook("op.sh",
+		`grep -q '"operation":"pool-destroy"' || { echo "unexpected request"; exit 1; }`)
+
+	tooLaxHook := filepath.Join(tmpDir, "toolax.sh")
+	createScriptFile(t, tooLaxHook, 0666, "exit 0")
+
+	for name, tc := range map[string]struct {
+		path      string
+		expAllow  bool
+		expReason string
+		expErr    error
+	}{
+		"missing hook": {
+			path:   filepath.Join(tmpDir, "notarealfile"),
+			expErr: config.FaultConfigValidationHookNotFound,
+		},
+		"insecure hook": {
+			path:   tooLaxHook,
+			expErr: config.FaultConfigValidationHookInsecure(tmpDir),
+		},
+		"denied": {
+			path:      denyHook,
+			expReason: "no change ticket",
+		},
+		"request passed on stdin": {
+			path:     opHook,
+			expAllow: true,
+		},
+		"approved": {
+			path:     approveHook,
+			expAllow: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			body := []byte(`{"version":1,"operation":"pool-destroy"}`)
+
+			allow, reason, err := runValidationExec(context.TODO(), tc.path, tmpDir, body)
+			common.CmpErr(t, tc.expErr, err)
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expAllow, allow, "unexpected decision")
+			common.AssertEqual(t, tc.expReason, reason, "unexpected reason")
+		})
+	}
+}
+
+func TestServer_postValidationReq(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status    int
+		respBody  string
+		expAllow  bool
+		expReason string
+		expErr    error
+	}{
+		"approved": {
+			status:   http.StatusOK,
+			expAllow: true,
+		},
+		"denied with reason": {
+			status:    http.StatusForbidden,
+			respBody:  "change freeze in effect\n",
+			expReason: "change freeze in effect",
+		},
+		"denied without reason": {
+			status:    http.StatusForbidden,
+			expReason: "403 Forbidden",
+		},
+		"hook error": {
+			status: http.StatusInternalServerError,
+			expErr: errors.New("500 Internal Server Error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var gotReq validationHookReq
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := json.Unmarshal(body, &gotReq); err != nil {
+					t.Fatal(err)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.respBody))
+			}))
+			defer srv.Close()
+
+			body := []byte(`{"version":1,"operation":"system-erase"}`)
+
+			allow, reason, err := postValidationReq(context.TODO(), srv.URL, body)
+			common.CmpErr(t, tc.expErr, err)
+			common.AssertEqual(t, "system-erase", gotReq.Operation, "unexpected request")
+			if tc.expErr != nil {
+				return
+			}
+
+			common.AssertEqual(t, tc.expAllow, allow, "unexpected decision")
+			common.AssertEqual(t, tc.expReason, reason, "unexpected reason")
+		})
+	}
+}
+
+type mockLeaderChecker struct {
+	err error
+}
+
+func (mlc *mockLeaderChecker) CheckLeader() error {
+	return mlc.err
+}
+
+func TestServer_validationHook_unaryInterceptor(t *testing.T) {
+	for name, tc := range map[string]struct {
+		method      string
+		notLeader   bool
+		allow       bool
+		reason      string
+		hookErr     error
+		expHookCall bool
+		expHandled  bool
+		expErr      error
+	}{
+		"operation not validated": {
+			method:     "/mgmt.MgmtSvc/PoolCreate",
+			expHandled: true,
+		},
+		"approved": {
+			method:      "/mgmt.MgmtSvc/PoolDestroy",
+			allow:       true,
+			expHookCall: true,
+			expHandled:  true,
+		},
+		"denied": {
+			method:      "/mgmt.MgmtSvc/PoolDestroy",
+			reason:      "no change ticket",
+			expHookCall: true,
+			expErr:      FaultValidationHookDenied("pool-destroy", "no change ticket"),
+		},
+		"hook failed": {
+			method:      "/mgmt.MgmtSvc/SystemErase",
+			hookErr:     errors.New("connection refused"),
+			expHookCall: true,
+			expErr:      FaultValidationHookFailed("system-erase", errors.New("connection refused")),
+		},
+		"ms operation on non-leader": {
+			method:     "/mgmt.MgmtSvc/SystemErase",
+			notLeader:  true,
+			expHandled: true,
+		},
+		"local operation on non-leader": {
+			method:      "/ctl.CtlSvc/StorageFormat",
+			notLeader:   true,
+			reason:      "maintenance window closed",
+			expHookCall: true,
+			expErr:      FaultValidationHookDenied("storage-format", "maintenance window closed"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			leader := &mockLeaderChecker{}
+			if tc.notLeader {
+				leader.err = errors.New("not leader")
+			}
+
+			var gotReq *validationHookReq
+			vh := &validationHook{
+				log:      log,
+				hook:     "/etc/daos/validation_hook",
+				system:   "daos_server",
+				hostname: "host1",
+				leader:   leader,
+				validate: func(_ context.Context, _ string, body []byte) (bool, string, error) {
+					gotReq = new(validationHookReq)
+					if err := json.Unmarshal(body, gotReq); err != nil {
+						t.Fatal(err)
+					}
+					return tc.allow, tc.reason, tc.hookErr
+				},
+			}
+
+			var handled bool
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handled = true
+				return nil, nil
+			}
+
+			req := &mgmtpb.PoolDestroyReq{Uuid: common.MockUUID()}
+			_, err := vh.unaryInterceptor(context.TODO(), req,
+				&grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			common.CmpErr(t, tc.expErr, err)
+			common.AssertEqual(t, tc.expHandled, handled, "unexpected handler call")
+			common.AssertEqual(t, tc.expHookCall, gotReq != nil, "unexpected hook call")
+			if gotReq == nil {
+				return
+			}
+
+			common.AssertEqual(t, validationHookVersion, gotReq.Version, "unexpected version")
+			common.AssertEqual(t, "daos_server", gotReq.System, "unexpected system")
+			common.AssertEqual(t, "host1", gotReq.Hostname, "unexpected hostname")
+			common.AssertEqual(t, validatedOps[tc.method].name, gotReq.Operation, "unexpected operation")
+			common.AssertEqual(t, "unauthenticated", gotReq.Initiator, "unexpected initiator")
+
+			var params map[string]interface{}
+			if err := json.Unmarshal(gotReq.Request, &params); err != nil {
+				t.Fatal(err)
+			}
+			common.AssertEqual(t, common.MockUUID(), params["uuid"], "unexpected request parameters")
+		})
+	}
+}
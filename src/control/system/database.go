@@ -44,6 +44,7 @@ type (
 		LeadershipTransfer() raft.Future
 		Shutdown() raft.Future
 		State() raft.RaftState
+		Stats() map[string]string
 	}
 
 	// syncRaft provides a wrapper for synchronized access to the
@@ -65,6 +66,11 @@ type (
 		MapVersion    uint32
 		Members       *MemberDatabase
 		Pools         *PoolDatabase
+		Maintenance   *MaintenanceDatabase
+		Locks         *LockDatabase
+		History       *HistoryDatabase
+		Agents        *AgentDatabase
+		PoolDefaults  *PoolDefaultsDatabase
 		SchemaVersion uint
 	}
 
@@ -91,6 +97,7 @@ type (
 		onRaftShutdown     []onRaftShutdownFn
 		shutdownCb         context.CancelFunc
 		shutdownErrCh      chan error
+		metrics            *raftMetrics
 
 		data *dbData
 	}
@@ -201,10 +208,25 @@ func NewDatabase(log logging.Logger, cfg *DatabaseConfig) (*Database, error) {
 				Uuids:  make(PoolUuidMap),
 				Labels: make(PoolLabelMap),
 			},
+			Maintenance: &MaintenanceDatabase{
+				Windows: make(map[uuid.UUID]*MaintenanceWindow),
+			},
+			Locks: &LockDatabase{
+				Locks: make(map[string]*AdminLock),
+			},
+			History: &HistoryDatabase{},
+			Agents: &AgentDatabase{
+				Agents: make(map[string]*AgentRecord),
+			},
+			PoolDefaults: &PoolDefaultsDatabase{
+				Props: make(map[string]*PoolPropDefault),
+			},
 			SchemaVersion: CurrentSchemaVersion,
 		},
 	}
 
+	db.metrics = newRaftMetrics(db)
+
 	for _, repAddr := range db.cfg.Replicas {
 		if !common.IsLocalAddr(repAddr) {
 			continue
@@ -445,6 +467,7 @@ func (db *Database) monitorLeadershipState(parent context.Context) {
 			close(db.shutdownErrCh)
 			return
 		case isLeader := <-db.raftLeaderNotifyCh:
+			db.metrics.leadershipChanged(isLeader)
 			if !isLeader {
 				db.log.Debugf("node %s lost MS leader state", db.replicaAddr)
 				if cancelGainedCtx != nil {
@@ -900,6 +923,252 @@ func (db *Database) UpdatePoolService(ps *PoolService) error {
 	return nil
 }
 
+// AddMaintenanceWindow records a new maintenance window.
+func (db *Database) AddMaintenanceWindow(mw *MaintenanceWindow) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	if mw.End.Before(mw.Start) {
+		return errors.Errorf("maintenance window %s ends before it starts", mw.ID)
+	}
+
+	db.data.RLock()
+	_, found := db.data.Maintenance.Windows[mw.ID]
+	db.data.RUnlock()
+	if found {
+		return errors.Errorf("maintenance window %s already exists", mw.ID)
+	}
+
+	return db.submitMaintenanceUpdate(raftOpAddMaintenanceWindow, mw)
+}
+
+// UpdateMaintenanceWindow updates an existing maintenance window.
+func (db *Database) UpdateMaintenanceWindow(mw *MaintenanceWindow) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	db.data.RLock()
+	_, found := db.data.Maintenance.Windows[mw.ID]
+	db.data.RUnlock()
+	if !found {
+		return errors.Errorf("maintenance window %s not found", mw.ID)
+	}
+
+	return db.submitMaintenanceUpdate(raftOpUpdateMaintenanceWindow, mw)
+}
+
+// ActiveMaintenanceWindows returns copies of the maintenance windows active
+// at the given time. If ranks are supplied, only windows covering at least
+// one of them are returned.
+func (db *Database) ActiveMaintenanceWindows(at time.Time, ranks ...Rank) ([]*MaintenanceWindow, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	return db.data.Maintenance.activeWindows(at, ranks...), nil
+}
+
+// RecordAgent records the version of an agent and the time it was last seen.
+// The update is skipped if the agent's version is unchanged and it was seen
+// within minInterval, so that agents frequently requesting attach info do not
+// generate a raft update for every request.
+func (db *Database) RecordAgent(ar *AgentRecord, minInterval time.Duration) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	db.data.RLock()
+	cur, found := db.data.Agents.Agents[ar.Host]
+	db.data.RUnlock()
+	if found && cur.Version == ar.Version && ar.LastSeen.Sub(cur.LastSeen) < minInterval {
+		return nil
+	}
+
+	return db.submitAgentUpdate(ar)
+}
+
+// Agents returns copies of the agents known to the system, ordered by host.
+func (db *Database) Agents() ([]*AgentRecord, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	return db.data.Agents.agents(), nil
+}
+
+// AcquireLocks records the supplied administrative locks. Either all of the
+// locks are acquired or, if any of them is already held by another owner,
+// none are.
+func (db *Database) AcquireLocks(locks []*AdminLock) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	if len(locks) == 0 {
+		return errors.New("no locks to acquire")
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	seen := make(map[string]struct{})
+	for _, al := range locks {
+		if al.Name == "" {
+			return errors.New("lock name must not be empty")
+		}
+		if al.Owner == "" {
+			return errors.Errorf("lock %q has no owner", al.Name)
+		}
+		if !al.Expires.After(al.Acquired) {
+			return errors.Errorf("lock %q expires before it is acquired", al.Name)
+		}
+		if _, dupe := seen[al.Name]; dupe {
+			return errors.Errorf("lock %q requested more than once", al.Name)
+		}
+		seen[al.Name] = struct{}{}
+	}
+
+	db.data.RLock()
+	for _, al := range locks {
+		cur, found := db.data.Locks.Locks[al.Name]
+		if found && cur.IsHeld(al.Acquired) {
+			db.data.RUnlock()
+			return &ErrLockHeld{Lock: copyAdminLock(cur)}
+		}
+	}
+	db.data.RUnlock()
+
+	return db.submitLockUpdate(raftOpAcquireLocks, &lockUpdate{Locks: locks})
+}
+
+// ReleaseLocks releases the named administrative locks. Unless force is set,
+// each lock must be held by the supplied owner. Names of locks that are not
+// held are ignored.
+func (db *Database) ReleaseLocks(names []string, owner string, force bool) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no locks to release")
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	lu := new(lockUpdate)
+	db.data.RLock()
+	for _, name := range names {
+		cur, found := db.data.Locks.Locks[name]
+		if !found {
+			continue
+		}
+		if !force && cur.Owner != owner {
+			db.data.RUnlock()
+			return errors.Errorf("lock %q is held by %q, not %q", name, cur.Owner, owner)
+		}
+		lu.Locks = append(lu.Locks, &AdminLock{Name: name})
+	}
+	db.data.RUnlock()
+
+	if len(lu.Locks) == 0 {
+		return nil
+	}
+	return db.submitLockUpdate(raftOpReleaseLocks, lu)
+}
+
+// HeldLocks returns copies of the administrative locks held at the given
+// time. If names are supplied, only locks with those names are returned.
+func (db *Database) HeldLocks(at time.Time, names ...string) ([]*AdminLock, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	return db.data.Locks.heldLocks(at, names...), nil
+}
+
+// RecordOperation appends a record of an administrative operation to the
+// operation history. The record ID is assigned when it is applied.
+func (db *Database) RecordOperation(rec *OperationRecord) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	if rec == nil {
+		return errors.New("nil operation record")
+	}
+	if rec.Operation == "" {
+		return errors.New("operation record has no operation name")
+	}
+
+	return db.submitHistoryUpdate(rec)
+}
+
+// OperationHistory returns copies of the recorded administrative operations
+// that match the supplied filter, oldest first.
+func (db *Database) OperationHistory(filter *HistoryFilter) ([]*OperationRecord, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	return db.data.History.records(filter), nil
+}
+
+// UpdatePoolPropDefaults removes the named pool property defaults and then
+// records the supplied defaults, replacing any existing defaults of the same
+// names. Property names and values are validated by the caller.
+func (db *Database) UpdatePoolPropDefaults(set []*PoolPropDefault, unset []string) error {
+	if err := db.CheckLeader(); err != nil {
+		return err
+	}
+	if len(set) == 0 && len(unset) == 0 {
+		return errors.New("no pool property defaults to update")
+	}
+	db.Lock()
+	defer db.Unlock()
+
+	seen := make(map[string]struct{})
+	for _, pd := range set {
+		if pd.Name == "" {
+			return errors.New("pool property name must not be empty")
+		}
+		if _, dupe := seen[pd.Name]; dupe {
+			return errors.Errorf("pool property %q supplied more than once", pd.Name)
+		}
+		seen[pd.Name] = struct{}{}
+	}
+	for _, name := range unset {
+		if _, dupe := seen[name]; dupe {
+			return errors.Errorf("pool property %q both set and unset", name)
+		}
+	}
+
+	return db.submitPoolDefaultsUpdate(&poolDefaultsUpdate{Set: set, Unset: unset})
+}
+
+// PoolPropDefaults returns copies of the system-level pool property
+// defaults, ordered by name.
+func (db *Database) PoolPropDefaults() ([]*PoolPropDefault, error) {
+	if err := db.CheckReplica(); err != nil {
+		return nil, err
+	}
+	db.data.RLock()
+	defer db.data.RUnlock()
+
+	return db.data.PoolDefaults.defaults(), nil
+}
+
 func (db *Database) handlePoolRepsUpdate(evt *events.RASEvent) {
 	ei := evt.GetPoolSvcInfo()
 	if ei == nil {
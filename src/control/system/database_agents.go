@@ -0,0 +1,62 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"sort"
+	"time"
+)
+
+// AgentRetention defines how long an agent is retained in the system
+// database after it last requested attach info.
+const AgentRetention = 30 * 24 * time.Hour
+
+type (
+	// AgentRecord describes a client agent that has requested attach info
+	// from the MS.
+	AgentRecord struct {
+		Host     string    `json:"host"`
+		Version  string    `json:"version"`
+		LastSeen time.Time `json:"last_seen"`
+	}
+
+	// AgentDatabase contains the known agents, keyed by host.
+	AgentDatabase struct {
+		Agents map[string]*AgentRecord
+	}
+)
+
+func copyAgentRecord(in *AgentRecord) *AgentRecord {
+	out := new(AgentRecord)
+	*out = *in
+	return out
+}
+
+// recordAgent adds or replaces the record for the agent's host, and removes
+// any agents last seen more than AgentRetention before it. The cutoff is
+// derived from the record itself rather than the local clock so that all
+// replicas prune identically.
+func (adb *AgentDatabase) recordAgent(ar *AgentRecord) {
+	cutoff := ar.LastSeen.Add(-AgentRetention)
+	for host, cur := range adb.Agents {
+		if cur.LastSeen.Before(cutoff) {
+			delete(adb.Agents, host)
+		}
+	}
+	adb.Agents[ar.Host] = ar
+}
+
+// agents returns copies of the known agents ordered by host.
+func (adb *AgentDatabase) agents() []*AgentRecord {
+	agents := make([]*AgentRecord, 0, len(adb.Agents))
+	for _, ar := range adb.Agents {
+		agents = append(agents, copyAgentRecord(ar))
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Host < agents[j].Host })
+
+	return agents
+}
@@ -0,0 +1,103 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"time"
+)
+
+// maxHistoryRecords is the number of operation records retained in the
+// history; once exceeded, the oldest records are discarded.
+const maxHistoryRecords = 1000
+
+type (
+	// OperationRecord describes an administrative operation performed on
+	// the system, who initiated it and what the outcome was.
+	OperationRecord struct {
+		ID         uint64
+		Time       time.Time
+		Operation  string
+		Initiator  string
+		Address    string
+		Host       string
+		Parameters string
+		Result     string
+		Failed     bool
+	}
+
+	// HistoryDatabase contains the recorded administrative operations,
+	// oldest first.
+	HistoryDatabase struct {
+		Records []*OperationRecord
+		NextID  uint64
+	}
+
+	// HistoryFilter selects the operation records returned from a history
+	// query. Zero values match all records.
+	HistoryFilter struct {
+		Operation  string
+		Initiator  string
+		Since      time.Time
+		FailedOnly bool
+		Limit      int
+	}
+)
+
+func copyOperationRecord(in *OperationRecord) *OperationRecord {
+	out := new(OperationRecord)
+	*out = *in
+	return out
+}
+
+func (hf *HistoryFilter) matches(rec *OperationRecord) bool {
+	if hf == nil {
+		return true
+	}
+	switch {
+	case hf.Operation != "" && hf.Operation != rec.Operation:
+		return false
+	case hf.Initiator != "" && hf.Initiator != rec.Initiator:
+		return false
+	case !hf.Since.IsZero() && rec.Time.Before(hf.Since):
+		return false
+	case hf.FailedOnly && !rec.Failed:
+		return false
+	}
+
+	return true
+}
+
+// addRecord appends the record to the history, assigning it the next ID and
+// discarding the oldest records if the history is full. IDs are assigned
+// when the update is applied so that they are identical on all replicas.
+func (hdb *HistoryDatabase) addRecord(rec *OperationRecord) {
+	hdb.NextID++
+	rec.ID = hdb.NextID
+	hdb.Records = append(hdb.Records, rec)
+
+	if excess := len(hdb.Records) - maxHistoryRecords; excess > 0 {
+		hdb.Records = append([]*OperationRecord{}, hdb.Records[excess:]...)
+	}
+}
+
+// records returns copies of the records matching the filter, oldest first.
+// If the filter specifies a limit, only the most recent matching records are
+// returned.
+func (hdb *HistoryDatabase) records(filter *HistoryFilter) []*OperationRecord {
+	var matched []*OperationRecord
+	for _, rec := range hdb.Records {
+		if filter.matches(rec) {
+			matched = append(matched, copyOperationRecord(rec))
+		}
+	}
+
+	if filter != nil && filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[len(matched)-filter.Limit:]
+	}
+
+	return matched
+}
@@ -0,0 +1,98 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"sort"
+	"time"
+)
+
+type (
+	// AdminLock is a named lock held by an administrative client in order
+	// to prevent conflicting operations from being run concurrently. A lock
+	// is released explicitly by its owner or implicitly once it expires.
+	AdminLock struct {
+		Name     string
+		Owner    string
+		Reason   string
+		Acquired time.Time
+		Expires  time.Time
+	}
+
+	// LockDatabase contains the set of recorded administrative locks.
+	LockDatabase struct {
+		Locks map[string]*AdminLock
+	}
+
+	// lockUpdate groups the locks acquired or released by a single
+	// request so that they are applied atomically.
+	lockUpdate struct {
+		Locks []*AdminLock
+	}
+)
+
+// IsHeld returns true if the lock has not expired at the supplied time.
+func (al *AdminLock) IsHeld(at time.Time) bool {
+	if al == nil {
+		return false
+	}
+	return at.Before(al.Expires)
+}
+
+func copyAdminLock(in *AdminLock) *AdminLock {
+	out := new(AdminLock)
+	*out = *in
+	return out
+}
+
+// acquireLocks records the supplied locks, replacing any expired locks of the
+// same names. Expired locks of other names are pruned based on the time of
+// acquisition rather than the local clock so that all replicas prune
+// identically.
+func (ldb *LockDatabase) acquireLocks(locks []*AdminLock) {
+	for _, al := range locks {
+		for name, cur := range ldb.Locks {
+			if !cur.IsHeld(al.Acquired) {
+				delete(ldb.Locks, name)
+			}
+		}
+		ldb.Locks[al.Name] = al
+	}
+}
+
+func (ldb *LockDatabase) releaseLocks(locks []*AdminLock) {
+	for _, al := range locks {
+		delete(ldb.Locks, al.Name)
+	}
+}
+
+// heldLocks returns the locks held at the given time, optionally filtered to
+// those with the supplied names, ordered by name.
+func (ldb *LockDatabase) heldLocks(at time.Time, names ...string) []*AdminLock {
+	var held []*AdminLock
+	for _, al := range ldb.Locks {
+		if !al.IsHeld(at) {
+			continue
+		}
+		match := len(names) == 0
+		for _, name := range names {
+			if al.Name == name {
+				match = true
+				break
+			}
+		}
+		if match {
+			held = append(held, copyAdminLock(al))
+		}
+	}
+
+	sort.Slice(held, func(i, j int) bool {
+		return held[i].Name < held[j].Name
+	})
+
+	return held
+}
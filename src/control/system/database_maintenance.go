@@ -0,0 +1,118 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceRetention defines how long a maintenance window is retained
+// in the system database after it has ended.
+const MaintenanceRetention = 30 * 24 * time.Hour
+
+type (
+	// MaintenanceWindow describes a period of time during which a set
+	// of ranks is undergoing administrative maintenance.
+	MaintenanceWindow struct {
+		ID     uuid.UUID
+		Hosts  string
+		Ranks  []Rank
+		Reason string
+		Start  time.Time
+		End    time.Time
+	}
+
+	// MaintenanceDatabase contains the set of recorded maintenance windows.
+	MaintenanceDatabase struct {
+		Windows map[uuid.UUID]*MaintenanceWindow
+	}
+)
+
+// IsActive returns true if the supplied time falls within the window.
+func (mw *MaintenanceWindow) IsActive(t time.Time) bool {
+	if mw == nil {
+		return false
+	}
+	return !t.Before(mw.Start) && t.Before(mw.End)
+}
+
+// HasRank returns true if the supplied rank is covered by the window.
+func (mw *MaintenanceWindow) HasRank(rank Rank) bool {
+	if mw == nil {
+		return false
+	}
+	for _, r := range mw.Ranks {
+		if r.Equals(rank) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyMaintenanceWindow(in *MaintenanceWindow) *MaintenanceWindow {
+	out := new(MaintenanceWindow)
+	*out = *in
+	out.Ranks = make([]Rank, len(in.Ranks))
+	copy(out.Ranks, in.Ranks)
+	return out
+}
+
+// addWindow adds a new window to the database, and removes any windows
+// which ended more than MaintenanceRetention before the new window started.
+// The cutoff is derived from the window itself rather than the local clock
+// so that all replicas prune identically.
+func (mdb *MaintenanceDatabase) addWindow(mw *MaintenanceWindow) {
+	cutoff := mw.Start.Add(-MaintenanceRetention)
+	for id, cur := range mdb.Windows {
+		if cur.End.Before(cutoff) {
+			delete(mdb.Windows, id)
+		}
+	}
+	mdb.Windows[mw.ID] = mw
+}
+
+func (mdb *MaintenanceDatabase) updateWindow(cur, mw *MaintenanceWindow) {
+	cur.Hosts = mw.Hosts
+	cur.Ranks = mw.Ranks
+	cur.Reason = mw.Reason
+	cur.Start = mw.Start
+	cur.End = mw.End
+}
+
+// activeWindows returns the windows active at the given time, optionally
+// filtered to those covering any of the supplied ranks, ordered by start
+// time.
+func (mdb *MaintenanceDatabase) activeWindows(at time.Time, ranks ...Rank) []*MaintenanceWindow {
+	var active []*MaintenanceWindow
+	for _, mw := range mdb.Windows {
+		if !mw.IsActive(at) {
+			continue
+		}
+		match := len(ranks) == 0
+		for _, r := range ranks {
+			if mw.HasRank(r) {
+				match = true
+				break
+			}
+		}
+		if match {
+			active = append(active, copyMaintenanceWindow(mw))
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Start.Equal(active[j].Start) {
+			return active[i].ID.String() < active[j].ID.String()
+		}
+		return active[i].Start.Before(active[j].Start)
+	})
+
+	return active
+}
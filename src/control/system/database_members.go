@@ -168,6 +168,8 @@ func (mdb *MemberDatabase) updateMember(m *Member) {
 	}
 	cur.state = m.state
 	cur.Info = m.Info
+	cur.Startup = m.Startup
+	cur.AdminFaultDomain = m.AdminFaultDomain
 
 	mdb.removeFromFaultDomainTree(cur)
 	cur.FaultDomain = m.FaultDomain
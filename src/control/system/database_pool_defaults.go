@@ -0,0 +1,64 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import "sort"
+
+type (
+	// PoolPropDefault is a system-level default value for a pool property,
+	// applied to pools created without a value for the property. An
+	// enforced default may not be overridden when a pool is created.
+	PoolPropDefault struct {
+		Name     string
+		Value    string
+		Enforced bool
+	}
+
+	// PoolDefaultsDatabase contains the system-level pool property
+	// defaults, keyed by property name.
+	PoolDefaultsDatabase struct {
+		Props map[string]*PoolPropDefault
+	}
+
+	// poolDefaultsUpdate groups the defaults set and unset by a single
+	// request so that they are applied atomically.
+	poolDefaultsUpdate struct {
+		Set   []*PoolPropDefault
+		Unset []string
+	}
+)
+
+func copyPoolPropDefault(in *PoolPropDefault) *PoolPropDefault {
+	out := new(PoolPropDefault)
+	*out = *in
+	return out
+}
+
+// updateDefaults removes the unset defaults and then records the set
+// defaults, replacing any existing defaults of the same names.
+func (pdb *PoolDefaultsDatabase) updateDefaults(pu *poolDefaultsUpdate) {
+	for _, name := range pu.Unset {
+		delete(pdb.Props, name)
+	}
+	for _, pd := range pu.Set {
+		pdb.Props[pd.Name] = pd
+	}
+}
+
+// defaults returns copies of the pool property defaults ordered by name.
+func (pdb *PoolDefaultsDatabase) defaults() []*PoolPropDefault {
+	out := make([]*PoolPropDefault, 0, len(pdb.Props))
+	for _, pd := range pdb.Props {
+		out = append(out, copyPoolPropDefault(pd))
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
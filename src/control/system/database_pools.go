@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
@@ -23,6 +24,9 @@ const (
 	PoolServiceStateReady
 	// PoolServiceStateDestroying indicates that the pool service is being destroyed
 	PoolServiceStateDestroying
+	// PoolServiceStateDisabled indicates that the pool is pending a delayed
+	// destroy and may not be used until it is restored
+	PoolServiceStateDisabled
 )
 
 type (
@@ -43,11 +47,14 @@ type (
 	// PoolService represents a pool service created to manage metadata
 	// for a DAOS Pool.
 	PoolService struct {
-		PoolUUID  uuid.UUID
-		PoolLabel string
-		State     PoolServiceState
-		Replicas  []Rank
-		Storage   *PoolServiceStorage
+		PoolUUID     uuid.UUID
+		PoolLabel    string
+		State        PoolServiceState
+		Replicas     []Rank
+		Storage      *PoolServiceStorage
+		Protected    bool      // pool may not be destroyed
+		CreatedAt    time.Time // set when the pool create is started
+		DestroyAfter time.Time // set when the pool is disabled pending destroy
 	}
 
 	// PoolRankMap provides a map of Rank->[]*PoolService.
@@ -141,6 +148,7 @@ func (pss PoolServiceState) String() string {
 		"Creating",
 		"Ready",
 		"Destroying",
+		"Disabled",
 	}[pss]
 }
 
@@ -244,6 +252,8 @@ func (pdb *PoolDatabase) updateService(cur, new *PoolService) {
 		panic("PoolDatabase.updateService() called with non-member pointer")
 	}
 	cur.State = new.State
+	cur.Protected = new.Protected
+	cur.DestroyAfter = new.DestroyAfter
 
 	// TODO: Update svc rank map
 	cur.Replicas = new.Replicas
@@ -14,6 +14,8 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"net"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -286,6 +288,69 @@ func TestSystem_Database_SnapshotRestore(t *testing.T) {
 		(*fsm)(db0).Apply(rl)
 	}
 
+	for i := 0; i < 4; i++ {
+		mw := &MaintenanceWindow{
+			ID:     uuid.New(),
+			Hosts:  fmt.Sprintf("host%d", i),
+			Ranks:  []Rank{Rank(i)},
+			Reason: "test",
+			Start:  time.Now(),
+			End:    time.Now().Add(time.Hour),
+		}
+		data, err := createRaftUpdate(raftOpAddMaintenanceWindow, mw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		(*fsm)(db0).Apply(&raft.Log{Data: data})
+	}
+
+	lu := &lockUpdate{
+		Locks: []*AdminLock{
+			{
+				Name:     "storage-format:host1",
+				Owner:    "admin@host0",
+				Reason:   "test",
+				Acquired: time.Now(),
+				Expires:  time.Now().Add(time.Hour),
+			},
+		},
+	}
+	data, err := createRaftUpdate(raftOpAcquireLocks, lu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	(*fsm)(db0).Apply(&raft.Log{Data: data})
+
+	data, err = createRaftUpdate(raftOpRecordOperation, &OperationRecord{
+		Time:       time.Now(),
+		Operation:  "PoolCreate",
+		Initiator:  "admin",
+		Parameters: "label:\"tank\"",
+		Result:     "ok",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	(*fsm)(db0).Apply(&raft.Log{Data: data})
+
+	data, err = createRaftUpdate(raftOpRecordAgent, &AgentRecord{
+		Host:     "10.0.0.1",
+		Version:  "2.0.0",
+		LastSeen: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	(*fsm)(db0).Apply(&raft.Log{Data: data})
+
+	data, err = createRaftUpdate(raftOpUpdatePoolDefaults, &poolDefaultsUpdate{
+		Set: []*PoolPropDefault{{Name: "self_heal", Value: "exclude", Enforced: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	(*fsm)(db0).Apply(&raft.Log{Data: data})
+
 	snap, err := (*fsm)(db0).Snapshot()
 	if err != nil {
 		t.Fatal(err)
@@ -375,6 +440,515 @@ func TestSystem_Database_BadApply(t *testing.T) {
 	}
 }
 
+func TestSystem_Database_MaintenanceWindows(t *testing.T) {
+	now := time.Now()
+	mockWindow := func(ranks []Rank, start, end time.Time) *MaintenanceWindow {
+		return &MaintenanceWindow{
+			ID:     uuid.New(),
+			Hosts:  "host1",
+			Ranks:  ranks,
+			Reason: "test",
+			Start:  start,
+			End:    end,
+		}
+	}
+	active0 := mockWindow([]Rank{0, 1}, now.Add(-time.Minute), now.Add(time.Hour))
+	active2 := mockWindow([]Rank{2}, now, now.Add(time.Hour))
+	ended := mockWindow([]Rank{0}, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	expired := mockWindow([]Rank{3}, now.Add(-MaintenanceRetention-2*time.Hour),
+		now.Add(-MaintenanceRetention-time.Hour))
+
+	for name, tc := range map[string]struct {
+		windows    []*MaintenanceWindow
+		update     *MaintenanceWindow
+		ranks      []Rank
+		expErr     error
+		expActive  []*MaintenanceWindow
+		expWindows int
+	}{
+		"none": {},
+		"all active": {
+			windows:    []*MaintenanceWindow{ended, active0, active2},
+			expActive:  []*MaintenanceWindow{active0, active2},
+			expWindows: 3,
+		},
+		"filtered by rank": {
+			windows:    []*MaintenanceWindow{ended, active0, active2},
+			ranks:      []Rank{1},
+			expActive:  []*MaintenanceWindow{active0},
+			expWindows: 3,
+		},
+		"no matching rank": {
+			windows:    []*MaintenanceWindow{active0, active2},
+			ranks:      []Rank{5},
+			expWindows: 2,
+		},
+		"expired windows pruned": {
+			windows:    []*MaintenanceWindow{expired, active2},
+			expActive:  []*MaintenanceWindow{active2},
+			expWindows: 1,
+		},
+		"ended by update": {
+			windows: []*MaintenanceWindow{active0, active2},
+			update: &MaintenanceWindow{
+				ID:     active0.ID,
+				Hosts:  active0.Hosts,
+				Ranks:  active0.Ranks,
+				Reason: active0.Reason,
+				Start:  active0.Start,
+				End:    now.Add(-time.Second),
+			},
+			expActive:  []*MaintenanceWindow{active2},
+			expWindows: 2,
+		},
+		"update unknown window": {
+			update: active0,
+			expErr: errors.New("not found"),
+		},
+		"ends before start": {
+			windows: []*MaintenanceWindow{mockWindow(nil, now, now.Add(-time.Hour))},
+			expErr:  errors.New("ends before"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+
+			var gotErr error
+			for _, mw := range tc.windows {
+				if gotErr = db.AddMaintenanceWindow(copyMaintenanceWindow(mw)); gotErr != nil {
+					break
+				}
+			}
+			if gotErr == nil && tc.update != nil {
+				gotErr = db.UpdateMaintenanceWindow(tc.update)
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotActive, err := db.ActiveMaintenanceWindows(now, tc.ranks...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expActive, gotActive); diff != "" {
+				t.Fatalf("unexpected active windows (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expWindows, len(db.data.Maintenance.Windows),
+				"unexpected number of stored windows")
+		})
+	}
+}
+
+func TestSystem_Database_Locks(t *testing.T) {
+	now := time.Now()
+	mockLock := func(name, owner string, acquired, expires time.Time) *AdminLock {
+		return &AdminLock{
+			Name:     name,
+			Owner:    owner,
+			Reason:   "test",
+			Acquired: acquired,
+			Expires:  expires,
+		}
+	}
+	lockA := mockLock("a", "admin1", now.Add(-time.Minute), now.Add(time.Hour))
+	lockB := mockLock("b", "admin1", now.Add(-time.Minute), now.Add(time.Hour))
+	lockC := mockLock("c", "admin2", now.Add(-time.Minute), now.Add(time.Hour))
+	expiredA := mockLock("a", "admin2", now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	for name, tc := range map[string]struct {
+		acquire  [][]*AdminLock
+		release  []string
+		owner    string
+		force    bool
+		names    []string
+		expErr   error
+		expHeld  []*AdminLock
+		expLocks int
+	}{
+		"none": {},
+		"acquire multiple": {
+			acquire:  [][]*AdminLock{{lockB, lockA}, {lockC}},
+			expHeld:  []*AdminLock{lockA, lockB, lockC},
+			expLocks: 3,
+		},
+		"filtered by name": {
+			acquire:  [][]*AdminLock{{lockA, lockB, lockC}},
+			names:    []string{"c", "d"},
+			expHeld:  []*AdminLock{lockC},
+			expLocks: 3,
+		},
+		"already held": {
+			acquire: [][]*AdminLock{
+				{lockA},
+				{mockLock("a", "admin2", now, now.Add(time.Hour))},
+			},
+			expErr: errors.New(`lock "a" is held by "admin1"`),
+		},
+		"acquire is atomic": {
+			acquire: [][]*AdminLock{
+				{lockA},
+				{lockC, mockLock("a", "admin2", now, now.Add(time.Hour))},
+			},
+			expErr: errors.New(`lock "a" is held`),
+		},
+		"expired lock replaced": {
+			acquire:  [][]*AdminLock{{expiredA, lockB}, {lockA}},
+			expHeld:  []*AdminLock{lockA, lockB},
+			expLocks: 2,
+		},
+		"expired lock pruned": {
+			acquire:  [][]*AdminLock{{expiredA}, {lockB}},
+			expHeld:  []*AdminLock{lockB},
+			expLocks: 1,
+		},
+		"duplicate names": {
+			acquire: [][]*AdminLock{{lockA, lockA}},
+			expErr:  errors.New("more than once"),
+		},
+		"no owner": {
+			acquire: [][]*AdminLock{{mockLock("a", "", now, now.Add(time.Hour))}},
+			expErr:  errors.New("no owner"),
+		},
+		"expires before acquired": {
+			acquire: [][]*AdminLock{{mockLock("a", "admin1", now, now)}},
+			expErr:  errors.New("expires before"),
+		},
+		"release": {
+			acquire:  [][]*AdminLock{{lockA, lockB, lockC}},
+			release:  []string{"a", "b", "unknown"},
+			owner:    "admin1",
+			expHeld:  []*AdminLock{lockC},
+			expLocks: 1,
+		},
+		"release wrong owner": {
+			acquire: [][]*AdminLock{{lockA, lockC}},
+			release: []string{"a", "c"},
+			owner:   "admin1",
+			expErr:  errors.New(`lock "c" is held by "admin2", not "admin1"`),
+		},
+		"release wrong owner forced": {
+			acquire:  [][]*AdminLock{{lockA, lockC}},
+			release:  []string{"c"},
+			owner:    "admin1",
+			force:    true,
+			expHeld:  []*AdminLock{lockA},
+			expLocks: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+
+			var gotErr error
+			for _, locks := range tc.acquire {
+				var toAcquire []*AdminLock
+				for _, al := range locks {
+					toAcquire = append(toAcquire, copyAdminLock(al))
+				}
+				if gotErr = db.AcquireLocks(toAcquire); gotErr != nil {
+					break
+				}
+			}
+			if gotErr == nil && len(tc.release) > 0 {
+				gotErr = db.ReleaseLocks(tc.release, tc.owner, tc.force)
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotHeld, err := db.HeldLocks(now, tc.names...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expHeld, gotHeld); diff != "" {
+				t.Fatalf("unexpected held locks (-want, +got):\n%s\n", diff)
+			}
+			common.AssertEqual(t, tc.expLocks, len(db.data.Locks.Locks),
+				"unexpected number of stored locks")
+		})
+	}
+}
+
+func TestSystem_Database_OperationHistory(t *testing.T) {
+	now := time.Now()
+	mockRecord := func(op, initiator string, age time.Duration, failed bool) *OperationRecord {
+		return &OperationRecord{
+			Time:      now.Add(-age),
+			Operation: op,
+			Initiator: initiator,
+			Host:      "host1",
+			Failed:    failed,
+		}
+	}
+	records := []*OperationRecord{
+		mockRecord("StorageFormat", "admin", 3*time.Hour, false),
+		mockRecord("PoolCreate", "admin", 2*time.Hour, true),
+		mockRecord("PoolCreate", "server", time.Hour, false),
+		mockRecord("SystemStop", "admin", time.Minute, false),
+	}
+	withID := func(id uint64, rec *OperationRecord) *OperationRecord {
+		out := copyOperationRecord(rec)
+		out.ID = id
+		return out
+	}
+
+	for name, tc := range map[string]struct {
+		records    []*OperationRecord
+		filter     *HistoryFilter
+		expErr     error
+		expRecords []*OperationRecord
+	}{
+		"empty": {},
+		"no operation name": {
+			records: []*OperationRecord{{Time: now}},
+			expErr:  errors.New("no operation name"),
+		},
+		"nil filter": {
+			records: records,
+			expRecords: []*OperationRecord{
+				withID(1, records[0]),
+				withID(2, records[1]),
+				withID(3, records[2]),
+				withID(4, records[3]),
+			},
+		},
+		"by operation": {
+			records:    records,
+			filter:     &HistoryFilter{Operation: "PoolCreate"},
+			expRecords: []*OperationRecord{withID(2, records[1]), withID(3, records[2])},
+		},
+		"by initiator": {
+			records:    records,
+			filter:     &HistoryFilter{Initiator: "server"},
+			expRecords: []*OperationRecord{withID(3, records[2])},
+		},
+		"since": {
+			records:    records,
+			filter:     &HistoryFilter{Since: now.Add(-90 * time.Minute)},
+			expRecords: []*OperationRecord{withID(3, records[2]), withID(4, records[3])},
+		},
+		"failed only": {
+			records:    records,
+			filter:     &HistoryFilter{FailedOnly: true},
+			expRecords: []*OperationRecord{withID(2, records[1])},
+		},
+		"limit returns most recent": {
+			records:    records,
+			filter:     &HistoryFilter{Initiator: "admin", Limit: 2},
+			expRecords: []*OperationRecord{withID(2, records[1]), withID(4, records[3])},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+
+			var gotErr error
+			for _, rec := range tc.records {
+				if gotErr = db.RecordOperation(copyOperationRecord(rec)); gotErr != nil {
+					break
+				}
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotRecords, err := db.OperationHistory(tc.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expRecords, gotRecords); diff != "" {
+				t.Fatalf("unexpected records (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_OperationHistoryLimit(t *testing.T) {
+	log, buf := logging.NewTestLogger(t.Name())
+	defer common.ShowBufferOnFailure(t, buf)
+
+	db := MockDatabase(t, log)
+	for i := 0; i < maxHistoryRecords+10; i++ {
+		if err := db.RecordOperation(&OperationRecord{Operation: "PoolCreate"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gotRecords, err := db.OperationHistory(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.AssertEqual(t, maxHistoryRecords, len(gotRecords), "unexpected number of records")
+	common.AssertEqual(t, uint64(11), gotRecords[0].ID, "unexpected oldest record")
+}
+
+func TestSystem_Database_Agents(t *testing.T) {
+	now := time.Now()
+
+	for name, tc := range map[string]struct {
+		records   []*AgentRecord
+		expAgents []*AgentRecord
+	}{
+		"empty": {
+			expAgents: []*AgentRecord{},
+		},
+		"ordered by host": {
+			records: []*AgentRecord{
+				{Host: "10.0.0.2", Version: "2.0.0", LastSeen: now},
+				{Host: "10.0.0.1", Version: "2.0.1", LastSeen: now},
+			},
+			expAgents: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.1", LastSeen: now},
+				{Host: "10.0.0.2", Version: "2.0.0", LastSeen: now},
+			},
+		},
+		"unchanged agent seen recently not updated": {
+			records: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.0", LastSeen: now},
+				{Host: "10.0.0.1", Version: "2.0.0", LastSeen: now.Add(time.Minute)},
+			},
+			expAgents: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.0", LastSeen: now},
+			},
+		},
+		"upgraded agent updated": {
+			records: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.0", LastSeen: now},
+				{Host: "10.0.0.1", Version: "2.0.1", LastSeen: now.Add(time.Minute)},
+			},
+			expAgents: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.1", LastSeen: now.Add(time.Minute)},
+			},
+		},
+		"stale agents pruned": {
+			records: []*AgentRecord{
+				{Host: "10.0.0.1", Version: "2.0.0", LastSeen: now.Add(-AgentRetention - time.Hour)},
+				{Host: "10.0.0.2", Version: "2.0.0", LastSeen: now},
+			},
+			expAgents: []*AgentRecord{
+				{Host: "10.0.0.2", Version: "2.0.0", LastSeen: now},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+			for _, ar := range tc.records {
+				if err := db.RecordAgent(copyAgentRecord(ar), time.Hour); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			gotAgents, err := db.Agents()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expAgents, gotAgents); diff != "" {
+				t.Fatalf("unexpected agents (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestSystem_Database_PoolPropDefaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		updates     []*poolDefaultsUpdate
+		expErr      error
+		expDefaults []*PoolPropDefault
+	}{
+		"empty": {
+			expDefaults: []*PoolPropDefault{},
+		},
+		"ordered by name": {
+			updates: []*poolDefaultsUpdate{
+				{Set: []*PoolPropDefault{
+					{Name: "space_rb", Value: "5"},
+					{Name: "reclaim", Value: "lazy", Enforced: true},
+				}},
+			},
+			expDefaults: []*PoolPropDefault{
+				{Name: "reclaim", Value: "lazy", Enforced: true},
+				{Name: "space_rb", Value: "5"},
+			},
+		},
+		"replaced and unset": {
+			updates: []*poolDefaultsUpdate{
+				{Set: []*PoolPropDefault{
+					{Name: "space_rb", Value: "5"},
+					{Name: "reclaim", Value: "lazy"},
+				}},
+				{
+					Set:   []*PoolPropDefault{{Name: "space_rb", Value: "10", Enforced: true}},
+					Unset: []string{"reclaim", "self_heal"},
+				},
+			},
+			expDefaults: []*PoolPropDefault{
+				{Name: "space_rb", Value: "10", Enforced: true},
+			},
+		},
+		"nothing to update": {
+			updates: []*poolDefaultsUpdate{{}},
+			expErr:  errors.New("no pool property defaults"),
+		},
+		"duplicate name": {
+			updates: []*poolDefaultsUpdate{
+				{Set: []*PoolPropDefault{
+					{Name: "reclaim", Value: "lazy"},
+					{Name: "reclaim", Value: "time"},
+				}},
+			},
+			expErr: errors.New("more than once"),
+		},
+		"set and unset": {
+			updates: []*poolDefaultsUpdate{
+				{
+					Set:   []*PoolPropDefault{{Name: "reclaim", Value: "lazy"}},
+					Unset: []string{"reclaim"},
+				},
+			},
+			expErr: errors.New("both set and unset"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			db := MockDatabase(t, log)
+
+			var gotErr error
+			for _, pu := range tc.updates {
+				if gotErr = db.UpdatePoolPropDefaults(pu.Set, pu.Unset); gotErr != nil {
+					break
+				}
+			}
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			gotDefaults, err := db.PoolPropDefaults()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expDefaults, gotDefaults); diff != "" {
+				t.Fatalf("unexpected defaults (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
+
 func raftUpdateTestMember(t *testing.T, db *Database, op raftOp, member *Member) {
 	t.Helper()
 
@@ -813,3 +1387,114 @@ func TestSystem_Database_GroupMap(t *testing.T) {
 		})
 	}
 }
+
+func TestSystem_Database_RaftStatus(t *testing.T) {
+	snapTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		notReplica bool
+		stats      map[string]string
+		snapshots  bool
+		expStatus  *RaftStatus
+		expErr     error
+	}{
+		"not replica": {
+			notReplica: true,
+			expErr:     &ErrNotReplica{},
+		},
+		"no snapshots": {
+			stats: map[string]string{
+				"term":           "2",
+				"last_log_index": "10",
+				"commit_index":   "10",
+				"applied_index":  "9",
+			},
+			expStatus: &RaftStatus{
+				State:        "Leader",
+				Leader:       "127.0.0.1:10001",
+				Term:         2,
+				LastIndex:    10,
+				CommitIndex:  10,
+				AppliedIndex: 9,
+				DBSize:       4,
+			},
+		},
+		"with snapshots": {
+			stats: map[string]string{
+				"term":                "3",
+				"last_log_index":      "40",
+				"commit_index":        "40",
+				"applied_index":       "40",
+				"last_snapshot_index": "32",
+			},
+			snapshots: true,
+			expStatus: &RaftStatus{
+				State:             "Leader",
+				Leader:            "127.0.0.1:10001",
+				Term:              3,
+				LastIndex:         40,
+				CommitIndex:       40,
+				AppliedIndex:      40,
+				LastSnapshotIndex: 32,
+				LastSnapshotTime:  snapTime,
+				DBSize:            4,
+			},
+		},
+		"bad stat": {
+			stats: map[string]string{
+				"term": "two",
+			},
+			expErr: errors.New("invalid raft term"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			testDir, cleanup := common.CreateTestDir(t)
+			defer cleanup()
+
+			if err := ioutil.WriteFile(filepath.Join(testDir, sysDBFile), []byte("test"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if tc.snapshots {
+				for i, mtime := range []time.Time{snapTime.Add(-time.Hour), snapTime} {
+					snapDir := filepath.Join(testDir, "snapshots", fmt.Sprintf("snap-%d", i))
+					if err := os.MkdirAll(snapDir, 0700); err != nil {
+						t.Fatal(err)
+					}
+					metaPath := filepath.Join(snapDir, "meta.json")
+					if err := ioutil.WriteFile(metaPath, []byte("{}"), 0600); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.Chtimes(metaPath, mtime, mtime); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			var db *Database
+			if tc.notReplica {
+				db = MockDatabaseWithAddr(t, log, nil)
+			} else {
+				db = MockDatabase(t, log)
+			}
+			db.cfg.RaftDir = testDir
+			db.raft.setSvc(newMockRaftService(&mockRaftServiceConfig{
+				State:         raft.Leader,
+				ServerAddress: raft.ServerAddress(db.getReplica().String()),
+				Stats:         tc.stats,
+			}, (*fsm)(db)))
+
+			gotStatus, gotErr := db.RaftStatus()
+			common.CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.expStatus, gotStatus, cmpopts.EquateApproxTime(time.Second)); diff != "" {
+				t.Fatalf("unexpected status (-want, +got):\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -223,3 +224,25 @@ func IsPoolNotFound(err error) bool {
 	_, ok := errors.Cause(err).(*ErrPoolNotFound)
 	return ok
 }
+
+// ErrLockHeld indicates that an administrative lock could not be acquired
+// because it is already held.
+type ErrLockHeld struct {
+	Lock *AdminLock
+}
+
+func (err *ErrLockHeld) Error() string {
+	msg := fmt.Sprintf("lock %q is held by %q until %s", err.Lock.Name, err.Lock.Owner,
+		err.Lock.Expires.Format(time.RFC3339))
+	if err.Lock.Reason != "" {
+		msg += fmt.Sprintf(" (%s)", err.Lock.Reason)
+	}
+	return msg
+}
+
+// IsLockHeld returns a boolean indicating whether or not the
+// supplied error is an instance of ErrLockHeld.
+func IsLockHeld(err error) bool {
+	_, ok := errors.Cause(err).(*ErrLockHeld)
+	return ok
+}
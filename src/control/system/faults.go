@@ -8,6 +8,9 @@ package system
 
 import (
 	"fmt"
+	"net"
+
+	"github.com/google/uuid"
 
 	"github.com/daos-stack/daos/src/control/fault"
 	"github.com/daos-stack/daos/src/control/fault/code"
@@ -22,6 +25,33 @@ func FaultBadFaultDomainDepth(domain *FaultDomain, expDepth int) *fault.Fault {
 		"reconfigure the fault domain with a depth consistent with other system members, and restart the server")
 }
 
+// FaultJoinDuplicateUUID generates a fault indicating that the server could not
+// join as a new member because its UUID is already in use by another rank.
+func FaultJoinDuplicateUUID(uuid uuid.UUID, curRank Rank) *fault.Fault {
+	return systemFault(code.SystemJoinDuplicateUUID,
+		fmt.Sprintf("cannot join system as a new member with uuid %s, already in use by rank %d", uuid, curRank),
+		"if the engine storage was cloned from another server, reformat it, and restart the server")
+}
+
+// FaultJoinDuplicateFabricURI generates a fault indicating that the server
+// could not join because its fabric address is already in use by another
+// available rank.
+func FaultJoinDuplicateFabricURI(uri string, curRank Rank) *fault.Fault {
+	return systemFault(code.SystemJoinDuplicateFabricURI,
+		fmt.Sprintf("cannot join system with fabric address %q, already in use by rank %d", uri, curRank),
+		"ensure that each engine is configured with a unique fabric interface and port, and restart the server")
+}
+
+// FaultJoinDuplicateHostname generates a fault indicating that the server could
+// not join because its hostname is already in use by an available rank on a
+// different host.
+func FaultJoinDuplicateHostname(hostname string, addr *net.TCPAddr, curRank Rank, curAddr *net.TCPAddr) *fault.Fault {
+	return systemFault(code.SystemJoinDuplicateHostname,
+		fmt.Sprintf("cannot join system from %s with hostname %q, already in use by rank %d at %s",
+			addr, hostname, curRank, curAddr),
+		"ensure that each server has a unique hostname, and restart the server")
+}
+
 func systemFault(code code.Code, desc, res string) *fault.Fault {
 	return &fault.Fault{
 		Domain:      "system",
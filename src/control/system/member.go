@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 	"github.com/google/uuid"
@@ -120,6 +121,9 @@ func (ms MemberState) isTransitionIllegal(to MemberState) bool {
 	if ms == to {
 		return true // identical state
 	}
+	if ms == MemberStateExcluded {
+		return true // exclusion may only be cleared administratively
+	}
 
 	return map[MemberState]map[MemberState]bool{
 		MemberStateAwaitFormat: {
@@ -155,6 +159,24 @@ func (ms MemberState) isTransitionIllegal(to MemberState) bool {
 	}[ms][to]
 }
 
+// StartupTimeline records when each phase of the most recent startup of a
+// data-plane instance was reached.
+type StartupTimeline struct {
+	StorageReady  time.Time `json:"storage_ready"`  // storage formatted and mounted
+	FabricUp      time.Time `json:"fabric_up"`      // engine reported its fabric URI
+	Joined        time.Time `json:"joined"`         // rank joined the system
+	PoolsRestored time.Time `json:"pools_restored"` // engine set up and pool handles restored
+}
+
+// ClockSkew records the offset of the clock of a member's host from that of
+// the MS replica which measured it.
+type ClockSkew struct {
+	Offset    time.Duration `json:"offset"`     // positive if the host is ahead
+	RoundTrip time.Duration `json:"round_trip"` // duration of the measurement
+	Checked   time.Time     `json:"checked"`    // time of the measurement
+	Exceeded  bool          `json:"exceeded"`   // offset exceeds the threshold
+}
+
 // Member refers to a data-plane instance that is a member of this DAOS
 // system running on host with the control-plane listening at "Addr".
 type Member struct {
@@ -166,6 +188,17 @@ type Member struct {
 	state          MemberState
 	Info           string       `json:"info"`
 	FaultDomain    *FaultDomain `json:"fault_domain"`
+	Hostname       string       `json:"hostname,omitempty"`
+	// AdminFaultDomain indicates that the fault domain was assigned by an
+	// administrator and is retained when the member rejoins.
+	AdminFaultDomain bool             `json:"admin_fault_domain,omitempty"`
+	Startup          *StartupTimeline `json:"startup,omitempty"`
+	// ClockSkew is not stored in the system database, it is set from
+	// the latest measurement when the member is queried.
+	ClockSkew *ClockSkew `json:"clock_skew,omitempty"`
+	// ConfigHash is not stored in the system database, it is set from
+	// the latest heartbeat of the member's host when the member is queried.
+	ConfigHash string `json:"config_hash,omitempty"`
 }
 
 // MarshalJSON marshals system.Member to JSON.
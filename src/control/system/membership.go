@@ -8,6 +8,7 @@ package system
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"sort"
@@ -87,6 +88,7 @@ type JoinRequest struct {
 	FabricURI      string
 	FabricContexts uint32
 	FaultDomain    *FaultDomain
+	Hostname       string
 }
 
 // JoinResponse contains information returned from join membership update.
@@ -130,12 +132,23 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		if curMember.UUID != req.UUID {
 			return nil, errUuidChanged(req.UUID, curMember.UUID, curMember.Rank)
 		}
+		if err := m.checkReqUnique(req); err != nil {
+			return nil, err
+		}
 
-		if !curMember.FaultDomain.Equals(req.FaultDomain) {
+		switch {
+		case curMember.FaultDomain.Equals(req.FaultDomain):
+		case curMember.AdminFaultDomain:
+			m.log.Infof("rank %d retains assigned fault domain %q rather than %q",
+				curMember.Rank,
+				curMember.FaultDomain.String(),
+				req.FaultDomain.String())
+		default:
 			m.log.Infof("fault domain for rank %d changed from %q to %q",
 				curMember.Rank,
 				curMember.FaultDomain.String(),
 				req.FaultDomain.String())
+			curMember.FaultDomain = req.FaultDomain
 		}
 
 		resp.PrevState = curMember.state
@@ -144,7 +157,9 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		curMember.Addr = req.ControlAddr
 		curMember.FabricURI = req.FabricURI
 		curMember.FabricContexts = req.FabricContexts
-		curMember.FaultDomain = req.FaultDomain
+		curMember.Hostname = req.Hostname
+		// timeline is recorded once the new startup has completed
+		curMember.Startup = nil
 		if err := m.db.UpdateMember(curMember); err != nil {
 			return nil, err
 		}
@@ -165,6 +180,12 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 	if err := m.checkReqFaultDomain(req); err != nil {
 		return nil, err
 	}
+	if dupe, err := m.db.FindMemberByUUID(req.UUID); err == nil {
+		return nil, FaultJoinDuplicateUUID(req.UUID, dupe.Rank)
+	}
+	if err := m.checkReqUnique(req); err != nil {
+		return nil, err
+	}
 
 	newMember := &Member{
 		Rank:           req.Rank,
@@ -173,6 +194,7 @@ func (m *Membership) Join(req *JoinRequest) (resp *JoinResponse, err error) {
 		FabricURI:      req.FabricURI,
 		FabricContexts: req.FabricContexts,
 		FaultDomain:    req.FaultDomain,
+		Hostname:       req.Hostname,
 		state:          MemberStateJoined,
 	}
 	if err := m.db.AddMember(newMember); err != nil {
@@ -198,6 +220,33 @@ func (m *Membership) checkReqFaultDomain(req *JoinRequest) error {
 	return nil
 }
 
+// checkReqUnique checks that the fabric address and hostname of the joining
+// member are not already in use by other available members, a conflict would
+// otherwise corrupt the group map.
+func (m *Membership) checkReqUnique(req *JoinRequest) error {
+	members, err := m.db.AllMembers()
+	if err != nil {
+		return err
+	}
+
+	for _, other := range members {
+		if other.UUID == req.UUID || other.state&AvailableMemberFilter == 0 {
+			continue
+		}
+
+		if req.FabricURI != "" && other.FabricURI == req.FabricURI {
+			return FaultJoinDuplicateFabricURI(req.FabricURI, other.Rank)
+		}
+		// engines on the same host share a hostname
+		if req.Hostname != "" && other.Hostname == req.Hostname &&
+			req.ControlAddr != nil && !req.ControlAddr.IP.Equal(other.Addr.IP) {
+			return FaultJoinDuplicateHostname(req.Hostname, req.ControlAddr, other.Rank, other.Addr)
+		}
+	}
+
+	return nil
+}
+
 // AddOrReplace adds member to membership or replaces member if it exists.
 //
 // Note: this method updates state without checking if state transition is
@@ -492,6 +541,98 @@ func (m *Membership) MarkRankDead(rank Rank) error {
 	return m.db.UpdateMember(member)
 }
 
+// ExcludeRank administratively excludes a rank from the system. Excluded
+// ranks are removed from CaRT group map updates and are refused when they
+// attempt to rejoin until the exclusion is cleared.
+func (m *Membership) ExcludeRank(rank Rank, reason string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return err
+	}
+
+	if member.State() == MemberStateExcluded {
+		return errors.Errorf("rank %d is already excluded", rank)
+	}
+
+	member.state = MemberStateExcluded
+	member.Info = reason
+	return m.db.UpdateMember(member)
+}
+
+// ClearRankExclusion clears the administrative exclusion of a rank, leaving
+// it in the stopped state so that it may rejoin the system when restarted.
+func (m *Membership) ClearRankExclusion(rank Rank) error {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return err
+	}
+
+	if member.State() != MemberStateExcluded {
+		return errors.Errorf("rank %d is not excluded", rank)
+	}
+
+	member.state = MemberStateStopped
+	member.Info = ""
+	return m.db.UpdateMember(member)
+}
+
+// SetRankFaultDomain assigns a fault domain to a rank. The assigned fault
+// domain is retained when the rank rejoins the system, in place of the fault
+// domain reported by its server. The depth of the fault domain must match that
+// of the other members.
+func (m *Membership) SetRankFaultDomain(rank Rank, fd *FaultDomain) error {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return err
+	}
+
+	if fd.Empty() {
+		return errors.New("fault domain must not be empty")
+	}
+	count, err := m.db.MemberCount()
+	if err != nil {
+		return err
+	}
+	// The tree depth includes the rank layer.
+	expDepth := m.db.FaultDomainTree().Depth() - 1
+	if count > 1 && fd.NumLevels() != expDepth {
+		return errors.Errorf("fault domain %q has %d layers, need %d",
+			fd, fd.NumLevels(), expDepth)
+	}
+
+	member.FaultDomain = fd
+	member.AdminFaultDomain = true
+	return m.db.UpdateMember(member)
+}
+
+// ClearRankFaultDomain clears the fault domain assignment of a rank so that the
+// fault domain reported by its server is used from the next time it joins.
+func (m *Membership) ClearRankFaultDomain(rank Rank) error {
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(rank)
+	if err != nil {
+		return err
+	}
+
+	if !member.AdminFaultDomain {
+		return errors.Errorf("rank %d has no assigned fault domain", rank)
+	}
+
+	member.AdminFaultDomain = false
+	return m.db.UpdateMember(member)
+}
+
 func (m *Membership) handleEngineFailure(evt *events.RASEvent) {
 	ei := evt.GetEngineStateInfo()
 	if ei == nil {
@@ -526,11 +667,41 @@ func (m *Membership) handleEngineFailure(evt *events.RASEvent) {
 	}
 }
 
+func (m *Membership) handleEngineStarted(evt *events.RASEvent) {
+	si := evt.GetStrInfo()
+	if si == nil {
+		m.log.Error("no extended info in EngineStarted event received")
+		return
+	}
+
+	timeline := new(StartupTimeline)
+	if err := json.Unmarshal([]byte(*si), timeline); err != nil {
+		m.log.Errorf("decoding startup timeline of rank %d: %s", evt.Rank, err)
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	member, err := m.db.FindMemberByRank(Rank(evt.Rank))
+	if err != nil {
+		m.log.Errorf("member with rank %d not found", evt.Rank)
+		return
+	}
+
+	member.Startup = timeline
+	if err := m.db.UpdateMember(member); err != nil {
+		m.log.Errorf("updating member with rank %d: %s", member.Rank, err)
+	}
+}
+
 // OnEvent handles events on channel and updates member states accordingly.
 func (m *Membership) OnEvent(_ context.Context, evt *events.RASEvent) {
 	switch evt.ID {
 	case events.RASEngineDied:
 		m.handleEngineFailure(evt)
+	case events.RASEngineStarted:
+		m.handleEngineStarted(evt)
 	default:
 		m.log.Debugf("no handler registered for event: %v", evt)
 	}
@@ -8,6 +8,7 @@ package system
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"testing"
@@ -677,6 +678,15 @@ func TestSystem_Membership_Join(t *testing.T) {
 	newUUID := uuid.New()
 	newMember := MockMember(t, 2, MemberStateJoined).WithFaultDomain(fd2)
 	newMemberShallowFD := MockMember(t, 3, MemberStateJoined).WithFaultDomain(shallowFD)
+	withHostname := func(m *Member, hostname string) *Member {
+		m.Hostname = hostname
+		return m
+	}
+	adminFDMember := func() *Member {
+		m := MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1)
+		m.AdminFaultDomain = true
+		return m
+	}
 
 	expMapVer := uint32(len(defaultCurMembers) + 1)
 
@@ -722,6 +732,24 @@ func TestSystem_Membership_Join(t *testing.T) {
 				MapVersion: expMapVer,
 			},
 		},
+		"rejoin retains assigned fault domain": {
+			curMembers: []*Member{
+				adminFDMember(),
+				MockMember(t, 1, MemberStateJoined).WithFaultDomain(fd1),
+			},
+			req: &JoinRequest{
+				Rank:        curMember.Rank,
+				UUID:        curMember.UUID,
+				ControlAddr: curMember.Addr,
+				FabricURI:   curMember.Addr.String(),
+				FaultDomain: fd2,
+			},
+			expResp: &JoinResponse{
+				Member:     adminFDMember(),
+				PrevState:  curMember.state,
+				MapVersion: expMapVer,
+			},
+		},
 		"rejoin with existing UUID and unknown rank": {
 			req: &JoinRequest{
 				Rank:        Rank(42),
@@ -730,7 +758,7 @@ func TestSystem_Membership_Join(t *testing.T) {
 				FabricURI:   curMember.Addr.String(),
 				FaultDomain: curMember.FaultDomain,
 			},
-			expErr: errUuidExists(curMember.UUID),
+			expErr: FaultJoinDuplicateUUID(curMember.UUID, curMember.Rank),
 		},
 		"rejoin with existing UUID and nil rank": {
 			req: &JoinRequest{
@@ -768,6 +796,93 @@ func TestSystem_Membership_Join(t *testing.T) {
 				MapVersion: expMapVer,
 			},
 		},
+		"new member with dupe fabric URI": {
+			req: &JoinRequest{
+				Rank:           NilRank,
+				UUID:           newMember.UUID,
+				ControlAddr:    newMember.Addr,
+				FabricURI:      curMember.FabricURI,
+				FabricContexts: newMember.FabricContexts,
+				FaultDomain:    newMember.FaultDomain,
+			},
+			expErr: FaultJoinDuplicateFabricURI(curMember.FabricURI, curMember.Rank),
+		},
+		"new member reuses fabric URI of stopped member": {
+			curMembers: []*Member{
+				MockMember(t, 0, MemberStateStopped).WithFaultDomain(fd1),
+			},
+			req: &JoinRequest{
+				Rank:           NilRank,
+				UUID:           newMember.UUID,
+				ControlAddr:    newMember.Addr,
+				FabricURI:      curMember.FabricURI,
+				FabricContexts: newMember.FabricContexts,
+				FaultDomain:    newMember.FaultDomain,
+			},
+			expResp: &JoinResponse{
+				Created: true,
+				Member: &Member{
+					Rank:           1,
+					UUID:           newMember.UUID,
+					Addr:           newMember.Addr,
+					FabricURI:      curMember.FabricURI,
+					FabricContexts: newMember.FabricContexts,
+					FaultDomain:    newMember.FaultDomain,
+				},
+				PrevState:  MemberStateUnknown,
+				MapVersion: 2,
+			},
+		},
+		"rejoin with fabric URI of other member": {
+			req: &JoinRequest{
+				Rank:        curMember.Rank,
+				UUID:        curMember.UUID,
+				ControlAddr: curMember.Addr,
+				FabricURI:   defaultCurMembers[1].FabricURI,
+				FaultDomain: curMember.FaultDomain,
+			},
+			expErr: FaultJoinDuplicateFabricURI(defaultCurMembers[1].FabricURI, defaultCurMembers[1].Rank),
+		},
+		"new member with dupe hostname on different host": {
+			curMembers: []*Member{
+				withHostname(MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1), "host1"),
+			},
+			req: &JoinRequest{
+				Rank:        NilRank,
+				UUID:        newMember.UUID,
+				ControlAddr: newMember.Addr,
+				FabricURI:   newMember.FabricURI,
+				FaultDomain: newMember.FaultDomain,
+				Hostname:    "host1",
+			},
+			expErr: FaultJoinDuplicateHostname("host1", newMember.Addr, curMember.Rank, curMember.Addr),
+		},
+		"new member with same hostname on same host": {
+			curMembers: []*Member{
+				withHostname(MockMember(t, 0, MemberStateJoined).WithFaultDomain(fd1), "host1"),
+			},
+			req: &JoinRequest{
+				Rank:        NilRank,
+				UUID:        newMember.UUID,
+				ControlAddr: curMember.Addr,
+				FabricURI:   newMember.FabricURI,
+				FaultDomain: fd1,
+				Hostname:    "host1",
+			},
+			expResp: &JoinResponse{
+				Created: true,
+				Member: &Member{
+					Rank:        1,
+					UUID:        newMember.UUID,
+					Addr:        curMember.Addr,
+					FabricURI:   newMember.FabricURI,
+					FaultDomain: fd1,
+					Hostname:    "host1",
+				},
+				PrevState:  MemberStateUnknown,
+				MapVersion: 2,
+			},
+		},
 		"new member with bad fault domain depth": {
 			req: &JoinRequest{
 				Rank:           NilRank,
@@ -844,6 +959,22 @@ func TestSystem_Membership_Join(t *testing.T) {
 }
 
 func TestSystem_Membership_OnEvent(t *testing.T) {
+	started := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeline := &StartupTimeline{
+		StorageReady:  started,
+		FabricUp:      started.Add(time.Second),
+		Joined:        started.Add(2 * time.Second),
+		PoolsRestored: started.Add(3 * time.Second),
+	}
+	timelineJSON, err := json.Marshal(timeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withStartup := func(m *Member) *Member {
+		m.Startup = timeline
+		return m
+	}
+
 	members := Members{
 		MockMember(t, 0, MemberStateJoined),
 		MockMember(t, 1, MemberStateJoined),
@@ -878,6 +1009,21 @@ func TestSystem_Membership_OnEvent(t *testing.T) {
 				MockMember(t, 3, MemberStateEvicted),
 			},
 		},
+		"startup timeline recorded on engine start": {
+			members: members,
+			event:   events.NewEngineStartedEvent("foo", 0, 1, string(timelineJSON)),
+			expMembers: Members{
+				MockMember(t, 0, MemberStateJoined),
+				withStartup(MockMember(t, 1, MemberStateJoined)),
+				MockMember(t, 2, MemberStateStopped),
+				MockMember(t, 3, MemberStateEvicted),
+			},
+		},
+		"invalid startup timeline ignored": {
+			members:    members,
+			event:      events.NewEngineStartedEvent("foo", 0, 1, "not a timeline"),
+			expMembers: members,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			log, buf := logging.NewTestLogger(t.Name())
@@ -906,6 +1052,148 @@ func TestSystem_Membership_OnEvent(t *testing.T) {
 	}
 }
 
+func TestSystem_Membership_ExcludeRank(t *testing.T) {
+	for name, tc := range map[string]struct {
+		member   *Member
+		clear    bool
+		expState MemberState
+		expInfo  string
+		expErr   error
+	}{
+		"exclude joined rank": {
+			member:   MockMember(t, 1, MemberStateJoined),
+			expState: MemberStateExcluded,
+			expInfo:  "too many errors",
+		},
+		"exclude already excluded rank": {
+			member: MockMember(t, 1, MemberStateExcluded),
+			expErr: errors.New("already excluded"),
+		},
+		"clear excluded rank": {
+			member:   MockMember(t, 1, MemberStateExcluded),
+			clear:    true,
+			expState: MemberStateStopped,
+		},
+		"clear rank not excluded": {
+			member: MockMember(t, 1, MemberStateJoined),
+			clear:  true,
+			expErr: errors.New("not excluded"),
+		},
+		"unknown rank": {
+			member: MockMember(t, 2, MemberStateJoined),
+			expErr: errors.New("unable to find"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer ShowBufferOnFailure(t, buf)
+
+			ms := populateMembership(t, log, tc.member)
+
+			var gotErr error
+			if tc.clear {
+				gotErr = ms.ClearRankExclusion(1)
+			} else {
+				gotErr = ms.ExcludeRank(1, "too many errors")
+			}
+			CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			m, err := ms.Get(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertEqual(t, tc.expState, m.State(), "unexpected state")
+			AssertEqual(t, tc.expInfo, m.Info, "unexpected info")
+
+			// excluded state may only be left administratively
+			if m.State() == MemberStateExcluded {
+				if err := ms.MarkRankDead(1); err == nil {
+					t.Fatal("expected excluded rank to not be marked dead")
+				}
+			}
+		})
+	}
+}
+
+func TestSystem_Membership_SetRankFaultDomain(t *testing.T) {
+	fd1 := MustCreateFaultDomainFromString("/host1")
+	rack1 := MustCreateFaultDomainFromString("/rack1")
+
+	for name, tc := range map[string]struct {
+		admin     bool
+		domain    *FaultDomain
+		clear     bool
+		rank      Rank
+		expDomain *FaultDomain
+		expAdmin  bool
+		expErr    error
+	}{
+		"assign": {
+			domain:    rack1,
+			rank:      1,
+			expDomain: rack1,
+			expAdmin:  true,
+		},
+		"assign empty": {
+			domain: MustCreateFaultDomain(),
+			rank:   1,
+			expErr: errors.New("must not be empty"),
+		},
+		"assign bad depth": {
+			domain: MustCreateFaultDomainFromString("/rack1/host1"),
+			rank:   1,
+			expErr: errors.New("has 2 layers, need 1"),
+		},
+		"clear": {
+			admin:     true,
+			clear:     true,
+			rank:      1,
+			expDomain: fd1,
+		},
+		"clear unassigned": {
+			clear:  true,
+			rank:   1,
+			expErr: errors.New("no assigned fault domain"),
+		},
+		"unknown rank": {
+			domain: rack1,
+			rank:   3,
+			expErr: errors.New("unable to find"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer ShowBufferOnFailure(t, buf)
+
+			member := MockMember(t, 1, MemberStateJoined).WithFaultDomain(fd1)
+			member.AdminFaultDomain = tc.admin
+			ms := populateMembership(t, log, member,
+				MockMember(t, 2, MemberStateJoined).WithFaultDomain(fd1))
+
+			var gotErr error
+			if tc.clear {
+				gotErr = ms.ClearRankFaultDomain(tc.rank)
+			} else {
+				gotErr = ms.SetRankFaultDomain(tc.rank, tc.domain)
+			}
+			CmpErr(t, tc.expErr, gotErr)
+			if tc.expErr != nil {
+				return
+			}
+
+			m, err := ms.Get(tc.rank)
+			if err != nil {
+				t.Fatal(err)
+			}
+			AssertEqual(t, tc.expDomain.String(), m.FaultDomain.String(), "unexpected fault domain")
+			AssertEqual(t, tc.expAdmin, m.AdminFaultDomain, "unexpected admin fault domain flag")
+		})
+	}
+}
+
 func TestSystem_Membership_CompressedFaultDomainTree(t *testing.T) {
 	rankDomain := func(parent string, rank uint32) *FaultDomain {
 		parentFd := MustCreateFaultDomainFromString(parent)
@@ -69,6 +69,7 @@ type (
 		LeaderCh      <-chan bool
 		ServerAddress raft.ServerAddress
 		State         raft.RaftState
+		Stats         map[string]string
 	}
 	mockRaftService struct {
 		cfg mockRaftServiceConfig
@@ -119,6 +120,10 @@ func (mrs *mockRaftService) State() raft.RaftState {
 	return mrs.cfg.State
 }
 
+func (mrs *mockRaftService) Stats() map[string]string {
+	return mrs.cfg.Stats
+}
+
 func newMockRaftService(cfg *mockRaftServiceConfig, fsm raft.FSM) *mockRaftService {
 	if cfg == nil {
 		cfg = &mockRaftServiceConfig{
@@ -9,7 +9,9 @@ package system
 import (
 	"encoding/json"
 	"io"
+	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	transport "github.com/Jille/raft-grpc-transport"
@@ -38,6 +40,13 @@ const (
 	raftOpAddPoolService
 	raftOpUpdatePoolService
 	raftOpRemovePoolService
+	raftOpAddMaintenanceWindow
+	raftOpUpdateMaintenanceWindow
+	raftOpAcquireLocks
+	raftOpReleaseLocks
+	raftOpRecordOperation
+	raftOpRecordAgent
+	raftOpUpdatePoolDefaults
 
 	sysDBFile = "daos_system.db"
 )
@@ -71,9 +80,104 @@ func (ro raftOp) String() string {
 		"addPoolService",
 		"updatePoolService",
 		"removePoolService",
+		"addMaintenanceWindow",
+		"updateMaintenanceWindow",
+		"acquireLocks",
+		"releaseLocks",
+		"recordOperation",
+		"recordAgent",
+		"updatePoolDefaults",
 	}[ro]
 }
 
+// RaftStatus describes the raft state of the local MS replica.
+type RaftStatus struct {
+	State             string
+	Leader            string
+	Term              uint64
+	LastIndex         uint64
+	CommitIndex       uint64
+	AppliedIndex      uint64
+	LastSnapshotIndex uint64
+	LastSnapshotTime  time.Time
+	DBSize            uint64
+}
+
+// lastSnapshotTime returns the time at which the most recent snapshot in the
+// raft directory was written, or the zero time if there are none.
+func lastSnapshotTime(raftDir string) (time.Time, error) {
+	metas, err := filepath.Glob(filepath.Join(raftDir, "snapshots", "*", "meta.json"))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var last time.Time
+	for _, meta := range metas {
+		fi, err := os.Stat(meta)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(last) {
+			last = fi.ModTime()
+		}
+	}
+
+	return last, nil
+}
+
+// RaftStatus returns the raft state of the local MS replica along with the
+// size of its database file.
+func (db *Database) RaftStatus() (*RaftStatus, error) {
+	if !db.IsReplica() {
+		return nil, &ErrNotReplica{db.cfg.stringReplicas(nil)}
+	}
+
+	status := new(RaftStatus)
+	if err := db.raft.withReadLock(func(svc raftService) error {
+		status.State = svc.State().String()
+		status.Leader = string(svc.Leader())
+
+		stats := svc.Stats()
+		for key, val := range map[string]*uint64{
+			"term":                &status.Term,
+			"last_log_index":      &status.LastIndex,
+			"commit_index":        &status.CommitIndex,
+			"applied_index":       &status.AppliedIndex,
+			"last_snapshot_index": &status.LastSnapshotIndex,
+		} {
+			if _, found := stats[key]; !found {
+				continue
+			}
+			var err error
+			if *val, err = strconv.ParseUint(stats[key], 10, 64); err != nil {
+				return errors.Wrapf(err, "invalid raft %s", key)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if db.cfg.RaftDir == "" {
+		return status, nil
+	}
+
+	var err error
+	if status.LastSnapshotTime, err = lastSnapshotTime(db.cfg.RaftDir); err != nil {
+		return nil, errors.Wrap(err, "reading raft snapshots")
+	}
+
+	fi, err := os.Stat(filepath.Join(db.cfg.RaftDir, sysDBFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		status.DBSize = uint64(fi.Size())
+	}
+
+	return status, nil
+}
+
 // ResignLeadership causes this instance to give up its raft
 // leadership state. No-op if there is only one replica configured.
 func (db *Database) ResignLeadership(cause error) error {
@@ -282,10 +386,63 @@ func (db *Database) submitPoolUpdate(op raftOp, ps *PoolService) error {
 	return db.submitRaftUpdate(data)
 }
 
+// submitMaintenanceUpdate submits the given maintenance window update
+// operation to the raft service.
+func (db *Database) submitMaintenanceUpdate(op raftOp, mw *MaintenanceWindow) error {
+	data, err := createRaftUpdate(op, mw)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitLockUpdate submits the given administrative lock update operation
+// to the raft service.
+func (db *Database) submitLockUpdate(op raftOp, lu *lockUpdate) error {
+	data, err := createRaftUpdate(op, lu)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitHistoryUpdate submits the given operation record to the raft
+// service.
+func (db *Database) submitHistoryUpdate(rec *OperationRecord) error {
+	data, err := createRaftUpdate(raftOpRecordOperation, rec)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitAgentUpdate submits the given agent record to the raft service.
+func (db *Database) submitAgentUpdate(ar *AgentRecord) error {
+	data, err := createRaftUpdate(raftOpRecordAgent, ar)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
+// submitPoolDefaultsUpdate submits the given pool property defaults update
+// to the raft service.
+func (db *Database) submitPoolDefaultsUpdate(pu *poolDefaultsUpdate) error {
+	data, err := createRaftUpdate(raftOpUpdatePoolDefaults, pu)
+	if err != nil {
+		return err
+	}
+	return db.submitRaftUpdate(data)
+}
+
 // submitRaftUpdate submits the serialized operation to the raft service.
 func (db *Database) submitRaftUpdate(data []byte) error {
 	return db.raft.withReadLock(func(svc raftService) error {
-		return svc.Apply(data, 0).Error()
+		start := time.Now()
+		err := svc.Apply(data, 0).Error()
+		db.metrics.committed(err, time.Since(start))
+
+		return err
 	})
 }
 
@@ -303,6 +460,7 @@ type fsm Database
 // to bring this node back into the raft cluster.
 func (f *fsm) EmergencyShutdown(err error) {
 	f.log.Errorf("EMERGENCY RAFT SHUTDOWN due to %s", err)
+	f.metrics.applyErrors.Inc()
 	_ = f.raft.withReadLock(func(svc raftService) error {
 		// Call .Error() on the future returned from
 		// raft.Shutdown() in order to block on completion.
@@ -325,6 +483,16 @@ func (f *fsm) Apply(l *raft.Log) interface{} {
 		f.data.applyMemberUpdate(c.Op, c.Data, f.EmergencyShutdown)
 	case raftOpAddPoolService, raftOpUpdatePoolService, raftOpRemovePoolService:
 		f.data.applyPoolUpdate(c.Op, c.Data, f.EmergencyShutdown)
+	case raftOpAddMaintenanceWindow, raftOpUpdateMaintenanceWindow:
+		f.data.applyMaintenanceUpdate(c.Op, c.Data, f.EmergencyShutdown)
+	case raftOpAcquireLocks, raftOpReleaseLocks:
+		f.data.applyLockUpdate(c.Op, c.Data, f.EmergencyShutdown)
+	case raftOpRecordOperation:
+		f.data.applyHistoryUpdate(c.Data, f.EmergencyShutdown)
+	case raftOpRecordAgent:
+		f.data.applyAgentUpdate(c.Data, f.EmergencyShutdown)
+	case raftOpUpdatePoolDefaults:
+		f.data.applyPoolDefaultsUpdate(c.Data, f.EmergencyShutdown)
 	default:
 		f.EmergencyShutdown(errors.Errorf("unhandled Apply operation: %d", c.Op))
 		return nil
@@ -395,6 +563,106 @@ func (d *dbData) applyPoolUpdate(op raftOp, data []byte, panicFn func(error)) {
 	d.MapVersion++
 }
 
+// applyMaintenanceUpdate is responsible for applying the maintenance
+// window update operation to the database. Maintenance windows do not
+// affect the system map, so the map version is not incremented.
+func (d *dbData) applyMaintenanceUpdate(op raftOp, data []byte, panicFn func(error)) {
+	mw := new(MaintenanceWindow)
+	if err := json.Unmarshal(data, mw); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode maintenance window update"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	switch op {
+	case raftOpAddMaintenanceWindow:
+		d.Maintenance.addWindow(mw)
+	case raftOpUpdateMaintenanceWindow:
+		cur, found := d.Maintenance.Windows[mw.ID]
+		if !found {
+			panicFn(errors.Errorf("maintenance window update for unknown window %+v", mw))
+			return
+		}
+		d.Maintenance.updateWindow(cur, mw)
+	default:
+		panicFn(errors.Errorf("unhandled Maintenance Window Apply operation: %d", op))
+		return
+	}
+}
+
+// applyLockUpdate is responsible for applying the administrative lock
+// update operation to the database. Locks do not affect the system map,
+// so the map version is not incremented.
+func (d *dbData) applyLockUpdate(op raftOp, data []byte, panicFn func(error)) {
+	lu := new(lockUpdate)
+	if err := json.Unmarshal(data, lu); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode lock update"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	switch op {
+	case raftOpAcquireLocks:
+		d.Locks.acquireLocks(lu.Locks)
+	case raftOpReleaseLocks:
+		d.Locks.releaseLocks(lu.Locks)
+	default:
+		panicFn(errors.Errorf("unhandled Lock Apply operation: %d", op))
+		return
+	}
+}
+
+// applyHistoryUpdate is responsible for appending an operation record to
+// the history. The history does not affect the system map, so the map version
+// is not incremented.
+func (d *dbData) applyHistoryUpdate(data []byte, panicFn func(error)) {
+	rec := new(OperationRecord)
+	if err := json.Unmarshal(data, rec); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode operation record"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.History.addRecord(rec)
+}
+
+// applyAgentUpdate is responsible for recording an agent. Agents do not
+// affect the system map, so the map version is not incremented.
+func (d *dbData) applyAgentUpdate(data []byte, panicFn func(error)) {
+	ar := new(AgentRecord)
+	if err := json.Unmarshal(data, ar); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode agent record"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.Agents.recordAgent(ar)
+}
+
+// applyPoolDefaultsUpdate is responsible for updating the pool property
+// defaults. The defaults do not affect the system map, so the map version is
+// not incremented.
+func (d *dbData) applyPoolDefaultsUpdate(data []byte, panicFn func(error)) {
+	pu := new(poolDefaultsUpdate)
+	if err := json.Unmarshal(data, pu); err != nil {
+		panicFn(errors.Wrap(err, "failed to decode pool defaults update"))
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.PoolDefaults.updateDefaults(pu)
+}
+
 // Snapshot is called to support log compaction, so that we don't have to keep
 // every log entry from the start of the system. Instead, the raft service periodically
 // creates a point-in-time snapshot which can be used to restore the current state, or
@@ -409,6 +677,7 @@ func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
 	}
 
 	f.log.Debugf("created raft db snapshot (map version %d)", f.data.MapVersion)
+	f.metrics.snapshots.WithLabelValues("create").Inc()
 	return &fsmSnapshot{data}, nil
 }
 
@@ -427,10 +696,16 @@ func (f *fsm) Restore(rc io.ReadCloser) error {
 	f.data.Lock()
 	f.data.Members = db.data.Members
 	f.data.Pools = db.data.Pools
+	f.data.Maintenance = db.data.Maintenance
+	f.data.Locks = db.data.Locks
+	f.data.History = db.data.History
+	f.data.Agents = db.data.Agents
+	f.data.PoolDefaults = db.data.PoolDefaults
 	f.data.NextRank = db.data.NextRank
 	f.data.MapVersion = db.data.MapVersion
 	f.data.Unlock()
 	f.log.Debugf("db snapshot loaded (map version %d)", db.data.MapVersion)
+	f.metrics.snapshots.WithLabelValues("restore").Inc()
 	return nil
 }
 
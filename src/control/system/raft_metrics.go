@@ -0,0 +1,147 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// raftMetrics exports the health of the raft service replicating the MS
+// database. Events are counted as they occur and the state of the local
+// replica is read from the raft service when collected.
+type raftMetrics struct {
+	db *Database
+
+	leaderChanges *prometheus.CounterVec
+	commitLatency prometheus.Histogram
+	commitErrors  prometheus.Counter
+	snapshots     *prometheus.CounterVec
+	applyErrors   prometheus.Counter
+
+	leader            *prometheus.Desc
+	term              *prometheus.Desc
+	commitIndex       *prometheus.Desc
+	appliedIndex      *prometheus.Desc
+	lastSnapshotIndex *prometheus.Desc
+	dbSize            *prometheus.Desc
+}
+
+func newRaftMetrics(db *Database) *raftMetrics {
+	gaugeDesc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("control", "raft", name), help, nil, nil)
+	}
+
+	return &raftMetrics{
+		db: db,
+		leaderChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "raft",
+			Name:      "leadership_changes_total",
+			Help:      "Number of times the local replica gained or lost MS leadership.",
+		}, []string{"change"}),
+		commitLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "control",
+			Subsystem: "raft",
+			Name:      "commit_duration_seconds",
+			Help:      "Time taken for updates submitted by the MS leader to be committed and applied.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 10),
+		}),
+		commitErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "raft",
+			Name:      "commit_errors_total",
+			Help:      "Number of updates submitted by the MS leader that failed to commit.",
+		}),
+		snapshots: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "raft",
+			Name:      "snapshots_total",
+			Help:      "Number of MS database snapshots created or restored by the local replica.",
+		}, []string{"op"}),
+		applyErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "control",
+			Subsystem: "raft",
+			Name:      "fsm_apply_errors_total",
+			Help:      "Number of committed log entries that failed to apply, each causing a raft shutdown.",
+		}),
+		leader:            gaugeDesc("leader", "Whether the local replica is the MS leader."),
+		term:              gaugeDesc("term", "Current raft term of the local replica."),
+		commitIndex:       gaugeDesc("commit_index", "Index of the last committed raft log entry."),
+		appliedIndex:      gaugeDesc("applied_index", "Index of the last raft log entry applied to the MS database."),
+		lastSnapshotIndex: gaugeDesc("last_snapshot_index", "Index of the last raft log entry included in a snapshot."),
+		dbSize:            gaugeDesc("db_size_bytes", "Size of the MS database file."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (rm *raftMetrics) Describe(ch chan<- *prometheus.Desc) {
+	rm.leaderChanges.Describe(ch)
+	rm.commitLatency.Describe(ch)
+	rm.commitErrors.Describe(ch)
+	rm.snapshots.Describe(ch)
+	rm.applyErrors.Describe(ch)
+	ch <- rm.leader
+	ch <- rm.term
+	ch <- rm.commitIndex
+	ch <- rm.appliedIndex
+	ch <- rm.lastSnapshotIndex
+	ch <- rm.dbSize
+}
+
+// Collect implements prometheus.Collector. The state of the local replica is
+// omitted if it is not an MS replica or its raft service is unavailable.
+func (rm *raftMetrics) Collect(ch chan<- prometheus.Metric) {
+	rm.leaderChanges.Collect(ch)
+	rm.commitLatency.Collect(ch)
+	rm.commitErrors.Collect(ch)
+	rm.snapshots.Collect(ch)
+	rm.applyErrors.Collect(ch)
+
+	status, err := rm.db.RaftStatus()
+	if err != nil {
+		return
+	}
+
+	var leader float64
+	if status.State == "Leader" {
+		leader = 1
+	}
+	for desc, val := range map[*prometheus.Desc]float64{
+		rm.leader:            leader,
+		rm.term:              float64(status.Term),
+		rm.commitIndex:       float64(status.CommitIndex),
+		rm.appliedIndex:      float64(status.AppliedIndex),
+		rm.lastSnapshotIndex: float64(status.LastSnapshotIndex),
+		rm.dbSize:            float64(status.DBSize),
+	} {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, val)
+	}
+}
+
+func (rm *raftMetrics) leadershipChanged(isLeader bool) {
+	change := "lost"
+	if isLeader {
+		change = "gained"
+	}
+	rm.leaderChanges.WithLabelValues(change).Inc()
+}
+
+func (rm *raftMetrics) committed(err error, elapsed time.Duration) {
+	if err != nil {
+		rm.commitErrors.Inc()
+		return
+	}
+	rm.commitLatency.Observe(elapsed.Seconds())
+}
+
+// RaftMetrics returns a collector exporting the health of the raft service
+// replicating the MS database.
+func (db *Database) RaftMetrics() prometheus.Collector {
+	return db.metrics
+}
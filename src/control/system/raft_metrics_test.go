@@ -0,0 +1,118 @@
+//
+// (C) Copyright 2021 Intel Corporation.
+//
+// SPDX-License-Identifier: BSD-2-Clause-Patent
+//
+
+package system
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/raft"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/daos-stack/daos/src/control/common"
+	"github.com/daos-stack/daos/src/control/logging"
+)
+
+func TestSystem_Database_RaftMetrics(t *testing.T) {
+	for name, tc := range map[string]struct {
+		notReplica bool
+		badApply   bool
+		expMetrics []string
+		notExp     []string
+	}{
+		"not replica": {
+			notReplica: true,
+			expMetrics: []string{
+				`control_raft_leadership_changes_total{change="gained"} 1`,
+				`control_raft_commit_duration_seconds_count 1`,
+				`control_raft_snapshots_total{op="create"} 1`,
+				`control_raft_fsm_apply_errors_total 0`,
+			},
+			notExp: []string{"control_raft_leader ", "control_raft_term "},
+		},
+		"replica": {
+			expMetrics: []string{
+				`control_raft_leadership_changes_total{change="gained"} 1`,
+				`control_raft_commit_duration_seconds_count 1`,
+				`control_raft_commit_errors_total 0`,
+				`control_raft_snapshots_total{op="create"} 1`,
+				`control_raft_fsm_apply_errors_total 0`,
+				`control_raft_leader 1`,
+				`control_raft_term 2`,
+				`control_raft_commit_index 10`,
+				`control_raft_applied_index 9`,
+			},
+		},
+		"failed apply": {
+			badApply: true,
+			expMetrics: []string{
+				`control_raft_fsm_apply_errors_total 1`,
+				`control_raft_leader 0`,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			log, buf := logging.NewTestLogger(t.Name())
+			defer common.ShowBufferOnFailure(t, buf)
+
+			var db *Database
+			if tc.notReplica {
+				db = MockDatabaseWithAddr(t, log, nil)
+			} else {
+				db = MockDatabase(t, log)
+			}
+			db.raft.setSvc(newMockRaftService(&mockRaftServiceConfig{
+				State: raft.Leader,
+				Stats: map[string]string{
+					"term":           "2",
+					"last_log_index": "10",
+					"commit_index":   "10",
+					"applied_index":  "9",
+				},
+			}, (*fsm)(db)))
+
+			db.metrics.leadershipChanged(true)
+			mu := &memberUpdate{Member: MockMember(t, 1, MemberStateJoined)}
+			if err := db.submitMemberUpdate(raftOpAddMember, mu); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := (*fsm)(db).Snapshot(); err != nil {
+				t.Fatal(err)
+			}
+			if tc.badApply {
+				(*fsm)(db).Apply(&raft.Log{Data: []byte{0, 1, 2}})
+			}
+
+			reg := prometheus.NewRegistry()
+			if err := reg.Register(db.RaftMetrics()); err != nil {
+				t.Fatal(err)
+			}
+			rec := httptest.NewRecorder()
+			promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(rec,
+				httptest.NewRequest("GET", "/metrics", nil))
+			body, err := ioutil.ReadAll(rec.Result().Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotMetrics := string(body)
+
+			for _, exp := range tc.expMetrics {
+				if !strings.Contains(gotMetrics, exp+"\n") {
+					t.Errorf("expected %q in metrics:\n%s", exp, gotMetrics)
+				}
+			}
+			for _, notExp := range tc.notExp {
+				if strings.Contains(gotMetrics, notExp) {
+					t.Errorf("unexpected %q in metrics:\n%s", notExp, gotMetrics)
+				}
+			}
+		})
+	}
+}
@@ -814,6 +814,8 @@ Options:\n\
       Boolean set to inhibit collection of NVME health data\n\
   --mem_size=mem_size, -r mem_size\n\
       Allocates mem_size MB for SPDK when using primary process mode\n\
+  --version, -V\n\
+      Print the engine version and exit\n\
   --help, -h\n\
       Print this description\n",
 		prog, prog, modules, daos_sysname, dss_storage_path,
@@ -839,6 +841,7 @@ parse(int argc, char **argv)
 		{ "xshelpernr",		required_argument,	NULL,	'x' },
 		{ "instance_idx",	required_argument,	NULL,	'I' },
 		{ "bypass_health_chk",	no_argument,		NULL,	'b' },
+		{ "version",		no_argument,		NULL,	'V' },
 		{ NULL,			0,			NULL,	0}
 	};
 	int	rc = 0;
@@ -846,7 +849,7 @@ parse(int argc, char **argv)
 
 	/* load all of modules by default */
 	sprintf(modules, "%s", MODULE_LIST);
-	while ((c = getopt_long(argc, argv, "c:d:f:g:hi:m:n:p:r:t:s:x:I:b",
+	while ((c = getopt_long(argc, argv, "c:d:f:g:hi:m:n:p:r:t:s:x:I:bV",
 				opts, NULL)) != -1) {
 		switch (c) {
 		case 'm':
@@ -906,6 +909,9 @@ parse(int argc, char **argv)
 		case 'b':
 			dss_nvme_bypass_health_check = true;
 			break;
+		case 'V':
+			printf("DAOS I/O Engine v%s\n", DAOS_VERSION);
+			exit(EXIT_SUCCESS);
 		default:
 			usage(argv[0], stderr);
 			rc = -DER_INVAL;
@@ -1021,6 +1027,7 @@ main(int argc, char **argv)
 	sigset_t	set;
 	int		sig;
 	int		rc;
+	bool		flush = true;
 
 	/** parse command line arguments */
 	rc = parse(argc, argv);
@@ -1141,6 +1148,18 @@ main(int argc, char **argv)
 		break;
 	}
 
+	/**
+	 * The control plane disables the flush when the engine should exit as
+	 * soon as it is signalled, rather than draining in-flight requests and
+	 * closing its targets.
+	 */
+	d_getenv_bool("DAOS_SHUTDOWN_FLUSH", &flush);
+	if (!flush) {
+		D_INFO("Service is exiting without flush\n");
+		daos_debug_fini();
+		_exit(EXIT_SUCCESS);
+	}
+
 	/** shutdown */
 	server_fini(true);
 
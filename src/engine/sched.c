@@ -432,6 +432,7 @@ sched_info_init(struct dss_xstream *dx)
 	info->si_stats.ss_busy_ts = info->si_cur_ts;
 	info->si_stats.ss_print_ts = 0;
 	info->si_stats.ss_watchdog_ts = 0;
+	info->si_stats.ss_cycle_cnt = 0;
 	info->si_stats.ss_last_unit = NULL;
 	D_INIT_LIST_HEAD(&info->si_idle_list);
 	D_INIT_LIST_HEAD(&info->si_sleep_list);
@@ -1305,6 +1306,7 @@ sched_pop_net_poll(struct sched_data *data, ABT_pool pool)
 	if (cycle->sc_ults_tot == 0) {
 		D_ASSERT(!cycle->sc_cycle_started);
 		cycle->sc_new_cycle = 1;
+		dx->dx_sched_info.si_stats.ss_cycle_cnt++;
 	}
 
 	/*
@@ -801,6 +801,66 @@ dss_xstream_is_busy(void)
 	return cur_sec < (cntr->rc_active_time + 5);
 }
 
+/**
+ * Collect scheduling statistics of all xstreams, the returned array should
+ * be freed by the caller.
+ *
+ * \param[out] stats		Array of per-xstream statistics
+ * \param[out] stats_nr	Number of entries in \a stats
+ *
+ * \return			0 on success, negative value on error
+ */
+int
+dss_xstream_stats_get(struct dss_xstream_stats **stats, int *stats_nr)
+{
+	struct dss_xstream_stats	*xs_stats;
+	struct dss_xstream		*dx;
+	int				 xs_nr;
+	int				 i, j;
+
+	D_ASSERT(stats != NULL && stats_nr != NULL);
+
+	xs_nr = dss_xstream_cnt();
+	D_ALLOC_ARRAY(xs_stats, xs_nr);
+	if (xs_stats == NULL)
+		return -DER_NOMEM;
+
+	for (i = 0; i < xs_nr; i++) {
+		struct sched_stats	*ss;
+
+		dx = dss_get_xstream(i);
+		D_ASSERT(dx != NULL);
+		ss = &dx->dx_sched_info.si_stats;
+
+		strncpy(xs_stats[i].xs_name, dx->dx_name, DSS_XS_NAME_LEN - 1);
+		xs_stats[i].xs_id = dx->dx_xs_id;
+		xs_stats[i].xs_tgt_id = dx->dx_tgt_id;
+		xs_stats[i].xs_main = dx->dx_main_xs;
+		xs_stats[i].xs_tot_time = ss->ss_tot_time;
+		xs_stats[i].xs_relax_time = ss->ss_relax_time;
+		xs_stats[i].xs_cycles = ss->ss_cycle_cnt;
+
+		for (j = 0; j < DSS_POOL_CNT; j++) {
+			size_t	pool_size = 0;
+			int	rc;
+
+			rc = ABT_pool_get_total_size(dx->dx_pools[j],
+						     &pool_size);
+			if (rc != ABT_SUCCESS) {
+				D_ERROR("XS(%d) get ABT pool(%d) size error: "
+					"%d\n", dx->dx_xs_id, j, rc);
+				continue;
+			}
+			xs_stats[i].xs_ults_queued += pool_size;
+		}
+	}
+
+	*stats = xs_stats;
+	*stats_nr = xs_nr;
+
+	return 0;
+}
+
 static int
 dss_start_xs_id(int xs_id)
 {
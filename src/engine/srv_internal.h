@@ -30,6 +30,7 @@ struct sched_stats {
 	uint64_t	ss_busy_ts;	/* Last busy timestamp (ms) */
 	uint64_t	ss_print_ts;	/* Last stats print timestamp (ms) */
 	uint64_t	ss_watchdog_ts;	/* Last watchdog print ts (ms) */
+	uint64_t	ss_cycle_cnt;	/* Scheduling cycles count */
 	void		*ss_last_unit;	/* Last executed unit */
 };
 
@@ -86,6 +86,17 @@ struct nvme_stats {
 	bool		 dev_reliability_warn;
 	bool		 read_only_warn;
 	bool		 volatile_mem_warn; /*volatile memory backup*/
+	/* Blob I/O statistics, latencies in microseconds */
+	uint32_t	 io_inflight;
+	uint32_t	 io_queue_depth; /* peak inflight */
+	uint64_t	 read_ops;
+	uint64_t	 write_ops;
+	uint64_t	 read_bytes;
+	uint64_t	 write_bytes;
+	uint64_t	 read_lat_p50;
+	uint64_t	 read_lat_p99;
+	uint64_t	 write_lat_p50;
+	uint64_t	 write_lat_p99;
 };
 
 /**
@@ -176,6 +176,25 @@ struct dss_xstream;
 
 bool dss_xstream_exiting(struct dss_xstream *dxs);
 bool dss_xstream_is_busy(void);
+
+/** Per-xstream scheduling statistics */
+struct dss_xstream_stats {
+	char		xs_name[DSS_XS_NAME_LEN];
+	/* xstream id, [0, DSS_XS_NR_TOTAL - 1] */
+	int		xs_id;
+	/* VOS target id, -1 for system XS */
+	int		xs_tgt_id;
+	bool		xs_main;
+	/* ULTs currently queued in all ABT pools of the xstream */
+	uint64_t	xs_ults_queued;
+	/* Total and relaxed (idle) CPU time (ms) */
+	uint64_t	xs_tot_time;
+	uint64_t	xs_relax_time;
+	/* Number of scheduling cycles */
+	uint64_t	xs_cycles;
+};
+
+int dss_xstream_stats_get(struct dss_xstream_stats **stats, int *stats_nr);
 daos_epoch_t dss_get_start_epoch(void);
 void dss_set_start_epoch(void);
 
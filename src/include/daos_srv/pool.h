@@ -226,13 +226,16 @@ int ds_pool_get_ranks(const uuid_t pool_uuid, int status,
 
 int ds_pool_get_failed_tgt_idx(const uuid_t pool_uuid, int **failed_tgts,
 			       unsigned int *failed_tgts_cnt);
+int ds_pool_tgt_info_query(const uuid_t pool_uuid, daos_target_info_t **infos,
+			   int *infos_nr);
 int ds_pool_svc_list_cont(uuid_t uuid, d_rank_list_t *ranks,
 			  struct daos_pool_cont_info **containers,
 			  uint64_t *ncontainers);
 
 int ds_pool_svc_check_evict(uuid_t pool_uuid, d_rank_list_t *ranks,
 			    uuid_t *handles, size_t n_handles,
-			    uint32_t destroy, uint32_t force);
+			    uint32_t destroy, uint32_t force,
+			    const char *machine);
 
 void ds_pool_disable_exclude(void);
 void ds_pool_enable_exclude(void);
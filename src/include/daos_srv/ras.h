@@ -54,7 +54,14 @@
 	X(RAS_SWIM_RANK_ALIVE,		"swim_rank_alive")		\
 	X(RAS_SWIM_RANK_DEAD,		"swim_rank_dead")		\
 	X(RAS_SYSTEM_START_FAILED,	"system_start_failed")		\
-	X(RAS_SYSTEM_STOP_FAILED,	"system_stop_failed")
+	X(RAS_SYSTEM_STOP_FAILED,	"system_stop_failed")		\
+	X(RAS_DEVICE_IO_ERROR,		"device_io_error")		\
+	X(RAS_DEVICE_SET_FAULTY,	"device_set_faulty")		\
+	X(RAS_RANK_EXCLUDED,		"rank_excluded")		\
+	X(RAS_AUTO_ACTION_SUPPRESSED,	"auto_action_suppressed")	\
+	X(RAS_ENGINE_STARTED,		"engine_started")		\
+	X(RAS_AGENT_IFACE_DOWN,		"agent_interface_down")		\
+	X(RAS_AGENT_IFACE_UP,		"agent_interface_up")
 
 /** Define RAS event enum */
 typedef enum {
@@ -87,6 +87,24 @@ ds_sec_cont_get_capabilities(uint64_t flags, d_iov_t *cred,
 			     struct ownership *ownership,
 			     struct daos_acl *acl, uint64_t *capas);
 
+/**
+ * Get the name of the machine the given user credential originated from.
+ *
+ * This function assumes the credential was acquired internally and was
+ * previously validated with the control plane.
+ *
+ * \param[in]	cred		User's security credential
+ * \param[out]	machine		Name of the machine, to be freed by the
+ *				caller with D_FREE
+ *
+ * \return	0		Success
+ *		-DER_INVAL	Invalid input
+ *		-DER_NOMEM	Out of memory
+ *		-DER_PROTO	Unexpected or corrupt credential
+ */
+int
+ds_sec_cred_get_origin(d_iov_t *cred, char **machine);
+
 /**
  * Determine if the pool connection can be established based on the calculated
  * set of pool capabilities.
@@ -0,0 +1,1128 @@
+/* Generated by the protocol buffer compiler.  DO NOT EDIT! */
+/* Generated from: engine.proto */
+
+/* Do not generate deprecated warnings for self */
+#ifndef PROTOBUF_C__NO_DEPRECATED
+#define PROTOBUF_C__NO_DEPRECATED
+#endif
+
+#include "engine.pb-c.h"
+void   ctl__xstream_stats_req__init
+                     (Ctl__XstreamStatsReq         *message)
+{
+  static const Ctl__XstreamStatsReq init_value = CTL__XSTREAM_STATS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__xstream_stats_req__get_packed_size
+                     (const Ctl__XstreamStatsReq *message)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__xstream_stats_req__pack
+                     (const Ctl__XstreamStatsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__xstream_stats_req__pack_to_buffer
+                     (const Ctl__XstreamStatsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__XstreamStatsReq *
+       ctl__xstream_stats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__XstreamStatsReq *)
+     protobuf_c_message_unpack (&ctl__xstream_stats_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__xstream_stats_req__free_unpacked
+                     (Ctl__XstreamStatsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__xstream_stats_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__xstream_stats__init
+                     (Ctl__XstreamStats         *message)
+{
+  static const Ctl__XstreamStats init_value = CTL__XSTREAM_STATS__INIT;
+  *message = init_value;
+}
+size_t ctl__xstream_stats__get_packed_size
+                     (const Ctl__XstreamStats *message)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__xstream_stats__pack
+                     (const Ctl__XstreamStats *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__xstream_stats__pack_to_buffer
+                     (const Ctl__XstreamStats *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__XstreamStats *
+       ctl__xstream_stats__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__XstreamStats *)
+     protobuf_c_message_unpack (&ctl__xstream_stats__descriptor,
+                                allocator, len, data);
+}
+void   ctl__xstream_stats__free_unpacked
+                     (Ctl__XstreamStats *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__xstream_stats__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__xstream_stats_resp__init
+                     (Ctl__XstreamStatsResp         *message)
+{
+  static const Ctl__XstreamStatsResp init_value = CTL__XSTREAM_STATS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__xstream_stats_resp__get_packed_size
+                     (const Ctl__XstreamStatsResp *message)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__xstream_stats_resp__pack
+                     (const Ctl__XstreamStatsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__xstream_stats_resp__pack_to_buffer
+                     (const Ctl__XstreamStatsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__xstream_stats_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__XstreamStatsResp *
+       ctl__xstream_stats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__XstreamStatsResp *)
+     protobuf_c_message_unpack (&ctl__xstream_stats_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__xstream_stats_resp__free_unpacked
+                     (Ctl__XstreamStatsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__xstream_stats_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_stats_req__init
+                     (Ctl__EngineStatsReq         *message)
+{
+  static const Ctl__EngineStatsReq init_value = CTL__ENGINE_STATS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_stats_req__get_packed_size
+                     (const Ctl__EngineStatsReq *message)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_stats_req__pack
+                     (const Ctl__EngineStatsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_stats_req__pack_to_buffer
+                     (const Ctl__EngineStatsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineStatsReq *
+       ctl__engine_stats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineStatsReq *)
+     protobuf_c_message_unpack (&ctl__engine_stats_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_stats_req__free_unpacked
+                     (Ctl__EngineStatsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_stats_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__engine_stats_resp__rank_resp__init
+                     (Ctl__EngineStatsResp__RankResp         *message)
+{
+  static const Ctl__EngineStatsResp__RankResp init_value = CTL__ENGINE_STATS_RESP__RANK_RESP__INIT;
+  *message = init_value;
+}
+void   ctl__engine_stats_resp__init
+                     (Ctl__EngineStatsResp         *message)
+{
+  static const Ctl__EngineStatsResp init_value = CTL__ENGINE_STATS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__engine_stats_resp__get_packed_size
+                     (const Ctl__EngineStatsResp *message)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__engine_stats_resp__pack
+                     (const Ctl__EngineStatsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__engine_stats_resp__pack_to_buffer
+                     (const Ctl__EngineStatsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__engine_stats_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__EngineStatsResp *
+       ctl__engine_stats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__EngineStatsResp *)
+     protobuf_c_message_unpack (&ctl__engine_stats_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__engine_stats_resp__free_unpacked
+                     (Ctl__EngineStatsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__engine_stats_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__pool_targets_req__init
+                     (Ctl__PoolTargetsReq         *message)
+{
+  static const Ctl__PoolTargetsReq init_value = CTL__POOL_TARGETS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__pool_targets_req__get_packed_size
+                     (const Ctl__PoolTargetsReq *message)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__pool_targets_req__pack
+                     (const Ctl__PoolTargetsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__pool_targets_req__pack_to_buffer
+                     (const Ctl__PoolTargetsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__PoolTargetsReq *
+       ctl__pool_targets_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__PoolTargetsReq *)
+     protobuf_c_message_unpack (&ctl__pool_targets_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__pool_targets_req__free_unpacked
+                     (Ctl__PoolTargetsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__pool_targets_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__pool_target_info__init
+                     (Ctl__PoolTargetInfo         *message)
+{
+  static const Ctl__PoolTargetInfo init_value = CTL__POOL_TARGET_INFO__INIT;
+  *message = init_value;
+}
+size_t ctl__pool_target_info__get_packed_size
+                     (const Ctl__PoolTargetInfo *message)
+{
+  assert(message->base.descriptor == &ctl__pool_target_info__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__pool_target_info__pack
+                     (const Ctl__PoolTargetInfo *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__pool_target_info__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__pool_target_info__pack_to_buffer
+                     (const Ctl__PoolTargetInfo *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__pool_target_info__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__PoolTargetInfo *
+       ctl__pool_target_info__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__PoolTargetInfo *)
+     protobuf_c_message_unpack (&ctl__pool_target_info__descriptor,
+                                allocator, len, data);
+}
+void   ctl__pool_target_info__free_unpacked
+                     (Ctl__PoolTargetInfo *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__pool_target_info__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__pool_targets_resp__init
+                     (Ctl__PoolTargetsResp         *message)
+{
+  static const Ctl__PoolTargetsResp init_value = CTL__POOL_TARGETS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__pool_targets_resp__get_packed_size
+                     (const Ctl__PoolTargetsResp *message)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__pool_targets_resp__pack
+                     (const Ctl__PoolTargetsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__pool_targets_resp__pack_to_buffer
+                     (const Ctl__PoolTargetsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__pool_targets_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__PoolTargetsResp *
+       ctl__pool_targets_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__PoolTargetsResp *)
+     protobuf_c_message_unpack (&ctl__pool_targets_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__pool_targets_resp__free_unpacked
+                     (Ctl__PoolTargetsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__pool_targets_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__pool_query_targets_req__init
+                     (Ctl__PoolQueryTargetsReq         *message)
+{
+  static const Ctl__PoolQueryTargetsReq init_value = CTL__POOL_QUERY_TARGETS_REQ__INIT;
+  *message = init_value;
+}
+size_t ctl__pool_query_targets_req__get_packed_size
+                     (const Ctl__PoolQueryTargetsReq *message)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_req__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__pool_query_targets_req__pack
+                     (const Ctl__PoolQueryTargetsReq *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_req__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__pool_query_targets_req__pack_to_buffer
+                     (const Ctl__PoolQueryTargetsReq *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_req__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__PoolQueryTargetsReq *
+       ctl__pool_query_targets_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__PoolQueryTargetsReq *)
+     protobuf_c_message_unpack (&ctl__pool_query_targets_req__descriptor,
+                                allocator, len, data);
+}
+void   ctl__pool_query_targets_req__free_unpacked
+                     (Ctl__PoolQueryTargetsReq *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__pool_query_targets_req__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+void   ctl__pool_query_targets_resp__rank_resp__init
+                     (Ctl__PoolQueryTargetsResp__RankResp         *message)
+{
+  static const Ctl__PoolQueryTargetsResp__RankResp init_value = CTL__POOL_QUERY_TARGETS_RESP__RANK_RESP__INIT;
+  *message = init_value;
+}
+void   ctl__pool_query_targets_resp__init
+                     (Ctl__PoolQueryTargetsResp         *message)
+{
+  static const Ctl__PoolQueryTargetsResp init_value = CTL__POOL_QUERY_TARGETS_RESP__INIT;
+  *message = init_value;
+}
+size_t ctl__pool_query_targets_resp__get_packed_size
+                     (const Ctl__PoolQueryTargetsResp *message)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_resp__descriptor);
+  return protobuf_c_message_get_packed_size ((const ProtobufCMessage*)(message));
+}
+size_t ctl__pool_query_targets_resp__pack
+                     (const Ctl__PoolQueryTargetsResp *message,
+                      uint8_t       *out)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_resp__descriptor);
+  return protobuf_c_message_pack ((const ProtobufCMessage*)message, out);
+}
+size_t ctl__pool_query_targets_resp__pack_to_buffer
+                     (const Ctl__PoolQueryTargetsResp *message,
+                      ProtobufCBuffer *buffer)
+{
+  assert(message->base.descriptor == &ctl__pool_query_targets_resp__descriptor);
+  return protobuf_c_message_pack_to_buffer ((const ProtobufCMessage*)message, buffer);
+}
+Ctl__PoolQueryTargetsResp *
+       ctl__pool_query_targets_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data)
+{
+  return (Ctl__PoolQueryTargetsResp *)
+     protobuf_c_message_unpack (&ctl__pool_query_targets_resp__descriptor,
+                                allocator, len, data);
+}
+void   ctl__pool_query_targets_resp__free_unpacked
+                     (Ctl__PoolQueryTargetsResp *message,
+                      ProtobufCAllocator *allocator)
+{
+  if(!message)
+    return;
+  assert(message->base.descriptor == &ctl__pool_query_targets_resp__descriptor);
+  protobuf_c_message_free_unpacked ((ProtobufCMessage*)message, allocator);
+}
+#define ctl__xstream_stats_req__field_descriptors NULL
+#define ctl__xstream_stats_req__field_indices_by_name NULL
+#define ctl__xstream_stats_req__number_ranges NULL
+const ProtobufCMessageDescriptor ctl__xstream_stats_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.XstreamStatsReq",
+  "XstreamStatsReq",
+  "Ctl__XstreamStatsReq",
+  "ctl",
+  sizeof(Ctl__XstreamStatsReq),
+  0,
+  ctl__xstream_stats_req__field_descriptors,
+  ctl__xstream_stats_req__field_indices_by_name,
+  0,  ctl__xstream_stats_req__number_ranges,
+  (ProtobufCMessageInit) ctl__xstream_stats_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__xstream_stats__field_descriptors[8] =
+{
+  {
+    "xs_id",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, xs_id),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "tgt_id",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, tgt_id),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "name",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, name),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "main",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_BOOL,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, main),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "ults_queued",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, ults_queued),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "total_time",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, total_time),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "relax_time",
+    7,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, relax_time),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "cycles",
+    8,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStats, cycles),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__xstream_stats__field_indices_by_name[] = {
+  7,   /* field[7] = cycles */
+  3,   /* field[3] = main */
+  2,   /* field[2] = name */
+  6,   /* field[6] = relax_time */
+  1,   /* field[1] = tgt_id */
+  5,   /* field[5] = total_time */
+  4,   /* field[4] = ults_queued */
+  0,   /* field[0] = xs_id */
+};
+static const ProtobufCIntRange ctl__xstream_stats__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 8 }
+};
+const ProtobufCMessageDescriptor ctl__xstream_stats__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.XstreamStats",
+  "XstreamStats",
+  "Ctl__XstreamStats",
+  "ctl",
+  sizeof(Ctl__XstreamStats),
+  8,
+  ctl__xstream_stats__field_descriptors,
+  ctl__xstream_stats__field_indices_by_name,
+  1,  ctl__xstream_stats__number_ranges,
+  (ProtobufCMessageInit) ctl__xstream_stats__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__xstream_stats_resp__field_descriptors[2] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__XstreamStatsResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "xstreams",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__XstreamStatsResp, n_xstreams),
+    offsetof(Ctl__XstreamStatsResp, xstreams),
+    &ctl__xstream_stats__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__xstream_stats_resp__field_indices_by_name[] = {
+  0,   /* field[0] = status */
+  1,   /* field[1] = xstreams */
+};
+static const ProtobufCIntRange ctl__xstream_stats_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__xstream_stats_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.XstreamStatsResp",
+  "XstreamStatsResp",
+  "Ctl__XstreamStatsResp",
+  "ctl",
+  sizeof(Ctl__XstreamStatsResp),
+  2,
+  ctl__xstream_stats_resp__field_descriptors,
+  ctl__xstream_stats_resp__field_indices_by_name,
+  1,  ctl__xstream_stats_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__xstream_stats_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_stats_req__field_descriptors[1] =
+{
+  {
+    "rank",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineStatsReq, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_stats_req__field_indices_by_name[] = {
+  0,   /* field[0] = rank */
+};
+static const ProtobufCIntRange ctl__engine_stats_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__engine_stats_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineStatsReq",
+  "EngineStatsReq",
+  "Ctl__EngineStatsReq",
+  "ctl",
+  sizeof(Ctl__EngineStatsReq),
+  1,
+  ctl__engine_stats_req__field_descriptors,
+  ctl__engine_stats_req__field_indices_by_name,
+  1,  ctl__engine_stats_req__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_stats_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_stats_resp__rank_resp__field_descriptors[2] =
+{
+  {
+    "rank",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__EngineStatsResp__RankResp, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "xstreams",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineStatsResp__RankResp, n_xstreams),
+    offsetof(Ctl__EngineStatsResp__RankResp, xstreams),
+    &ctl__xstream_stats__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_stats_resp__rank_resp__field_indices_by_name[] = {
+  0,   /* field[0] = rank */
+  1,   /* field[1] = xstreams */
+};
+static const ProtobufCIntRange ctl__engine_stats_resp__rank_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__engine_stats_resp__rank_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineStatsResp.RankResp",
+  "RankResp",
+  "Ctl__EngineStatsResp__RankResp",
+  "ctl",
+  sizeof(Ctl__EngineStatsResp__RankResp),
+  2,
+  ctl__engine_stats_resp__rank_resp__field_descriptors,
+  ctl__engine_stats_resp__rank_resp__field_indices_by_name,
+  1,  ctl__engine_stats_resp__rank_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_stats_resp__rank_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__engine_stats_resp__field_descriptors[1] =
+{
+  {
+    "ranks",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__EngineStatsResp, n_ranks),
+    offsetof(Ctl__EngineStatsResp, ranks),
+    &ctl__engine_stats_resp__rank_resp__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__engine_stats_resp__field_indices_by_name[] = {
+  0,   /* field[0] = ranks */
+};
+static const ProtobufCIntRange ctl__engine_stats_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__engine_stats_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.EngineStatsResp",
+  "EngineStatsResp",
+  "Ctl__EngineStatsResp",
+  "ctl",
+  sizeof(Ctl__EngineStatsResp),
+  1,
+  ctl__engine_stats_resp__field_descriptors,
+  ctl__engine_stats_resp__field_indices_by_name,
+  1,  ctl__engine_stats_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__engine_stats_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_targets_req__field_descriptors[1] =
+{
+  {
+    "uuid",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetsReq, uuid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_targets_req__field_indices_by_name[] = {
+  0,   /* field[0] = uuid */
+};
+static const ProtobufCIntRange ctl__pool_targets_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__pool_targets_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolTargetsReq",
+  "PoolTargetsReq",
+  "Ctl__PoolTargetsReq",
+  "ctl",
+  sizeof(Ctl__PoolTargetsReq),
+  1,
+  ctl__pool_targets_req__field_descriptors,
+  ctl__pool_targets_req__field_indices_by_name,
+  1,  ctl__pool_targets_req__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_targets_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_target_info__field_descriptors[6] =
+{
+  {
+    "tgt_idx",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, tgt_idx),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "state",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, state),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "scm_total",
+    3,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, scm_total),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "scm_free",
+    4,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, scm_free),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "nvme_total",
+    5,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, nvme_total),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "nvme_free",
+    6,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT64,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetInfo, nvme_free),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_target_info__field_indices_by_name[] = {
+  5,   /* field[5] = nvme_free */
+  4,   /* field[4] = nvme_total */
+  3,   /* field[3] = scm_free */
+  2,   /* field[2] = scm_total */
+  1,   /* field[1] = state */
+  0,   /* field[0] = tgt_idx */
+};
+static const ProtobufCIntRange ctl__pool_target_info__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 6 }
+};
+const ProtobufCMessageDescriptor ctl__pool_target_info__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolTargetInfo",
+  "PoolTargetInfo",
+  "Ctl__PoolTargetInfo",
+  "ctl",
+  sizeof(Ctl__PoolTargetInfo),
+  6,
+  ctl__pool_target_info__field_descriptors,
+  ctl__pool_target_info__field_indices_by_name,
+  1,  ctl__pool_target_info__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_target_info__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_targets_resp__field_descriptors[2] =
+{
+  {
+    "status",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_INT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolTargetsResp, status),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "targets",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__PoolTargetsResp, n_targets),
+    offsetof(Ctl__PoolTargetsResp, targets),
+    &ctl__pool_target_info__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_targets_resp__field_indices_by_name[] = {
+  0,   /* field[0] = status */
+  1,   /* field[1] = targets */
+};
+static const ProtobufCIntRange ctl__pool_targets_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__pool_targets_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolTargetsResp",
+  "PoolTargetsResp",
+  "Ctl__PoolTargetsResp",
+  "ctl",
+  sizeof(Ctl__PoolTargetsResp),
+  2,
+  ctl__pool_targets_resp__field_descriptors,
+  ctl__pool_targets_resp__field_indices_by_name,
+  1,  ctl__pool_targets_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_targets_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_query_targets_req__field_descriptors[2] =
+{
+  {
+    "uuid",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_STRING,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolQueryTargetsReq, uuid),
+    NULL,
+    &protobuf_c_empty_string,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "rank",
+    2,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolQueryTargetsReq, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_query_targets_req__field_indices_by_name[] = {
+  1,   /* field[1] = rank */
+  0,   /* field[0] = uuid */
+};
+static const ProtobufCIntRange ctl__pool_query_targets_req__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__pool_query_targets_req__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolQueryTargetsReq",
+  "PoolQueryTargetsReq",
+  "Ctl__PoolQueryTargetsReq",
+  "ctl",
+  sizeof(Ctl__PoolQueryTargetsReq),
+  2,
+  ctl__pool_query_targets_req__field_descriptors,
+  ctl__pool_query_targets_req__field_indices_by_name,
+  1,  ctl__pool_query_targets_req__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_query_targets_req__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_query_targets_resp__rank_resp__field_descriptors[2] =
+{
+  {
+    "rank",
+    1,
+    PROTOBUF_C_LABEL_NONE,
+    PROTOBUF_C_TYPE_UINT32,
+    0,   /* quantifier_offset */
+    offsetof(Ctl__PoolQueryTargetsResp__RankResp, rank),
+    NULL,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+  {
+    "targets",
+    2,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__PoolQueryTargetsResp__RankResp, n_targets),
+    offsetof(Ctl__PoolQueryTargetsResp__RankResp, targets),
+    &ctl__pool_target_info__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_query_targets_resp__rank_resp__field_indices_by_name[] = {
+  0,   /* field[0] = rank */
+  1,   /* field[1] = targets */
+};
+static const ProtobufCIntRange ctl__pool_query_targets_resp__rank_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 2 }
+};
+const ProtobufCMessageDescriptor ctl__pool_query_targets_resp__rank_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolQueryTargetsResp.RankResp",
+  "RankResp",
+  "Ctl__PoolQueryTargetsResp__RankResp",
+  "ctl",
+  sizeof(Ctl__PoolQueryTargetsResp__RankResp),
+  2,
+  ctl__pool_query_targets_resp__rank_resp__field_descriptors,
+  ctl__pool_query_targets_resp__rank_resp__field_indices_by_name,
+  1,  ctl__pool_query_targets_resp__rank_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_query_targets_resp__rank_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
+static const ProtobufCFieldDescriptor ctl__pool_query_targets_resp__field_descriptors[1] =
+{
+  {
+    "ranks",
+    1,
+    PROTOBUF_C_LABEL_REPEATED,
+    PROTOBUF_C_TYPE_MESSAGE,
+    offsetof(Ctl__PoolQueryTargetsResp, n_ranks),
+    offsetof(Ctl__PoolQueryTargetsResp, ranks),
+    &ctl__pool_query_targets_resp__rank_resp__descriptor,
+    NULL,
+    0,             /* flags */
+    0,NULL,NULL    /* reserved1,reserved2, etc */
+  },
+};
+static const unsigned ctl__pool_query_targets_resp__field_indices_by_name[] = {
+  0,   /* field[0] = ranks */
+};
+static const ProtobufCIntRange ctl__pool_query_targets_resp__number_ranges[1 + 1] =
+{
+  { 1, 0 },
+  { 0, 1 }
+};
+const ProtobufCMessageDescriptor ctl__pool_query_targets_resp__descriptor =
+{
+  PROTOBUF_C__MESSAGE_DESCRIPTOR_MAGIC,
+  "ctl.PoolQueryTargetsResp",
+  "PoolQueryTargetsResp",
+  "Ctl__PoolQueryTargetsResp",
+  "ctl",
+  sizeof(Ctl__PoolQueryTargetsResp),
+  1,
+  ctl__pool_query_targets_resp__field_descriptors,
+  ctl__pool_query_targets_resp__field_indices_by_name,
+  1,  ctl__pool_query_targets_resp__number_ranges,
+  (ProtobufCMessageInit) ctl__pool_query_targets_resp__init,
+  NULL,NULL,NULL    /* reserved[123] */
+};
@@ -0,0 +1,529 @@
+/* Generated by the protocol buffer compiler.  DO NOT EDIT! */
+/* Generated from: engine.proto */
+
+#ifndef PROTOBUF_C_engine_2eproto__INCLUDED
+#define PROTOBUF_C_engine_2eproto__INCLUDED
+
+#include <protobuf-c/protobuf-c.h>
+
+PROTOBUF_C__BEGIN_DECLS
+
+#if PROTOBUF_C_VERSION_NUMBER < 1003000
+# error This file was generated by a newer version of protoc-c which is incompatible with your libprotobuf-c headers. Please update your headers.
+#elif 1003003 < PROTOBUF_C_MIN_COMPILER_VERSION
+# error This file was generated by an older version of protoc-c which is incompatible with your libprotobuf-c headers. Please regenerate this file with a newer version of protoc-c.
+#endif
+
+
+typedef struct _Ctl__XstreamStatsReq Ctl__XstreamStatsReq;
+typedef struct _Ctl__XstreamStats Ctl__XstreamStats;
+typedef struct _Ctl__XstreamStatsResp Ctl__XstreamStatsResp;
+typedef struct _Ctl__EngineStatsReq Ctl__EngineStatsReq;
+typedef struct _Ctl__EngineStatsResp Ctl__EngineStatsResp;
+typedef struct _Ctl__EngineStatsResp__RankResp Ctl__EngineStatsResp__RankResp;
+typedef struct _Ctl__PoolTargetsReq Ctl__PoolTargetsReq;
+typedef struct _Ctl__PoolTargetInfo Ctl__PoolTargetInfo;
+typedef struct _Ctl__PoolTargetsResp Ctl__PoolTargetsResp;
+typedef struct _Ctl__PoolQueryTargetsReq Ctl__PoolQueryTargetsReq;
+typedef struct _Ctl__PoolQueryTargetsResp Ctl__PoolQueryTargetsResp;
+typedef struct _Ctl__PoolQueryTargetsResp__RankResp Ctl__PoolQueryTargetsResp__RankResp;
+
+
+/* --- enums --- */
+
+
+/* --- messages --- */
+
+struct  _Ctl__XstreamStatsReq
+{
+  ProtobufCMessage base;
+};
+#define CTL__XSTREAM_STATS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__xstream_stats_req__descriptor) \
+     }
+
+
+/*
+ * XstreamStats mirrors dss_xstream_stats structure.
+ */
+struct  _Ctl__XstreamStats
+{
+  ProtobufCMessage base;
+  /*
+   * xstream ID
+   */
+  int32_t xs_id;
+  /*
+   * VOS target ID, -1 for system xstreams
+   */
+  int32_t tgt_id;
+  /*
+   * xstream name
+   */
+  char *name;
+  /*
+   * true for main (target) xstreams
+   */
+  protobuf_c_boolean main;
+  /*
+   * ULTs currently queued
+   */
+  uint64_t ults_queued;
+  /*
+   * total scheduler CPU time (ms)
+   */
+  uint64_t total_time;
+  /*
+   * idle scheduler CPU time (ms)
+   */
+  uint64_t relax_time;
+  /*
+   * number of scheduling cycles
+   */
+  uint64_t cycles;
+};
+#define CTL__XSTREAM_STATS__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__xstream_stats__descriptor) \
+    , 0, 0, (char *)protobuf_c_empty_string, 0, 0, 0, 0, 0 }
+
+
+struct  _Ctl__XstreamStatsResp
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS error code
+   */
+  int32_t status;
+  /*
+   * per-xstream statistics
+   */
+  size_t n_xstreams;
+  Ctl__XstreamStats **xstreams;
+};
+#define CTL__XSTREAM_STATS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__xstream_stats_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+struct  _Ctl__EngineStatsReq
+{
+  ProtobufCMessage base;
+  /*
+   * response should only include information about this rank
+   */
+  uint32_t rank;
+};
+#define CTL__ENGINE_STATS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_stats_req__descriptor) \
+    , 0 }
+
+
+struct  _Ctl__EngineStatsResp__RankResp
+{
+  ProtobufCMessage base;
+  /*
+   * rank to which this response corresponds
+   */
+  uint32_t rank;
+  /*
+   * per-xstream statistics
+   */
+  size_t n_xstreams;
+  Ctl__XstreamStats **xstreams;
+};
+#define CTL__ENGINE_STATS_RESP__RANK_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_stats_resp__rank_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+struct  _Ctl__EngineStatsResp
+{
+  ProtobufCMessage base;
+  /*
+   * List of per-rank responses
+   */
+  size_t n_ranks;
+  Ctl__EngineStatsResp__RankResp **ranks;
+};
+#define CTL__ENGINE_STATS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__engine_stats_resp__descriptor) \
+    , 0,NULL }
+
+
+/*
+ * PoolTargetsReq requests the state and space usage of the pool targets local
+ * to an I/O Engine.
+ */
+struct  _Ctl__PoolTargetsReq
+{
+  ProtobufCMessage base;
+  /*
+   * pool UUID
+   */
+  char *uuid;
+};
+#define CTL__POOL_TARGETS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_targets_req__descriptor) \
+    , (char *)protobuf_c_empty_string }
+
+
+/*
+ * PoolTargetInfo represents the state and space usage of a single pool target.
+ */
+struct  _Ctl__PoolTargetInfo
+{
+  ProtobufCMessage base;
+  /*
+   * target index on the engine
+   */
+  uint32_t tgt_idx;
+  /*
+   * pool map target state
+   */
+  char *state;
+  /*
+   * total SCM space in bytes
+   */
+  uint64_t scm_total;
+  /*
+   * free SCM space in bytes
+   */
+  uint64_t scm_free;
+  /*
+   * total NVMe space in bytes
+   */
+  uint64_t nvme_total;
+  /*
+   * free NVMe space in bytes
+   */
+  uint64_t nvme_free;
+};
+#define CTL__POOL_TARGET_INFO__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_target_info__descriptor) \
+    , 0, (char *)protobuf_c_empty_string, 0, 0, 0, 0 }
+
+
+struct  _Ctl__PoolTargetsResp
+{
+  ProtobufCMessage base;
+  /*
+   * DAOS error code
+   */
+  int32_t status;
+  /*
+   * per-target information
+   */
+  size_t n_targets;
+  Ctl__PoolTargetInfo **targets;
+};
+#define CTL__POOL_TARGETS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_targets_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+struct  _Ctl__PoolQueryTargetsReq
+{
+  ProtobufCMessage base;
+  /*
+   * pool UUID
+   */
+  char *uuid;
+  /*
+   * response should only include information about this rank
+   */
+  uint32_t rank;
+};
+#define CTL__POOL_QUERY_TARGETS_REQ__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_query_targets_req__descriptor) \
+    , (char *)protobuf_c_empty_string, 0 }
+
+
+struct  _Ctl__PoolQueryTargetsResp__RankResp
+{
+  ProtobufCMessage base;
+  /*
+   * rank to which this response corresponds
+   */
+  uint32_t rank;
+  /*
+   * per-target information
+   */
+  size_t n_targets;
+  Ctl__PoolTargetInfo **targets;
+};
+#define CTL__POOL_QUERY_TARGETS_RESP__RANK_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_query_targets_resp__rank_resp__descriptor) \
+    , 0, 0,NULL }
+
+
+struct  _Ctl__PoolQueryTargetsResp
+{
+  ProtobufCMessage base;
+  /*
+   * List of per-rank responses
+   */
+  size_t n_ranks;
+  Ctl__PoolQueryTargetsResp__RankResp **ranks;
+};
+#define CTL__POOL_QUERY_TARGETS_RESP__INIT \
+ { PROTOBUF_C_MESSAGE_INIT (&ctl__pool_query_targets_resp__descriptor) \
+    , 0,NULL }
+
+
+/* Ctl__XstreamStatsReq methods */
+void   ctl__xstream_stats_req__init
+                     (Ctl__XstreamStatsReq         *message);
+size_t ctl__xstream_stats_req__get_packed_size
+                     (const Ctl__XstreamStatsReq   *message);
+size_t ctl__xstream_stats_req__pack
+                     (const Ctl__XstreamStatsReq   *message,
+                      uint8_t             *out);
+size_t ctl__xstream_stats_req__pack_to_buffer
+                     (const Ctl__XstreamStatsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__XstreamStatsReq *
+       ctl__xstream_stats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__xstream_stats_req__free_unpacked
+                     (Ctl__XstreamStatsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__XstreamStats methods */
+void   ctl__xstream_stats__init
+                     (Ctl__XstreamStats         *message);
+size_t ctl__xstream_stats__get_packed_size
+                     (const Ctl__XstreamStats   *message);
+size_t ctl__xstream_stats__pack
+                     (const Ctl__XstreamStats   *message,
+                      uint8_t             *out);
+size_t ctl__xstream_stats__pack_to_buffer
+                     (const Ctl__XstreamStats   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__XstreamStats *
+       ctl__xstream_stats__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__xstream_stats__free_unpacked
+                     (Ctl__XstreamStats *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__XstreamStatsResp methods */
+void   ctl__xstream_stats_resp__init
+                     (Ctl__XstreamStatsResp         *message);
+size_t ctl__xstream_stats_resp__get_packed_size
+                     (const Ctl__XstreamStatsResp   *message);
+size_t ctl__xstream_stats_resp__pack
+                     (const Ctl__XstreamStatsResp   *message,
+                      uint8_t             *out);
+size_t ctl__xstream_stats_resp__pack_to_buffer
+                     (const Ctl__XstreamStatsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__XstreamStatsResp *
+       ctl__xstream_stats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__xstream_stats_resp__free_unpacked
+                     (Ctl__XstreamStatsResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineStatsReq methods */
+void   ctl__engine_stats_req__init
+                     (Ctl__EngineStatsReq         *message);
+size_t ctl__engine_stats_req__get_packed_size
+                     (const Ctl__EngineStatsReq   *message);
+size_t ctl__engine_stats_req__pack
+                     (const Ctl__EngineStatsReq   *message,
+                      uint8_t             *out);
+size_t ctl__engine_stats_req__pack_to_buffer
+                     (const Ctl__EngineStatsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineStatsReq *
+       ctl__engine_stats_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_stats_req__free_unpacked
+                     (Ctl__EngineStatsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__EngineStatsResp__RankResp methods */
+void   ctl__engine_stats_resp__rank_resp__init
+                     (Ctl__EngineStatsResp__RankResp         *message);
+/* Ctl__EngineStatsResp methods */
+void   ctl__engine_stats_resp__init
+                     (Ctl__EngineStatsResp         *message);
+size_t ctl__engine_stats_resp__get_packed_size
+                     (const Ctl__EngineStatsResp   *message);
+size_t ctl__engine_stats_resp__pack
+                     (const Ctl__EngineStatsResp   *message,
+                      uint8_t             *out);
+size_t ctl__engine_stats_resp__pack_to_buffer
+                     (const Ctl__EngineStatsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__EngineStatsResp *
+       ctl__engine_stats_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__engine_stats_resp__free_unpacked
+                     (Ctl__EngineStatsResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__PoolTargetsReq methods */
+void   ctl__pool_targets_req__init
+                     (Ctl__PoolTargetsReq         *message);
+size_t ctl__pool_targets_req__get_packed_size
+                     (const Ctl__PoolTargetsReq   *message);
+size_t ctl__pool_targets_req__pack
+                     (const Ctl__PoolTargetsReq   *message,
+                      uint8_t             *out);
+size_t ctl__pool_targets_req__pack_to_buffer
+                     (const Ctl__PoolTargetsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__PoolTargetsReq *
+       ctl__pool_targets_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__pool_targets_req__free_unpacked
+                     (Ctl__PoolTargetsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__PoolTargetInfo methods */
+void   ctl__pool_target_info__init
+                     (Ctl__PoolTargetInfo         *message);
+size_t ctl__pool_target_info__get_packed_size
+                     (const Ctl__PoolTargetInfo   *message);
+size_t ctl__pool_target_info__pack
+                     (const Ctl__PoolTargetInfo   *message,
+                      uint8_t             *out);
+size_t ctl__pool_target_info__pack_to_buffer
+                     (const Ctl__PoolTargetInfo   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__PoolTargetInfo *
+       ctl__pool_target_info__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__pool_target_info__free_unpacked
+                     (Ctl__PoolTargetInfo *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__PoolTargetsResp methods */
+void   ctl__pool_targets_resp__init
+                     (Ctl__PoolTargetsResp         *message);
+size_t ctl__pool_targets_resp__get_packed_size
+                     (const Ctl__PoolTargetsResp   *message);
+size_t ctl__pool_targets_resp__pack
+                     (const Ctl__PoolTargetsResp   *message,
+                      uint8_t             *out);
+size_t ctl__pool_targets_resp__pack_to_buffer
+                     (const Ctl__PoolTargetsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__PoolTargetsResp *
+       ctl__pool_targets_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__pool_targets_resp__free_unpacked
+                     (Ctl__PoolTargetsResp *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__PoolQueryTargetsReq methods */
+void   ctl__pool_query_targets_req__init
+                     (Ctl__PoolQueryTargetsReq         *message);
+size_t ctl__pool_query_targets_req__get_packed_size
+                     (const Ctl__PoolQueryTargetsReq   *message);
+size_t ctl__pool_query_targets_req__pack
+                     (const Ctl__PoolQueryTargetsReq   *message,
+                      uint8_t             *out);
+size_t ctl__pool_query_targets_req__pack_to_buffer
+                     (const Ctl__PoolQueryTargetsReq   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__PoolQueryTargetsReq *
+       ctl__pool_query_targets_req__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__pool_query_targets_req__free_unpacked
+                     (Ctl__PoolQueryTargetsReq *message,
+                      ProtobufCAllocator *allocator);
+/* Ctl__PoolQueryTargetsResp__RankResp methods */
+void   ctl__pool_query_targets_resp__rank_resp__init
+                     (Ctl__PoolQueryTargetsResp__RankResp         *message);
+/* Ctl__PoolQueryTargetsResp methods */
+void   ctl__pool_query_targets_resp__init
+                     (Ctl__PoolQueryTargetsResp         *message);
+size_t ctl__pool_query_targets_resp__get_packed_size
+                     (const Ctl__PoolQueryTargetsResp   *message);
+size_t ctl__pool_query_targets_resp__pack
+                     (const Ctl__PoolQueryTargetsResp   *message,
+                      uint8_t             *out);
+size_t ctl__pool_query_targets_resp__pack_to_buffer
+                     (const Ctl__PoolQueryTargetsResp   *message,
+                      ProtobufCBuffer     *buffer);
+Ctl__PoolQueryTargetsResp *
+       ctl__pool_query_targets_resp__unpack
+                     (ProtobufCAllocator  *allocator,
+                      size_t               len,
+                      const uint8_t       *data);
+void   ctl__pool_query_targets_resp__free_unpacked
+                     (Ctl__PoolQueryTargetsResp *message,
+                      ProtobufCAllocator *allocator);
+/* --- per-message closures --- */
+
+typedef void (*Ctl__XstreamStatsReq_Closure)
+                 (const Ctl__XstreamStatsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__XstreamStats_Closure)
+                 (const Ctl__XstreamStats *message,
+                  void *closure_data);
+typedef void (*Ctl__XstreamStatsResp_Closure)
+                 (const Ctl__XstreamStatsResp *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineStatsReq_Closure)
+                 (const Ctl__EngineStatsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineStatsResp__RankResp_Closure)
+                 (const Ctl__EngineStatsResp__RankResp *message,
+                  void *closure_data);
+typedef void (*Ctl__EngineStatsResp_Closure)
+                 (const Ctl__EngineStatsResp *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolTargetsReq_Closure)
+                 (const Ctl__PoolTargetsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolTargetInfo_Closure)
+                 (const Ctl__PoolTargetInfo *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolTargetsResp_Closure)
+                 (const Ctl__PoolTargetsResp *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolQueryTargetsReq_Closure)
+                 (const Ctl__PoolQueryTargetsReq *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolQueryTargetsResp__RankResp_Closure)
+                 (const Ctl__PoolQueryTargetsResp__RankResp *message,
+                  void *closure_data);
+typedef void (*Ctl__PoolQueryTargetsResp_Closure)
+                 (const Ctl__PoolQueryTargetsResp *message,
+                  void *closure_data);
+
+/* --- services --- */
+
+
+/* --- descriptors --- */
+
+extern const ProtobufCMessageDescriptor ctl__xstream_stats_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__xstream_stats__descriptor;
+extern const ProtobufCMessageDescriptor ctl__xstream_stats_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_stats_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_stats_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__engine_stats_resp__rank_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_targets_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_target_info__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_targets_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_query_targets_req__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_query_targets_resp__descriptor;
+extern const ProtobufCMessageDescriptor ctl__pool_query_targets_resp__rank_resp__descriptor;
+
+PROTOBUF_C__END_DECLS
+
+
+#endif  /* PROTOBUF_C_engine_2eproto__INCLUDED */
@@ -236,10 +236,14 @@ struct  _Mgmt__PoolEvictReq
    */
   size_t n_handles;
   char **handles;
+  /*
+   * Optional machine name, to only evict its handles
+   */
+  char *machine;
 };
 #define MGMT__POOL_EVICT_REQ__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__pool_evict_req__descriptor) \
-    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0,NULL, 0,NULL }
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0,NULL, 0,NULL, (char *)protobuf_c_empty_string }
 
 
 /*
@@ -111,10 +111,29 @@ struct  _Ctl__BioHealthResp
    * free space in blobstore
    */
   uint64_t avail_bytes;
+  /*
+   * I/O stats, latencies in microseconds
+   */
+  /*
+   * blob I/Os currently in flight
+   */
+  uint32_t io_inflight;
+  /*
+   * peak blob I/Os in flight
+   */
+  uint32_t io_queue_depth;
+  uint64_t read_ops;
+  uint64_t write_ops;
+  uint64_t read_bytes;
+  uint64_t write_bytes;
+  uint64_t read_lat_p50;
+  uint64_t read_lat_p99;
+  uint64_t write_lat_p50;
+  uint64_t write_lat_p99;
 };
 #define CTL__BIO_HEALTH_RESP__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&ctl__bio_health_resp__descriptor) \
-    , 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, (char *)protobuf_c_empty_string, 0, 0 }
+    , 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, (char *)protobuf_c_empty_string, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0 }
 
 
 struct  _Ctl__SmdDevReq
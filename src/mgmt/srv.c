@@ -105,6 +105,12 @@ process_drpc_request(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 	case DRPC_METHOD_MGMT_SMD_LIST_POOLS:
 		ds_mgmt_drpc_smd_list_pools(drpc_req, drpc_resp);
 		break;
+	case DRPC_METHOD_MGMT_XSTREAM_STATS:
+		ds_mgmt_drpc_xstream_stats(drpc_req, drpc_resp);
+		break;
+	case DRPC_METHOD_MGMT_POOL_TARGETS:
+		ds_mgmt_drpc_pool_targets(drpc_req, drpc_resp);
+		break;
 	case DRPC_METHOD_MGMT_DEV_STATE_QUERY:
 		ds_mgmt_drpc_dev_state_query(drpc_req, drpc_resp);
 		break;
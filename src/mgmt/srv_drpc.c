@@ -429,6 +429,7 @@ ds_mgmt_drpc_pool_evict(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 	uuid_t			 uuid;
 	uuid_t			*handles;
 	int			 n_handles;
+	char			*machine = NULL;
 	d_rank_list_t		*svc_ranks = NULL;
 	uint8_t			*body;
 	size_t			 len;
@@ -480,7 +481,11 @@ ds_mgmt_drpc_pool_evict(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 		n_handles = 0;
 	}
 
-	rc = ds_mgmt_evict_pool(uuid, svc_ranks, handles, n_handles, req->sys);
+	if (req->machine != NULL && req->machine[0] != '\0')
+		machine = req->machine;
+
+	rc = ds_mgmt_evict_pool(uuid, svc_ranks, handles, n_handles, machine,
+				req->sys);
 
 	if (rc != 0) {
 		D_ERROR("Failed to evict pool connections %s: "DF_RC"\n",
@@ -1577,6 +1582,112 @@ out:
 	D_FREE(resp);
 }
 
+void
+ds_mgmt_drpc_xstream_stats(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
+{
+	struct drpc_alloc	 alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Ctl__XstreamStatsReq	*req = NULL;
+	Ctl__XstreamStatsResp	 resp = CTL__XSTREAM_STATS_RESP__INIT;
+	uint8_t			*body;
+	size_t			 len;
+	int			 i;
+	int			 rc = 0;
+
+	/* Unpack the inner request from the drpc call body */
+	req = ctl__xstream_stats_req__unpack(&alloc.alloc,
+					     drpc_req->body.len,
+					     drpc_req->body.data);
+
+	if (alloc.oom || req == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		D_ERROR("Failed to unpack req (xstream stats)\n");
+		return;
+	}
+
+	D_INFO("Received request to query xstream stats\n");
+
+	rc = ds_mgmt_xstream_stats(&resp);
+	if (rc != 0)
+		D_ERROR("Failed to query xstream stats :"DF_RC"\n", DP_RC(rc));
+
+	resp.status = rc;
+	len = ctl__xstream_stats_resp__get_packed_size(&resp);
+	D_ALLOC(body, len);
+	if (body == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_MARSHAL;
+		D_ERROR("Failed to allocate drpc response body\n");
+	} else {
+		ctl__xstream_stats_resp__pack(&resp, body);
+		drpc_resp->body.len = len;
+		drpc_resp->body.data = body;
+	}
+
+	ctl__xstream_stats_req__free_unpacked(req, &alloc.alloc);
+
+	for (i = 0; i < resp.n_xstreams; i++) {
+		D_FREE(resp.xstreams[i]->name);
+		D_FREE(resp.xstreams[i]);
+	}
+	D_FREE(resp.xstreams);
+}
+
+void
+ds_mgmt_drpc_pool_targets(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
+{
+	struct drpc_alloc	 alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Ctl__PoolTargetsReq	*req = NULL;
+	Ctl__PoolTargetsResp	 resp = CTL__POOL_TARGETS_RESP__INIT;
+	uuid_t			 uuid;
+	uint8_t			*body;
+	size_t			 len;
+	int			 i;
+	int			 rc = 0;
+
+	/* Unpack the inner request from the drpc call body */
+	req = ctl__pool_targets_req__unpack(&alloc.alloc,
+					    drpc_req->body.len,
+					    drpc_req->body.data);
+
+	if (alloc.oom || req == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_UNMARSHAL_PAYLOAD;
+		D_ERROR("Failed to unpack req (pool targets)\n");
+		return;
+	}
+
+	D_INFO("Received request to query local targets of pool %s\n",
+	       req->uuid);
+
+	rc = uuid_parse(req->uuid, uuid);
+	if (rc != 0) {
+		D_ERROR("Unable to parse pool UUID %s: "DF_RC"\n", req->uuid,
+			DP_RC(rc));
+		D_GOTO(out, rc = -DER_INVAL);
+	}
+
+	rc = ds_mgmt_pool_targets(uuid, &resp);
+	if (rc != 0 && rc != -DER_NONEXIST)
+		D_ERROR("Failed to query pool targets :"DF_RC"\n", DP_RC(rc));
+
+out:
+	resp.status = rc;
+	len = ctl__pool_targets_resp__get_packed_size(&resp);
+	D_ALLOC(body, len);
+	if (body == NULL) {
+		drpc_resp->status = DRPC__STATUS__FAILED_MARSHAL;
+		D_ERROR("Failed to allocate drpc response body\n");
+	} else {
+		ctl__pool_targets_resp__pack(&resp, body);
+		drpc_resp->body.len = len;
+		drpc_resp->body.data = body;
+	}
+
+	ctl__pool_targets_req__free_unpacked(req, &alloc.alloc);
+
+	for (i = 0; i < resp.n_targets; i++)
+		D_FREE(resp.targets[i]);
+	D_FREE(resp.targets);
+}
+
 void
 ds_mgmt_drpc_bio_health_query(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 {
@@ -1666,6 +1777,16 @@ ds_mgmt_drpc_bio_health_query(Drpc__Call *drpc_req, Drpc__Response *drpc_resp)
 	resp->volatile_mem_warn = stats.volatile_mem_warn;
 	resp->total_bytes = stats.total_bytes;
 	resp->avail_bytes = stats.avail_bytes;
+	resp->io_inflight = stats.io_inflight;
+	resp->io_queue_depth = stats.io_queue_depth;
+	resp->read_ops = stats.read_ops;
+	resp->write_ops = stats.write_ops;
+	resp->read_bytes = stats.read_bytes;
+	resp->write_bytes = stats.write_bytes;
+	resp->read_lat_p50 = stats.read_lat_p50;
+	resp->read_lat_p99 = stats.read_lat_p99;
+	resp->write_lat_p50 = stats.write_lat_p50;
+	resp->write_lat_p99 = stats.write_lat_p99;
 out:
 	resp->status = rc;
 	len = ctl__bio_health_resp__get_packed_size(resp);
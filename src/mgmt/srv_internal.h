@@ -26,6 +26,7 @@
 
 #include "svc.pb-c.h"
 #include "smd.pb-c.h"
+#include "engine.pb-c.h"
 #include "rpc.h"
 #include "srv_layout.h"
 
@@ -71,7 +72,8 @@ int ds_mgmt_create_pool(uuid_t pool_uuid, const char *group, char *tgt_dev,
 int ds_mgmt_destroy_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
 			 const char *group, uint32_t force);
 int ds_mgmt_evict_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
-		       uuid_t *handles, size_t n_handles, const char *group);
+		       uuid_t *handles, size_t n_handles, const char *machine,
+		       const char *group);
 int ds_mgmt_pool_target_update_state(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
 				     uint32_t rank,
 				     struct pool_target_id_list *tgt_list,
@@ -121,6 +123,8 @@ void ds_mgmt_hdlr_get_bs_state(crt_rpc_t *rpc_req);
 int ds_mgmt_dev_replace(uuid_t old_uuid, uuid_t new_uuid,
 			Ctl__DevReplaceResp *resp);
 int ds_mgmt_dev_identify(uuid_t uuid, Ctl__DevIdentifyResp *resp);
+int ds_mgmt_xstream_stats(Ctl__XstreamStatsResp *resp);
+int ds_mgmt_pool_targets(uuid_t pool_uuid, Ctl__PoolTargetsResp *resp);
 
 /** srv_target.c */
 int ds_mgmt_tgt_setup(void);
@@ -300,7 +300,7 @@ ds_mgmt_destroy_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
 
 	/* Check active pool connections, evict only if force */
 	rc = ds_pool_svc_check_evict(pool_uuid, svc_ranks, NULL, 0, true,
-				     force);
+				     force, NULL);
 	if (rc != 0) {
 		D_ERROR("Failed to check/evict pool handles " DF_UUID ", "
 			DF_RC "\n",  DP_UUID(pool_uuid), DP_RC(rc));
@@ -391,7 +391,8 @@ out:
 
 int
 ds_mgmt_evict_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
-		   uuid_t *handles, size_t n_handles, const char *group)
+		   uuid_t *handles, size_t n_handles, const char *machine,
+		   const char *group)
 {
 	int		 rc;
 
@@ -399,7 +400,7 @@ ds_mgmt_evict_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
 
 	/* Evict active pool connections if they exist*/
 	rc = ds_pool_svc_check_evict(pool_uuid, svc_ranks, handles, n_handles,
-				     false, false);
+				     false, false, machine);
 	if (rc != 0) {
 		D_ERROR("Failed to evict pool handles"DF_UUID" rc: %d\n",
 			DP_UUID(pool_uuid), rc);
@@ -10,6 +10,7 @@
 
 #include <daos_srv/bio.h>
 #include <daos_srv/smd.h>
+#include <daos_srv/pool.h>
 
 #include "srv_internal.h"
 
@@ -837,3 +838,139 @@ out:
 
 	return rc;
 }
+
+int
+ds_mgmt_xstream_stats(Ctl__XstreamStatsResp *resp)
+{
+	struct dss_xstream_stats	*stats = NULL;
+	int				 stats_nr = 0;
+	int				 i;
+	int				 rc;
+
+	D_DEBUG(DB_MGMT, "Querying xstream scheduling stats\n");
+
+	rc = dss_xstream_stats_get(&stats, &stats_nr);
+	if (rc != 0) {
+		D_ERROR("Failed to get xstream stats: "DF_RC"\n", DP_RC(rc));
+		return rc;
+	}
+
+	D_ALLOC_ARRAY(resp->xstreams, stats_nr);
+	if (resp->xstreams == NULL) {
+		rc = -DER_NOMEM;
+		goto out;
+	}
+
+	for (i = 0; i < stats_nr; i++) {
+		Ctl__XstreamStats *xs;
+
+		D_ALLOC_PTR(xs);
+		if (xs == NULL) {
+			rc = -DER_NOMEM;
+			goto out;
+		}
+		ctl__xstream_stats__init(xs);
+		resp->xstreams[i] = xs;
+		resp->n_xstreams++;
+
+		D_STRNDUP(xs->name, stats[i].xs_name, DSS_XS_NAME_LEN);
+		if (xs->name == NULL) {
+			rc = -DER_NOMEM;
+			goto out;
+		}
+		xs->xs_id = stats[i].xs_id;
+		xs->tgt_id = stats[i].xs_tgt_id;
+		xs->main = stats[i].xs_main;
+		xs->ults_queued = stats[i].xs_ults_queued;
+		xs->total_time = stats[i].xs_tot_time;
+		xs->relax_time = stats[i].xs_relax_time;
+		xs->cycles = stats[i].xs_cycles;
+	}
+
+out:
+	if (rc != 0) {
+		for (i = 0; i < resp->n_xstreams; i++) {
+			D_FREE(resp->xstreams[i]->name);
+			D_FREE(resp->xstreams[i]);
+		}
+		D_FREE(resp->xstreams);
+		resp->n_xstreams = 0;
+	}
+	D_FREE(stats);
+
+	return rc;
+}
+
+static char *
+tgt_state_enum_to_str(daos_target_state_t state)
+{
+	switch (state) {
+	case DAOS_TS_UNKNOWN:	return "UNKNOWN";
+	case DAOS_TS_DOWN_OUT:	return "DOWNOUT";
+	case DAOS_TS_DOWN:	return "DOWN";
+	case DAOS_TS_UP:	return "UP";
+	case DAOS_TS_UP_IN:	return "UPIN";
+	case DAOS_TS_NEW:	return "NEW";
+	case DAOS_TS_DRAIN:	return "DRAIN";
+	}
+
+	return "UNKNOWN";
+}
+
+int
+ds_mgmt_pool_targets(uuid_t pool_uuid, Ctl__PoolTargetsResp *resp)
+{
+	daos_target_info_t	*infos = NULL;
+	int			 infos_nr = 0;
+	int			 i;
+	int			 rc;
+
+	D_DEBUG(DB_MGMT, "Querying local targets of pool "DF_UUID"\n",
+		DP_UUID(pool_uuid));
+
+	rc = ds_pool_tgt_info_query(pool_uuid, &infos, &infos_nr);
+	if (rc != 0) {
+		if (rc != -DER_NONEXIST)
+			D_ERROR("Failed to query targets of pool "DF_UUID": "
+				DF_RC"\n", DP_UUID(pool_uuid), DP_RC(rc));
+		return rc;
+	}
+
+	D_ALLOC_ARRAY(resp->targets, infos_nr);
+	if (resp->targets == NULL) {
+		rc = -DER_NOMEM;
+		goto out;
+	}
+
+	for (i = 0; i < infos_nr; i++) {
+		Ctl__PoolTargetInfo *tgt;
+
+		D_ALLOC_PTR(tgt);
+		if (tgt == NULL) {
+			rc = -DER_NOMEM;
+			goto out;
+		}
+		ctl__pool_target_info__init(tgt);
+		resp->targets[i] = tgt;
+		resp->n_targets++;
+
+		tgt->tgt_idx = i;
+		/* points to static string, not freed */
+		tgt->state = tgt_state_enum_to_str(infos[i].ta_state);
+		tgt->scm_total = infos[i].ta_space.s_total[DAOS_MEDIA_SCM];
+		tgt->scm_free = infos[i].ta_space.s_free[DAOS_MEDIA_SCM];
+		tgt->nvme_total = infos[i].ta_space.s_total[DAOS_MEDIA_NVME];
+		tgt->nvme_free = infos[i].ta_space.s_free[DAOS_MEDIA_NVME];
+	}
+
+out:
+	if (rc != 0) {
+		for (i = 0; i < resp->n_targets; i++)
+			D_FREE(resp->targets[i]);
+		D_FREE(resp->targets);
+		resp->n_targets = 0;
+	}
+	D_FREE(infos);
+
+	return rc;
+}
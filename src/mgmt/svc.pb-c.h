@@ -132,10 +132,14 @@ struct  _Mgmt__JoinReq
    * Instance index on server node.
    */
   uint32_t idx;
+  /*
+   * Server hostname.
+   */
+  char *hostname;
 };
 #define MGMT__JOIN_REQ__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__join_req__descriptor) \
-    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0 }
+    , (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, (char *)protobuf_c_empty_string }
 
 
 struct  _Mgmt__JoinResp
@@ -203,10 +207,18 @@ struct  _Mgmt__GetAttachInfoReq
    * Return Rank URIs for all ranks.
    */
   protobuf_c_boolean all_ranks;
+  /*
+   * Return recommended client bindings.
+   */
+  protobuf_c_boolean binding_hints;
+  /*
+   * Version of the requesting daos_agent.
+   */
+  char *agent_version;
 };
 #define MGMT__GET_ATTACH_INFO_REQ__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__get_attach_info_req__descriptor) \
-    , (char *)protobuf_c_empty_string, 0 }
+    , (char *)protobuf_c_empty_string, 0, 0, (char *)protobuf_c_empty_string }
 
 
 struct  _Mgmt__GetAttachInfoResp__RankUri
@@ -268,10 +280,24 @@ struct  _Mgmt__GetAttachInfoResp
    */
   size_t n_ms_ranks;
   uint32_t *ms_ranks;
+  /*
+   * Recommended client bindings, only set
+   */
+  /*
+   * if requested with binding_hints.
+   */
+  /*
+   * NUMA node local to OFI_INTERFACE
+   */
+  uint32_t numa_node;
+  /*
+   * CPUs local to numa_node, e.g. "0-17,36-53"
+   */
+  char *cpu_list;
 };
 #define MGMT__GET_ATTACH_INFO_RESP__INIT \
  { PROTOBUF_C_MESSAGE_INIT (&mgmt__get_attach_info_resp__descriptor) \
-    , 0, 0,NULL, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0, 0, 0,NULL }
+    , 0, 0,NULL, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, (char *)protobuf_c_empty_string, 0, 0, 0, 0,NULL, 0, (char *)protobuf_c_empty_string }
 
 
 struct  _Mgmt__PrepShutdownReq
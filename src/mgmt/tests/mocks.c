@@ -353,11 +353,16 @@ mock_ds_mgmt_pool_extend_setup(void)
 
 int     ds_mgmt_pool_evict_return;
 uuid_t  ds_mgmt_pool_evict_uuid;
+char    ds_mgmt_pool_evict_machine[MAXHOSTNAMELEN];
 int
 ds_mgmt_evict_pool(uuid_t pool_uuid, d_rank_list_t *svc_ranks,
-		   uuid_t *handles, size_t n_handles, const char *group)
+		   uuid_t *handles, size_t n_handles, const char *machine,
+		   const char *group)
 {
 	uuid_copy(ds_mgmt_pool_evict_uuid, pool_uuid);
+	if (machine != NULL)
+		strncpy(ds_mgmt_pool_evict_machine, machine,
+			sizeof(ds_mgmt_pool_evict_machine) - 1);
 	return ds_mgmt_pool_evict_return;
 }
 
@@ -366,6 +371,8 @@ mock_ds_mgmt_pool_evict_setup(void)
 {
 	ds_mgmt_pool_evict_return = 0;
 	uuid_clear(ds_mgmt_pool_evict_uuid);
+	memset(ds_mgmt_pool_evict_machine, 0,
+	       sizeof(ds_mgmt_pool_evict_machine));
 }
 
 /*
@@ -443,6 +450,18 @@ ds_mgmt_smd_list_pools(Ctl__SmdPoolResp *resp)
 	return 0;
 }
 
+int
+ds_mgmt_xstream_stats(Ctl__XstreamStatsResp *resp)
+{
+	return 0;
+}
+
+int
+ds_mgmt_pool_targets(uuid_t pool_uuid, Ctl__PoolTargetsResp *resp)
+{
+	return 0;
+}
+
 int
 ds_mgmt_dev_state_query(uuid_t uuid, Ctl__DevStateResp *resp)
 {
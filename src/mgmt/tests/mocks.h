@@ -11,6 +11,7 @@
 #ifndef __MGMT_TESTS_MOCKS_H__
 #define __MGMT_TESTS_MOCKS_H__
 
+#include <sys/param.h>
 #include <gurt/types.h>
 #include <daos_types.h>
 #include <daos_security.h>
@@ -112,6 +113,7 @@ void mock_ds_mgmt_tgt_update_setup(void);
  */
 extern int		ds_mgmt_pool_evict_return;
 extern uuid_t		ds_mgmt_pool_evict_uuid;
+extern char		ds_mgmt_pool_evict_machine[MAXHOSTNAMELEN];
 void mock_ds_mgmt_pool_evict_setup(void);
 
 /*
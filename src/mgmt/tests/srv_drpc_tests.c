@@ -105,6 +105,8 @@ test_mgmt_drpc_handlers_bad_call_payload(void **state)
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_pool_query);
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_smd_list_devs);
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_smd_list_pools);
+	expect_failure_for_bad_call_payload(ds_mgmt_drpc_xstream_stats);
+	expect_failure_for_bad_call_payload(ds_mgmt_drpc_pool_targets);
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_bio_health_query);
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_pool_list_cont);
 	expect_failure_for_bad_call_payload(ds_mgmt_drpc_pool_set_prop);
@@ -1796,6 +1798,27 @@ test_drpc_pool_evict_success(void **state)
 	D_FREE(resp.body.data);
 }
 
+static void
+test_drpc_pool_evict_machine_success(void **state)
+{
+	Drpc__Call		call = DRPC__CALL__INIT;
+	Drpc__Response		resp = DRPC__RESPONSE__INIT;
+	Mgmt__PoolEvictReq	req = MGMT__POOL_EVICT_REQ__INIT;
+
+	req.uuid = TEST_UUID;
+	req.sys = "DaosSys";
+	req.machine = "node1";
+	pack_pool_evict_req(&call, &req);
+
+	ds_mgmt_drpc_pool_evict(&call, &resp);
+
+	expect_drpc_evict_resp_with_status(&resp, 0);
+	assert_string_equal(ds_mgmt_pool_evict_machine, "node1");
+
+	D_FREE(call.body.data);
+	D_FREE(resp.body.data);
+}
+
 /*
  * dRPC Rank test utils
  */
@@ -2147,6 +2170,7 @@ main(void)
 		POOL_EVICT_TEST(test_drpc_pool_evict_bad_uuid),
 		POOL_EVICT_TEST(test_drpc_pool_evict_mgmt_svc_fails),
 		POOL_EVICT_TEST(test_drpc_pool_evict_success),
+		POOL_EVICT_TEST(test_drpc_pool_evict_machine_success),
 		PING_RANK_TEST(test_drpc_ping_rank_success),
 		PREP_SHUTDOWN_TEST(test_drpc_prep_shutdown_success),
 		CONT_SET_OWNER_TEST(test_drpc_cont_set_owner_bad_cont_uuid),
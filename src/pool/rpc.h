@@ -320,7 +320,8 @@ CRT_RPC_DECLARE(pool_exclude_out, DAOS_ISEQ_POOL_TGT_UPDATE,
 	((struct pool_op_in)	(pvi_op)			CRT_VAR) \
 	((uint32_t)		(pvi_pool_destroy)		CRT_VAR) \
 	((uint32_t)		(pvi_pool_destroy_force)	CRT_VAR) \
-	((uuid_t)		(pvi_hdls)			CRT_ARRAY)
+	((uuid_t)		(pvi_hdls)			CRT_ARRAY) \
+	((d_const_string_t)	(pvi_machine)			CRT_VAR)
 
 #define DAOS_OSEQ_POOL_EVICT	/* output fields */		 \
 	((struct pool_op_out)	(pvo_op)		CRT_VAR)
@@ -140,6 +140,7 @@ void ds_pool_attr_del_handler(crt_rpc_t *rpc);
 void ds_pool_list_cont_handler(crt_rpc_t *rpc);
 void ds_pool_query_info_handler(crt_rpc_t *rpc);
 void ds_pool_ranks_get_handler(crt_rpc_t *rpc);
+daos_target_state_t enum_pool_comp_state_to_tgt_state(int tgt_state);
 
 /*
  * srv_target.c
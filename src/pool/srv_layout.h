@@ -23,13 +23,17 @@
 #ifndef __POOL_SRV_LAYOUT_H__
 #define __POOL_SRV_LAYOUT_H__
 
+#include <sys/param.h>
 #include <daos_types.h>
 
 /* Default layout version */
-#define DS_POOL_MD_VERSION 1
+#define DS_POOL_MD_VERSION 2
 
-/* Lowest compatible layout version */
-#define DS_POOL_MD_VERSION_LOW 1
+/*
+ * Lowest compatible layout version. Version 2 added ph_machine to the pool
+ * handle values.
+ */
+#define DS_POOL_MD_VERSION_LOW 2
 
 /*
  * Root KVS (RDB_KVS_GENERIC): pool properties
@@ -66,6 +70,7 @@ extern d_iov_t ds_pool_attr_user;		/* pool user attributes KVS */
 struct pool_hdl {
 	uint64_t	ph_flags;
 	uint64_t	ph_sec_capas;
+	char		ph_machine[MAXHOSTNAMELEN];	/* client machine name */
 };
 
 /*
@@ -2021,6 +2021,7 @@ ds_pool_connect_handler(crt_rpc_t *rpc)
 	struct daos_prop_entry	       *owner_grp_entry;
 	uint64_t			sec_capas = 0;
 	struct pool_metrics	       *metrics;
+	char			       *machine = NULL;
 
 	metrics = &ds_pool_metrics;
 
@@ -2127,6 +2128,14 @@ ds_pool_connect_handler(crt_rpc_t *rpc)
 		D_GOTO(out_map_version, rc = -DER_NO_PERM);
 	}
 
+	/* Record the machine name so that its handles can be evicted. */
+	rc = ds_sec_cred_get_origin(&in->pci_cred, &machine);
+	if (rc != 0) {
+		D_ERROR(DF_UUID": unable to retrieve origin error: "DF_RC"\n",
+			DP_UUID(in->pci_op.pi_uuid), DP_RC(rc));
+		D_GOTO(out_map_version, rc);
+	}
+
 	d_tm_inc_gauge(metrics->open_hdl_gauge, 1);
 	/*
 	 * Transfer the pool map to the client before adding the pool handle,
@@ -2189,6 +2198,8 @@ ds_pool_connect_handler(crt_rpc_t *rpc)
 
 	hdl.ph_flags = in->pci_flags;
 	hdl.ph_sec_capas = sec_capas;
+	memset(hdl.ph_machine, 0, sizeof(hdl.ph_machine));
+	strncpy(hdl.ph_machine, machine, sizeof(hdl.ph_machine) - 1);
 	nhandles++;
 	d_iov_set(&key, in->pci_op.pi_hdl, sizeof(uuid_t));
 	d_iov_set(&value, &hdl, sizeof(hdl));
@@ -2212,6 +2223,7 @@ out_map_version:
 	out->pco_op.po_map_version = ds_pool_get_version(svc->ps_pool);
 	if (map_buf)
 		D_FREE(map_buf);
+	D_FREE(machine);
 out_lock:
 	ABT_rwlock_unlock(svc->ps_lock);
 	rdb_tx_end(&tx);
@@ -2880,7 +2892,7 @@ out:
 }
 
 /* Convert pool_comp_state_t to daos_target_state_t */
-static daos_target_state_t
+daos_target_state_t
 enum_pool_comp_state_to_tgt_state(int tgt_state)
 {
 
@@ -4685,15 +4697,17 @@ pool_svc_exclude_rank(struct pool_svc *svc, d_rank_t rank)
 }
 
 struct evict_iter_arg {
-	uuid_t *eia_hdl_uuids;
-	size_t	eia_hdl_uuids_size;
-	int	eia_n_hdl_uuids;
+	uuid_t		*eia_hdl_uuids;
+	size_t		 eia_hdl_uuids_size;
+	int		 eia_n_hdl_uuids;
+	const char	*eia_machine;
 };
 
 static int
 evict_iter_cb(daos_handle_t ih, d_iov_t *key, d_iov_t *val, void *varg)
 {
 	struct evict_iter_arg  *arg = varg;
+	struct pool_hdl	       *hdl = val->iov_buf;
 
 	D_ASSERT(arg->eia_hdl_uuids != NULL);
 	D_ASSERT(arg->eia_hdl_uuids_size > sizeof(uuid_t));
@@ -4705,6 +4719,12 @@ evict_iter_cb(daos_handle_t ih, d_iov_t *key, d_iov_t *val, void *varg)
 		return -DER_IO;
 	}
 
+	/* Only select the handles of the given machine, if any. */
+	if (arg->eia_machine != NULL &&
+	    strncmp(arg->eia_machine, hdl->ph_machine,
+		    sizeof(hdl->ph_machine)) != 0)
+		return 0;
+
 	/*
 	 * Make sure arg->eia_hdl_uuids[arg->eia_hdl_uuids_size] have enough
 	 * space for this handle.
@@ -4735,7 +4755,8 @@ evict_iter_cb(daos_handle_t ih, d_iov_t *key, d_iov_t *val, void *varg)
  */
 static int
 find_hdls_to_evict(struct rdb_tx *tx, struct pool_svc *svc, uuid_t **hdl_uuids,
-		   size_t *hdl_uuids_size, int *n_hdl_uuids)
+		   size_t *hdl_uuids_size, int *n_hdl_uuids,
+		   const char *machine)
 {
 	struct evict_iter_arg	arg;
 	int			rc;
@@ -4745,6 +4766,7 @@ find_hdls_to_evict(struct rdb_tx *tx, struct pool_svc *svc, uuid_t **hdl_uuids,
 	if (arg.eia_hdl_uuids == NULL)
 		return -DER_NOMEM;
 	arg.eia_n_hdl_uuids = 0;
+	arg.eia_machine = machine;
 
 	rc = rdb_tx_iterate(tx, &svc->ps_handles, false /* backward */,
 			    evict_iter_cb, &arg);
@@ -4838,7 +4860,8 @@ ds_pool_evict_handler(crt_rpc_t *rpc)
 
 	/*
 	 * If a subset of handles is specified use them instead of iterating
-	 * through all handles for the pool uuid
+	 * through all handles for the pool uuid. If a machine is specified,
+	 * only the handles opened from that machine are selected.
 	 */
 	if (in->pvi_hdls.ca_arrays) {
 		rc = validate_hdls_to_evict(&tx, svc, &hdl_uuids, &n_hdl_uuids,
@@ -4846,7 +4869,7 @@ ds_pool_evict_handler(crt_rpc_t *rpc)
 					    in->pvi_hdls.ca_count);
 	} else {
 		rc = find_hdls_to_evict(&tx, svc, &hdl_uuids, &hdl_uuids_size,
-					&n_hdl_uuids);
+					&n_hdl_uuids, in->pvi_machine);
 	}
 
 	if (rc != 0)
@@ -4914,6 +4937,8 @@ out:
  * \param[in]	destroy		If true the evict request is a destroy request
  * \param[in]	force		If true and destroy is true request all handles
  *				be forcibly evicted
+ * \param[in]	machine		If not NULL, only evict the handles opened
+ *				from this machine
  *
  * \return	0		Success
  *		-DER_BUSY	Open pool handles exist and no force requested
@@ -4922,7 +4947,8 @@ out:
 int
 ds_pool_svc_check_evict(uuid_t pool_uuid, d_rank_list_t *ranks,
 			uuid_t *handles, size_t n_handles,
-			uint32_t destroy, uint32_t force)
+			uint32_t destroy, uint32_t force,
+			const char *machine)
 {
 	int			 rc;
 	struct rsvc_client	 client;
@@ -4961,6 +4987,7 @@ rechoose:
 	uuid_clear(in->pvi_op.pi_hdl);
 	in->pvi_hdls.ca_arrays = handles;
 	in->pvi_hdls.ca_count = n_handles;
+	in->pvi_machine = machine;
 
 	/* Pool destroy (force=false): assert no open handles / do not evict.
 	 * Pool destroy (force=true): evict any/all open handles on the pool.
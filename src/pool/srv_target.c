@@ -897,6 +897,24 @@ pool_query_xs_arg_free(struct dss_stream_arg_type *xs)
 	D_FREE(xs->st_arg);
 }
 
+static void
+pool_space_from_vos(struct vos_pool_space *vps, struct daos_space *space)
+{
+	space->s_total[DAOS_MEDIA_SCM] = SCM_TOTAL(vps);
+	space->s_total[DAOS_MEDIA_NVME] = NVME_TOTAL(vps);
+
+	/* Exclude the sys reserved space before reporting to user */
+	if (SCM_FREE(vps) > SCM_SYS(vps))
+		space->s_free[DAOS_MEDIA_SCM] = SCM_FREE(vps) - SCM_SYS(vps);
+	else
+		space->s_free[DAOS_MEDIA_SCM] = 0;
+
+	if (NVME_FREE(vps) > NVME_SYS(vps))
+		space->s_free[DAOS_MEDIA_NVME] = NVME_FREE(vps) - NVME_SYS(vps);
+	else
+		space->s_free[DAOS_MEDIA_NVME] = 0;
+}
+
 static int
 pool_query_one(void *vin)
 {
@@ -925,21 +943,7 @@ pool_query_one(void *vin)
 	}
 
 	x_ps->ps_ntargets = 1;
-	x_ps->ps_space.s_total[DAOS_MEDIA_SCM] = SCM_TOTAL(vps);
-	x_ps->ps_space.s_total[DAOS_MEDIA_NVME] = NVME_TOTAL(vps);
-
-	/* Exclude the sys reserved space before reporting to user */
-	if (SCM_FREE(vps) > SCM_SYS(vps))
-		x_ps->ps_space.s_free[DAOS_MEDIA_SCM] =
-				SCM_FREE(vps) - SCM_SYS(vps);
-	else
-		x_ps->ps_space.s_free[DAOS_MEDIA_SCM] = 0;
-
-	if (NVME_FREE(vps) > NVME_SYS(vps))
-		x_ps->ps_space.s_free[DAOS_MEDIA_NVME] =
-				NVME_FREE(vps) - NVME_SYS(vps);
-	else
-		x_ps->ps_space.s_free[DAOS_MEDIA_NVME] = 0;
+	pool_space_from_vos(vps, &x_ps->ps_space);
 
 	for (i = DAOS_MEDIA_SCM; i < DAOS_MEDIA_MAX; i++) {
 		x_ps->ps_free_max[i] = x_ps->ps_space.s_free[i];
@@ -995,6 +999,107 @@ pool_tgt_query(struct ds_pool *pool, struct daos_pool_space *ps)
 	return rc;
 }
 
+struct pool_tgt_info_arg {
+	struct ds_pool		*tia_pool;
+	daos_target_info_t	*tia_infos;
+};
+
+static int
+pool_tgt_info_one(void *varg)
+{
+	struct pool_tgt_info_arg	*arg = varg;
+	struct dss_module_info		*info = dss_get_module_info();
+	int				 tid = info->dmi_tgt_id;
+	struct ds_pool_child		*pool_child;
+	vos_pool_info_t			 vos_pool_info = { 0 };
+	int				 rc;
+
+	/* Excluded targets have no pool child, report them without space */
+	pool_child = ds_pool_child_lookup(arg->tia_pool->sp_uuid);
+	if (pool_child == NULL)
+		return 0;
+
+	rc = vos_pool_query(pool_child->spc_hdl, &vos_pool_info);
+	if (rc != 0) {
+		D_ERROR("Failed to query pool "DF_UUID", tgt_id: %d, "
+			"rc: "DF_RC"\n", DP_UUID(arg->tia_pool->sp_uuid), tid,
+			DP_RC(rc));
+		goto out;
+	}
+
+	pool_space_from_vos(&vos_pool_info.pif_space,
+			    &arg->tia_infos[tid].ta_space);
+out:
+	ds_pool_child_put(pool_child);
+	return rc;
+}
+
+/**
+ * Query the state and space usage of each local target of a pool.
+ *
+ * \param[in]	pool_uuid	Pool UUID
+ * \param[out]	infos		Per-target info, indexed by target index;
+ *				caller is responsible for freeing
+ * \param[out]	infos_nr	Number of entries in \a infos
+ *
+ * \return			0 on success, -DER_NONEXIST if the pool is not
+ *				present on this engine, other negative
+ *				value on error
+ */
+int
+ds_pool_tgt_info_query(const uuid_t pool_uuid, daos_target_info_t **infos,
+		       int *infos_nr)
+{
+	struct pool_tgt_info_arg	 arg = { 0 };
+	struct ds_pool			*pool;
+	struct pool_target		*target;
+	d_rank_t			 rank = dss_self_rank();
+	int				 i;
+	int				 rc;
+
+	D_ASSERT(infos != NULL && infos_nr != NULL);
+
+	pool = ds_pool_lookup(pool_uuid);
+	if (pool == NULL)
+		return -DER_NONEXIST;
+
+	D_ALLOC_ARRAY(arg.tia_infos, dss_tgt_nr);
+	if (arg.tia_infos == NULL)
+		D_GOTO(out, rc = -DER_NOMEM);
+	arg.tia_pool = pool;
+
+	ABT_rwlock_rdlock(pool->sp_lock);
+	for (i = 0; i < dss_tgt_nr; i++) {
+		arg.tia_infos[i].ta_type = DAOS_TP_UNKNOWN;
+		arg.tia_infos[i].ta_state = DAOS_TS_UNKNOWN;
+		if (pool->sp_map == NULL)
+			continue;
+
+		target = NULL;
+		rc = pool_map_find_target_by_rank_idx(pool->sp_map, rank, i,
+						      &target);
+		if (rc == 1 && target != NULL)
+			arg.tia_infos[i].ta_state =
+				enum_pool_comp_state_to_tgt_state(
+					target->ta_comp.co_status);
+	}
+	ABT_rwlock_unlock(pool->sp_lock);
+
+	rc = dss_thread_collective(pool_tgt_info_one, &arg, 0);
+	if (rc != 0) {
+		D_ERROR(DF_UUID": failed to query local targets: "DF_RC"\n",
+			DP_UUID(pool_uuid), DP_RC(rc));
+		D_FREE(arg.tia_infos);
+		goto out;
+	}
+
+	*infos = arg.tia_infos;
+	*infos_nr = dss_tgt_nr;
+out:
+	ds_pool_put(pool);
+	return rc;
+}
+
 int
 ds_pool_tgt_connect(struct ds_pool *pool, struct pool_iv_conn *pic)
 {
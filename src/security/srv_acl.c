@@ -5,6 +5,7 @@
  */
 
 #include <unistd.h>
+#include <sys/param.h>
 #include <string.h>
 #include <daos_errno.h>
 #include <daos/drpc.h>
@@ -681,6 +682,48 @@ ds_sec_cont_get_capabilities(uint64_t flags, d_iov_t *cred,
 	return rc;
 }
 
+int
+ds_sec_cred_get_origin(d_iov_t *cred, char **machine)
+{
+	struct drpc_alloc	alloc = PROTO_ALLOCATOR_INIT(alloc);
+	Auth__Token	*token;
+	Auth__Sys	*authsys;
+	int		rc;
+
+	if (cred == NULL || machine == NULL) {
+		D_ERROR("NULL input\n");
+		return -DER_INVAL;
+	}
+
+	if (cred->iov_buf == NULL) {
+		D_ERROR("Credential data is NULL\n");
+		return -DER_INVAL;
+	}
+
+	token = unpack_token_from_cred(cred);
+	if (token == NULL)
+		return -DER_INVAL;
+
+	rc = get_auth_sys_payload(token, &authsys);
+	if (rc != 0)
+		goto out_token;
+
+	if (authsys->machinename == NULL) {
+		D_ERROR("Credential has no machine name\n");
+		D_GOTO(out_authsys, rc = -DER_PROTO);
+	}
+
+	D_STRNDUP(*machine, authsys->machinename, MAXHOSTNAMELEN);
+	if (*machine == NULL)
+		rc = -DER_NOMEM;
+
+out_authsys:
+	auth__sys__free_unpacked(authsys, &alloc.alloc);
+out_token:
+	auth__token__free_unpacked(token, &alloc.alloc);
+	return rc;
+}
+
 bool
 ds_sec_pool_can_connect(uint64_t pool_capas)
 {
@@ -10,6 +10,7 @@
 
 #include <stdarg.h>
 #include <stdlib.h>
+#include <sys/param.h>
 #include <setjmp.h>
 #include <cmocka.h>
 
@@ -31,6 +32,7 @@ char *ds_sec_server_socket_path = "/fake/socket/path";
  */
 #define TEST_USER	"myuser@"
 #define TEST_GROUP	"mygroup@"
+#define TEST_MACHINE	"mymachine"
 
 /*
  * Test helper functions
@@ -50,6 +52,7 @@ create_valid_auth_token(const char *user, const char *grp,
 	auth__sys__init(authsys);
 	D_STRNDUP(authsys->user, user, DAOS_ACL_MAX_PRINCIPAL_LEN);
 	D_STRNDUP(authsys->group, grp, DAOS_ACL_MAX_PRINCIPAL_LEN);
+	D_STRNDUP(authsys->machinename, TEST_MACHINE, MAXHOSTNAMELEN);
 
 	if (num_grps > 0) {
 		size_t i;
@@ -1847,6 +1850,76 @@ test_cont_get_capas_owner_implicit_acl_access(void **state)
 /*
  * Pool access tests
  */
+static void
+test_cred_get_origin_null_input(void **state)
+{
+	d_iov_t	cred;
+	char	*machine = NULL;
+
+	init_default_cred(&cred);
+
+	assert_rc_equal(ds_sec_cred_get_origin(NULL, &machine), -DER_INVAL);
+	assert_rc_equal(ds_sec_cred_get_origin(&cred, NULL), -DER_INVAL);
+	assert_null(machine);
+
+	daos_iov_free(&cred);
+}
+
+static void
+test_cred_get_origin_bad_cred(void **state)
+{
+	d_iov_t			bad_cred;
+	char			*machine = NULL;
+	uint8_t			bad_buf[32];
+	size_t			i;
+	Auth__Credential	cred = AUTH__CREDENTIAL__INIT;
+	Auth__Token		token = AUTH__TOKEN__INIT;
+	uint8_t			*buf;
+	size_t			bufsize;
+
+	/* some random bytes that won't translate to an auth credential */
+	for (i = 0; i < sizeof(bad_buf); i++)
+		bad_buf[i] = (uint8_t)i;
+	d_iov_set(&bad_cred, bad_buf, sizeof(bad_buf));
+	assert_rc_equal(ds_sec_cred_get_origin(&bad_cred, &machine),
+			-DER_INVAL);
+
+	/* null data */
+	d_iov_set(&bad_cred, NULL, 0);
+	assert_rc_equal(ds_sec_cred_get_origin(&bad_cred, &machine),
+			-DER_INVAL);
+
+	/* Junk in token data */
+	token.flavor = AUTH__FLAVOR__AUTH_SYS;
+	token.data.data = bad_buf;
+	token.data.len = sizeof(bad_buf);
+	cred.token = &token;
+	bufsize = auth__credential__get_packed_size(&cred);
+	D_ALLOC(buf, bufsize);
+	auth__credential__pack(&cred, buf);
+	d_iov_set(&bad_cred, buf, bufsize);
+	assert_rc_equal(ds_sec_cred_get_origin(&bad_cred, &machine),
+			-DER_PROTO);
+	assert_null(machine);
+	D_FREE(buf);
+}
+
+static void
+test_cred_get_origin_success(void **state)
+{
+	d_iov_t	cred;
+	char	*machine = NULL;
+
+	init_default_cred(&cred);
+
+	assert_rc_equal(ds_sec_cred_get_origin(&cred, &machine), 0);
+	assert_non_null(machine);
+	assert_string_equal(machine, TEST_MACHINE);
+
+	D_FREE(machine);
+	daos_iov_free(&cred);
+}
+
 static void
 test_pool_can_connect(void **state)
 {
@@ -2104,6 +2177,9 @@ main(void)
 		cmocka_unit_test(test_cont_get_capas_success),
 		cmocka_unit_test(test_cont_get_capas_denied),
 		cmocka_unit_test(test_cont_get_capas_owner_implicit_acl_access),
+		cmocka_unit_test(test_cred_get_origin_null_input),
+		cmocka_unit_test(test_cred_get_origin_bad_cred),
+		cmocka_unit_test(test_cred_get_origin_success),
 		cmocka_unit_test(test_pool_can_connect),
 		cmocka_unit_test(test_pool_can_create_cont),
 		cmocka_unit_test(test_pool_can_delete_cont),
@@ -389,6 +389,7 @@ getent passwd daos_agent >/dev/null || useradd -s /sbin/nologin -r -g daos_agent
 %{_bindir}/cart_ctl
 %{_bindir}/self_test
 %{_bindir}/dmg
+%{_bindir}/daos_gateway
 %{_bindir}/daos_agent
 %{_bindir}/dfuse
 %{_bindir}/daos